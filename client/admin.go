@@ -4,13 +4,16 @@
 	"context"
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/persistorai/persistor/internal/models"
 )
 
-// AdminService handles administrative operations.
+// AdminService handles administrative operations. Tenants manages other
+// tenants and needs an operator tenant's key.
 type AdminService struct {
-	c *Client
+	c       *Client
+	Tenants *TenantService
 }
 
 // BackfillEmbeddings queues embedding generation for nodes without embeddings.
@@ -25,6 +28,65 @@ func (s *AdminService) BackfillEmbeddings(ctx context.Context) (int, error) {
 	return resp.Queued, nil
 }
 
+// StartBackfill queues embedding generation for nodes without embeddings and
+// returns the backfill job, whose ID BackfillStatus and CancelBackfill take.
+func (s *AdminService) StartBackfill(ctx context.Context) (*models.BackfillJob, error) {
+	var resp models.BackfillJob
+	if err := s.c.post(ctx, "/api/v1/admin/backfill-embeddings", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListBackfills returns the tenant's recent embedding backfills, newest first.
+func (s *AdminService) ListBackfills(ctx context.Context) ([]models.BackfillJob, error) {
+	var resp struct {
+		Backfills []models.BackfillJob `json:"backfills"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/backfill-embeddings", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Backfills, nil
+}
+
+// BackfillStatus returns the progress of an embedding backfill.
+func (s *AdminService) BackfillStatus(ctx context.Context, id string) (*models.BackfillJob, error) {
+	var resp models.BackfillJob
+	if err := s.c.get(ctx, "/api/v1/admin/backfill-embeddings/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelBackfill cancels an embedding backfill; jobs it still has queued are skipped.
+func (s *AdminService) CancelBackfill(ctx context.Context, id string) (*models.BackfillJob, error) {
+	var resp models.BackfillJob
+	if err := s.c.post(ctx, "/api/v1/admin/backfill-embeddings/"+url.PathEscape(id)+"/cancel", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StartReembed starts regenerating every embedding not produced by the
+// server's configured embedding model. Semantic search is refused until the
+// run completes.
+func (s *AdminService) StartReembed(ctx context.Context) (*models.ReembedStatus, error) {
+	var resp models.ReembedStatus
+	if err := s.c.post(ctx, "/api/v1/admin/reembed", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReembedStatus returns the progress of the tenant's latest re-embed.
+func (s *AdminService) ReembedStatus(ctx context.Context) (*models.ReembedStatus, error) {
+	var resp models.ReembedStatus
+	if err := s.c.get(ctx, "/api/v1/admin/reembed/status", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // ReprocessNodes rewrites search text and/or queues embeddings for existing nodes.
 func (s *AdminService) ReprocessNodes(ctx context.Context, req models.ReprocessNodesRequest) (*models.ReprocessNodesResult, error) {
 	var resp models.ReprocessNodesResult
@@ -64,9 +126,258 @@ func (s *AdminService) ListMergeSuggestions(ctx context.Context, opts models.Mer
 	return resp.Suggestions, nil
 }
 
-// HistoryService handles property history operations.
-// Note: History is accessed via NodeService.History() for convenience,
-// but this service exists for direct access if needed.
+// ListDuplicates returns probable duplicate node pairs. Merge each pair with
+// Nodes.MergeInto(ctx, pair.Duplicate.ID, pair.Canonical.ID, ...).
+func (s *AdminService) ListDuplicates(ctx context.Context, opts models.DuplicateListOpts) ([]models.DuplicatePair, error) {
+	query := make(url.Values)
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.MinScore > 0 {
+		query.Set("min_score", strconv.FormatFloat(opts.MinScore, 'f', -1, 64))
+	}
+	var resp struct {
+		Duplicates []models.DuplicatePair `json:"duplicates"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/duplicates", query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Duplicates, nil
+}
+
+// Broadcast sends an operator message to connected WebSocket clients of one
+// tenant, or of every tenant when req.TenantID is empty. Requires an
+// operator tenant's key.
+func (s *AdminService) Broadcast(ctx context.Context, req models.BroadcastRequest) (*models.BroadcastResult, error) {
+	var resp models.BroadcastResult
+	if err := s.c.post(ctx, "/api/v1/admin/broadcast", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SecurityBlocks lists API keys of every tenant currently locked out after
+// repeated authentication failures. Requires an operator tenant's key.
+func (s *AdminService) SecurityBlocks(ctx context.Context) ([]models.SecurityBlock, error) {
+	var resp struct {
+		Blocks []models.SecurityBlock `json:"blocks"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/security/blocks", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Blocks, nil
+}
+
+// Partitions reports the size of each tenant partition of the graph tables;
+// it is empty when the server does not partition them. Requires an operator
+// tenant's key.
+func (s *AdminService) Partitions(ctx context.Context) ([]models.PartitionSize, error) {
+	var resp struct {
+		Partitions []models.PartitionSize `json:"partitions"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/partitions", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Partitions, nil
+}
+
+// VectorIndex reports the node embedding index shared by every tenant, the
+// parameters a rebuild would use by default, and warnings when semantic
+// search is likely scanning nodes sequentially. Requires an operator
+// tenant's key.
+func (s *AdminService) VectorIndex(ctx context.Context) (*models.VectorIndexStatus, error) {
+	var resp models.VectorIndexStatus
+	if err := s.c.get(ctx, "/api/v1/admin/vector-index", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RebuildVectorIndex starts building a new node embedding index in the
+// background and returns the parameters it uses; omitted ones are sized
+// from the number of embedded nodes. Poll VectorIndex for completion.
+// Requires an operator tenant's key.
+func (s *AdminService) RebuildVectorIndex(ctx context.Context, req models.VectorIndexRequest) (*models.VectorIndexRequest, error) {
+	var resp models.VectorIndexRequest
+	if err := s.c.post(ctx, "/api/v1/admin/vector-index", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// IndexAdvice analyzes pg_stat_statements and reports the node filter
+// combinations queries use, the index covering each, and indexes to add
+// for frequent, slow ones. Zero thresholds in req use the server defaults.
+// Requires an operator tenant's key.
+func (s *AdminService) IndexAdvice(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	query := make(url.Values)
+	if req.MinCalls > 0 {
+		query.Set("min_calls", strconv.FormatInt(req.MinCalls, 10))
+	}
+	if req.MinMeanMs > 0 {
+		query.Set("min_mean_ms", strconv.FormatFloat(req.MinMeanMs, 'f', -1, 64))
+	}
+	var resp models.IndexAdvisorReport
+	if err := s.c.get(ctx, "/api/v1/admin/index-advisor", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateAdvisedIndexes starts building, in the background, the recommended
+// indexes whose extensions are installed and returns the report they came
+// from. Poll IndexAdvice for completion. Requires an operator tenant's key.
+func (s *AdminService) CreateAdvisedIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	var resp models.IndexAdvisorReport
+	if err := s.c.post(ctx, "/api/v1/admin/index-advisor", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DiffTenants reports what the right tenant's graph adds, removes, and
+// changes relative to the left one. Requires an operator tenant's key.
+func (s *AdminService) DiffTenants(ctx context.Context, leftTenantID, rightTenantID string) (*models.GraphDiff, error) {
+	params := url.Values{}
+	params.Set("left", leftTenantID)
+	params.Set("right", rightTenantID)
+	var resp models.GraphDiff
+	if err := s.c.get(ctx, "/api/v1/admin/diff", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// HistoryRetention returns the tenant's property history retention policy.
+func (s *AdminService) HistoryRetention(ctx context.Context) (*models.HistoryRetention, error) {
+	var resp models.HistoryRetention
+	if err := s.c.get(ctx, "/api/v1/admin/history/retention", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetHistoryRetention replaces the tenant's property history retention
+// policy. Nil periods disable the corresponding step.
+func (s *AdminService) SetHistoryRetention(ctx context.Context, r models.HistoryRetention) (*models.HistoryRetention, error) {
+	var resp models.HistoryRetention
+	if err := s.c.put(ctx, "/api/v1/admin/history/retention", r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PruneHistory applies the tenant's history retention policy immediately.
+func (s *AdminService) PruneHistory(ctx context.Context) (*models.HistoryPruneResult, error) {
+	var resp models.HistoryPruneResult
+	if err := s.c.post(ctx, "/api/v1/admin/history/prune", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ArchivePolicy returns the tenant's forgetting policy.
+func (s *AdminService) ArchivePolicy(ctx context.Context) (*models.ArchivePolicy, error) {
+	var resp models.ArchivePolicy
+	if err := s.c.get(ctx, "/api/v1/admin/archive/policy", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetArchivePolicy replaces the tenant's forgetting policy. Nil fields
+// disable archival.
+func (s *AdminService) SetArchivePolicy(ctx context.Context, p models.ArchivePolicy) (*models.ArchivePolicy, error) {
+	var resp models.ArchivePolicy
+	if err := s.c.put(ctx, "/api/v1/admin/archive/policy", p, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RunArchive applies the tenant's forgetting policy immediately.
+func (s *AdminService) RunArchive(ctx context.Context) (*models.ArchiveResult, error) {
+	var resp models.ArchiveResult
+	if err := s.c.post(ctx, "/api/v1/admin/archive/run", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// HistoryService handles tenant-wide property history queries. History for a
+// single node is available from NodeService.History.
 type HistoryService struct {
 	c *Client
 }
+
+// List returns node changes across the tenant, newest first.
+func (s *HistoryService) List(ctx context.Context, opts *HistoryListOptions) ([]PropertyChange, bool, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Since != nil {
+			params.Set("since", opts.Since.Format(time.RFC3339))
+		}
+		if opts.Until != nil {
+			params.Set("until", opts.Until.Format(time.RFC3339))
+		}
+		if opts.PropertyKey != "" {
+			params.Set("property", opts.PropertyKey)
+		}
+		if opts.Field != "" {
+			params.Set("field", opts.Field)
+		}
+		if opts.Actor != "" {
+			params.Set("actor", opts.Actor)
+		}
+		if opts.Reason != "" {
+			params.Set("reason", opts.Reason)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.Offset > 0 {
+			params.Set("offset", strconv.Itoa(opts.Offset))
+		}
+	}
+	var resp struct {
+		Changes []PropertyChange `json:"changes"`
+		HasMore bool             `json:"has_more"`
+	}
+	if err := s.c.get(ctx, "/api/v1/history", params, &resp); err != nil {
+		return nil, false, err
+	}
+	return resp.Changes, resp.HasMore, nil
+}
+
+// RebuildTagCentroids recomputes the tag centroids SuggestTags ranks against.
+// Suggestions rebuild stale centroids on their own; this forces it.
+func (s *AdminService) RebuildTagCentroids(ctx context.Context) (*models.TagCentroidRebuild, error) {
+	var resp models.TagCentroidRebuild
+	if err := s.c.post(ctx, "/api/v1/admin/tags/centroids/rebuild", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// InferCoAccessRelations creates, reweights, and removes weak related_to
+// edges between nodes that searches and sessions keep returning together.
+func (s *AdminService) InferCoAccessRelations(ctx context.Context, req models.CoAccessInferenceRequest) (*models.CoAccessInferenceResult, error) {
+	var resp models.CoAccessInferenceResult
+	if err := s.c.post(ctx, "/api/v1/admin/relations/infer-co-access", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExplainQuery returns the query plan PostgreSQL uses for a named internal
+// query (fulltext, semantic, hybrid, or traverse) run with req's parameters.
+func (s *AdminService) ExplainQuery(ctx context.Context, req models.ExplainRequest) (*models.ExplainResult, error) {
+	var resp models.ExplainResult
+	if err := s.c.post(ctx, "/api/v1/admin/explain", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
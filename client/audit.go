@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 )
@@ -13,12 +14,32 @@ type AuditService struct {
 
 // auditQueryResponse wraps the paginated audit query response.
 type auditQueryResponse struct {
-	Data    []AuditEntry `json:"data"`
-	HasMore bool         `json:"has_more"`
+	Data       []AuditEntry `json:"data"`
+	HasMore    bool         `json:"has_more"`
+	NextCursor string       `json:"next_cursor,omitempty"`
 }
 
 // Query returns audit log entries matching the given options.
 func (s *AuditService) Query(ctx context.Context, opts *AuditQueryOptions) ([]AuditEntry, bool, error) {
+	resp, err := s.query(ctx, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Data, resp.HasMore, nil
+}
+
+// QueryPage returns one page of audit entries and the cursor for the next
+// page, which is empty on the last page. Pass it as AuditQueryOptions.After
+// to continue.
+func (s *AuditService) QueryPage(ctx context.Context, opts *AuditQueryOptions) ([]AuditEntry, string, error) {
+	resp, err := s.query(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Data, resp.NextCursor, nil
+}
+
+func (s *AuditService) query(ctx context.Context, opts *AuditQueryOptions) (*auditQueryResponse, error) {
 	params := url.Values{}
 	if opts != nil {
 		if opts.EntityType != "" {
@@ -39,12 +60,46 @@ func (s *AuditService) Query(ctx context.Context, opts *AuditQueryOptions) ([]Au
 		if opts.Offset > 0 {
 			params.Set("offset", strconv.Itoa(opts.Offset))
 		}
+		if opts.After != "" {
+			params.Set("after", opts.After)
+		}
 	}
 	var resp auditQueryResponse
 	if err := s.c.get(ctx, "/api/v1/audit", params, &resp); err != nil {
-		return nil, false, err
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Iter iterates over every audit entry matching opts, newest first,
+// following cursors page by page. Offset and After in opts are ignored.
+// Iteration stops at the first error, which is yielded with a zero
+// AuditEntry.
+func (s *AuditService) Iter(ctx context.Context, opts *AuditQueryOptions) iter.Seq2[AuditEntry, error] {
+	return func(yield func(AuditEntry, error) bool) {
+		var page AuditQueryOptions
+		if opts != nil {
+			page = *opts
+		}
+		page.Offset, page.After = 0, ""
+
+		for {
+			entries, next, err := s.QueryPage(ctx, &page)
+			if err != nil {
+				yield(AuditEntry{}, err)
+				return
+			}
+			for _, e := range entries {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			page.After = next
+		}
 	}
-	return resp.Data, resp.HasMore, nil
 }
 
 // Purge deletes audit entries older than retentionDays. Returns count deleted.
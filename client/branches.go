@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// BranchService handles branches: copy-on-write views of the graph where
+// writes are staged until the branch is merged or discarded.
+type BranchService struct {
+	c *Client
+}
+
+// Create creates an empty branch. The name is optional.
+func (s *BranchService) Create(ctx context.Context, name string) (*models.Branch, error) {
+	var b models.Branch
+	if err := s.c.post(ctx, "/api/v1/branches", models.CreateBranchRequest{Name: name}, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// List returns the tenant's branches, oldest first.
+func (s *BranchService) List(ctx context.Context) ([]models.Branch, error) {
+	var resp struct {
+		Branches []models.Branch `json:"branches"`
+	}
+	if err := s.c.get(ctx, "/api/v1/branches", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Branches, nil
+}
+
+// Get returns a branch with its staged change counts.
+func (s *BranchService) Get(ctx context.Context, id string) (*models.Branch, error) {
+	var b models.Branch
+	if err := s.c.get(ctx, branchPath(id), nil, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Changes lists the nodes and edges staged on a branch.
+func (s *BranchService) Changes(ctx context.Context, id string) (*models.BranchChanges, error) {
+	var changes models.BranchChanges
+	if err := s.c.get(ctx, branchPath(id)+"/changes", nil, &changes); err != nil {
+		return nil, err
+	}
+	return &changes, nil
+}
+
+// Merge applies a branch to the live graph and deletes it. When live nodes
+// or edges changed since the branch staged them, nothing is written and the
+// result lists the conflicts; force overwrites those changes instead.
+func (s *BranchService) Merge(ctx context.Context, id string, force bool) (*models.MergeBranchResult, error) {
+	var result models.MergeBranchResult
+	if err := s.c.post(ctx, branchPath(id)+"/merge", models.MergeBranchRequest{Force: force}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Discard deletes a branch and everything staged on it.
+func (s *BranchService) Discard(ctx context.Context, id string) error {
+	return s.c.del(ctx, branchPath(id), nil, nil)
+}
+
+// GetNode returns a node as the branch sees it.
+func (s *BranchService) GetNode(ctx context.Context, id, nodeID string) (*Node, error) {
+	var node Node
+	if err := s.c.get(ctx, branchPath(id)+"/nodes/"+url.PathEscape(nodeID), nil, &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// CreateNode stages a new node on a branch.
+func (s *BranchService) CreateNode(ctx context.Context, id string, req *CreateNodeRequest) (*Node, error) {
+	var node Node
+	if err := s.c.post(ctx, branchPath(id)+"/nodes", req, &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// UpdateNode stages an update to a node on a branch.
+func (s *BranchService) UpdateNode(ctx context.Context, id, nodeID string, req *UpdateNodeRequest) (*Node, error) {
+	var node Node
+	if err := s.c.put(ctx, branchPath(id)+"/nodes/"+url.PathEscape(nodeID), req, &node); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+// DeleteNode stages the deletion of a node and its edges on a branch.
+// Requires an admin-scoped key, like NodeService.Delete.
+func (s *BranchService) DeleteNode(ctx context.Context, id, nodeID string) error {
+	return s.c.del(ctx, branchPath(id)+"/nodes/"+url.PathEscape(nodeID), nil, nil)
+}
+
+// GetEdge returns an edge as the branch sees it.
+func (s *BranchService) GetEdge(ctx context.Context, id, source, target, relation string) (*Edge, error) {
+	var edge Edge
+	if err := s.c.get(ctx, branchEdgePath(id, source, target, relation), nil, &edge); err != nil {
+		return nil, err
+	}
+	return &edge, nil
+}
+
+// CreateEdge stages a new edge on a branch. Both endpoints must exist as the
+// branch sees the graph.
+func (s *BranchService) CreateEdge(ctx context.Context, id string, req *CreateEdgeRequest) (*Edge, error) {
+	var edge Edge
+	if err := s.c.post(ctx, branchPath(id)+"/edges", req, &edge); err != nil {
+		return nil, err
+	}
+	return &edge, nil
+}
+
+// UpdateEdge stages an update to an edge on a branch.
+func (s *BranchService) UpdateEdge(
+	ctx context.Context, id, source, target, relation string, req *UpdateEdgeRequest,
+) (*Edge, error) {
+	var edge Edge
+	if err := s.c.put(ctx, branchEdgePath(id, source, target, relation), req, &edge); err != nil {
+		return nil, err
+	}
+	return &edge, nil
+}
+
+// DeleteEdge stages the deletion of an edge on a branch. Requires an
+// admin-scoped key, like EdgeService.Delete.
+func (s *BranchService) DeleteEdge(ctx context.Context, id, source, target, relation string) error {
+	return s.c.del(ctx, branchEdgePath(id, source, target, relation), nil, nil)
+}
+
+// Neighbors returns a node's neighbors as the branch sees the graph.
+func (s *BranchService) Neighbors(ctx context.Context, id, nodeID string, limit int) (*NeighborResult, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var resp NeighborResult
+	if err := s.c.get(ctx, branchPath(id)+"/graph/neighbors/"+url.PathEscape(nodeID), params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func branchPath(id string) string {
+	return "/api/v1/branches/" + url.PathEscape(id)
+}
+
+func branchEdgePath(id, source, target, relation string) string {
+	return fmt.Sprintf("%s/edges/%s/%s/%s",
+		branchPath(id), url.PathEscape(source), url.PathEscape(target), url.PathEscape(relation))
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Capabilities reports which optional subsystems the server has enabled.
+func (c *Client) Capabilities(ctx context.Context) (*models.Capabilities, error) {
+	var resp models.Capabilities
+	if err := c.get(ctx, "/api/v1/capabilities", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
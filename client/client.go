@@ -4,19 +4,33 @@
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/security"
 )
 
 // Client is the top-level Persistor API client.
 type Client struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
+	baseURL       string
+	apiKey        string
+	signingSecret []byte
+	httpClient    *http.Client
+	retry         RetryPolicy
+	throttle      time.Duration
+	rateLimit     rateLimitTracker
+	// typedProperties is set by WithTypedProperties.
+	typedProperties bool
+	// language is sent as Accept-Language; see WithLanguage.
+	language string
 
 	Nodes    *NodeService
 	Edges    *EdgeService
@@ -27,6 +41,8 @@ type Client struct {
 	Audit    *AuditService
 	Admin    *AdminService
 	History  *HistoryService
+	Keys     *KeyService
+	Branches *BranchService
 }
 
 // Option configures a Client.
@@ -37,6 +53,12 @@ func WithAPIKey(key string) Option {
 	return func(c *Client) { c.apiKey = key }
 }
 
+// WithRequestSigning signs every request with the tenant's request signing
+// secret. Required once signing has been enabled with Keys.EnableSigning.
+func WithRequestSigning(secret string) Option {
+	return func(c *Client) { c.signingSecret = []byte(secret) }
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(hc *http.Client) Option {
 	return func(c *Client) { c.httpClient = hc }
@@ -47,6 +69,13 @@ func WithTimeout(d time.Duration) Option {
 	return func(c *Client) { c.httpClient.Timeout = d }
 }
 
+// WithLanguage asks for error messages in lang (e.g. "es" or "fr-CA, fr;q=0.9")
+// by sending it as Accept-Language. Languages the server lacks fall back to
+// English.
+func WithLanguage(lang string) Option {
+	return func(c *Client) { c.language = lang }
+}
+
 // New creates a Persistor client for the given base URL (e.g. "http://localhost:3030").
 func New(baseURL string, opts ...Option) *Client {
 	c := &Client{
@@ -63,8 +92,10 @@ func New(baseURL string, opts ...Option) *Client {
 	c.Bulk = &BulkService{c: c}
 	c.Salience = &SalienceService{c: c}
 	c.Audit = &AuditService{c: c}
-	c.Admin = &AdminService{c: c}
+	c.Admin = &AdminService{c: c, Tenants: &TenantService{c: c}}
 	c.History = &HistoryService{c: c}
+	c.Keys = &KeyService{c: c}
+	c.Branches = &BranchService{c: c}
 	return c
 }
 
@@ -86,50 +117,210 @@ func (c *Client) Stats(ctx context.Context) (*StatsResponse, error) {
 	return &resp, nil
 }
 
+// StatsReport returns per-group counts, salience spread, and monthly growth
+// for the tenant's nodes or edges. Empty fields use the server defaults.
+func (c *Client) StatsReport(ctx context.Context, req models.StatsReportRequest) (*models.StatsReport, error) {
+	params := url.Values{}
+	if req.Kind != "" {
+		params.Set("type", req.Kind)
+	}
+	if req.GroupBy != "" {
+		params.Set("group_by", req.GroupBy)
+	}
+
+	var resp models.StatsReport
+	if err := c.get(ctx, "/api/v1/stats/report", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Usage returns the tenant's node and edge counts, storage size, this
+// month's embedding and LLM usage, and quota.
+func (c *Client) Usage(ctx context.Context) (*models.TenantUsage, error) {
+	var resp models.TenantUsage
+	if err := c.get(ctx, "/api/v1/usage", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReportLLMUsage reports LLM requests and tokens the caller spent for the
+// tenant, so they count against its monthly LLM token budget. The usage is
+// recorded even when the returned error matches ErrQuotaExceeded, which
+// means the budget is now used up.
+func (c *Client) ReportLLMUsage(ctx context.Context, requests, tokens int64) error {
+	req := models.ReportModelUsageRequest{Requests: requests, Tokens: tokens}
+	return c.post(ctx, "/api/v1/usage/llm", req, nil)
+}
+
+// AccessAnalytics returns the tenant's most read nodes, most run search
+// queries (hashed), and traversal hot spots over the last days days, with
+// limit entries per ranking. Zero values use the server defaults.
+func (c *Client) AccessAnalytics(ctx context.Context, days, limit int) (*models.AccessAnalytics, error) {
+	params := url.Values{}
+	if days > 0 {
+		params.Set("days", strconv.Itoa(days))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp models.AccessAnalytics
+	if err := c.get(ctx, "/api/v1/analytics/access", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // do executes an HTTP request and decodes the JSON response.
 func (c *Client) do(ctx context.Context, method, path string, body any, result any) error {
-	u := c.baseURL + path
+	return c.doWith(ctx, c.httpClient, method, path, body, result)
+}
 
-	var bodyReader io.Reader
-	if body != nil {
-		data, err := json.Marshal(body)
+// doUntimed is do without the client timeout, for requests whose duration
+// grows with the size of the tenant's data. Cancel ctx to stop waiting.
+func (c *Client) doUntimed(ctx context.Context, method, path string, body any, result any) error {
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	return c.doWith(ctx, &httpClient, method, path, body, result)
+}
+
+// doWith is do using the given HTTP client, waiting out rate limits and
+// retrying as the client's options allow.
+func (c *Client) doWith(ctx context.Context, httpClient *http.Client, method, path string, body any, result any) error {
+	var idempotencyKey string
+	if method == http.MethodPost {
+		key, err := newIdempotencyKey()
 		if err != nil {
-			return fmt.Errorf("marshal request: %w", err)
+			return fmt.Errorf("generate idempotency key: %w", err)
 		}
-		bodyReader = bytes.NewReader(data)
+		idempotencyKey = key
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, bodyReader)
-	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+	canReplay := replayable(method, path)
+
+	var throttled time.Duration
+	for attempt := 1; ; {
+		retryAfter, err := c.attempt(ctx, httpClient, method, path, body, idempotencyKey, result)
+		if err == nil {
+			return nil
+		}
+
+		// Waiting out a rate limit does not use up a retry attempt.
+		if wait, ok := c.throttleWait(err, throttled); ok {
+			throttled += wait
+			if sleep(ctx, wait) != nil {
+				return err
+			}
+			continue
+		}
+
+		if attempt >= c.retry.MaxAttempts || !c.retry.retryable(ctx, err, canReplay) {
+			return err
+		}
+
+		if sleep(ctx, c.retry.backoff(attempt, retryAfter)) != nil {
+			return err
+		}
+		attempt++
 	}
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+}
+
+// attempt sends one request and decodes the JSON response. On an error
+// response it also returns the wait the server asked for, if any.
+func (c *Client) attempt(
+	ctx context.Context, httpClient *http.Client, method, path string, body any, idempotencyKey string, result any,
+) (time.Duration, error) {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return 0, err
 	}
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read response: %w", err)
+		return 0, fmt.Errorf("read response: %w", err)
 	}
 
+	c.rateLimit.update(resp.Header)
+
 	if resp.StatusCode >= 400 {
-		return parseAPIError(resp.StatusCode, respBody)
+		retryAfter := parseRetryAfter(resp.Header)
+		apiErr := parseAPIError(resp.StatusCode, respBody)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return retryAfter, &RateLimitError{APIError: apiErr, RetryAfter: retryAfter}
+		}
+		return retryAfter, apiErr
 	}
 
 	if result != nil && len(respBody) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("decode response: %w", err)
+			return 0, fmt.Errorf("decode response: %w", err)
 		}
 	}
+	return 0, nil
+}
+
+// newRequest builds an authenticated, and if configured signed, request with
+// body encoded as JSON.
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var (
+		bodyReader io.Reader
+		data       []byte
+	)
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal request: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if len(c.signingSecret) > 0 {
+		if err := c.sign(req, data); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// sign adds request signature headers covering the method, URI, and body.
+func (c *Client) sign(req *http.Request, body []byte) error {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("generate signature nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(buf)
+	ts := time.Now().Unix()
+
+	req.Header.Set(security.SignatureTimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(security.SignatureNonceHeader, nonce)
+	req.Header.Set(security.SignatureHeader,
+		security.SignRequest(c.signingSecret, ts, nonce, req.Method, req.URL.RequestURI(), body))
 	return nil
 }
 
@@ -1,13 +1,20 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/security"
 )
 
 // newTestServer creates a test server that routes to the given handler map.
@@ -48,6 +55,53 @@ func TestHealth(t *testing.T) {
 	}
 }
 
+func TestCapabilities(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/capabilities": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"version": "0.8.0", "features": map[string]bool{"embeddings": true, "webhooks": false}})
+		},
+	})
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities() error: %v", err)
+	}
+	if !caps.Has(models.CapabilityEmbeddings) || caps.Has(models.CapabilityWebhooks) || caps.Has("unknown") {
+		t.Errorf("got features %v", caps.Features)
+	}
+}
+
+func TestErrorCatalogLanguage(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/errors": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept-Language") != "es" {
+				t.Errorf("Accept-Language = %q, want es", r.Header.Get("Accept-Language"))
+			}
+			jsonResponse(w, 200, models.NewErrorCatalog("es"))
+		},
+		"GET /api/v1/nodes/missing": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 404, map[string]string{
+				"code": "not_found", "message": "No se encontró el recurso solicitado.",
+				"detail": "node not found", "docs_url": models.ErrorDocsURL("not_found"),
+			})
+		},
+	})
+	WithLanguage("es")(c)
+
+	catalog, err := c.ErrorCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("ErrorCatalog() error: %v", err)
+	}
+	if catalog.Language != "es" || len(catalog.Errors) == 0 {
+		t.Errorf("got catalog %+v", catalog)
+	}
+
+	_, err = c.Nodes.Get(context.Background(), "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Detail != "node not found" || apiErr.DocsURL == "" {
+		t.Errorf("got error %#v", err)
+	}
+}
+
 func TestStats(t *testing.T) {
 	_, c := newTestServer(t, map[string]http.HandlerFunc{
 		"GET /api/v1/stats": func(w http.ResponseWriter, _ *http.Request) {
@@ -63,6 +117,50 @@ func TestStats(t *testing.T) {
 	}
 }
 
+func TestAccessAnalytics(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/analytics/access": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("days") != "30" || r.URL.Query().Has("limit") {
+				t.Errorf("query = %s, want days=30 only", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, map[string]any{
+				"top_nodes":   []map[string]any{{"node_id": "n1", "label": "Ada", "count": 7}},
+				"top_queries": []map[string]any{{"query_hash": "abc", "count": 3}},
+			})
+		},
+	})
+
+	resp, err := c.AccessAnalytics(context.Background(), 30, 0)
+	if err != nil || len(resp.TopNodes) != 1 || resp.TopNodes[0].Count != 7 || resp.TopQueries[0].QueryHash != "abc" {
+		t.Fatalf("AccessAnalytics: err=%v, resp=%+v", err, resp)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/usage": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"nodes": 90, "edges": 12, "storage_bytes": 4096, "quota": map[string]int{"max_nodes": 100}})
+		},
+		"POST /api/v1/nodes": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 403, map[string]string{"code": "quota_exceeded", "message": "quota exceeded: tenant is limited to 100 nodes"})
+		},
+	})
+	ctx := context.Background()
+
+	usage, err := c.Usage(ctx)
+	if err != nil || usage.Nodes != 90 || usage.Quota.MaxNodes == nil || *usage.Quota.MaxNodes != 100 || usage.Quota.MaxEdges != nil {
+		t.Fatalf("Usage: err=%v, usage=%+v", err, usage)
+	}
+
+	_, err = c.Nodes.Create(ctx, &CreateNodeRequest{ID: "n1", Type: "person", Label: "Alice"})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Create over quota: err = %v, want ErrQuotaExceeded", err)
+	}
+	if errors.Is(&APIError{StatusCode: 403, Code: "forbidden"}, ErrQuotaExceeded) {
+		t.Error("a forbidden error should not match ErrQuotaExceeded")
+	}
+}
+
 func TestNodesCRUD(t *testing.T) {
 	_, c := newTestServer(t, map[string]http.HandlerFunc{
 		"GET /api/v1/nodes": func(w http.ResponseWriter, _ *http.Request) {
@@ -79,9 +177,28 @@ func TestNodesCRUD(t *testing.T) {
 		"PUT /api/v1/nodes/n1": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, Node{ID: "n1", Label: "Updated"})
 		},
-		"DELETE /api/v1/nodes/n1": func(w http.ResponseWriter, _ *http.Request) {
+		"DELETE /api/v1/nodes/n1": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("mode") != "detach" {
+				jsonResponse(w, 409, map[string]any{"error": map[string]string{"code": "conflict", "message": "node has edges: 2"}})
+				return
+			}
 			jsonResponse(w, 200, map[string]bool{"deleted": true})
 		},
+		"POST /api/v1/nodes/n2/merge-into/n1": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, MergeNodeResult{SourceID: "n2", TargetID: "n1", Node: &Node{ID: "n1"}, EdgesRewired: 2})
+		},
+		"POST /api/v1/nodes/delete-by-filter/preview": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, models.DeletePreview{Token: "tok", Count: 3})
+		},
+		"POST /api/v1/nodes/delete-by-filter": func(w http.ResponseWriter, r *http.Request) {
+			var req models.DeleteByFilterRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Token != "tok" || req.Filter.Type != "old" {
+				jsonResponse(w, 409, map[string]any{"error": map[string]string{"code": "conflict", "message": "mismatch"}})
+				return
+			}
+			jsonResponse(w, 200, models.DeleteByFilterResult{NodesDeleted: 3, EdgesDeleted: 4})
+		},
 	})
 
 	ctx := context.Background()
@@ -123,9 +240,332 @@ func TestNodesCRUD(t *testing.T) {
 		t.Errorf("Update: got label %q", node.Label)
 	}
 
+	// Merge
+	merged, err := c.Nodes.MergeInto(ctx, "n2", "n1", &MergeNodeRequest{})
+	if err != nil {
+		t.Fatalf("MergeInto error: %v", err)
+	}
+	if merged.Node.ID != "n1" || merged.EdgesRewired != 2 {
+		t.Errorf("MergeInto: got %+v", merged)
+	}
+
+	// Delete by filter
+	preview, err := c.Nodes.PreviewDeleteByFilter(ctx, models.NodeFilter{Type: "old"})
+	if err != nil {
+		t.Fatalf("PreviewDeleteByFilter error: %v", err)
+	}
+	deleted, err := c.Nodes.DeleteByFilter(ctx, models.NodeFilter{Type: "old"}, preview.Token)
+	if err != nil {
+		t.Fatalf("DeleteByFilter error: %v", err)
+	}
+	if deleted.NodesDeleted != 3 || deleted.EdgesDeleted != 4 {
+		t.Errorf("DeleteByFilter: got %+v", deleted)
+	}
+
 	// Delete
-	if err := c.Nodes.Delete(ctx, "n1"); err != nil {
-		t.Fatalf("Delete error: %v", err)
+	if err := c.Nodes.Delete(ctx, "n1"); !IsConflict(err) {
+		t.Fatalf("Delete of a node with edges: got %v, want conflict", err)
+	}
+	if err := c.Nodes.DeleteDetach(ctx, "n1"); err != nil {
+		t.Fatalf("DeleteDetach error: %v", err)
+	}
+}
+
+func TestNodesSuggestTags(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/nodes/n1/suggest-tags": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("limit"); got != "3" {
+				t.Errorf("limit = %q, want 3", got)
+			}
+			jsonResponse(w, 200, map[string]any{
+				"node_id":     "n1",
+				"suggestions": []map[string]any{{"tag": "infra", "score": 0.91, "node_count": 4}},
+			})
+		},
+	})
+
+	result, err := c.Nodes.SuggestTags(context.Background(), "n1", 3)
+	if err != nil || len(result.Suggestions) != 1 || result.Suggestions[0].Tag != "infra" {
+		t.Fatalf("SuggestTags: err=%v, result=%+v", err, result)
+	}
+}
+
+func TestSearchPropsFilters(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/search": func(w http.ResponseWriter, r *http.Request) {
+			got := r.URL.Query()["props"]
+			if len(got) != 2 || got[0] != "status:active" || got[1] != "owner" {
+				t.Errorf("props = %q, want [status:active owner]", got)
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []any{}, "total": 0})
+		},
+	})
+
+	opts := &SearchOptions{Props: []string{"status:active", "owner"}}
+	if _, err := c.Search.FullText(context.Background(), "q", opts); err != nil {
+		t.Fatalf("FullText error: %v", err)
+	}
+}
+
+func TestSearchFullTextFacets(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/search": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("facets") != "true" {
+				t.Errorf("facets = %q, want true", r.URL.Query().Get("facets"))
+			}
+			jsonResponse(w, 200, map[string]any{
+				"nodes":  []Node{{ID: "n1"}},
+				"total":  1,
+				"facets": models.SearchFacets{Total: 4, Types: []models.FacetCount{{Value: "person", Count: 4}}},
+			})
+		},
+	})
+
+	nodes, facets, err := c.Search.FullTextFacets(context.Background(), "q", &SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("FullTextFacets error: %v", err)
+	}
+	if len(nodes) != 1 || facets == nil || facets.Total != 4 || len(facets.Types) != 1 {
+		t.Errorf("got nodes %v, facets %+v", nodes, facets)
+	}
+}
+
+func TestSearchFilters(t *testing.T) {
+	checkFilters := func(t *testing.T, r *http.Request) {
+		t.Helper()
+		q := r.URL.Query()
+		if q.Get("type") != "person" || q.Get("min_salience") != "2.5" || q.Get("include_superseded") != "true" {
+			t.Errorf("query = %q, want type, min_salience, and include_superseded", r.URL.RawQuery)
+		}
+	}
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/search/semantic": func(w http.ResponseWriter, r *http.Request) {
+			checkFilters(t, r)
+			jsonResponse(w, 200, map[string]any{"nodes": []any{}, "total": 0})
+		},
+		"GET /api/v1/search/hybrid": func(w http.ResponseWriter, r *http.Request) {
+			checkFilters(t, r)
+			jsonResponse(w, 200, map[string]any{"nodes": []any{}, "total": 0})
+		},
+	})
+
+	opts := &SearchOptions{Type: "person", MinSalience: 2.5, IncludeSuperseded: true}
+	if _, err := c.Search.SemanticWithOptions(context.Background(), "q", opts); err != nil {
+		t.Fatalf("SemanticWithOptions error: %v", err)
+	}
+	if _, err := c.Search.Hybrid(context.Background(), "q", opts); err != nil {
+		t.Fatalf("Hybrid error: %v", err)
+	}
+}
+
+func TestSearchRerank(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/search/hybrid": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("rerank") != "true" {
+				t.Errorf("query = %q, want rerank=true", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []any{}, "total": 0})
+		},
+	})
+
+	if _, err := c.Search.Hybrid(context.Background(), "q", &SearchOptions{Rerank: true}); err != nil {
+		t.Fatalf("Hybrid error: %v", err)
+	}
+}
+
+func TestSearchNeighborhood(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/search": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("root") != "proj-y" || q.Get("depth") != "3" {
+				t.Errorf("query = %q, want root and depth", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []any{}, "total": 0})
+		},
+	})
+
+	if _, err := c.Search.FullText(context.Background(), "q", &SearchOptions{Root: "proj-y", Depth: 3}); err != nil {
+		t.Fatalf("FullText error: %v", err)
+	}
+}
+
+func TestGraphMetapaths(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"PUT /api/v1/metapaths/employer-city": func(w http.ResponseWriter, r *http.Request) {
+			var req models.PutMetapathRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Steps) != 2 {
+				t.Errorf("body = %+v, %v", req, err)
+			}
+			jsonResponse(w, 200, models.Metapath{Name: "employer-city", Steps: req.Steps})
+		},
+		"GET /api/v1/graph/metapath/employer-city/alice": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") != "5" {
+				t.Errorf("limit = %q, want 5", r.URL.Query().Get("limit"))
+			}
+			jsonResponse(w, 200, models.MetapathResult{Metapath: "employer-city", StartID: "alice", Paths: [][]string{{"alice", "acme", "berlin"}}})
+		},
+	})
+
+	steps := []models.MetapathStep{{Relation: "works_at"}, {Relation: "located_in"}}
+	if _, err := c.Graph.PutMetapath(context.Background(), "employer-city", models.PutMetapathRequest{Steps: steps}); err != nil {
+		t.Fatalf("PutMetapath error: %v", err)
+	}
+
+	result, err := c.Graph.RunMetapath(context.Background(), "employer-city", "alice", 5)
+	if err != nil {
+		t.Fatalf("RunMetapath error: %v", err)
+	}
+	if len(result.Paths) != 1 || result.Paths[0][2] != "berlin" {
+		t.Errorf("RunMetapath = %+v", result)
+	}
+}
+
+func TestGraphSnapshots(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/snapshots": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CreateSnapshotRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "pre-experiment" {
+				t.Errorf("body = %+v, %v", req, err)
+			}
+			jsonResponse(w, 201, models.Snapshot{Name: req.Name, NodeCount: 3})
+		},
+		"GET /api/v1/snapshots/pre-experiment/compare/post-experiment": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, models.SnapshotComparison{From: "pre-experiment", To: "post-experiment", NodeDelta: 2})
+		},
+	})
+
+	snap, err := c.Graph.CreateSnapshot(context.Background(), models.CreateSnapshotRequest{Name: "pre-experiment"})
+	if err != nil || snap.NodeCount != 3 {
+		t.Fatalf("CreateSnapshot = %+v, %v", snap, err)
+	}
+
+	cmp, err := c.Graph.CompareSnapshots(context.Background(), "pre-experiment", "post-experiment")
+	if err != nil || cmp.NodeDelta != 2 {
+		t.Errorf("CompareSnapshots = %+v, %v", cmp, err)
+	}
+}
+
+func TestNodesWatch(t *testing.T) {
+	checkWatcher := func(t *testing.T, r *http.Request) {
+		t.Helper()
+		if got := r.URL.Query().Get("watcher"); got != "agent 1" {
+			t.Errorf("watcher = %q, want %q", got, "agent 1")
+		}
+	}
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/watch/n1": func(w http.ResponseWriter, r *http.Request) {
+			checkWatcher(t, r)
+			jsonResponse(w, 200, map[string]any{"watcher": "agent 1", "node_id": "n1"})
+		},
+		"DELETE /api/v1/watch/n1": func(w http.ResponseWriter, r *http.Request) {
+			checkWatcher(t, r)
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"GET /api/v1/watch": func(w http.ResponseWriter, r *http.Request) {
+			checkWatcher(t, r)
+			jsonResponse(w, 200, map[string]any{"watches": []map[string]any{{"watcher": "agent 1", "node_id": "n1"}}})
+		},
+	})
+
+	watch, err := c.Nodes.Watch(context.Background(), "n1", "agent 1")
+	if err != nil || watch.NodeID != "n1" {
+		t.Fatalf("Watch: err=%v, watch=%+v", err, watch)
+	}
+
+	watches, err := c.Nodes.Watched(context.Background(), "agent 1")
+	if err != nil || len(watches) != 1 || watches[0].NodeID != "n1" {
+		t.Fatalf("Watched: err=%v, watches=%+v", err, watches)
+	}
+
+	if err := c.Nodes.Unwatch(context.Background(), "n1", "agent 1"); err != nil {
+		t.Fatalf("Unwatch: %v", err)
+	}
+}
+
+func TestNodesIter(t *testing.T) {
+	pages := map[string]map[string]any{
+		"":   {"nodes": []Node{{ID: "n1"}, {ID: "n2"}}, "has_more": true, "next_cursor": "c1"},
+		"c1": {"nodes": []Node{{ID: "n3"}}, "has_more": false},
+	}
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/nodes": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("type") != "person" || r.URL.Query().Has("offset") {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, pages[r.URL.Query().Get("after")])
+		},
+	})
+
+	var ids []string
+	for n, err := range c.Nodes.Iter(context.Background(), &NodeListOptions{Type: "person", Offset: 5}) {
+		if err != nil {
+			t.Fatalf("Iter error: %v", err)
+		}
+		ids = append(ids, n.ID)
+	}
+	if len(ids) != 3 || ids[2] != "n3" {
+		t.Errorf("Iter = %v, want n1 n2 n3", ids)
+	}
+}
+
+func TestEdgesIter_Error(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/edges": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("after") == "" {
+				jsonResponse(w, 200, map[string]any{"edges": []Edge{{Source: "a"}}, "has_more": true, "next_cursor": "c1"})
+				return
+			}
+			jsonResponse(w, 400, map[string]any{"error": map[string]string{"code": "invalid_request", "message": "invalid list cursor"}})
+		},
+	})
+
+	var got int
+	var lastErr error
+	for _, err := range c.Edges.Iter(context.Background(), nil) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		got++
+	}
+	if got != 1 || lastErr == nil {
+		t.Errorf("Iter yielded %d edges, err %v; want 1 edge then an error", got, lastErr)
+	}
+}
+
+func TestAuditIter(t *testing.T) {
+	var requests int
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/audit": func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if r.URL.Query().Get("action") != "delete" || r.URL.Query().Has("offset") {
+				t.Errorf("unexpected query %q", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("after") == "" {
+				jsonResponse(w, 200, map[string]any{"data": []AuditEntry{{ID: 3}, {ID: 2}}, "has_more": true, "next_cursor": "c1"})
+				return
+			}
+			jsonResponse(w, 200, map[string]any{"data": []AuditEntry{{ID: 1}}, "has_more": false})
+		},
+	})
+
+	var ids []int64
+	for e, err := range c.Audit.Iter(context.Background(), &AuditQueryOptions{Action: "delete", Offset: 10}) {
+		if err != nil {
+			t.Fatalf("Iter error: %v", err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 3 || ids[0] != 3 || ids[2] != 1 || requests != 2 {
+		t.Errorf("Iter = %v after %d requests, want 3 2 1 after 2", ids, requests)
+	}
+
+	requests = 0
+	for range c.Audit.Iter(context.Background(), &AuditQueryOptions{Action: "delete"}) {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("early break made %d requests, want 1", requests)
 	}
 }
 
@@ -137,12 +577,30 @@ func TestEdgesCRUD(t *testing.T) {
 		"POST /api/v1/edges": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 201, Edge{Source: "a", Target: "b", Relation: "knows"})
 		},
+		"POST /api/v1/edges/exists": func(w http.ResponseWriter, r *http.Request) {
+			var req models.EdgeExistsRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Edges) != 2 {
+				t.Fatalf("exists body = %+v, err = %v", req, err)
+			}
+			jsonResponse(w, 200, map[string]any{"edges": []models.EdgeExistence{
+				{EdgeKey: req.Edges[0], Exists: true}, {EdgeKey: req.Edges[1]},
+			}, "existing": 1})
+		},
 		"PUT /api/v1/edges/a/b/knows": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, Edge{Source: "a", Target: "b", Relation: "knows", Weight: 0.9})
 		},
 		"DELETE /api/v1/edges/a/b/knows": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]bool{"deleted": true})
 		},
+		"GET /api/v1/edges/a/b/knows/history": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("property"); got != "since" {
+				t.Fatalf("property query = %q, want since", got)
+			}
+			jsonResponse(w, 200, map[string]any{
+				"changes":  []EdgePropertyChange{{ID: 1, Source: "a", Target: "b", Relation: "knows", PropertyKey: "since"}},
+				"has_more": false,
+			})
+		},
 	})
 
 	ctx := context.Background()
@@ -160,17 +618,84 @@ func TestEdgesCRUD(t *testing.T) {
 		t.Errorf("Create: got source %q", edge.Source)
 	}
 
+	exists, err := c.Edges.Exists(ctx, []models.EdgeKey{
+		{Source: "a", Target: "b", Relation: "knows"}, {Source: "a", Target: "c", Relation: "knows"},
+	})
+	if err != nil || len(exists) != 2 || !exists[0].Exists || exists[1].Exists || exists[1].Target != "c" {
+		t.Fatalf("Exists: err=%v, exists=%+v", err, exists)
+	}
+
 	w := 0.9
 	edge, err = c.Edges.Update(ctx, "a", "b", "knows", &UpdateEdgeRequest{Weight: &w})
 	if err != nil {
 		t.Fatalf("Update error: %v", err)
 	}
 
+	changes, _, err := c.Edges.History(ctx, "a", "b", "knows", "since", 0, 0)
+	if err != nil || len(changes) != 1 || changes[0].PropertyKey != "since" {
+		t.Fatalf("History: err=%v, changes=%+v", err, changes)
+	}
+
 	if err := c.Edges.Delete(ctx, "a", "b", "knows"); err != nil {
 		t.Fatalf("Delete error: %v", err)
 	}
 }
 
+func TestTypedProperties(t *testing.T) {
+	var sent map[string]any
+	srv, _ := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/nodes": func(w http.ResponseWriter, r *http.Request) {
+			var req CreateNodeRequest
+			json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+			sent = req.Properties
+			jsonResponse(w, 201, Node{ID: req.ID, Properties: req.Properties})
+		},
+	})
+	c := New(srv.URL, WithAPIKey("test-key"), WithTypedProperties())
+
+	at := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	node, err := c.Nodes.Create(context.Background(), &CreateNodeRequest{
+		ID: "n1", Type: "t", Label: "l",
+		Properties: map[string]any{"count": int64(9007199254740993), "at": at, "name": "x"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count, ok := sent["count"].(map[string]any)
+	if !ok || count["$type"] != "int" || count["value"] != "9007199254740993" {
+		t.Errorf("sent count = %#v", sent["count"])
+	}
+	if node.Properties["count"] != int64(9007199254740993) {
+		t.Errorf("count = %#v", node.Properties["count"])
+	}
+	if got, ok := node.Properties["at"].(time.Time); !ok || !got.Equal(at) {
+		t.Errorf("at = %#v", node.Properties["at"])
+	}
+	if node.Properties["name"] != "x" {
+		t.Errorf("name = %#v", node.Properties["name"])
+	}
+}
+
+func TestNodeFieldHistory(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/nodes/n1/history": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("field") != "label" {
+				t.Errorf("field = %q, want label", r.URL.Query().Get("field"))
+			}
+			jsonResponse(w, 200, map[string]any{
+				"changes":  []map[string]any{{"id": 1, "node_id": "n1", "field": "label", "old_value": "Bob", "new_value": "Robert"}},
+				"has_more": false,
+			})
+		},
+	})
+
+	changes, _, err := c.Nodes.FieldHistory(context.Background(), "n1", "label", 0, 0)
+	if err != nil || len(changes) != 1 || changes[0].Field != "label" || changes[0].PropertyKey != "" {
+		t.Fatalf("FieldHistory: err=%v, changes=%+v", err, changes)
+	}
+}
+
 func TestSearch(t *testing.T) {
 	_, c := newTestServer(t, map[string]http.HandlerFunc{
 		"GET /api/v1/search": func(w http.ResponseWriter, r *http.Request) {
@@ -232,6 +757,33 @@ func TestGraph(t *testing.T) {
 		"GET /api/v1/graph/path/n1/n3": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]any{"path": []Node{{ID: "n1"}, {ID: "n2"}, {ID: "n3"}}})
 		},
+		"GET /api/v1/graph/summary/n1": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") != "5" {
+				t.Errorf("summary limit = %q, want 5", r.URL.Query().Get("limit"))
+			}
+			jsonResponse(w, 200, models.GraphSummary{Node: models.NodeSummary{ID: "n1"}, Degree: 2})
+		},
+		"POST /api/v1/graph/subgraph": func(w http.ResponseWriter, r *http.Request) {
+			var req models.SubgraphRequest
+			json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+			nodes := make([]models.Node, len(req.NodeIDs))
+			for i, id := range req.NodeIDs {
+				nodes[i] = models.Node{ID: id}
+			}
+			jsonResponse(w, 200, models.SubgraphResult{Nodes: nodes})
+		},
+		"POST /api/v1/graph/communities": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CommunityRequest
+			json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+			jsonResponse(w, 200, models.CommunityResult{Iterations: req.MaxIterations, Communities: []models.Community{{ID: 1, Size: 2}}})
+		},
+		"GET /api/v1/graph/asof": func(w http.ResponseWriter, r *http.Request) {
+			ts, err := time.Parse(time.RFC3339, r.URL.Query().Get("timestamp"))
+			if err != nil {
+				t.Errorf("asof timestamp: %v", err)
+			}
+			jsonResponse(w, 200, models.GraphSnapshot{AsOf: ts, Nodes: []models.Node{{ID: "n1"}}})
+		},
 	})
 
 	ctx := context.Background()
@@ -255,6 +807,27 @@ func TestGraph(t *testing.T) {
 	if err != nil || len(path) != 3 {
 		t.Fatalf("ShortestPath: err=%v, len=%d", err, len(path))
 	}
+
+	sum, err := c.Graph.Summary(ctx, "n1", 5)
+	if err != nil || sum.Node.ID != "n1" || sum.Degree != 2 {
+		t.Fatalf("Summary: err=%v", err)
+	}
+
+	sub, err := c.Graph.Subgraph(ctx, []string{"n1", "n2"}, true)
+	if err != nil || len(sub.Nodes) != 2 {
+		t.Fatalf("Subgraph: err=%v", err)
+	}
+
+	comm, err := c.Graph.Communities(ctx, models.CommunityRequest{MaxIterations: 7})
+	if err != nil || comm.Iterations != 7 || len(comm.Communities) != 1 {
+		t.Fatalf("Communities: err=%v", err)
+	}
+
+	asOf := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	snap, err := c.Graph.AsOf(ctx, models.AsOfQuery{Timestamp: asOf})
+	if err != nil || !snap.AsOf.Equal(asOf) || len(snap.Nodes) != 1 {
+		t.Fatalf("AsOf: err=%v", err)
+	}
 }
 
 func TestSalience(t *testing.T) {
@@ -268,6 +841,12 @@ func TestSalience(t *testing.T) {
 		"POST /api/v1/salience/recalc": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]int{"updated": 42})
 		},
+		"GET /api/v1/salience/top": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("type") != "person" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("top query = %s", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []SalienceEntry{{NodeID: "n1", Salience: 3.2}}})
+		},
 	})
 
 	ctx := context.Background()
@@ -285,7 +864,12 @@ func TestSalience(t *testing.T) {
 	if err != nil || count != 42 {
 		t.Fatalf("Recalculate: err=%v, count=%d", err, count)
 	}
-}
+
+	top, err := c.Salience.Top(ctx, "person", 5)
+	if err != nil || len(top) != 1 || top[0].NodeID != "n1" {
+		t.Fatalf("Top: err=%v, nodes=%v", err, top)
+	}
+}
 
 func TestBulk(t *testing.T) {
 	_, c := newTestServer(t, map[string]http.HandlerFunc{
@@ -324,7 +908,7 @@ func TestBulk(t *testing.T) {
 func TestAudit(t *testing.T) {
 	_, c := newTestServer(t, map[string]http.HandlerFunc{
 		"GET /api/v1/audit": func(w http.ResponseWriter, _ *http.Request) {
-			jsonResponse(w, 200, map[string]any{"data": []AuditEntry{{ID: "a1", Action: "node.create"}}, "has_more": false})
+			jsonResponse(w, 200, map[string]any{"data": []AuditEntry{{ID: 1, Action: "node.create"}}, "has_more": false})
 		},
 		"DELETE /api/v1/audit": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]any{"deleted": 10, "retention_days": 90})
@@ -349,6 +933,21 @@ func TestAdmin(t *testing.T) {
 		"POST /api/v1/admin/backfill-embeddings": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]int{"queued": 25})
 		},
+		"GET /api/v1/admin/backfill-embeddings": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"backfills": []map[string]any{{"job_id": "bf-1", "status": "running"}}})
+		},
+		"GET /api/v1/admin/backfill-embeddings/bf-1": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"job_id": "bf-1", "status": "running", "queued": 25, "processed": 10, "remaining": 15})
+		},
+		"POST /api/v1/admin/backfill-embeddings/bf-1/cancel": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"job_id": "bf-1", "status": "cancelled", "queued": 25, "processed": 10, "remaining": 15})
+		},
+		"POST /api/v1/admin/reembed": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 202, map[string]any{"target_model": "new-model", "status": "running", "total": 40, "remaining": 40})
+		},
+		"GET /api/v1/admin/reembed/status": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"target_model": "new-model", "status": "running", "total": 40, "processed": 30, "remaining": 10})
+		},
 		"POST /api/v1/admin/reprocess-nodes": func(w http.ResponseWriter, _ *http.Request) {
 			jsonResponse(w, 200, map[string]int{"scanned": 100, "updated_search": 100, "queued_embeddings": 100})
 		},
@@ -366,6 +965,113 @@ func TestAdmin(t *testing.T) {
 				"reasons":   []map[string]any{{"code": "same_normalized_label", "description": "Nodes have the same normalized label.", "weight": 0.55, "evidence": []string{"Bill Gates", "bill gates"}}},
 			}}})
 		},
+		"POST /api/v1/admin/broadcast": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 202, map[string]string{"status": "queued", "target": "all"})
+		},
+		"GET /api/v1/admin/duplicates": func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("type"); got != "company" {
+				t.Fatalf("type query = %q, want company", got)
+			}
+			jsonResponse(w, 200, map[string]any{"duplicates": []map[string]any{{
+				"canonical": map[string]any{"id": "acme", "label": "Acme"},
+				"duplicate": map[string]any{"id": "acme-inc", "label": "Acme Inc"},
+				"score":     0.82,
+			}}})
+		},
+		"POST /api/v1/admin/relations/infer-co-access": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CoAccessInferenceRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MinScore != 2 {
+				t.Fatalf("infer-co-access body = %+v, err = %v", req, err)
+			}
+			jsonResponse(w, 200, map[string]int{"pairs": 4, "created": 3, "updated": 1, "removed": 2})
+		},
+		"GET /api/v1/admin/security/blocks": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"blocks": []map[string]any{{"key_hash": "0123456789abcdef", "attempts": 5}}})
+		},
+		"GET /api/v1/admin/partitions": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"partitions": []map[string]any{
+				{"table": "kg_nodes", "partition": "kg_nodes_p0", "modulus": 2, "remainder": 0, "total_bytes": 8192},
+			}})
+		},
+		"GET /api/v1/admin/vector-index": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"exists": true, "valid": true, "params": map[string]any{"method": "hnsw", "m": 16, "ef_construction": 64},
+				"embedded_nodes": 20000, "recommended": map[string]any{"method": "hnsw", "m": 16, "ef_construction": 64},
+				"warnings": []string{"unused"},
+			})
+		},
+		"POST /api/v1/admin/vector-index": func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["method"] != "ivfflat" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			jsonResponse(w, 202, map[string]any{"method": "ivfflat", "lists": 20})
+		},
+		"GET /api/v1/admin/index-advisor": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("min_calls") != "50" || r.URL.Query().Get("min_mean_ms") != "2.5" {
+				http.Error(w, "bad thresholds", http.StatusBadRequest)
+				return
+			}
+			jsonResponse(w, 200, map[string]any{
+				"stats_available": true, "building": false,
+				"advice": []map[string]any{{
+					"filters": []string{"props", "type"}, "statements": 2, "calls": 900, "mean_time_ms": 12.5,
+					"recommended": map[string]any{"name": "idx_nodes_tenant_type_props", "method": "gin", "columns": []string{"tenant_id", "type", "search_props"}},
+				}},
+			})
+		},
+		"POST /api/v1/admin/index-advisor": func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["min_calls"] != float64(50) {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			jsonResponse(w, 202, map[string]any{"stats_available": true, "building": true, "advice": []any{}})
+		},
+		"GET /api/v1/admin/diff": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("left") != "t1" || r.URL.Query().Get("right") != "t2" {
+				http.Error(w, "bad tenants", http.StatusBadRequest)
+				return
+			}
+			jsonResponse(w, 200, map[string]any{
+				"left": "t1", "right": "t2",
+				"stats":       map[string]int{"nodes_added": 1},
+				"nodes_added": []map[string]any{{"id": "bob", "type": "person", "label": "Bob"}},
+			})
+		},
+		"GET /api/v1/admin/history/retention": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"retention_days": 365, "compact_after_days": nil})
+		},
+		"PUT /api/v1/admin/history/retention": func(w http.ResponseWriter, r *http.Request) {
+			var req models.HistoryRetention
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CompactAfterDays == nil || *req.CompactAfterDays != 30 {
+				t.Fatalf("retention body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, req)
+		},
+		"POST /api/v1/admin/history/prune": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]int{"deleted": 12, "compacted": 7})
+		},
+		"PUT /api/v1/admin/archive/policy": func(w http.ResponseWriter, r *http.Request) {
+			var req models.ArchivePolicy
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.IdleDays == nil || *req.IdleDays != 90 {
+				t.Fatalf("archive policy body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, req)
+		},
+		"POST /api/v1/admin/archive/run": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]int{"nodes": 8, "edges": 11})
+		},
+		"GET /api/v1/archive": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") != "10" {
+				t.Errorf("archive limit = %q, want 10", r.URL.Query().Get("limit"))
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []map[string]any{{"id": "old", "label": "Old"}}, "has_more": false})
+		},
+		"POST /api/v1/archive/old/restore": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"node": map[string]any{"id": "old"}, "edges_restored": 2})
+		},
 	})
 
 	queued, err := c.Admin.BackfillEmbeddings(context.Background())
@@ -373,6 +1079,31 @@ func TestAdmin(t *testing.T) {
 		t.Fatalf("BackfillEmbeddings: err=%v, queued=%d", err, queued)
 	}
 
+	backfills, err := c.Admin.ListBackfills(context.Background())
+	if err != nil || len(backfills) != 1 || backfills[0].ID != "bf-1" {
+		t.Fatalf("ListBackfills: err=%v, backfills=%+v", err, backfills)
+	}
+
+	backfill, err := c.Admin.BackfillStatus(context.Background(), "bf-1")
+	if err != nil || backfill.Processed != 10 || backfill.Remaining != 15 {
+		t.Fatalf("BackfillStatus: err=%v, backfill=%+v", err, backfill)
+	}
+
+	backfill, err = c.Admin.CancelBackfill(context.Background(), "bf-1")
+	if err != nil || backfill.Status != "cancelled" {
+		t.Fatalf("CancelBackfill: err=%v, backfill=%+v", err, backfill)
+	}
+
+	reembed, err := c.Admin.StartReembed(context.Background())
+	if err != nil || reembed.TargetModel != "new-model" || reembed.Total != 40 {
+		t.Fatalf("StartReembed: err=%v, reembed=%+v", err, reembed)
+	}
+
+	reembed, err = c.Admin.ReembedStatus(context.Background())
+	if err != nil || reembed.Processed != 30 || reembed.Remaining != 10 {
+		t.Fatalf("ReembedStatus: err=%v, reembed=%+v", err, reembed)
+	}
+
 	result, err := c.Admin.ReprocessNodes(context.Background(), models.ReprocessNodesRequest{BatchSize: 100, SearchText: true, Embeddings: true})
 	if err != nil || result.Scanned != 100 || result.UpdatedSearch != 100 || result.QueuedEmbed != 100 {
 		t.Fatalf("ReprocessNodes: err=%v, result=%+v", err, result)
@@ -393,6 +1124,567 @@ func TestAdmin(t *testing.T) {
 	if len(suggestions[0].Reasons) != 1 {
 		t.Fatalf("reasons = %#v, want 1 reason", suggestions[0].Reasons)
 	}
+
+	broadcast, err := c.Admin.Broadcast(context.Background(), models.BroadcastRequest{Message: "maintenance in 5 minutes"})
+	if err != nil || broadcast.Target != "all" {
+		t.Fatalf("Broadcast: err=%v, result=%+v", err, broadcast)
+	}
+
+	duplicates, err := c.Admin.ListDuplicates(context.Background(), models.DuplicateListOpts{Type: "company"})
+	if err != nil || len(duplicates) != 1 || duplicates[0].Duplicate.ID != "acme-inc" {
+		t.Fatalf("ListDuplicates: err=%v, duplicates=%+v", err, duplicates)
+	}
+
+	blocks, err := c.Admin.SecurityBlocks(context.Background())
+	if err != nil || len(blocks) != 1 || blocks[0].Attempts != 5 {
+		t.Fatalf("SecurityBlocks: err=%v, blocks=%+v", err, blocks)
+	}
+
+	partitions, err := c.Admin.Partitions(context.Background())
+	if err != nil || len(partitions) != 1 || partitions[0].Partition != "kg_nodes_p0" || partitions[0].TotalBytes != 8192 {
+		t.Fatalf("Partitions: err=%v, partitions=%+v", err, partitions)
+	}
+
+	index, err := c.Admin.VectorIndex(context.Background())
+	if err != nil || !index.Exists || index.Params.M != 16 || index.EmbeddedNodes != 20000 || len(index.Warnings) != 1 {
+		t.Fatalf("VectorIndex: err=%v, index=%+v", err, index)
+	}
+
+	rebuild, err := c.Admin.RebuildVectorIndex(context.Background(), models.VectorIndexRequest{Method: "ivfflat"})
+	if err != nil || rebuild.Lists != 20 {
+		t.Fatalf("RebuildVectorIndex: err=%v, params=%+v", err, rebuild)
+	}
+
+	advice, err := c.Admin.IndexAdvice(context.Background(), models.IndexAdvisorRequest{MinCalls: 50, MinMeanMs: 2.5})
+	if err != nil || !advice.StatsAvailable || len(advice.Advice) != 1 || advice.Advice[0].Recommended.Name != "idx_nodes_tenant_type_props" {
+		t.Fatalf("IndexAdvice: err=%v, report=%+v", err, advice)
+	}
+
+	advised, err := c.Admin.CreateAdvisedIndexes(context.Background(), models.IndexAdvisorRequest{MinCalls: 50})
+	if err != nil || !advised.Building {
+		t.Fatalf("CreateAdvisedIndexes: err=%v, report=%+v", err, advised)
+	}
+
+	diff, err := c.Admin.DiffTenants(context.Background(), "t1", "t2")
+	if err != nil || diff.Stats.NodesAdded != 1 || diff.NodesAdded[0].ID != "bob" {
+		t.Fatalf("DiffTenants: err=%v, diff=%+v", err, diff)
+	}
+
+	retention, err := c.Admin.HistoryRetention(context.Background())
+	if err != nil || retention.RetentionDays == nil || *retention.RetentionDays != 365 || retention.CompactAfterDays != nil {
+		t.Fatalf("HistoryRetention: err=%v, retention=%+v", err, retention)
+	}
+
+	compactDays := 30
+	retention, err = c.Admin.SetHistoryRetention(context.Background(), models.HistoryRetention{CompactAfterDays: &compactDays})
+	if err != nil || retention.CompactAfterDays == nil || *retention.CompactAfterDays != 30 {
+		t.Fatalf("SetHistoryRetention: err=%v, retention=%+v", err, retention)
+	}
+
+	pruned, err := c.Admin.PruneHistory(context.Background())
+	if err != nil || pruned.Deleted != 12 || pruned.Compacted != 7 {
+		t.Fatalf("PruneHistory: err=%v, result=%+v", err, pruned)
+	}
+
+	below, days := 0.2, 90
+	policy, err := c.Admin.SetArchivePolicy(context.Background(), models.ArchivePolicy{SalienceBelow: &below, IdleDays: &days})
+	if err != nil || policy.SalienceBelow == nil || *policy.SalienceBelow != 0.2 {
+		t.Fatalf("SetArchivePolicy: err=%v, policy=%+v", err, policy)
+	}
+
+	archived, err := c.Admin.RunArchive(context.Background())
+	if err != nil || archived.Nodes != 8 || archived.Edges != 11 {
+		t.Fatalf("RunArchive: err=%v, result=%+v", err, archived)
+	}
+
+	archivedNodes, hasMore, err := c.Nodes.Archived(context.Background(), 10, 0)
+	if err != nil || hasMore || len(archivedNodes) != 1 || archivedNodes[0].ID != "old" {
+		t.Fatalf("Archived: err=%v, nodes=%+v", err, archivedNodes)
+	}
+
+	restored, err := c.Nodes.Restore(context.Background(), "old")
+	if err != nil || restored.Node.ID != "old" || restored.EdgesRestored != 2 {
+		t.Fatalf("Restore: err=%v, result=%+v", err, restored)
+	}
+
+	inferred, err := c.Admin.InferCoAccessRelations(context.Background(), models.CoAccessInferenceRequest{MinScore: 2})
+	if err != nil || inferred.Created != 3 || inferred.Removed != 2 {
+		t.Fatalf("InferCoAccessRelations: err=%v, result=%+v", err, inferred)
+	}
+}
+
+func TestHistoryList(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/history": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("since") != "2026-03-01T00:00:00Z" || q.Get("actor") != "ingest" || q.Get("property") != "status" {
+				t.Fatalf("query = %s", r.URL.RawQuery)
+			}
+			jsonResponse(w, 200, map[string]any{
+				"changes":  []map[string]any{{"id": 1, "node_id": "n1", "field": "property", "property_key": "status"}},
+				"has_more": true,
+			})
+		},
+	})
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changes, hasMore, err := c.History.List(context.Background(), &HistoryListOptions{Since: &since, Actor: "ingest", PropertyKey: "status"})
+	if err != nil || !hasMore || len(changes) != 1 || changes[0].NodeID != "n1" {
+		t.Fatalf("List: err=%v, has_more=%v, changes=%+v", err, hasMore, changes)
+	}
+}
+
+func TestExportStream(t *testing.T) {
+	truncate := false
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/export": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") != "ndjson" {
+				t.Fatalf("format = %q, want ndjson", r.URL.Query().Get("format"))
+			}
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			enc := json.NewEncoder(w)
+			enc.Encode(models.ExportRecord{Type: models.ExportRecordManifest, Manifest: &models.ExportManifest{TenantID: "t1"}}) //nolint:errcheck
+			enc.Encode(models.ExportRecord{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "a"}})                    //nolint:errcheck
+			if !truncate {
+				enc.Encode(models.ExportRecord{Type: models.ExportRecordEnd, Stats: &models.ExportStats{NodeCount: 1}}) //nolint:errcheck
+			}
+		},
+	})
+
+	var types []string
+	collect := func(rec *models.ExportRecord) error {
+		types = append(types, rec.Type)
+		return nil
+	}
+
+	if err := c.ExportStream(context.Background(), collect); err != nil {
+		t.Fatalf("ExportStream: %v", err)
+	}
+	if len(types) != 3 || types[1] != models.ExportRecordNode {
+		t.Errorf("record types = %v", types)
+	}
+
+	truncate = true
+	if err := c.ExportStream(context.Background(), collect); !errors.Is(err, models.ErrIncompleteExport) {
+		t.Errorf("truncated stream: err = %v, want ErrIncompleteExport", err)
+	}
+}
+
+func TestJSONLD(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/export": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") != "jsonld" {
+				t.Fatalf("format = %q, want jsonld", r.URL.Query().Get("format"))
+			}
+			w.Header().Set("Content-Type", "application/ld+json")
+			json.NewEncoder(w).Encode(models.JSONLDDocument{ //nolint:errcheck
+				Context: map[string]any{"@vocab": models.DefaultJSONLDVocab},
+				Graph:   []map[string]any{{"@id": "urn:persistor:node:a"}},
+			})
+		},
+		"PUT /api/v1/export/jsonld-context": func(w http.ResponseWriter, r *http.Request) {
+			var req models.JSONLDContext
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Types["person"] != "schema:Person" {
+				t.Fatalf("set body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, req.WithDefaults())
+		},
+		"DELETE /api/v1/export/jsonld-context": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, models.JSONLDContext{}.WithDefaults())
+		},
+	})
+	ctx := context.Background()
+
+	doc, err := c.ExportJSONLD(ctx)
+	if err != nil || len(doc.Graph) != 1 || doc.Context["@vocab"] != models.DefaultJSONLDVocab {
+		t.Fatalf("ExportJSONLD = %+v, %v", doc, err)
+	}
+
+	set, err := c.SetJSONLDContext(ctx, models.JSONLDContext{Types: map[string]string{"person": "schema:Person"}})
+	if err != nil || set.Base != models.DefaultJSONLDBase {
+		t.Fatalf("SetJSONLDContext = %+v, %v", set, err)
+	}
+
+	reset, err := c.ResetJSONLDContext(ctx)
+	if err != nil || reset.Vocab != models.DefaultJSONLDVocab {
+		t.Fatalf("ResetJSONLDContext = %+v, %v", reset, err)
+	}
+}
+
+func TestExportNeo4j(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/export": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("format") != "cypher" {
+				jsonResponse(w, 400, map[string]any{"error": map[string]any{"code": "validation_error", "message": "bad format"}})
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte("CREATE (:PersistorNode:Person {id: 'a'});\n")) //nolint:errcheck
+		},
+	})
+
+	var buf bytes.Buffer
+
+	n, err := c.ExportNeo4j(context.Background(), "cypher", &buf)
+	if err != nil || n != int64(buf.Len()) || !strings.HasPrefix(buf.String(), "CREATE (:PersistorNode") {
+		t.Fatalf("ExportNeo4j = %d, %v; body %q", n, err, buf.String())
+	}
+
+	if _, err := c.ExportNeo4j(context.Background(), "graphml", io.Discard); err == nil {
+		t.Error("ExportNeo4j with an unknown format: want error")
+	}
+}
+
+func TestImportSession(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/import/sessions": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CreateImportSessionRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.SchemaVersion != 7 || !req.Options.OverwriteExisting {
+				t.Fatalf("create body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, models.ImportSession{ID: "s1", Status: models.ImportSessionOpen})
+		},
+		"PUT /api/v1/import/sessions/s1/chunks/3": func(w http.ResponseWriter, r *http.Request) {
+			var chunk models.ImportChunk
+			if err := json.NewDecoder(r.Body).Decode(&chunk); err != nil || len(chunk.Nodes) != 1 {
+				t.Fatalf("chunk body: err=%v, chunk=%+v", err, chunk)
+			}
+			jsonResponse(w, 200, models.ImportChunkInfo{Seq: 3, Nodes: 1})
+		},
+		"POST /api/v1/import/sessions/s1/commit": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, models.ImportResult{NodesCreated: 1})
+		},
+		"DELETE /api/v1/import/sessions/s1": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	ctx := context.Background()
+
+	sess, err := c.CreateImportSession(ctx, 7, models.ImportOptions{OverwriteExisting: true})
+	if err != nil || sess.ID != "s1" {
+		t.Fatalf("CreateImportSession = %+v, %v", sess, err)
+	}
+
+	if err := c.PutImportChunk(ctx, "s1", 3, &models.ImportChunk{Nodes: []models.ExportNode{{ID: "a"}}}); err != nil {
+		t.Fatalf("PutImportChunk: %v", err)
+	}
+
+	result, err := c.CommitImportSession(ctx, "s1")
+	if err != nil || result.NodesCreated != 1 {
+		t.Fatalf("CommitImportSession = %+v, %v", result, err)
+	}
+
+	if err := c.DeleteImportSession(ctx, "s1"); err != nil {
+		t.Fatalf("DeleteImportSession: %v", err)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/keys": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]string{"scope": "admin"})
+		},
+		"POST /api/v1/keys/rotate": func(w http.ResponseWriter, r *http.Request) {
+			var req models.RotateAPIKeyRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GraceHours == nil || *req.GraceHours != 2 {
+				t.Fatalf("rotate body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, map[string]string{"api_key": "new-key", "scope": "admin", "previous_key_expires_at": "2026-01-01T02:00:00Z"})
+		},
+		"DELETE /api/v1/keys/previous": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]string{"scope": "admin"})
+		},
+		"POST /api/v1/keys/signing": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"signing_secret": "s3cret", "scope": "admin", "signing_required": true})
+		},
+		"DELETE /api/v1/keys/signing": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"scope": "admin", "signing_required": false})
+		},
+	})
+
+	status, err := c.Keys.Status(context.Background())
+	if err != nil || status.Scope != "admin" {
+		t.Fatalf("Status: err=%v, status=%+v", err, status)
+	}
+
+	grace := 2
+	rotation, err := c.Keys.Rotate(context.Background(), models.RotateAPIKeyRequest{GraceHours: &grace})
+	if err != nil || rotation.APIKey != "new-key" || rotation.PreviousKeyExpiresAt == nil {
+		t.Fatalf("Rotate: err=%v, rotation=%+v", err, rotation)
+	}
+
+	status, err = c.Keys.RevokePrevious(context.Background())
+	if err != nil || status.PreviousKeyExpiresAt != nil {
+		t.Fatalf("RevokePrevious: err=%v, status=%+v", err, status)
+	}
+
+	signing, err := c.Keys.EnableSigning(context.Background())
+	if err != nil || signing.SigningSecret != "s3cret" || !signing.SigningRequired {
+		t.Fatalf("EnableSigning: err=%v, signing=%+v", err, signing)
+	}
+
+	status, err = c.Keys.DisableSigning(context.Background())
+	if err != nil || status.SigningRequired {
+		t.Fatalf("DisableSigning: err=%v, status=%+v", err, status)
+	}
+}
+
+func TestManagedKeys(t *testing.T) {
+	const keyID = "6f1c3c4e-8a59-4e0c-9a57-0f4f2b1d7c11"
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/admin/keys": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CreateAPIKeyRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "ci" || req.Scope != "admin" {
+				t.Fatalf("create body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, map[string]string{"api_key": "k1", "id": keyID, "name": "ci", "scope": "admin"})
+		},
+		"GET /api/v1/admin/keys": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"keys": []map[string]string{{"id": keyID, "name": "ci"}}})
+		},
+		"POST /api/v1/admin/keys/" + keyID + "/rotate": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{
+				"api_key": "k2", "id": "new-id", "name": "ci",
+				"previous": map[string]string{"id": keyID, "expires_at": "2026-01-01T02:00:00Z"},
+			})
+		},
+		"DELETE /api/v1/admin/keys/" + keyID: func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]string{"id": keyID, "revoked_at": "2026-01-01T00:00:00Z"})
+		},
+	})
+	ctx := context.Background()
+
+	created, err := c.Keys.Create(ctx, models.CreateAPIKeyRequest{Name: "ci", Scope: "admin"})
+	if err != nil || created.APIKey != "k1" || created.ID != keyID {
+		t.Fatalf("Create: err=%v, created=%+v", err, created)
+	}
+
+	keys, err := c.Keys.List(ctx)
+	if err != nil || len(keys) != 1 || keys[0].Name != "ci" {
+		t.Fatalf("List: err=%v, keys=%+v", err, keys)
+	}
+
+	rotated, err := c.Keys.RotateKey(ctx, keyID, models.RotateAPIKeyRequest{})
+	if err != nil || rotated.APIKey != "k2" || rotated.Previous.ExpiresAt == nil {
+		t.Fatalf("RotateKey: err=%v, rotated=%+v", err, rotated)
+	}
+
+	revoked, err := c.Keys.Revoke(ctx, keyID)
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("Revoke: err=%v, revoked=%+v", err, revoked)
+	}
+}
+
+func TestAdminQuarantine(t *testing.T) {
+	released := ""
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"GET /api/v1/admin/quarantine": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") != "5" {
+				t.Errorf("limit = %q, want 5", r.URL.Query().Get("limit"))
+			}
+			jsonResponse(w, 200, map[string]any{"nodes": []models.QuarantinedNode{{NodeID: "bad", Pattern: "ignore_instructions"}}})
+		},
+		"DELETE /api/v1/admin/quarantine/{id}": func(w http.ResponseWriter, r *http.Request) {
+			released = r.PathValue("id")
+			w.WriteHeader(http.StatusNoContent)
+		},
+	})
+	ctx := context.Background()
+
+	nodes, err := c.Admin.ListQuarantined(ctx, 5)
+	if err != nil || len(nodes) != 1 || nodes[0].NodeID != "bad" {
+		t.Fatalf("ListQuarantined = %+v, %v", nodes, err)
+	}
+
+	if err := c.Admin.ReleaseQuarantined(ctx, "bad"); err != nil || released != "bad" {
+		t.Errorf("ReleaseQuarantined: err = %v, released %q", err, released)
+	}
+}
+
+func TestRelationCatalog(t *testing.T) {
+	var stored models.RelationCatalog
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"PUT /api/v1/admin/relations/catalog": func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+				t.Fatalf("decoding catalog: %v", err)
+			}
+			jsonResponse(w, 200, stored)
+		},
+		"GET /api/v1/admin/relations/catalog": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, stored)
+		},
+		"GET /api/v1/relations/usage": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"relations": []models.RelationUsage{
+				{SourceType: "person", Relation: "works_at", TargetType: "org", Count: 4, Declared: true},
+			}})
+		},
+	})
+	ctx := context.Background()
+
+	want := models.RelationCatalog{Enforce: true, Rules: []models.RelationRule{
+		{SourceType: "person", Relation: "works_at", TargetType: "org"},
+	}}
+	if _, err := c.Admin.SetRelationCatalog(ctx, want); err != nil || !stored.Enforce || len(stored.Rules) != 1 {
+		t.Fatalf("SetRelationCatalog: err = %v, stored %+v", err, stored)
+	}
+
+	got, err := c.Admin.RelationCatalog(ctx)
+	if err != nil || !got.Enforce || got.Rules[0] != want.Rules[0] {
+		t.Errorf("RelationCatalog = %+v, %v", got, err)
+	}
+
+	usage, err := c.Edges.RelationUsage(ctx)
+	if err != nil || len(usage) != 1 || usage[0].Count != 4 || !usage[0].Declared {
+		t.Errorf("RelationUsage = %+v, %v", usage, err)
+	}
+}
+
+func TestAdminTenants(t *testing.T) {
+	const tenantID = "4b8e2f6a-9c1d-4e3b-a5f7-2d6c8e0a1b3f"
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/admin/tenants": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CreateTenantRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "acme" || req.Plan != "pro" {
+				t.Fatalf("create body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, map[string]string{"api_key": "k1", "id": tenantID, "name": "acme", "plan": "pro"})
+		},
+		"GET /api/v1/admin/tenants": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"tenants": []map[string]string{{"id": tenantID, "name": "acme"}}})
+		},
+		"PATCH /api/v1/admin/tenants/" + tenantID: func(w http.ResponseWriter, r *http.Request) {
+			var req models.UpdateTenantRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != nil || req.Plan == nil || *req.Plan != "team" {
+				t.Fatalf("update body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, map[string]string{"id": tenantID, "plan": "team"})
+		},
+		"POST /api/v1/admin/tenants/" + tenantID + "/rotate-key": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]string{"api_key": "k2", "scope": "admin"})
+		},
+		"POST /api/v1/admin/tenants/" + tenantID + "/suspend": func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]string{"id": tenantID, "suspended_at": "2026-01-01T00:00:00Z"})
+		},
+		"DELETE /api/v1/admin/tenants/" + tenantID: func(w http.ResponseWriter, _ *http.Request) {
+			jsonResponse(w, 200, map[string]any{"tenant_id": tenantID, "deleted_rows": 12})
+		},
+		"POST /api/v1/admin/tenants/" + tenantID + "/impersonate": func(w http.ResponseWriter, r *http.Request) {
+			var req models.ImpersonateTenantRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Reason != "ticket 42" {
+				t.Fatalf("impersonate body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, map[string]string{"api_key": "k3", "scope": "read", "tenant_id": tenantID, "reason": req.Reason})
+		},
+	})
+	ctx := context.Background()
+
+	created, err := c.Admin.Tenants.Create(ctx, models.CreateTenantRequest{Name: "acme", Plan: "pro"})
+	if err != nil || created.APIKey != "k1" || created.ID != tenantID {
+		t.Fatalf("Create: err=%v, created=%+v", err, created)
+	}
+
+	tenants, err := c.Admin.Tenants.List(ctx)
+	if err != nil || len(tenants) != 1 || tenants[0].Name != "acme" {
+		t.Fatalf("List: err=%v, tenants=%+v", err, tenants)
+	}
+
+	plan := "team"
+	updated, err := c.Admin.Tenants.Update(ctx, tenantID, models.UpdateTenantRequest{Plan: &plan})
+	if err != nil || updated.Plan != "team" {
+		t.Fatalf("Update: err=%v, updated=%+v", err, updated)
+	}
+
+	rotation, err := c.Admin.Tenants.RotateKey(ctx, tenantID, models.RotateAPIKeyRequest{})
+	if err != nil || rotation.APIKey != "k2" {
+		t.Fatalf("RotateKey: err=%v, rotation=%+v", err, rotation)
+	}
+
+	suspended, err := c.Admin.Tenants.Suspend(ctx, tenantID)
+	if err != nil || suspended.SuspendedAt == nil {
+		t.Fatalf("Suspend: err=%v, suspended=%+v", err, suspended)
+	}
+
+	purged, err := c.Admin.Tenants.Delete(ctx, tenantID)
+	if err != nil || purged.DeletedRows != 12 {
+		t.Fatalf("Delete: err=%v, purged=%+v", err, purged)
+	}
+
+	impersonation, err := c.Admin.Tenants.Impersonate(ctx, tenantID, models.ImpersonateTenantRequest{Reason: "ticket 42"})
+	if err != nil || impersonation.APIKey != "k3" || impersonation.TenantID != tenantID {
+		t.Fatalf("Impersonate: err=%v, impersonation=%+v", err, impersonation)
+	}
+}
+
+func TestBranches(t *testing.T) {
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"POST /api/v1/branches": func(w http.ResponseWriter, r *http.Request) {
+			var req models.CreateBranchRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "what-if" {
+				t.Fatalf("create body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, models.Branch{ID: "b1", Name: req.Name})
+		},
+		"POST /api/v1/branches/b1/nodes": func(w http.ResponseWriter, r *http.Request) {
+			var req CreateNodeRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID != "n1" {
+				t.Fatalf("create node body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 201, Node{ID: req.ID, Type: req.Type, Label: req.Label})
+		},
+		"DELETE /api/v1/branches/b1/edges/a/b/knows": func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+		"POST /api/v1/branches/b1/merge": func(w http.ResponseWriter, r *http.Request) {
+			var req models.MergeBranchRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Force {
+				t.Fatalf("merge body: err=%v, req=%+v", err, req)
+			}
+			jsonResponse(w, 200, models.MergeBranchResult{Merged: true, NodesWritten: 1})
+		},
+	})
+	ctx := context.Background()
+
+	b, err := c.Branches.Create(ctx, "what-if")
+	if err != nil || b.ID != "b1" {
+		t.Fatalf("Create = %+v, %v", b, err)
+	}
+
+	node, err := c.Branches.CreateNode(ctx, "b1", &CreateNodeRequest{ID: "n1", Type: "person", Label: "Ada"})
+	if err != nil || node.Label != "Ada" {
+		t.Fatalf("CreateNode = %+v, %v", node, err)
+	}
+
+	if err := c.Branches.DeleteEdge(ctx, "b1", "a", "b", "knows"); err != nil {
+		t.Fatalf("DeleteEdge: %v", err)
+	}
+
+	result, err := c.Branches.Merge(ctx, "b1", true)
+	if err != nil || !result.Merged || result.NodesWritten != 1 {
+		t.Fatalf("Merge = %+v, %v", result, err)
+	}
+}
+
+func TestRequestSigning(t *testing.T) {
+	secret := []byte("signing-secret")
+	var verified bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(security.SignatureTimestampHeader), 10, 64)
+		verified = security.VerifyRequestSignature(secret, r.Header.Get(security.SignatureHeader), ts,
+			r.Header.Get(security.SignatureNonceHeader), r.Method, r.URL.RequestURI(), body)
+		jsonResponse(w, 201, Node{ID: "n1"})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c := New(srv.URL, WithAPIKey("test-key"), WithRequestSigning(string(secret)))
+	if _, err := c.Nodes.Create(context.Background(), &CreateNodeRequest{Type: "person", Label: "Alice"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !verified {
+		t.Error("server could not verify the request signature")
+	}
 }
 
 func TestAPIError(t *testing.T) {
@@ -3,8 +3,11 @@
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // EdgeService handles edge CRUD operations.
@@ -14,20 +17,78 @@ type EdgeService struct {
 
 // edgeListResponse wraps the paginated edge list response.
 type edgeListResponse struct {
-	Edges   []Edge `json:"edges"`
-	HasMore bool   `json:"has_more"`
+	Edges      []Edge `json:"edges"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
 }
 
 // List returns edges with optional filtering and pagination.
 func (s *EdgeService) List(ctx context.Context, opts *EdgeListOptions) ([]Edge, bool, error) {
-	params := edgeListParams(opts)
-	var resp edgeListResponse
-	if err := s.c.get(ctx, "/api/v1/edges", params, &resp); err != nil {
+	resp, err := s.list(ctx, opts)
+	if err != nil {
 		return nil, false, err
 	}
 	return resp.Edges, resp.HasMore, nil
 }
 
+// ListPage returns one page of edges and the cursor for the next page, which
+// is empty on the last page. Pass it as EdgeListOptions.After to continue.
+func (s *EdgeService) ListPage(ctx context.Context, opts *EdgeListOptions) ([]Edge, string, error) {
+	resp, err := s.list(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Edges, resp.NextCursor, nil
+}
+
+func (s *EdgeService) list(ctx context.Context, opts *EdgeListOptions) (*edgeListResponse, error) {
+	var resp edgeListResponse
+	if err := s.c.get(ctx, "/api/v1/edges", edgeListParams(opts), &resp); err != nil {
+		return nil, err
+	}
+	for i := range resp.Edges {
+		s.c.decodeEdges(&resp.Edges[i])
+	}
+	return &resp, nil
+}
+
+// Iter iterates over every edge matching opts, following cursors page by
+// page. Offset and After in opts are ignored. Iteration stops at the first
+// error, which is yielded with a zero Edge.
+func (s *EdgeService) Iter(ctx context.Context, opts *EdgeListOptions) iter.Seq2[Edge, error] {
+	return func(yield func(Edge, error) bool) {
+		var page EdgeListOptions
+		if opts != nil {
+			page = *opts
+		}
+		page.Offset, page.After = 0, ""
+
+		for {
+			edges, next, err := s.ListPage(ctx, &page)
+			if err != nil {
+				yield(Edge{}, err)
+				return
+			}
+			for _, e := range edges {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			page.After = next
+		}
+	}
+}
+
+// ListAll iterates over every edge matching opts.
+//
+// Deprecated: use Iter.
+func (s *EdgeService) ListAll(ctx context.Context, opts *EdgeListOptions) iter.Seq2[Edge, error] {
+	return s.Iter(ctx, opts)
+}
+
 // edgeListParams converts EdgeListOptions into URL query parameters.
 func edgeListParams(opts *EdgeListOptions) url.Values {
 	params := url.Values{}
@@ -49,6 +110,9 @@ func edgeListParams(opts *EdgeListOptions) url.Values {
 	if opts.Offset > 0 {
 		params.Set("offset", strconv.Itoa(opts.Offset))
 	}
+	if opts.After != "" {
+		params.Set("after", opts.After)
+	}
 	if opts.ActiveOn != nil {
 		params.Set("active_on", opts.ActiveOn.Format("2006-01-02"))
 	}
@@ -64,21 +128,45 @@ func edgeListParams(opts *EdgeListOptions) url.Values {
 
 // Create creates a new edge.
 func (s *EdgeService) Create(ctx context.Context, req *CreateEdgeRequest) (*Edge, error) {
+	if s.c.typedProperties && req != nil {
+		encoded := *req
+		encoded.Properties = s.c.encodeProperties(req.Properties)
+		req = &encoded
+	}
 	var edge Edge
 	if err := s.c.post(ctx, "/api/v1/edges", req, &edge); err != nil {
 		return nil, err
 	}
+	s.c.decodeEdges(&edge)
 	return &edge, nil
 }
 
+// Exists reports, in the order of keys, whether each edge exists. At most
+// models.MaxEdgeExistsKeys keys can be checked in one call.
+func (s *EdgeService) Exists(ctx context.Context, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+	var resp struct {
+		Edges []models.EdgeExistence `json:"edges"`
+	}
+	if err := s.c.post(ctx, "/api/v1/edges/exists", models.EdgeExistsRequest{Edges: keys}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Edges, nil
+}
+
 // Update updates an existing edge by source/target/relation.
 func (s *EdgeService) Update(ctx context.Context, source, target, relation string, req *UpdateEdgeRequest) (*Edge, error) {
 	path := fmt.Sprintf("/api/v1/edges/%s/%s/%s",
 		url.PathEscape(source), url.PathEscape(target), url.PathEscape(relation))
+	if s.c.typedProperties && req != nil {
+		encoded := *req
+		encoded.Properties = s.c.encodeProperties(req.Properties)
+		req = &encoded
+	}
 	var edge Edge
 	if err := s.c.put(ctx, path, req, &edge); err != nil {
 		return nil, err
 	}
+	s.c.decodeEdges(&edge)
 	return &edge, nil
 }
 
@@ -87,13 +175,38 @@ func (s *EdgeService) PatchProperties(ctx context.Context, source, target, relat
 	path := fmt.Sprintf("/api/v1/edges/%s/%s/%s/properties",
 		url.PathEscape(source), url.PathEscape(target), url.PathEscape(relation))
 	var edge Edge
-	req := &PatchPropertiesRequest{Properties: properties}
+	req := &PatchPropertiesRequest{Properties: s.c.encodeProperties(properties)}
 	if err := s.c.patch(ctx, path, req, &edge); err != nil {
 		return nil, err
 	}
+	s.c.decodeEdges(&edge)
 	return &edge, nil
 }
 
+// History returns property change history for an edge.
+func (s *EdgeService) History(ctx context.Context, source, target, relation, property string, limit, offset int) ([]EdgePropertyChange, bool, error) {
+	params := url.Values{}
+	if property != "" {
+		params.Set("property", property)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	path := fmt.Sprintf("/api/v1/edges/%s/%s/%s/history",
+		url.PathEscape(source), url.PathEscape(target), url.PathEscape(relation))
+	var resp struct {
+		Changes []EdgePropertyChange `json:"changes"`
+		HasMore bool                 `json:"has_more"`
+	}
+	if err := s.c.get(ctx, path, params, &resp); err != nil {
+		return nil, false, err
+	}
+	return resp.Changes, resp.HasMore, nil
+}
+
 // Delete removes an edge by source/target/relation.
 func (s *EdgeService) Delete(ctx context.Context, source, target, relation string) error {
 	path := fmt.Sprintf("/api/v1/edges/%s/%s/%s",
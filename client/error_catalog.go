@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ErrorCatalog lists every API error code with its HTTP status, message,
+// and documentation link, in the language set with WithLanguage.
+func (c *Client) ErrorCatalog(ctx context.Context) (*models.ErrorCatalog, error) {
+	var resp models.ErrorCatalog
+	if err := c.get(ctx, "/api/v1/errors", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -2,14 +2,29 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 )
 
+// ErrRateLimited matches, with errors.Is, every error caused by a 429
+// response. Use errors.As with *RateLimitError to read how long to wait.
+var ErrRateLimited = errors.New("persistor: rate limited")
+
+// ErrQuotaExceeded matches, with errors.Is, every error caused by a write
+// that would take the tenant past one of its quotas, or a model call once
+// its monthly token budget is used up. See Client.Usage.
+var ErrQuotaExceeded = errors.New("persistor: quota exceeded")
+
 // APIError represents a structured error response from the Persistor API.
+// With WithLanguage, Message is translated and Detail holds the server's
+// original English message.
 type APIError struct {
 	StatusCode int    `json:"-"`
 	Code       string `json:"code"`
 	Message    string `json:"message"`
+	Detail     string `json:"detail,omitempty"`
+	DocsURL    string `json:"docs_url,omitempty"`
 	RequestID  string `json:"request_id,omitempty"`
 }
 
@@ -21,6 +36,12 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("persistor: %d %s: %s", e.StatusCode, e.Code, e.Message)
 }
 
+// Is reports whether target is ErrQuotaExceeded and the server answered
+// with the quota_exceeded error code.
+func (e *APIError) Is(target error) bool {
+	return target == ErrQuotaExceeded && e.Code == "quota_exceeded"
+}
+
 // IsNotFound returns true if the error is a 404 not found.
 func IsNotFound(err error) bool {
 	if e, ok := err.(*APIError); ok {
@@ -29,7 +50,8 @@ func IsNotFound(err error) bool {
 	return false
 }
 
-// IsConflict returns true if the error is a 409 conflict (duplicate key).
+// IsConflict returns true if the error is a 409 conflict, such as a duplicate
+// key or deleting a node that still has edges.
 func IsConflict(err error) bool {
 	if e, ok := err.(*APIError); ok {
 		return e.StatusCode == 409
@@ -37,12 +59,22 @@ func IsConflict(err error) bool {
 	return false
 }
 
+// RateLimitError is returned for a 429 response. RetryAfter is how long the
+// server asked the client to wait, or zero if it did not say.
+type RateLimitError struct {
+	*APIError
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying API error.
+func (e *RateLimitError) Unwrap() error { return e.APIError }
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
 // IsRateLimited returns true if the error is a 429 rate limit.
 func IsRateLimited(err error) bool {
-	if e, ok := err.(*APIError); ok {
-		return e.StatusCode == 429
-	}
-	return false
+	return errors.Is(err, ErrRateLimited)
 }
 
 // parseAPIError attempts to decode a JSON error body; falls back to raw text.
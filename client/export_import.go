@@ -2,7 +2,13 @@
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/persistorai/persistor/internal/models"
 )
@@ -17,6 +23,50 @@ func (c *Client) Export(ctx context.Context) (*models.ExportFormat, error) {
 	return &result, nil
 }
 
+// ExportStream downloads a streaming (NDJSON) export, passing each record to
+// fn as it arrives so the export never has to fit in memory. It returns
+// models.ErrIncompleteExport if the stream ends before its end record. The
+// client timeout does not apply to the download; cancel ctx to stop it.
+func (c *Client) ExportStream(ctx context.Context, fn func(*models.ExportRecord) error) error {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v1/export?format=ndjson", nil)
+	if err != nil {
+		return fmt.Errorf("export stream: %w", err)
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("export stream: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort error body.
+		return fmt.Errorf("export stream: %w", parseAPIError(resp.StatusCode, body))
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var rec models.ExportRecord
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("export stream: %w", models.ErrIncompleteExport)
+			}
+			return fmt.Errorf("export stream: %w: %w", models.ErrIncompleteExport, err)
+		}
+
+		if err := fn(&rec); err != nil {
+			return err
+		}
+
+		if rec.Type == models.ExportRecordEnd {
+			return nil
+		}
+	}
+}
+
 // Import writes an export payload into the knowledge graph.
 func (c *Client) Import(ctx context.Context, data *models.ExportFormat, opts models.ImportOptions) (*models.ImportResult, error) {
 	query := ""
@@ -62,3 +112,59 @@ func (c *Client) ValidateImport(ctx context.Context, data *models.ExportFormat)
 
 	return result.Errors, nil
 }
+
+// ImportConflicts returns the stored versions of the payload's nodes and edges
+// that already exist.
+func (c *Client) ImportConflicts(ctx context.Context, data *models.ExportFormat) (*models.ImportConflicts, error) {
+	var result models.ImportConflicts
+	if err := c.post(ctx, "/api/v1/import/conflicts", data, &result); err != nil {
+		return nil, fmt.Errorf("import conflicts: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ExportManifest returns the header and record counts for a chunked export.
+func (c *Client) ExportManifest(ctx context.Context) (*models.ExportManifest, error) {
+	var result models.ExportManifest
+	if err := c.get(ctx, "/api/v1/export/manifest", nil, &result); err != nil {
+		return nil, fmt.Errorf("export manifest: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ExportNodes returns one page of a chunked node export. Pass an empty cursor
+// for the first page and the returned NextCursor for each following page.
+func (c *Client) ExportNodes(ctx context.Context, cursor string, limit int) (*models.ExportNodePage, error) {
+	var result models.ExportNodePage
+	if err := c.get(ctx, "/api/v1/export/nodes", exportPageParams(cursor, limit), &result); err != nil {
+		return nil, fmt.Errorf("export nodes: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ExportEdges returns one page of a chunked edge export. Pass an empty cursor
+// for the first page and the returned NextCursor for each following page.
+func (c *Client) ExportEdges(ctx context.Context, cursor string, limit int) (*models.ExportEdgePage, error) {
+	var result models.ExportEdgePage
+	if err := c.get(ctx, "/api/v1/export/edges", exportPageParams(cursor, limit), &result); err != nil {
+		return nil, fmt.Errorf("export edges: %w", err)
+	}
+
+	return &result, nil
+}
+
+func exportPageParams(cursor string, limit int) url.Values {
+	params := url.Values{}
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	return params
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ExportJSONLD retrieves the knowledge graph as a JSON-LD document, mapped to
+// IRIs with the tenant's JSON-LD context.
+func (c *Client) ExportJSONLD(ctx context.Context) (*models.JSONLDDocument, error) {
+	var result models.JSONLDDocument
+	if err := c.get(ctx, "/api/v1/export", url.Values{"format": {"jsonld"}}, &result); err != nil {
+		return nil, fmt.Errorf("export JSON-LD: %w", err)
+	}
+
+	return &result, nil
+}
+
+// JSONLDContext returns the context JSON-LD exports use.
+func (c *Client) JSONLDContext(ctx context.Context) (*models.JSONLDContext, error) {
+	var result models.JSONLDContext
+	if err := c.get(ctx, "/api/v1/export/jsonld-context", nil, &result); err != nil {
+		return nil, fmt.Errorf("get JSON-LD context: %w", err)
+	}
+
+	return &result, nil
+}
+
+// SetJSONLDContext replaces the tenant's JSON-LD context and returns it with
+// defaults filled in.
+func (c *Client) SetJSONLDContext(ctx context.Context, jsonldCtx models.JSONLDContext) (*models.JSONLDContext, error) {
+	var result models.JSONLDContext
+	if err := c.put(ctx, "/api/v1/export/jsonld-context", jsonldCtx, &result); err != nil {
+		return nil, fmt.Errorf("set JSON-LD context: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ResetJSONLDContext removes the tenant's JSON-LD context so exports use the
+// default vocabulary, and returns the default.
+func (c *Client) ResetJSONLDContext(ctx context.Context) (*models.JSONLDContext, error) {
+	var result models.JSONLDContext
+	if err := c.del(ctx, "/api/v1/export/jsonld-context", nil, &result); err != nil {
+		return nil, fmt.Errorf("reset JSON-LD context: %w", err)
+	}
+
+	return &result, nil
+}
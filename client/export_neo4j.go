@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ExportNeo4j downloads the knowledge graph in a Neo4j format, "cypher" or
+// "neo4j-csv", copying the response to w as it arrives. Like ExportStream it
+// is not bound by the client timeout. The copy is not checked for
+// completeness: a Cypher script ends with an "// End of export:" comment and
+// a neo4j-csv zip archive is unreadable if cut short.
+func (c *Client) ExportNeo4j(ctx context.Context, format string, w io.Writer) (int64, error) {
+	path := "/api/v1/export?" + url.Values{"format": {format}}.Encode()
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("export Neo4j: %w", err)
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("export Neo4j: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body) //nolint:errcheck // best-effort error body.
+		return 0, fmt.Errorf("export Neo4j: %w", parseAPIError(resp.StatusCode, body))
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("export Neo4j: %w", err)
+	}
+
+	return n, nil
+}
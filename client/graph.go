@@ -5,6 +5,9 @@
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // GraphService handles graph traversal operations.
@@ -58,3 +61,134 @@ func (s *GraphService) ShortestPath(ctx context.Context, fromID, toID string) ([
 	}
 	return resp.Path, nil
 }
+
+// Summary returns a compact neighborhood summary suitable for prompt injection.
+// limit caps the number of top neighbors returned (0 uses the server default).
+func (s *GraphService) Summary(ctx context.Context, id string, limit int) (*models.GraphSummary, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var resp models.GraphSummary
+	if err := s.c.get(ctx, "/api/v1/graph/summary/"+url.PathEscape(id), params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Subgraph returns the induced subgraph over ids, optionally expanded by one hop.
+func (s *GraphService) Subgraph(ctx context.Context, ids []string, expand bool) (*models.SubgraphResult, error) {
+	req := models.SubgraphRequest{NodeIDs: ids, Expand: expand}
+	var resp models.SubgraphResult
+	if err := s.c.post(ctx, "/api/v1/graph/subgraph", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Communities clusters the tenant graph and returns communities of node IDs.
+func (s *GraphService) Communities(ctx context.Context, req models.CommunityRequest) (*models.CommunityResult, error) {
+	var resp models.CommunityResult
+	if err := s.c.post(ctx, "/api/v1/graph/communities", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AsOf returns a page of the graph as it was at q.Timestamp, with node
+// properties rolled back using the property history.
+func (s *GraphService) AsOf(ctx context.Context, q models.AsOfQuery) (*models.GraphSnapshot, error) {
+	params := url.Values{}
+	params.Set("timestamp", q.Timestamp.UTC().Format(time.RFC3339))
+	if q.Type != "" {
+		params.Set("type", q.Type)
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		params.Set("offset", strconv.Itoa(q.Offset))
+	}
+	var resp models.GraphSnapshot
+	if err := s.c.get(ctx, "/api/v1/graph/asof", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PutMetapath creates the named metapath, a multi-hop relation path
+// template, or replaces its definition.
+func (s *GraphService) PutMetapath(ctx context.Context, name string, req models.PutMetapathRequest) (*models.Metapath, error) {
+	var resp models.Metapath
+	if err := s.c.put(ctx, "/api/v1/metapaths/"+url.PathEscape(name), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Metapaths lists the tenant's metapaths.
+func (s *GraphService) Metapaths(ctx context.Context) ([]models.Metapath, error) {
+	var resp struct {
+		Metapaths []models.Metapath `json:"metapaths"`
+	}
+	if err := s.c.get(ctx, "/api/v1/metapaths", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Metapaths, nil
+}
+
+// DeleteMetapath deletes the named metapath.
+func (s *GraphService) DeleteMetapath(ctx context.Context, name string) error {
+	return s.c.del(ctx, "/api/v1/metapaths/"+url.PathEscape(name), nil, nil)
+}
+
+// RunMetapath follows the named metapath from the start node and returns up
+// to limit paths.
+func (s *GraphService) RunMetapath(ctx context.Context, name, startID string, limit int) (*models.MetapathResult, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var resp models.MetapathResult
+	path := "/api/v1/graph/metapath/" + url.PathEscape(name) + "/" + url.PathEscape(startID)
+	if err := s.c.get(ctx, path, params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateSnapshot records a named snapshot of the graph's counts and content
+// hashes as it is now.
+func (s *GraphService) CreateSnapshot(ctx context.Context, req models.CreateSnapshotRequest) (*models.Snapshot, error) {
+	var resp models.Snapshot
+	if err := s.c.post(ctx, "/api/v1/snapshots", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Snapshots lists the tenant's snapshots, newest first.
+func (s *GraphService) Snapshots(ctx context.Context) ([]models.Snapshot, error) {
+	var resp struct {
+		Snapshots []models.Snapshot `json:"snapshots"`
+	}
+	if err := s.c.get(ctx, "/api/v1/snapshots", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
+// DeleteSnapshot deletes the named snapshot.
+func (s *GraphService) DeleteSnapshot(ctx context.Context, name string) error {
+	return s.c.del(ctx, "/api/v1/snapshots/"+url.PathEscape(name), nil, nil)
+}
+
+// CompareSnapshots reports the drift from snapshot from to snapshot to.
+func (s *GraphService) CompareSnapshots(ctx context.Context, from, to string) (*models.SnapshotComparison, error) {
+	var resp models.SnapshotComparison
+	path := "/api/v1/snapshots/" + url.PathEscape(from) + "/compare/" + url.PathEscape(to)
+	if err := s.c.get(ctx, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CreateImportSession opens a chunked import session. Nothing is written to
+// the graph until CommitImportSession is called.
+func (c *Client) CreateImportSession(
+	ctx context.Context, schemaVersion int, opts models.ImportOptions,
+) (*models.ImportSession, error) {
+	req := models.CreateImportSessionRequest{SchemaVersion: schemaVersion, Options: opts}
+
+	var result models.ImportSession
+	if err := c.post(ctx, "/api/v1/import/sessions", req, &result); err != nil {
+		return nil, fmt.Errorf("create import session: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ImportSession returns an import session and the chunks it has received,
+// which is what an interrupted upload needs to resume.
+func (c *Client) ImportSession(ctx context.Context, id string) (*models.ImportSession, error) {
+	var result models.ImportSession
+	if err := c.get(ctx, importSessionPath(id), nil, &result); err != nil {
+		return nil, fmt.Errorf("get import session: %w", err)
+	}
+
+	return &result, nil
+}
+
+// PutImportChunk uploads chunk seq of a session. Re-uploading a seq replaces it.
+func (c *Client) PutImportChunk(ctx context.Context, id string, seq int, chunk *models.ImportChunk) error {
+	path := importSessionPath(id) + "/chunks/" + strconv.Itoa(seq)
+	if err := c.put(ctx, path, chunk, nil); err != nil {
+		return fmt.Errorf("put import chunk %d: %w", seq, err)
+	}
+
+	return nil
+}
+
+// CommitImportSession applies every chunk of a session in one transaction.
+// Validation failures are returned in the result's Errors and leave the
+// session open. The client timeout does not apply; cancel ctx to stop waiting.
+func (c *Client) CommitImportSession(ctx context.Context, id string) (*models.ImportResult, error) {
+	var result models.ImportResult
+	if err := c.doUntimed(ctx, http.MethodPost, importSessionPath(id)+"/commit", nil, &result); err != nil {
+		return nil, fmt.Errorf("commit import session: %w", err)
+	}
+
+	return &result, nil
+}
+
+// DeleteImportSession aborts a session and discards its chunks.
+func (c *Client) DeleteImportSession(ctx context.Context, id string) error {
+	if err := c.del(ctx, importSessionPath(id), nil, nil); err != nil {
+		return fmt.Errorf("delete import session: %w", err)
+	}
+
+	return nil
+}
+
+func importSessionPath(id string) string {
+	return "/api/v1/import/sessions/" + url.PathEscape(id)
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// KeyService handles tenant API key management. All methods require an
+// admin-scoped key.
+type KeyService struct {
+	c *Client
+}
+
+// Status returns the key scope and rotation state without revealing the key.
+func (s *KeyService) Status(ctx context.Context) (*models.APIKeyStatus, error) {
+	var resp models.APIKeyStatus
+	if err := s.c.get(ctx, "/api/v1/keys", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Rotate generates a new API key. The current key keeps working for the
+// requested grace period. The returned key cannot be retrieved again.
+func (s *KeyService) Rotate(ctx context.Context, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error) {
+	var resp models.APIKeyRotation
+	if err := s.c.post(ctx, "/api/v1/keys/rotate", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RevokePrevious stops accepting a rotated-out key before its grace period ends.
+func (s *KeyService) RevokePrevious(ctx context.Context) (*models.APIKeyStatus, error) {
+	var resp models.APIKeyStatus
+	if err := s.c.del(ctx, "/api/v1/keys/previous", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// EnableSigning generates a request signing secret, replacing any existing
+// one. Every later request must be signed with it; configure the client with
+// WithRequestSigning. The returned secret cannot be retrieved again.
+func (s *KeyService) EnableSigning(ctx context.Context) (*models.RequestSigningSecret, error) {
+	var resp models.RequestSigningSecret
+	if err := s.c.post(ctx, "/api/v1/keys/signing", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DisableSigning stops requiring signed requests.
+func (s *KeyService) DisableSigning(ctx context.Context) (*models.APIKeyStatus, error) {
+	var resp models.APIKeyStatus
+	if err := s.c.del(ctx, "/api/v1/keys/signing", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Create generates a named API key alongside the tenant's primary key. The
+// returned key cannot be retrieved again.
+func (s *KeyService) Create(ctx context.Context, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	var resp models.CreatedAPIKey
+	if err := s.c.post(ctx, "/api/v1/admin/keys", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns the tenant's named API keys, newest first, including revoked
+// and expired ones. Keys are never revealed.
+func (s *KeyService) List(ctx context.Context) ([]models.ManagedAPIKey, error) {
+	var resp struct {
+		Keys []models.ManagedAPIKey `json:"keys"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/keys", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// Revoke stops accepting a named API key.
+func (s *KeyService) Revoke(ctx context.Context, id string) (*models.ManagedAPIKey, error) {
+	var resp models.ManagedAPIKey
+	if err := s.c.del(ctx, managedKeyPath(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RotateKey replaces a named API key with a new one of the same name, scope,
+// and expiry. The old key keeps working for the requested grace period. The
+// returned key cannot be retrieved again.
+func (s *KeyService) RotateKey(ctx context.Context, id string, req models.RotateAPIKeyRequest) (*models.RotatedAPIKey, error) {
+	var resp models.RotatedAPIKey
+	if err := s.c.post(ctx, managedKeyPath(id)+"/rotate", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func managedKeyPath(id string) string {
+	return "/api/v1/admin/keys/" + url.PathEscape(id)
+}
@@ -3,8 +3,11 @@
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // NodeService handles node CRUD operations.
@@ -14,12 +17,31 @@ type NodeService struct {
 
 // nodeListResponse wraps the paginated node list response.
 type nodeListResponse struct {
-	Nodes   []Node `json:"nodes"`
-	HasMore bool   `json:"has_more"`
+	Nodes      []Node `json:"nodes"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
 }
 
 // List returns nodes with optional filtering and pagination.
 func (s *NodeService) List(ctx context.Context, opts *NodeListOptions) ([]Node, bool, error) {
+	resp, err := s.list(ctx, opts)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Nodes, resp.HasMore, nil
+}
+
+// ListPage returns one page of nodes and the cursor for the next page, which
+// is empty on the last page. Pass it as NodeListOptions.After to continue.
+func (s *NodeService) ListPage(ctx context.Context, opts *NodeListOptions) ([]Node, string, error) {
+	resp, err := s.list(ctx, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Nodes, resp.NextCursor, nil
+}
+
+func (s *NodeService) list(ctx context.Context, opts *NodeListOptions) (*nodeListResponse, error) {
 	params := url.Values{}
 	if opts != nil {
 		if opts.Type != "" {
@@ -34,12 +56,61 @@ func (s *NodeService) List(ctx context.Context, opts *NodeListOptions) ([]Node,
 		if opts.Offset > 0 {
 			params.Set("offset", strconv.Itoa(opts.Offset))
 		}
+		if opts.After != "" {
+			params.Set("after", opts.After)
+		}
+		if opts.OmitProperties {
+			params.Set("include_properties", "false")
+		}
+		for _, p := range opts.Props {
+			params.Add("props", p)
+		}
 	}
 	var resp nodeListResponse
 	if err := s.c.get(ctx, "/api/v1/nodes", params, &resp); err != nil {
-		return nil, false, err
+		return nil, err
 	}
-	return resp.Nodes, resp.HasMore, nil
+	for i := range resp.Nodes {
+		s.c.decodeNodes(&resp.Nodes[i])
+	}
+	return &resp, nil
+}
+
+// Iter iterates over every node matching opts, following cursors page by
+// page. Offset and After in opts are ignored. Iteration stops at the first
+// error, which is yielded with a zero Node.
+func (s *NodeService) Iter(ctx context.Context, opts *NodeListOptions) iter.Seq2[Node, error] {
+	return func(yield func(Node, error) bool) {
+		var page NodeListOptions
+		if opts != nil {
+			page = *opts
+		}
+		page.Offset, page.After = 0, ""
+
+		for {
+			nodes, next, err := s.ListPage(ctx, &page)
+			if err != nil {
+				yield(Node{}, err)
+				return
+			}
+			for _, n := range nodes {
+				if !yield(n, nil) {
+					return
+				}
+			}
+			if next == "" {
+				return
+			}
+			page.After = next
+		}
+	}
+}
+
+// ListAll iterates over every node matching opts.
+//
+// Deprecated: use Iter.
+func (s *NodeService) ListAll(ctx context.Context, opts *NodeListOptions) iter.Seq2[Node, error] {
+	return s.Iter(ctx, opts)
 }
 
 // GetByLabel returns the node whose label matches exactly (case-insensitive),
@@ -57,6 +128,7 @@ func (s *NodeService) GetByLabel(ctx context.Context, label string) (*Node, erro
 		return nil, nil
 	}
 
+	s.c.decodeNodes(&resp.Nodes[0])
 	return &resp.Nodes[0], nil
 }
 
@@ -66,61 +138,104 @@ func (s *NodeService) Get(ctx context.Context, id string) (*Node, error) {
 	if err := s.c.get(ctx, "/api/v1/nodes/"+url.PathEscape(id), nil, &node); err != nil {
 		return nil, err
 	}
+	s.c.decodeNodes(&node)
 	return &node, nil
 }
 
 // Create creates a new node.
 func (s *NodeService) Create(ctx context.Context, req *CreateNodeRequest) (*Node, error) {
+	if s.c.typedProperties && req != nil {
+		encoded := *req
+		encoded.Properties = s.c.encodeProperties(req.Properties)
+		req = &encoded
+	}
 	var node Node
 	if err := s.c.post(ctx, "/api/v1/nodes", req, &node); err != nil {
 		return nil, err
 	}
+	s.c.decodeNodes(&node)
 	return &node, nil
 }
 
 // Update updates an existing node by ID.
 func (s *NodeService) Update(ctx context.Context, id string, req *UpdateNodeRequest) (*Node, error) {
+	if s.c.typedProperties && req != nil {
+		encoded := *req
+		encoded.Properties = s.c.encodeProperties(req.Properties)
+		req = &encoded
+	}
 	var node Node
 	if err := s.c.put(ctx, "/api/v1/nodes/"+url.PathEscape(id), req, &node); err != nil {
 		return nil, err
 	}
+	s.c.decodeNodes(&node)
 	return &node, nil
 }
 
 // PatchProperties partially updates node properties (merge semantics).
 func (s *NodeService) PatchProperties(ctx context.Context, id string, properties map[string]any) (*Node, error) {
 	var node Node
-	req := &PatchPropertiesRequest{Properties: properties}
+	req := &PatchPropertiesRequest{Properties: s.c.encodeProperties(properties)}
 	if err := s.c.patch(ctx, "/api/v1/nodes/"+url.PathEscape(id)+"/properties", req, &node); err != nil {
 		return nil, err
 	}
+	s.c.decodeNodes(&node)
 	return &node, nil
 }
 
-// Delete removes a node by ID.
+// Delete removes a node by ID. It fails with a conflict error (see
+// IsConflict) while edges still reference the node; use DeleteDetach to
+// remove them too.
 func (s *NodeService) Delete(ctx context.Context, id string) error {
 	return s.c.del(ctx, "/api/v1/nodes/"+url.PathEscape(id), nil, nil)
 }
 
+// DeleteDetach removes a node by ID together with every edge that references it.
+func (s *NodeService) DeleteDetach(ctx context.Context, id string) error {
+	params := url.Values{"mode": {models.DeleteDetach}}
+	return s.c.del(ctx, "/api/v1/nodes/"+url.PathEscape(id), params, nil)
+}
+
 // MigrateNodeRequest is the payload for migrating a node to a new ID.
+// Edges with a relation in ExcludeRelations stay on the old node, or are
+// deleted with it when DeleteOld is set. CopyEdges copies edges to the new
+// node instead of moving them and cannot be combined with DeleteOld. DryRun
+// reports what the migration would do, including conflicting edges, without
+// changing anything.
 type MigrateNodeRequest struct {
-	NewID     string `json:"new_id"`
-	NewLabel  string `json:"new_label,omitempty"`
-	DeleteOld bool   `json:"delete_old"`
+	NewID            string   `json:"new_id"`
+	NewLabel         string   `json:"new_label,omitempty"`
+	DeleteOld        bool     `json:"delete_old"`
+	ExcludeRelations []string `json:"exclude_relations,omitempty"`
+	CopyEdges        bool     `json:"copy_edges,omitempty"`
+	DryRun           bool     `json:"dry_run,omitempty"`
+}
+
+// MigratedEdge reports what a migration did with one edge of the old node:
+// moved, copied, excluded, or dropped. Source and Target are the endpoints
+// before the migration. Conflict marks an edge the new node already has,
+// which fails a migration that is not a dry run.
+type MigratedEdge struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+	Action   string `json:"action"`
+	Conflict bool   `json:"conflict,omitempty"`
 }
 
 // MigrateNodeResult summarizes the outcome of a node migration.
 type MigrateNodeResult struct {
-	OldID         string  `json:"old_id"`
-	NewID         string  `json:"new_id"`
-	OutgoingEdges int     `json:"outgoing_edges"`
-	IncomingEdges int     `json:"incoming_edges"`
-	Salience      float64 `json:"salience"`
-	OldDeleted    bool    `json:"old_deleted"`
-	DryRun        bool    `json:"dry_run"`
+	OldID         string         `json:"old_id"`
+	NewID         string         `json:"new_id"`
+	OutgoingEdges int            `json:"outgoing_edges"`
+	IncomingEdges int            `json:"incoming_edges"`
+	Salience      float64        `json:"salience"`
+	OldDeleted    bool           `json:"old_deleted"`
+	DryRun        bool           `json:"dry_run"`
+	Edges         []MigratedEdge `json:"edges"`
 }
 
-// Migrate migrates a node to a new ID, atomically updating all edges.
+// Migrate migrates a node to a new ID, atomically moving or copying its edges.
 func (s *NodeService) Migrate(ctx context.Context, oldID string, req *MigrateNodeRequest) (*MigrateNodeResult, error) {
 	var result MigrateNodeResult
 	if err := s.c.post(ctx, fmt.Sprintf("/api/v1/nodes/%s/migrate", url.PathEscape(oldID)), req, &result); err != nil {
@@ -129,12 +244,75 @@ func (s *NodeService) Migrate(ctx context.Context, oldID string, req *MigrateNod
 	return &result, nil
 }
 
+// MergeNodeRequest is the payload for merging one node into another.
+// ConflictStrategy is "keep_target" (default), "keep_source", or "fail".
+type MergeNodeRequest struct {
+	ConflictStrategy string `json:"conflict_strategy,omitempty"`
+}
+
+// MergeNodeResult summarizes a node merge. Node is the target after the merge.
+type MergeNodeResult struct {
+	SourceID          string   `json:"source_id"`
+	TargetID          string   `json:"target_id"`
+	Node              *Node    `json:"node"`
+	PropertiesAdded   int      `json:"properties_added"`
+	PropertyConflicts []string `json:"property_conflicts,omitempty"`
+	EdgesRewired      int      `json:"edges_rewired"`
+	EdgesMerged       int      `json:"edges_merged"`
+	EdgesDropped      int      `json:"edges_dropped"`
+}
+
+// MergeInto merges the source node into the target in one transaction and
+// deletes the source. Requires an admin-scoped key.
+func (s *NodeService) MergeInto(ctx context.Context, sourceID, targetID string, req *MergeNodeRequest) (*MergeNodeResult, error) {
+	var result MergeNodeResult
+	path := fmt.Sprintf("/api/v1/nodes/%s/merge-into/%s", url.PathEscape(sourceID), url.PathEscape(targetID))
+	if err := s.c.post(ctx, path, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PreviewDeleteByFilter reports how many nodes filter matches and returns the
+// token DeleteByFilter needs. Requires an admin-scoped key.
+func (s *NodeService) PreviewDeleteByFilter(ctx context.Context, filter models.NodeFilter) (*models.DeletePreview, error) {
+	var preview models.DeletePreview
+	if err := s.c.post(ctx, "/api/v1/nodes/delete-by-filter/preview", filter, &preview); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// DeleteByFilter deletes the nodes a preview matched, with their edges. It
+// fails if the token expired or the filter now matches a different count.
+func (s *NodeService) DeleteByFilter(ctx context.Context, filter models.NodeFilter, token string) (*models.DeleteByFilterResult, error) {
+	var result models.DeleteByFilterResult
+	req := models.DeleteByFilterRequest{Filter: filter, Token: token}
+	if err := s.c.post(ctx, "/api/v1/nodes/delete-by-filter", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // History returns property change history for a node.
 func (s *NodeService) History(ctx context.Context, id string, property string, limit, offset int) ([]PropertyChange, bool, error) {
+	return s.history(ctx, id, property, "", limit, offset)
+}
+
+// FieldHistory returns the node's changes to one field: "property", "label",
+// "type", or "salience".
+func (s *NodeService) FieldHistory(ctx context.Context, id string, field string, limit, offset int) ([]PropertyChange, bool, error) {
+	return s.history(ctx, id, "", field, limit, offset)
+}
+
+func (s *NodeService) history(ctx context.Context, id, property, field string, limit, offset int) ([]PropertyChange, bool, error) {
 	params := url.Values{}
 	if property != "" {
 		params.Set("property", property)
 	}
+	if field != "" {
+		params.Set("field", field)
+	}
 	if limit > 0 {
 		params.Set("limit", strconv.Itoa(limit))
 	}
@@ -150,3 +328,78 @@ func (s *NodeService) History(ctx context.Context, id string, property string, l
 	}
 	return resp.Changes, resp.HasMore, nil
 }
+
+// SuggestTags ranks the tenant's tags by how close each tag's centroid is to
+// the node's embedding, leaving out tags the node already has. A limit of 0
+// uses the server default. It fails with a 409 until the node is embedded.
+func (s *NodeService) SuggestTags(ctx context.Context, id string, limit int) (*models.TagSuggestions, error) {
+	path := fmt.Sprintf("/api/v1/nodes/%s/suggest-tags", url.PathEscape(id))
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var result models.TagSuggestions
+	if err := s.c.post(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Archived lists nodes the tenant's forgetting policy archived, most recently
+// archived first. Archived nodes are left out of search and traversal.
+func (s *NodeService) Archived(ctx context.Context, limit, offset int) ([]models.ArchivedNode, bool, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	var resp struct {
+		Nodes   []models.ArchivedNode `json:"nodes"`
+		HasMore bool                  `json:"has_more"`
+	}
+	if err := s.c.get(ctx, "/api/v1/archive", params, &resp); err != nil {
+		return nil, false, err
+	}
+	return resp.Nodes, resp.HasMore, nil
+}
+
+// Restore moves an archived node back into the graph, with its archived edges
+// to nodes that are not archived.
+func (s *NodeService) Restore(ctx context.Context, id string) (*models.RestoreResult, error) {
+	var result models.RestoreResult
+	if err := s.c.post(ctx, fmt.Sprintf("/api/v1/archive/%s/restore", url.PathEscape(id)), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Watch makes watcher follow the node. WebSocket and SSE subscribers that
+// give the watcher name receive only the events for the nodes it follows.
+func (s *NodeService) Watch(ctx context.Context, id, watcher string) (*models.Watch, error) {
+	var w models.Watch
+	if err := s.c.post(ctx, watchPath(id, watcher), nil, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Unwatch stops watcher following the node.
+func (s *NodeService) Unwatch(ctx context.Context, id, watcher string) error {
+	return s.c.del(ctx, "/api/v1/watch/"+url.PathEscape(id), url.Values{"watcher": {watcher}}, nil)
+}
+
+// Watched lists the nodes watcher follows.
+func (s *NodeService) Watched(ctx context.Context, watcher string) ([]models.Watch, error) {
+	var resp struct {
+		Watches []models.Watch `json:"watches"`
+	}
+	if err := s.c.get(ctx, "/api/v1/watch", url.Values{"watcher": {watcher}}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Watches, nil
+}
+
+func watchPath(id, watcher string) string {
+	return "/api/v1/watch/" + url.PathEscape(id) + "?" + url.Values{"watcher": {watcher}}.Encode()
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ListQuarantined returns up to limit nodes the prompt-injection scanner
+// flagged, most recent first. A limit of 0 uses the server default.
+func (s *AdminService) ListQuarantined(ctx context.Context, limit int) ([]models.QuarantinedNode, error) {
+	query := make(url.Values)
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	var resp struct {
+		Nodes []models.QuarantinedNode `json:"nodes"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/quarantine", query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Nodes, nil
+}
+
+// ReleaseQuarantined clears a node's quarantine after review, so recall packs
+// include it again.
+func (s *AdminService) ReleaseQuarantined(ctx context.Context, nodeID string) error {
+	return s.c.del(ctx, "/api/v1/admin/quarantine/"+url.PathEscape(nodeID), nil, nil)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultThrottleWait is how long WithAutoThrottle waits after a 429
+// response that does not carry Retry-After.
+const defaultThrottleWait = time.Second
+
+// RateLimitStatus is the server's rate limit as of the last response.
+type RateLimitStatus struct {
+	// Limit is the number of requests the server allows in a burst.
+	Limit int
+	// Remaining is how many of those were left after the last request.
+	Remaining int
+	// Reset is how long until the full burst is available again; zero if
+	// the server did not say.
+	Reset time.Duration
+	// Updated is when the status was read; zero if no response has
+	// carried rate limit headers yet.
+	Updated time.Time
+}
+
+// WithAutoThrottle waits out 429 responses and resends the request, for up
+// to maxWait in total per call. Each wait lasts as long as the server's
+// Retry-After header asks, or one second if it has none. The call fails
+// with a *RateLimitError once the next wait would go past maxWait.
+// Waiting does not count against WithRetry attempts.
+func WithAutoThrottle(maxWait time.Duration) Option {
+	return func(c *Client) { c.throttle = maxWait }
+}
+
+// RateLimit returns the rate limit reported by the most recent response.
+func (c *Client) RateLimit() RateLimitStatus {
+	return c.rateLimit.get()
+}
+
+// throttleWait returns how long to wait before resending a request that
+// failed with err, given the time already spent waiting. It returns false
+// if err is not a rate limit or the wait would exceed the throttle budget.
+func (c *Client) throttleWait(err error, waited time.Duration) (time.Duration, bool) {
+	var rlErr *RateLimitError
+	if c.throttle <= 0 || !errors.As(err, &rlErr) {
+		return 0, false
+	}
+
+	wait := rlErr.RetryAfter
+	if wait <= 0 {
+		wait = defaultThrottleWait
+	}
+	if waited+wait > c.throttle {
+		return 0, false
+	}
+
+	return wait, true
+}
+
+// rateLimitTracker keeps the rate limit headers of the latest response.
+type rateLimitTracker struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+}
+
+// update records the tenant rate limit (RateLimit-*) when the response has
+// one, since it is usually the tighter limit, and the per-IP rate limit
+// (X-RateLimit-*) otherwise.
+func (t *rateLimitTracker) update(h http.Header) {
+	prefix := "RateLimit-"
+	if h.Get(prefix+"Limit") == "" {
+		prefix = "X-RateLimit-"
+	}
+
+	limit, err := strconv.Atoi(h.Get(prefix + "Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(h.Get(prefix + "Remaining"))
+	if err != nil {
+		return
+	}
+	var reset time.Duration
+	if secs, err := strconv.Atoi(h.Get(prefix + "Reset")); err == nil {
+		reset = time.Duration(secs) * time.Second
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status = RateLimitStatus{Limit: limit, Remaining: remaining, Reset: reset, Updated: time.Now()}
+}
+
+func (t *rateLimitTracker) get() RateLimitStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
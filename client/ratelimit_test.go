@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	var attempts int
+	limited := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("X-RateLimit-Limit", "200")
+		if attempts <= limited {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "1")
+			jsonResponse(w, http.StatusTooManyRequests, map[string]string{"code": "rate_limited", "message": "slow down"})
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "199")
+		jsonResponse(w, 200, Node{ID: "n1"})
+	}))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+
+	c := New(srv.URL)
+	_, err := c.Nodes.Get(ctx, "n1")
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.RetryAfter != time.Second || !IsRateLimited(err) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v, want a RateLimitError with RetryAfter 1s", err)
+	}
+	if rlErr.StatusCode != http.StatusTooManyRequests || rlErr.Code != "rate_limited" {
+		t.Errorf("api error = %+v", rlErr.APIError)
+	}
+	if got := c.RateLimit(); got.Limit != 200 || got.Remaining != 0 || got.Updated.IsZero() {
+		t.Errorf("RateLimit() = %+v, want 200 with 0 remaining", got)
+	}
+
+	attempts = 0
+	c = New(srv.URL, WithAutoThrottle(5*time.Second))
+	if node, err := c.Nodes.Get(ctx, "n1"); err != nil || node.ID != "n1" || attempts != 2 {
+		t.Fatalf("throttled Get = %+v, %v after %d attempts; want n1 after 2", node, err, attempts)
+	}
+	if got := c.RateLimit(); got.Remaining != 199 {
+		t.Errorf("RateLimit().Remaining = %d, want 199", got.Remaining)
+	}
+
+	attempts, limited = 0, 5
+	c = New(srv.URL, WithAutoThrottle(500*time.Millisecond))
+	if _, err := c.Nodes.Get(ctx, "n1"); !IsRateLimited(err) || attempts != 1 {
+		t.Errorf("over budget: err %v after %d attempts, want a rate limit error after 1", err, attempts)
+	}
+}
+
+func TestRateLimit_PrefersTenantHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", "199")
+		w.Header().Set("RateLimit-Limit", "40")
+		w.Header().Set("RateLimit-Remaining", "10")
+		w.Header().Set("RateLimit-Reset", "2")
+		jsonResponse(w, 200, Node{ID: "n1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	c := New(srv.URL)
+	if _, err := c.Nodes.Get(context.Background(), "n1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := c.RateLimit(); got.Limit != 40 || got.Remaining != 10 || got.Reset != 2*time.Second {
+		t.Errorf("RateLimit() = %+v, want the tenant limit 40 with 10 remaining, reset 2s", got)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// RelationCatalog returns the tenant's catalog of allowed (source type,
+// relation, target type) triples and whether it is enforced.
+func (s *AdminService) RelationCatalog(ctx context.Context) (*models.RelationCatalog, error) {
+	var resp models.RelationCatalog
+	if err := s.c.get(ctx, "/api/v1/admin/relations/catalog", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetRelationCatalog replaces the tenant's relation catalog. While Enforce is
+// set, edges matching no rule are rejected.
+func (s *AdminService) SetRelationCatalog(ctx context.Context, catalog models.RelationCatalog) (*models.RelationCatalog, error) {
+	var resp models.RelationCatalog
+	if err := s.c.put(ctx, "/api/v1/admin/relations/catalog", catalog, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RelationUsage returns the (source type, relation, target type) triples in
+// use with their edge counts, most used first.
+func (s *EdgeService) RelationUsage(ctx context.Context) ([]models.RelationUsage, error) {
+	var resp struct {
+		Relations []models.RelationUsage `json:"relations"`
+	}
+	if err := s.c.get(ctx, "/api/v1/relations/usage", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Relations, nil
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IdempotencyKeyHeader is sent with every POST. The server uses it to return
+// the first response to a retried create instead of creating a duplicate.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how failed requests are retried. The zero value makes
+// a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. Each later retry
+	// waits twice as long as the one before, up to MaxBackoff, with jitter.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryOn429 retries rate-limited requests. A Retry-After header, when
+	// present, replaces the backoff.
+	RetryOn429 bool
+	// RetryOn5xx retries 500, 502, 503, and 504 responses and requests that
+	// failed without a response.
+	RetryOn5xx bool
+}
+
+// DefaultRetryPolicy makes up to three attempts, retrying rate limits,
+// server errors, and network failures after 200ms and then 400ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		RetryOn429:     true,
+		RetryOn5xx:     true,
+	}
+}
+
+// WithRetry retries failed requests according to p. Every attempt of a POST
+// carries the same Idempotency-Key, so node and edge creates are not
+// duplicated when a response is lost. Other POST endpoints ignore the key
+// and would run again on each attempt, so they are only retried when rate
+// limited, which the server answers before doing any work.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// deduplicatedPaths are the POST routes on which the server honours
+// Idempotency-Key.
+var deduplicatedPaths = map[string]bool{
+	"/api/v1/nodes": true,
+	"/api/v1/edges": true,
+}
+
+// replayable reports whether a request may be sent again after a failure
+// that it could have caused side effects before: every method but POST, and
+// POSTs the server deduplicates.
+func replayable(method, path string) bool {
+	if method != http.MethodPost {
+		return true
+	}
+
+	path, _, _ = strings.Cut(path, "?")
+
+	return deduplicatedPaths[path]
+}
+
+// retryable reports whether a failed attempt should be retried. A request
+// that is not replayable is only retried when it was rate limited.
+func (p RetryPolicy) retryable(ctx context.Context, err error, canReplay bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return p.RetryOn429
+		case http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return p.RetryOn5xx && canReplay
+		default:
+			return false
+		}
+	}
+
+	var urlErr *url.Error
+	return p.RetryOn5xx && canReplay && errors.As(err, &urlErr)
+}
+
+// backoff returns the wait before retry number attempt, counting from 1.
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	d := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	// Up to 20% jitter keeps clients that failed together from retrying together.
+	return d - time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds.
+func parseRetryAfter(h http.Header) time.Duration {
+	secs, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+func newIdempotencyKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := cryptorand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
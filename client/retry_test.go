@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var attempts int
+	var keys []string
+	failures := 2
+	failStatus := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if attempts <= failures {
+			jsonResponse(w, failStatus, map[string]string{"code": "unavailable", "message": "try later"})
+			return
+		}
+		jsonResponse(w, 201, Node{ID: "n1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryOn429: true, RetryOn5xx: true}
+	c := New(srv.URL, WithRetry(policy))
+	ctx := context.Background()
+
+	node, err := c.Nodes.Create(ctx, &CreateNodeRequest{Type: "person", Label: "A"})
+	if err != nil || node.ID != "n1" || attempts != 3 {
+		t.Fatalf("Create = %+v, %v after %d attempts; want n1 after 3", node, err, attempts)
+	}
+	if keys[0] == "" || keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("idempotency keys = %q, want one key on every attempt", keys)
+	}
+
+	attempts, keys, failures = 0, nil, 5
+	if _, err := c.Nodes.Create(ctx, &CreateNodeRequest{Type: "person", Label: "A"}); err == nil || attempts != 3 {
+		t.Errorf("exhausted: err %v after %d attempts, want an error after 3", err, attempts)
+	}
+
+	attempts, failures, failStatus = 0, 1, http.StatusBadRequest
+	if _, err := c.Nodes.Create(ctx, &CreateNodeRequest{Type: "person", Label: "A"}); err == nil || attempts != 1 {
+		t.Errorf("400: err %v after %d attempts, want no retry", err, attempts)
+	}
+
+	attempts, failures, failStatus = 0, 1, http.StatusServiceUnavailable
+	if _, err := c.Salience.Boost(ctx, "n1"); err == nil || attempts != 1 {
+		t.Errorf("non-deduplicated POST: err %v after %d attempts, want no retry", err, attempts)
+	}
+
+	attempts, failures, failStatus = 0, 1, http.StatusTooManyRequests
+	if _, err := c.Salience.Boost(ctx, "n1"); err != nil || attempts != 2 {
+		t.Errorf("rate-limited POST: err %v after %d attempts, want success after 2", err, attempts)
+	}
+
+	attempts, keys, failures, failStatus = 0, nil, 1, http.StatusServiceUnavailable
+	if _, err := New(srv.URL).Nodes.Get(ctx, "n1"); err == nil || attempts != 1 || keys[0] != "" {
+		t.Errorf("default policy: err %v after %d attempts, key %q; want one attempt without a key", err, attempts, keys[0])
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: 300 * time.Millisecond} {
+		if got := p.backoff(attempt, 0); got > want || got < want*4/5 {
+			t.Errorf("backoff(%d) = %v, want within 20%% below %v", attempt, got, want)
+		}
+	}
+
+	if got := p.backoff(1, 2*time.Second); got != 2*time.Second {
+		t.Errorf("backoff with Retry-After = %v, want 2s", got)
+	}
+}
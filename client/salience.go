@@ -3,6 +3,7 @@
 import (
 	"context"
 	"net/url"
+	"strconv"
 )
 
 // SalienceService handles salience scoring operations.
@@ -35,3 +36,32 @@ func (s *SalienceService) Recalculate(ctx context.Context) (int, error) {
 	}
 	return resp.Updated, nil
 }
+
+// Top returns the most salient nodes, optionally of one type. A zero limit
+// uses the server default.
+func (s *SalienceService) Top(ctx context.Context, typeFilter string, limit int) ([]SalienceEntry, error) {
+	return s.report(ctx, "/api/v1/salience/top", typeFilter, limit)
+}
+
+// Decaying returns the nodes whose score dropped most in the last
+// recalculation, optionally of one type.
+func (s *SalienceService) Decaying(ctx context.Context, typeFilter string, limit int) ([]SalienceEntry, error) {
+	return s.report(ctx, "/api/v1/salience/decaying", typeFilter, limit)
+}
+
+func (s *SalienceService) report(ctx context.Context, path, typeFilter string, limit int) ([]SalienceEntry, error) {
+	params := url.Values{}
+	if typeFilter != "" {
+		params.Set("type", typeFilter)
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var resp struct {
+		Nodes []SalienceEntry `json:"nodes"`
+	}
+	if err := s.c.get(ctx, path, params, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Nodes, nil
+}
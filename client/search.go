@@ -4,6 +4,8 @@
 	"context"
 	"net/url"
 	"strconv"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // SearchService handles search operations.
@@ -23,8 +25,36 @@ type searchScoredResponse struct {
 	Total int          `json:"total"`
 }
 
+// searchFacetsResponse wraps full-text search results with facet counts.
+type searchFacetsResponse struct {
+	Nodes  []Node               `json:"nodes"`
+	Facets *models.SearchFacets `json:"facets"`
+}
+
 // FullText performs a full-text search.
 func (s *SearchService) FullText(ctx context.Context, query string, opts *SearchOptions) ([]Node, error) {
+	var resp searchNodeResponse
+	if err := s.c.get(ctx, "/api/v1/search", fullTextParams(query, opts), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Nodes, nil
+}
+
+// FullTextFacets performs a full-text search and also counts every match,
+// before the limit, by type, salience bucket, and creation month.
+func (s *SearchService) FullTextFacets(ctx context.Context, query string, opts *SearchOptions) ([]Node, *models.SearchFacets, error) {
+	params := fullTextParams(query, opts)
+	params.Set("facets", "true")
+	var resp searchFacetsResponse
+	if err := s.c.get(ctx, "/api/v1/search", params, &resp); err != nil {
+		return nil, nil, err
+	}
+	return resp.Nodes, resp.Facets, nil
+}
+
+// fullTextParams builds the query parameters for a full-text search, which
+// semantic and hybrid search share.
+func fullTextParams(query string, opts *SearchOptions) url.Values {
 	params := url.Values{"q": {query}}
 	if opts != nil {
 		if opts.Type != "" {
@@ -36,19 +66,33 @@ func (s *SearchService) FullText(ctx context.Context, query string, opts *Search
 		if opts.Limit > 0 {
 			params.Set("limit", strconv.Itoa(opts.Limit))
 		}
+		if opts.OmitProperties {
+			params.Set("include_properties", "false")
+		}
+		for _, p := range opts.Props {
+			params.Add("props", p)
+		}
+		if opts.Root != "" {
+			params.Set("root", opts.Root)
+		}
+		if opts.Depth > 0 {
+			params.Set("depth", strconv.Itoa(opts.Depth))
+		}
 	}
-	var resp searchNodeResponse
-	if err := s.c.get(ctx, "/api/v1/search", params, &resp); err != nil {
-		return nil, err
-	}
-	return resp.Nodes, nil
+	return params
 }
 
 // Semantic performs a semantic (vector) search.
 func (s *SearchService) Semantic(ctx context.Context, query string, limit int) ([]ScoredNode, error) {
-	params := url.Values{"q": {query}}
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
+	return s.SemanticWithOptions(ctx, query, &SearchOptions{Limit: limit})
+}
+
+// SemanticWithOptions performs a semantic (vector) search narrowed by the
+// type, salience, superseded, and props filters in opts.
+func (s *SearchService) SemanticWithOptions(ctx context.Context, query string, opts *SearchOptions) ([]ScoredNode, error) {
+	params := fullTextParams(query, opts)
+	if opts != nil && opts.IncludeSuperseded {
+		params.Set("include_superseded", "true")
 	}
 	var resp searchScoredResponse
 	if err := s.c.get(ctx, "/api/v1/search/semantic", params, &resp); err != nil {
@@ -59,10 +103,10 @@ func (s *SearchService) Semantic(ctx context.Context, query string, limit int) (
 
 // Hybrid performs a hybrid (full-text + vector RRF fusion) search.
 func (s *SearchService) Hybrid(ctx context.Context, query string, opts *SearchOptions) ([]Node, error) {
-	params := url.Values{"q": {query}}
+	params := fullTextParams(query, opts)
 	if opts != nil {
-		if opts.Limit > 0 {
-			params.Set("limit", strconv.Itoa(opts.Limit))
+		if opts.Rerank {
+			params.Set("rerank", "true")
 		}
 		if opts.InternalRerank != "" {
 			params.Set("internal_rerank", opts.InternalRerank)
@@ -70,6 +114,9 @@ func (s *SearchService) Hybrid(ctx context.Context, query string, opts *SearchOp
 		if opts.InternalRerankProfile != "" {
 			params.Set("internal_rerank_profile", opts.InternalRerankProfile)
 		}
+		if opts.IncludeSuperseded {
+			params.Set("include_superseded", "true")
+		}
 	}
 	var resp searchNodeResponse
 	if err := s.c.get(ctx, "/api/v1/search/hybrid", params, &resp); err != nil {
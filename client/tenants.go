@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TenantService manages other tenants. All methods require an admin-scoped
+// key of an operator tenant.
+type TenantService struct {
+	c *Client
+}
+
+// Create creates a tenant. The returned primary API key cannot be retrieved
+// again.
+func (s *TenantService) Create(ctx context.Context, req models.CreateTenantRequest) (*models.CreatedTenant, error) {
+	var resp models.CreatedTenant
+	if err := s.c.post(ctx, "/api/v1/admin/tenants", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// List returns every tenant, oldest first.
+func (s *TenantService) List(ctx context.Context) ([]models.Tenant, error) {
+	var resp struct {
+		Tenants []models.Tenant `json:"tenants"`
+	}
+	if err := s.c.get(ctx, "/api/v1/admin/tenants", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tenants, nil
+}
+
+// Get returns a tenant by ID.
+func (s *TenantService) Get(ctx context.Context, id string) (*models.Tenant, error) {
+	var resp models.Tenant
+	if err := s.c.get(ctx, tenantPath(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Update changes a tenant's name, plan, or quotas.
+func (s *TenantService) Update(ctx context.Context, id string, req models.UpdateTenantRequest) (*models.Tenant, error) {
+	var resp models.Tenant
+	if err := s.c.patch(ctx, tenantPath(id), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RotateKey generates a new primary API key for a tenant. The current key
+// keeps working for the requested grace period. The returned key cannot be
+// retrieved again.
+func (s *TenantService) RotateKey(ctx context.Context, id string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error) {
+	var resp models.APIKeyRotation
+	if err := s.c.post(ctx, tenantPath(id)+"/rotate-key", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Suspend rejects every key of a tenant until it is resumed.
+func (s *TenantService) Suspend(ctx context.Context, id string) (*models.Tenant, error) {
+	var resp models.Tenant
+	if err := s.c.post(ctx, tenantPath(id)+"/suspend", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Resume accepts a suspended tenant's keys again.
+func (s *TenantService) Resume(ctx context.Context, id string) (*models.Tenant, error) {
+	var resp models.Tenant
+	if err := s.c.post(ctx, tenantPath(id)+"/resume", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delete deletes a suspended tenant and purges all of its data.
+func (s *TenantService) Delete(ctx context.Context, id string) (*models.TenantPurgeResult, error) {
+	var resp models.TenantPurgeResult
+	if err := s.c.del(ctx, tenantPath(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListKeys returns a tenant's named API keys, newest first, including revoked
+// and expired ones. Keys are never revealed.
+func (s *TenantService) ListKeys(ctx context.Context, id string) ([]models.ManagedAPIKey, error) {
+	var resp struct {
+		Keys []models.ManagedAPIKey `json:"keys"`
+	}
+	if err := s.c.get(ctx, tenantPath(id)+"/keys", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// CreateKey generates a named API key for a tenant. The returned key cannot
+// be retrieved again.
+func (s *TenantService) CreateKey(ctx context.Context, id string, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	var resp models.CreatedAPIKey
+	if err := s.c.post(ctx, tenantPath(id)+"/keys", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RevokeKey stops accepting one of a tenant's named API keys.
+func (s *TenantService) RevokeKey(ctx context.Context, id, keyID string) (*models.ManagedAPIKey, error) {
+	var resp models.ManagedAPIKey
+	if err := s.c.del(ctx, tenantPath(id)+"/keys/"+url.PathEscape(keyID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Impersonate issues a short-lived key that authenticates as a tenant, for
+// reproducing a customer's issue. The reason is audited, as is every request
+// made with the key. The returned key cannot be retrieved again.
+func (s *TenantService) Impersonate(ctx context.Context, id string, req models.ImpersonateTenantRequest) (*models.ImpersonationKey, error) {
+	var resp models.ImpersonationKey
+	if err := s.c.post(ctx, tenantPath(id)+"/impersonate", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func tenantPath(id string) string {
+	return "/api/v1/admin/tenants/" + url.PathEscape(id)
+}
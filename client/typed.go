@@ -0,0 +1,55 @@
+package client
+
+import "github.com/persistorai/persistor/internal/models"
+
+// WithTypedProperties preserves property value types across the JSON round
+// trip. Integers, *big.Int and *big.Float, time.Time, and []byte values in
+// node and edge properties are sent as typed envelopes, and envelopes in the
+// nodes and edges returned by the Nodes and Edges services are decoded back
+// into int64, *big.Int or *big.Float, time.Time, and []byte. Other clients
+// see the envelopes as {"$type": ..., "value": ...} objects.
+func WithTypedProperties() Option {
+	return func(c *Client) { c.typedProperties = true }
+}
+
+// EncodeProperties replaces typed Go values in props with typed envelopes,
+// for requests made outside the services WithTypedProperties covers.
+func EncodeProperties(props map[string]any) map[string]any {
+	return models.EncodeTypedValues(props)
+}
+
+// DecodeProperties replaces typed envelopes in props with Go values, for
+// responses outside the services WithTypedProperties covers.
+func DecodeProperties(props map[string]any) map[string]any {
+	return models.DecodeTypedValues(props)
+}
+
+// encodeProperties encodes outgoing properties when typed properties are on.
+func (c *Client) encodeProperties(props map[string]any) map[string]any {
+	if !c.typedProperties {
+		return props
+	}
+	return models.EncodeTypedValues(props)
+}
+
+// decodeNodes decodes the properties of returned nodes in place when typed
+// properties are on.
+func (c *Client) decodeNodes(nodes ...*Node) {
+	if !c.typedProperties {
+		return
+	}
+	for _, n := range nodes {
+		n.Properties = models.DecodeTypedValues(n.Properties)
+	}
+}
+
+// decodeEdges decodes the properties of returned edges in place when typed
+// properties are on.
+func (c *Client) decodeEdges(edges ...*Edge) {
+	if !c.typedProperties {
+		return
+	}
+	for _, e := range edges {
+		e.Properties = models.DecodeTypedValues(e.Properties)
+	}
+}
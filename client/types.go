@@ -96,6 +96,22 @@ type SupersedeRequest struct {
 	NewID string `json:"new_id"`
 }
 
+// SalienceEntry is a node's salience standing in the top and decaying
+// reports. Previous and Change are set only in the decaying report.
+type SalienceEntry struct {
+	NodeID       string     `json:"node_id"`
+	Type         string     `json:"type"`
+	Label        string     `json:"label"`
+	Salience     float64    `json:"salience_score"`
+	AccessCount  int        `json:"access_count"`
+	LastAccessed *time.Time `json:"last_accessed,omitempty"`
+	UserBoosted  bool       `json:"user_boosted"`
+	SupersededBy *string    `json:"superseded_by,omitempty"`
+	Previous     *float64   `json:"previous,omitempty"`
+	Change       *float64   `json:"change,omitempty"`
+	SnapshotAt   *time.Time `json:"snapshot_at,omitempty"`
+}
+
 // NeighborResult holds nodes and edges directly connected to a node.
 type NeighborResult struct {
 	Nodes []Node `json:"nodes"`
@@ -117,7 +133,7 @@ type ContextResult struct {
 
 // AuditEntry represents a single audit log entry.
 type AuditEntry struct {
-	ID         string         `json:"id"`
+	ID         int64          `json:"id"`
 	Action     string         `json:"action"`
 	EntityType string         `json:"entity_type"`
 	EntityID   string         `json:"entity_id"`
@@ -126,10 +142,26 @@ type AuditEntry struct {
 	CreatedAt  time.Time      `json:"created_at"`
 }
 
-// PropertyChange represents a single property value change.
+// PropertyChange represents a single change to a node: a property value, or
+// its label, type, or salience as given by Field.
 type PropertyChange struct {
 	ID          int64           `json:"id"`
 	NodeID      string          `json:"node_id"`
+	Field       string          `json:"field"`
+	PropertyKey string          `json:"property_key,omitempty"`
+	OldValue    json.RawMessage `json:"old_value"`
+	NewValue    json.RawMessage `json:"new_value"`
+	ChangedAt   time.Time       `json:"changed_at"`
+	Reason      *string         `json:"reason,omitempty"`
+	ChangedBy   *string         `json:"changed_by,omitempty"`
+}
+
+// EdgePropertyChange represents a single property value change on an edge.
+type EdgePropertyChange struct {
+	ID          int64           `json:"id"`
+	Source      string          `json:"source"`
+	Target      string          `json:"target"`
+	Relation    string          `json:"relation"`
 	PropertyKey string          `json:"property_key"`
 	OldValue    json.RawMessage `json:"old_value"`
 	NewValue    json.RawMessage `json:"new_value"`
@@ -166,6 +198,15 @@ type NodeListOptions struct {
 	MinSalience float64
 	Limit       int
 	Offset      int
+	// After continues a listing from a cursor returned by ListPage; Offset
+	// is ignored when it is set.
+	After string
+	// OmitProperties returns nodes with nil Properties, which the server
+	// then never decrypts.
+	OmitProperties bool
+	// Props filters on searchable properties, each "key:value" or just
+	// "key" to require the key. All must match.
+	Props []string
 }
 
 // EdgeListOptions holds parameters for listing edges.
@@ -177,15 +218,50 @@ type EdgeListOptions struct {
 	Offset   int
 	ActiveOn *time.Time
 	Current  *bool
+	// After continues a listing from a cursor returned by ListPage; Offset
+	// is ignored when it is set.
+	After string
+	// OmitProperties returns nodes with nil Properties, which the server
+	// then never decrypts.
+	OmitProperties bool
 }
 
 // SearchOptions holds parameters for search queries.
 type SearchOptions struct {
-	Type                  string
-	MinSalience           float64
-	Limit                 int
+	Type        string
+	MinSalience float64
+	Limit       int
+	// Rerank has hybrid search reorder its top candidates with the
+	// server's cross-encoder (RERANK_MODEL); ignored when none is set.
+	Rerank                bool
 	InternalRerank        string
 	InternalRerankProfile string
+	// OmitProperties returns nodes with nil Properties, which the server
+	// then never decrypts.
+	OmitProperties bool
+	// Props filters on searchable properties, each "key:value" or just
+	// "key" to require the key. All must match.
+	Props []string
+	// IncludeSuperseded keeps superseded nodes in semantic and hybrid
+	// results, which exclude them by default.
+	IncludeSuperseded bool
+	// Root restricts results to nodes within Depth hops of this node ID.
+	// Depth defaults to 2 on the server when zero.
+	Root  string
+	Depth int
+}
+
+// HistoryListOptions holds filters for tenant-wide node history. Since and
+// Until bound the change time; Reason matches any reason containing it.
+type HistoryListOptions struct {
+	Since       *time.Time
+	Until       *time.Time
+	PropertyKey string
+	Field       string
+	Actor       string
+	Reason      string
+	Limit       int
+	Offset      int
 }
 
 // AuditQueryOptions holds parameters for querying audit logs.
@@ -196,4 +272,7 @@ type AuditQueryOptions struct {
 	Since      *time.Time
 	Limit      int
 	Offset     int
+	// After continues a query from a cursor returned by QueryPage; Offset
+	// is ignored when it is set.
+	After string
 }
@@ -8,6 +8,7 @@
 	"path/filepath"
 
 	"github.com/persistorai/persistor/internal/ingest"
+	"github.com/persistorai/persistor/internal/llm"
 )
 
 type benchmarkResult struct {
@@ -28,18 +29,25 @@ func main() {
 	model := os.Args[2]
 	files := os.Args[3:]
 
-	var llm ingest.LLMClient
+	cfg := llm.Config{Provider: provider, Model: model}
 	switch provider {
-	case "ollama":
-		llm = ingest.NewOllamaClientWithURL(envOr("OLLAMA_URL", "http://localhost:11434"), model)
-	case "openai":
-		llm = ingest.NewOpenAIClientWithConfig(envOr("INGEST_LLM_URL", "https://api.openai.com/v1"), model, envOr("INGEST_LLM_API_KEY", os.Getenv("OPENAI_API_KEY")))
+	case llm.ProviderOllama:
+		cfg.URL = envOr("OLLAMA_URL", "http://localhost:11434")
+	case llm.ProviderOpenAI:
+		cfg.URL = envOr("LLM_URL", envOr("INGEST_LLM_URL", "https://api.openai.com/v1"))
+		cfg.APIKey = envOr("LLM_API_KEY", envOr("INGEST_LLM_API_KEY", os.Getenv("OPENAI_API_KEY")))
 	default:
 		fmt.Fprintf(os.Stderr, "unknown provider: %s\n", provider)
 		os.Exit(2)
 	}
 
-	extractor := ingest.NewExtractor(llm)
+	client, err := llm.New(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	extractor := ingest.NewExtractor(client)
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 
@@ -6,6 +6,7 @@
   "os"
 
   "github.com/persistorai/persistor/internal/ingest"
+  "github.com/persistorai/persistor/internal/llm"
 )
 
 func main() {
@@ -19,7 +20,10 @@ func main() {
     panic(err)
   }
 
-  c := ingest.NewLLMClient()
+  c, err := llm.FromEnv()
+  if err != nil {
+    panic(err)
+  }
   e := ingest.NewExtractor(c)
   r, err := e.Extract(context.Background(), string(b))
   if err != nil {
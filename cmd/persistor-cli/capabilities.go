@@ -0,0 +1,18 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// explainUnavailable is called when a request that depends on an optional
+// server subsystem fails. If the server reports the capability as disabled,
+// the error says so instead of surfacing a bare 404 or 503. The capability
+// lookup is best-effort; err is returned unchanged if it fails.
+func explainUnavailable(ctx context.Context, err error, capability string) error {
+	caps, capErr := apiClient.Capabilities(ctx)
+	if capErr != nil || caps.Has(capability) {
+		return err
+	}
+	return fmt.Errorf("%s is not enabled on this server: %w", capability, err)
+}
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/persistorai/persistor/client"
 	clientmodels "github.com/persistorai/persistor/internal/models"
@@ -16,10 +17,28 @@ func newAdminCmd() *cobra.Command {
 	}
 	cmd.AddCommand(adminHealthCmd())
 	cmd.AddCommand(adminStatsCmd())
+	cmd.AddCommand(adminUsageCmd())
+	cmd.AddCommand(adminCapabilitiesCmd())
 	cmd.AddCommand(adminBackfillCmd())
+	cmd.AddCommand(adminBackfillJobCmd())
 	cmd.AddCommand(adminReprocessCmd())
+	cmd.AddCommand(adminReembedCmd())
 	cmd.AddCommand(adminMaintenanceCmd())
 	cmd.AddCommand(adminMergeSuggestionsCmd())
+	cmd.AddCommand(adminDuplicatesCmd())
+	cmd.AddCommand(adminBroadcastCmd())
+	cmd.AddCommand(adminSecurityBlocksCmd())
+	cmd.AddCommand(adminPartitionsCmd())
+	cmd.AddCommand(adminVectorIndexCmd())
+	cmd.AddCommand(adminIndexAdvisorCmd())
+	cmd.AddCommand(adminHistoryRetentionCmd())
+	cmd.AddCommand(adminHistoryPruneCmd())
+	cmd.AddCommand(adminArchivePolicyCmd())
+	cmd.AddCommand(adminArchiveRunCmd())
+	cmd.AddCommand(adminInferRelationsCmd())
+	cmd.AddCommand(adminQuarantineCmd())
+	cmd.AddCommand(adminKeyCmd())
+	cmd.AddCommand(adminTenantCmd())
 	return cmd
 }
 
@@ -37,6 +56,33 @@ func adminHealthCmd() *cobra.Command {
 	}
 }
 
+func adminCapabilitiesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "capabilities",
+		Short: "Show which optional server subsystems are enabled",
+		Run: func(cmd *cobra.Command, args []string) {
+			caps, err := apiClient.Capabilities(context.Background())
+			if err != nil {
+				fatal("capabilities", err)
+			}
+			if flagFmt == "table" {
+				names := make([]string, 0, len(caps.Features))
+				for name := range caps.Features {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				rows := make([][]string, 0, len(names))
+				for _, name := range names {
+					rows = append(rows, []string{name, fmt.Sprintf("%t", caps.Features[name])})
+				}
+				formatTable([]string{"CAPABILITY", "ENABLED"}, rows)
+				return
+			}
+			output(caps, "")
+		},
+	}
+}
+
 func adminStatsCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "stats",
@@ -70,11 +116,11 @@ func adminBackfillCmd() *cobra.Command {
 		Use:   "backfill-embeddings",
 		Short: "Queue embedding generation for nodes without embeddings",
 		Run: func(cmd *cobra.Command, args []string) {
-			queued, err := apiClient.Admin.BackfillEmbeddings(context.Background())
+			job, err := apiClient.Admin.StartBackfill(context.Background())
 			if err != nil {
 				fatal("backfill", err)
 			}
-			output(map[string]int{"queued": queued}, fmt.Sprintf("%d", queued))
+			output(job, fmt.Sprintf("queued=%d job_id=%s", job.Queued, job.ID))
 		},
 	}
 }
@@ -200,7 +246,7 @@ func newAuditCmd() *cobra.Command {
 				headers := []string{"ID", "ACTION", "ENTITY_TYPE", "ENTITY_ID", "CREATED_AT"}
 				var rows [][]string
 				for _, e := range entries {
-					rows = append(rows, []string{e.ID, e.Action, e.EntityType, e.EntityID, e.CreatedAt.Format("2006-01-02 15:04:05")})
+					rows = append(rows, []string{fmt.Sprint(e.ID), e.Action, e.EntityType, e.EntityID, e.CreatedAt.Format("2006-01-02 15:04:05")})
 				}
 				formatTable(headers, rows)
 				return
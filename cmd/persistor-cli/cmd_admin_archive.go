@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminArchivePolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive-policy",
+		Short: "Show the forgetting policy that archives low-salience, unused nodes",
+		Run: func(cmd *cobra.Command, args []string) {
+			policy, err := apiClient.Admin.ArchivePolicy(context.Background())
+			if err != nil {
+				fatal("archive policy", err)
+			}
+			output(policy, formatArchivePolicy(policy))
+		},
+	}
+	cmd.AddCommand(adminArchivePolicySetCmd())
+	return cmd
+}
+
+func adminArchivePolicySetCmd() *cobra.Command {
+	var salienceBelow float64
+	var idleDays int
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the forgetting policy (omit both flags to disable archival)",
+		Run: func(cmd *cobra.Command, args []string) {
+			var req clientmodels.ArchivePolicy
+			if salienceBelow > 0 {
+				req.SalienceBelow = &salienceBelow
+			}
+			if idleDays > 0 {
+				req.IdleDays = &idleDays
+			}
+			policy, err := apiClient.Admin.SetArchivePolicy(context.Background(), req)
+			if err != nil {
+				fatal("set archive policy", err)
+			}
+			output(policy, formatArchivePolicy(policy))
+		},
+	}
+	cmd.Flags().Float64Var(&salienceBelow, "salience-below", 0, "Archive nodes whose salience is below this score")
+	cmd.Flags().IntVar(&idleDays, "idle-days", 0, "Archive only nodes nobody has read or updated for N days")
+	return cmd
+}
+
+func adminArchiveRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "archive-run",
+		Short: "Apply the forgetting policy now",
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Admin.RunArchive(context.Background())
+			if err != nil {
+				fatal("archive run", err)
+			}
+			output(result, fmt.Sprintf("archived %d nodes, %d edges", result.Nodes, result.Edges))
+		},
+	}
+}
+
+func formatArchivePolicy(p *clientmodels.ArchivePolicy) string {
+	if !p.Enabled() {
+		return "archival: off"
+	}
+	return fmt.Sprintf("archive below salience %g after %d idle days", *p.SalienceBelow, *p.IdleDays)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminBackfillJobCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Follow or cancel embedding backfills",
+		Long: `Backfills are started with "persistor admin backfill-embeddings", which prints
+the job ID. The server tracks recent backfills in memory until it restarts.`,
+	}
+	cmd.AddCommand(adminBackfillStatusCmd())
+	cmd.AddCommand(adminBackfillCancelCmd())
+	return cmd
+}
+
+func adminBackfillStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [job-id]",
+		Short: "Show a backfill's progress, or list recent backfills",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				job, err := apiClient.Admin.BackfillStatus(context.Background(), args[0])
+				if err != nil {
+					fatal("admin backfill status", err)
+				}
+				output(job, backfillSummary(job))
+				return
+			}
+
+			jobs, err := apiClient.Admin.ListBackfills(context.Background())
+			if err != nil {
+				fatal("admin backfill status", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(jobs))
+				for _, j := range jobs {
+					rows = append(rows, []string{
+						j.ID, j.Status, strconv.Itoa(j.Processed), strconv.Itoa(j.Remaining),
+						strconv.Itoa(j.Failed), formatOptionalTime(&j.CreatedAt),
+					})
+				}
+				formatTable([]string{"ID", "STATUS", "PROCESSED", "REMAINING", "FAILED", "STARTED"}, rows)
+				return
+			}
+			output(jobs, fmt.Sprintf("%d", len(jobs)))
+		},
+	}
+}
+
+func adminBackfillCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <job-id>",
+		Short: "Cancel a backfill; jobs it still has queued are skipped",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			job, err := apiClient.Admin.CancelBackfill(context.Background(), args[0])
+			if err != nil {
+				fatal("admin backfill cancel", err)
+			}
+			output(job, backfillSummary(job))
+		},
+	}
+}
+
+// backfillSummary is the one-line text form of a backfill job.
+func backfillSummary(job *clientmodels.BackfillJob) string {
+	return fmt.Sprintf("%s %s processed=%d remaining=%d failed=%d skipped=%d dropped=%d",
+		job.ID, job.Status, job.Processed, job.Remaining, job.Failed, job.Skipped, job.Dropped)
+}
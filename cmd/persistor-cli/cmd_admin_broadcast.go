@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminBroadcastCmd() *cobra.Command {
+	var level string
+	var tenantID string
+
+	cmd := &cobra.Command{
+		Use:   "broadcast <message>",
+		Short: "Send an operator message to connected WebSocket clients (operator only)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Admin.Broadcast(context.Background(), clientmodels.BroadcastRequest{
+				Message:  args[0],
+				Level:    level,
+				TenantID: tenantID,
+			})
+			if err != nil {
+				fatal("broadcast", err)
+			}
+			output(result, fmt.Sprintf("%s to %s", result.Status, result.Target))
+		},
+	}
+	cmd.Flags().StringVar(&level, "level", "info", "Message level: info, warning, or critical")
+	cmd.Flags().StringVar(&tenantID, "tenant", "", "Only notify clients of this tenant (default: all tenants)")
+	return cmd
+}
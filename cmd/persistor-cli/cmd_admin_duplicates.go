@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminDuplicatesCmd() *cobra.Command {
+	var limit int
+	var minScore float64
+	var typeFilter string
+
+	cmd := &cobra.Command{
+		Use:   "duplicates",
+		Short: "Find probable duplicate nodes by label and embedding similarity",
+		Long: `Find probable duplicate nodes of the same type, scored by label trigram
+similarity and embedding cosine similarity. Merge a pair with:
+
+  persistor node merge <duplicate> <canonical>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			duplicates, err := apiClient.Admin.ListDuplicates(context.Background(), clientmodels.DuplicateListOpts{
+				Type:     typeFilter,
+				Limit:    limit,
+				MinScore: minScore,
+			})
+			if err != nil {
+				fatal("duplicates", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(duplicates))
+				for _, d := range duplicates {
+					embedding := "-"
+					if d.EmbeddingSimilarity != nil {
+						embedding = fmt.Sprintf("%.2f", *d.EmbeddingSimilarity)
+					}
+					rows = append(rows, []string{
+						d.Canonical.ID,
+						d.Duplicate.ID,
+						fmt.Sprintf("%.2f", d.Score),
+						fmt.Sprintf("%.2f", d.LabelSimilarity),
+						embedding,
+					})
+				}
+				formatTable([]string{"CANONICAL", "DUPLICATE", "SCORE", "LABEL_SIM", "EMBEDDING_SIM"}, rows)
+				return
+			}
+			output(map[string]any{"duplicates": duplicates}, fmt.Sprintf("%d", len(duplicates)))
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 25, "Maximum number of pairs to return")
+	cmd.Flags().Float64Var(&minScore, "min-score", clientmodels.DefaultDuplicateMinScore, "Minimum pair score to include")
+	cmd.Flags().StringVar(&typeFilter, "type", "", "Filter to a single node type")
+	return cmd
+}
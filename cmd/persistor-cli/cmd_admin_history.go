@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminHistoryRetentionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history-retention",
+		Short: "Show the property history retention policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			retention, err := apiClient.Admin.HistoryRetention(context.Background())
+			if err != nil {
+				fatal("history retention", err)
+			}
+			output(retention, formatHistoryRetention(retention))
+		},
+	}
+	cmd.AddCommand(adminHistoryRetentionSetCmd())
+	return cmd
+}
+
+func adminHistoryRetentionSetCmd() *cobra.Command {
+	var retentionDays, compactAfterDays int
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the property history retention policy (0 disables a step)",
+		Run: func(cmd *cobra.Command, args []string) {
+			var req clientmodels.HistoryRetention
+			if retentionDays > 0 {
+				req.RetentionDays = &retentionDays
+			}
+			if compactAfterDays > 0 {
+				req.CompactAfterDays = &compactAfterDays
+			}
+			retention, err := apiClient.Admin.SetHistoryRetention(context.Background(), req)
+			if err != nil {
+				fatal("set history retention", err)
+			}
+			output(retention, formatHistoryRetention(retention))
+		},
+	}
+	cmd.Flags().IntVar(&retentionDays, "retention-days", 0, "Delete history older than N days")
+	cmd.Flags().IntVar(&compactAfterDays, "compact-after-days", 0, "Keep only the first and last change per key per day for history older than N days")
+	return cmd
+}
+
+func adminHistoryPruneCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history-prune",
+		Short: "Apply the property history retention policy now",
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Admin.PruneHistory(context.Background())
+			if err != nil {
+				fatal("history prune", err)
+			}
+			output(result, fmt.Sprintf("deleted %d, compacted %d", result.Deleted, result.Compacted))
+		},
+	}
+}
+
+func formatHistoryRetention(r *clientmodels.HistoryRetention) string {
+	days := func(v *int) string {
+		if v == nil {
+			return "off"
+		}
+		return fmt.Sprintf("%d days", *v)
+	}
+	return fmt.Sprintf("retention: %s, compaction: %s", days(r.RetentionDays), days(r.CompactAfterDays))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminIndexAdvisorCmd() *cobra.Command {
+	var req clientmodels.IndexAdvisorRequest
+
+	cmd := &cobra.Command{
+		Use:   "index-advisor",
+		Short: "Recommend node indexes from query statistics (operator only)",
+		Long: `Analyze pg_stat_statements for queries that filter nodes by type, by props
+(tags and other SEARCHABLE_PROPERTIES), or both, and report the index that
+serves each combination. Combinations no index covers that run at least
+--min-calls times with a mean of at least --min-mean-ms get a recommended
+CREATE INDEX statement. Needs the pg_stat_statements extension.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			report, err := apiClient.Admin.IndexAdvice(context.Background(), req)
+			if err != nil {
+				fatal("admin index-advisor", err)
+			}
+			printIndexAdvisorReport(report)
+		},
+	}
+	indexAdvisorFlags(cmd, &req)
+	cmd.AddCommand(adminIndexAdvisorCreateCmd())
+	return cmd
+}
+
+func adminIndexAdvisorCreateCmd() *cobra.Command {
+	var req clientmodels.IndexAdvisorRequest
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Build the recommended node indexes in the background (operator only)",
+		Long: `Build every index "persistor admin index-advisor" recommends, concurrently
+unless the graph tables are partitioned. An index that needs an extension
+that is not installed, such as btree_gin, is left out. Follow progress with
+"persistor admin index-advisor".`,
+		Run: func(cmd *cobra.Command, args []string) {
+			report, err := apiClient.Admin.CreateAdvisedIndexes(context.Background(), req)
+			if err != nil {
+				fatal("admin index-advisor create", err)
+			}
+			printIndexAdvisorReport(report)
+		},
+	}
+	indexAdvisorFlags(cmd, &req)
+	return cmd
+}
+
+func indexAdvisorFlags(cmd *cobra.Command, req *clientmodels.IndexAdvisorRequest) {
+	cmd.Flags().Int64Var(&req.MinCalls, "min-calls", 0, "Calls before an index is recommended (default 100)")
+	cmd.Flags().Float64Var(&req.MinMeanMs, "min-mean-ms", 0, "Mean milliseconds before an index is recommended (default 5)")
+}
+
+func printIndexAdvisorReport(r *clientmodels.IndexAdvisorReport) {
+	if flagFmt == "table" {
+		printIndexAdvice(r)
+		return
+	}
+	output(r, indexAdvisorSummary(r))
+}
+
+func printIndexAdvice(r *clientmodels.IndexAdvisorReport) {
+	rows := make([][]string, 0, len(r.Advice))
+	for _, a := range r.Advice {
+		index := a.CoveredBy
+		if a.Recommended != nil {
+			index = "recommended: " + a.Definition
+			if a.Recommended.Requires != "" {
+				index += " (needs " + a.Recommended.Requires + ")"
+			}
+		}
+		rows = append(rows, []string{
+			strings.Join(a.Filters, "+"), strconv.Itoa(a.Statements), strconv.FormatInt(a.Calls, 10),
+			strconv.FormatFloat(a.MeanTimeMs, 'f', 2, 64), index,
+		})
+	}
+	formatTable([]string{"FILTERS", "STATEMENTS", "CALLS", "MEAN MS", "INDEX"}, rows)
+}
+
+// indexAdvisorSummary is the text form of the index advisor report.
+func indexAdvisorSummary(r *clientmodels.IndexAdvisorReport) string {
+	if !r.StatsAvailable {
+		return "pg_stat_statements is not installed; nothing to analyze"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d filter combinations", len(r.Advice))
+	for _, a := range r.Advice {
+		if a.Recommended != nil {
+			fmt.Fprintf(&b, "\nrecommended for %s: %s", strings.Join(a.Filters, "+"), a.Definition)
+		}
+	}
+	if r.Building {
+		b.WriteString("\n(index build running)")
+	}
+	if r.LastError != "" {
+		b.WriteString("\nlast build failed: " + r.LastError)
+	}
+	return b.String()
+}
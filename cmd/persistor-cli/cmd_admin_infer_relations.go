@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminInferRelationsCmd() *cobra.Command {
+	var req clientmodels.CoAccessInferenceRequest
+
+	cmd := &cobra.Command{
+		Use:   "infer-relations",
+		Short: "Infer weak related_to edges between nodes that are often accessed together",
+		Long: `Score node pairs by how often searches and sessions returned them together,
+with each co-access counting less as it ages, and reconcile related_to edges
+with the pairs that score at least --min-score. Edges created this way are
+reweighted or removed on later runs; existing edges are never touched.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Admin.InferCoAccessRelations(context.Background(), req)
+			if err != nil {
+				fatal("infer-relations", err)
+			}
+			output(result, fmt.Sprintf("pairs=%d created=%d updated=%d removed=%d",
+				result.Pairs, result.Created, result.Updated, result.Removed))
+		},
+	}
+	cmd.Flags().Float64Var(&req.HalfLifeDays, "half-life-days", clientmodels.DefaultCoAccessHalfLifeDays, "Days after which a co-access counts half as much")
+	cmd.Flags().Float64Var(&req.MinScore, "min-score", clientmodels.DefaultCoAccessMinScore, "Decayed co-access score a pair needs for an edge")
+	cmd.Flags().IntVar(&req.WindowDays, "window-days", clientmodels.DefaultCoAccessWindowDays, "Forget accesses older than this many days")
+	cmd.Flags().IntVar(&req.MaxEdges, "max-edges", clientmodels.DefaultCoAccessMaxEdges, "Keep at most this many inferred edges, highest scoring first")
+	return cmd
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage named API keys",
+		Long: `Named API keys work alongside the tenant's primary key (see "persistor keys").
+Each has its own scope and optional expiry and can be revoked on its own.`,
+	}
+	cmd.AddCommand(adminKeyCreateCmd())
+	cmd.AddCommand(adminKeyListCmd())
+	cmd.AddCommand(adminKeyRevokeCmd())
+	cmd.AddCommand(adminKeyRotateCmd())
+	return cmd
+}
+
+func adminKeyCreateCmd() *cobra.Command {
+	var (
+		scope     string
+		expiresIn time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a named API key",
+		Long:  `Create a named API key. The key is shown once and cannot be retrieved again.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := clientmodels.CreateAPIKeyRequest{Name: args[0], Scope: scope}
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				req.ExpiresAt = &expiresAt
+			}
+			created, err := apiClient.Keys.Create(context.Background(), req)
+			if err != nil {
+				fatal("admin key create", err)
+			}
+			output(created, created.APIKey)
+			fmt.Fprintf(os.Stderr, "Created key %s. Store it now; it cannot be shown again.\n", created.ID)
+		},
+	}
+	cmd.Flags().StringVar(&scope, "scope", clientmodels.APIKeyScopeReadWrite, "Key scope (search, read, read_write, or admin)")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "Expire the key after this long, e.g. 720h (default never)")
+	return cmd
+}
+
+func adminKeyListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List named API keys, including revoked and expired ones",
+		Run: func(cmd *cobra.Command, args []string) {
+			keys, err := apiClient.Keys.List(context.Background())
+			if err != nil {
+				fatal("admin key list", err)
+			}
+			if flagFmt == "table" {
+				now := time.Now()
+				rows := make([][]string, 0, len(keys))
+				for _, k := range keys {
+					rows = append(rows, []string{
+						k.ID, k.Name, k.Scope, managedKeyState(&k, now),
+						formatOptionalTime(k.ExpiresAt), formatOptionalTime(k.LastUsedAt),
+					})
+				}
+				formatTable([]string{"ID", "NAME", "SCOPE", "STATE", "EXPIRES", "LAST USED"}, rows)
+				return
+			}
+			output(keys, fmt.Sprintf("%d", len(keys)))
+		},
+	}
+}
+
+func adminKeyRevokeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke a named API key",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			key, err := apiClient.Keys.Revoke(context.Background(), args[0])
+			if err != nil {
+				fatal("admin key revoke", err)
+			}
+			output(key, "revoked")
+		},
+	}
+}
+
+func adminKeyRotateCmd() *cobra.Command {
+	var graceHours int
+
+	cmd := &cobra.Command{
+		Use:   "rotate <id>",
+		Short: "Replace a named API key, keeping the old one valid for a grace period",
+		Long: `Replace a named API key with a new one of the same name, scope, and expiry.
+The old key keeps working for --grace-hours; use 0 to revoke it immediately.
+The new key is shown once and cannot be retrieved again.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rotated, err := apiClient.Keys.RotateKey(context.Background(), args[0], clientmodels.RotateAPIKeyRequest{GraceHours: &graceHours})
+			if err != nil {
+				fatal("admin key rotate", err)
+			}
+			output(rotated, rotated.APIKey)
+			if rotated.Previous.RevokedAt == nil && rotated.Previous.ExpiresAt != nil {
+				fmt.Fprintf(os.Stderr, "The old key remains valid until %s.\n", rotated.Previous.ExpiresAt.Format(time.RFC3339))
+			}
+		},
+	}
+	cmd.Flags().IntVar(&graceHours, "grace-hours", clientmodels.DefaultAPIKeyGraceHours, "Hours the old key stays valid (0 revokes it immediately)")
+	return cmd
+}
+
+// managedKeyState summarizes whether a named key is still accepted.
+func managedKeyState(k *clientmodels.ManagedAPIKey, now time.Time) string {
+	switch {
+	case k.RevokedAt != nil:
+		return "revoked"
+	case !k.Active(now):
+		return "expired"
+	default:
+		return "active"
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
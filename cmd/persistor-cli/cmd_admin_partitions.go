@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func adminPartitionsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "partitions",
+		Short: "Show the size of each tenant partition of the graph tables (operator only)",
+		Run: func(cmd *cobra.Command, args []string) {
+			partitions, err := apiClient.Admin.Partitions(context.Background())
+			if err != nil {
+				fatal("partitions", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(partitions))
+				for _, p := range partitions {
+					rows = append(rows, []string{
+						p.Partition,
+						fmt.Sprintf("%d/%d", p.Remainder, p.Modulus),
+						fmt.Sprintf("%d", p.Rows),
+						fmt.Sprintf("%d", p.TableBytes),
+						fmt.Sprintf("%d", p.IndexBytes),
+						fmt.Sprintf("%d", p.TotalBytes),
+					})
+				}
+				formatTable([]string{"PARTITION", "REMAINDER", "ROWS (EST)", "TABLE BYTES", "INDEX BYTES", "TOTAL BYTES"}, rows)
+				return
+			}
+			output(partitions, fmt.Sprintf("%d", len(partitions)))
+		},
+	}
+}
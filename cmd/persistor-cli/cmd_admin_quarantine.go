@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func adminQuarantineCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "quarantine",
+		Short: "List nodes quarantined as likely prompt injection",
+		Long: `List nodes the prompt-injection scanner (INJECTION_SCAN=true) flagged at
+write time, with the field and pattern that matched. Quarantined nodes are
+left out of recall packs. After review, release a node with:
+
+  persistor admin quarantine release <node-id>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			nodes, err := apiClient.Admin.ListQuarantined(context.Background(), limit)
+			if err != nil {
+				fatal("admin quarantine", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(nodes))
+				for _, n := range nodes {
+					rows = append(rows, []string{n.NodeID, n.Label, n.Field, n.Pattern, n.FlaggedAt.Format("2006-01-02 15:04")})
+				}
+				formatTable([]string{"NODE", "LABEL", "FIELD", "PATTERN", "FLAGGED"}, rows)
+				return
+			}
+			output(map[string]any{"nodes": nodes}, fmt.Sprintf("%d", len(nodes)))
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum nodes to list (default 100, max 1000)")
+	cmd.AddCommand(&cobra.Command{
+		Use:   "release <node-id>",
+		Short: "Release a quarantined node after review",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := apiClient.Admin.ReleaseQuarantined(context.Background(), args[0]); err != nil {
+				fatal("admin quarantine release", err)
+			}
+			output(map[string]any{"released": args[0]}, args[0])
+		},
+	})
+	return cmd
+}
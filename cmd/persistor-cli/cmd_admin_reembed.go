@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminReembedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reembed",
+		Short: "Regenerate embeddings produced by another embedding model",
+		Long: `Run after changing EMBEDDING_MODEL or EMBEDDING_DIMENSIONS. The server
+regenerates, in batches, every embedding the configured model did not
+produce. Semantic search is refused until the run completes; hybrid search
+falls back to full-text.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			run, err := apiClient.Admin.StartReembed(context.Background())
+			if err != nil {
+				fatal("admin reembed", err)
+			}
+			output(run, reembedSummary(run))
+		},
+	}
+	cmd.AddCommand(adminReembedStatusCmd())
+	return cmd
+}
+
+func adminReembedStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the progress of the latest re-embed",
+		Run: func(cmd *cobra.Command, args []string) {
+			run, err := apiClient.Admin.ReembedStatus(context.Background())
+			if err != nil {
+				fatal("admin reembed status", err)
+			}
+			output(run, reembedSummary(run))
+		},
+	}
+}
+
+// reembedSummary is the one-line text form of a re-embed run.
+func reembedSummary(run *clientmodels.ReembedStatus) string {
+	s := fmt.Sprintf("%s model=%s processed=%d remaining=%d failed=%d",
+		run.Status, run.TargetModel, run.Processed, run.Remaining, run.Failed)
+	if run.Error != "" {
+		s += " error=" + run.Error
+	}
+	return s
+}
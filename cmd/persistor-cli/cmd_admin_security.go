@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func adminSecurityBlocksCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "security-blocks",
+		Short: "List API keys locked out after repeated authentication failures (operator only)",
+		Run: func(cmd *cobra.Command, args []string) {
+			blocks, err := apiClient.Admin.SecurityBlocks(context.Background())
+			if err != nil {
+				fatal("security blocks", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(blocks))
+				for _, b := range blocks {
+					rows = append(rows, []string{
+						b.KeyHash,
+						fmt.Sprintf("%d", b.Attempts),
+						b.FirstFailureAt.Format(time.RFC3339),
+						b.BlockedUntil.Format(time.RFC3339),
+					})
+				}
+				formatTable([]string{"KEY HASH", "ATTEMPTS", "FIRST FAILURE", "BLOCKED UNTIL"}, rows)
+				return
+			}
+			output(blocks, fmt.Sprintf("%d", len(blocks)))
+		},
+	}
+}
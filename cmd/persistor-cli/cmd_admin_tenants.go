@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminTenantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Manage tenants (operator tenants only)",
+		Long: `Create, suspend, and delete tenants. These commands need an admin key of an
+operator tenant. A tenant must be suspended before it can be deleted.`,
+	}
+	cmd.AddCommand(adminTenantCreateCmd())
+	cmd.AddCommand(adminTenantListCmd())
+	cmd.AddCommand(adminTenantGetCmd())
+	cmd.AddCommand(adminTenantUpdateCmd())
+	cmd.AddCommand(adminTenantRotateKeyCmd())
+	cmd.AddCommand(adminTenantSuspendCmd())
+	cmd.AddCommand(adminTenantResumeCmd())
+	cmd.AddCommand(adminTenantDeleteCmd())
+	cmd.AddCommand(adminTenantImpersonateCmd())
+	return cmd
+}
+
+func adminTenantCreateCmd() *cobra.Command {
+	var req clientmodels.CreateTenantRequest
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a tenant",
+		Long:  `Create a tenant. Its primary API key is shown once and cannot be retrieved again.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req.Name = args[0]
+			created, err := apiClient.Admin.Tenants.Create(context.Background(), req)
+			if err != nil {
+				fatal("admin tenant create", err)
+			}
+			output(created, created.APIKey)
+			fmt.Fprintf(os.Stderr, "Created tenant %s. Store its key now; it cannot be shown again.\n", created.ID)
+		},
+	}
+	cmd.Flags().StringVar(&req.Plan, "plan", clientmodels.DefaultTenantPlan, "Rate limit plan")
+	cmd.Flags().StringVar(&req.Scope, "scope", clientmodels.APIKeyScopeAdmin, "Scope of the primary key (search, read, read_write, or admin)")
+	return cmd
+}
+
+func adminTenantListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List tenants",
+		Run: func(cmd *cobra.Command, args []string) {
+			tenants, err := apiClient.Admin.Tenants.List(context.Background())
+			if err != nil {
+				fatal("admin tenant list", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(tenants))
+				for _, t := range tenants {
+					rows = append(rows, []string{
+						t.ID, t.Name, t.Plan, tenantState(&t),
+						t.CreatedAt.Format(time.RFC3339), formatOptionalTime(t.SuspendedAt),
+					})
+				}
+				formatTable([]string{"ID", "NAME", "PLAN", "STATE", "CREATED", "SUSPENDED"}, rows)
+				return
+			}
+			output(tenants, fmt.Sprintf("%d", len(tenants)))
+		},
+	}
+}
+
+func adminTenantGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a tenant",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tenant, err := apiClient.Admin.Tenants.Get(context.Background(), args[0])
+			if err != nil {
+				fatal("admin tenant get", err)
+			}
+			output(tenant, tenantState(tenant))
+		},
+	}
+}
+
+func adminTenantUpdateCmd() *cobra.Command {
+	var (
+		name, plan                          string
+		maxNodes, maxEdges, maxStorageBytes int64
+		maxEmbeddingTokens, maxLLMTokens    int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <id>",
+		Short: "Rename a tenant or change its plan or quotas",
+		Long: `Rename a tenant or change its plan or quotas. Only the flags given are
+changed; a quota of 0 removes the limit.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var req clientmodels.UpdateTenantRequest
+			if cmd.Flags().Changed("name") {
+				req.Name = &name
+			}
+			if cmd.Flags().Changed("plan") {
+				req.Plan = &plan
+			}
+			if cmd.Flags().Changed("max-nodes") {
+				req.MaxNodes = &maxNodes
+			}
+			if cmd.Flags().Changed("max-edges") {
+				req.MaxEdges = &maxEdges
+			}
+			if cmd.Flags().Changed("max-storage-bytes") {
+				req.MaxStorageBytes = &maxStorageBytes
+			}
+			if cmd.Flags().Changed("max-monthly-embedding-tokens") {
+				req.MaxMonthlyEmbeddingTokens = &maxEmbeddingTokens
+			}
+			if cmd.Flags().Changed("max-monthly-llm-tokens") {
+				req.MaxMonthlyLLMTokens = &maxLLMTokens
+			}
+			tenant, err := apiClient.Admin.Tenants.Update(context.Background(), args[0], req)
+			if err != nil {
+				fatal("admin tenant update", err)
+			}
+			output(tenant, "updated")
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "New tenant name")
+	cmd.Flags().StringVar(&plan, "plan", "", "New rate limit plan")
+	cmd.Flags().Int64Var(&maxNodes, "max-nodes", 0, "Node quota (0 for unlimited)")
+	cmd.Flags().Int64Var(&maxEdges, "max-edges", 0, "Edge quota (0 for unlimited)")
+	cmd.Flags().Int64Var(&maxStorageBytes, "max-storage-bytes", 0, "Storage quota in bytes (0 for unlimited)")
+	cmd.Flags().Int64Var(&maxEmbeddingTokens, "max-monthly-embedding-tokens", 0, "Embedding tokens per calendar month (0 for unlimited)")
+	cmd.Flags().Int64Var(&maxLLMTokens, "max-monthly-llm-tokens", 0, "LLM tokens per calendar month (0 for unlimited)")
+	return cmd
+}
+
+func adminTenantRotateKeyCmd() *cobra.Command {
+	var graceHours int
+
+	cmd := &cobra.Command{
+		Use:   "rotate-key <id>",
+		Short: "Replace a tenant's primary API key",
+		Long: `Replace a tenant's primary API key, for example when its only admin key was
+lost. The old key keeps working for --grace-hours; use 0 to revoke it
+immediately. The new key is shown once and cannot be retrieved again.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rotation, err := apiClient.Admin.Tenants.RotateKey(context.Background(), args[0], clientmodels.RotateAPIKeyRequest{GraceHours: &graceHours})
+			if err != nil {
+				fatal("admin tenant rotate-key", err)
+			}
+			output(rotation, rotation.APIKey)
+			if rotation.PreviousKeyExpiresAt != nil {
+				fmt.Fprintf(os.Stderr, "The old key remains valid until %s.\n", rotation.PreviousKeyExpiresAt.Format(time.RFC3339))
+			}
+		},
+	}
+	cmd.Flags().IntVar(&graceHours, "grace-hours", clientmodels.DefaultAPIKeyGraceHours, "Hours the old key stays valid (0 revokes it immediately)")
+	return cmd
+}
+
+func adminTenantSuspendCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "suspend <id>",
+		Short: "Reject every key of a tenant until it is resumed",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tenant, err := apiClient.Admin.Tenants.Suspend(context.Background(), args[0])
+			if err != nil {
+				fatal("admin tenant suspend", err)
+			}
+			output(tenant, "suspended")
+		},
+	}
+}
+
+func adminTenantResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Accept a suspended tenant's keys again",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tenant, err := apiClient.Admin.Tenants.Resume(context.Background(), args[0])
+			if err != nil {
+				fatal("admin tenant resume", err)
+			}
+			output(tenant, "active")
+		},
+	}
+}
+
+func adminTenantDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <id>",
+		Short: "Delete a suspended tenant and purge all of its data",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !yes {
+				fmt.Printf("Delete tenant %s and all of its data? This cannot be undone. [y/N]: ", args[0])
+				line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+					fmt.Println("Aborted.")
+					return
+				}
+			}
+			result, err := apiClient.Admin.Tenants.Delete(context.Background(), args[0])
+			if err != nil {
+				fatal("admin tenant delete", err)
+			}
+			output(result, fmt.Sprint(result.DeletedRows))
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+	return cmd
+}
+
+func adminTenantImpersonateCmd() *cobra.Command {
+	var req clientmodels.ImpersonateTenantRequest
+	var ttlMinutes int
+
+	cmd := &cobra.Command{
+		Use:   "impersonate <id>",
+		Short: "Issue a short-lived key to act as a tenant for support",
+		Long: `Issue a short-lived key that authenticates as the tenant through the normal
+API, for reproducing a customer's issue without handling its keys. The reason
+is recorded in both tenants' audit logs, and every request made with the key
+is audited in the tenant's log. The key cannot have admin scope, is shown
+once, and can be revoked early with the tenant's key commands.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req.TTLMinutes = &ttlMinutes
+			key, err := apiClient.Admin.Tenants.Impersonate(context.Background(), args[0], req)
+			if err != nil {
+				fatal("admin tenant impersonate", err)
+			}
+			output(key, key.APIKey)
+			if key.ExpiresAt != nil {
+				fmt.Fprintf(os.Stderr, "The key expires at %s.\n", key.ExpiresAt.Format(time.RFC3339))
+			}
+		},
+	}
+	cmd.Flags().StringVar(&req.Reason, "reason", "", "Why the tenant is impersonated, such as a ticket reference (required)")
+	cmd.Flags().StringVar(&req.Scope, "scope", clientmodels.APIKeyScopeRead, "Key scope: search, read, or read_write")
+	cmd.Flags().IntVar(&ttlMinutes, "ttl", clientmodels.DefaultImpersonationMinutes, "Minutes the key stays valid")
+	_ = cmd.MarkFlagRequired("reason")
+	return cmd
+}
+
+// tenantState summarizes whether a tenant's keys are accepted.
+func tenantState(t *clientmodels.Tenant) string {
+	if t.SuspendedAt != nil {
+		return "suspended"
+	}
+	return "active"
+}
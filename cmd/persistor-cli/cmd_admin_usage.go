@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func adminUsageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "usage",
+		Short: "Show resource usage against the tenant's quotas",
+		Run: func(cmd *cobra.Command, args []string) {
+			usage, err := apiClient.Usage(context.Background())
+			if err != nil {
+				fatal("usage", err)
+			}
+			if flagFmt == "table" {
+				formatTable(
+					[]string{"RESOURCE", "USED", "LIMIT"},
+					[][]string{
+						{"Nodes", fmt.Sprintf("%d", usage.Nodes), formatQuota(usage.Quota.MaxNodes)},
+						{"Edges", fmt.Sprintf("%d", usage.Edges), formatQuota(usage.Quota.MaxEdges)},
+						{"Storage Bytes", fmt.Sprintf("%d", usage.StorageBytes), formatQuota(usage.Quota.MaxStorageBytes)},
+						{"Embedding Requests (month)", fmt.Sprintf("%d", usage.Embedding.Requests), "-"},
+						{"Embedding Tokens (month)", fmt.Sprintf("%d", usage.Embedding.Tokens), formatQuota(usage.Quota.MaxMonthlyEmbeddingTokens)},
+						{"LLM Requests (month)", fmt.Sprintf("%d", usage.LLM.Requests), "-"},
+						{"LLM Tokens (month)", fmt.Sprintf("%d", usage.LLM.Tokens), formatQuota(usage.Quota.MaxMonthlyLLMTokens)},
+					},
+				)
+				return
+			}
+			output(usage, "")
+		},
+	}
+}
+
+func formatQuota(limit *int64) string {
+	if limit == nil {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", *limit)
+}
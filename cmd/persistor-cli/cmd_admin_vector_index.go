@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func adminVectorIndexCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vector-index",
+		Short: "Show the node embedding index and sequential scan warnings (operator only)",
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := apiClient.Admin.VectorIndex(context.Background())
+			if err != nil {
+				fatal("admin vector-index", err)
+			}
+			output(status, vectorIndexSummary(status))
+		},
+	}
+	cmd.AddCommand(adminVectorIndexRebuildCmd())
+	return cmd
+}
+
+func adminVectorIndexRebuildCmd() *cobra.Command {
+	var req clientmodels.VectorIndexRequest
+
+	cmd := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Build a new node embedding index in the background (operator only)",
+		Long: `Build a new HNSW or IVFFlat index beside the current one and swap it in
+when it is ready, so semantic search keeps an index throughout. Parameters
+left unset are sized from the number of embedded nodes. When the graph
+tables are partitioned, node writes wait for the build. Follow progress
+with "persistor admin vector-index".`,
+		Run: func(cmd *cobra.Command, args []string) {
+			params, err := apiClient.Admin.RebuildVectorIndex(context.Background(), req)
+			if err != nil {
+				fatal("admin vector-index rebuild", err)
+			}
+			output(params, "building "+vectorIndexParams(*params))
+		},
+	}
+	cmd.Flags().StringVar(&req.Method, "method", "", "Index method: hnsw (default) or ivfflat")
+	cmd.Flags().IntVar(&req.M, "m", 0, "HNSW connections per node (2–100)")
+	cmd.Flags().IntVar(&req.EfConstruction, "ef-construction", 0, "HNSW candidate list size while building (4–1000, at least twice m)")
+	cmd.Flags().IntVar(&req.Lists, "lists", 0, "IVFFlat lists (1–32768)")
+	return cmd
+}
+
+// vectorIndexParams is the text form of an index method and its parameters.
+func vectorIndexParams(p clientmodels.VectorIndexRequest) string {
+	if p.Method == clientmodels.VectorIndexIVFFlat {
+		return fmt.Sprintf("ivfflat lists=%d", p.Lists)
+	}
+	return fmt.Sprintf("%s m=%d ef_construction=%d", p.Method, p.M, p.EfConstruction)
+}
+
+// vectorIndexSummary is the text form of the vector index status.
+func vectorIndexSummary(s *clientmodels.VectorIndexStatus) string {
+	var b strings.Builder
+	switch {
+	case !s.Exists:
+		b.WriteString("no vector index")
+	case !s.Valid:
+		b.WriteString("invalid " + vectorIndexParams(s.Params))
+	default:
+		fmt.Fprintf(&b, "%s size=%d index_scans=%d", vectorIndexParams(s.Params), s.SizeBytes, s.IndexScans)
+	}
+	fmt.Fprintf(&b, " embedded_nodes~%d seq_scans=%d", s.EmbeddedNodes, s.TableSeqScans)
+	if s.Building {
+		b.WriteString(" (rebuild running)")
+	}
+	if s.LastError != "" {
+		b.WriteString("\nlast build failed: " + s.LastError)
+	}
+	fmt.Fprintf(&b, "\nrecommended: %s", vectorIndexParams(s.Recommended))
+	for _, w := range s.Warnings {
+		b.WriteString("\nwarning: " + w)
+	}
+	return b.String()
+}
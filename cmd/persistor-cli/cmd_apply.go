@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Apply actions, in the order they are planned for each tenant.
+const (
+	applyCreateTenant  = "create_tenant"
+	applyUpdateTenant  = "update_tenant"
+	applySuspendTenant = "suspend_tenant"
+	applyResumeTenant  = "resume_tenant"
+	applyCreateKey     = "create_key"
+	applyRevokeKey     = "revoke_key"
+)
+
+// applyStep is one change needed to reach the file's state. Steps for a
+// tenant that does not exist yet have no tenantID until it is created.
+type applyStep struct {
+	Action string `json:"action"`
+	Tenant string `json:"tenant"`
+	Detail string `json:"detail,omitempty"`
+	APIKey string `json:"api_key,omitempty"`
+
+	tenantID string
+	create   models.CreateTenantRequest
+	update   models.UpdateTenantRequest
+	key      models.CreateAPIKeyRequest
+	keyID    string
+}
+
+func newApplyCmd() *cobra.Command {
+	var (
+		file   string
+		dryRun bool
+		prune  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <tenants.yaml>",
+		Short: "Reconcile tenants, quotas, and API keys from a file (operator tenants only)",
+		Long: `Make the server's tenants match a YAML file, so tenants can be managed as
+code. Tenants are matched by name and are created when missing; tenants not
+in the file are left alone, and apply never deletes a tenant.
+
+  tenants:
+    - name: acme
+      plan: pro                # omit to leave the plan unmanaged
+      suspended: false
+      quota:                   # omit to leave quotas unmanaged
+        max_nodes: 100000      # omitted limits are unlimited
+        max_storage_bytes: 1073741824
+        max_monthly_llm_tokens: 5000000
+      keys:                    # omit to leave named keys unmanaged
+        - name: ci
+          scope: read_write
+          expires_at: 2027-01-01T00:00:00Z
+
+A listed key is created when the tenant has no active key of that name, and
+replaced (a new key created, the old one revoked) when its scope or expiry
+differs. With --prune, active named keys not listed are revoked. New tenant
+and key secrets are printed once; store them when apply finishes.
+
+Run with --dry-run first to see the planned changes.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := loadTenantsFile(file)
+			if err != nil {
+				return err
+			}
+
+			steps, err := planApply(cmd.Context(), spec, prune, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if !dryRun {
+				if err := runApply(cmd.Context(), steps); err != nil {
+					printApplySteps(steps, dryRun)
+					return err
+				}
+			}
+
+			printApplySteps(steps, dryRun)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "YAML file describing the tenants (- for stdin)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the planned changes without making them")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Revoke active named keys that are not in the file")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func printApplySteps(steps []*applyStep, dryRun bool) {
+	switch flagFmt {
+	case "table":
+		rows := make([][]string, 0, len(steps))
+		for _, s := range steps {
+			rows = append(rows, []string{s.Action, s.Tenant, s.Detail, s.APIKey})
+		}
+		formatTable([]string{"ACTION", "TENANT", "DETAIL", "API_KEY"}, rows)
+	case "quiet":
+		for _, s := range steps {
+			if s.APIKey != "" {
+				fmt.Printf("%s\t%s\n", s.Tenant, s.APIKey)
+			}
+		}
+	default:
+		if steps == nil {
+			steps = []*applyStep{}
+		}
+		formatJSON(map[string]any{"dry_run": dryRun, "actions": steps})
+	}
+
+	switch {
+	case len(steps) == 0:
+		fmt.Fprintln(os.Stderr, "No changes.")
+	case dryRun:
+		fmt.Fprintf(os.Stderr, "%d changes planned; run without --dry-run to apply them.\n", len(steps))
+	default:
+		for _, s := range steps {
+			if s.APIKey != "" {
+				fmt.Fprintln(os.Stderr, "Store the new API keys now; they cannot be shown again.")
+				break
+			}
+		}
+	}
+}
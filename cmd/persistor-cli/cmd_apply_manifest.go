@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// tenantsFile is the declarative configuration read by persistor apply.
+// Unknown fields are rejected so typos are not silently ignored.
+type tenantsFile struct {
+	Tenants []tenantSpec `yaml:"tenants"`
+}
+
+// tenantSpec is the desired state of one tenant, matched by name. An omitted
+// plan, quota, or keys section leaves that part of the tenant unmanaged.
+type tenantSpec struct {
+	Name      string     `yaml:"name"`
+	Plan      string     `yaml:"plan"`
+	Suspended bool       `yaml:"suspended"`
+	Quota     *quotaSpec `yaml:"quota"`
+	Keys      []keySpec  `yaml:"keys"`
+}
+
+// quotaSpec is a tenant's desired quota. Omitted or zero limits are unlimited.
+type quotaSpec struct {
+	MaxNodes                  int64 `yaml:"max_nodes"`
+	MaxEdges                  int64 `yaml:"max_edges"`
+	MaxStorageBytes           int64 `yaml:"max_storage_bytes"`
+	MaxMonthlyEmbeddingTokens int64 `yaml:"max_monthly_embedding_tokens"`
+	MaxMonthlyLLMTokens       int64 `yaml:"max_monthly_llm_tokens"`
+}
+
+// keySpec is a named API key the tenant should have.
+type keySpec struct {
+	Name      string     `yaml:"name"`
+	Scope     string     `yaml:"scope"`
+	ExpiresAt *time.Time `yaml:"expires_at"`
+}
+
+// loadTenantsFile reads and validates a tenants file.
+func loadTenantsFile(path string) (*tenantsFile, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading tenants file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck // read-only file.
+
+		r = f
+	}
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var spec tenantsFile
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing tenants file: %w", err)
+	}
+
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("invalid tenants file: %w", err)
+	}
+
+	return &spec, nil
+}
+
+func (f *tenantsFile) validate() error {
+	names := map[string]bool{}
+
+	for i := range f.Tenants {
+		t := &f.Tenants[i]
+
+		req := models.CreateTenantRequest{Name: t.Name, Plan: t.Plan}
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("tenant %d: %w", i+1, err)
+		}
+
+		if names[t.Name] {
+			return fmt.Errorf("tenant %q is listed twice", t.Name)
+		}
+		names[t.Name] = true
+
+		if q := t.Quota; q != nil && (q.MaxNodes < 0 || q.MaxEdges < 0 || q.MaxStorageBytes < 0 ||
+			q.MaxMonthlyEmbeddingTokens < 0 || q.MaxMonthlyLLMTokens < 0) {
+			return fmt.Errorf("tenant %q: quotas must not be negative", t.Name)
+		}
+
+		keys := map[string]bool{}
+		for j := range t.Keys {
+			k := &t.Keys[j]
+
+			req := models.CreateAPIKeyRequest{Name: k.Name, Scope: k.Scope, ExpiresAt: k.ExpiresAt}
+			if err := req.Validate(); err != nil {
+				return fmt.Errorf("tenant %q key %d: %w", t.Name, j+1, err)
+			}
+			k.Scope = req.Scope
+
+			if keys[k.Name] {
+				return fmt.Errorf("tenant %q: key %q is listed twice", t.Name, k.Name)
+			}
+			keys[k.Name] = true
+		}
+	}
+
+	return nil
+}
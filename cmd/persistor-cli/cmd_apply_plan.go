@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// planApply compares the file with the server and returns the steps that
+// reconcile them, without changing anything.
+func planApply(ctx context.Context, spec *tenantsFile, prune bool, now time.Time) ([]*applyStep, error) {
+	tenants, err := apiClient.Admin.Tenants.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+
+	byName := map[string][]models.Tenant{}
+	for _, t := range tenants {
+		byName[t.Name] = append(byName[t.Name], t)
+	}
+
+	var steps []*applyStep
+
+	for i := range spec.Tenants {
+		want := &spec.Tenants[i]
+
+		matches := byName[want.Name]
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("tenant name %q is shared by %d tenants; rename them so apply can tell them apart",
+				want.Name, len(matches))
+		}
+
+		if len(matches) == 0 {
+			steps = append(steps, planNewTenant(want)...)
+			continue
+		}
+
+		tenantSteps, err := planExistingTenant(ctx, want, &matches[0], prune, now)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, tenantSteps...)
+	}
+
+	return steps, nil
+}
+
+func planNewTenant(want *tenantSpec) []*applyStep {
+	plan := want.Plan
+	if plan == "" {
+		plan = models.DefaultTenantPlan
+	}
+
+	steps := []*applyStep{{
+		Action: applyCreateTenant, Tenant: want.Name, Detail: "plan " + plan,
+		create: models.CreateTenantRequest{Name: want.Name, Plan: plan},
+	}}
+
+	if want.Quota != nil {
+		if update, detail := quotaUpdate(want.Quota, models.TenantQuota{}); detail != nil {
+			steps = append(steps, &applyStep{
+				Action: applyUpdateTenant, Tenant: want.Name, Detail: strings.Join(detail, ", "), update: update,
+			})
+		}
+	}
+
+	if want.Suspended {
+		steps = append(steps, &applyStep{Action: applySuspendTenant, Tenant: want.Name})
+	}
+
+	for _, k := range want.Keys {
+		steps = append(steps, createKeyStep(want.Name, "", k))
+	}
+
+	return steps
+}
+
+func planExistingTenant(
+	ctx context.Context, want *tenantSpec, have *models.Tenant, prune bool, now time.Time,
+) ([]*applyStep, error) {
+	var (
+		steps  []*applyStep
+		update models.UpdateTenantRequest
+		detail []string
+	)
+
+	if want.Plan != "" && want.Plan != have.Plan {
+		update.Plan = &want.Plan
+		detail = append(detail, fmt.Sprintf("plan %s -> %s", have.Plan, want.Plan))
+	}
+
+	if want.Quota != nil {
+		quota, quotaDetail := quotaUpdate(want.Quota, have.Quota)
+		update.MaxNodes, update.MaxEdges, update.MaxStorageBytes = quota.MaxNodes, quota.MaxEdges, quota.MaxStorageBytes
+		update.MaxMonthlyEmbeddingTokens, update.MaxMonthlyLLMTokens = quota.MaxMonthlyEmbeddingTokens, quota.MaxMonthlyLLMTokens
+		detail = append(detail, quotaDetail...)
+	}
+
+	if detail != nil {
+		steps = append(steps, &applyStep{
+			Action: applyUpdateTenant, Tenant: want.Name, Detail: strings.Join(detail, ", "),
+			tenantID: have.ID, update: update,
+		})
+	}
+
+	switch suspended := have.SuspendedAt != nil; {
+	case want.Suspended && !suspended:
+		steps = append(steps, &applyStep{Action: applySuspendTenant, Tenant: want.Name, tenantID: have.ID})
+	case !want.Suspended && suspended:
+		steps = append(steps, &applyStep{Action: applyResumeTenant, Tenant: want.Name, tenantID: have.ID})
+	}
+
+	if want.Keys == nil && !prune {
+		return steps, nil
+	}
+
+	keys, err := apiClient.Admin.Tenants.ListKeys(ctx, have.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing keys of %s: %w", want.Name, err)
+	}
+
+	// Keys are listed newest first, so after a rotation the first active
+	// key of a name is the current one; older ones are in their grace period.
+	active := map[string]models.ManagedAPIKey{}
+	for _, k := range keys {
+		if _, seen := active[k.Name]; !seen && k.Active(now) {
+			active[k.Name] = k
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, k := range want.Keys {
+		listed[k.Name] = true
+
+		current, ok := active[k.Name]
+		switch {
+		case !ok:
+			steps = append(steps, createKeyStep(want.Name, have.ID, k))
+		case current.Scope != k.Scope || !sameTime(current.ExpiresAt, k.ExpiresAt):
+			step := createKeyStep(want.Name, have.ID, k)
+			step.Detail += " (replaces " + current.ID + ")"
+			steps = append(steps, step, &applyStep{
+				Action: applyRevokeKey, Tenant: want.Name, Detail: k.Name + " " + current.ID,
+				tenantID: have.ID, keyID: current.ID,
+			})
+		}
+	}
+
+	if prune {
+		for _, k := range keys {
+			if current, ok := active[k.Name]; ok && current.ID == k.ID && !listed[k.Name] {
+				steps = append(steps, &applyStep{
+					Action: applyRevokeKey, Tenant: want.Name, Detail: k.Name + " " + k.ID,
+					tenantID: have.ID, keyID: k.ID,
+				})
+			}
+		}
+	}
+
+	return steps, nil
+}
+
+// quotaUpdate returns the update that moves have to want, with a description
+// of each change, or nil detail when they already match.
+func quotaUpdate(want *quotaSpec, have models.TenantQuota) (models.UpdateTenantRequest, []string) {
+	var (
+		update models.UpdateTenantRequest
+		detail []string
+	)
+
+	limits := []struct {
+		name   string
+		want   int64
+		have   *int64
+		update **int64
+	}{
+		{"max_nodes", want.MaxNodes, have.MaxNodes, &update.MaxNodes},
+		{"max_edges", want.MaxEdges, have.MaxEdges, &update.MaxEdges},
+		{"max_storage_bytes", want.MaxStorageBytes, have.MaxStorageBytes, &update.MaxStorageBytes},
+		{
+			"max_monthly_embedding_tokens", want.MaxMonthlyEmbeddingTokens,
+			have.MaxMonthlyEmbeddingTokens, &update.MaxMonthlyEmbeddingTokens,
+		},
+		{"max_monthly_llm_tokens", want.MaxMonthlyLLMTokens, have.MaxMonthlyLLMTokens, &update.MaxMonthlyLLMTokens},
+	}
+
+	for _, l := range limits {
+		var current int64
+		if l.have != nil {
+			current = *l.have
+		}
+
+		if current == l.want {
+			continue
+		}
+
+		v := l.want
+		*l.update = &v
+		detail = append(detail, fmt.Sprintf("%s %s -> %s", l.name, formatQuota(l.have), formatQuota(nonZero(v))))
+	}
+
+	return update, detail
+}
+
+func nonZero(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func createKeyStep(tenant, tenantID string, k keySpec) *applyStep {
+	return &applyStep{
+		Action: applyCreateKey, Tenant: tenant, Detail: k.Name + " (" + k.Scope + ")",
+		tenantID: tenantID,
+		key:      models.CreateAPIKeyRequest{Name: k.Name, Scope: k.Scope, ExpiresAt: k.ExpiresAt},
+	}
+}
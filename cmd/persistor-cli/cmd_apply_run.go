@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// runApply carries out the steps in order, stopping at the first failure.
+// Tenants created along the way supply the IDs of their later steps.
+func runApply(ctx context.Context, steps []*applyStep) error {
+	created := map[string]string{}
+	tenants := apiClient.Admin.Tenants
+
+	for _, s := range steps {
+		if s.tenantID == "" {
+			s.tenantID = created[s.Tenant]
+		}
+
+		var err error
+
+		switch s.Action {
+		case applyCreateTenant:
+			var t *models.CreatedTenant
+			if t, err = tenants.Create(ctx, s.create); err == nil {
+				created[s.Tenant], s.tenantID, s.APIKey = t.ID, t.ID, t.APIKey
+			}
+		case applyUpdateTenant:
+			_, err = tenants.Update(ctx, s.tenantID, s.update)
+		case applySuspendTenant:
+			_, err = tenants.Suspend(ctx, s.tenantID)
+		case applyResumeTenant:
+			_, err = tenants.Resume(ctx, s.tenantID)
+		case applyCreateKey:
+			var k *models.CreatedAPIKey
+			if k, err = tenants.CreateKey(ctx, s.tenantID, s.key); err == nil {
+				s.APIKey = k.APIKey
+			}
+		case applyRevokeKey:
+			_, err = tenants.RevokeKey(ctx, s.tenantID, s.keyID)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s %s: %w", strings.ReplaceAll(s.Action, "_", " "), s.Tenant, err)
+		}
+	}
+
+	return nil
+}
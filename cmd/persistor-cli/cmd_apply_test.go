@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const applyTestFile = `tenants:
+  - name: acme
+    plan: pro
+    quota:
+      max_nodes: 500
+    keys:
+      - name: ci
+        scope: read
+      - name: ingest
+  - name: globex
+    quota:
+      max_nodes: 100
+      max_edges: 200
+    suspended: true
+    keys:
+      - name: bot
+        scope: search
+`
+
+// newApplyServer serves acme (plan free, 100 node limit, suspended) with an
+// active ci key of the wrong scope, a stale key, and a revoked key, and
+// records every change made to it.
+func newApplyServer(t *testing.T) *[]string {
+	t.Helper()
+
+	var calls []string
+	maxNodes := int64(100)
+	suspended := time.Now().Add(-time.Hour)
+	revoked := time.Now().Add(-time.Hour)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/admin/tenants", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"tenants": []models.Tenant{ //nolint:errcheck
+			{ID: "t-acme", Name: "acme", Plan: "free", SuspendedAt: &suspended, Quota: models.TenantQuota{MaxNodes: &maxNodes}},
+			{ID: "t-other", Name: "other", Plan: "free"},
+		}})
+	})
+	mux.HandleFunc("GET /api/v1/admin/tenants/t-acme/keys", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []models.ManagedAPIKey{ //nolint:errcheck
+			{ID: "k-ci", Name: "ci", Scope: "read_write"},
+			{ID: "k-stale", Name: "stale", Scope: "read"},
+			{ID: "k-old", Name: "old", Scope: "read", RevokedAt: &revoked},
+		}})
+	})
+	record := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/admin/tenants":
+			json.NewEncoder(w).Encode(models.CreatedTenant{APIKey: "tenant-secret", Tenant: models.Tenant{ID: "t-globex"}}) //nolint:errcheck
+		case strings.HasSuffix(r.URL.Path, "/keys") && r.Method == http.MethodPost:
+			json.NewEncoder(w).Encode(models.CreatedAPIKey{APIKey: "key-secret"}) //nolint:errcheck
+		default:
+			w.Write([]byte(`{}`)) //nolint:errcheck
+		}
+	}
+	mux.HandleFunc("POST /api/v1/admin/tenants", record)
+	mux.HandleFunc("/api/v1/admin/tenants/", record)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	return &calls
+}
+
+func writeTenantsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tenants.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestApply(t *testing.T) {
+	calls := newApplyServer(t)
+
+	spec, err := loadTenantsFile(writeTenantsFile(t, applyTestFile))
+	if err != nil {
+		t.Fatalf("loadTenantsFile: %v", err)
+	}
+
+	steps, err := planApply(t.Context(), spec, true, time.Now())
+	if err != nil {
+		t.Fatalf("planApply: %v", err)
+	}
+
+	var actions []string
+	for _, s := range steps {
+		actions = append(actions, s.Action+" "+s.Tenant+": "+s.Detail)
+	}
+	want := []string{
+		"update_tenant acme: plan free -> pro, max_nodes 100 -> 500",
+		"resume_tenant acme: ",
+		"create_key acme: ci (read) (replaces k-ci)",
+		"revoke_key acme: ci k-ci",
+		"create_key acme: ingest (read_write)",
+		"revoke_key acme: stale k-stale",
+		"create_tenant globex: plan free",
+		"update_tenant globex: max_nodes unlimited -> 100, max_edges unlimited -> 200",
+		"suspend_tenant globex: ",
+		"create_key globex: bot (search)",
+	}
+	if !reflect.DeepEqual(actions, want) {
+		t.Fatalf("plan:\n%s\nwant:\n%s", strings.Join(actions, "\n"), strings.Join(want, "\n"))
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("planning made changes: %v", *calls)
+	}
+
+	if err := runApply(t.Context(), steps); err != nil {
+		t.Fatalf("runApply: %v", err)
+	}
+
+	wantCalls := []string{
+		`PATCH /api/v1/admin/tenants/t-acme {"plan":"pro","max_nodes":500}`,
+		`POST /api/v1/admin/tenants/t-acme/resume`,
+		`POST /api/v1/admin/tenants/t-acme/keys {"name":"ci","scope":"read"}`,
+		`DELETE /api/v1/admin/tenants/t-acme/keys/k-ci`,
+		`POST /api/v1/admin/tenants/t-acme/keys {"name":"ingest","scope":"read_write"}`,
+		`DELETE /api/v1/admin/tenants/t-acme/keys/k-stale`,
+		`POST /api/v1/admin/tenants {"name":"globex","plan":"free"}`,
+		`PATCH /api/v1/admin/tenants/t-globex {"max_nodes":100,"max_edges":200}`,
+		`POST /api/v1/admin/tenants/t-globex/suspend`,
+		`POST /api/v1/admin/tenants/t-globex/keys {"name":"bot","scope":"search"}`,
+	}
+	if !reflect.DeepEqual(*calls, wantCalls) {
+		t.Errorf("calls:\n%s\nwant:\n%s", strings.Join(*calls, "\n"), strings.Join(wantCalls, "\n"))
+	}
+	if steps[6].APIKey != "tenant-secret" || steps[9].APIKey != "key-secret" {
+		t.Errorf("secrets not reported: %+v, %+v", steps[6], steps[9])
+	}
+}
+
+func TestApply_Unmanaged(t *testing.T) {
+	calls := newApplyServer(t)
+
+	// Without plan, quota, or keys, only the suspension is reconciled.
+	spec, err := loadTenantsFile(writeTenantsFile(t, "tenants:\n  - name: acme\n    suspended: true\n"))
+	if err != nil {
+		t.Fatalf("loadTenantsFile: %v", err)
+	}
+
+	steps, err := planApply(t.Context(), spec, false, time.Now())
+	if err != nil || len(steps) != 0 {
+		t.Fatalf("planApply: err=%v, steps=%+v", err, steps)
+	}
+	if len(*calls) != 0 {
+		t.Errorf("calls = %v", *calls)
+	}
+}
+
+func TestLoadTenantsFile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown section":  "tenants: []\nwebhooks: []\n",
+		"missing name":     "tenants:\n  - plan: pro\n",
+		"duplicate tenant": "tenants:\n  - name: a\n  - name: a\n",
+		"negative quota":   "tenants:\n  - name: a\n    quota:\n      max_edges: -1\n",
+		"invalid scope":    "tenants:\n  - name: a\n    keys:\n      - name: k\n        scope: root\n",
+		"duplicate key":    "tenants:\n  - name: a\n    keys:\n      - name: k\n      - name: k\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := loadTenantsFile(writeTenantsFile(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	var left, right, leftTenant, rightTenant string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show what changed between two graph exports or tenants",
+		Long: `Compare two knowledge graphs and report the nodes and edges the right one
+adds, removes, and changes relative to the left one, e.g. to review what an
+agent learned between two checkpoints.
+
+Compare two export files (JSON from 'persistor export', or NDJSON from
+'persistor export --stream') locally with --left and --right, or two tenants
+on the server with --left-tenant and --right-tenant (operator key required).
+
+Nodes are compared by type, label, properties, and superseded_by; edges by
+weight and properties. Embeddings, access counts, salience, and timestamps
+are ignored.`,
+		Example: `  persistor diff --left monday.json --right friday.json --format table
+  persistor diff --left-tenant <id> --right-tenant <id>`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				diff *models.GraphDiff
+				err  error
+			)
+
+			switch {
+			case leftTenant != "" && rightTenant != "":
+				diff, err = apiClient.Admin.DiffTenants(cmd.Context(), leftTenant, rightTenant)
+				if err != nil {
+					return fmt.Errorf("diff tenants: %w", err)
+				}
+			case left != "" && right != "":
+				diff, err = diffExportFiles(left, right)
+				if err != nil {
+					return err
+				}
+			default:
+				return errors.New("pass --left and --right, or --left-tenant and --right-tenant")
+			}
+
+			if flagFmt == "table" {
+				printGraphDiff(os.Stdout, diff)
+				return nil
+			}
+
+			output(diff, graphDiffSummary(diff.Stats))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&left, "left", "", "Export file to compare from")
+	cmd.Flags().StringVar(&right, "right", "", "Export file to compare to")
+	cmd.Flags().StringVar(&leftTenant, "left-tenant", "", "Tenant ID to compare from")
+	cmd.Flags().StringVar(&rightTenant, "right-tenant", "", "Tenant ID to compare to")
+	cmd.MarkFlagsRequiredTogether("left", "right")
+	cmd.MarkFlagsRequiredTogether("left-tenant", "right-tenant")
+	cmd.MarkFlagsMutuallyExclusive("left", "left-tenant")
+
+	return cmd
+}
+
+func diffExportFiles(leftPath, rightPath string) (*models.GraphDiff, error) {
+	left, err := readExportFile(leftPath)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := readExportFile(rightPath)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := models.DiffGraphs(left, right)
+	diff.Left, diff.Right = leftPath, rightPath
+
+	return diff, nil
+}
+
+// readExportFile loads an export written by 'persistor export', as a single
+// JSON document or, for .ndjson files, as a record stream.
+func readExportFile(path string) (*models.ExportFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading export file: %w", err)
+	}
+	defer f.Close()
+
+	var data models.ExportFormat
+
+	if strings.EqualFold(filepath.Ext(path), ".ndjson") {
+		if err := readExportStream(bufio.NewReader(f), &data); err != nil {
+			return nil, fmt.Errorf("parsing export file %s: %w", path, err)
+		}
+
+		return &data, nil
+	}
+
+	if err := json.NewDecoder(f).Decode(&data); err != nil {
+		return nil, fmt.Errorf("parsing export file %s: %w", path, err)
+	}
+
+	return &data, nil
+}
+
+func readExportStream(r io.Reader, data *models.ExportFormat) error {
+	dec := json.NewDecoder(r)
+
+	for {
+		var rec models.ExportRecord
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return models.ErrIncompleteExport
+			}
+
+			return err
+		}
+
+		switch rec.Type {
+		case models.ExportRecordNode:
+			if rec.Node != nil {
+				data.Nodes = append(data.Nodes, *rec.Node)
+			}
+		case models.ExportRecordEdge:
+			if rec.Edge != nil {
+				data.Edges = append(data.Edges, *rec.Edge)
+			}
+		case models.ExportRecordEnd:
+			return nil
+		}
+	}
+}
+
+func graphDiffSummary(s models.GraphDiffStats) string {
+	return fmt.Sprintf("nodes +%d -%d ~%d, edges +%d -%d ~%d",
+		s.NodesAdded, s.NodesRemoved, s.NodesChanged, s.EdgesAdded, s.EdgesRemoved, s.EdgesChanged)
+}
+
+// printGraphDiff writes the diff one line per node or edge: + added,
+// - removed, ~ changed with each changed field indented below.
+func printGraphDiff(w io.Writer, d *models.GraphDiff) {
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", d.Left, d.Right)
+
+	for _, n := range d.NodesAdded {
+		fmt.Fprintf(w, "+ node %s (%s) %q\n", n.ID, n.Type, n.Label)
+	}
+
+	for _, n := range d.NodesRemoved {
+		fmt.Fprintf(w, "- node %s (%s) %q\n", n.ID, n.Type, n.Label)
+	}
+
+	for _, n := range d.NodesChanged {
+		fmt.Fprintf(w, "~ node %s\n", n.ID)
+		printFieldDiffs(w, n.Fields)
+	}
+
+	for _, e := range d.EdgesAdded {
+		fmt.Fprintf(w, "+ edge %s -%s-> %s\n", e.Source, e.Relation, e.Target)
+	}
+
+	for _, e := range d.EdgesRemoved {
+		fmt.Fprintf(w, "- edge %s -%s-> %s\n", e.Source, e.Relation, e.Target)
+	}
+
+	for _, e := range d.EdgesChanged {
+		fmt.Fprintf(w, "~ edge %s -%s-> %s\n", e.Source, e.Relation, e.Target)
+		printFieldDiffs(w, e.Fields)
+	}
+
+	fmt.Fprintln(w, graphDiffSummary(d.Stats))
+}
+
+func printFieldDiffs(w io.Writer, fields []models.FieldDiff) {
+	for _, f := range fields {
+		fmt.Fprintf(w, "    %s: %s -> %s\n", f.Field, diffValue(f.Left), diffValue(f.Right))
+	}
+}
+
+func diffValue(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(raw)
+}
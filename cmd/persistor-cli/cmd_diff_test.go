@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestDiffExportFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	left := models.ExportFormat{
+		Nodes: []models.ExportNode{{ID: "alice", Type: "person", Label: "Alice"}},
+	}
+	leftPath := filepath.Join(dir, "left.json")
+	raw, _ := json.Marshal(left)
+	if err := os.WriteFile(leftPath, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The right side is a streaming export.
+	var stream bytes.Buffer
+	enc := json.NewEncoder(&stream)
+	for _, rec := range []models.ExportRecord{
+		{Type: models.ExportRecordManifest, Manifest: &models.ExportManifest{}},
+		{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "alice", Type: "person", Label: "Alice Smith"}},
+		{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "bob", Type: "person", Label: "Bob"}},
+		{Type: models.ExportRecordEdge, Edge: &models.ExportEdge{Source: "alice", Target: "bob", Relation: "knows"}},
+		{Type: models.ExportRecordEnd, Stats: &models.ExportStats{NodeCount: 2, EdgeCount: 1}},
+	} {
+		_ = enc.Encode(rec)
+	}
+	rightPath := filepath.Join(dir, "right.ndjson")
+	if err := os.WriteFile(rightPath, stream.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := diffExportFiles(leftPath, rightPath)
+	if err != nil {
+		t.Fatalf("diffExportFiles: %v", err)
+	}
+
+	var out bytes.Buffer
+	printGraphDiff(&out, diff)
+
+	for _, want := range []string{
+		"+ node bob (person) \"Bob\"",
+		"~ node alice\n    label: \"Alice\" -> \"Alice Smith\"",
+		"+ edge alice -knows-> bob",
+		"nodes +1 -0 ~1, edges +1 -0 ~0",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	// A stream cut off before its end record is rejected.
+	truncated := filepath.Join(dir, "truncated.ndjson")
+	if err := os.WriteFile(truncated, stream.Bytes()[:bytes.LastIndexByte(stream.Bytes()[:stream.Len()-1], '\n')+1], 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := diffExportFiles(leftPath, truncated); err == nil {
+		t.Error("expected error for an incomplete stream")
+	}
+}
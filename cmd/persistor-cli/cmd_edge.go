@@ -21,6 +21,7 @@ func newEdgeCmd() *cobra.Command {
 	cmd.AddCommand(edgeUpdateCmd())
 	cmd.AddCommand(edgePatchCmd())
 	cmd.AddCommand(edgeDeleteCmd())
+	cmd.AddCommand(edgeHistoryCmd())
 	return cmd
 }
 
@@ -199,6 +200,26 @@ func edgeDeleteCmd() *cobra.Command {
 	}
 }
 
+func edgeHistoryCmd() *cobra.Command {
+	var property string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "history <source> <target> <relation>",
+		Short: "Show property change history for an edge",
+		Args:  cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			changes, _, err := apiClient.Edges.History(context.Background(), args[0], args[1], args[2], property, limit, 0)
+			if err != nil {
+				fatal("get edge history", err)
+			}
+			output(changes, "")
+		},
+	}
+	cmd.Flags().StringVar(&property, "property", "", "Only show changes to this property key")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of changes to return")
+	return cmd
+}
+
 // edgeTableRow formats a single edge as a table row with temporal fields.
 func edgeTableRow(e *client.Edge) []string {
 	ds := "-"
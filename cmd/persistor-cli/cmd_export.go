@@ -1,58 +1,254 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
+	"github.com/persistorai/persistor/internal/models"
 	"github.com/spf13/cobra"
 )
 
 func newExportCmd() *cobra.Command {
-	var outputPath string
+	var (
+		outputPath string
+		resumePath string
+		pageSize   int
+		stream     bool
+		jsonld     bool
+		neo4jKind  string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export the full knowledge graph to a JSON file",
 		Long: `Export all nodes, edges, embeddings, and metadata to a portable JSON file.
 The export is full-fidelity: embeddings, access counts, salience scores, and
-all properties are preserved. Use 'persistor import' to restore.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
+all properties are preserved. Use 'persistor import' to restore.
 
-			data, err := apiClient.Export(ctx)
-			if err != nil {
-				return fmt.Errorf("export failed: %w", err)
-			}
+Records are downloaded in pages with a progress bar on stderr. Pass
+--resume <state-file> to record progress; if the export is interrupted,
+run the same command again to continue from the last completed page.
 
-			out, err := json.MarshalIndent(data, "", "  ")
-			if err != nil {
-				return fmt.Errorf("marshalling export: %w", err)
-			}
+With --stream the server sends the whole export in one NDJSON response, one
+record per line: a manifest, the nodes, the edges, and an end record. This
+is the fastest way to export a large graph but cannot be resumed.
+
+With --jsonld the graph is exported as a JSON-LD document for linked-data
+tooling, mapped to IRIs by the tenant's context (see 'persistor export
+jsonld-context'). It is not a backup: embeddings, usage metrics, and edge
+properties are left out, and 'persistor import' cannot read it.
 
-			if outputPath == "" {
-				outputPath = fmt.Sprintf("persistor-export-%s.json",
-					time.Now().UTC().Format("20060102T150405Z"))
+With --neo4j cypher the graph is exported as a Cypher script for
+'cypher-shell -f'; with --neo4j csv, as a zip archive of nodes.csv and
+relationships.csv for 'neo4j-admin database import full'. Node types become
+labels and relations become relationship types. Embeddings are left out.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if resumePath != "" && outputPath == "-" {
+				return errors.New("--resume requires --output to be a file")
 			}
 
-			if outputPath == "-" {
-				_, err = os.Stdout.Write(out)
-				return err
+			if stream {
+				return runStreamExport(cmd.Context(), outputPath)
 			}
 
-			if err := os.WriteFile(outputPath, out, 0o600); err != nil {
-				return fmt.Errorf("writing export file: %w", err)
+			if jsonld {
+				return runJSONLDExport(cmd.Context(), outputPath)
 			}
 
-			fmt.Fprintf(os.Stderr, "Exported %d nodes, %d edges to %s\n",
-				data.Stats.NodeCount, data.Stats.EdgeCount, outputPath)
+			if neo4jKind != "" {
+				return runNeo4jExport(cmd.Context(), neo4jKind, outputPath)
+			}
 
-			return nil
+			return runExport(cmd.Context(), outputPath, cmd.Flags().Changed("output"), resumePath, pageSize)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Output file path (default: persistor-export-<timestamp>.json, use - for stdout)")
+	cmd.Flags().StringVar(&resumePath, "resume", "", "State file used to resume an interrupted export")
+	cmd.Flags().IntVar(&pageSize, "page-size", models.DefaultExportPageSize, "Records fetched per request")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Download the export as a single NDJSON stream")
+	cmd.MarkFlagsMutuallyExclusive("stream", "resume")
+	cmd.Flags().BoolVar(&jsonld, "jsonld", false, "Export the graph as JSON-LD")
+	cmd.MarkFlagsMutuallyExclusive("stream", "page-size")
+	cmd.MarkFlagsMutuallyExclusive("jsonld", "stream", "resume")
+	cmd.MarkFlagsMutuallyExclusive("jsonld", "page-size")
+	cmd.Flags().StringVar(&neo4jKind, "neo4j", "", "Export the graph for Neo4j: cypher or csv")
+	cmd.MarkFlagsMutuallyExclusive("neo4j", "jsonld", "stream", "resume")
+	cmd.MarkFlagsMutuallyExclusive("neo4j", "page-size")
+	cmd.AddCommand(newExportJSONLDContextCmd())
 
 	return cmd
 }
+
+// runStreamExport writes a streaming export to outputPath as NDJSON. A file
+// is written under a .part name and renamed once the end record arrives, so
+// an interrupted download never looks complete.
+func runStreamExport(ctx context.Context, outputPath string) error {
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("persistor-export-%s.ndjson", time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	var (
+		out      io.Writer = os.Stdout
+		partPath string
+	)
+
+	if outputPath != "-" {
+		partPath = outputPath + ".part"
+
+		f, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("writing export file: %w", err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	enc := json.NewEncoder(w)
+
+	var (
+		bar   *progressBar
+		stats models.ExportStats
+	)
+
+	err := apiClient.ExportStream(ctx, func(rec *models.ExportRecord) error {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("writing export file: %w", err)
+		}
+
+		switch rec.Type {
+		case models.ExportRecordManifest:
+			if rec.Manifest == nil {
+				return errors.New("export stream: manifest record without manifest")
+			}
+			bar = newProgressBar("records", rec.Manifest.Stats.NodeCount+rec.Manifest.Stats.EdgeCount, 0, 0)
+		case models.ExportRecordNode, models.ExportRecordEdge:
+			if bar != nil {
+				bar.add(1, 0)
+			}
+		case models.ExportRecordEnd:
+			if rec.Stats != nil {
+				stats = *rec.Stats
+			}
+			if bar != nil {
+				bar.finish()
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if bar != nil {
+			fmt.Fprintln(os.Stderr)
+		}
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	if partPath == "" {
+		return nil
+	}
+
+	if err := os.Rename(partPath, outputPath); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d nodes, %d edges to %s\n", stats.NodeCount, stats.EdgeCount, outputPath)
+
+	return nil
+}
+
+// writeExport assembles the spooled records into a models.ExportFormat
+// document at st.Output, or stdout for "-".
+func writeExport(st *exportState, nodesPath, edgesPath string) error {
+	var out io.Writer = os.Stdout
+
+	if st.Output != "-" {
+		f, err := os.OpenFile(st.Output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("writing export file: %w", err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	manifest := st.Manifest
+	manifest.Stats = models.ExportStats{NodeCount: st.Nodes, EdgeCount: st.Edges}
+
+	header, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("marshalling export header: %w", err)
+	}
+
+	// The manifest carries the same header fields as models.ExportFormat, so
+	// the record arrays are spliced in before its closing brace.
+	w := bufio.NewWriter(out)
+	w.Write(header[:len(header)-1]) //nolint:errcheck // surfaced by Flush
+	w.WriteString(`,"nodes":`)      //nolint:errcheck // surfaced by Flush
+
+	if err := copyJSONLinesArray(w, nodesPath); err != nil {
+		return err
+	}
+
+	w.WriteString(`,"edges":`) //nolint:errcheck // surfaced by Flush
+
+	if err := copyJSONLinesArray(w, edgesPath); err != nil {
+		return err
+	}
+
+	w.WriteString("}\n") //nolint:errcheck // surfaced by Flush
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	return nil
+}
+
+// copyJSONLinesArray writes the JSON lines in path to w as a JSON array.
+func copyJSONLinesArray(w *bufio.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("reading spool file: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	w.WriteByte('[') //nolint:errcheck // surfaced by Flush
+
+	first := true
+	for {
+		line, err := r.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if !first {
+				w.WriteByte(',') //nolint:errcheck // surfaced by Flush
+			}
+			w.Write(line) //nolint:errcheck // surfaced by Flush
+			first = false
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading spool file: %w", err)
+		}
+	}
+
+	w.WriteByte(']') //nolint:errcheck // surfaced by Flush
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// runJSONLDExport writes the graph as a JSON-LD document to outputPath.
+func runJSONLDExport(ctx context.Context, outputPath string) error {
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("persistor-export-%s.jsonld", time.Now().UTC().Format("20060102T150405Z"))
+	}
+
+	doc, err := apiClient.ExportJSONLD(ctx)
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding export: %w", err)
+	}
+
+	if outputPath == "-" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d nodes to %s\n", len(doc.Graph), outputPath)
+
+	return nil
+}
+
+func newExportJSONLDContextCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jsonld-context",
+		Short: "Show the JSON-LD context used by 'export --jsonld'",
+		Long: `Show the tenant's JSON-LD context: the base IRI of nodes, the default
+vocabulary, and the prefixes and IRIs that node types, relations, and
+property keys are mapped to.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonldCtx, err := apiClient.JSONLDContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			output(jsonldCtx, jsonldCtx.Vocab)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <file>",
+		Short: "Replace the JSON-LD context from a JSON file",
+		Long: `Replace the tenant's JSON-LD context with the one in a JSON file:
+
+  {
+    "base": "https://example.org/kg/",
+    "vocab": "https://example.org/vocab#",
+    "prefixes": {"schema": "https://schema.org/"},
+    "types": {"person": "schema:Person"},
+    "relations": {"works_at": "schema:worksFor"},
+    "properties": {"email": "schema:email"}
+  }
+
+Every field is optional; base and vocab default to Persistor URNs.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("reading context file: %w", err)
+			}
+
+			var jsonldCtx models.JSONLDContext
+
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+
+			if err := dec.Decode(&jsonldCtx); err != nil {
+				return fmt.Errorf("parsing context file: %w", err)
+			}
+
+			result, err := apiClient.SetJSONLDContext(cmd.Context(), jsonldCtx)
+			if err != nil {
+				return err
+			}
+
+			output(result, result.Vocab)
+
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "reset",
+		Short: "Remove the JSON-LD context so exports use the default vocabulary",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			result, err := apiClient.ResetJSONLDContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			output(result, result.Vocab)
+
+			return nil
+		},
+	})
+
+	return cmd
+}
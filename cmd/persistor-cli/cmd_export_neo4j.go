@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/persistorai/persistor/internal/neo4j"
+)
+
+// neo4jFormats maps the values of 'export --neo4j' to the API's export
+// formats and the default file extension.
+var neo4jFormats = map[string]struct{ format, ext string }{
+	"cypher": {neo4j.FormatCypher, "cypher"},
+	"csv":    {neo4j.FormatCSV, "zip"},
+}
+
+// runNeo4jExport downloads the graph as a Cypher script or a neo4j-admin
+// import archive. A file is written under a .part name and only renamed once
+// it is known to be complete.
+func runNeo4jExport(ctx context.Context, kind, outputPath string) error {
+	f, ok := neo4jFormats[kind]
+	if !ok {
+		return fmt.Errorf("--neo4j must be cypher or csv, got %q", kind)
+	}
+
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("persistor-export-%s.%s", time.Now().UTC().Format("20060102T150405Z"), f.ext)
+	}
+
+	if outputPath == "-" {
+		if _, err := apiClient.ExportNeo4j(ctx, f.format, os.Stdout); err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+
+		return nil
+	}
+
+	partPath := outputPath + ".part"
+
+	out, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := apiClient.ExportNeo4j(ctx, f.format, out); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	if err := checkNeo4jExport(partPath, f.format); err != nil {
+		return fmt.Errorf("export failed: %w (partial file left at %s)", err, partPath)
+	}
+
+	if err := os.Rename(partPath, outputPath); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported to %s\n", outputPath)
+
+	return nil
+}
+
+// checkNeo4jExport reports an export cut short by the server: a Cypher
+// script without its closing comment, or a zip archive that cannot be read.
+func checkNeo4jExport(path, format string) error {
+	if format == neo4j.FormatCSV {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return fmt.Errorf("incomplete archive: %w", err)
+		}
+
+		return zr.Close()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	tail := make([]byte, min(info.Size(), 256))
+	if _, err := f.ReadAt(tail, info.Size()-int64(len(tail))); err != nil && err != io.EOF {
+		return err
+	}
+
+	lines := bytes.Split(bytes.TrimRight(tail, "\n"), []byte("\n"))
+	if !bytes.HasPrefix(lines[len(lines)-1], []byte(neo4j.CypherTrailer)) {
+		return errors.New("incomplete Cypher script")
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	exportPhaseNodes = "nodes"
+	exportPhaseEdges = "edges"
+	exportPhaseDone  = "done"
+)
+
+// exportState is saved to the --resume file after every page so an
+// interrupted export can continue where it stopped. Downloaded records are
+// spooled as JSON lines next to the output file; the sizes record how much of
+// each spool file is known to be complete.
+type exportState struct {
+	Output    string                `json:"output"`
+	Manifest  models.ExportManifest `json:"manifest"`
+	Phase     string                `json:"phase"`
+	Cursor    string                `json:"cursor,omitempty"`
+	Nodes     int                   `json:"nodes"`
+	Edges     int                   `json:"edges"`
+	NodesSize int64                 `json:"nodes_size"`
+	EdgesSize int64                 `json:"edges_size"`
+}
+
+func runExport(ctx context.Context, outputPath string, outputSet bool, resumePath string, pageSize int) error {
+	st := &exportState{}
+
+	resumed := false
+	if resumePath != "" {
+		var err error
+		if resumed, err = loadResumeState(resumePath, st); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case resumed && outputSet && outputPath != st.Output:
+		return fmt.Errorf("resume state %s belongs to an export to %s", resumePath, st.Output)
+	case resumed:
+		outputPath = st.Output
+		fmt.Fprintf(os.Stderr, "Resuming export to %s (%d nodes, %d edges already downloaded)\n",
+			outputPath, st.Nodes, st.Edges)
+	default:
+		if outputPath == "" {
+			outputPath = fmt.Sprintf("persistor-export-%s.json",
+				time.Now().UTC().Format("20060102T150405Z"))
+		}
+
+		manifest, err := apiClient.ExportManifest(ctx)
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+
+		st = &exportState{Output: outputPath, Manifest: *manifest, Phase: exportPhaseNodes}
+	}
+
+	spoolDir := filepath.Dir(outputPath)
+	if outputPath == "-" {
+		tmp, err := os.MkdirTemp("", "persistor-export-*")
+		if err != nil {
+			return fmt.Errorf("creating spool directory: %w", err)
+		}
+		defer os.RemoveAll(tmp) //nolint:errcheck // best-effort cleanup
+
+		spoolDir = tmp
+	}
+
+	base := filepath.Base(outputPath)
+	nodesPath := filepath.Join(spoolDir, base+".nodes.part")
+	edgesPath := filepath.Join(spoolDir, base+".edges.part")
+
+	save := func() error {
+		if resumePath == "" {
+			return nil
+		}
+		return saveResumeState(resumePath, st)
+	}
+
+	if err := save(); err != nil {
+		return err
+	}
+
+	if st.Phase == exportPhaseNodes {
+		fetch := func(cursor string) ([]models.ExportNode, string, error) {
+			page, err := apiClient.ExportNodes(ctx, cursor, pageSize)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Nodes, page.NextCursor, nil
+		}
+
+		err := downloadPages("nodes", st.Manifest.Stats.NodeCount, nodesPath, &st.Nodes, &st.NodesSize, &st.Cursor, fetch, save)
+		if err != nil {
+			return err
+		}
+
+		st.Phase, st.Cursor = exportPhaseEdges, ""
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	if st.Phase == exportPhaseEdges {
+		fetch := func(cursor string) ([]models.ExportEdge, string, error) {
+			page, err := apiClient.ExportEdges(ctx, cursor, pageSize)
+			if err != nil {
+				return nil, "", err
+			}
+			return page.Edges, page.NextCursor, nil
+		}
+
+		err := downloadPages("edges", st.Manifest.Stats.EdgeCount, edgesPath, &st.Edges, &st.EdgesSize, &st.Cursor, fetch, save)
+		if err != nil {
+			return err
+		}
+
+		st.Phase, st.Cursor = exportPhaseDone, ""
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	if err := writeExport(st, nodesPath, edgesPath); err != nil {
+		return err
+	}
+
+	os.Remove(nodesPath) //nolint:errcheck // best-effort cleanup
+	os.Remove(edgesPath) //nolint:errcheck // best-effort cleanup
+
+	if resumePath != "" {
+		os.Remove(resumePath) //nolint:errcheck // best-effort cleanup
+	}
+
+	if outputPath != "-" {
+		fmt.Fprintf(os.Stderr, "Exported %d nodes, %d edges to %s\n", st.Nodes, st.Edges, outputPath)
+	}
+
+	return nil
+}
+
+// downloadPages fetches pages until the cursor is exhausted, appending each
+// page to the spool file at path and advancing count, size and cursor only
+// after the page is on disk.
+func downloadPages[T any](
+	label string,
+	total int,
+	path string,
+	count *int,
+	size *int64,
+	cursor *string,
+	fetch func(cursor string) ([]T, string, error),
+	save func() error,
+) error {
+	f, err := openSpool(path, *size)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	bar := newProgressBar(label, total, *count, *size)
+
+	for {
+		records, next, err := fetch(*cursor)
+		if err != nil {
+			fmt.Fprintln(os.Stderr)
+			return fmt.Errorf("export failed: %w", err)
+		}
+
+		n, err := appendJSONLines(f, records)
+		if err != nil {
+			fmt.Fprintln(os.Stderr)
+			return err
+		}
+
+		*count += len(records)
+		*size += n
+		*cursor = next
+		bar.add(len(records), n)
+
+		if err := save(); err != nil {
+			fmt.Fprintln(os.Stderr)
+			return err
+		}
+
+		if next == "" {
+			bar.finish()
+			return nil
+		}
+	}
+}
+
+// openSpool opens a spool file for appending, discarding anything past size
+// left behind by a page that was not fully written.
+func openSpool(path string, size int64) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening spool file: %w", err)
+	}
+
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("truncating spool file: %w", err)
+	}
+
+	if _, err := f.Seek(size, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("seeking spool file: %w", err)
+	}
+
+	return f, nil
+}
+
+// appendJSONLines writes one JSON document per record and syncs the file so
+// the returned byte count can be recorded in the resume state.
+func appendJSONLines[T any](f *os.File, records []T) (int64, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return 0, fmt.Errorf("encoding export record: %w", err)
+		}
+	}
+
+	n, err := f.Write(buf.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("writing spool file: %w", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("syncing spool file: %w", err)
+	}
+
+	return int64(n), nil
+}
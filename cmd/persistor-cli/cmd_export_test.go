@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/neo4j"
+)
+
+// newExportServer serves three nodes one per page. The node page after
+// failAfter fails until failAfter is reset to -1.
+func newExportServer(t *testing.T, failAfter *int) {
+	t.Helper()
+
+	nodes := []models.ExportNode{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/export/manifest", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(models.ExportManifest{ //nolint:errcheck
+			SchemaVersion: 1,
+			TenantID:      "t1",
+			Stats:         models.ExportStats{NodeCount: len(nodes)},
+		})
+	})
+	mux.HandleFunc("/api/v1/export/nodes", func(w http.ResponseWriter, r *http.Request) {
+		i, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		if *failAfter >= 0 && i > *failAfter {
+			http.Error(w, `{"error":{"code":"internal_error","message":"boom"}}`, http.StatusInternalServerError)
+			return
+		}
+		page := models.ExportNodePage{Nodes: nodes[i : i+1]}
+		if i+1 < len(nodes) {
+			page.NextCursor = fmt.Sprint(i + 1)
+		}
+		json.NewEncoder(w).Encode(page) //nolint:errcheck
+	})
+	mux.HandleFunc("/api/v1/export/edges", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(models.ExportEdgePage{ //nolint:errcheck
+			Edges: []models.ExportEdge{{Source: "a", Target: "b", Relation: "knows"}},
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+}
+
+func TestRunExport_Resume(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "export.json")
+	state := filepath.Join(dir, "export.state")
+
+	failAfter := 1
+	newExportServer(t, &failAfter)
+
+	if err := runExport(context.Background(), out, true, state, 1); err == nil {
+		t.Fatal("expected the first run to fail")
+	}
+
+	var st exportState
+	if ok, err := loadResumeState(state, &st); !ok || err != nil {
+		t.Fatalf("loadResumeState: ok=%v err=%v", ok, err)
+	}
+	if st.Nodes != 2 || st.Cursor != "2" {
+		t.Fatalf("state = %d nodes, cursor %q; want 2 nodes, cursor \"2\"", st.Nodes, st.Cursor)
+	}
+
+	failAfter = -1
+	if err := runExport(context.Background(), out, false, state, 1); err != nil {
+		t.Fatalf("resumed runExport: %v", err)
+	}
+
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+
+	var data models.ExportFormat
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, raw)
+	}
+
+	if len(data.Nodes) != 3 || data.Nodes[2].ID != "c" || len(data.Edges) != 1 {
+		t.Errorf("export = %d nodes, %d edges; want 3 nodes, 1 edge", len(data.Nodes), len(data.Edges))
+	}
+	if data.Stats.NodeCount != 3 || data.TenantID != "t1" {
+		t.Errorf("header = %+v, tenant %q", data.Stats, data.TenantID)
+	}
+
+	if _, err := os.Stat(state); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed after a successful export, stat err = %v", err)
+	}
+}
+
+func TestRunStreamExport(t *testing.T) {
+	truncate := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/export", func(w http.ResponseWriter, _ *http.Request) {
+		enc := json.NewEncoder(w)
+		enc.Encode(models.ExportRecord{Type: models.ExportRecordManifest, Manifest: &models.ExportManifest{TenantID: "t1", Stats: models.ExportStats{NodeCount: 1}}}) //nolint:errcheck
+		enc.Encode(models.ExportRecord{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "a"}})                                                             //nolint:errcheck
+		if !truncate {
+			enc.Encode(models.ExportRecord{Type: models.ExportRecordEnd, Stats: &models.ExportStats{NodeCount: 1}}) //nolint:errcheck
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	out := filepath.Join(t.TempDir(), "export.ndjson")
+	if err := runStreamExport(context.Background(), out); err != nil {
+		t.Fatalf("runStreamExport: %v", err)
+	}
+
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	if lines := strings.Count(string(raw), "\n"); lines != 3 {
+		t.Errorf("export has %d lines, want 3:\n%s", lines, raw)
+	}
+
+	truncate = true
+	out = filepath.Join(t.TempDir(), "truncated.ndjson")
+	if err := runStreamExport(context.Background(), out); !errors.Is(err, models.ErrIncompleteExport) {
+		t.Fatalf("truncated stream: err = %v, want ErrIncompleteExport", err)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("truncated export should not be renamed into place, stat err = %v", err)
+	}
+}
+
+func TestRunNeo4jExport(t *testing.T) {
+	truncate := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/export", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "CREATE (:PersistorNode:Person {id: 'a'});")
+		if !truncate {
+			fmt.Fprintln(w, neo4j.CypherTrailer, "1 nodes, 0 relationships.")
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	out := filepath.Join(t.TempDir(), "graph.cypher")
+	if err := runNeo4jExport(context.Background(), "cypher", out); err != nil {
+		t.Fatalf("runNeo4jExport: %v", err)
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("export not written: %v", err)
+	}
+
+	truncate = true
+	out = filepath.Join(t.TempDir(), "truncated.cypher")
+	if err := runNeo4jExport(context.Background(), "cypher", out); err == nil {
+		t.Fatal("truncated script: want error")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("truncated export should not be renamed into place, stat err = %v", err)
+	}
+
+	if err := runNeo4jExport(context.Background(), "graphml", out); err == nil {
+		t.Error("unknown kind: want error")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		0:       "0 B",
+		1023:    "1023 B",
+		1536:    "1.5 KiB",
+		5 << 20: "5.0 MiB",
+	}
+	for in, want := range cases {
+		if got := formatBytes(in); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", in, got, want)
+		}
+	}
+}
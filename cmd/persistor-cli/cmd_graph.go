@@ -2,7 +2,11 @@
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
 	"github.com/spf13/cobra"
 )
 
@@ -15,58 +19,84 @@ func newGraphCmd() *cobra.Command {
 	cmd.AddCommand(graphTraverseCmd())
 	cmd.AddCommand(graphContextCmd())
 	cmd.AddCommand(graphPathCmd())
+	cmd.AddCommand(graphSummaryCmd())
+	cmd.AddCommand(graphSubgraphCmd())
+	cmd.AddCommand(graphCommunitiesCmd())
+	cmd.AddCommand(graphAsOfCmd())
+	cmd.AddCommand(graphSnapshotCmd())
+	cmd.AddCommand(graphSnapshotsCmd())
+	cmd.AddCommand(graphCompareCmd())
 	return cmd
 }
 
 func graphNeighborsCmd() *cobra.Command {
 	var limit int
+	var direction string
 	cmd := &cobra.Command{
 		Use:   "neighbors <id>",
 		Short: "Get neighbors of a node",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateDirection(direction); err != nil {
+				fatal("neighbors", err)
+			}
 			result, err := apiClient.Graph.Neighbors(context.Background(), args[0], limit)
 			if err != nil {
 				fatal("neighbors", err)
 			}
-			output(result, "")
+			result.Nodes, result.Edges = filterDirection(args[0], result.Nodes, result.Edges, direction, 1)
+			outputGraph(result, result.Nodes, result.Edges)
 		},
 	}
 	cmd.Flags().IntVar(&limit, "limit", 0, "Max results")
+	cmd.Flags().StringVar(&direction, "direction", directionBoth, "Follow edges: out|in|both")
 	return cmd
 }
 
 func graphTraverseCmd() *cobra.Command {
 	var depth int
+	var direction string
 	cmd := &cobra.Command{
 		Use:   "traverse <id>",
 		Short: "BFS traverse from a node",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateDirection(direction); err != nil {
+				fatal("traverse", err)
+			}
 			result, err := apiClient.Graph.Traverse(context.Background(), args[0], depth)
 			if err != nil {
 				fatal("traverse", err)
 			}
-			output(result, "")
+			result.Nodes, result.Edges = filterDirection(args[0], result.Nodes, result.Edges, direction, depth)
+			outputGraph(result, result.Nodes, result.Edges)
 		},
 	}
-	cmd.Flags().IntVar(&depth, "depth", 2, "Max traversal depth")
+	cmd.Flags().IntVar(&depth, "depth", 2, "Max traversal depth (at most 10)")
+	cmd.Flags().StringVar(&direction, "direction", directionBoth, "Follow edges: out|in|both")
 	return cmd
 }
 
 func graphContextCmd() *cobra.Command {
-	return &cobra.Command{
+	var direction string
+	cmd := &cobra.Command{
 		Use:   "context <id>",
 		Short: "Get a node with its neighborhood",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := validateDirection(direction); err != nil {
+				fatal("context", err)
+			}
 			result, err := apiClient.Graph.Context(context.Background(), args[0])
 			if err != nil {
 				fatal("context", err)
 			}
-			output(result, "")
+			result.Neighbors, result.Edges = filterDirection(args[0], result.Neighbors, result.Edges, direction, 1)
+			outputGraph(result, append([]client.Node{result.Node}, result.Neighbors...), result.Edges)
 		},
 	}
+	cmd.Flags().StringVar(&direction, "direction", directionBoth, "Follow edges: out|in|both")
+	return cmd
 }
 
 func graphPathCmd() *cobra.Command {
@@ -79,7 +109,88 @@ func graphPathCmd() *cobra.Command {
 			if err != nil {
 				fatal("path", err)
 			}
-			output(path, "")
+			outputGraph(path, path, pathEdges(path))
+		},
+	}
+}
+
+func graphSummaryCmd() *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "summary <id>",
+		Short: "Summarize a node's neighborhood",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Graph.Summary(context.Background(), args[0], limit)
+			if err != nil {
+				fatal("summary", err)
+			}
+			output(result, result.Node.ID)
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Max top neighbors")
+	return cmd
+}
+
+func graphSubgraphCmd() *cobra.Command {
+	var expand bool
+	cmd := &cobra.Command{
+		Use:   "subgraph <id> [id...]",
+		Short: "Extract the subgraph induced by a set of nodes",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Graph.Subgraph(context.Background(), args, expand)
+			if err != nil {
+				fatal("subgraph", err)
+			}
+			output(result, "")
+		},
+	}
+	cmd.Flags().BoolVar(&expand, "expand", false, "Include direct neighbors of the given nodes")
+	return cmd
+}
+
+func graphCommunitiesCmd() *cobra.Command {
+	var req models.CommunityRequest
+	cmd := &cobra.Command{
+		Use:   "communities",
+		Short: "Detect communities (clusters) in the graph",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Graph.Communities(context.Background(), req)
+			if err != nil {
+				fatal("communities", err)
+			}
+			output(result, "")
+		},
+	}
+	cmd.Flags().IntVar(&req.MaxIterations, "max-iterations", 0, "Max label propagation iterations (server default 20)")
+	cmd.Flags().IntVar(&req.MinSize, "min-size", 0, "Minimum community size (server default 2)")
+	cmd.Flags().StringVar(&req.Relation, "relation", "", "Only cluster over edges with this relation")
+	return cmd
+}
+
+func graphAsOfCmd() *cobra.Command {
+	var q models.AsOfQuery
+	cmd := &cobra.Command{
+		Use:   "asof <timestamp>",
+		Short: "Show the graph as it was at an RFC 3339 timestamp",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ts, err := time.Parse(time.RFC3339, args[0])
+			if err != nil {
+				fatal("asof", fmt.Errorf("timestamp must be RFC 3339: %w", err))
+			}
+			q.Timestamp = ts
+			result, err := apiClient.Graph.AsOf(context.Background(), q)
+			if err != nil {
+				fatal("asof", err)
+			}
+			output(result, "")
 		},
 	}
+	cmd.Flags().StringVar(&q.Type, "type", "", "Only include nodes of this type")
+	cmd.Flags().IntVar(&q.Limit, "limit", 0, "Max nodes (server default 100)")
+	cmd.Flags().IntVar(&q.Offset, "offset", 0, "Pagination offset")
+	return cmd
 }
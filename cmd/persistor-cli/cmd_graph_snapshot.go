@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func graphSnapshotCmd() *cobra.Command {
+	var req clientmodels.CreateSnapshotRequest
+	cmd := &cobra.Command{
+		Use:   "snapshot <name>",
+		Short: "Tag the graph's current state with counts and content hashes",
+		Long: `Records node and edge counts and a content hash per node type and relation
+under a name, e.g. "pre-experiment", to compare later states against.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req.Name = args[0]
+			snap, err := apiClient.Graph.CreateSnapshot(context.Background(), req)
+			if err != nil {
+				fatal("snapshot", err)
+			}
+			output(snap, snap.Name)
+		},
+	}
+	cmd.Flags().StringVar(&req.Description, "description", "", "What the snapshot marks")
+	cmd.Flags().StringVar(&req.ExportRef, "export-ref", "", "Where a full export taken alongside it is kept")
+	return cmd
+}
+
+func graphSnapshotsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snapshots",
+		Short: "List graph snapshots, newest first",
+		Run: func(cmd *cobra.Command, args []string) {
+			snapshots, err := apiClient.Graph.Snapshots(context.Background())
+			if err != nil {
+				fatal("list snapshots", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(snapshots))
+				for _, s := range snapshots {
+					rows = append(rows, []string{
+						s.Name, strconv.FormatInt(s.NodeCount, 10), strconv.FormatInt(s.EdgeCount, 10),
+						s.CreatedAt.Format("2006-01-02 15:04"),
+					})
+				}
+				formatTable([]string{"NAME", "NODES", "EDGES", "CREATED"}, rows)
+				return
+			}
+			names := make([]string, 0, len(snapshots))
+			for _, s := range snapshots {
+				names = append(names, s.Name)
+			}
+			output(snapshots, strings.Join(names, "\n"))
+		},
+	}
+}
+
+func graphCompareCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compare <from> <to>",
+		Short: "Report the drift between two graph snapshots",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			c, err := apiClient.Graph.CompareSnapshots(context.Background(), args[0], args[1])
+			if err != nil {
+				fatal("compare snapshots", err)
+			}
+			if flagFmt == "table" {
+				var rows [][]string
+				for _, d := range c.Types {
+					if d.Status != clientmodels.DriftUnchanged {
+						rows = append(rows, []string{"type", d.Name, d.Status, fmt.Sprintf("%+d", d.Delta)})
+					}
+				}
+				for _, d := range c.Relations {
+					if d.Status != clientmodels.DriftUnchanged {
+						rows = append(rows, []string{"relation", d.Name, d.Status, fmt.Sprintf("%+d", d.Delta)})
+					}
+				}
+				formatTable([]string{"KIND", "NAME", "STATUS", "DELTA"}, rows)
+				return
+			}
+			output(c, strconv.FormatFloat(c.Changed, 'f', -1, 64))
+		},
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/spf13/cobra"
+)
+
+func newHistoryCmd() *cobra.Command {
+	var since, until string
+	opts := &client.HistoryListOptions{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List node changes across the tenant",
+		Long: "List property, label, type, and salience changes across all nodes, newest first.\n" +
+			"--since and --until take an RFC 3339 timestamp or a duration before now, e.g. 24h.",
+		Run: func(cmd *cobra.Command, args []string) {
+			opts.Since = parseHistoryTime("since", since)
+			opts.Until = parseHistoryTime("until", until)
+			changes, hasMore, err := apiClient.History.List(context.Background(), opts)
+			if err != nil {
+				fatal("list history", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(changes))
+				for _, c := range changes {
+					key := c.Field
+					if c.PropertyKey != "" {
+						key = c.PropertyKey
+					}
+					reason := ""
+					if c.Reason != nil {
+						reason = *c.Reason
+					}
+					rows = append(rows, []string{
+						c.ChangedAt.Format(time.RFC3339), c.NodeID, key, string(c.OldValue), string(c.NewValue), reason,
+					})
+				}
+				formatTable([]string{"CHANGED_AT", "NODE", "KEY", "OLD", "NEW", "REASON"}, rows)
+				return
+			}
+			output(map[string]any{"changes": changes, "has_more": hasMore}, fmt.Sprintf("%d", len(changes)))
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "", "Only changes at or after this time")
+	cmd.Flags().StringVar(&until, "until", "", "Only changes before this time")
+	cmd.Flags().StringVar(&opts.PropertyKey, "property", "", "Only changes to this property")
+	cmd.Flags().StringVar(&opts.Field, "field", "", "Only changes to this field: property|label|type|salience")
+	cmd.Flags().StringVar(&opts.Actor, "actor", "", "Only changes made by this actor")
+	cmd.Flags().StringVar(&opts.Reason, "reason", "", "Only changes whose reason contains this text")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Max changes")
+	cmd.Flags().IntVar(&opts.Offset, "offset", 0, "Pagination offset")
+	return cmd
+}
+
+// parseHistoryTime accepts an RFC 3339 timestamp or a duration before now.
+func parseHistoryTime(flag, value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		fatal("list history", fmt.Errorf("--%s must be an RFC 3339 timestamp or a positive duration", flag))
+	}
+	t := time.Now().Add(-d)
+	return &t
+}
@@ -21,6 +21,7 @@ func newImportCmd() *cobra.Command {
 		Short: "Import data from external sources",
 	}
 	cmd.AddCommand(newImportOpenClawCmd())
+	cmd.AddCommand(newImportRDFCmd())
 	return cmd
 }
 
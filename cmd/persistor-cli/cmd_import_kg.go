@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,6 +17,11 @@ func newImportKGCmd() *cobra.Command {
 		regenEmbed   bool
 		resetUsage   bool
 		validateOnly bool
+		resumePath   string
+		batchSize    int
+		atomic       bool
+		sessionID    string
+		interactive  bool
 	)
 
 	cmd := &cobra.Command{
@@ -29,43 +35,24 @@ func newImportKGCmd() *cobra.Command {
   --dry-run                Validate and count without writing
   --regenerate-embeddings  Clear imported embeddings so they get regenerated
   --reset-usage            Zero out access_count and last_accessed
-  --validate               Only validate the file, don't import`,
+  --validate               Only validate the file, don't import
+  --batch-size             Records sent per request (progress is shown per batch)
+  --resume <state-file>    Record progress; re-run to continue an interrupted import
+  --atomic                 Stage batches server-side and apply them in one transaction
+  --session <id>           Continue an interrupted --atomic import session
+  --interactive            Choose keep/overwrite/merge/skip for each existing record`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			filePath := args[0]
 
-			raw, err := os.ReadFile(filePath)
+			data, size, err := loadImportFile(filePath)
 			if err != nil {
-				return fmt.Errorf("reading file: %w", err)
+				return err
 			}
 
-			var data models.ExportFormat
-			if err := json.Unmarshal(raw, &data); err != nil {
-				return fmt.Errorf("parsing export file: %w", err)
-			}
-
-			fmt.Fprintf(os.Stderr, "Export file: schema v%d, %d nodes, %d edges (Persistor %s)\n",
-				data.SchemaVersion, data.Stats.NodeCount, data.Stats.EdgeCount, data.PersistorVersion)
-
 			if validateOnly {
-				errs, err := apiClient.ValidateImport(ctx, &data)
-				if err != nil {
-					return fmt.Errorf("validation failed: %w", err)
-				}
-
-				if len(errs) == 0 {
-					fmt.Fprintln(os.Stderr, "✓ Validation passed — no errors found.")
-					return nil
-				}
-
-				fmt.Fprintf(os.Stderr, "✗ %d validation error(s):\n", len(errs))
-
-				for _, e := range errs {
-					fmt.Fprintf(os.Stderr, "  - %s\n", e)
-				}
-
-				return fmt.Errorf("validation failed with %d error(s)", len(errs))
+				return validateExportFile(ctx, data)
 			}
 
 			opts := models.ImportOptions{
@@ -75,28 +62,27 @@ func newImportKGCmd() *cobra.Command {
 				ResetUsage:           resetUsage,
 			}
 
-			result, err := apiClient.Import(ctx, &data, opts)
-			if err != nil {
-				return fmt.Errorf("import failed: %w", err)
+			var result *models.ImportResult
+			switch {
+			case interactive:
+				result, err = importInteractively(ctx, filePath, size, data, opts, batchSize)
+			case atomic || sessionID != "":
+				result, err = importViaSession(ctx, data, opts, batchSize, sessionID)
+			case dryRun:
+				// Edges are validated against the nodes in the same payload, so a
+				// dry run cannot be split into batches.
+				result, err = apiClient.Import(ctx, data, opts)
+				if err != nil {
+					err = fmt.Errorf("import failed: %w", err)
+				}
+			default:
+				result, err = importInBatches(ctx, filePath, size, data, opts, batchSize, resumePath)
 			}
-
-			prefix := ""
-			if dryRun {
-				prefix = "(dry run) "
+			if err != nil {
+				return err
 			}
 
-			fmt.Fprintf(os.Stderr, "%sNodes: %d created, %d updated, %d skipped\n",
-				prefix, result.NodesCreated, result.NodesUpdated, result.NodesSkipped)
-			fmt.Fprintf(os.Stderr, "%sEdges: %d created, %d updated, %d skipped\n",
-				prefix, result.EdgesCreated, result.EdgesUpdated, result.EdgesSkipped)
-
-			if len(result.Errors) > 0 {
-				fmt.Fprintf(os.Stderr, "%d error(s):\n", len(result.Errors))
-
-				for _, e := range result.Errors {
-					fmt.Fprintf(os.Stderr, "  - %s\n", e)
-				}
-			}
+			printImportResult(result, dryRun)
 
 			return nil
 		},
@@ -107,6 +93,82 @@ func newImportKGCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&regenEmbed, "regenerate-embeddings", false, "Clear embeddings for regeneration")
 	cmd.Flags().BoolVar(&resetUsage, "reset-usage", false, "Zero out access counts")
 	cmd.Flags().BoolVar(&validateOnly, "validate", false, "Only validate, don't import")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 500, "Records sent per request")
+	cmd.Flags().StringVar(&resumePath, "resume", "", "State file used to resume an interrupted import")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Apply the whole import in one transaction")
+	cmd.Flags().StringVar(&sessionID, "session", "", "Import session ID to resume (implies --atomic)")
+	cmd.MarkFlagsMutuallyExclusive("atomic", "resume")
+	cmd.MarkFlagsMutuallyExclusive("session", "resume")
+	cmd.Flags().BoolVar(&interactive, "interactive", false, "Resolve conflicts with existing records one by one")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "overwrite")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "dry-run")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "resume")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "atomic")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "session")
 
 	return cmd
 }
+
+// loadImportFile reads and parses an export file and describes it on stderr.
+// It returns the parsed export and the file size, which identifies the file
+// in resume state.
+func loadImportFile(filePath string) (*models.ExportFormat, int64, error) {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	var data models.ExportFormat
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, 0, fmt.Errorf("parsing export file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Export file: schema v%d, %d nodes, %d edges, %s (Persistor %s)\n",
+		data.SchemaVersion, data.Stats.NodeCount, data.Stats.EdgeCount,
+		formatBytes(int64(len(raw))), data.PersistorVersion)
+
+	return &data, int64(len(raw)), nil
+}
+
+// validateExportFile has the server validate data without importing it and
+// lists any errors.
+func validateExportFile(ctx context.Context, data *models.ExportFormat) error {
+	errs, err := apiClient.ValidateImport(ctx, data)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if len(errs) == 0 {
+		fmt.Fprintln(os.Stderr, "✓ Validation passed — no errors found.")
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "✗ %d validation error(s):\n", len(errs))
+
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "  - %s\n", e)
+	}
+
+	return fmt.Errorf("validation failed with %d error(s)", len(errs))
+}
+
+// printImportResult summarises an import on stderr.
+func printImportResult(result *models.ImportResult, dryRun bool) {
+	prefix := ""
+	if dryRun {
+		prefix = "(dry run) "
+	}
+
+	fmt.Fprintf(os.Stderr, "%sNodes: %d created, %d updated, %d skipped\n",
+		prefix, result.NodesCreated, result.NodesUpdated, result.NodesSkipped)
+	fmt.Fprintf(os.Stderr, "%sEdges: %d created, %d updated, %d skipped\n",
+		prefix, result.EdgesCreated, result.EdgesUpdated, result.EdgesSkipped)
+
+	if len(result.Errors) > 0 {
+		fmt.Fprintf(os.Stderr, "%d error(s):\n", len(result.Errors))
+
+		for _, e := range result.Errors {
+			fmt.Fprintf(os.Stderr, "  - %s\n", e)
+		}
+	}
+}
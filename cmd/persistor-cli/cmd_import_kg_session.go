@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// importChunks splits an export into session chunks of up to batchSize
+// records: nodes first, then edges. The split only depends on the data and
+// batchSize, so a resumed session re-creates the same chunks.
+func importChunks(data *models.ExportFormat, batchSize int) []models.ImportChunk {
+	var chunks []models.ImportChunk
+	for i := 0; i < len(data.Nodes); i += batchSize {
+		chunks = append(chunks, models.ImportChunk{Nodes: data.Nodes[i:min(i+batchSize, len(data.Nodes))]})
+	}
+	for i := 0; i < len(data.Edges); i += batchSize {
+		chunks = append(chunks, models.ImportChunk{Edges: data.Edges[i:min(i+batchSize, len(data.Edges))]})
+	}
+
+	return chunks
+}
+
+// importViaSession uploads the export to an import session, skipping chunks
+// the session already holds, and commits it. Nothing is written to the graph
+// unless the commit succeeds as a whole. An empty sessionID starts a new session.
+func importViaSession(
+	ctx context.Context,
+	data *models.ExportFormat,
+	opts models.ImportOptions,
+	batchSize int,
+	sessionID string,
+) (*models.ImportResult, error) {
+	if batchSize <= 0 || batchSize > models.MaxImportChunkRecords {
+		return nil, fmt.Errorf("--batch-size must be between 1 and %d", models.MaxImportChunkRecords)
+	}
+
+	chunks := importChunks(data, batchSize)
+
+	sess, err := openImportSession(ctx, data, opts, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess.Status == models.ImportSessionCommitted && sess.Result != nil {
+		fmt.Fprintln(os.Stderr, "Import session was already committed.")
+		return sess.Result, nil
+	}
+
+	received := make(map[int]bool, len(sess.Chunks))
+	for _, info := range sess.Chunks {
+		if info.Seq >= len(chunks) || info.Nodes != len(chunks[info.Seq].Nodes) || info.Edges != len(chunks[info.Seq].Edges) {
+			return nil, fmt.Errorf("import session %s does not match this file and --batch-size", sess.ID)
+		}
+		received[info.Seq] = true
+	}
+
+	if len(received) > 0 {
+		fmt.Fprintf(os.Stderr, "Resuming import session (%d of %d chunks already uploaded)\n", len(received), len(chunks))
+	}
+
+	if err := uploadImportChunks(ctx, sess.ID, chunks, received); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(os.Stderr, "Committing import session...")
+	result, err := apiClient.CommitImportSession(ctx, sess.ID)
+	if err != nil {
+		return nil, fmt.Errorf("committing import (continue with --session %s): %w", sess.ID, err)
+	}
+
+	return result, nil
+}
+
+// openImportSession starts a new import session, or fetches the session to
+// continue when sessionID is set.
+func openImportSession(
+	ctx context.Context, data *models.ExportFormat, opts models.ImportOptions, sessionID string,
+) (*models.ImportSession, error) {
+	if sessionID != "" {
+		sess, err := apiClient.ImportSession(ctx, sessionID)
+		if err != nil {
+			return nil, fmt.Errorf("import failed: %w", err)
+		}
+
+		return sess, nil
+	}
+
+	sess, err := apiClient.CreateImportSession(ctx, data.SchemaVersion, opts)
+	if err != nil {
+		return nil, fmt.Errorf("import failed: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Import session %s (continue with --session %s)\n", sess.ID, sess.ID)
+
+	return sess, nil
+}
+
+// uploadImportChunks uploads the chunks the session has not received yet,
+// drawing a progress bar over all records.
+func uploadImportChunks(ctx context.Context, sessionID string, chunks []models.ImportChunk, received map[int]bool) error {
+	total, done := 0, 0
+	for seq, chunk := range chunks {
+		total += len(chunk.Nodes) + len(chunk.Edges)
+		if received[seq] {
+			done += len(chunk.Nodes) + len(chunk.Edges)
+		}
+	}
+
+	bar := newProgressBar("records", total, done, 0)
+	for seq := range chunks {
+		if received[seq] {
+			continue
+		}
+		if err := apiClient.PutImportChunk(ctx, sessionID, seq, &chunks[seq]); err != nil {
+			fmt.Fprintln(os.Stderr)
+			return fmt.Errorf("uploading chunk %d (continue with --session %s): %w", seq, sessionID, err)
+		}
+		bar.add(len(chunks[seq].Nodes)+len(chunks[seq].Edges), 0)
+	}
+	bar.finish()
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// newImportSessionServer stages chunks in memory. Uploading chunk failSeq
+// fails until failSeq is reset to -1. puts counts uploads per seq.
+func newImportSessionServer(t *testing.T, failSeq *int, puts map[int]int) {
+	t.Helper()
+
+	const sessionPath = "/api/v1/import/sessions/s1"
+	var chunks []models.ImportChunkInfo
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/import/sessions", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(models.ImportSession{ID: "s1", Status: models.ImportSessionOpen}) //nolint:errcheck
+	})
+	mux.HandleFunc("GET "+sessionPath, func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(models.ImportSession{ID: "s1", Status: models.ImportSessionOpen, Chunks: chunks}) //nolint:errcheck
+	})
+	mux.HandleFunc("PUT "+sessionPath+"/chunks/{seq}", func(w http.ResponseWriter, r *http.Request) {
+		seq, _ := strconv.Atoi(r.PathValue("seq"))
+		if seq == *failSeq {
+			http.Error(w, `{"code":"internal_error","message":"boom"}`, http.StatusInternalServerError)
+			return
+		}
+		var chunk models.ImportChunk
+		json.NewDecoder(r.Body).Decode(&chunk) //nolint:errcheck
+		puts[seq]++
+		chunks = append(chunks, models.ImportChunkInfo{Seq: seq, Nodes: len(chunk.Nodes), Edges: len(chunk.Edges)})
+		json.NewEncoder(w).Encode(chunks[len(chunks)-1]) //nolint:errcheck
+	})
+	mux.HandleFunc("POST "+sessionPath+"/commit", func(w http.ResponseWriter, _ *http.Request) {
+		var result models.ImportResult
+		for _, c := range chunks {
+			result.NodesCreated += c.Nodes
+			result.EdgesCreated += c.Edges
+		}
+		json.NewEncoder(w).Encode(result) //nolint:errcheck
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+}
+
+func TestImportViaSession_Resume(t *testing.T) {
+	data := &models.ExportFormat{
+		SchemaVersion: 1,
+		Nodes:         []models.ExportNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		Edges:         []models.ExportEdge{{Source: "a", Target: "b", Relation: "knows"}},
+	}
+
+	failSeq := 1
+	puts := map[int]int{}
+	newImportSessionServer(t, &failSeq, puts)
+
+	_, err := importViaSession(context.Background(), data, models.ImportOptions{}, 2, "")
+	if err == nil || !strings.Contains(err.Error(), "--session s1") {
+		t.Fatalf("first run: err = %v, want an upload failure naming the session", err)
+	}
+
+	failSeq = -1
+	result, err := importViaSession(context.Background(), data, models.ImportOptions{}, 2, "s1")
+	if err != nil {
+		t.Fatalf("resumed importViaSession: %v", err)
+	}
+
+	if result.NodesCreated != 3 || result.EdgesCreated != 1 {
+		t.Errorf("result = %+v, want 3 nodes and 1 edge", result)
+	}
+	if puts[0] != 1 || puts[1] != 1 || puts[2] != 1 {
+		t.Errorf("uploads per chunk = %v, want each chunk uploaded once", puts)
+	}
+
+	if _, err := importViaSession(context.Background(), data, models.ImportOptions{}, 1, "s1"); err == nil {
+		t.Error("resuming with a different --batch-size should fail")
+	}
+}
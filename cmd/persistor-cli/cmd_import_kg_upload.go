@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// importState is saved to the --resume file after every batch so an
+// interrupted import can skip the records that were already written.
+type importState struct {
+	File   string              `json:"file"`
+	Size   int64               `json:"size"`
+	Nodes  int                 `json:"nodes"`
+	Edges  int                 `json:"edges"`
+	Result models.ImportResult `json:"result"`
+}
+
+// importInBatches sends nodes and then edges in batches, drawing a progress bar
+// and recording progress in resumePath when set. Nodes go first so every edge
+// batch can be validated against nodes that are already stored.
+func importInBatches(
+	ctx context.Context,
+	filePath string,
+	size int64,
+	data *models.ExportFormat,
+	opts models.ImportOptions,
+	batchSize int,
+	resumePath string,
+) (*models.ImportResult, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("--batch-size must be positive")
+	}
+
+	st, err := openImportState(filePath, size, resumePath)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &batchImport{state: st, opts: opts, batchSize: batchSize, resumePath: resumePath}
+
+	err = run.pass(ctx, "nodes", len(data.Nodes), &st.Nodes, func(start, end int) *models.ExportFormat {
+		return exportBatch(data, data.Nodes[start:end], nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(st.Result.Errors) > 0 {
+		return &st.Result, nil
+	}
+
+	err = run.pass(ctx, "edges", len(data.Edges), &st.Edges, func(start, end int) *models.ExportFormat {
+		return exportBatch(data, nil, data.Edges[start:end])
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(st.Result.Errors) > 0 {
+		return &st.Result, nil
+	}
+
+	if resumePath != "" {
+		os.Remove(resumePath) //nolint:errcheck // best-effort cleanup
+	}
+
+	return &st.Result, nil
+}
+
+// exportBatch returns a copy of data holding only nodes and edges.
+func exportBatch(data *models.ExportFormat, nodes []models.ExportNode, edges []models.ExportEdge) *models.ExportFormat {
+	batch := *data
+	batch.Nodes, batch.Edges = nodes, edges
+
+	return &batch
+}
+
+// openImportState returns the progress recorded in resumePath, or a fresh
+// state when resumePath is empty or does not exist yet.
+func openImportState(filePath string, size int64, resumePath string) (*importState, error) {
+	st := &importState{File: filePath, Size: size}
+	if resumePath == "" {
+		return st, nil
+	}
+
+	resumed, err := loadResumeState(resumePath, st)
+	if err != nil {
+		return nil, err
+	}
+
+	if resumed && (st.File != filePath || st.Size != size) {
+		return nil, fmt.Errorf("resume state %s belongs to a different import file (%s)", resumePath, st.File)
+	}
+
+	if resumed {
+		fmt.Fprintf(os.Stderr, "Resuming import (%d nodes, %d edges already imported)\n", st.Nodes, st.Edges)
+	}
+
+	return st, nil
+}
+
+// batchImport is one run of importInBatches.
+type batchImport struct {
+	state      *importState
+	opts       models.ImportOptions
+	batchSize  int
+	resumePath string
+}
+
+// pass sends records *done up to total in batches built by batchOf, advancing
+// *done and saving the state after each one. It stops at the first batch the
+// server reports errors for, leaving them in the state's result.
+func (r *batchImport) pass(
+	ctx context.Context, label string, total int, done *int, batchOf func(start, end int) *models.ExportFormat,
+) error {
+	bar := newProgressBar(label, total, *done, 0)
+	for *done < total {
+		end := min(*done+r.batchSize, total)
+
+		if err := r.send(ctx, batchOf(*done, end)); err != nil {
+			return err
+		}
+		if len(r.state.Result.Errors) > 0 {
+			return nil
+		}
+
+		bar.add(end-*done, 0)
+		*done = end
+
+		if r.resumePath != "" {
+			if err := saveResumeState(r.resumePath, r.state); err != nil {
+				return err
+			}
+		}
+	}
+	bar.finish()
+
+	return nil
+}
+
+// send imports one batch and adds its counts to the state's result.
+func (r *batchImport) send(ctx context.Context, batch *models.ExportFormat) error {
+	res, err := apiClient.Import(ctx, batch, r.opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr)
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	if len(res.Errors) > 0 {
+		fmt.Fprintln(os.Stderr)
+		r.state.Result.Errors = res.Errors
+		return nil
+	}
+
+	addImportCounts(&r.state.Result, res)
+
+	return nil
+}
+
+// addImportCounts adds the created, updated, and skipped counts of res to
+// total.
+func addImportCounts(total, res *models.ImportResult) {
+	total.NodesCreated += res.NodesCreated
+	total.NodesUpdated += res.NodesUpdated
+	total.NodesSkipped += res.NodesSkipped
+	total.EdgesCreated += res.EdgesCreated
+	total.EdgesUpdated += res.EdgesUpdated
+	total.EdgesSkipped += res.EdgesSkipped
+}
+
+// importInteractively asks how to handle each record that already exists,
+// then imports the new records and the ones to replace in two passes.
+func importInteractively(
+	ctx context.Context,
+	filePath string,
+	size int64,
+	data *models.ExportFormat,
+	opts models.ImportOptions,
+	batchSize int,
+) (*models.ImportResult, error) {
+	fresh, replace, result, err := resolveImportConflicts(ctx, data, batchSize, newConflictResolver(os.Stdin, os.Stderr))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pass := range []struct {
+		data      *models.ExportFormat
+		overwrite bool
+	}{{fresh, false}, {replace, true}} {
+		if len(pass.data.Nodes)+len(pass.data.Edges) == 0 {
+			continue
+		}
+
+		opts.OverwriteExisting = pass.overwrite
+		res, err := importInBatches(ctx, filePath, size, pass.data, opts, batchSize, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Errors) > 0 {
+			return res, nil
+		}
+
+		addImportCounts(result, res)
+	}
+
+	return result, nil
+}
@@ -3,22 +3,30 @@
 import (
 	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/persistorai/persistor/internal/ingest"
+	"github.com/persistorai/persistor/internal/llm"
 	"github.com/spf13/cobra"
 )
 
 func runIngestion(cmd *cobra.Command, dryRun bool, source, scanDir string, chunkTokens int) error {
-	llmClient := ingest.NewLLMClient()
-	fmt.Fprintf(os.Stderr, "LLM provider: %s\n", ingest.LLMProviderName(llmClient))
+	llmClient, err := llm.FromEnv()
+	if err != nil {
+		return fmt.Errorf("configuring LLM provider: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "LLM provider: %s\n", llmClient.Name())
+
+	if err := checkLLMHealth(cmd.Context(), llmClient); err != nil {
+		return err
+	}
 
-	if err := checkLLMHealth(llmClient); err != nil {
+	usage := &llmUsageReporter{provider: llmClient}
+	if err := usage.checkBudget(cmd.Context()); err != nil {
 		return err
 	}
 
@@ -26,46 +34,22 @@ func runIngestion(cmd *cobra.Command, dryRun bool, source, scanDir string, chunk
 	gc := ingest.NewPersistorClient(apiClient)
 
 	if scanDir != "" {
-		return scanAndIngest(cmd.Context(), ext, gc, scanDir, dryRun, chunkTokens)
+		return scanAndIngest(cmd.Context(), ext, gc, usage, scanDir, dryRun, chunkTokens)
 	}
 
-	return ingestStdin(cmd.Context(), ext, gc, source, dryRun, chunkTokens)
-}
-
-func checkLLMHealth(client ingest.LLMClient) error {
-	if c, ok := client.(*ingest.OpenAIClient); ok {
-		return c.HealthCheck(context.Background())
+	err = ingestStdin(cmd.Context(), ext, gc, source, dryRun, chunkTokens)
+	if reportErr := usage.report(cmd.Context()); err == nil {
+		err = reportErr
 	}
 
-	return checkOllamaHealth()
+	return err
 }
 
-func checkOllamaHealth() error {
-	ollamaURL := os.Getenv("OLLAMA_URL")
-	if ollamaURL == "" {
-		ollamaURL = "http://localhost:11434"
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func checkLLMHealth(ctx context.Context, p llm.Provider) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ollamaURL+"/api/tags", nil)
-	if err != nil {
-		return fmt.Errorf("creating Ollama health check: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("Ollama is not reachable at %s: %w\nMake sure Ollama is running (ollama serve)", ollamaURL, err)
-	}
-	defer resp.Body.Close()
-	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain response body
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Ollama returned status %d at %s", resp.StatusCode, ollamaURL)
-	}
-
-	return nil
+	return p.Check(ctx)
 }
 
 func ingestStdin(
@@ -101,6 +85,7 @@ func scanAndIngest(
 	ctx context.Context,
 	ext *ingest.Extractor,
 	gc ingest.GraphClient,
+	usage *llmUsageReporter,
 	dir string,
 	dryRun bool,
 	chunkTokens int,
@@ -122,6 +107,13 @@ func scanAndIngest(
 	processed, skipped := 0, 0
 	fmt.Fprintf(os.Stderr, "Corpus ingest: %d markdown files, manifest %s\n", len(entries), ingestManifestFileName)
 	for _, path := range entries {
+		// Report the previous file's LLM usage, stopping once the
+		// tenant's monthly budget is used up.
+		if err := usage.report(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Stopping corpus ingest: %v\n", err)
+			return err
+		}
+
 		info, err := os.Stat(path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error stating %s: %v\n", path, err)
@@ -156,7 +148,7 @@ func scanAndIngest(
 	}
 
 	fmt.Fprintf(os.Stderr, "Corpus summary: processed %d, skipped %d, total %d\n", processed, skipped, len(entries))
-	return nil
+	return usage.report(ctx)
 }
 
 func findMarkdownFiles(dir string) ([]string, error) {
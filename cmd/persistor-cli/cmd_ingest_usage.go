@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/llm"
+)
+
+// llmUsageReporter reports the LLM usage of an ingest run to the server, so
+// it counts against the tenant's monthly LLM token budget.
+type llmUsageReporter struct {
+	provider llm.Provider
+	reported llm.Usage
+}
+
+// checkBudget fails when the tenant's monthly LLM token budget is already
+// used up. A server that cannot say is not treated as a failure.
+func (r *llmUsageReporter) checkBudget(ctx context.Context) error {
+	usage, err := apiClient.Usage(ctx)
+	if err != nil {
+		return nil //nolint:nilerr // servers that predate model usage reporting do not block the run.
+	}
+
+	if budget := usage.Quota.MaxMonthlyLLMTokens; budget != nil && usage.LLM.Tokens >= *budget {
+		return fmt.Errorf("monthly LLM token budget of %d is used up (%d used)", *budget, usage.LLM.Tokens)
+	}
+
+	return nil
+}
+
+// report sends the usage since the last report. It returns an error only
+// when the budget is now used up; other failures are printed and the usage
+// is reported again next time.
+func (r *llmUsageReporter) report(ctx context.Context) error {
+	usage := llm.UsageOf(r.provider)
+
+	requests, tokens := usage.Requests-r.reported.Requests, usage.Tokens-r.reported.Tokens
+	if requests == 0 && tokens == 0 {
+		return nil
+	}
+
+	err := apiClient.ReportLLMUsage(ctx, requests, tokens)
+	if err == nil || errors.Is(err, client.ErrQuotaExceeded) {
+		r.reported = usage
+	}
+
+	if errors.Is(err, client.ErrQuotaExceeded) {
+		return fmt.Errorf("monthly LLM token budget used up: %w", err)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: reporting LLM usage: %v\n", err)
+	}
+
+	return nil
+}
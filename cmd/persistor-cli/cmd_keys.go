@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	clientmodels "github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func newKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "API key management commands",
+	}
+	cmd.AddCommand(keysStatusCmd())
+	cmd.AddCommand(keysRotateCmd())
+	cmd.AddCommand(keysRevokePreviousCmd())
+	cmd.AddCommand(keysSigningCmd())
+	return cmd
+}
+
+func keysStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show API key scope and rotation state",
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := apiClient.Keys.Status(context.Background())
+			if err != nil {
+				fatal("keys status", err)
+			}
+			output(status, status.Scope)
+		},
+	}
+}
+
+func keysRotateCmd() *cobra.Command {
+	var graceHours int
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Generate a new API key, keeping the current one valid for a grace period",
+		Long: `Generate a new API key for this tenant. The current key keeps working for
+--grace-hours so clients can switch over without downtime; use 0 to revoke it
+immediately. The new key is shown once and cannot be retrieved again.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rotation, err := apiClient.Keys.Rotate(context.Background(), clientmodels.RotateAPIKeyRequest{GraceHours: &graceHours})
+			if err != nil {
+				fatal("keys rotate", err)
+			}
+			output(rotation, rotation.APIKey)
+			if rotation.PreviousKeyExpiresAt != nil {
+				fmt.Fprintf(os.Stderr, "The previous key remains valid until %s.\n", rotation.PreviousKeyExpiresAt.Format(time.RFC3339))
+			}
+			fmt.Fprintln(os.Stderr, "Update PERSISTOR_API_KEY or ~/.persistor/config.yaml with the new key.")
+		},
+	}
+	cmd.Flags().IntVar(&graceHours, "grace-hours", clientmodels.DefaultAPIKeyGraceHours, "Hours the current key stays valid (0 revokes it immediately)")
+	return cmd
+}
+
+func keysRevokePreviousCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-previous",
+		Short: "Stop accepting the previous API key before its grace period ends",
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := apiClient.Keys.RevokePrevious(context.Background())
+			if err != nil {
+				fatal("keys revoke-previous", err)
+			}
+			output(status, "revoked")
+		},
+	}
+}
+
+func keysSigningCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing",
+		Short: "Manage HMAC request signing",
+		Long: `When request signing is enabled, every request must carry a timestamped
+HMAC-SHA256 signature over the method, path, and body. A leaked API key is
+useless without the signing secret.`,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "enable",
+		Short: "Require signed requests, generating a new signing secret",
+		Long: `Generate a request signing secret and require every request to be signed
+with it. Running this again rotates the secret. The secret is shown once and
+cannot be retrieved again.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			signing, err := apiClient.Keys.EnableSigning(context.Background())
+			if err != nil {
+				fatal("keys signing enable", err)
+			}
+			output(signing, signing.SigningSecret)
+			fmt.Fprintln(os.Stderr, "Set PERSISTOR_SIGNING_SECRET or signing_secret in ~/.persistor/config.yaml; unsigned requests are now rejected.")
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "disable",
+		Short: "Stop requiring signed requests",
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := apiClient.Keys.DisableSigning(context.Background())
+			if err != nil {
+				fatal("keys signing disable", err)
+			}
+			output(status, "disabled")
+		},
+	})
+	return cmd
+}
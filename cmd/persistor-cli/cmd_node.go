@@ -5,6 +5,7 @@
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/persistorai/persistor/client"
 	"github.com/spf13/cobra"
@@ -17,12 +18,21 @@ func newNodeCmd() *cobra.Command {
 	}
 	cmd.AddCommand(nodeCreateCmd())
 	cmd.AddCommand(nodeGetCmd())
+	cmd.AddCommand(nodeShowCmd())
 	cmd.AddCommand(nodeUpdateCmd())
 	cmd.AddCommand(nodePatchCmd())
 	cmd.AddCommand(nodeDeleteCmd())
+	cmd.AddCommand(nodeDeleteByFilterCmd())
 	cmd.AddCommand(nodeListCmd())
 	cmd.AddCommand(nodeHistoryCmd())
 	cmd.AddCommand(nodeMigrateCmd())
+	cmd.AddCommand(nodeMergeCmd())
+	cmd.AddCommand(nodeSuggestTagsCmd())
+	cmd.AddCommand(nodeArchivedCmd())
+	cmd.AddCommand(nodeRestoreCmd())
+	cmd.AddCommand(nodeWatchCmd())
+	cmd.AddCommand(nodeUnwatchCmd())
+	cmd.AddCommand(nodeWatchedCmd())
 	return cmd
 }
 
@@ -128,22 +138,37 @@ func nodePatchCmd() *cobra.Command {
 }
 
 func nodeDeleteCmd() *cobra.Command {
-	return &cobra.Command{
+	var detach bool
+	cmd := &cobra.Command{
 		Use:   "delete <id>",
 		Short: "Delete a node",
-		Args:  cobra.ExactArgs(1),
+		Long: `Delete a node. A node that still has edges is not deleted unless
+--detach is given, which deletes its edges along with it.`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := apiClient.Nodes.Delete(context.Background(), args[0]); err != nil {
+			var err error
+			if detach {
+				err = apiClient.Nodes.DeleteDetach(context.Background(), args[0])
+			} else {
+				err = apiClient.Nodes.Delete(context.Background(), args[0])
+			}
+			if err != nil {
+				if client.IsConflict(err) {
+					fatal("delete node", fmt.Errorf("%w (use --detach to delete its edges too)", err))
+				}
 				fatal("delete node", err)
 			}
 			fmt.Println("deleted")
 		},
 	}
+	cmd.Flags().BoolVar(&detach, "detach", false, "Also delete every edge that references the node")
+	return cmd
 }
 
 func nodeListCmd() *cobra.Command {
 	var nodeType string
 	var limit, offset int
+	var props []string
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List nodes",
@@ -160,6 +185,7 @@ func nodeListCmd() *cobra.Command {
 				Type:   nodeType,
 				Limit:  limit,
 				Offset: offset,
+				Props:  props,
 			}
 			nodes, _, err := apiClient.Nodes.List(context.Background(), opts)
 			if err != nil {
@@ -186,84 +212,69 @@ func nodeListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&nodeType, "type", "", "Filter by type")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Max results")
 	cmd.Flags().IntVar(&offset, "offset", 0, "Offset")
+	cmd.Flags().StringArrayVar(&props, "prop", nil, "Only list nodes whose searchable property is key:value, or has key (repeatable)")
 	return cmd
 }
 
-func nodeMigrateCmd() *cobra.Command {
-	var label string
-	var deleteOld, dryRun bool
+func nodeMergeCmd() *cobra.Command {
+	var strategy string
 	cmd := &cobra.Command{
-		Use:   "migrate <old-id> <new-id>",
-		Short: "Migrate a node to a new ID, updating all edges atomically",
-		Args:  cobra.ExactArgs(2),
-		Run: func(cmd *cobra.Command, args []string) {
-			oldID, newID := args[0], args[1]
+		Use:   "merge <source-id> <target-id>",
+		Short: "Merge a duplicate node into another and delete it",
+		Long: `Merge the source node into the target in one transaction. Properties are
+unioned, edges, aliases, and event links move to the target, access counts are
+summed, the higher salience is kept, and the source node is deleted.
 
-			if dryRun {
-				// Dry run: fetch node and count edges client-side.
-				node, err := apiClient.Nodes.Get(context.Background(), oldID)
-				if err != nil {
-					fatal("get node for dry run", err)
-				}
-				edges, _, err := apiClient.Edges.List(context.Background(), &client.EdgeListOptions{Source: oldID, Limit: 10000})
-				if err != nil {
-					fatal("list outgoing edges", err)
-				}
-				outgoing := len(edges)
-				edges, _, err = apiClient.Edges.List(context.Background(), &client.EdgeListOptions{Target: oldID, Limit: 10000})
-				if err != nil {
-					fatal("list incoming edges", err)
-				}
-				incoming := len(edges)
-				fmt.Println("Dry run — no changes made")
-				fmt.Printf("  Node: %s → %s\n", oldID, newID)
-				fmt.Printf("  Outgoing edges: %d would be migrated\n", outgoing)
-				fmt.Printf("  Incoming edges: %d would be migrated\n", incoming)
-				if deleteOld {
-					fmt.Println("  Old node would be deleted")
-				}
-				_ = node
-				return
-			}
-
-			req := &client.MigrateNodeRequest{
-				NewID:     newID,
-				NewLabel:  label,
-				DeleteOld: deleteOld,
-			}
-			result, err := apiClient.Nodes.Migrate(context.Background(), oldID, req)
+--on-conflict decides which value wins when both nodes set a property:
+keep_target (default), keep_source, or fail to abort the merge.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Nodes.MergeInto(context.Background(), args[0], args[1], &client.MergeNodeRequest{ConflictStrategy: strategy})
 			if err != nil {
-				fatal("migrate node", err)
+				fatal("merge node", err)
 			}
-			total := result.OutgoingEdges + result.IncomingEdges
-			fmt.Printf("Migrating node: %s → %s\n", oldID, newID)
-			fmt.Printf("  Edges migrated: %d (%d outgoing, %d incoming)\n", total, result.OutgoingEdges, result.IncomingEdges)
-			fmt.Printf("  Salience transferred: %.2f\n", result.Salience)
-			deleted := "no"
-			if result.OldDeleted {
-				deleted = "yes"
+			if flagFmt != "table" {
+				output(result, result.TargetID)
+				return
 			}
-			fmt.Printf("  Old node deleted: %s\n", deleted)
-			fmt.Println("  Done!")
+			fmt.Printf("Merged node: %s → %s\n", result.SourceID, result.TargetID)
+			fmt.Printf("  Properties added: %d\n", result.PropertiesAdded)
+			if len(result.PropertyConflicts) > 0 {
+				fmt.Printf("  Conflicting properties (%s): %s\n", strategy, strings.Join(result.PropertyConflicts, ", "))
+			}
+			fmt.Printf("  Edges: %d rewired, %d merged, %d dropped\n", result.EdgesRewired, result.EdgesMerged, result.EdgesDropped)
 		},
 	}
-	cmd.Flags().StringVar(&label, "label", "", "New label (defaults to keeping the old one)")
-	cmd.Flags().BoolVar(&deleteOld, "delete-old", true, "Delete the old node after migration")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would happen without doing it")
+	cmd.Flags().StringVar(&strategy, "on-conflict", "keep_target", "Property conflict strategy: keep_target|keep_source|fail")
 	return cmd
 }
 
 func nodeHistoryCmd() *cobra.Command {
-	return &cobra.Command{
+	var property, field string
+	var limit int
+	cmd := &cobra.Command{
 		Use:   "history <id>",
-		Short: "Show property change history for a node",
+		Short: "Show property, label, type, and salience change history for a node",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			changes, _, err := apiClient.Nodes.History(context.Background(), args[0], "", 50, 0)
+			var (
+				changes []client.PropertyChange
+				err     error
+			)
+			if field != "" {
+				changes, _, err = apiClient.Nodes.FieldHistory(context.Background(), args[0], field, limit, 0)
+			} else {
+				changes, _, err = apiClient.Nodes.History(context.Background(), args[0], property, limit, 0)
+			}
 			if err != nil {
 				fatal("get history", err)
 			}
 			output(changes, "")
 		},
 	}
+	cmd.Flags().StringVar(&property, "property", "", "Only show changes to this property")
+	cmd.Flags().StringVar(&field, "field", "", "Only show changes to this field: property|label|type|salience")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max changes")
+	cmd.MarkFlagsMutuallyExclusive("property", "field")
+	return cmd
 }
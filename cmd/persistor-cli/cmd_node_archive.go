@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func nodeArchivedCmd() *cobra.Command {
+	var limit, offset int
+	cmd := &cobra.Command{
+		Use:   "archived",
+		Short: "List nodes archived by the forgetting policy",
+		Long: `List nodes the tenant's forgetting policy moved out of the graph, most
+recently archived first. Archived nodes are left out of search and traversal
+until restored with "persistor node restore".`,
+		Run: func(cmd *cobra.Command, args []string) {
+			nodes, _, err := apiClient.Nodes.Archived(context.Background(), limit, offset)
+			if err != nil {
+				fatal("list archived nodes", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(nodes))
+				for _, n := range nodes {
+					rows = append(rows, []string{n.ID, n.Type, n.Label,
+						fmt.Sprintf("%.3f", n.Salience), n.ArchivedAt.Format("2006-01-02")})
+				}
+				formatTable([]string{"ID", "TYPE", "LABEL", "SALIENCE", "ARCHIVED"}, rows)
+				return
+			}
+			ids := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				ids = append(ids, n.ID)
+			}
+			output(nodes, strings.Join(ids, "\n"))
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max nodes")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Skip this many nodes")
+	return cmd
+}
+
+func nodeRestoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Restore an archived node and its edges to live nodes",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Nodes.Restore(context.Background(), args[0])
+			if err != nil {
+				fatal("restore node", err)
+			}
+			output(result, fmt.Sprintf("%s (%d edges)", result.Node.ID, result.EdgesRestored))
+		},
+	}
+}
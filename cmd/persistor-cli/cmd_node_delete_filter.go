@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func nodeDeleteByFilterCmd() *cobra.Command {
+	var filter models.NodeFilter
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "delete-by-filter",
+		Short: "Delete every node matching a filter, after a preview",
+		Long: `Preview the nodes matching --type and --min-salience, then delete them and
+their edges once confirmed. The delete fails if the filter matches a different
+number of nodes than the preview did, so nothing created in between is lost.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := deleteByFilter(context.Background(), filter, yes, os.Stdin, os.Stdout)
+			if err != nil {
+				fatal("delete by filter", err)
+			}
+			if result != nil {
+				output(result, fmt.Sprint(result.NodesDeleted))
+			}
+		},
+	}
+	cmd.Flags().StringVar(&filter.Type, "type", "", "Delete nodes of this type")
+	cmd.Flags().Float64Var(&filter.MinSalience, "min-salience", 0, "Delete nodes with at least this salience")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+	return cmd
+}
+
+// deleteByFilter previews filter, asks on in unless yes is set, and deletes
+// the previewed nodes. It returns nil without error when nothing matches or
+// the user declines.
+func deleteByFilter(ctx context.Context, filter models.NodeFilter, yes bool, in io.Reader, out io.Writer) (*models.DeleteByFilterResult, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	preview, err := apiClient.Nodes.PreviewDeleteByFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("preview: %w", err)
+	}
+
+	if preview.Count == 0 {
+		fmt.Fprintln(out, "No nodes match the filter.")
+		return nil, nil
+	}
+
+	if !yes {
+		fmt.Fprintf(out, "%d nodes match the filter:\n", preview.Count)
+		for _, n := range preview.Sample {
+			fmt.Fprintf(out, "  %s  %s (%s)\n", n.ID, n.Label, n.Type)
+		}
+		if more := preview.Count - len(preview.Sample); more > 0 {
+			fmt.Fprintf(out, "  ... and %d more\n", more)
+		}
+		fmt.Fprintf(out, "Delete them and their edges? Token expires %s. [y/N]: ", preview.ExpiresAt.Format(time.RFC3339))
+
+		line, _ := bufio.NewReader(in).ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+			fmt.Fprintln(out, "Aborted.")
+			return nil, nil
+		}
+	}
+
+	result, err := apiClient.Nodes.DeleteByFilter(ctx, filter, preview.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
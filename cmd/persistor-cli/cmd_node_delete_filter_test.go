@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestDeleteByFilter(t *testing.T) {
+	var deletes int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/nodes/delete-by-filter/preview", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(models.DeletePreview{ //nolint:errcheck
+			Token:  "tok",
+			Count:  3,
+			Sample: []models.NodeSummary{{ID: "a", Type: "legacy", Label: "A"}},
+		})
+	})
+	mux.HandleFunc("/api/v1/nodes/delete-by-filter", func(w http.ResponseWriter, r *http.Request) {
+		var req models.DeleteByFilterRequest
+		json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck
+		if req.Token != "tok" || req.Filter.Type != "legacy" {
+			t.Errorf("delete request = %+v", req)
+		}
+		deletes++
+		json.NewEncoder(w).Encode(models.DeleteByFilterResult{NodesDeleted: 3, EdgesDeleted: 5}) //nolint:errcheck
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	filter := models.NodeFilter{Type: "legacy"}
+
+	var out bytes.Buffer
+	result, err := deleteByFilter(context.Background(), filter, false, strings.NewReader("n\n"), &out)
+	if err != nil || result != nil || deletes != 0 {
+		t.Fatalf("declined: result %+v, err %v, deletes %d", result, err, deletes)
+	}
+	for _, want := range []string{"3 nodes match", "a  A (legacy)", "... and 2 more", "Aborted."} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	result, err = deleteByFilter(context.Background(), filter, false, strings.NewReader("y\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("confirmed: %v", err)
+	}
+	if result.NodesDeleted != 3 || result.EdgesDeleted != 5 || deletes != 1 {
+		t.Errorf("confirmed: result %+v, deletes %d", result, deletes)
+	}
+
+	if _, err := deleteByFilter(context.Background(), models.NodeFilter{}, true, nil, &bytes.Buffer{}); err == nil {
+		t.Error("empty filter: expected error")
+	}
+}
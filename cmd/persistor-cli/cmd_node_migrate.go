@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/spf13/cobra"
+)
+
+func nodeMigrateCmd() *cobra.Command {
+	var label string
+	var deleteOld, dryRun, copyEdges bool
+	var exclude []string
+	cmd := &cobra.Command{
+		Use:   "migrate <old-id> <new-id>",
+		Short: "Migrate a node to a new ID, updating all edges atomically",
+		Long: `Migrate a node to a new ID. Its edges move to the new node unless their
+relation is passed to --exclude-relation, in which case they stay on the old
+node, or are deleted with it under --delete-old. --copy-edges gives the new
+node copies and keeps the old node and its edges; it turns --delete-old off.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			oldID, newID := args[0], args[1]
+			if copyEdges && !cmd.Flags().Changed("delete-old") {
+				deleteOld = false
+			}
+
+			req := &client.MigrateNodeRequest{
+				NewID:            newID,
+				NewLabel:         label,
+				DeleteOld:        deleteOld,
+				ExcludeRelations: exclude,
+				CopyEdges:        copyEdges,
+				DryRun:           dryRun,
+			}
+
+			result, err := apiClient.Nodes.Migrate(context.Background(), oldID, req)
+			if err != nil {
+				fatal("migrate node", err)
+			}
+			if result.DryRun {
+				fmt.Println("Dry run — no changes made")
+			} else {
+				fmt.Printf("Migrating node: %s → %s\n", oldID, newID)
+			}
+			printMigration(result)
+			if !result.DryRun {
+				fmt.Println("  Done!")
+			}
+		},
+	}
+	cmd.Flags().StringVar(&label, "label", "", "New label (defaults to keeping the old one)")
+	cmd.Flags().BoolVar(&deleteOld, "delete-old", true, "Delete the old node after migration")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would happen without doing it")
+	cmd.Flags().StringSliceVar(&exclude, "exclude-relation", nil, "Leave edges with this relation on the old node (repeatable)")
+	cmd.Flags().BoolVar(&copyEdges, "copy-edges", false, "Copy edges to the new node instead of moving them")
+	return cmd
+}
+
+func printMigration(result *client.MigrateNodeResult) {
+	total := result.OutgoingEdges + result.IncomingEdges
+	fmt.Printf("  Node: %s → %s\n", result.OldID, result.NewID)
+	fmt.Printf("  Edges migrated: %d (%d outgoing, %d incoming)\n", total, result.OutgoingEdges, result.IncomingEdges)
+	for _, e := range result.Edges {
+		conflict := ""
+		if e.Conflict {
+			conflict = "  (conflict: the new node already has this edge)"
+		}
+		fmt.Printf("    %-8s %s -%s-> %s%s\n", e.Action, e.Source, e.Relation, e.Target, conflict)
+	}
+	fmt.Printf("  Salience transferred: %.2f\n", result.Salience)
+	deleted := "no"
+	if result.OldDeleted {
+		deleted = "yes"
+	}
+	fmt.Printf("  Old node deleted: %s\n", deleted)
+}
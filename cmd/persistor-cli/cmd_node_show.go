@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// nodeView is everything `node show` displays about one node.
+type nodeView struct {
+	Node      *client.Node               `json:"node"`
+	Salience  models.SalienceExplanation `json:"salience"`
+	Neighbors *models.GraphSummary       `json:"neighbors"`
+	History   []client.PropertyChange    `json:"history"`
+}
+
+func nodeShowCmd() *cobra.Command {
+	var historyLimit, neighborLimit int
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a node with its salience breakdown, neighbors, and recent changes",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			view, err := fetchNodeView(context.Background(), args[0], historyLimit, neighborLimit, time.Now())
+			if err != nil {
+				fatal("show node", err)
+			}
+			// The human view is the default here; JSON only when asked for.
+			switch {
+			case flagFmt == "json" && cmd.Flags().Changed("format"):
+				formatJSON(view)
+			case flagFmt == "quiet":
+				formatQuiet(view.Node.ID)
+			default:
+				renderNodeView(os.Stdout, view)
+			}
+		},
+	}
+	cmd.Flags().IntVar(&historyLimit, "history", 10, "Number of recent changes to show")
+	cmd.Flags().IntVar(&neighborLimit, "neighbors", 10, "Number of top neighbors to show")
+	return cmd
+}
+
+// fetchNodeView gathers the node, its neighborhood summary, and its change
+// history tail. The salience breakdown is computed locally as of now.
+func fetchNodeView(ctx context.Context, id string, historyLimit, neighborLimit int, now time.Time) (*nodeView, error) {
+	node, err := apiClient.Nodes.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get node: %w", err)
+	}
+
+	summary, err := apiClient.Graph.Summary(ctx, id, neighborLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get neighbors: %w", err)
+	}
+
+	history, _, err := apiClient.Nodes.History(ctx, id, "", historyLimit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get history: %w", err)
+	}
+
+	return &nodeView{
+		Node:      node,
+		Salience:  models.ExplainSalience(salienceInputs(node), now),
+		Neighbors: summary,
+		History:   history,
+	}, nil
+}
+
+// salienceInputs copies the fields the salience formula reads.
+func salienceInputs(n *client.Node) *models.Node {
+	return &models.Node{
+		AccessCount:  n.AccessCount,
+		LastAccessed: n.LastAccessed,
+		Salience:     n.Salience,
+		SupersededBy: n.SupersededBy,
+		UserBoosted:  n.UserBoosted,
+		CreatedAt:    n.CreatedAt,
+	}
+}
+
+func renderNodeView(w io.Writer, v *nodeView) {
+	n := v.Node
+	fmt.Fprintf(w, "%s (%s)\n", n.Label, n.Type)
+	fmt.Fprintf(w, "  id:       %s\n", n.ID)
+	fmt.Fprintf(w, "  created:  %s\n", n.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "  updated:  %s\n", n.UpdatedAt.Format(time.RFC3339))
+	if n.SupersededBy != nil {
+		fmt.Fprintf(w, "  superseded by: %s\n", *n.SupersededBy)
+	}
+
+	fmt.Fprintln(w, "\nProperties")
+	if len(n.Properties) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	keys := make([]string, 0, len(n.Properties))
+	for k := range n.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %s: %v\n", k, n.Properties[k])
+	}
+
+	s := v.Salience
+	fmt.Fprintf(w, "\nSalience %.2f (computed now: %.2f)\n", s.Stored, s.Computed)
+	fmt.Fprintf(w, "  base       %+.2f\n", s.Base)
+	fmt.Fprintf(w, "  access     %+.2f  (%d accesses)\n", s.Access, n.AccessCount)
+	fmt.Fprintf(w, "  recency    %+.2f  (last active %s)\n", s.Recency, s.LastActive.Format(time.RFC3339))
+	fmt.Fprintf(w, "  boost      %+.2f\n", s.Boost)
+	fmt.Fprintf(w, "  supersede  %+.2f\n", s.Supersede)
+
+	fmt.Fprintf(w, "\nNeighbors (%d edges)\n", v.Neighbors.Degree)
+	if len(v.Neighbors.Relations) > 0 {
+		rels := make([]string, len(v.Neighbors.Relations))
+		for i, r := range v.Neighbors.Relations {
+			rels[i] = fmt.Sprintf("%s %s x%d", r.Direction, r.Relation, r.Count)
+		}
+		fmt.Fprintf(w, "  %s\n", strings.Join(rels, ", "))
+	}
+	for _, nb := range v.Neighbors.TopNeighbors {
+		arrow := "->"
+		if nb.Direction == models.DirectionIn {
+			arrow = "<-"
+		}
+		fmt.Fprintf(w, "  %s %s %s (%s, %.2f)\n", arrow, nb.Relation, nb.Label, nb.ID, nb.Salience)
+	}
+
+	fmt.Fprintln(w, "\nRecent changes")
+	if len(v.History) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, c := range v.History {
+		what := c.Field
+		if c.PropertyKey != "" {
+			what = c.PropertyKey
+		}
+		fmt.Fprintf(w, "  %s  %s: %s -> %s\n", c.ChangedAt.Format(time.RFC3339), what, c.OldValue, c.NewValue)
+	}
+}
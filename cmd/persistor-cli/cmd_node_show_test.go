@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestFetchNodeView(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/nodes/alice", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(client.Node{ //nolint:errcheck
+			ID:          "alice",
+			Type:        "person",
+			Label:       "Alice",
+			Properties:  map[string]any{"role": "engineer"},
+			AccessCount: 1,
+			UserBoosted: true,
+			Salience:    3.1,
+			CreatedAt:   now,
+		})
+	})
+	mux.HandleFunc("/api/v1/graph/summary/alice", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(models.GraphSummary{ //nolint:errcheck
+			Degree:       1,
+			Relations:    []models.RelationCount{{Relation: "knows", Direction: models.DirectionOut, Count: 1}},
+			TopNeighbors: []models.SummaryNeighbor{{ID: "bob", Label: "Bob", Relation: "knows", Direction: models.DirectionOut}},
+		})
+	})
+	mux.HandleFunc("/api/v1/nodes/alice/history", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != "5" {
+			t.Errorf("history limit = %q, want 5", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+			"changes": []client.PropertyChange{{
+				Field:       "property",
+				PropertyKey: "role",
+				OldValue:    json.RawMessage(`"intern"`),
+				NewValue:    json.RawMessage(`"engineer"`),
+				ChangedAt:   now,
+			}},
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	view, err := fetchNodeView(context.Background(), "alice", 5, 10, now)
+	if err != nil {
+		t.Fatalf("fetchNodeView: %v", err)
+	}
+	if view.Salience.Computed != 3.8 {
+		t.Errorf("computed salience = %v, want 3.8", view.Salience.Computed)
+	}
+
+	var buf bytes.Buffer
+	renderNodeView(&buf, view)
+	for _, want := range []string{"Alice (person)", "role: engineer", "Salience 3.10", "boost      +2.00", "-> knows Bob (bob", `role: "intern" -> "engineer"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func nodeSuggestTagsCmd() *cobra.Command {
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "suggest-tags <id>",
+		Short: "Suggest tags for a node from the tags of similar nodes",
+		Long: `Rank the tenant's tags (the "tags" node property) by how close the mean
+embedding of each tag's nodes is to this node's embedding. Tags the node
+already has are left out.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := apiClient.Nodes.SuggestTags(context.Background(), args[0], limit)
+			if err != nil {
+				fatal("suggest tags", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(result.Suggestions))
+				for _, s := range result.Suggestions {
+					rows = append(rows, []string{s.Tag, fmt.Sprintf("%.3f", s.Score), fmt.Sprintf("%d", s.NodeCount)})
+				}
+				formatTable([]string{"TAG", "SCORE", "NODES"}, rows)
+				return
+			}
+			tags := make([]string, 0, len(result.Suggestions))
+			for _, s := range result.Suggestions {
+				tags = append(tags, s.Tag)
+			}
+			output(result, strings.Join(tags, "\n"))
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of suggestions (server default 5)")
+	return cmd
+}
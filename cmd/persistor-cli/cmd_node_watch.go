@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const watchLong = `A watcher is a name for a list of watched nodes, e.g. one per agent. A
+WebSocket or SSE subscriber that passes the watcher name receives only the
+events for the nodes on its list.`
+
+func nodeWatchCmd() *cobra.Command {
+	var watcher string
+	cmd := &cobra.Command{
+		Use:   "watch <id>",
+		Short: "Add a node to a watcher's list",
+		Long:  watchLong,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			w, err := apiClient.Nodes.Watch(context.Background(), args[0], watcher)
+			if err != nil {
+				fatal("watch node", err)
+			}
+			output(w, w.NodeID)
+		},
+	}
+	cmd.Flags().StringVar(&watcher, "watcher", "", "Watcher name (required)")
+	_ = cmd.MarkFlagRequired("watcher")
+	return cmd
+}
+
+func nodeUnwatchCmd() *cobra.Command {
+	var watcher string
+	cmd := &cobra.Command{
+		Use:   "unwatch <id>",
+		Short: "Remove a node from a watcher's list",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := apiClient.Nodes.Unwatch(context.Background(), args[0], watcher); err != nil {
+				fatal("unwatch node", err)
+			}
+			fmt.Println("unwatched")
+		},
+	}
+	cmd.Flags().StringVar(&watcher, "watcher", "", "Watcher name (required)")
+	_ = cmd.MarkFlagRequired("watcher")
+	return cmd
+}
+
+func nodeWatchedCmd() *cobra.Command {
+	var watcher string
+	cmd := &cobra.Command{
+		Use:   "watched",
+		Short: "List the nodes a watcher follows",
+		Long:  watchLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			watches, err := apiClient.Nodes.Watched(context.Background(), watcher)
+			if err != nil {
+				fatal("list watched nodes", err)
+			}
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(watches))
+				for _, w := range watches {
+					rows = append(rows, []string{w.NodeID, w.CreatedAt.Format("2006-01-02 15:04")})
+				}
+				formatTable([]string{"NODE", "SINCE"}, rows)
+				return
+			}
+			ids := make([]string, 0, len(watches))
+			for _, w := range watches {
+				ids = append(ids, w.NodeID)
+			}
+			output(watches, strings.Join(ids, "\n"))
+		},
+	}
+	cmd.Flags().StringVar(&watcher, "watcher", "", "Watcher name (required)")
+	_ = cmd.MarkFlagRequired("watcher")
+	return cmd
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func newReportCmd() *cobra.Command {
+	var (
+		kind    string
+		groupBy string
+		out     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Write a summary report as an Excel workbook or CSV file",
+		Long: `Summarize the knowledge graph for spreadsheet users. The report has three
+sections: overall statistics, a per-group breakdown (count, share, salience
+min/avg/max, first and last created), and monthly growth per group with a
+running total.
+
+The file format follows the --out extension: .xlsx writes one worksheet per
+section, .csv writes the sections one after another separated by a blank row.
+
+Nodes can be grouped by type and edges by relation.`,
+		Example: `  persistor report --type nodes --group-by type --out report.xlsx
+  persistor report --type edges --out relations.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := models.StatsReportRequest{Kind: kind, GroupBy: groupBy}
+			return runReport(cmd.Context(), req, out, time.Now())
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "type", models.StatsReportNodes, "What to summarize: nodes|edges")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Grouping: type for nodes, relation for edges (default per type)")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Output file ending in .xlsx or .csv")
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+func runReport(ctx context.Context, req models.StatsReportRequest, out string, now time.Time) error {
+	ext := strings.ToLower(filepath.Ext(out))
+	if ext != ".xlsx" && ext != ".csv" {
+		return fmt.Errorf("--out must end in .xlsx or .csv, got %q", out)
+	}
+
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	stats, err := apiClient.Stats(ctx)
+	if err != nil {
+		return fmt.Errorf("report failed: %w", err)
+	}
+
+	report, err := apiClient.StatsReport(ctx, req)
+	if err != nil {
+		return fmt.Errorf("report failed: %w", err)
+	}
+
+	sheets := reportSheets(stats, report, now)
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("creating report: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // closed explicitly below.
+
+	if ext == ".xlsx" {
+		err = writeXLSX(f, sheets)
+	} else {
+		err = writeReportCSV(f, sheets)
+	}
+
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s report by %s (%d groups) to %s\n",
+		report.Kind, report.GroupBy, len(report.Groups), out)
+
+	return nil
+}
+
+// reportSheets lays out a report as Summary, Groups, and Growth sheets.
+func reportSheets(stats *client.StatsResponse, report *models.StatsReport, now time.Time) []sheet {
+	summary := sheet{Name: "Summary", Rows: [][]any{
+		{"Metric", "Value"},
+		{"Generated", now},
+		{"Report", report.Kind + " by " + report.GroupBy},
+		{"Nodes", stats.Nodes},
+		{"Edges", stats.Edges},
+		{"Entity types", stats.EntityTypes},
+		{"Average node salience", stats.AvgSalience},
+		{"Embeddings complete", stats.EmbeddingsComplete},
+		{"Embeddings pending", stats.EmbeddingsPending},
+		{"Groups", len(report.Groups)},
+	}}
+
+	total := 0
+	for _, g := range report.Groups {
+		total += g.Count
+	}
+
+	groups := sheet{Name: "Groups", Rows: [][]any{{
+		report.GroupBy, "Count", "Share", "Salience min", "Salience avg", "Salience max",
+		"First created", "Last created",
+	}}}
+
+	for _, g := range report.Groups {
+		share := 0.0
+		if total > 0 {
+			share = float64(g.Count) / float64(total)
+		}
+
+		groups.Rows = append(groups.Rows, []any{
+			g.Key, g.Count, share, g.SalienceMin, g.SalienceAvg, g.SalienceMax,
+			g.FirstCreated, g.LastCreated,
+		})
+	}
+
+	// Growth rows arrive ordered by month, so a running sum per group is
+	// its size at the end of each month.
+	growth := sheet{Name: "Growth", Rows: [][]any{{"Month", report.GroupBy, "Added", "Total"}}}
+	running := map[string]int{}
+
+	for _, g := range report.Growth {
+		running[g.Key] += g.Added
+		growth.Rows = append(growth.Rows, []any{g.Month, g.Key, g.Added, running[g.Key]})
+	}
+
+	return []sheet{summary, groups, growth}
+}
+
+// writeReportCSV writes each sheet as a titled section, separated by a
+// blank row.
+func writeReportCSV(w io.Writer, sheets []sheet) error {
+	cw := csv.NewWriter(w)
+
+	for i, s := range sheets {
+		if i > 0 {
+			if err := cw.Write([]string{}); err != nil {
+				return err
+			}
+		}
+
+		if err := cw.Write([]string{s.Name}); err != nil {
+			return err
+		}
+
+		for _, row := range s.Rows {
+			record := make([]string, len(row))
+			for j, v := range row {
+				record[j] = cellString(v)
+			}
+
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
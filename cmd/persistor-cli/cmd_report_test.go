@@ -0,0 +1,149 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func newReportServer(t *testing.T) *string {
+	t.Helper()
+
+	var query string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/stats", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(client.StatsResponse{Nodes: 3, Edges: 1, EntityTypes: 2}) //nolint:errcheck
+	})
+	mux.HandleFunc("/api/v1/stats/report", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		created := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+		json.NewEncoder(w).Encode(models.StatsReport{ //nolint:errcheck
+			Kind:    "nodes",
+			GroupBy: "type",
+			Groups: []models.StatsReportGroup{
+				{Key: "person", Count: 2, SalienceMin: 1, SalienceAvg: 1.5, SalienceMax: 2, FirstCreated: created, LastCreated: created},
+				{Key: "R&D <team>", Count: 1, SalienceMin: 1, SalienceAvg: 1, SalienceMax: 1, FirstCreated: created, LastCreated: created},
+			},
+			Growth: []models.StatsGrowth{
+				{Month: "2026-01", Key: "person", Added: 1},
+				{Month: "2026-02", Key: "R&D <team>", Added: 1},
+				{Month: "2026-02", Key: "person", Added: 1},
+			},
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	return &query
+}
+
+func TestRunReport_CSV(t *testing.T) {
+	query := newReportServer(t)
+	out := filepath.Join(t.TempDir(), "report.csv")
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := runReport(t.Context(), models.StatsReportRequest{}, out, now); err != nil {
+		t.Fatalf("runReport: %v", err)
+	}
+	if *query != "group_by=type&type=nodes" {
+		t.Errorf("query = %q", *query)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"Summary\nMetric,Value\nGenerated,2026-03-01T00:00:00Z\n",
+		"\n\nGroups\ntype,Count,Share,",
+		"person,2,0.6666666666666666,1,1.5,2,2026-01-05T00:00:00Z,2026-01-05T00:00:00Z\n",
+		"\n\nGrowth\nMonth,type,Added,Total\n2026-01,person,1,1\n2026-02,R&D <team>,1,1\n2026-02,person,1,2\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("CSV missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestRunReport_XLSX(t *testing.T) {
+	newReportServer(t)
+	out := filepath.Join(t.TempDir(), "report.xlsx")
+
+	if err := runReport(t.Context(), models.StatsReportRequest{}, out, time.Now()); err != nil {
+		t.Fatalf("runReport: %v", err)
+	}
+
+	zr, err := zip.OpenReader(out)
+	if err != nil {
+		t.Fatalf("not a zip: %v", err)
+	}
+	defer zr.Close()
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(b)
+	}
+
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Growth" sheetId="3" r:id="rId3"/>`) {
+		t.Errorf("workbook = %s", parts["xl/workbook.xml"])
+	}
+	if !strings.Contains(parts["[Content_Types].xml"], "/xl/worksheets/sheet3.xml") {
+		t.Errorf("content types = %s", parts["[Content_Types].xml"])
+	}
+
+	groups := parts["xl/worksheets/sheet2.xml"]
+	for _, want := range []string{
+		`<c r="A3" t="inlineStr"><is><t xml:space="preserve">R&amp;D &lt;team&gt;</t></is></c>`,
+		`<c r="B2"><v>2</v></c>`,
+		`<c r="E2"><v>1.5</v></c>`,
+	} {
+		if !strings.Contains(groups, want) {
+			t.Errorf("groups sheet missing %s:\n%s", want, groups)
+		}
+	}
+	if !strings.Contains(parts["xl/worksheets/sheet3.xml"], `<c r="D4"><v>2</v></c>`) {
+		t.Errorf("growth sheet = %s", parts["xl/worksheets/sheet3.xml"])
+	}
+}
+
+func TestRunReport_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := runReport(t.Context(), models.StatsReportRequest{}, filepath.Join(dir, "report.pdf"), time.Now()); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+	req := models.StatsReportRequest{Kind: "edges", GroupBy: "type"}
+	if err := runReport(t.Context(), req, filepath.Join(dir, "report.csv"), time.Now()); err == nil {
+		t.Error("expected an error for grouping edges by type")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -5,6 +5,8 @@
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/persistorai/persistor/client"
 )
 
 func newSalienceCmd() *cobra.Command {
@@ -15,6 +17,8 @@ func newSalienceCmd() *cobra.Command {
 	cmd.AddCommand(salienceBoostCmd())
 	cmd.AddCommand(salienceSupersedeCmd())
 	cmd.AddCommand(salienceRecalcCmd())
+	cmd.AddCommand(salienceTopCmd())
+	cmd.AddCommand(salienceDecayingCmd())
 	return cmd
 }
 
@@ -64,3 +68,64 @@ func salienceRecalcCmd() *cobra.Command {
 		},
 	}
 }
+
+func salienceTopCmd() *cobra.Command {
+	var nodeType string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "List the most salient nodes",
+		Run: func(cmd *cobra.Command, args []string) {
+			nodes, err := apiClient.Salience.Top(context.Background(), nodeType, limit)
+			if err != nil {
+				fatal("salience top", err)
+			}
+			printSalienceEntries(nodes)
+		},
+	}
+	cmd.Flags().StringVar(&nodeType, "type", "", "Filter by type")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max results")
+	return cmd
+}
+
+func salienceDecayingCmd() *cobra.Command {
+	var nodeType string
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "decaying",
+		Short: "List nodes whose salience dropped most in the last recalc",
+		Run: func(cmd *cobra.Command, args []string) {
+			nodes, err := apiClient.Salience.Decaying(context.Background(), nodeType, limit)
+			if err != nil {
+				fatal("salience decaying", err)
+			}
+			printSalienceEntries(nodes)
+		},
+	}
+	cmd.Flags().StringVar(&nodeType, "type", "", "Filter by type")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Max results")
+	return cmd
+}
+
+func printSalienceEntries(nodes []client.SalienceEntry) {
+	if flagFmt == "table" {
+		headers := []string{"ID", "TYPE", "LABEL", "SALIENCE", "CHANGE"}
+		var rows [][]string
+		for _, n := range nodes {
+			change := ""
+			if n.Change != nil {
+				change = fmt.Sprintf("%+.2f", *n.Change)
+			}
+			rows = append(rows, []string{n.NodeID, n.Type, n.Label, fmt.Sprintf("%.2f", n.Salience), change})
+		}
+		formatTable(headers, rows)
+		return
+	}
+	if flagFmt == "quiet" {
+		for _, n := range nodes {
+			fmt.Println(n.NodeID)
+		}
+		return
+	}
+	output(nodes, "")
+}
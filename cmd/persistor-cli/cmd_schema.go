@@ -14,6 +14,8 @@ func newSchemaCmd() *cobra.Command {
 	}
 	cmd.AddCommand(schemaListRelationsCmd())
 	cmd.AddCommand(schemaAddRelationCmd())
+	cmd.AddCommand(schemaCatalogCmd())
+	cmd.AddCommand(schemaUsageCmd())
 
 	return cmd
 }
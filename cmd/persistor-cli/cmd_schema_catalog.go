@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func schemaCatalogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Show the catalog of allowed relation triples",
+		Long: `Show the tenant's relation catalog: the (source type, relation, target
+type) triples edges may use, and whether the catalog is enforced. Replace it
+with:
+
+  persistor schema catalog set <file>`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			catalog, err := apiClient.Admin.RelationCatalog(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			printRelationCatalog(catalog)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <file>",
+		Short: "Replace the relation catalog from a JSON file",
+		Long: `Replace the tenant's relation catalog with the one in a JSON file:
+
+  {
+    "enforce": true,
+    "rules": [
+      {"source_type": "person", "relation": "works_at", "target_type": "organization"},
+      {"source_type": "*", "relation": "mentions", "target_type": "*"}
+    ]
+  }
+
+"*" matches any node type. While enforce is true, creating an edge that
+matches no rule fails; existing edges are not rechecked. Use
+"persistor schema usage" to see the triples already in use.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("reading catalog file: %w", err)
+			}
+
+			var catalog models.RelationCatalog
+
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+
+			if err := dec.Decode(&catalog); err != nil {
+				return fmt.Errorf("parsing catalog file: %w", err)
+			}
+
+			result, err := apiClient.Admin.SetRelationCatalog(cmd.Context(), catalog)
+			if err != nil {
+				return err
+			}
+
+			printRelationCatalog(result)
+
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+func printRelationCatalog(catalog *models.RelationCatalog) {
+	if flagFmt == "table" {
+		rows := make([][]string, 0, len(catalog.Rules))
+		for _, r := range catalog.Rules {
+			rows = append(rows, []string{r.SourceType, r.Relation, r.TargetType})
+		}
+
+		formatTable([]string{"SOURCE TYPE", "RELATION", "TARGET TYPE"}, rows)
+		fmt.Printf("enforced: %t\n", catalog.Enforce)
+
+		return
+	}
+
+	output(catalog, strconv.Itoa(len(catalog.Rules)))
+}
+
+func schemaUsageCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "usage",
+		Short: "List the relation triples in use with edge counts",
+		Long: `List every (source type, relation, target type) triple the tenant's edges
+use, most used first, and whether the relation catalog allows it. Triples
+that are not declared would be rejected once the catalog is enforced.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			usage, err := apiClient.Edges.RelationUsage(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if flagFmt == "table" {
+				rows := make([][]string, 0, len(usage))
+				for _, u := range usage {
+					rows = append(rows, []string{
+						u.SourceType, u.Relation, u.TargetType,
+						strconv.FormatInt(u.Count, 10), strconv.FormatBool(u.Declared),
+					})
+				}
+
+				formatTable([]string{"SOURCE TYPE", "RELATION", "TARGET TYPE", "EDGES", "DECLARED"}, rows)
+
+				return nil
+			}
+
+			output(map[string]any{"relations": usage}, strconv.Itoa(len(usage)))
+
+			return nil
+		},
+	}
+}
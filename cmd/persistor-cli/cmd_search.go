@@ -3,14 +3,20 @@
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/persistorai/persistor/client"
+	clientmodels "github.com/persistorai/persistor/internal/models"
 	"github.com/spf13/cobra"
 )
 
 func newSearchCmd() *cobra.Command {
 	var mode string
 	var limit int
+	var props []string
+	var root string
+	var depth int
+	var rerank bool
 	cmd := &cobra.Command{
 		Use:   "search <query>",
 		Short: "Search the knowledge graph",
@@ -21,7 +27,7 @@ func newSearchCmd() *cobra.Command {
 
 			switch mode {
 			case "text":
-				opts := &client.SearchOptions{Limit: limit}
+				opts := &client.SearchOptions{Limit: limit, Props: props, Root: root, Depth: depth}
 				nodes, err := apiClient.Search.FullText(ctx, query, opts)
 				if err != nil {
 					fatal("search", err)
@@ -33,9 +39,14 @@ func newSearchCmd() *cobra.Command {
 				output(nodes, "")
 
 			case "vector":
-				scored, err := apiClient.Search.Semantic(ctx, query, limit)
+				if len(props) > 0 {
+					fmt.Fprintf(os.Stderr, "Error: --prop is not supported with --mode vector\n")
+					os.Exit(1)
+				}
+				opts := &client.SearchOptions{Limit: limit, Root: root, Depth: depth}
+				scored, err := apiClient.Search.SemanticWithOptions(ctx, query, opts)
 				if err != nil {
-					fatal("search", err)
+					fatal("search", explainUnavailable(ctx, err, clientmodels.CapabilityEmbeddings))
 				}
 				if flagFmt == "table" {
 					headers := []string{"ID", "LABEL", "TYPE", "SCORE"}
@@ -49,7 +60,7 @@ func newSearchCmd() *cobra.Command {
 				output(scored, "")
 
 			default: // hybrid
-				opts := &client.SearchOptions{Limit: limit}
+				opts := &client.SearchOptions{Limit: limit, Props: props, Root: root, Depth: depth, Rerank: rerank}
 				nodes, err := apiClient.Search.Hybrid(ctx, query, opts)
 				if err != nil {
 					fatal("search", err)
@@ -64,6 +75,10 @@ func newSearchCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&mode, "mode", "hybrid", "Search mode: text|vector|hybrid")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Max results")
+	cmd.Flags().StringArrayVar(&props, "prop", nil, "Only match nodes whose searchable property is key:value, or has key (repeatable)")
+	cmd.Flags().StringVar(&root, "root", "", "Only match nodes within --depth hops of this node ID")
+	cmd.Flags().IntVar(&depth, "depth", 0, "Hops from --root to search within (default 2)")
+	cmd.Flags().BoolVar(&rerank, "rerank", false, "Reorder hybrid results with the server's cross-encoder (RERANK_MODEL)")
 	return cmd
 }
 
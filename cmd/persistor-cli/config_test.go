@@ -9,10 +9,11 @@
 // resetFlags restores global flag state after each test.
 func resetFlags(t *testing.T) {
 	t.Helper()
-	orig := struct{ url, key, fmt string }{flagURL, flagKey, flagFmt}
+	orig := struct{ url, key, secret, fmt string }{flagURL, flagKey, flagSigningSecret, flagFmt}
 	t.Cleanup(func() {
 		flagURL = orig.url
 		flagKey = orig.key
+		flagSigningSecret = orig.secret
 		flagFmt = orig.fmt
 	})
 }
@@ -137,6 +138,7 @@ func TestResolveConfigProfileYAML(t *testing.T) {
 	resetFlags(t)
 	unsetEnv(t, "PERSISTOR_URL")
 	unsetEnv(t, "PERSISTOR_API_KEY")
+	unsetEnv(t, "PERSISTOR_SIGNING_SECRET")
 
 	tmp := t.TempDir()
 	setEnv(t, "HOME", tmp)
@@ -154,6 +156,7 @@ func TestResolveConfigProfileYAML(t *testing.T) {
   staging:
     url: http://staging:4040
     api_key: staging-key
+    signing_secret: staging-secret
 `
 	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(cfgContent), 0o600); err != nil {
 		t.Fatal(err)
@@ -161,6 +164,7 @@ func TestResolveConfigProfileYAML(t *testing.T) {
 
 	flagURL = "http://localhost:3030"
 	flagKey = ""
+	flagSigningSecret = ""
 	resolveConfig()
 
 	if flagURL != "http://staging:4040" {
@@ -169,6 +173,9 @@ func TestResolveConfigProfileYAML(t *testing.T) {
 	if flagKey != "staging-key" {
 		t.Errorf("flagKey from profile: got %q, want %q", flagKey, "staging-key")
 	}
+	if flagSigningSecret != "staging-secret" {
+		t.Errorf("flagSigningSecret from profile: got %q, want %q", flagSigningSecret, "staging-secret")
+	}
 }
 
 // TestResolveConfigDefaultProfile verifies that when active_profile is empty
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// directionBoth follows edges either way; models.DirectionOut and
+// models.DirectionIn follow them from source to target or back.
+const directionBoth = "both"
+
+func validateDirection(direction string) error {
+	switch direction {
+	case directionBoth, models.DirectionOut, models.DirectionIn:
+		return nil
+	default:
+		return fmt.Errorf("direction must be %s, %s, or %s", models.DirectionOut, models.DirectionIn, directionBoth)
+	}
+}
+
+// filterDirection keeps the nodes reachable from root within depth hops when
+// edges are only followed in direction, and the edges such a walk follows.
+// The server walks edges both ways, so a directed walk over its result is exact.
+func filterDirection(root string, nodes []client.Node, edges []client.Edge, direction string, depth int) ([]client.Node, []client.Edge) {
+	if direction == directionBoth {
+		return nodes, edges
+	}
+
+	ends := func(e client.Edge) (from, to string) {
+		if direction == models.DirectionOut {
+			return e.Source, e.Target
+		}
+		return e.Target, e.Source
+	}
+
+	next := make(map[string][]string)
+	for _, e := range edges {
+		from, to := ends(e)
+		next[from] = append(next[from], to)
+	}
+
+	hops := map[string]int{root: 0}
+	frontier := []string{root}
+	for hop := 1; hop <= depth && len(frontier) > 0; hop++ {
+		var nextFrontier []string
+		for _, id := range frontier {
+			for _, to := range next[id] {
+				if _, ok := hops[to]; !ok {
+					hops[to] = hop
+					nextFrontier = append(nextFrontier, to)
+				}
+			}
+		}
+		frontier = nextFrontier
+	}
+
+	keptNodes := make([]client.Node, 0, len(hops))
+	for _, n := range nodes {
+		if _, ok := hops[n.ID]; ok {
+			keptNodes = append(keptNodes, n)
+		}
+	}
+
+	keptEdges := make([]client.Edge, 0, len(edges))
+	for _, e := range edges {
+		from, to := ends(e)
+		fromHop, ok := hops[from]
+		if _, reached := hops[to]; ok && reached && fromHop < depth {
+			keptEdges = append(keptEdges, e)
+		}
+	}
+
+	return keptNodes, keptEdges
+}
+
+// outputGraph prints nodes and edges in the selected --format: JSON by
+// default, node and edge tables, DOT, or node IDs only.
+func outputGraph(v any, nodes []client.Node, edges []client.Edge) {
+	switch flagFmt {
+	case "table":
+		graphTable(nodes, edges)
+	case "dot":
+		writeDOT(os.Stdout, nodes, edges)
+	case "quiet":
+		for _, n := range nodes {
+			formatQuiet(n.ID)
+		}
+	default:
+		formatJSON(v)
+	}
+}
+
+func graphTable(nodes []client.Node, edges []client.Edge) {
+	nodeRows := make([][]string, len(nodes))
+	for i, n := range nodes {
+		nodeRows[i] = []string{n.ID, n.Type, n.Label, strconv.FormatFloat(n.Salience, 'f', 2, 64)}
+	}
+	formatTable([]string{"ID", "TYPE", "LABEL", "SALIENCE"}, nodeRows)
+
+	fmt.Println()
+
+	edgeRows := make([][]string, len(edges))
+	for i, e := range edges {
+		edgeRows[i] = []string{e.Source, e.Relation, e.Target, strconv.FormatFloat(e.Weight, 'f', 2, 64)}
+	}
+	formatTable([]string{"SOURCE", "RELATION", "TARGET", "WEIGHT"}, edgeRows)
+}
+
+// writeDOT renders a Graphviz digraph labeled with node labels and relations.
+func writeDOT(w io.Writer, nodes []client.Node, edges []client.Edge) {
+	fmt.Fprintln(w, "digraph persistor {")
+	for _, n := range nodes {
+		fmt.Fprintf(w, "  %s [label=%s];\n", dotQuote(n.ID), dotQuote(n.Label+"\n("+n.Type+")"))
+	}
+	for _, e := range edges {
+		fmt.Fprintf(w, "  %s -> %s [label=%s];\n", dotQuote(e.Source), dotQuote(e.Target), dotQuote(e.Relation))
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// pathEdges links consecutive path nodes for table and DOT output. The path
+// endpoint does not say which relation joins each step.
+func pathEdges(path []client.Node) []client.Edge {
+	if len(path) < 2 {
+		return nil
+	}
+
+	edges := make([]client.Edge, len(path)-1)
+	for i := range edges {
+		edges[i] = client.Edge{Source: path[i].ID, Target: path[i+1].ID}
+	}
+
+	return edges
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
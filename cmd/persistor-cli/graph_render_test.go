@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/client"
+)
+
+func nodeIDs(nodes []client.Node) string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestFilterDirection(t *testing.T) {
+	// a -> b -> c, d -> a, and b -> a closing a cycle.
+	nodes := []client.Node{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	edges := []client.Edge{
+		{Source: "a", Target: "b"},
+		{Source: "b", Target: "c"},
+		{Source: "d", Target: "a"},
+		{Source: "b", Target: "a"},
+	}
+
+	tests := []struct {
+		direction string
+		depth     int
+		wantNodes string
+		wantEdges int
+	}{
+		{"both", 1, "a,b,c,d", 4},
+		{"out", 1, "a,b", 1},
+		{"out", 2, "a,b,c", 3},
+		{"in", 1, "a,b,d", 2},
+		{"in", 2, "a,b,d", 3},
+	}
+
+	for _, tt := range tests {
+		gotNodes, gotEdges := filterDirection("a", nodes, edges, tt.direction, tt.depth)
+		if got := nodeIDs(gotNodes); got != tt.wantNodes || len(gotEdges) != tt.wantEdges {
+			t.Errorf("%s depth %d: nodes = %s, edges = %d; want %s, %d",
+				tt.direction, tt.depth, got, len(gotEdges), tt.wantNodes, tt.wantEdges)
+		}
+	}
+
+	if err := validateDirection("sideways"); err == nil {
+		t.Error("expected an error for an unknown direction")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	path := []client.Node{{ID: "a", Type: "person", Label: `Al "the" pal`}, {ID: "b", Type: "person", Label: "Bo"}}
+
+	var buf bytes.Buffer
+	writeDOT(&buf, path, pathEdges(path))
+
+	want := `digraph persistor {
+  "a" [label="Al \"the\" pal\n(person)"];
+  "b" [label="Bo\n(person)"];
+  "a" -> "b" [label=""];
+}
+`
+	if buf.String() != want {
+		t.Errorf("dot =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// conflictChoice is how one conflicting import record is handled.
+type conflictChoice string
+
+const (
+	// choiceKeep leaves the stored record as it is.
+	choiceKeep conflictChoice = "keep"
+	// choiceOverwrite replaces the stored record with the incoming one.
+	choiceOverwrite conflictChoice = "overwrite"
+	// choiceMerge imports the incoming record with the stored properties it
+	// does not set carried over.
+	choiceMerge conflictChoice = "merge"
+	// choiceSkip leaves the record, and for a node every incoming edge
+	// touching it, out of the import.
+	choiceSkip conflictChoice = "skip"
+)
+
+var conflictKeys = map[string]conflictChoice{
+	"k": choiceKeep,
+	"o": choiceOverwrite,
+	"m": choiceMerge,
+	"s": choiceSkip,
+}
+
+// conflictResolver asks the user how to handle each conflict, remembering
+// "apply to all" answers per record kind.
+type conflictResolver struct {
+	in  *bufio.Reader
+	out io.Writer
+	all map[string]conflictChoice
+}
+
+func newConflictResolver(in io.Reader, out io.Writer) *conflictResolver {
+	return &conflictResolver{in: bufio.NewReader(in), out: out, all: make(map[string]conflictChoice)}
+}
+
+// ask prints summary and reads a choice. An uppercase answer applies to the
+// remaining conflicts of the same kind without asking again.
+func (r *conflictResolver) ask(kind, summary string) (conflictChoice, error) {
+	if choice, ok := r.all[kind]; ok {
+		return choice, nil
+	}
+
+	fmt.Fprintln(r.out, summary)
+	for {
+		fmt.Fprintf(r.out, "[k]eep local, [o]verwrite, [m]erge, [s]kip (uppercase = all remaining %ss): ", kind)
+
+		line, err := r.in.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if choice, ok := conflictKeys[strings.ToLower(answer)]; ok {
+			if answer != strings.ToLower(answer) {
+				r.all[kind] = choice
+			}
+			fmt.Fprintln(r.out)
+			return choice, nil
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("input closed before all conflicts were resolved")
+			}
+			return "", fmt.Errorf("reading choice: %w", err)
+		}
+	}
+}
+
+// resolveImportConflicts looks up which records in data already exist and
+// asks how to handle each. It returns the records to import without
+// overwriting, the records to import with overwriting, and the count of
+// records left out per kind.
+func resolveImportConflicts(
+	ctx context.Context,
+	data *models.ExportFormat,
+	batchSize int,
+	r *conflictResolver,
+) (fresh, replace *models.ExportFormat, left *models.ImportResult, err error) {
+	if batchSize <= 0 {
+		return nil, nil, nil, fmt.Errorf("--batch-size must be positive")
+	}
+
+	header := *data
+	header.Nodes, header.Edges = nil, nil
+	freshData, replaceData := header, header
+	fresh, replace, left = &freshData, &replaceData, &models.ImportResult{}
+
+	localNodes := make(map[string]models.ExportNode)
+	for i := 0; i < len(data.Nodes); i += batchSize {
+		found, err := apiClient.ImportConflicts(ctx, &models.ExportFormat{Nodes: data.Nodes[i:min(i+batchSize, len(data.Nodes))]})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, n := range found.Nodes {
+			localNodes[n.ID] = n
+		}
+	}
+
+	skipped := make(map[string]bool)
+	for _, n := range data.Nodes {
+		local, ok := localNodes[n.ID]
+		if !ok {
+			fresh.Nodes = append(fresh.Nodes, n)
+			continue
+		}
+
+		choice, err := r.ask("node", nodeConflictSummary(local, n))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch choice {
+		case choiceKeep:
+			left.NodesSkipped++
+		case choiceSkip:
+			left.NodesSkipped++
+			skipped[n.ID] = true
+		case choiceOverwrite:
+			replace.Nodes = append(replace.Nodes, n)
+		case choiceMerge:
+			n.Properties = overlayProperties(local.Properties, n.Properties)
+			replace.Nodes = append(replace.Nodes, n)
+		}
+	}
+
+	edges := make([]models.ExportEdge, 0, len(data.Edges))
+	for _, e := range data.Edges {
+		if skipped[e.Source] || skipped[e.Target] {
+			left.EdgesSkipped++
+			continue
+		}
+		edges = append(edges, e)
+	}
+
+	localEdges := make(map[[3]string]models.ExportEdge)
+	for i := 0; i < len(edges); i += batchSize {
+		found, err := apiClient.ImportConflicts(ctx, &models.ExportFormat{Edges: edges[i:min(i+batchSize, len(edges))]})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, e := range found.Edges {
+			localEdges[edgeKey(e)] = e
+		}
+	}
+
+	for _, e := range edges {
+		local, ok := localEdges[edgeKey(e)]
+		if !ok {
+			fresh.Edges = append(fresh.Edges, e)
+			continue
+		}
+
+		choice, err := r.ask("edge", edgeConflictSummary(local, e))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch choice {
+		case choiceKeep, choiceSkip:
+			left.EdgesSkipped++
+		case choiceOverwrite:
+			replace.Edges = append(replace.Edges, e)
+		case choiceMerge:
+			e.Properties = overlayProperties(local.Properties, e.Properties)
+			replace.Edges = append(replace.Edges, e)
+		}
+	}
+
+	return fresh, replace, left, nil
+}
+
+func edgeKey(e models.ExportEdge) [3]string {
+	return [3]string{e.Source, e.Target, e.Relation}
+}
+
+// overlayProperties returns local with incoming's keys set over it.
+func overlayProperties(local, incoming map[string]any) map[string]any {
+	merged := make(map[string]any, len(local)+len(incoming))
+	for k, v := range local {
+		merged[k] = v
+	}
+	for k, v := range incoming {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func nodeConflictSummary(local, incoming models.ExportNode) string {
+	side := func(n models.ExportNode) string {
+		return fmt.Sprintf("%s %q, %d properties, updated %s",
+			n.Type, n.Label, len(n.Properties), n.UpdatedAt.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("Node %s already exists\n  local:      %s\n  incoming:   %s\n  properties: %s",
+		local.ID, side(local), side(incoming), propertyDiff(local.Properties, incoming.Properties))
+}
+
+func edgeConflictSummary(local, incoming models.ExportEdge) string {
+	side := func(e models.ExportEdge) string {
+		return fmt.Sprintf("weight %.2f, %d properties, updated %s",
+			e.Weight, len(e.Properties), e.UpdatedAt.Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("Edge %s -%s-> %s already exists\n  local:      %s\n  incoming:   %s\n  properties: %s",
+		local.Source, local.Relation, local.Target, side(local), side(incoming),
+		propertyDiff(local.Properties, incoming.Properties))
+}
+
+// propertyDiff names the property keys that differ between two versions.
+func propertyDiff(local, incoming map[string]any) string {
+	var changed, onlyLocal, onlyIncoming []string
+	for k, v := range local {
+		iv, ok := incoming[k]
+		switch {
+		case !ok:
+			onlyLocal = append(onlyLocal, k)
+		case !reflect.DeepEqual(v, iv):
+			changed = append(changed, k)
+		}
+	}
+	for k := range incoming {
+		if _, ok := local[k]; !ok {
+			onlyIncoming = append(onlyIncoming, k)
+		}
+	}
+
+	var parts []string
+	for _, group := range []struct {
+		name string
+		keys []string
+	}{{"changed", changed}, {"only local", onlyLocal}, {"only incoming", onlyIncoming}} {
+		if len(group.keys) > 0 {
+			sort.Strings(group.keys)
+			parts = append(parts, group.name+" "+strings.Join(group.keys, ", "))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "identical"
+	}
+
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/client"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestResolveImportConflicts(t *testing.T) {
+	stored := models.ImportConflicts{
+		Nodes: []models.ExportNode{
+			{ID: "a", Label: "A", Properties: map[string]any{"k": "old", "local": true}},
+			{ID: "b", Label: "B"},
+			{ID: "c", Label: "C"},
+			{ID: "d", Label: "D"},
+		},
+		Edges: []models.ExportEdge{{Source: "e", Target: "a", Relation: "r"}},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data models.ExportFormat
+		json.NewDecoder(r.Body).Decode(&data) //nolint:errcheck
+
+		var found models.ImportConflicts
+		for _, n := range stored.Nodes {
+			for _, in := range data.Nodes {
+				if in.ID == n.ID {
+					found.Nodes = append(found.Nodes, n)
+				}
+			}
+		}
+		for _, e := range stored.Edges {
+			for _, in := range data.Edges {
+				if edgeKey(in) == edgeKey(e) {
+					found.Edges = append(found.Edges, e)
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(found) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	orig := apiClient
+	apiClient = client.New(srv.URL)
+	t.Cleanup(func() { apiClient = orig })
+
+	data := &models.ExportFormat{
+		Nodes: []models.ExportNode{
+			{ID: "a", Label: "A2", Properties: map[string]any{"k": "new"}},
+			{ID: "b"}, {ID: "c"}, {ID: "d"},
+			{ID: "e"},
+		},
+		Edges: []models.ExportEdge{
+			{Source: "e", Target: "a", Relation: "r"},
+			{Source: "e", Target: "b", Relation: "r"},
+			{Source: "e", Target: "c", Relation: "r"},
+		},
+	}
+
+	// a: merge, b: skip (drops e→b), c: garbage then keep, d: overwrite, edge e→a: keep.
+	in := strings.NewReader("m\ns\nx\nk\no\nK\n")
+	fresh, replace, left, err := resolveImportConflicts(t.Context(), data, 2, newConflictResolver(in, io.Discard))
+	if err != nil {
+		t.Fatalf("resolveImportConflicts: %v", err)
+	}
+
+	if len(fresh.Nodes) != 1 || fresh.Nodes[0].ID != "e" {
+		t.Errorf("fresh nodes = %+v, want only e", fresh.Nodes)
+	}
+	if len(fresh.Edges) != 1 || fresh.Edges[0].Target != "c" {
+		t.Errorf("fresh edges = %+v, want only e→c", fresh.Edges)
+	}
+	if len(replace.Nodes) != 2 || replace.Nodes[0].ID != "a" || replace.Nodes[1].ID != "d" {
+		t.Fatalf("replace nodes = %+v, want a and d", replace.Nodes)
+	}
+	if props := replace.Nodes[0].Properties; props["k"] != "new" || props["local"] != true {
+		t.Errorf("merged properties = %v", props)
+	}
+	if len(replace.Edges) != 0 {
+		t.Errorf("replace edges = %+v, want none", replace.Edges)
+	}
+	if left.NodesSkipped != 2 || left.EdgesSkipped != 2 {
+		t.Errorf("left out = %d nodes, %d edges; want 2, 2", left.NodesSkipped, left.EdgesSkipped)
+	}
+
+	// Running out of answers is an error, not a silent default.
+	_, _, _, err = resolveImportConflicts(t.Context(), data, 2, newConflictResolver(strings.NewReader("o\n"), io.Discard))
+	if err == nil {
+		t.Error("expected an error when input ends early")
+	}
+}
+
+func TestConflictResolver_ApplyToAll(t *testing.T) {
+	r := newConflictResolver(strings.NewReader("O\n"), io.Discard)
+	for i := range 3 {
+		choice, err := r.ask("node", "conflict")
+		if err != nil || choice != choiceOverwrite {
+			t.Fatalf("ask %d = %q, %v; want overwrite", i, choice, err)
+		}
+	}
+	if _, err := r.ask("edge", "conflict"); err == nil {
+		t.Error("apply-to-all for nodes should not answer edge conflicts")
+	}
+}
@@ -18,10 +18,11 @@
 )
 
 var (
-	apiClient *client.Client
-	flagURL   string
-	flagKey   string
-	flagFmt   string
+	apiClient         *client.Client
+	flagURL           string
+	flagKey           string
+	flagSigningSecret string
+	flagFmt           string
 )
 
 func versionString() string {
@@ -33,16 +34,18 @@ func versionString() string {
 
 type configFile struct {
 	// Flat format (legacy)
-	URL    string `yaml:"url"`
-	APIKey string `yaml:"api_key"`
+	URL           string `yaml:"url"`
+	APIKey        string `yaml:"api_key"`
+	SigningSecret string `yaml:"signing_secret"`
 	// Profile format
 	Profiles      map[string]configProfile `yaml:"profiles"`
 	ActiveProfile string                   `yaml:"active_profile"`
 }
 
 type configProfile struct {
-	URL    string `yaml:"url"`
-	APIKey string `yaml:"api_key"`
+	URL           string `yaml:"url"`
+	APIKey        string `yaml:"api_key"`
+	SigningSecret string `yaml:"signing_secret"`
 }
 
 func main() {
@@ -52,11 +55,7 @@ func main() {
 		Version: versionString(),
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			resolveConfig()
-			var opts []client.Option
-			if flagKey != "" {
-				opts = append(opts, client.WithAPIKey(flagKey))
-			}
-			apiClient = client.New(flagURL, opts...)
+			apiClient = newAPIClient()
 		},
 		SilenceUsage: true,
 	}
@@ -64,7 +63,8 @@ func main() {
 
 	rootCmd.PersistentFlags().StringVar(&flagURL, "url", "http://localhost:3030", "Persistor server URL (env: PERSISTOR_URL)")
 	rootCmd.PersistentFlags().StringVar(&flagKey, "api-key", "", "API key (env: PERSISTOR_API_KEY)")
-	rootCmd.PersistentFlags().StringVar(&flagFmt, "format", "json", "Output format: json|table|quiet")
+	rootCmd.PersistentFlags().StringVar(&flagSigningSecret, "signing-secret", "", "Request signing secret (env: PERSISTOR_SIGNING_SECRET)")
+	rootCmd.PersistentFlags().StringVar(&flagFmt, "format", "json", "Output format: json|table|quiet, or dot for graph commands")
 
 	initCmd := newInitCmd()
 	initCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {} // skip client setup
@@ -80,8 +80,13 @@ func main() {
 	rootCmd.AddCommand(newSalienceCmd())
 	rootCmd.AddCommand(newAdminCmd())
 	rootCmd.AddCommand(newAuditCmd())
+	rootCmd.AddCommand(newHistoryCmd())
+	rootCmd.AddCommand(newKeysCmd())
 	rootCmd.AddCommand(newImportCmd())
 	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(newDiffCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newApplyCmd())
 	rootCmd.AddCommand(newImportKGCmd())
 	rootCmd.AddCommand(newSchemaCmd())
 	rootCmd.AddCommand(newEvalCmd())
@@ -95,11 +100,7 @@ func main() {
 			return
 		}
 		resolveConfig()
-		var opts []client.Option
-		if flagKey != "" {
-			opts = append(opts, client.WithAPIKey(flagKey))
-		}
-		apiClient = client.New(flagURL, opts...)
+		apiClient = newAPIClient()
 	}
 	rootCmd.AddCommand(ingestCmd)
 
@@ -108,6 +109,18 @@ func main() {
 	}
 }
 
+// newAPIClient builds the API client from the resolved flags.
+func newAPIClient() *client.Client {
+	var opts []client.Option
+	if flagKey != "" {
+		opts = append(opts, client.WithAPIKey(flagKey))
+	}
+	if flagSigningSecret != "" {
+		opts = append(opts, client.WithRequestSigning(flagSigningSecret))
+	}
+	return client.New(flagURL, opts...)
+}
+
 func resolveConfig() {
 	// Flag takes precedence, then env, then config file.
 	if flagURL == "http://localhost:3030" {
@@ -118,6 +131,9 @@ func resolveConfig() {
 	if flagKey == "" {
 		flagKey = os.Getenv("PERSISTOR_API_KEY")
 	}
+	if flagSigningSecret == "" {
+		flagSigningSecret = os.Getenv("PERSISTOR_SIGNING_SECRET")
+	}
 
 	// Try config file for any remaining defaults.
 	home, err := os.UserHomeDir()
@@ -136,6 +152,7 @@ func resolveConfig() {
 	// Resolve from profiles if available, fall back to flat format
 	resolvedURL := cfg.URL
 	resolvedKey := cfg.APIKey
+	resolvedSecret := cfg.SigningSecret
 	if cfg.Profiles != nil {
 		profileName := cfg.ActiveProfile
 		if profileName == "" {
@@ -148,6 +165,9 @@ func resolveConfig() {
 			if p.APIKey != "" {
 				resolvedKey = p.APIKey
 			}
+			if p.SigningSecret != "" {
+				resolvedSecret = p.SigningSecret
+			}
 		}
 	}
 	if flagURL == "http://localhost:3030" && resolvedURL != "" {
@@ -156,6 +176,9 @@ func resolveConfig() {
 	if flagKey == "" && resolvedKey != "" {
 		flagKey = resolvedKey
 	}
+	if flagSigningSecret == "" && resolvedSecret != "" {
+		flagSigningSecret = resolvedSecret
+	}
 }
 
 func fatal(msg string, err error) {
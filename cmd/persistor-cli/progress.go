@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const progressBarWidth = 30
+
+// progressBar renders a single-line record counter on stderr, redrawn in place
+// with a carriage return. The byte count is shown once it is non-zero.
+type progressBar struct {
+	out   io.Writer
+	label string
+	total int
+	done  int
+	bytes int64
+}
+
+// newProgressBar starts a progress bar for total records. done and bytes seed
+// the counters when a run is resumed.
+func newProgressBar(label string, total, done int, bytes int64) *progressBar {
+	p := &progressBar{out: os.Stderr, label: label, total: max(total, done), done: done, bytes: bytes}
+	p.render()
+	return p
+}
+
+// add advances the bar by the given number of records and bytes. The total
+// grows if more records arrive than were expected, e.g. when the graph
+// changes during an export.
+func (p *progressBar) add(records int, bytes int64) {
+	p.done += records
+	p.total = max(p.total, p.done)
+	p.bytes += bytes
+	p.render()
+}
+
+// finish draws the final state and ends the line.
+func (p *progressBar) finish() {
+	p.render()
+	fmt.Fprintln(p.out, " ✓")
+}
+
+func (p *progressBar) render() {
+	fmt.Fprintf(p.out, "\r  %s: %s %d/%d records", p.label, p.bar(), p.done, p.total)
+	if p.bytes > 0 {
+		fmt.Fprintf(p.out, ", %s", formatBytes(p.bytes))
+	}
+}
+
+func (p *progressBar) bar() string {
+	filled := progressBarWidth
+	pct := 100
+	if p.total > 0 {
+		filled = p.done * progressBarWidth / p.total
+		pct = p.done * 100 / p.total
+	}
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), pct)
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// loadResumeState reads a --resume state file into v. It reports false when
+// the file does not exist yet, i.e. this is the first run.
+func loadResumeState(path string, v any) (bool, error) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading resume state: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, fmt.Errorf("parsing resume state %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// saveResumeState writes v to path atomically so an interrupted run never
+// leaves a truncated state file behind.
+func saveResumeState(path string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling resume state: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("writing resume state: %w", err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("saving resume state: %w", err)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -41,12 +42,70 @@ func (h *AdminHandler) BackfillEmbeddings(c *gin.Context) {
 		return
 	}
 
-	for _, n := range nodes {
-		h.embedWorker.Enqueue(service.EmbedJob{TenantID: tenantID, NodeID: n.ID, Text: n.EmbeddingText()})
+	jobs := make([]service.EmbedJob, len(nodes))
+	for i, n := range nodes {
+		jobs[i] = service.EmbedJob{TenantID: tenantID, NodeID: n.ID, Text: n.EmbeddingText()}
 	}
+	job := h.embedWorker.Backfill(tenantID, jobs)
 
-	h.log.WithFields(logrus.Fields{"action": "admin.backfill_embeddings", "tenant_id": tenantID, "queued": len(nodes)}).Info("audit")
-	c.JSON(http.StatusOK, gin.H{"queued": len(nodes)})
+	h.log.WithFields(logrus.Fields{"action": "admin.backfill_embeddings", "tenant_id": tenantID, "job_id": job.ID, "queued": job.Queued}).Info("audit")
+	c.JSON(http.StatusOK, job)
+}
+
+// ListBackfills returns the tenant's recent embedding backfills, newest first.
+func (h *AdminHandler) ListBackfills(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+	if h.embedWorker == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "embedding worker not available")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"backfills": h.embedWorker.ListBackfills(tenantID)})
+}
+
+// BackfillStatus returns the progress of one embedding backfill.
+func (h *AdminHandler) BackfillStatus(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+	if h.embedWorker == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "embedding worker not available")
+		return
+	}
+
+	job, err := h.embedWorker.BackfillStatus(tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelBackfill stops an embedding backfill. Jobs it still has queued are
+// skipped; cancelling a finished backfill returns it unchanged.
+func (h *AdminHandler) CancelBackfill(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+	if h.embedWorker == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "embedding worker not available")
+		return
+	}
+
+	job, err := h.embedWorker.CancelBackfill(tenantID, c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "admin.cancel_backfill", "tenant_id": tenantID, "job_id": job.ID, "remaining": job.Remaining}).Info("audit")
+	c.JSON(http.StatusOK, job)
 }
 
 func (h *AdminHandler) ReprocessNodes(c *gin.Context) {
@@ -139,6 +198,44 @@ func (h *AdminHandler) ListMergeSuggestions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions})
 }
 
+// ListDuplicates handles GET /api/v1/admin/duplicates.
+// Returns probable duplicate node pairs scored by label trigram similarity
+// and embedding cosine similarity. Each duplicate can be merged into its
+// canonical node with POST /nodes/:id/merge-into/:target.
+func (h *AdminHandler) ListDuplicates(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	opts := models.DuplicateListOpts{Type: c.Query("type")}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid limit")
+			return
+		}
+		opts.Limit = limit
+	}
+	if minScoreStr := c.Query("min_score"); minScoreStr != "" {
+		minScore, err := strconv.ParseFloat(minScoreStr, 64)
+		if err != nil || minScore < 0 || minScore > 1 {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "min_score must be between 0 and 1")
+			return
+		}
+		opts.MinScore = minScore
+	}
+
+	duplicates, err := h.repo.ListDuplicates(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		h.log.WithError(err).Error("listing duplicate nodes")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"duplicates": duplicates})
+}
+
 func (h *AdminHandler) RecordRetrievalFeedback(c *gin.Context) {
 	tenantID := getTenantID(c)
 	if tenantID == "" {
@@ -182,3 +279,38 @@ func (h *AdminHandler) GetRetrievalFeedbackSummary(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, summary)
 }
+
+// ExplainQuery returns the PostgreSQL plan for a named internal query run
+// with caller-supplied parameters against the tenant's data.
+func (h *AdminHandler) ExplainQuery(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.ExplainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+		return
+	}
+	req = req.Normalized()
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+		return
+	}
+
+	result, err := h.repo.ExplainQuery(c.Request.Context(), tenantID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrEmbeddingUnavailable) {
+			h.log.WithError(err).Warn("embedding query for explain")
+			respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, models.ErrEmbeddingUnavailable.Error())
+			return
+		}
+		h.log.WithError(err).Error("explaining query")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "admin.explain", "tenant_id": tenantID, "query": req.Query}).Info("audit")
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/service"
+)
+
+func newBackfillRouter(nodes []models.NodeSummary) *gin.Engine {
+	repo := &mockAdminRepo{withoutEmbedFn: func(_ context.Context, _ string, _ int) ([]models.NodeSummary, error) {
+		return nodes, nil
+	}}
+	// The worker is never run, so queued jobs stay queued.
+	worker := service.NewEmbedWorker(nil, nil, testLogger(), 10, 1)
+
+	r := newTestRouter()
+	h := api.NewAdminHandler(repo, worker, testLogger())
+	r.POST("/admin/backfill-embeddings", h.BackfillEmbeddings)
+	r.GET("/admin/backfill-embeddings", h.ListBackfills)
+	r.GET("/admin/backfill-embeddings/:id", h.BackfillStatus)
+	r.POST("/admin/backfill-embeddings/:id/cancel", h.CancelBackfill)
+
+	return r
+}
+
+func decodeBackfill(t *testing.T, body []byte) models.BackfillJob {
+	t.Helper()
+
+	var job models.BackfillJob
+	if err := json.Unmarshal(body, &job); err != nil {
+		t.Fatalf("decode backfill: %v", err)
+	}
+
+	return job
+}
+
+func TestBackfillEmbeddings_TracksJob(t *testing.T) {
+	r := newBackfillRouter([]models.NodeSummary{{ID: "n1", Type: "concept", Label: "A"}, {ID: "n2", Type: "concept", Label: "B"}})
+
+	w := doRequest(r, http.MethodPost, "/admin/backfill-embeddings", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("start: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	started := decodeBackfill(t, w.Body.Bytes())
+	if started.ID == "" || started.Queued != 2 || started.Remaining != 2 || started.Status != models.BackfillRunning {
+		t.Fatalf("start: got %+v", started)
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/backfill-embeddings/"+started.ID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := decodeBackfill(t, w.Body.Bytes()); got.ID != started.ID || got.Remaining != 2 {
+		t.Fatalf("status: got %+v", got)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/backfill-embeddings/"+started.ID+"/cancel", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("cancel: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := decodeBackfill(t, w.Body.Bytes()); got.Status != models.BackfillCancelled {
+		t.Fatalf("cancel: status = %q, want %q", got.Status, models.BackfillCancelled)
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/backfill-embeddings", "")
+	var list struct {
+		Backfills []models.BackfillJob `json:"backfills"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Backfills) != 1 {
+		t.Fatalf("list: err=%v, body=%s", err, w.Body.String())
+	}
+}
+
+func TestBackfillStatus_NotFound(t *testing.T) {
+	r := newBackfillRouter(nil)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/admin/backfill-embeddings/missing"},
+		{http.MethodPost, "/admin/backfill-embeddings/missing/cancel"},
+	} {
+		if w := doRequest(r, tc.method, tc.path, ""); w.Code != http.StatusNotFound {
+			t.Errorf("%s %s: expected 404, got %d: %s", tc.method, tc.path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestBackfillStatus_WorkerUnavailable(t *testing.T) {
+	r := newTestRouter()
+	h := api.NewAdminHandler(&mockAdminRepo{}, nil, testLogger())
+	r.GET("/admin/backfill-embeddings/:id", h.BackfillStatus)
+
+	if w := doRequest(r, http.MethodGet, "/admin/backfill-embeddings/any", ""); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -47,3 +47,86 @@ func TestGetRetrievalFeedbackSummary(t *testing.T) {
 		t.Fatalf("TotalEvents = %d, want 1", body.TotalEvents)
 	}
 }
+
+func TestListDuplicates(t *testing.T) {
+	repo := &mockAdminRepo{duplicatesFn: func(_ context.Context, _ string, opts models.DuplicateListOpts) ([]models.DuplicatePair, error) {
+		if opts.Type != "person" || opts.MinScore != 0.8 {
+			t.Fatalf("opts = %+v, want type person and min_score 0.8", opts)
+		}
+		return []models.DuplicatePair{{
+			Canonical: models.MergeSuggestionNode{ID: "a", Label: "Bill Gates"},
+			Duplicate: models.MergeSuggestionNode{ID: "b", Label: "bill gates"},
+			Score:     0.9,
+		}}, nil
+	}}
+	r := newTestRouter()
+	h := api.NewAdminHandler(repo, nil, testLogger())
+	r.GET("/admin/duplicates", h.ListDuplicates)
+
+	w := doRequest(r, http.MethodGet, "/admin/duplicates?type=person&min_score=0.8", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Duplicates []models.DuplicatePair `json:"duplicates"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(body.Duplicates) != 1 || body.Duplicates[0].Duplicate.ID != "b" {
+		t.Fatalf("duplicates = %+v", body.Duplicates)
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/duplicates?min_score=2", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("min_score=2: expected 400, got %d", w.Code)
+	}
+}
+
+func TestExplainQuery(t *testing.T) {
+	repo := &mockAdminRepo{explainFn: func(_ context.Context, _ string, req models.ExplainRequest) (*models.ExplainResult, error) {
+		if req.Query != models.ExplainFullText || req.Params.Q != "quantum" || req.Params.Limit != 5 {
+			t.Fatalf("req = %+v, want fulltext quantum limit 5", req)
+		}
+		return &models.ExplainResult{Query: req.Query, ExecutionTimeMs: 1.5, Plan: json.RawMessage(`{"Node Type":"Limit"}`)}, nil
+	}}
+	r := newTestRouter()
+	h := api.NewAdminHandler(repo, nil, testLogger())
+	r.POST("/admin/explain", h.ExplainQuery)
+
+	w := doRequest(r, http.MethodPost, "/admin/explain", `{"query":"fulltext","params":{"q":"quantum","limit":5}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body models.ExplainResult
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if body.ExecutionTimeMs != 1.5 || string(body.Plan) != `{"Node Type":"Limit"}` {
+		t.Fatalf("body = %+v", body)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/explain", `{"query":"drop_tables"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown query: expected 400, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/explain", `{"query":"traverse","params":{}}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("traverse without node_id: expected 400, got %d", w.Code)
+	}
+}
+
+func TestExplainQuery_EmbeddingUnavailable(t *testing.T) {
+	repo := &mockAdminRepo{explainFn: func(context.Context, string, models.ExplainRequest) (*models.ExplainResult, error) {
+		return nil, models.ErrEmbeddingUnavailable
+	}}
+	r := newTestRouter()
+	h := api.NewAdminHandler(repo, nil, testLogger())
+	r.POST("/admin/explain", h.ExplainQuery)
+
+	w := doRequest(r, http.MethodPost, "/admin/explain", `{"query":"semantic","params":{"q":"quantum"}}`)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
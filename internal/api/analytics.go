@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// AnalyticsHandler serves the access analytics endpoint and counts the
+// accesses it reports.
+type AnalyticsHandler struct {
+	svc AccessAnalyticsService
+	log *logrus.Logger
+}
+
+// NewAnalyticsHandler creates an AnalyticsHandler. svc may be nil; accesses
+// are then not tracked and the endpoint answers 503.
+func NewAnalyticsHandler(svc AccessAnalyticsService, log *logrus.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{svc: svc, log: log}
+}
+
+// Access handles GET /api/v1/analytics/access?days=7&limit=10.
+func (h *AnalyticsHandler) Access(c *gin.Context) {
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "access analytics not available")
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.AccessAnalyticsRequest
+	for _, p := range []struct {
+		name string
+		dest *int
+	}{{"days", &req.Days}, {"limit", &req.Limit}} {
+		v := c.Query(p.name)
+		if v == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid "+p.name)
+
+			return
+		}
+
+		*p.dest = n
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	analytics, err := h.svc.GetAccessAnalytics(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.log.WithError(err).Error("getting access analytics")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// Track returns middleware that counts a successful request as an access of
+// kind, keyed by key. Failed requests are not counted.
+func (h *AnalyticsHandler) Track(kind models.AccessKind, key func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if h.svc == nil || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		if tenantID := c.GetString("tenant_id"); tenantID != "" {
+			h.svc.TrackAccess(tenantID, kind, key(c))
+		}
+	}
+}
+
+// accessParam keys an access by a path parameter, such as a node ID.
+func accessParam(name string) func(c *gin.Context) string {
+	return func(c *gin.Context) string { return c.Param(name) }
+}
+
+// accessQueryHash keys a search by the hash of its query.
+func accessQueryHash(c *gin.Context) string {
+	q := c.Query("q")
+	if q == "" {
+		return ""
+	}
+
+	return models.HashSearchQuery(q)
+}
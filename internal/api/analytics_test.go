@@ -0,0 +1,79 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type trackedAccess struct {
+	kind models.AccessKind
+	key  string
+}
+
+type mockAccessAnalyticsService struct {
+	tracked []trackedAccess
+	req     models.AccessAnalyticsRequest
+}
+
+func (m *mockAccessAnalyticsService) TrackAccess(_ string, kind models.AccessKind, key string) {
+	m.tracked = append(m.tracked, trackedAccess{kind: kind, key: key})
+}
+
+func (m *mockAccessAnalyticsService) GetAccessAnalytics(
+	_ context.Context, _ string, req models.AccessAnalyticsRequest,
+) (*models.AccessAnalytics, error) {
+	m.req = req
+	return &models.AccessAnalytics{TopNodes: []models.AccessedNode{{NodeID: "a", Count: 3}}}, nil
+}
+
+func TestAnalyticsAccess(t *testing.T) {
+	svc := &mockAccessAnalyticsService{}
+	r := newTestRouter()
+	r.GET("/analytics/access", api.NewAnalyticsHandler(svc, testLogger()).Access)
+
+	w := doRequest(r, http.MethodGet, "/analytics/access?days=30", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if svc.req.Days != 30 || svc.req.Limit != models.DefaultAccessAnalyticsLimit {
+		t.Errorf("request = %+v, want 30 days and the default limit", svc.req)
+	}
+
+	for _, path := range []string{"/analytics/access?days=x", "/analytics/access?days=91", "/analytics/access?limit=101"} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", path, w.Code)
+		}
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/analytics/access", api.NewAnalyticsHandler(nil, testLogger()).Access)
+	if w := doRequest(disabled, http.MethodGet, "/analytics/access", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
+
+func TestAnalyticsTrack(t *testing.T) {
+	svc := &mockAccessAnalyticsService{}
+	h := api.NewAnalyticsHandler(svc, testLogger())
+	r := newTestRouter()
+	r.GET("/nodes/:id", h.Track(models.AccessRead, func(c *gin.Context) string { return c.Param("id") }), func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	doRequest(r, http.MethodGet, "/nodes/a", "")
+	doRequest(r, http.MethodGet, "/nodes/missing", "")
+
+	if len(svc.tracked) != 1 || svc.tracked[0] != (trackedAccess{kind: models.AccessRead, key: "a"}) {
+		t.Errorf("tracked = %+v, want only the successful read of a", svc.tracked)
+	}
+}
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// APIKeyHandler serves tenant API key management endpoints.
+type APIKeyHandler struct {
+	svc     APIKeyService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewAPIKeyHandler creates an APIKeyHandler with the given dependencies.
+func NewAPIKeyHandler(svc APIKeyService, auditor Auditor, log *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// Status handles GET /api/v1/keys.
+func (h *APIKeyHandler) Status(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	status, err := h.svc.GetAPIKeyStatus(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondKeyError(c, err, "getting api key status")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Rotate handles POST /api/v1/keys/rotate.
+// Returns the new key once; the previous key stays valid for grace_hours.
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.RotateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	rotation, err := h.svc.RotateAPIKey(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondKeyError(c, err, "rotating api key")
+
+		return
+	}
+
+	graceHours := int(req.Grace().Hours())
+	h.log.WithFields(logrus.Fields{
+		"action":      "keys.rotate",
+		"tenant_id":   tenantID,
+		"grace_hours": graceHours,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.rotate", map[string]any{"grace_hours": graceHours})
+
+	c.JSON(http.StatusOK, rotation)
+}
+
+// RevokePrevious handles DELETE /api/v1/keys/previous.
+func (h *APIKeyHandler) RevokePrevious(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	status, err := h.svc.RevokePreviousAPIKey(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondKeyError(c, err, "revoking previous api key")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "keys.revoke_previous", "tenant_id": tenantID}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.revoke_previous", nil)
+
+	c.JSON(http.StatusOK, status)
+}
+
+// EnableSigning handles POST /api/v1/keys/signing.
+// Returns a new signing secret once; an existing secret is replaced. Every
+// later request from the tenant must be signed with it.
+func (h *APIKeyHandler) EnableSigning(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	secret, err := h.svc.EnableRequestSigning(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondKeyError(c, err, "enabling request signing")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "keys.enable_signing", "tenant_id": tenantID}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.enable_signing", nil)
+
+	c.JSON(http.StatusOK, secret)
+}
+
+// DisableSigning handles DELETE /api/v1/keys/signing.
+func (h *APIKeyHandler) DisableSigning(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	status, err := h.svc.DisableRequestSigning(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondKeyError(c, err, "disabling request signing")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "keys.disable_signing", "tenant_id": tenantID}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.disable_signing", nil)
+
+	c.JSON(http.StatusOK, status)
+}
+
+// recordAudit stores a persistent audit entry for a key change. Failures are
+// logged but do not fail the request, since the change has already happened.
+func (h *APIKeyHandler) recordAudit(c *gin.Context, tenantID, action string, detail map[string]any) {
+	if h.auditor == nil {
+		return
+	}
+
+	if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, action, "api_key", tenantID, "", detail); err != nil {
+		h.log.WithError(err).Warn("recording api key audit entry")
+	}
+}
+
+func (h *APIKeyHandler) respondKeyError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrTenantNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+
+		return
+	case errors.Is(err, models.ErrAPIKeyInactive):
+		respondError(c, http.StatusConflict, "conflict", err.Error())
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
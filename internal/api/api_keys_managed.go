@@ -0,0 +1,152 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ListManaged handles GET /api/v1/admin/keys.
+func (h *APIKeyHandler) ListManaged(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	keys, err := h.svc.ListManagedAPIKeys(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondKeyError(c, err, "listing api keys")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+// CreateManaged handles POST /api/v1/admin/keys.
+// Returns the new key once; it cannot be retrieved again.
+func (h *APIKeyHandler) CreateManaged(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	created, err := h.svc.CreateManagedAPIKey(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondKeyError(c, err, "creating api key")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "keys.create",
+		"tenant_id": tenantID,
+		"key_id":    created.ID,
+		"scope":     created.Scope,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.create", map[string]any{
+		"key_id": created.ID, "name": created.Name, "scope": created.Scope,
+	})
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// RevokeManaged handles DELETE /api/v1/admin/keys/:id.
+func (h *APIKeyHandler) RevokeManaged(c *gin.Context) {
+	tenantID, keyID, ok := managedKeyParams(c)
+	if !ok {
+		return
+	}
+
+	key, err := h.svc.RevokeManagedAPIKey(c.Request.Context(), tenantID, keyID)
+	if err != nil {
+		h.respondKeyError(c, err, "revoking api key")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "keys.revoke", "tenant_id": tenantID, "key_id": keyID}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.revoke", map[string]any{"key_id": keyID})
+
+	c.JSON(http.StatusOK, key)
+}
+
+// RotateManaged handles POST /api/v1/admin/keys/:id/rotate.
+// Returns the replacement key once; the old key stays valid for grace_hours.
+func (h *APIKeyHandler) RotateManaged(c *gin.Context) {
+	tenantID, keyID, ok := managedKeyParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.RotateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	rotated, err := h.svc.RotateManagedAPIKey(c.Request.Context(), tenantID, keyID, req)
+	if err != nil {
+		h.respondKeyError(c, err, "rotating api key")
+
+		return
+	}
+
+	graceHours := int(req.Grace().Hours())
+	h.log.WithFields(logrus.Fields{
+		"action":      "keys.rotate_managed",
+		"tenant_id":   tenantID,
+		"key_id":      keyID,
+		"new_key_id":  rotated.ID,
+		"grace_hours": graceHours,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "keys.rotate_managed", map[string]any{
+		"key_id": keyID, "new_key_id": rotated.ID, "grace_hours": graceHours,
+	})
+
+	c.JSON(http.StatusOK, rotated)
+}
+
+// managedKeyParams extracts the tenant ID and key ID. A malformed key ID
+// cannot name a key, so it is reported as not found.
+func managedKeyParams(c *gin.Context) (tenantID, keyID string, ok bool) {
+	tenantID = getTenantID(c)
+	if tenantID == "" {
+		return "", "", false
+	}
+
+	keyID = c.Param("id")
+	if _, err := uuid.Parse(keyID); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, models.ErrAPIKeyNotFound.Error())
+
+		return "", "", false
+	}
+
+	return tenantID, keyID, true
+}
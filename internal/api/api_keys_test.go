@@ -0,0 +1,215 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockAPIKeyService struct {
+	rotateFn func(ctx context.Context, tenantID string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error)
+}
+
+func (m *mockAPIKeyService) GetAPIKeyStatus(_ context.Context, _ string) (*models.APIKeyStatus, error) {
+	return nil, models.ErrTenantNotFound
+}
+
+func (m *mockAPIKeyService) RotateAPIKey(ctx context.Context, tenantID string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error) {
+	return m.rotateFn(ctx, tenantID, req)
+}
+
+func (m *mockAPIKeyService) RevokePreviousAPIKey(_ context.Context, _ string) (*models.APIKeyStatus, error) {
+	return &models.APIKeyStatus{Scope: "admin"}, nil
+}
+
+func (m *mockAPIKeyService) EnableRequestSigning(_ context.Context, _ string) (*models.RequestSigningSecret, error) {
+	now := time.Now()
+	return &models.RequestSigningSecret{
+		SigningSecret: "signing-secret",
+		APIKeyStatus:  models.APIKeyStatus{Scope: "admin", SigningRequired: true, SigningEnabledAt: &now},
+	}, nil
+}
+
+func (m *mockAPIKeyService) DisableRequestSigning(_ context.Context, _ string) (*models.APIKeyStatus, error) {
+	return &models.APIKeyStatus{Scope: "admin"}, nil
+}
+
+const (
+	testManagedKeyID = "3d0f4b7e-2c1a-4e8b-9f6d-5a7c8e9b0a12"
+	testRevokedKeyID = "8e2a6c4d-1b3f-4a5e-8c7d-9f0a1b2c3d4e"
+	testMissingKeyID = "5b9d1e3f-7a2c-4d6e-b8f0-1a3c5e7f9b2d"
+)
+
+func (m *mockAPIKeyService) CreateManagedAPIKey(
+	_ context.Context, _ string, req models.CreateAPIKeyRequest,
+) (*models.CreatedAPIKey, error) {
+	return &models.CreatedAPIKey{
+		APIKey:        "managed-key",
+		ManagedAPIKey: models.ManagedAPIKey{ID: testManagedKeyID, Name: req.Name, Scope: req.Scope},
+	}, nil
+}
+
+func (m *mockAPIKeyService) ListManagedAPIKeys(_ context.Context, _ string) ([]models.ManagedAPIKey, error) {
+	return []models.ManagedAPIKey{{ID: testManagedKeyID, Name: "ci"}}, nil
+}
+
+func (m *mockAPIKeyService) RevokeManagedAPIKey(_ context.Context, _, keyID string) (*models.ManagedAPIKey, error) {
+	if keyID == testMissingKeyID {
+		return nil, models.ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	return &models.ManagedAPIKey{ID: keyID, RevokedAt: &now}, nil
+}
+
+func (m *mockAPIKeyService) RotateManagedAPIKey(
+	_ context.Context, _, keyID string, _ models.RotateAPIKeyRequest,
+) (*models.RotatedAPIKey, error) {
+	if keyID == testRevokedKeyID {
+		return nil, models.ErrAPIKeyInactive
+	}
+	return &models.RotatedAPIKey{
+		CreatedAPIKey: models.CreatedAPIKey{APIKey: "rotated-key", ManagedAPIKey: models.ManagedAPIKey{ID: "new-id"}},
+		Previous:      models.ManagedAPIKey{ID: keyID},
+	}, nil
+}
+
+func TestAPIKeyRotate(t *testing.T) {
+	var gotGrace time.Duration
+	auditor := &mockAuditor{}
+	svc := &mockAPIKeyService{
+		rotateFn: func(_ context.Context, _ string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error) {
+			gotGrace = req.Grace()
+			expires := time.Now().Add(gotGrace)
+			return &models.APIKeyRotation{APIKey: "new-key", APIKeyStatus: models.APIKeyStatus{Scope: "admin", PreviousKeyExpiresAt: &expires}}, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewAPIKeyHandler(svc, auditor, testLogger())
+	r.GET("/keys", h.Status)
+	r.POST("/keys/rotate", h.Rotate)
+	r.DELETE("/keys/previous", h.RevokePrevious)
+
+	w := doRequest(r, http.MethodPost, "/keys/rotate", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if gotGrace != models.DefaultAPIKeyGraceHours*time.Hour {
+		t.Errorf("grace = %s, want default", gotGrace)
+	}
+
+	var rotation models.APIKeyRotation
+	if err := json.Unmarshal(w.Body.Bytes(), &rotation); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if rotation.APIKey != "new-key" || rotation.PreviousKeyExpiresAt == nil {
+		t.Errorf("rotation = %+v, want new key with previous key expiry", rotation)
+	}
+	if auditor.action != "keys.rotate" {
+		t.Errorf("audit action = %q, want keys.rotate", auditor.action)
+	}
+
+	w = doRequest(r, http.MethodPost, "/keys/rotate", `{"grace_hours":0}`)
+	if w.Code != http.StatusOK || gotGrace != 0 {
+		t.Errorf("immediate rotation: status = %d, grace = %s", w.Code, gotGrace)
+	}
+
+	w = doRequest(r, http.MethodPost, "/keys/rotate", `{"grace_hours":-1}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("negative grace: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/keys/previous", "")
+	if w.Code != http.StatusOK || auditor.action != "keys.revoke_previous" {
+		t.Errorf("revoke previous: status = %d, audit action = %q", w.Code, auditor.action)
+	}
+
+	w = doRequest(r, http.MethodGet, "/keys", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing tenant: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestAPIKeySigning(t *testing.T) {
+	auditor := &mockAuditor{}
+	r := newTestRouter()
+	h := api.NewAPIKeyHandler(&mockAPIKeyService{}, auditor, testLogger())
+	r.POST("/keys/signing", h.EnableSigning)
+	r.DELETE("/keys/signing", h.DisableSigning)
+
+	w := doRequest(r, http.MethodPost, "/keys/signing", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var secret models.RequestSigningSecret
+	if err := json.Unmarshal(w.Body.Bytes(), &secret); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if secret.SigningSecret != "signing-secret" || !secret.SigningRequired {
+		t.Errorf("response = %+v, want secret with signing required", secret)
+	}
+	if auditor.action != "keys.enable_signing" {
+		t.Errorf("audit action = %q, want keys.enable_signing", auditor.action)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/keys/signing", "")
+	if w.Code != http.StatusOK || auditor.action != "keys.disable_signing" {
+		t.Errorf("disable: status = %d, audit action = %q", w.Code, auditor.action)
+	}
+}
+
+func TestManagedAPIKeys(t *testing.T) {
+	auditor := &mockAuditor{}
+	r := newTestRouter()
+	h := api.NewAPIKeyHandler(&mockAPIKeyService{}, auditor, testLogger())
+	r.GET("/admin/keys", h.ListManaged)
+	r.POST("/admin/keys", h.CreateManaged)
+	r.DELETE("/admin/keys/:id", h.RevokeManaged)
+	r.POST("/admin/keys/:id/rotate", h.RotateManaged)
+
+	w := doRequest(r, http.MethodPost, "/admin/keys", `{"name":"ci"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d: %s", w.Code, w.Body.String())
+	}
+	var created models.CreatedAPIKey
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if created.APIKey != "managed-key" || created.Scope != models.APIKeyScopeReadWrite {
+		t.Errorf("created = %+v, want the key with the default scope", created)
+	}
+	if auditor.action != "keys.create" {
+		t.Errorf("audit action = %q, want keys.create", auditor.action)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path, body string
+		wantStatus int
+	}{
+		{"missing name", http.MethodPost, "/admin/keys", `{}`, http.StatusBadRequest},
+		{"invalid scope", http.MethodPost, "/admin/keys", `{"name":"ci","scope":"root"}`, http.StatusBadRequest},
+		{"past expiry", http.MethodPost, "/admin/keys", `{"name":"ci","expires_at":"2020-01-01T00:00:00Z"}`, http.StatusBadRequest},
+		{"list", http.MethodGet, "/admin/keys", "", http.StatusOK},
+		{"revoke", http.MethodDelete, "/admin/keys/" + testManagedKeyID, "", http.StatusOK},
+		{"revoke unknown", http.MethodDelete, "/admin/keys/" + testMissingKeyID, "", http.StatusNotFound},
+		{"revoke malformed ID", http.MethodDelete, "/admin/keys/nope", "", http.StatusNotFound},
+		{"rotate", http.MethodPost, "/admin/keys/" + testManagedKeyID + "/rotate", "", http.StatusOK},
+		{"rotate revoked", http.MethodPost, "/admin/keys/" + testRevokedKeyID + "/rotate", "", http.StatusConflict},
+		{"rotate bad grace", http.MethodPost, "/admin/keys/" + testManagedKeyID + "/rotate", `{"grace_hours":-1}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		w := doRequest(r, tt.method, tt.path, tt.body)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+}
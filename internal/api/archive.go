@@ -0,0 +1,212 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ArchiveHandler serves node archival: the tenant's forgetting policy, the
+// archive listing, and restores.
+type ArchiveHandler struct {
+	svc     ArchiveService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewArchiveHandler creates an ArchiveHandler. svc may be nil when archival
+// is not configured; the endpoints then answer 503.
+func NewArchiveHandler(svc ArchiveService, auditor Auditor, log *logrus.Logger) *ArchiveHandler {
+	return &ArchiveHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// GetPolicy handles GET /api/v1/admin/archive/policy.
+func (h *ArchiveHandler) GetPolicy(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	policy, err := h.svc.GetArchivePolicy(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondArchiveError(c, err, "getting archive policy")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// SetPolicy handles PUT /api/v1/admin/archive/policy.
+// Omitted or null fields disable archival.
+func (h *ArchiveHandler) SetPolicy(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.ArchivePolicy
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	policy, err := h.svc.SetArchivePolicy(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondArchiveError(c, err, "setting archive policy")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":         "archive.set_policy",
+		"tenant_id":      tenantID,
+		"salience_below": policy.SalienceBelow,
+		"idle_days":      policy.IdleDays,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "archive.set_policy", "tenant", tenantID, map[string]any{
+		"salience_below": policy.SalienceBelow,
+		"idle_days":      policy.IdleDays,
+	})
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Run handles POST /api/v1/admin/archive/run.
+// Applies the tenant's archive policy immediately instead of waiting for the
+// background job.
+func (h *ArchiveHandler) Run(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.svc.ArchiveTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondArchiveError(c, err, "archiving nodes")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "node.archive",
+		"tenant_id": tenantID,
+		"nodes":     result.Nodes,
+		"edges":     result.Edges,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "node.archive", "node", "", map[string]any{
+		"nodes": result.Nodes,
+		"edges": result.Edges,
+	})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// List handles GET /api/v1/archive.
+func (h *ArchiveHandler) List(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.DefaultQuery("limit", "50"), 50)
+	offset := parseOffset(c.DefaultQuery("offset", "0"))
+
+	nodes, hasMore, err := h.svc.ListArchivedNodes(c.Request.Context(), tenantID, limit, offset)
+	if err != nil {
+		h.respondArchiveError(c, err, "listing archived nodes")
+
+		return
+	}
+
+	if nodes == nil {
+		nodes = []models.ArchivedNode{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"nodes": nodes, "has_more": hasMore})
+}
+
+// Restore handles POST /api/v1/archive/:id/restore.
+func (h *ArchiveHandler) Restore(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.svc.RestoreNode(c.Request.Context(), tenantID, nodeID)
+	if err != nil {
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, models.ErrNodeNotArchived):
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "archived node not found")
+		case errors.Is(err, models.ErrDuplicateKey):
+			respondError(c, http.StatusConflict, "conflict", "a node with this ID exists again")
+		default:
+			h.respondArchiveError(c, err, "restoring archived node")
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// tenant returns the request's tenant, or "" after answering when there is
+// none or archival is not available.
+func (h *ArchiveHandler) tenant(c *gin.Context) string {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "node archive not available")
+		return ""
+	}
+
+	return tenantID
+}
+
+// recordAudit stores a persistent audit entry for a policy change or run.
+// Failures are logged but do not fail the request.
+func (h *ArchiveHandler) recordAudit(c *gin.Context, tenantID, action, entityType, entityID string, detail map[string]any) {
+	if h.auditor == nil {
+		return
+	}
+
+	if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, action, entityType, entityID, "", detail); err != nil {
+		h.log.WithError(err).Warn("recording archive audit entry")
+	}
+}
+
+func (h *ArchiveHandler) respondArchiveError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, models.ErrTenantNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
@@ -0,0 +1,134 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockArchiveService struct {
+	policy models.ArchivePolicy
+}
+
+func (m *mockArchiveService) GetArchivePolicy(_ context.Context, _ string) (*models.ArchivePolicy, error) {
+	p := m.policy
+	return &p, nil
+}
+
+func (m *mockArchiveService) SetArchivePolicy(_ context.Context, _ string, p models.ArchivePolicy) (*models.ArchivePolicy, error) {
+	m.policy = p
+	return &p, nil
+}
+
+func (m *mockArchiveService) ArchiveTenant(_ context.Context, _ string) (*models.ArchiveResult, error) {
+	return &models.ArchiveResult{Nodes: 4, Edges: 6}, nil
+}
+
+func (m *mockArchiveService) ListArchivedNodes(_ context.Context, _ string, _, _ int) ([]models.ArchivedNode, bool, error) {
+	return []models.ArchivedNode{{ID: "alice", Type: "person", Label: "Alice"}}, true, nil
+}
+
+func (m *mockArchiveService) RestoreNode(_ context.Context, _, nodeID string) (*models.RestoreResult, error) {
+	if nodeID != "alice" {
+		return nil, models.ErrNodeNotArchived
+	}
+
+	return &models.RestoreResult{Node: &models.Node{ID: nodeID}, EdgesRestored: 1}, nil
+}
+
+func TestArchivePolicy(t *testing.T) {
+	auditor := &mockAuditor{}
+	r := newTestRouter()
+	h := api.NewArchiveHandler(&mockArchiveService{}, auditor, testLogger())
+	r.GET("/admin/archive/policy", h.GetPolicy)
+	r.PUT("/admin/archive/policy", h.SetPolicy)
+	r.POST("/admin/archive/run", h.Run)
+
+	w := doRequest(r, http.MethodPut, "/admin/archive/policy", `{"salience_below":0.2,"idle_days":90}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if auditor.action != "archive.set_policy" {
+		t.Errorf("audit action = %q, want archive.set_policy", auditor.action)
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/archive/policy", "")
+	var got models.ArchivePolicy
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.SalienceBelow == nil || *got.SalienceBelow != 0.2 || got.IdleDays == nil || *got.IdleDays != 90 {
+		t.Errorf("policy = %+v, want 0.2/90", got)
+	}
+
+	for _, body := range []string{
+		`{"salience_below":0.2}`,
+		`{"salience_below":0,"idle_days":30}`,
+		`{"salience_below":0.2,"idle_days":0}`,
+	} {
+		w = doRequest(r, http.MethodPut, "/admin/archive/policy", body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", body, w.Code, http.StatusBadRequest)
+		}
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/archive/run", "")
+	if w.Code != http.StatusOK || auditor.action != "node.archive" {
+		t.Fatalf("run: status = %d, audit action = %q", w.Code, auditor.action)
+	}
+
+	var result models.ArchiveResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Nodes != 4 || result.Edges != 6 {
+		t.Errorf("result = %+v, want 4 nodes, 6 edges", result)
+	}
+}
+
+func TestArchiveListAndRestore(t *testing.T) {
+	r := newTestRouter()
+	h := api.NewArchiveHandler(&mockArchiveService{}, nil, testLogger())
+	r.GET("/archive", h.List)
+	r.POST("/archive/:id/restore", h.Restore)
+
+	w := doRequest(r, http.MethodGet, "/archive?limit=1", "")
+	var list struct {
+		Nodes   []models.ArchivedNode `json:"nodes"`
+		HasMore bool                  `json:"has_more"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if w.Code != http.StatusOK || len(list.Nodes) != 1 || !list.HasMore {
+		t.Errorf("list: status = %d, body = %+v", w.Code, list)
+	}
+
+	w = doRequest(r, http.MethodPost, "/archive/alice/restore", "")
+	var restored models.RestoreResult
+	if err := json.Unmarshal(w.Body.Bytes(), &restored); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if w.Code != http.StatusOK || restored.Node == nil || restored.EdgesRestored != 1 {
+		t.Errorf("restore: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodPost, "/archive/bob/restore", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("restore of live node: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestArchiveUnavailable(t *testing.T) {
+	r := newTestRouter()
+	h := api.NewArchiveHandler(nil, nil, testLogger())
+	r.GET("/archive", h.List)
+
+	if w := doRequest(r, http.MethodGet, "/archive", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -23,6 +23,7 @@ func NewAuditHandler(repo AuditService, log *logrus.Logger) *AuditHandler {
 }
 
 // Query handles GET /api/v1/audit.
+// Pass next_cursor as ?after= to fetch the following page without an offset.
 func (h *AuditHandler) Query(c *gin.Context) {
 	tenantID := getTenantID(c)
 	if tenantID == "" {
@@ -46,6 +47,13 @@ func (h *AuditHandler) Query(c *gin.Context) {
 		opts.Since = &t
 	}
 
+	after, err := models.DecodeAuditCursor(c.Query("after"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return
+	}
+	opts.After = after
+
 	entries, hasMore, err := h.repo.QueryAudit(c.Request.Context(), tenantID, opts)
 	if err != nil {
 		h.log.WithError(err).Error("failed to query audit log")
@@ -53,10 +61,12 @@ func (h *AuditHandler) Query(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":     entries,
-		"has_more": hasMore,
-	})
+	resp := gin.H{"data": entries, "has_more": hasMore}
+	if hasMore && len(entries) > 0 {
+		resp["next_cursor"] = models.AuditCursorAfter(&entries[len(entries)-1]).Encode()
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 // Purge handles DELETE /api/v1/audit.
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// BranchHandler serves branch endpoints: creating, inspecting, merging, and
+// discarding branches, and staging node and edge writes on them.
+type BranchHandler struct {
+	svc BranchService
+	log *logrus.Logger
+}
+
+// NewBranchHandler creates a BranchHandler.
+func NewBranchHandler(svc BranchService, log *logrus.Logger) *BranchHandler {
+	return &BranchHandler{svc: svc, log: log}
+}
+
+// Create handles POST /api/v1/branches.
+func (h *BranchHandler) Create(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.CreateBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	b, err := h.svc.CreateBranch(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondBranchError(c, err, "creating branch")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, b)
+}
+
+// List handles GET /api/v1/branches.
+func (h *BranchHandler) List(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	branches, err := h.svc.ListBranches(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondBranchError(c, err, "listing branches")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"branches": branches})
+}
+
+// Get handles GET /api/v1/branches/:id.
+func (h *BranchHandler) Get(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	b, err := h.svc.GetBranch(c.Request.Context(), tenantID, branchID)
+	if err != nil {
+		h.respondBranchError(c, err, "getting branch")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, b)
+}
+
+// Changes handles GET /api/v1/branches/:id/changes.
+func (h *BranchHandler) Changes(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.svc.GetBranchChanges(c.Request.Context(), tenantID, branchID)
+	if err != nil {
+		h.respondBranchError(c, err, "getting branch changes")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// Merge handles POST /api/v1/branches/:id/merge.
+// A merge stopped by conflicts is returned with merged=false and the
+// conflicts; the branch is kept so it can be fixed or merged with force.
+func (h *BranchHandler) Merge(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.MergeBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	result, err := h.svc.MergeBranch(c.Request.Context(), tenantID, branchID, req)
+	if err != nil {
+		h.respondBranchError(c, err, "merging branch")
+
+		return
+	}
+
+	if result.Merged {
+		h.log.WithFields(logrus.Fields{
+			"action":        "branch.merge",
+			"tenant_id":     tenantID,
+			"branch_id":     branchID,
+			"force":         req.Force,
+			"nodes_written": result.NodesWritten,
+			"nodes_deleted": result.NodesDeleted,
+			"edges_written": result.EdgesWritten,
+			"edges_deleted": result.EdgesDeleted,
+		}).Info("audit")
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete handles DELETE /api/v1/branches/:id, discarding everything staged
+// on the branch.
+func (h *BranchHandler) Delete(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteBranch(c.Request.Context(), tenantID, branchID); err != nil {
+		h.respondBranchError(c, err, "deleting branch")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// branchParams extracts the tenant and branch IDs. Malformed branch IDs are
+// reported as not found.
+func (h *BranchHandler) branchParams(c *gin.Context) (tenantID, branchID string, ok bool) {
+	tenantID = getTenantID(c)
+	if tenantID == "" {
+		return "", "", false
+	}
+
+	branchID = c.Param("id")
+	if _, err := uuid.Parse(branchID); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "branch not found")
+
+		return "", "", false
+	}
+
+	return tenantID, branchID, true
+}
+
+func (h *BranchHandler) respondBranchError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrBranchNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "branch not found")
+	case errors.Is(err, models.ErrNodeNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+	case errors.Is(err, models.ErrEdgeNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "edge not found")
+	case errors.Is(err, models.ErrDuplicateKey):
+		respondError(c, http.StatusConflict, "conflict", "branch already has this node or edge")
+	default:
+		h.log.WithError(err).Error(msg)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+	}
+}
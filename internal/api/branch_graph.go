@@ -0,0 +1,266 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GetNode handles GET /api/v1/branches/:id/nodes/:node.
+func (h *BranchHandler) GetNode(c *gin.Context) {
+	tenantID, branchID, nodeID, ok := h.branchNodeParams(c)
+	if !ok {
+		return
+	}
+
+	node, err := h.svc.GetBranchNode(c.Request.Context(), tenantID, branchID, nodeID)
+	if err != nil {
+		h.respondBranchError(c, err, "getting branch node")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, node)
+}
+
+// CreateNode handles POST /api/v1/branches/:id/nodes.
+func (h *BranchHandler) CreateNode(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	node, err := h.svc.CreateBranchNode(c.Request.Context(), tenantID, branchID, req)
+	if err != nil {
+		h.respondBranchError(c, err, "creating branch node")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, node)
+}
+
+// UpdateNode handles PUT /api/v1/branches/:id/nodes/:node.
+func (h *BranchHandler) UpdateNode(c *gin.Context) {
+	tenantID, branchID, nodeID, ok := h.branchNodeParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	node, err := h.svc.UpdateBranchNode(c.Request.Context(), tenantID, branchID, nodeID, req)
+	if err != nil {
+		h.respondBranchError(c, err, "updating branch node")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, node)
+}
+
+// DeleteNode handles DELETE /api/v1/branches/:id/nodes/:node.
+// The node and every edge touching it are deleted on the branch only.
+func (h *BranchHandler) DeleteNode(c *gin.Context) {
+	tenantID, branchID, nodeID, ok := h.branchNodeParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteBranchNode(c.Request.Context(), tenantID, branchID, nodeID); err != nil {
+		h.respondBranchError(c, err, "deleting branch node")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetEdge handles GET /api/v1/branches/:id/edges/:source/:target/:relation.
+func (h *BranchHandler) GetEdge(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	source, target, relation, ok := edgePathParams(c)
+	if !ok {
+		return
+	}
+
+	edge, err := h.svc.GetBranchEdge(c.Request.Context(), tenantID, branchID, source, target, relation)
+	if err != nil {
+		h.respondBranchError(c, err, "getting branch edge")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, edge)
+}
+
+// CreateEdge handles POST /api/v1/branches/:id/edges.
+// Both endpoints must exist as the branch sees the graph.
+func (h *BranchHandler) CreateEdge(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateEdgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	edge, err := h.svc.CreateBranchEdge(c.Request.Context(), tenantID, branchID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrNodeNotFound) {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+			return
+		}
+
+		h.respondBranchError(c, err, "creating branch edge")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, edge)
+}
+
+// UpdateEdge handles PUT /api/v1/branches/:id/edges/:source/:target/:relation.
+func (h *BranchHandler) UpdateEdge(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	source, target, relation, ok := edgePathParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateEdgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	edge, err := h.svc.UpdateBranchEdge(c.Request.Context(), tenantID, branchID, source, target, relation, req)
+	if err != nil {
+		h.respondBranchError(c, err, "updating branch edge")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, edge)
+}
+
+// DeleteEdge handles DELETE /api/v1/branches/:id/edges/:source/:target/:relation.
+func (h *BranchHandler) DeleteEdge(c *gin.Context) {
+	tenantID, branchID, ok := h.branchParams(c)
+	if !ok {
+		return
+	}
+
+	source, target, relation, ok := edgePathParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteBranchEdge(c.Request.Context(), tenantID, branchID, source, target, relation); err != nil {
+		h.respondBranchError(c, err, "deleting branch edge")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Neighbors handles GET /api/v1/branches/:id/graph/neighbors/:node.
+func (h *BranchHandler) Neighbors(c *gin.Context) {
+	tenantID, branchID, nodeID, ok := h.branchNodeParams(c)
+	if !ok {
+		return
+	}
+
+	limit := parseInt(c.DefaultQuery("limit", "100"), 100)
+	result, err := h.svc.BranchNeighbors(c.Request.Context(), tenantID, branchID, nodeID, limit)
+	if err != nil {
+		h.respondBranchError(c, err, "getting branch neighbors")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// branchNodeParams extracts the tenant, branch, and node IDs.
+func (h *BranchHandler) branchNodeParams(c *gin.Context) (tenantID, branchID, nodeID string, ok bool) {
+	nodeID = c.Param("node")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return "", "", "", false
+	}
+
+	tenantID, branchID, ok = h.branchParams(c)
+
+	return tenantID, branchID, nodeID, ok
+}
+
+// edgePathParams extracts and validates the source, target, and relation
+// path parameters.
+func edgePathParams(c *gin.Context) (source, target, relation string, ok bool) {
+	source, target, relation = c.Param("source"), c.Param("target"), models.NormalizeText(c.Param("relation"))
+	for _, pair := range []struct{ name, val string }{{"source", source}, {"target", target}, {"relation", relation}} {
+		if err := validatePathID(pair.val); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid "+pair.name+": "+err.Error())
+
+			return "", "", "", false
+		}
+	}
+
+	return source, target, relation, true
+}
@@ -0,0 +1,104 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const testBranchID = "0b7e4a0e-3f7d-4f55-a1c4-6d2b9f5e8a01"
+
+type mockBranchService struct {
+	api.BranchService
+	nodes  map[string]*models.Node
+	merge  *models.MergeBranchResult
+	forced bool
+}
+
+func (m *mockBranchService) CreateBranchNode(
+	_ context.Context, _, branchID string, req models.CreateNodeRequest,
+) (*models.Node, error) {
+	if branchID != testBranchID {
+		return nil, models.ErrBranchNotFound
+	}
+	if _, ok := m.nodes[req.ID]; ok {
+		return nil, models.ErrDuplicateKey
+	}
+	n := &models.Node{ID: req.ID, Type: req.Type, Label: req.Label}
+	m.nodes[req.ID] = n
+	return n, nil
+}
+
+func (m *mockBranchService) MergeBranch(
+	_ context.Context, _, _ string, req models.MergeBranchRequest,
+) (*models.MergeBranchResult, error) {
+	m.forced = req.Force
+	return m.merge, nil
+}
+
+func newBranchRouter(svc *mockBranchService) *gin.Engine {
+	r := newTestRouter()
+	h := api.NewBranchHandler(svc, testLogger())
+	r.POST("/branches/:id/nodes", h.CreateNode)
+	r.POST("/branches/:id/merge", h.Merge)
+
+	return r
+}
+
+func TestBranch_CreateNode(t *testing.T) {
+	svc := &mockBranchService{nodes: map[string]*models.Node{}}
+	r := newBranchRouter(svc)
+	body := `{"id":"n1","type":"person","label":"Ada"}`
+
+	tests := []struct {
+		name       string
+		path, body string
+		wantStatus int
+	}{
+		{"staged", "/branches/" + testBranchID + "/nodes", body, http.StatusCreated},
+		{"duplicate", "/branches/" + testBranchID + "/nodes", body, http.StatusConflict},
+		{"invalid node", "/branches/" + testBranchID + "/nodes", `{"id":"n2"}`, http.StatusBadRequest},
+		{"malformed branch ID", "/branches/nope/nodes", body, http.StatusNotFound},
+		{"unknown branch", "/branches/6f1c3c4e-8a59-4e0c-9a57-0f4f2b1d7c11/nodes", body, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		w := doRequest(r, http.MethodPost, tt.path, tt.body)
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+}
+
+func TestBranch_Merge(t *testing.T) {
+	svc := &mockBranchService{merge: &models.MergeBranchResult{
+		Conflicts: []models.BranchConflict{{NodeID: "n1", Reason: models.BranchConflictChanged}},
+	}}
+	r := newBranchRouter(svc)
+	path := "/branches/" + testBranchID + "/merge"
+
+	// An empty body merges without force; conflicts come back with merged=false.
+	w := doRequest(r, http.MethodPost, path, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var result models.MergeBranchResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Merged || len(result.Conflicts) != 1 || svc.forced {
+		t.Errorf("result = %+v, forced = %v", result, svc.forced)
+	}
+
+	doRequest(r, http.MethodPost, path, `{"force":true}`)
+	if !svc.forced {
+		t.Error("force was not passed to the service")
+	}
+}
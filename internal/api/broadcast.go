@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+// broadcastTargetAll is the audit entity ID used for broadcasts to every tenant.
+const broadcastTargetAll = "all"
+
+// BroadcastHandler pushes operator messages to connected WebSocket clients.
+type BroadcastHandler struct {
+	hub     *ws.Hub
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewBroadcastHandler creates a BroadcastHandler with the given dependencies.
+func NewBroadcastHandler(hub *ws.Hub, auditor Auditor, log *logrus.Logger) *BroadcastHandler {
+	return &BroadcastHandler{hub: hub, auditor: auditor, log: log}
+}
+
+// Broadcast handles POST /api/v1/admin/broadcast.
+// Sends an operator_message event to one tenant's clients, or to every
+// connected client when tenant_id is omitted.
+func (h *BroadcastHandler) Broadcast(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.BroadcastRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	if h.hub == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "websocket hub not available")
+
+		return
+	}
+
+	if err := h.hub.BroadcastOperatorMessage(req.TenantID, req.Message, req.Level); err != nil {
+		if errors.Is(err, ws.ErrBroadcastDropped) {
+			respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "broadcast queue full, try again")
+
+			return
+		}
+
+		h.log.WithError(err).Error("broadcasting operator message")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	target := req.TenantID
+	if target == "" {
+		target = broadcastTargetAll
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":      "admin.broadcast",
+		"tenant_id":   tenantID,
+		"target":      target,
+		"level":       req.Level,
+		"message_len": len(req.Message),
+	}).Info("audit")
+
+	if h.auditor != nil {
+		detail := map[string]any{"message": req.Message, "level": req.Level}
+		if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, "admin.broadcast", "broadcast", target, "", detail); err != nil {
+			h.log.WithError(err).Warn("recording broadcast audit entry")
+		}
+	}
+
+	c.JSON(http.StatusAccepted, models.BroadcastResult{Status: "queued", Target: target})
+}
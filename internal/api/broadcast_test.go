@@ -0,0 +1,54 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+type mockAuditor struct {
+	action, entityID string
+	detail           map[string]any
+}
+
+func (m *mockAuditor) RecordAudit(_ context.Context, _, action, _, entityID, _ string, detail map[string]any) error {
+	m.action, m.entityID, m.detail = action, entityID, detail
+
+	return nil
+}
+
+func TestBroadcast(t *testing.T) {
+	auditor := &mockAuditor{}
+	r := newTestRouter()
+	h := api.NewBroadcastHandler(ws.NewHub(testLogger()), auditor, testLogger())
+	r.POST("/admin/broadcast", h.Broadcast)
+
+	w := doRequest(r, http.MethodPost, "/admin/broadcast", `{"message":"maintenance in 5 minutes","level":"warning"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusAccepted, w.Body.String())
+	}
+	if auditor.action != "admin.broadcast" || auditor.entityID != "all" || auditor.detail["level"] != "warning" {
+		t.Errorf("audit = %+v, want admin.broadcast to all at warning", auditor)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/broadcast", `{"message":"hi","tenant_id":"`+testTenantID+`"}`)
+	if w.Code != http.StatusAccepted || auditor.entityID != testTenantID {
+		t.Errorf("tenant broadcast status = %d, target = %q", w.Code, auditor.entityID)
+	}
+
+	for name, body := range map[string]string{
+		"empty message": `{"message":""}`,
+		"too long":      `{"message":"` + strings.Repeat("x", 1001) + `"}`,
+		"bad level":     `{"message":"hi","level":"loud"}`,
+		"bad tenant":    `{"message":"hi","tenant_id":"nope"}`,
+	} {
+		w = doRequest(r, http.MethodPost, "/admin/broadcast", body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", name, w.Code, http.StatusBadRequest)
+		}
+	}
+}
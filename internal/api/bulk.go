@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -51,6 +52,10 @@ func (h *BulkHandler) BulkNodes(c *gin.Context) {
 
 	nodes, err := h.repo.BulkUpsertNodes(c.Request.Context(), tenantID, reqs)
 	if err != nil {
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+
 		h.log.WithError(err).Error("bulk upserting nodes")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
 
@@ -92,6 +97,16 @@ func (h *BulkHandler) BulkEdges(c *gin.Context) {
 
 	edges, err := h.repo.BulkUpsertEdges(c.Request.Context(), tenantID, reqs)
 	if err != nil {
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+
+		if errors.Is(err, models.ErrRelationNotAllowed) {
+			respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+			return
+		}
+
 		h.log.WithError(err).Error("bulk upserting edges")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
 
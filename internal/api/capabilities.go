@@ -0,0 +1,43 @@
+package api
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CapabilitiesHandler serves the capability discovery endpoint.
+type CapabilitiesHandler struct {
+	caps models.Capabilities
+}
+
+// NewCapabilitiesHandler creates a CapabilitiesHandler reporting the
+// subsystems enabled in deps. Capabilities are fixed at startup.
+func NewCapabilitiesHandler(deps *RouterDeps) *CapabilitiesHandler {
+	return &CapabilitiesHandler{caps: models.Capabilities{
+		Version: deps.Version,
+		Features: map[string]bool{
+			models.CapabilityEmbeddings:        deps.EmbedWorker != nil,
+			models.CapabilityGraphQL:           true,
+			models.CapabilityGraphQLPlayground: deps.EnablePlayground,
+			models.CapabilityWebSocket:         deps.Hub != nil,
+			models.CapabilityWebhooks:          false,
+			models.CapabilityNamespaces:        false,
+			models.CapabilitySoftDelete:        false,
+			models.CapabilityWatchLists:        deps.Watches != nil,
+			models.CapabilityMetapaths:         deps.Metapaths != nil,
+			models.CapabilitySnapshots:         deps.Snapshots != nil,
+		},
+	}}
+}
+
+// Get handles GET /capabilities.
+func (h *CapabilitiesHandler) Get(c *gin.Context) {
+	caps := h.caps
+	caps.Features = maps.Clone(h.caps.Features)
+
+	c.JSON(http.StatusOK, caps)
+}
@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+
+	h := api.NewCapabilitiesHandler(&api.RouterDeps{
+		Version:          "test-v1",
+		Hub:              ws.NewHub(testLogger()),
+		EnablePlayground: true,
+	})
+
+	r := gin.New()
+	r.GET("/capabilities", h.Get)
+
+	w := doRequest(r, http.MethodGet, "/capabilities", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var caps models.Capabilities
+	if err := json.Unmarshal(w.Body.Bytes(), &caps); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if caps.Version != "test-v1" {
+		t.Errorf("expected version 'test-v1', got %q", caps.Version)
+	}
+
+	want := map[string]bool{
+		models.CapabilityEmbeddings:        false,
+		models.CapabilityGraphQL:           true,
+		models.CapabilityGraphQLPlayground: true,
+		models.CapabilityWebSocket:         true,
+		models.CapabilitySoftDelete:        false,
+		models.CapabilityWatchLists:        false,
+		models.CapabilityMetapaths:         false,
+		models.CapabilitySnapshots:         false,
+	}
+	for name, enabled := range want {
+		if caps.Has(name) != enabled {
+			t.Errorf("%s = %v, want %v", name, caps.Has(name), enabled)
+		}
+	}
+}
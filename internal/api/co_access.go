@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CoAccessHandler serves the co-access relation inference endpoint.
+type CoAccessHandler struct {
+	svc CoAccessService
+	log *logrus.Logger
+}
+
+// NewCoAccessHandler creates a CoAccessHandler with the given dependencies.
+func NewCoAccessHandler(svc CoAccessService, log *logrus.Logger) *CoAccessHandler {
+	return &CoAccessHandler{svc: svc, log: log}
+}
+
+// Infer handles POST /api/v1/admin/relations/infer-co-access.
+// The body is optional; omitted fields use the defaults.
+func (h *CoAccessHandler) Infer(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.CoAccessInferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	result, err := h.svc.InferRelations(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.log.WithError(err).Error("inferring co-access relations")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "admin.infer_co_access",
+		"tenant_id": tenantID,
+		"created":   result.Created,
+		"updated":   result.Updated,
+		"removed":   result.Removed,
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, result)
+}
+
+// accessSession returns the client's session query parameter, or "" when
+// the request names none. An invalid session is answered with 400.
+func accessSession(c *gin.Context) (string, bool) {
+	session, ok := c.GetQuery("session")
+	if !ok {
+		return "", true
+	}
+
+	if session == "" || len(session) > models.MaxAccessSessionIDLength {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, models.ErrInvalidAccessSession.Error())
+
+		return "", false
+	}
+
+	return session, true
+}
+
+// recordAccess records a search's results as accessed together: in the
+// client's session when it named one, otherwise in a session of their own,
+// which only pairs anything when there are at least two results. Failures
+// are logged and never fail the search.
+func (h *SearchHandler) recordAccess(c *gin.Context, tenantID, session string, ids []string) {
+	if h.access == nil {
+		return
+	}
+
+	if session == "" {
+		if len(ids) < 2 {
+			return
+		}
+
+		session = uuid.NewString()
+	}
+
+	if err := h.access.RecordAccess(c.Request.Context(), tenantID, session, ids); err != nil {
+		h.log.WithError(err).Warn("recording search access")
+	}
+}
+
+func nodeIDs(nodes []models.Node) []string {
+	ids := make([]string, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+
+	return ids
+}
+
+func scoredNodeIDs(nodes []models.ScoredNode) []string {
+	ids := make([]string, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+
+	return ids
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type recordedAccess struct {
+	session string
+	nodeIDs []string
+}
+
+type mockCoAccessService struct {
+	accesses []recordedAccess
+	req      models.CoAccessInferenceRequest
+}
+
+func (m *mockCoAccessService) RecordAccess(_ context.Context, _, sessionID string, nodeIDs []string) error {
+	m.accesses = append(m.accesses, recordedAccess{session: sessionID, nodeIDs: nodeIDs})
+	return nil
+}
+
+func (m *mockCoAccessService) InferRelations(
+	_ context.Context, _ string, req models.CoAccessInferenceRequest,
+) (*models.CoAccessInferenceResult, error) {
+	m.req = req
+	return &models.CoAccessInferenceResult{Pairs: 2, Created: 1, Updated: 1}, nil
+}
+
+func TestSearchRecordsCoAccess(t *testing.T) {
+	results := []models.Node{{ID: "a"}, {ID: "b"}}
+	repo := &mockSearchRepo{
+		fullTextFn: func(_ context.Context, _, query string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			if query == "one" {
+				return results[:1], nil
+			}
+			return results, nil
+		},
+	}
+	access := &mockCoAccessService{}
+	r := newTestRouter()
+	r.GET("/search", api.NewSearchHandler(repo, access, testLogger()).FullText)
+
+	for _, path := range []string{"/search?q=two", "/search?q=one", "/search?q=one&session=chat-1"} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	// A lone result without a session cannot pair with anything.
+	if len(access.accesses) != 2 {
+		t.Fatalf("accesses = %+v, want 2", access.accesses)
+	}
+	if got := access.accesses[0]; got.session == "" || strings.Join(got.nodeIDs, ",") != "a,b" {
+		t.Errorf("search access = %+v", got)
+	}
+	if got := access.accesses[1]; got.session != "chat-1" || strings.Join(got.nodeIDs, ",") != "a" {
+		t.Errorf("session access = %+v", got)
+	}
+
+	long := strings.Repeat("s", models.MaxAccessSessionIDLength+1)
+	for _, path := range []string{"/search?q=two&session=", "/search?q=two&session=" + long} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%.40s: status = %d, want 400", path, w.Code)
+		}
+	}
+}
+
+func TestInferCoAccess(t *testing.T) {
+	svc := &mockCoAccessService{}
+	r := newTestRouter()
+	r.POST("/admin/relations/infer-co-access", api.NewCoAccessHandler(svc, testLogger()).Infer)
+
+	w := doRequest(r, http.MethodPost, "/admin/relations/infer-co-access", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var result models.CoAccessInferenceResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Created != 1 || svc.req.MinScore != models.DefaultCoAccessMinScore {
+		t.Errorf("result = %+v, req = %+v", result, svc.req)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/relations/infer-co-access", `{"half_life_days":7,"min_score":1.5}`)
+	if w.Code != http.StatusOK || svc.req.HalfLifeDays != 7 || svc.req.MinScore != 1.5 {
+		t.Errorf("status = %d, req = %+v", w.Code, svc.req)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/relations/infer-co-access", `{"window_days":1000}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("window too long: status = %d, want 400", w.Code)
+	}
+}
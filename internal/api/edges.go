@@ -24,6 +24,7 @@ func NewEdgeHandler(repo EdgeService, log *logrus.Logger) *EdgeHandler {
 
 // List handles GET /api/edges.
 // Supports optional temporal filters: active_on (date) and current (bool).
+// Pass next_cursor as ?after= to fetch the following page without an offset.
 func (h *EdgeHandler) List(c *gin.Context) {
 	tenantID := getTenantID(c)
 	if tenantID == "" {
@@ -51,7 +52,14 @@ func (h *EdgeHandler) List(c *gin.Context) {
 		current = &v
 	}
 
-	edges, hasMore, err := h.repo.ListEdges(c.Request.Context(), tenantID, source, target, relation, limit, offset, activeOn, current)
+	after, err := models.DecodeEdgeCursor(c.Query("after"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	edges, hasMore, err := h.repo.ListEdges(c.Request.Context(), tenantID, source, target, relation, limit, offset, activeOn, current, after)
 	if err != nil {
 		h.log.WithError(err).Error("listing edges")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
@@ -59,7 +67,12 @@ func (h *EdgeHandler) List(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"edges": edges, "has_more": hasMore})
+	resp := gin.H{"edges": edges, "has_more": hasMore}
+	if hasMore && len(edges) > 0 {
+		resp["next_cursor"] = models.EdgeCursorAfter(&edges[len(edges)-1]).Encode()
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 // Create handles POST /api/edges.
@@ -84,6 +97,16 @@ func (h *EdgeHandler) Create(c *gin.Context) {
 
 	edge, err := h.repo.CreateEdge(c.Request.Context(), tenantID, req)
 	if err != nil {
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+
+		if errors.Is(err, models.ErrRelationNotAllowed) {
+			respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+			return
+		}
+
 		if errors.Is(err, models.ErrNodeNotFound) {
 			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
 
@@ -107,11 +130,51 @@ func (h *EdgeHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, edge)
 }
 
+// Exists handles POST /api/edges/exists.
+// Reports, in request order, whether each (source, target, relation) exists,
+// so callers can skip creates without one GET per edge.
+func (h *EdgeHandler) Exists(c *gin.Context) {
+	var req models.EdgeExistsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	edges, err := h.repo.EdgesExist(c.Request.Context(), tenantID, req.Edges)
+	if err != nil {
+		h.log.WithError(err).Error("checking edges exist")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	existing := 0
+	for _, e := range edges {
+		if e.Exists {
+			existing++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"edges": edges, "existing": existing})
+}
+
 // Update handles PUT /api/edges/:source/:target/:relation.
 func (h *EdgeHandler) Update(c *gin.Context) {
 	source := c.Param("source")
 	target := c.Param("target")
-	relation := c.Param("relation")
+	relation := models.NormalizeText(c.Param("relation"))
 
 	for _, pair := range []struct{ name, val string }{{"source", source}, {"target", target}, {"relation", relation}} {
 		if err := validatePathID(pair.val); err != nil {
@@ -161,7 +224,7 @@ func (h *EdgeHandler) Update(c *gin.Context) {
 func (h *EdgeHandler) PatchProperties(c *gin.Context) {
 	source := c.Param("source")
 	target := c.Param("target")
-	relation := c.Param("relation")
+	relation := models.NormalizeText(c.Param("relation"))
 
 	for _, pair := range []struct{ name, val string }{{"source", source}, {"target", target}, {"relation", relation}} {
 		if err := validatePathID(pair.val); err != nil {
@@ -215,7 +278,7 @@ func (h *EdgeHandler) PatchProperties(c *gin.Context) {
 func (h *EdgeHandler) Delete(c *gin.Context) {
 	source := c.Param("source")
 	target := c.Param("target")
-	relation := c.Param("relation")
+	relation := models.NormalizeText(c.Param("relation"))
 
 	for _, pair := range []struct{ name, val string }{{"source", source}, {"target", target}, {"relation", relation}} {
 		if err := validatePathID(pair.val); err != nil {
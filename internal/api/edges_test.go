@@ -88,3 +88,48 @@ func TestEdgeDelete_OK(t *testing.T) {
 		t.Errorf("expected deleted=true, got %v", body["deleted"])
 	}
 }
+
+func TestEdgeExists(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockEdgeRepo{
+		existsFn: func(_ context.Context, _ string, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+			result := make([]models.EdgeExistence, len(keys))
+			for i, k := range keys {
+				result[i] = models.EdgeExistence{EdgeKey: k, Exists: k.Target == "b"}
+			}
+			return result, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewEdgeHandler(repo, testLogger())
+	r.POST("/edges/exists", h.Exists)
+
+	w := doRequest(r, http.MethodPost, "/edges/exists",
+		`{"edges":[{"source":"a","target":"b","relation":"knows"},{"source":"a","target":"c","relation":"knows"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Edges    []models.EdgeExistence `json:"edges"`
+		Existing int                    `json:"existing"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if resp.Existing != 1 || len(resp.Edges) != 2 || !resp.Edges[0].Exists || resp.Edges[1].Exists || resp.Edges[1].Target != "c" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	for _, body := range []string{
+		`{"edges":[]}`,
+		`{"edges":[{"source":"a","relation":"knows"}]}`,
+	} {
+		if w := doRequest(r, http.MethodPost, "/edges/exists", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ErrorCatalogHandler serves the error catalog endpoint.
+type ErrorCatalogHandler struct{}
+
+// NewErrorCatalogHandler creates an ErrorCatalogHandler.
+func NewErrorCatalogHandler() *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{}
+}
+
+// Get handles GET /errors, listing every error code with its message in the
+// catalog language best matching Accept-Language.
+func (h *ErrorCatalogHandler) Get(c *gin.Context) {
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, models.NewErrorCatalog(models.ErrorLanguage(c.GetHeader("Accept-Language"))))
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/httputil"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestErrorCatalog(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.GET("/errors", api.NewErrorCatalogHandler().Get)
+
+	req := httptest.NewRequest(http.MethodGet, "/errors", http.NoBody)
+	req.Header.Set("Accept-Language", "fr-CA, en;q=0.5")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var catalog models.ErrorCatalog
+	if err := json.Unmarshal(w.Body.Bytes(), &catalog); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if catalog.Language != "fr" {
+		t.Errorf("language = %q, want fr", catalog.Language)
+	}
+
+	var found bool
+	for _, e := range catalog.Errors {
+		if e.Code != api.ErrCodeNotFound {
+			continue
+		}
+		found = true
+		if e.Status != http.StatusNotFound || e.Message != "La ressource demandée est introuvable." || e.DocsURL == "" {
+			t.Errorf("not_found entry = %+v", e)
+		}
+	}
+	if !found {
+		t.Error("catalog has no not_found entry")
+	}
+}
+
+func TestRespondError_Localized(t *testing.T) {
+	t.Parallel()
+
+	r := gin.New()
+	r.GET("/missing", func(c *gin.Context) {
+		httputil.RespondError(c, http.StatusNotFound, api.ErrCodeNotFound, "node not found")
+	})
+
+	tests := []struct {
+		name         string
+		lang         string
+		wantMessage  string
+		wantDetail   string
+		wantLanguage string
+	}{
+		{"default", "", "node not found", "", ""},
+		{"unsupported", "ja", "node not found", "", ""},
+		{"spanish", "es-MX,es;q=0.9", "No se encontró el recurso solicitado.", "node not found", "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/missing", http.NoBody)
+			if tt.lang != "" {
+				req.Header.Set("Accept-Language", tt.lang)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			var body map[string]string
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+
+			if body["message"] != tt.wantMessage || body["detail"] != tt.wantDetail {
+				t.Errorf("message %q detail %q, want %q and %q", body["message"], body["detail"], tt.wantMessage, tt.wantDetail)
+			}
+			if body["docs_url"] != models.ErrorDocsURL(api.ErrCodeNotFound) {
+				t.Errorf("docs_url = %q", body["docs_url"])
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("Content-Language = %q, want %q", got, tt.wantLanguage)
+			}
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/persistorai/persistor/internal/httputil"
 	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // Error code constants for standardized API responses.
@@ -15,6 +19,7 @@
 	ErrCodeUnauthorized    = "unauthorized"
 	ErrCodeRateLimited     = "rate_limited"
 	ErrCodeValidationError = "validation_error"
+	ErrCodeQuotaExceeded   = "quota_exceeded"
 )
 
 // respondError writes a standardized JSON error response, pulling the request
@@ -23,3 +28,15 @@ func respondError(c *gin.Context, status int, code, message string) {
 	metrics.ErrorsTotal.WithLabelValues(code).Inc()
 	httputil.RespondError(c, status, code, message)
 }
+
+// respondQuotaExceeded answers 403 quota_exceeded when err is a quota error
+// and reports whether it did.
+func respondQuotaExceeded(c *gin.Context, err error) bool {
+	if !errors.Is(err, models.ErrQuotaExceeded) {
+		return false
+	}
+
+	respondError(c, http.StatusForbidden, ErrCodeQuotaExceeded, err.Error())
+
+	return true
+}
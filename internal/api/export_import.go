@@ -1,6 +1,8 @@
 package api
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,6 +12,7 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/neo4j"
 )
 
 // ExportImportHandler serves backup and restore endpoints.
@@ -23,14 +26,40 @@ func NewExportImportHandler(repo ExportImportService, log *logrus.Logger) *Expor
 	return &ExportImportHandler{repo: repo, log: log}
 }
 
+// exportFormatNDJSON selects the streaming export on GET /api/v1/export.
+const exportFormatNDJSON = "ndjson"
+
 // Export handles GET /api/v1/export.
-// Returns the full tenant export as a JSON file attachment.
+// Returns the full tenant export as a JSON file attachment, or with
+// ?format=ndjson streams it as one models.ExportRecord per line. With
+// ?format=jsonld it returns the graph as JSON-LD using the tenant's context,
+// and ?format=cypher or ?format=neo4j-csv stream it for loading into Neo4j.
 func (h *ExportImportHandler) Export(c *gin.Context) {
 	tenantID := getTenantID(c)
 	if tenantID == "" {
 		return
 	}
 
+	switch c.Query("format") {
+	case "", "json":
+	case exportFormatNDJSON:
+		h.streamExport(c, tenantID)
+
+		return
+	case exportFormatJSONLD:
+		h.exportJSONLD(c, tenantID)
+
+		return
+	case neo4j.FormatCypher, neo4j.FormatCSV:
+		h.streamNeo4j(c, tenantID, c.Query("format"))
+
+		return
+	default:
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "format must be json, ndjson, jsonld, cypher, or neo4j-csv")
+
+		return
+	}
+
 	data, err := h.repo.Export(c.Request.Context(), tenantID)
 	if err != nil {
 		h.log.WithError(err).Error("exporting knowledge graph")
@@ -54,6 +83,63 @@ func (h *ExportImportHandler) Export(c *gin.Context) {
 	c.JSON(http.StatusOK, data)
 }
 
+// streamExport writes the export as NDJSON while it is read from the store.
+// Once the first record is sent the status can no longer change, so a later
+// failure ends the stream without its end record for the client to detect.
+func (h *ExportImportHandler) streamExport(c *gin.Context, tenantID string) {
+	hostname, _ := os.Hostname()
+	ts := time.Now().UTC().Format("20060102T150405Z")
+	filename := fmt.Sprintf("persistor-export-%s-%s.ndjson", hostname, ts)
+
+	enc := json.NewEncoder(c.Writer)
+	started := false
+
+	var stats models.ExportStats
+
+	err := h.repo.StreamExport(c.Request.Context(), tenantID, func(rec *models.ExportRecord) error {
+		if !started {
+			c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Status(http.StatusOK)
+			started = true
+		}
+
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("writing export record: %w", err)
+		}
+
+		switch rec.Type {
+		case models.ExportRecordNode:
+			stats.NodeCount++
+		case models.ExportRecordEdge:
+			stats.EdgeCount++
+		case models.ExportRecordManifest, models.ExportRecordEnd:
+			// Flush at the boundaries; records in between are flushed by
+			// the response buffer as it fills.
+			c.Writer.Flush()
+		}
+
+		return nil
+	})
+	if err != nil {
+		h.log.WithError(err).WithField("tenant_id", tenantID).Error("streaming knowledge graph export")
+
+		if !started {
+			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "export failed")
+		}
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":     "export",
+		"format":     exportFormatNDJSON,
+		"tenant_id":  tenantID,
+		"node_count": stats.NodeCount,
+		"edge_count": stats.EdgeCount,
+	}).Info("audit")
+}
+
 // Import handles POST /api/v1/import.
 // Accepts an ExportFormat JSON body and writes it into the tenant graph.
 func (h *ExportImportHandler) Import(c *gin.Context) {
@@ -120,3 +206,100 @@ func (h *ExportImportHandler) Validate(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"errors": errs, "valid": len(errs) == 0})
 }
+
+// Conflicts handles POST /api/v1/import/conflicts.
+// Returns the stored versions of the payload's records that already exist.
+func (h *ExportImportHandler) Conflicts(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var data models.ExportFormat
+	if err := c.ShouldBindJSON(&data); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	conflicts, err := h.repo.ImportConflicts(c.Request.Context(), tenantID, &data)
+	if err != nil {
+		h.log.WithError(err).Error("finding import conflicts")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, conflicts)
+}
+
+// Manifest handles GET /api/v1/export/manifest.
+// Returns the export header and record counts for a chunked export.
+func (h *ExportImportHandler) Manifest(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	manifest, err := h.repo.ExportManifest(c.Request.Context(), tenantID)
+	if err != nil {
+		h.log.WithError(err).Error("building export manifest")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "export failed")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+// Nodes handles GET /api/v1/export/nodes.
+// Returns one page of a chunked node export; pass next_cursor as ?cursor= to continue.
+func (h *ExportImportHandler) Nodes(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.Query("limit"), models.DefaultExportPageSize)
+
+	page, err := h.repo.ExportNodes(c.Request.Context(), tenantID, c.Query("cursor"), limit)
+	if err != nil {
+		h.respondPageError(c, err, "exporting node page")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// Edges handles GET /api/v1/export/edges.
+// Returns one page of a chunked edge export; pass next_cursor as ?cursor= to continue.
+func (h *ExportImportHandler) Edges(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.Query("limit"), models.DefaultExportPageSize)
+
+	page, err := h.repo.ExportEdges(c.Request.Context(), tenantID, c.Query("cursor"), limit)
+	if err != nil {
+		h.respondPageError(c, err, "exporting edge page")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// respondPageError maps chunked export errors to HTTP responses.
+func (h *ExportImportHandler) respondPageError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, models.ErrInvalidExportCursor) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "export failed")
+}
@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockExportImportService struct {
+	api.ExportImportService
+	records []models.ExportRecord
+	failAt  int // index of the record whose emit fails; -1 for none
+}
+
+func (m *mockExportImportService) StreamExport(_ context.Context, _ string, emit func(*models.ExportRecord) error) error {
+	for i := range m.records {
+		if i == m.failAt {
+			return errors.New("database went away")
+		}
+		if err := emit(&m.records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExport_NDJSON(t *testing.T) {
+	svc := &mockExportImportService{
+		failAt: -1,
+		records: []models.ExportRecord{
+			{Type: models.ExportRecordManifest, Manifest: &models.ExportManifest{TenantID: "t1"}},
+			{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "a"}},
+			{Type: models.ExportRecordEdge, Edge: &models.ExportEdge{Source: "a", Target: "a", Relation: "self"}},
+			{Type: models.ExportRecordEnd, Stats: &models.ExportStats{NodeCount: 1, EdgeCount: 1}},
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewExportImportHandler(svc, testLogger())
+	r.GET("/export", h.Export)
+
+	w := doRequest(r, http.MethodGet, "/export?format=ndjson", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("content type = %q, want application/x-ndjson", ct)
+	}
+
+	var types []string
+	scanner := bufio.NewScanner(strings.NewReader(w.Body.String()))
+	for scanner.Scan() {
+		var rec models.ExportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		types = append(types, rec.Type)
+	}
+	if got := strings.Join(types, ","); got != "manifest,node,edge,end" {
+		t.Errorf("record types = %s", got)
+	}
+
+	w = doRequest(r, http.MethodGet, "/export?format=xml", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestExport_NDJSONFailure(t *testing.T) {
+	r := newTestRouter()
+	svc := &mockExportImportService{failAt: 0, records: []models.ExportRecord{{Type: models.ExportRecordManifest}}}
+	r.GET("/export", api.NewExportImportHandler(svc, testLogger()).Export)
+
+	// Nothing sent yet: the failure is reported as an error response.
+	w := doRequest(r, http.MethodGet, "/export?format=ndjson", "")
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+
+	// Mid-stream: the response stops without an end record.
+	svc.records = []models.ExportRecord{
+		{Type: models.ExportRecordManifest, Manifest: &models.ExportManifest{}},
+		{Type: models.ExportRecordNode, Node: &models.ExportNode{ID: "a"}},
+		{Type: models.ExportRecordEnd, Stats: &models.ExportStats{}},
+	}
+	svc.failAt = 2
+
+	w = doRequest(r, http.MethodGet, "/export?format=ndjson", "")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), `"type":"end"`) {
+		t.Errorf("status = %d, body = %s; want a truncated stream", w.Code, w.Body.String())
+	}
+}
+
+type mockConflictService struct {
+	api.ExportImportService
+	got *models.ExportFormat
+}
+
+func (m *mockConflictService) ImportConflicts(_ context.Context, _ string, data *models.ExportFormat) (*models.ImportConflicts, error) {
+	m.got = data
+	return &models.ImportConflicts{Nodes: []models.ExportNode{{ID: "a", Label: "stored"}}, Edges: []models.ExportEdge{}}, nil
+}
+
+func TestImportConflicts(t *testing.T) {
+	svc := &mockConflictService{}
+	r := newTestRouter()
+	r.POST("/import/conflicts", api.NewExportImportHandler(svc, testLogger()).Conflicts)
+
+	w := doRequest(r, http.MethodPost, "/import/conflicts", `{"nodes":[{"id":"a"},{"id":"b"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if len(svc.got.Nodes) != 2 {
+		t.Errorf("service got %d nodes, want 2", len(svc.got.Nodes))
+	}
+
+	var got models.ImportConflicts
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || len(got.Nodes) != 1 || got.Nodes[0].Label != "stored" {
+		t.Errorf("body = %s (%v)", w.Body.String(), err)
+	}
+
+	w = doRequest(r, http.MethodPost, "/import/conflicts", `{`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad body: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
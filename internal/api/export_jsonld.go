@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// exportFormatJSONLD selects the JSON-LD export on GET /api/v1/export.
+const exportFormatJSONLD = "jsonld"
+
+// exportJSONLD writes the graph as a JSON-LD document attachment.
+func (h *ExportImportHandler) exportJSONLD(c *gin.Context, tenantID string) {
+	doc, err := h.repo.ExportJSONLD(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondJSONLDError(c, err, "exporting knowledge graph as JSON-LD")
+
+		return
+	}
+
+	hostname, _ := os.Hostname()
+	ts := time.Now().UTC().Format("20060102T150405Z")
+	filename := fmt.Sprintf("persistor-export-%s-%s.jsonld", hostname, ts)
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	h.log.WithFields(logrus.Fields{
+		"action":     "export",
+		"format":     exportFormatJSONLD,
+		"tenant_id":  tenantID,
+		"node_count": len(doc.Graph),
+	}).Info("audit")
+
+	c.Header("Content-Type", "application/ld+json")
+	c.Status(http.StatusOK)
+
+	if err := json.NewEncoder(c.Writer).Encode(doc); err != nil {
+		h.log.WithError(err).WithField("tenant_id", tenantID).Error("writing JSON-LD export")
+	}
+}
+
+// GetJSONLDContext handles GET /api/v1/export/jsonld-context.
+// Returns the context JSON-LD exports use, with defaults filled in.
+func (h *ExportImportHandler) GetJSONLDContext(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	jsonldCtx, err := h.repo.GetJSONLDContext(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondJSONLDError(c, err, "getting JSON-LD context")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, jsonldCtx)
+}
+
+// SetJSONLDContext handles PUT /api/v1/export/jsonld-context.
+// Replaces the tenant's context; omitted base and vocab use the defaults.
+func (h *ExportImportHandler) SetJSONLDContext(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.JSONLDContext
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	h.putJSONLDContext(c, tenantID, &req)
+}
+
+// ResetJSONLDContext handles DELETE /api/v1/export/jsonld-context.
+// Removes the tenant's context so exports use the default vocabulary.
+func (h *ExportImportHandler) ResetJSONLDContext(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	h.putJSONLDContext(c, tenantID, nil)
+}
+
+func (h *ExportImportHandler) putJSONLDContext(c *gin.Context, tenantID string, req *models.JSONLDContext) {
+	jsonldCtx, err := h.repo.SetJSONLDContext(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondJSONLDError(c, err, "setting JSON-LD context")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "export.set_jsonld_context",
+		"tenant_id": tenantID,
+		"reset":     req == nil,
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, jsonldCtx)
+}
+
+func (h *ExportImportHandler) respondJSONLDError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, models.ErrTenantNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
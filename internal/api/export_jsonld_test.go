@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockJSONLDService struct {
+	api.ExportImportService
+	stored *models.JSONLDContext
+	setErr error
+}
+
+func (m *mockJSONLDService) ExportJSONLD(_ context.Context, _ string) (*models.JSONLDDocument, error) {
+	return &models.JSONLDDocument{
+		Context: map[string]any{"@vocab": models.DefaultJSONLDVocab},
+		Graph:   []map[string]any{{"@id": models.DefaultJSONLDBase + "a", "@type": "person"}},
+	}, nil
+}
+
+func (m *mockJSONLDService) SetJSONLDContext(_ context.Context, _ string, c *models.JSONLDContext) (*models.JSONLDContext, error) {
+	if m.setErr != nil {
+		return nil, m.setErr
+	}
+
+	m.stored = c
+	if c == nil {
+		c = &models.JSONLDContext{}
+	}
+
+	withDefaults := c.WithDefaults()
+
+	return &withDefaults, nil
+}
+
+func TestExport_JSONLD(t *testing.T) {
+	r := newTestRouter()
+	r.GET("/export", api.NewExportImportHandler(&mockJSONLDService{}, testLogger()).Export)
+
+	w := doRequest(r, http.MethodGet, "/export?format=jsonld", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/ld+json" {
+		t.Errorf("content type = %q, want application/ld+json", ct)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body.String(), err)
+	}
+	if _, ok := doc["@context"]; !ok {
+		t.Errorf("body has no @context: %s", w.Body.String())
+	}
+	if graph, ok := doc["@graph"].([]any); !ok || len(graph) != 1 {
+		t.Errorf("@graph = %v, want one node", doc["@graph"])
+	}
+}
+
+func TestSetJSONLDContext(t *testing.T) {
+	svc := &mockJSONLDService{}
+	h := api.NewExportImportHandler(svc, testLogger())
+	r := newTestRouter()
+	r.PUT("/export/jsonld-context", h.SetJSONLDContext)
+	r.DELETE("/export/jsonld-context", h.ResetJSONLDContext)
+
+	w := doRequest(r, http.MethodPut, "/export/jsonld-context",
+		`{"prefixes":{"schema":"https://schema.org/"},"types":{"person":"schema:Person"}}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if svc.stored == nil || svc.stored.Types["person"] != "schema:Person" {
+		t.Errorf("stored = %+v", svc.stored)
+	}
+
+	var got models.JSONLDContext
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || got.Vocab != models.DefaultJSONLDVocab {
+		t.Errorf("body = %s (%v), want the defaults filled in", w.Body.String(), err)
+	}
+
+	w = doRequest(r, http.MethodPut, "/export/jsonld-context", `{"types":{"person":"Person"}}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("relative IRI: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/export/jsonld-context", "")
+	if w.Code != http.StatusOK || svc.stored != nil {
+		t.Errorf("reset: status = %d, stored = %+v", w.Code, svc.stored)
+	}
+
+	svc.setErr = models.ErrTenantNotFound
+
+	w = doRequest(r, http.MethodDelete, "/export/jsonld-context", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing tenant: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -3,6 +3,7 @@
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -36,7 +37,7 @@ func (h *GraphHandler) Neighbors(c *gin.Context) {
 	}
 
 	limit := parseInt(c.DefaultQuery("limit", "100"), 100)
-	result, err := h.repo.Neighbors(c.Request.Context(), tenantID, nodeID, limit)
+	result, err := h.repo.Neighbors(c.Request.Context(), tenantID, nodeID, limit, models.ReadOpts{OmitProperties: omitProperties(c)})
 	if err != nil {
 		if errors.Is(err, models.ErrNodeNotFound) {
 			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
@@ -73,7 +74,7 @@ func (h *GraphHandler) Traverse(c *gin.Context) {
 		return
 	}
 
-	result, err := h.repo.Traverse(c.Request.Context(), tenantID, nodeID, maxHops)
+	result, err := h.repo.Traverse(c.Request.Context(), tenantID, nodeID, maxHops, models.ReadOpts{OmitProperties: omitProperties(c)})
 	if err != nil {
 		if errors.Is(err, models.ErrNodeNotFound) {
 			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
@@ -165,3 +166,135 @@ func (h *GraphHandler) Path(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"path": nodes})
 }
+
+// Summary handles GET /api/graph/summary/:id.
+func (h *GraphHandler) Summary(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.DefaultQuery("limit", "10"), 10)
+	result, err := h.repo.Summary(c.Request.Context(), tenantID, nodeID, limit)
+	if err != nil {
+		if errors.Is(err, models.ErrNodeNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+
+			return
+		}
+
+		h.log.WithError(err).Error("summarizing node neighborhood")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Subgraph handles POST /api/graph/subgraph.
+func (h *GraphHandler) Subgraph(c *gin.Context) {
+	var req models.SubgraphRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.repo.Subgraph(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.log.WithError(err).Error("extracting subgraph")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Communities handles POST /api/graph/communities.
+func (h *GraphHandler) Communities(c *gin.Context) {
+	var req models.CommunityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.repo.Communities(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.log.WithError(err).Error("detecting communities")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AsOf handles GET /api/graph/asof.
+func (h *GraphHandler) AsOf(c *gin.Context) {
+	ts, err := time.Parse(time.RFC3339, c.Query("timestamp"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "timestamp must be an RFC 3339 date-time")
+
+		return
+	}
+
+	q := models.AsOfQuery{
+		Timestamp: ts,
+		Type:      c.Query("type"),
+		Limit:     parseInt(c.DefaultQuery("limit", "100"), 100),
+		Offset:    parseOffset(c.DefaultQuery("offset", "0")),
+	}
+
+	if err := q.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.repo.AsOf(c.Request.Context(), tenantID, q)
+	if err != nil {
+		h.log.WithError(err).Error("reconstructing graph as of timestamp")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GraphDiffHandler serves the operator comparison of two tenants' graphs.
+type GraphDiffHandler struct {
+	svc GraphDiffService
+	log *logrus.Logger
+}
+
+// NewGraphDiffHandler creates a GraphDiffHandler. svc may be nil when graph
+// diffs are not configured; the endpoint then answers 503.
+func NewGraphDiffHandler(svc GraphDiffService, log *logrus.Logger) *GraphDiffHandler {
+	return &GraphDiffHandler{svc: svc, log: log}
+}
+
+// Diff handles GET /api/v1/admin/diff?left=<tenant>&right=<tenant>.
+// Reports what the right tenant's graph adds, removes, and changes relative
+// to the left one.
+func (h *GraphDiffHandler) Diff(c *gin.Context) {
+	if getTenantID(c) == "" {
+		return
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "graph diff not available")
+		return
+	}
+
+	left, right := c.Query("left"), c.Query("right")
+	for _, id := range []string{left, right} {
+		if _, err := uuid.Parse(id); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "left and right must be tenant IDs")
+
+			return
+		}
+	}
+
+	diff, err := h.svc.DiffTenants(c.Request.Context(), left, right)
+	if err != nil {
+		if errors.Is(err, models.ErrTenantNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+			return
+		}
+
+		h.log.WithError(err).Error("diffing tenant graphs")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
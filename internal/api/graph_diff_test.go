@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	diffLeftTenant  = "11111111-1111-1111-1111-111111111111"
+	diffRightTenant = "22222222-2222-2222-2222-222222222222"
+)
+
+type mockGraphDiffService struct{}
+
+func (mockGraphDiffService) DiffTenants(_ context.Context, left, right string) (*models.GraphDiff, error) {
+	if right != diffRightTenant {
+		return nil, models.ErrTenantNotFound
+	}
+
+	return &models.GraphDiff{
+		Left:       left,
+		Right:      right,
+		Stats:      models.GraphDiffStats{NodesAdded: 1},
+		NodesAdded: []models.ExportNode{{ID: "bob", Type: "person", Label: "Bob"}},
+	}, nil
+}
+
+func TestGraphDiff(t *testing.T) {
+	r := newTestRouter()
+	r.GET("/admin/diff", api.NewGraphDiffHandler(mockGraphDiffService{}, testLogger()).Diff)
+
+	w := doRequest(r, http.MethodGet, "/admin/diff?left="+diffLeftTenant+"&right="+diffRightTenant, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var diff models.GraphDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if diff.Left != diffLeftTenant || diff.Stats.NodesAdded != 1 || diff.NodesAdded[0].ID != "bob" {
+		t.Errorf("diff = %+v", diff)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/admin/diff?left="+diffLeftTenant+"&right=bob", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid tenant ID: status = %d, want 400", w.Code)
+	}
+
+	missing := "/admin/diff?left=" + diffLeftTenant + "&right=" + diffLeftTenant
+	if w := doRequest(r, http.MethodGet, missing, ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown tenant: status = %d, want 404", w.Code)
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/admin/diff", api.NewGraphDiffHandler(nil, testLogger()).Diff)
+	if w := doRequest(disabled, http.MethodGet, "/admin/diff", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/persistorai/persistor/internal/api"
@@ -14,13 +15,17 @@ type mockGraphRepo struct {
 	traverseFn     func(ctx context.Context, tenantID, nodeID string, maxHops int) (*models.TraverseResult, error)
 	graphContextFn func(ctx context.Context, tenantID, nodeID string) (*models.ContextResult, error)
 	shortestPathFn func(ctx context.Context, tenantID, fromID, toID string) ([]models.Node, error)
+	summaryFn      func(ctx context.Context, tenantID, nodeID string, limit int) (*models.GraphSummary, error)
+	subgraphFn     func(ctx context.Context, tenantID string, req models.SubgraphRequest) (*models.SubgraphResult, error)
+	communitiesFn  func(ctx context.Context, tenantID string, req models.CommunityRequest) (*models.CommunityResult, error)
+	asOfFn         func(ctx context.Context, tenantID string, q models.AsOfQuery) (*models.GraphSnapshot, error)
 }
 
-func (m *mockGraphRepo) Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error) {
+func (m *mockGraphRepo) Neighbors(ctx context.Context, tenantID, nodeID string, limit int, _ models.ReadOpts) (*models.NeighborResult, error) {
 	return m.neighborsFn(ctx, tenantID, nodeID, limit)
 }
 
-func (m *mockGraphRepo) Traverse(ctx context.Context, tenantID, nodeID string, maxHops int) (*models.TraverseResult, error) {
+func (m *mockGraphRepo) Traverse(ctx context.Context, tenantID, nodeID string, maxHops int, _ models.ReadOpts) (*models.TraverseResult, error) {
 	return m.traverseFn(ctx, tenantID, nodeID, maxHops)
 }
 
@@ -32,6 +37,22 @@ func (m *mockGraphRepo) ShortestPath(ctx context.Context, tenantID, fromID, toID
 	return m.shortestPathFn(ctx, tenantID, fromID, toID)
 }
 
+func (m *mockGraphRepo) Summary(ctx context.Context, tenantID, nodeID string, limit int) (*models.GraphSummary, error) {
+	return m.summaryFn(ctx, tenantID, nodeID, limit)
+}
+
+func (m *mockGraphRepo) Subgraph(ctx context.Context, tenantID string, req models.SubgraphRequest) (*models.SubgraphResult, error) {
+	return m.subgraphFn(ctx, tenantID, req)
+}
+
+func (m *mockGraphRepo) Communities(ctx context.Context, tenantID string, req models.CommunityRequest) (*models.CommunityResult, error) {
+	return m.communitiesFn(ctx, tenantID, req)
+}
+
+func (m *mockGraphRepo) AsOf(ctx context.Context, tenantID string, q models.AsOfQuery) (*models.GraphSnapshot, error) {
+	return m.asOfFn(ctx, tenantID, q)
+}
+
 func TestGraphPathMissingNodeReturns404(t *testing.T) {
 	r := newTestRouter()
 	h := api.NewGraphHandler(&mockGraphRepo{
@@ -49,3 +70,125 @@ func TestGraphPathMissingNodeReturns404(t *testing.T) {
 		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
 	}
 }
+
+func TestGraphSummary(t *testing.T) {
+	var gotLimit int
+	r := newTestRouter()
+	h := api.NewGraphHandler(&mockGraphRepo{
+		summaryFn: func(_ context.Context, _, nodeID string, limit int) (*models.GraphSummary, error) {
+			gotLimit = limit
+			if nodeID == "missing" {
+				return nil, models.ErrNodeNotFound
+			}
+
+			return &models.GraphSummary{
+				Node:      models.NodeSummary{ID: nodeID, Type: "person", Label: "Alice"},
+				Degree:    1,
+				Relations: []models.RelationCount{{Relation: "knows", Direction: models.DirectionOut, Count: 1}},
+			}, nil
+		},
+	}, testLogger())
+	r.GET("/graph/summary/:id", h.Summary)
+
+	w := doRequest(r, http.MethodGet, "/graph/summary/alice?limit=5", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if gotLimit != 5 {
+		t.Errorf("limit = %d, want 5", gotLimit)
+	}
+	if !strings.Contains(w.Body.String(), `"relations":[{"relation":"knows","direction":"out","count":1}]`) {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/graph/summary/missing", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("missing status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestGraphSubgraph(t *testing.T) {
+	var got models.SubgraphRequest
+	r := newTestRouter()
+	h := api.NewGraphHandler(&mockGraphRepo{
+		subgraphFn: func(_ context.Context, _ string, req models.SubgraphRequest) (*models.SubgraphResult, error) {
+			got = req
+
+			return &models.SubgraphResult{
+				Nodes: []models.Node{{ID: "a"}, {ID: "b"}},
+				Edges: []models.Edge{{Source: "a", Target: "b", Relation: "knows"}},
+			}, nil
+		},
+	}, testLogger())
+	r.POST("/graph/subgraph", h.Subgraph)
+
+	w := doRequest(r, http.MethodPost, "/graph/subgraph", `{"node_ids":["a","b"],"expand":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if len(got.NodeIDs) != 2 || !got.Expand {
+		t.Errorf("request = %+v, want two ids with expand", got)
+	}
+
+	w = doRequest(r, http.MethodPost, "/graph/subgraph", `{"node_ids":[]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("empty ids status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGraphCommunitiesAppliesDefaults(t *testing.T) {
+	var got models.CommunityRequest
+	r := newTestRouter()
+	h := api.NewGraphHandler(&mockGraphRepo{
+		communitiesFn: func(_ context.Context, _ string, req models.CommunityRequest) (*models.CommunityResult, error) {
+			got = req
+
+			return &models.CommunityResult{Communities: []models.Community{{ID: 1, Label: "Alpha", Size: 2, NodeIDs: []string{"a", "b"}}}}, nil
+		},
+	}, testLogger())
+	r.POST("/graph/communities", h.Communities)
+
+	w := doRequest(r, http.MethodPost, "/graph/communities", `{}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got.MaxIterations != models.DefaultCommunityIterations || got.MinSize != models.DefaultCommunityMinSize {
+		t.Errorf("request = %+v, want defaults applied", got)
+	}
+
+	w = doRequest(r, http.MethodPost, "/graph/communities", `{"max_iterations":1000}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("oversized iterations status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGraphAsOf(t *testing.T) {
+	var got models.AsOfQuery
+	r := newTestRouter()
+	h := api.NewGraphHandler(&mockGraphRepo{
+		asOfFn: func(_ context.Context, _ string, q models.AsOfQuery) (*models.GraphSnapshot, error) {
+			got = q
+
+			return &models.GraphSnapshot{AsOf: q.Timestamp, Nodes: []models.Node{{ID: "a"}}}, nil
+		},
+	}, testLogger())
+	r.GET("/graph/asof", h.AsOf)
+
+	w := doRequest(r, http.MethodGet, "/graph/asof?timestamp=2026-01-02T03:04:05Z&type=person&limit=5", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got.Timestamp.Year() != 2026 || got.Type != "person" || got.Limit != 5 {
+		t.Errorf("query = %+v, want 2026 timestamp, type person, limit 5", got)
+	}
+	if !strings.Contains(w.Body.String(), `"as_of":"2026-01-02T03:04:05Z"`) {
+		t.Errorf("body = %s, want as_of echoed", w.Body.String())
+	}
+
+	for _, path := range []string{"/graph/asof", "/graph/asof?timestamp=yesterday"} {
+		w = doRequest(r, http.MethodGet, path, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -53,16 +53,18 @@ type readinessResponse struct {
 
 // healthResponse is the JSON payload returned by the health/liveness endpoint.
 type healthResponse struct {
-	Status              string  `json:"status"`
-	Version             string  `json:"version"`
-	Database            string  `json:"database"`
-	OllamaModel         string  `json:"ollama_model,omitempty"`
-	Embeddings          string  `json:"embeddings"`
-	EmbeddingDimensions int     `json:"embedding_dimensions"`
-	UptimeSeconds       float64 `json:"uptime_seconds"`
+	Status              string            `json:"status"`
+	Version             string            `json:"version"`
+	Database            string            `json:"database"`
+	OllamaModel         string            `json:"ollama_model,omitempty"`
+	Embeddings          string            `json:"embeddings"`
+	EmbeddingDimensions int               `json:"embedding_dimensions"`
+	UptimeSeconds       float64           `json:"uptime_seconds"`
+	Pool                *dbpool.PoolStats `json:"pool,omitempty"`
 }
 
 // Liveness handles GET /api/health — returns status with db, embeddings, and uptime info.
+// With ?details=true it also reports connection pool statistics.
 func (h *HealthHandler) Liveness(c *gin.Context) {
 	resp := healthResponse{
 		Status:              "ok",
@@ -82,6 +84,11 @@ func (h *HealthHandler) Liveness(c *gin.Context) {
 		if err := h.pool.HealthCheck(ctx); err != nil {
 			resp.Database = "disconnected"
 		}
+
+		if c.Query("details") == "true" {
+			stats := h.pool.Stats()
+			resp.Pool = &stats
+		}
 	} else {
 		resp.Database = "not_configured"
 	}
@@ -41,3 +41,27 @@ func TestLiveness_ReturnsOK(t *testing.T) {
 		t.Errorf("expected ollama_model 'qwen3.5:9b', got %v", body["ollama_model"])
 	}
 }
+
+func TestLiveness_DetailsWithoutPool(t *testing.T) {
+	t.Parallel()
+
+	h := api.NewHealthHandler(nil, nil, testLogger(), "test-v1", "", "", "", 0)
+
+	r := gin.New()
+	r.GET("/health", h.Liveness)
+
+	w := doRequest(r, http.MethodGet, "/health?details=true", "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if _, ok := body["pool"]; ok {
+		t.Errorf("expected no pool details without a pool, got %v", body["pool"])
+	}
+}
@@ -2,10 +2,12 @@
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // HistoryHandler serves property history endpoints.
@@ -34,10 +36,17 @@ func (h *HistoryHandler) GetHistory(c *gin.Context) {
 	}
 
 	propertyKey := c.Query("property")
+	field := c.Query("field")
+	if field != "" && !models.ValidHistoryField(field) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "field must be one of property, label, type, salience")
+
+		return
+	}
+
 	limit := parseInt(c.DefaultQuery("limit", "50"), 50)
 	offset := parseOffset(c.DefaultQuery("offset", "0"))
 
-	changes, hasMore, err := h.repo.GetPropertyHistory(c.Request.Context(), tenantID, nodeID, propertyKey, limit, offset)
+	changes, hasMore, err := h.repo.GetPropertyHistory(c.Request.Context(), tenantID, nodeID, propertyKey, field, limit, offset)
 	if err != nil {
 		h.log.WithError(err).Error("getting property history")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
@@ -54,3 +63,113 @@ func (h *HistoryHandler) GetHistory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"changes": changes, "has_more": hasMore})
 }
+
+// GetEdgeHistory handles GET /api/v1/edges/:source/:target/:relation/history.
+func (h *HistoryHandler) GetEdgeHistory(c *gin.Context) {
+	source := c.Param("source")
+	target := c.Param("target")
+	relation := models.NormalizeText(c.Param("relation"))
+
+	for _, pair := range []struct{ name, val string }{{"source", source}, {"target", target}, {"relation", relation}} {
+		if err := validatePathID(pair.val); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid "+pair.name+": "+err.Error())
+
+			return
+		}
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	propertyKey := c.Query("property")
+	limit := parseInt(c.DefaultQuery("limit", "50"), 50)
+	offset := parseOffset(c.DefaultQuery("offset", "0"))
+
+	changes, hasMore, err := h.repo.GetEdgePropertyHistory(c.Request.Context(), tenantID, source, target, relation, propertyKey, limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("getting edge property history")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "history.get_edge",
+		"tenant_id": tenantID,
+		"source":    source,
+		"target":    target,
+		"relation":  relation,
+		"count":     len(changes),
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, gin.H{"changes": changes, "has_more": hasMore})
+}
+
+// List handles GET /api/v1/history.
+// Returns node changes across the tenant, newest first. since and until are
+// RFC3339 timestamps bounding changed_at; actor matches changed_by exactly and
+// reason matches any reason containing the given text.
+func (h *HistoryHandler) List(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	opts := models.HistoryListOpts{
+		PropertyKey: c.Query("property"),
+		Field:       c.Query("field"),
+		Actor:       c.Query("actor"),
+		Reason:      c.Query("reason"),
+		Limit:       parseInt(c.DefaultQuery("limit", "50"), 50),
+		Offset:      parseOffset(c.DefaultQuery("offset", "0")),
+	}
+
+	if opts.Field != "" && !models.ValidHistoryField(opts.Field) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "field must be one of property, label, type, salience")
+
+		return
+	}
+
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"since", &opts.Since}, {"until", &opts.Until}} {
+		raw := c.Query(bound.name)
+		if raw == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid "+bound.name+" format, use RFC3339")
+
+			return
+		}
+
+		*bound.dst = &t
+	}
+
+	if opts.Since != nil && opts.Until != nil && !opts.Since.Before(*opts.Until) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "since must be before until")
+
+		return
+	}
+
+	changes, hasMore, err := h.repo.ListHistory(c.Request.Context(), tenantID, opts)
+	if err != nil {
+		h.log.WithError(err).Error("listing history")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "history.list",
+		"tenant_id": tenantID,
+		"count":     len(changes),
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, gin.H{"changes": changes, "has_more": hasMore})
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// HistoryRetentionHandler serves property history retention endpoints.
+type HistoryRetentionHandler struct {
+	svc     HistoryRetentionService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewHistoryRetentionHandler creates a HistoryRetentionHandler with the given dependencies.
+func NewHistoryRetentionHandler(svc HistoryRetentionService, auditor Auditor, log *logrus.Logger) *HistoryRetentionHandler {
+	return &HistoryRetentionHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// Get handles GET /api/v1/admin/history/retention.
+func (h *HistoryRetentionHandler) Get(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	retention, err := h.svc.GetHistoryRetention(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondRetentionError(c, err, "getting history retention")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, retention)
+}
+
+// Set handles PUT /api/v1/admin/history/retention.
+// Omitted or null periods disable the corresponding step.
+func (h *HistoryRetentionHandler) Set(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.HistoryRetention
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	retention, err := h.svc.SetHistoryRetention(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondRetentionError(c, err, "setting history retention")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":             "history.set_retention",
+		"tenant_id":          tenantID,
+		"retention_days":     retention.RetentionDays,
+		"compact_after_days": retention.CompactAfterDays,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "history.set_retention", map[string]any{
+		"retention_days":     retention.RetentionDays,
+		"compact_after_days": retention.CompactAfterDays,
+	})
+
+	c.JSON(http.StatusOK, retention)
+}
+
+// Prune handles POST /api/v1/admin/history/prune.
+// Applies the tenant's retention policy immediately instead of waiting for
+// the background job.
+func (h *HistoryRetentionHandler) Prune(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.svc.PruneTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondRetentionError(c, err, "pruning property history")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "history.prune",
+		"tenant_id": tenantID,
+		"deleted":   result.Deleted,
+		"compacted": result.Compacted,
+	}).Info("audit")
+	h.recordAudit(c, tenantID, "history.prune", map[string]any{
+		"deleted":   result.Deleted,
+		"compacted": result.Compacted,
+	})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// recordAudit stores a persistent audit entry for a retention change or run.
+// Failures are logged but do not fail the request.
+func (h *HistoryRetentionHandler) recordAudit(c *gin.Context, tenantID, action string, detail map[string]any) {
+	if h.auditor == nil {
+		return
+	}
+
+	if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, action, "tenant", tenantID, "", detail); err != nil {
+		h.log.WithError(err).Warn("recording history retention audit entry")
+	}
+}
+
+func (h *HistoryRetentionHandler) respondRetentionError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, models.ErrTenantNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockHistoryRetentionService struct {
+	retention models.HistoryRetention
+}
+
+func (m *mockHistoryRetentionService) GetHistoryRetention(_ context.Context, _ string) (*models.HistoryRetention, error) {
+	r := m.retention
+	return &r, nil
+}
+
+func (m *mockHistoryRetentionService) SetHistoryRetention(_ context.Context, _ string, r models.HistoryRetention) (*models.HistoryRetention, error) {
+	m.retention = r
+	return &r, nil
+}
+
+func (m *mockHistoryRetentionService) PruneTenant(_ context.Context, _ string) (*models.HistoryPruneResult, error) {
+	return &models.HistoryPruneResult{Deleted: 3, Compacted: 2}, nil
+}
+
+func TestHistoryRetention(t *testing.T) {
+	auditor := &mockAuditor{}
+	svc := &mockHistoryRetentionService{}
+	r := newTestRouter()
+	h := api.NewHistoryRetentionHandler(svc, auditor, testLogger())
+	r.GET("/admin/history/retention", h.Get)
+	r.PUT("/admin/history/retention", h.Set)
+	r.POST("/admin/history/prune", h.Prune)
+
+	w := doRequest(r, http.MethodPut, "/admin/history/retention", `{"retention_days":365,"compact_after_days":30}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if auditor.action != "history.set_retention" {
+		t.Errorf("audit action = %q, want history.set_retention", auditor.action)
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/history/retention", "")
+	var got models.HistoryRetention
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.RetentionDays == nil || *got.RetentionDays != 365 || got.CompactAfterDays == nil || *got.CompactAfterDays != 30 {
+		t.Errorf("retention = %+v, want 365/30", got)
+	}
+
+	for _, body := range []string{
+		`{"retention_days":0}`,
+		`{"retention_days":30,"compact_after_days":30}`,
+		`{"compact_after_days":100000}`,
+	} {
+		w = doRequest(r, http.MethodPut, "/admin/history/retention", body)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", body, w.Code, http.StatusBadRequest)
+		}
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/history/prune", "")
+	if w.Code != http.StatusOK || auditor.action != "history.prune" {
+		t.Fatalf("prune: status = %d, audit action = %q", w.Code, auditor.action)
+	}
+
+	var result models.HistoryPruneResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Deleted != 3 || result.Compacted != 2 {
+		t.Errorf("result = %+v, want 3 deleted, 2 compacted", result)
+	}
+}
@@ -0,0 +1,147 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockHistoryService struct {
+	historyFn     func(ctx context.Context, tenantID, nodeID, propertyKey, field string, limit, offset int) ([]models.PropertyChange, bool, error)
+	edgeHistoryFn func(ctx context.Context, tenantID, source, target, relation, propertyKey string, limit, offset int) ([]models.EdgePropertyChange, bool, error)
+	listFn        func(ctx context.Context, tenantID string, opts models.HistoryListOpts) ([]models.PropertyChange, bool, error)
+}
+
+func (m *mockHistoryService) GetPropertyHistory(ctx context.Context, tenantID, nodeID, propertyKey, field string, limit, offset int) ([]models.PropertyChange, bool, error) {
+	return m.historyFn(ctx, tenantID, nodeID, propertyKey, field, limit, offset)
+}
+
+func (m *mockHistoryService) GetEdgePropertyHistory(ctx context.Context, tenantID, source, target, relation, propertyKey string, limit, offset int) ([]models.EdgePropertyChange, bool, error) {
+	return m.edgeHistoryFn(ctx, tenantID, source, target, relation, propertyKey, limit, offset)
+}
+
+func (m *mockHistoryService) ListHistory(ctx context.Context, tenantID string, opts models.HistoryListOpts) ([]models.PropertyChange, bool, error) {
+	return m.listFn(ctx, tenantID, opts)
+}
+
+func TestGetHistory_Field(t *testing.T) {
+	svc := &mockHistoryService{
+		historyFn: func(_ context.Context, _, nodeID, _, field string, _, _ int) ([]models.PropertyChange, bool, error) {
+			if field != models.HistoryFieldLabel {
+				t.Fatalf("field = %q, want label", field)
+			}
+			old, _ := json.Marshal("Bob")
+			return []models.PropertyChange{{ID: 1, NodeID: nodeID, Field: field, OldValue: old}}, false, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewHistoryHandler(svc, testLogger())
+	r.GET("/nodes/:id/history", h.GetHistory)
+
+	w := doRequest(r, http.MethodGet, "/nodes/n1/history?field=label", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var resp struct {
+		Changes []map[string]any `json:"changes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Changes) != 1 || resp.Changes[0]["field"] != "label" {
+		t.Errorf("changes = %+v, want one label change", resp.Changes)
+	}
+	if _, ok := resp.Changes[0]["property_key"]; ok {
+		t.Error("property_key should be omitted for label changes")
+	}
+
+	w = doRequest(r, http.MethodGet, "/nodes/n1/history?field=colour", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGetEdgeHistory(t *testing.T) {
+	svc := &mockHistoryService{
+		edgeHistoryFn: func(_ context.Context, _, source, target, relation, propertyKey string, limit, _ int) ([]models.EdgePropertyChange, bool, error) {
+			if source != "a" || target != "b" || relation != "knows" || propertyKey != "since" || limit != 10 {
+				t.Fatalf("unexpected args: %s %s %s %s %d", source, target, relation, propertyKey, limit)
+			}
+			return []models.EdgePropertyChange{{ID: 1, Source: source, Target: target, Relation: relation, PropertyKey: "since"}}, true, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewHistoryHandler(svc, testLogger())
+	r.GET("/edges/:source/:target/:relation/history", h.GetEdgeHistory)
+
+	w := doRequest(r, http.MethodGet, "/edges/a/b/knows/history?property=since&limit=10", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var resp struct {
+		Changes []models.EdgePropertyChange `json:"changes"`
+		HasMore bool                        `json:"has_more"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Changes) != 1 || !resp.HasMore || resp.Changes[0].Relation != "knows" {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestListHistory(t *testing.T) {
+	var got models.HistoryListOpts
+	svc := &mockHistoryService{
+		listFn: func(_ context.Context, _ string, opts models.HistoryListOpts) ([]models.PropertyChange, bool, error) {
+			got = opts
+			return []models.PropertyChange{{ID: 7, NodeID: "n1", Field: models.HistoryFieldProperty, PropertyKey: "status"}}, true, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewHistoryHandler(svc, testLogger())
+	r.GET("/history", h.List)
+
+	w := doRequest(r, http.MethodGet, "/history?since=2026-03-01T00:00:00Z&until=2026-03-02T00:00:00Z&property=status&actor=ingest&reason=merged&limit=20", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got.Since == nil || got.Until == nil || got.Until.Sub(*got.Since) != 24*time.Hour {
+		t.Errorf("time range = %v..%v, want one day", got.Since, got.Until)
+	}
+	if got.PropertyKey != "status" || got.Actor != "ingest" || got.Reason != "merged" || got.Limit != 20 {
+		t.Errorf("opts = %+v", got)
+	}
+
+	var resp struct {
+		Changes []models.PropertyChange `json:"changes"`
+		HasMore bool                    `json:"has_more"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Changes) != 1 || !resp.HasMore || resp.Changes[0].NodeID != "n1" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	for _, query := range []string{
+		"since=yesterday",
+		"since=2026-03-02T00:00:00Z&until=2026-03-01T00:00:00Z",
+		"field=colour",
+	} {
+		w = doRequest(r, http.MethodGet, "/history?"+query, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/middleware"
+)
+
+// auditImpersonation returns middleware that records every request made with
+// an impersonation key in the impersonated tenant's audit log, with the
+// operator tenant as the actor. Other requests pass through untouched.
+func auditImpersonation(auditor Auditor, log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		operatorID := c.GetString(middleware.ImpersonatorContextKey)
+		if operatorID == "" {
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		tenantID := c.GetString("tenant_id")
+		detail := map[string]any{
+			"method":     c.Request.Method,
+			"path":       c.Request.URL.Path,
+			"status":     c.Writer.Status(),
+			"request_id": c.GetString("request_id"),
+		}
+
+		log.WithFields(logrus.Fields{
+			"action":          "impersonation.request",
+			"tenant_id":       tenantID,
+			"impersonated_by": operatorID,
+			"method":          c.Request.Method,
+			"path":            c.Request.URL.Path,
+			"status":          c.Writer.Status(),
+		}).Info("audit")
+
+		if auditor == nil {
+			return
+		}
+
+		// The request's own deadline may already have passed.
+		ctx := context.WithoutCancel(c.Request.Context())
+		if err := auditor.RecordAudit(ctx, tenantID, "impersonation.request", "request", c.FullPath(), operatorID, detail); err != nil {
+			log.WithError(err).Warn("recording impersonation audit entry")
+		}
+	}
+}
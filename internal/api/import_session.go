@@ -0,0 +1,187 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxReportedChunkErrors caps the validation errors echoed for a rejected chunk.
+const maxReportedChunkErrors = 20
+
+// ImportSessionHandler serves chunked, resumable import endpoints.
+type ImportSessionHandler struct {
+	svc ImportSessionService
+	log *logrus.Logger
+}
+
+// NewImportSessionHandler creates an ImportSessionHandler.
+func NewImportSessionHandler(svc ImportSessionService, log *logrus.Logger) *ImportSessionHandler {
+	return &ImportSessionHandler{svc: svc, log: log}
+}
+
+// Create handles POST /api/v1/import/sessions.
+func (h *ImportSessionHandler) Create(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.CreateImportSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	sess, err := h.svc.CreateImportSession(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondSessionError(c, err, "creating import session")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, sess)
+}
+
+// Get handles GET /api/v1/import/sessions/:id.
+// Lists the received chunks so an interrupted upload can be resumed.
+func (h *ImportSessionHandler) Get(c *gin.Context) {
+	tenantID, sessionID, ok := h.sessionParams(c)
+	if !ok {
+		return
+	}
+
+	sess, err := h.svc.GetImportSession(c.Request.Context(), tenantID, sessionID)
+	if err != nil {
+		h.respondSessionError(c, err, "getting import session")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, sess)
+}
+
+// PutChunk handles PUT /api/v1/import/sessions/:id/chunks/:seq.
+// Re-uploading a seq replaces the staged chunk; invalid chunks are rejected
+// with their validation errors and not staged.
+func (h *ImportSessionHandler) PutChunk(c *gin.Context) {
+	tenantID, sessionID, ok := h.sessionParams(c)
+	if !ok {
+		return
+	}
+
+	seq, err := strconv.Atoi(c.Param("seq"))
+	if err != nil || seq < 0 {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "seq must be a non-negative integer")
+
+		return
+	}
+
+	var chunk models.ImportChunk
+	if err := c.ShouldBindJSON(&chunk); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	errs, err := h.svc.PutImportChunk(c.Request.Context(), tenantID, sessionID, seq, &chunk)
+	if err != nil {
+		h.respondSessionError(c, err, "storing import chunk")
+
+		return
+	}
+
+	if len(errs) > 0 {
+		if len(errs) > maxReportedChunkErrors {
+			errs = append(errs[:maxReportedChunkErrors], fmt.Sprintf("and %d more", len(errs)-maxReportedChunkErrors))
+		}
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, strings.Join(errs, "; "))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ImportChunkInfo{Seq: seq, Nodes: len(chunk.Nodes), Edges: len(chunk.Edges)})
+}
+
+// Commit handles POST /api/v1/import/sessions/:id/commit.
+// Validation errors are returned in the result and leave the session open.
+func (h *ImportSessionHandler) Commit(c *gin.Context) {
+	tenantID, sessionID, ok := h.sessionParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.CommitImportSession(c.Request.Context(), tenantID, sessionID)
+	if err != nil {
+		h.respondSessionError(c, err, "committing import session")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":        "import",
+		"tenant_id":     tenantID,
+		"session_id":    sessionID,
+		"nodes_created": result.NodesCreated,
+		"edges_created": result.EdgesCreated,
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete handles DELETE /api/v1/import/sessions/:id.
+func (h *ImportSessionHandler) Delete(c *gin.Context) {
+	tenantID, sessionID, ok := h.sessionParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteImportSession(c.Request.Context(), tenantID, sessionID); err != nil {
+		h.respondSessionError(c, err, "deleting import session")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// sessionParams extracts the tenant and session IDs. Malformed session IDs
+// are reported as not found.
+func (h *ImportSessionHandler) sessionParams(c *gin.Context) (tenantID, sessionID string, ok bool) {
+	tenantID = getTenantID(c)
+	if tenantID == "" {
+		return "", "", false
+	}
+
+	sessionID = c.Param("id")
+	if _, err := uuid.Parse(sessionID); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "import session not found")
+
+		return "", "", false
+	}
+
+	return tenantID, sessionID, true
+}
+
+func (h *ImportSessionHandler) respondSessionError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrImportSchemaTooNew):
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+	case errors.Is(err, models.ErrImportSessionNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "import session not found")
+	case errors.Is(err, models.ErrImportSessionClosed):
+		respondError(c, http.StatusConflict, "conflict", "import session is already committed")
+	default:
+		h.log.WithError(err).Error(msg)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+	}
+}
@@ -0,0 +1,102 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const testSessionID = "6f1c3c4e-8a59-4e0c-9a57-0f4f2b1d7c11"
+
+type mockImportSessionService struct {
+	api.ImportSessionService
+	staged    map[int]*models.ImportChunk
+	committed bool
+}
+
+func (m *mockImportSessionService) PutImportChunk(
+	_ context.Context, _, _ string, seq int, chunk *models.ImportChunk,
+) ([]string, error) {
+	if errs := chunk.Validate(); len(errs) > 0 {
+		return errs, nil
+	}
+	if m.committed {
+		return nil, models.ErrImportSessionClosed
+	}
+	m.staged[seq] = chunk
+	return nil, nil
+}
+
+func (m *mockImportSessionService) CommitImportSession(_ context.Context, _, sessionID string) (*models.ImportResult, error) {
+	if sessionID != testSessionID {
+		return nil, models.ErrImportSessionNotFound
+	}
+	m.committed = true
+	return &models.ImportResult{NodesCreated: len(m.staged)}, nil
+}
+
+func newImportSessionRouter(svc *mockImportSessionService) *gin.Engine {
+	r := newTestRouter()
+	h := api.NewImportSessionHandler(svc, testLogger())
+	r.PUT("/import/sessions/:id/chunks/:seq", h.PutChunk)
+	r.POST("/import/sessions/:id/commit", h.Commit)
+
+	return r
+}
+
+func TestImportSession_PutChunk(t *testing.T) {
+	svc := &mockImportSessionService{staged: map[int]*models.ImportChunk{}}
+	r := newImportSessionRouter(svc)
+	path := "/import/sessions/" + testSessionID + "/chunks/"
+
+	w := doRequest(r, http.MethodPut, path+"0", `{"nodes":[{"id":"a"}]}`)
+	if w.Code != http.StatusOK || svc.staged[0] == nil {
+		t.Fatalf("valid chunk: status = %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodPut, path+"1", `{"nodes":[{"id":""}]}`)
+	if w.Code != http.StatusBadRequest || svc.staged[1] != nil {
+		t.Errorf("invalid chunk: status = %d, want %d and nothing staged", w.Code, http.StatusBadRequest)
+	}
+
+	w = doRequest(r, http.MethodPut, path+"-1", `{"nodes":[{"id":"a"}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("negative seq: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	w = doRequest(r, http.MethodPut, "/import/sessions/not-a-uuid/chunks/0", `{"nodes":[{"id":"a"}]}`)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("malformed session id: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestImportSession_Commit(t *testing.T) {
+	svc := &mockImportSessionService{staged: map[int]*models.ImportChunk{0: {}}}
+	r := newImportSessionRouter(svc)
+
+	w := doRequest(r, http.MethodPost, "/import/sessions/"+testSessionID+"/commit", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var result models.ImportResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || result.NodesCreated != 1 {
+		t.Errorf("result = %+v, %v", result, err)
+	}
+
+	w = doRequest(r, http.MethodPut, "/import/sessions/"+testSessionID+"/chunks/1", `{"nodes":[{"id":"b"}]}`)
+	if w.Code != http.StatusConflict {
+		t.Errorf("chunk after commit: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	w = doRequest(r, http.MethodPost, "/import/sessions/6f1c3c4e-0000-4e0c-9a57-0f4f2b1d7c11/commit", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown session: status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// IndexAdvisorHandler serves the operator endpoints of the index advisor,
+// which reads statistics shared by every tenant.
+type IndexAdvisorHandler struct {
+	svc     IndexAdvisorService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewIndexAdvisorHandler creates an IndexAdvisorHandler. svc may be nil when
+// the advisor is not configured; the endpoints then answer 503.
+func NewIndexAdvisorHandler(svc IndexAdvisorService, auditor Auditor, log *logrus.Logger) *IndexAdvisorHandler {
+	return &IndexAdvisorHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// Report handles GET /api/v1/admin/index-advisor?min_calls=<n>&min_mean_ms=<ms>.
+func (h *IndexAdvisorHandler) Report(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	var req models.IndexAdvisorRequest
+
+	var err error
+	if raw := c.Query("min_calls"); raw != "" {
+		if req.MinCalls, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "min_calls must be an integer")
+
+			return
+		}
+	}
+
+	if raw := c.Query("min_mean_ms"); raw != "" {
+		if req.MinMeanMs, err = strconv.ParseFloat(raw, 64); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "min_mean_ms must be a number")
+
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	report, err := h.svc.AdviseIndexes(c.Request.Context(), req)
+	if err != nil {
+		h.log.WithError(err).Error("advising indexes")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Create handles POST /api/v1/admin/index-advisor.
+// Starts building the recommended indexes whose extensions are installed and
+// answers 202 with the report they came from.
+func (h *IndexAdvisorHandler) Create(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	var req models.IndexAdvisorRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	report, err := h.svc.CreateAdvisedIndexes(c.Request.Context(), req)
+	if errors.Is(err, models.ErrIndexBuilding) {
+		respondError(c, http.StatusConflict, "conflict", err.Error())
+
+		return
+	}
+
+	if err != nil {
+		h.log.WithError(err).Error("creating advised indexes")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	var created []string
+	for _, rec := range report.Buildable() {
+		created = append(created, rec.Name)
+	}
+
+	tenantID := getTenantID(c)
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "admin.index_advisor_create",
+		"tenant_id": tenantID,
+		"indexes":   created,
+	}).Info("audit")
+
+	if h.auditor != nil && len(created) > 0 {
+		if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, "admin.index_advisor_create", "index", "", "",
+			map[string]any{"indexes": created}); err != nil {
+			h.log.WithError(err).Warn("recording index advisor audit entry")
+		}
+	}
+
+	c.JSON(http.StatusAccepted, report)
+}
+
+// available reports whether the request has a tenant and the service is
+// configured, answering 503 when it is not.
+func (h *IndexAdvisorHandler) available(c *gin.Context) bool {
+	if getTenantID(c) == "" {
+		return false
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "index advisor not available")
+
+		return false
+	}
+
+	return true
+}
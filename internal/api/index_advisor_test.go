@@ -0,0 +1,82 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockIndexAdvisorService struct {
+	building bool
+	got      models.IndexAdvisorRequest
+}
+
+func (m *mockIndexAdvisorService) AdviseIndexes(_ context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	m.got = req
+	rec := &models.IndexRecommendation{Name: "idx_nodes_search_props", Method: "gin", Columns: []string{"search_props"}}
+	return &models.IndexAdvisorReport{
+		StatsAvailable: true,
+		Advice: []models.IndexAdvice{{
+			Filters: []string{models.IndexFilterProps}, Calls: 300, MeanTimeMs: 20, Recommended: rec, Definition: rec.Definition(),
+		}},
+	}, nil
+}
+
+func (m *mockIndexAdvisorService) CreateAdvisedIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	if m.building {
+		return nil, models.ErrIndexBuilding
+	}
+	report, _ := m.AdviseIndexes(ctx, req)
+	report.Building = true
+	return report, nil
+}
+
+func TestIndexAdvisor(t *testing.T) {
+	svc := &mockIndexAdvisorService{}
+	h := api.NewIndexAdvisorHandler(svc, nil, testLogger())
+	r := newTestRouter()
+	r.GET("/admin/index-advisor", h.Report)
+	r.POST("/admin/index-advisor", h.Create)
+
+	w := doRequest(r, http.MethodGet, "/admin/index-advisor?min_calls=50&min_mean_ms=2.5", "")
+	var report models.IndexAdvisorReport
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &report) != nil {
+		t.Fatalf("report status = %d: %s", w.Code, w.Body.String())
+	}
+	if svc.got.MinCalls != 50 || svc.got.MinMeanMs != 2.5 {
+		t.Errorf("request = %+v", svc.got)
+	}
+	if len(report.Advice) != 1 || report.Advice[0].Definition != "CREATE INDEX CONCURRENTLY idx_nodes_search_props ON kg_nodes USING gin (search_props)" {
+		t.Errorf("report = %+v", report)
+	}
+
+	for _, query := range []string{"min_calls=many", "min_mean_ms=slow", "min_calls=-1"} {
+		if w := doRequest(r, http.MethodGet, "/admin/index-advisor?"+query, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		}
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/index-advisor", "")
+	if w.Code != http.StatusAccepted || json.Unmarshal(w.Body.Bytes(), &report) != nil || !report.Building {
+		t.Fatalf("create status = %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRequest(r, http.MethodPost, "/admin/index-advisor", `{"min_mean_ms":-2}`); w.Code != http.StatusBadRequest {
+		t.Errorf("negative threshold: status = %d, want 400", w.Code)
+	}
+
+	svc.building = true
+	if w := doRequest(r, http.MethodPost, "/admin/index-advisor", ""); w.Code != http.StatusConflict {
+		t.Errorf("during a build: status = %d, want 409", w.Code)
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/admin/index-advisor", api.NewIndexAdvisorHandler(nil, nil, testLogger()).Report)
+	if w := doRequest(disabled, http.MethodGet, "/admin/index-advisor", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,181 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/service"
+	"github.com/persistorai/persistor/internal/store"
+	"github.com/persistorai/persistor/internal/testutil"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+const integrationDimensions = 1024
+
+// newIntegrationRouter wires the real router to Postgres stores and a fake
+// Ollama, the way the server does.
+func newIntegrationRouter(t *testing.T, env *testutil.Env) http.Handler {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cryptoSvc := testutil.NewCryptoService(t)
+	base := store.Base{Pool: env.Pool, Log: env.Log, Crypto: cryptoSvc}
+	ollama := testutil.FakeOllama(t, integrationDimensions)
+
+	embedSvc := service.NewEmbeddingService(ollama.URL, "test-embed", integrationDimensions, false)
+	embedWorker := service.NewEmbedWorker(embedSvc, store.NewEmbeddingStore(base), env.Log, 0, 1)
+
+	go embedWorker.Run(ctx)
+
+	t.Cleanup(func() {
+		cancel()
+		embedWorker.Wait(5 * time.Second)
+	})
+
+	hub := ws.NewHub(env.Log)
+
+	return api.NewRouter(ctx, &api.RouterDeps{
+		Log:                 env.Log,
+		Pool:                env.Pool,
+		Hub:                 hub,
+		Nodes:               service.NewNodeService(store.NewNodeStore(base), embedWorker, nil, env.Log),
+		Edges:               service.NewEdgeService(store.NewEdgeStore(base), nil, env.Log),
+		TenantLookup:        store.NewTenantStore(env.Pool, cryptoSvc),
+		EmbedWorker:         embedWorker,
+		EmbeddingModel:      "test-embed",
+		EmbeddingDimensions: integrationDimensions,
+	})
+}
+
+func doAuthRequest(h http.Handler, apiKey, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	return w
+}
+
+// scanAsTenant runs a single-row query in a transaction scoped to tenantID,
+// as the stores do, so row-level security applies.
+func scanAsTenant(t *testing.T, env *testutil.Env, tenantID, sql string, args []any, dest ...any) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	tx, err := env.Pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only.
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		t.Fatalf("setting tenant: %v", err)
+	}
+
+	if err := tx.QueryRow(ctx, sql, args...).Scan(dest...); err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+func TestIntegration_NodeRequestPath(t *testing.T) {
+	env := testutil.NewEnv(t)
+	tenantA, keyA := env.SeedTenant(t)
+	tenantB, keyB := env.SeedTenant(t)
+	router := newIntegrationRouter(t, env)
+
+	w := doAuthRequest(router, keyA, http.MethodPost, "/api/v1/nodes",
+		`{"type":"person","label":"Ada Lovelace","properties":{"email":"ada@example.com"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decoding created node: %v", err)
+	}
+
+	t.Run("properties round-trip", func(t *testing.T) {
+		w := doAuthRequest(router, keyA, http.MethodGet, "/api/v1/nodes/"+created.ID, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("get: status = %d, body = %s", w.Code, w.Body.String())
+		}
+
+		if !strings.Contains(w.Body.String(), "ada@example.com") {
+			t.Errorf("get: decrypted properties missing from %s", w.Body.String())
+		}
+	})
+
+	t.Run("properties encrypted at rest", func(t *testing.T) {
+		var raw string
+		scanAsTenant(t, env, tenantA, "SELECT properties::text FROM kg_nodes WHERE id = $1", []any{created.ID}, &raw)
+
+		if strings.Contains(raw, "ada@example.com") || !strings.Contains(raw, "_enc") {
+			t.Errorf("stored properties = %s, want an encrypted envelope", raw)
+		}
+	})
+
+	t.Run("other tenant cannot read", func(t *testing.T) {
+		w := doAuthRequest(router, keyB, http.MethodGet, "/api/v1/nodes/"+created.ID, "")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("get as other tenant: status = %d, want 404", w.Code)
+		}
+	})
+
+	t.Run("row-level security", func(t *testing.T) {
+		if isSuperuser(t, env) {
+			t.Skip("superusers bypass row-level security")
+		}
+
+		var count int
+		scanAsTenant(t, env, tenantB, "SELECT count(*) FROM kg_nodes WHERE id = $1", []any{created.ID}, &count)
+
+		if count != 0 {
+			t.Errorf("tenant B sees %d of tenant A's nodes, want 0", count)
+		}
+	})
+
+	t.Run("embedding generated", func(t *testing.T) {
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			var embedded bool
+			scanAsTenant(t, env, tenantA, "SELECT embedding IS NOT NULL FROM kg_nodes WHERE id = $1", []any{created.ID}, &embedded)
+
+			if embedded {
+				return
+			}
+
+			if time.Now().After(deadline) {
+				t.Fatal("node was not embedded")
+			}
+
+			time.Sleep(100 * time.Millisecond)
+		}
+	})
+}
+
+func isSuperuser(t *testing.T, env *testutil.Env) bool {
+	t.Helper()
+
+	var super bool
+	err := env.Pool.QueryRow(context.Background(),
+		"SELECT rolsuper FROM pg_roles WHERE rolname = current_user").Scan(&super)
+	if err != nil {
+		t.Fatalf("checking role: %v", err)
+	}
+
+	return super
+}
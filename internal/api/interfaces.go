@@ -15,5 +15,25 @@
 	Auditor        = domain.Auditor
 	AdminService         = domain.AdminService
 	HistoryService       = domain.HistoryService
+	HistoryRetentionService = domain.HistoryRetentionService
+	ArchiveService       = domain.ArchiveService
+	ReembedService       = domain.ReembedService
 	ExportImportService  = domain.ExportImportService
+	ImportSessionService = domain.ImportSessionService
+	BranchService        = domain.BranchService
+	APIKeyService        = domain.APIKeyService
+	TagService           = domain.TagService
+	CoAccessService      = domain.CoAccessService
+	AccessAnalyticsService = domain.AccessAnalyticsService
+	TenantService        = domain.TenantService
+	UsageService         = domain.UsageService
+	PartitionService     = domain.PartitionService
+	VectorIndexService   = domain.VectorIndexService
+	IndexAdvisorService  = domain.IndexAdvisorService
+	GraphDiffService     = domain.GraphDiffService
+	WatchService         = domain.WatchService
+	QuarantineService    = domain.QuarantineService
+	RelationCatalogService = domain.RelationCatalogService
+	MetapathService      = domain.MetapathService
+	SnapshotService      = domain.SnapshotService
 )
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// MetapathHandler serves metapaths: named multi-hop relation path templates
+// that agents run from a start node as reusable structured queries.
+type MetapathHandler struct {
+	svc MetapathService
+	log *logrus.Logger
+}
+
+// NewMetapathHandler creates a MetapathHandler. svc may be nil when
+// metapaths are not configured; the endpoints then answer 503.
+func NewMetapathHandler(svc MetapathService, log *logrus.Logger) *MetapathHandler {
+	return &MetapathHandler{svc: svc, log: log}
+}
+
+// List handles GET /api/v1/metapaths.
+func (h *MetapathHandler) List(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	metapaths, err := h.svc.ListMetapaths(c.Request.Context(), tenantID)
+	if err != nil {
+		h.log.WithError(err).Error("listing metapaths")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	if metapaths == nil {
+		metapaths = []models.Metapath{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"metapaths": metapaths})
+}
+
+// Get handles GET /api/v1/metapaths/:name.
+func (h *MetapathHandler) Get(c *gin.Context) {
+	tenantID, name := h.named(c)
+	if tenantID == "" {
+		return
+	}
+
+	m, err := h.svc.GetMetapath(c.Request.Context(), tenantID, name)
+	if err != nil {
+		h.respondErr(c, err, "getting metapath")
+		return
+	}
+
+	c.JSON(http.StatusOK, m)
+}
+
+// Put handles PUT /api/v1/metapaths/:name, creating the metapath or
+// replacing its definition.
+func (h *MetapathHandler) Put(c *gin.Context) {
+	tenantID, name := h.named(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.PutMetapathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	m, err := h.svc.PutMetapath(c.Request.Context(), tenantID, name, req)
+	if err != nil {
+		h.respondErr(c, err, "putting metapath")
+		return
+	}
+
+	c.JSON(http.StatusOK, m)
+}
+
+// Delete handles DELETE /api/v1/metapaths/:name.
+func (h *MetapathHandler) Delete(c *gin.Context) {
+	tenantID, name := h.named(c)
+	if tenantID == "" {
+		return
+	}
+
+	if err := h.svc.DeleteMetapath(c.Request.Context(), tenantID, name); err != nil {
+		h.respondErr(c, err, "deleting metapath")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Run handles GET /api/v1/graph/metapath/:name/:start, following the named
+// metapath from the start node. ?limit= caps the paths returned.
+func (h *MetapathHandler) Run(c *gin.Context) {
+	startID := c.Param("start")
+	if err := validatePathID(startID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID, name := h.named(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.DefaultQuery("limit", "100"), models.DefaultMetapathLimit)
+
+	result, err := h.svc.RunMetapath(c.Request.Context(), tenantID, name, startID, limit, models.ReadOpts{OmitProperties: omitProperties(c)})
+	if err != nil {
+		if errors.Is(err, models.ErrNodeNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+
+			return
+		}
+
+		h.respondErr(c, err, "running metapath")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondErr answers 404 for an unknown metapath and 500 otherwise.
+func (h *MetapathHandler) respondErr(c *gin.Context, err error, action string) {
+	if errors.Is(err, models.ErrMetapathNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "metapath not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(action)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
+
+// named returns the request's tenant and metapath name, or "" after
+// answering when either is missing or metapaths are not available.
+func (h *MetapathHandler) named(c *gin.Context) (string, string) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return "", ""
+	}
+
+	name := c.Param("name")
+	if err := models.ValidateMetapathName(name); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+		return "", ""
+	}
+
+	return tenantID, name
+}
+
+// tenant returns the request's tenant, or "" after answering when it is
+// missing or metapaths are not available.
+func (h *MetapathHandler) tenant(c *gin.Context) string {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "metapaths not available")
+		return ""
+	}
+
+	return tenantID
+}
@@ -0,0 +1,118 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockMetapathService struct {
+	metapaths map[string]*models.Metapath
+}
+
+func (m *mockMetapathService) PutMetapath(_ context.Context, _, name string, req models.PutMetapathRequest) (*models.Metapath, error) {
+	mp := &models.Metapath{Name: name, StartType: req.StartType, Steps: req.Steps}
+	m.metapaths[name] = mp
+
+	return mp, nil
+}
+
+func (m *mockMetapathService) GetMetapath(_ context.Context, _, name string) (*models.Metapath, error) {
+	mp, ok := m.metapaths[name]
+	if !ok {
+		return nil, models.ErrMetapathNotFound
+	}
+
+	return mp, nil
+}
+
+func (m *mockMetapathService) ListMetapaths(_ context.Context, _ string) ([]models.Metapath, error) {
+	var out []models.Metapath
+	for _, mp := range m.metapaths {
+		out = append(out, *mp)
+	}
+
+	return out, nil
+}
+
+func (m *mockMetapathService) DeleteMetapath(_ context.Context, _, name string) error {
+	if _, ok := m.metapaths[name]; !ok {
+		return models.ErrMetapathNotFound
+	}
+
+	delete(m.metapaths, name)
+
+	return nil
+}
+
+func (m *mockMetapathService) RunMetapath(_ context.Context, _, name, startID string, _ int, _ models.ReadOpts) (*models.MetapathResult, error) {
+	if _, ok := m.metapaths[name]; !ok {
+		return nil, models.ErrMetapathNotFound
+	}
+
+	if startID == "missing" {
+		return nil, models.ErrNodeNotFound
+	}
+
+	return &models.MetapathResult{Metapath: name, StartID: startID, Paths: [][]string{{startID, "acme", "berlin"}}}, nil
+}
+
+func TestMetapathHandler(t *testing.T) {
+	h := api.NewMetapathHandler(&mockMetapathService{metapaths: map[string]*models.Metapath{}}, testLogger())
+	r := newTestRouter()
+	r.GET("/metapaths", h.List)
+	r.PUT("/metapaths/:name", h.Put)
+	r.DELETE("/metapaths/:name", h.Delete)
+	r.GET("/graph/metapath/:name/:start", h.Run)
+
+	w := doRequest(r, http.MethodPut, "/metapaths/employer-city", `{"steps":[{"relation":"works_at"},{"relation":"located_in","direction":"sideways"}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("put with bad direction: status = %d, want 400", w.Code)
+	}
+
+	w = doRequest(r, http.MethodPut, "/metapaths/employer-city", `{"start_type":"person","steps":[{"relation":"works_at"},{"relation":"located_in"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("put: status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var m models.Metapath
+	if err := json.Unmarshal(w.Body.Bytes(), &m); err != nil || m.Steps[0].Direction != models.MetapathOut {
+		t.Errorf("put: metapath = %+v, %v; want directions defaulted to out", m, err)
+	}
+
+	w = doRequest(r, http.MethodGet, "/graph/metapath/employer-city/alice", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("run: status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var result models.MetapathResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || len(result.Paths) != 1 {
+		t.Errorf("run: result = %+v, %v", result, err)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/graph/metapath/employer-city/missing", ""); w.Code != http.StatusNotFound {
+		t.Errorf("run from missing node: status = %d, want 404", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/graph/metapath/unknown/alice", ""); w.Code != http.StatusNotFound {
+		t.Errorf("run unknown metapath: status = %d, want 404", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodDelete, "/metapaths/employer-city", ""); w.Code != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want 204", w.Code)
+	}
+}
+
+func TestMetapathHandler_Unavailable(t *testing.T) {
+	h := api.NewMetapathHandler(nil, testLogger())
+	r := newTestRouter()
+	r.GET("/metapaths", h.List)
+
+	if w := doRequest(r, http.MethodGet, "/metapaths", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+}
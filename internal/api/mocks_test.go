@@ -9,15 +9,19 @@
 
 // mockNodeRepo implements api.NodeService for testing.
 type mockNodeRepo struct {
-	listFn   func(ctx context.Context, tenantID, typeFilter string, minSalience float64, limit, offset int) ([]models.Node, bool, error)
+	listFn   func(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error)
 	getFn    func(ctx context.Context, tenantID, nodeID string) (*models.Node, error)
 	createFn func(ctx context.Context, tenantID string, req models.CreateNodeRequest) (*models.Node, error)
 	updateFn func(ctx context.Context, tenantID, nodeID string, req models.UpdateNodeRequest) (*models.Node, error)
-	deleteFn func(ctx context.Context, tenantID, nodeID string) error
+	deleteFn func(ctx context.Context, tenantID, nodeID, mode string) error
+	mergeFn  func(ctx context.Context, tenantID, sourceID, targetID string, req models.MergeNodeRequest) (*models.MergeNodeResult, error)
+
+	previewDeleteFn  func(ctx context.Context, tenantID string, filter models.NodeFilter) (*models.DeletePreview, error)
+	deleteByFilterFn func(ctx context.Context, tenantID string, req models.DeleteByFilterRequest) (*models.DeleteByFilterResult, error)
 }
 
-func (m *mockNodeRepo) ListNodes(ctx context.Context, tenantID, typeFilter string, minSalience float64, limit, offset int) ([]models.Node, bool, error) {
-	return m.listFn(ctx, tenantID, typeFilter, minSalience, limit, offset)
+func (m *mockNodeRepo) ListNodes(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error) {
+	return m.listFn(ctx, tenantID, opts)
 }
 
 func (m *mockNodeRepo) GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error) {
@@ -36,8 +40,8 @@ func (m *mockNodeRepo) UpdateNode(ctx context.Context, tenantID, nodeID string,
 	return m.updateFn(ctx, tenantID, nodeID, req)
 }
 
-func (m *mockNodeRepo) DeleteNode(ctx context.Context, tenantID, nodeID string) error {
-	return m.deleteFn(ctx, tenantID, nodeID)
+func (m *mockNodeRepo) DeleteNode(ctx context.Context, tenantID, nodeID, mode string) error {
+	return m.deleteFn(ctx, tenantID, nodeID, mode)
 }
 
 func (m *mockNodeRepo) PatchNodeProperties(_ context.Context, _, _ string, _ models.PatchPropertiesRequest) (*models.Node, error) {
@@ -48,16 +52,29 @@ func (m *mockNodeRepo) MigrateNode(_ context.Context, _, _ string, _ models.Migr
 	return nil, nil
 }
 
+func (m *mockNodeRepo) MergeNode(ctx context.Context, tenantID, sourceID, targetID string, req models.MergeNodeRequest) (*models.MergeNodeResult, error) {
+	return m.mergeFn(ctx, tenantID, sourceID, targetID, req)
+}
+
+func (m *mockNodeRepo) PreviewDeleteByFilter(ctx context.Context, tenantID string, filter models.NodeFilter) (*models.DeletePreview, error) {
+	return m.previewDeleteFn(ctx, tenantID, filter)
+}
+
+func (m *mockNodeRepo) DeleteByFilter(ctx context.Context, tenantID string, req models.DeleteByFilterRequest) (*models.DeleteByFilterResult, error) {
+	return m.deleteByFilterFn(ctx, tenantID, req)
+}
+
 // mockEdgeRepo implements api.EdgeService for testing.
 type mockEdgeRepo struct {
-	listFn   func(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) ([]models.Edge, bool, error)
+	listFn   func(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool, after *models.EdgeCursor) ([]models.Edge, bool, error)
 	createFn func(ctx context.Context, tenantID string, req models.CreateEdgeRequest) (*models.Edge, error)
 	updateFn func(ctx context.Context, tenantID, source, target, relation string, req models.UpdateEdgeRequest) (*models.Edge, error)
 	deleteFn func(ctx context.Context, tenantID, source, target, relation string) error
+	existsFn func(ctx context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error)
 }
 
-func (m *mockEdgeRepo) ListEdges(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) ([]models.Edge, bool, error) {
-	return m.listFn(ctx, tenantID, source, target, relation, limit, offset, activeOn, current)
+func (m *mockEdgeRepo) ListEdges(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool, after *models.EdgeCursor) ([]models.Edge, bool, error) {
+	return m.listFn(ctx, tenantID, source, target, relation, limit, offset, activeOn, current, after)
 }
 
 func (m *mockEdgeRepo) CreateEdge(ctx context.Context, tenantID string, req models.CreateEdgeRequest) (*models.Edge, error) { //nolint:gocritic // hugeParam: matches domain.EdgeService interface signature
@@ -76,31 +93,46 @@ func (m *mockEdgeRepo) DeleteEdge(ctx context.Context, tenantID, source, target,
 	return m.deleteFn(ctx, tenantID, source, target, relation)
 }
 
+func (m *mockEdgeRepo) EdgesExist(ctx context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+	return m.existsFn(ctx, tenantID, keys)
+}
+
 // mockSearchRepo implements api.SearchService for testing.
 type mockSearchRepo struct {
-	fullTextFn func(ctx context.Context, tenantID, query, typeFilter string, minSalience float64, limit int) ([]models.Node, error)
-	semanticFn func(ctx context.Context, tenantID, query string, limit int) ([]models.ScoredNode, error)
-	hybridFn   func(ctx context.Context, tenantID, query string, limit int) ([]models.Node, error)
+	fullTextFn func(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
+	semanticFn func(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.ScoredNode, error)
+	hybridFn   func(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
+	facetsFn   func(ctx context.Context, tenantID, query string, filter models.SearchFilter) (*models.SearchFacets, error)
+}
+
+func (m *mockSearchRepo) FullTextSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error) {
+	return m.fullTextFn(ctx, tenantID, query, filter, limit)
 }
 
-func (m *mockSearchRepo) FullTextSearch(ctx context.Context, tenantID, query, typeFilter string, minSalience float64, limit int) ([]models.Node, error) {
-	return m.fullTextFn(ctx, tenantID, query, typeFilter, minSalience, limit)
+func (m *mockSearchRepo) FullTextSearchFacets(ctx context.Context, tenantID, query string, filter models.SearchFilter) (*models.SearchFacets, error) {
+	return m.facetsFn(ctx, tenantID, query, filter)
 }
 
-func (m *mockSearchRepo) SemanticSearch(ctx context.Context, tenantID, query string, limit int) ([]models.ScoredNode, error) {
-	return m.semanticFn(ctx, tenantID, query, limit)
+func (m *mockSearchRepo) SemanticSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.ScoredNode, error) {
+	return m.semanticFn(ctx, tenantID, query, filter, limit)
 }
 
-func (m *mockSearchRepo) HybridSearch(ctx context.Context, tenantID, query string, limit int) ([]models.Node, error) {
-	return m.hybridFn(ctx, tenantID, query, limit)
+func (m *mockSearchRepo) HybridSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error) {
+	return m.hybridFn(ctx, tenantID, query, filter, limit)
 }
 
 type mockAdminRepo struct {
+	withoutEmbedFn   func(ctx context.Context, tenantID string, limit int) ([]models.NodeSummary, error)
+	duplicatesFn     func(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]models.DuplicatePair, error)
 	recordFeedbackFn func(ctx context.Context, tenantID string, req models.RetrievalFeedbackRequest) (*models.RetrievalFeedbackRecord, error)
 	summaryFn        func(ctx context.Context, tenantID string, opts models.RetrievalFeedbackListOpts) (*models.RetrievalFeedbackSummary, error)
+	explainFn        func(ctx context.Context, tenantID string, req models.ExplainRequest) (*models.ExplainResult, error)
 }
 
-func (m *mockAdminRepo) ListNodesWithoutEmbeddings(_ context.Context, _ string, _ int) ([]models.NodeSummary, error) {
+func (m *mockAdminRepo) ListNodesWithoutEmbeddings(ctx context.Context, tenantID string, limit int) ([]models.NodeSummary, error) {
+	if m.withoutEmbedFn != nil {
+		return m.withoutEmbedFn(ctx, tenantID, limit)
+	}
 	return nil, nil
 }
 
@@ -116,6 +148,10 @@ func (m *mockAdminRepo) ListMergeSuggestions(_ context.Context, _ string, _ mode
 	return nil, nil
 }
 
+func (m *mockAdminRepo) ListDuplicates(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]models.DuplicatePair, error) {
+	return m.duplicatesFn(ctx, tenantID, opts)
+}
+
 func (m *mockAdminRepo) RecordRetrievalFeedback(ctx context.Context, tenantID string, req models.RetrievalFeedbackRequest) (*models.RetrievalFeedbackRecord, error) {
 	return m.recordFeedbackFn(ctx, tenantID, req)
 }
@@ -123,3 +159,21 @@ func (m *mockAdminRepo) RecordRetrievalFeedback(ctx context.Context, tenantID st
 func (m *mockAdminRepo) GetRetrievalFeedbackSummary(ctx context.Context, tenantID string, opts models.RetrievalFeedbackListOpts) (*models.RetrievalFeedbackSummary, error) {
 	return m.summaryFn(ctx, tenantID, opts)
 }
+
+func (m *mockAdminRepo) ExplainQuery(ctx context.Context, tenantID string, req models.ExplainRequest) (*models.ExplainResult, error) {
+	return m.explainFn(ctx, tenantID, req)
+}
+
+// mockReembedService implements api.ReembedService for testing.
+type mockReembedService struct {
+	startFn  func(ctx context.Context, tenantID string) (*models.ReembedStatus, error)
+	statusFn func(ctx context.Context, tenantID string) (*models.ReembedStatus, error)
+}
+
+func (m *mockReembedService) StartReembed(ctx context.Context, tenantID string) (*models.ReembedStatus, error) {
+	return m.startFn(ctx, tenantID)
+}
+
+func (m *mockReembedService) ReembedStatus(ctx context.Context, tenantID string) (*models.ReembedStatus, error) {
+	return m.statusFn(ctx, tenantID)
+}
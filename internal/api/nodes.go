@@ -2,6 +2,7 @@
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +25,7 @@ func NewNodeHandler(repo NodeService, log *logrus.Logger) *NodeHandler {
 // List handles GET /api/nodes.
 // When the ?label= query param is present, performs an exact (case-insensitive)
 // label lookup and returns at most one node. All other filters are ignored.
+// Pass next_cursor as ?after= to fetch the following page without an offset.
 func (h *NodeHandler) List(c *gin.Context) {
 	tenantID := getTenantID(c)
 	if tenantID == "" {
@@ -35,12 +37,31 @@ func (h *NodeHandler) List(c *gin.Context) {
 		return
 	}
 
-	typeFilter := c.Query("type")
-	minSalience := parseFloat(c.DefaultQuery("min_salience", "0"))
-	limit := parseInt(c.DefaultQuery("limit", "50"), 50)
-	offset := parseOffset(c.DefaultQuery("offset", "0"))
+	after, err := models.DecodeNodeCursor(c.Query("after"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	props, ok := propertyFilters(c)
+	if !ok {
+		return
+	}
+
+	nodes, hasMore, err := h.repo.ListNodes(c.Request.Context(), tenantID, models.NodeListOpts{
+		Type:           c.Query("type"),
+		MinSalience:    parseFloat(c.DefaultQuery("min_salience", "0")),
+		Properties:     props,
+		Limit:          parseInt(c.DefaultQuery("limit", "50"), 50),
+		Offset:         parseOffset(c.DefaultQuery("offset", "0")),
+		After:          after,
+		OmitProperties: omitProperties(c),
+	})
+	if respondPropertyFilterError(c, err) {
+		return
+	}
 
-	nodes, hasMore, err := h.repo.ListNodes(c.Request.Context(), tenantID, typeFilter, minSalience, limit, offset)
 	if err != nil {
 		h.log.WithError(err).Error("listing nodes")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
@@ -48,7 +69,12 @@ func (h *NodeHandler) List(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"nodes": nodes, "has_more": hasMore})
+	resp := gin.H{"nodes": nodes, "has_more": hasMore}
+	if hasMore && len(nodes) > 0 {
+		resp["next_cursor"] = models.NodeCursorAfter(&nodes[len(nodes)-1]).Encode()
+	}
+
+	c.JSON(http.StatusOK, resp)
 }
 
 // getByLabel is called by List when the ?label= param is present.
@@ -122,6 +148,10 @@ func (h *NodeHandler) Create(c *gin.Context) {
 
 	node, err := h.repo.CreateNode(c.Request.Context(), tenantID, req)
 	if err != nil {
+		if respondQuotaExceeded(c, err) {
+			return
+		}
+
 		if errors.Is(err, models.ErrDuplicateKey) {
 			respondError(c, http.StatusConflict, "conflict", "node with this ID already exists")
 
@@ -241,8 +271,8 @@ func (h *NodeHandler) Migrate(c *gin.Context) {
 		return
 	}
 
-	if req.NewID == "" {
-		respondError(c, http.StatusBadRequest, ErrCodeValidationError, "new_id is required")
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
 
 		return
 	}
@@ -261,7 +291,7 @@ func (h *NodeHandler) Migrate(c *gin.Context) {
 		}
 
 		if errors.Is(err, models.ErrDuplicateKey) {
-			respondError(c, http.StatusConflict, "conflict", "node with new_id already exists")
+			respondError(c, http.StatusConflict, "conflict", "node or edge with new_id already exists")
 
 			return
 		}
@@ -275,7 +305,75 @@ func (h *NodeHandler) Migrate(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// MergeInto handles POST /api/nodes/:id/merge-into/:target.
+func (h *NodeHandler) MergeInto(c *gin.Context) {
+	sourceID := c.Param("id")
+	targetID := c.Param("target")
+	for _, id := range []string{sourceID, targetID} {
+		if err := validatePathID(id); err != nil {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+			return
+		}
+	}
+
+	if sourceID == targetID {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, "cannot merge a node into itself")
+
+		return
+	}
+
+	var req models.MergeNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.repo.MergeNode(c.Request.Context(), tenantID, sourceID, targetID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrNodeNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+
+			return
+		}
+
+		if errors.Is(err, models.ErrMergePropertyConflict) {
+			respondError(c, http.StatusConflict, "conflict", err.Error())
+
+			return
+		}
+
+		h.log.WithError(err).Error("merging node")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "node.merge",
+		"tenant_id": tenantID,
+		"source_id": sourceID,
+		"target_id": targetID,
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, result)
+}
+
 // Delete handles DELETE /api/nodes/:id.
+// ?mode=restrict (the default) refuses to delete a node that has edges;
+// ?mode=detach deletes its edges too.
 func (h *NodeHandler) Delete(c *gin.Context) {
 	nodeID := c.Param("id")
 	if err := validatePathID(nodeID); err != nil {
@@ -284,12 +382,19 @@ func (h *NodeHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	mode, err := models.ParseDeleteMode(c.Query("mode"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
 	tenantID := getTenantID(c)
 	if tenantID == "" {
 		return
 	}
 
-	err := h.repo.DeleteNode(c.Request.Context(), tenantID, nodeID)
+	err = h.repo.DeleteNode(c.Request.Context(), tenantID, nodeID, mode)
 	if err != nil {
 		if errors.Is(err, models.ErrNodeNotFound) {
 			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
@@ -297,6 +402,12 @@ func (h *NodeHandler) Delete(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, models.ErrNodeHasEdges) {
+			respondError(c, http.StatusConflict, "conflict", err.Error()+"; delete with mode=detach to remove them too")
+
+			return
+		}
+
 		h.log.WithError(err).Error("deleting node")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
 
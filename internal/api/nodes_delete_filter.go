@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// PreviewDeleteByFilter handles POST /api/v1/nodes/delete-by-filter/preview.
+// Returns the number of matching nodes, a sample, and the token the delete
+// requires.
+func (h *NodeHandler) PreviewDeleteByFilter(c *gin.Context) {
+	var filter models.NodeFilter
+	if err := c.ShouldBindJSON(&filter); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := filter.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	preview, err := h.repo.PreviewDeleteByFilter(c.Request.Context(), tenantID, filter)
+	if err != nil {
+		h.log.WithError(err).Error("previewing delete by filter")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// DeleteByFilter handles POST /api/v1/nodes/delete-by-filter.
+// The request must carry a preview token for the same filter; if the filter
+// now matches different nodes nothing is deleted and a new preview is needed.
+func (h *NodeHandler) DeleteByFilter(c *gin.Context) {
+	var req models.DeleteByFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	// Tokens are UUIDs; anything else cannot match a preview.
+	if _, err := uuid.Parse(req.Token); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, models.ErrDeletePreviewNotFound.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.repo.DeleteByFilter(c.Request.Context(), tenantID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrDeletePreviewNotFound):
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		case errors.Is(err, models.ErrDeletePreviewMismatch):
+			respondError(c, http.StatusConflict, "conflict", err.Error())
+		default:
+			h.log.WithError(err).Error("deleting by filter")
+			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		}
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":        "node.delete_by_filter",
+		"tenant_id":     tenantID,
+		"nodes_deleted": result.NodesDeleted,
+		"edges_deleted": result.EdgesDeleted,
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, result)
+}
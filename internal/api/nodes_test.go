@@ -3,7 +3,9 @@
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -89,6 +91,124 @@ func TestNodeGet_Found(t *testing.T) {
 	}
 }
 
+func TestNodeList_Cursor(t *testing.T) {
+	t.Parallel()
+
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var gotAfter *models.NodeCursor
+	repo := &mockNodeRepo{
+		listFn: func(_ context.Context, _ string, opts models.NodeListOpts) ([]models.Node, bool, error) {
+			gotAfter = opts.After
+			if opts.After != nil {
+				return []models.Node{{ID: "n3"}}, false, nil
+			}
+			return []models.Node{{ID: "n1"}, {ID: "n2", Salience: 1.5, UpdatedAt: updated}}, true, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.GET("/nodes", h.List)
+
+	w := doRequest(r, http.MethodGet, "/nodes?limit=2", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var first struct {
+		HasMore    bool   `json:"has_more"`
+		NextCursor string `json:"next_cursor"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !first.HasMore || first.NextCursor == "" {
+		t.Fatalf("first page: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/nodes?after="+first.NextCursor, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if gotAfter == nil || gotAfter.ID != "n2" || gotAfter.Salience != 1.5 || !gotAfter.UpdatedAt.Equal(updated) {
+		t.Errorf("after = %+v, want the last node of the first page", gotAfter)
+	}
+	if strings.Contains(w.Body.String(), "next_cursor") {
+		t.Errorf("last page should not have next_cursor: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/nodes?after=not-a-cursor", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid cursor: expected 400, got %d", w.Code)
+	}
+}
+
+func TestNodeList_IncludeProperties(t *testing.T) {
+	t.Parallel()
+
+	var omitted []bool
+	repo := &mockNodeRepo{
+		listFn: func(_ context.Context, _ string, opts models.NodeListOpts) ([]models.Node, bool, error) {
+			omitted = append(omitted, opts.OmitProperties)
+			return []models.Node{}, false, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.GET("/nodes", h.List)
+
+	for _, path := range []string{"/nodes", "/nodes?include_properties=true", "/nodes?include_properties=false"} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+
+	if len(omitted) != 3 || omitted[0] || omitted[1] || !omitted[2] {
+		t.Errorf("properties omitted = %v, want [false false true]", omitted)
+	}
+}
+
+func TestNodeList_PropertyFilters(t *testing.T) {
+	t.Parallel()
+
+	var got []models.PropertyFilter
+	repo := &mockNodeRepo{
+		listFn: func(_ context.Context, _ string, opts models.NodeListOpts) ([]models.Node, bool, error) {
+			got = opts.Properties
+			for _, f := range got {
+				if f.Key == "secret" {
+					return nil, false, fmt.Errorf("listing: %w: secret", models.ErrPropertyNotSearchable)
+				}
+			}
+
+			return []models.Node{}, false, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.GET("/nodes", h.List)
+
+	w := doRequest(r, http.MethodGet, "/nodes?props=status:active&props=owner", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if len(got) != 2 || got[0].Key != "status" || got[0].Value == nil || *got[0].Value != "active" ||
+		got[1].Key != "owner" || got[1].Value != nil {
+		t.Errorf("filters = %+v, want status:active and owner", got)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/nodes?props=bad%20key:x", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("malformed key: expected 400, got %d", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/nodes?props=secret:x", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("unsearchable key: expected 400, got %d", w.Code)
+	}
+}
+
 func TestNodeGet_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -132,8 +252,10 @@ func TestNodeUpdate_OK(t *testing.T) {
 func TestNodeDelete_OK(t *testing.T) {
 	t.Parallel()
 
+	var gotMode string
 	repo := &mockNodeRepo{
-		deleteFn: func(_ context.Context, _, _ string) error {
+		deleteFn: func(_ context.Context, _, _, mode string) error {
+			gotMode = mode
 			return nil
 		},
 	}
@@ -156,4 +278,159 @@ func TestNodeDelete_OK(t *testing.T) {
 	if body["deleted"] != true {
 		t.Errorf("expected deleted=true, got %v", body["deleted"])
 	}
+	if gotMode != models.DeleteRestrict {
+		t.Errorf("default mode = %q, want %q", gotMode, models.DeleteRestrict)
+	}
+}
+
+func TestNodeDelete_Modes(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockNodeRepo{
+		deleteFn: func(_ context.Context, _, _, mode string) error {
+			if mode == models.DeleteRestrict {
+				return fmt.Errorf("%w: %d", models.ErrNodeHasEdges, 3)
+			}
+			return nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.DELETE("/nodes/:id", h.Delete)
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", http.StatusConflict},
+		{"?mode=restrict", http.StatusConflict},
+		{"?mode=detach", http.StatusOK},
+		{"?mode=cascade", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		w := doRequest(r, http.MethodDelete, "/nodes/hub"+tt.query, "")
+		if w.Code != tt.want {
+			t.Errorf("DELETE /nodes/hub%s: expected %d, got %d: %s", tt.query, tt.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestNodeMergeInto(t *testing.T) {
+	t.Parallel()
+
+	var gotStrategy string
+	repo := &mockNodeRepo{
+		mergeFn: func(_ context.Context, _, sourceID, targetID string, req models.MergeNodeRequest) (*models.MergeNodeResult, error) {
+			gotStrategy = req.ConflictStrategy
+			switch {
+			case sourceID == "missing":
+				return nil, models.ErrNodeNotFound
+			case req.ConflictStrategy == models.MergeFail:
+				return nil, fmt.Errorf("%w: role", models.ErrMergePropertyConflict)
+			}
+			return &models.MergeNodeResult{SourceID: sourceID, TargetID: targetID, EdgesRewired: 3}, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.POST("/nodes/:id/merge-into/:target", h.MergeInto)
+
+	w := doRequest(r, http.MethodPost, "/nodes/dup/merge-into/canonical", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if gotStrategy != models.MergeKeepTarget {
+		t.Errorf("strategy = %q, want default %q", gotStrategy, models.MergeKeepTarget)
+	}
+
+	var result models.MergeNodeResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.TargetID != "canonical" || result.EdgesRewired != 3 {
+		t.Errorf("result = %+v", result)
+	}
+
+	cases := []struct {
+		path, body string
+		want       int
+	}{
+		{"/nodes/a/merge-into/a", "", http.StatusBadRequest},
+		{"/nodes/a/merge-into/b", `{"conflict_strategy":"newest"}`, http.StatusBadRequest},
+		{"/nodes/missing/merge-into/b", "", http.StatusNotFound},
+		{"/nodes/a/merge-into/b", `{"conflict_strategy":"fail"}`, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		if w := doRequest(r, http.MethodPost, tc.path, tc.body); w.Code != tc.want {
+			t.Errorf("POST %s %s: status = %d, want %d", tc.path, tc.body, w.Code, tc.want)
+		}
+	}
+}
+
+func TestNodeDeleteByFilter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		token        = "5b0e7b8e-3f53-4d8e-9a59-0a1e2f3c4d5e"
+		expiredToken = "6c1f8c9f-4a64-4e9f-8b6a-1b2f3a4d5e6f"
+		staleToken   = "7d2a9dab-5b75-4fa0-9c7b-2c3a4b5e6f70"
+	)
+
+	repo := &mockNodeRepo{
+		previewDeleteFn: func(_ context.Context, _ string, filter models.NodeFilter) (*models.DeletePreview, error) {
+			return &models.DeletePreview{Token: token, Filter: filter, Count: 2}, nil
+		},
+		deleteByFilterFn: func(_ context.Context, _ string, req models.DeleteByFilterRequest) (*models.DeleteByFilterResult, error) {
+			switch req.Token {
+			case expiredToken:
+				return nil, models.ErrDeletePreviewNotFound
+			case staleToken:
+				return nil, models.ErrDeletePreviewMismatch
+			}
+			return &models.DeleteByFilterResult{NodesDeleted: 2, EdgesDeleted: 5}, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewNodeHandler(repo, testLogger())
+	r.POST("/nodes/delete-by-filter/preview", h.PreviewDeleteByFilter)
+	r.POST("/nodes/delete-by-filter", h.DeleteByFilter)
+
+	w := doRequest(r, http.MethodPost, "/nodes/delete-by-filter/preview", `{"type":"deprecated"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("preview: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var preview models.DeletePreview
+	if err := json.Unmarshal(w.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if preview.Token != token || preview.Count != 2 || preview.Filter.Type != "deprecated" {
+		t.Errorf("preview = %+v", preview)
+	}
+
+	w = doRequest(r, http.MethodPost, "/nodes/delete-by-filter", `{"filter":{"type":"deprecated"},"token":"`+token+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	cases := []struct {
+		path, body string
+		want       int
+	}{
+		{"/nodes/delete-by-filter/preview", `{}`, http.StatusBadRequest},
+		{"/nodes/delete-by-filter", `{"filter":{"type":"deprecated"}}`, http.StatusBadRequest},
+		{"/nodes/delete-by-filter", `{"filter":{},"token":"` + token + `"}`, http.StatusBadRequest},
+		{"/nodes/delete-by-filter", `{"filter":{"type":"deprecated"},"token":"not-a-token"}`, http.StatusNotFound},
+		{"/nodes/delete-by-filter", `{"filter":{"type":"deprecated"},"token":"` + expiredToken + `"}`, http.StatusNotFound},
+		{"/nodes/delete-by-filter", `{"filter":{"type":"deprecated"},"token":"` + staleToken + `"}`, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		if w := doRequest(r, http.MethodPost, tc.path, tc.body); w.Code != tc.want {
+			t.Errorf("POST %s %s: status = %d, want %d", tc.path, tc.body, w.Code, tc.want)
+		}
+	}
 }
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// PartitionHandler serves the operator report on graph table partitions.
+type PartitionHandler struct {
+	svc PartitionService
+	log *logrus.Logger
+}
+
+// NewPartitionHandler creates a PartitionHandler with the given dependencies.
+func NewPartitionHandler(svc PartitionService, log *logrus.Logger) *PartitionHandler {
+	return &PartitionHandler{svc: svc, log: log}
+}
+
+// List handles GET /api/v1/admin/partitions.
+func (h *PartitionHandler) List(c *gin.Context) {
+	if getTenantID(c) == "" {
+		return
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "partition report not available")
+		return
+	}
+
+	partitions, err := h.svc.ListPartitions(c.Request.Context())
+	if err != nil {
+		h.log.WithError(err).Error("listing partitions")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"partitions": partitions})
+}
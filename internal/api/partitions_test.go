@@ -0,0 +1,46 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockPartitionService struct{}
+
+func (mockPartitionService) ListPartitions(context.Context) ([]models.PartitionSize, error) {
+	return []models.PartitionSize{
+		{Table: "kg_nodes", Partition: "kg_nodes_p0", Modulus: 2, Remainder: 0, Rows: 10, TotalBytes: 8192},
+		{Table: "kg_nodes", Partition: "kg_nodes_p1", Modulus: 2, Remainder: 1, Rows: 4, TotalBytes: 4096},
+	}, nil
+}
+
+func TestPartitionsList(t *testing.T) {
+	r := newTestRouter()
+	r.GET("/admin/partitions", api.NewPartitionHandler(mockPartitionService{}, testLogger()).List)
+
+	w := doRequest(r, http.MethodGet, "/admin/partitions", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Partitions []models.PartitionSize `json:"partitions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Partitions) != 2 || resp.Partitions[1].Partition != "kg_nodes_p1" || resp.Partitions[1].Rows != 4 {
+		t.Errorf("partitions = %+v", resp.Partitions)
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/admin/partitions", api.NewPartitionHandler(nil, testLogger()).List)
+	if w := doRequest(disabled, http.MethodGet, "/admin/partitions", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
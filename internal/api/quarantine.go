@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// QuarantineHandler serves review of nodes the prompt-injection scanner
+// flagged at write time. Flagged nodes are left out of recall packs until
+// released.
+type QuarantineHandler struct {
+	svc QuarantineService
+	log *logrus.Logger
+}
+
+// NewQuarantineHandler creates a QuarantineHandler. svc may be nil when the
+// scanner is not configured; the endpoints then answer 503.
+func NewQuarantineHandler(svc QuarantineService, log *logrus.Logger) *QuarantineHandler {
+	return &QuarantineHandler{svc: svc, log: log}
+}
+
+// List handles GET /api/v1/admin/quarantine?limit=<n>.
+func (h *QuarantineHandler) List(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := models.DefaultQuarantineListLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > models.MaxQuarantineListLimit {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest,
+				fmt.Sprintf("limit must be between 1 and %d", models.MaxQuarantineListLimit))
+
+			return
+		}
+
+		limit = n
+	}
+
+	nodes, err := h.svc.ListQuarantined(c.Request.Context(), tenantID, limit)
+	if err != nil {
+		h.log.WithError(err).Error("listing quarantined nodes")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	if nodes == nil {
+		nodes = []models.QuarantinedNode{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"nodes": nodes})
+}
+
+// Release handles DELETE /api/v1/admin/quarantine/:id, clearing a node's
+// flag after review.
+func (h *QuarantineHandler) Release(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	if err := h.svc.ReleaseNode(c.Request.Context(), tenantID, nodeID); err != nil {
+		if errors.Is(err, models.ErrNotQuarantined) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+
+			return
+		}
+
+		h.log.WithError(err).Error("releasing quarantined node")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "quarantine.release",
+		"tenant_id": tenantID,
+		"node_id":   nodeID,
+	}).Info("audit")
+
+	c.Status(http.StatusNoContent)
+}
+
+// tenant returns the request's tenant, or "" after answering when it is
+// missing or the quarantine is not available.
+func (h *QuarantineHandler) tenant(c *gin.Context) string {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "quarantine not available")
+		return ""
+	}
+
+	return tenantID
+}
@@ -0,0 +1,81 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockQuarantineService struct {
+	flagged   map[string]bool
+	lastLimit int
+}
+
+func (m *mockQuarantineService) ListQuarantined(_ context.Context, _ string, limit int) ([]models.QuarantinedNode, error) {
+	m.lastLimit = limit
+
+	var out []models.QuarantinedNode
+	for id := range m.flagged {
+		out = append(out, models.QuarantinedNode{NodeID: id, Field: "label", Pattern: "ignore_instructions"})
+	}
+
+	return out, nil
+}
+
+func (m *mockQuarantineService) ReleaseNode(_ context.Context, _, nodeID string) error {
+	if !m.flagged[nodeID] {
+		return models.ErrNotQuarantined
+	}
+
+	delete(m.flagged, nodeID)
+
+	return nil
+}
+
+func TestQuarantineHandler(t *testing.T) {
+	svc := &mockQuarantineService{flagged: map[string]bool{"bad": true}}
+	h := api.NewQuarantineHandler(svc, testLogger())
+	r := newTestRouter()
+	r.GET("/admin/quarantine", h.List)
+	r.DELETE("/admin/quarantine/:id", h.Release)
+
+	w := doRequest(r, http.MethodGet, "/admin/quarantine", "")
+	var list struct {
+		Nodes []models.QuarantinedNode `json:"nodes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Nodes) != 1 || list.Nodes[0].NodeID != "bad" {
+		t.Fatalf("list: status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if svc.lastLimit != models.DefaultQuarantineListLimit {
+		t.Errorf("default limit = %d, want %d", svc.lastLimit, models.DefaultQuarantineListLimit)
+	}
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/admin/quarantine?limit=0", http.StatusBadRequest},
+		{http.MethodGet, "/admin/quarantine?limit=5000", http.StatusBadRequest},
+		{http.MethodDelete, "/admin/quarantine/bad", http.StatusNoContent},
+		{http.MethodDelete, "/admin/quarantine/bad", http.StatusNotFound},
+	} {
+		if w := doRequest(r, tc.method, tc.path, ""); w.Code != tc.want {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/quarantine", "")
+	if w.Body.String() != `{"nodes":[]}` {
+		t.Errorf("empty list body = %s", w.Body.String())
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/admin/quarantine", api.NewQuarantineHandler(nil, testLogger()).List)
+	if w := doRequest(disabled, http.MethodGet, "/admin/quarantine", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ReembedHandler serves the embedding model migration endpoints.
+type ReembedHandler struct {
+	svc ReembedService
+	log *logrus.Logger
+}
+
+// NewReembedHandler creates a ReembedHandler. svc may be nil when embeddings
+// are not configured; the endpoints then answer 503.
+func NewReembedHandler(svc ReembedService, log *logrus.Logger) *ReembedHandler {
+	return &ReembedHandler{svc: svc, log: log}
+}
+
+// Start handles POST /api/v1/admin/reembed.
+func (h *ReembedHandler) Start(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "embedding worker not available")
+		return
+	}
+
+	run, err := h.svc.StartReembed(c.Request.Context(), tenantID)
+	if errors.Is(err, models.ErrReembedInProgress) {
+		respondError(c, http.StatusConflict, "conflict", "a re-embed is already running")
+		return
+	}
+
+	if err != nil {
+		h.log.WithError(err).Error("starting re-embed")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{"action": "admin.reembed", "tenant_id": tenantID, "model": run.TargetModel, "total": run.Total}).Info("audit")
+	c.JSON(http.StatusAccepted, run)
+}
+
+// Status handles GET /api/v1/admin/reembed/status.
+func (h *ReembedHandler) Status(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "embedding worker not available")
+		return
+	}
+
+	run, err := h.svc.ReembedStatus(c.Request.Context(), tenantID)
+	if errors.Is(err, models.ErrReembedNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		h.log.WithError(err).Error("getting re-embed status")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
@@ -0,0 +1,69 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestReembedStart(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		startErr error
+		want     int
+	}{
+		{name: "started", want: http.StatusAccepted},
+		{name: "already running", startErr: models.ErrReembedInProgress, want: http.StatusConflict},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &mockReembedService{startFn: func(_ context.Context, _ string) (*models.ReembedStatus, error) {
+				if tc.startErr != nil {
+					return nil, tc.startErr
+				}
+				return &models.ReembedStatus{TargetModel: "m", Status: models.ReembedRunning, Total: 3}, nil
+			}}
+
+			r := newTestRouter()
+			r.POST("/admin/reembed", api.NewReembedHandler(svc, testLogger()).Start)
+
+			if w := doRequest(r, http.MethodPost, "/admin/reembed", ""); w.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestReembedStatus_NotStarted(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockReembedService{statusFn: func(_ context.Context, _ string) (*models.ReembedStatus, error) {
+		return nil, models.ErrReembedNotFound
+	}}
+
+	r := newTestRouter()
+	r.GET("/admin/reembed/status", api.NewReembedHandler(svc, testLogger()).Status)
+
+	if w := doRequest(r, http.MethodGet, "/admin/reembed/status", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestReembed_Unavailable(t *testing.T) {
+	t.Parallel()
+
+	r := newTestRouter()
+	r.POST("/admin/reembed", api.NewReembedHandler(nil, testLogger()).Start)
+
+	if w := doRequest(r, http.MethodPost, "/admin/reembed", ""); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// RelationCatalogHandler serves the tenant's relation catalog and the report
+// of relation triples in use.
+type RelationCatalogHandler struct {
+	svc RelationCatalogService
+	log *logrus.Logger
+}
+
+// NewRelationCatalogHandler creates a RelationCatalogHandler. svc may be nil
+// when the catalog is not configured; the endpoints then answer 503.
+func NewRelationCatalogHandler(svc RelationCatalogService, log *logrus.Logger) *RelationCatalogHandler {
+	return &RelationCatalogHandler{svc: svc, log: log}
+}
+
+// Get handles GET /api/v1/admin/relations/catalog.
+func (h *RelationCatalogHandler) Get(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	catalog, err := h.svc.GetRelationCatalog(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondCatalogError(c, err, "getting relation catalog")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// Set handles PUT /api/v1/admin/relations/catalog, replacing every rule and
+// the enforcement toggle.
+func (h *RelationCatalogHandler) Set(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.RelationCatalog
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	catalog, err := h.svc.SetRelationCatalog(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondCatalogError(c, err, "setting relation catalog")
+
+		return
+	}
+
+	h.log.WithFields(logrus.Fields{
+		"action":    "relation_catalog.set",
+		"tenant_id": tenantID,
+		"enforce":   catalog.Enforce,
+		"rules":     len(catalog.Rules),
+	}).Info("audit")
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// Usage handles GET /api/v1/relations/usage, listing the (source type,
+// relation, target type) triples in use with their edge counts.
+func (h *RelationCatalogHandler) Usage(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	usage, err := h.svc.ListRelationUsage(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondCatalogError(c, err, "listing relation usage")
+
+		return
+	}
+
+	if usage == nil {
+		usage = []models.RelationUsage{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"relations": usage})
+}
+
+// tenant returns the request's tenant, or "" after answering when it is
+// missing or the catalog is not available.
+func (h *RelationCatalogHandler) tenant(c *gin.Context) string {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "relation catalog not available")
+		return ""
+	}
+
+	return tenantID
+}
+
+func (h *RelationCatalogHandler) respondCatalogError(c *gin.Context, err error, msg string) {
+	if errors.Is(err, models.ErrTenantNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
@@ -0,0 +1,119 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockRelationCatalogService struct {
+	catalog models.RelationCatalog
+}
+
+func (m *mockRelationCatalogService) GetRelationCatalog(_ context.Context, _ string) (*models.RelationCatalog, error) {
+	c := m.catalog
+	return &c, nil
+}
+
+func (m *mockRelationCatalogService) SetRelationCatalog(_ context.Context, _ string, c models.RelationCatalog) (*models.RelationCatalog, error) {
+	m.catalog = c
+	return &c, nil
+}
+
+func (m *mockRelationCatalogService) ListRelationUsage(_ context.Context, _ string) ([]models.RelationUsage, error) {
+	if len(m.catalog.Rules) == 0 {
+		return nil, nil
+	}
+
+	r := m.catalog.Rules[0]
+
+	return []models.RelationUsage{{SourceType: r.SourceType, Relation: r.Relation, TargetType: r.TargetType, Count: 3, Declared: true}}, nil
+}
+
+func TestRelationCatalogHandler(t *testing.T) {
+	svc := &mockRelationCatalogService{}
+	h := api.NewRelationCatalogHandler(svc, testLogger())
+	r := newTestRouter()
+	r.GET("/admin/relations/catalog", h.Get)
+	r.PUT("/admin/relations/catalog", h.Set)
+	r.GET("/relations/usage", h.Usage)
+
+	if w := doRequest(r, http.MethodGet, "/relations/usage", ""); w.Body.String() != `{"relations":[]}` {
+		t.Errorf("empty usage: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	for _, body := range []string{
+		`{"rules":[{"source_type":"person","relation":"*","target_type":"org"}]}`,
+		`{"rules":[{"source_type":"person","target_type":"org"}]}`,
+		`not json`,
+	} {
+		if w := doRequest(r, http.MethodPut, "/admin/relations/catalog", body); w.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s: status = %d, want 400", body, w.Code)
+		}
+	}
+
+	w := doRequest(r, http.MethodPut, "/admin/relations/catalog",
+		`{"enforce":true,"rules":[{"source_type":"person","relation":"works_at","target_type":"org"}]}`)
+	if w.Code != http.StatusOK || !svc.catalog.Enforce || len(svc.catalog.Rules) != 1 {
+		t.Fatalf("PUT: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/relations/catalog", "")
+	var got models.RelationCatalog
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || !got.Enforce || got.Rules[0].Relation != "works_at" {
+		t.Errorf("GET: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/relations/usage", "")
+	var usage struct {
+		Relations []models.RelationUsage `json:"relations"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &usage); err != nil || len(usage.Relations) != 1 || usage.Relations[0].Count != 3 {
+		t.Errorf("usage: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/relations/usage", api.NewRelationCatalogHandler(nil, testLogger()).Usage)
+	if w := doRequest(disabled, http.MethodGet, "/relations/usage", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
+
+// catalogBulkService rejects every edge upsert as outside the relation catalog.
+type catalogBulkService struct{ quotaBulkService }
+
+func (catalogBulkService) BulkUpsertEdges(_ context.Context, _ string, _ []models.CreateEdgeRequest) ([]models.Edge, error) {
+	return nil, fmt.Errorf("%w: org -[knows]-> person", models.ErrRelationNotAllowed)
+}
+
+func TestRelationNotAllowed(t *testing.T) {
+	edges := &mockEdgeRepo{
+		createFn: func(_ context.Context, _ string, _ models.CreateEdgeRequest) (*models.Edge, error) {
+			return nil, fmt.Errorf("%w: org -[knows]-> person", models.ErrRelationNotAllowed)
+		},
+	}
+	r := newTestRouter()
+	r.POST("/edges", api.NewEdgeHandler(edges, testLogger()).Create)
+	r.POST("/bulk/edges", api.NewBulkHandler(catalogBulkService{}, testLogger()).BulkEdges)
+
+	for path, body := range map[string]string{
+		"/edges":      `{"source":"a","target":"b","relation":"knows"}`,
+		"/bulk/edges": `[{"source":"a","target":"b","relation":"knows"}]`,
+	} {
+		w := doRequest(r, http.MethodPost, path, body)
+
+		var resp struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusBadRequest ||
+			resp.Code != api.ErrCodeValidationError || resp.Message != "relation not allowed by catalog: org -[knows]-> person" {
+			t.Errorf("%s: status = %d, body = %s", path, w.Code, w.Body.String())
+		}
+	}
+}
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	gqlhandler "github.com/99designs/gqlgen/graphql/handler"
@@ -14,6 +15,8 @@
 	"github.com/persistorai/persistor/internal/dbpool"
 	gql "github.com/persistorai/persistor/internal/graphql"
 	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/neo4j"
 	"github.com/persistorai/persistor/internal/security"
 	"github.com/persistorai/persistor/internal/service"
 	"github.com/persistorai/persistor/internal/ws"
@@ -32,10 +35,34 @@ type RouterDeps struct {
 	Salience            SalienceService
 	Embedding           AdminService
 	History             HistoryService
+	HistoryRetention    HistoryRetentionService
+	Reembed             ReembedService // optional; re-embed endpoints answer 503 when nil
 	Audit               AuditService
 	ExportImport        ExportImportService
+	ImportSessions      ImportSessionService
+	Branches            BranchService
+	Tags                TagService
+	CoAccess            CoAccessService
+	AccessAnalytics     AccessAnalyticsService // optional; accesses are not tracked when nil
+	APIKeys             APIKeyService
+	Tenants             TenantService
+	Usage               UsageService
+	Partitions          PartitionService       // optional; the partition report answers 503 when nil
+	VectorIndex         VectorIndexService     // optional; vector index endpoints answer 503 when nil
+	IndexAdvisor        IndexAdvisorService    // optional; index advisor endpoints answer 503 when nil
+	Archive             ArchiveService         // optional; archive endpoints answer 503 when nil
+	GraphDiff           GraphDiffService       // optional; the tenant diff answers 503 when nil
+	Watches             WatchService           // optional; watch endpoints answer 503 when nil
+	Quarantine          QuarantineService      // optional; quarantine endpoints answer 503 when nil
+	RelationCatalog     RelationCatalogService // optional; relation catalog endpoints answer 503 when nil
+	Metapaths           MetapathService        // optional; metapath endpoints answer 503 when nil
+	Snapshots           SnapshotService        // optional; snapshot endpoints answer 503 when nil
 	TenantLookup        middleware.TenantLookup
-	EmbedWorker         *service.EmbedWorker // used by admin handler only
+	SecurityBlocks      security.BlockStore         // optional; brute-force blocks are per-process when nil
+	Idempotency         middleware.IdempotencyStore // optional; idempotency keys are per-process when nil
+	SignatureNonces     security.NonceStore         // optional; signature nonces are per-process when nil
+	PlanRateLimits      middleware.PlanRateLimits   // optional; built-in plan limits when nil
+	EmbedWorker         *service.EmbedWorker        // used by admin handler only
 	CORSOrigins         []string
 	Version             string
 	OllamaURL           string
@@ -58,17 +85,27 @@ type RouterDeps struct {
 func setupMiddleware(ctx context.Context, r *gin.Engine, deps *RouterDeps) {
 	r.SetTrustedProxies(nil) //nolint:errcheck // nil always succeeds.
 	r.Use(middleware.RequestID(deps.Log))
+	r.Use(middleware.Tracing())
 	r.Use(ginLogger(deps.Log))
 	r.Use(gin.Recovery())
-	r.Use(middleware.RequestTimeout(requestTimeout))
+	r.Use(middleware.RequestTimeoutExcept(requestTimeout, isLongRunning))
 	r.Use(middleware.SecurityHeaders())
 	r.Use(middleware.MaxBodySizeByPath(maxBodySize, map[string]int64{
 		"/api/v1/import": importMaxBodySize,
 	}))
 	r.Use(cors.New(cors.Config{
-		AllowOrigins:     deps.CORSOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization"},
+		AllowOrigins: deps.CORSOrigins,
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{
+			"Content-Type", "Authorization", middleware.IdempotencyKeyHeader, middleware.TraceParentHeader,
+			security.SignatureTimestampHeader, security.SignatureNonceHeader, security.SignatureHeader,
+		},
+		ExposeHeaders: []string{
+			middleware.RateLimitLimitHeader, middleware.RateLimitRemainingHeader,
+			middleware.RetryAfterHeader, middleware.IdempotentReplayedHeader,
+			middleware.TenantRateLimitLimitHeader, middleware.TenantRateLimitRemainingHeader,
+			middleware.TenantRateLimitResetHeader,
+		},
 		MaxAge:           1 * time.Hour,
 		AllowCredentials: false,
 	}))
@@ -76,97 +113,311 @@ func setupMiddleware(ctx context.Context, r *gin.Engine, deps *RouterDeps) {
 	r.Use(middleware.PrometheusMiddleware())
 }
 
+// isLongRunning reports whether the request is exempt from requestTimeout:
+// an NDJSON or Neo4j export, which streams for as long as the tenant's graph takes to
+// read, an import session commit, which applies the whole session in one
+// transaction, or an event stream, which lasts as long as the subscriber.
+// Exports and commits are still bounded by their own store timeouts.
+func isLongRunning(c *gin.Context) bool {
+	path := c.Request.URL.Path
+	if path == "/api/v1/events" {
+		return true
+	}
+
+	if path == "/api/v1/export" {
+		switch c.Query("format") {
+		case exportFormatNDJSON, neo4j.FormatCypher, neo4j.FormatCSV:
+			return true
+		}
+	}
+
+	return strings.HasPrefix(path, "/api/v1/import/sessions/") && strings.HasSuffix(path, "/commit")
+}
+
 // registerRoutes sets up all API route handlers on the given router group.
 func registerRoutes(ctx context.Context, api *gin.RouterGroup, deps *RouterDeps) {
 	log := deps.Log
 
 	health := NewHealthHandler(deps.Pool, deps.Hub, log, deps.Version, deps.OllamaURL, deps.OllamaModel, deps.EmbeddingModel, deps.EmbeddingDimensions)
+	capabilities := NewCapabilitiesHandler(deps)
 	nodes := NewNodeHandler(deps.Nodes, log)
 	edges := NewEdgeHandler(deps.Edges, log)
-	search := NewSearchHandler(deps.Search, log)
+	search := NewSearchHandler(deps.Search, deps.CoAccess, log)
 	graph := NewGraphHandler(deps.Graph, log)
 	bulk := NewBulkHandler(deps.Bulk, log)
 	salience := NewSalienceHandler(ctx, deps.Salience, log)
 	admin := NewAdminHandler(deps.Embedding, deps.EmbedWorker, log)
 	stats := NewStatsHandler(deps.Pool, log)
 	history := NewHistoryHandler(deps.History, log)
+	historyRetention := NewHistoryRetentionHandler(deps.HistoryRetention, deps.Audit, log)
+	reembed := NewReembedHandler(deps.Reembed, log)
 	audit := NewAuditHandler(deps.Audit, log)
 	exportImport := NewExportImportHandler(deps.ExportImport, log)
+	importSessions := NewImportSessionHandler(deps.ImportSessions, log)
+	branches := NewBranchHandler(deps.Branches, log)
+	tags := NewTagHandler(deps.Tags, log)
+	coAccess := NewCoAccessHandler(deps.CoAccess, log)
+	broadcast := NewBroadcastHandler(deps.Hub, deps.Audit, log)
+	apiKeys := NewAPIKeyHandler(deps.APIKeys, deps.Audit, log)
+	tenants := NewTenantHandler(deps.Tenants, deps.Audit, log)
+	usage := NewUsageHandler(deps.Usage, log)
+	partitions := NewPartitionHandler(deps.Partitions, log)
+	vectorIndex := NewVectorIndexHandler(deps.VectorIndex, deps.Audit, log)
+	indexAdvisor := NewIndexAdvisorHandler(deps.IndexAdvisor, deps.Audit, log)
+	archive := NewArchiveHandler(deps.Archive, deps.Audit, log)
+	graphDiff := NewGraphDiffHandler(deps.GraphDiff, log)
+	watches := NewWatchHandler(deps.Watches, log)
+	quarantine := NewQuarantineHandler(deps.Quarantine, log)
+	relationCatalog := NewRelationCatalogHandler(deps.RelationCatalog, log)
+	metapaths := NewMetapathHandler(deps.Metapaths, log)
+	snapshots := NewSnapshotHandler(deps.Snapshots, log)
+	analytics := NewAnalyticsHandler(deps.AccessAnalytics, log)
+	wsTickets := ws.NewTicketStore()
+	wsTicket := NewWSTicketHandler(wsTickets, log)
 
-	// Health and readiness are unauthenticated.
+	// Health, readiness, capability discovery, and the error catalog are
+	// unauthenticated.
 	api.GET("/health", health.Liveness)
 	api.GET("/ready", health.Readiness)
+	api.GET("/capabilities", capabilities.Get)
+	api.GET("/errors", NewErrorCatalogHandler().Get)
 
 	// All other API routes require authentication.
-	bfGuard := security.NewBruteForceGuard(ctx, log)
+	bfGuard := newBruteForceGuard(ctx, deps)
+	securityH := NewSecurityHandler(bfGuard, log)
 	api.Use(middleware.BruteForceMiddleware(bfGuard))
+
+	// WebSocket and Server-Sent Events endpoints. They authenticate
+	// themselves (header, ticket, or first message) because browsers cannot
+	// set headers on the upgrade request or an EventSource. They sit ahead of
+	// RequestSigning, so tenants that require signing are refused a bare API
+	// key here and must connect with a ticket from a signed POST /ws/ticket.
+	wsAuth := &wsAuthenticator{lookup: deps.TenantLookup, tickets: wsTickets, guard: bfGuard}
+	api.GET("/ws", wsHandler(ctx, log, deps.Hub, deps.CORSOrigins, wsAuth))
+	api.GET("/events", sseHandler(ctx, log, deps.Hub, wsAuth))
+
 	api.Use(middleware.AuthMiddleware(middleware.NewCachedTenantLookup(ctx, deps.TenantLookup), log, bfGuard))
+	api.Use(middleware.RequestSigning(newNonceStore(ctx, deps), log))
+	api.Use(auditImpersonation(deps.Audit, log))
+	api.Use(middleware.NewTenantRateLimiter(ctx, deps.PlanRateLimits).Handler())
+	idempotent := middleware.Idempotency(newIdempotencyStore(ctx, deps), log)
+
+	// Each group requires at least its scope; see middleware.AuthScope.
+	searchOnly := api.Group("")
+	searchOnly.Use(middleware.RequireScope(middleware.ScopeSearch, log))
+	readOnly := api.Group("")
+	readOnly.Use(middleware.RequireScope(middleware.ScopeRead, log))
+	readWrite := api.Group("")
+	readWrite.Use(middleware.RequireScope(middleware.ScopeReadWrite, log))
+
+	// Search.
+	trackSearch := analytics.Track(models.AccessSearch, accessQueryHash)
+	searchOnly.GET("/search", trackSearch, search.FullText)
+	searchOnly.GET("/search/semantic", trackSearch, search.Semantic)
+	searchOnly.GET("/search/hybrid", trackSearch, search.Hybrid)
 
 	// Nodes.
-	api.GET("/nodes", nodes.List)
-	api.POST("/nodes", nodes.Create)
-	api.GET("/nodes/:id", nodes.Get)
-	api.PUT("/nodes/:id", nodes.Update)
-	api.PATCH("/nodes/:id/properties", nodes.PatchProperties)
-	api.POST("/nodes/:id/migrate", nodes.Migrate)
-	api.GET("/nodes/:id/history", history.GetHistory)
+	readOnly.GET("/nodes", nodes.List)
+	readWrite.POST("/nodes", idempotent, nodes.Create)
+	readOnly.GET("/nodes/:id", analytics.Track(models.AccessRead, accessParam("id")), nodes.Get)
+	readWrite.PUT("/nodes/:id", nodes.Update)
+	readWrite.PATCH("/nodes/:id/properties", nodes.PatchProperties)
+	readWrite.POST("/nodes/:id/migrate", nodes.Migrate)
+	readOnly.GET("/nodes/:id/history", history.GetHistory)
+	readOnly.POST("/nodes/:id/suggest-tags", tags.Suggest)
 
 	// Edges.
-	api.GET("/edges", edges.List)
-	api.POST("/edges", edges.Create)
-	api.PUT("/edges/:source/:target/:relation", edges.Update)
-	api.PATCH("/edges/:source/:target/:relation/properties", edges.PatchProperties)
+	readOnly.GET("/edges", edges.List)
+	readWrite.POST("/edges", idempotent, edges.Create)
+	readOnly.POST("/edges/exists", edges.Exists)
+	readWrite.PUT("/edges/:source/:target/:relation", edges.Update)
+	readWrite.PATCH("/edges/:source/:target/:relation/properties", edges.PatchProperties)
+	readOnly.GET("/edges/:source/:target/:relation/history", history.GetEdgeHistory)
 
-	// Search.
-	api.GET("/search", search.FullText)
-	api.GET("/search/semantic", search.Semantic)
-	api.GET("/search/hybrid", search.Hybrid)
+	// Tenant-wide history.
+	readOnly.GET("/history", history.List)
 
-	// Graph traversal.
-	api.GET("/graph/neighbors/:id", graph.Neighbors)
-	api.GET("/graph/traverse/:id", graph.Traverse)
-	api.GET("/graph/context/:id", graph.Context)
-	api.GET("/graph/path/:from/:to", graph.Path)
+	// Graph traversal. Traversals count as accesses of their start node.
+	trackTraverse := analytics.Track(models.AccessTraverse, accessParam("id"))
+	readOnly.GET("/graph/neighbors/:id", trackTraverse, graph.Neighbors)
+	readOnly.GET("/graph/traverse/:id", trackTraverse, graph.Traverse)
+	readOnly.GET("/graph/context/:id", trackTraverse, graph.Context)
+	readOnly.GET("/graph/path/:from/:to", analytics.Track(models.AccessTraverse, accessParam("from")), graph.Path)
+	readOnly.GET("/graph/summary/:id", trackTraverse, graph.Summary)
+	readOnly.POST("/graph/subgraph", graph.Subgraph)
+	readOnly.POST("/graph/communities", graph.Communities)
+	readOnly.GET("/graph/asof", graph.AsOf)
+	readOnly.GET("/graph/metapath/:name/:start", analytics.Track(models.AccessTraverse, accessParam("start")), metapaths.Run)
 
 	// Bulk operations.
-	api.POST("/bulk/nodes", bulk.BulkNodes)
-	api.POST("/bulk/edges", bulk.BulkEdges)
+	readWrite.POST("/bulk/nodes", bulk.BulkNodes)
+	readWrite.POST("/bulk/edges", bulk.BulkEdges)
+
+	// Branches: staged, copy-on-write views of the graph.
+	readWrite.POST("/branches", branches.Create)
+	readOnly.GET("/branches", branches.List)
+	readOnly.GET("/branches/:id", branches.Get)
+	readWrite.DELETE("/branches/:id", branches.Delete)
+	readOnly.GET("/branches/:id/changes", branches.Changes)
+	readWrite.POST("/branches/:id/merge", branches.Merge)
+	readWrite.POST("/branches/:id/nodes", branches.CreateNode)
+	readOnly.GET("/branches/:id/nodes/:node", branches.GetNode)
+	readWrite.PUT("/branches/:id/nodes/:node", branches.UpdateNode)
+	readWrite.POST("/branches/:id/edges", branches.CreateEdge)
+	readOnly.GET("/branches/:id/edges/:source/:target/:relation", branches.GetEdge)
+	readWrite.PUT("/branches/:id/edges/:source/:target/:relation", branches.UpdateEdge)
+	readOnly.GET("/branches/:id/graph/neighbors/:node", branches.Neighbors)
 
 	// Salience management.
-	api.POST("/salience/boost/:id", salience.Boost)
-	api.POST("/salience/supersede", salience.Supersede)
-	api.POST("/salience/recalc", salience.Recalculate)
+	readWrite.POST("/salience/boost/:id", salience.Boost)
+	readWrite.POST("/salience/supersede", salience.Supersede)
+	readWrite.POST("/salience/recalc", salience.Recalculate)
+	readOnly.GET("/salience/top", salience.Top)
+	readOnly.GET("/salience/decaying", salience.Decaying)
+
+	// Archived nodes: out of search and traversal until restored.
+	readOnly.GET("/archive", archive.List)
+	readWrite.POST("/archive/:id/restore", archive.Restore)
 
 	// Audit.
-	api.GET("/audit", audit.Query)
+	readOnly.GET("/audit", audit.Query)
+
+	// GraphQL mixes queries and mutations on one endpoint, so it needs a
+	// key that can write.
+	registerGraphQL(readWrite, deps)
+
+	// Stats and usage.
+	readOnly.GET("/stats", stats.GetStats)
+	readOnly.GET("/stats/report", stats.GetReport)
+	readOnly.GET("/usage", usage.Get)
+	readWrite.POST("/usage/llm", usage.ReportLLM)
+	readOnly.GET("/analytics/access", analytics.Access)
+
+	// WebSocket tickets.
+	readOnly.POST("/ws/ticket", wsTicket.Issue)
+
+	// Watch lists limit a subscriber's events to the nodes it follows.
+	readOnly.GET("/watch", watches.List)
+	readWrite.POST("/watch/:id", watches.Watch)
+	readWrite.DELETE("/watch/:id", watches.Unwatch)
+
+	// Relation triples in use, for building the relation catalog.
+	readOnly.GET("/relations/usage", relationCatalog.Usage)
 
-	// GraphQL.
-	registerGraphQL(api, deps)
+	// Metapaths: named relation path templates run by /graph/metapath.
+	readOnly.GET("/metapaths", metapaths.List)
+	readOnly.GET("/metapaths/:name", metapaths.Get)
+	readWrite.PUT("/metapaths/:name", metapaths.Put)
+	readWrite.DELETE("/metapaths/:name", metapaths.Delete)
 
-	// Stats.
-	api.GET("/stats", stats.GetStats)
+	// Snapshots: named counts and content hashes of the graph to compare.
+	readOnly.GET("/snapshots", snapshots.List)
+	readOnly.GET("/snapshots/:name", snapshots.Get)
+	readOnly.GET("/snapshots/:name/compare/:other", snapshots.Compare)
+	readWrite.POST("/snapshots", snapshots.Create)
+	readWrite.DELETE("/snapshots/:name", snapshots.Delete)
 
 	adminOnly := api.Group("")
 	adminOnly.Use(middleware.RequireScope(middleware.ScopeAdmin, log))
 
 	// Export / Import.
 	adminOnly.GET("/export", exportImport.Export)
+	adminOnly.GET("/export/manifest", exportImport.Manifest)
+	adminOnly.GET("/export/nodes", exportImport.Nodes)
+	adminOnly.GET("/export/edges", exportImport.Edges)
+	adminOnly.GET("/export/jsonld-context", exportImport.GetJSONLDContext)
+	adminOnly.PUT("/export/jsonld-context", exportImport.SetJSONLDContext)
+	adminOnly.DELETE("/export/jsonld-context", exportImport.ResetJSONLDContext)
 	adminOnly.POST("/import", exportImport.Import)
 	adminOnly.POST("/import/validate", exportImport.Validate)
+	adminOnly.POST("/import/conflicts", exportImport.Conflicts)
+	adminOnly.POST("/import/sessions", importSessions.Create)
+	adminOnly.GET("/import/sessions/:id", importSessions.Get)
+	adminOnly.DELETE("/import/sessions/:id", importSessions.Delete)
+	adminOnly.PUT("/import/sessions/:id/chunks/:seq", importSessions.PutChunk)
+	adminOnly.POST("/import/sessions/:id/commit", importSessions.Commit)
+
+	// API keys.
+	adminOnly.GET("/keys", apiKeys.Status)
+	adminOnly.POST("/keys/rotate", apiKeys.Rotate)
+	adminOnly.DELETE("/keys/previous", apiKeys.RevokePrevious)
+	adminOnly.POST("/keys/signing", apiKeys.EnableSigning)
+	adminOnly.DELETE("/keys/signing", apiKeys.DisableSigning)
+	adminOnly.GET("/admin/keys", apiKeys.ListManaged)
+	adminOnly.POST("/admin/keys", apiKeys.CreateManaged)
+	adminOnly.DELETE("/admin/keys/:id", apiKeys.RevokeManaged)
+	adminOnly.POST("/admin/keys/:id/rotate", apiKeys.RotateManaged)
 
 	// Admin.
 	adminOnly.DELETE("/audit", audit.Purge)
 	adminOnly.DELETE("/nodes/:id", nodes.Delete)
+	adminOnly.POST("/nodes/delete-by-filter/preview", nodes.PreviewDeleteByFilter)
+	adminOnly.POST("/nodes/delete-by-filter", nodes.DeleteByFilter)
+	adminOnly.POST("/nodes/:id/merge-into/:target", nodes.MergeInto)
 	adminOnly.DELETE("/edges/:source/:target/:relation", edges.Delete)
+	adminOnly.DELETE("/branches/:id/nodes/:node", branches.DeleteNode)
+	adminOnly.DELETE("/branches/:id/edges/:source/:target/:relation", branches.DeleteEdge)
 	adminOnly.POST("/admin/backfill-embeddings", admin.BackfillEmbeddings)
+	adminOnly.GET("/admin/backfill-embeddings", admin.ListBackfills)
+	adminOnly.GET("/admin/backfill-embeddings/:id", admin.BackfillStatus)
+	adminOnly.POST("/admin/backfill-embeddings/:id/cancel", admin.CancelBackfill)
 	adminOnly.POST("/admin/reprocess-nodes", admin.ReprocessNodes)
+	adminOnly.POST("/admin/reembed", reembed.Start)
+	adminOnly.GET("/admin/reembed/status", reembed.Status)
 	adminOnly.POST("/admin/maintenance/run", admin.RunMaintenance)
 	adminOnly.GET("/admin/merge-suggestions", admin.ListMergeSuggestions)
+	adminOnly.GET("/admin/duplicates", admin.ListDuplicates)
 	adminOnly.POST("/admin/retrieval-feedback", admin.RecordRetrievalFeedback)
 	adminOnly.GET("/admin/retrieval-feedback", admin.GetRetrievalFeedbackSummary)
+	adminOnly.POST("/admin/explain", admin.ExplainQuery)
+	adminOnly.GET("/admin/history/retention", historyRetention.Get)
+	adminOnly.PUT("/admin/history/retention", historyRetention.Set)
+	adminOnly.POST("/admin/history/prune", historyRetention.Prune)
+	adminOnly.GET("/admin/archive/policy", archive.GetPolicy)
+	adminOnly.PUT("/admin/archive/policy", archive.SetPolicy)
+	adminOnly.POST("/admin/archive/run", archive.Run)
+	adminOnly.POST("/admin/tags/centroids/rebuild", tags.RebuildCentroids)
+	adminOnly.POST("/admin/relations/infer-co-access", coAccess.Infer)
+	adminOnly.GET("/admin/quarantine", quarantine.List)
+	adminOnly.DELETE("/admin/quarantine/:id", quarantine.Release)
+	adminOnly.GET("/admin/relations/catalog", relationCatalog.Get)
+	adminOnly.PUT("/admin/relations/catalog", relationCatalog.Set)
+
+	// Tenant management and other cross-tenant routes need an admin key of an
+	// operator tenant.
+	operatorOnly := adminOnly.Group("")
+	operatorOnly.Use(middleware.RequireOperator(log))
+
+	operatorOnly.GET("/admin/tenants", tenants.List)
+	operatorOnly.POST("/admin/tenants", tenants.Create)
+	operatorOnly.GET("/admin/tenants/:id", tenants.Get)
+	operatorOnly.PATCH("/admin/tenants/:id", tenants.Update)
+	operatorOnly.DELETE("/admin/tenants/:id", tenants.Delete)
+	operatorOnly.POST("/admin/tenants/:id/rotate-key", tenants.RotateKey)
+	operatorOnly.POST("/admin/tenants/:id/suspend", tenants.Suspend)
+	operatorOnly.POST("/admin/tenants/:id/resume", tenants.Resume)
+	operatorOnly.GET("/admin/tenants/:id/keys", tenants.ListKeys)
+	operatorOnly.POST("/admin/tenants/:id/keys", tenants.CreateKey)
+	operatorOnly.DELETE("/admin/tenants/:id/keys/:key_id", tenants.RevokeKey)
+	operatorOnly.POST("/admin/tenants/:id/impersonate", tenants.Impersonate)
+	operatorOnly.GET("/admin/partitions", partitions.List)
+	operatorOnly.GET("/admin/vector-index", vectorIndex.Status)
+	operatorOnly.POST("/admin/vector-index", vectorIndex.Rebuild)
+	operatorOnly.GET("/admin/index-advisor", indexAdvisor.Report)
+	operatorOnly.POST("/admin/index-advisor", indexAdvisor.Create)
+	operatorOnly.GET("/admin/diff", graphDiff.Diff)
+	operatorOnly.POST("/admin/broadcast", broadcast.Broadcast)
+	operatorOnly.GET("/admin/security/blocks", securityH.Blocks)
+}
+
+// newBruteForceGuard returns a guard shared through deps.SecurityBlocks when
+// one is configured, so blocks survive restarts and apply on every replica.
+func newBruteForceGuard(ctx context.Context, deps *RouterDeps) *security.BruteForceGuard {
+	if deps.SecurityBlocks != nil {
+		return security.NewSharedBruteForceGuard(ctx, deps.Log, deps.SecurityBlocks)
+	}
 
-	// WebSocket endpoint.
-	api.GET("/ws", wsHandler(ctx, log, deps.Hub, deps.CORSOrigins, deps.TenantLookup))
+	return security.NewBruteForceGuard(ctx, deps.Log)
 }
 
 // registerGraphQL sets up the GraphQL endpoint and optional playground.
@@ -178,6 +429,7 @@ func registerGraphQL(api *gin.RouterGroup, deps *RouterDeps) {
 		GraphSvc:    deps.Graph,
 		SalienceSvc: deps.Salience,
 		AuditSvc:    deps.Audit,
+		BulkSvc:     deps.Bulk,
 	}
 	gqlSrv := gqlhandler.NewDefaultServer(gql.NewExecutableSchema(gql.Config{Resolvers: gqlResolver}))
 	gqlGroup := api.Group("/graphql", gql.GinContextToTenantMiddleware())
@@ -2,18 +2,21 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"strconv"
 	"time"
+	"unicode/utf8"
 
-	"github.com/coder/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/middleware"
-	"github.com/persistorai/persistor/internal/ws"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/security"
 )
 
 // getTenantID extracts the authenticated tenant ID from the Gin context
@@ -30,49 +33,6 @@ func getTenantID(c *gin.Context) string {
 	return tid
 }
 
-func wsHandler(appCtx context.Context, log *logrus.Logger, hub *ws.Hub, corsOrigins []string, lookup middleware.TenantLookup) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		tenantID := getTenantID(c)
-		if tenantID == "" {
-			return
-		}
-
-		// Extract the raw API key for periodic re-validation.
-		apiKey := middleware.ExtractBearerToken(c)
-
-		// CORS origins are reused as WebSocket origin patterns. The config
-		// validator ensures these are safe host patterns (no wildcards etc.).
-		conn, err := websocket.Accept(c.Writer, c.Request, &websocket.AcceptOptions{
-			OriginPatterns:       corsOrigins,
-			CompressionMode:      websocket.CompressionContextTakeover,
-			CompressionThreshold: 128,
-		})
-		if err != nil {
-			log.WithError(err).Error("websocket accept failed")
-
-			return
-		}
-
-		client := ws.NewClient(hub, conn, lookup, apiKey)
-		client.TenantID = tenantID
-		hub.Register(client)
-
-		// Derive a context that cancels when either the server shuts down or the request ends.
-		wsCtx, wsCancel := context.WithCancel(appCtx)
-		go func() {
-			select {
-			case <-c.Request.Context().Done():
-				wsCancel()
-			case <-wsCtx.Done():
-			}
-		}()
-
-		go client.WritePump(wsCtx)
-		client.ReadPump(wsCtx)
-		wsCancel()
-	}
-}
-
 func ginLogger(log *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -102,6 +62,38 @@ func ginLogger(log *logrus.Logger) gin.HandlerFunc {
 // maxPaginationOffset caps the maximum offset for paginated queries.
 const maxPaginationOffset = 100000
 
+// omitProperties reports whether the client passed ?include_properties=false,
+// asking for nodes and edges without properties. Skipping decryption makes
+// large list, search, and traversal results much cheaper.
+func omitProperties(c *gin.Context) bool {
+	raw := c.Query("include_properties")
+	return raw == "false" || raw == "0"
+}
+
+// propertyFilters parses the request's ?props=key:value and ?props=key
+// filters. It responds 400 and returns false when a filter is malformed.
+func propertyFilters(c *gin.Context) ([]models.PropertyFilter, bool) {
+	filters, err := models.ParsePropertyFilters(c.QueryArray("props"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+		return nil, false
+	}
+
+	return filters, true
+}
+
+// respondPropertyFilterError answers 400 and returns true when err is a
+// props filter on a property that is not searchable.
+func respondPropertyFilterError(c *gin.Context, err error) bool {
+	if !errors.Is(err, models.ErrPropertyNotSearchable) {
+		return false
+	}
+
+	respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+	return true
+}
+
 func parseInt(s string, fallback int) int {
 	v, err := strconv.Atoi(s)
 	if err != nil || v <= 0 {
@@ -133,7 +125,7 @@ func validatePathID(id string) error {
 	if id == "" {
 		return fmt.Errorf("id must not be empty")
 	}
-	if len(id) > 255 {
+	if utf8.RuneCountInString(id) > 255 {
 		return fmt.Errorf("id exceeds maximum length of 255")
 	}
 	return nil
@@ -147,3 +139,25 @@ func parseFloat(s string) float64 {
 
 	return v
 }
+
+// newIdempotencyStore returns deps.Idempotency when one is configured, so
+// retried creates are deduplicated on every replica, and an in-memory store
+// otherwise.
+func newIdempotencyStore(ctx context.Context, deps *RouterDeps) middleware.IdempotencyStore {
+	if deps.Idempotency != nil {
+		return deps.Idempotency
+	}
+
+	return middleware.NewMemoryIdempotencyStore(ctx)
+}
+
+// newNonceStore returns deps.SignatureNonces when one is configured, so a
+// signed request cannot be replayed against another replica, and an
+// in-memory cache otherwise.
+func newNonceStore(ctx context.Context, deps *RouterDeps) security.NonceStore {
+	if deps.SignatureNonces != nil {
+		return deps.SignatureNonces
+	}
+
+	return security.NewReplayCache(ctx)
+}
@@ -208,3 +208,43 @@ func (h *SalienceHandler) Recalculate(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"updated": count})
 }
+
+// Top handles GET /api/v1/salience/top: the highest-salience nodes, what the
+// memory system is promoting.
+func (h *SalienceHandler) Top(c *gin.Context) {
+	h.listEntries(c, "listing top salient nodes", h.repo.TopSalientNodes)
+}
+
+// Decaying handles GET /api/v1/salience/decaying: the nodes whose salience
+// dropped most in the last recalculation, what the memory system is
+// forgetting.
+func (h *SalienceHandler) Decaying(c *gin.Context) {
+	h.listEntries(c, "listing decaying nodes", h.repo.DecayingNodes)
+}
+
+func (h *SalienceHandler) listEntries(
+	c *gin.Context,
+	what string,
+	list func(ctx context.Context, tenantID, typeFilter string, limit int) ([]models.SalienceEntry, error),
+) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := parseInt(c.DefaultQuery("limit", "50"), 50)
+
+	entries, err := list(c.Request.Context(), tenantID, c.Query("type"), limit)
+	if err != nil {
+		h.log.WithError(err).Error(what)
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	if entries == nil {
+		entries = []models.SalienceEntry{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"nodes": entries})
+}
@@ -0,0 +1,59 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// mockSalienceService records the report queries it receives.
+type mockSalienceService struct {
+	api.SalienceService
+	typeFilter string
+	limit      int
+}
+
+func (m *mockSalienceService) TopSalientNodes(_ context.Context, _, typeFilter string, limit int) ([]models.SalienceEntry, error) {
+	m.typeFilter, m.limit = typeFilter, limit
+	return []models.SalienceEntry{{NodeID: "n1", Type: "person", Salience: 3.2, UserBoosted: true}}, nil
+}
+
+func (m *mockSalienceService) DecayingNodes(_ context.Context, _, typeFilter string, limit int) ([]models.SalienceEntry, error) {
+	m.typeFilter, m.limit = typeFilter, limit
+	return nil, nil
+}
+
+func TestSalienceReports(t *testing.T) {
+	svc := &mockSalienceService{}
+	h := api.NewSalienceHandler(t.Context(), svc, testLogger())
+	r := newTestRouter()
+	r.GET("/salience/top", h.Top)
+	r.GET("/salience/decaying", h.Decaying)
+
+	w := doRequest(r, http.MethodGet, "/salience/top?type=person&limit=5", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("top status = %d: %s", w.Code, w.Body.String())
+	}
+	if svc.typeFilter != "person" || svc.limit != 5 {
+		t.Errorf("top query = %q, %d", svc.typeFilter, svc.limit)
+	}
+
+	var top struct {
+		Nodes []models.SalienceEntry `json:"nodes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &top); err != nil || len(top.Nodes) != 1 || top.Nodes[0].NodeID != "n1" {
+		t.Fatalf("top = %s (%v)", w.Body.String(), err)
+	}
+
+	w = doRequest(r, http.MethodGet, "/salience/decaying", "")
+	if w.Code != http.StatusOK || w.Body.String() != `{"nodes":[]}` {
+		t.Errorf("decaying = %d %s", w.Code, w.Body.String())
+	}
+	if svc.typeFilter != "" || svc.limit != 50 {
+		t.Errorf("decaying query = %q, %d; want the default limit", svc.typeFilter, svc.limit)
+	}
+}
@@ -1,12 +1,16 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/persistorai/persistor/internal/models"
 	"github.com/persistorai/persistor/internal/service"
 )
 
@@ -15,16 +19,21 @@
 
 // SearchHandler serves search endpoints.
 type SearchHandler struct {
-	repo SearchService
-	log  *logrus.Logger
+	repo   SearchService
+	access CoAccessService
+	log    *logrus.Logger
 }
 
-// NewSearchHandler creates a SearchHandler with the given repository and logger.
-func NewSearchHandler(repo SearchService, log *logrus.Logger) *SearchHandler {
-	return &SearchHandler{repo: repo, log: log}
+// NewSearchHandler creates a SearchHandler with the given repository and
+// logger. When access is non-nil, each search's top results are recorded as
+// accessed together for co-access inference.
+func NewSearchHandler(repo SearchService, access CoAccessService, log *logrus.Logger) *SearchHandler {
+	return &SearchHandler{repo: repo, access: access, log: log}
 }
 
 // FullText handles GET /api/search.
+// With ?facets=true the response also counts every match, before the limit,
+// by type, salience bucket, and creation month.
 func (h *SearchHandler) FullText(c *gin.Context) {
 	q := c.Query("q")
 	if q == "" {
@@ -43,11 +52,28 @@ func (h *SearchHandler) FullText(c *gin.Context) {
 	if tenantID == "" {
 		return
 	}
-	typeFilter := c.Query("type")
-	minSalience := parseFloat(c.DefaultQuery("min_salience", "0"))
+
+	session, ok := accessSession(c)
+	if !ok {
+		return
+	}
 	limit := parseInt(c.DefaultQuery("limit", "20"), 20)
 
-	nodes, err := h.repo.FullTextSearch(c.Request.Context(), tenantID, q, typeFilter, minSalience, limit)
+	filter, ok := searchFilter(c)
+	if !ok {
+		return
+	}
+
+	// Unlike semantic and hybrid search, full-text search returns superseded
+	// nodes unless asked not to.
+	filter.IncludeSuperseded = c.DefaultQuery("include_superseded", "true") == "true"
+	ctx := c.Request.Context()
+
+	nodes, err := h.repo.FullTextSearch(ctx, tenantID, q, filter, limit)
+	if respondPropertyFilterError(c, err) || respondRootNotFound(c, err) {
+		return
+	}
+
 	if err != nil {
 		h.log.WithError(err).Error("full-text search")
 		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
@@ -55,9 +81,90 @@ func (h *SearchHandler) FullText(c *gin.Context) {
 		return
 	}
 
+	resp := gin.H{"nodes": nodes, "total": len(nodes)}
+
+	if c.Query("facets") == "true" {
+		facets, err := h.repo.FullTextSearchFacets(ctx, tenantID, q, filter)
+		if err != nil {
+			h.log.WithError(err).Error("full-text search facets")
+			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+			return
+		}
+
+		resp["facets"] = facets
+	}
+
 	h.log.WithFields(logrus.Fields{"action": "search.fulltext", "tenant_id": tenantID, "results": len(nodes)}).Info("audit")
+	h.recordAccess(c, tenantID, session, nodeIDs(nodes))
 
-	c.JSON(http.StatusOK, gin.H{"nodes": nodes, "total": len(nodes)})
+	c.JSON(http.StatusOK, resp)
+}
+
+// searchFilter parses the type, min_salience, include_superseded, props,
+// root, depth, and include_properties query parameters of search. Superseded
+// nodes are excluded unless include_superseded=true. It answers 400 and returns false on a
+// malformed props filter, root, or depth.
+func searchFilter(c *gin.Context) (models.SearchFilter, bool) {
+	props, ok := propertyFilters(c)
+	if !ok {
+		return models.SearchFilter{}, false
+	}
+
+	hood, ok := searchNeighborhood(c)
+	if !ok {
+		return models.SearchFilter{}, false
+	}
+
+	return models.SearchFilter{
+		Type:              c.Query("type"),
+		MinSalience:       parseFloat(c.DefaultQuery("min_salience", "0")),
+		IncludeSuperseded: c.Query("include_superseded") == "true",
+		Properties:        props,
+		Neighborhood:      hood,
+		OmitProperties:    omitProperties(c),
+	}, true
+}
+
+// searchNeighborhood parses the optional root and depth parameters, which
+// restrict candidates to nodes within depth hops of root. It answers 400 and
+// returns false on a malformed root or depth.
+func searchNeighborhood(c *gin.Context) (models.Neighborhood, bool) {
+	root := c.Query("root")
+	if root == "" {
+		return models.Neighborhood{}, true
+	}
+
+	if err := validatePathID(root); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "root: "+err.Error())
+		return models.Neighborhood{}, false
+	}
+
+	depth := models.DefaultNeighborhoodDepth
+	if raw := c.Query("depth"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > models.MaxNeighborhoodDepth {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest,
+				fmt.Sprintf("depth must be between 1 and %d", models.MaxNeighborhoodDepth))
+			return models.Neighborhood{}, false
+		}
+
+		depth = v
+	}
+
+	return models.Neighborhood{Root: root, Depth: depth}, true
+}
+
+// respondRootNotFound answers 404 and returns true when err reports that the
+// neighborhood root does not exist.
+func respondRootNotFound(c *gin.Context, err error) bool {
+	if !errors.Is(err, models.ErrNodeNotFound) {
+		return false
+	}
+
+	respondError(c, http.StatusNotFound, ErrCodeNotFound, "root node not found")
+
+	return true
 }
 
 // Semantic handles GET /api/search/semantic.
@@ -79,9 +186,29 @@ func (h *SearchHandler) Semantic(c *gin.Context) {
 	if tenantID == "" {
 		return
 	}
+
+	session, ok := accessSession(c)
+	if !ok {
+		return
+	}
 	limit := parseInt(c.DefaultQuery("limit", "10"), 10)
 
-	results, err := h.repo.SemanticSearch(c.Request.Context(), tenantID, q, limit)
+	filter, ok := searchFilter(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.repo.SemanticSearch(c.Request.Context(), tenantID, q, filter, limit)
+	if respondPropertyFilterError(c, err) || respondRootNotFound(c, err) || respondQuotaExceeded(c, err) {
+		return
+	}
+
+	if errors.Is(err, models.ErrReembedInProgress) {
+		respondError(c, http.StatusConflict, "conflict", "embeddings are being migrated to a new model; semantic search is unavailable until the re-embed completes")
+
+		return
+	}
+
 	if err != nil {
 		h.log.WithError(err).Error("semantic search")
 		respondError(c, http.StatusBadGateway, ErrCodeInternalError, "search unavailable")
@@ -90,6 +217,7 @@ func (h *SearchHandler) Semantic(c *gin.Context) {
 	}
 
 	h.log.WithFields(logrus.Fields{"action": "search.semantic", "tenant_id": tenantID, "results": len(results)}).Info("audit")
+	h.recordAccess(c, tenantID, session, scoredNodeIDs(results))
 
 	c.JSON(http.StatusOK, gin.H{"nodes": results, "total": len(results)})
 }
@@ -113,8 +241,22 @@ func (h *SearchHandler) Hybrid(c *gin.Context) {
 	if tenantID == "" {
 		return
 	}
+
+	session, ok := accessSession(c)
+	if !ok {
+		return
+	}
 	limit := parseInt(c.DefaultQuery("limit", "10"), 10)
+
+	filter, ok := searchFilter(c)
+	if !ok {
+		return
+	}
+
 	ctx := c.Request.Context()
+	if c.Query("rerank") == "true" {
+		ctx = service.WithRerank(ctx)
+	}
 	if rerankMode := strings.TrimSpace(c.Query("internal_rerank")); rerankMode != "" {
 		ctx = service.WithInternalRerankMode(ctx, rerankMode)
 	}
@@ -122,12 +264,16 @@ func (h *SearchHandler) Hybrid(c *gin.Context) {
 		ctx = service.WithInternalRerankProfile(ctx, rerankProfile)
 	}
 
-	nodes, err := h.repo.HybridSearch(ctx, tenantID, q, limit)
+	nodes, err := h.repo.HybridSearch(ctx, tenantID, q, filter, limit)
+	if respondPropertyFilterError(c, err) || respondRootNotFound(c, err) {
+		return
+	}
+
 	if err != nil {
 		// Embedding failed — fall back to full-text search.
 		h.log.WithError(err).Warn("hybrid search failed, falling back to full-text")
 
-		nodes, ftErr := h.repo.FullTextSearch(c.Request.Context(), tenantID, q, "", 0, limit)
+		nodes, ftErr := h.repo.FullTextSearch(c.Request.Context(), tenantID, q, filter, limit)
 		if ftErr != nil {
 			h.log.WithError(ftErr).Error("full-text fallback in hybrid search")
 			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
@@ -136,6 +282,7 @@ func (h *SearchHandler) Hybrid(c *gin.Context) {
 		}
 
 		h.log.WithFields(logrus.Fields{"action": "search.hybrid_fallback", "tenant_id": tenantID, "results": len(nodes)}).Info("audit")
+		h.recordAccess(c, tenantID, session, nodeIDs(nodes))
 
 		c.JSON(http.StatusOK, gin.H{"nodes": nodes, "total": len(nodes)})
 
@@ -143,6 +290,7 @@ func (h *SearchHandler) Hybrid(c *gin.Context) {
 	}
 
 	h.log.WithFields(logrus.Fields{"action": "search.hybrid", "tenant_id": tenantID, "results": len(nodes)}).Info("audit")
+	h.recordAccess(c, tenantID, session, nodeIDs(nodes))
 
 	c.JSON(http.StatusOK, gin.H{"nodes": nodes, "total": len(nodes)})
 }
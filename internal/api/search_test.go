@@ -3,7 +3,10 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/persistorai/persistor/internal/api"
@@ -15,13 +18,13 @@ func TestFullTextSearch_OK(t *testing.T) {
 	t.Parallel()
 
 	repo := &mockSearchRepo{
-		fullTextFn: func(_ context.Context, _, query, _ string, _ float64, _ int) ([]models.Node, error) {
+		fullTextFn: func(_ context.Context, _, query string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			return []models.Node{{ID: "n1", Type: "person", Label: query}}, nil
 		},
 	}
 
 	r := newTestRouter()
-	h := api.NewSearchHandler(repo, testLogger())
+	h := api.NewSearchHandler(repo, nil, testLogger())
 	r.GET("/search", h.FullText)
 
 	w := doRequest(r, http.MethodGet, "/search?q=test", "")
@@ -41,11 +44,59 @@ func TestFullTextSearch_OK(t *testing.T) {
 	}
 }
 
+func TestFullTextSearch_Facets(t *testing.T) {
+	t.Parallel()
+
+	var gotType string
+	repo := &mockSearchRepo{
+		fullTextFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			return []models.Node{{ID: "n1", Type: "person"}}, nil
+		},
+		facetsFn: func(_ context.Context, _, _ string, filter models.SearchFilter) (*models.SearchFacets, error) {
+			gotType = filter.Type
+			return &models.SearchFacets{Total: 3, Types: []models.FacetCount{{Value: "person", Count: 3}}}, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search", h.FullText)
+
+	w := doRequest(r, http.MethodGet, "/search?q=test&type=person&limit=1&facets=true", "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		Total  int                 `json:"total"`
+		Facets models.SearchFacets `json:"facets"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if gotType != "person" {
+		t.Errorf("facets type filter = %q, want person", gotType)
+	}
+
+	if body.Total != 1 || body.Facets.Total != 3 || len(body.Facets.Types) != 1 {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+
+	// Without facets=true no facet query runs.
+	repo.facetsFn = nil
+	w = doRequest(r, http.MethodGet, "/search?q=test", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 without facets, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 func TestFullTextSearch_MissingQ(t *testing.T) {
 	t.Parallel()
 
 	r := newTestRouter()
-	h := api.NewSearchHandler(&mockSearchRepo{}, testLogger())
+	h := api.NewSearchHandler(&mockSearchRepo{}, nil, testLogger())
 	r.GET("/search", h.FullText)
 
 	w := doRequest(r, http.MethodGet, "/search", "")
@@ -59,7 +110,7 @@ func TestSemanticSearch_OK(t *testing.T) {
 	t.Parallel()
 
 	repo := &mockSearchRepo{
-		semanticFn: func(_ context.Context, _, _ string, _ int) ([]models.ScoredNode, error) {
+		semanticFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.ScoredNode, error) {
 			return []models.ScoredNode{
 				{Node: models.Node{ID: "n1", Type: "concept", Label: "test"}, Score: 0.95},
 			}, nil
@@ -67,27 +118,73 @@ func TestSemanticSearch_OK(t *testing.T) {
 	}
 
 	r := newTestRouter()
-	h := api.NewSearchHandler(repo, testLogger())
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search/semantic", h.Semantic)
+
+	w := doRequest(r, http.MethodGet, "/search/semantic?q=test", "")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSemanticSearch_ReembedInProgress(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockSearchRepo{
+		semanticFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.ScoredNode, error) {
+			return nil, models.ErrReembedInProgress
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
 	r.GET("/search/semantic", h.Semantic)
 
 	w := doRequest(r, http.MethodGet, "/search/semantic?q=test", "")
 
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestSemanticSearch_PassesSearchFilter(t *testing.T) {
+	t.Parallel()
+
+	var got models.SearchFilter
+	repo := &mockSearchRepo{
+		semanticFn: func(_ context.Context, _, _ string, filter models.SearchFilter, _ int) ([]models.ScoredNode, error) {
+			got = filter
+			return nil, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search/semantic", h.Semantic)
+
+	w := doRequest(r, http.MethodGet, "/search/semantic?q=test&type=person&min_salience=2.5&include_superseded=true", "")
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
 	}
+
+	want := models.SearchFilter{Type: "person", MinSalience: 2.5, IncludeSuperseded: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("filter = %+v, want %+v", got, want)
+	}
 }
 
 func TestHybridSearch_OK(t *testing.T) {
 	t.Parallel()
 
 	repo := &mockSearchRepo{
-		hybridFn: func(_ context.Context, _, _ string, _ int) ([]models.Node, error) {
+		hybridFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			return []models.Node{{ID: "n1", Type: "concept", Label: "test"}}, nil
 		},
 	}
 
 	r := newTestRouter()
-	h := api.NewSearchHandler(repo, testLogger())
+	h := api.NewSearchHandler(repo, nil, testLogger())
 	r.GET("/search/hybrid", h.Hybrid)
 
 	w := doRequest(r, http.MethodGet, "/search/hybrid?q=test", "")
@@ -103,7 +200,7 @@ func TestHybridSearch_PassesInternalRerankContext(t *testing.T) {
 	var mode string
 	var profile string
 	repo := &mockSearchRepo{
-		hybridFn: func(ctx context.Context, _, _ string, _ int) ([]models.Node, error) {
+		hybridFn: func(ctx context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			mode = service.InternalRerankMode(ctx)
 			profile = service.InternalRerankProfile(ctx)
 			return []models.Node{{ID: "n1", Type: "concept", Label: "test"}}, nil
@@ -111,7 +208,7 @@ func TestHybridSearch_PassesInternalRerankContext(t *testing.T) {
 	}
 
 	r := newTestRouter()
-	h := api.NewSearchHandler(repo, testLogger())
+	h := api.NewSearchHandler(repo, nil, testLogger())
 	r.GET("/search/hybrid", h.Hybrid)
 
 	w := doRequest(r, http.MethodGet, "/search/hybrid?q=test&internal_rerank=prototype&internal_rerank_profile=term_focus", "")
@@ -125,3 +222,138 @@ func TestHybridSearch_PassesInternalRerankContext(t *testing.T) {
 		t.Fatalf("expected term_focus rerank profile, got %q", profile)
 	}
 }
+
+func TestHybridSearch_FallbackKeepsFilter(t *testing.T) {
+	t.Parallel()
+
+	var hybridFilter models.SearchFilter
+	var gotType string
+	var gotSalience float64
+	repo := &mockSearchRepo{
+		hybridFn: func(_ context.Context, _, _ string, filter models.SearchFilter, _ int) ([]models.Node, error) {
+			hybridFilter = filter
+			return nil, errors.New("embedding unavailable")
+		},
+		fullTextFn: func(_ context.Context, _, _ string, filter models.SearchFilter, _ int) ([]models.Node, error) {
+			gotType, gotSalience = filter.Type, filter.MinSalience
+			return nil, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search/hybrid", h.Hybrid)
+
+	w := doRequest(r, http.MethodGet, "/search/hybrid?q=test&type=person&min_salience=3", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if want := (models.SearchFilter{Type: "person", MinSalience: 3}); !reflect.DeepEqual(hybridFilter, want) {
+		t.Errorf("hybrid filter = %+v, want %+v", hybridFilter, want)
+	}
+
+	if gotType != "person" || gotSalience != 3 {
+		t.Errorf("fallback filters = (%q, %v), want (person, 3)", gotType, gotSalience)
+	}
+}
+
+func TestHybridSearch_UnsearchablePropertyDoesNotFallBack(t *testing.T) {
+	t.Parallel()
+
+	fellBack := false
+	repo := &mockSearchRepo{
+		hybridFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			return nil, fmt.Errorf("hybrid search: %w: secret", models.ErrPropertyNotSearchable)
+		},
+		fullTextFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			fellBack = true
+			return nil, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search/hybrid", h.Hybrid)
+
+	w := doRequest(r, http.MethodGet, "/search/hybrid?q=test&props=secret:x", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if fellBack {
+		t.Error("unsearchable property fell back to full-text search")
+	}
+}
+
+func TestSearch_Neighborhood(t *testing.T) {
+	t.Parallel()
+
+	var got models.Neighborhood
+	repo := &mockSearchRepo{
+		fullTextFn: func(_ context.Context, _, _ string, filter models.SearchFilter, _ int) ([]models.Node, error) {
+			got = filter.Neighborhood
+			return nil, nil
+		},
+		hybridFn: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			return nil, models.ErrNodeNotFound
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search", h.FullText)
+	r.GET("/search/hybrid", h.Hybrid)
+
+	w := doRequest(r, http.MethodGet, "/search?q=test&root=proj-y", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if want := (models.Neighborhood{Root: "proj-y", Depth: models.DefaultNeighborhoodDepth}); got != want {
+		t.Errorf("neighborhood = %+v, want %+v", got, want)
+	}
+
+	w = doRequest(r, http.MethodGet, "/search?q=test&root=proj-y&depth=3", "")
+	if w.Code != http.StatusOK || got.Depth != 3 {
+		t.Errorf("depth=3: code %d, depth %d", w.Code, got.Depth)
+	}
+
+	for _, depth := range []string{"0", "6", "x"} {
+		w = doRequest(r, http.MethodGet, "/search?q=test&root=proj-y&depth="+depth, "")
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("depth=%s: expected 400, got %d", depth, w.Code)
+		}
+	}
+
+	// An unknown root is a 404, not a full-text fallback.
+	w = doRequest(r, http.MethodGet, "/search/hybrid?q=test&root=missing", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown root: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHybridSearch_Rerank(t *testing.T) {
+	t.Parallel()
+
+	var got []bool
+	repo := &mockSearchRepo{
+		hybridFn: func(ctx context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			got = append(got, service.RerankRequested(ctx))
+			return []models.Node{{ID: "n1"}}, nil
+		},
+	}
+
+	r := newTestRouter()
+	h := api.NewSearchHandler(repo, nil, testLogger())
+	r.GET("/search/hybrid", h.Hybrid)
+
+	for _, path := range []string{"/search/hybrid?q=test", "/search/hybrid?q=test&rerank=true"} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Errorf("rerank requested = %v, want [false true]", got)
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/security"
+)
+
+// SecurityHandler exposes brute-force guard state to administrators.
+type SecurityHandler struct {
+	guard *security.BruteForceGuard
+	log   *logrus.Logger
+}
+
+// NewSecurityHandler creates a SecurityHandler with the given dependencies.
+func NewSecurityHandler(guard *security.BruteForceGuard, log *logrus.Logger) *SecurityHandler {
+	return &SecurityHandler{guard: guard, log: log}
+}
+
+// Blocks handles GET /api/v1/admin/security/blocks.
+// Lists API keys currently locked out after repeated auth failures. Blocks
+// are shared across replicas when the guard is backed by a store.
+func (h *SecurityHandler) Blocks(c *gin.Context) {
+	if getTenantID(c) == "" {
+		return
+	}
+
+	blocks, err := h.guard.Blocks(c.Request.Context())
+	if err != nil {
+		h.log.WithError(err).Error("listing security blocks")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"blocks": blocks})
+}
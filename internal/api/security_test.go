@@ -0,0 +1,42 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/security"
+)
+
+func TestSecurityBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	guard := security.NewBruteForceGuard(ctx, testLogger())
+	for range security.BruteForceMaxAttempts {
+		guard.RecordFailure("bad-key")
+	}
+	guard.RecordFailure("other-key")
+
+	r := newTestRouter()
+	h := api.NewSecurityHandler(guard, testLogger())
+	r.GET("/admin/security/blocks", h.Blocks)
+
+	w := doRequest(r, http.MethodGet, "/admin/security/blocks", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var resp struct {
+		Blocks []models.SecurityBlock `json:"blocks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Blocks) != 1 || resp.Blocks[0].Attempts != security.BruteForceMaxAttempts {
+		t.Errorf("blocks = %+v, want only the locked-out key", resp.Blocks)
+	}
+}
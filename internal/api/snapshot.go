@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// SnapshotHandler serves graph snapshots: named counts and content hashes
+// of a tenant's graph, taken to tag a state such as "pre-experiment" and
+// compare against later.
+type SnapshotHandler struct {
+	svc SnapshotService
+	log *logrus.Logger
+}
+
+// NewSnapshotHandler creates a SnapshotHandler. svc may be nil when
+// snapshots are not configured; the endpoints then answer 503.
+func NewSnapshotHandler(svc SnapshotService, log *logrus.Logger) *SnapshotHandler {
+	return &SnapshotHandler{svc: svc, log: log}
+}
+
+// Create handles POST /api/v1/snapshots.
+func (h *SnapshotHandler) Create(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	var req models.CreateSnapshotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	snap, err := h.svc.CreateSnapshot(c.Request.Context(), tenantID, req)
+	if err != nil {
+		if errors.Is(err, models.ErrSnapshotExists) {
+			respondError(c, http.StatusConflict, "conflict", err.Error())
+
+			return
+		}
+
+		h.log.WithError(err).Error("creating snapshot")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, snap)
+}
+
+// List handles GET /api/v1/snapshots.
+func (h *SnapshotHandler) List(c *gin.Context) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return
+	}
+
+	snapshots, err := h.svc.ListSnapshots(c.Request.Context(), tenantID)
+	if err != nil {
+		h.log.WithError(err).Error("listing snapshots")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	if snapshots == nil {
+		snapshots = []models.Snapshot{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"snapshots": snapshots})
+}
+
+// Get handles GET /api/v1/snapshots/:name.
+func (h *SnapshotHandler) Get(c *gin.Context) {
+	tenantID, name := h.named(c, "name")
+	if tenantID == "" {
+		return
+	}
+
+	snap, err := h.svc.GetSnapshot(c.Request.Context(), tenantID, name)
+	if err != nil {
+		h.respondErr(c, err, "getting snapshot")
+		return
+	}
+
+	c.JSON(http.StatusOK, snap)
+}
+
+// Delete handles DELETE /api/v1/snapshots/:name.
+func (h *SnapshotHandler) Delete(c *gin.Context) {
+	tenantID, name := h.named(c, "name")
+	if tenantID == "" {
+		return
+	}
+
+	if err := h.svc.DeleteSnapshot(c.Request.Context(), tenantID, name); err != nil {
+		h.respondErr(c, err, "deleting snapshot")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Compare handles GET /api/v1/snapshots/:name/compare/:other, reporting the
+// drift from snapshot name to snapshot other.
+func (h *SnapshotHandler) Compare(c *gin.Context) {
+	tenantID, from := h.named(c, "name")
+	if tenantID == "" {
+		return
+	}
+
+	to := c.Param("other")
+	if err := models.ValidateSnapshotName(to); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	comparison, err := h.svc.CompareSnapshots(c.Request.Context(), tenantID, from, to)
+	if err != nil {
+		h.respondErr(c, err, "comparing snapshots")
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// respondErr answers 404 for an unknown snapshot and 500 otherwise.
+func (h *SnapshotHandler) respondErr(c *gin.Context, err error, action string) {
+	if errors.Is(err, models.ErrSnapshotNotFound) {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "snapshot not found")
+
+		return
+	}
+
+	h.log.WithError(err).Error(action)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
+
+// named returns the request's tenant and the snapshot name in path
+// parameter param, or "" after answering when either is missing or
+// snapshots are not available.
+func (h *SnapshotHandler) named(c *gin.Context, param string) (string, string) {
+	tenantID := h.tenant(c)
+	if tenantID == "" {
+		return "", ""
+	}
+
+	name := c.Param(param)
+	if err := models.ValidateSnapshotName(name); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+		return "", ""
+	}
+
+	return tenantID, name
+}
+
+// tenant returns the request's tenant, or "" after answering when it is
+// missing or snapshots are not available.
+func (h *SnapshotHandler) tenant(c *gin.Context) string {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "snapshots not available")
+		return ""
+	}
+
+	return tenantID
+}
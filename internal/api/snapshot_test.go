@@ -0,0 +1,109 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockSnapshotService struct {
+	snapshots map[string]*models.Snapshot
+}
+
+func (m *mockSnapshotService) CreateSnapshot(_ context.Context, _ string, req models.CreateSnapshotRequest) (*models.Snapshot, error) {
+	if _, ok := m.snapshots[req.Name]; ok {
+		return nil, models.ErrSnapshotExists
+	}
+
+	snap := &models.Snapshot{Name: req.Name, NodeCount: int64(len(m.snapshots))}
+	m.snapshots[req.Name] = snap
+
+	return snap, nil
+}
+
+func (m *mockSnapshotService) GetSnapshot(_ context.Context, _, name string) (*models.Snapshot, error) {
+	snap, ok := m.snapshots[name]
+	if !ok {
+		return nil, models.ErrSnapshotNotFound
+	}
+
+	return snap, nil
+}
+
+func (m *mockSnapshotService) ListSnapshots(_ context.Context, _ string) ([]models.Snapshot, error) {
+	var out []models.Snapshot
+	for _, snap := range m.snapshots {
+		out = append(out, *snap)
+	}
+
+	return out, nil
+}
+
+func (m *mockSnapshotService) DeleteSnapshot(_ context.Context, _, name string) error {
+	if _, ok := m.snapshots[name]; !ok {
+		return models.ErrSnapshotNotFound
+	}
+
+	delete(m.snapshots, name)
+
+	return nil
+}
+
+func (m *mockSnapshotService) CompareSnapshots(ctx context.Context, tenantID, from, to string) (*models.SnapshotComparison, error) {
+	a, err := m.GetSnapshot(ctx, tenantID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := m.GetSnapshot(ctx, tenantID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.CompareSnapshots(a, b), nil
+}
+
+func TestSnapshotHandler(t *testing.T) {
+	h := api.NewSnapshotHandler(&mockSnapshotService{snapshots: map[string]*models.Snapshot{}}, testLogger())
+	r := newTestRouter()
+	r.POST("/snapshots", h.Create)
+	r.GET("/snapshots", h.List)
+	r.DELETE("/snapshots/:name", h.Delete)
+	r.GET("/snapshots/:name/compare/:other", h.Compare)
+
+	if w := doRequest(r, http.MethodPost, "/snapshots", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("create without name: status = %d, want 400", w.Code)
+	}
+
+	for _, name := range []string{"pre-experiment", "post-experiment"} {
+		if w := doRequest(r, http.MethodPost, "/snapshots", `{"name":"`+name+`"}`); w.Code != http.StatusCreated {
+			t.Fatalf("create %s: status = %d: %s", name, w.Code, w.Body.String())
+		}
+	}
+
+	if w := doRequest(r, http.MethodPost, "/snapshots", `{"name":"pre-experiment"}`); w.Code != http.StatusConflict {
+		t.Errorf("create duplicate: status = %d, want 409", w.Code)
+	}
+
+	w := doRequest(r, http.MethodGet, "/snapshots/pre-experiment/compare/post-experiment", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("compare: status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var c models.SnapshotComparison
+	if err := json.Unmarshal(w.Body.Bytes(), &c); err != nil || c.NodeDelta != 1 {
+		t.Errorf("compare = %+v, %v; want node delta 1", c, err)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/snapshots/pre-experiment/compare/unknown", ""); w.Code != http.StatusNotFound {
+		t.Errorf("compare with unknown: status = %d, want 404", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodDelete, "/snapshots/pre-experiment", ""); w.Code != http.StatusNoContent {
+		t.Errorf("delete: status = %d, want 204", w.Code)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+// sseHandler streams events as Server-Sent Events for clients that cannot
+// use WebSocket. Like /ws it authenticates itself, with the Authorization
+// header or a ticket, resumes from Last-Event-ID after a reconnect, and
+// takes the subscribe message's filters as query parameters.
+func sseHandler(appCtx context.Context, log *logrus.Logger, hub *ws.Hub, auth *wsAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, apiKey, ok := auth.fromRequest(c)
+		if !ok {
+			return
+		}
+
+		if tenantID == "" {
+			respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "missing authorization header or ticket")
+
+			return
+		}
+
+		opts := ws.SSEOptions{
+			TenantID:  tenantID,
+			APIKey:    apiKey,
+			Validator: auth.lookup,
+			Verbose:   c.Query("verbose") == "true",
+		}
+
+		if !sseFilter(c, log, hub, &opts) || !sseResume(c, &opts) {
+			return
+		}
+
+		sseCtx, sseCancel := context.WithCancel(c.Request.Context())
+		defer sseCancel()
+
+		stop := context.AfterFunc(appCtx, sseCancel)
+		defer stop()
+
+		hub.ServeSSE(sseCtx, c.Writer, opts)
+	}
+}
+
+// sseFilter sets opts.Filter from the types, node_types, node_id_prefixes,
+// and watcher query parameters, resolving the watch list. It responds and
+// returns false when the filter is invalid or the watch list cannot be read.
+func sseFilter(c *gin.Context, log *logrus.Logger, hub *ws.Hub, opts *ws.SSEOptions) bool {
+	opts.Filter = ws.EventFilter{
+		Types:          splitQueryList(c, "types"),
+		NodeTypes:      splitQueryList(c, "node_types"),
+		NodeIDPrefixes: splitQueryList(c, "node_id_prefixes"),
+		Watcher:        c.Query("watcher"),
+	}
+
+	if err := opts.Filter.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return false
+	}
+
+	if err := hub.ResolveWatches(c.Request.Context(), opts.TenantID, &opts.Filter); err != nil {
+		if errors.Is(err, ws.ErrWatchesUnavailable) {
+			respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "watch lists not available")
+
+			return false
+		}
+
+		log.WithError(err).Error("resolving watch list")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return false
+	}
+
+	return true
+}
+
+// sseResume sets opts to replay events after the client's last event id.
+// It responds 400 and returns false when the id is malformed.
+func sseResume(c *gin.Context, opts *ws.SSEOptions) bool {
+	// EventSource resends the last id it saw as a header; the query
+	// parameter lets a fresh EventSource resume too.
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	if lastEventID == "" {
+		return true
+	}
+
+	id, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid last event id")
+
+		return false
+	}
+
+	opts.Replay = true
+	opts.LastEventID = id
+
+	return true
+}
+
+// splitQueryList reads a comma-separated query parameter, which may also be
+// repeated, dropping empty values.
+func splitQueryList(c *gin.Context, key string) []string {
+	var values []string
+
+	for _, raw := range c.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+
+	return values
+}
@@ -10,6 +10,7 @@
 
 	"github.com/persistorai/persistor/internal/dbpool"
 	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // StatsHandler serves the knowledge graph statistics endpoint.
@@ -36,29 +37,13 @@ type statsResponse struct {
 // GetStats handles GET /api/v1/stats — returns aggregate KG statistics.
 func (h *StatsHandler) GetStats(c *gin.Context) {
 	ctx := c.Request.Context()
-	tenantID := c.GetString("tenant_id")
 
-	if _, err := uuid.Parse(tenantID); err != nil {
-		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid tenant id")
-		return
-	}
-
-	// Start a read-only transaction with tenant RLS.
-	tx, err := h.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
-	if err != nil {
-		h.log.WithError(err).Error("stats: begin tx")
-		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+	tx, ok := h.beginTenantTx(c)
+	if !ok {
 		return
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
 
-	// Set tenant context for RLS.
-	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
-		h.log.WithError(err).Error("stats: set tenant")
-		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
-		return
-	}
-
 	var resp statsResponse
 
 	// Single consolidated query for all tenant-scoped stats.
@@ -90,3 +75,123 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// statsReportTables maps a report kind to its table and its groupings to
+// columns. Both are fixed identifiers, never user input.
+var statsReportTables = map[string]struct {
+	table   string
+	columns map[string]string
+}{
+	models.StatsReportNodes: {table: "kg_nodes", columns: map[string]string{"type": "type"}},
+	models.StatsReportEdges: {table: "kg_edges", columns: map[string]string{"relation": "relation"}},
+}
+
+// GetReport handles GET /api/v1/stats/report?type=nodes&group_by=type —
+// returns per-group counts, salience spread rounded to 4 decimal places, and
+// monthly growth.
+func (h *StatsHandler) GetReport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	req := models.StatsReportRequest{Kind: c.Query("type"), GroupBy: c.Query("group_by")}
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+		return
+	}
+
+	source := statsReportTables[req.Kind]
+	table, column := source.table, source.columns[req.GroupBy]
+
+	tx, ok := h.beginTenantTx(c)
+	if !ok {
+		return
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	report := models.StatsReport{
+		Kind:    req.Kind,
+		GroupBy: req.GroupBy,
+		Groups:  []models.StatsReportGroup{},
+		Growth:  []models.StatsGrowth{},
+	}
+
+	rows, err := tx.Query(ctx, `SELECT `+column+`, COUNT(*),
+			ROUND(MIN(salience_score)::numeric, 4)::double precision,
+			ROUND(AVG(salience_score)::numeric, 4)::double precision,
+			ROUND(MAX(salience_score)::numeric, 4)::double precision,
+			MIN(created_at), MAX(created_at)
+		FROM `+table+`
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`)
+	if err != nil {
+		h.log.WithError(err).Error("stats report: groups query")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	report.Groups, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.StatsReportGroup, error) {
+		var g models.StatsReportGroup
+		err := row.Scan(&g.Key, &g.Count, &g.SalienceMin, &g.SalienceAvg, &g.SalienceMax, &g.FirstCreated, &g.LastCreated)
+		return g, err
+	})
+	if err != nil {
+		h.log.WithError(err).Error("stats report: scanning groups")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	rows, err = tx.Query(ctx, `SELECT to_char(date_trunc('month', created_at AT TIME ZONE 'UTC'), 'YYYY-MM'),
+			`+column+`, COUNT(*)
+		FROM `+table+`
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		GROUP BY 1, 2
+		ORDER BY 1, 2`)
+	if err != nil {
+		h.log.WithError(err).Error("stats report: growth query")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	report.Growth, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.StatsGrowth, error) {
+		var g models.StatsGrowth
+		err := row.Scan(&g.Month, &g.Key, &g.Added)
+		return g, err
+	})
+	if err != nil {
+		h.log.WithError(err).Error("stats report: scanning growth")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// beginTenantTx starts a read-only transaction scoped to the request's
+// tenant. On failure it responds and returns false.
+func (h *StatsHandler) beginTenantTx(c *gin.Context) (pgx.Tx, bool) {
+	ctx := c.Request.Context()
+	tenantID := c.GetString("tenant_id")
+
+	if _, err := uuid.Parse(tenantID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid tenant id")
+		return nil, false
+	}
+
+	// Start a read-only transaction with tenant RLS.
+	tx, err := h.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		h.log.WithError(err).Error("stats: begin tx")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return nil, false
+	}
+
+	// Set tenant context for RLS.
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		tx.Rollback(ctx) //nolint:errcheck // best-effort rollback on failure.
+		h.log.WithError(err).Error("stats: set tenant")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		return nil, false
+	}
+
+	return tx, true
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TagHandler serves embedding-based tag suggestion endpoints.
+type TagHandler struct {
+	svc TagService
+	log *logrus.Logger
+}
+
+// NewTagHandler creates a TagHandler with the given dependencies.
+func NewTagHandler(svc TagService, log *logrus.Logger) *TagHandler {
+	return &TagHandler{svc: svc, log: log}
+}
+
+// Suggest handles POST /api/v1/nodes/:id/suggest-tags.
+// Query params: limit (default 5, max 50).
+func (h *TagHandler) Suggest(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	limit := models.DefaultTagSuggestionLimit
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > models.MaxTagSuggestionLimit {
+			respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest,
+				"limit must be between 1 and "+strconv.Itoa(models.MaxTagSuggestionLimit))
+
+			return
+		}
+
+		limit = n
+	}
+
+	result, err := h.svc.SuggestTags(c.Request.Context(), tenantID, nodeID, limit)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNodeNotFound):
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+		case errors.Is(err, models.ErrNodeNotEmbedded):
+			respondError(c, http.StatusConflict, "conflict", err.Error())
+		default:
+			h.log.WithError(err).Error("suggesting tags")
+			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RebuildCentroids handles POST /api/v1/admin/tags/centroids/rebuild.
+// Suggestions rebuild stale centroids on their own; this forces it, e.g.
+// after a bulk retag.
+func (h *TagHandler) RebuildCentroids(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	result, err := h.svc.RebuildTagCentroids(c.Request.Context(), tenantID)
+	if err != nil {
+		h.log.WithError(err).Error("rebuilding tag centroids")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,67 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockTagService struct {
+	api.TagService
+	limit int
+}
+
+func (m *mockTagService) SuggestTags(_ context.Context, _, nodeID string, limit int) (*models.TagSuggestions, error) {
+	switch nodeID {
+	case "missing":
+		return nil, models.ErrNodeNotFound
+	case "fresh":
+		return nil, models.ErrNodeNotEmbedded
+	}
+
+	m.limit = limit
+	return &models.TagSuggestions{NodeID: nodeID, Suggestions: []models.TagSuggestion{{Tag: "infra", Score: 0.8}}}, nil
+}
+
+func TestTagSuggest(t *testing.T) {
+	svc := &mockTagService{}
+	r := newTestRouter()
+	h := api.NewTagHandler(svc, testLogger())
+	r.POST("/nodes/:id/suggest-tags", h.Suggest)
+
+	w := doRequest(r, http.MethodPost, "/nodes/n1/suggest-tags", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var result models.TagSuggestions
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(result.Suggestions) != 1 || svc.limit != models.DefaultTagSuggestionLimit {
+		t.Errorf("result = %+v, limit = %d", result, svc.limit)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"custom limit", "/nodes/n1/suggest-tags?limit=10", http.StatusOK},
+		{"limit too large", "/nodes/n1/suggest-tags?limit=51", http.StatusBadRequest},
+		{"limit not a number", "/nodes/n1/suggest-tags?limit=x", http.StatusBadRequest},
+		{"unknown node", "/nodes/missing/suggest-tags", http.StatusNotFound},
+		{"node not embedded yet", "/nodes/fresh/suggest-tags", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		w := doRequest(r, http.MethodPost, tt.path, "")
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.wantStatus, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TenantHandler serves operator tenant management endpoints.
+type TenantHandler struct {
+	svc     TenantService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewTenantHandler creates a TenantHandler with the given dependencies.
+func NewTenantHandler(svc TenantService, auditor Auditor, log *logrus.Logger) *TenantHandler {
+	return &TenantHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// List handles GET /api/v1/admin/tenants.
+func (h *TenantHandler) List(c *gin.Context) {
+	if getTenantID(c) == "" {
+		return
+	}
+
+	tenants, err := h.svc.ListTenants(c.Request.Context())
+	if err != nil {
+		h.respondTenantError(c, err, "listing tenants")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tenants": tenants})
+}
+
+// Create handles POST /api/v1/admin/tenants.
+// Returns the new tenant's primary API key once.
+func (h *TenantHandler) Create(c *gin.Context) {
+	operatorID := getTenantID(c)
+	if operatorID == "" {
+		return
+	}
+
+	var req models.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	created, err := h.svc.CreateTenant(c.Request.Context(), req)
+	if err != nil {
+		h.respondTenantError(c, err, "creating tenant")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.create", created.ID, map[string]any{"plan": created.Plan, "scope": req.Scope})
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// Get handles GET /api/v1/admin/tenants/:id.
+func (h *TenantHandler) Get(c *gin.Context) {
+	_, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.svc.GetTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondTenantError(c, err, "getting tenant")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tenant)
+}
+
+// Suspend handles POST /api/v1/admin/tenants/:id/suspend.
+// The tenant's keys are rejected within the auth cache TTL.
+func (h *TenantHandler) Suspend(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.svc.SuspendTenant(c.Request.Context(), operatorID, tenantID)
+	if err != nil {
+		h.respondTenantError(c, err, "suspending tenant")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.suspend", tenantID, nil)
+
+	c.JSON(http.StatusOK, tenant)
+}
+
+// Resume handles POST /api/v1/admin/tenants/:id/resume.
+func (h *TenantHandler) Resume(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	tenant, err := h.svc.ResumeTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondTenantError(c, err, "resuming tenant")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.resume", tenantID, nil)
+
+	c.JSON(http.StatusOK, tenant)
+}
+
+// Delete handles DELETE /api/v1/admin/tenants/:id.
+// Only suspended tenants can be deleted; all of their data is purged.
+func (h *TenantHandler) Delete(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.svc.DeleteTenant(c.Request.Context(), operatorID, tenantID)
+	if err != nil {
+		h.respondTenantError(c, err, "deleting tenant")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.delete", tenantID, map[string]any{"deleted_rows": result.DeletedRows})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// tenantParams extracts the operator's tenant ID and the managed tenant ID.
+// A malformed tenant ID cannot name a tenant, so it is reported as not found.
+func tenantParams(c *gin.Context) (operatorID, tenantID string, ok bool) {
+	operatorID = getTenantID(c)
+	if operatorID == "" {
+		return "", "", false
+	}
+
+	tenantID = c.Param("id")
+	if _, err := uuid.Parse(tenantID); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return "", "", false
+	}
+
+	return operatorID, tenantID, true
+}
+
+// audit logs an operator action and records it in the operator's audit log.
+func (h *TenantHandler) audit(c *gin.Context, operatorID, action, tenantID string, detail map[string]any) {
+	h.log.WithFields(logrus.Fields{
+		"action":           action,
+		"tenant_id":        operatorID,
+		"target_tenant_id": tenantID,
+	}).Info("audit")
+
+	if h.auditor == nil {
+		return
+	}
+
+	if err := h.auditor.RecordAudit(c.Request.Context(), operatorID, action, "tenant", tenantID, "", detail); err != nil {
+		h.log.WithError(err).Warn("recording tenant audit entry")
+	}
+}
+
+func (h *TenantHandler) respondTenantError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, models.ErrTenantNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, "tenant not found")
+
+		return
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, models.ErrAPIKeyNotFound.Error())
+
+		return
+	case errors.Is(err, models.ErrTenantNotSuspended), errors.Is(err, models.ErrOwnTenant),
+		errors.Is(err, models.ErrImpersonateOwnTenant):
+		respondError(c, http.StatusConflict, "conflict", err.Error())
+
+		return
+	}
+
+	h.log.WithError(err).Error(msg)
+	respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// RotateKey handles POST /api/v1/admin/tenants/:id/rotate-key.
+// Returns the tenant's new primary key once; the previous key stays valid
+// for grace_hours.
+func (h *TenantHandler) RotateKey(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.RotateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	rotation, err := h.svc.RotateTenantKey(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondTenantError(c, err, "rotating tenant key")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.rotate_key", tenantID, map[string]any{"grace_hours": int(req.Grace().Hours())})
+
+	c.JSON(http.StatusOK, rotation)
+}
+
+// ListKeys handles GET /api/v1/admin/tenants/:id/keys.
+func (h *TenantHandler) ListKeys(c *gin.Context) {
+	_, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.svc.ListTenantKeys(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondTenantError(c, err, "listing tenant keys")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+// CreateKey handles POST /api/v1/admin/tenants/:id/keys.
+// Returns the new key once; it cannot be retrieved again.
+func (h *TenantHandler) CreateKey(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	created, err := h.svc.CreateTenantKey(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondTenantError(c, err, "creating tenant key")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.create_key", tenantID, map[string]any{
+		"key_id": created.ID, "name": created.Name, "scope": created.Scope,
+	})
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// RevokeKey handles DELETE /api/v1/admin/tenants/:id/keys/:key_id.
+func (h *TenantHandler) RevokeKey(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	keyID := c.Param("key_id")
+	if _, err := uuid.Parse(keyID); err != nil {
+		respondError(c, http.StatusNotFound, ErrCodeNotFound, models.ErrAPIKeyNotFound.Error())
+
+		return
+	}
+
+	key, err := h.svc.RevokeTenantKey(c.Request.Context(), tenantID, keyID)
+	if err != nil {
+		h.respondTenantError(c, err, "revoking tenant key")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.revoke_key", tenantID, map[string]any{"key_id": keyID})
+
+	c.JSON(http.StatusOK, key)
+}
+
+// Impersonate handles POST /api/v1/admin/tenants/:id/impersonate.
+// Returns a short-lived key for the tenant once. Issuing it is audited in
+// both the operator's and the tenant's audit log, and so is every request
+// made with it.
+func (h *TenantHandler) Impersonate(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.ImpersonateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	key, err := h.svc.ImpersonateTenant(c.Request.Context(), operatorID, tenantID, req)
+	if err != nil {
+		h.respondTenantError(c, err, "impersonating tenant")
+
+		return
+	}
+
+	detail := map[string]any{
+		"key_id": key.ID, "scope": key.Scope, "reason": req.Reason, "expires_at": key.ExpiresAt,
+	}
+	h.audit(c, operatorID, "tenants.impersonate", tenantID, detail)
+
+	if h.auditor != nil {
+		err := h.auditor.RecordAudit(c.Request.Context(), tenantID, "impersonation.start", "api_key", key.ID, operatorID, detail)
+		if err != nil {
+			h.log.WithError(err).Warn("recording impersonation audit entry")
+		}
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
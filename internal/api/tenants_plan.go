@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Update handles PATCH /api/v1/admin/tenants/:id.
+func (h *TenantHandler) Update(c *gin.Context) {
+	operatorID, tenantID, ok := tenantParams(c)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenant, err := h.svc.UpdateTenant(c.Request.Context(), tenantID, req)
+	if err != nil {
+		h.respondTenantError(c, err, "updating tenant")
+
+		return
+	}
+
+	h.audit(c, operatorID, "tenants.update", tenantID, map[string]any{"name": tenant.Name, "plan": tenant.Plan})
+
+	c.JSON(http.StatusOK, tenant)
+}
@@ -0,0 +1,267 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	testOtherTenantID   = "7c1e9a2b-4d3f-4b6a-8e5c-2f1d0a9b8c7e"
+	testActiveTenantID  = "2a4c6e8f-1b3d-4f5a-9c7e-0d2f4a6c8e1b"
+	testMissingTenantID = "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a"
+)
+
+type mockTenantService struct{}
+
+func (m *mockTenantService) CreateTenant(_ context.Context, req models.CreateTenantRequest) (*models.CreatedTenant, error) {
+	return &models.CreatedTenant{
+		APIKey: "tenant-key",
+		Tenant: models.Tenant{ID: testOtherTenantID, Name: req.Name, Plan: req.Plan},
+	}, nil
+}
+
+func (m *mockTenantService) ListTenants(_ context.Context) ([]models.Tenant, error) {
+	return []models.Tenant{{ID: testTenantID, Operator: true}, {ID: testOtherTenantID}}, nil
+}
+
+func (m *mockTenantService) GetTenant(_ context.Context, tenantID string) (*models.Tenant, error) {
+	if tenantID == testMissingTenantID {
+		return nil, models.ErrTenantNotFound
+	}
+	return &models.Tenant{ID: tenantID}, nil
+}
+
+func (m *mockTenantService) UpdateTenant(_ context.Context, tenantID string, req models.UpdateTenantRequest) (*models.Tenant, error) {
+	return &models.Tenant{ID: tenantID, Plan: *req.Plan}, nil
+}
+
+func (m *mockTenantService) RotateTenantKey(_ context.Context, _ string, _ models.RotateAPIKeyRequest) (*models.APIKeyRotation, error) {
+	return &models.APIKeyRotation{APIKey: "rotated-tenant-key"}, nil
+}
+
+func (m *mockTenantService) SuspendTenant(_ context.Context, operatorID, tenantID string) (*models.Tenant, error) {
+	if operatorID == tenantID {
+		return nil, models.ErrOwnTenant
+	}
+	now := time.Now()
+	return &models.Tenant{ID: tenantID, SuspendedAt: &now}, nil
+}
+
+func (m *mockTenantService) ResumeTenant(_ context.Context, tenantID string) (*models.Tenant, error) {
+	return &models.Tenant{ID: tenantID}, nil
+}
+
+func (m *mockTenantService) DeleteTenant(_ context.Context, operatorID, tenantID string) (*models.TenantPurgeResult, error) {
+	switch tenantID {
+	case operatorID:
+		return nil, models.ErrOwnTenant
+	case testActiveTenantID:
+		return nil, models.ErrTenantNotSuspended
+	}
+	return &models.TenantPurgeResult{TenantID: tenantID, DeletedRows: 42}, nil
+}
+
+func (m *mockTenantService) ListTenantKeys(_ context.Context, tenantID string) ([]models.ManagedAPIKey, error) {
+	if tenantID == testMissingTenantID {
+		return nil, models.ErrTenantNotFound
+	}
+	return []models.ManagedAPIKey{{ID: testManagedKeyID, Name: "ci", Scope: "read"}}, nil
+}
+
+func (m *mockTenantService) CreateTenantKey(
+	_ context.Context, _ string, req models.CreateAPIKeyRequest,
+) (*models.CreatedAPIKey, error) {
+	return &models.CreatedAPIKey{
+		APIKey:        "tenant-named-key",
+		ManagedAPIKey: models.ManagedAPIKey{ID: testManagedKeyID, Name: req.Name, Scope: req.Scope},
+	}, nil
+}
+
+func (m *mockTenantService) RevokeTenantKey(_ context.Context, _, keyID string) (*models.ManagedAPIKey, error) {
+	if keyID != testManagedKeyID {
+		return nil, models.ErrAPIKeyNotFound
+	}
+	now := time.Now()
+	return &models.ManagedAPIKey{ID: keyID, RevokedAt: &now}, nil
+}
+
+func (m *mockTenantService) ImpersonateTenant(
+	_ context.Context, operatorID, tenantID string, req models.ImpersonateTenantRequest,
+) (*models.ImpersonationKey, error) {
+	if operatorID == tenantID {
+		return nil, models.ErrImpersonateOwnTenant
+	}
+	if tenantID == testMissingTenantID {
+		return nil, models.ErrTenantNotFound
+	}
+	expires := time.Now().Add(req.TTL())
+	return &models.ImpersonationKey{
+		CreatedAPIKey: models.CreatedAPIKey{
+			APIKey: "impersonation-key",
+			ManagedAPIKey: models.ManagedAPIKey{
+				ID: testManagedKeyID, Name: models.ImpersonationKeyName, Scope: req.Scope,
+				ExpiresAt: &expires, ImpersonatedBy: &operatorID,
+			},
+		},
+		TenantID: tenantID,
+		Reason:   req.Reason,
+	}, nil
+}
+
+func TestTenantImpersonate(t *testing.T) {
+	auditor := &recordingAuditor{}
+	r := newTestRouter()
+	h := api.NewTenantHandler(&mockTenantService{}, auditor, testLogger())
+	r.POST("/admin/tenants/:id/impersonate", h.Impersonate)
+
+	w := doRequest(r, http.MethodPost, "/admin/tenants/"+testOtherTenantID+"/impersonate", `{"reason":"ticket 42"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("impersonate: status = %d: %s", w.Code, w.Body.String())
+	}
+	var key models.ImpersonationKey
+	if err := json.Unmarshal(w.Body.Bytes(), &key); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if key.APIKey != "impersonation-key" || key.Scope != models.APIKeyScopeRead || key.TenantID != testOtherTenantID {
+		t.Errorf("key = %+v, want a read key for %s", key, testOtherTenantID)
+	}
+
+	want := []auditRecord{
+		{tenantID: testTenantID, action: "tenants.impersonate", actor: ""},
+		{tenantID: testOtherTenantID, action: "impersonation.start", actor: testTenantID},
+	}
+	if len(auditor.records) != len(want) {
+		t.Fatalf("audit records = %+v, want %+v", auditor.records, want)
+	}
+	for i, rec := range auditor.records {
+		if rec.tenantID != want[i].tenantID || rec.action != want[i].action || rec.actor != want[i].actor {
+			t.Errorf("audit record %d = %+v, want %+v", i, rec, want[i])
+		}
+		if rec.reason != "ticket 42" {
+			t.Errorf("audit record %d reason = %q, want ticket 42", i, rec.reason)
+		}
+	}
+
+	tests := []struct {
+		name, path, body string
+		wantStatus       int
+	}{
+		{"missing reason", testOtherTenantID, `{}`, http.StatusBadRequest},
+		{"admin scope", testOtherTenantID, `{"reason":"x","scope":"admin"}`, http.StatusBadRequest},
+		{"ttl too long", testOtherTenantID, `{"reason":"x","ttl_minutes":61}`, http.StatusBadRequest},
+		{"own tenant", testTenantID, `{"reason":"x"}`, http.StatusConflict},
+		{"missing tenant", testMissingTenantID, `{"reason":"x"}`, http.StatusNotFound},
+		{"malformed id", "acme", `{"reason":"x"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, http.MethodPost, "/admin/tenants/"+tt.path+"/impersonate", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+type auditRecord struct {
+	tenantID, action, actor, reason string
+}
+
+// recordingAuditor keeps every audit entry, in order.
+type recordingAuditor struct {
+	records []auditRecord
+}
+
+func (m *recordingAuditor) RecordAudit(_ context.Context, tenantID, action, _, _, actor string, detail map[string]any) error {
+	reason, _ := detail["reason"].(string)
+	m.records = append(m.records, auditRecord{tenantID: tenantID, action: action, actor: actor, reason: reason})
+
+	return nil
+}
+
+func TestTenantManagement(t *testing.T) {
+	auditor := &mockAuditor{}
+	r := newTestRouter()
+	h := api.NewTenantHandler(&mockTenantService{}, auditor, testLogger())
+	r.GET("/admin/tenants", h.List)
+	r.POST("/admin/tenants", h.Create)
+	r.GET("/admin/tenants/:id", h.Get)
+	r.PATCH("/admin/tenants/:id", h.Update)
+	r.DELETE("/admin/tenants/:id", h.Delete)
+	r.POST("/admin/tenants/:id/rotate-key", h.RotateKey)
+	r.POST("/admin/tenants/:id/suspend", h.Suspend)
+	r.POST("/admin/tenants/:id/resume", h.Resume)
+	r.GET("/admin/tenants/:id/keys", h.ListKeys)
+	r.POST("/admin/tenants/:id/keys", h.CreateKey)
+	r.DELETE("/admin/tenants/:id/keys/:key_id", h.RevokeKey)
+
+	w := doRequest(r, http.MethodPost, "/admin/tenants", `{"name":"acme"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d: %s", w.Code, w.Body.String())
+	}
+	var created models.CreatedTenant
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if created.APIKey != "tenant-key" || created.Plan != models.DefaultTenantPlan {
+		t.Errorf("created = %+v, want the key with the default plan", created)
+	}
+	if auditor.action != "tenants.create" {
+		t.Errorf("audit action = %q, want tenants.create", auditor.action)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/admin/tenants/"+testOtherTenantID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d: %s", w.Code, w.Body.String())
+	}
+	var purged models.TenantPurgeResult
+	if err := json.Unmarshal(w.Body.Bytes(), &purged); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if purged.DeletedRows != 42 || auditor.action != "tenants.delete" {
+		t.Errorf("purge = %+v, audit action = %q", purged, auditor.action)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path, body string
+		wantStatus int
+	}{
+		{"missing name", http.MethodPost, "/admin/tenants", `{}`, http.StatusBadRequest},
+		{"invalid scope", http.MethodPost, "/admin/tenants", `{"name":"acme","scope":"root"}`, http.StatusBadRequest},
+		{"list", http.MethodGet, "/admin/tenants", "", http.StatusOK},
+		{"get", http.MethodGet, "/admin/tenants/" + testOtherTenantID, "", http.StatusOK},
+		{"get missing", http.MethodGet, "/admin/tenants/" + testMissingTenantID, "", http.StatusNotFound},
+		{"malformed id", http.MethodGet, "/admin/tenants/acme", "", http.StatusNotFound},
+		{"update", http.MethodPatch, "/admin/tenants/" + testOtherTenantID, `{"plan":"pro"}`, http.StatusOK},
+		{"empty update", http.MethodPatch, "/admin/tenants/" + testOtherTenantID, `{}`, http.StatusBadRequest},
+		{"rotate key", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/rotate-key", "", http.StatusOK},
+		{"invalid grace", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/rotate-key", `{"grace_hours":-1}`, http.StatusBadRequest},
+		{"suspend", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/suspend", "", http.StatusOK},
+		{"suspend self", http.MethodPost, "/admin/tenants/" + testTenantID + "/suspend", "", http.StatusConflict},
+		{"resume", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/resume", "", http.StatusOK},
+		{"delete active", http.MethodDelete, "/admin/tenants/" + testActiveTenantID, "", http.StatusConflict},
+		{"delete self", http.MethodDelete, "/admin/tenants/" + testTenantID, "", http.StatusConflict},
+		{"list keys", http.MethodGet, "/admin/tenants/" + testOtherTenantID + "/keys", "", http.StatusOK},
+		{"list keys of missing tenant", http.MethodGet, "/admin/tenants/" + testMissingTenantID + "/keys", "", http.StatusNotFound},
+		{"create key", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/keys", `{"name":"ci","scope":"read"}`, http.StatusCreated},
+		{"create key without name", http.MethodPost, "/admin/tenants/" + testOtherTenantID + "/keys", `{}`, http.StatusBadRequest},
+		{"revoke key", http.MethodDelete, "/admin/tenants/" + testOtherTenantID + "/keys/" + testManagedKeyID, "", http.StatusOK},
+		{"revoke missing key", http.MethodDelete, "/admin/tenants/" + testOtherTenantID + "/keys/" + testMissingKeyID, "", http.StatusNotFound},
+		{"revoke malformed key", http.MethodDelete, "/admin/tenants/" + testOtherTenantID + "/keys/ci", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := doRequest(r, tt.method, tt.path, tt.body); w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// UsageHandler serves the tenant resource usage endpoint.
+type UsageHandler struct {
+	svc UsageService
+	log *logrus.Logger
+}
+
+// NewUsageHandler creates a UsageHandler with the given dependencies.
+func NewUsageHandler(svc UsageService, log *logrus.Logger) *UsageHandler {
+	return &UsageHandler{svc: svc, log: log}
+}
+
+// Get handles GET /api/v1/usage.
+func (h *UsageHandler) Get(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	usage, err := h.svc.GetUsage(c.Request.Context(), tenantID)
+	if err != nil {
+		h.log.WithError(err).Error("getting usage")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// ReportLLM handles POST /api/v1/usage/llm. Clients that call an LLM for the
+// tenant, such as ingest enrichment, report the requests and tokens here.
+// A 403 quota_exceeded reply means the tenant's monthly LLM token budget is
+// used up; the reported usage is recorded either way.
+func (h *UsageHandler) ReportLLM(c *gin.Context) {
+	var req models.ReportModelUsageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	err := h.svc.ReportLLMUsage(c.Request.Context(), tenantID, req)
+	if respondQuotaExceeded(c, err) {
+		return
+	}
+
+	if err != nil {
+		h.log.WithError(err).Error("reporting llm usage")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockUsageService struct {
+	reported []models.ReportModelUsageRequest
+}
+
+func (m *mockUsageService) GetUsage(_ context.Context, _ string) (*models.TenantUsage, error) {
+	limit := int64(100)
+	return &models.TenantUsage{Nodes: 90, Edges: 12, StorageBytes: 4096, Quota: models.TenantQuota{MaxNodes: &limit}}, nil
+}
+
+// ReportLLMUsage records req and reports the budget used up from the second
+// report on.
+func (m *mockUsageService) ReportLLMUsage(_ context.Context, _ string, req models.ReportModelUsageRequest) error {
+	m.reported = append(m.reported, req)
+	if len(m.reported) > 1 {
+		return models.TokenBudgetExceeded(models.ModelUsageLLM, 1000)
+	}
+
+	return nil
+}
+
+// quotaBulkService rejects every upsert as over quota.
+type quotaBulkService struct{}
+
+func (quotaBulkService) BulkUpsertNodes(_ context.Context, _ string, _ []models.CreateNodeRequest) ([]models.Node, error) {
+	return nil, fmt.Errorf("%w: tenant is limited to 100 nodes", models.ErrQuotaExceeded)
+}
+
+func (quotaBulkService) BulkUpsertEdges(_ context.Context, _ string, _ []models.CreateEdgeRequest) ([]models.Edge, error) {
+	return nil, fmt.Errorf("%w: tenant is limited to 10 edges", models.ErrQuotaExceeded)
+}
+
+func TestUsage(t *testing.T) {
+	r := newTestRouter()
+	r.GET("/usage", api.NewUsageHandler(&mockUsageService{}, testLogger()).Get)
+
+	w := doRequest(r, http.MethodGet, "/usage", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var usage models.TenantUsage
+	if err := json.Unmarshal(w.Body.Bytes(), &usage); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if usage.Nodes != 90 || usage.Quota.MaxNodes == nil || *usage.Quota.MaxNodes != 100 || usage.Quota.MaxEdges != nil {
+		t.Errorf("usage = %+v", usage)
+	}
+}
+
+func TestUsageReportLLM(t *testing.T) {
+	svc := &mockUsageService{}
+	r := newTestRouter()
+	r.POST("/usage/llm", api.NewUsageHandler(svc, testLogger()).ReportLLM)
+
+	if w := doRequest(r, http.MethodPost, "/usage/llm", `{"requests":-1}`); w.Code != http.StatusBadRequest {
+		t.Errorf("negative requests: status = %d, want 400", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodPost, "/usage/llm", `{"requests":2,"tokens":900}`); w.Code != http.StatusNoContent {
+		t.Errorf("first report: status = %d, want 204: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRequest(r, http.MethodPost, "/usage/llm", `{"requests":1,"tokens":300}`); w.Code != http.StatusForbidden {
+		t.Errorf("report over budget: status = %d, want 403: %s", w.Code, w.Body.String())
+	}
+
+	if len(svc.reported) != 2 || svc.reported[0].Tokens != 900 {
+		t.Errorf("reported = %+v", svc.reported)
+	}
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	nodes := &mockNodeRepo{
+		createFn: func(_ context.Context, _ string, _ models.CreateNodeRequest) (*models.Node, error) {
+			return nil, fmt.Errorf("%w: tenant is limited to 100 nodes", models.ErrQuotaExceeded)
+		},
+	}
+	r := newTestRouter()
+	r.POST("/nodes", api.NewNodeHandler(nodes, testLogger()).Create)
+	bulk := api.NewBulkHandler(quotaBulkService{}, testLogger())
+	r.POST("/bulk/nodes", bulk.BulkNodes)
+	r.POST("/bulk/edges", bulk.BulkEdges)
+
+	tests := []struct{ path, body string }{
+		{"/nodes", `{"id":"n1","type":"person","label":"Alice"}`},
+		{"/bulk/nodes", `[{"id":"n1","type":"person","label":"Alice"}]`},
+		{"/bulk/edges", `[{"source":"a","target":"b","relation":"knows"}]`},
+	}
+	for _, tt := range tests {
+		w := doRequest(r, http.MethodPost, tt.path, tt.body)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: status = %d, want 403: %s", tt.path, w.Code, w.Body.String())
+			continue
+		}
+
+		var body struct{ Code, Message string }
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Code != api.ErrCodeQuotaExceeded {
+			t.Errorf("%s: body = %s", tt.path, w.Body.String())
+		}
+	}
+}
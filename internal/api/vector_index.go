@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// VectorIndexHandler serves the operator endpoints for the node embedding
+// index, which is shared by every tenant.
+type VectorIndexHandler struct {
+	svc     VectorIndexService
+	auditor Auditor
+	log     *logrus.Logger
+}
+
+// NewVectorIndexHandler creates a VectorIndexHandler. svc may be nil when
+// index management is not configured; the endpoints then answer 503.
+func NewVectorIndexHandler(svc VectorIndexService, auditor Auditor, log *logrus.Logger) *VectorIndexHandler {
+	return &VectorIndexHandler{svc: svc, auditor: auditor, log: log}
+}
+
+// Status handles GET /api/v1/admin/vector-index.
+func (h *VectorIndexHandler) Status(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	status, err := h.svc.VectorIndexStatus(c.Request.Context())
+	if err != nil {
+		h.log.WithError(err).Error("reading vector index status")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Rebuild handles POST /api/v1/admin/vector-index.
+// Starts building a new index with the requested parameters, sized from the
+// number of embedded nodes where omitted, and answers 202 with them.
+func (h *VectorIndexHandler) Rebuild(c *gin.Context) {
+	if !h.available(c) {
+		return
+	}
+
+	var req models.VectorIndexRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, "invalid request body")
+
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+
+		return
+	}
+
+	params, err := h.svc.RebuildVectorIndex(c.Request.Context(), req)
+	if errors.Is(err, models.ErrVectorIndexBuilding) {
+		respondError(c, http.StatusConflict, "conflict", err.Error())
+
+		return
+	}
+
+	if err != nil {
+		h.log.WithError(err).Error("starting vector index build")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	tenantID := getTenantID(c)
+	detail := map[string]any{
+		"method":          params.Method,
+		"m":               params.M,
+		"ef_construction": params.EfConstruction,
+		"lists":           params.Lists,
+	}
+
+	h.log.WithFields(logrus.Fields(detail)).WithFields(logrus.Fields{
+		"action":    "admin.vector_index_rebuild",
+		"tenant_id": tenantID,
+	}).Info("audit")
+
+	if h.auditor != nil {
+		if err := h.auditor.RecordAudit(c.Request.Context(), tenantID, "admin.vector_index_rebuild", "index", "", "", detail); err != nil {
+			h.log.WithError(err).Warn("recording vector index audit entry")
+		}
+	}
+
+	c.JSON(http.StatusAccepted, params)
+}
+
+// available reports whether the request has a tenant and the service is
+// configured, answering 503 when it is not.
+func (h *VectorIndexHandler) available(c *gin.Context) bool {
+	if getTenantID(c) == "" {
+		return false
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "vector index management not available")
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockVectorIndexService struct {
+	building bool
+	got      *models.VectorIndexRequest
+}
+
+func (m *mockVectorIndexService) VectorIndexStatus(context.Context) (*models.VectorIndexStatus, error) {
+	return &models.VectorIndexStatus{
+		VectorIndexStats: models.VectorIndexStats{Exists: true, Valid: true, EmbeddedNodes: 50000},
+		Warnings:         []string{"kg_nodes has had 3 sequential scans"},
+	}, nil
+}
+
+func (m *mockVectorIndexService) RebuildVectorIndex(_ context.Context, req models.VectorIndexRequest) (*models.VectorIndexRequest, error) {
+	if m.building {
+		return nil, models.ErrVectorIndexBuilding
+	}
+	m.got = &req
+	params := req.WithDefaults(50000)
+	return &params, nil
+}
+
+func TestVectorIndex(t *testing.T) {
+	svc := &mockVectorIndexService{}
+	h := api.NewVectorIndexHandler(svc, nil, testLogger())
+	r := newTestRouter()
+	r.GET("/admin/vector-index", h.Status)
+	r.POST("/admin/vector-index", h.Rebuild)
+
+	w := doRequest(r, http.MethodGet, "/admin/vector-index", "")
+	var status models.VectorIndexStatus
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &status) != nil {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if !status.Exists || status.EmbeddedNodes != 50000 || len(status.Warnings) != 1 {
+		t.Errorf("status = %+v", status)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/vector-index", "")
+	var params models.VectorIndexRequest
+	if w.Code != http.StatusAccepted || json.Unmarshal(w.Body.Bytes(), &params) != nil {
+		t.Fatalf("rebuild status = %d: %s", w.Code, w.Body.String())
+	}
+	if params.Method != models.VectorIndexHNSW || params.M != 16 || params.EfConstruction != 64 {
+		t.Errorf("params = %+v, want hnsw defaults", params)
+	}
+
+	w = doRequest(r, http.MethodPost, "/admin/vector-index", `{"method":"ivfflat","lists":200}`)
+	if w.Code != http.StatusAccepted || svc.got.Lists != 200 {
+		t.Errorf("ivfflat: status = %d, request = %+v", w.Code, svc.got)
+	}
+
+	for _, body := range []string{`{"method":"btree"}`, `{"m":40,"ef_construction":50}`, `{"lists":10}`} {
+		if w := doRequest(r, http.MethodPost, "/admin/vector-index", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, w.Code)
+		}
+	}
+
+	svc.building = true
+	if w := doRequest(r, http.MethodPost, "/admin/vector-index", ""); w.Code != http.StatusConflict {
+		t.Errorf("during a build: status = %d, want 409", w.Code)
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/admin/vector-index", api.NewVectorIndexHandler(nil, nil, testLogger()).Status)
+	if w := doRequest(disabled, http.MethodGet, "/admin/vector-index", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// WatchHandler serves watch lists: the nodes a named watcher follows. A
+// WebSocket or SSE subscriber that gives the watcher name receives only the
+// events for those nodes.
+type WatchHandler struct {
+	svc WatchService
+	log *logrus.Logger
+}
+
+// NewWatchHandler creates a WatchHandler. svc may be nil when watch lists
+// are not configured; the endpoints then answer 503.
+func NewWatchHandler(svc WatchService, log *logrus.Logger) *WatchHandler {
+	return &WatchHandler{svc: svc, log: log}
+}
+
+// Watch handles POST /api/v1/watch/:id?watcher=<name>.
+// Watching a node the watcher already follows returns the existing watch.
+func (h *WatchHandler) Watch(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID, watcher := h.watcher(c)
+	if tenantID == "" {
+		return
+	}
+
+	w, err := h.svc.Watch(c.Request.Context(), tenantID, watcher, nodeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNodeNotFound):
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "node not found")
+		case errors.Is(err, models.ErrTooManyWatches):
+			respondError(c, http.StatusConflict, "conflict", err.Error())
+		default:
+			h.log.WithError(err).Error("watching node")
+			respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, w)
+}
+
+// Unwatch handles DELETE /api/v1/watch/:id?watcher=<name>.
+func (h *WatchHandler) Unwatch(c *gin.Context) {
+	nodeID := c.Param("id")
+	if err := validatePathID(nodeID); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeInvalidRequest, err.Error())
+
+		return
+	}
+
+	tenantID, watcher := h.watcher(c)
+	if tenantID == "" {
+		return
+	}
+
+	if err := h.svc.Unwatch(c.Request.Context(), tenantID, watcher, nodeID); err != nil {
+		if errors.Is(err, models.ErrWatchNotFound) {
+			respondError(c, http.StatusNotFound, ErrCodeNotFound, "watch not found")
+
+			return
+		}
+
+		h.log.WithError(err).Error("unwatching node")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// List handles GET /api/v1/watch?watcher=<name>.
+func (h *WatchHandler) List(c *gin.Context) {
+	tenantID, watcher := h.watcher(c)
+	if tenantID == "" {
+		return
+	}
+
+	watches, err := h.svc.ListWatches(c.Request.Context(), tenantID, watcher)
+	if err != nil {
+		h.log.WithError(err).Error("listing watches")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	if watches == nil {
+		watches = []models.Watch{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watcher": watcher, "watches": watches})
+}
+
+// watcher returns the request's tenant and watcher name, or "" after
+// answering when either is missing or watch lists are not available.
+func (h *WatchHandler) watcher(c *gin.Context) (string, string) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return "", ""
+	}
+
+	if h.svc == nil {
+		respondError(c, http.StatusServiceUnavailable, ErrCodeInternalError, "watch lists not available")
+		return "", ""
+	}
+
+	watcher := c.Query("watcher")
+	if err := models.ValidateWatcher(watcher); err != nil {
+		respondError(c, http.StatusBadRequest, ErrCodeValidationError, err.Error())
+		return "", ""
+	}
+
+	return tenantID, watcher
+}
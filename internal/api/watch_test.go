@@ -0,0 +1,97 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockWatchService struct {
+	watches map[string]bool
+}
+
+func (m *mockWatchService) Watch(_ context.Context, _, watcher, nodeID string) (*models.Watch, error) {
+	switch nodeID {
+	case "missing":
+		return nil, models.ErrNodeNotFound
+	case "one-too-many":
+		return nil, models.ErrTooManyWatches
+	}
+
+	m.watches[nodeID] = true
+
+	return &models.Watch{Watcher: watcher, NodeID: nodeID}, nil
+}
+
+func (m *mockWatchService) Unwatch(_ context.Context, _, _, nodeID string) error {
+	if !m.watches[nodeID] {
+		return models.ErrWatchNotFound
+	}
+
+	delete(m.watches, nodeID)
+
+	return nil
+}
+
+func (m *mockWatchService) ListWatches(_ context.Context, _, watcher string) ([]models.Watch, error) {
+	var out []models.Watch
+	for id := range m.watches {
+		out = append(out, models.Watch{Watcher: watcher, NodeID: id})
+	}
+
+	return out, nil
+}
+
+func TestWatchHandler(t *testing.T) {
+	h := api.NewWatchHandler(&mockWatchService{watches: map[string]bool{}}, testLogger())
+	r := newTestRouter()
+	r.GET("/watch", h.List)
+	r.POST("/watch/:id", h.Watch)
+	r.DELETE("/watch/:id", h.Unwatch)
+
+	w := doRequest(r, http.MethodPost, "/watch/alice?watcher=agent-1", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("watch: status = %d: %s", w.Code, w.Body.String())
+	}
+
+	var watch models.Watch
+	if err := json.Unmarshal(w.Body.Bytes(), &watch); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if watch.Watcher != "agent-1" || watch.NodeID != "alice" {
+		t.Errorf("watch = %+v", watch)
+	}
+
+	w = doRequest(r, http.MethodGet, "/watch?watcher=agent-1", "")
+	var list struct {
+		Watches []models.Watch `json:"watches"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Watches) != 1 {
+		t.Errorf("list: status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodPost, "/watch/alice", http.StatusBadRequest},
+		{http.MethodPost, "/watch/missing?watcher=agent-1", http.StatusNotFound},
+		{http.MethodPost, "/watch/one-too-many?watcher=agent-1", http.StatusConflict},
+		{http.MethodDelete, "/watch/alice?watcher=agent-1", http.StatusNoContent},
+		{http.MethodDelete, "/watch/alice?watcher=agent-1", http.StatusNotFound},
+	} {
+		if w := doRequest(r, tc.method, tc.path, ""); w.Code != tc.want {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+
+	disabled := newTestRouter()
+	disabled.GET("/watch", api.NewWatchHandler(nil, testLogger()).List)
+	if w := doRequest(disabled, http.MethodGet, "/watch?watcher=agent-1", ""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("without a service: status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/coder/websocket"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/security"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+var (
+	errWSAuthFailed     = errors.New("invalid websocket credentials")
+	errWSAuthBlocked    = errors.New("too many failed authentication attempts")
+	errWSSigningEnabled = errors.New("request signing is enabled for this tenant; connect with a ticket")
+	errWSScope          = errors.New("insufficient api key scope")
+)
+
+// WSTicketHandler issues short-lived WebSocket connection tickets.
+type WSTicketHandler struct {
+	tickets *ws.TicketStore
+	log     *logrus.Logger
+}
+
+// NewWSTicketHandler creates a WSTicketHandler with the given dependencies.
+func NewWSTicketHandler(tickets *ws.TicketStore, log *logrus.Logger) *WSTicketHandler {
+	return &WSTicketHandler{tickets: tickets, log: log}
+}
+
+// Issue handles POST /api/v1/ws/ticket.
+func (h *WSTicketHandler) Issue(c *gin.Context) {
+	tenantID := getTenantID(c)
+	if tenantID == "" {
+		return
+	}
+
+	ticket, expiresAt, err := h.tickets.Issue(tenantID, middleware.ExtractBearerToken(c))
+	if err != nil {
+		h.log.WithError(err).Error("issuing websocket ticket")
+		respondError(c, http.StatusInternalServerError, ErrCodeInternalError, "internal server error")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.WSTicket{Ticket: ticket, ExpiresAt: expiresAt})
+}
+
+// wsAuthenticator resolves WebSocket credentials to a tenant. The WebSocket
+// route sits outside AuthMiddleware because browsers cannot set headers on
+// the upgrade request.
+type wsAuthenticator struct {
+	lookup  middleware.TenantLookup
+	tickets *ws.TicketStore
+	guard   *security.BruteForceGuard
+}
+
+// resolve maps either an API key or a ticket to a tenant ID and the API key
+// used for periodic re-validation. Tenants that require signed requests must
+// use a ticket.
+func (a *wsAuthenticator) resolve(ctx context.Context, token, ticket string) (tenantID, apiKey string, err error) {
+	if ticket != "" {
+		tenantID, apiKey, ok := a.tickets.Redeem(ticket)
+		if !ok {
+			return "", "", errWSAuthFailed
+		}
+
+		return tenantID, apiKey, nil
+	}
+
+	if a.guard.IsBlocked(token) {
+		return "", "", errWSAuthBlocked
+	}
+
+	principal, err := middleware.LookupPrincipal(ctx, a.lookup, token)
+	if err != nil {
+		a.guard.RecordFailure(token)
+
+		return "", "", errWSAuthFailed
+	}
+
+	a.guard.ResetKey(token)
+
+	// Events carry node and edge data, which search-only keys cannot read.
+	if !principal.Scope.Allows(middleware.ScopeRead) {
+		return "", "", errWSScope
+	}
+
+	// A bare API key cannot be signed here, so signing tenants must obtain a
+	// ticket through a signed POST /ws/ticket.
+	if len(principal.SigningSecret) > 0 {
+		return "", "", errWSSigningEnabled
+	}
+
+	return principal.TenantID, token, nil
+}
+
+// fromRequest authenticates the upgrade request using the Authorization header
+// or a ticket query parameter. An empty tenant ID with ok=true means the
+// client will authenticate with its first message instead.
+func (a *wsAuthenticator) fromRequest(c *gin.Context) (tenantID, apiKey string, ok bool) {
+	token := middleware.ExtractBearerToken(c)
+	ticket := c.Query("ticket")
+
+	if token == "" && ticket == "" {
+		return "", "", true
+	}
+
+	tenantID, apiKey, err := a.resolve(c.Request.Context(), token, ticket)
+	switch {
+	case errors.Is(err, errWSAuthBlocked):
+		respondError(c, http.StatusTooManyRequests, ErrCodeRateLimited, err.Error())
+
+		return "", "", false
+	case errors.Is(err, errWSScope):
+		respondError(c, http.StatusForbidden, "forbidden", err.Error())
+
+		return "", "", false
+	case err != nil:
+		respondError(c, http.StatusUnauthorized, ErrCodeUnauthorized, err.Error())
+
+		return "", "", false
+	}
+
+	return tenantID, apiKey, true
+}
+
+// wsFirstMessageAuth authenticates an accepted connection from its first frame
+// and acknowledges success.
+func wsFirstMessageAuth(ctx context.Context, conn *websocket.Conn, auth *wsAuthenticator) (tenantID, apiKey string, err error) {
+	msg, err := ws.ReadAuthMessage(ctx, conn)
+	if err != nil {
+		return "", "", err
+	}
+
+	tenantID, apiKey, err = auth.resolve(ctx, msg.Token, msg.Ticket)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := ws.AckAuth(ctx, conn, tenantID); err != nil {
+		return "", "", err
+	}
+
+	return tenantID, apiKey, nil
+}
+
+// upgradeWriter lets websocket.Accept hijack a Gin response. Accept flushes
+// the 101 status through Gin's WriteHeaderNow, after which Gin refuses to
+// hijack, so the status goes straight to the underlying writer instead and
+// Gin only sees the hijack.
+type upgradeWriter struct {
+	gin gin.ResponseWriter
+}
+
+func (w upgradeWriter) Header() http.Header { return w.gin.Header() }
+
+func (w upgradeWriter) Write(b []byte) (int, error) { return w.gin.Write(b) }
+
+func (w upgradeWriter) WriteHeader(code int) {
+	if u, ok := w.gin.(interface{ Unwrap() http.ResponseWriter }); ok && code == http.StatusSwitchingProtocols {
+		u.Unwrap().WriteHeader(code)
+
+		return
+	}
+
+	w.gin.WriteHeader(code)
+}
+
+func (w upgradeWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return w.gin.Hijack() }
+
+// wsHandler upgrades an authenticated request to a WebSocket and serves it
+// events from hub until the client or the server goes away.
+func wsHandler(appCtx context.Context, log *logrus.Logger, hub *ws.Hub, corsOrigins []string, auth *wsAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, apiKey, ok := auth.fromRequest(c)
+		if !ok {
+			return
+		}
+
+		// CORS origins are reused as WebSocket origin patterns. The config
+		// validator ensures these are safe host patterns (no wildcards etc.).
+		conn, err := websocket.Accept(upgradeWriter{c.Writer}, c.Request, &websocket.AcceptOptions{
+			OriginPatterns:       corsOrigins,
+			CompressionMode:      websocket.CompressionContextTakeover,
+			CompressionThreshold: 128,
+		})
+		if err != nil {
+			log.WithError(err).Error("websocket accept failed")
+
+			return
+		}
+
+		// Browsers cannot send an Authorization header, so they authenticate
+		// with an API key or ticket in the first frame instead.
+		if tenantID == "" {
+			tenantID, apiKey, err = wsFirstMessageAuth(c.Request.Context(), conn, auth)
+			if err != nil {
+				log.WithError(err).Debug("websocket first-message auth failed")
+				conn.Close(websocket.StatusPolicyViolation, "authentication failed") //nolint:errcheck // best-effort
+
+				return
+			}
+		}
+
+		client := ws.NewClient(hub, conn, auth.lookup, apiKey)
+		client.TenantID = tenantID
+		hub.Register(client)
+
+		// Derive a context that cancels when either the server shuts down or the request ends.
+		wsCtx, wsCancel := context.WithCancel(appCtx)
+		go func() {
+			select {
+			case <-c.Request.Context().Done():
+				wsCancel()
+			case <-wsCtx.Done():
+			}
+		}()
+
+		go client.WritePump(wsCtx)
+		client.ReadPump(wsCtx)
+		wsCancel()
+	}
+}
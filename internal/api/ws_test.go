@@ -0,0 +1,127 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/persistorai/persistor/internal/api"
+	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+func TestWSTicketIssue(t *testing.T) {
+	store := ws.NewTicketStore()
+	r := newTestRouter()
+	r.POST("/ws/ticket", api.NewWSTicketHandler(store, testLogger()).Issue)
+
+	w := doRequest(r, http.MethodPost, "/ws/ticket", "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+
+	var got models.WSTicket
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	tenantID, _, ok := store.Redeem(got.Ticket)
+	if !ok || tenantID != testTenantID {
+		t.Fatalf("Redeem = (%q, %v), want (%q, true)", tenantID, ok, testTenantID)
+	}
+}
+
+// principalLookup resolves every key to a read-write principal of the test
+// tenant with the given signing secret.
+type principalLookup struct {
+	signingSecret []byte
+}
+
+func (principalLookup) GetTenantByAPIKey(_ context.Context, _ string) (string, error) {
+	return testTenantID, nil
+}
+
+func (l principalLookup) GetAuthPrincipalByAPIKey(_ context.Context, _ string) (middleware.AuthPrincipal, error) {
+	return middleware.AuthPrincipal{
+		TenantID:      testTenantID,
+		Scope:         middleware.ScopeReadWrite,
+		SigningSecret: l.signingSecret,
+	}, nil
+}
+
+func newWSRouter(t *testing.T, lookup principalLookup) http.Handler {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	return api.NewRouter(ctx, &api.RouterDeps{
+		Log:          testLogger(),
+		Hub:          ws.NewHub(testLogger()),
+		TenantLookup: lookup,
+		CORSOrigins:  []string{"http://localhost"},
+	})
+}
+
+func TestWS_SigningTenantRejectsBareKey(t *testing.T) {
+	router := newWSRouter(t, principalLookup{signingSecret: []byte("signing-secret")})
+
+	for _, path := range []string{"/api/v1/ws", "/api/v1/events"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer test-key")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "ticket") {
+			t.Errorf("GET %s: status = %d, body = %s; want 401 asking for a ticket", path, w.Code, w.Body.String())
+		}
+	}
+}
+
+// wsFirstMessageAuth dials /ws without credentials, sends the API key as the
+// first message, and returns the server's reply.
+func wsFirstMessageAuth(t *testing.T, lookup principalLookup) ([]byte, error) {
+	t.Helper()
+
+	srv := httptest.NewServer(newWSRouter(t, lookup))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/api/v1/ws", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // test cleanup.
+
+	if err := conn.Write(ctx, websocket.MessageText, []byte(`{"type":"auth","token":"test-key"}`)); err != nil {
+		t.Fatalf("writing auth message: %v", err)
+	}
+
+	_, msg, err := conn.Read(ctx)
+
+	return msg, err
+}
+
+func TestWS_FirstMessageKey(t *testing.T) {
+	msg, err := wsFirstMessageAuth(t, principalLookup{})
+	if err != nil || !strings.Contains(string(msg), "auth_ok") {
+		t.Errorf("read = %q, %v; want auth_ok", msg, err)
+	}
+}
+
+func TestWS_SigningTenantRejectsFirstMessageKey(t *testing.T) {
+	msg, err := wsFirstMessageAuth(t, principalLookup{signingSecret: []byte("signing-secret")})
+	if status := websocket.CloseStatus(err); status != websocket.StatusPolicyViolation {
+		t.Errorf("read = %q, %v; want close with policy violation", msg, err)
+	}
+}
@@ -6,6 +6,9 @@
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
 )
 
 // Secret wraps a sensitive string to prevent accidental logging or marshalling.
@@ -25,29 +28,81 @@ func (s Secret) Value() string { return string(s) }
 
 // Config holds all application configuration values.
 type Config struct {
-	DatabaseURL         Secret
-	Port                string
-	ListenHost          string
-	MetricsPort         string
-	CORSOrigins         []string
-	OllamaURL           string
-	OllamaModel         string
-	EmbeddingModel      string
-	EmbeddingDimensions int
-	LogLevel            string
-	EncryptionProvider  string
-	EncryptionKey       Secret
-	VaultAddr           string
-	VaultToken          Secret
-	EmbedWorkers        int
-	EnablePlayground    bool
-	DBMaxConns          int32
-	OllamaAllowRemote   bool
+	StorageBackend         string
+	DatabaseURL            Secret
+	DatabaseReplicaURLs    []Secret
+	ReplicaMaxLagSeconds   int
+	Port                   string
+	ListenHost             string
+	MetricsPort            string
+	CORSOrigins            []string
+	OllamaURL              string
+	OllamaModel            string
+	EmbeddingModel         string
+	EmbeddingDimensions    int
+	RerankModel            string
+	RerankURL              string
+	RerankProvider         string
+	RerankTopK             int
+	LogLevel               string
+	EncryptionProvider     string
+	EncryptionKey          Secret
+	VaultAddr              string
+	VaultToken             Secret
+	VaultTransitMount      string
+	EmbedWorkers           int
+	EmbedBatchSize         int
+	EmbeddingCacheSize     int
+	QueryCacheSize         int
+	QueryCacheTTLSeconds   int
+	EnablePlayground       bool
+	DBMaxConns             int32
+	OllamaAllowRemote      bool
+	EventLogRetentionHours int
+	SalienceRecalcCron     string
+	GraphPartitions        int
+	FTSDetectLanguage      bool
+	InjectionScan          bool
+	SearchableProperties   []string
+	SeedPath               string
+	OTLPEndpoint           string
 }
 
 // Load reads configuration from environment variables with sensible defaults.
+//
+// When VALIDATE_ONLY=true, Load instead prints a validation report to stdout
+// and exits: 0 when the configuration is valid, 1 otherwise. Deployments run
+// the server this way as a Kubernetes initContainer to fail fast on
+// misconfiguration.
 func Load() (*Config, error) {
-	cfg := &Config{
+	if envOrDefault("VALIDATE_ONLY", "false") == "true" {
+		report := ValidateEnv()
+		report.WriteTo(os.Stdout) //nolint:errcheck // best-effort report before exiting.
+
+		if report.Valid() {
+			os.Exit(0)
+		}
+
+		os.Exit(1)
+	}
+
+	cfg, parseErrs, validationErrs := load()
+	if len(parseErrs) > 0 {
+		return nil, parseErrs[0]
+	}
+
+	if len(validationErrs) > 0 {
+		return nil, fmt.Errorf("config validation: %w", validationErrs[0])
+	}
+
+	return cfg, nil
+}
+
+// load reads the environment and reports every problem found. A value that
+// fails to parse keeps its default so the rest can still be validated.
+func load() (cfg *Config, parseErrs, validationErrs []error) {
+	cfg = &Config{
+		StorageBackend:     envOrDefault("STORAGE_BACKEND", "postgres"),
 		DatabaseURL:        Secret(envOrDefault("DATABASE_URL", "")),
 		Port:               envOrDefault("PORT", "3030"),
 		ListenHost:         envOrDefault("LISTEN_HOST", "127.0.0.1"),
@@ -55,32 +110,118 @@ func Load() (*Config, error) {
 		OllamaURL:          envOrDefault("OLLAMA_URL", "http://localhost:11434"),
 		OllamaModel:        envOrDefault("OLLAMA_MODEL", "gemma4:e4b"),
 		EmbeddingModel:     envOrDefault("EMBEDDING_MODEL", "qwen3-embedding:0.6b"),
+		RerankModel:        envOrDefault("RERANK_MODEL", ""),
+		RerankProvider:     envOrDefault("RERANK_PROVIDER", "ollama"),
 		LogLevel:           envOrDefault("LOG_LEVEL", "info"),
 		EncryptionProvider: envOrDefault("ENCRYPTION_PROVIDER", "static"),
 		EncryptionKey:      Secret(envOrDefault("ENCRYPTION_KEY", "")),
 		VaultAddr:          envOrDefault("VAULT_ADDR", "http://127.0.0.1:8200"),
 		VaultToken:         Secret(envOrDefault("VAULT_TOKEN", "")),
+		VaultTransitMount:  envOrDefault("VAULT_TRANSIT_MOUNT", "transit"),
 		EnablePlayground:   envOrDefault("ENABLE_PLAYGROUND", "false") == "true",
 		OllamaAllowRemote:  envOrDefault("OLLAMA_ALLOW_REMOTE", "false") == "true",
+		FTSDetectLanguage:  envOrDefault("FTS_DETECT_LANGUAGE", "false") == "true",
+		InjectionScan:      envOrDefault("INJECTION_SCAN", "false") == "true",
+		SeedPath:           envOrDefault("SEED_PATH", ""),
+		OTLPEndpoint:       envOrDefault("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+	}
+
+	cfg.EmbeddingDimensions = 1024
+	if v, err := strconv.Atoi(envOrDefault("EMBEDDING_DIMENSIONS", "1024")); err != nil || v < 1 || v > 4096 {
+		parseErrs = append(parseErrs, fmt.Errorf("EMBEDDING_DIMENSIONS must be an integer between 1 and 4096"))
+	} else {
+		cfg.EmbeddingDimensions = v
+	}
+
+	cfg.RerankURL = envOrDefault("RERANK_URL", cfg.OllamaURL)
+
+	cfg.RerankTopK = 20
+	if v, err := strconv.Atoi(envOrDefault("RERANK_TOP_K", "20")); err != nil || v < 1 || v > 100 {
+		parseErrs = append(parseErrs, fmt.Errorf("RERANK_TOP_K must be an integer between 1 and 100"))
+	} else {
+		cfg.RerankTopK = v
+	}
+
+	cfg.EmbedWorkers = 4
+	if v, err := strconv.Atoi(envOrDefault("EMBED_WORKERS", "4")); err != nil || v < 1 || v > 16 {
+		parseErrs = append(parseErrs, fmt.Errorf("EMBED_WORKERS must be an integer between 1 and 16"))
+	} else {
+		cfg.EmbedWorkers = v
+	}
+
+	cfg.EmbedBatchSize = 16
+	if v, err := strconv.Atoi(envOrDefault("EMBED_BATCH_SIZE", "16")); err != nil || v < 1 || v > 256 {
+		parseErrs = append(parseErrs, fmt.Errorf("EMBED_BATCH_SIZE must be an integer between 1 and 256"))
+	} else {
+		cfg.EmbedBatchSize = v
 	}
 
-	embeddingDims, err := strconv.Atoi(envOrDefault("EMBEDDING_DIMENSIONS", "1024"))
-	if err != nil || embeddingDims < 1 || embeddingDims > 4096 {
-		return nil, fmt.Errorf("EMBEDDING_DIMENSIONS must be an integer between 1 and 4096")
+	cfg.EmbeddingCacheSize = 100000
+	if v, err := strconv.Atoi(envOrDefault("EMBEDDING_CACHE_MAX_ENTRIES", "100000")); err != nil || v < 0 || v > 10000000 {
+		parseErrs = append(parseErrs, fmt.Errorf("EMBEDDING_CACHE_MAX_ENTRIES must be an integer between 0 and 10000000"))
+	} else {
+		cfg.EmbeddingCacheSize = v
 	}
-	cfg.EmbeddingDimensions = embeddingDims
 
-	embedWorkers, err := strconv.Atoi(envOrDefault("EMBED_WORKERS", "4"))
-	if err != nil || embedWorkers < 1 || embedWorkers > 16 {
-		return nil, fmt.Errorf("EMBED_WORKERS must be an integer between 1 and 16")
+	cfg.QueryCacheSize = 1000
+	if v, err := strconv.Atoi(envOrDefault("QUERY_EMBEDDING_CACHE_SIZE", "1000")); err != nil || v < 0 || v > 100000 {
+		parseErrs = append(parseErrs, fmt.Errorf("QUERY_EMBEDDING_CACHE_SIZE must be an integer between 0 and 100000"))
+	} else {
+		cfg.QueryCacheSize = v
 	}
-	cfg.EmbedWorkers = embedWorkers
 
-	dbMaxConns, err := strconv.Atoi(envOrDefault("DB_MAX_CONNS", "21"))
-	if err != nil || dbMaxConns < 2 || dbMaxConns > 200 {
-		return nil, fmt.Errorf("DB_MAX_CONNS must be an integer between 2 and 200")
+	cfg.QueryCacheTTLSeconds = 600
+	if v, err := strconv.Atoi(envOrDefault("QUERY_EMBEDDING_CACHE_TTL_SECONDS", "600")); err != nil || v < 1 || v > 86400 {
+		parseErrs = append(parseErrs, fmt.Errorf("QUERY_EMBEDDING_CACHE_TTL_SECONDS must be an integer between 1 and 86400"))
+	} else {
+		cfg.QueryCacheTTLSeconds = v
+	}
+
+	cfg.DBMaxConns = 21
+	if v, err := strconv.Atoi(envOrDefault("DB_MAX_CONNS", "21")); err != nil || v < 2 || v > 200 {
+		parseErrs = append(parseErrs, fmt.Errorf("DB_MAX_CONNS must be an integer between 2 and 200"))
+	} else {
+		cfg.DBMaxConns = int32(v)
+	}
+
+	if v, err := strconv.Atoi(envOrDefault("EVENT_LOG_RETENTION_HOURS", "0")); err != nil || v < 0 || v > 2160 {
+		parseErrs = append(parseErrs, fmt.Errorf("EVENT_LOG_RETENTION_HOURS must be an integer between 0 and 2160"))
+	} else {
+		cfg.EventLogRetentionHours = v
+	}
+
+	if spec := envOrDefault("SALIENCE_RECALC_CRON", ""); spec != "" {
+		if _, err := cron.ParseStandard(spec); err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("SALIENCE_RECALC_CRON must be a five-field cron expression: %w", err))
+		} else {
+			cfg.SalienceRecalcCron = spec
+		}
+	}
+
+	if v, err := strconv.Atoi(envOrDefault("GRAPH_PARTITIONS", "0")); err != nil || v < 0 || v == 1 || v > 1024 {
+		parseErrs = append(parseErrs, fmt.Errorf("GRAPH_PARTITIONS must be 0 or an integer between 2 and 1024"))
+	} else {
+		cfg.GraphPartitions = v
+	}
+
+	for _, u := range strings.Split(envOrDefault("DATABASE_REPLICA_URLS", ""), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			cfg.DatabaseReplicaURLs = append(cfg.DatabaseReplicaURLs, Secret(u))
+		}
+	}
+
+	cfg.ReplicaMaxLagSeconds = 5
+	if v, err := strconv.Atoi(envOrDefault("DATABASE_REPLICA_MAX_LAG_SECONDS", "5")); err != nil || v < 0 || v > 3600 {
+		parseErrs = append(parseErrs, fmt.Errorf("DATABASE_REPLICA_MAX_LAG_SECONDS must be an integer between 0 and 3600"))
+	} else {
+		cfg.ReplicaMaxLagSeconds = v
+	}
+
+	for _, k := range strings.Split(envOrDefault("SEARCHABLE_PROPERTIES", ""), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			cfg.SearchableProperties = append(cfg.SearchableProperties, k)
+		}
 	}
-	cfg.DBMaxConns = int32(dbMaxConns)
 
 	origins := envOrDefault("CORS_ORIGINS", "http://localhost:3002")
 	cfg.CORSOrigins = strings.Split(origins, ",")
@@ -89,11 +230,25 @@ func Load() (*Config, error) {
 		cfg.CORSOrigins[i] = strings.TrimSpace(o)
 	}
 
-	if err := cfg.validate(); err != nil {
-		return nil, fmt.Errorf("config validation: %w", err)
-	}
+	return cfg, parseErrs, cfg.validate()
+}
 
-	return cfg, nil
+// EventLogRetention returns how long change-feed events are kept, or 0 when
+// the event log is disabled.
+func (c *Config) EventLogRetention() time.Duration {
+	return time.Duration(c.EventLogRetentionHours) * time.Hour
+}
+
+// QueryCacheTTL returns how long a search query's embedding stays cached
+// in memory.
+func (c *Config) QueryCacheTTL() time.Duration {
+	return time.Duration(c.QueryCacheTTLSeconds) * time.Second
+}
+
+// ReplicaMaxLag returns how far a read replica may fall behind the primary
+// before reads go to the primary instead.
+func (c *Config) ReplicaMaxLag() time.Duration {
+	return time.Duration(c.ReplicaMaxLagSeconds) * time.Second
 }
 
 // Addr returns the listen address in host:port format.
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Setting is one effective configuration value, safe to print: secrets are
+// shown only as set or not set, and the database password is masked.
+type Setting struct {
+	Env     string
+	Value   string
+	Default bool
+}
+
+// Report is the result of validating the environment without starting the
+// server.
+type Report struct {
+	Settings []Setting
+	Problems []error
+}
+
+// ValidateEnv reads the environment like Load but, instead of stopping at the
+// first problem, reports all of them alongside the effective settings.
+func ValidateEnv() *Report {
+	cfg, parseErrs, validationErrs := load()
+
+	return &Report{
+		Settings: cfg.Settings(),
+		Problems: append(parseErrs, validationErrs...),
+	}
+}
+
+// Valid reports whether the configuration has no problems.
+func (r *Report) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+// WriteTo writes the report as text: the version, every setting, and the
+// problems found.
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Persistor %s configuration\n\n", Version)
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, s := range r.Settings {
+		source := ""
+		if s.Default {
+			source = "(default)"
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", s.Env, s.Value, source)
+	}
+	tw.Flush() //nolint:errcheck // strings.Builder never fails.
+
+	if r.Valid() {
+		b.WriteString("\nOK: configuration is valid\n")
+	} else {
+		fmt.Fprintf(&b, "\nFAIL: %d problem(s)\n", len(r.Problems))
+		for _, p := range r.Problems {
+			fmt.Fprintf(&b, "  - %s\n", p)
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+
+	return int64(n), err
+}
+
+// Settings returns the effective configuration for a validation report or a
+// startup banner.
+func (c *Config) Settings() []Setting {
+	settings := []Setting{
+		{Env: "STORAGE_BACKEND", Value: c.StorageBackend},
+		{Env: "DATABASE_URL", Value: redactDatabaseURL(c.DatabaseURL)},
+		{Env: "DATABASE_REPLICA_URLS", Value: redactDatabaseURLs(c.DatabaseReplicaURLs)},
+		{Env: "DATABASE_REPLICA_MAX_LAG_SECONDS", Value: strconv.Itoa(c.ReplicaMaxLagSeconds)},
+		{Env: "PORT", Value: c.Port},
+		{Env: "LISTEN_HOST", Value: c.ListenHost},
+		{Env: "METRICS_PORT", Value: c.MetricsPort},
+		{Env: "CORS_ORIGINS", Value: strings.Join(c.CORSOrigins, ",")},
+		{Env: "OLLAMA_URL", Value: c.OllamaURL},
+		{Env: "OLLAMA_ALLOW_REMOTE", Value: strconv.FormatBool(c.OllamaAllowRemote)},
+		{Env: "OLLAMA_MODEL", Value: c.OllamaModel},
+		{Env: "EMBEDDING_MODEL", Value: c.EmbeddingModel},
+		{Env: "EMBEDDING_DIMENSIONS", Value: strconv.Itoa(c.EmbeddingDimensions)},
+		{Env: "RERANK_MODEL", Value: c.RerankModel},
+		{Env: "RERANK_PROVIDER", Value: c.RerankProvider},
+		{Env: "RERANK_URL", Value: c.RerankURL},
+		{Env: "RERANK_TOP_K", Value: strconv.Itoa(c.RerankTopK)},
+		{Env: "EMBED_WORKERS", Value: strconv.Itoa(c.EmbedWorkers)},
+		{Env: "EMBED_BATCH_SIZE", Value: strconv.Itoa(c.EmbedBatchSize)},
+		{Env: "EMBEDDING_CACHE_MAX_ENTRIES", Value: strconv.Itoa(c.EmbeddingCacheSize)},
+		{Env: "QUERY_EMBEDDING_CACHE_SIZE", Value: strconv.Itoa(c.QueryCacheSize)},
+		{Env: "QUERY_EMBEDDING_CACHE_TTL_SECONDS", Value: strconv.Itoa(c.QueryCacheTTLSeconds)},
+		{Env: "DB_MAX_CONNS", Value: strconv.Itoa(int(c.DBMaxConns))},
+		{Env: "EVENT_LOG_RETENTION_HOURS", Value: strconv.Itoa(c.EventLogRetentionHours)},
+		{Env: "SALIENCE_RECALC_CRON", Value: c.SalienceRecalcCron},
+		{Env: "GRAPH_PARTITIONS", Value: strconv.Itoa(c.GraphPartitions)},
+		{Env: "FTS_DETECT_LANGUAGE", Value: strconv.FormatBool(c.FTSDetectLanguage)},
+		{Env: "INJECTION_SCAN", Value: strconv.FormatBool(c.InjectionScan)},
+		{Env: "SEARCHABLE_PROPERTIES", Value: strings.Join(c.SearchableProperties, ",")},
+		{Env: "SEED_PATH", Value: c.SeedPath},
+		{Env: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: c.OTLPEndpoint},
+		{Env: "LOG_LEVEL", Value: c.LogLevel},
+		{Env: "ENABLE_PLAYGROUND", Value: strconv.FormatBool(c.EnablePlayground)},
+		{Env: "ENCRYPTION_PROVIDER", Value: c.EncryptionProvider},
+		{Env: "ENCRYPTION_KEY", Value: secretState(c.EncryptionKey)},
+		{Env: "VAULT_ADDR", Value: c.VaultAddr},
+		{Env: "VAULT_TOKEN", Value: secretState(c.VaultToken)},
+		{Env: "VAULT_TRANSIT_MOUNT", Value: c.VaultTransitMount},
+	}
+
+	for i := range settings {
+		settings[i].Default = os.Getenv(settings[i].Env) == ""
+	}
+
+	return settings
+}
+
+func secretState(s Secret) string {
+	if s.Value() == "" {
+		return "not set"
+	}
+
+	return "set"
+}
+
+// redactDatabaseURLs redacts each URL in a list.
+func redactDatabaseURLs(urls []Secret) string {
+	if len(urls) == 0 {
+		return "not set"
+	}
+
+	redacted := make([]string, len(urls))
+	for i, u := range urls {
+		redacted[i] = redactDatabaseURL(u)
+	}
+
+	return strings.Join(redacted, ",")
+}
+
+// redactDatabaseURL shows where the database is without its password.
+func redactDatabaseURL(s Secret) string {
+	if s.Value() == "" {
+		return "not set"
+	}
+
+	u, err := url.Parse(s.Value())
+	if err != nil {
+		return "set (unparseable)"
+	}
+
+	if q := u.Query(); q.Has("password") {
+		q.Set("password", "xxxxx")
+		u.RawQuery = q.Encode()
+	}
+
+	return u.Redacted()
+}
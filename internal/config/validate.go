@@ -4,57 +4,76 @@
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
-func (c *Config) validate() error {
-	if err := c.validateDatabase(); err != nil {
-		return err
-	}
+// validate checks the configuration and returns the first problem found by
+// each check.
+func (c *Config) validate() []error {
+	var errs []error
 
-	if err := c.validateNetwork(); err != nil {
-		return err
+	for _, check := range []func() error{
+		c.validateDatabase,
+		c.validateNetwork,
+		c.validateOllama,
+		c.validateRerank,
+		c.validateCORS,
+		c.validateEncryption,
+		c.validateTracing,
+		c.validateSearchableProperties,
+		c.validateSeedPath,
+	} {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	if err := c.validateOllama(); err != nil {
-		return err
+	return errs
+}
+
+func (c *Config) validateDatabase() error {
+	if c.DatabaseURL.Value() == "" {
+		return fmt.Errorf("DATABASE_URL is required")
 	}
 
-	if err := c.validateCORS(); err != nil {
+	if err := validateDatabaseURL("DATABASE_URL", c.DatabaseURL.Value()); err != nil {
 		return err
 	}
 
-	if err := c.validateEncryption(); err != nil {
-		return err
+	for _, u := range c.DatabaseReplicaURLs {
+		if err := validateDatabaseURL("DATABASE_REPLICA_URLS", u.Value()); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (c *Config) validateDatabase() error {
-	if c.DatabaseURL.Value() == "" {
-		return fmt.Errorf("DATABASE_URL is required")
-	}
-
-	dbURL, err := url.Parse(c.DatabaseURL.Value())
+// validateDatabaseURL checks one PostgreSQL connection URL from the env
+// variable named env.
+func validateDatabaseURL(env, raw string) error {
+	dbURL, err := url.Parse(raw)
 	if err != nil {
-		return fmt.Errorf("DATABASE_URL is not a valid URL: %w", err)
+		return fmt.Errorf("%s is not a valid URL: %w", env, err)
 	}
 
 	if dbURL.Scheme != "postgres" && dbURL.Scheme != "postgresql" {
-		return fmt.Errorf("DATABASE_URL scheme must be postgres:// or postgresql://")
+		return fmt.Errorf("%s scheme must be postgres:// or postgresql://", env)
 	}
 
 	if dbURL.Hostname() == "" {
-		return fmt.Errorf("DATABASE_URL must include a host")
+		return fmt.Errorf("%s must include a host", env)
 	}
 
 	dbHost := dbURL.Hostname()
 	if dbHost != "localhost" && dbHost != "127.0.0.1" && dbHost != "::1" {
 		sslmode := dbURL.Query().Get("sslmode")
 		if sslmode == "disable" {
-			return fmt.Errorf("DATABASE_URL sslmode=disable is not allowed for non-local host %q", dbHost)
+			return fmt.Errorf("%s sslmode=disable is not allowed for non-local host %q", env, dbHost)
 		}
 	}
 
@@ -117,6 +136,30 @@ func (c *Config) validateOllama() error {
 	return nil
 }
 
+func (c *Config) validateRerank() error {
+	if c.RerankModel == "" {
+		return nil
+	}
+
+	if c.RerankProvider != "ollama" && c.RerankProvider != "tei" {
+		return fmt.Errorf("RERANK_PROVIDER must be ollama or tei (got %q)", c.RerankProvider)
+	}
+
+	rerankURL, err := url.ParseRequestURI(c.RerankURL)
+	if err != nil {
+		return fmt.Errorf("RERANK_URL is not a valid URL: %w", err)
+	}
+
+	rerankHost := rerankURL.Hostname()
+	if rerankHost != "localhost" && rerankHost != "127.0.0.1" && rerankHost != "::1" {
+		if !c.OllamaAllowRemote {
+			return fmt.Errorf("RERANK_URL must point to localhost (set OLLAMA_ALLOW_REMOTE=true for distributed deployments)")
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) validateCORS() error {
 	for _, origin := range c.CORSOrigins {
 		if origin == "*" {
@@ -149,16 +192,57 @@ func (c *Config) validateEncryption() error {
 		if len(keyBytes) != 32 {
 			return fmt.Errorf("ENCRYPTION_KEY must be 64 hex characters (32 bytes), got %d chars", len(c.EncryptionKey.Value()))
 		}
-	case "vault":
+	case "vault", "transit":
 		if c.VaultToken.Value() == "" {
-			return fmt.Errorf("VAULT_TOKEN is required when ENCRYPTION_PROVIDER is vault")
+			return fmt.Errorf("VAULT_TOKEN is required when ENCRYPTION_PROVIDER is %s", c.EncryptionProvider)
 		}
 
 		if !isLocalhost(c.VaultAddr) && !strings.HasPrefix(c.VaultAddr, "https://") {
 			return fmt.Errorf("VAULT_ADDR must use HTTPS for non-localhost connections")
 		}
 	default:
-		return fmt.Errorf("ENCRYPTION_PROVIDER must be 'static' or 'vault', got %q", c.EncryptionProvider)
+		return fmt.Errorf("ENCRYPTION_PROVIDER must be 'static', 'vault', or 'transit', got %q", c.EncryptionProvider)
+	}
+
+	return nil
+}
+
+func (c *Config) validateTracing() error {
+	if c.OTLPEndpoint == "" {
+		return nil
+	}
+
+	u, err := url.ParseRequestURI(c.OTLPEndpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT must be an http:// or https:// URL, got %q", c.OTLPEndpoint)
+	}
+
+	return nil
+}
+
+func (c *Config) validateSearchableProperties() error {
+	for _, k := range c.SearchableProperties {
+		if err := models.ValidatePropertyKey(k); err != nil {
+			return fmt.Errorf("SEARCHABLE_PROPERTIES: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateSeedPath checks that SEED_PATH, when set, is a readable directory.
+func (c *Config) validateSeedPath() error {
+	if c.SeedPath == "" {
+		return nil
+	}
+
+	info, err := os.Stat(c.SeedPath)
+	if err != nil {
+		return fmt.Errorf("SEED_PATH: %w", err)
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("SEED_PATH %q is not a directory", c.SeedPath)
 	}
 
 	return nil
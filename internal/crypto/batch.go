@@ -0,0 +1,260 @@
+package crypto
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// minBatchPerWorker is the fewest ciphertexts worth handing to another
+// goroutine; smaller batches are decrypted on the caller's goroutine.
+const minBatchPerWorker = 16
+
+// bufferPool holds scratch buffers that ciphertexts are decoded and
+// decrypted in place into.
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 4096)
+		return &b
+	},
+}
+
+// BatchError reports which ciphertext of a batch failed to decrypt.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("crypto: batch item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// batch is a set of ciphertexts ready to decrypt: the base64 body of each
+// and the key, by ID, it was sealed with.
+type batch struct {
+	tenantID string
+	bodies   []string
+	keyIDs   []string // nil when every item uses keys[""]
+	keys     map[string][]byte
+}
+
+func (b *batch) keyID(i int) string {
+	if b.keyIDs == nil {
+		return ""
+	}
+
+	return b.keyIDs[i]
+}
+
+// DecryptBatch decrypts ciphertexts for one tenant, resolving keys once
+// and spreading large batches across up to GOMAXPROCS workers. fn receives
+// each index and its plaintext, possibly concurrently for different
+// indexes; the plaintext is a reused buffer valid only until fn returns.
+// The first failure stops the batch and is returned: a *BatchError when a
+// ciphertext fails to decrypt, or fn's error as is.
+func (s *Service) DecryptBatch(ctx context.Context, tenantID string, ciphertexts []string, fn func(i int, plaintext []byte) error) error {
+	if len(ciphertexts) == 0 {
+		return nil
+	}
+
+	b, err := s.prepareBatch(ctx, tenantID, ciphertexts)
+	if err != nil {
+		return err
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), (len(ciphertexts)+minBatchPerWorker-1)/minBatchPerWorker)
+	if workers <= 1 {
+		return b.decryptRange(new(atomic.Int64), new(atomic.Bool), fn)
+	}
+
+	var (
+		next   atomic.Int64
+		failed atomic.Bool
+		wg     sync.WaitGroup
+		errs   = make([]error, workers)
+	)
+
+	for w := range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[w] = b.decryptRange(&next, &failed, fn)
+		}()
+	}
+
+	wg.Wait()
+
+	return firstBatchError(errs)
+}
+
+// prepareBatch resolves the keys ciphertexts need: the tenant key, or for
+// an envelope service every distinct data key, unwrapped in one call.
+func (s *Service) prepareBatch(ctx context.Context, tenantID string, ciphertexts []string) (*batch, error) {
+	if s.dataKeys == nil {
+		key, err := s.keys.GetKey(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("crypto: get key: %w", err)
+		}
+
+		return &batch{tenantID: tenantID, bodies: ciphertexts, keys: map[string][]byte{"": key}}, nil
+	}
+
+	b := &batch{
+		tenantID: tenantID,
+		bodies:   make([]string, len(ciphertexts)),
+		keyIDs:   make([]string, len(ciphertexts)),
+	}
+
+	seen := make(map[string]bool)
+
+	var wrapped []string
+
+	for i, ct := range ciphertexts {
+		w, body, err := splitEnvelope(ct)
+		if err != nil {
+			return nil, &BatchError{Index: i, Err: err}
+		}
+
+		b.keyIDs[i], b.bodies[i] = w, body
+
+		if !seen[w] {
+			seen[w] = true
+			wrapped = append(wrapped, w)
+		}
+	}
+
+	keys, err := s.dataKeys.UnwrapDataKeys(ctx, tenantID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: unwrap data keys: %w", err)
+	}
+
+	b.keys = keys
+
+	return b, nil
+}
+
+// decryptRange decrypts ciphertexts, claiming indexes from next, until they
+// run out or a worker fails.
+func (b *batch) decryptRange(next *atomic.Int64, failed *atomic.Bool, fn func(int, []byte) error) error {
+	aeads := make(map[string]cipher.AEAD, 1)
+
+	bufp, _ := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(bufp)
+
+	aad := []byte(b.tenantID)
+
+	for !failed.Load() {
+		i := int(next.Add(1) - 1)
+		if i >= len(b.bodies) {
+			return nil
+		}
+
+		plaintext, err := b.open(aeads, bufp, i, aad)
+		if err != nil {
+			failed.Store(true)
+			return &BatchError{Index: i, Err: err}
+		}
+
+		if err := fn(i, plaintext); err != nil {
+			failed.Store(true)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// open decrypts item i into *bufp with the worker's cipher for its key,
+// creating that cipher on first use.
+func (b *batch) open(aeads map[string]cipher.AEAD, bufp *[]byte, i int, aad []byte) ([]byte, error) {
+	id := b.keyID(i)
+
+	gcm, ok := aeads[id]
+	if !ok {
+		var err error
+		if gcm, err = b.newAEAD(id); err != nil {
+			return nil, err
+		}
+
+		aeads[id] = gcm
+	}
+
+	return openInto(gcm, bufp, b.bodies[i], aad)
+}
+
+func (b *batch) newAEAD(id string) (cipher.AEAD, error) {
+	key, ok := b.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("crypto: data key was not unwrapped")
+	}
+
+	return newGCM(key)
+}
+
+// openInto decodes ciphertext into *bufp, growing it as needed, and
+// decrypts it in place.
+func openInto(gcm cipher.AEAD, bufp *[]byte, ciphertext string, aad []byte) ([]byte, error) {
+	size := base64.StdEncoding.DecodedLen(len(ciphertext))
+	if cap(*bufp) < size {
+		*bufp = make([]byte, 0, size)
+	}
+
+	data := (*bufp)[:size]
+
+	n, err := base64.StdEncoding.Decode(data, []byte(ciphertext))
+	if err != nil {
+		return nil, fmt.Errorf("crypto: base64 decode: %w", err)
+	}
+
+	data = data[:n]
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("crypto: ciphertext too short")
+	}
+
+	nonce, sealed := data[:nonceSize], data[nonceSize:]
+
+	plaintext, err := gcm.Open(sealed[:0], nonce, sealed, aad)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: decrypt failed: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// firstBatchError returns a worker's error, preferring fn's errors and then
+// the lowest failing index so the result depends on scheduling as little as
+// possible.
+func firstBatchError(errs []error) error {
+	var first *BatchError
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var be *BatchError
+		if !errors.As(err, &be) {
+			return err
+		}
+
+		if first == nil || be.Index < first.Index {
+			first = be
+		}
+	}
+
+	if first == nil {
+		return nil
+	}
+
+	return first
+}
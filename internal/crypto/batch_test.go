@@ -0,0 +1,178 @@
+package crypto_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/crypto"
+)
+
+// encryptBatch encrypts n JSON property payloads for tenant "t".
+func encryptBatch(tb testing.TB, svc *crypto.Service, n int) []string {
+	tb.Helper()
+
+	out := make([]string, n)
+
+	for i := range out {
+		plain, _ := json.Marshal(map[string]any{
+			"index":   i,
+			"summary": strings.Repeat(fmt.Sprintf("payload %d ", i), 40),
+		})
+
+		ct, err := svc.Encrypt(context.Background(), "t", plain)
+		if err != nil {
+			tb.Fatalf("encrypt: %v", err)
+		}
+
+		out[i] = ct
+	}
+
+	return out
+}
+
+func TestDecryptBatch(t *testing.T) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+
+	for _, n := range []int{0, 1, 5, 300} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			ciphertexts := encryptBatch(t, svc, n)
+			got := make([]int, n)
+
+			var mu sync.Mutex
+
+			seen := 0
+
+			err := svc.DecryptBatch(context.Background(), "t", ciphertexts, func(i int, plaintext []byte) error {
+				var props struct {
+					Index int `json:"index"`
+				}
+				if err := json.Unmarshal(plaintext, &props); err != nil {
+					return err
+				}
+
+				got[i] = props.Index
+
+				mu.Lock()
+				seen++
+				mu.Unlock()
+
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("decrypt batch: %v", err)
+			}
+
+			if seen != n {
+				t.Fatalf("fn called %d times, want %d", seen, n)
+			}
+
+			for i, idx := range got {
+				if idx != i {
+					t.Fatalf("item %d decrypted to index %d", i, idx)
+				}
+			}
+		})
+	}
+}
+
+func TestDecryptBatchReportsFailingIndex(t *testing.T) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+
+	ciphertexts := encryptBatch(t, svc, 100)
+	ciphertexts[42] = "not-valid-base64!!!"
+
+	err := svc.DecryptBatch(context.Background(), "t", ciphertexts, func(int, []byte) error { return nil })
+
+	var be *crypto.BatchError
+	if !errors.As(err, &be) {
+		t.Fatalf("expected *BatchError, got %v", err)
+	}
+
+	if be.Index != 42 {
+		t.Fatalf("failing index = %d, want 42", be.Index)
+	}
+}
+
+func TestDecryptBatchWrongTenant(t *testing.T) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+
+	ciphertexts := encryptBatch(t, svc, 3)
+
+	err := svc.DecryptBatch(context.Background(), "other", ciphertexts, func(int, []byte) error { return nil })
+	if err == nil {
+		t.Fatal("expected error decrypting with another tenant's AAD")
+	}
+}
+
+func TestDecryptBatchReturnsCallbackError(t *testing.T) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+
+	ciphertexts := encryptBatch(t, svc, 50)
+	errStop := errors.New("stop")
+
+	err := svc.DecryptBatch(context.Background(), "t", ciphertexts, func(i int, _ []byte) error {
+		if i == 7 {
+			return errStop
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+}
+
+// The benchmarks decrypt and unmarshal a page of node properties, as a list
+// or search result does.
+const benchPageSize = 500
+
+func BenchmarkDecryptSequential(b *testing.B) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+	ctx := context.Background()
+	ciphertexts := encryptBatch(b, svc, benchPageSize)
+
+	b.ResetTimer()
+
+	for range b.N {
+		for _, ct := range ciphertexts {
+			plaintext, err := svc.Decrypt(ctx, "t", ct)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var props map[string]any
+			if err := json.Unmarshal(plaintext, &props); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDecryptBatch(b *testing.B) {
+	provider, _ := crypto.NewStaticProvider(testKeyHex)
+	svc := crypto.NewService(provider)
+	ctx := context.Background()
+	ciphertexts := encryptBatch(b, svc, benchPageSize)
+
+	b.ResetTimer()
+
+	for range b.N {
+		err := svc.DecryptBatch(ctx, "t", ciphertexts, func(_ int, plaintext []byte) error {
+			var props map[string]any
+			return json.Unmarshal(plaintext, &props)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -8,11 +8,17 @@
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
 )
 
+// envelopePrefix marks ciphertexts sealed with a wrapped data key, laid out
+// as envelopePrefix + wrapped key + "|" + base64 nonce+ciphertext.
+const envelopePrefix = "env:"
+
 // Service provides tenant-aware AES-256-GCM encryption and decryption.
 type Service struct {
-	keys KeyProvider
+	keys     KeyProvider
+	dataKeys DataKeyProvider
 }
 
 // NewService creates an encryption service backed by the given key provider.
@@ -20,22 +26,72 @@ func NewService(keys KeyProvider) *Service {
 	return &Service{keys: keys}
 }
 
+// NewEnvelopeService creates an encryption service that seals each value
+// with a data key from dataKeys and stores the wrapped key alongside it.
+func NewEnvelopeService(dataKeys DataKeyProvider) *Service {
+	return &Service{dataKeys: dataKeys}
+}
+
 // Encrypt encrypts plaintext with AES-256-GCM for the given tenant.
-// Returns base64-encoded nonce+ciphertext.
+// Returns base64-encoded nonce+ciphertext, prefixed by the wrapped data key
+// for an envelope service.
 func (s *Service) Encrypt(ctx context.Context, tenantID string, plaintext []byte) (string, error) {
+	if s.dataKeys != nil {
+		key, wrapped, err := s.dataKeys.DataKey(ctx, tenantID)
+		if err != nil {
+			return "", fmt.Errorf("crypto: get data key: %w", err)
+		}
+
+		sealed, err := seal(key, tenantID, plaintext)
+		if err != nil {
+			return "", err
+		}
+
+		return envelopePrefix + wrapped + "|" + sealed, nil
+	}
+
 	key, err := s.keys.GetKey(ctx, tenantID)
 	if err != nil {
 		return "", fmt.Errorf("crypto: get key: %w", err)
 	}
 
-	block, err := aes.NewCipher(key)
+	return seal(key, tenantID, plaintext)
+}
+
+// Decrypt decrypts a base64-encoded ciphertext (nonce prepended) for the given tenant.
+func (s *Service) Decrypt(ctx context.Context, tenantID, ciphertext string) ([]byte, error) {
+	if s.dataKeys != nil {
+		wrapped, body, err := splitEnvelope(ciphertext)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := s.dataKeys.UnwrapDataKeys(ctx, tenantID, []string{wrapped})
+		if err != nil {
+			return nil, fmt.Errorf("crypto: unwrap data key: %w", err)
+		}
+
+		key, ok := keys[wrapped]
+		if !ok {
+			return nil, fmt.Errorf("crypto: data key was not unwrapped")
+		}
+
+		return open(key, tenantID, body)
+	}
+
+	key, err := s.keys.GetKey(ctx, tenantID)
 	if err != nil {
-		return "", fmt.Errorf("crypto: new cipher: %w", err)
+		return nil, fmt.Errorf("crypto: get key: %w", err)
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	return open(key, tenantID, ciphertext)
+}
+
+// seal encrypts plaintext under key and returns base64 nonce+ciphertext.
+func seal(key []byte, tenantID string, plaintext []byte) (string, error) {
+	gcm, err := newGCM(key)
 	if err != nil {
-		return "", fmt.Errorf("crypto: new gcm: %w", err)
+		return "", err
 	}
 
 	nonce := make([]byte, gcm.NonceSize())
@@ -48,26 +104,16 @@ func (s *Service) Encrypt(ctx context.Context, tenantID string, plaintext []byte
 	return base64.StdEncoding.EncodeToString(sealed), nil
 }
 
-// Decrypt decrypts a base64-encoded ciphertext (nonce prepended) for the given tenant.
-func (s *Service) Decrypt(ctx context.Context, tenantID, ciphertext string) ([]byte, error) {
-	key, err := s.keys.GetKey(ctx, tenantID)
-	if err != nil {
-		return nil, fmt.Errorf("crypto: get key: %w", err)
-	}
-
+// open decrypts base64 nonce+ciphertext under key.
+func open(key []byte, tenantID, ciphertext string) ([]byte, error) {
 	data, err := base64.StdEncoding.DecodeString(ciphertext)
 	if err != nil {
 		return nil, fmt.Errorf("crypto: base64 decode: %w", err)
 	}
 
-	block, err := aes.NewCipher(key)
+	gcm, err := newGCM(key)
 	if err != nil {
-		return nil, fmt.Errorf("crypto: new cipher: %w", err)
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return nil, fmt.Errorf("crypto: new gcm: %w", err)
+		return nil, err
 	}
 
 	nonceSize := gcm.NonceSize()
@@ -84,3 +130,33 @@ func (s *Service) Decrypt(ctx context.Context, tenantID, ciphertext string) ([]b
 
 	return plaintext, nil
 }
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: new cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("crypto: new gcm: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// splitEnvelope separates an envelope ciphertext into its wrapped data key
+// and its base64 nonce+ciphertext.
+func splitEnvelope(ciphertext string) (wrapped, body string, err error) {
+	rest, ok := strings.CutPrefix(ciphertext, envelopePrefix)
+	if !ok {
+		return "", "", fmt.Errorf("crypto: ciphertext is not an envelope")
+	}
+
+	wrapped, body, ok = strings.Cut(rest, "|")
+	if !ok || wrapped == "" {
+		return "", "", fmt.Errorf("crypto: malformed envelope")
+	}
+
+	return wrapped, body, nil
+}
@@ -8,3 +8,16 @@ type KeyProvider interface {
 	// GetKey returns the 32-byte AES-256 key for the given tenant.
 	GetKey(ctx context.Context, tenantID string) ([]byte, error)
 }
+
+// DataKeyProvider issues and unwraps per-tenant data keys for envelope
+// encryption. The tenant's key-encryption key never leaves the provider;
+// only data keys, which it wraps, are handed out.
+type DataKeyProvider interface {
+	// DataKey returns a 32-byte data key for encrypting new values for the
+	// tenant, together with its wrapped form.
+	DataKey(ctx context.Context, tenantID string) (key []byte, wrapped string, err error)
+
+	// UnwrapDataKeys returns the plaintext of each wrapped data key, keyed
+	// by its wrapped form.
+	UnwrapDataKeys(ctx context.Context, tenantID string, wrapped []string) (map[string][]byte, error)
+}
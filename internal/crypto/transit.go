@@ -0,0 +1,301 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/persistorai/persistor/internal/config"
+)
+
+// Transit data key limits.
+const (
+	dataKeyTTL          = 15 * time.Minute // how long one data key seals new values and stays cached
+	maxUnwrappedKeys    = 10000            // cap on cached unwrapped data keys
+	maxTransitBatch     = 250              // wrapped keys per transit decrypt call
+	transitKeyPrefix    = "persistor-"     // transit key name is this plus the tenant ID
+	defaultTransitMount = "transit"
+)
+
+// TransitProvider performs envelope encryption with HashiCorp Vault's
+// transit engine. Each tenant has a transit key, persistor-<tenant ID>,
+// that never leaves Vault. Vault generates the data keys that seal
+// property values and unwraps them again on read; unwrapped keys are
+// cached for dataKeyTTL and each unwrap request covers many keys.
+type TransitProvider struct {
+	addr   string
+	mount  string
+	token  config.Secret
+	client *http.Client
+	group  singleflight.Group
+
+	mu        sync.Mutex
+	current   map[string]transitDataKey // tenant ID → key sealing new values
+	unwrapped map[string]cachedKey      // wrapped key → plaintext key
+}
+
+type transitDataKey struct {
+	key       []byte
+	wrapped   string
+	fetchedAt time.Time
+}
+
+// NewTransitProvider creates a TransitProvider for the transit engine
+// mounted at mount (default "transit") on the Vault server at addr.
+func NewTransitProvider(addr, token, mount string) *TransitProvider {
+	if mount == "" {
+		mount = defaultTransitMount
+	}
+
+	return &TransitProvider{
+		addr:  strings.TrimRight(addr, "/"),
+		mount: strings.Trim(mount, "/"),
+		token: config.Secret(token),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+		},
+		current:   make(map[string]transitDataKey),
+		unwrapped: make(map[string]cachedKey),
+	}
+}
+
+// DataKey returns the tenant's current data key, asking Vault for a new one
+// once the current key is older than dataKeyTTL.
+func (p *TransitProvider) DataKey(ctx context.Context, tenantID string) ([]byte, string, error) {
+	p.mu.Lock()
+	dk, ok := p.current[tenantID]
+	p.mu.Unlock()
+
+	if ok && time.Since(dk.fetchedAt) < dataKeyTTL {
+		return append([]byte(nil), dk.key...), dk.wrapped, nil
+	}
+
+	val, err, _ := p.group.Do("datakey/"+tenantID, func() (any, error) {
+		return p.generateDataKey(ctx, tenantID)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	dk, ok = val.(transitDataKey)
+	if !ok {
+		return nil, "", fmt.Errorf("crypto/transit: unexpected singleflight result type %T", val)
+	}
+
+	return append([]byte(nil), dk.key...), dk.wrapped, nil
+}
+
+// UnwrapDataKeys returns the plaintext of each wrapped data key, serving
+// cached keys locally and unwrapping the rest in batched transit calls.
+func (p *TransitProvider) UnwrapDataKeys(ctx context.Context, tenantID string, wrapped []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(wrapped))
+
+	var missing []string
+
+	queued := make(map[string]bool)
+
+	p.mu.Lock()
+	for _, w := range wrapped {
+		if entry, ok := p.unwrapped[w]; ok && time.Since(entry.fetchedAt) < dataKeyTTL {
+			out[w] = append([]byte(nil), entry.key...)
+		} else if !queued[w] {
+			queued[w] = true
+			missing = append(missing, w)
+		}
+	}
+	p.mu.Unlock()
+
+	for len(missing) > 0 {
+		n := min(len(missing), maxTransitBatch)
+
+		keys, err := p.decryptDataKeys(ctx, tenantID, missing[:n])
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		p.evictLocked(len(keys))
+		for w, k := range keys {
+			p.unwrapped[w] = cachedKey{key: append([]byte(nil), k...), fetchedAt: time.Now()}
+			out[w] = k
+		}
+		p.mu.Unlock()
+
+		missing = missing[n:]
+	}
+
+	return out, nil
+}
+
+// evictLocked makes room for n more unwrapped keys, dropping expired
+// entries first and then arbitrary ones. p.mu must be held.
+func (p *TransitProvider) evictLocked(n int) {
+	if len(p.unwrapped)+n <= maxUnwrappedKeys {
+		return
+	}
+
+	for w, entry := range p.unwrapped {
+		if time.Since(entry.fetchedAt) >= dataKeyTTL {
+			delete(p.unwrapped, w)
+		}
+	}
+
+	for w := range p.unwrapped {
+		if len(p.unwrapped)+n <= maxUnwrappedKeys {
+			return
+		}
+
+		delete(p.unwrapped, w)
+	}
+}
+
+func (p *TransitProvider) generateDataKey(ctx context.Context, tenantID string) (transitDataKey, error) {
+	var resp struct {
+		Data struct {
+			Plaintext  string `json:"plaintext"`
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+
+	if err := p.call(ctx, tenantID, "datakey/plaintext", map[string]any{"bits": 256}, &resp); err != nil {
+		return transitDataKey{}, err
+	}
+
+	key, err := decodeDataKey(resp.Data.Plaintext)
+	if err != nil {
+		return transitDataKey{}, err
+	}
+
+	if resp.Data.Ciphertext == "" {
+		return transitDataKey{}, fmt.Errorf("crypto/transit: data key response has no ciphertext")
+	}
+
+	dk := transitDataKey{key: key, wrapped: resp.Data.Ciphertext, fetchedAt: time.Now()}
+
+	p.mu.Lock()
+	p.current[tenantID] = dk
+	p.evictLocked(1)
+	p.unwrapped[dk.wrapped] = cachedKey{key: append([]byte(nil), key...), fetchedAt: dk.fetchedAt}
+	p.mu.Unlock()
+
+	return dk, nil
+}
+
+// decryptDataKeys unwraps wrapped data keys with one batched transit call.
+func (p *TransitProvider) decryptDataKeys(ctx context.Context, tenantID string, wrapped []string) (map[string][]byte, error) {
+	input := make([]map[string]string, len(wrapped))
+	for i, w := range wrapped {
+		input[i] = map[string]string{"ciphertext": w}
+	}
+
+	var resp struct {
+		Data struct {
+			BatchResults []struct {
+				Plaintext string `json:"plaintext"`
+				Error     string `json:"error"`
+			} `json:"batch_results"`
+		} `json:"data"`
+	}
+
+	if err := p.call(ctx, tenantID, "decrypt", map[string]any{"batch_input": input}, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Data.BatchResults) != len(wrapped) {
+		return nil, fmt.Errorf("crypto/transit: decrypt returned %d results for %d keys", len(resp.Data.BatchResults), len(wrapped))
+	}
+
+	keys := make(map[string][]byte, len(wrapped))
+
+	for i, r := range resp.Data.BatchResults {
+		if r.Error != "" {
+			return nil, fmt.Errorf("crypto/transit: unwrapping data key: %s", r.Error)
+		}
+
+		key, err := decodeDataKey(r.Plaintext)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[wrapped[i]] = key
+	}
+
+	return keys, nil
+}
+
+// call posts body to the transit operation op for the tenant's key and
+// decodes the response into out.
+func (p *TransitProvider) call(ctx context.Context, tenantID, op string, body, out any) error {
+	if !uuidPattern.MatchString(tenantID) {
+		return fmt.Errorf("crypto/transit: invalid tenant ID format: %q", tenantID)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("crypto/transit: marshal request: %w", err)
+	}
+
+	reqURL := fmt.Sprintf("%s/v1/%s/%s/%s", p.addr, p.mount, op, url.PathEscape(transitKeyPrefix+tenantID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("crypto/transit: create request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", p.token.Value())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("crypto/transit: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Limit all body reads to 1 MB to prevent memory exhaustion.
+	limitedBody := io.LimitReader(resp.Body, 1<<20)
+
+	if resp.StatusCode != http.StatusOK {
+		msg, readErr := io.ReadAll(limitedBody)
+		if readErr != nil {
+			return fmt.Errorf("crypto/transit: %s: unexpected status %d (failed to read body: %w)", op, resp.StatusCode, readErr)
+		}
+
+		return fmt.Errorf("crypto/transit: %s for tenant %q: unexpected status %d: %s — check the transit key %s/keys/%s%s exists",
+			op, tenantID, resp.StatusCode, string(msg), p.mount, transitKeyPrefix, tenantID)
+	}
+
+	if err := json.NewDecoder(limitedBody).Decode(out); err != nil {
+		return fmt.Errorf("crypto/transit: decode response: %w", err)
+	}
+
+	return nil
+}
+
+func decodeDataKey(b64 string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, fmt.Errorf("crypto/transit: decode data key: %w", err)
+	}
+
+	if len(key) != 32 {
+		return nil, fmt.Errorf("crypto/transit: data key must be 32 bytes, got %d", len(key))
+	}
+
+	return key, nil
+}
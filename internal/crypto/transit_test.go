@@ -0,0 +1,171 @@
+package crypto_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/crypto"
+)
+
+const transitTenant = "0b9a7c1e-3f4d-4e5a-8b6c-7d8e9f0a1b2c"
+
+// fakeTransit imitates Vault's transit engine. Its "wrapped" keys are the
+// plaintext key in base64, which is enough to exercise the protocol.
+type fakeTransit struct {
+	datakeyCalls atomic.Int64
+	decryptCalls atomic.Int64
+	decryptKeys  atomic.Int64
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "test-token" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/v1/transit/datakey/plaintext/persistor-"+transitTenant):
+		f.datakeyCalls.Add(1)
+
+		key := make([]byte, 32)
+		rand.Read(key) //nolint:errcheck // crypto/rand.Read never fails.
+		b64 := base64.StdEncoding.EncodeToString(key)
+
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]string{ //nolint:errcheck // test server.
+			"plaintext":  b64,
+			"ciphertext": "vault:v1:" + b64,
+		}})
+	case strings.HasPrefix(r.URL.Path, "/v1/transit/decrypt/persistor-"+transitTenant):
+		f.decryptCalls.Add(1)
+
+		var req struct {
+			BatchInput []struct {
+				Ciphertext string `json:"ciphertext"`
+			} `json:"batch_input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		f.decryptKeys.Add(int64(len(req.BatchInput)))
+
+		results := make([]map[string]string, len(req.BatchInput))
+		for i, in := range req.BatchInput {
+			results[i] = map[string]string{"plaintext": strings.TrimPrefix(in.Ciphertext, "vault:v1:")}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"batch_results": results}}) //nolint:errcheck // test server.
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestTransitEnvelopeRoundtrip(t *testing.T) {
+	fake := &fakeTransit{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	svc := crypto.NewEnvelopeService(crypto.NewTransitProvider(srv.URL, "test-token", ""))
+	ctx := context.Background()
+
+	encrypted, err := svc.Encrypt(ctx, transitTenant, []byte("hello, transit"))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	if strings.Contains(encrypted, "hello") {
+		t.Fatal("ciphertext leaks plaintext")
+	}
+
+	decrypted, err := svc.Decrypt(ctx, transitTenant, encrypted)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+
+	if string(decrypted) != "hello, transit" {
+		t.Fatalf("got %q", decrypted)
+	}
+
+	// The data key is reused and its unwrapped form is cached.
+	if _, err := svc.Encrypt(ctx, transitTenant, []byte("again")); err != nil {
+		t.Fatalf("second encrypt: %v", err)
+	}
+
+	if n := fake.datakeyCalls.Load(); n != 1 {
+		t.Errorf("datakey calls = %d, want 1", n)
+	}
+
+	if n := fake.decryptCalls.Load(); n != 0 {
+		t.Errorf("decrypt calls = %d, want 0 (cached)", n)
+	}
+}
+
+func TestTransitDecryptBatchUnwrapsOnce(t *testing.T) {
+	fake := &fakeTransit{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	ctx := context.Background()
+	writer := crypto.NewEnvelopeService(crypto.NewTransitProvider(srv.URL, "test-token", "transit"))
+
+	ciphertexts := make([]string, 100)
+	for i := range ciphertexts {
+		ct, err := writer.Encrypt(ctx, transitTenant, []byte("value"))
+		if err != nil {
+			t.Fatalf("encrypt: %v", err)
+		}
+
+		ciphertexts[i] = ct
+	}
+
+	// A fresh provider has nothing cached, as after a restart.
+	reader := crypto.NewEnvelopeService(crypto.NewTransitProvider(srv.URL, "test-token", "transit"))
+
+	err := reader.DecryptBatch(ctx, transitTenant, ciphertexts, func(_ int, plaintext []byte) error {
+		if string(plaintext) != "value" {
+			t.Errorf("plaintext = %q", plaintext)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("decrypt batch: %v", err)
+	}
+
+	if n := fake.decryptCalls.Load(); n != 1 {
+		t.Errorf("decrypt calls = %d, want 1", n)
+	}
+
+	if n := fake.decryptKeys.Load(); n != 1 {
+		t.Errorf("unwrapped keys = %d, want 1 (one shared data key)", n)
+	}
+}
+
+func TestTransitRejectsBadInput(t *testing.T) {
+	srv := httptest.NewServer(&fakeTransit{})
+	defer srv.Close()
+
+	svc := crypto.NewEnvelopeService(crypto.NewTransitProvider(srv.URL, "test-token", ""))
+	ctx := context.Background()
+
+	if _, err := svc.Encrypt(ctx, "../../sys/seal", []byte("x")); err == nil {
+		t.Fatal("expected error for non-UUID tenant")
+	}
+
+	if _, err := svc.Decrypt(ctx, transitTenant, "bm90IGFuIGVudmVsb3Bl"); err == nil {
+		t.Fatal("expected error for non-envelope ciphertext")
+	}
+
+	wrongToken := crypto.NewEnvelopeService(crypto.NewTransitProvider(srv.URL, "bad-token", ""))
+	if _, err := wrongToken.Encrypt(ctx, transitTenant, []byte("x")); err == nil {
+		t.Fatal("expected error for rejected token")
+	}
+}
@@ -0,0 +1,13 @@
+-- +goose Up
+-- A rotated-out key stays valid until previous_api_key_expires_at so clients
+-- can switch to the new key without downtime.
+ALTER TABLE tenants
+    ADD COLUMN previous_api_key_hash       TEXT UNIQUE,
+    ADD COLUMN previous_api_key_expires_at TIMESTAMPTZ,
+    ADD COLUMN api_key_rotated_at          TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS api_key_rotated_at,
+    DROP COLUMN IF EXISTS previous_api_key_expires_at,
+    DROP COLUMN IF EXISTS previous_api_key_hash;
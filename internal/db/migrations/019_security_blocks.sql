@@ -0,0 +1,16 @@
+-- +goose Up
+-- Brute-force guard state shared by all replicas. Rows are keyed by the
+-- SHA-256 hash of the presented API key, not by tenant, because failures
+-- happen before a tenant is known; the table therefore has no RLS.
+CREATE TABLE security_blocks (
+    key_hash         TEXT PRIMARY KEY,
+    attempts         INT NOT NULL DEFAULT 1,
+    first_failure_at TIMESTAMPTZ NOT NULL,
+    blocked_until    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_security_blocks_blocked_until ON security_blocks (blocked_until)
+    WHERE blocked_until IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS security_blocks;
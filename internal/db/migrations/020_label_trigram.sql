@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Trigram index on lowercased labels for duplicate-node detection, which
+-- pairs nodes whose labels are similar under pg_trgm's % operator.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX CONCURRENTLY idx_nodes_label_trgm ON kg_nodes USING gin (lower(label) gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_nodes_label_trgm;
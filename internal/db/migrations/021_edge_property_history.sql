@@ -0,0 +1,30 @@
+-- +goose Up
+-- Property change history for edges, mirroring kg_property_history for nodes.
+-- Rows are keyed by the edge's (source, target, relation) and are kept when
+-- the edge is deleted.
+CREATE TABLE kg_edge_property_history (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    source TEXT NOT NULL,
+    target TEXT NOT NULL,
+    relation TEXT NOT NULL,
+    property_key TEXT NOT NULL,
+    old_value JSONB,
+    new_value JSONB,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reason TEXT,
+    changed_by TEXT
+);
+
+ALTER TABLE kg_edge_property_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_edge_property_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_edge_property_history ON kg_edge_property_history
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_edge_property_history_edge
+    ON kg_edge_property_history (tenant_id, source, target, relation, changed_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_edge_property_history;
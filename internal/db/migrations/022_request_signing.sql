@@ -0,0 +1,12 @@
+-- +goose Up
+-- Tenants with request signing enabled must sign every request with an HMAC
+-- secret. The secret is encrypted with the tenant's data key because the
+-- server needs it back to verify signatures.
+ALTER TABLE tenants
+    ADD COLUMN signing_secret     TEXT,
+    ADD COLUMN signing_enabled_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS signing_enabled_at,
+    DROP COLUMN IF EXISTS signing_secret;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- kg_property_history also records changes to a node's label, type, and
+-- salience. field says what changed; property_key is empty for those rows.
+ALTER TABLE kg_property_history
+    ADD COLUMN field TEXT NOT NULL DEFAULT 'property'
+        CHECK (field IN ('property', 'label', 'type', 'salience'));
+
+-- +goose Down
+DELETE FROM kg_property_history WHERE field <> 'property';
+ALTER TABLE kg_property_history DROP COLUMN IF EXISTS field;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Per-tenant retention for kg_property_history and kg_edge_property_history.
+-- NULL keeps history forever / never compacts it.
+ALTER TABLE tenants
+    ADD COLUMN history_retention_days     INTEGER CHECK (history_retention_days > 0),
+    ADD COLUMN history_compact_after_days INTEGER CHECK (history_compact_after_days > 0);
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS history_compact_after_days,
+    DROP COLUMN IF EXISTS history_retention_days;
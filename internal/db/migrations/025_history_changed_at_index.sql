@@ -0,0 +1,12 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Pruning and compaction scan each tenant's history by age.
+CREATE INDEX CONCURRENTLY idx_property_history_changed_at
+    ON kg_property_history (tenant_id, changed_at);
+
+CREATE INDEX CONCURRENTLY idx_edge_property_history_changed_at
+    ON kg_edge_property_history (tenant_id, changed_at);
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS idx_edge_property_history_changed_at;
+DROP INDEX CONCURRENTLY IF EXISTS idx_property_history_changed_at;
@@ -0,0 +1,46 @@
+-- +goose Up
+-- Staging area for chunked imports. Chunks are encrypted like node
+-- properties and applied in a single transaction on commit.
+CREATE TABLE kg_import_sessions (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id      UUID NOT NULL,
+    status         TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'committed')),
+    schema_version INTEGER NOT NULL,
+    options        JSONB NOT NULL DEFAULT '{}',
+    result         JSONB,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at     TIMESTAMPTZ NOT NULL,
+    committed_at   TIMESTAMPTZ
+);
+
+ALTER TABLE kg_import_sessions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_import_sessions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_import_sessions ON kg_import_sessions
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_import_sessions_tenant_expires ON kg_import_sessions(tenant_id, expires_at);
+
+CREATE TABLE kg_import_chunks (
+    tenant_id  UUID NOT NULL,
+    session_id UUID NOT NULL REFERENCES kg_import_sessions(id) ON DELETE CASCADE,
+    seq        INTEGER NOT NULL CHECK (seq >= 0),
+    node_count INTEGER NOT NULL,
+    edge_count INTEGER NOT NULL,
+    payload    TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (session_id, seq)
+);
+
+ALTER TABLE kg_import_chunks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_import_chunks FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_import_chunks ON kg_import_chunks
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_import_chunks;
+DROP TABLE IF EXISTS kg_import_sessions;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Previews issued before a delete-by-filter. The delete must present a
+-- preview token for the same filter and still match the previewed count.
+CREATE TABLE kg_delete_previews (
+    token      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id  UUID NOT NULL,
+    filter     JSONB NOT NULL,
+    node_count INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE kg_delete_previews ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_delete_previews FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_delete_previews ON kg_delete_previews
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_delete_previews_tenant_expires ON kg_delete_previews(tenant_id, expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_delete_previews;
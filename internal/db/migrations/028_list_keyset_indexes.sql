@@ -0,0 +1,22 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Keyset pagination for ListNodes and ListEdges orders by the list sort keys
+-- plus the primary key as a tie-breaker. These indexes cover that order so a
+-- page starting after a cursor is an index range scan instead of a sort.
+-- The node indexes supersede idx_nodes_tenant_salience_updated and
+-- idx_nodes_tenant_type_salience, which lacked the id tie-breaker.
+CREATE INDEX CONCURRENTLY idx_nodes_tenant_salience_keyset
+    ON kg_nodes(tenant_id, salience_score DESC, updated_at DESC, id DESC);
+CREATE INDEX CONCURRENTLY idx_nodes_tenant_type_salience_keyset
+    ON kg_nodes(tenant_id, type, salience_score DESC, updated_at DESC, id DESC);
+CREATE INDEX CONCURRENTLY idx_edges_tenant_updated_keyset
+    ON kg_edges(tenant_id, updated_at DESC, source DESC, target DESC, relation DESC);
+DROP INDEX CONCURRENTLY IF EXISTS idx_nodes_tenant_salience_updated;
+DROP INDEX CONCURRENTLY IF EXISTS idx_nodes_tenant_type_salience;
+
+-- +goose Down
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_nodes_tenant_salience_updated ON kg_nodes(tenant_id, salience_score DESC, updated_at DESC);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_nodes_tenant_type_salience ON kg_nodes(tenant_id, type, salience_score DESC, updated_at DESC);
+DROP INDEX CONCURRENTLY IF EXISTS idx_edges_tenant_updated_keyset;
+DROP INDEX CONCURRENTLY IF EXISTS idx_nodes_tenant_type_salience_keyset;
+DROP INDEX CONCURRENTLY IF EXISTS idx_nodes_tenant_salience_keyset;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- Idempotency-Key headers seen on create requests. A row is claimed before
+-- the request runs and holds its response once it finishes, so a retry with
+-- the same key gets the first response back instead of creating a duplicate.
+CREATE TABLE kg_idempotency_keys (
+    tenant_id     UUID NOT NULL,
+    key           TEXT NOT NULL,
+    fingerprint   TEXT NOT NULL,
+    status        INTEGER,
+    response      BYTEA,
+    locked_until  TIMESTAMPTZ NOT NULL,
+    expires_at    TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, key)
+);
+
+ALTER TABLE kg_idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_idempotency_keys FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_idempotency_keys ON kg_idempotency_keys
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_idempotency_keys_tenant_expires ON kg_idempotency_keys(tenant_id, expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_idempotency_keys;
@@ -0,0 +1,91 @@
+-- +goose Up
+-- Branches stage speculative edits without touching the live graph. Staged
+-- rows are full copies of the node or edge as the branch sees it, with
+-- properties encrypted like kg_nodes; reads resolve a branch row over the
+-- live row of the same key. base_updated_at is the live row's updated_at when
+-- the row was first staged (NULL if it did not exist) and detects conflicting
+-- changes on merge.
+CREATE TABLE kg_branches (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id   UUID NOT NULL,
+    name        TEXT NOT NULL DEFAULT '' CONSTRAINT chk_branch_name_len CHECK (length(name) <= 255),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE kg_branches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_branches FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_branches ON kg_branches
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_branches_tenant_created ON kg_branches(tenant_id, created_at);
+
+CREATE TABLE kg_branch_nodes (
+    tenant_id        UUID NOT NULL,
+    branch_id        UUID NOT NULL REFERENCES kg_branches(id) ON DELETE CASCADE,
+    id               TEXT NOT NULL,
+    type             TEXT NOT NULL,
+    label            TEXT NOT NULL,
+    properties       JSONB NOT NULL DEFAULT '{}',
+    access_count     INTEGER NOT NULL DEFAULT 0,
+    last_accessed    TIMESTAMPTZ,
+    salience_score   REAL NOT NULL DEFAULT 1.0,
+    superseded_by    TEXT,
+    user_boosted     BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    search_text      TEXT NOT NULL DEFAULT '',
+    deleted          BOOLEAN NOT NULL DEFAULT FALSE,
+    base_updated_at  TIMESTAMPTZ,
+
+    PRIMARY KEY (branch_id, id)
+);
+
+ALTER TABLE kg_branch_nodes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_branch_nodes FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_branch_nodes ON kg_branch_nodes
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE TABLE kg_branch_edges (
+    tenant_id        UUID NOT NULL,
+    branch_id        UUID NOT NULL REFERENCES kg_branches(id) ON DELETE CASCADE,
+    source           TEXT NOT NULL,
+    target           TEXT NOT NULL,
+    relation         TEXT NOT NULL,
+    properties       JSONB NOT NULL DEFAULT '{}',
+    weight           REAL NOT NULL DEFAULT 1.0,
+    access_count     INTEGER NOT NULL DEFAULT 0,
+    last_accessed    TIMESTAMPTZ,
+    salience_score   REAL NOT NULL DEFAULT 1.0,
+    superseded_by    TEXT,
+    user_boosted     BOOLEAN NOT NULL DEFAULT FALSE,
+    date_start       TEXT,
+    date_end         TEXT,
+    date_lower       DATE,
+    date_upper       DATE,
+    is_current       BOOLEAN,
+    date_qualifier   TEXT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted          BOOLEAN NOT NULL DEFAULT FALSE,
+    base_updated_at  TIMESTAMPTZ,
+
+    PRIMARY KEY (branch_id, source, target, relation)
+);
+
+ALTER TABLE kg_branch_edges ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_branch_edges FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_branch_edges ON kg_branch_edges
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_branch_edges_target ON kg_branch_edges(branch_id, target);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_branch_edges;
+DROP TABLE IF EXISTS kg_branch_nodes;
+DROP TABLE IF EXISTS kg_branches;
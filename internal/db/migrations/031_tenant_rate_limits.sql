@@ -0,0 +1,11 @@
+-- +goose Up
+-- Per-tenant rate limit overrides. NULL uses the default for the tenant's
+-- plan; both columns must be set for an override to apply.
+ALTER TABLE tenants
+    ADD COLUMN rate_limit_per_sec INTEGER CONSTRAINT chk_tenant_rate_limit_per_sec CHECK (rate_limit_per_sec > 0),
+    ADD COLUMN rate_limit_burst   INTEGER CONSTRAINT chk_tenant_rate_limit_burst CHECK (rate_limit_burst > 0);
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS rate_limit_burst,
+    DROP COLUMN IF EXISTS rate_limit_per_sec;
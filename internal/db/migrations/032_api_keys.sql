@@ -0,0 +1,21 @@
+-- +goose Up
+-- Named API keys, in addition to the tenant's primary key on the tenants
+-- table. Keys are looked up by hash before a tenant is known, so like
+-- tenants the table has no RLS; every other query filters on tenant_id.
+CREATE TABLE api_keys (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id    UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name         TEXT NOT NULL CONSTRAINT chk_api_keys_name_len CHECK (length(name) BETWEEN 1 AND 100),
+    key_hash     TEXT NOT NULL UNIQUE,
+    scope        TEXT NOT NULL DEFAULT 'read_write'
+                 CONSTRAINT chk_api_keys_scope CHECK (scope IN ('read_write', 'admin')),
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_tenant_created ON api_keys (tenant_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Per-tag mean of the embeddings of nodes carrying the tag in their "tags"
+-- property, used to suggest tags for a node. Tags live in encrypted node
+-- properties, so centroids are rebuilt from decrypted nodes by the
+-- application. Tag names are stored in plaintext, like node labels.
+CREATE TABLE kg_tag_centroids (
+    tenant_id  UUID NOT NULL,
+    tag        TEXT NOT NULL CONSTRAINT chk_tag_centroid_tag_len CHECK (length(tag) <= 255),
+    centroid   vector(1024) NOT NULL,
+    node_count INTEGER NOT NULL,
+    built_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, tag)
+);
+
+ALTER TABLE kg_tag_centroids ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_tag_centroids FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_tag_centroids ON kg_tag_centroids
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_tag_centroids;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Read-only and search-only scopes for dashboards and agents that should not
+-- write. Scopes are ordered search < read < read_write < admin.
+ALTER TABLE tenants
+    DROP CONSTRAINT chk_tenants_api_key_scope,
+    ADD CONSTRAINT chk_tenants_api_key_scope
+        CHECK (api_key_scope IN ('search', 'read', 'read_write', 'admin'));
+
+ALTER TABLE api_keys
+    DROP CONSTRAINT chk_api_keys_scope,
+    ADD CONSTRAINT chk_api_keys_scope
+        CHECK (scope IN ('search', 'read', 'read_write', 'admin'));
+
+-- +goose Down
+-- The older schema cannot express the narrower scopes. Named keys using them
+-- are revoked rather than widened; a tenant's primary key has no revocation,
+-- so it falls back to read_write.
+UPDATE tenants SET api_key_scope = 'read_write' WHERE api_key_scope IN ('search', 'read');
+UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE scope IN ('search', 'read');
+UPDATE api_keys SET scope = 'read_write' WHERE scope IN ('search', 'read');
+
+ALTER TABLE tenants
+    DROP CONSTRAINT chk_tenants_api_key_scope,
+    ADD CONSTRAINT chk_tenants_api_key_scope CHECK (api_key_scope IN ('read_write', 'admin'));
+
+ALTER TABLE api_keys
+    DROP CONSTRAINT chk_api_keys_scope,
+    ADD CONSTRAINT chk_api_keys_scope CHECK (scope IN ('read_write', 'admin'));
@@ -0,0 +1,49 @@
+-- +goose Up
+-- Nodes accessed together in one session: either a client-supplied session
+-- ID, or a single search whose top results count as accessed together.
+-- Rows older than the inference window are pruned by each inference run.
+CREATE TABLE kg_access_sessions (
+    tenant_id   UUID NOT NULL,
+    session_id  TEXT NOT NULL CONSTRAINT chk_access_session_id_len CHECK (length(session_id) BETWEEN 1 AND 255),
+    node_id     TEXT NOT NULL,
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, session_id, node_id)
+);
+
+CREATE INDEX idx_access_sessions_tenant_accessed ON kg_access_sessions (tenant_id, accessed_at);
+
+ALTER TABLE kg_access_sessions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_access_sessions FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_access_sessions ON kg_access_sessions
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- Edges created by co-access inference, so later runs reweight or remove
+-- only their own edges and never touch one a client created. Paths that
+-- delete or re-point edges update these rows in the same transaction.
+CREATE TABLE kg_inferred_edges (
+    tenant_id  UUID NOT NULL,
+    source     TEXT NOT NULL,
+    target     TEXT NOT NULL,
+    relation   TEXT NOT NULL,
+    score      REAL NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, source, target, relation)
+);
+
+ALTER TABLE kg_inferred_edges ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_inferred_edges FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_inferred_edges ON kg_inferred_edges
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+INSERT INTO relation_types (name, description) VALUES
+('related_to', 'A is associated with B; inferred from co-access')
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_inferred_edges;
+DROP TABLE IF EXISTS kg_access_sessions;
+DELETE FROM relation_types WHERE name = 'related_to' AND tenant_id IS NULL;
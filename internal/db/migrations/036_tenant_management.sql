@@ -0,0 +1,16 @@
+-- +goose Up
+-- Operator tenants can manage other tenants through /admin/tenants with an
+-- admin-scoped key. Suspended tenants' keys are rejected until resumed.
+ALTER TABLE tenants
+    ADD COLUMN operator     BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN suspended_at TIMESTAMPTZ;
+
+-- A single-tenant install's only tenant is its operator. Multi-tenant
+-- installs choose theirs explicitly:
+--   UPDATE tenants SET operator = TRUE WHERE id = '<tenant id>';
+UPDATE tenants SET operator = TRUE WHERE (SELECT COUNT(*) FROM tenants) = 1;
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS suspended_at,
+    DROP COLUMN IF EXISTS operator;
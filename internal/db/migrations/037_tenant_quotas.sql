@@ -0,0 +1,13 @@
+-- +goose Up
+-- Per-tenant quotas. NULL means unlimited. Storage counts the on-disk size
+-- of the tenant's node and edge rows, embeddings included.
+ALTER TABLE tenants
+    ADD COLUMN max_nodes         BIGINT CONSTRAINT chk_tenant_max_nodes CHECK (max_nodes > 0),
+    ADD COLUMN max_edges         BIGINT CONSTRAINT chk_tenant_max_edges CHECK (max_edges > 0),
+    ADD COLUMN max_storage_bytes BIGINT CONSTRAINT chk_tenant_max_storage_bytes CHECK (max_storage_bytes > 0);
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS max_storage_bytes,
+    DROP COLUMN IF EXISTS max_edges,
+    DROP COLUMN IF EXISTS max_nodes;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- Change-feed events, kept so WebSocket and SSE clients that were
+-- disconnected for longer than the in-memory replay buffer covers can still
+-- resume without a full refresh. IDs come from kg_event_sequences, so every
+-- replica logs and sends an event under the same ID. Rows older than the
+-- configured retention are removed as new events arrive.
+CREATE TABLE kg_event_log (
+    tenant_id  UUID NOT NULL,
+    id         BIGINT NOT NULL,
+    type       TEXT NOT NULL,
+    data       JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, id)
+);
+
+CREATE INDEX idx_event_log_tenant_created ON kg_event_log (tenant_id, created_at);
+
+ALTER TABLE kg_event_log ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_event_log FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_event_log ON kg_event_log
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- The last change-feed event ID assigned to each tenant. Each notification
+-- takes the next ID in the transaction that sends it, and the row lock keeps
+-- IDs consecutive and in the order the notifications are delivered.
+CREATE TABLE kg_event_sequences (
+    tenant_id  UUID PRIMARY KEY,
+    last_id    BIGINT NOT NULL
+);
+
+ALTER TABLE kg_event_sequences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_event_sequences FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_event_sequences ON kg_event_sequences
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_event_sequences;
+DROP TABLE IF EXISTS kg_event_log;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Each node's salience score as it was just before the last recalculation,
+-- so the decay report can show which nodes the recalculation demoted most.
+-- Node deletes remove a node's row in the same transaction.
+CREATE TABLE kg_salience_snapshots (
+    tenant_id UUID NOT NULL,
+    node_id   TEXT NOT NULL,
+    score     REAL NOT NULL,
+    taken_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, node_id)
+);
+
+ALTER TABLE kg_salience_snapshots ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_salience_snapshots FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_salience_snapshots ON kg_salience_snapshots
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_salience_snapshots;
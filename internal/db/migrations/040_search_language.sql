@@ -0,0 +1,29 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- The text search configuration each node's search text is indexed with.
+-- Nodes written before language detection, or with it off, stay English.
+ALTER TABLE kg_nodes
+    ADD COLUMN search_lang regconfig NOT NULL DEFAULT 'english';
+
+ALTER TABLE kg_branch_nodes
+    ADD COLUMN search_lang regconfig NOT NULL DEFAULT 'english';
+
+-- Dropping search_tsv drops idx_nodes_fts with it.
+ALTER TABLE kg_nodes
+    DROP COLUMN search_tsv,
+    ADD COLUMN search_tsv tsvector GENERATED ALWAYS AS (to_tsvector(search_lang, search_text)) STORED;
+
+CREATE INDEX CONCURRENTLY idx_nodes_fts ON kg_nodes USING gin (search_tsv);
+
+-- +goose Down
+ALTER TABLE kg_nodes
+    DROP COLUMN search_tsv,
+    ADD COLUMN search_tsv tsvector GENERATED ALWAYS AS (to_tsvector('english', search_text)) STORED;
+
+CREATE INDEX CONCURRENTLY idx_nodes_fts ON kg_nodes USING gin (search_tsv);
+
+ALTER TABLE kg_branch_nodes
+    DROP COLUMN IF EXISTS search_lang;
+
+ALTER TABLE kg_nodes
+    DROP COLUMN IF EXISTS search_lang;
@@ -0,0 +1,110 @@
+-- +goose Up
+-- Writes now store text in Unicode Normalization Form C; bring existing rows
+-- in line so composed and decomposed spellings compare and search equal.
+-- FORCE ROW LEVEL SECURITY is lifted while rewriting so the migration sees
+-- every tenant's rows, then restored.
+ALTER TABLE kg_nodes NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_edges NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_aliases NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_episodes NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_event_records NO FORCE ROW LEVEL SECURITY;
+
+UPDATE kg_nodes
+SET type = normalize(type, NFC),
+    label = normalize(label, NFC),
+    search_text = normalize(search_text, NFC)
+WHERE type IS NOT NFC NORMALIZED
+   OR label IS NOT NFC NORMALIZED
+   OR search_text IS NOT NFC NORMALIZED;
+
+-- Edges whose relations normalize to the same spelling would collide on the
+-- primary key. Each such group is ranked, preferring a relation that is
+-- already normalized and then the most recently updated edge; rank 1 keeps
+-- the edge, absorbing the others' access counts and salience, and the rest
+-- are deleted before it is rewritten.
+CREATE TEMP TABLE edge_nfc_ranked ON COMMIT DROP AS
+SELECT tenant_id, source, target, relation,
+       normalize(relation, NFC) AS nfc_relation,
+       access_count, last_accessed, salience_score, user_boosted,
+       row_number() OVER (
+           PARTITION BY tenant_id, source, target, normalize(relation, NFC)
+           ORDER BY (relation IS NFC NORMALIZED) DESC, updated_at DESC, relation
+       ) AS rank
+FROM kg_edges
+WHERE (tenant_id, source, target) IN (
+    SELECT tenant_id, source, target FROM kg_edges WHERE relation IS NOT NFC NORMALIZED
+);
+
+UPDATE kg_edges e
+SET access_count = e.access_count + d.access_count,
+    last_accessed = GREATEST(e.last_accessed, d.last_accessed),
+    salience_score = GREATEST(e.salience_score, d.salience_score),
+    user_boosted = e.user_boosted OR d.user_boosted
+FROM edge_nfc_ranked s
+JOIN (
+    SELECT tenant_id, source, target, nfc_relation,
+           sum(access_count) AS access_count,
+           max(last_accessed) AS last_accessed,
+           max(salience_score) AS salience_score,
+           bool_or(user_boosted) AS user_boosted
+    FROM edge_nfc_ranked
+    WHERE rank > 1
+    GROUP BY tenant_id, source, target, nfc_relation
+) d USING (tenant_id, source, target, nfc_relation)
+WHERE s.rank = 1
+  AND e.tenant_id = s.tenant_id AND e.source = s.source AND e.target = s.target
+  AND e.relation = s.relation;
+
+DELETE FROM kg_edges e
+USING edge_nfc_ranked d
+WHERE d.rank > 1
+  AND e.tenant_id = d.tenant_id AND e.source = d.source AND e.target = d.target
+  AND e.relation = d.relation;
+
+UPDATE kg_edges e
+SET relation = s.nfc_relation
+FROM edge_nfc_ranked s
+WHERE s.rank = 1 AND s.relation <> s.nfc_relation
+  AND e.tenant_id = s.tenant_id AND e.source = s.source AND e.target = s.target
+  AND e.relation = s.relation;
+
+-- Aliases of a node that normalize to the same spelling are ranked the same
+-- way; rank 1 is rewritten and the rest are deleted.
+CREATE TEMP TABLE alias_nfc_ranked ON COMMIT DROP AS
+SELECT id,
+       row_number() OVER (
+           PARTITION BY tenant_id, node_id, normalize(normalized_alias, NFC)
+           ORDER BY (normalized_alias IS NFC NORMALIZED) DESC, created_at, id
+       ) AS rank
+FROM kg_aliases
+WHERE (tenant_id, node_id) IN (
+    SELECT tenant_id, node_id FROM kg_aliases
+    WHERE alias IS NOT NFC NORMALIZED
+       OR alias_type IS NOT NFC NORMALIZED
+       OR normalized_alias IS NOT NFC NORMALIZED
+);
+
+DELETE FROM kg_aliases a
+USING alias_nfc_ranked r
+WHERE r.rank > 1 AND a.id = r.id;
+
+UPDATE kg_aliases
+SET alias = normalize(alias, NFC),
+    alias_type = normalize(alias_type, NFC),
+    normalized_alias = normalize(normalized_alias, NFC)
+WHERE alias IS NOT NFC NORMALIZED
+   OR alias_type IS NOT NFC NORMALIZED
+   OR normalized_alias IS NOT NFC NORMALIZED;
+
+UPDATE kg_episodes SET title = normalize(title, NFC) WHERE title IS NOT NFC NORMALIZED;
+UPDATE kg_event_records SET title = normalize(title, NFC) WHERE title IS NOT NFC NORMALIZED;
+
+ALTER TABLE kg_nodes FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_edges FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_aliases FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_episodes FORCE ROW LEVEL SECURITY;
+ALTER TABLE kg_event_records FORCE ROW LEVEL SECURITY;
+
+-- +goose Down
+-- Normalization is not reversible; the original spellings are not kept.
+SELECT 1;
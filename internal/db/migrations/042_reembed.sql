@@ -0,0 +1,32 @@
+-- +goose Up
+-- The model that produced each node's embedding, so a re-embed can find the
+-- vectors a model change left behind. NULL for embeddings written before
+-- this column existed.
+ALTER TABLE kg_nodes ADD COLUMN embedding_model TEXT;
+
+-- Each tenant's latest re-embed run: the model it moves the tenant's
+-- embeddings to and how far it has got. last_node_id is the keyset cursor a
+-- run resumes from after a restart. The worker that resumes runs reads
+-- every tenant's row, so like tenants the table has no RLS; every other
+-- query filters on tenant_id.
+CREATE TABLE reembed_runs (
+    tenant_id         UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    target_model      TEXT NOT NULL,
+    target_dimensions INT NOT NULL,
+    status            TEXT NOT NULL
+                      CONSTRAINT chk_reembed_runs_status CHECK (status IN ('running', 'completed', 'failed')),
+    total             INT NOT NULL DEFAULT 0,
+    processed         INT NOT NULL DEFAULT 0,
+    failed            INT NOT NULL DEFAULT 0,
+    last_node_id      TEXT NOT NULL DEFAULT '',
+    error             TEXT,
+    started_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at      TIMESTAMPTZ
+);
+
+CREATE INDEX idx_reembed_runs_running ON reembed_runs (tenant_id) WHERE status = 'running';
+
+-- +goose Down
+DROP TABLE IF EXISTS reembed_runs;
+ALTER TABLE kg_nodes DROP COLUMN IF EXISTS embedding_model;
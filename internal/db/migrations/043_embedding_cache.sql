@@ -0,0 +1,18 @@
+-- +goose Up
+-- Embeddings keyed by sha256 of the model name and the embedded text, so
+-- identical texts (common across bulk imports) are sent to the embedding
+-- model once. The text itself is not stored. Entries are shared by every
+-- tenant, so like tenants the table has no RLS. The vector has no fixed
+-- dimensions; readers skip entries that do not match the configured size.
+CREATE TABLE embedding_cache (
+    hash         BYTEA PRIMARY KEY,
+    embedding    vector NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Eviction removes the least recently used entries first.
+CREATE INDEX idx_embedding_cache_last_used ON embedding_cache (last_used_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS embedding_cache;
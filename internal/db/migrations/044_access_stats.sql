@@ -0,0 +1,24 @@
+-- +goose Up
+-- Hourly access counters behind the access analytics report: node reads,
+-- search queries (by hash), and traversal start nodes. Counters older than
+-- the longest report window are pruned as new counts are recorded.
+CREATE TABLE kg_access_stats (
+    tenant_id UUID NOT NULL,
+    kind      TEXT NOT NULL CONSTRAINT chk_access_stats_kind CHECK (kind IN ('read', 'search', 'traverse')),
+    key       TEXT NOT NULL,
+    bucket    TIMESTAMPTZ NOT NULL,
+    count     BIGINT NOT NULL,
+    PRIMARY KEY (tenant_id, kind, key, bucket)
+);
+
+CREATE INDEX idx_access_stats_tenant_bucket ON kg_access_stats (tenant_id, bucket);
+
+ALTER TABLE kg_access_stats ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_access_stats FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_access_stats ON kg_access_stats
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_access_stats;
@@ -0,0 +1,80 @@
+-- +goose Up
+-- Per-tenant forgetting policy: nodes scoring below archive_salience_below
+-- and neither read nor updated for archive_idle_days are moved to
+-- kg_nodes_archive. NULL disables archival.
+ALTER TABLE tenants
+    ADD COLUMN archive_salience_below REAL CONSTRAINT chk_tenant_archive_salience_below CHECK (archive_salience_below > 0),
+    ADD COLUMN archive_idle_days      INTEGER CONSTRAINT chk_tenant_archive_idle_days CHECK (archive_idle_days > 0);
+
+-- Archived nodes, out of reach of search and traversal until restored. The
+-- embedding is not kept: a restored node is embedded again with the current
+-- model. Generated search columns are recomputed on restore.
+CREATE TABLE kg_nodes_archive (
+    tenant_id       UUID NOT NULL,
+    id              TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    label           TEXT NOT NULL,
+    properties      JSONB NOT NULL,
+    access_count    INTEGER NOT NULL,
+    last_accessed   TIMESTAMPTZ,
+    salience_score  REAL NOT NULL,
+    superseded_by   TEXT,
+    user_boosted    BOOLEAN NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    search_text     TEXT NOT NULL,
+    search_lang     regconfig NOT NULL,
+    archived_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, id)
+);
+
+CREATE INDEX idx_nodes_archive_archived ON kg_nodes_archive (tenant_id, archived_at DESC, id);
+
+-- Edges of archived nodes. An edge comes back once both its nodes are live
+-- again.
+CREATE TABLE kg_edges_archive (
+    tenant_id       UUID NOT NULL,
+    source          TEXT NOT NULL,
+    target          TEXT NOT NULL,
+    relation        TEXT NOT NULL,
+    properties      JSONB NOT NULL,
+    weight          REAL NOT NULL,
+    access_count    INTEGER NOT NULL,
+    last_accessed   TIMESTAMPTZ,
+    salience_score  REAL NOT NULL,
+    superseded_by   TEXT,
+    user_boosted    BOOLEAN NOT NULL,
+    date_start      TEXT,
+    date_end        TEXT,
+    date_lower      DATE,
+    date_upper      DATE,
+    is_current      BOOLEAN,
+    date_qualifier  TEXT,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    archived_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, source, target, relation)
+);
+
+CREATE INDEX idx_edges_archive_target ON kg_edges_archive (tenant_id, target);
+
+ALTER TABLE kg_nodes_archive ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_nodes_archive FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_nodes_archive ON kg_nodes_archive
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+ALTER TABLE kg_edges_archive ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_edges_archive FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_edges_archive ON kg_edges_archive
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_edges_archive;
+DROP TABLE IF EXISTS kg_nodes_archive;
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS archive_idle_days,
+    DROP COLUMN IF EXISTS archive_salience_below;
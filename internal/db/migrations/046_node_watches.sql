@@ -0,0 +1,20 @@
+-- +goose Up
+-- Nodes a named watcher follows. A WebSocket or SSE subscriber that gives
+-- its watcher name receives only the events for these nodes.
+CREATE TABLE kg_watches (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    watcher    TEXT NOT NULL,
+    node_id    TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, watcher, node_id)
+);
+
+ALTER TABLE kg_watches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_watches FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_watches ON kg_watches
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_watches;
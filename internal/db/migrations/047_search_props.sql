@@ -0,0 +1,26 @@
+-- +goose Up
+-- Plaintext copy of each node's SEARCHABLE_PROPERTIES, which props filters on
+-- list and search match against; properties itself is encrypted. Nodes
+-- written before a key was made searchable have it copied when next written.
+ALTER TABLE kg_nodes
+    ADD COLUMN search_props JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE kg_branch_nodes
+    ADD COLUMN search_props JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE kg_nodes_archive
+    ADD COLUMN search_props JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_nodes_search_props ON kg_nodes USING gin (search_props);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_nodes_search_props;
+
+ALTER TABLE kg_nodes_archive
+    DROP COLUMN IF EXISTS search_props;
+
+ALTER TABLE kg_branch_nodes
+    DROP COLUMN IF EXISTS search_props;
+
+ALTER TABLE kg_nodes
+    DROP COLUMN IF EXISTS search_props;
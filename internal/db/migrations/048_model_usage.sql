@@ -0,0 +1,32 @@
+-- +goose Up
+-- Embedding and LLM requests and tokens each tenant used, per calendar
+-- month (UTC), for billing and monthly token budgets. NULL budgets are
+-- unlimited.
+CREATE TABLE kg_model_usage (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    month     DATE NOT NULL,
+    kind      TEXT NOT NULL CONSTRAINT chk_model_usage_kind CHECK (kind IN ('embedding', 'llm')),
+    requests  BIGINT NOT NULL DEFAULT 0,
+    tokens    BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, month, kind)
+);
+
+ALTER TABLE kg_model_usage ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_model_usage FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_model_usage ON kg_model_usage
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+ALTER TABLE tenants
+    ADD COLUMN max_monthly_embedding_tokens BIGINT
+        CONSTRAINT chk_tenant_max_monthly_embedding_tokens CHECK (max_monthly_embedding_tokens > 0),
+    ADD COLUMN max_monthly_llm_tokens BIGINT
+        CONSTRAINT chk_tenant_max_monthly_llm_tokens CHECK (max_monthly_llm_tokens > 0);
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS max_monthly_llm_tokens,
+    DROP COLUMN IF EXISTS max_monthly_embedding_tokens;
+
+DROP TABLE IF EXISTS kg_model_usage;
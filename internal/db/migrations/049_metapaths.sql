@@ -0,0 +1,23 @@
+-- +goose Up
+-- Named multi-hop relation path templates. Running one follows its steps
+-- from a start node as a single join chain.
+CREATE TABLE kg_metapaths (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    start_type  TEXT NOT NULL DEFAULT '',
+    steps       JSONB NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, name)
+);
+
+ALTER TABLE kg_metapaths ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_metapaths FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_metapaths ON kg_metapaths
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_metapaths;
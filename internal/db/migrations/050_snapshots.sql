@@ -0,0 +1,26 @@
+-- +goose Up
+-- Named logical snapshots of a tenant's graph: node and edge counts and a
+-- content hash per node type and per relation, cheap enough to take before
+-- any experiment and compare later.
+CREATE TABLE kg_snapshots (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    export_ref  TEXT NOT NULL DEFAULT '',
+    node_count  BIGINT NOT NULL,
+    edge_count  BIGINT NOT NULL,
+    types       JSONB NOT NULL,
+    relations   JSONB NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, name)
+);
+
+ALTER TABLE kg_snapshots ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_snapshots FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_snapshots ON kg_snapshots
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_snapshots;
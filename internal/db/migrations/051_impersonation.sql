@@ -0,0 +1,13 @@
+-- +goose Up
+-- Impersonation keys are short-lived managed keys an operator issues to act
+-- as a tenant for support. They record the operator tenant and the reason,
+-- so each request made with one can be audited in the customer's log.
+ALTER TABLE api_keys
+    ADD COLUMN impersonated_by UUID REFERENCES tenants(id) ON DELETE SET NULL,
+    ADD COLUMN impersonation_reason TEXT
+        CONSTRAINT chk_api_keys_impersonation_reason_len CHECK (length(impersonation_reason) <= 500);
+
+-- +goose Down
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS impersonation_reason,
+    DROP COLUMN IF EXISTS impersonated_by;
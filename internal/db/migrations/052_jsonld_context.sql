@@ -0,0 +1,9 @@
+-- +goose Up
+-- Per-tenant JSON-LD context mapping node types, relations, and property
+-- keys to IRIs for linked-data exports. NULL uses the default vocabulary.
+ALTER TABLE tenants
+    ADD COLUMN jsonld_context JSONB;
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS jsonld_context;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Nodes the prompt-injection scanner flagged at write time. A quarantined
+-- node is left out of recall packs until an admin reviews and releases it.
+CREATE TABLE kg_quarantine (
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    node_id    TEXT NOT NULL,
+    field      TEXT NOT NULL,
+    pattern    TEXT NOT NULL,
+    flagged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, node_id)
+);
+
+ALTER TABLE kg_quarantine ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_quarantine FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_quarantine ON kg_quarantine
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_quarantine;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Per-tenant catalog of allowed (source type, relation, target type)
+-- triples. '*' in a type column matches any node type. Edges are checked
+-- against it only while tenants.enforce_relation_catalog is set.
+CREATE TABLE kg_relation_catalog (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    source_type TEXT NOT NULL,
+    relation    TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, relation, source_type, target_type)
+);
+
+ALTER TABLE kg_relation_catalog ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_relation_catalog FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_relation_catalog ON kg_relation_catalog
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+ALTER TABLE tenants
+    ADD COLUMN enforce_relation_catalog BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS enforce_relation_catalog;
+
+DROP TABLE IF EXISTS kg_relation_catalog;
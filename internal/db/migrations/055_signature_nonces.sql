@@ -0,0 +1,22 @@
+-- +goose Up
+-- Nonces of verified request signatures. A nonce is kept until its signature
+-- timestamp falls outside the allowed skew, so a captured signed request is
+-- refused on every replica, not just the one that first accepted it.
+CREATE TABLE kg_signature_nonces (
+    tenant_id   UUID NOT NULL,
+    nonce       TEXT NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, nonce)
+);
+
+ALTER TABLE kg_signature_nonces ENABLE ROW LEVEL SECURITY;
+ALTER TABLE kg_signature_nonces FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_signature_nonces ON kg_signature_nonces
+    FOR ALL
+    USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+
+CREATE INDEX idx_signature_nonces_tenant_expires ON kg_signature_nonces(tenant_id, expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS kg_signature_nonces;
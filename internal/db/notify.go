@@ -22,6 +22,8 @@
 
 const (
 	listenChannel     = "kg_changes"
+	broadcastChannel  = "kg_broadcast"
+	watchTable        = "kg_watches"
 	initialBackoff    = 1 * time.Second
 	maxBackoff        = 30 * time.Second
 	backoffMultiplier = 2
@@ -30,11 +32,15 @@
 // Broadcaster sends messages to connected clients.
 type Broadcaster interface {
 	BroadcastToTenant(tenantID string, msg []byte)
-	BroadcastEvent(eventType, tenantID string, data json.RawMessage)
+	BroadcastEvent(eventType, tenantID string, id uint64, data json.RawMessage)
+	DeliverOperatorMessage(tenantID string, msg []byte)
+	ApplyWatchChange(tenantID, watcher, nodeID string, watched bool)
 }
 
 // NotifyBridge subscribes to PostgreSQL LISTEN/NOTIFY on the kg_changes
-// channel and forwards each payload to the WebSocket hub.
+// channel and forwards each payload to the WebSocket hub. It also carries
+// operator messages between server instances on the kg_broadcast channel,
+// so every replica's clients receive them.
 type NotifyBridge struct {
 	log  *logrus.Logger
 	pool *dbpool.Pool
@@ -55,8 +61,10 @@ func NewNotifyBridge(log *logrus.Logger, pool *dbpool.Pool, hub Broadcaster) *No
 // LISTEN fails, it returns an error. The background goroutine handles
 // reconnection for subsequent failures.
 func (b *NotifyBridge) Start(ctx context.Context) error {
-	if !validChannel.MatchString(listenChannel) {
-		return fmt.Errorf("notify bridge: invalid channel name %q", listenChannel)
+	for _, channel := range []string{listenChannel, broadcastChannel} {
+		if !validChannel.MatchString(channel) {
+			return fmt.Errorf("notify bridge: invalid channel name %q", channel)
+		}
 	}
 
 	if err := b.pool.Ping(ctx); err != nil {
@@ -107,12 +115,13 @@ func (b *NotifyBridge) subscribeAndForward(ctx context.Context) error {
 
 	// LISTEN requires the channel name inline (not a parameter), so we use
 	// pgx.Identifier to safely quote/sanitize the channel name.
-	sanitizedChannel := pgx.Identifier{listenChannel}.Sanitize()
-	if _, err := conn.Exec(ctx, "LISTEN "+sanitizedChannel); err != nil {
-		return fmt.Errorf("executing LISTEN: %w", err)
+	for _, channel := range []string{listenChannel, broadcastChannel} {
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			return fmt.Errorf("executing LISTEN %s: %w", channel, err)
+		}
 	}
 
-	b.log.WithField("channel", listenChannel).Info("notify bridge listening")
+	b.log.WithField("channels", []string{listenChannel, broadcastChannel}).Info("notify bridge listening")
 
 	for {
 		// Set a 2-minute read deadline so we periodically check ctx cancellation.
@@ -134,10 +143,49 @@ func (b *NotifyBridge) subscribeAndForward(ctx context.Context) error {
 			return fmt.Errorf("waiting for notification: %w", err)
 		}
 
+		if notification.Channel == broadcastChannel {
+			b.handleBroadcast(notification)
+			continue
+		}
+
 		b.handleNotification(notification)
 	}
 }
 
+// operatorBroadcast is the kg_broadcast payload: an encoded operator message
+// and the tenant it is for, empty for every tenant.
+type operatorBroadcast struct {
+	TenantID string          `json:"tenant_id"`
+	Message  json.RawMessage `json:"message"`
+}
+
+// PublishOperatorMessage sends an operator message to every instance
+// listening on kg_broadcast, this one included.
+func (b *NotifyBridge) PublishOperatorMessage(ctx context.Context, tenantID string, msg []byte) error {
+	payload, err := json.Marshal(operatorBroadcast{TenantID: tenantID, Message: msg})
+	if err != nil {
+		return fmt.Errorf("encoding operator broadcast: %w", err)
+	}
+
+	if _, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", broadcastChannel, string(payload)); err != nil {
+		return fmt.Errorf("sending operator broadcast: %w", err)
+	}
+
+	return nil
+}
+
+// handleBroadcast delivers an operator message published by any instance to
+// this instance's clients.
+func (b *NotifyBridge) handleBroadcast(n *pgconn.Notification) {
+	var payload operatorBroadcast
+	if err := json.Unmarshal([]byte(n.Payload), &payload); err != nil || len(payload.Message) == 0 {
+		b.log.Warn("dropping malformed operator broadcast")
+		return
+	}
+
+	b.hub.DeliverOperatorMessage(payload.TenantID, payload.Message)
+}
+
 // handleNotification forwards a single PG notification payload to the hub.
 // Handles both statement-level payloads (with "count") and legacy per-row
 // payloads (with "id") for backward compatibility.
@@ -151,12 +199,24 @@ func (b *NotifyBridge) handleNotification(n *pgconn.Notification) {
 		TenantID string `json:"tenant_id"`
 		Type     string `json:"type,omitempty"`
 		Count    *int64 `json:"count,omitempty"`
+		Table    string `json:"table,omitempty"`
+		Op       string `json:"op,omitempty"`
+		Watcher  string `json:"watcher,omitempty"`
+		NodeID   string `json:"node_id,omitempty"`
+		EventID  uint64 `json:"event_id,omitempty"`
 	}
 	if err := json.Unmarshal([]byte(n.Payload), &payload); err != nil || payload.TenantID == "" {
 		b.log.Warn("dropping notification without tenant_id")
 		return
 	}
 
+	// Watch list changes update subscribers' filters rather than reaching
+	// clients as events.
+	if payload.Table == watchTable {
+		b.hub.ApplyWatchChange(payload.TenantID, payload.Watcher, payload.NodeID, payload.Op == "insert")
+		return
+	}
+
 	if payload.Count != nil {
 		b.log.WithField("count", *payload.Count).Debug("statement-level notification")
 	}
@@ -166,7 +226,7 @@ func (b *NotifyBridge) handleNotification(n *pgconn.Notification) {
 		eventType = "kg.change"
 	}
 
-	b.hub.BroadcastEvent(eventType, payload.TenantID, json.RawMessage(n.Payload))
+	b.hub.BroadcastEvent(eventType, payload.TenantID, payload.EventID, json.RawMessage(n.Payload))
 }
 
 // nextBackoff doubles the current backoff duration with random jitter (±25%),
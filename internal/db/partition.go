@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/dbpool"
+)
+
+// PartitionedTables are the tables EnsurePartitioning splits by tenant.
+var PartitionedTables = []string{"kg_nodes", "kg_edges"}
+
+// EnsurePartitioning hash-partitions kg_nodes and kg_edges by tenant_id into
+// the given number of partitions, so each tenant's rows and indexes live in
+// one partition and tenant-scoped queries scan only that partition. It runs
+// after RunMigrations; 0 leaves the tables as they are.
+//
+// Converting a table copies every row and rebuilds every index in one
+// transaction that holds an exclusive lock on it, so the first start with
+// partitioning enabled takes as long as that copy. A table that is already
+// partitioned is left alone: changing the partition count is not supported.
+func EnsurePartitioning(ctx context.Context, pool *dbpool.Pool, log *logrus.Logger, partitions int) error {
+	if partitions == 0 {
+		return nil
+	}
+
+	if partitions < 2 || partitions > 1024 {
+		return fmt.Errorf("graph partitions must be 0 or between 2 and 1024, got %d", partitions)
+	}
+
+	for _, table := range PartitionedTables {
+		current, err := PartitionCount(ctx, pool, table)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case current == partitions:
+			log.WithField("table", table).Debug("table already partitioned")
+		case current > 0:
+			log.WithFields(logrus.Fields{
+				"table":      table,
+				"partitions": current,
+				"configured": partitions,
+			}).Warn("table is already partitioned with a different count, leaving it as is")
+		default:
+			start := time.Now()
+			if err := partitionTable(ctx, pool, table, partitions); err != nil {
+				return fmt.Errorf("partitioning %s: %w", table, err)
+			}
+
+			log.WithFields(logrus.Fields{
+				"table":      table,
+				"partitions": partitions,
+				"duration":   time.Since(start),
+			}).Info("table partitioned by tenant")
+
+			// Fresh partitions have no statistics until autovacuum gets to them.
+			if _, err := pool.Exec(ctx, `ANALYZE `+pgx.Identifier{table}.Sanitize()); err != nil {
+				log.WithError(err).WithField("table", table).Warn("analyzing partitioned table")
+			}
+		}
+	}
+
+	return nil
+}
+
+// PartitionCount returns how many partitions table has, or 0 when it is not
+// partitioned.
+func PartitionCount(ctx context.Context, pool *dbpool.Pool, table string) (int, error) {
+	var n int
+	if err := pool.QueryRow(ctx,
+		`SELECT count(*) FROM pg_inherits WHERE inhparent = to_regclass($1)`, table,
+	).Scan(&n); err != nil {
+		return 0, fmt.Errorf("counting partitions of %s: %w", table, err)
+	}
+
+	return n, nil
+}
+
+// partitionTable replaces table with a copy hash-partitioned by tenant_id,
+// in one transaction.
+func partitionTable(ctx context.Context, pool *dbpool.Pool, table string, partitions int) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning partition tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	// The copy takes far longer than the pool's statement timeout.
+	if _, err := tx.Exec(ctx, `SET LOCAL statement_timeout = 0`); err != nil {
+		return fmt.Errorf("disabling statement timeout: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE `+pgx.Identifier{table}.Sanitize()+` IN ACCESS EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("locking table: %w", err)
+	}
+
+	def, err := readTableDefinition(ctx, tx, table)
+	if err != nil {
+		return err
+	}
+
+	if err := dropReferencingKeys(ctx, tx, def); err != nil {
+		return err
+	}
+
+	stmts := copyStatements(table, def.columns, partitions)
+	stmts = append(stmts, def.recreateStatements(table, partitions)...)
+
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("%s: %w", stmt, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing partitioned table: %w", err)
+	}
+
+	return nil
+}
+
+// copyStatements rename table out of the way, create its partitioned
+// replacement, and copy columns across.
+func copyStatements(table string, columns []string, partitions int) []string {
+	ident := pgx.Identifier{table}.Sanitize()
+	old := pgx.Identifier{table + "_unpartitioned"}.Sanitize()
+
+	list := ""
+	for i, c := range columns {
+		if i > 0 {
+			list += ", "
+		}
+		list += pgx.Identifier{c}.Sanitize()
+	}
+
+	stmts := []string{
+		`ALTER TABLE ` + ident + ` RENAME TO ` + old,
+		// The owner copies every tenant's rows only with RLS not forced.
+		`ALTER TABLE ` + old + ` NO FORCE ROW LEVEL SECURITY`,
+		`CREATE TABLE ` + ident + ` (LIKE ` + old + ` INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+			INCLUDING GENERATED INCLUDING STORAGE INCLUDING COMPRESSION INCLUDING COMMENTS)
+			PARTITION BY HASH (tenant_id)`,
+	}
+
+	for i := range partitions {
+		stmts = append(stmts, fmt.Sprintf(`CREATE TABLE %s PARTITION OF %s FOR VALUES WITH (MODULUS %d, REMAINDER %d)`,
+			partitionIdent(table, i), ident, partitions, i))
+	}
+
+	return append(stmts,
+		`INSERT INTO `+ident+` (`+list+`) SELECT `+list+` FROM `+old,
+		`DROP TABLE `+old,
+	)
+}
+
+// partitionIdent names the partition of table holding hash remainder i.
+func partitionIdent(table string, i int) string {
+	return pgx.Identifier{fmt.Sprintf("%s_p%d", table, i)}.Sanitize()
+}
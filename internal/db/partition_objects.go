@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// tableDefinition is what a table's partitioned replacement must recreate
+// beyond the columns and check constraints CREATE TABLE ... LIKE copies.
+type tableDefinition struct {
+	// columns are the columns a copy inserts; generated columns are
+	// computed again.
+	columns []string
+	// statements recreate constraints, indexes, triggers, and grants under
+	// their original names.
+	statements []string
+	// policies are row-level security policies, each the part of its
+	// CREATE POLICY statement after the table name.
+	policies         map[string]string
+	rowSecurity      bool
+	forceRowSecurity bool
+	// referencedBy are other tables' foreign keys to the table.
+	referencedBy []foreignKey
+}
+
+type foreignKey struct {
+	table, name, definition string
+}
+
+// definitionQueries read the statements that recreate a table's
+// constraints, indexes, triggers, and grants, given its name as $1.
+//
+// Primary and unique keys come before foreign keys, which may refer to
+// them; indexes backing a constraint come with the constraint.
+var definitionQueries = []string{
+	`SELECT format('ALTER TABLE %I ADD CONSTRAINT %I %s', $1::text, conname, pg_get_constraintdef(oid))
+		FROM pg_constraint
+		WHERE conrelid = to_regclass($1) AND contype IN ('p', 'u', 'x', 'f')
+		ORDER BY contype = 'f', conname`,
+	`SELECT pg_get_indexdef(i.indexrelid) FROM pg_index i
+		WHERE i.indrelid = to_regclass($1)
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conrelid = i.indrelid AND c.conindid = i.indexrelid)
+		ORDER BY i.indexrelid`,
+	`SELECT pg_get_triggerdef(oid) FROM pg_trigger
+		WHERE tgrelid = to_regclass($1) AND NOT tgisinternal
+		ORDER BY tgname`,
+	`SELECT format('GRANT %s ON %I TO %s', a.privilege_type, $1::text,
+			CASE WHEN a.grantee = 0 THEN 'PUBLIC' ELSE quote_ident(pg_get_userbyid(a.grantee)) END)
+		FROM pg_class c, aclexplode(c.relacl) a
+		WHERE c.oid = to_regclass($1) AND a.grantee <> c.relowner`,
+}
+
+// readTableDefinition reads what partitionTable must recreate from the
+// catalog. Statements name the table as it is now, so it must be read
+// before the table is renamed.
+func readTableDefinition(ctx context.Context, tx pgx.Tx, table string) (*tableDefinition, error) {
+	def := &tableDefinition{}
+
+	if err := tx.QueryRow(ctx,
+		`SELECT relrowsecurity, relforcerowsecurity FROM pg_class WHERE oid = to_regclass($1)`, table,
+	).Scan(&def.rowSecurity, &def.forceRowSecurity); err != nil {
+		return nil, fmt.Errorf("reading row security of %s: %w", table, err)
+	}
+
+	var err error
+
+	def.columns, err = collectStrings(ctx, tx, `SELECT attname::text FROM pg_attribute
+		WHERE attrelid = to_regclass($1) AND attnum > 0 AND NOT attisdropped AND attgenerated = ''
+		ORDER BY attnum`, table)
+	if err != nil {
+		return nil, fmt.Errorf("reading columns of %s: %w", table, err)
+	}
+
+	for _, q := range definitionQueries {
+		stmts, err := collectStrings(ctx, tx, q, table)
+		if err != nil {
+			return nil, fmt.Errorf("reading definition of %s: %w", table, err)
+		}
+
+		def.statements = append(def.statements, stmts...)
+	}
+
+	if def.policies, err = readPolicies(ctx, tx, table); err != nil {
+		return nil, err
+	}
+
+	if def.referencedBy, err = readReferencingKeys(ctx, tx, table); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// readPolicies reads table's row-level security policies by name, each as
+// the part of its CREATE POLICY statement after the table name.
+func readPolicies(ctx context.Context, tx pgx.Tx, table string) (map[string]string, error) {
+	policies := make(map[string]string)
+
+	rows, err := tx.Query(ctx, `SELECT policyname::text,
+			format('AS %s FOR %s TO %s', permissive, cmd, (SELECT string_agg(quote_ident(r), ', ') FROM unnest(roles) r))
+				|| coalesce(' USING (' || qual || ')', '')
+				|| coalesce(' WITH CHECK (' || with_check || ')', '')
+		FROM pg_policies
+		WHERE schemaname = current_schema() AND tablename = $1`, table)
+	if err != nil {
+		return nil, fmt.Errorf("reading policies of %s: %w", table, err)
+	}
+
+	for rows.Next() {
+		var name, clause string
+		if err := rows.Scan(&name, &clause); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning policy of %s: %w", table, err)
+		}
+
+		policies[name] = clause
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading policies of %s: %w", table, err)
+	}
+
+	return policies, nil
+}
+
+// readReferencingKeys reads other tables' foreign keys to table.
+func readReferencingKeys(ctx context.Context, tx pgx.Tx, table string) ([]foreignKey, error) {
+	rows, err := tx.Query(ctx, `SELECT conrelid::regclass::text, conname::text, pg_get_constraintdef(oid)
+		FROM pg_constraint
+		WHERE confrelid = to_regclass($1) AND conrelid <> confrelid AND contype = 'f'
+		ORDER BY conname`, table)
+	if err != nil {
+		return nil, fmt.Errorf("reading foreign keys to %s: %w", table, err)
+	}
+
+	keys, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (foreignKey, error) {
+		var fk foreignKey
+		err := row.Scan(&fk.table, &fk.name, &fk.definition)
+
+		return fk, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading foreign keys to %s: %w", table, err)
+	}
+
+	return keys, nil
+}
+
+// dropReferencingKeys drops the foreign keys other tables have to the table.
+// They come back with recreateStatements once the partitioned table has its
+// primary key.
+func dropReferencingKeys(ctx context.Context, tx pgx.Tx, def *tableDefinition) error {
+	for _, fk := range def.referencedBy {
+		if _, err := tx.Exec(ctx,
+			`ALTER TABLE `+fk.table+` DROP CONSTRAINT `+pgx.Identifier{fk.name}.Sanitize(),
+		); err != nil {
+			return fmt.Errorf("dropping foreign key %s: %w", fk.name, err)
+		}
+	}
+
+	return nil
+}
+
+// recreateStatements restore def on the partitioned table: constraints,
+// indexes, triggers, and grants, then row-level security on the table and
+// each partition, then the foreign keys that refer to it.
+func (def *tableDefinition) recreateStatements(table string, partitions int) []string {
+	stmts := append([]string(nil), def.statements...)
+
+	// Partitions get the same policies, so reading one directly is as
+	// isolated as reading through the parent.
+	for _, target := range rowSecurityTargets(table, partitions) {
+		if def.rowSecurity {
+			stmts = append(stmts, `ALTER TABLE `+target+` ENABLE ROW LEVEL SECURITY`)
+		}
+
+		if def.forceRowSecurity {
+			stmts = append(stmts, `ALTER TABLE `+target+` FORCE ROW LEVEL SECURITY`)
+		}
+
+		for name, clause := range def.policies {
+			stmts = append(stmts, `CREATE POLICY `+pgx.Identifier{name}.Sanitize()+` ON `+target+` `+clause)
+		}
+	}
+
+	for _, fk := range def.referencedBy {
+		stmts = append(stmts, `ALTER TABLE `+fk.table+` ADD CONSTRAINT `+pgx.Identifier{fk.name}.Sanitize()+` `+fk.definition)
+	}
+
+	return stmts
+}
+
+// rowSecurityTargets are the table and each of its partitions.
+func rowSecurityTargets(table string, partitions int) []string {
+	targets := []string{pgx.Identifier{table}.Sanitize()}
+	for i := range partitions {
+		targets = append(targets, partitionIdent(table, i))
+	}
+
+	return targets
+}
+
+func collectStrings(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
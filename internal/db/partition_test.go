@@ -0,0 +1,198 @@
+package db_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/store"
+	"github.com/persistorai/persistor/internal/testutil"
+)
+
+// The test partitions scratch tables shaped like kg_nodes, so a shared test
+// database keeps its graph tables as they are.
+const scratchSchema = `
+CREATE TABLE partition_test_items (
+	tenant_id  UUID NOT NULL,
+	id         TEXT NOT NULL CONSTRAINT chk_partition_test_id_len CHECK (length(id) <= 255),
+	label      TEXT NOT NULL DEFAULT '',
+	label_tsv  tsvector GENERATED ALWAYS AS (to_tsvector('english', label)) STORED,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (tenant_id, id)
+);
+CREATE INDEX idx_partition_test_label ON partition_test_items (tenant_id, label);
+CREATE TRIGGER partition_test_updated BEFORE UPDATE ON partition_test_items
+	FOR EACH ROW EXECUTE FUNCTION update_timestamp();
+CREATE TABLE partition_test_refs (
+	tenant_id UUID NOT NULL,
+	item_id   TEXT NOT NULL,
+	PRIMARY KEY (tenant_id, item_id),
+	CONSTRAINT fk_partition_test_item FOREIGN KEY (tenant_id, item_id)
+		REFERENCES partition_test_items (tenant_id, id) ON DELETE CASCADE
+);
+`
+
+const scratchRLS = `
+ALTER TABLE partition_test_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE partition_test_items FORCE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation_partition_test ON partition_test_items
+	FOR ALL
+	USING (tenant_id = current_setting('app.tenant_id', true)::uuid)
+	WITH CHECK (tenant_id = current_setting('app.tenant_id', true)::uuid);
+`
+
+func TestEnsurePartitioning(t *testing.T) {
+	env := testutil.NewEnv(t)
+	pool := env.Pool
+	ctx := context.Background()
+
+	dropScratch := func() {
+		pool.Exec(ctx, `DROP TABLE IF EXISTS partition_test_refs, partition_test_items CASCADE`) //nolint:errcheck // best-effort cleanup.
+	}
+	dropScratch()
+	t.Cleanup(dropScratch)
+
+	tables := db.PartitionedTables
+	db.PartitionedTables = []string{"partition_test_items"}
+	t.Cleanup(func() { db.PartitionedTables = tables })
+
+	if _, err := pool.Exec(ctx, scratchSchema); err != nil {
+		t.Fatalf("creating scratch tables: %v", err)
+	}
+
+	tenants := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+	for _, tenant := range tenants {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO partition_test_items (tenant_id, id, label) VALUES ($1, 'a', 'alpha'), ($1, 'b', 'beta')`, tenant,
+		); err != nil {
+			t.Fatalf("seeding items: %v", err)
+		}
+	}
+
+	if _, err := pool.Exec(ctx, `INSERT INTO partition_test_refs VALUES ($1, 'a')`, tenants[0]); err != nil {
+		t.Fatalf("seeding refs: %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, scratchRLS); err != nil {
+		t.Fatalf("enabling row security: %v", err)
+	}
+
+	if err := db.EnsurePartitioning(ctx, pool, env.Log, 4); err != nil {
+		t.Fatalf("EnsurePartitioning: %v", err)
+	}
+
+	if n, err := db.PartitionCount(ctx, pool, "partition_test_items"); err != nil || n != 4 {
+		t.Fatalf("PartitionCount = %d, %v; want 4", n, err)
+	}
+
+	// A second run leaves the partitioned table alone.
+	if err := db.EnsurePartitioning(ctx, pool, env.Log, 4); err != nil {
+		t.Fatalf("EnsurePartitioning again: %v", err)
+	}
+
+	t.Run("rows copied", func(t *testing.T) {
+		var count int
+		var tsv string
+		asTenant(t, pool, tenants[1], func(tx pgx.Tx) {
+			if err := tx.QueryRow(ctx, `SELECT count(*), max(label_tsv::text) FROM partition_test_items
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid`).Scan(&count, &tsv); err != nil {
+				t.Fatalf("counting rows: %v", err)
+			}
+		})
+
+		if count != 2 || !strings.Contains(tsv, "beta") {
+			t.Errorf("tenant has %d rows (tsv %q), want 2 with generated columns", count, tsv)
+		}
+	})
+
+	t.Run("definition recreated", func(t *testing.T) {
+		var indexes, triggers, fks int
+		if err := pool.QueryRow(ctx, `SELECT
+				(SELECT count(*) FROM pg_indexes WHERE tablename = 'partition_test_items'
+					AND indexname IN ('partition_test_items_pkey', 'idx_partition_test_label')),
+				(SELECT count(*) FROM pg_trigger WHERE tgrelid = 'partition_test_items'::regclass
+					AND tgname = 'partition_test_updated'),
+				(SELECT count(*) FROM pg_constraint WHERE conname = 'fk_partition_test_item'
+					AND confrelid = 'partition_test_items'::regclass)`,
+		).Scan(&indexes, &triggers, &fks); err != nil {
+			t.Fatalf("reading catalog: %v", err)
+		}
+
+		if indexes != 2 || triggers != 1 || fks != 1 {
+			t.Errorf("indexes = %d, triggers = %d, foreign keys = %d; want 2, 1, 1", indexes, triggers, fks)
+		}
+	})
+
+	t.Run("tenant queries prune partitions", func(t *testing.T) {
+		var plan []string
+		asTenant(t, pool, tenants[0], func(tx pgx.Tx) {
+			rows, err := tx.Query(ctx, `EXPLAIN (ANALYZE, COSTS OFF, TIMING OFF, SUMMARY OFF)
+				SELECT label FROM partition_test_items
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = 'a'`)
+			if err != nil {
+				t.Fatalf("explaining query: %v", err)
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var line string
+				if err := rows.Scan(&line); err != nil {
+					t.Fatalf("scanning plan: %v", err)
+				}
+				plan = append(plan, line)
+			}
+		})
+
+		scanned := 0
+		for _, line := range plan {
+			if strings.Contains(line, "on partition_test_items_p") {
+				scanned++
+			}
+		}
+
+		if scanned != 1 {
+			t.Errorf("query scanned %d partitions, want 1:\n%s", scanned, strings.Join(plan, "\n"))
+		}
+	})
+
+	t.Run("size report", func(t *testing.T) {
+		partitions, err := store.NewPartitionStore(pool).ListPartitions(ctx)
+		if err != nil {
+			t.Fatalf("ListPartitions: %v", err)
+		}
+
+		if len(partitions) != 4 {
+			t.Fatalf("partitions = %+v, want 4", partitions)
+		}
+
+		for i, p := range partitions {
+			if p.Table != "partition_test_items" || p.Modulus != 4 || p.Remainder != i || p.TotalBytes <= 0 {
+				t.Errorf("partition %d = %+v", i, p)
+			}
+		}
+	})
+}
+
+// asTenant runs fn in a transaction with the tenant setting row-level
+// security filters on.
+func asTenant(t *testing.T, pool *dbpool.Pool, tenantID string, fn func(tx pgx.Tx)) {
+	t.Helper()
+
+	ctx := context.Background()
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("beginning tx: %v", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		t.Fatalf("setting tenant: %v", err)
+	}
+
+	fn(tx)
+}
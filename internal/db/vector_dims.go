@@ -77,8 +77,20 @@ func EnsureVectorDimensions(ctx context.Context, pool *dbpool.Pool, log *logrus.
 	// Phase 2: rebuild HNSW index concurrently, outside any transaction.
 	// CREATE INDEX CONCURRENTLY cannot run inside a transaction block; it acquires
 	// weaker locks and allows concurrent reads and writes during the build.
+	// Partitioned tables cannot be indexed concurrently, so kg_nodes is locked
+	// for the build once EnsurePartitioning has split it.
+	partitions, err := PartitionCount(ctx, pool, "kg_nodes")
+	if err != nil {
+		return err
+	}
+
+	createIndex := `CREATE INDEX CONCURRENTLY`
+	if partitions > 0 {
+		createIndex = `CREATE INDEX`
+	}
+
 	if _, err := pool.Exec(ctx,
-		`CREATE INDEX CONCURRENTLY idx_nodes_embedding ON kg_nodes USING hnsw (embedding vector_cosine_ops)
+		createIndex+` idx_nodes_embedding ON kg_nodes USING hnsw (embedding vector_cosine_ops)
 		 WITH (m = 32, ef_construction = 200) WHERE embedding IS NOT NULL`,
 	); err != nil {
 		return fmt.Errorf("recreating embedding index: %w", err)
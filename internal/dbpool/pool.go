@@ -15,7 +15,8 @@
 // The underlying pool is unexported to prevent callers from bypassing
 // the withTimeout pattern used by Repository methods.
 type Pool struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	counters *poolCounters
 }
 
 // NewPool creates a new PostgreSQL connection pool with sensible defaults.
@@ -27,6 +28,8 @@ func NewPool(ctx context.Context, databaseURL string, maxConns int32) (*Pool, er
 	}
 
 	cfg.ConnConfig.RuntimeParams["statement_timeout"] = "30000"
+	counters := &poolCounters{}
+	cfg.ConnConfig.Tracer = queryTracer{counters: counters}
 
 	cfg.MaxConns = maxConns
 	cfg.MinConns = 2
@@ -45,7 +48,7 @@ func NewPool(ctx context.Context, databaseURL string, maxConns int32) (*Pool, er
 		return nil, fmt.Errorf("pinging database: %w", err)
 	}
 
-	return &Pool{pool: pool}, nil
+	return &Pool{pool: pool, counters: counters}, nil
 }
 
 // Acquire returns a connection from the pool.
@@ -0,0 +1,159 @@
+package dbpool
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Replica lag probing.
+const (
+	replicaCheckInterval = 5 * time.Second
+	replicaCheckTimeout  = 2 * time.Second
+)
+
+// replicaLagSQL measures how far a standby is behind its primary. A standby
+// that has replayed everything it received is not behind, however old its
+// last replayed transaction is; a server that is not a standby reports -1.
+const replicaLagSQL = `
+SELECT CASE
+	WHEN NOT pg_is_in_recovery() THEN -1
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+// replica is one read replica and whether its last lag probe found it
+// usable.
+type replica struct {
+	pool *Pool
+	host string
+
+	usable atomic.Bool
+}
+
+// ReplicaSet routes read-only transactions to streaming replicas. Replicas
+// are probed for replication lag in Run; one that is unreachable or lags
+// more than maxLag behind the primary is skipped until it catches up.
+type ReplicaSet struct {
+	replicas []*replica
+	maxLag   time.Duration
+	log      *logrus.Logger
+	next     atomic.Uint64
+}
+
+// NewReplicaSet connects a pool of up to maxConns connections to each
+// replica URL. A replica that cannot be reached at startup is an error.
+func NewReplicaSet(ctx context.Context, urls []string, maxConns int32, maxLag time.Duration, log *logrus.Logger) (*ReplicaSet, error) {
+	rs := &ReplicaSet{maxLag: maxLag, log: log}
+
+	for _, u := range urls {
+		host := replicaHost(u)
+
+		pool, err := NewPool(ctx, u, maxConns)
+		if err != nil {
+			rs.Close()
+
+			return nil, fmt.Errorf("connecting to replica %s: %w", host, err)
+		}
+
+		r := &replica{pool: pool, host: host}
+		r.usable.Store(true)
+		rs.replicas = append(rs.replicas, r)
+	}
+
+	return rs, nil
+}
+
+// replicaHost returns the host of a connection URL, for logs and errors.
+func replicaHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "replica"
+	}
+
+	return u.Host
+}
+
+// Pick returns a replica pool to run a read-only transaction on, rotating
+// between usable replicas, or nil when none is usable and the caller should
+// use the primary.
+func (rs *ReplicaSet) Pick() *Pool {
+	if rs == nil || len(rs.replicas) == 0 {
+		return nil
+	}
+
+	start := rs.next.Add(1)
+	for i := range len(rs.replicas) {
+		r := rs.replicas[(start+uint64(i))%uint64(len(rs.replicas))]
+		if r.usable.Load() {
+			return r.pool
+		}
+	}
+
+	return nil
+}
+
+// Run probes every replica's lag every replicaCheckInterval until ctx is
+// cancelled. It should be run as a goroutine.
+func (rs *ReplicaSet) Run(ctx context.Context) {
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, r := range rs.replicas {
+			rs.probe(ctx, r)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe measures one replica's lag and marks it usable or not.
+func (rs *ReplicaSet) probe(ctx context.Context, r *replica) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	log := rs.log.WithField("replica", r.host)
+
+	var seconds float64
+	if err := r.pool.QueryRow(ctx, replicaLagSQL).Scan(&seconds); err != nil {
+		if r.usable.Swap(false) {
+			log.WithError(err).Warn("replica lag probe failed, reading from primary")
+		}
+
+		return
+	}
+
+	lag := time.Duration(seconds * float64(time.Second))
+
+	usable := seconds >= 0 && lag <= rs.maxLag
+	if was := r.usable.Swap(usable); was != usable {
+		switch {
+		case usable:
+			log.WithField("lag_ms", lag.Milliseconds()).Info("replica caught up, routing reads to it")
+		case seconds < 0:
+			log.Warn("replica is not a standby, reading from primary")
+		default:
+			log.WithField("lag_ms", lag.Milliseconds()).Warn("replica lagging, reading from primary")
+		}
+	}
+}
+
+// Close closes every replica pool.
+func (rs *ReplicaSet) Close() {
+	if rs == nil {
+		return
+	}
+
+	for _, r := range rs.replicas {
+		r.pool.Close()
+	}
+}
@@ -0,0 +1,34 @@
+package dbpool
+
+import "testing"
+
+func TestReplicaSet_Pick(t *testing.T) {
+	t.Parallel()
+
+	var none *ReplicaSet
+	if got := none.Pick(); got != nil {
+		t.Errorf("nil set Pick() = %p, want nil", got)
+	}
+
+	a, b, c := &replica{pool: &Pool{}}, &replica{pool: &Pool{}}, &replica{pool: &Pool{}}
+	a.usable.Store(true)
+	c.usable.Store(true)
+
+	rs := &ReplicaSet{replicas: []*replica{a, b, c}}
+
+	seen := map[*Pool]int{}
+	for range 6 {
+		seen[rs.Pick()]++
+	}
+
+	if seen[a.pool] == 0 || seen[c.pool] == 0 || seen[b.pool] != 0 {
+		t.Errorf("Pick() spread = a:%d b:%d c:%d, want only a and c, both used", seen[a.pool], seen[b.pool], seen[c.pool])
+	}
+
+	a.usable.Store(false)
+	c.usable.Store(false)
+
+	if got := rs.Pick(); got != nil {
+		t.Errorf("Pick() with no usable replica = %p, want nil", got)
+	}
+}
@@ -0,0 +1,107 @@
+package dbpool
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+// poolCounters holds the pool statistics pgxpool does not keep itself.
+type poolCounters struct {
+	failedAcquires atomic.Int64
+	recycledConns  atomic.Int64
+	resets         atomic.Int64
+}
+
+type acquireStartKey struct{}
+
+// TraceAcquireStart implements pgxpool.AcquireTracer.
+func (t queryTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return context.WithValue(ctx, acquireStartKey{}, time.Now())
+}
+
+// TraceAcquireEnd implements pgxpool.AcquireTracer.
+func (t queryTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	if start, ok := ctx.Value(acquireStartKey{}).(time.Time); ok {
+		metrics.DBPoolAcquireDuration.Observe(time.Since(start).Seconds())
+	}
+
+	if data.Err != nil {
+		metrics.DBPoolAcquireFailures.Inc()
+
+		if t.counters != nil {
+			t.counters.failedAcquires.Add(1)
+		}
+	}
+}
+
+// PoolStats is a snapshot of the connection pool, reported by the health
+// endpoint.
+type PoolStats struct {
+	MaxConns          int32   `json:"max_conns"`
+	TotalConns        int32   `json:"total_conns"`
+	IdleConns         int32   `json:"idle_conns"`
+	AcquiredConns     int32   `json:"acquired_conns"`
+	ConstructingConns int32   `json:"constructing_conns"`
+	AcquireCount      int64   `json:"acquire_count"`
+	EmptyAcquireCount int64   `json:"empty_acquire_count"`
+	FailedAcquires    int64   `json:"failed_acquires"`
+	AvgAcquireMs      float64 `json:"avg_acquire_ms"`
+	RecycledConns     int64   `json:"recycled_conns"`
+	Resets            int64   `json:"resets"`
+	Saturated         bool    `json:"saturated"`
+}
+
+// Stats returns a snapshot of the pool's connections and acquire history.
+func (p *Pool) Stats() PoolStats {
+	st := p.pool.Stat()
+
+	stats := PoolStats{
+		MaxConns:          st.MaxConns(),
+		TotalConns:        st.TotalConns(),
+		IdleConns:         st.IdleConns(),
+		AcquiredConns:     st.AcquiredConns(),
+		ConstructingConns: st.ConstructingConns(),
+		AcquireCount:      st.AcquireCount(),
+		EmptyAcquireCount: st.EmptyAcquireCount(),
+		FailedAcquires:    p.counters.failedAcquires.Load(),
+		RecycledConns:     p.counters.recycledConns.Load(),
+		Resets:            p.counters.resets.Load(),
+		Saturated:         st.MaxConns() > 0 && st.AcquiredConns() >= st.MaxConns(),
+	}
+
+	if n := st.AcquireCount(); n > 0 {
+		stats.AvgAcquireMs = float64(st.AcquireDuration().Microseconds()) / float64(n) / 1000
+	}
+
+	return stats
+}
+
+// sample reads the cumulative counters the supervisor compares between
+// checks.
+func (p *Pool) sample() poolSample {
+	st := p.pool.Stat()
+
+	return poolSample{
+		maxConns:        st.MaxConns(),
+		acquiredConns:   st.AcquiredConns(),
+		acquireCount:    st.AcquireCount(),
+		acquireDuration: st.AcquireDuration(),
+		emptyAcquires:   st.EmptyAcquireCount(),
+		failedAcquires:  p.counters.failedAcquires.Load(),
+	}
+}
+
+// recordConnections publishes the pool's connection counts as metrics.
+func (p *Pool) recordConnections() {
+	st := p.pool.Stat()
+
+	metrics.DBPoolConnections.WithLabelValues("idle").Set(float64(st.IdleConns()))
+	metrics.DBPoolConnections.WithLabelValues("acquired").Set(float64(st.AcquiredConns()))
+	metrics.DBPoolConnections.WithLabelValues("constructing").Set(float64(st.ConstructingConns()))
+	metrics.DBPoolConnections.WithLabelValues("max").Set(float64(st.MaxConns()))
+}
@@ -0,0 +1,193 @@
+package dbpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+// Supervisor limits.
+const (
+	superviseInterval    = 15 * time.Second
+	idleProbeTimeout     = 2 * time.Second
+	slowAcquireThreshold = 250 * time.Millisecond
+
+	// resetAfterFailedChecks is how many checks in a row must find the pool
+	// failing before the supervisor resets it.
+	resetAfterFailedChecks = 3
+)
+
+// poolSample holds the pool's cumulative acquire counters at one moment.
+type poolSample struct {
+	maxConns        int32
+	acquiredConns   int32
+	acquireCount    int64
+	acquireDuration time.Duration
+	emptyAcquires   int64
+	failedAcquires  int64
+}
+
+// poolHealth is what one check concludes from the samples taken at its start
+// and at the previous check.
+type poolHealth struct {
+	avgAcquire     time.Duration
+	emptyAcquires  int64
+	failedAcquires int64
+
+	saturated bool // every connection is checked out
+	slow      bool // acquires took slowAcquireThreshold or longer on average
+	failing   bool // acquires failed and none succeeded
+}
+
+// assess compares two samples of the pool.
+func assess(prev, cur poolSample) poolHealth {
+	acquires := cur.acquireCount - prev.acquireCount
+
+	h := poolHealth{
+		emptyAcquires:  cur.emptyAcquires - prev.emptyAcquires,
+		failedAcquires: cur.failedAcquires - prev.failedAcquires,
+		saturated:      cur.maxConns > 0 && cur.acquiredConns >= cur.maxConns,
+	}
+
+	if acquires > 0 {
+		h.avgAcquire = (cur.acquireDuration - prev.acquireDuration) / time.Duration(acquires)
+	}
+
+	h.slow = h.avgAcquire >= slowAcquireThreshold
+	h.failing = h.failedAcquires > 0 && acquires == 0
+
+	return h
+}
+
+// Supervisor watches a Pool: it logs when the pool saturates or acquires
+// turn slow or fail, probes idle connections and recycles broken ones, and
+// resets the whole pool when it keeps failing.
+type Supervisor struct {
+	pool *Pool
+	log  *logrus.Logger
+
+	last         poolSample
+	failedChecks int
+}
+
+// NewSupervisor creates a Supervisor for pool. Call Run to start it.
+func NewSupervisor(pool *Pool, log *logrus.Logger) *Supervisor {
+	return &Supervisor{pool: pool, log: log}
+}
+
+// Run checks the pool every superviseInterval until ctx is cancelled. It
+// should be run as a goroutine.
+func (s *Supervisor) Run(ctx context.Context) {
+	ticker := time.NewTicker(superviseInterval)
+	defer ticker.Stop()
+
+	s.last = s.pool.sample()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+// check runs one supervision pass.
+func (s *Supervisor) check(ctx context.Context) {
+	cur := s.pool.sample()
+	h := assess(s.last, cur)
+	s.last = cur
+
+	s.pool.recordConnections()
+
+	log := s.log.WithFields(logrus.Fields{
+		"max_conns":       cur.maxConns,
+		"acquired_conns":  cur.acquiredConns,
+		"avg_acquire_ms":  h.avgAcquire.Milliseconds(),
+		"empty_acquires":  h.emptyAcquires,
+		"failed_acquires": h.failedAcquires,
+	})
+
+	if h.saturated {
+		log.Warn("database pool saturated: every connection is in use")
+	}
+
+	if h.slow {
+		log.Warn("database pool acquires are slow")
+	}
+
+	if h.failedAcquires > 0 {
+		log.Warn("database pool acquires failed")
+	}
+
+	probed, broken := s.pool.recycleBroken(ctx)
+	if broken > 0 {
+		log.WithField("recycled", broken).Warn("recycled broken database connections")
+	}
+
+	if h.failing || (probed > 0 && broken == probed) {
+		s.failedChecks++
+	} else {
+		s.failedChecks = 0
+	}
+
+	if s.failedChecks >= resetAfterFailedChecks {
+		log.WithField("failed_checks", s.failedChecks).Warn("resetting database pool")
+		s.pool.reset()
+		s.failedChecks = 0
+	}
+}
+
+// recycleBroken pings every idle connection and closes those that fail, so
+// the pool replaces them before a request acquires one. It returns how many
+// connections were probed and how many were broken.
+func (p *Pool) recycleBroken(ctx context.Context) (probed, broken int) {
+	conns := p.pool.AcquireAllIdle(ctx)
+
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Int64
+	)
+
+	for _, c := range conns {
+		wg.Add(1)
+
+		go func(c *pgxpool.Conn) {
+			defer wg.Done()
+			defer c.Release()
+
+			pingCtx, cancel := context.WithTimeout(ctx, idleProbeTimeout)
+			defer cancel()
+
+			if err := c.Ping(pingCtx); err != nil {
+				c.Conn().Close(pingCtx) //nolint:errcheck // the connection is already broken; Release destroys it.
+				failed.Add(1)
+			}
+		}(c)
+	}
+
+	wg.Wait()
+
+	broken = int(failed.Load())
+	if broken > 0 {
+		p.counters.recycledConns.Add(int64(broken))
+		metrics.DBPoolRecycledConns.WithLabelValues("broken").Add(float64(broken))
+	}
+
+	return len(conns), broken
+}
+
+// reset closes every connection; checked-out connections are closed when
+// they are released.
+func (p *Pool) reset() {
+	p.pool.Reset()
+	p.counters.resets.Add(1)
+	metrics.DBPoolRecycledConns.WithLabelValues("reset").Inc()
+}
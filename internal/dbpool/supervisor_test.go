@@ -0,0 +1,73 @@
+package dbpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestAssess(t *testing.T) {
+	t.Parallel()
+
+	prev := poolSample{maxConns: 10, acquireCount: 100, acquireDuration: time.Second, emptyAcquires: 5, failedAcquires: 1}
+
+	tests := []struct {
+		name string
+		cur  poolSample
+		want poolHealth
+	}{
+		{
+			name: "healthy",
+			cur:  poolSample{maxConns: 10, acquiredConns: 3, acquireCount: 200, acquireDuration: 2 * time.Second, emptyAcquires: 5, failedAcquires: 1},
+			want: poolHealth{avgAcquire: 10 * time.Millisecond},
+		},
+		{
+			name: "saturated and slow",
+			cur:  poolSample{maxConns: 10, acquiredConns: 10, acquireCount: 110, acquireDuration: 4 * time.Second, emptyAcquires: 15, failedAcquires: 1},
+			want: poolHealth{avgAcquire: 300 * time.Millisecond, emptyAcquires: 10, saturated: true, slow: true},
+		},
+		{
+			name: "some acquires failed",
+			cur:  poolSample{maxConns: 10, acquireCount: 101, acquireDuration: time.Second + time.Millisecond, emptyAcquires: 5, failedAcquires: 3},
+			want: poolHealth{avgAcquire: time.Millisecond, failedAcquires: 2},
+		},
+		{
+			name: "every acquire failed",
+			cur:  poolSample{maxConns: 10, acquireCount: 100, acquireDuration: time.Second, emptyAcquires: 5, failedAcquires: 4},
+			want: poolHealth{failedAcquires: 3, failing: true},
+		},
+		{
+			name: "idle",
+			cur:  prev,
+			want: poolHealth{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := assess(prev, tt.cur); got != tt.want {
+				t.Errorf("assess() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryTracer_CountsFailedAcquires(t *testing.T) {
+	t.Parallel()
+
+	counters := &poolCounters{}
+	tracer := queryTracer{counters: counters}
+
+	ctx := tracer.TraceAcquireStart(context.Background(), nil, pgxpool.TraceAcquireStartData{})
+	tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{})
+	tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: errors.New("connection refused")})
+
+	if got := counters.failedAcquires.Load(); got != 1 {
+		t.Errorf("failedAcquires = %d, want 1", got)
+	}
+}
@@ -0,0 +1,63 @@
+package dbpool
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// maxTracedStatement caps the SQL recorded on a query span. Only the
+// statement text is recorded, never its arguments.
+const maxTracedStatement = 2048
+
+// queryTracer records a span for every query run through the pool and times
+// every connection acquire.
+type queryTracer struct {
+	counters *poolCounters
+}
+
+type querySpanKey struct{}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !tracing.Enabled() {
+		return ctx
+	}
+
+	statement := data.SQL
+	if len(statement) > maxTracedStatement {
+		statement = statement[:maxTracedStatement]
+	}
+
+	ctx, span := tracing.StartClient(ctx, "pg "+operation(data.SQL),
+		tracing.String("db.system", "postgresql"),
+		tracing.String("db.statement", statement),
+	)
+
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, _ := ctx.Value(querySpanKey{}).(*tracing.Span)
+	if span == nil {
+		return
+	}
+
+	span.SetAttributes(tracing.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	span.RecordError(data.Err)
+	span.End()
+}
+
+// operation returns the leading SQL keyword of sql, such as SELECT.
+func operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	return strings.ToUpper(fields[0])
+}
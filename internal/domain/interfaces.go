@@ -12,39 +12,48 @@
 
 // NodeService defines all node operations.
 type NodeService interface {
-	ListNodes(ctx context.Context, tenantID string, typeFilter string, minSalience float64, limit, offset int) ([]models.Node, bool, error)
+	ListNodes(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error)
 	GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error)
 	GetNodeByLabel(ctx context.Context, tenantID, label string) (*models.Node, error)
 	CreateNode(ctx context.Context, tenantID string, req models.CreateNodeRequest) (*models.Node, error)
 	UpdateNode(ctx context.Context, tenantID string, nodeID string, req models.UpdateNodeRequest) (*models.Node, error)
 	PatchNodeProperties(ctx context.Context, tenantID string, nodeID string, req models.PatchPropertiesRequest) (*models.Node, error)
-	DeleteNode(ctx context.Context, tenantID, nodeID string) error
+	DeleteNode(ctx context.Context, tenantID, nodeID, mode string) error
 	MigrateNode(ctx context.Context, tenantID, oldID string, req models.MigrateNodeRequest) (*models.MigrateNodeResult, error)
+	MergeNode(ctx context.Context, tenantID, sourceID, targetID string, req models.MergeNodeRequest) (*models.MergeNodeResult, error)
+	PreviewDeleteByFilter(ctx context.Context, tenantID string, filter models.NodeFilter) (*models.DeletePreview, error)
+	DeleteByFilter(ctx context.Context, tenantID string, req models.DeleteByFilterRequest) (*models.DeleteByFilterResult, error)
 }
 
 // EdgeService defines all edge operations.
 type EdgeService interface {
-	ListEdges(ctx context.Context, tenantID string, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) ([]models.Edge, bool, error)
+	ListEdges(ctx context.Context, tenantID string, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool, after *models.EdgeCursor) ([]models.Edge, bool, error)
 	CreateEdge(ctx context.Context, tenantID string, req models.CreateEdgeRequest) (*models.Edge, error)
 	UpdateEdge(ctx context.Context, tenantID string, source, target, relation string, req models.UpdateEdgeRequest) (*models.Edge, error)
 	PatchEdgeProperties(ctx context.Context, tenantID string, source, target, relation string, req models.PatchPropertiesRequest) (*models.Edge, error)
 	DeleteEdge(ctx context.Context, tenantID string, source, target, relation string) error
+	EdgesExist(ctx context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error)
 }
 
 // SearchService defines search operations.
 // The service layer handles embedding generation — callers pass query strings.
 type SearchService interface {
-	FullTextSearch(ctx context.Context, tenantID string, query string, typeFilter string, minSalience float64, limit int) ([]models.Node, error)
-	SemanticSearch(ctx context.Context, tenantID, query string, limit int) ([]models.ScoredNode, error)
-	HybridSearch(ctx context.Context, tenantID, query string, limit int) ([]models.Node, error)
+	FullTextSearch(ctx context.Context, tenantID string, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
+	FullTextSearchFacets(ctx context.Context, tenantID, query string, filter models.SearchFilter) (*models.SearchFacets, error)
+	SemanticSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.ScoredNode, error)
+	HybridSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
 }
 
 // GraphService defines graph traversal operations.
 type GraphService interface {
-	Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error)
-	Traverse(ctx context.Context, tenantID string, nodeID string, maxHops int) (*models.TraverseResult, error)
+	Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (*models.NeighborResult, error)
+	Traverse(ctx context.Context, tenantID string, nodeID string, maxHops int, opts models.ReadOpts) (*models.TraverseResult, error)
 	GraphContext(ctx context.Context, tenantID, nodeID string) (*models.ContextResult, error)
 	ShortestPath(ctx context.Context, tenantID, fromID, toID string) ([]models.Node, error)
+	Summary(ctx context.Context, tenantID, nodeID string, limit int) (*models.GraphSummary, error)
+	Subgraph(ctx context.Context, tenantID string, req models.SubgraphRequest) (*models.SubgraphResult, error)
+	Communities(ctx context.Context, tenantID string, req models.CommunityRequest) (*models.CommunityResult, error)
+	AsOf(ctx context.Context, tenantID string, q models.AsOfQuery) (*models.GraphSnapshot, error)
 }
 
 // SalienceService defines salience scoring operations.
@@ -52,6 +61,8 @@ type SalienceService interface {
 	BoostNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error)
 	SupersedeNode(ctx context.Context, tenantID, oldID, newID string) error
 	RecalculateSalience(ctx context.Context, tenantID string) (int, error)
+	TopSalientNodes(ctx context.Context, tenantID, typeFilter string, limit int) ([]models.SalienceEntry, error)
+	DecayingNodes(ctx context.Context, tenantID, typeFilter string, limit int) ([]models.SalienceEntry, error)
 }
 
 // BulkService defines bulk upsert operations.
@@ -79,13 +90,160 @@ type AdminService interface {
 	ReprocessNodes(ctx context.Context, tenantID string, req models.ReprocessNodesRequest) (*models.ReprocessNodesResult, error)
 	RunMaintenance(ctx context.Context, tenantID string, req models.MaintenanceRunRequest) (*models.MaintenanceRunResult, error)
 	ListMergeSuggestions(ctx context.Context, tenantID string, opts models.MergeSuggestionListOpts) ([]models.MergeSuggestion, error)
+	ListDuplicates(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]models.DuplicatePair, error)
 	RecordRetrievalFeedback(ctx context.Context, tenantID string, req models.RetrievalFeedbackRequest) (*models.RetrievalFeedbackRecord, error)
 	GetRetrievalFeedbackSummary(ctx context.Context, tenantID string, opts models.RetrievalFeedbackListOpts) (*models.RetrievalFeedbackSummary, error)
+	ExplainQuery(ctx context.Context, tenantID string, req models.ExplainRequest) (*models.ExplainResult, error)
+}
+
+// APIKeyService defines tenant API key management.
+type APIKeyService interface {
+	GetAPIKeyStatus(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	RotateAPIKey(ctx context.Context, tenantID string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error)
+	RevokePreviousAPIKey(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	EnableRequestSigning(ctx context.Context, tenantID string) (*models.RequestSigningSecret, error)
+	DisableRequestSigning(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	CreateManagedAPIKey(ctx context.Context, tenantID string, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error)
+	ListManagedAPIKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error)
+	RevokeManagedAPIKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error)
+	RotateManagedAPIKey(ctx context.Context, tenantID, keyID string, req models.RotateAPIKeyRequest) (*models.RotatedAPIKey, error)
+}
+
+// TenantService defines operator management of tenants. operatorID is the
+// calling operator's tenant, which cannot suspend or delete itself.
+type TenantService interface {
+	CreateTenant(ctx context.Context, req models.CreateTenantRequest) (*models.CreatedTenant, error)
+	ListTenants(ctx context.Context) ([]models.Tenant, error)
+	GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+	UpdateTenant(ctx context.Context, tenantID string, req models.UpdateTenantRequest) (*models.Tenant, error)
+	RotateTenantKey(ctx context.Context, tenantID string, req models.RotateAPIKeyRequest) (*models.APIKeyRotation, error)
+	SuspendTenant(ctx context.Context, operatorID, tenantID string) (*models.Tenant, error)
+	ResumeTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+	DeleteTenant(ctx context.Context, operatorID, tenantID string) (*models.TenantPurgeResult, error)
+	ListTenantKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error)
+	CreateTenantKey(ctx context.Context, tenantID string, req models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error)
+	RevokeTenantKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error)
+	ImpersonateTenant(
+		ctx context.Context, operatorID, tenantID string, req models.ImpersonateTenantRequest,
+	) (*models.ImpersonationKey, error)
+}
+
+// PartitionService defines reporting on the tenant partitions of the graph
+// tables.
+type PartitionService interface {
+	ListPartitions(ctx context.Context) ([]models.PartitionSize, error)
+}
+
+// VectorIndexService defines inspecting and rebuilding the node embedding
+// index.
+type VectorIndexService interface {
+	VectorIndexStatus(ctx context.Context) (*models.VectorIndexStatus, error)
+	RebuildVectorIndex(ctx context.Context, req models.VectorIndexRequest) (*models.VectorIndexRequest, error)
+}
+
+// IndexAdvisorService defines recommending and creating kg_nodes indexes
+// from recorded query statistics.
+type IndexAdvisorService interface {
+	AdviseIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error)
+	CreateAdvisedIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error)
+}
+
+// GraphDiffService defines comparing the graphs of two tenants.
+type GraphDiffService interface {
+	DiffTenants(ctx context.Context, leftTenantID, rightTenantID string) (*models.GraphDiff, error)
+}
+
+// WatchService defines per-watcher node watch lists.
+type WatchService interface {
+	Watch(ctx context.Context, tenantID, watcher, nodeID string) (*models.Watch, error)
+	Unwatch(ctx context.Context, tenantID, watcher, nodeID string) error
+	ListWatches(ctx context.Context, tenantID, watcher string) ([]models.Watch, error)
+}
+
+// QuarantineService defines review of nodes flagged as likely prompt
+// injection.
+type QuarantineService interface {
+	ListQuarantined(ctx context.Context, tenantID string, limit int) ([]models.QuarantinedNode, error)
+	ReleaseNode(ctx context.Context, tenantID, nodeID string) error
+}
+
+// RelationCatalogService defines the tenant's catalog of allowed relation
+// triples and the report of triples in use.
+type RelationCatalogService interface {
+	GetRelationCatalog(ctx context.Context, tenantID string) (*models.RelationCatalog, error)
+	SetRelationCatalog(ctx context.Context, tenantID string, catalog models.RelationCatalog) (*models.RelationCatalog, error)
+	ListRelationUsage(ctx context.Context, tenantID string) ([]models.RelationUsage, error)
+}
+
+// MetapathService defines named multi-hop relation path templates.
+type MetapathService interface {
+	PutMetapath(ctx context.Context, tenantID, name string, req models.PutMetapathRequest) (*models.Metapath, error)
+	GetMetapath(ctx context.Context, tenantID, name string) (*models.Metapath, error)
+	ListMetapaths(ctx context.Context, tenantID string) ([]models.Metapath, error)
+	DeleteMetapath(ctx context.Context, tenantID, name string) error
+	RunMetapath(ctx context.Context, tenantID, name, startID string, limit int, opts models.ReadOpts) (*models.MetapathResult, error)
+}
+
+// SnapshotService defines named logical graph snapshots and their drift.
+type SnapshotService interface {
+	CreateSnapshot(ctx context.Context, tenantID string, req models.CreateSnapshotRequest) (*models.Snapshot, error)
+	GetSnapshot(ctx context.Context, tenantID, name string) (*models.Snapshot, error)
+	ListSnapshots(ctx context.Context, tenantID string) ([]models.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, tenantID, name string) error
+	CompareSnapshots(ctx context.Context, tenantID, from, to string) (*models.SnapshotComparison, error)
+}
+
+// UsageService defines tenant resource usage reporting.
+type UsageService interface {
+	GetUsage(ctx context.Context, tenantID string) (*models.TenantUsage, error)
+	ReportLLMUsage(ctx context.Context, tenantID string, req models.ReportModelUsageRequest) error
+}
+
+// TagService defines embedding-based tag suggestion.
+type TagService interface {
+	SuggestTags(ctx context.Context, tenantID, nodeID string, limit int) (*models.TagSuggestions, error)
+	RebuildTagCentroids(ctx context.Context, tenantID string) (*models.TagCentroidRebuild, error)
+}
+
+// CoAccessService defines co-access tracking and related_to inference.
+type CoAccessService interface {
+	RecordAccess(ctx context.Context, tenantID, sessionID string, nodeIDs []string) error
+	InferRelations(ctx context.Context, tenantID string, req models.CoAccessInferenceRequest) (*models.CoAccessInferenceResult, error)
+}
+
+// AccessAnalyticsService defines access pattern tracking and reporting.
+type AccessAnalyticsService interface {
+	TrackAccess(tenantID string, kind models.AccessKind, key string)
+	GetAccessAnalytics(ctx context.Context, tenantID string, req models.AccessAnalyticsRequest) (*models.AccessAnalytics, error)
 }
 
 // HistoryService defines property history operations.
 type HistoryService interface {
-	GetPropertyHistory(ctx context.Context, tenantID, nodeID string, propertyKey string, limit, offset int) ([]models.PropertyChange, bool, error)
+	GetPropertyHistory(ctx context.Context, tenantID, nodeID string, propertyKey, field string, limit, offset int) ([]models.PropertyChange, bool, error)
+	GetEdgePropertyHistory(ctx context.Context, tenantID, source, target, relation string, propertyKey string, limit, offset int) ([]models.EdgePropertyChange, bool, error)
+	ListHistory(ctx context.Context, tenantID string, opts models.HistoryListOpts) ([]models.PropertyChange, bool, error)
+}
+
+// HistoryRetentionService defines property history retention operations.
+type HistoryRetentionService interface {
+	GetHistoryRetention(ctx context.Context, tenantID string) (*models.HistoryRetention, error)
+	SetHistoryRetention(ctx context.Context, tenantID string, r models.HistoryRetention) (*models.HistoryRetention, error)
+	PruneTenant(ctx context.Context, tenantID string) (*models.HistoryPruneResult, error)
+}
+
+// ArchiveService defines salience-based node archival operations.
+type ArchiveService interface {
+	GetArchivePolicy(ctx context.Context, tenantID string) (*models.ArchivePolicy, error)
+	SetArchivePolicy(ctx context.Context, tenantID string, p models.ArchivePolicy) (*models.ArchivePolicy, error)
+	ArchiveTenant(ctx context.Context, tenantID string) (*models.ArchiveResult, error)
+	ListArchivedNodes(ctx context.Context, tenantID string, limit, offset int) ([]models.ArchivedNode, bool, error)
+	RestoreNode(ctx context.Context, tenantID, nodeID string) (*models.RestoreResult, error)
+}
+
+// ReembedService defines embedding model migration operations.
+type ReembedService interface {
+	StartReembed(ctx context.Context, tenantID string) (*models.ReembedStatus, error)
+	ReembedStatus(ctx context.Context, tenantID string) (*models.ReembedStatus, error)
 }
 
 // AliasService defines persisted alias operations.
@@ -106,6 +264,65 @@ type ExportImportService interface {
 	// ValidateImport checks an export payload for consistency errors without writing
 	// anything to the database. Returns a list of human-readable error descriptions.
 	ValidateImport(ctx context.Context, tenantID string, data *models.ExportFormat) ([]string, error)
+	// ImportConflicts returns the stored versions of the payload's records that
+	// already exist, for resolving conflicts before an import.
+	ImportConflicts(ctx context.Context, tenantID string, data *models.ExportFormat) (*models.ImportConflicts, error)
+	// ExportManifest returns the export header and record counts for a chunked export.
+	ExportManifest(ctx context.Context, tenantID string) (*models.ExportManifest, error)
+	// ExportNodes returns one page of nodes following cursor (empty for the first page).
+	ExportNodes(ctx context.Context, tenantID, cursor string, limit int) (*models.ExportNodePage, error)
+	// ExportEdges returns one page of edges following cursor (empty for the first page).
+	ExportEdges(ctx context.Context, tenantID, cursor string, limit int) (*models.ExportEdgePage, error)
+	// StreamExport passes the export to emit one record at a time, reading the
+	// graph page by page so memory use stays flat for large tenants.
+	StreamExport(ctx context.Context, tenantID string, emit func(*models.ExportRecord) error) error
+	// ExportJSONLD exports the graph as JSON-LD using the tenant's context.
+	ExportJSONLD(ctx context.Context, tenantID string) (*models.JSONLDDocument, error)
+	// GetJSONLDContext returns the context JSON-LD exports use.
+	GetJSONLDContext(ctx context.Context, tenantID string) (*models.JSONLDContext, error)
+	// SetJSONLDContext replaces the tenant's context; nil restores the default.
+	SetJSONLDContext(ctx context.Context, tenantID string, c *models.JSONLDContext) (*models.JSONLDContext, error)
+}
+
+// ImportSessionService defines chunked, resumable import operations.
+type ImportSessionService interface {
+	// CreateImportSession opens a session that stages chunks server-side.
+	CreateImportSession(ctx context.Context, tenantID string, req models.CreateImportSessionRequest) (*models.ImportSession, error)
+	// GetImportSession returns a session and the chunks it has received.
+	GetImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportSession, error)
+	// PutImportChunk validates and stages one chunk. It returns the validation
+	// errors, if any, without staging the chunk.
+	PutImportChunk(ctx context.Context, tenantID, sessionID string, seq int, chunk *models.ImportChunk) ([]string, error)
+	// CommitImportSession validates the staged chunks as a whole and applies
+	// them in a single transaction.
+	CommitImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportResult, error)
+	// DeleteImportSession aborts a session and discards its chunks.
+	DeleteImportSession(ctx context.Context, tenantID, sessionID string) error
+}
+
+// BranchService defines branch operations. A branch stages writes without
+// touching the live graph; reads on a branch see its staged versions over the
+// live ones.
+type BranchService interface {
+	CreateBranch(ctx context.Context, tenantID string, req models.CreateBranchRequest) (*models.Branch, error)
+	ListBranches(ctx context.Context, tenantID string) ([]models.Branch, error)
+	GetBranch(ctx context.Context, tenantID, branchID string) (*models.Branch, error)
+	// DeleteBranch discards a branch and everything staged on it.
+	DeleteBranch(ctx context.Context, tenantID, branchID string) error
+	GetBranchChanges(ctx context.Context, tenantID, branchID string) (*models.BranchChanges, error)
+	GetBranchNode(ctx context.Context, tenantID, branchID, nodeID string) (*models.Node, error)
+	CreateBranchNode(ctx context.Context, tenantID, branchID string, req models.CreateNodeRequest) (*models.Node, error)
+	UpdateBranchNode(ctx context.Context, tenantID, branchID, nodeID string, req models.UpdateNodeRequest) (*models.Node, error)
+	// DeleteBranchNode stages the deletion of a node and its edges.
+	DeleteBranchNode(ctx context.Context, tenantID, branchID, nodeID string) error
+	GetBranchEdge(ctx context.Context, tenantID, branchID, source, target, relation string) (*models.Edge, error)
+	CreateBranchEdge(ctx context.Context, tenantID, branchID string, req models.CreateEdgeRequest) (*models.Edge, error)
+	UpdateBranchEdge(ctx context.Context, tenantID, branchID, source, target, relation string, req models.UpdateEdgeRequest) (*models.Edge, error)
+	DeleteBranchEdge(ctx context.Context, tenantID, branchID, source, target, relation string) error
+	BranchNeighbors(ctx context.Context, tenantID, branchID, nodeID string, limit int) (*models.NeighborResult, error)
+	// MergeBranch applies the branch to the live graph in one transaction and
+	// deletes it, unless conflicts stop the merge.
+	MergeBranch(ctx context.Context, tenantID, branchID string, req models.MergeBranchRequest) (*models.MergeBranchResult, error)
 }
 
 // EpisodicStore defines foundational episode and event persistence operations.
@@ -0,0 +1,39 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxBulkItems matches the REST bulk endpoints' per-request limit.
+const maxBulkItems = 1000
+
+// dropEdgesToMissing removes the edges whose source or target is one of the
+// missing node IDs, recording an item error for each. indexes maps each req
+// back to its position in the mutation input.
+func dropEdgesToMissing(
+	reqs []models.CreateEdgeRequest,
+	indexes []int,
+	missing []string,
+	itemErrs []*BulkItemError,
+) ([]models.CreateEdgeRequest, []*BulkItemError) {
+	gone := make(map[string]bool, len(missing))
+	for _, id := range missing {
+		gone[id] = true
+	}
+
+	kept := reqs[:0]
+	for i, req := range reqs {
+		switch {
+		case gone[req.Source]:
+			itemErrs = append(itemErrs, &BulkItemError{Index: indexes[i], Message: fmt.Sprintf("source node %s not found", req.Source)})
+		case gone[req.Target]:
+			itemErrs = append(itemErrs, &BulkItemError{Index: indexes[i], Message: fmt.Sprintf("target node %s not found", req.Target)})
+		default:
+			kept = append(kept, req)
+		}
+	}
+
+	return kept, itemErrs
+}
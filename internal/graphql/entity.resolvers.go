@@ -0,0 +1,28 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"context"
+)
+
+// FindNodeByID is the resolver for the findNodeByID field.
+func (r *entityResolver) FindNodeByID(ctx context.Context, id string) (*Node, error) {
+	tid, err := TenantIDFromContext(ctx)
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	n, err := r.NodeSvc.GetNode(ctx, tid, id)
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	return nodeToGQL(n), nil
+}
+
+// Entity returns EntityResolver implementation.
+func (r *Resolver) Entity() EntityResolver { return &entityResolver{r} }
+
+type entityResolver struct{ *Resolver }
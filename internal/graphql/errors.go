@@ -16,6 +16,7 @@
 	codeNotFound      = "NOT_FOUND"
 	codeBadRequest    = "BAD_REQUEST"
 	codeInternalError = "INTERNAL_ERROR"
+	codeQuotaExceeded = "QUOTA_EXCEEDED"
 )
 
 // gqlErr maps a service/store error to a user-friendly GraphQL error with
@@ -37,9 +38,14 @@ func gqlErr(ctx context.Context, err error) error {
 		errors.Is(err, models.ErrMissingSource),
 		errors.Is(err, models.ErrMissingTarget),
 		errors.Is(err, models.ErrMissingRelation),
-		errors.Is(err, models.ErrDuplicateKey):
+		errors.Is(err, models.ErrDuplicateKey),
+		errors.Is(err, models.ErrRelationNotAllowed),
+		errors.Is(err, models.ErrNodeHasEdges):
 		return gqlErrWithCode(ctx, err.Error(), codeBadRequest)
 
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return gqlErrWithCode(ctx, err.Error(), codeQuotaExceeded)
+
 	case strings.Contains(err.Error(), "exceeds maximum length"):
 		return gqlErrWithCode(ctx, err.Error(), codeBadRequest)
 
@@ -14,6 +14,7 @@
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
+	"github.com/99designs/gqlgen/plugin/federation/fedruntime"
 	gqlparser "github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -39,6 +40,7 @@ type Config struct {
 
 type ResolverRoot interface {
 	Edge() EdgeResolver
+	Entity() EntityResolver
 	Mutation() MutationResolver
 	Node() NodeResolver
 	Query() QueryResolver
@@ -63,6 +65,23 @@ type ComplexityRoot struct {
 		ID         func(childComplexity int) int
 	}
 
+	BulkEdgesResult struct {
+		Edges    func(childComplexity int) int
+		Errors   func(childComplexity int) int
+		Upserted func(childComplexity int) int
+	}
+
+	BulkItemError struct {
+		Index   func(childComplexity int) int
+		Message func(childComplexity int) int
+	}
+
+	BulkNodesResult struct {
+		Errors   func(childComplexity int) int
+		Nodes    func(childComplexity int) int
+		Upserted func(childComplexity int) int
+	}
+
 	ContextResult struct {
 		Edges     func(childComplexity int) int
 		Neighbors func(childComplexity int) int
@@ -72,6 +91,12 @@ type ComplexityRoot struct {
 	Edge struct {
 		AccessCount   func(childComplexity int) int
 		CreatedAt     func(childComplexity int) int
+		DateEnd       func(childComplexity int) int
+		DateLower     func(childComplexity int) int
+		DateQualifier func(childComplexity int) int
+		DateStart     func(childComplexity int) int
+		DateUpper     func(childComplexity int) int
+		IsCurrent     func(childComplexity int) int
 		Properties    func(childComplexity int) int
 		Relation      func(childComplexity int) int
 		SalienceScore func(childComplexity int) int
@@ -89,12 +114,18 @@ type ComplexityRoot struct {
 		HasMore func(childComplexity int) int
 	}
 
+	Entity struct {
+		FindNodeByID func(childComplexity int, id string) int
+	}
+
 	Mutation struct {
 		BoostNode           func(childComplexity int, id string) int
+		BulkUpsertEdges     func(childComplexity int, input []*CreateEdgeInput) int
+		BulkUpsertNodes     func(childComplexity int, input []*CreateNodeInput) int
 		CreateEdge          func(childComplexity int, input CreateEdgeInput) int
 		CreateNode          func(childComplexity int, input CreateNodeInput) int
 		DeleteEdge          func(childComplexity int, source string, target string, relation string) int
-		DeleteNode          func(childComplexity int, id string) int
+		DeleteNode          func(childComplexity int, id string, mode *string) int
 		RecalculateSalience func(childComplexity int) int
 		SupersedeNode       func(childComplexity int, oldID string, newID string) int
 		UpdateEdge          func(childComplexity int, source string, target string, relation string, input UpdateEdgeInput) int
@@ -126,17 +157,19 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		Audit          func(childComplexity int, entityType *string, entityID *string, action *string, since *string, limit *int, offset *int) int
-		Context        func(childComplexity int, id string) int
-		Edges          func(childComplexity int, source *string, target *string, relation *string, limit *int, offset *int) int
-		Neighbors      func(childComplexity int, id string, limit *int) int
-		Node           func(childComplexity int, id string) int
-		Nodes          func(childComplexity int, typeArg *string, minSalience *float64, limit *int, offset *int) int
-		Path           func(childComplexity int, from string, to string) int
-		Search         func(childComplexity int, query string, limit *int) int
-		SearchHybrid   func(childComplexity int, query string, limit *int) int
-		SearchSemantic func(childComplexity int, query string, limit *int) int
-		Traverse       func(childComplexity int, id string, maxHops *int) int
+		Audit              func(childComplexity int, entityType *string, entityID *string, action *string, since *string, limit *int, offset *int) int
+		Context            func(childComplexity int, id string) int
+		Edges              func(childComplexity int, source *string, target *string, relation *string, limit *int, offset *int) int
+		Neighbors          func(childComplexity int, id string, limit *int) int
+		Node               func(childComplexity int, id string) int
+		Nodes              func(childComplexity int, typeArg *string, minSalience *float64, limit *int, offset *int) int
+		Path               func(childComplexity int, from string, to string) int
+		Search             func(childComplexity int, query string, limit *int) int
+		SearchHybrid       func(childComplexity int, query string, limit *int) int
+		SearchSemantic     func(childComplexity int, query string, limit *int) int
+		Traverse           func(childComplexity int, id string, maxHops *int) int
+		__resolve__service func(childComplexity int) int
+		__resolve_entities func(childComplexity int, representations []map[string]any) int
 	}
 
 	SearchResult struct {
@@ -148,19 +181,28 @@ type ComplexityRoot struct {
 		Edges func(childComplexity int) int
 		Nodes func(childComplexity int) int
 	}
+
+	_Service struct {
+		SDL func(childComplexity int) int
+	}
 }
 
 type EdgeResolver interface {
 	SourceNode(ctx context.Context, obj *Edge) (*Node, error)
 	TargetNode(ctx context.Context, obj *Edge) (*Node, error)
 }
+type EntityResolver interface {
+	FindNodeByID(ctx context.Context, id string) (*Node, error)
+}
 type MutationResolver interface {
 	CreateNode(ctx context.Context, input CreateNodeInput) (*Node, error)
 	UpdateNode(ctx context.Context, id string, input UpdateNodeInput) (*Node, error)
-	DeleteNode(ctx context.Context, id string) (bool, error)
+	DeleteNode(ctx context.Context, id string, mode *string) (bool, error)
 	CreateEdge(ctx context.Context, input CreateEdgeInput) (*Edge, error)
 	UpdateEdge(ctx context.Context, source string, target string, relation string, input UpdateEdgeInput) (*Edge, error)
 	DeleteEdge(ctx context.Context, source string, target string, relation string) (bool, error)
+	BulkUpsertNodes(ctx context.Context, input []*CreateNodeInput) (*BulkNodesResult, error)
+	BulkUpsertEdges(ctx context.Context, input []*CreateEdgeInput) (*BulkEdgesResult, error)
 	BoostNode(ctx context.Context, id string) (*Node, error)
 	SupersedeNode(ctx context.Context, oldID string, newID string) (bool, error)
 	RecalculateSalience(ctx context.Context) (int, error)
@@ -258,6 +300,57 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.AuditEntry.ID(childComplexity), true
 
+	case "BulkEdgesResult.edges":
+		if e.complexity.BulkEdgesResult.Edges == nil {
+			break
+		}
+
+		return e.complexity.BulkEdgesResult.Edges(childComplexity), true
+	case "BulkEdgesResult.errors":
+		if e.complexity.BulkEdgesResult.Errors == nil {
+			break
+		}
+
+		return e.complexity.BulkEdgesResult.Errors(childComplexity), true
+	case "BulkEdgesResult.upserted":
+		if e.complexity.BulkEdgesResult.Upserted == nil {
+			break
+		}
+
+		return e.complexity.BulkEdgesResult.Upserted(childComplexity), true
+
+	case "BulkItemError.index":
+		if e.complexity.BulkItemError.Index == nil {
+			break
+		}
+
+		return e.complexity.BulkItemError.Index(childComplexity), true
+	case "BulkItemError.message":
+		if e.complexity.BulkItemError.Message == nil {
+			break
+		}
+
+		return e.complexity.BulkItemError.Message(childComplexity), true
+
+	case "BulkNodesResult.errors":
+		if e.complexity.BulkNodesResult.Errors == nil {
+			break
+		}
+
+		return e.complexity.BulkNodesResult.Errors(childComplexity), true
+	case "BulkNodesResult.nodes":
+		if e.complexity.BulkNodesResult.Nodes == nil {
+			break
+		}
+
+		return e.complexity.BulkNodesResult.Nodes(childComplexity), true
+	case "BulkNodesResult.upserted":
+		if e.complexity.BulkNodesResult.Upserted == nil {
+			break
+		}
+
+		return e.complexity.BulkNodesResult.Upserted(childComplexity), true
+
 	case "ContextResult.edges":
 		if e.complexity.ContextResult.Edges == nil {
 			break
@@ -289,6 +382,42 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Edge.CreatedAt(childComplexity), true
+	case "Edge.dateEnd":
+		if e.complexity.Edge.DateEnd == nil {
+			break
+		}
+
+		return e.complexity.Edge.DateEnd(childComplexity), true
+	case "Edge.dateLower":
+		if e.complexity.Edge.DateLower == nil {
+			break
+		}
+
+		return e.complexity.Edge.DateLower(childComplexity), true
+	case "Edge.dateQualifier":
+		if e.complexity.Edge.DateQualifier == nil {
+			break
+		}
+
+		return e.complexity.Edge.DateQualifier(childComplexity), true
+	case "Edge.dateStart":
+		if e.complexity.Edge.DateStart == nil {
+			break
+		}
+
+		return e.complexity.Edge.DateStart(childComplexity), true
+	case "Edge.dateUpper":
+		if e.complexity.Edge.DateUpper == nil {
+			break
+		}
+
+		return e.complexity.Edge.DateUpper(childComplexity), true
+	case "Edge.isCurrent":
+		if e.complexity.Edge.IsCurrent == nil {
+			break
+		}
+
+		return e.complexity.Edge.IsCurrent(childComplexity), true
 	case "Edge.properties":
 		if e.complexity.Edge.Properties == nil {
 			break
@@ -363,6 +492,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.EdgeConnection.HasMore(childComplexity), true
 
+	case "Entity.findNodeByID":
+		if e.complexity.Entity.FindNodeByID == nil {
+			break
+		}
+
+		args, err := ec.field_Entity_findNodeByID_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Entity.FindNodeByID(childComplexity, args["id"].(string)), true
+
 	case "Mutation.boostNode":
 		if e.complexity.Mutation.BoostNode == nil {
 			break
@@ -374,6 +515,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.BoostNode(childComplexity, args["id"].(string)), true
+	case "Mutation.bulkUpsertEdges":
+		if e.complexity.Mutation.BulkUpsertEdges == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_bulkUpsertEdges_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.BulkUpsertEdges(childComplexity, args["input"].([]*CreateEdgeInput)), true
+	case "Mutation.bulkUpsertNodes":
+		if e.complexity.Mutation.BulkUpsertNodes == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_bulkUpsertNodes_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.BulkUpsertNodes(childComplexity, args["input"].([]*CreateNodeInput)), true
 	case "Mutation.createEdge":
 		if e.complexity.Mutation.CreateEdge == nil {
 			break
@@ -417,7 +580,7 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.DeleteNode(childComplexity, args["id"].(string)), true
+		return e.complexity.Mutation.DeleteNode(childComplexity, args["id"].(string), args["mode"].(*string)), true
 	case "Mutation.recalculateSalience":
 		if e.complexity.Mutation.RecalculateSalience == nil {
 			break
@@ -682,6 +845,23 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.Traverse(childComplexity, args["id"].(string), args["maxHops"].(*int)), true
+	case "Query._service":
+		if e.complexity.Query.__resolve__service == nil {
+			break
+		}
+
+		return e.complexity.Query.__resolve__service(childComplexity), true
+	case "Query._entities":
+		if e.complexity.Query.__resolve_entities == nil {
+			break
+		}
+
+		args, err := ec.field_Query__entities_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.__resolve_entities(childComplexity, args["representations"].([]map[string]any)), true
 
 	case "SearchResult.node":
 		if e.complexity.SearchResult.Node == nil {
@@ -709,6 +889,13 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.TraverseResult.Nodes(childComplexity), true
 
+	case "_Service.sdl":
+		if e.complexity._Service.SDL == nil {
+			break
+		}
+
+		return e.complexity._Service.SDL(childComplexity), true
+
 	}
 	return 0, false
 }
@@ -831,6 +1018,75 @@ func sourceData(filename string) string {
 var sources = []*ast.Source{
 	{Name: "schema.graphql", Input: sourceData("schema.graphql"), BuiltIn: false},
 	{Name: "types.graphql", Input: sourceData("types.graphql"), BuiltIn: false},
+	{Name: "federation/directives.graphql", Input: `
+	directive @authenticated on FIELD_DEFINITION | OBJECT | INTERFACE | SCALAR | ENUM
+	directive @composeDirective(name: String!) repeatable on SCHEMA
+	directive @extends on OBJECT | INTERFACE
+	directive @external on OBJECT | FIELD_DEFINITION
+	directive @key(fields: FieldSet!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE
+	directive @inaccessible on
+	  | ARGUMENT_DEFINITION
+	  | ENUM
+	  | ENUM_VALUE
+	  | FIELD_DEFINITION
+	  | INPUT_FIELD_DEFINITION
+	  | INPUT_OBJECT
+	  | INTERFACE
+	  | OBJECT
+	  | SCALAR
+	  | UNION
+	directive @interfaceObject on OBJECT
+	directive @link(import: [String!], url: String!) repeatable on SCHEMA
+	directive @override(from: String!, label: String) on FIELD_DEFINITION
+	directive @policy(policies: [[federation__Policy!]!]!) on
+	  | FIELD_DEFINITION
+	  | OBJECT
+	  | INTERFACE
+	  | SCALAR
+	  | ENUM
+	directive @provides(fields: FieldSet!) on FIELD_DEFINITION
+	directive @requires(fields: FieldSet!) on FIELD_DEFINITION
+	directive @requiresScopes(scopes: [[federation__Scope!]!]!) on
+	  | FIELD_DEFINITION
+	  | OBJECT
+	  | INTERFACE
+	  | SCALAR
+	  | ENUM
+	directive @shareable repeatable on FIELD_DEFINITION | OBJECT
+	directive @tag(name: String!) repeatable on
+	  | ARGUMENT_DEFINITION
+	  | ENUM
+	  | ENUM_VALUE
+	  | FIELD_DEFINITION
+	  | INPUT_FIELD_DEFINITION
+	  | INPUT_OBJECT
+	  | INTERFACE
+	  | OBJECT
+	  | SCALAR
+	  | UNION
+	scalar _Any
+	scalar FieldSet
+	scalar federation__Policy
+	scalar federation__Scope
+`, BuiltIn: true},
+	{Name: "federation/entity.graphql", Input: `
+# a union of all types that use the @key directive
+union _Entity = Node
+
+# fake type to build resolver interfaces for users to implement
+type Entity {
+	findNodeByID(id: ID!,): Node!
+}
+
+type _Service {
+  sdl: String
+}
+
+extend type Query {
+  _entities(representations: [_Any!]!): [_Entity]!
+  _service: _Service!
+}
+`, BuiltIn: true},
 }
 var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
@@ -838,6 +1094,17 @@ func sourceData(filename string) string {
 
 // region    ***************************** args.gotpl *****************************
 
+func (ec *executionContext) field_Entity_findNodeByID_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_boostNode_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -849,6 +1116,28 @@ func (ec *executionContext) field_Mutation_boostNode_args(ctx context.Context, r
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_bulkUpsertEdges_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNCreateEdgeInput2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateEdgeInputᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_bulkUpsertNodes_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNCreateNodeInput2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateNodeInputᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_createEdge_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -900,6 +1189,11 @@ func (ec *executionContext) field_Mutation_deleteNode_args(ctx context.Context,
 		return nil, err
 	}
 	args["id"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "mode", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["mode"] = arg1
 	return args, nil
 }
 
@@ -999,6 +1293,17 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query__entities_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "representations", ec.unmarshalN_Any2ᚕmapᚄ)
+	if err != nil {
+		return nil, err
+	}
+	args["representations"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_audit_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1539,118 +1844,41 @@ func (ec *executionContext) fieldContext_AuditEntry_createdAt(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _ContextResult_node(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
-	return graphql.ResolveField(
-		ctx,
-		ec.OperationContext,
-		field,
-		ec.fieldContext_ContextResult_node,
-		func(ctx context.Context) (any, error) {
-			return obj.Node, nil
-		},
-		nil,
-		ec.marshalNNode2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNode,
-		true,
-		true,
-	)
-}
-
-func (ec *executionContext) fieldContext_ContextResult_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "ContextResult",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Node_id(ctx, field)
-			case "type":
-				return ec.fieldContext_Node_type(ctx, field)
-			case "label":
-				return ec.fieldContext_Node_label(ctx, field)
-			case "properties":
-				return ec.fieldContext_Node_properties(ctx, field)
-			case "accessCount":
-				return ec.fieldContext_Node_accessCount(ctx, field)
-			case "salienceScore":
-				return ec.fieldContext_Node_salienceScore(ctx, field)
-			case "userBoosted":
-				return ec.fieldContext_Node_userBoosted(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Node_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Node_updatedAt(ctx, field)
-			case "edges":
-				return ec.fieldContext_Node_edges(ctx, field)
-			case "neighbors":
-				return ec.fieldContext_Node_neighbors(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _ContextResult_neighbors(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkEdgesResult_upserted(ctx context.Context, field graphql.CollectedField, obj *BulkEdgesResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ContextResult_neighbors,
+		ec.fieldContext_BulkEdgesResult_upserted,
 		func(ctx context.Context) (any, error) {
-			return obj.Neighbors, nil
+			return obj.Upserted, nil
 		},
 		nil,
-		ec.marshalNNode2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeᚄ,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ContextResult_neighbors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkEdgesResult_upserted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ContextResult",
+		Object:     "BulkEdgesResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Node_id(ctx, field)
-			case "type":
-				return ec.fieldContext_Node_type(ctx, field)
-			case "label":
-				return ec.fieldContext_Node_label(ctx, field)
-			case "properties":
-				return ec.fieldContext_Node_properties(ctx, field)
-			case "accessCount":
-				return ec.fieldContext_Node_accessCount(ctx, field)
-			case "salienceScore":
-				return ec.fieldContext_Node_salienceScore(ctx, field)
-			case "userBoosted":
-				return ec.fieldContext_Node_userBoosted(ctx, field)
-			case "createdAt":
-				return ec.fieldContext_Node_createdAt(ctx, field)
-			case "updatedAt":
-				return ec.fieldContext_Node_updatedAt(ctx, field)
-			case "edges":
-				return ec.fieldContext_Node_edges(ctx, field)
-			case "neighbors":
-				return ec.fieldContext_Node_neighbors(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ContextResult_edges(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkEdgesResult_edges(ctx context.Context, field graphql.CollectedField, obj *BulkEdgesResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ContextResult_edges,
+		ec.fieldContext_BulkEdgesResult_edges,
 		func(ctx context.Context) (any, error) {
 			return obj.Edges, nil
 		},
@@ -1661,9 +1889,9 @@ func(ctx context.Context) (any, error) {
 	)
 }
 
-func (ec *executionContext) fieldContext_ContextResult_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkEdgesResult_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ContextResult",
+		Object:     "BulkEdgesResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1685,6 +1913,18 @@ func (ec *executionContext) fieldContext_ContextResult_edges(_ context.Context,
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -1700,72 +1940,78 @@ func (ec *executionContext) fieldContext_ContextResult_edges(_ context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_source(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkEdgesResult_errors(ctx context.Context, field graphql.CollectedField, obj *BulkEdgesResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Edge_source,
+		ec.fieldContext_BulkEdgesResult_errors,
 		func(ctx context.Context) (any, error) {
-			return obj.Source, nil
+			return obj.Errors, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNBulkItemError2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkItemErrorᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Edge_source(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkEdgesResult_errors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "BulkEdgesResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "index":
+				return ec.fieldContext_BulkItemError_index(ctx, field)
+			case "message":
+				return ec.fieldContext_BulkItemError_message(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BulkItemError", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_target(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkItemError_index(ctx context.Context, field graphql.CollectedField, obj *BulkItemError) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Edge_target,
+		ec.fieldContext_BulkItemError_index,
 		func(ctx context.Context) (any, error) {
-			return obj.Target, nil
+			return obj.Index, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Edge_target(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkItemError_index(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "BulkItemError",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_relation(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkItemError_message(ctx context.Context, field graphql.CollectedField, obj *BulkItemError) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Edge_relation,
+		ec.fieldContext_BulkItemError_message,
 		func(ctx context.Context) (any, error) {
-			return obj.Relation, nil
+			return obj.Message, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -1774,9 +2020,9 @@ func(ctx context.Context) (any, error) {
 	)
 }
 
-func (ec *executionContext) fieldContext_Edge_relation(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkItemError_message(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "BulkItemError",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1787,36 +2033,413 @@ func (ec *executionContext) fieldContext_Edge_relation(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_properties(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkNodesResult_upserted(ctx context.Context, field graphql.CollectedField, obj *BulkNodesResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Edge_properties,
+		ec.fieldContext_BulkNodesResult_upserted,
 		func(ctx context.Context) (any, error) {
-			return obj.Properties, nil
+			return obj.Upserted, nil
 		},
 		nil,
-		ec.marshalOJSON2map,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Edge_properties(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_BulkNodesResult_upserted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "BulkNodesResult",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type JSON does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_weight(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+func (ec *executionContext) _BulkNodesResult_nodes(ctx context.Context, field graphql.CollectedField, obj *BulkNodesResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_BulkNodesResult_nodes,
+		func(ctx context.Context) (any, error) {
+			return obj.Nodes, nil
+		},
+		nil,
+		ec.marshalNNode2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_BulkNodesResult_nodes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BulkNodesResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "properties":
+				return ec.fieldContext_Node_properties(ctx, field)
+			case "accessCount":
+				return ec.fieldContext_Node_accessCount(ctx, field)
+			case "salienceScore":
+				return ec.fieldContext_Node_salienceScore(ctx, field)
+			case "userBoosted":
+				return ec.fieldContext_Node_userBoosted(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Node_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Node_updatedAt(ctx, field)
+			case "edges":
+				return ec.fieldContext_Node_edges(ctx, field)
+			case "neighbors":
+				return ec.fieldContext_Node_neighbors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _BulkNodesResult_errors(ctx context.Context, field graphql.CollectedField, obj *BulkNodesResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_BulkNodesResult_errors,
+		func(ctx context.Context) (any, error) {
+			return obj.Errors, nil
+		},
+		nil,
+		ec.marshalNBulkItemError2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkItemErrorᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_BulkNodesResult_errors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "BulkNodesResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "index":
+				return ec.fieldContext_BulkItemError_index(ctx, field)
+			case "message":
+				return ec.fieldContext_BulkItemError_message(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BulkItemError", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ContextResult_node(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ContextResult_node,
+		func(ctx context.Context) (any, error) {
+			return obj.Node, nil
+		},
+		nil,
+		ec.marshalNNode2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNode,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ContextResult_node(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ContextResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "properties":
+				return ec.fieldContext_Node_properties(ctx, field)
+			case "accessCount":
+				return ec.fieldContext_Node_accessCount(ctx, field)
+			case "salienceScore":
+				return ec.fieldContext_Node_salienceScore(ctx, field)
+			case "userBoosted":
+				return ec.fieldContext_Node_userBoosted(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Node_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Node_updatedAt(ctx, field)
+			case "edges":
+				return ec.fieldContext_Node_edges(ctx, field)
+			case "neighbors":
+				return ec.fieldContext_Node_neighbors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ContextResult_neighbors(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ContextResult_neighbors,
+		func(ctx context.Context) (any, error) {
+			return obj.Neighbors, nil
+		},
+		nil,
+		ec.marshalNNode2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ContextResult_neighbors(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ContextResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "properties":
+				return ec.fieldContext_Node_properties(ctx, field)
+			case "accessCount":
+				return ec.fieldContext_Node_accessCount(ctx, field)
+			case "salienceScore":
+				return ec.fieldContext_Node_salienceScore(ctx, field)
+			case "userBoosted":
+				return ec.fieldContext_Node_userBoosted(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Node_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Node_updatedAt(ctx, field)
+			case "edges":
+				return ec.fieldContext_Node_edges(ctx, field)
+			case "neighbors":
+				return ec.fieldContext_Node_neighbors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ContextResult_edges(ctx context.Context, field graphql.CollectedField, obj *ContextResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ContextResult_edges,
+		func(ctx context.Context) (any, error) {
+			return obj.Edges, nil
+		},
+		nil,
+		ec.marshalNEdge2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐEdgeᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ContextResult_edges(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ContextResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "source":
+				return ec.fieldContext_Edge_source(ctx, field)
+			case "target":
+				return ec.fieldContext_Edge_target(ctx, field)
+			case "relation":
+				return ec.fieldContext_Edge_relation(ctx, field)
+			case "properties":
+				return ec.fieldContext_Edge_properties(ctx, field)
+			case "weight":
+				return ec.fieldContext_Edge_weight(ctx, field)
+			case "accessCount":
+				return ec.fieldContext_Edge_accessCount(ctx, field)
+			case "salienceScore":
+				return ec.fieldContext_Edge_salienceScore(ctx, field)
+			case "userBoosted":
+				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Edge_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Edge_updatedAt(ctx, field)
+			case "sourceNode":
+				return ec.fieldContext_Edge_sourceNode(ctx, field)
+			case "targetNode":
+				return ec.fieldContext_Edge_targetNode(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Edge", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_source(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_source,
+		func(ctx context.Context) (any, error) {
+			return obj.Source, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_source(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_target(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_target,
+		func(ctx context.Context) (any, error) {
+			return obj.Target, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_target(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_relation(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_relation,
+		func(ctx context.Context) (any, error) {
+			return obj.Relation, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_relation(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_properties(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_properties,
+		func(ctx context.Context) (any, error) {
+			return obj.Properties, nil
+		},
+		nil,
+		ec.marshalOJSON2map,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_properties(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type JSON does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_weight(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
@@ -1913,20 +2536,194 @@ func(ctx context.Context) (any, error) {
 			return obj.UserBoosted, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
-		true,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_userBoosted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_dateStart(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_dateStart,
+		func(ctx context.Context) (any, error) {
+			return obj.DateStart, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_dateStart(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_dateEnd(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_dateEnd,
+		func(ctx context.Context) (any, error) {
+			return obj.DateEnd, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_dateEnd(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_dateLower(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_dateLower,
+		func(ctx context.Context) (any, error) {
+			return obj.DateLower, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_dateLower(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_dateUpper(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_dateUpper,
+		func(ctx context.Context) (any, error) {
+			return obj.DateUpper, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_dateUpper(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_isCurrent(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_isCurrent,
+		func(ctx context.Context) (any, error) {
+			return obj.IsCurrent, nil
+		},
+		nil,
+		ec.marshalOBoolean2ᚖbool,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Edge_isCurrent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Edge",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Edge_dateQualifier(ctx context.Context, field graphql.CollectedField, obj *Edge) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Edge_dateQualifier,
+		func(ctx context.Context) (any, error) {
+			return obj.DateQualifier, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Edge_userBoosted(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Edge_dateQualifier(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Edge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
@@ -2136,6 +2933,18 @@ func (ec *executionContext) fieldContext_EdgeConnection_edges(_ context.Context,
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -2180,6 +2989,71 @@ func (ec *executionContext) fieldContext_EdgeConnection_hasMore(_ context.Contex
 	return fc, nil
 }
 
+func (ec *executionContext) _Entity_findNodeByID(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Entity_findNodeByID,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Entity().FindNodeByID(ctx, fc.Args["id"].(string))
+		},
+		nil,
+		ec.marshalNNode2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNode,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Entity_findNodeByID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Entity",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "properties":
+				return ec.fieldContext_Node_properties(ctx, field)
+			case "accessCount":
+				return ec.fieldContext_Node_accessCount(ctx, field)
+			case "salienceScore":
+				return ec.fieldContext_Node_salienceScore(ctx, field)
+			case "userBoosted":
+				return ec.fieldContext_Node_userBoosted(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Node_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Node_updatedAt(ctx, field)
+			case "edges":
+				return ec.fieldContext_Node_edges(ctx, field)
+			case "neighbors":
+				return ec.fieldContext_Node_neighbors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Entity_findNodeByID_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_createNode(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -2318,7 +3192,7 @@ func (ec *executionContext) _Mutation_deleteNode(ctx context.Context, field grap
 		ec.fieldContext_Mutation_deleteNode,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().DeleteNode(ctx, fc.Args["id"].(string))
+			return ec.resolvers.Mutation().DeleteNode(ctx, fc.Args["id"].(string), fc.Args["mode"].(*string))
 		},
 		nil,
 		ec.marshalNBoolean2bool,
@@ -2392,6 +3266,18 @@ func (ec *executionContext) fieldContext_Mutation_createEdge(ctx context.Context
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -2459,6 +3345,18 @@ func (ec *executionContext) fieldContext_Mutation_updateEdge(ctx context.Context
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -2526,6 +3424,104 @@ func (ec *executionContext) fieldContext_Mutation_deleteEdge(ctx context.Context
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_bulkUpsertNodes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_bulkUpsertNodes,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().BulkUpsertNodes(ctx, fc.Args["input"].([]*CreateNodeInput))
+		},
+		nil,
+		ec.marshalNBulkNodesResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkNodesResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_bulkUpsertNodes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "upserted":
+				return ec.fieldContext_BulkNodesResult_upserted(ctx, field)
+			case "nodes":
+				return ec.fieldContext_BulkNodesResult_nodes(ctx, field)
+			case "errors":
+				return ec.fieldContext_BulkNodesResult_errors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BulkNodesResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_bulkUpsertNodes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_bulkUpsertEdges(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_bulkUpsertEdges,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().BulkUpsertEdges(ctx, fc.Args["input"].([]*CreateEdgeInput))
+		},
+		nil,
+		ec.marshalNBulkEdgesResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkEdgesResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_bulkUpsertEdges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "upserted":
+				return ec.fieldContext_BulkEdgesResult_upserted(ctx, field)
+			case "edges":
+				return ec.fieldContext_BulkEdgesResult_edges(ctx, field)
+			case "errors":
+				return ec.fieldContext_BulkEdgesResult_errors(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type BulkEdgesResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_bulkUpsertEdges_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_boostNode(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -2754,6 +3750,18 @@ func (ec *executionContext) fieldContext_NeighborResult_edges(_ context.Context,
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -3071,6 +4079,18 @@ func (ec *executionContext) fieldContext_Node_edges(ctx context.Context, field g
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -3799,6 +4819,80 @@ func (ec *executionContext) fieldContext_Query_audit(ctx context.Context, field
 	return fc, nil
 }
 
+func (ec *executionContext) _Query__entities(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query__entities,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.__resolve_entities(ctx, fc.Args["representations"].([]map[string]any)), nil
+		},
+		nil,
+		ec.marshalN_Entity2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐEntity,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query__entities(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type _Entity does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query__entities_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query__service(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query__service,
+		func(ctx context.Context) (any, error) {
+			return ec.__resolve__service(ctx)
+		},
+		nil,
+		ec.marshalN_Service2githubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐService,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query__service(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "sdl":
+				return ec.fieldContext__Service_sdl(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type _Service", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -4082,6 +5176,18 @@ func (ec *executionContext) fieldContext_TraverseResult_edges(_ context.Context,
 				return ec.fieldContext_Edge_salienceScore(ctx, field)
 			case "userBoosted":
 				return ec.fieldContext_Edge_userBoosted(ctx, field)
+			case "dateStart":
+				return ec.fieldContext_Edge_dateStart(ctx, field)
+			case "dateEnd":
+				return ec.fieldContext_Edge_dateEnd(ctx, field)
+			case "dateLower":
+				return ec.fieldContext_Edge_dateLower(ctx, field)
+			case "dateUpper":
+				return ec.fieldContext_Edge_dateUpper(ctx, field)
+			case "isCurrent":
+				return ec.fieldContext_Edge_isCurrent(ctx, field)
+			case "dateQualifier":
+				return ec.fieldContext_Edge_dateQualifier(ctx, field)
 			case "createdAt":
 				return ec.fieldContext_Edge_createdAt(ctx, field)
 			case "updatedAt":
@@ -4097,6 +5203,35 @@ func (ec *executionContext) fieldContext_TraverseResult_edges(_ context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) __Service_sdl(ctx context.Context, field graphql.CollectedField, obj *fedruntime.Service) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext__Service_sdl,
+		func(ctx context.Context) (any, error) {
+			return obj.SDL, nil
+		},
+		nil,
+		ec.marshalOString2string,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext__Service_sdl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "_Service",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -5550,7 +6685,7 @@ func (ec *executionContext) unmarshalInputCreateEdgeInput(ctx context.Context, o
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"source", "target", "relation", "properties", "weight"}
+	fieldsInOrder := [...]string{"source", "target", "relation", "properties", "weight", "dateStart", "dateEnd", "isCurrent"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -5592,6 +6727,27 @@ func (ec *executionContext) unmarshalInputCreateEdgeInput(ctx context.Context, o
 				return it, err
 			}
 			it.Weight = data
+		case "dateStart":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dateStart"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DateStart = data
+		case "dateEnd":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dateEnd"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DateEnd = data
+		case "isCurrent":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("isCurrent"))
+			data, err := ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.IsCurrent = data
 		}
 	}
 
@@ -5653,7 +6809,7 @@ func (ec *executionContext) unmarshalInputUpdateEdgeInput(ctx context.Context, o
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"properties", "weight"}
+	fieldsInOrder := [...]string{"properties", "weight", "dateStart", "dateEnd", "isCurrent"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -5674,6 +6830,27 @@ func (ec *executionContext) unmarshalInputUpdateEdgeInput(ctx context.Context, o
 				return it, err
 			}
 			it.Weight = data
+		case "dateStart":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dateStart"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DateStart = data
+		case "dateEnd":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("dateEnd"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.DateEnd = data
+		case "isCurrent":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("isCurrent"))
+			data, err := ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.IsCurrent = data
 		}
 	}
 
@@ -5721,10 +6898,30 @@ func (ec *executionContext) unmarshalInputUpdateNodeInput(ctx context.Context, o
 	return it, nil
 }
 
-// endregion **************************** input.gotpl *****************************
-
-// region    ************************** interface.gotpl ***************************
-
+// endregion **************************** input.gotpl *****************************
+
+// region    ************************** interface.gotpl ***************************
+
+func (ec *executionContext) __Entity(ctx context.Context, sel ast.SelectionSet, obj fedruntime.Entity) graphql.Marshaler {
+	switch obj := (obj).(type) {
+	case nil:
+		return graphql.Null
+	case Node:
+		return ec._Node(ctx, sel, &obj)
+	case *Node:
+		if obj == nil {
+			return graphql.Null
+		}
+		return ec._Node(ctx, sel, obj)
+	default:
+		if typedObj, ok := obj.(graphql.Marshaler); ok {
+			return typedObj
+		} else {
+			panic(fmt.Errorf("unexpected type %T; non-generated variants of _Entity must implement graphql.Marshaler", obj))
+		}
+	}
+}
+
 // endregion ************************** interface.gotpl ***************************
 
 // region    **************************** object.gotpl ****************************
@@ -5836,6 +7033,148 @@ func (ec *executionContext) _AuditEntry(ctx context.Context, sel ast.SelectionSe
 	return out
 }
 
+var bulkEdgesResultImplementors = []string{"BulkEdgesResult"}
+
+func (ec *executionContext) _BulkEdgesResult(ctx context.Context, sel ast.SelectionSet, obj *BulkEdgesResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, bulkEdgesResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("BulkEdgesResult")
+		case "upserted":
+			out.Values[i] = ec._BulkEdgesResult_upserted(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "edges":
+			out.Values[i] = ec._BulkEdgesResult_edges(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "errors":
+			out.Values[i] = ec._BulkEdgesResult_errors(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var bulkItemErrorImplementors = []string{"BulkItemError"}
+
+func (ec *executionContext) _BulkItemError(ctx context.Context, sel ast.SelectionSet, obj *BulkItemError) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, bulkItemErrorImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("BulkItemError")
+		case "index":
+			out.Values[i] = ec._BulkItemError_index(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "message":
+			out.Values[i] = ec._BulkItemError_message(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var bulkNodesResultImplementors = []string{"BulkNodesResult"}
+
+func (ec *executionContext) _BulkNodesResult(ctx context.Context, sel ast.SelectionSet, obj *BulkNodesResult) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, bulkNodesResultImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("BulkNodesResult")
+		case "upserted":
+			out.Values[i] = ec._BulkNodesResult_upserted(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "nodes":
+			out.Values[i] = ec._BulkNodesResult_nodes(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "errors":
+			out.Values[i] = ec._BulkNodesResult_errors(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var contextResultImplementors = []string{"ContextResult"}
 
 func (ec *executionContext) _ContextResult(ctx context.Context, sel ast.SelectionSet, obj *ContextResult) graphql.Marshaler {
@@ -5933,6 +7272,18 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&out.Invalids, 1)
 			}
+		case "dateStart":
+			out.Values[i] = ec._Edge_dateStart(ctx, field, obj)
+		case "dateEnd":
+			out.Values[i] = ec._Edge_dateEnd(ctx, field, obj)
+		case "dateLower":
+			out.Values[i] = ec._Edge_dateLower(ctx, field, obj)
+		case "dateUpper":
+			out.Values[i] = ec._Edge_dateUpper(ctx, field, obj)
+		case "isCurrent":
+			out.Values[i] = ec._Edge_isCurrent(ctx, field, obj)
+		case "dateQualifier":
+			out.Values[i] = ec._Edge_dateQualifier(ctx, field, obj)
 		case "createdAt":
 			out.Values[i] = ec._Edge_createdAt(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -6076,6 +7427,70 @@ func (ec *executionContext) _EdgeConnection(ctx context.Context, sel ast.Selecti
 	return out
 }
 
+var entityImplementors = []string{"Entity"}
+
+func (ec *executionContext) _Entity(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, entityImplementors)
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Entity",
+	})
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		innerCtx := graphql.WithRootFieldContext(ctx, &graphql.RootFieldContext{
+			Object: field.Name,
+			Field:  field,
+		})
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Entity")
+		case "findNodeByID":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Entity_findNodeByID(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var mutationImplementors = []string{"Mutation"}
 
 func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -6137,6 +7552,20 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "bulkUpsertNodes":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_bulkUpsertNodes(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "bulkUpsertEdges":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_bulkUpsertEdges(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "boostNode":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_boostNode(ctx, field)
@@ -6225,7 +7654,7 @@ func (ec *executionContext) _NeighborResult(ctx context.Context, sel ast.Selecti
 	return out
 }
 
-var nodeImplementors = []string{"Node"}
+var nodeImplementors = []string{"Node", "_Entity"}
 
 func (ec *executionContext) _Node(ctx context.Context, sel ast.SelectionSet, obj *Node) graphql.Marshaler {
 	fields := graphql.CollectFields(ec.OperationContext, sel, nodeImplementors)
@@ -6674,6 +8103,50 @@ func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "_entities":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query__entities(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "_service":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query__service(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -6794,6 +8267,42 @@ func (ec *executionContext) _TraverseResult(ctx context.Context, sel ast.Selecti
 	return out
 }
 
+var _ServiceImplementors = []string{"_Service"}
+
+func (ec *executionContext) __Service(ctx context.Context, sel ast.SelectionSet, obj *fedruntime.Service) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, _ServiceImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("_Service")
+		case "sdl":
+			out.Values[i] = ec.__Service_sdl(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var __DirectiveImplementors = []string{"__Directive"}
 
 func (ec *executionContext) ___Directive(ctx context.Context, sel ast.SelectionSet, obj *introspection.Directive) graphql.Marshaler {
@@ -7213,6 +8722,88 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) marshalNBulkEdgesResult2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkEdgesResult(ctx context.Context, sel ast.SelectionSet, v BulkEdgesResult) graphql.Marshaler {
+	return ec._BulkEdgesResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNBulkEdgesResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkEdgesResult(ctx context.Context, sel ast.SelectionSet, v *BulkEdgesResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._BulkEdgesResult(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNBulkItemError2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkItemErrorᚄ(ctx context.Context, sel ast.SelectionSet, v []*BulkItemError) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNBulkItemError2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkItemError(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNBulkItemError2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkItemError(ctx context.Context, sel ast.SelectionSet, v *BulkItemError) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._BulkItemError(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNBulkNodesResult2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkNodesResult(ctx context.Context, sel ast.SelectionSet, v BulkNodesResult) graphql.Marshaler {
+	return ec._BulkNodesResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNBulkNodesResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐBulkNodesResult(ctx context.Context, sel ast.SelectionSet, v *BulkNodesResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._BulkNodesResult(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNContextResult2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐContextResult(ctx context.Context, sel ast.SelectionSet, v ContextResult) graphql.Marshaler {
 	return ec._ContextResult(ctx, sel, &v)
 }
@@ -7232,11 +8823,51 @@ func (ec *executionContext) unmarshalNCreateEdgeInput2githubᚗcomᚋpersistorai
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNCreateEdgeInput2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateEdgeInputᚄ(ctx context.Context, v any) ([]*CreateEdgeInput, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]*CreateEdgeInput, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNCreateEdgeInput2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateEdgeInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNCreateEdgeInput2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateEdgeInput(ctx context.Context, v any) (*CreateEdgeInput, error) {
+	res, err := ec.unmarshalInputCreateEdgeInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNCreateNodeInput2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateNodeInput(ctx context.Context, v any) (CreateNodeInput, error) {
 	res, err := ec.unmarshalInputCreateNodeInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNCreateNodeInput2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateNodeInputᚄ(ctx context.Context, v any) ([]*CreateNodeInput, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]*CreateNodeInput, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNCreateNodeInput2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateNodeInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) unmarshalNCreateNodeInput2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐCreateNodeInput(ctx context.Context, v any) (*CreateNodeInput, error) {
+	res, err := ec.unmarshalInputCreateNodeInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalNEdge2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐEdge(ctx context.Context, sel ast.SelectionSet, v Edge) graphql.Marshaler {
 	return ec._Edge(ctx, sel, &v)
 }
@@ -7309,6 +8940,22 @@ func (ec *executionContext) marshalNEdgeConnection2ᚖgithubᚗcomᚋpersistorai
 	return ec._EdgeConnection(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNFieldSet2string(ctx context.Context, v any) (string, error) {
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNFieldSet2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalString(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) unmarshalNFloat2float64(ctx context.Context, v any) (float64, error) {
 	res, err := graphql.UnmarshalFloatContext(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -7424,26 +9071,172 @@ func (ec *executionContext) marshalNNode2ᚖgithubᚗcomᚋpersistoraiᚋpersist
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
 		}
-		return graphql.Null
+		return graphql.Null
+	}
+	return ec._Node(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNNodeConnection2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v NodeConnection) graphql.Marshaler {
+	return ec._NodeConnection(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNNodeConnection2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v *NodeConnection) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._NodeConnection(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNSearchResult2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResultᚄ(ctx context.Context, sel ast.SelectionSet, v []*SearchResult) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNSearchResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResult(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNSearchResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResult(ctx context.Context, sel ast.SelectionSet, v *SearchResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._SearchResult(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalString(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNTraverseResult2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐTraverseResult(ctx context.Context, sel ast.SelectionSet, v TraverseResult) graphql.Marshaler {
+	return ec._TraverseResult(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNTraverseResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐTraverseResult(ctx context.Context, sel ast.SelectionSet, v *TraverseResult) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._TraverseResult(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNUpdateEdgeInput2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐUpdateEdgeInput(ctx context.Context, v any) (UpdateEdgeInput, error) {
+	res, err := ec.unmarshalInputUpdateEdgeInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNUpdateNodeInput2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐUpdateNodeInput(ctx context.Context, v any) (UpdateNodeInput, error) {
+	res, err := ec.unmarshalInputUpdateNodeInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalN_Any2map(ctx context.Context, v any) (map[string]any, error) {
+	res, err := graphql.UnmarshalMap(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalN_Any2map(ctx context.Context, sel ast.SelectionSet, v map[string]any) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	_ = sel
+	res := graphql.MarshalMap(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) unmarshalN_Any2ᚕmapᚄ(ctx context.Context, v any) ([]map[string]any, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]map[string]any, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalN_Any2map(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
 	}
-	return ec._Node(ctx, sel, v)
+	return res, nil
 }
 
-func (ec *executionContext) marshalNNodeConnection2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v NodeConnection) graphql.Marshaler {
-	return ec._NodeConnection(ctx, sel, &v)
-}
+func (ec *executionContext) marshalN_Any2ᚕmapᚄ(ctx context.Context, sel ast.SelectionSet, v []map[string]any) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalN_Any2map(ctx, sel, v[i])
+	}
 
-func (ec *executionContext) marshalNNodeConnection2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐNodeConnection(ctx context.Context, sel ast.SelectionSet, v *NodeConnection) graphql.Marshaler {
-	if v == nil {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
 		}
-		return graphql.Null
 	}
-	return ec._NodeConnection(ctx, sel, v)
+
+	return ret
 }
 
-func (ec *executionContext) marshalNSearchResult2ᚕᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResultᚄ(ctx context.Context, sel ast.SelectionSet, v []*SearchResult) graphql.Marshaler {
+func (ec *executionContext) marshalN_Entity2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐEntity(ctx context.Context, sel ast.SelectionSet, v []fedruntime.Entity) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -7467,7 +9260,7 @@ func (ec *executionContext) marshalNSearchResult2ᚕᚖgithubᚗcomᚋpersistora
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNSearchResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResult(ctx, sel, v[i])
+			ret[i] = ec.marshalO_Entity2githubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐEntity(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -7478,63 +9271,11 @@ func (ec *executionContext) marshalNSearchResult2ᚕᚖgithubᚗcomᚋpersistora
 	}
 	wg.Wait()
 
-	for _, e := range ret {
-		if e == graphql.Null {
-			return graphql.Null
-		}
-	}
-
 	return ret
 }
 
-func (ec *executionContext) marshalNSearchResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐSearchResult(ctx context.Context, sel ast.SelectionSet, v *SearchResult) graphql.Marshaler {
-	if v == nil {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
-		}
-		return graphql.Null
-	}
-	return ec._SearchResult(ctx, sel, v)
-}
-
-func (ec *executionContext) unmarshalNString2string(ctx context.Context, v any) (string, error) {
-	res, err := graphql.UnmarshalString(v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
-	_ = sel
-	res := graphql.MarshalString(v)
-	if res == graphql.Null {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
-		}
-	}
-	return res
-}
-
-func (ec *executionContext) marshalNTraverseResult2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐTraverseResult(ctx context.Context, sel ast.SelectionSet, v TraverseResult) graphql.Marshaler {
-	return ec._TraverseResult(ctx, sel, &v)
-}
-
-func (ec *executionContext) marshalNTraverseResult2ᚖgithubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐTraverseResult(ctx context.Context, sel ast.SelectionSet, v *TraverseResult) graphql.Marshaler {
-	if v == nil {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
-		}
-		return graphql.Null
-	}
-	return ec._TraverseResult(ctx, sel, v)
-}
-
-func (ec *executionContext) unmarshalNUpdateEdgeInput2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐUpdateEdgeInput(ctx context.Context, v any) (UpdateEdgeInput, error) {
-	res, err := ec.unmarshalInputUpdateEdgeInput(ctx, v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) unmarshalNUpdateNodeInput2githubᚗcomᚋpersistoraiᚋpersistorᚋinternalᚋgraphqlᚐUpdateNodeInput(ctx context.Context, v any) (UpdateNodeInput, error) {
-	res, err := ec.unmarshalInputUpdateNodeInput(ctx, v)
-	return res, graphql.ErrorOnPath(ctx, err)
+func (ec *executionContext) marshalN_Service2githubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐService(ctx context.Context, sel ast.SelectionSet, v fedruntime.Service) graphql.Marshaler {
+	return ec.__Service(ctx, sel, &v)
 }
 
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
@@ -7790,6 +9531,158 @@ func (ec *executionContext) marshalN__TypeKind2string(ctx context.Context, sel a
 	return res
 }
 
+func (ec *executionContext) unmarshalNfederation__Policy2string(ctx context.Context, v any) (string, error) {
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNfederation__Policy2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalString(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) unmarshalNfederation__Policy2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNfederation__Policy2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNfederation__Policy2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNfederation__Policy2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalNfederation__Policy2ᚕᚕstringᚄ(ctx context.Context, v any) ([][]string, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([][]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNfederation__Policy2ᚕstringᚄ(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNfederation__Policy2ᚕᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v [][]string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNfederation__Policy2ᚕstringᚄ(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalNfederation__Scope2string(ctx context.Context, v any) (string, error) {
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNfederation__Scope2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalString(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) unmarshalNfederation__Scope2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNfederation__Scope2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNfederation__Scope2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNfederation__Scope2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalNfederation__Scope2ᚕᚕstringᚄ(ctx context.Context, v any) ([][]string, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([][]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNfederation__Scope2ᚕstringᚄ(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNfederation__Scope2ᚕᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v [][]string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNfederation__Scope2ᚕstringᚄ(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOBoolean2bool(ctx context.Context, v any) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -7880,6 +9773,54 @@ func (ec *executionContext) marshalONode2ᚖgithubᚗcomᚋpersistoraiᚋpersist
 	return ec._Node(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOString2string(ctx context.Context, v any) (string, error) {
+	res, err := graphql.UnmarshalString(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	_ = sel
+	_ = ctx
+	res := graphql.MarshalString(v)
+	return res
+}
+
+func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOString2ᚕstringᚄ(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -7898,6 +9839,13 @@ func (ec *executionContext) marshalOString2ᚖstring(ctx context.Context, sel as
 	return res
 }
 
+func (ec *executionContext) marshalO_Entity2githubᚗcomᚋ99designsᚋgqlgenᚋpluginᚋfederationᚋfedruntimeᚐEntity(ctx context.Context, sel ast.SelectionSet, v fedruntime.Entity) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec.__Entity(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -17,6 +17,23 @@ type AuditEntry struct {
 	CreatedAt  string         `json:"createdAt"`
 }
 
+type BulkEdgesResult struct {
+	Upserted int              `json:"upserted"`
+	Edges    []*Edge          `json:"edges"`
+	Errors   []*BulkItemError `json:"errors"`
+}
+
+type BulkItemError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+type BulkNodesResult struct {
+	Upserted int              `json:"upserted"`
+	Nodes    []*Node          `json:"nodes"`
+	Errors   []*BulkItemError `json:"errors"`
+}
+
 type ContextResult struct {
 	Node      *Node   `json:"node"`
 	Neighbors []*Node `json:"neighbors"`
@@ -89,6 +106,8 @@ type Node struct {
 	Neighbors     []*Node        `json:"neighbors"`
 }
 
+func (Node) IsEntity() {}
+
 type NodeConnection struct {
 	Nodes   []*Node `json:"nodes"`
 	HasMore bool    `json:"hasMore"`
@@ -4,6 +4,9 @@
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/persistorai/persistor/internal/models"
 )
@@ -51,13 +54,18 @@ func (r *mutationResolver) UpdateNode(ctx context.Context, id string, input Upda
 	return nodeToGQL(n), nil
 }
 
-// DeleteNode is the resolver for the deleteNode field.
-func (r *mutationResolver) DeleteNode(ctx context.Context, id string) (bool, error) {
+// DeleteNode is the resolver for the deleteNode field. mode is "restrict"
+// (the default), which fails while the node has edges, or "detach".
+func (r *mutationResolver) DeleteNode(ctx context.Context, id string, mode *string) (bool, error) {
 	tid, err := TenantIDFromContext(ctx)
 	if err != nil {
 		return false, gqlErr(ctx, err)
 	}
-	if err := r.NodeSvc.DeleteNode(ctx, tid, id); err != nil {
+	deleteMode, err := models.ParseDeleteMode(derefStr(mode))
+	if err != nil {
+		return false, gqlErrWithCode(ctx, err.Error(), codeBadRequest)
+	}
+	if err := r.NodeSvc.DeleteNode(ctx, tid, id, deleteMode); err != nil {
 		return false, gqlErr(ctx, err)
 	}
 	return true, nil
@@ -161,3 +169,102 @@ func (r *mutationResolver) RecalculateSalience(ctx context.Context) (int, error)
 	}
 	return count, nil
 }
+
+// BulkUpsertNodes is the resolver for the bulkUpsertNodes field. Invalid items
+// and repeated IDs are reported in errors; the rest are upserted together.
+func (r *mutationResolver) BulkUpsertNodes(ctx context.Context, input []*CreateNodeInput) (*BulkNodesResult, error) {
+	tid, err := TenantIDFromContext(ctx)
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	if len(input) > maxBulkItems {
+		return nil, gqlErrWithCode(ctx, fmt.Sprintf("bulk request exceeds maximum of %d items", maxBulkItems), codeBadRequest)
+	}
+	result := &BulkNodesResult{Nodes: []*Node{}, Errors: []*BulkItemError{}}
+	reqs := make([]models.CreateNodeRequest, 0, len(input))
+	seen := make(map[string]int, len(input))
+	for i, in := range input {
+		req := models.CreateNodeRequest{
+			ID:         derefStr(in.ID),
+			Type:       in.Type,
+			Label:      in.Label,
+			Properties: in.Properties,
+		}
+		if err := req.Validate(); err != nil {
+			result.Errors = append(result.Errors, &BulkItemError{Index: i, Message: err.Error()})
+			continue
+		}
+		if first, ok := seen[req.ID]; ok {
+			result.Errors = append(result.Errors, &BulkItemError{Index: i, Message: fmt.Sprintf("duplicate of item %d", first)})
+			continue
+		}
+		seen[req.ID] = i
+		reqs = append(reqs, req)
+	}
+	nodes, err := r.BulkSvc.BulkUpsertNodes(ctx, tid, reqs)
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	for i := range nodes {
+		result.Nodes = append(result.Nodes, nodeToGQL(&nodes[i]))
+	}
+	result.Upserted = len(nodes)
+	return result, nil
+}
+
+// BulkUpsertEdges is the resolver for the bulkUpsertEdges field. Invalid items,
+// repeated keys, and edges whose source or target node does not exist are
+// reported in errors; the rest are upserted together.
+func (r *mutationResolver) BulkUpsertEdges(ctx context.Context, input []*CreateEdgeInput) (*BulkEdgesResult, error) {
+	tid, err := TenantIDFromContext(ctx)
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	if len(input) > maxBulkItems {
+		return nil, gqlErrWithCode(ctx, fmt.Sprintf("bulk request exceeds maximum of %d items", maxBulkItems), codeBadRequest)
+	}
+	result := &BulkEdgesResult{Edges: []*Edge{}, Errors: []*BulkItemError{}}
+	reqs := make([]models.CreateEdgeRequest, 0, len(input))
+	indexes := make([]int, 0, len(input))
+	seen := make(map[[3]string]int, len(input))
+	for i, in := range input {
+		req := models.CreateEdgeRequest{
+			Source:     in.Source,
+			Target:     in.Target,
+			Relation:   in.Relation,
+			Properties: in.Properties,
+			Weight:     in.Weight,
+			DateStart:  in.DateStart,
+			DateEnd:    in.DateEnd,
+			IsCurrent:  in.IsCurrent,
+		}
+		if err := req.Validate(); err != nil {
+			result.Errors = append(result.Errors, &BulkItemError{Index: i, Message: err.Error()})
+			continue
+		}
+		key := [3]string{req.Source, req.Target, req.Relation}
+		if first, ok := seen[key]; ok {
+			result.Errors = append(result.Errors, &BulkItemError{Index: i, Message: fmt.Sprintf("duplicate of item %d", first)})
+			continue
+		}
+		seen[key] = i
+		reqs = append(reqs, req)
+		indexes = append(indexes, i)
+	}
+	edges, err := r.BulkSvc.BulkUpsertEdges(ctx, tid, reqs)
+	var missing *models.MissingNodesError
+	if errors.As(err, &missing) {
+		// The store rejects the whole batch; drop the edges it named and retry once.
+		reqs, result.Errors = dropEdgesToMissing(reqs, indexes, missing.IDs, result.Errors)
+		sort.Slice(result.Errors, func(a, b int) bool { return result.Errors[a].Index < result.Errors[b].Index })
+		edges, err = r.BulkSvc.BulkUpsertEdges(ctx, tid, reqs)
+	}
+	if err != nil {
+		return nil, gqlErr(ctx, err)
+	}
+	for i := range edges {
+		result.Edges = append(result.Edges, edgeToGQL(&edges[i]))
+	}
+	result.Upserted = len(edges)
+	return result, nil
+}
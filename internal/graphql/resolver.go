@@ -11,4 +11,5 @@ type Resolver struct {
 	GraphSvc    domain.GraphService
 	SalienceSvc domain.SalienceService
 	AuditSvc    domain.AuditService
+	BulkSvc     domain.BulkService
 }
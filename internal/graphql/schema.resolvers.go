@@ -30,7 +30,12 @@ func (r *queryResolver) Nodes(ctx context.Context, typeArg *string, minSalience
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	nodes, hasMore, err := r.NodeSvc.ListNodes(ctx, tid, derefStr(typeArg), deref(minSalience, 0.0), deref(limit, 50), deref(offset, 0))
+	nodes, hasMore, err := r.NodeSvc.ListNodes(ctx, tid, models.NodeListOpts{
+		Type:        derefStr(typeArg),
+		MinSalience: deref(minSalience, 0.0),
+		Limit:       deref(limit, 50),
+		Offset:      deref(offset, 0),
+	})
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -43,7 +48,7 @@ func (r *queryResolver) Edges(ctx context.Context, source *string, target *strin
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	edges, hasMore, err := r.EdgeSvc.ListEdges(ctx, tid, derefStr(source), derefStr(target), derefStr(relation), deref(limit, 50), deref(offset, 0), nil, nil)
+	edges, hasMore, err := r.EdgeSvc.ListEdges(ctx, tid, derefStr(source), derefStr(target), derefStr(relation), deref(limit, 50), deref(offset, 0), nil, nil, nil)
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -56,7 +61,7 @@ func (r *queryResolver) Search(ctx context.Context, query string, limit *int) ([
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	nodes, err := r.SearchSvc.FullTextSearch(ctx, tid, query, "", 0, deref(limit, 20))
+	nodes, err := r.SearchSvc.FullTextSearch(ctx, tid, query, models.SearchFilter{IncludeSuperseded: true}, deref(limit, 20))
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -69,7 +74,7 @@ func (r *queryResolver) SearchSemantic(ctx context.Context, query string, limit
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	scored, err := r.SearchSvc.SemanticSearch(ctx, tid, query, deref(limit, 20))
+	scored, err := r.SearchSvc.SemanticSearch(ctx, tid, query, models.SearchFilter{}, deref(limit, 20))
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -82,7 +87,7 @@ func (r *queryResolver) SearchHybrid(ctx context.Context, query string, limit *i
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	nodes, err := r.SearchSvc.HybridSearch(ctx, tid, query, deref(limit, 20))
+	nodes, err := r.SearchSvc.HybridSearch(ctx, tid, query, models.SearchFilter{}, deref(limit, 20))
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -95,7 +100,7 @@ func (r *queryResolver) Neighbors(ctx context.Context, id string, limit *int) (*
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	result, err := r.GraphSvc.Neighbors(ctx, tid, id, deref(limit, 50))
+	result, err := r.GraphSvc.Neighbors(ctx, tid, id, deref(limit, 50), models.ReadOpts{})
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -108,7 +113,7 @@ func (r *queryResolver) Traverse(ctx context.Context, id string, maxHops *int) (
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
-	result, err := r.GraphSvc.Traverse(ctx, tid, id, deref(maxHops, 2))
+	result, err := r.GraphSvc.Traverse(ctx, tid, id, deref(maxHops, 2), models.ReadOpts{})
 	if err != nil {
 		return nil, gqlErr(ctx, err)
 	}
@@ -7,6 +7,8 @@
 
 import (
 	"context"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // SourceNode is the resolver for the sourceNode field.
@@ -42,7 +44,7 @@ func (r *nodeResolver) Edges(ctx context.Context, obj *Node, relation *string, l
 		return nil, err
 	}
 	// Fetch edges where this node is the source.
-	edges, _, err := r.EdgeSvc.ListEdges(ctx, tid, obj.ID, "", derefStr(relation), deref(limit, 50), 0, nil, nil)
+	edges, _, err := r.EdgeSvc.ListEdges(ctx, tid, obj.ID, "", derefStr(relation), deref(limit, 50), 0, nil, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +57,7 @@ func (r *nodeResolver) Neighbors(ctx context.Context, obj *Node, limit *int) ([]
 	if err != nil {
 		return nil, err
 	}
-	result, err := r.GraphSvc.Neighbors(ctx, tid, obj.ID, deref(limit, 50))
+	result, err := r.GraphSvc.Neighbors(ctx, tid, obj.ID, deref(limit, 50), models.ReadOpts{})
 	if err != nil {
 		return nil, err
 	}
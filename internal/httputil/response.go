@@ -1,9 +1,16 @@
 // Package httputil provides shared HTTP response helpers.
 package httputil
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/models"
+)
 
 // RespondError writes a standardized JSON error response and aborts the request.
+// Codes in the error catalog carry a docs_url. When Accept-Language asks for
+// another catalog language, message is the code's translated catalog message
+// and the original English message moves to detail.
 func RespondError(c *gin.Context, status int, code, message string) {
 	var requestID string
 	if rid, exists := c.Get("request_id"); exists {
@@ -21,5 +28,18 @@ func RespondError(c *gin.Context, status int, code, message string) {
 		resp["request_id"] = requestID
 	}
 
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	lang := models.ErrorLanguage(c.GetHeader("Accept-Language"))
+	if translated, ok := models.ErrorMessage(code, lang); ok {
+		resp["docs_url"] = models.ErrorDocsURL(code)
+
+		if lang != models.DefaultErrorLanguage {
+			resp["message"] = translated
+			resp["detail"] = message
+			c.Header("Content-Language", lang)
+		}
+	}
+
 	c.AbortWithStatusJSON(status, resp)
 }
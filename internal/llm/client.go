@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// retryBaseDelay is the wait before the first retry; each further retry
+	// doubles it.
+	retryBaseDelay = time.Second
+	// budgetWindow is the rolling window MaxCallsPerHour applies to.
+	budgetWindow = time.Hour
+)
+
+// client wraps a provider with the shared retry and budget controls.
+type client struct {
+	Provider
+
+	maxRetries     int
+	maxCalls       int
+	maxPromptChars int
+	baseDelay      time.Duration
+	now            func() time.Time
+
+	mu    sync.Mutex
+	calls []time.Time
+
+	requests atomic.Int64
+	tokens   atomic.Int64
+}
+
+func newClient(p Provider, cfg Config) *client {
+	return &client{
+		Provider:       p,
+		maxRetries:     cfg.MaxRetries,
+		maxCalls:       cfg.MaxCallsPerHour,
+		maxPromptChars: cfg.MaxPromptChars,
+		baseDelay:      retryBaseDelay,
+		now:            time.Now,
+	}
+}
+
+// Chat sends the prompt, retrying rate limits, server errors, and network
+// errors with exponential backoff. Every request sent counts against the
+// call budget, retries included.
+func (c *client) Chat(ctx context.Context, prompt string) (string, error) {
+	if c.maxPromptChars > 0 && len(prompt) > c.maxPromptChars {
+		return "", fmt.Errorf("%w: %d characters, limit %d", ErrPromptTooLong, len(prompt), c.maxPromptChars)
+	}
+
+	delay := c.baseDelay
+
+	for attempt := 0; ; attempt++ {
+		if err := c.reserve(); err != nil {
+			return "", err
+		}
+
+		resp, err := c.send(ctx, prompt)
+		if err == nil || attempt >= c.maxRetries || !retryable(ctx, err) {
+			return resp, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// reserve records one call, or returns ErrBudgetExceeded when the rolling
+// hour is already full.
+func (c *client) reserve() error {
+	if c.maxCalls <= 0 {
+		return nil
+	}
+
+	now := c.now()
+	cutoff := now.Add(-budgetWindow)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.calls[:0]
+	for _, t := range c.calls {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	c.calls = kept
+
+	if len(c.calls) >= c.maxCalls {
+		return fmt.Errorf("%w: %d calls per hour", ErrBudgetExceeded, c.maxCalls)
+	}
+
+	c.calls = append(c.calls, now)
+
+	return nil
+}
+
+// retryable reports whether a failed call may succeed if sent again.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode == http.StatusTooManyRequests || se.StatusCode >= http.StatusInternalServerError
+	}
+
+	// Transport failures surface as *url.Error; malformed responses are not
+	// worth repeating.
+	var ue *url.Error
+
+	return errors.As(err, &ue)
+}
@@ -0,0 +1,109 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persistorai/persistor/internal/llm"
+)
+
+// openAIServer answers chat completions with "ok" after failing the first
+// failures requests with status.
+func openAIServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		resp := map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"role": "assistant", "content": "ok"}}},
+			"usage":   map[string]any{"total_tokens": 12},
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("encoding response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	server, calls := openAIServer(t, 1, http.StatusServiceUnavailable)
+
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: server.URL, Model: "m", MaxRetries: 2})
+	require.NoError(t, err)
+
+	resp, err := p.Chat(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := openAIServer(t, 1, http.StatusUnauthorized)
+
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: server.URL, Model: "m", MaxRetries: 2})
+	require.NoError(t, err)
+
+	_, err = p.Chat(context.Background(), "hello")
+
+	var se *llm.StatusError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusUnauthorized, se.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_CallBudget(t *testing.T) {
+	server, calls := openAIServer(t, 0, http.StatusOK)
+
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: server.URL, Model: "m", MaxCallsPerHour: 2})
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = p.Chat(context.Background(), "hello")
+		require.NoError(t, err)
+	}
+
+	_, err = p.Chat(context.Background(), "hello")
+	assert.ErrorIs(t, err, llm.ErrBudgetExceeded)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_MaxPromptChars(t *testing.T) {
+	server, calls := openAIServer(t, 0, http.StatusOK)
+
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: server.URL, Model: "m", MaxPromptChars: 10})
+	require.NoError(t, err)
+
+	_, err = p.Chat(context.Background(), strings.Repeat("x", 11))
+	assert.ErrorIs(t, err, llm.ErrPromptTooLong)
+	assert.Zero(t, calls.Load())
+}
+
+func TestUsageOf(t *testing.T) {
+	server, _ := openAIServer(t, 1, http.StatusServiceUnavailable)
+
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: server.URL, Model: "m", MaxRetries: 2})
+	require.NoError(t, err)
+
+	_, err = p.Chat(context.Background(), "hello")
+	require.NoError(t, err)
+
+	// The failed attempt is a request too, but reports no tokens.
+	assert.Equal(t, llm.Usage{Requests: 2, Tokens: 12}, llm.UsageOf(p))
+	assert.Equal(t, llm.Usage{}, llm.UsageOf(llm.NewOpenAI(server.URL, "m", "")))
+}
@@ -0,0 +1,194 @@
+// Package llm provides the chat model providers shared by every subsystem
+// that prompts a language model, so they all take the same configuration,
+// retries, and budget controls.
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Provider names accepted by LLM_PROVIDER.
+const (
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+	ProviderNone   = "none"
+)
+
+var (
+	// ErrDisabled is returned by every call when LLM_PROVIDER=none.
+	ErrDisabled = errors.New("LLM provider is disabled (LLM_PROVIDER=none)")
+	// ErrBudgetExceeded is returned when LLM_MAX_CALLS_PER_HOUR is used up.
+	ErrBudgetExceeded = errors.New("LLM call budget exceeded")
+	// ErrPromptTooLong is returned when a prompt exceeds LLM_MAX_PROMPT_CHARS.
+	ErrPromptTooLong = errors.New("LLM prompt too long")
+)
+
+// Provider is a chat model backend.
+type Provider interface {
+	// Chat sends a single-turn prompt and returns the response text.
+	Chat(ctx context.Context, prompt string) (string, error)
+	// Name describes the provider for logs and diagnostics.
+	Name() string
+	// Check verifies that the provider is configured and reachable.
+	Check(ctx context.Context) error
+}
+
+// StatusError is a non-200 response from a provider.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+}
+
+// Error implements error. The "returned status N" wording is parsed by the
+// ingest diagnostics.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+// Config selects and tunes a provider.
+type Config struct {
+	Provider string
+	URL      string
+	Model    string
+	APIKey   string
+	// Timeout overrides the provider's per-request timeout when positive.
+	Timeout time.Duration
+	// MaxRetries is how many times a failed call is retried on rate limits,
+	// server errors, and network errors.
+	MaxRetries int
+	// MaxCallsPerHour caps requests sent in any rolling hour; 0 is unlimited.
+	MaxCallsPerHour int
+	// MaxPromptChars rejects longer prompts without calling the provider;
+	// 0 is unlimited.
+	MaxPromptChars int
+}
+
+// ConfigFromEnv reads the LLM_* environment variables. INGEST_LLM_URL,
+// INGEST_LLM_MODEL, INGEST_LLM_API_KEY, OLLAMA_URL, and OLLAMA_MODEL are
+// honoured as fallbacks. Without LLM_PROVIDER the provider is openai when a
+// URL is set and ollama otherwise.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Provider: os.Getenv("LLM_PROVIDER"),
+		URL:      firstEnv("LLM_URL", "INGEST_LLM_URL"),
+		Model:    firstEnv("LLM_MODEL", "INGEST_LLM_MODEL"),
+		APIKey:   firstEnv("LLM_API_KEY", "INGEST_LLM_API_KEY"),
+	}
+
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderOllama
+		if cfg.URL != "" {
+			cfg.Provider = ProviderOpenAI
+		}
+	}
+
+	if cfg.Provider == ProviderOllama {
+		if cfg.URL == "" {
+			cfg.URL = envOrDefault("OLLAMA_URL", "http://localhost:11434")
+		}
+
+		if cfg.Model == "" {
+			cfg.Model = envOrDefault("OLLAMA_MODEL", "gemma4:e4b")
+		}
+	}
+
+	timeout, err := envInt("LLM_TIMEOUT_SECONDS", 0, 0, 3600)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg.Timeout = time.Duration(timeout) * time.Second
+
+	if cfg.MaxRetries, err = envInt("LLM_MAX_RETRIES", 2, 0, 10); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.MaxCallsPerHour, err = envInt("LLM_MAX_CALLS_PER_HOUR", 0, 0, 1000000); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.MaxPromptChars, err = envInt("LLM_MAX_PROMPT_CHARS", 0, 0, 10000000); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// New creates the provider cfg selects, wrapped with its retry and budget
+// controls.
+func New(cfg Config) (Provider, error) {
+	var p Provider
+
+	switch cfg.Provider {
+	case ProviderOllama:
+		o := NewOllama(cfg.URL, cfg.Model)
+		if cfg.Timeout > 0 {
+			o.client.Timeout = cfg.Timeout
+		}
+
+		p = o
+	case ProviderOpenAI:
+		c := NewOpenAI(cfg.URL, cfg.Model, cfg.APIKey)
+		if cfg.Timeout > 0 {
+			c.client.Timeout = cfg.Timeout
+		}
+
+		p = c
+	case ProviderNone:
+		return None{}, nil
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER %q (want ollama, openai, or none)", cfg.Provider)
+	}
+
+	return newClient(p, cfg), nil
+}
+
+// FromEnv creates the provider configured by the environment.
+func FromEnv() (Provider, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(cfg)
+}
+
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// envInt reads an integer variable in [lo, hi], returning fallback when it
+// is unset.
+func envInt(key string, fallback, lo, hi int) (int, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < lo || v > hi {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", key, lo, hi)
+	}
+
+	return v, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,94 @@
+package llm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persistorai/persistor/internal/llm"
+)
+
+func clearLLMEnv(t *testing.T) {
+	t.Helper()
+
+	for _, k := range []string{
+		"LLM_PROVIDER", "LLM_URL", "LLM_MODEL", "LLM_API_KEY", "LLM_TIMEOUT_SECONDS",
+		"LLM_MAX_RETRIES", "LLM_MAX_CALLS_PER_HOUR", "LLM_MAX_PROMPT_CHARS",
+		"INGEST_LLM_URL", "INGEST_LLM_MODEL", "INGEST_LLM_API_KEY", "OLLAMA_URL", "OLLAMA_MODEL",
+	} {
+		t.Setenv(k, "")
+	}
+}
+
+func TestConfigFromEnv_DefaultsToOllama(t *testing.T) {
+	clearLLMEnv(t)
+
+	cfg, err := llm.ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, llm.ProviderOllama, cfg.Provider)
+	assert.Equal(t, "http://localhost:11434", cfg.URL)
+	assert.Equal(t, "gemma4:e4b", cfg.Model)
+	assert.Equal(t, 2, cfg.MaxRetries)
+}
+
+func TestConfigFromEnv_UsesOpenAIWhenURLSet(t *testing.T) {
+	clearLLMEnv(t)
+	t.Setenv("INGEST_LLM_URL", "https://api.x.ai/v1")
+	t.Setenv("INGEST_LLM_MODEL", "grok-beta")
+	t.Setenv("INGEST_LLM_API_KEY", "xai-test-key")
+
+	cfg, err := llm.ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, llm.ProviderOpenAI, cfg.Provider)
+	assert.Equal(t, "https://api.x.ai/v1", cfg.URL)
+	assert.Equal(t, "grok-beta", cfg.Model)
+	assert.Equal(t, "xai-test-key", cfg.APIKey)
+}
+
+func TestConfigFromEnv_LLMVarsWin(t *testing.T) {
+	clearLLMEnv(t)
+	t.Setenv("LLM_PROVIDER", "ollama")
+	t.Setenv("LLM_URL", "http://gpu:11434")
+	t.Setenv("OLLAMA_URL", "http://localhost:11434")
+	t.Setenv("LLM_TIMEOUT_SECONDS", "30")
+	t.Setenv("LLM_MAX_CALLS_PER_HOUR", "100")
+
+	cfg, err := llm.ConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "http://gpu:11434", cfg.URL)
+	assert.Equal(t, 30*time.Second, cfg.Timeout)
+	assert.Equal(t, 100, cfg.MaxCallsPerHour)
+}
+
+func TestConfigFromEnv_InvalidNumber(t *testing.T) {
+	clearLLMEnv(t)
+	t.Setenv("LLM_MAX_RETRIES", "lots")
+
+	_, err := llm.ConfigFromEnv()
+	assert.ErrorContains(t, err, "LLM_MAX_RETRIES")
+}
+
+func TestNew(t *testing.T) {
+	p, err := llm.New(llm.Config{Provider: llm.ProviderOllama, URL: "http://localhost:11434", Model: "qwen3.5:9b"})
+	require.NoError(t, err)
+	assert.Contains(t, p.Name(), "Ollama")
+
+	p, err = llm.New(llm.Config{Provider: llm.ProviderOpenAI, URL: "https://api.x.ai/v1", Model: "grok-beta", APIKey: "key"})
+	require.NoError(t, err)
+	assert.Contains(t, p.Name(), "OpenAI-compatible")
+
+	_, err = llm.New(llm.Config{Provider: "bard"})
+	assert.ErrorContains(t, err, "unknown LLM_PROVIDER")
+}
+
+func TestNone(t *testing.T) {
+	p, err := llm.New(llm.Config{Provider: llm.ProviderNone})
+	require.NoError(t, err)
+
+	_, err = p.Chat(context.Background(), "hello")
+	assert.ErrorIs(t, err, llm.ErrDisabled)
+	assert.ErrorIs(t, p.Check(context.Background()), llm.ErrDisabled)
+}
@@ -0,0 +1,16 @@
+package llm
+
+import "context"
+
+// None is the provider for LLM_PROVIDER=none; every call fails with
+// ErrDisabled so LLM-dependent features can skip themselves.
+type None struct{}
+
+// Name describes the provider for logs and diagnostics.
+func (None) Name() string { return "none (LLM features disabled)" }
+
+// Chat always returns ErrDisabled.
+func (None) Chat(context.Context, string) (string, error) { return "", ErrDisabled }
+
+// Check always returns ErrDisabled.
+func (None) Check(context.Context) error { return ErrDisabled }
@@ -0,0 +1,155 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ollamaTimeout bounds one Ollama chat request.
+const ollamaTimeout = 120 * time.Second
+
+// Ollama talks to an Ollama instance's chat API.
+type Ollama struct {
+	URL    string
+	Model  string
+	client *http.Client
+}
+
+// NewOllama creates an Ollama provider for the given URL and model.
+func NewOllama(url, model string) *Ollama {
+	return &Ollama{
+		URL:   url,
+		Model: model,
+		client: &http.Client{
+			Timeout: ollamaTimeout,
+		},
+	}
+}
+
+// chatRequest is the request body for the Ollama /api/chat endpoint.
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  chatOptions   `json:"options"`
+	Think    bool          `json:"think"`
+}
+
+// chatMessage is a single message in the chat request.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatOptions holds Ollama generation options.
+type chatOptions struct {
+	NumPredict  int     `json:"num_predict"`
+	Temperature float64 `json:"temperature"`
+}
+
+// chatResponse is the response body from Ollama /api/chat. The counts are
+// the prompt and generated tokens.
+type chatResponse struct {
+	Message         chatMessage `json:"message"`
+	PromptEvalCount int64       `json:"prompt_eval_count"`
+	EvalCount       int64       `json:"eval_count"`
+}
+
+// Name describes the provider for logs and diagnostics.
+func (o *Ollama) Name() string {
+	return fmt.Sprintf("Ollama (%s, model: %s)", o.URL, o.Model)
+}
+
+// Chat sends a prompt and returns the raw response text.
+func (o *Ollama) Chat(ctx context.Context, prompt string) (string, error) {
+	resp, _, err := o.chatTokens(ctx, prompt)
+
+	return resp, err
+}
+
+// chatTokens is Chat that also returns the tokens the call consumed.
+func (o *Ollama) chatTokens(ctx context.Context, prompt string) (string, int64, error) {
+	body, err := buildChatRequest(o.Model, prompt)
+	if err != nil {
+		return "", 0, fmt.Errorf("marshaling chat request: %w", err)
+	}
+
+	return o.doRequest(ctx, body)
+}
+
+// Check verifies that Ollama is reachable.
+func (o *Ollama) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.URL+"/api/tags", http.NoBody)
+	if err != nil {
+		return fmt.Errorf("creating Ollama health check: %w", err)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Ollama is not reachable at %s: %w\nMake sure Ollama is running (ollama serve)", o.URL, err) //nolint:staticcheck // user-facing message names the product.
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // drain response body
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Ollama returned status %d at %s", resp.StatusCode, o.URL) //nolint:staticcheck // user-facing message names the product.
+	}
+
+	return nil
+}
+
+func buildChatRequest(model, prompt string) ([]byte, error) {
+	req := chatRequest{
+		Model: model,
+		Messages: []chatMessage{
+			{Role: "user", Content: prompt},
+		},
+		Stream:  false,
+		Options: chatOptions{NumPredict: 2048, Temperature: 0.3},
+		Think:   false,
+	}
+
+	return json.Marshal(req)
+}
+
+func (o *Ollama) doRequest(ctx context.Context, body []byte) (string, int64, error) {
+	url := o.URL + "/api/chat"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", 0, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("calling ollama: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return parseChatResponse(resp)
+}
+
+func parseChatResponse(resp *http.Response) (string, int64, error) {
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, &StatusError{Provider: "ollama", StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return "", 0, fmt.Errorf("parsing response: %w", err)
+	}
+
+	return chatResp.Message.Content, chatResp.PromptEvalCount + chatResp.EvalCount, nil
+}
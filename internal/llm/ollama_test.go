@@ -1,4 +1,4 @@
-package ingest_test
+package llm_test
 
 import (
 	"context"
@@ -8,7 +8,7 @@
 	"net/http/httptest"
 	"testing"
 
-	"github.com/persistorai/persistor/internal/ingest"
+	"github.com/persistorai/persistor/internal/llm"
 )
 
 func TestOllamaChat_RequestConstruction(t *testing.T) {
@@ -50,7 +50,7 @@ func TestOllamaChat_RequestConstruction(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := ingest.NewOllamaClientWithURL(server.URL, "test-model")
+	client := llm.NewOllama(server.URL, "test-model")
 
 	result, err := client.Chat(context.Background(), "hello world")
 	if err != nil {
@@ -140,7 +140,7 @@ func TestOllamaChat_ErrorStatus(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := ingest.NewOllamaClientWithURL(server.URL, "test-model")
+	client := llm.NewOllama(server.URL, "test-model")
 
 	_, err := client.Chat(context.Background(), "hello")
 	if err == nil {
@@ -164,7 +164,7 @@ func TestOllamaChat_ValidResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := ingest.NewOllamaClientWithURL(server.URL, "test-model")
+	client := llm.NewOllama(server.URL, "test-model")
 
 	result, err := client.Chat(context.Background(), "extract stuff")
 	if err != nil {
@@ -1,4 +1,4 @@
-package ingest
+package llm
 
 import (
 	"bytes"
@@ -7,38 +7,30 @@
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 )
 
-// OpenAIClient talks to any OpenAI-compatible API (xAI, OpenAI, Anthropic, etc).
-type OpenAIClient struct {
+// openAITimeout bounds one chat completions request; hosted models can be
+// slow on long extraction prompts.
+const openAITimeout = 300 * time.Second
+
+// OpenAI talks to any OpenAI-compatible API (xAI, OpenAI, Anthropic, etc).
+type OpenAI struct {
 	BaseURL string
 	Model   string
 	APIKey  string
 	client  *http.Client
 }
 
-// NewOpenAIClient creates a client from INGEST_LLM env vars.
-func NewOpenAIClient() *OpenAIClient {
-	return &OpenAIClient{
-		BaseURL: os.Getenv("INGEST_LLM_URL"),
-		Model:   os.Getenv("INGEST_LLM_MODEL"),
-		APIKey:  os.Getenv("INGEST_LLM_API_KEY"),
-		client: &http.Client{
-			Timeout: 300 * time.Second,
-		},
-	}
-}
-
-// NewOpenAIClientWithConfig creates a client with explicit configuration.
-func NewOpenAIClientWithConfig(baseURL, model, apiKey string) *OpenAIClient {
-	return &OpenAIClient{
+// NewOpenAI creates an OpenAI-compatible provider.
+func NewOpenAI(baseURL, model, apiKey string) *OpenAI {
+	return &OpenAI{
 		BaseURL: baseURL,
 		Model:   model,
 		APIKey:  apiKey,
 		client: &http.Client{
-			Timeout: 300 * time.Second,
+			Timeout: openAITimeout,
 		},
 	}
 }
@@ -55,9 +47,15 @@ type openaiRequest struct {
 // openaiResponse is the response from the chat completions endpoint.
 type openaiResponse struct {
 	Choices []openaiChoice `json:"choices"`
+	Usage   openaiUsage    `json:"usage"`
 	Error   *openaiError   `json:"error,omitempty"`
 }
 
+// openaiUsage is the tokens a request consumed.
+type openaiUsage struct {
+	TotalTokens int64 `json:"total_tokens"`
+}
+
 // openaiChoice is a single choice in the response.
 type openaiChoice struct {
 	Message chatMessage `json:"message"`
@@ -69,17 +67,29 @@ type openaiError struct {
 	Type    string `json:"type"`
 }
 
+// Name describes the provider for logs and diagnostics.
+func (c *OpenAI) Name() string {
+	return fmt.Sprintf("OpenAI-compatible (%s, model: %s)", c.BaseURL, c.Model)
+}
+
 // Chat sends a prompt and returns the response text.
-func (c *OpenAIClient) Chat(ctx context.Context, prompt string) (string, error) {
+func (c *OpenAI) Chat(ctx context.Context, prompt string) (string, error) {
+	resp, _, err := c.chatTokens(ctx, prompt)
+
+	return resp, err
+}
+
+// chatTokens is Chat that also returns the tokens the call consumed.
+func (c *OpenAI) chatTokens(ctx context.Context, prompt string) (string, int64, error) {
 	body, err := c.buildRequest(prompt)
 	if err != nil {
-		return "", fmt.Errorf("marshaling openai request: %w", err)
+		return "", 0, fmt.Errorf("marshaling openai request: %w", err)
 	}
 
 	return c.doRequest(ctx, body)
 }
 
-func (c *OpenAIClient) buildRequest(prompt string) ([]byte, error) {
+func (c *OpenAI) buildRequest(prompt string) ([]byte, error) {
 	req := openaiRequest{
 		Model: c.Model,
 		Messages: []chatMessage{
@@ -97,12 +107,12 @@ func (c *OpenAIClient) buildRequest(prompt string) ([]byte, error) {
 	return json.Marshal(req)
 }
 
-func (c *OpenAIClient) doRequest(ctx context.Context, body []byte) (string, error) {
+func (c *OpenAI) doRequest(ctx context.Context, body []byte) (string, int64, error) {
 	url := c.BaseURL + "/chat/completions"
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
+		return "", 0, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -110,7 +120,7 @@ func (c *OpenAIClient) doRequest(ctx context.Context, body []byte) (string, erro
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("calling LLM API: %w", err)
+		return "", 0, fmt.Errorf("calling LLM API: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -118,45 +128,45 @@ func (c *OpenAIClient) doRequest(ctx context.Context, body []byte) (string, erro
 }
 
 func usesCompletionTokens(model string) bool {
-	return len(model) >= 5 && model[:5] == "gpt-5"
+	return strings.HasPrefix(model, "gpt-5")
 }
 
-func parseOpenAIResponse(resp *http.Response) (string, error) {
+func parseOpenAIResponse(resp *http.Response) (string, int64, error) {
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("reading response: %w", err)
+		return "", 0, fmt.Errorf("reading response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("LLM API returned status %d: %s", resp.StatusCode, string(respBody))
+		return "", 0, &StatusError{Provider: "LLM API", StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var result openaiResponse
 	if err := json.Unmarshal(respBody, &result); err != nil {
-		return "", fmt.Errorf("parsing response: %w", err)
+		return "", 0, fmt.Errorf("parsing response: %w", err)
 	}
 
 	if result.Error != nil {
-		return "", fmt.Errorf("LLM API error: %s", result.Error.Message)
+		return "", 0, fmt.Errorf("LLM API error: %s", result.Error.Message)
 	}
 
 	if len(result.Choices) == 0 {
-		return "", fmt.Errorf("LLM API returned no choices")
+		return "", 0, fmt.Errorf("LLM API returned no choices")
 	}
 
-	return result.Choices[0].Message.Content, nil
+	return result.Choices[0].Message.Content, result.Usage.TotalTokens, nil
 }
 
-// HealthCheck does a lightweight validation of the API configuration.
-func (c *OpenAIClient) HealthCheck(ctx context.Context) error {
+// Check does a lightweight validation of the API configuration.
+func (c *OpenAI) Check(_ context.Context) error {
 	if c.BaseURL == "" {
-		return fmt.Errorf("INGEST_LLM_URL is not set")
+		return fmt.Errorf("LLM_URL is not set")
 	}
 	if c.Model == "" {
-		return fmt.Errorf("INGEST_LLM_MODEL is not set")
+		return fmt.Errorf("LLM_MODEL is not set")
 	}
 	if c.APIKey == "" {
-		return fmt.Errorf("INGEST_LLM_API_KEY is not set")
+		return fmt.Errorf("LLM_API_KEY is not set")
 	}
 
 	return nil
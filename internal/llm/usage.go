@@ -0,0 +1,56 @@
+package llm
+
+import "context"
+
+// charsPerToken estimates tokens from text length for providers that do not
+// report them.
+const charsPerToken = 4
+
+// Usage is the requests a provider sent and the tokens they consumed.
+type Usage struct {
+	Requests int64
+	Tokens   int64
+}
+
+// tokenCounter is implemented by providers whose responses report the
+// tokens a call consumed.
+type tokenCounter interface {
+	chatTokens(ctx context.Context, prompt string) (string, int64, error)
+}
+
+// UsageOf returns the usage p has counted since it was created. Providers
+// from New and FromEnv count every request they send, retries included;
+// others report none.
+func UsageOf(p Provider) Usage {
+	c, ok := p.(*client)
+	if !ok {
+		return Usage{}
+	}
+
+	return Usage{Requests: c.requests.Load(), Tokens: c.tokens.Load()}
+}
+
+// send sends prompt once and counts the request and its tokens, estimated
+// from the text when the provider does not report them.
+func (c *client) send(ctx context.Context, prompt string) (string, error) {
+	c.requests.Add(1)
+
+	tc, ok := c.Provider.(tokenCounter)
+	if !ok {
+		resp, err := c.Provider.Chat(ctx, prompt)
+		if err == nil {
+			c.tokens.Add(int64((len(prompt) + len(resp) + charsPerToken - 1) / charsPerToken))
+		}
+
+		return resp, err
+	}
+
+	resp, tokens, err := tc.chatTokens(ctx, prompt)
+	if err == nil && tokens <= 0 {
+		tokens = int64((len(prompt) + len(resp) + charsPerToken - 1) / charsPerToken)
+	}
+
+	c.tokens.Add(tokens)
+
+	return resp, err
+}
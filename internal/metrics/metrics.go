@@ -56,6 +56,163 @@
 			Help: "Total edge count",
 		},
 	)
+
+	StoreOperationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "persistor_store_operation_duration_seconds",
+			Help:    "Store operation duration in seconds by operation",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"operation"},
+	)
+
+	EmbeddingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "persistor_embedding_duration_seconds",
+			Help:    "Embedding generation duration in seconds by result (success, error)",
+			Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"result"},
+	)
+
+	EmbeddingCircuitState = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "persistor_embedding_circuit_state",
+			Help: "Embedding circuit breaker state (0 closed, 1 open, 2 half-open)",
+		},
+	)
+
+	EmbeddingCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_embedding_cache_requests_total",
+			Help: "Embedding cache lookups by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
+
+	EmbeddingCacheEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "persistor_embedding_cache_evictions_total",
+			Help: "Embedding cache entries evicted to stay within the size limit",
+		},
+	)
+
+	QueryEmbeddingCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_query_embedding_cache_requests_total",
+			Help: "In-memory search query embedding cache lookups by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
+
+	QueryEmbeddingCacheEntries = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "persistor_query_embedding_cache_entries",
+			Help: "Search query embeddings currently held in memory",
+		},
+	)
+
+	AuditQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "persistor_audit_queue_depth",
+			Help: "Current audit queue depth",
+		},
+	)
+
+	DBPoolConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "persistor_db_pool_connections",
+			Help: "Database pool connections by state (idle, acquired, constructing, max)",
+		},
+		[]string{"state"},
+	)
+
+	DBPoolAcquireDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "persistor_db_pool_acquire_duration_seconds",
+			Help:    "Time spent acquiring a database connection from the pool",
+			Buckets: []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 5},
+		},
+	)
+
+	DBPoolAcquireFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "persistor_db_pool_acquire_failures_total",
+			Help: "Failed database connection acquires",
+		},
+	)
+
+	DBPoolRecycledConns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_db_pool_recycled_connections_total",
+			Help: "Database connections recycled by the pool supervisor by reason (broken, reset)",
+		},
+		[]string{"reason"},
+	)
+
+	TenantRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_tenant_requests_total",
+			Help: "Requests checked against tenant rate limits by result (allowed, limited)",
+		},
+		[]string{"tenant_id", "result"},
+	)
+
+	SalienceRecalcRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_salience_recalc_runs_total",
+			Help: "Scheduled per-tenant salience recalculations by result (success, error)",
+		},
+		[]string{"result"},
+	)
+
+	SalienceRecalcDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "persistor_salience_recalc_duration_seconds",
+			Help:    "Duration of scheduled per-tenant salience recalculations in seconds",
+			Buckets: []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+		},
+	)
+
+	ChangeEventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_change_events_dropped_total",
+			Help: "Change events lost before reaching clients by reason (notify_oversized, notify_failed, oversized, channel_full, slow_client)",
+		},
+		[]string{"reason"},
+	)
+
+	ChangeEventsCompacted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_change_events_compacted_total",
+			Help: "Change events reduced to entity references to fit a payload limit by stage (notify, broadcast)",
+		},
+		[]string{"stage"},
+	)
+
+	ModelRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_model_requests_total",
+			Help: "Embedding and LLM requests made for each tenant by kind (embedding, llm)",
+		},
+		[]string{"tenant_id", "kind"},
+	)
+
+	ModelTokens = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_model_tokens_total",
+			Help: "Embedding and LLM tokens consumed for each tenant by kind (embedding, llm)",
+		},
+		[]string{"tenant_id", "kind"},
+	)
+
+	ModelBudgetRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "persistor_model_budget_rejections_total",
+			Help: "Model requests refused because the tenant's monthly token budget was used up, by kind (embedding, llm)",
+		},
+		[]string{"tenant_id", "kind"},
+	)
 )
 
 // Register registers all metrics with the given registerer.
@@ -66,5 +223,15 @@ func Register(r prometheus.Registerer) {
 		RequestDuration, RequestsTotal, ErrorsTotal,
 		EmbedQueueDepth, WSConnections,
 		NodeCount, EdgeCount,
+		StoreOperationDuration, EmbeddingDuration,
+		EmbeddingCircuitState, EmbeddingCacheRequests,
+		EmbeddingCacheEvictions, QueryEmbeddingCacheRequests,
+		QueryEmbeddingCacheEntries, AuditQueueDepth,
+		DBPoolConnections, DBPoolAcquireDuration,
+		DBPoolAcquireFailures, DBPoolRecycledConns,
+		TenantRequestsTotal,
+		SalienceRecalcRuns, SalienceRecalcDuration,
+		ChangeEventsDropped, ChangeEventsCompacted,
+		ModelRequests, ModelTokens, ModelBudgetRejections,
 	)
 }
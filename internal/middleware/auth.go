@@ -63,7 +63,7 @@ func AuthMiddleware(lookup TenantLookup, log *logrus.Logger, guards ...*security
 			return
 		}
 
-		principal, err := lookupPrincipal(c.Request.Context(), lookup, apiKey)
+		principal, err := LookupPrincipal(c.Request.Context(), lookup, apiKey)
 		if err != nil {
 			logAuthFailure(log, c, apiKey)
 
@@ -81,11 +81,26 @@ func AuthMiddleware(lookup TenantLookup, log *logrus.Logger, guards ...*security
 
 		c.Set("tenant_id", principal.TenantID)
 		c.Set(AuthScopeContextKey, principal.Scope)
+		if len(principal.SigningSecret) > 0 {
+			c.Set(SigningSecretContextKey, principal.SigningSecret)
+		}
+		c.Set(TenantPlanContextKey, principal.Plan)
+		if principal.Operator {
+			c.Set(OperatorContextKey, true)
+		}
+		if principal.ImpersonatedBy != "" {
+			c.Set(ImpersonatorContextKey, principal.ImpersonatedBy)
+		}
+		if !principal.RateLimit.IsZero() {
+			c.Set(RateLimitContextKey, principal.RateLimit)
+		}
 		c.Next()
 	}
 }
 
-func lookupPrincipal(ctx context.Context, lookup TenantLookup, apiKey string) (AuthPrincipal, error) {
+// LookupPrincipal resolves an API key to its principal, defaulting to
+// read-write scope when lookup does not implement PrincipalLookup.
+func LookupPrincipal(ctx context.Context, lookup TenantLookup, apiKey string) (AuthPrincipal, error) {
 	if scopedLookup, ok := lookup.(PrincipalLookup); ok {
 		return scopedLookup.GetAuthPrincipalByAPIKey(ctx, apiKey)
 	}
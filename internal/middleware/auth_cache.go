@@ -115,7 +115,7 @@ func (c *CachedTenantLookup) GetAuthPrincipalByAPIKey(ctx context.Context, apiKe
 	c.mu.RUnlock()
 
 	// Cache miss or expired — fetch from inner.
-	principal, err := lookupPrincipal(ctx, c.inner, apiKey)
+	principal, err := LookupPrincipal(ctx, c.inner, apiKey)
 	if err != nil {
 		// Negative cache: store failed lookup with short TTL.
 		c.mu.Lock()
@@ -16,6 +16,10 @@
 type mockTenantLookup struct {
 	validKeys map[string]string
 	scopes    map[string]middleware.AuthScope
+	secrets   map[string][]byte
+	operators map[string]bool
+	// impersonators maps impersonation keys to their operator tenant.
+	impersonators map[string]string
 }
 
 func (m *mockTenantLookup) GetTenantByAPIKey(_ context.Context, apiKey string) (string, error) {
@@ -34,7 +38,10 @@ func (m *mockTenantLookup) GetAuthPrincipalByAPIKey(_ context.Context, apiKey st
 				scope = middleware.ScopeReadWrite
 			}
 		}
-		return middleware.AuthPrincipal{TenantID: tid, Scope: scope}, nil
+		return middleware.AuthPrincipal{
+			TenantID: tid, Scope: scope, SigningSecret: m.secrets[apiKey], Operator: m.operators[apiKey],
+			ImpersonatedBy: m.impersonators[apiKey],
+		}, nil
 	}
 
 	return middleware.AuthPrincipal{}, errors.New("invalid key")
@@ -100,6 +107,32 @@ func TestAuthMiddleware_SetsTenantID(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_SetsImpersonator(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	lookup := &mockTenantLookup{
+		validKeys:     map[string]string{"own": "t1", "support": "t1"},
+		impersonators: map[string]string{"support": "op1"},
+	}
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(lookup, log))
+	r.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(middleware.ImpersonatorContextKey))
+	})
+
+	for key, want := range map[string]string{"own": "", "support": "op1"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+key)
+		r.ServeHTTP(w, req)
+
+		if got := w.Body.String(); got != want {
+			t.Errorf("key %s: impersonator = %q, want %q", key, got, want)
+		}
+	}
+}
+
 func TestExtractBearerToken(t *testing.T) {
 	tests := []struct {
 		header string
@@ -169,3 +202,89 @@ func TestRequireScope(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireScope_Ladder(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	scopes := []middleware.AuthScope{
+		middleware.ScopeSearch, middleware.ScopeRead, middleware.ScopeReadWrite, middleware.ScopeAdmin,
+	}
+	lookup := &mockTenantLookup{validKeys: map[string]string{}, scopes: map[string]middleware.AuthScope{}}
+	for _, s := range scopes {
+		lookup.validKeys[string(s)] = "tenant-1"
+		lookup.scopes[string(s)] = s
+	}
+	lookup.validKeys["bogus"] = "tenant-1"
+	lookup.scopes["bogus"] = middleware.AuthScope("superuser")
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(lookup, log))
+	for _, required := range scopes {
+		g := r.Group("/" + string(required))
+		g.Use(middleware.RequireScope(required, log))
+		g.GET("", func(c *gin.Context) { c.Status(http.StatusOK) })
+	}
+
+	for i, key := range scopes {
+		for j, required := range scopes {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/"+string(required), http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+string(key))
+			r.ServeHTTP(w, req)
+
+			want := http.StatusForbidden
+			if i >= j {
+				want = http.StatusOK
+			}
+			if w.Code != want {
+				t.Errorf("%s key on %s route: got %d, want %d", key, required, w.Code, want)
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/"+string(middleware.ScopeSearch), http.NoBody)
+	req.Header.Set("Authorization", "Bearer bogus")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("unknown scope: got %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestRequireOperator(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	lookup := &mockTenantLookup{
+		validKeys: map[string]string{"operator-admin": "tenant-1", "operator-rw": "tenant-1", "tenant-admin": "tenant-2"},
+		scopes: map[string]middleware.AuthScope{
+			"operator-admin": middleware.ScopeAdmin, "operator-rw": middleware.ScopeReadWrite, "tenant-admin": middleware.ScopeAdmin,
+		},
+		operators: map[string]bool{"operator-admin": true, "operator-rw": true},
+	}
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(lookup, log))
+	r.GET("/admin/tenants",
+		middleware.RequireScope(middleware.ScopeAdmin, log),
+		middleware.RequireOperator(log),
+		func(c *gin.Context) { c.Status(http.StatusOK) },
+	)
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"operator-admin", http.StatusOK},
+		{"operator-rw", http.StatusForbidden},
+		{"tenant-admin", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/admin/tenants", http.NoBody)
+		req.Header.Set("Authorization", "Bearer "+tt.key)
+		r.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.key, w.Code, tt.want)
+		}
+	}
+}
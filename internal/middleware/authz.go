@@ -10,26 +10,56 @@
 // AuthScopeContextKey stores the caller scope in Gin context.
 const AuthScopeContextKey = "auth_scope"
 
-// AuthScope defines the privilege level attached to an API key.
+// OperatorContextKey is set in Gin context when the caller's tenant is an
+// operator tenant.
+const OperatorContextKey = "operator"
+
+// ImpersonatorContextKey stores, in Gin context, the operator tenant that
+// issued the impersonation key a request authenticated with.
+const ImpersonatorContextKey = "impersonated_by"
+
+// AuthScope defines the privilege level attached to an API key. Each scope
+// includes the ones before it: search-only keys can only search, read keys
+// can also read nodes, edges, and the graph, read_write keys can also write,
+// and admin keys can do everything.
 type AuthScope string
 
 const (
+	ScopeSearch    AuthScope = "search"
+	ScopeRead      AuthScope = "read"
 	ScopeReadWrite AuthScope = "read_write"
 	ScopeAdmin     AuthScope = "admin"
 )
 
+// scopeRank orders scopes by privilege. Unknown scopes rank zero and are
+// allowed nothing.
+var scopeRank = map[AuthScope]int{
+	ScopeSearch:    1,
+	ScopeRead:      2,
+	ScopeReadWrite: 3,
+	ScopeAdmin:     4,
+}
+
 // AuthPrincipal is the authenticated identity derived from an API key.
+// SigningSecret is set when the tenant requires signed requests. RateLimit
+// overrides the default limit for Plan when set. Operator tenants can manage
+// other tenants. ImpersonatedBy is the operator tenant that issued the key
+// when it is an impersonation key.
 type AuthPrincipal struct {
-	TenantID string
-	Scope    AuthScope
+	TenantID       string
+	Scope          AuthScope
+	SigningSecret  []byte
+	Plan           string
+	RateLimit      RateLimit
+	Operator       bool
+	ImpersonatedBy string
 }
 
-func (s AuthScope) allows(required AuthScope) bool {
-	if required == ScopeReadWrite {
-		return true
-	}
+// Allows reports whether a key with scope s may use routes that need required.
+func (s AuthScope) Allows(required AuthScope) bool {
+	rank := scopeRank[s]
 
-	return s == ScopeAdmin
+	return rank > 0 && rank >= scopeRank[required]
 }
 
 // RequireScope blocks requests whose authenticated API key lacks the required scope.
@@ -41,7 +71,7 @@ func RequireScope(required AuthScope, log *logrus.Logger) gin.HandlerFunc {
 			actual = ScopeReadWrite
 		}
 
-		if actual.allows(required) {
+		if actual.Allows(required) {
 			c.Next()
 			return
 		}
@@ -58,3 +88,23 @@ func RequireScope(required AuthScope, log *logrus.Logger) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// RequireOperator blocks requests from tenants that are not operators. Use it
+// after RequireScope(ScopeAdmin) so only an operator's admin keys pass.
+func RequireOperator(log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetBool(OperatorContextKey) {
+			c.Next()
+			return
+		}
+
+		log.WithFields(logrus.Fields{
+			"path":      c.Request.URL.Path,
+			"method":    c.Request.Method,
+			"tenant_id": c.GetString("tenant_id"),
+		}).Warn("authorization failed: tenant is not an operator")
+
+		respondError(c, http.StatusForbidden, "forbidden", "operator tenant required")
+		c.Abort()
+	}
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Idempotency headers.
+const (
+	// IdempotencyKeyHeader carries a client-chosen key that makes a retried
+	// POST return the first attempt's response instead of running again.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set to "true" on a replayed response.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyStore claims idempotency keys and keeps the responses to the
+// requests that claimed them.
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey claims key for a request with fingerprint. It
+	// returns nil if the key was claimed, or the existing record if another
+	// request holds it or already finished with it.
+	ReserveIdempotencyKey(ctx context.Context, tenantID, key, fingerprint string, now time.Time) (*models.IdempotencyRecord, error)
+	// CompleteIdempotencyKey stores the response to the request holding key.
+	CompleteIdempotencyKey(ctx context.Context, tenantID, key string, status int, body []byte) error
+	// ReleaseIdempotencyKey gives up a claim so that a retry can run again.
+	ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error
+}
+
+// Idempotency returns Gin middleware that honours the Idempotency-Key header
+// on the routes it is attached to. It must run after AuthMiddleware.
+//
+// The first request with a key runs normally and its response is stored.
+// Later requests with the same key and body get that response back with
+// Idempotent-Replayed set, or 409 while the first is still running, or 422
+// if the body differs. A 5xx response is not stored, so a retry runs again.
+// Requests without the header pass through unchanged.
+func Idempotency(store IdempotencyStore, log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > models.MaxIdempotencyKeyLength {
+			respondError(c, http.StatusBadRequest, "invalid_request", "Idempotency-Key header too long")
+			return
+		}
+
+		body, ok := readSignedBody(c)
+		if !ok {
+			return
+		}
+
+		tenantID := c.GetString("tenant_id")
+		fingerprint := requestFingerprint(c, body)
+
+		existing, err := store.ReserveIdempotencyKey(c.Request.Context(), tenantID, key, fingerprint, time.Now())
+		if err != nil {
+			idempotencyLog(log, c).WithError(err).Error("reserving idempotency key")
+			respondError(c, http.StatusInternalServerError, "internal_error", "internal server error")
+			return
+		}
+
+		if existing != nil {
+			replayIdempotent(c, existing, fingerprint)
+			return
+		}
+
+		w := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		// The request context may be done by now; the outcome must still be saved.
+		ctx := context.WithoutCancel(c.Request.Context())
+		if status := w.Status(); status >= http.StatusInternalServerError {
+			err = store.ReleaseIdempotencyKey(ctx, tenantID, key)
+		} else {
+			err = store.CompleteIdempotencyKey(ctx, tenantID, key, status, w.body.Bytes())
+		}
+
+		if err != nil {
+			idempotencyLog(log, c).WithError(err).Error("saving idempotency key outcome")
+		}
+	}
+}
+
+func idempotencyLog(log *logrus.Logger, c *gin.Context) *logrus.Entry {
+	return log.WithFields(logrus.Fields{
+		"request_id": c.GetString("request_id"),
+		"tenant_id":  c.GetString("tenant_id"),
+	})
+}
+
+// replayIdempotent answers a request whose key is already taken.
+func replayIdempotent(c *gin.Context, existing *models.IdempotencyRecord, fingerprint string) {
+	switch {
+	case existing.Fingerprint != fingerprint:
+		respondError(c, http.StatusUnprocessableEntity, "idempotency_key_reused",
+			"Idempotency-Key was already used for a different request")
+	case existing.Status == 0:
+		respondError(c, http.StatusConflict, "conflict",
+			"a request with this Idempotency-Key is still in progress")
+	default:
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(existing.Status, "application/json; charset=utf-8", existing.Body)
+		c.Abort()
+	}
+}
+
+// requestFingerprint identifies a request by method, path, and body, so that
+// a key reused for a different request is detected.
+func requestFingerprint(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// capturingWriter keeps a copy of the response body as it is written.
+type capturingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
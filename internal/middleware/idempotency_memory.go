@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// idempotencyCleanup is how often expired keys are swept from a
+// MemoryIdempotencyStore.
+const idempotencyCleanup = 10 * time.Minute
+
+type memoryIdempotencyEntry struct {
+	record      models.IdempotencyRecord
+	lockedUntil time.Time
+	expiresAt   time.Time
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore that keeps keys in memory.
+// State is per process, so retries that reach another replica run again.
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryIdempotencyEntry // tenant ID + key → entry
+}
+
+// NewMemoryIdempotencyStore creates a MemoryIdempotencyStore and starts a
+// background cleanup goroutine that stops when ctx is cancelled.
+func NewMemoryIdempotencyStore(ctx context.Context) *MemoryIdempotencyStore {
+	s := &MemoryIdempotencyStore{entries: make(map[string]*memoryIdempotencyEntry)}
+	go s.cleanupLoop(ctx)
+	return s
+}
+
+// ReserveIdempotencyKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) ReserveIdempotencyKey(
+	_ context.Context, tenantID, key, fingerprint string, now time.Time,
+) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := tenantID + ":" + key
+	if e, ok := s.entries[id]; ok && now.Before(e.expiresAt) && (e.record.Status != 0 || now.Before(e.lockedUntil)) {
+		record := e.record
+		return &record, nil
+	}
+
+	s.entries[id] = &memoryIdempotencyEntry{
+		record:      models.IdempotencyRecord{Fingerprint: fingerprint},
+		lockedUntil: now.Add(models.IdempotencyLockTTL),
+		expiresAt:   now.Add(models.IdempotencyKeyTTL),
+	}
+
+	return nil, nil
+}
+
+// CompleteIdempotencyKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) CompleteIdempotencyKey(_ context.Context, tenantID, key string, status int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[tenantID+":"+key]; ok {
+		e.record.Status = status
+		e.record.Body = append([]byte(nil), body...)
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) ReleaseIdempotencyKey(_ context.Context, tenantID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, tenantID+":"+key)
+
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyCleanup)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.mu.Lock()
+			for id, e := range s.entries {
+				if !now.Before(e.expiresAt) {
+					delete(s.entries, id)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/middleware"
+)
+
+func TestIdempotency(t *testing.T) {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var runs int
+	status := http.StatusCreated
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("tenant_id", "t1"); c.Next() })
+	r.POST("/nodes", middleware.Idempotency(middleware.NewMemoryIdempotencyStore(ctx), log), func(c *gin.Context) {
+		runs++
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(status, gin.H{"run": runs, "body": string(body)})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/nodes", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("k1", `{"id":"a"}`)
+	replay := post("k1", `{"id":"a"}`)
+	if runs != 1 || replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Errorf("replay: runs %d, status %d, body %s; want 1 run and %s", runs, replay.Code, replay.Body, first.Body)
+	}
+	if replay.Header().Get(middleware.IdempotentReplayedHeader) != "true" || first.Header().Get(middleware.IdempotentReplayedHeader) != "" {
+		t.Error("only the replay should carry the replayed header")
+	}
+
+	if w := post("k1", `{"id":"b"}`); w.Code != http.StatusUnprocessableEntity || runs != 1 {
+		t.Errorf("reused key: status %d, runs %d", w.Code, runs)
+	}
+
+	post("", `{"id":"a"}`)
+	post("", `{"id":"a"}`)
+	if runs != 3 {
+		t.Errorf("without a key: runs = %d, want 3", runs)
+	}
+
+	status = http.StatusInternalServerError
+	post("k2", `{}`)
+	status = http.StatusCreated
+	if w := post("k2", `{}`); w.Code != http.StatusCreated || runs != 5 {
+		t.Errorf("retry after 5xx: status %d, runs %d; want a second run", w.Code, runs)
+	}
+
+	if w := post(strings.Repeat("k", 256), `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("long key: status %d, want 400", w.Code)
+	}
+}
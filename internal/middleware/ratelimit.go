@@ -4,6 +4,7 @@
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,6 +14,14 @@
 // maxBuckets is the maximum number of tracked IPs to prevent memory exhaustion.
 const maxBuckets = 100_000
 
+// Rate limit response headers. Every response carries the bucket size and
+// the requests left in it; a 429 also says how many seconds to wait.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RetryAfterHeader         = "Retry-After"
+)
+
 // RateLimiter implements a simple token bucket rate limiter per IP.
 type RateLimiter struct {
 	buckets map[string]*bucket
@@ -29,7 +38,8 @@ type bucket struct {
 	burst      int
 }
 
-func (b *bucket) allow() bool {
+// allow takes a token if one is left and reports the tokens remaining.
+func (b *bucket) allow() (bool, int) {
 	now := time.Now()
 	elapsed := now.Sub(b.lastFill).Seconds()
 	refill := int(elapsed * float64(b.ratePerSec))
@@ -46,10 +56,10 @@ func (b *bucket) allow() bool {
 	if b.tokens > 0 {
 		b.tokens--
 
-		return true
+		return true, b.tokens
 	}
 
-	return false
+	return false, 0
 }
 
 // NewRateLimiter creates a RateLimiter with the given requests per second and burst size.
@@ -101,6 +111,7 @@ func (rl *RateLimiter) Handler() gin.HandlerFunc {
 			// Reject new IPs when bucket table is full to prevent memory exhaustion.
 			if len(rl.buckets) >= maxBuckets {
 				rl.mu.Unlock()
+				c.Header(RetryAfterHeader, "1")
 				respondError(c, http.StatusTooManyRequests, "rate_limited", "too many clients")
 
 				return
@@ -115,10 +126,15 @@ func (rl *RateLimiter) Handler() gin.HandlerFunc {
 			rl.buckets[ip] = b
 		}
 
-		allowed := b.allow()
+		allowed, remaining := b.allow()
 		rl.mu.Unlock()
 
+		c.Header(RateLimitLimitHeader, strconv.Itoa(rl.burst))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(remaining))
+
 		if !allowed {
+			// Buckets refill at least one token per second.
+			c.Header(RetryAfterHeader, "1")
 			respondError(c, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded")
 
 			return
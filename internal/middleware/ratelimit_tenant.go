@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+// Gin context keys set by AuthMiddleware for the tenant rate limiter.
+const (
+	TenantPlanContextKey = "tenant_plan"
+	RateLimitContextKey  = "rate_limit"
+)
+
+// Tenant rate limit response headers. They follow the IETF RateLimit header
+// fields and describe the tenant's bucket; the X-RateLimit-* headers describe
+// the per-IP bucket.
+const (
+	TenantRateLimitLimitHeader     = "RateLimit-Limit"
+	TenantRateLimitRemainingHeader = "RateLimit-Remaining"
+	TenantRateLimitResetHeader     = "RateLimit-Reset"
+)
+
+// Plans with built-in rate limits. Tenants on any other plan get the free
+// plan's limit.
+const (
+	PlanFree       = "free"
+	PlanPro        = "pro"
+	PlanSelfHosted = "self-hosted"
+)
+
+// RateLimit is a token bucket size and refill rate. The zero value means no
+// limit.
+type RateLimit struct {
+	PerSec int
+	Burst  int
+}
+
+// IsZero reports whether l is unset, i.e. unlimited.
+func (l RateLimit) IsZero() bool {
+	return l.PerSec <= 0 || l.Burst <= 0
+}
+
+// PlanRateLimits maps tenant plans to their default rate limit.
+type PlanRateLimits map[string]RateLimit
+
+// DefaultPlanRateLimits returns the built-in plan limits. Self-hosted
+// tenants are only subject to the per-IP limit. The limits apply per server
+// process: a tenant spread over N instances may get up to N times its limit.
+func DefaultPlanRateLimits() PlanRateLimits {
+	return PlanRateLimits{
+		PlanFree:       {PerSec: 20, Burst: 40},
+		PlanPro:        {PerSec: 200, Burst: 400},
+		PlanSelfHosted: {},
+	}
+}
+
+// For returns the limit for plan, falling back to the free plan's.
+func (p PlanRateLimits) For(plan string) RateLimit {
+	if l, ok := p[plan]; ok {
+		return l
+	}
+
+	return p[PlanFree]
+}
+
+// TenantRateLimiter applies a token bucket per authenticated tenant, sized by
+// the tenant's rate limit override or its plan's default. All of a tenant's
+// API keys share its bucket. Buckets are held in memory, so each process
+// limits only the requests it serves. It must run after AuthMiddleware.
+type TenantRateLimiter struct {
+	buckets map[string]*bucket
+	mu      sync.Mutex
+	plans   PlanRateLimits
+}
+
+// NewTenantRateLimiter creates a TenantRateLimiter. A nil plans uses
+// DefaultPlanRateLimits. Stale buckets are evicted in the background until
+// ctx is cancelled.
+func NewTenantRateLimiter(ctx context.Context, plans PlanRateLimits) *TenantRateLimiter {
+	if plans == nil {
+		plans = DefaultPlanRateLimits()
+	}
+
+	rl := &TenantRateLimiter{buckets: make(map[string]*bucket), plans: plans}
+	go rl.startCleanup(ctx)
+
+	return rl
+}
+
+// startCleanup periodically evicts buckets of tenants that stopped sending requests.
+func (rl *TenantRateLimiter) startCleanup(ctx context.Context) {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	const maxAge = 10 * time.Minute
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			rl.mu.Lock()
+			for tenantID, b := range rl.buckets {
+				if now.Sub(b.lastFill) > maxAge {
+					delete(rl.buckets, tenantID)
+				}
+			}
+			rl.mu.Unlock()
+		}
+	}
+}
+
+// limitFor returns the rate limit AuthMiddleware resolved for the request.
+func (rl *TenantRateLimiter) limitFor(c *gin.Context) RateLimit {
+	if v, ok := c.Get(RateLimitContextKey); ok {
+		if l, ok := v.(RateLimit); ok {
+			return l
+		}
+	}
+
+	return rl.plans.For(c.GetString(TenantPlanContextKey))
+}
+
+// take takes a token from the tenant's bucket, resizing the bucket first if
+// the tenant's limit changed since it was created.
+func (rl *TenantRateLimiter) take(tenantID string, limit RateLimit) (allowed bool, remaining int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	b, ok := rl.buckets[tenantID]
+	if !ok {
+		b = &bucket{tokens: limit.Burst, lastFill: time.Now(), ratePerSec: limit.PerSec, burst: limit.Burst}
+		rl.buckets[tenantID] = b
+	}
+
+	if b.ratePerSec != limit.PerSec || b.burst != limit.Burst {
+		b.ratePerSec, b.burst = limit.PerSec, limit.Burst
+		b.tokens = min(b.tokens, b.burst)
+	}
+
+	return b.allow()
+}
+
+// Handler returns Gin middleware that rate-limits requests per tenant and
+// sets the RateLimit-* headers. Unlimited tenants get no headers.
+func (rl *TenantRateLimiter) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.GetString("tenant_id")
+		limit := rl.limitFor(c)
+		if tenantID == "" || limit.IsZero() {
+			c.Next()
+
+			return
+		}
+
+		allowed, remaining := rl.take(tenantID, limit)
+
+		// Seconds until the bucket is full again, rounded up.
+		reset := (limit.Burst - remaining + limit.PerSec - 1) / limit.PerSec
+		c.Header(TenantRateLimitLimitHeader, strconv.Itoa(limit.Burst))
+		c.Header(TenantRateLimitRemainingHeader, strconv.Itoa(remaining))
+		c.Header(TenantRateLimitResetHeader, strconv.Itoa(reset))
+
+		if !allowed {
+			metrics.TenantRequestsTotal.WithLabelValues(tenantID, "limited").Inc()
+			// Buckets refill at least one token per second.
+			c.Header(RetryAfterHeader, "1")
+			respondError(c, http.StatusTooManyRequests, "rate_limited", "tenant rate limit exceeded")
+
+			return
+		}
+
+		metrics.TenantRequestsTotal.WithLabelValues(tenantID, "allowed").Inc()
+		c.Next()
+	}
+}
@@ -0,0 +1,103 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/middleware"
+)
+
+// newTenantRateLimitRouter stands in for AuthMiddleware by setting the tenant,
+// plan, and override from request headers.
+func newTenantRateLimitRouter(ctx context.Context, plans middleware.PlanRateLimits) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("tenant_id", c.GetHeader("X-Tenant"))
+		c.Set(middleware.TenantPlanContextKey, c.GetHeader("X-Plan"))
+		if c.GetHeader("X-Override") != "" {
+			c.Set(middleware.RateLimitContextKey, middleware.RateLimit{PerSec: 1, Burst: 3})
+		}
+	})
+	r.Use(middleware.NewTenantRateLimiter(ctx, plans).Handler())
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return r
+}
+
+func doTenantRequest(r *gin.Engine, tenant, plan string, override bool) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/test", http.NoBody)
+	req.Header.Set("X-Tenant", tenant)
+	req.Header.Set("X-Plan", plan)
+	if override {
+		req.Header.Set("X-Override", "1")
+	}
+	r.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestTenantRateLimiter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := newTenantRateLimitRouter(ctx, middleware.PlanRateLimits{
+		middleware.PlanFree:       {PerSec: 1, Burst: 1},
+		middleware.PlanPro:        {PerSec: 1, Burst: 2},
+		middleware.PlanSelfHosted: {},
+	})
+
+	tests := []struct {
+		name     string
+		tenant   string
+		plan     string
+		override bool
+		allowed  int
+	}{
+		{"free plan", "t-free", middleware.PlanFree, false, 1},
+		{"pro plan", "t-pro", middleware.PlanPro, false, 2},
+		{"unknown plan uses free", "t-other", "enterprise", false, 1},
+		{"tenant override", "t-override", middleware.PlanFree, true, 3},
+	}
+
+	for _, tt := range tests {
+		for i := range tt.allowed + 1 {
+			w := doTenantRequest(r, tt.tenant, tt.plan, tt.override)
+
+			wantStatus := http.StatusOK
+			if i == tt.allowed {
+				wantStatus = http.StatusTooManyRequests
+			}
+			if w.Code != wantStatus {
+				t.Fatalf("%s: request %d: status = %d, want %d", tt.name, i, w.Code, wantStatus)
+			}
+			if w.Header().Get(middleware.TenantRateLimitLimitHeader) == "" {
+				t.Errorf("%s: request %d: missing %s", tt.name, i, middleware.TenantRateLimitLimitHeader)
+			}
+			if wantStatus == http.StatusTooManyRequests && w.Header().Get(middleware.RetryAfterHeader) != "1" {
+				t.Errorf("%s: 429 Retry-After = %q, want 1", tt.name, w.Header().Get(middleware.RetryAfterHeader))
+			}
+		}
+	}
+}
+
+func TestTenantRateLimiter_SelfHostedUnlimited(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := newTenantRateLimitRouter(ctx, nil)
+
+	for i := range 100 {
+		w := doTenantRequest(r, "t-local", middleware.PlanSelfHosted, false)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, w.Code)
+		}
+		if got := w.Header().Get(middleware.TenantRateLimitLimitHeader); got != "" {
+			t.Fatalf("unlimited tenant got %s = %q", middleware.TenantRateLimitLimitHeader, got)
+		}
+	}
+}
@@ -4,6 +4,7 @@
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -57,6 +58,17 @@ func TestRateLimiter_BlocksExceedingLimit(t *testing.T) {
 		if i == 2 && w.Code != http.StatusTooManyRequests {
 			t.Fatalf("request %d: expected 429, got %d", i, w.Code)
 		}
+
+		wantRemaining := strconv.Itoa(max(0, 1-i))
+		if got := w.Header().Get(middleware.RateLimitRemainingHeader); got != wantRemaining {
+			t.Errorf("request %d: remaining = %q, want %q", i, got, wantRemaining)
+		}
+		if got := w.Header().Get(middleware.RateLimitLimitHeader); got != "2" {
+			t.Errorf("request %d: limit = %q, want 2", i, got)
+		}
+		if i == 2 && w.Header().Get(middleware.RetryAfterHeader) != "1" {
+			t.Errorf("429 Retry-After = %q, want 1", w.Header().Get(middleware.RetryAfterHeader))
+		}
 	}
 }
 
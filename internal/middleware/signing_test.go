@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/security"
+)
+
+func newSigningRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	lookup := &mockTenantLookup{
+		validKeys: map[string]string{"signed-key": "t1", "plain-key": "t2"},
+		secrets:   map[string][]byte{"signed-key": []byte("secret")},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(lookup, log))
+	r.Use(middleware.RequestSigning(security.NewReplayCache(ctx), log))
+	r.POST("/nodes", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	})
+
+	return r
+}
+
+func signedRequest(key string, ts time.Time, nonce, target, body string, secret []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set(security.SignatureTimestampHeader, strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set(security.SignatureNonceHeader, nonce)
+	req.Header.Set(security.SignatureHeader,
+		security.SignRequest(secret, ts.Unix(), nonce, http.MethodPost, target, []byte(body)))
+	return req
+}
+
+func TestRequestSigning(t *testing.T) {
+	r := newSigningRouter(t)
+	secret := []byte("secret")
+	now := time.Now()
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(signedRequest("signed-key", now, "n1", "/nodes?x=1", `{"id":"a"}`, secret))
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"a"}` {
+		t.Fatalf("valid signature: got %d %q, want 200 with the body restored", w.Code, w.Body.String())
+	}
+
+	if w := serve(signedRequest("signed-key", now, "n1", "/nodes?x=1", `{"id":"a"}`, secret)); w.Code != http.StatusUnauthorized {
+		t.Errorf("replayed nonce: got %d, want 401", w.Code)
+	}
+
+	tampered := signedRequest("signed-key", now, "n2", "/nodes?x=1", `{"id":"a"}`, secret)
+	tampered.Body = io.NopCloser(strings.NewReader(`{"id":"b"}`))
+	if w := serve(tampered); w.Code != http.StatusUnauthorized {
+		t.Errorf("tampered body: got %d, want 401", w.Code)
+	}
+
+	if w := serve(signedRequest("signed-key", now, "n3", "/nodes", "", []byte("wrong"))); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong secret: got %d, want 401", w.Code)
+	}
+
+	stale := now.Add(-security.SignatureMaxSkew - time.Minute)
+	if w := serve(signedRequest("signed-key", stale, "n4", "/nodes", "", secret)); w.Code != http.StatusUnauthorized {
+		t.Errorf("stale timestamp: got %d, want 401", w.Code)
+	}
+
+	unsigned := httptest.NewRequest(http.MethodPost, "/nodes", http.NoBody)
+	unsigned.Header.Set("Authorization", "Bearer signed-key")
+	if w := serve(unsigned); w.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned request for signing tenant: got %d, want 401", w.Code)
+	}
+
+	plain := httptest.NewRequest(http.MethodPost, "/nodes", http.NoBody)
+	plain.Header.Set("Authorization", "Bearer plain-key")
+	if w := serve(plain); w.Code != http.StatusOK {
+		t.Errorf("unsigned request for tenant without signing: got %d, want 200", w.Code)
+	}
+}
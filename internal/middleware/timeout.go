@@ -19,3 +19,20 @@ func RequestTimeout(d time.Duration) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequestTimeoutExcept is RequestTimeout for every request except those
+// matched by exempt, such as long-running streaming responses. Exempt
+// requests keep the server's request context, which is still cancelled when
+// the client disconnects.
+func RequestTimeoutExcept(d time.Duration, exempt func(*gin.Context) bool) gin.HandlerFunc {
+	timeout := RequestTimeout(d)
+
+	return func(c *gin.Context) {
+		if exempt(c) {
+			c.Next()
+			return
+		}
+
+		timeout(c)
+	}
+}
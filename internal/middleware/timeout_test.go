@@ -0,0 +1,39 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/middleware"
+)
+
+func TestRequestTimeoutExcept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware.RequestTimeoutExcept(time.Minute, func(c *gin.Context) bool {
+		return c.Query("stream") == "true"
+	}))
+	r.GET("/export", func(c *gin.Context) {
+		if _, ok := c.Request.Context().Deadline(); ok {
+			c.String(http.StatusOK, "deadline")
+			return
+		}
+
+		c.String(http.StatusOK, "none")
+	})
+
+	for query, want := range map[string]string{"": "deadline", "?stream=true": "none"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/export"+query, nil)
+		r.ServeHTTP(w, req)
+
+		if got := w.Body.String(); got != want {
+			t.Errorf("GET /export%s: deadline = %s, want %s", query, got, want)
+		}
+	}
+}
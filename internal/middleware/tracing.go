@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// TraceParentHeader is the W3C trace context header a caller may send to
+// make the request's span part of its own trace.
+const TraceParentHeader = "traceparent"
+
+// Tracing records a server span for each request, named after the route
+// pattern, and carries it in the request context so handlers, services, and
+// queries record child spans. The tenant is added once authentication has
+// run.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !tracing.Enabled() {
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unknown"
+		}
+
+		ctx, span := tracing.StartServer(c.Request.Context(), c.Request.Method+" "+route, c.GetHeader(TraceParentHeader),
+			tracing.String("http.request.method", c.Request.Method),
+			tracing.String("http.route", route),
+			tracing.String("request_id", c.GetString(RequestIDKey)),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(tracing.Int("http.response.status_code", status))
+
+		if tenantID := c.GetString("tenant_id"); tenantID != "" {
+			span.SetAttributes(tracing.String("tenant_id", tenantID))
+		}
+
+		if status >= http.StatusInternalServerError {
+			span.RecordError(errors.New(http.StatusText(status)))
+		}
+	}
+}
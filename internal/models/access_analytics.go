@@ -0,0 +1,107 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AccessKind is what an access analytics counter counts.
+type AccessKind string
+
+// Access kinds. The key of a read or traversal is a node ID; the key of a
+// search is HashSearchQuery of the query.
+const (
+	AccessRead     AccessKind = "read"
+	AccessSearch   AccessKind = "search"
+	AccessTraverse AccessKind = "traverse"
+)
+
+// Access analytics defaults and bounds. Counters older than
+// MaxAccessAnalyticsDays are pruned.
+const (
+	DefaultAccessAnalyticsDays  = 7
+	MaxAccessAnalyticsDays      = 90
+	DefaultAccessAnalyticsLimit = 10
+	MaxAccessAnalyticsLimit     = 100
+)
+
+// AccessCount is how often key of kind was accessed since the last flush.
+type AccessCount struct {
+	Kind  AccessKind
+	Key   string
+	Count int64
+}
+
+// NodeAccess is how often a node was read since the last flush and when it
+// was last read.
+type NodeAccess struct {
+	NodeID       string
+	Count        int64
+	LastAccessed time.Time
+}
+
+// AccessAnalyticsRequest selects the window and length of each ranking of
+// an access analytics report. Zero values use the defaults.
+type AccessAnalyticsRequest struct {
+	Days  int
+	Limit int
+}
+
+// Validate checks the bounds and applies defaults.
+func (r *AccessAnalyticsRequest) Validate() error {
+	if r.Days == 0 {
+		r.Days = DefaultAccessAnalyticsDays
+	}
+
+	if r.Limit == 0 {
+		r.Limit = DefaultAccessAnalyticsLimit
+	}
+
+	if r.Days < 1 || r.Days > MaxAccessAnalyticsDays {
+		return fmt.Errorf("days must be between 1 and %d", MaxAccessAnalyticsDays)
+	}
+
+	if r.Limit < 1 || r.Limit > MaxAccessAnalyticsLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxAccessAnalyticsLimit)
+	}
+
+	return nil
+}
+
+// AccessedNode is a node ranked by access count. Type and label are empty
+// when the node has since been deleted.
+type AccessedNode struct {
+	NodeID string `json:"node_id"`
+	Type   string `json:"type,omitempty"`
+	Label  string `json:"label,omitempty"`
+	Count  int64  `json:"count"`
+}
+
+// SearchQueryCount is a search query, identified by its hash, ranked by how
+// often it was run.
+type SearchQueryCount struct {
+	QueryHash string `json:"query_hash"`
+	Count     int64  `json:"count"`
+}
+
+// AccessAnalytics reports what a tenant's clients accessed most in a window:
+// the nodes read, the searches run, and the nodes traversals started from.
+type AccessAnalytics struct {
+	Since             time.Time          `json:"since"`
+	Until             time.Time          `json:"until"`
+	TopNodes          []AccessedNode     `json:"top_nodes"`
+	TopQueries        []SearchQueryCount `json:"top_queries"`
+	TraversalHotSpots []AccessedNode     `json:"traversal_hot_spots"`
+}
+
+// HashSearchQuery identifies a search query in access analytics without
+// storing it. Queries differing only in case or surrounding whitespace hash
+// alike.
+func HashSearchQuery(query string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(query))))
+
+	return hex.EncodeToString(sum[:])
+}
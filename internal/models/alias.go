@@ -40,13 +40,17 @@ type AliasListOpts struct {
 	Offset          int
 }
 
-// Validate checks CreateAliasRequest fields and fills defaults.
+// Validate checks CreateAliasRequest fields and fills defaults. The alias
+// and its type are normalized to NFC.
 func (r *CreateAliasRequest) Validate() error {
+	r.Alias = NormalizeText(r.Alias)
+	r.AliasType = NormalizeText(r.AliasType)
+
 	if r.NodeID == "" {
 		return fmt.Errorf("node_id: %w", ErrMissingID)
 	}
 
-	if len(r.NodeID) > 255 {
+	if tooLong(r.NodeID, 255) {
 		return ErrFieldTooLong("node_id", 255)
 	}
 
@@ -54,15 +58,15 @@ func (r *CreateAliasRequest) Validate() error {
 		return fmt.Errorf("alias is required")
 	}
 
-	if len(r.Alias) > 1000 {
+	if tooLong(r.Alias, 1000) {
 		return ErrFieldTooLong("alias", 1000)
 	}
 
-	if len(r.AliasType) > 100 {
+	if tooLong(r.AliasType, 100) {
 		return ErrFieldTooLong("alias_type", 100)
 	}
 
-	if len(r.Source) > 255 {
+	if tooLong(r.Source, 255) {
 		return ErrFieldTooLong("source", 255)
 	}
 
@@ -80,7 +84,7 @@ func (r *CreateAliasRequest) Validate() error {
 
 // NormalizeAlias converts aliases into a deterministic, index-friendly form.
 func NormalizeAlias(alias string) string {
-	alias = strings.ToLower(strings.TrimSpace(alias))
+	alias = strings.ToLower(strings.TrimSpace(NormalizeText(alias)))
 	if alias == "" {
 		return ""
 	}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// API key rotation grace period limits, in hours.
+const (
+	DefaultAPIKeyGraceHours = 24
+	MaxAPIKeyGraceHours     = 24 * 30
+)
+
+// RotateAPIKeyRequest is the payload for rotating a tenant's API key.
+// GraceHours is how long the current key keeps working alongside the new one;
+// nil uses DefaultAPIKeyGraceHours and 0 revokes the current key immediately.
+type RotateAPIKeyRequest struct {
+	GraceHours *int `json:"grace_hours,omitempty"`
+}
+
+// Validate checks the grace period is within bounds.
+func (r *RotateAPIKeyRequest) Validate() error {
+	if r.GraceHours != nil && (*r.GraceHours < 0 || *r.GraceHours > MaxAPIKeyGraceHours) {
+		return fmt.Errorf("grace_hours must be between 0 and %d", MaxAPIKeyGraceHours)
+	}
+
+	return nil
+}
+
+// Grace returns the requested grace period, applying the default.
+func (r *RotateAPIKeyRequest) Grace() time.Duration {
+	if r.GraceHours == nil {
+		return DefaultAPIKeyGraceHours * time.Hour
+	}
+
+	return time.Duration(*r.GraceHours) * time.Hour
+}
+
+// APIKeyStatus describes a tenant's API key without revealing it.
+// PreviousKeyExpiresAt is set while a rotated-out key is still accepted, and
+// SigningEnabledAt while every request must carry an HMAC signature.
+type APIKeyStatus struct {
+	Scope                string     `json:"scope"`
+	RotatedAt            *time.Time `json:"rotated_at,omitempty"`
+	PreviousKeyExpiresAt *time.Time `json:"previous_key_expires_at,omitempty"`
+	SigningRequired      bool       `json:"signing_required"`
+	SigningEnabledAt     *time.Time `json:"signing_enabled_at,omitempty"`
+}
+
+// APIKeyRotation is returned once when a key is rotated. The new key is not
+// stored in plaintext and cannot be retrieved again.
+type APIKeyRotation struct {
+	APIKey string `json:"api_key"`
+	APIKeyStatus
+}
+
+// RequestSigningSecret is returned once when request signing is enabled or its
+// secret is rotated. The secret cannot be retrieved again.
+type RequestSigningSecret struct {
+	SigningSecret string `json:"signing_secret"`
+	APIKeyStatus
+}
+
+// MaxAPIKeyNameLength is the longest name a managed API key may have.
+const MaxAPIKeyNameLength = 100
+
+// API key scopes, from least to most privileged. Search keys can only
+// search; read keys can also read nodes, edges, and the graph; read_write
+// keys can also write; admin keys can also manage keys, delete data, and run
+// admin operations.
+const (
+	APIKeyScopeSearch    = "search"
+	APIKeyScopeRead      = "read"
+	APIKeyScopeReadWrite = "read_write"
+	APIKeyScopeAdmin     = "admin"
+)
+
+// ValidAPIKeyScope reports whether scope is one of the API key scopes.
+func ValidAPIKeyScope(scope string) bool {
+	switch scope {
+	case APIKeyScopeSearch, APIKeyScopeRead, APIKeyScopeReadWrite, APIKeyScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Managed API key errors.
+var (
+	// ErrAPIKeyNotFound is returned when a managed API key does not exist or
+	// belongs to another tenant.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrAPIKeyInactive is returned when rotating a revoked or expired key.
+	ErrAPIKeyInactive = errors.New("api key is revoked or expired")
+)
+
+// ManagedAPIKey is a named API key a tenant holds alongside its primary key.
+// The key itself is never stored; only its hash is. ImpersonatedBy is the
+// operator tenant that issued an impersonation key.
+type ManagedAPIKey struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Scope          string     `json:"scope"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	ImpersonatedBy *string    `json:"impersonated_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Active reports whether the key is still accepted at now.
+func (k *ManagedAPIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+
+// CreateAPIKeyRequest is the payload for creating a managed API key. Scope
+// defaults to read_write; a nil ExpiresAt never expires.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Validate checks the name, scope, and expiry, applying the default scope.
+func (r *CreateAPIKeyRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if tooLong(r.Name, MaxAPIKeyNameLength) {
+		return ErrFieldTooLong("name", MaxAPIKeyNameLength)
+	}
+
+	if r.Scope == "" {
+		r.Scope = APIKeyScopeReadWrite
+	}
+
+	if !ValidAPIKeyScope(r.Scope) {
+		return fmt.Errorf("scope must be one of %s, %s, %s, %s",
+			APIKeyScopeSearch, APIKeyScopeRead, APIKeyScopeReadWrite, APIKeyScopeAdmin)
+	}
+
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+
+	return nil
+}
+
+// CreatedAPIKey is returned once when a managed key is created or rotated.
+// The key cannot be retrieved again.
+type CreatedAPIKey struct {
+	APIKey string `json:"api_key"`
+	ManagedAPIKey
+}
+
+// RotatedAPIKey is returned when a managed key is rotated: the replacement
+// key, and the old key with the expiry its grace period gave it.
+type RotatedAPIKey struct {
+	CreatedAPIKey
+	Previous ManagedAPIKey `json:"previous"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxArchiveIdleDays bounds ArchivePolicy.IdleDays.
+const MaxArchiveIdleDays = 36500
+
+// ErrNodeNotArchived indicates a restore of a node that is not in the archive.
+var ErrNodeNotArchived = errors.New("node is not archived")
+
+// ArchivePolicy is a tenant's forgetting policy. Nodes whose salience is
+// below SalienceBelow and that nobody has read or updated for IdleDays are
+// archived, with their edges, out of search and traversal. Boosted nodes are
+// never archived. Both fields nil disables archival.
+type ArchivePolicy struct {
+	SalienceBelow *float64 `json:"salience_below"`
+	IdleDays      *int     `json:"idle_days"`
+}
+
+// Enabled reports whether the policy archives anything.
+func (p *ArchivePolicy) Enabled() bool {
+	return p.SalienceBelow != nil && p.IdleDays != nil
+}
+
+// Validate checks the fields are set together and within bounds.
+func (p *ArchivePolicy) Validate() error {
+	if (p.SalienceBelow == nil) != (p.IdleDays == nil) {
+		return errors.New("salience_below and idle_days must be set together")
+	}
+
+	if p.SalienceBelow != nil && *p.SalienceBelow <= 0 {
+		return errors.New("salience_below must be greater than 0")
+	}
+
+	if p.IdleDays != nil && (*p.IdleDays < 1 || *p.IdleDays > MaxArchiveIdleDays) {
+		return fmt.Errorf("idle_days must be between 1 and %d", MaxArchiveIdleDays)
+	}
+
+	return nil
+}
+
+// TenantArchivePolicy pairs a tenant with its archive policy.
+type TenantArchivePolicy struct {
+	TenantID string
+	ArchivePolicy
+}
+
+// ArchiveResult reports what one archival run moved to the archive.
+type ArchiveResult struct {
+	Nodes int `json:"nodes"`
+	Edges int `json:"edges"`
+}
+
+// ArchivedNode is a node in the archive. Its properties stay in the archive
+// until it is restored.
+type ArchivedNode struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	Label        string     `json:"label"`
+	Salience     float64    `json:"salience_score"`
+	AccessCount  int        `json:"access_count"`
+	LastAccessed *time.Time `json:"last_accessed,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	ArchivedAt   time.Time  `json:"archived_at"`
+}
+
+// RestoreResult is a node moved back from the archive, with the number of
+// its archived edges restored alongside it. Edges to nodes still archived
+// stay in the archive.
+type RestoreResult struct {
+	Node          *Node `json:"node"`
+	EdgesRestored int   `json:"edges_restored"`
+}
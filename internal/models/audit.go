@@ -22,4 +22,7 @@ type AuditQueryOpts struct {
 	Since      *time.Time
 	Limit      int
 	Offset     int
+	// After continues a query after the last entry of a previous page;
+	// Offset is ignored when it is set.
+	After *AuditCursor
 }
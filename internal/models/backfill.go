@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Backfill job statuses.
+const (
+	BackfillRunning   = "running"
+	BackfillCompleted = "completed"
+	BackfillCancelled = "cancelled"
+)
+
+// BackfillJob reports the progress of an embedding backfill. Queued jobs are
+// either processed, failed, or skipped because the backfill was cancelled;
+// dropped jobs never made it onto a full queue.
+type BackfillJob struct {
+	ID         string     `json:"job_id"`
+	TenantID   string     `json:"-"`
+	Status     string     `json:"status"`
+	Queued     int        `json:"queued"`
+	Dropped    int        `json:"dropped"`
+	Processed  int        `json:"processed"`
+	Failed     int        `json:"failed"`
+	Skipped    int        `json:"skipped"`
+	Remaining  int        `json:"remaining"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
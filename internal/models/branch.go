@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBranchNotFound is returned when a branch does not exist.
+var ErrBranchNotFound = errors.New("branch not found")
+
+// Staged change operations, relative to the live graph when the change was
+// first staged.
+const (
+	BranchOpCreate = "create"
+	BranchOpUpdate = "update"
+	BranchOpDelete = "delete"
+)
+
+// Branch merge conflict reasons.
+const (
+	// BranchConflictChanged means the live node or edge was created, updated,
+	// or deleted after the branch staged its own version.
+	BranchConflictChanged = "changed"
+	// BranchConflictMissingEndpoint means a staged edge's source or target
+	// would not exist once the branch is merged.
+	BranchConflictMissingEndpoint = "missing_endpoint"
+)
+
+// Branch is a copy-on-write view of the tenant graph. Writes made on a
+// branch are staged without touching the live graph; reads on the branch see
+// the staged versions over the live ones. Merging applies every staged
+// change in one transaction, and discarding drops them.
+type Branch struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	NodeChanges int       `json:"node_changes"`
+	EdgeChanges int       `json:"edge_changes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateBranchRequest is the payload for creating a branch.
+type CreateBranchRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Validate checks the branch name length.
+func (r *CreateBranchRequest) Validate() error {
+	if tooLong(r.Name, 255) {
+		return ErrFieldTooLong("name", 255)
+	}
+
+	return nil
+}
+
+// BranchChanges lists the nodes and edges a branch has staged.
+type BranchChanges struct {
+	Nodes []BranchNodeChange `json:"nodes"`
+	Edges []BranchEdgeChange `json:"edges"`
+}
+
+// BranchNodeChange is a node staged on a branch. Node is the staged version
+// and is omitted for deletes.
+type BranchNodeChange struct {
+	ID   string `json:"id"`
+	Op   string `json:"op"`
+	Node *Node  `json:"node,omitempty"`
+}
+
+// BranchEdgeChange is an edge staged on a branch. Edge is the staged version
+// and is omitted for deletes.
+type BranchEdgeChange struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+	Op       string `json:"op"`
+	Edge     *Edge  `json:"edge,omitempty"`
+}
+
+// MergeBranchRequest is the payload for merging a branch. Force applies
+// staged changes over live changes made since they were staged; it cannot
+// merge edges whose endpoints would be missing.
+type MergeBranchRequest struct {
+	Force bool `json:"force"`
+}
+
+// BranchConflict is a staged change that cannot be merged as is. NodeID is
+// set for nodes; Source, Target, and Relation for edges.
+type BranchConflict struct {
+	NodeID   string `json:"node_id,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Target   string `json:"target,omitempty"`
+	Relation string `json:"relation,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// String describes the conflicting node or edge.
+func (c BranchConflict) String() string {
+	if c.NodeID != "" {
+		return fmt.Sprintf("node %s: %s", c.NodeID, c.Reason)
+	}
+
+	return fmt.Sprintf("edge %s -%s-> %s: %s", c.Source, c.Relation, c.Target, c.Reason)
+}
+
+// MergeBranchResult reports a branch merge. When Merged is false nothing was
+// written and the branch is kept; Conflicts says why. A merged branch is
+// deleted.
+type MergeBranchResult struct {
+	Merged       bool             `json:"merged"`
+	NodesWritten int              `json:"nodes_written"`
+	NodesDeleted int              `json:"nodes_deleted"`
+	EdgesWritten int              `json:"edges_written"`
+	EdgesDeleted int              `json:"edges_deleted"`
+	Conflicts    []BranchConflict `json:"conflicts,omitempty"`
+
+	// Nodes holds the written nodes so the service can re-embed them.
+	Nodes []Node `json:"-"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxBroadcastMessageLen caps the length of an operator broadcast message.
+const MaxBroadcastMessageLen = 1000
+
+// Broadcast severity levels.
+const (
+	BroadcastLevelInfo     = "info"
+	BroadcastLevelWarning  = "warning"
+	BroadcastLevelCritical = "critical"
+)
+
+// BroadcastRequest is an operator message pushed to connected WebSocket
+// clients, e.g. a maintenance notice. An empty TenantID targets every tenant.
+type BroadcastRequest struct {
+	Message  string `json:"message"`
+	Level    string `json:"level,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// BroadcastResult acknowledges a queued broadcast. Target is the tenant ID,
+// or "all" for every tenant.
+type BroadcastResult struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+}
+
+// Validate checks the message, defaults the level to info, and checks that
+// tenant_id, when given, is a UUID.
+func (r *BroadcastRequest) Validate() error {
+	if r.Message == "" {
+		return fmt.Errorf("message is required")
+	}
+
+	if utf8.RuneCountInString(r.Message) > MaxBroadcastMessageLen {
+		return ErrFieldTooLong("message", MaxBroadcastMessageLen)
+	}
+
+	switch r.Level {
+	case "":
+		r.Level = BroadcastLevelInfo
+	case BroadcastLevelInfo, BroadcastLevelWarning, BroadcastLevelCritical:
+	default:
+		return fmt.Errorf("level must be one of %s, %s, %s", BroadcastLevelInfo, BroadcastLevelWarning, BroadcastLevelCritical)
+	}
+
+	if r.TenantID != "" {
+		if _, err := uuid.Parse(r.TenantID); err != nil {
+			return fmt.Errorf("tenant_id must be a UUID")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+// Capability names reported by the capabilities endpoint.
+//
+// CapabilityNamespaces is reserved and always reported as disabled: nodes
+// and edges carry no namespace yet, so per-namespace policies (TTL,
+// retention, export inclusion, salience) wait on namespaces themselves.
+// Tenant-wide history retention and archive policies apply meanwhile.
+const (
+	CapabilityEmbeddings        = "embeddings"
+	CapabilityGraphQL           = "graphql"
+	CapabilityGraphQLPlayground = "graphql_playground"
+	CapabilityWebSocket         = "websocket"
+	CapabilityWebhooks          = "webhooks"
+	CapabilityNamespaces        = "namespaces"
+	CapabilitySoftDelete        = "soft_delete"
+	CapabilityWatchLists        = "watch_lists"
+	CapabilityMetapaths         = "metapaths"
+	CapabilitySnapshots         = "snapshots"
+)
+
+// Capabilities reports which optional subsystems the server has enabled so
+// clients can adapt instead of probing endpoints and hitting 404s.
+type Capabilities struct {
+	Version  string          `json:"version"`
+	Features map[string]bool `json:"features"`
+}
+
+// Has reports whether the named capability is enabled. Capabilities the
+// server does not know about are reported as disabled.
+func (c *Capabilities) Has(name string) bool {
+	return c != nil && c.Features[name]
+}
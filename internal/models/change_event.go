@@ -0,0 +1,23 @@
+package models
+
+// ChangeDetail describes what a single write changed. It rides along with
+// change-feed notifications and is only delivered to WebSocket clients that
+// subscribe in verbose mode. Properties are encrypted at rest, so only the
+// changed key names are included; plaintext fields carry old and new values.
+type ChangeDetail struct {
+	NodeID     string                 `json:"node_id,omitempty"`
+	Source     string                 `json:"source,omitempty"`
+	Target     string                 `json:"target,omitempty"`
+	Relation   string                 `json:"relation,omitempty"`
+	Fields     map[string]FieldChange `json:"fields,omitempty"`
+	Properties []string               `json:"properties,omitempty"`
+	// Truncated is set when the detail was too large to send; consumers
+	// should refetch the entity instead.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// FieldChange is the old and new value of a plaintext field.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
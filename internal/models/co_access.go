@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// CoAccessRelation is the relation of edges inferred from co-access.
+const CoAccessRelation = "related_to"
+
+// Co-access recording limits.
+const (
+	// MaxAccessSessionIDLength is the longest client-supplied session ID.
+	MaxAccessSessionIDLength = 255
+	// MaxCoAccessNodesPerSearch is how many of a search's top results are
+	// recorded as accessed together.
+	MaxCoAccessNodesPerSearch = 10
+)
+
+// Co-access inference defaults and bounds.
+const (
+	DefaultCoAccessHalfLifeDays = 14
+	DefaultCoAccessMinScore     = 3.0
+	DefaultCoAccessWindowDays   = 90
+	DefaultCoAccessMaxEdges     = 1000
+	MaxCoAccessWindowDays       = 365
+	MaxCoAccessMaxEdges         = 10000
+	// MaxCoAccessEdgeWeight caps inferred edge weight so co-access never
+	// outweighs an edge someone asserted.
+	MaxCoAccessEdgeWeight = 0.5
+)
+
+// ErrInvalidAccessSession is returned for an empty or over-long session ID.
+var ErrInvalidAccessSession = fmt.Errorf("session must be 1 to %d characters", MaxAccessSessionIDLength)
+
+// CoAccessInferenceRequest tunes a co-access inference run. Each session in
+// which two nodes were accessed together adds 0.5^(age/half_life) to the
+// pair's score; pairs scoring at least MinScore get a related_to edge.
+// Zero values use the defaults.
+type CoAccessInferenceRequest struct {
+	HalfLifeDays float64 `json:"half_life_days,omitempty"`
+	MinScore     float64 `json:"min_score,omitempty"`
+	WindowDays   int     `json:"window_days,omitempty"`
+	MaxEdges     int     `json:"max_edges,omitempty"`
+}
+
+// Validate checks the bounds and applies defaults.
+func (r *CoAccessInferenceRequest) Validate() error {
+	if r.HalfLifeDays < 0 || r.MinScore < 0 || r.WindowDays < 0 || r.MaxEdges < 0 {
+		return errors.New("half_life_days, min_score, window_days, and max_edges must not be negative")
+	}
+
+	if r.HalfLifeDays == 0 {
+		r.HalfLifeDays = DefaultCoAccessHalfLifeDays
+	}
+
+	if r.MinScore == 0 {
+		r.MinScore = DefaultCoAccessMinScore
+	}
+
+	if r.WindowDays == 0 {
+		r.WindowDays = DefaultCoAccessWindowDays
+	}
+
+	if r.MaxEdges == 0 {
+		r.MaxEdges = DefaultCoAccessMaxEdges
+	}
+
+	if r.WindowDays > MaxCoAccessWindowDays {
+		return fmt.Errorf("window_days must be at most %d", MaxCoAccessWindowDays)
+	}
+
+	if r.MaxEdges > MaxCoAccessMaxEdges {
+		return fmt.Errorf("max_edges must be at most %d", MaxCoAccessMaxEdges)
+	}
+
+	return nil
+}
+
+// CoAccessEdgeWeight maps a pair's co-access score to an edge weight. The
+// weight reaches about 0.63 of MaxCoAccessEdgeWeight at minScore and
+// approaches it as the score grows.
+func CoAccessEdgeWeight(score, minScore float64) float64 {
+	if minScore <= 0 {
+		return MaxCoAccessEdgeWeight
+	}
+
+	return MaxCoAccessEdgeWeight * (1 - math.Exp(-score/minScore))
+}
+
+// CoAccessInferenceResult summarizes a co-access inference run. Removed
+// counts inferred edges whose score decayed below the threshold.
+type CoAccessInferenceResult struct {
+	Pairs   int `json:"pairs"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+	Pruned  int `json:"pruned_accesses"`
+}
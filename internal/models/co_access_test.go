@@ -0,0 +1,38 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoAccessInferenceRequestValidate(t *testing.T) {
+	var req CoAccessInferenceRequest
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if req.HalfLifeDays != DefaultCoAccessHalfLifeDays || req.MinScore != DefaultCoAccessMinScore ||
+		req.WindowDays != DefaultCoAccessWindowDays || req.MaxEdges != DefaultCoAccessMaxEdges {
+		t.Errorf("defaults not applied: %+v", req)
+	}
+
+	for _, bad := range []CoAccessInferenceRequest{
+		{MinScore: -1},
+		{WindowDays: MaxCoAccessWindowDays + 1},
+		{MaxEdges: MaxCoAccessMaxEdges + 1},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", bad)
+		}
+	}
+}
+
+func TestCoAccessEdgeWeight(t *testing.T) {
+	atThreshold := CoAccessEdgeWeight(3, 3)
+	if want := MaxCoAccessEdgeWeight * (1 - math.Exp(-1)); math.Abs(atThreshold-want) > 1e-9 {
+		t.Errorf("weight at threshold = %v, want %v", atThreshold, want)
+	}
+
+	if heavy := CoAccessEdgeWeight(300, 3); heavy <= atThreshold || heavy > MaxCoAccessEdgeWeight {
+		t.Errorf("weight for a frequent pair = %v, want in (%v, %v]", heavy, atThreshold, MaxCoAccessEdgeWeight)
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "fmt"
+
+// Community detection limits.
+const (
+	DefaultCommunityIterations = 20
+	MaxCommunityIterations     = 100
+	DefaultCommunityMinSize    = 2
+)
+
+// CommunityRequest configures a label-propagation run over a tenant's graph.
+type CommunityRequest struct {
+	MaxIterations int    `json:"max_iterations,omitempty"`
+	MinSize       int    `json:"min_size,omitempty"`
+	Relation      string `json:"relation,omitempty"`
+}
+
+// Validate checks bounds and fills in defaults.
+func (r *CommunityRequest) Validate() error {
+	if r.MaxIterations < 0 || r.MaxIterations > MaxCommunityIterations {
+		return fmt.Errorf("max_iterations must be between 0 and %d", MaxCommunityIterations)
+	}
+
+	if r.MaxIterations == 0 {
+		r.MaxIterations = DefaultCommunityIterations
+	}
+
+	if r.MinSize < 0 {
+		return fmt.Errorf("min_size must not be negative")
+	}
+
+	if r.MinSize == 0 {
+		r.MinSize = DefaultCommunityMinSize
+	}
+
+	if tooLong(r.Relation, 255) {
+		return ErrFieldTooLong("relation", 255)
+	}
+
+	return nil
+}
+
+// Community is a cluster of densely connected nodes. Label is taken from the
+// most salient member so the cluster can be named in prompts.
+type Community struct {
+	ID      int      `json:"id"`
+	Label   string   `json:"label"`
+	Size    int      `json:"size"`
+	NodeIDs []string `json:"node_ids"`
+}
+
+// CommunityResult holds the detected communities and run statistics.
+type CommunityResult struct {
+	Communities []Community `json:"communities"`
+	NodeCount   int         `json:"node_count"`
+	EdgeCount   int         `json:"edge_count"`
+	Iterations  int         `json:"iterations"`
+	Converged   bool        `json:"converged"`
+	Truncated   bool        `json:"truncated"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// Duplicate detection scoring. A pair's score is a weighted mean of label
+// trigram similarity and embedding cosine similarity; when either node has no
+// embedding the score is the label similarity alone.
+const (
+	DuplicateLabelWeight     = 0.4
+	DuplicateEmbeddingWeight = 0.6
+	DefaultDuplicateMinScore = 0.7
+)
+
+// DuplicateListOpts controls duplicate-node detection.
+type DuplicateListOpts struct {
+	Type     string  `json:"type,omitempty"`
+	Limit    int     `json:"limit,omitempty"`
+	MinScore float64 `json:"min_score,omitempty"`
+}
+
+// DuplicatePair is a probable duplicate. Duplicate is the node to merge into
+// Canonical, which is the one with the higher salience.
+type DuplicatePair struct {
+	Canonical           MergeSuggestionNode `json:"canonical"`
+	Duplicate           MergeSuggestionNode `json:"duplicate"`
+	Score               float64             `json:"score"`
+	LabelSimilarity     float64             `json:"label_similarity"`
+	EmbeddingSimilarity *float64            `json:"embedding_similarity,omitempty"`
+}
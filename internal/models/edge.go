@@ -46,12 +46,15 @@ type CreateEdgeRequest struct {
 }
 
 // Validate checks that required fields are present and within limits on CreateEdgeRequest.
+// The relation is normalized to NFC.
 func (r *CreateEdgeRequest) Validate() error {
+	r.Relation = NormalizeText(r.Relation)
+
 	if r.Source == "" {
 		return ErrMissingSource
 	}
 
-	if len(r.Source) > 255 {
+	if tooLong(r.Source, 255) {
 		return ErrFieldTooLong("source", 255)
 	}
 
@@ -59,7 +62,7 @@ func (r *CreateEdgeRequest) Validate() error {
 		return ErrMissingTarget
 	}
 
-	if len(r.Target) > 255 {
+	if tooLong(r.Target, 255) {
 		return ErrFieldTooLong("target", 255)
 	}
 
@@ -67,7 +70,7 @@ func (r *CreateEdgeRequest) Validate() error {
 		return ErrMissingRelation
 	}
 
-	if len(r.Relation) > 255 {
+	if tooLong(r.Relation, 255) {
 		return ErrFieldTooLong("relation", 255)
 	}
 
@@ -76,7 +79,7 @@ func (r *CreateEdgeRequest) Validate() error {
 	}
 
 	if r.Properties != nil {
-		if err := validatePropertiesSize(r.Properties); err != nil {
+		if err := validateProperties(r.Properties); err != nil {
 			return err
 		}
 	}
@@ -108,7 +111,7 @@ func (r *UpdateEdgeRequest) Validate() error {
 	}
 
 	if r.Properties != nil {
-		if err := validatePropertiesSize(r.Properties); err != nil {
+		if err := validateProperties(r.Properties); err != nil {
 			return err
 		}
 	}
@@ -124,8 +127,9 @@ func (r *UpdateEdgeRequest) Validate() error {
 	return nil
 }
 
-// validatePropertiesSize checks that the JSON-encoded properties fit within the limit.
-func validatePropertiesSize(props map[string]any) error {
+// validateProperties checks that the JSON-encoded properties fit within the
+// limit and that any typed values are well formed.
+func validateProperties(props map[string]any) error {
 	data, err := json.Marshal(props)
 	if err != nil {
 		return fmt.Errorf("invalid properties: %w", err)
@@ -133,7 +137,7 @@ func validatePropertiesSize(props map[string]any) error {
 	if len(data) > 65536 {
 		return ErrFieldTooLong("properties", 65536)
 	}
-	return nil
+	return ValidateTypedValues(props)
 }
 
 // validateEDTFDate returns an error if the date string is non-nil and not valid EDTF.
@@ -0,0 +1,61 @@
+package models
+
+import "fmt"
+
+// MaxEdgeExistsKeys is the most edges one existence check accepts.
+const MaxEdgeExistsKeys = 1000
+
+// EdgeKey identifies an edge by its endpoints and relation.
+type EdgeKey struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+}
+
+// EdgeExistsRequest is the payload for checking which of a batch of edges
+// exist.
+type EdgeExistsRequest struct {
+	Edges []EdgeKey `json:"edges"`
+}
+
+// Validate checks the batch size and each key's fields. Relations are
+// normalized to NFC, as they are when an edge is created.
+func (r *EdgeExistsRequest) Validate() error {
+	if len(r.Edges) == 0 {
+		return fmt.Errorf("edges must not be empty")
+	}
+
+	if len(r.Edges) > MaxEdgeExistsKeys {
+		return fmt.Errorf("at most %d edges can be checked at once", MaxEdgeExistsKeys)
+	}
+
+	for i := range r.Edges {
+		k := &r.Edges[i]
+		k.Relation = NormalizeText(k.Relation)
+
+		for _, f := range []struct {
+			name, value string
+			missing     error
+		}{
+			{"source", k.Source, ErrMissingSource},
+			{"target", k.Target, ErrMissingTarget},
+			{"relation", k.Relation, ErrMissingRelation},
+		} {
+			if f.value == "" {
+				return fmt.Errorf("edges[%d]: %w", i, f.missing)
+			}
+
+			if tooLong(f.value, 255) {
+				return fmt.Errorf("edges[%d]: %w", i, ErrFieldTooLong(f.name, 255))
+			}
+		}
+	}
+
+	return nil
+}
+
+// EdgeExistence reports whether one checked edge exists.
+type EdgeExistence struct {
+	EdgeKey
+	Exists bool `json:"exists"`
+}
@@ -107,13 +107,14 @@ func (r *CreateEpisodeRequest) Validate() error {
 	if r.ID == "" {
 		r.ID = uuid.New().String()
 	}
-	if len(r.ID) > 255 {
+	if tooLong(r.ID, 255) {
 		return ErrFieldTooLong("id", 255)
 	}
+	r.Title = NormalizeText(r.Title)
 	if r.Title == "" {
 		return fmt.Errorf("title: %w", ErrMissingLabel)
 	}
-	if len(r.Title) > 10000 {
+	if tooLong(r.Title, 10000) {
 		return ErrFieldTooLong("title", 10000)
 	}
 	if r.Status == "" {
@@ -165,16 +166,17 @@ func (r *CreateEventRecordRequest) Validate() error {
 	if r.ID == "" {
 		r.ID = uuid.New().String()
 	}
-	if len(r.ID) > 255 {
+	if tooLong(r.ID, 255) {
 		return ErrFieldTooLong("id", 255)
 	}
 	if _, ok := allowedEventKinds[r.Kind]; !ok {
 		return fmt.Errorf("kind must be one of: observation, conversation, message, decision, task, promise, outcome")
 	}
+	r.Title = NormalizeText(r.Title)
 	if r.Title == "" {
 		return fmt.Errorf("title: %w", ErrMissingLabel)
 	}
-	if len(r.Title) > 10000 {
+	if tooLong(r.Title, 10000) {
 		return ErrFieldTooLong("title", 10000)
 	}
 	confidence := 1.0
@@ -0,0 +1,189 @@
+package models
+
+import (
+	"net/http"
+
+	"golang.org/x/text/language"
+)
+
+// errorDocsURL prefixes the README anchor documenting each error code.
+const errorDocsURL = "https://github.com/persistorai/persistor#error-"
+
+// DefaultErrorLanguage is the language of error messages when the request
+// does not ask for one the catalog has.
+const DefaultErrorLanguage = "en"
+
+// ErrorCatalogEntry documents one API error code.
+type ErrorCatalogEntry struct {
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	DocsURL string `json:"docs_url"`
+}
+
+// ErrorCatalog is every API error code with its message in Language.
+type ErrorCatalog struct {
+	Language  string              `json:"language"`
+	Languages []string            `json:"languages"`
+	Errors    []ErrorCatalogEntry `json:"errors"`
+}
+
+// errorDef is a catalog entry with its message in each supported language.
+type errorDef struct {
+	code     string
+	status   int
+	messages map[string]string
+}
+
+// errorLanguages lists the catalog's languages, DefaultErrorLanguage first
+// so the matcher falls back to it.
+var errorLanguages = []language.Tag{language.English, language.Spanish, language.French, language.German}
+
+var errorLanguageMatcher = language.NewMatcher(errorLanguages)
+
+// errorDefs is the error catalog, in the order GET /errors lists it.
+var errorDefs = []errorDef{
+	{"invalid_request", http.StatusBadRequest, map[string]string{
+		"en": "The request is malformed or has an invalid parameter.",
+		"es": "La solicitud está mal formada o tiene un parámetro no válido.",
+		"fr": "La requête est mal formée ou contient un paramètre invalide.",
+		"de": "Die Anfrage ist fehlerhaft oder enthält einen ungültigen Parameter.",
+	}},
+	{"validation_error", http.StatusBadRequest, map[string]string{
+		"en": "The request body failed validation.",
+		"es": "El cuerpo de la solicitud no superó la validación.",
+		"fr": "Le corps de la requête n'a pas passé la validation.",
+		"de": "Der Anfragetext hat die Validierung nicht bestanden.",
+	}},
+	{"unauthorized", http.StatusUnauthorized, map[string]string{
+		"en": "A valid API key is required.",
+		"es": "Se requiere una clave de API válida.",
+		"fr": "Une clé d'API valide est requise.",
+		"de": "Ein gültiger API-Schlüssel ist erforderlich.",
+	}},
+	{"signature_required", http.StatusUnauthorized, map[string]string{
+		"en": "This tenant requires signed requests.",
+		"es": "Este inquilino requiere solicitudes firmadas.",
+		"fr": "Ce locataire exige des requêtes signées.",
+		"de": "Dieser Mandant erfordert signierte Anfragen.",
+	}},
+	{"invalid_signature", http.StatusUnauthorized, map[string]string{
+		"en": "The request signature is missing, stale, replayed, or wrong.",
+		"es": "La firma de la solicitud falta, caducó, se reutilizó o es incorrecta.",
+		"fr": "La signature de la requête est absente, expirée, rejouée ou incorrecte.",
+		"de": "Die Anfragesignatur fehlt, ist abgelaufen, wurde wiederverwendet oder ist falsch.",
+	}},
+	{"forbidden", http.StatusForbidden, map[string]string{
+		"en": "The API key's scope does not allow this request.",
+		"es": "El alcance de la clave de API no permite esta solicitud.",
+		"fr": "La portée de la clé d'API n'autorise pas cette requête.",
+		"de": "Der Geltungsbereich des API-Schlüssels erlaubt diese Anfrage nicht.",
+	}},
+	{"quota_exceeded", http.StatusForbidden, map[string]string{
+		"en": "The request would exceed the tenant's quota.",
+		"es": "La solicitud superaría la cuota del inquilino.",
+		"fr": "La requête dépasserait le quota du locataire.",
+		"de": "Die Anfrage würde das Kontingent des Mandanten überschreiten.",
+	}},
+	{"not_found", http.StatusNotFound, map[string]string{
+		"en": "The requested resource was not found.",
+		"es": "No se encontró el recurso solicitado.",
+		"fr": "La ressource demandée est introuvable.",
+		"de": "Die angeforderte Ressource wurde nicht gefunden.",
+	}},
+	{"conflict", http.StatusConflict, map[string]string{
+		"en": "The request conflicts with the current state of the resource.",
+		"es": "La solicitud entra en conflicto con el estado actual del recurso.",
+		"fr": "La requête est en conflit avec l'état actuel de la ressource.",
+		"de": "Die Anfrage steht im Konflikt mit dem aktuellen Zustand der Ressource.",
+	}},
+	{"idempotency_key_reused", http.StatusUnprocessableEntity, map[string]string{
+		"en": "The idempotency key was already used for a different request.",
+		"es": "La clave de idempotencia ya se usó para otra solicitud.",
+		"fr": "La clé d'idempotence a déjà été utilisée pour une autre requête.",
+		"de": "Der Idempotenzschlüssel wurde bereits für eine andere Anfrage verwendet.",
+	}},
+	{"rate_limited", http.StatusTooManyRequests, map[string]string{
+		"en": "Too many requests; retry after the Retry-After delay.",
+		"es": "Demasiadas solicitudes; reintente tras el tiempo indicado en Retry-After.",
+		"fr": "Trop de requêtes ; réessayez après le délai Retry-After.",
+		"de": "Zu viele Anfragen; erneut versuchen nach der Retry-After-Wartezeit.",
+	}},
+	{"internal_error", http.StatusInternalServerError, map[string]string{
+		"en": "An internal error occurred; report the request ID if it persists.",
+		"es": "Se produjo un error interno; informe el ID de la solicitud si persiste.",
+		"fr": "Une erreur interne s'est produite ; signalez l'ID de requête si elle persiste.",
+		"de": "Ein interner Fehler ist aufgetreten; melden Sie die Anfrage-ID, falls er bestehen bleibt.",
+	}},
+}
+
+// ErrorLanguage picks the catalog language best matching an Accept-Language
+// header, falling back to DefaultErrorLanguage.
+func ErrorLanguage(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultErrorLanguage
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultErrorLanguage
+	}
+
+	_, idx, confidence := errorLanguageMatcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultErrorLanguage
+	}
+
+	base, _ := errorLanguages[idx].Base()
+
+	return base.String()
+}
+
+// ErrorMessage returns the catalog message for code in lang, and false when
+// the code is not in the catalog.
+func ErrorMessage(code, lang string) (string, bool) {
+	for _, def := range errorDefs {
+		if def.code != code {
+			continue
+		}
+
+		if msg, ok := def.messages[lang]; ok {
+			return msg, true
+		}
+
+		return def.messages[DefaultErrorLanguage], true
+	}
+
+	return "", false
+}
+
+// ErrorDocsURL returns the documentation link for an error code.
+func ErrorDocsURL(code string) string {
+	return errorDocsURL + code
+}
+
+// NewErrorCatalog returns every error code with its message in lang.
+func NewErrorCatalog(lang string) ErrorCatalog {
+	catalog := ErrorCatalog{
+		Language:  lang,
+		Languages: make([]string, 0, len(errorLanguages)),
+		Errors:    make([]ErrorCatalogEntry, 0, len(errorDefs)),
+	}
+
+	for _, tag := range errorLanguages {
+		base, _ := tag.Base()
+		catalog.Languages = append(catalog.Languages, base.String())
+	}
+
+	for _, def := range errorDefs {
+		msg, _ := ErrorMessage(def.code, lang)
+		catalog.Errors = append(catalog.Errors, ErrorCatalogEntry{
+			Code:    def.code,
+			Status:  def.status,
+			Message: msg,
+			DocsURL: ErrorDocsURL(def.code),
+		})
+	}
+
+	return catalog
+}
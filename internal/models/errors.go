@@ -24,9 +24,17 @@
 	ErrUnknownRelationNotFound    = errors.New("unknown relation not found")
 	ErrEpisodeNotFound            = errors.New("episode not found")
 	ErrEventRecordNotFound        = errors.New("event record not found")
+	ErrTenantNotFound             = errors.New("tenant not found")
+	ErrBackfillJobNotFound        = errors.New("backfill job not found")
+	ErrReembedNotFound            = errors.New("no re-embed has been started")
 	ErrEmbeddingWorkerUnavailable = errors.New("embedding worker not available")
 )
 
+// ErrReembedInProgress indicates a re-embed run is moving the tenant's
+// embeddings to another model. A second run cannot start, and vector search
+// is refused because stored vectors come from two models until it finishes.
+var ErrReembedInProgress = errors.New("re-embed in progress")
+
 // ErrDuplicateKey indicates a unique constraint violation (maps to HTTP 409 Conflict).
 var ErrDuplicateKey = errors.New("duplicate key")
 
@@ -34,3 +42,13 @@
 func ErrFieldTooLong(field string, maxLen int) error {
 	return fmt.Errorf("%s exceeds maximum length of %d", field, maxLen)
 }
+
+// MissingNodesError reports the node IDs a batch of edges references that do
+// not exist.
+type MissingNodesError struct {
+	IDs []string
+}
+
+func (e *MissingNodesError) Error() string {
+	return fmt.Sprintf("missing node IDs referenced by edges: %v", e.IDs)
+}
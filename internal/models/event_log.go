@@ -0,0 +1,15 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// LoggedEvent is a change-feed event as kept in the persistent event log.
+type LoggedEvent struct {
+	TenantID string
+	ID       uint64
+	Type     string
+	Data     json.RawMessage
+	Time     time.Time
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Named queries an admin can EXPLAIN.
+const (
+	ExplainFullText = "fulltext"
+	ExplainSemantic = "semantic"
+	ExplainHybrid   = "hybrid"
+	ExplainTraverse = "traverse"
+
+	DefaultExplainLimit = 10
+	MaxExplainLimit     = 100
+)
+
+// ErrEmbeddingUnavailable indicates no query embedding could be produced.
+var ErrEmbeddingUnavailable = errors.New("embedding service not available")
+
+// ExplainRequest names an internal query and the parameters to plan and run
+// it with against the caller's tenant.
+type ExplainRequest struct {
+	Query  string        `json:"query"`
+	Params ExplainParams `json:"params"`
+}
+
+// ExplainParams are the parameters of an explained query. Q is required for
+// fulltext and hybrid, and for semantic unless Embedding is given; NodeID is
+// required for traverse.
+type ExplainParams struct {
+	Q           string    `json:"q,omitempty"`
+	Embedding   []float32 `json:"embedding,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	MinSalience float64   `json:"min_salience,omitempty"`
+	NodeID      string    `json:"node_id,omitempty"`
+	Limit       int       `json:"limit,omitempty"`
+}
+
+// Normalized trims the request and applies the default limit.
+func (r ExplainRequest) Normalized() ExplainRequest {
+	r.Query = normalizeLowerToken(r.Query)
+	r.Params.Q = strings.TrimSpace(r.Params.Q)
+	r.Params.Type = strings.TrimSpace(r.Params.Type)
+	r.Params.NodeID = strings.TrimSpace(r.Params.NodeID)
+
+	if r.Params.Limit <= 0 {
+		r.Params.Limit = DefaultExplainLimit
+	}
+
+	return r
+}
+
+// Validate checks that the named query exists and has the parameters it
+// needs.
+func (r ExplainRequest) Validate() error {
+	p := r.Params
+
+	switch r.Query {
+	case ExplainFullText, ExplainHybrid:
+		if p.Q == "" {
+			return fmt.Errorf("params.q is required for %s", r.Query)
+		}
+	case ExplainSemantic:
+		if p.Q == "" && len(p.Embedding) == 0 {
+			return fmt.Errorf("params.q or params.embedding is required for semantic")
+		}
+	case ExplainTraverse:
+		if p.NodeID == "" {
+			return fmt.Errorf("params.node_id is required for traverse")
+		}
+	default:
+		return fmt.Errorf("query must be fulltext, semantic, hybrid, or traverse")
+	}
+
+	if tooLong(p.Q, 500) {
+		return fmt.Errorf("params.q exceeds 500 characters")
+	}
+
+	if p.Limit > MaxExplainLimit {
+		return fmt.Errorf("params.limit must be at most %d", MaxExplainLimit)
+	}
+
+	if p.MinSalience < 0 {
+		return fmt.Errorf("params.min_salience must not be negative")
+	}
+
+	return nil
+}
+
+// ExplainResult is the plan PostgreSQL chose for a named query, from
+// EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON). The query is really executed, in
+// a read-only transaction that is rolled back.
+type ExplainResult struct {
+	Query           string          `json:"query"`
+	PlanningTimeMs  float64         `json:"planning_time_ms"`
+	ExecutionTimeMs float64         `json:"execution_time_ms"`
+	Plan            json.RawMessage `json:"plan"`
+}
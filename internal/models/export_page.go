@@ -0,0 +1,39 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Chunked export page size limits.
+const (
+	DefaultExportPageSize = 500
+	MaxExportPageSize     = 1000
+)
+
+// ErrInvalidExportCursor is returned when a chunked export cursor cannot be decoded.
+var ErrInvalidExportCursor = errors.New("invalid export cursor")
+
+// ExportManifest describes a chunked export: the same header fields as
+// ExportFormat, without the nodes and edges, which are fetched page by page.
+type ExportManifest struct {
+	SchemaVersion    int         `json:"schema_version"`
+	PersistorVersion string      `json:"persistor_version"`
+	ExportedAt       time.Time   `json:"exported_at"`
+	TenantID         string      `json:"tenant_id"`
+	Stats            ExportStats `json:"stats"`
+}
+
+// ExportNodePage is one page of a chunked node export, ordered by id.
+// NextCursor is empty on the last page.
+type ExportNodePage struct {
+	Nodes      []ExportNode `json:"nodes"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// ExportEdgePage is one page of a chunked edge export, ordered by
+// (source, target, relation). NextCursor is empty on the last page.
+type ExportEdgePage struct {
+	Edges      []ExportEdge `json:"edges"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "errors"
+
+// Streaming export record types, in the order they appear in the stream.
+const (
+	ExportRecordManifest = "manifest"
+	ExportRecordNode     = "node"
+	ExportRecordEdge     = "edge"
+	ExportRecordEnd      = "end"
+)
+
+// ErrIncompleteExport is returned when a streaming export ends without its
+// end record, e.g. because the connection dropped or the server failed
+// part-way through.
+var ErrIncompleteExport = errors.New("export stream ended before its end record")
+
+// ExportRecord is one line of a streaming (NDJSON) export. The stream starts
+// with a manifest record, follows with node records and then edge records,
+// and finishes with an end record whose Stats count what was actually sent.
+// Exactly one payload field is set, matching Type.
+type ExportRecord struct {
+	Type     string          `json:"type"`
+	Manifest *ExportManifest `json:"manifest,omitempty"`
+	Node     *ExportNode     `json:"node,omitempty"`
+	Edge     *ExportEdge     `json:"edge,omitempty"`
+	Stats    *ExportStats    `json:"stats,omitempty"`
+}
@@ -12,6 +12,13 @@ type TraverseResult struct {
 	Edges []Edge `json:"edges"`
 }
 
+// ReadOpts shapes the nodes and edges a graph read returns.
+type ReadOpts struct {
+	// OmitProperties returns nodes and edges with nil properties, skipping
+	// their decryption.
+	OmitProperties bool
+}
+
 // ContextResult holds a node with its immediate neighborhood.
 type ContextResult struct {
 	Node      Node   `json:"node"`
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// AsOfQuery asks for the graph as it was at a point in time.
+type AsOfQuery struct {
+	Timestamp time.Time
+	Type      string // optional node type filter
+	Limit     int
+	Offset    int
+}
+
+// Validate checks that a timestamp was given and the type filter is bounded.
+func (q *AsOfQuery) Validate() error {
+	if q.Timestamp.IsZero() {
+		return errors.New("timestamp is required")
+	}
+
+	if len(q.Type) > 100 {
+		return ErrFieldTooLong("type", 100)
+	}
+
+	return nil
+}
+
+// GraphSnapshot is a page of nodes as they existed at AsOf, with their
+// properties rolled back using the property history, plus the edges among
+// them that existed at that time. Only properties are versioned; other fields
+// carry their current values, and nodes deleted since AsOf are not included.
+type GraphSnapshot struct {
+	AsOf    time.Time `json:"as_of"`
+	Nodes   []Node    `json:"nodes"`
+	Edges   []Edge    `json:"edges"`
+	HasMore bool      `json:"has_more"`
+}
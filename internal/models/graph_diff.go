@@ -0,0 +1,252 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// GraphDiff reports how the right graph differs from the left one. Nodes and
+// edges only on the right are added, those only on the left are removed, and
+// those on both sides with different content are changed. Left and Right
+// name the compared graphs, e.g. tenant IDs or export file names.
+type GraphDiff struct {
+	Left         string         `json:"left"`
+	Right        string         `json:"right"`
+	Stats        GraphDiffStats `json:"stats"`
+	NodesAdded   []ExportNode   `json:"nodes_added"`
+	NodesRemoved []ExportNode   `json:"nodes_removed"`
+	NodesChanged []NodeDiff     `json:"nodes_changed"`
+	EdgesAdded   []ExportEdge   `json:"edges_added"`
+	EdgesRemoved []ExportEdge   `json:"edges_removed"`
+	EdgesChanged []EdgeDiff     `json:"edges_changed"`
+}
+
+// GraphDiffStats counts the entries of a GraphDiff.
+type GraphDiffStats struct {
+	NodesAdded   int `json:"nodes_added"`
+	NodesRemoved int `json:"nodes_removed"`
+	NodesChanged int `json:"nodes_changed"`
+	EdgesAdded   int `json:"edges_added"`
+	EdgesRemoved int `json:"edges_removed"`
+	EdgesChanged int `json:"edges_changed"`
+}
+
+// Empty reports whether the two graphs have the same content.
+func (s GraphDiffStats) Empty() bool {
+	return s == GraphDiffStats{}
+}
+
+// FieldDiff is a field whose value differs between the two sides. Property
+// fields are named "properties.<key>"; the side without the key is null.
+type FieldDiff struct {
+	Field string `json:"field"`
+	Left  any    `json:"left"`
+	Right any    `json:"right"`
+}
+
+// NodeDiff lists the changed fields of a node present on both sides.
+type NodeDiff struct {
+	ID     string      `json:"id"`
+	Fields []FieldDiff `json:"fields"`
+}
+
+// EdgeDiff lists the changed fields of an edge present on both sides.
+type EdgeDiff struct {
+	EdgeKey
+	Fields []FieldDiff `json:"fields"`
+}
+
+// DiffGraphs compares two exports by content: node type, label, properties,
+// and superseded_by, and edge weight and properties. Embeddings, usage
+// metrics, salience, and timestamps are ignored since they change on reads
+// and re-embeds without anything new being learned. Added and removed nodes
+// are reported without their embeddings. Every list is sorted by key.
+func DiffGraphs(left, right *ExportFormat) *GraphDiff {
+	d := &GraphDiff{
+		NodesAdded:   []ExportNode{},
+		NodesRemoved: []ExportNode{},
+		NodesChanged: []NodeDiff{},
+		EdgesAdded:   []ExportEdge{},
+		EdgesRemoved: []ExportEdge{},
+		EdgesChanged: []EdgeDiff{},
+	}
+
+	leftNodes := make(map[string]*ExportNode, len(left.Nodes))
+	for i := range left.Nodes {
+		leftNodes[left.Nodes[i].ID] = &left.Nodes[i]
+	}
+
+	for i := range right.Nodes {
+		r := &right.Nodes[i]
+
+		l, ok := leftNodes[r.ID]
+		if !ok {
+			d.NodesAdded = append(d.NodesAdded, withoutEmbedding(r))
+			continue
+		}
+
+		delete(leftNodes, r.ID)
+
+		if fields := diffNodeFields(l, r); len(fields) > 0 {
+			d.NodesChanged = append(d.NodesChanged, NodeDiff{ID: r.ID, Fields: fields})
+		}
+	}
+
+	for _, l := range leftNodes {
+		d.NodesRemoved = append(d.NodesRemoved, withoutEmbedding(l))
+	}
+
+	leftEdges := make(map[EdgeKey]*ExportEdge, len(left.Edges))
+	for i := range left.Edges {
+		e := &left.Edges[i]
+		leftEdges[EdgeKey{Source: e.Source, Target: e.Target, Relation: e.Relation}] = e
+	}
+
+	for i := range right.Edges {
+		r := &right.Edges[i]
+		key := EdgeKey{Source: r.Source, Target: r.Target, Relation: r.Relation}
+
+		l, ok := leftEdges[key]
+		if !ok {
+			d.EdgesAdded = append(d.EdgesAdded, *r)
+			continue
+		}
+
+		delete(leftEdges, key)
+
+		if fields := diffEdgeFields(l, r); len(fields) > 0 {
+			d.EdgesChanged = append(d.EdgesChanged, EdgeDiff{EdgeKey: key, Fields: fields})
+		}
+	}
+
+	for _, l := range leftEdges {
+		d.EdgesRemoved = append(d.EdgesRemoved, *l)
+	}
+
+	sortGraphDiff(d)
+
+	d.Stats = GraphDiffStats{
+		NodesAdded:   len(d.NodesAdded),
+		NodesRemoved: len(d.NodesRemoved),
+		NodesChanged: len(d.NodesChanged),
+		EdgesAdded:   len(d.EdgesAdded),
+		EdgesRemoved: len(d.EdgesRemoved),
+		EdgesChanged: len(d.EdgesChanged),
+	}
+
+	return d
+}
+
+func withoutEmbedding(n *ExportNode) ExportNode {
+	out := *n
+	out.Embedding = nil
+
+	return out
+}
+
+func diffNodeFields(l, r *ExportNode) []FieldDiff {
+	var fields []FieldDiff
+
+	if l.Type != r.Type {
+		fields = append(fields, FieldDiff{Field: "type", Left: l.Type, Right: r.Type})
+	}
+
+	if l.Label != r.Label {
+		fields = append(fields, FieldDiff{Field: "label", Left: l.Label, Right: r.Label})
+	}
+
+	if !equalStringPtr(l.SupersededBy, r.SupersededBy) {
+		fields = append(fields, FieldDiff{Field: "superseded_by", Left: l.SupersededBy, Right: r.SupersededBy})
+	}
+
+	return append(fields, diffPropertyFields(l.Properties, r.Properties)...)
+}
+
+func diffEdgeFields(l, r *ExportEdge) []FieldDiff {
+	var fields []FieldDiff
+
+	if l.Weight != r.Weight {
+		fields = append(fields, FieldDiff{Field: "weight", Left: l.Weight, Right: r.Weight})
+	}
+
+	return append(fields, diffPropertyFields(l.Properties, r.Properties)...)
+}
+
+// diffPropertyFields compares property values by their JSON encoding, so
+// values decoded into different Go types compare by content.
+func diffPropertyFields(l, r map[string]any) []FieldDiff {
+	keys := make([]string, 0, len(l)+len(r))
+	for k := range l {
+		keys = append(keys, k)
+	}
+
+	for k := range r {
+		if _, ok := l[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var fields []FieldDiff
+
+	for _, k := range keys {
+		lv, lok := l[k]
+		rv, rok := r[k]
+
+		if lok && rok && equalJSON(lv, rv) {
+			continue
+		}
+
+		fields = append(fields, FieldDiff{Field: "properties." + k, Left: lv, Right: rv})
+	}
+
+	return fields
+}
+
+func equalJSON(a, b any) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+
+	return aerr == nil && berr == nil && bytes.Equal(aj, bj)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func sortGraphDiff(d *GraphDiff) {
+	sort.Slice(d.NodesAdded, func(i, j int) bool { return d.NodesAdded[i].ID < d.NodesAdded[j].ID })
+	sort.Slice(d.NodesRemoved, func(i, j int) bool { return d.NodesRemoved[i].ID < d.NodesRemoved[j].ID })
+	sort.Slice(d.NodesChanged, func(i, j int) bool { return d.NodesChanged[i].ID < d.NodesChanged[j].ID })
+
+	edgeLess := func(a, b EdgeKey) bool {
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+
+		return a.Relation < b.Relation
+	}
+	exportKey := func(e *ExportEdge) EdgeKey {
+		return EdgeKey{Source: e.Source, Target: e.Target, Relation: e.Relation}
+	}
+
+	sort.Slice(d.EdgesAdded, func(i, j int) bool {
+		return edgeLess(exportKey(&d.EdgesAdded[i]), exportKey(&d.EdgesAdded[j]))
+	})
+	sort.Slice(d.EdgesRemoved, func(i, j int) bool {
+		return edgeLess(exportKey(&d.EdgesRemoved[i]), exportKey(&d.EdgesRemoved[j]))
+	})
+	sort.Slice(d.EdgesChanged, func(i, j int) bool {
+		return edgeLess(d.EdgesChanged[i].EdgeKey, d.EdgesChanged[j].EdgeKey)
+	})
+}
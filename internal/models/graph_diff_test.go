@@ -0,0 +1,63 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestDiffGraphs(t *testing.T) {
+	left := &models.ExportFormat{
+		Nodes: []models.ExportNode{
+			{ID: "alice", Type: "person", Label: "Alice", Properties: map[string]any{"role": "engineer", "age": float64(30)}},
+			{ID: "bob", Type: "person", Label: "Bob"},
+			{ID: "carol", Type: "person", Label: "Carol", AccessCount: 1},
+		},
+		Edges: []models.ExportEdge{
+			{Source: "alice", Target: "bob", Relation: "knows", Weight: 1},
+			{Source: "alice", Target: "carol", Relation: "knows", Weight: 1},
+		},
+	}
+	right := &models.ExportFormat{
+		Nodes: []models.ExportNode{
+			{ID: "alice", Type: "person", Label: "Alice", Properties: map[string]any{"role": "manager", "age": 30}},
+			{ID: "carol", Type: "person", Label: "Carol", AccessCount: 9, SalienceScore: 2},
+			{ID: "dave", Type: "person", Label: "Dave", Embedding: []float32{0.1, 0.2}},
+		},
+		Edges: []models.ExportEdge{
+			{Source: "alice", Target: "carol", Relation: "knows", Weight: 2},
+			{Source: "alice", Target: "dave", Relation: "knows", Weight: 1},
+		},
+	}
+
+	d := models.DiffGraphs(left, right)
+
+	want := models.GraphDiffStats{NodesAdded: 1, NodesRemoved: 1, NodesChanged: 1, EdgesAdded: 1, EdgesRemoved: 1, EdgesChanged: 1}
+	if d.Stats != want {
+		t.Fatalf("stats = %+v, want %+v", d.Stats, want)
+	}
+
+	if d.NodesAdded[0].ID != "dave" || d.NodesAdded[0].Embedding != nil {
+		t.Errorf("added = %+v, want dave without embedding", d.NodesAdded[0])
+	}
+	if d.NodesRemoved[0].ID != "bob" {
+		t.Errorf("removed = %s, want bob", d.NodesRemoved[0].ID)
+	}
+
+	// age differs only in Go type; usage and salience changes are ignored.
+	changed := d.NodesChanged[0]
+	if changed.ID != "alice" || len(changed.Fields) != 1 || changed.Fields[0].Field != "properties.role" {
+		t.Errorf("changed = %+v, want alice properties.role", changed)
+	}
+
+	if d.EdgesChanged[0].Target != "carol" || d.EdgesChanged[0].Fields[0].Field != "weight" {
+		t.Errorf("edge changed = %+v, want alice->carol weight", d.EdgesChanged[0])
+	}
+	if d.EdgesAdded[0].Target != "dave" || d.EdgesRemoved[0].Target != "bob" {
+		t.Errorf("edges added %+v, removed %+v", d.EdgesAdded, d.EdgesRemoved)
+	}
+
+	if same := models.DiffGraphs(left, left); !same.Stats.Empty() {
+		t.Errorf("self diff = %+v, want empty", same.Stats)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Edge directions relative to the node being summarized.
+const (
+	DirectionOut = "out"
+	DirectionIn  = "in"
+)
+
+// RelationCount is the number of edges of one relation and direction touching a node.
+type RelationCount struct {
+	Relation  string `json:"relation"`
+	Direction string `json:"direction"`
+	Count     int    `json:"count"`
+}
+
+// SummaryNeighbor is a compact view of a neighbor ranked by salience.
+type SummaryNeighbor struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Label     string  `json:"label"`
+	Relation  string  `json:"relation"`
+	Direction string  `json:"direction"`
+	Salience  float64 `json:"salience_score"`
+}
+
+// SummaryChange records a recent property change without its values.
+type SummaryChange struct {
+	PropertyKey string    `json:"property_key"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// GraphSummary is a compact, prompt-friendly description of a node's neighborhood.
+type GraphSummary struct {
+	Node          NodeSummary       `json:"node"`
+	Salience      float64           `json:"salience_score"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	Degree        int               `json:"degree"`
+	Relations     []RelationCount   `json:"relations"`
+	TopNeighbors  []SummaryNeighbor `json:"top_neighbors"`
+	RecentChanges []SummaryChange   `json:"recent_changes"`
+}
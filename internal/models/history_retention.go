@@ -0,0 +1,49 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxHistoryRetentionDays bounds both history retention settings.
+const MaxHistoryRetentionDays = 36500
+
+// HistoryRetention is a tenant's property history retention policy. Changes
+// older than RetentionDays are deleted. Changes older than CompactAfterDays
+// are compacted to the first and last change per key per day. Nil disables
+// the corresponding step.
+type HistoryRetention struct {
+	RetentionDays    *int `json:"retention_days"`
+	CompactAfterDays *int `json:"compact_after_days"`
+}
+
+// Validate checks both periods are within bounds and that compaction starts
+// before retention removes the rows.
+func (r *HistoryRetention) Validate() error {
+	for _, f := range []struct {
+		name string
+		days *int
+	}{{"retention_days", r.RetentionDays}, {"compact_after_days", r.CompactAfterDays}} {
+		if f.days != nil && (*f.days < 1 || *f.days > MaxHistoryRetentionDays) {
+			return fmt.Errorf("%s must be between 1 and %d", f.name, MaxHistoryRetentionDays)
+		}
+	}
+
+	if r.RetentionDays != nil && r.CompactAfterDays != nil && *r.CompactAfterDays >= *r.RetentionDays {
+		return errors.New("compact_after_days must be less than retention_days")
+	}
+
+	return nil
+}
+
+// TenantHistoryRetention pairs a tenant with its retention policy.
+type TenantHistoryRetention struct {
+	TenantID string
+	HistoryRetention
+}
+
+// HistoryPruneResult reports the history rows removed by one pruning run.
+type HistoryPruneResult struct {
+	Deleted   int `json:"deleted"`
+	Compacted int `json:"compacted"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Idempotency key limits.
+const (
+	// IdempotencyKeyTTL is how long the response to a request made with an
+	// Idempotency-Key header is kept for replay.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// IdempotencyLockTTL is how long a key stays claimed by a request that has
+	// not finished, after which a retry may claim it again.
+	IdempotencyLockTTL = time.Minute
+	// MaxIdempotencyKeyLength is the longest accepted Idempotency-Key header.
+	MaxIdempotencyKeyLength = 255
+)
+
+// IdempotencyRecord is the stored outcome of a request made with an
+// Idempotency-Key header. Status is zero while that request is still running.
+type IdempotencyRecord struct {
+	Fingerprint string
+	Status      int
+	Body        []byte
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Impersonation key limits.
+const (
+	DefaultImpersonationMinutes  = 15
+	MaxImpersonationMinutes      = 60
+	MaxImpersonationReasonLength = 500
+)
+
+// ErrImpersonateOwnTenant is returned when an operator impersonates the
+// tenant it is authenticated as.
+var ErrImpersonateOwnTenant = errors.New("cannot impersonate your own tenant")
+
+// ImpersonationKeyName is the name every impersonation key is created with,
+// so the tenant can tell them apart in its key list.
+const ImpersonationKeyName = "support impersonation"
+
+// ImpersonateTenantRequest is the payload for issuing an impersonation key.
+// Reason is recorded with the key and in both tenants' audit logs. Scope
+// defaults to read and cannot be admin; TTLMinutes defaults to
+// DefaultImpersonationMinutes.
+type ImpersonateTenantRequest struct {
+	Reason     string `json:"reason"`
+	Scope      string `json:"scope,omitempty"`
+	TTLMinutes *int   `json:"ttl_minutes,omitempty"`
+}
+
+// Validate checks the reason, scope, and lifetime, applying the default
+// scope.
+func (r *ImpersonateTenantRequest) Validate() error {
+	if r.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if tooLong(r.Reason, MaxImpersonationReasonLength) {
+		return ErrFieldTooLong("reason", MaxImpersonationReasonLength)
+	}
+
+	if r.Scope == "" {
+		r.Scope = APIKeyScopeRead
+	}
+
+	if r.Scope == APIKeyScopeAdmin || !ValidAPIKeyScope(r.Scope) {
+		return fmt.Errorf("scope must be one of %s, %s, %s",
+			APIKeyScopeSearch, APIKeyScopeRead, APIKeyScopeReadWrite)
+	}
+
+	if r.TTLMinutes != nil && (*r.TTLMinutes < 1 || *r.TTLMinutes > MaxImpersonationMinutes) {
+		return fmt.Errorf("ttl_minutes must be between 1 and %d", MaxImpersonationMinutes)
+	}
+
+	return nil
+}
+
+// TTL returns how long the key is accepted, applying the default.
+func (r *ImpersonateTenantRequest) TTL() time.Duration {
+	if r.TTLMinutes == nil {
+		return DefaultImpersonationMinutes * time.Minute
+	}
+
+	return time.Duration(*r.TTLMinutes) * time.Minute
+}
+
+// ImpersonationKey is returned once when an operator impersonates a tenant.
+// The key authenticates as TenantID through the normal API until it expires
+// or is revoked, and cannot be retrieved again.
+type ImpersonationKey struct {
+	CreatedAPIKey
+	TenantID string `json:"tenant_id"`
+	Reason   string `json:"reason"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// ImportConflicts holds the stored versions of records in an import payload
+// that already exist: nodes by ID, edges by (source, target, relation).
+type ImportConflicts struct {
+	Nodes []ExportNode `json:"nodes"`
+	Edges []ExportEdge `json:"edges"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Import session states.
+const (
+	ImportSessionOpen      = "open"
+	ImportSessionCommitted = "committed"
+)
+
+// Import session limits.
+const (
+	// MaxImportChunkRecords caps the nodes plus edges sent in one chunk.
+	MaxImportChunkRecords = 5000
+	// ImportSessionTTL is how long an uncommitted session is kept.
+	ImportSessionTTL = 24 * time.Hour
+)
+
+// Import session errors.
+var (
+	ErrImportSessionNotFound = errors.New("import session not found")
+	ErrImportSessionClosed   = errors.New("import session is already committed")
+	ErrImportSchemaTooNew    = errors.New("export was created by a newer version of Persistor")
+)
+
+// CreateImportSessionRequest opens a chunked import. SchemaVersion is the
+// schema version of the export being imported.
+type CreateImportSessionRequest struct {
+	SchemaVersion int           `json:"schema_version"`
+	Options       ImportOptions `json:"options"`
+}
+
+// ImportSession is a chunked import staged server-side. Chunks can be
+// uploaded in any order and re-uploaded; nothing is written to the graph
+// until the session is committed, which applies every chunk in a single
+// transaction.
+type ImportSession struct {
+	ID            string            `json:"id"`
+	Status        string            `json:"status"`
+	SchemaVersion int               `json:"schema_version"`
+	Options       ImportOptions     `json:"options"`
+	Chunks        []ImportChunkInfo `json:"chunks"`
+	Result        *ImportResult     `json:"result,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	CommittedAt   *time.Time        `json:"committed_at,omitempty"`
+}
+
+// ImportChunkInfo describes a chunk received by an import session.
+type ImportChunkInfo struct {
+	Seq   int `json:"seq"`
+	Nodes int `json:"nodes"`
+	Edges int `json:"edges"`
+}
+
+// ImportChunk is one slice of an import, uploaded to a session.
+type ImportChunk struct {
+	Nodes []ExportNode `json:"nodes,omitempty"`
+	Edges []ExportEdge `json:"edges,omitempty"`
+}
+
+// Validate checks the chunk size and that every record is addressable.
+// Edge endpoints are resolved against the whole session on commit.
+func (c *ImportChunk) Validate() []string {
+	if n := len(c.Nodes) + len(c.Edges); n == 0 || n > MaxImportChunkRecords {
+		return []string{fmt.Sprintf("chunk must hold between 1 and %d records, got %d", MaxImportChunkRecords, n)}
+	}
+
+	var errs []string
+
+	for i, n := range c.Nodes {
+		if n.ID == "" {
+			errs = append(errs, fmt.Sprintf("node[%d] has an empty ID", i))
+		}
+	}
+
+	for i, e := range c.Edges {
+		if e.Source == "" || e.Target == "" || e.Relation == "" {
+			errs = append(errs, fmt.Sprintf("edge[%d] needs a source, target, and relation", i))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Node filters the index advisor recognizes in recorded statements. Tags and
+// other SEARCHABLE_PROPERTIES are matched through the plaintext search_props
+// column, so all props filters count as one.
+const (
+	IndexFilterType  = "type"
+	IndexFilterProps = "props"
+)
+
+// Thresholds above which the index advisor recommends an index for a filter
+// combination that no existing index covers.
+const (
+	DefaultIndexAdvisorMinCalls  = 100
+	DefaultIndexAdvisorMinMeanMs = 5
+)
+
+// ErrIndexBuilding is returned when advised indexes are requested while
+// earlier ones are still being built.
+var ErrIndexBuilding = errors.New("an advised index build is already running")
+
+// IndexAdvisorRequest sets the thresholds for recommending an index. Zero
+// values use the defaults.
+type IndexAdvisorRequest struct {
+	MinCalls  int64   `json:"min_calls,omitempty"`
+	MinMeanMs float64 `json:"min_mean_ms,omitempty"`
+}
+
+// Validate rejects negative thresholds.
+func (r *IndexAdvisorRequest) Validate() error {
+	if r.MinCalls < 0 {
+		return errors.New("min_calls must not be negative")
+	}
+
+	if r.MinMeanMs < 0 {
+		return errors.New("min_mean_ms must not be negative")
+	}
+
+	return nil
+}
+
+// WithDefaults returns the request with omitted thresholds filled in.
+func (r IndexAdvisorRequest) WithDefaults() IndexAdvisorRequest {
+	if r.MinCalls == 0 {
+		r.MinCalls = DefaultIndexAdvisorMinCalls
+	}
+
+	if r.MinMeanMs == 0 {
+		r.MinMeanMs = DefaultIndexAdvisorMinMeanMs
+	}
+
+	return r
+}
+
+// StatementStats is one normalized statement from pg_stat_statements.
+type StatementStats struct {
+	Query       string
+	Calls       int64
+	TotalTimeMs float64
+}
+
+// IndexInfo describes an existing valid index on kg_nodes. Columns are its
+// key columns or expressions in order.
+type IndexInfo struct {
+	Name    string
+	Method  string
+	Columns []string
+}
+
+// IndexRecommendation is an index on kg_nodes the advisor suggests. Requires
+// names an extension the index needs that is not installed; such an index is
+// not created until an operator installs it.
+type IndexRecommendation struct {
+	Name     string   `json:"name"`
+	Method   string   `json:"method"`
+	Columns  []string `json:"columns"`
+	Requires string   `json:"requires,omitempty"`
+}
+
+// Definition returns the CREATE INDEX statement for the recommendation.
+func (r *IndexRecommendation) Definition() string {
+	return fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON kg_nodes USING %s (%s)", r.Name, r.Method, strings.Join(r.Columns, ", "))
+}
+
+// IndexAdvice summarizes the recorded statements that filter nodes by one
+// combination of filters. CoveredBy names an existing index serving the
+// combination; otherwise Recommended is set once the combination is used
+// often and slowly enough.
+type IndexAdvice struct {
+	Filters     []string             `json:"filters"`
+	Statements  int                  `json:"statements"`
+	Calls       int64                `json:"calls"`
+	TotalTimeMs float64              `json:"total_time_ms"`
+	MeanTimeMs  float64              `json:"mean_time_ms"`
+	CoveredBy   string               `json:"covered_by,omitempty"`
+	Recommended *IndexRecommendation `json:"recommended,omitempty"`
+	Definition  string               `json:"definition,omitempty"`
+}
+
+// IndexAdvisorReport is the index advisor's analysis of pg_stat_statements.
+// StatsAvailable is false when the extension is not installed, leaving
+// nothing to analyze. Statistics are cumulative across tenants since they
+// were last reset.
+type IndexAdvisorReport struct {
+	StatsAvailable bool          `json:"stats_available"`
+	Building       bool          `json:"building"`
+	LastError      string        `json:"last_error,omitempty"`
+	Advice         []IndexAdvice `json:"advice"`
+}
+
+// Buildable returns the recommended indexes whose extensions are installed.
+func (r *IndexAdvisorReport) Buildable() []IndexRecommendation {
+	var recs []IndexRecommendation
+
+	for _, advice := range r.Advice {
+		if advice.Recommended != nil && advice.Recommended.Requires == "" {
+			recs = append(recs, *advice.Recommended)
+		}
+	}
+
+	return recs
+}
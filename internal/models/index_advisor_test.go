@@ -0,0 +1,33 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestIndexAdvisorRequest(t *testing.T) {
+	got := models.IndexAdvisorRequest{MinMeanMs: 0.5}.WithDefaults()
+	if want := (models.IndexAdvisorRequest{MinCalls: 100, MinMeanMs: 0.5}); got != want {
+		t.Errorf("WithDefaults = %+v, want %+v", got, want)
+	}
+
+	for _, req := range []models.IndexAdvisorRequest{{MinCalls: -1}, {MinMeanMs: -0.1}} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", req)
+		}
+	}
+}
+
+func TestIndexAdvisorReportBuildable(t *testing.T) {
+	report := models.IndexAdvisorReport{Advice: []models.IndexAdvice{
+		{CoveredBy: "idx_nodes_tenant_type"},
+		{Recommended: &models.IndexRecommendation{Name: "idx_nodes_search_props"}},
+		{Recommended: &models.IndexRecommendation{Name: "idx_nodes_tenant_type_props", Requires: "btree_gin"}},
+	}}
+
+	recs := report.Buildable()
+	if len(recs) != 1 || recs[0].Name != "idx_nodes_search_props" {
+		t.Errorf("Buildable = %+v", recs)
+	}
+}
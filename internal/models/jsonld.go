@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Defaults for the parts of a JSONLDContext a tenant leaves unset.
+const (
+	DefaultJSONLDBase  = "urn:persistor:node:"
+	DefaultJSONLDVocab = "urn:persistor:vocab:"
+)
+
+// MaxJSONLDContextTerms bounds the prefixes and mappings in a JSONLDContext.
+const MaxJSONLDContextTerms = 1000
+
+// JSONLDContext is a tenant's mapping of its graph onto linked-data
+// vocabularies for JSON-LD exports. Node IRIs are Base followed by the
+// escaped node ID. Types, Relations, and Properties map node types, edge
+// relations, and property keys to IRIs, which may be compact ("schema:Person")
+// using Prefixes; anything unmapped falls under Vocab.
+type JSONLDContext struct {
+	Base       string            `json:"base,omitempty"`
+	Vocab      string            `json:"vocab,omitempty"`
+	Prefixes   map[string]string `json:"prefixes,omitempty"`
+	Types      map[string]string `json:"types,omitempty"`
+	Relations  map[string]string `json:"relations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// WithDefaults returns a copy with Base and Vocab filled in where unset.
+func (c JSONLDContext) WithDefaults() JSONLDContext {
+	if c.Base == "" {
+		c.Base = DefaultJSONLDBase
+	}
+
+	if c.Vocab == "" {
+		c.Vocab = DefaultJSONLDVocab
+	}
+
+	return c
+}
+
+// Validate checks that every IRI is absolute or compact, that terms are
+// usable as JSON-LD terms, and that no term maps to two different IRIs.
+func (c *JSONLDContext) Validate() error {
+	if c.Base != "" && !isJSONLDIRI(c.Base) {
+		return errors.New("base must be an absolute IRI")
+	}
+
+	if c.Vocab != "" && !isJSONLDIRI(c.Vocab) {
+		return errors.New("vocab must be an absolute IRI")
+	}
+
+	if n := len(c.Prefixes) + len(c.Types) + len(c.Relations) + len(c.Properties); n > MaxJSONLDContextTerms {
+		return fmt.Errorf("context has %d terms, maximum is %d", n, MaxJSONLDContextTerms)
+	}
+
+	terms := make(map[string]string)
+
+	for _, group := range []struct {
+		name  string
+		terms map[string]string
+	}{{"prefixes", c.Prefixes}, {"types", c.Types}, {"relations", c.Relations}, {"properties", c.Properties}} {
+		for term, iri := range group.terms {
+			if term == "" || strings.HasPrefix(term, "@") || strings.Contains(term, ":") {
+				return fmt.Errorf("%s: %q is not a valid term", group.name, term)
+			}
+
+			if !isJSONLDIRI(iri) {
+				return fmt.Errorf("%s: %q must map to an absolute or compact IRI", group.name, term)
+			}
+
+			if prev, ok := terms[term]; ok && prev != iri {
+				return fmt.Errorf("%s: %q is mapped to both %s and %s", group.name, term, prev, iri)
+			}
+
+			terms[term] = iri
+		}
+	}
+
+	return nil
+}
+
+// isJSONLDIRI reports whether s has a scheme, which both absolute IRIs and
+// compact IRIs ("prefix:suffix") do.
+func isJSONLDIRI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme != "" && !strings.ContainsAny(s, " \t\n<>\"")
+}
+
+// JSONLDDocument is a JSON-LD export: the context and one node object per
+// graph node, with its outgoing edges as references to other nodes.
+type JSONLDDocument struct {
+	Context map[string]any   `json:"@context"`
+	Graph   []map[string]any `json:"@graph"`
+}
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestJSONLDContextValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     models.JSONLDContext
+		wantErr bool
+	}{
+		{"empty", models.JSONLDContext{}, false},
+		{"full", models.JSONLDContext{
+			Base:       "https://example.org/node/",
+			Vocab:      "https://example.org/vocab#",
+			Prefixes:   map[string]string{"schema": "https://schema.org/"},
+			Types:      map[string]string{"person": "schema:Person"},
+			Relations:  map[string]string{"works_at": "schema:worksFor"},
+			Properties: map[string]string{"email": "https://schema.org/email"},
+		}, false},
+		{"same term same IRI", models.JSONLDContext{
+			Types:      map[string]string{"name": "schema:name"},
+			Properties: map[string]string{"name": "schema:name"},
+		}, false},
+		{"relative base", models.JSONLDContext{Base: "/nodes/"}, true},
+		{"relative vocab", models.JSONLDContext{Vocab: "vocab#"}, true},
+		{"relative mapping", models.JSONLDContext{Types: map[string]string{"person": "Person"}}, true},
+		{"keyword term", models.JSONLDContext{Relations: map[string]string{"@type": "https://example.org/t"}}, true},
+		{"compact term", models.JSONLDContext{Properties: map[string]string{"ex:p": "https://example.org/p"}}, true},
+		{"conflicting term", models.JSONLDContext{
+			Types:     map[string]string{"member": "https://example.org/Member"},
+			Relations: map[string]string{"member": "https://example.org/member"},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ctx.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONLDContextWithDefaults(t *testing.T) {
+	got := models.JSONLDContext{Vocab: "https://example.org/vocab#"}.WithDefaults()
+	if got.Base != models.DefaultJSONLDBase || got.Vocab != "https://example.org/vocab#" {
+		t.Errorf("WithDefaults = %+v", got)
+	}
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidListCursor is returned when a node, edge, or audit list cursor
+// cannot be decoded.
+var ErrInvalidListCursor = errors.New("invalid list cursor")
+
+// NodeCursor is the position of the last node on a page of a node listing,
+// which is ordered by salience, then last update, then ID, all descending.
+type NodeCursor struct {
+	Salience  float64   `json:"s"`
+	UpdatedAt time.Time `json:"u"`
+	ID        string    `json:"i"`
+}
+
+// NodeCursorAfter returns the cursor that continues a listing after n.
+func NodeCursorAfter(n *Node) *NodeCursor {
+	return &NodeCursor{Salience: n.Salience, UpdatedAt: n.UpdatedAt, ID: n.ID}
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c *NodeCursor) Encode() string {
+	return encodeListCursor(c)
+}
+
+// DecodeNodeCursor parses a cursor returned by Encode. An empty string
+// decodes to nil, meaning the first page.
+func DecodeNodeCursor(s string) (*NodeCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var c NodeCursor
+	if err := decodeListCursor(s, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidListCursor
+	}
+
+	return &c, nil
+}
+
+// EdgeCursor is the position of the last edge on a page of an edge listing,
+// which is ordered by last update, then source, target, and relation, all
+// descending.
+type EdgeCursor struct {
+	UpdatedAt time.Time `json:"u"`
+	Source    string    `json:"s"`
+	Target    string    `json:"t"`
+	Relation  string    `json:"r"`
+}
+
+// EdgeCursorAfter returns the cursor that continues a listing after e.
+func EdgeCursorAfter(e *Edge) *EdgeCursor {
+	return &EdgeCursor{UpdatedAt: e.UpdatedAt, Source: e.Source, Target: e.Target, Relation: e.Relation}
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c *EdgeCursor) Encode() string {
+	return encodeListCursor(c)
+}
+
+// DecodeEdgeCursor parses a cursor returned by Encode. An empty string
+// decodes to nil, meaning the first page.
+func DecodeEdgeCursor(s string) (*EdgeCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var c EdgeCursor
+	if err := decodeListCursor(s, &c); err != nil || c.Source == "" || c.Target == "" || c.Relation == "" {
+		return nil, ErrInvalidListCursor
+	}
+
+	return &c, nil
+}
+
+// AuditCursor is the position of the last entry on a page of an audit log
+// query, which is ordered by creation time, then ID, both descending.
+type AuditCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int64     `json:"i"`
+}
+
+// AuditCursorAfter returns the cursor that continues a query after e.
+func AuditCursorAfter(e *AuditEntry) *AuditCursor {
+	return &AuditCursor{CreatedAt: e.CreatedAt, ID: e.ID}
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c *AuditCursor) Encode() string {
+	return encodeListCursor(c)
+}
+
+// DecodeAuditCursor parses a cursor returned by Encode. An empty string
+// decodes to nil, meaning the first page.
+func DecodeAuditCursor(s string) (*AuditCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var c AuditCursor
+	if err := decodeListCursor(s, &c); err != nil || c.ID <= 0 {
+		return nil, ErrInvalidListCursor
+	}
+
+	return &c, nil
+}
+
+func encodeListCursor(v any) string {
+	raw, _ := json.Marshal(v) //nolint:errcheck // cursor structs always marshal.
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeListCursor(s string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
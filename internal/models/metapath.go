@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Metapath limits.
+const (
+	// MaxMetapathSteps caps the hops in a metapath, since each one is a
+	// further join.
+	MaxMetapathSteps = 8
+	// DefaultMetapathLimit is the number of paths a run returns by default.
+	DefaultMetapathLimit = 100
+	// MaxMetapathLimit caps the paths one run returns.
+	MaxMetapathLimit = 1000
+	// maxMetapathNameLen caps a metapath name.
+	maxMetapathNameLen = 128
+	// maxMetapathDescriptionLen caps a metapath description.
+	maxMetapathDescriptionLen = 1000
+)
+
+// Metapath step directions.
+const (
+	MetapathOut = "out"
+	MetapathIn  = "in"
+)
+
+// ErrMetapathNotFound indicates the tenant has no metapath with the name.
+var ErrMetapathNotFound = errors.New("metapath not found")
+
+// MetapathStep is one hop of a metapath: an edge with Relation, followed
+// from source to target ("out", the default) or target to source ("in"),
+// to a node of Type when set.
+type MetapathStep struct {
+	Relation  string `json:"relation"`
+	Direction string `json:"direction,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// Metapath is a named multi-hop relation path template, such as
+// person -works_at-> company -located_in-> city. Running it from a start
+// node, of StartType when set, returns every path that follows its steps.
+type Metapath struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	StartType   string         `json:"start_type,omitempty"`
+	Steps       []MetapathStep `json:"steps"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// PutMetapathRequest defines or replaces a metapath.
+type PutMetapathRequest struct {
+	Description string         `json:"description"`
+	StartType   string         `json:"start_type"`
+	Steps       []MetapathStep `json:"steps"`
+}
+
+// Validate checks the steps, normalizes their text, and defaults each
+// direction to "out".
+func (r *PutMetapathRequest) Validate() error {
+	if tooLong(r.Description, maxMetapathDescriptionLen) {
+		return ErrFieldTooLong("description", maxMetapathDescriptionLen)
+	}
+
+	r.StartType = NormalizeText(r.StartType)
+	if tooLong(r.StartType, 100) {
+		return ErrFieldTooLong("start_type", 100)
+	}
+
+	if len(r.Steps) == 0 {
+		return fmt.Errorf("steps is required")
+	}
+
+	if len(r.Steps) > MaxMetapathSteps {
+		return fmt.Errorf("a metapath can have at most %d steps", MaxMetapathSteps)
+	}
+
+	for i := range r.Steps {
+		step := &r.Steps[i]
+		step.Relation = NormalizeText(step.Relation)
+		step.Type = NormalizeText(step.Type)
+
+		if step.Relation == "" {
+			return fmt.Errorf("steps[%d]: relation is required", i)
+		}
+
+		if tooLong(step.Relation, 255) || tooLong(step.Type, 100) {
+			return fmt.Errorf("steps[%d]: relation or type is too long", i)
+		}
+
+		switch step.Direction {
+		case "":
+			step.Direction = MetapathOut
+		case MetapathOut, MetapathIn:
+		default:
+			return fmt.Errorf("steps[%d]: direction must be %s or %s", i, MetapathOut, MetapathIn)
+		}
+	}
+
+	return nil
+}
+
+// ValidateMetapathName checks a metapath name: required, at most 128
+// characters.
+func ValidateMetapathName(name string) error {
+	if name == "" {
+		return fmt.Errorf("metapath name is required")
+	}
+
+	if tooLong(name, maxMetapathNameLen) {
+		return ErrFieldTooLong("name", maxMetapathNameLen)
+	}
+
+	return nil
+}
+
+// MetapathResult is the outcome of running a metapath from a start node.
+// Each path lists node IDs from the start node on, one per step; Nodes
+// holds every node on them once.
+type MetapathResult struct {
+	Metapath string     `json:"metapath"`
+	StartID  string     `json:"start_id"`
+	Paths    [][]string `json:"paths"`
+	Nodes    []Node     `json:"nodes"`
+	HasMore  bool       `json:"has_more"`
+}
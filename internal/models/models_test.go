@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -43,6 +44,8 @@ func TestCreateNodeRequest_Validate(t *testing.T) {
 		{name: "label too long", req: models.CreateNodeRequest{Type: "p", Label: strings.Repeat("x", 10001)}, wantErr: "exceeds maximum length"},
 		{name: "id too long", req: models.CreateNodeRequest{ID: strings.Repeat("x", 256), Type: "p", Label: "a"}, wantErr: "exceeds maximum length"},
 		{name: "type too long", req: models.CreateNodeRequest{Type: strings.Repeat("x", 101), Label: "a"}, wantErr: "exceeds maximum length"},
+		{name: "emoji label within limit", req: models.CreateNodeRequest{Type: "p", Label: strings.Repeat("🧠", 10000)}},
+		{name: "emoji label too long", req: models.CreateNodeRequest{Type: "p", Label: strings.Repeat("🧠", 10001)}, wantErr: "exceeds maximum length"},
 	}
 
 	for _, tc := range tests {
@@ -57,6 +60,23 @@ func TestCreateNodeRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestCreateNodeRequest_ValidateNormalizesNFC(t *testing.T) {
+	req := models.CreateNodeRequest{Type: "cafe\u0301", Label: "Jose\u0301 Mu\u0308ller"}
+	assertNoError(t, req.Validate())
+
+	if req.Type != "caf\u00e9" || req.Label != "Jos\u00e9 M\u00fcller" {
+		t.Errorf("normalized = %q, %q", req.Type, req.Label)
+	}
+
+	label := "Cre\u0300me"
+	update := models.UpdateNodeRequest{Label: &label}
+	assertNoError(t, update.Validate())
+
+	if label != "Cr\u00e8me" {
+		t.Errorf("updated label = %q", label)
+	}
+}
+
 func TestCreateEdgeRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -88,6 +108,32 @@ func TestCreateEdgeRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestEdgeExistsRequest_Validate(t *testing.T) {
+	key := models.EdgeKey{Source: "a", Target: "b", Relation: "knows"}
+	tests := []struct {
+		name    string
+		req     models.EdgeExistsRequest
+		wantErr string
+	}{
+		{name: "valid", req: models.EdgeExistsRequest{Edges: []models.EdgeKey{key}}},
+		{name: "empty", req: models.EdgeExistsRequest{}, wantErr: "edges must not be empty"},
+		{name: "too many", req: models.EdgeExistsRequest{Edges: make([]models.EdgeKey, models.MaxEdgeExistsKeys+1)}, wantErr: "at most"},
+		{name: "missing target", req: models.EdgeExistsRequest{Edges: []models.EdgeKey{key, {Source: "a", Relation: "r"}}}, wantErr: "edges[1]: target is required"},
+		{name: "relation too long", req: models.EdgeExistsRequest{Edges: []models.EdgeKey{{Source: "a", Target: "b", Relation: strings.Repeat("r", 256)}}}, wantErr: "exceeds maximum length"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.req.Validate()
+			if tc.wantErr != "" {
+				assertErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assertNoError(t, err)
+		})
+	}
+}
+
 func TestUpdateNodeRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -208,3 +254,182 @@ func modelsTime(v string) time.Time {
 	}
 	return t
 }
+
+func TestSubgraphRequest_Validate(t *testing.T) {
+	tooMany := make([]string, models.MaxSubgraphNodeIDs+1)
+	for i := range tooMany {
+		tooMany[i] = "n"
+	}
+
+	tests := []struct {
+		name    string
+		req     models.SubgraphRequest
+		wantErr string
+	}{
+		{name: "valid", req: models.SubgraphRequest{NodeIDs: []string{"a", "b"}, Expand: true}},
+		{name: "missing ids", req: models.SubgraphRequest{}, wantErr: "node_ids is required"},
+		{name: "too many ids", req: models.SubgraphRequest{NodeIDs: tooMany}, wantErr: "exceeds maximum"},
+		{name: "empty id", req: models.SubgraphRequest{NodeIDs: []string{"a", ""}}, wantErr: "node_ids[1] must not be empty"},
+		{name: "id too long", req: models.SubgraphRequest{NodeIDs: []string{strings.Repeat("x", 256)}}, wantErr: "exceeds maximum length"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.req.Validate()
+			if tc.wantErr != "" {
+				assertErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assertNoError(t, err)
+		})
+	}
+}
+
+func TestMergeNodeProperties(t *testing.T) {
+	target := map[string]any{"role": "ceo", "city": "Seattle"}
+	source := map[string]any{"role": "founder", "city": "Seattle", "born": float64(1955)}
+
+	merged, added, conflicts := models.MergeNodeProperties(target, source, models.MergeKeepTarget)
+	if added != 1 || len(conflicts) != 1 || conflicts[0] != "role" {
+		t.Fatalf("added = %d, conflicts = %v; want 1 added, conflict on role", added, conflicts)
+	}
+	if merged["role"] != "ceo" || merged["born"] != float64(1955) {
+		t.Errorf("keep_target merged = %v", merged)
+	}
+	if len(target) != 2 {
+		t.Errorf("target was modified: %v", target)
+	}
+
+	merged, _, _ = models.MergeNodeProperties(target, source, models.MergeKeepSource)
+	if merged["role"] != "founder" {
+		t.Errorf("keep_source role = %v, want founder", merged["role"])
+	}
+}
+
+func TestMergeNodeRequest_Validate(t *testing.T) {
+	req := models.MergeNodeRequest{}
+	assertNoError(t, req.Validate())
+
+	if req.ConflictStrategy != models.MergeKeepTarget {
+		t.Errorf("default strategy = %q, want %q", req.ConflictStrategy, models.MergeKeepTarget)
+	}
+
+	req = models.MergeNodeRequest{ConflictStrategy: "newest"}
+	assertErrorContains(t, req.Validate(), "conflict_strategy")
+}
+
+func TestExplainSalience(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	accessed := now.Add(-90 * 24 * time.Hour)
+
+	n := &models.Node{
+		AccessCount:  3,
+		LastAccessed: &accessed,
+		UserBoosted:  true,
+		SupersededBy: ptr("new"),
+		CreatedAt:    now.Add(-365 * 24 * time.Hour),
+		Salience:     2.9,
+	}
+
+	e := models.ExplainSalience(n, now)
+	if e.Access != 0.6 || e.Recency != 0.25 || e.Boost != 2.0 || e.Supersede != -0.5 {
+		t.Errorf("terms = %+v", e)
+	}
+	if e.Computed != 3.35 || e.Stored != 2.9 || !e.LastActive.Equal(accessed) {
+		t.Errorf("computed = %v, stored = %v, last active = %v", e.Computed, e.Stored, e.LastActive)
+	}
+
+	// Never accessed and long idle: recency is zero and the floor is not hit.
+	n = &models.Node{CreatedAt: now.Add(-400 * 24 * time.Hour)}
+	if e := models.ExplainSalience(n, now); e.Recency != 0 || e.Computed != 1.0 {
+		t.Errorf("idle node = %+v", e)
+	}
+}
+
+func TestDeleteByFilterRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.DeleteByFilterRequest
+		wantErr bool
+	}{
+		{"type filter", models.DeleteByFilterRequest{Filter: models.NodeFilter{Type: "old"}, Token: "t"}, false},
+		{"salience filter", models.DeleteByFilterRequest{Filter: models.NodeFilter{MinSalience: 0.5}, Token: "t"}, false},
+		{"empty filter", models.DeleteByFilterRequest{Token: "t"}, true},
+		{"missing token", models.DeleteByFilterRequest{Filter: models.NodeFilter{Type: "old"}}, true},
+		{"long type", models.DeleteByFilterRequest{Filter: models.NodeFilter{Type: strings.Repeat("x", 101)}, Token: "t"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestListCursors_RoundTrip(t *testing.T) {
+	updated := time.Date(2026, 3, 4, 5, 6, 7, 123456000, time.UTC)
+
+	node := &models.Node{ID: "n1", Salience: float64(float32(0.7)), UpdatedAt: updated}
+	nc, err := models.DecodeNodeCursor(models.NodeCursorAfter(node).Encode())
+	if err != nil {
+		t.Fatalf("DecodeNodeCursor: %v", err)
+	}
+	if nc.ID != "n1" || nc.Salience != node.Salience || !nc.UpdatedAt.Equal(updated) {
+		t.Errorf("node cursor = %+v", nc)
+	}
+
+	edge := &models.Edge{Source: "a", Target: "b", Relation: "knows", UpdatedAt: updated}
+	ec, err := models.DecodeEdgeCursor(models.EdgeCursorAfter(edge).Encode())
+	if err != nil {
+		t.Fatalf("DecodeEdgeCursor: %v", err)
+	}
+	if ec.Source != "a" || ec.Target != "b" || ec.Relation != "knows" || !ec.UpdatedAt.Equal(updated) {
+		t.Errorf("edge cursor = %+v", ec)
+	}
+
+	entry := &models.AuditEntry{ID: 42, CreatedAt: updated}
+	ac, err := models.DecodeAuditCursor(models.AuditCursorAfter(entry).Encode())
+	if err != nil {
+		t.Fatalf("DecodeAuditCursor: %v", err)
+	}
+	if ac.ID != 42 || !ac.CreatedAt.Equal(updated) {
+		t.Errorf("audit cursor = %+v", ac)
+	}
+
+	if c, err := models.DecodeNodeCursor(""); c != nil || err != nil {
+		t.Errorf("empty node cursor = %+v, %v", c, err)
+	}
+	for _, bad := range []string{"%%%", "bm90IGpzb24", "e30"} {
+		if _, err := models.DecodeNodeCursor(bad); !errors.Is(err, models.ErrInvalidListCursor) {
+			t.Errorf("DecodeNodeCursor(%q) error = %v", bad, err)
+		}
+		if _, err := models.DecodeEdgeCursor(bad); !errors.Is(err, models.ErrInvalidListCursor) {
+			t.Errorf("DecodeEdgeCursor(%q) error = %v", bad, err)
+		}
+		if _, err := models.DecodeAuditCursor(bad); !errors.Is(err, models.ErrInvalidListCursor) {
+			t.Errorf("DecodeAuditCursor(%q) error = %v", bad, err)
+		}
+	}
+}
+
+func TestMigrateNodeRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.MigrateNodeRequest
+		wantErr bool
+	}{
+		{"valid", models.MigrateNodeRequest{NewID: "n2", ExcludeRelations: []string{"knows"}}, false},
+		{"copy", models.MigrateNodeRequest{NewID: "n2", CopyEdges: true}, false},
+		{"missing new_id", models.MigrateNodeRequest{}, true},
+		{"copy with delete_old", models.MigrateNodeRequest{NewID: "n2", CopyEdges: true, DeleteOld: true}, true},
+		{"empty relation", models.MigrateNodeRequest{NewID: "n2", ExcludeRelations: []string{""}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
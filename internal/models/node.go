@@ -2,7 +2,6 @@
 package models
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -33,6 +32,22 @@ type NodeSummary struct {
 	Label string `json:"label"`
 }
 
+// NodeListOpts selects one page of a node listing. Limit defaults to 50;
+// After continues from a cursor instead of Offset.
+type NodeListOpts struct {
+	Type        string
+	MinSalience float64
+	// Properties keeps only nodes whose searchable properties match every
+	// filter.
+	Properties []PropertyFilter
+	Limit      int
+	Offset     int
+	After      *NodeCursor
+	// OmitProperties returns the page with nil properties, skipping their
+	// decryption.
+	OmitProperties bool
+}
+
 // ScoredNode pairs a Node with a similarity score from semantic search.
 type ScoredNode struct {
 	Node
@@ -48,13 +63,16 @@ type CreateNodeRequest struct {
 }
 
 // Validate checks that required fields are present and within limits on CreateNodeRequest.
-// If ID is empty, a UUID is auto-generated.
+// If ID is empty, a UUID is auto-generated. Type and label are normalized to NFC.
 func (r *CreateNodeRequest) Validate() error {
 	if r.ID == "" {
 		r.ID = uuid.New().String()
 	}
 
-	if len(r.ID) > 255 {
+	r.Type = NormalizeText(r.Type)
+	r.Label = NormalizeText(r.Label)
+
+	if tooLong(r.ID, 255) {
 		return ErrFieldTooLong("id", 255)
 	}
 
@@ -62,7 +80,7 @@ func (r *CreateNodeRequest) Validate() error {
 		return ErrMissingType
 	}
 
-	if len(r.Type) > 100 {
+	if tooLong(r.Type, 100) {
 		return ErrFieldTooLong("type", 100)
 	}
 
@@ -70,41 +88,19 @@ func (r *CreateNodeRequest) Validate() error {
 		return ErrMissingLabel
 	}
 
-	if len(r.Label) > 10000 {
+	if tooLong(r.Label, 10000) {
 		return ErrFieldTooLong("label", 10000)
 	}
 
 	if r.Properties != nil {
-		data, err := json.Marshal(r.Properties)
-		if err != nil {
-			return fmt.Errorf("invalid properties: %w", err)
-		}
-		if len(data) > 65536 {
-			return ErrFieldTooLong("properties", 65536)
+		if err := validateProperties(r.Properties); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// MigrateNodeRequest is the payload for migrating a node to a new ID.
-type MigrateNodeRequest struct {
-	NewID     string `json:"new_id"`
-	NewLabel  string `json:"new_label,omitempty"`
-	DeleteOld bool   `json:"delete_old"`
-}
-
-// MigrateNodeResult summarizes the outcome of a node migration.
-type MigrateNodeResult struct {
-	OldID         string  `json:"old_id"`
-	NewID         string  `json:"new_id"`
-	OutgoingEdges int     `json:"outgoing_edges"`
-	IncomingEdges int     `json:"incoming_edges"`
-	Salience      float64 `json:"salience"`
-	OldDeleted    bool    `json:"old_deleted"`
-	DryRun        bool    `json:"dry_run"`
-}
-
 // UpdateNodeRequest is the payload for updating an existing node.
 type UpdateNodeRequest struct {
 	Type       *string        `json:"type,omitempty"`
@@ -124,15 +120,7 @@ func (r *PatchPropertiesRequest) Validate() error {
 		return fmt.Errorf("properties is required and must not be empty")
 	}
 
-	data, err := json.Marshal(r.Properties)
-	if err != nil {
-		return fmt.Errorf("invalid properties: %w", err)
-	}
-	if len(data) > 65536 {
-		return ErrFieldTooLong("properties", 65536)
-	}
-
-	return nil
+	return validateProperties(r.Properties)
 }
 
 // MergeProperties merges patch into existing properties.
@@ -153,8 +141,16 @@ func MergeProperties(existing, patch map[string]any) map[string]any {
 	return existing
 }
 
-// Validate checks UpdateNodeRequest fields.
+// Validate checks UpdateNodeRequest fields, normalizing type and label to NFC.
 func (r *UpdateNodeRequest) Validate() error {
+	if r.Type != nil {
+		*r.Type = NormalizeText(*r.Type)
+	}
+
+	if r.Label != nil {
+		*r.Label = NormalizeText(*r.Label)
+	}
+
 	if r.Type != nil && *r.Type == "" {
 		return fmt.Errorf("type cannot be empty")
 	}
@@ -163,21 +159,17 @@ func (r *UpdateNodeRequest) Validate() error {
 		return fmt.Errorf("label cannot be empty")
 	}
 
-	if r.Type != nil && len(*r.Type) > 100 {
+	if r.Type != nil && tooLong(*r.Type, 100) {
 		return ErrFieldTooLong("type", 100)
 	}
 
-	if r.Label != nil && len(*r.Label) > 10000 {
+	if r.Label != nil && tooLong(*r.Label, 10000) {
 		return ErrFieldTooLong("label", 10000)
 	}
 
 	if r.Properties != nil {
-		data, err := json.Marshal(r.Properties)
-		if err != nil {
-			return fmt.Errorf("invalid properties: %w", err)
-		}
-		if len(data) > 65536 {
-			return ErrFieldTooLong("properties", 65536)
+		if err := validateProperties(r.Properties); err != nil {
+			return err
 		}
 	}
 
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Node delete modes.
+const (
+	// DeleteRestrict refuses to delete a node that still has edges.
+	DeleteRestrict = "restrict"
+	// DeleteDetach deletes the node's edges along with it.
+	DeleteDetach = "detach"
+)
+
+// ErrNodeHasEdges is returned when a node is deleted with DeleteRestrict and
+// edges still reference it.
+var ErrNodeHasEdges = errors.New("node has edges")
+
+// ParseDeleteMode checks a node delete mode. An empty mode is DeleteRestrict,
+// so removing a node together with its edges always has to be asked for.
+func ParseDeleteMode(mode string) (string, error) {
+	switch mode {
+	case "":
+		return DeleteRestrict, nil
+	case DeleteRestrict, DeleteDetach:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("mode must be %s or %s", DeleteRestrict, DeleteDetach)
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Delete-by-filter limits.
+const (
+	// DeletePreviewTTL is how long a preview token can be used.
+	DeletePreviewTTL = 10 * time.Minute
+	// DeletePreviewSampleSize caps the matching nodes listed in a preview.
+	DeletePreviewSampleSize = 20
+)
+
+// Delete-by-filter errors.
+var (
+	ErrDeletePreviewNotFound = errors.New("delete preview not found or expired")
+	ErrDeletePreviewMismatch = errors.New("filter or matching nodes changed since the preview")
+)
+
+// NodeFilter selects nodes with the same predicates as listing nodes.
+type NodeFilter struct {
+	Type        string  `json:"type,omitempty"`
+	MinSalience float64 `json:"min_salience,omitempty"`
+}
+
+// Validate requires at least one predicate so a filter never selects the
+// whole graph by accident.
+func (f *NodeFilter) Validate() error {
+	if f.Type == "" && f.MinSalience <= 0 {
+		return fmt.Errorf("filter needs a type or a positive min_salience")
+	}
+
+	if len(f.Type) > 100 {
+		return ErrFieldTooLong("type", 100)
+	}
+
+	return nil
+}
+
+// DeletePreview reports what a delete-by-filter would remove. Token must be
+// passed to the delete, which fails if the filter matches different nodes by
+// then.
+type DeletePreview struct {
+	Token     string        `json:"token"`
+	Filter    NodeFilter    `json:"filter"`
+	Count     int           `json:"count"`
+	Sample    []NodeSummary `json:"sample"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+// DeleteByFilterRequest deletes the nodes a preview reported.
+type DeleteByFilterRequest struct {
+	Filter NodeFilter `json:"filter"`
+	Token  string     `json:"token"`
+}
+
+// Validate checks the filter and that a token is present.
+func (r *DeleteByFilterRequest) Validate() error {
+	if r.Token == "" {
+		return fmt.Errorf("token is required; request a preview first")
+	}
+
+	return r.Filter.Validate()
+}
+
+// DeleteByFilterResult counts what a delete-by-filter removed.
+type DeleteByFilterResult struct {
+	NodesDeleted int `json:"nodes_deleted"`
+	EdgesDeleted int `json:"edges_deleted"`
+}
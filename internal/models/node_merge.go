@@ -0,0 +1,86 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Property conflict strategies for merging one node into another.
+const (
+	MergeKeepTarget = "keep_target"
+	MergeKeepSource = "keep_source"
+	MergeFail       = "fail"
+)
+
+// ErrMergePropertyConflict is returned when both nodes set a property to
+// different values and the conflict strategy is MergeFail.
+var ErrMergePropertyConflict = errors.New("conflicting properties")
+
+// MergeNodeRequest is the payload for merging a source node into a target.
+// ConflictStrategy decides which value wins when both nodes set a property
+// to different values; it defaults to MergeKeepTarget.
+type MergeNodeRequest struct {
+	ConflictStrategy string `json:"conflict_strategy,omitempty"`
+}
+
+// Validate checks the conflict strategy and applies the default.
+func (r *MergeNodeRequest) Validate() error {
+	switch r.ConflictStrategy {
+	case "":
+		r.ConflictStrategy = MergeKeepTarget
+	case MergeKeepTarget, MergeKeepSource, MergeFail:
+	default:
+		return fmt.Errorf("conflict_strategy must be one of %s, %s, %s", MergeKeepTarget, MergeKeepSource, MergeFail)
+	}
+
+	return nil
+}
+
+// MergeNodeResult summarizes a node merge. Node is the target after the
+// merge; the source node no longer exists.
+type MergeNodeResult struct {
+	SourceID          string   `json:"source_id"`
+	TargetID          string   `json:"target_id"`
+	Node              *Node    `json:"node"`
+	PropertiesAdded   int      `json:"properties_added"`
+	PropertyConflicts []string `json:"property_conflicts,omitempty"`
+	EdgesRewired      int      `json:"edges_rewired"`
+	EdgesMerged       int      `json:"edges_merged"`
+	EdgesDropped      int      `json:"edges_dropped"`
+}
+
+// MergeNodeProperties unions source into target without modifying either.
+// Keys set on both nodes to different values are returned as conflicts,
+// sorted, and resolved according to strategy. With MergeFail the merged map
+// is still returned; callers should reject the merge when conflicts exist.
+func MergeNodeProperties(target, source map[string]any, strategy string) (merged map[string]any, added int, conflicts []string) {
+	merged = make(map[string]any, len(target)+len(source))
+	for k, v := range target {
+		merged[k] = v
+	}
+
+	for k, v := range source {
+		existing, ok := merged[k]
+		if !ok {
+			merged[k] = v
+			added++
+
+			continue
+		}
+
+		if reflect.DeepEqual(existing, v) {
+			continue
+		}
+
+		conflicts = append(conflicts, k)
+		if strategy == MergeKeepSource {
+			merged[k] = v
+		}
+	}
+
+	sort.Strings(conflicts)
+
+	return merged, added, conflicts
+}
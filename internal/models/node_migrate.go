@@ -0,0 +1,84 @@
+package models
+
+import "fmt"
+
+// Actions reported for each edge of a migrated node.
+const (
+	// EdgeMoved means the edge now points at the new node.
+	EdgeMoved = "moved"
+	// EdgeCopied means a copy of the edge points at the new node and the
+	// original still points at the old one.
+	EdgeCopied = "copied"
+	// EdgeExcluded means the edge's relation was excluded and it still
+	// points at the old node.
+	EdgeExcluded = "excluded"
+	// EdgeDropped means the edge's relation was excluded and it was deleted
+	// together with the old node.
+	EdgeDropped = "dropped"
+)
+
+// MigrateNodeRequest is the payload for migrating a node to a new ID.
+type MigrateNodeRequest struct {
+	NewID     string `json:"new_id"`
+	NewLabel  string `json:"new_label,omitempty"`
+	DeleteOld bool   `json:"delete_old"`
+	// ExcludeRelations lists relations whose edges stay on the old node. With
+	// DeleteOld they are deleted instead.
+	ExcludeRelations []string `json:"exclude_relations,omitempty"`
+	// CopyEdges gives the new node a copy of each edge and leaves the old
+	// node's edges in place. It cannot be combined with DeleteOld.
+	CopyEdges bool `json:"copy_edges,omitempty"`
+	// DryRun runs the migration in a transaction that is rolled back and
+	// reports what it would have done, including conflicting edges.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// Validate checks that the request names a new ID and a consistent set of
+// edge options.
+func (r *MigrateNodeRequest) Validate() error {
+	if r.NewID == "" {
+		return fmt.Errorf("new_id: %w", ErrMissingID)
+	}
+
+	if tooLong(r.NewID, 255) {
+		return ErrFieldTooLong("new_id", 255)
+	}
+
+	if r.CopyEdges && r.DeleteOld {
+		return fmt.Errorf("copy_edges cannot be combined with delete_old")
+	}
+
+	for _, rel := range r.ExcludeRelations {
+		if rel == "" || len(rel) > 255 {
+			return fmt.Errorf("exclude_relations: invalid relation %q", rel)
+		}
+	}
+
+	return nil
+}
+
+// MigratedEdge reports what a node migration did with one edge. Source and
+// Target are the edge's endpoints before the migration. Conflict is set when
+// the new node already has the edge being moved or copied, which fails the
+// migration unless it is a dry run.
+type MigratedEdge struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+	Action   string `json:"action"`
+	Conflict bool   `json:"conflict,omitempty"`
+}
+
+// MigrateNodeResult summarizes the outcome of a node migration.
+// OutgoingEdges and IncomingEdges count the edges moved or copied to the
+// new node; Edges lists every edge of the old node with what was done to it.
+type MigrateNodeResult struct {
+	OldID         string         `json:"old_id"`
+	NewID         string         `json:"new_id"`
+	OutgoingEdges int            `json:"outgoing_edges"`
+	IncomingEdges int            `json:"incoming_edges"`
+	Salience      float64        `json:"salience"`
+	OldDeleted    bool           `json:"old_deleted"`
+	DryRun        bool           `json:"dry_run"`
+	Edges         []MigratedEdge `json:"edges"`
+}
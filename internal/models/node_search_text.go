@@ -31,7 +31,8 @@
 	"inference_fix_summary",
 }
 
-// BuildNodeSearchText builds a deterministic text blob for full-text indexing.
+// BuildNodeSearchText builds a deterministic, NFC-normalized text blob for
+// full-text indexing.
 func BuildNodeSearchText(node *Node) string {
 	if node == nil {
 		return ""
@@ -46,7 +47,7 @@ func BuildNodeSearchText(node *Node) string {
 	}
 	appendSearchLine(&builder, BuildNodeFactText(node))
 
-	return strings.TrimSpace(builder.String())
+	return NormalizeText(strings.TrimSpace(builder.String()))
 }
 
 // BuildNodeSummarySearchText builds full-text index input for lightweight node summaries.
@@ -115,6 +116,15 @@ func normalizeSearchValue(value any) string {
 			}
 		}
 		return strings.Join(parts, " ")
+	case map[string]any:
+		// Typed values are indexed by their text form; binary data is not indexed.
+		if typ, text, _, ok := typedEnvelope(v); ok {
+			if typ == TypedBytes {
+				return ""
+			}
+			return text
+		}
+		return strings.TrimSpace(fmt.Sprintf("%v", v))
 	default:
 		return strings.TrimSpace(fmt.Sprintf("%v", v))
 	}
@@ -0,0 +1,14 @@
+package models
+
+// PartitionSize is the size of one hash partition of a graph table. Rows is
+// the planner's estimate as of the last VACUUM or ANALYZE, not an exact count.
+type PartitionSize struct {
+	Table      string `json:"table"`
+	Partition  string `json:"partition"`
+	Modulus    int    `json:"modulus"`
+	Remainder  int    `json:"remainder"`
+	Rows       int64  `json:"rows"`
+	TableBytes int64  `json:"table_bytes"`
+	IndexBytes int64  `json:"index_bytes"`
+	TotalBytes int64  `json:"total_bytes"`
+}
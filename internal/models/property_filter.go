@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPropertyFilters caps the props filters one list or search request may
+// combine.
+const MaxPropertyFilters = 10
+
+// maxPropertyKeyLen caps the length of a searchable property key.
+const maxPropertyKeyLen = 64
+
+// ErrPropertyNotSearchable is returned when a props filter names a key that
+// is not in the server's SEARCHABLE_PROPERTIES allowlist.
+var ErrPropertyNotSearchable = errors.New("property is not searchable")
+
+// PropertyFilter matches nodes on one searchable property. A nil Value
+// matches every node that has the key; otherwise the property must equal
+// Value.
+type PropertyFilter struct {
+	Key   string
+	Value *string
+}
+
+// ParsePropertyFilters parses props query values of the form key:value or,
+// to match on the key alone, key. All filters must match.
+func ParsePropertyFilters(raw []string) ([]PropertyFilter, error) {
+	var filters []PropertyFilter
+
+	for _, r := range raw {
+		if r == "" {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(r, ":")
+		if err := ValidatePropertyKey(key); err != nil {
+			return nil, err
+		}
+
+		f := PropertyFilter{Key: key}
+		if hasValue {
+			f.Value = &value
+		}
+
+		filters = append(filters, f)
+	}
+
+	if len(filters) > MaxPropertyFilters {
+		return nil, fmt.Errorf("at most %d props filters are allowed", MaxPropertyFilters)
+	}
+
+	return filters, nil
+}
+
+// ValidatePropertyKey checks a searchable property key: 1 to 64 letters,
+// digits, underscores, dashes, or dots.
+func ValidatePropertyKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("property key is required")
+	}
+
+	if len(key) > maxPropertyKeyLen {
+		return ErrFieldTooLong("property key", maxPropertyKeyLen)
+	}
+
+	for _, r := range key {
+		if !isPropertyKeyRune(r) {
+			return fmt.Errorf("property key %q may only contain letters, digits, '_', '-', and '.'", key)
+		}
+	}
+
+	return nil
+}
+
+func isPropertyKeyRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		r == '_' || r == '-' || r == '.'
+}
+
+// Matches returns the values the property may hold to satisfy the filter:
+// the value as a string and, when it reads as a number or boolean, also as
+// that, so props=priority:3 matches both "3" and 3.
+func (f PropertyFilter) Matches() []any {
+	if f.Value == nil {
+		return nil
+	}
+
+	v := *f.Value
+	matches := []any{v}
+
+	if v == "true" || v == "false" {
+		matches = append(matches, v == "true")
+	} else if n, err := strconv.ParseFloat(v, 64); err == nil {
+		matches = append(matches, n)
+	}
+
+	return matches
+}
+
+// SearchableProperties returns the properties of props whose keys are in
+// keys: the plaintext projection props filters are matched against.
+func SearchableProperties(props map[string]any, keys []string) map[string]any {
+	projected := make(map[string]any)
+
+	for _, k := range keys {
+		if v, ok := props[k]; ok {
+			projected[k] = v
+		}
+	}
+
+	return projected
+}
@@ -0,0 +1,74 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestParsePropertyFilters(t *testing.T) {
+	filters, err := models.ParsePropertyFilters([]string{"status:active", "owner", "", "url:https://x.test"})
+	if err != nil {
+		t.Fatalf("ParsePropertyFilters: %v", err)
+	}
+
+	if len(filters) != 3 {
+		t.Fatalf("got %d filters, want 3", len(filters))
+	}
+
+	if filters[0].Key != "status" || *filters[0].Value != "active" {
+		t.Errorf("filters[0] = %+v, want status:active", filters[0])
+	}
+
+	if filters[1].Key != "owner" || filters[1].Value != nil {
+		t.Errorf("filters[1] = %+v, want owner with no value", filters[1])
+	}
+
+	if *filters[2].Value != "https://x.test" {
+		t.Errorf("filters[2] value = %q, want everything after the first colon", *filters[2].Value)
+	}
+
+	tooMany := make([]string, models.MaxPropertyFilters+1)
+	for i := range tooMany {
+		tooMany[i] = "k:v"
+	}
+
+	for _, bad := range [][]string{{":x"}, {"a b:x"}, tooMany} {
+		if _, err := models.ParsePropertyFilters(bad); err == nil {
+			t.Errorf("ParsePropertyFilters(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestPropertyFilterMatches(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		value *string
+		want  []any
+	}{
+		{nil, nil},
+		{str("active"), []any{"active"}},
+		{str("3"), []any{"3", float64(3)}},
+		{str("true"), []any{"true", true}},
+	}
+
+	for _, tt := range tests {
+		got := models.PropertyFilter{Key: "k", Value: tt.value}.Matches()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Matches(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSearchableProperties(t *testing.T) {
+	props := map[string]any{"status": "active", "secret": "x", "priority": 3}
+
+	got := models.SearchableProperties(props, []string{"status", "priority", "missing"})
+	want := map[string]any{"status": "active", "priority": 3}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchableProperties = %v, want %v", got, want)
+	}
+}
@@ -7,11 +7,47 @@
 	"github.com/google/uuid"
 )
 
-// PropertyChange represents a single property value change on a node.
+// Node history fields. Property changes name the key in PropertyKey; label,
+// type, and salience changes leave it empty.
+const (
+	HistoryFieldProperty = "property"
+	HistoryFieldLabel    = "label"
+	HistoryFieldType     = "type"
+	HistoryFieldSalience = "salience"
+)
+
+// ValidHistoryField reports whether field is a recognized history field.
+func ValidHistoryField(field string) bool {
+	switch field {
+	case HistoryFieldProperty, HistoryFieldLabel, HistoryFieldType, HistoryFieldSalience:
+		return true
+	}
+
+	return false
+}
+
+// PropertyChange represents a single change to a node: a property value, or
+// its label, type, or salience as given by Field.
 type PropertyChange struct {
 	ID          int64           `json:"id"`
 	TenantID    uuid.UUID       `json:"-"`
 	NodeID      string          `json:"node_id"`
+	Field       string          `json:"field"`
+	PropertyKey string          `json:"property_key,omitempty"`
+	OldValue    json.RawMessage `json:"old_value"`
+	NewValue    json.RawMessage `json:"new_value"`
+	ChangedAt   time.Time       `json:"changed_at"`
+	Reason      *string         `json:"reason,omitempty"`
+	ChangedBy   *string         `json:"changed_by,omitempty"`
+}
+
+// EdgePropertyChange represents a single property value change on an edge.
+type EdgePropertyChange struct {
+	ID          int64           `json:"id"`
+	TenantID    uuid.UUID       `json:"-"`
+	Source      string          `json:"source"`
+	Target      string          `json:"target"`
+	Relation    string          `json:"relation"`
 	PropertyKey string          `json:"property_key"`
 	OldValue    json.RawMessage `json:"old_value"`
 	NewValue    json.RawMessage `json:"new_value"`
@@ -24,6 +60,20 @@ type PropertyChange struct {
 type PropertyHistoryQuery struct {
 	NodeID      string
 	PropertyKey string // optional filter
+	Field       string // optional filter
+	Limit       int
+	Offset      int
+}
+
+// HistoryListOpts filters the tenant-wide node history listing. Empty
+// fields do not filter.
+type HistoryListOpts struct {
+	Since       *time.Time // changes at or after
+	Until       *time.Time // changes before
+	PropertyKey string
+	Field       string
+	Actor       string // exact changed_by match
+	Reason      string // substring of reason
 	Limit       int
 	Offset      int
 }
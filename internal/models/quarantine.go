@@ -0,0 +1,35 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultQuarantineListLimit is the number of quarantined nodes listed when
+// no limit is given; MaxQuarantineListLimit caps it.
+const (
+	DefaultQuarantineListLimit = 100
+	MaxQuarantineListLimit     = 1000
+)
+
+// ErrNotQuarantined indicates the node is not quarantined.
+var ErrNotQuarantined = errors.New("node is not quarantined")
+
+// InjectionMatch is a likely prompt-injection pattern found in a node.
+// Field is "label" or the property path, such as "properties.notes".
+type InjectionMatch struct {
+	Field   string `json:"field"`
+	Pattern string `json:"pattern"`
+}
+
+// QuarantinedNode is a node flagged by the prompt-injection scanner. It is
+// left out of recall packs and graph context, summaries, and subgraphs until
+// an admin reviews and releases it.
+type QuarantinedNode struct {
+	NodeID    string    `json:"node_id"`
+	Type      string    `json:"type"`
+	Label     string    `json:"label"`
+	Field     string    `json:"field"`
+	Pattern   string    `json:"pattern"`
+	FlaggedAt time.Time `json:"flagged_at"`
+}
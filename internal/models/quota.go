@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaExceeded is returned when a write would take a tenant past one of
+// its quotas. Errors wrapping it name the limit.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// TenantQuota is a tenant's resource limits. Nil fields are unlimited. The
+// monthly token budgets cap embedding and LLM tokens per calendar month
+// (UTC).
+type TenantQuota struct {
+	MaxNodes                  *int64 `json:"max_nodes,omitempty"`
+	MaxEdges                  *int64 `json:"max_edges,omitempty"`
+	MaxStorageBytes           *int64 `json:"max_storage_bytes,omitempty"`
+	MaxMonthlyEmbeddingTokens *int64 `json:"max_monthly_embedding_tokens,omitempty"`
+	MaxMonthlyLLMTokens       *int64 `json:"max_monthly_llm_tokens,omitempty"`
+}
+
+// Unlimited reports whether no limit is set.
+func (q *TenantQuota) Unlimited() bool {
+	return q.MaxNodes == nil && q.MaxEdges == nil && q.MaxStorageBytes == nil &&
+		q.MaxMonthlyEmbeddingTokens == nil && q.MaxMonthlyLLMTokens == nil
+}
+
+// TokenBudget returns the monthly token budget for kind, nil if unlimited.
+func (q *TenantQuota) TokenBudget(kind string) *int64 {
+	switch kind {
+	case ModelUsageEmbedding:
+		return q.MaxMonthlyEmbeddingTokens
+	case ModelUsageLLM:
+		return q.MaxMonthlyLLMTokens
+	}
+
+	return nil
+}
+
+// TenantUsage is a tenant's current consumption and its quota. StorageBytes
+// is the on-disk size of the tenant's node and edge rows. Embedding and LLM
+// are this month's model usage; Month is its first day (UTC), YYYY-MM-DD.
+type TenantUsage struct {
+	Nodes        int64       `json:"nodes"`
+	Edges        int64       `json:"edges"`
+	StorageBytes int64       `json:"storage_bytes"`
+	Month        string      `json:"month"`
+	Embedding    ModelUsage  `json:"embedding"`
+	LLM          ModelUsage  `json:"llm"`
+	Quota        TenantQuota `json:"quota"`
+}
+
+// Kinds of model usage.
+const (
+	ModelUsageEmbedding = "embedding"
+	ModelUsageLLM       = "llm"
+)
+
+// ModelUsage is the requests made to a model provider and the tokens they
+// consumed.
+type ModelUsage struct {
+	Requests int64 `json:"requests"`
+	Tokens   int64 `json:"tokens"`
+}
+
+// ReportModelUsageRequest reports LLM usage a client incurred on the
+// tenant's behalf, such as enrichment during ingest.
+type ReportModelUsageRequest struct {
+	Requests int64 `json:"requests"`
+	Tokens   int64 `json:"tokens"`
+}
+
+// Validate checks that the counts are non-negative and not both zero.
+func (r *ReportModelUsageRequest) Validate() error {
+	if r.Requests < 0 || r.Tokens < 0 {
+		return errors.New("requests and tokens must not be negative")
+	}
+
+	if r.Requests == 0 && r.Tokens == 0 {
+		return errors.New("requests or tokens is required")
+	}
+
+	return nil
+}
+
+// TokenBudgetExceeded returns the error for a tenant that has used its
+// monthly budget of kind tokens.
+func TokenBudgetExceeded(kind string, budget int64) error {
+	return fmt.Errorf("%w: tenant is limited to %d %s tokens per month", ErrQuotaExceeded, budget, kind)
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Re-embed run statuses.
+const (
+	ReembedRunning   = "running"
+	ReembedCompleted = "completed"
+	ReembedFailed    = "failed"
+)
+
+// ReembedStatus reports a tenant's re-embed run, which regenerates every
+// embedding not produced by the target model.
+type ReembedStatus struct {
+	TenantID         string     `json:"-"`
+	TargetModel      string     `json:"target_model"`
+	TargetDimensions int        `json:"target_dimensions"`
+	Status           string     `json:"status"`
+	Total            int        `json:"total"`
+	Processed        int        `json:"processed"`
+	Failed           int        `json:"failed"`
+	Remaining        int        `json:"remaining"`
+	LastNodeID       string     `json:"-"`
+	Error            string     `json:"error,omitempty"`
+	StartedAt        time.Time  `json:"started_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxRelationRules caps the number of rules in a tenant's relation catalog.
+const MaxRelationRules = 1000
+
+// AnyNodeType in a rule's source or target type matches every node type.
+const AnyNodeType = "*"
+
+// ErrRelationNotAllowed indicates an edge whose (source type, relation,
+// target type) triple is not in the tenant's enforced relation catalog.
+var ErrRelationNotAllowed = errors.New("relation not allowed by catalog")
+
+// RelationRule allows edges with Relation from nodes of SourceType to nodes
+// of TargetType. Either type may be AnyNodeType.
+type RelationRule struct {
+	SourceType string `json:"source_type"`
+	Relation   string `json:"relation"`
+	TargetType string `json:"target_type"`
+}
+
+// RelationCatalog is a tenant's declared relation triples. When Enforce is
+// set, creating an edge that matches no rule fails with
+// ErrRelationNotAllowed. With Enforce unset the catalog is documentation
+// only.
+type RelationCatalog struct {
+	Enforce bool           `json:"enforce"`
+	Rules   []RelationRule `json:"rules"`
+}
+
+// Validate normalizes the rules, checks their fields, and rejects
+// duplicates.
+func (c *RelationCatalog) Validate() error {
+	if len(c.Rules) > MaxRelationRules {
+		return fmt.Errorf("rules exceeds maximum of %d", MaxRelationRules)
+	}
+
+	seen := make(map[RelationRule]int, len(c.Rules))
+
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		r.SourceType = NormalizeText(r.SourceType)
+		r.Relation = NormalizeText(r.Relation)
+		r.TargetType = NormalizeText(r.TargetType)
+
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		if first, ok := seen[*r]; ok {
+			return fmt.Errorf("rule %d: duplicate of rule %d", i, first)
+		}
+
+		seen[*r] = i
+	}
+
+	return nil
+}
+
+func (r *RelationRule) validate() error {
+	for _, f := range []struct {
+		name, value string
+		maxLen      int
+	}{
+		{"source_type", r.SourceType, 100},
+		{"relation", r.Relation, 255},
+		{"target_type", r.TargetType, 100},
+	} {
+		if f.value == "" {
+			return fmt.Errorf("%s is required", f.name)
+		}
+
+		if tooLong(f.value, f.maxLen) {
+			return ErrFieldTooLong(f.name, f.maxLen)
+		}
+	}
+
+	if r.Relation == AnyNodeType {
+		return errors.New("relation cannot be a wildcard")
+	}
+
+	return nil
+}
+
+// RelationUsage counts the edges with one (source type, relation, target
+// type) triple. Declared reports whether a catalog rule allows it.
+type RelationUsage struct {
+	SourceType string `json:"source_type"`
+	Relation   string `json:"relation"`
+	TargetType string `json:"target_type"`
+	Count      int64  `json:"count"`
+	Declared   bool   `json:"declared"`
+}
@@ -0,0 +1,52 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestRelationCatalog_Validate(t *testing.T) {
+	rule := func(source, relation, target string) models.RelationRule {
+		return models.RelationRule{SourceType: source, Relation: relation, TargetType: target}
+	}
+
+	tests := []struct {
+		name    string
+		rules   []models.RelationRule
+		wantErr string
+	}{
+		{"empty", nil, ""},
+		{"valid", []models.RelationRule{rule("person", "works_at", "organization"), rule("*", "mentions", "*")}, ""},
+		{"missing source type", []models.RelationRule{rule("", "works_at", "organization")}, "rule 0: source_type is required"},
+		{"missing relation", []models.RelationRule{rule("person", "", "organization")}, "relation is required"},
+		{"wildcard relation", []models.RelationRule{rule("person", "*", "organization")}, "wildcard"},
+		{"long type", []models.RelationRule{rule(strings.Repeat("x", 101), "works_at", "organization")}, "maximum length"},
+		{
+			"duplicate after normalizing",
+			[]models.RelationRule{rule("person", "visited", "caf\u00e9"), rule("person", "visited", "cafe\u0301")},
+			"rule 1: duplicate of rule 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := models.RelationCatalog{Rules: tt.rules}
+
+			err := c.Validate()
+			if tt.wantErr == "" {
+				assertNoError(t, err)
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	tooMany := models.RelationCatalog{Rules: make([]models.RelationRule, models.MaxRelationRules+1)}
+	if err := tooMany.Validate(); err == nil {
+		t.Error("expected error for too many rules")
+	}
+}
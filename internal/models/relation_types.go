@@ -51,6 +51,8 @@ type RelationType struct {
 	"achieved":      "A achieved B",
 	"detected_in":   "A was detected in B",
 	"experienced":   "A experienced B",
+	"subclass_of":   "A is a subclass of B",
+	"instance_of":   "A is an instance of B",
 }
 
 // mu guards customRelationTypes for concurrent access.
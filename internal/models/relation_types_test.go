@@ -31,9 +31,9 @@ func TestIsCanonicalRelation(t *testing.T) {
 func TestListRelationTypes(t *testing.T) {
 	types := models.ListRelationTypes()
 
-	// Should include at least the 37 canonical types.
-	if len(types) < 37 {
-		t.Errorf("ListRelationTypes() returned %d types, want >= 37", len(types))
+	// Should include at least the 39 canonical types.
+	if len(types) < 39 {
+		t.Errorf("ListRelationTypes() returned %d types, want >= 39", len(types))
 	}
 
 	// Verify sorted order.
@@ -54,7 +54,7 @@ func (r RetrievalFeedbackRequest) Validate() error {
 	if strings.TrimSpace(r.Query) == "" {
 		return fmt.Errorf("query is required")
 	}
-	if len(r.Query) > 500 {
+	if tooLong(r.Query, 500) {
 		return fmt.Errorf("query exceeds 500 characters")
 	}
 	switch normalizeRetrievalOutcome(r.Outcome) {
@@ -62,7 +62,7 @@ func (r RetrievalFeedbackRequest) Validate() error {
 	default:
 		return fmt.Errorf("outcome must be helpful, unhelpful, or missed")
 	}
-	if len(r.Note) > 500 {
+	if tooLong(r.Note, 500) {
 		return fmt.Errorf("note exceeds 500 characters")
 	}
 	if len(r.RetrievedNodeIDs) > 20 || len(r.SelectedNodeIDs) > 20 || len(r.ExpectedNodeIDs) > 20 {
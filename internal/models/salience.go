@@ -1,6 +1,21 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Salience formula terms. These mirror the SQL used when scores are recalculated.
+const (
+	SalienceBase             = 1.0
+	SalienceFloor            = 0.1
+	SalienceAccessWeight     = 0.3
+	SalienceRecencyWeight    = 0.5
+	SalienceRecencyWindow    = 180 * 24 * time.Hour
+	SalienceBoostBonus       = 2.0
+	SalienceSupersedePenalty = 0.5
+)
 
 // SupersedeRequest represents a request to supersede one node with another.
 type SupersedeRequest struct {
@@ -18,13 +33,73 @@ func (r *SupersedeRequest) Validate() error {
 		return fmt.Errorf("old_id and new_id must be different")
 	}
 
-	if len(r.OldID) > 255 {
+	if tooLong(r.OldID, 255) {
 		return ErrFieldTooLong("old_id", 255)
 	}
 
-	if len(r.NewID) > 255 {
+	if tooLong(r.NewID, 255) {
 		return ErrFieldTooLong("new_id", 255)
 	}
 
 	return nil
 }
+
+// SalienceExplanation breaks a node's salience score into the terms of the
+// scoring formula.
+type SalienceExplanation struct {
+	Base       float64   `json:"base"`
+	Access     float64   `json:"access"`
+	Recency    float64   `json:"recency"`
+	Boost      float64   `json:"boost"`
+	Supersede  float64   `json:"supersede"`
+	Computed   float64   `json:"computed"`
+	Stored     float64   `json:"stored"`
+	LastActive time.Time `json:"last_active"`
+}
+
+// ExplainSalience computes each salience term for n as of now. Stored is the
+// score last persisted, which lags Computed until the next access or recalc.
+func ExplainSalience(n *Node, now time.Time) SalienceExplanation {
+	lastActive := n.CreatedAt
+	if n.LastAccessed != nil {
+		lastActive = *n.LastAccessed
+	}
+
+	e := SalienceExplanation{
+		Base:       SalienceBase,
+		Access:     math.Log2(float64(n.AccessCount)+1) * SalienceAccessWeight,
+		Recency:    math.Max(0, 1-float64(now.Sub(lastActive))/float64(SalienceRecencyWindow)) * SalienceRecencyWeight,
+		Stored:     n.Salience,
+		LastActive: lastActive,
+	}
+
+	if n.UserBoosted {
+		e.Boost = SalienceBoostBonus
+	}
+
+	if n.SupersededBy != nil {
+		e.Supersede = -SalienceSupersedePenalty
+	}
+
+	e.Computed = math.Max(SalienceFloor, e.Base+e.Access+e.Recency+e.Boost+e.Supersede)
+
+	return e
+}
+
+// SalienceEntry is a node's salience standing, without its properties, for
+// the top and decaying reports. Previous and Change are set only in the
+// decaying report: the score before the last recalculation and how much it
+// moved since.
+type SalienceEntry struct {
+	NodeID       string     `json:"node_id"`
+	Type         string     `json:"type"`
+	Label        string     `json:"label"`
+	Salience     float64    `json:"salience_score"`
+	AccessCount  int        `json:"access_count"`
+	LastAccessed *time.Time `json:"last_accessed,omitempty"`
+	UserBoosted  bool       `json:"user_boosted"`
+	SupersededBy *string    `json:"superseded_by,omitempty"`
+	Previous     *float64   `json:"previous,omitempty"`
+	Change       *float64   `json:"change,omitempty"`
+	SnapshotAt   *time.Time `json:"snapshot_at,omitempty"`
+}
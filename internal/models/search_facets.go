@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SalienceFacetCeiling is the floor of the top salience facet bucket. Below
+// it, buckets are one salience point wide; the top bucket holds every node at
+// or above it.
+const SalienceFacetCeiling = 5
+
+// SearchFacets counts the nodes matching a full-text search, before its
+// limit, by type, salience, and creation month.
+type SearchFacets struct {
+	Total    int              `json:"total"`
+	Types    []FacetCount     `json:"types"`
+	Salience []SalienceBucket `json:"salience"`
+	Months   []MonthCount     `json:"months"`
+}
+
+// FacetCount is the number of matching nodes with one value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SalienceBucket is the number of matching nodes with a salience score in
+// [Min, Max). Max is nil for the open-ended top bucket.
+type SalienceBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int      `json:"count"`
+}
+
+// MonthCount is the number of matching nodes created in the calendar month
+// starting at Month, in UTC.
+type MonthCount struct {
+	Month time.Time `json:"month"`
+	Count int       `json:"count"`
+}
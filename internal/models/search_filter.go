@@ -0,0 +1,53 @@
+package models
+
+// SearchFilter narrows search to nodes of one type at or above a salience
+// floor, optionally matching props filters and near a root node. The zero
+// value matches every node that has not been superseded.
+type SearchFilter struct {
+	Type              string
+	MinSalience       float64
+	IncludeSuperseded bool
+	// Properties keeps only nodes whose searchable properties match every
+	// filter.
+	Properties []PropertyFilter
+	// Neighborhood keeps only nodes within its depth of its root.
+	Neighborhood Neighborhood
+	// OmitProperties returns the matches with nil properties, skipping their
+	// decryption. It does not narrow the search.
+	OmitProperties bool
+}
+
+// Matches reports whether n passes the type, salience, and supersession
+// conditions of the filter. Only the store can check Properties and
+// Neighborhood; see StoreOnly.
+func (f SearchFilter) Matches(n *Node) bool {
+	if f.Type != "" && n.Type != f.Type {
+		return false
+	}
+
+	if f.MinSalience > 0 && n.Salience < f.MinSalience {
+		return false
+	}
+
+	return f.IncludeSuperseded || n.SupersededBy == nil
+}
+
+// StoreOnly reports whether the filter has props filters or a neighborhood,
+// which Matches cannot check, so nodes found outside the store's search must
+// not be added to the results.
+func (f SearchFilter) StoreOnly() bool {
+	return len(f.Properties) > 0 || f.Neighborhood.Root != ""
+}
+
+// Neighborhood search depth limits, in hops.
+const (
+	DefaultNeighborhoodDepth = 2
+	MaxNeighborhoodDepth     = 5
+)
+
+// Neighborhood restricts search to nodes within Depth hops of Root, following
+// edges in either direction. The zero value does not restrict search.
+type Neighborhood struct {
+	Root  string
+	Depth int
+}
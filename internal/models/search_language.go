@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultSearchLanguage is the text search configuration used when language
+// detection is off or inconclusive.
+const DefaultSearchLanguage = "english"
+
+// minLanguageHits is how many stopwords of one language a text must contain
+// before it is indexed as that language rather than the default.
+const minLanguageHits = 2
+
+// searchLanguageStopwords holds the most frequent function words of each
+// Latin-script language PostgreSQL ships a text search configuration for.
+// Words shared by several languages still count for each of them; the
+// language with the most hits wins.
+var searchLanguageStopwords = map[string][]string{
+	"english":    {"the", "and", "of", "to", "in", "is", "that", "for", "it", "with", "was", "on", "are", "this", "by", "from", "at", "have", "has", "which"},
+	"german":     {"der", "die", "und", "das", "ist", "nicht", "mit", "den", "von", "sich", "des", "auf", "für", "ein", "eine", "dem", "zu", "auch", "wird", "wurde"},
+	"french":     {"le", "la", "les", "et", "des", "est", "une", "du", "dans", "pour", "qui", "que", "pas", "sur", "avec", "au", "sont", "aux", "ce", "il"},
+	"spanish":    {"el", "los", "las", "y", "del", "es", "una", "por", "con", "para", "que", "en", "se", "su", "al", "lo", "como", "pero", "fue", "sus"},
+	"italian":    {"il", "di", "che", "e", "della", "per", "non", "sono", "gli", "una", "con", "del", "nel", "alla", "le", "è", "anche", "come", "dei", "più"},
+	"portuguese": {"o", "os", "da", "do", "das", "dos", "não", "uma", "em", "para", "com", "que", "é", "por", "mais", "foi", "ao", "na", "no", "se"},
+	"dutch":      {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "te", "zijn", "voor", "met", "ook", "wordt", "naar", "maar", "bij", "er", "heeft"},
+	"swedish":    {"och", "att", "det", "som", "en", "är", "av", "för", "med", "till", "inte", "har", "om", "ett", "var", "på", "den", "jag", "men", "de"},
+	"danish":     {"og", "det", "som", "er", "af", "med", "til", "ikke", "har", "et", "på", "jeg", "fra", "blev", "noget", "hvad", "meget", "efter", "nogle", "kun"},
+	"norwegian":  {"og", "det", "som", "er", "av", "med", "til", "ikke", "har", "et", "på", "jeg", "fra", "ble", "noe", "hva", "mye", "etter", "noen", "bare"},
+	"finnish":    {"ja", "on", "ei", "se", "että", "oli", "hän", "mutta", "kun", "niin", "kuin", "ole", "myös", "tai", "jos", "ovat", "joka", "sen", "ne", "vain"},
+}
+
+// SearchLanguages lists the text search configurations DetectSearchLanguage
+// can return, default first.
+var SearchLanguages = []string{
+	"english", "german", "french", "spanish", "italian", "portuguese",
+	"dutch", "swedish", "danish", "norwegian", "finnish", "russian",
+}
+
+// stopwordLanguages maps each stopword to the languages it belongs to.
+var stopwordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range searchLanguageStopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+
+	return index
+}()
+
+// DetectSearchLanguage returns the PostgreSQL text search configuration best
+// suited to text. Mostly-Cyrillic text is Russian; Latin-script text is
+// matched against each language's stopwords. Short or ambiguous text gets
+// DefaultSearchLanguage.
+func DetectSearchLanguage(text string) string {
+	var cyrillic, letters int
+
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+
+			if unicode.Is(unicode.Cyrillic, r) {
+				cyrillic++
+			}
+		}
+	}
+
+	if letters > 0 && cyrillic*2 > letters {
+		return "russian"
+	}
+
+	hits := make(map[string]int)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		for _, lang := range stopwordLanguages[word] {
+			hits[lang]++
+		}
+	}
+
+	best, bestHits := DefaultSearchLanguage, hits[DefaultSearchLanguage]
+
+	for _, lang := range SearchLanguages {
+		if hits[lang] > bestHits {
+			best, bestHits = lang, hits[lang]
+		}
+	}
+
+	if bestHits < minLanguageHits {
+		return DefaultSearchLanguage
+	}
+
+	return best
+}
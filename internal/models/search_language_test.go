@@ -0,0 +1,32 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestDetectSearchLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"", "english"},
+		{"Alice", "english"},
+		{"The project is owned by the platform team and was shipped in May.", "english"},
+		{"Das Projekt wird von dem Team in Berlin betreut und ist nicht öffentlich.", "german"},
+		{"Le projet est géré par une équipe à Paris et les données sont dans le cloud.", "french"},
+		{"El proyecto es de los equipos de Madrid y se publica con las notas del mes.", "spanish"},
+		{"Il progetto della squadra non è pubblico e gli accessi sono per il team.", "italian"},
+		{"Het project van de afdeling is niet openbaar en wordt door een team beheerd.", "dutch"},
+		{"Проект ведёт команда из Москвы", "russian"},
+		{"Prosjektet ble flyttet etter noe arbeid, og det er mye igjen.", "norwegian"},
+		{"Projektet blev flyttet efter noget arbejde, og der er meget tilbage.", "danish"},
+	}
+
+	for _, tt := range tests {
+		if got := models.DetectSearchLanguage(tt.text); got != tt.want {
+			t.Errorf("DetectSearchLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// SecurityBlock is an API key locked out by the brute-force guard. Only a
+// prefix of the SHA-256 hash of the key is exposed.
+type SecurityBlock struct {
+	KeyHash        string    `json:"key_hash"`
+	Attempts       int       `json:"attempts"`
+	FirstFailureAt time.Time `json:"first_failure_at"`
+	BlockedUntil   time.Time `json:"blocked_until"`
+}
@@ -0,0 +1,46 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestParseSeedFile(t *testing.T) {
+	yamlFile, err := models.ParseSeedFile("base.yml", []byte(`
+tenant: acme
+nodes:
+  - id: person
+    type: concept
+    label: Person
+    properties: {plural: people}
+edges:
+  - {source: person, target: person, relation: knows, weight: 0.5}
+`))
+	if err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if yamlFile.Tenant != "acme" || len(yamlFile.Nodes) != 1 || yamlFile.Nodes[0].Properties["plural"] != "people" {
+		t.Errorf("unexpected yaml file: %+v", yamlFile)
+	}
+	if len(yamlFile.Edges) != 1 || *yamlFile.Edges[0].Weight != 0.5 {
+		t.Errorf("unexpected yaml edges: %+v", yamlFile.Edges)
+	}
+
+	if _, err := models.ParseSeedFile("base.json", []byte(`{"nodes": [{"id": "a", "type": "t", "label": "A"}]}`)); err != nil {
+		t.Errorf("json: %v", err)
+	}
+
+	if _, err := models.ParseSeedFile("bad.json", []byte(`{"node": []}`)); err == nil {
+		t.Error("expected an unknown field to be rejected")
+	}
+
+	if _, err := models.ParseSeedFile("bad.yaml", []byte("nodes:\n  - {type: t, label: A}\n")); !errors.Is(err, models.ErrSeedNodeID) {
+		t.Errorf("err = %v, want ErrSeedNodeID", err)
+	}
+
+	if models.IsSeedFile("notes.txt") || !models.IsSeedFile("BASE.YAML") {
+		t.Error("IsSeedFile matched the wrong extensions")
+	}
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Snapshot limits.
+const (
+	maxSnapshotNameLen        = 128
+	maxSnapshotDescriptionLen = 1000
+	maxSnapshotExportRefLen   = 2048
+)
+
+// Snapshot drift statuses.
+const (
+	DriftAdded     = "added"
+	DriftRemoved   = "removed"
+	DriftChanged   = "changed"
+	DriftUnchanged = "unchanged"
+)
+
+var (
+	// ErrSnapshotNotFound indicates the tenant has no snapshot with the name.
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+	// ErrSnapshotExists indicates the tenant already has a snapshot with the
+	// name. Snapshots are immutable; delete the old one to reuse its name.
+	ErrSnapshotExists = errors.New("snapshot already exists")
+)
+
+// CreateSnapshotRequest names a snapshot of the tenant's graph as it is now.
+// ExportRef optionally records where a full export taken alongside it is
+// kept; the server does not read it.
+type CreateSnapshotRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ExportRef   string `json:"export_ref"`
+}
+
+// Validate checks the name and field lengths.
+func (r *CreateSnapshotRequest) Validate() error {
+	if err := ValidateSnapshotName(r.Name); err != nil {
+		return err
+	}
+
+	if tooLong(r.Description, maxSnapshotDescriptionLen) {
+		return ErrFieldTooLong("description", maxSnapshotDescriptionLen)
+	}
+
+	if tooLong(r.ExportRef, maxSnapshotExportRefLen) {
+		return ErrFieldTooLong("export_ref", maxSnapshotExportRefLen)
+	}
+
+	return nil
+}
+
+// ValidateSnapshotName checks a snapshot name: required, at most 128
+// characters.
+func ValidateSnapshotName(name string) error {
+	if name == "" {
+		return fmt.Errorf("snapshot name is required")
+	}
+
+	if tooLong(name, maxSnapshotNameLen) {
+		return ErrFieldTooLong("name", maxSnapshotNameLen)
+	}
+
+	return nil
+}
+
+// SnapshotGroup is the number of nodes of one type, or edges of one
+// relation, and a hash of their IDs, labels, and update times. The hash
+// changes when any of them is added, removed, or edited.
+type SnapshotGroup struct {
+	Count int64  `json:"count"`
+	Hash  string `json:"hash"`
+}
+
+// Snapshot is a named logical snapshot of a tenant's graph: counts and
+// content hashes per node type and relation, not the data itself.
+type Snapshot struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	ExportRef   string                   `json:"export_ref,omitempty"`
+	NodeCount   int64                    `json:"node_count"`
+	EdgeCount   int64                    `json:"edge_count"`
+	Types       map[string]SnapshotGroup `json:"types"`
+	Relations   map[string]SnapshotGroup `json:"relations"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// SnapshotDrift is how one node type or relation changed between two
+// snapshots.
+type SnapshotDrift struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	FromCount int64  `json:"from_count"`
+	ToCount   int64  `json:"to_count"`
+	Delta     int64  `json:"delta"`
+}
+
+// DriftSummary counts the node types or relations by drift status.
+type DriftSummary struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// SnapshotComparison is the drift from one snapshot to another. Changed is
+// the share of node types and relations, present in either snapshot, whose
+// content differs: 0 for identical graphs, 1 when nothing is unchanged.
+type SnapshotComparison struct {
+	From            string          `json:"from"`
+	To              string          `json:"to"`
+	NodeDelta       int64           `json:"node_delta"`
+	EdgeDelta       int64           `json:"edge_delta"`
+	Changed         float64         `json:"changed"`
+	TypeSummary     DriftSummary    `json:"type_summary"`
+	RelationSummary DriftSummary    `json:"relation_summary"`
+	Types           []SnapshotDrift `json:"types"`
+	Relations       []SnapshotDrift `json:"relations"`
+}
+
+// CompareSnapshots returns the drift from snapshot from to snapshot to.
+func CompareSnapshots(from, to *Snapshot) *SnapshotComparison {
+	c := &SnapshotComparison{
+		From:      from.Name,
+		To:        to.Name,
+		NodeDelta: to.NodeCount - from.NodeCount,
+		EdgeDelta: to.EdgeCount - from.EdgeCount,
+	}
+
+	c.Types, c.TypeSummary = compareGroups(from.Types, to.Types)
+	c.Relations, c.RelationSummary = compareGroups(from.Relations, to.Relations)
+
+	total := len(c.Types) + len(c.Relations)
+	if total > 0 {
+		c.Changed = float64(total-c.TypeSummary.Unchanged-c.RelationSummary.Unchanged) / float64(total)
+	}
+
+	return c
+}
+
+// compareGroups returns the drift of every group in either map, ordered by
+// name, and the count per status.
+func compareGroups(from, to map[string]SnapshotGroup) ([]SnapshotDrift, DriftSummary) {
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	drift := make([]SnapshotDrift, 0, len(names))
+
+	var summary DriftSummary
+
+	for _, name := range names {
+		f, inFrom := from[name]
+		t, inTo := to[name]
+		d := SnapshotDrift{Name: name, FromCount: f.Count, ToCount: t.Count, Delta: t.Count - f.Count}
+
+		switch {
+		case !inFrom:
+			d.Status = DriftAdded
+			summary.Added++
+		case !inTo:
+			d.Status = DriftRemoved
+			summary.Removed++
+		case f.Hash != t.Hash:
+			d.Status = DriftChanged
+			summary.Changed++
+		default:
+			d.Status = DriftUnchanged
+			summary.Unchanged++
+		}
+
+		drift = append(drift, d)
+	}
+
+	return drift, summary
+}
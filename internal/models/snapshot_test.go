@@ -0,0 +1,65 @@
+package models
+
+import "testing"
+
+func TestCompareSnapshots(t *testing.T) {
+	from := &Snapshot{
+		Name:      "pre-experiment",
+		NodeCount: 5,
+		EdgeCount: 2,
+		Types: map[string]SnapshotGroup{
+			"person":  {Count: 3, Hash: "a"},
+			"project": {Count: 2, Hash: "b"},
+		},
+		Relations: map[string]SnapshotGroup{"works_on": {Count: 2, Hash: "c"}},
+	}
+	to := &Snapshot{
+		Name:      "post-experiment",
+		NodeCount: 6,
+		EdgeCount: 3,
+		Types: map[string]SnapshotGroup{
+			"person":  {Count: 3, Hash: "a"},
+			"project": {Count: 1, Hash: "d"},
+			"city":    {Count: 2, Hash: "e"},
+		},
+		Relations: map[string]SnapshotGroup{"located_in": {Count: 3, Hash: "f"}},
+	}
+
+	c := CompareSnapshots(from, to)
+
+	if c.NodeDelta != 1 || c.EdgeDelta != 1 {
+		t.Errorf("deltas = %d nodes, %d edges; want 1, 1", c.NodeDelta, c.EdgeDelta)
+	}
+
+	wantTypes := []SnapshotDrift{
+		{Name: "city", Status: DriftAdded, ToCount: 2, Delta: 2},
+		{Name: "person", Status: DriftUnchanged, FromCount: 3, ToCount: 3},
+		{Name: "project", Status: DriftChanged, FromCount: 2, ToCount: 1, Delta: -1},
+	}
+	if len(c.Types) != len(wantTypes) {
+		t.Fatalf("Types = %+v, want %+v", c.Types, wantTypes)
+	}
+
+	for i, want := range wantTypes {
+		if c.Types[i] != want {
+			t.Errorf("Types[%d] = %+v, want %+v", i, c.Types[i], want)
+		}
+	}
+
+	if c.TypeSummary != (DriftSummary{Added: 1, Changed: 1, Unchanged: 1}) {
+		t.Errorf("TypeSummary = %+v", c.TypeSummary)
+	}
+
+	if c.RelationSummary != (DriftSummary{Added: 1, Removed: 1}) {
+		t.Errorf("RelationSummary = %+v", c.RelationSummary)
+	}
+
+	// Four of the five types and relations changed.
+	if c.Changed != 0.8 {
+		t.Errorf("Changed = %v, want 0.8", c.Changed)
+	}
+
+	if same := CompareSnapshots(from, from); same.Changed != 0 {
+		t.Errorf("comparing a snapshot with itself: Changed = %v, want 0", same.Changed)
+	}
+}
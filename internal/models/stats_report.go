@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Stats report kinds.
+const (
+	StatsReportNodes = "nodes"
+	StatsReportEdges = "edges"
+)
+
+// statsReportGroupings lists what each report kind can be grouped by; the
+// first entry is the default.
+var statsReportGroupings = map[string][]string{
+	StatsReportNodes: {"type"},
+	StatsReportEdges: {"relation"},
+}
+
+// StatsReportRequest selects a stats report: which records to summarize and
+// what to group them by.
+type StatsReportRequest struct {
+	Kind    string `json:"type"`
+	GroupBy string `json:"group_by"`
+}
+
+// Validate checks the kind and grouping and applies defaults.
+func (r *StatsReportRequest) Validate() error {
+	if r.Kind == "" {
+		r.Kind = StatsReportNodes
+	}
+
+	groupings, ok := statsReportGroupings[r.Kind]
+	if !ok {
+		return fmt.Errorf("type must be %s or %s", StatsReportNodes, StatsReportEdges)
+	}
+
+	if r.GroupBy == "" {
+		r.GroupBy = groupings[0]
+	}
+
+	for _, g := range groupings {
+		if r.GroupBy == g {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s can be grouped by %v", r.Kind, groupings)
+}
+
+// StatsReport summarizes a tenant's nodes or edges per group.
+type StatsReport struct {
+	Kind    string             `json:"type"`
+	GroupBy string             `json:"group_by"`
+	Groups  []StatsReportGroup `json:"groups"`
+	Growth  []StatsGrowth      `json:"growth"`
+}
+
+// StatsReportGroup is the count and salience spread of one group.
+type StatsReportGroup struct {
+	Key          string    `json:"key"`
+	Count        int       `json:"count"`
+	SalienceMin  float64   `json:"salience_min"`
+	SalienceAvg  float64   `json:"salience_avg"`
+	SalienceMax  float64   `json:"salience_max"`
+	FirstCreated time.Time `json:"first_created"`
+	LastCreated  time.Time `json:"last_created"`
+}
+
+// StatsGrowth is how many records of a group were created in a month,
+// formatted YYYY-MM.
+type StatsGrowth struct {
+	Month string `json:"month"`
+	Key   string `json:"key"`
+	Added int    `json:"added"`
+}
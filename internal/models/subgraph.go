@@ -0,0 +1,41 @@
+package models
+
+import "fmt"
+
+// MaxSubgraphNodeIDs caps the number of seed IDs accepted by a subgraph request.
+const MaxSubgraphNodeIDs = 500
+
+// SubgraphRequest asks for the induced subgraph over a set of node IDs.
+type SubgraphRequest struct {
+	NodeIDs []string `json:"node_ids"`
+	Expand  bool     `json:"expand,omitempty"`
+}
+
+// Validate checks that node_ids is present, bounded, and well-formed.
+func (r *SubgraphRequest) Validate() error {
+	if len(r.NodeIDs) == 0 {
+		return fmt.Errorf("node_ids is required")
+	}
+
+	if len(r.NodeIDs) > MaxSubgraphNodeIDs {
+		return fmt.Errorf("node_ids exceeds maximum of %d", MaxSubgraphNodeIDs)
+	}
+
+	for i, id := range r.NodeIDs {
+		if id == "" {
+			return fmt.Errorf("node_ids[%d] must not be empty", i)
+		}
+
+		if len(id) > 255 {
+			return ErrFieldTooLong(fmt.Sprintf("node_ids[%d]", i), 255)
+		}
+	}
+
+	return nil
+}
+
+// SubgraphResult holds the nodes and every edge among them.
+type SubgraphResult struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// TagsProperty is the node property holding the node's tags, a list of
+// strings.
+const TagsProperty = "tags"
+
+// Tag suggestion limits.
+const (
+	DefaultTagSuggestionLimit = 5
+	MaxTagSuggestionLimit     = 50
+	MaxTagLength              = 255
+)
+
+// ErrNodeNotEmbedded is returned when an operation needs a node's embedding
+// before the embedding worker has produced it.
+var ErrNodeNotEmbedded = errors.New("node has no embedding yet")
+
+// NodeTags returns the distinct, trimmed tags in a node's properties. Values
+// that are not strings, and tags longer than MaxTagLength, are ignored.
+func NodeTags(properties map[string]any) []string {
+	var raw []any
+	switch v := properties[TagsProperty].(type) {
+	case []any:
+		raw = v
+	case []string:
+		for _, s := range v {
+			raw = append(raw, s)
+		}
+	case string:
+		raw = []any{v}
+	}
+
+	seen := make(map[string]bool, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, v := range raw {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+
+		s = strings.TrimSpace(s)
+		if s == "" || len(s) > MaxTagLength || seen[s] {
+			continue
+		}
+
+		seen[s] = true
+		tags = append(tags, s)
+	}
+
+	return tags
+}
+
+// TagSuggestion is a tag ranked by how close its centroid is to a node's
+// embedding. NodeCount is how many nodes carried the tag when the centroid
+// was built.
+type TagSuggestion struct {
+	Tag       string  `json:"tag"`
+	Score     float64 `json:"score"`
+	NodeCount int     `json:"node_count"`
+}
+
+// TagSuggestions lists suggested tags for a node, best first. Tags the node
+// already has are left out.
+type TagSuggestions struct {
+	NodeID           string          `json:"node_id"`
+	Suggestions      []TagSuggestion `json:"suggestions"`
+	CentroidsBuiltAt *time.Time      `json:"centroids_built_at,omitempty"`
+}
+
+// TagCentroidRebuild summarizes a rebuild of a tenant's tag centroids.
+type TagCentroidRebuild struct {
+	Tags    int       `json:"tags"`
+	Nodes   int       `json:"nodes"`
+	BuiltAt time.Time `json:"built_at"`
+}
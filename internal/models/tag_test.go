@@ -0,0 +1,31 @@
+package models_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestNodeTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]any
+		want  []string
+	}{
+		{name: "no tags", props: map[string]any{"role": "engineer"}, want: []string{}},
+		{name: "decoded JSON list", props: map[string]any{"tags": []any{" infra ", "oncall", "infra", 3, ""}}, want: []string{"infra", "oncall"}},
+		{name: "string list", props: map[string]any{"tags": []string{"a", "b"}}, want: []string{"a", "b"}},
+		{name: "single string", props: map[string]any{"tags": "solo"}, want: []string{"solo"}},
+		{name: "too long", props: map[string]any{"tags": []any{strings.Repeat("x", models.MaxTagLength+1), "ok"}}, want: []string{"ok"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := models.NodeTags(tc.props); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("NodeTags(%v) = %q, want %q", tc.props, got, tc.want)
+			}
+		})
+	}
+}
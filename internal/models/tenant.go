@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Tenant field limits and defaults.
+const (
+	MaxTenantNameLength = 255
+	MaxTenantPlanLength = 50
+	DefaultTenantPlan   = "free"
+)
+
+// Tenant management errors.
+var (
+	// ErrTenantNotSuspended is returned when deleting a tenant that has not
+	// been suspended first.
+	ErrTenantNotSuspended = errors.New("tenant must be suspended before it is deleted")
+	// ErrOwnTenant is returned when an operator suspends or deletes the
+	// tenant it is authenticated as.
+	ErrOwnTenant = errors.New("cannot suspend or delete your own tenant")
+)
+
+// Tenant is a tenant as seen by an operator. Keys are never returned.
+type Tenant struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Plan        string      `json:"plan"`
+	Operator    bool        `json:"operator"`
+	SuspendedAt *time.Time  `json:"suspended_at,omitempty"`
+	Quota       TenantQuota `json:"quota"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// CreateTenantRequest is the payload for creating a tenant. Plan defaults to
+// free and Scope, the scope of the tenant's primary key, to admin so the new
+// tenant can manage its own keys.
+type CreateTenantRequest struct {
+	Name  string `json:"name"`
+	Plan  string `json:"plan,omitempty"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// Validate checks the fields and applies defaults.
+func (r *CreateTenantRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if tooLong(r.Name, MaxTenantNameLength) {
+		return ErrFieldTooLong("name", MaxTenantNameLength)
+	}
+
+	if r.Plan == "" {
+		r.Plan = DefaultTenantPlan
+	}
+
+	if tooLong(r.Plan, MaxTenantPlanLength) {
+		return ErrFieldTooLong("plan", MaxTenantPlanLength)
+	}
+
+	if r.Scope == "" {
+		r.Scope = APIKeyScopeAdmin
+	}
+
+	if !ValidAPIKeyScope(r.Scope) {
+		return errors.New("scope must be one of search, read, read_write, admin")
+	}
+
+	return nil
+}
+
+// UpdateTenantRequest changes a tenant's name, plan, or quotas. Nil fields
+// are left unchanged; a quota of 0 removes the limit.
+type UpdateTenantRequest struct {
+	Name                      *string `json:"name,omitempty"`
+	Plan                      *string `json:"plan,omitempty"`
+	MaxNodes                  *int64  `json:"max_nodes,omitempty"`
+	MaxEdges                  *int64  `json:"max_edges,omitempty"`
+	MaxStorageBytes           *int64  `json:"max_storage_bytes,omitempty"`
+	MaxMonthlyEmbeddingTokens *int64  `json:"max_monthly_embedding_tokens,omitempty"`
+	MaxMonthlyLLMTokens       *int64  `json:"max_monthly_llm_tokens,omitempty"`
+}
+
+// Validate checks that at least one field is set and within bounds.
+func (r *UpdateTenantRequest) Validate() error {
+	quotas := []*int64{r.MaxNodes, r.MaxEdges, r.MaxStorageBytes, r.MaxMonthlyEmbeddingTokens, r.MaxMonthlyLLMTokens}
+
+	if r.Name == nil && r.Plan == nil && !slices.ContainsFunc(quotas, func(q *int64) bool { return q != nil }) {
+		return errors.New("name, plan, or a quota is required")
+	}
+
+	for _, q := range quotas {
+		if q != nil && *q < 0 {
+			return errors.New("quotas must not be negative")
+		}
+	}
+
+	if r.Name != nil && (*r.Name == "" || tooLong(*r.Name, MaxTenantNameLength)) {
+		return fmt.Errorf("name must be 1 to %d characters", MaxTenantNameLength)
+	}
+
+	if r.Plan != nil && (*r.Plan == "" || tooLong(*r.Plan, MaxTenantPlanLength)) {
+		return fmt.Errorf("plan must be 1 to %d characters", MaxTenantPlanLength)
+	}
+
+	return nil
+}
+
+// CreatedTenant is returned once when a tenant is created. The primary API
+// key cannot be retrieved again.
+type CreatedTenant struct {
+	APIKey string `json:"api_key"`
+	Tenant
+}
+
+// TenantPurgeResult reports a deleted tenant and how many rows of its data
+// were removed.
+type TenantPurgeResult struct {
+	TenantID    string `json:"tenant_id"`
+	DeletedRows int64  `json:"deleted_rows"`
+}
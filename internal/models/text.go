@@ -0,0 +1,21 @@
+package models
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText returns s in Unicode Normalization Form C, so text typed
+// with combining characters and its precomposed equivalent are stored, and
+// searched, as the same string.
+func NormalizeText(s string) string {
+	return norm.NFC.String(s)
+}
+
+// tooLong reports whether s has more than maxLen characters. Limits count
+// runes, like the length() checks on the database columns, so an emoji or
+// accented letter counts once rather than once per byte.
+func tooLong(s string, maxLen int) bool {
+	return utf8.RuneCountInString(s) > maxLen
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Typed property values. JSON has a single number type and no timestamps or
+// binary data, so a plain round trip turns integers into float64, loses
+// precision past 2^53, and flattens times and bytes into strings. A property
+// value may instead be a tagged envelope such as
+//
+//	{"$type": "int", "value": "9007199254740993"}
+//
+// whose value is always a string. The server validates envelopes and indexes
+// their value for search but otherwise stores them, encrypted, verbatim.
+const (
+	TypedValueTag   = "$type"
+	TypedValueField = "value"
+
+	TypedInt    = "int"    // base-10 int64
+	TypedBigNum = "bignum" // arbitrary-precision decimal number
+	TypedTime   = "time"   // RFC 3339 timestamp with optional nanoseconds
+	TypedBytes  = "bytes"  // standard base64
+)
+
+// typedEnvelope reports whether v is an object tagged with $type, returning
+// its type and encoded value. wellFormed is false unless the object holds
+// exactly a string $type and a string value.
+func typedEnvelope(v any) (typ, value string, tagged, wellFormed bool) {
+	m, isMap := v.(map[string]any)
+	if !isMap {
+		return "", "", false, false
+	}
+
+	tag, hasTag := m[TypedValueTag]
+	if !hasTag {
+		return "", "", false, false
+	}
+
+	typ, typOK := tag.(string)
+	value, valueOK := m[TypedValueField].(string)
+
+	return typ, value, true, typOK && valueOK && len(m) == 2
+}
+
+// ValidateTypedValues checks every typed envelope in props, at any depth:
+// each must hold exactly $type and value, with a known type and a value that
+// parses as that type.
+func ValidateTypedValues(props map[string]any) error {
+	for key, v := range props {
+		if err := validateTypedValue(v); err != nil {
+			return fmt.Errorf("property %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func validateTypedValue(v any) error {
+	if typ, value, tagged, wellFormed := typedEnvelope(v); tagged {
+		if !wellFormed {
+			return fmt.Errorf("typed value must hold exactly a string %q and a string %q", TypedValueTag, TypedValueField)
+		}
+
+		_, err := decodeTypedValue(typ, value)
+
+		return err
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		return ValidateTypedValues(val)
+	case []any:
+		for _, item := range val {
+			if err := validateTypedValue(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// EncodeTypedValues returns a copy of props in which Go integers, big
+// numbers, times, and byte slices, at any depth, are replaced by typed
+// envelopes. Other values are copied as is.
+func EncodeTypedValues(props map[string]any) map[string]any {
+	if props == nil {
+		return nil
+	}
+
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		out[k] = encodeTypedValue(v)
+	}
+
+	return out
+}
+
+func encodeTypedValue(v any) any {
+	switch val := v.(type) {
+	case int:
+		return typedValue(TypedInt, strconv.FormatInt(int64(val), 10))
+	case int8:
+		return typedValue(TypedInt, strconv.FormatInt(int64(val), 10))
+	case int16:
+		return typedValue(TypedInt, strconv.FormatInt(int64(val), 10))
+	case int32:
+		return typedValue(TypedInt, strconv.FormatInt(int64(val), 10))
+	case int64:
+		return typedValue(TypedInt, strconv.FormatInt(val, 10))
+	case uint:
+		return encodeUint(uint64(val))
+	case uint8:
+		return encodeUint(uint64(val))
+	case uint16:
+		return encodeUint(uint64(val))
+	case uint32:
+		return encodeUint(uint64(val))
+	case uint64:
+		return encodeUint(val)
+	case *big.Int:
+		return typedValue(TypedBigNum, val.String())
+	case *big.Float:
+		return typedValue(TypedBigNum, val.Text('g', -1))
+	case time.Time:
+		return typedValue(TypedTime, val.Format(time.RFC3339Nano))
+	case []byte:
+		return typedValue(TypedBytes, base64.StdEncoding.EncodeToString(val))
+	case map[string]any:
+		return EncodeTypedValues(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = encodeTypedValue(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+func encodeUint(v uint64) map[string]any {
+	if v > math.MaxInt64 {
+		return typedValue(TypedBigNum, strconv.FormatUint(v, 10))
+	}
+
+	return typedValue(TypedInt, strconv.FormatUint(v, 10))
+}
+
+func typedValue(typ, value string) map[string]any {
+	return map[string]any{TypedValueTag: typ, TypedValueField: value}
+}
+
+// DecodeTypedValues returns a copy of props in which typed envelopes, at any
+// depth, are replaced by Go values: int64 for int, *big.Int or *big.Float
+// for bignum, time.Time for time, and []byte for bytes. Malformed envelopes
+// are left as they are.
+func DecodeTypedValues(props map[string]any) map[string]any {
+	if props == nil {
+		return nil
+	}
+
+	out := make(map[string]any, len(props))
+	for k, v := range props {
+		out[k] = decodeTypedTree(v)
+	}
+
+	return out
+}
+
+func decodeTypedTree(v any) any {
+	if typ, value, _, wellFormed := typedEnvelope(v); wellFormed {
+		if decoded, err := decodeTypedValue(typ, value); err == nil {
+			return decoded
+		}
+
+		return v
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		return DecodeTypedValues(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = decodeTypedTree(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+// decodeTypedValue parses value as typ.
+func decodeTypedValue(typ, value string) (any, error) {
+	switch typ {
+	case TypedInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", typ, value)
+		}
+
+		return n, nil
+	case TypedBigNum:
+		if n, ok := new(big.Int).SetString(value, 10); ok {
+			return n, nil
+		}
+
+		f, _, err := big.ParseFloat(value, 10, 256, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", typ, value)
+		}
+
+		return f, nil
+	case TypedTime:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", typ, value)
+		}
+
+		return t, nil
+	case TypedBytes:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value", typ)
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown typed value type %q", typ)
+	}
+}
@@ -0,0 +1,98 @@
+package models_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestTypedValues_RoundTrip(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	at := time.Date(2026, 3, 1, 12, 30, 0, 123456789, time.UTC)
+
+	props := map[string]any{
+		"count":  int64(9007199254740993),
+		"huge":   huge,
+		"at":     at,
+		"blob":   []byte{0, 1, 2, 255},
+		"nested": map[string]any{"n": 7, "list": []any{uint64(18446744073709551615)}},
+		"plain":  "text",
+	}
+
+	// Through JSON, as the server stores it.
+	data, err := json.Marshal(models.EncodeTypedValues(props))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var stored map[string]any
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if err := models.ValidateTypedValues(stored); err != nil {
+		t.Fatalf("ValidateTypedValues: %v", err)
+	}
+
+	got := models.DecodeTypedValues(stored)
+
+	if got["count"] != int64(9007199254740993) {
+		t.Errorf("count = %#v", got["count"])
+	}
+	if n, ok := got["huge"].(*big.Int); !ok || n.Cmp(huge) != 0 {
+		t.Errorf("huge = %#v", got["huge"])
+	}
+	if tm, ok := got["at"].(time.Time); !ok || !tm.Equal(at) {
+		t.Errorf("at = %#v", got["at"])
+	}
+	if b, ok := got["blob"].([]byte); !ok || !bytes.Equal(b, []byte{0, 1, 2, 255}) {
+		t.Errorf("blob = %#v", got["blob"])
+	}
+
+	nested := got["nested"].(map[string]any)
+	if nested["n"] != int64(7) {
+		t.Errorf("nested.n = %#v", nested["n"])
+	}
+	if n, ok := nested["list"].([]any)[0].(*big.Int); !ok || n.String() != "18446744073709551615" {
+		t.Errorf("nested.list[0] = %#v", nested["list"])
+	}
+	if got["plain"] != "text" {
+		t.Errorf("plain = %#v", got["plain"])
+	}
+}
+
+func TestValidateTypedValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		wantErr string
+	}{
+		{name: "int", value: map[string]any{"$type": "int", "value": "-42"}},
+		{name: "bignum decimal", value: map[string]any{"$type": "bignum", "value": "3.14159265358979323846264338327950288"}},
+		{name: "empty bytes", value: map[string]any{"$type": "bytes", "value": ""}},
+		{name: "unknown type", value: map[string]any{"$type": "uuid", "value": "x"}, wantErr: "unknown typed value type"},
+		{name: "bad int", value: map[string]any{"$type": "int", "value": "1.5"}, wantErr: "invalid int value"},
+		{name: "bad time", value: map[string]any{"$type": "time", "value": "yesterday"}, wantErr: "invalid time value"},
+		{name: "extra key", value: map[string]any{"$type": "int", "value": "1", "x": 1}, wantErr: "must hold exactly"},
+		{name: "non-string value", value: map[string]any{"$type": "int", "value": 1.0}, wantErr: "must hold exactly"},
+		{name: "nested in list", value: []any{map[string]any{"$type": "time", "value": "nope"}}, wantErr: "invalid time value"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := models.ValidateTypedValues(map[string]any{"p": tc.value})
+			if tc.wantErr == "" {
+				assertNoError(t, err)
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
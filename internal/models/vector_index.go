@@ -0,0 +1,137 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// Vector index methods pgvector supports.
+const (
+	VectorIndexHNSW    = "hnsw"
+	VectorIndexIVFFlat = "ivfflat"
+)
+
+// VectorIndexSeqScanRows is the embedded node count above which a missing,
+// invalid, or unused vector index is reported as a warning: below it a
+// sequential scan is as fast as the index.
+const VectorIndexSeqScanRows = 10000
+
+// ErrVectorIndexBuilding is returned when a vector index build is requested
+// while another is still running.
+var ErrVectorIndexBuilding = errors.New("a vector index build is already running")
+
+// VectorIndexRequest is the payload for building the node embedding index.
+// Method defaults to hnsw. Omitted parameters are sized from the number of
+// embedded nodes; M and EfConstruction apply to hnsw, Lists to ivfflat.
+type VectorIndexRequest struct {
+	Method         string `json:"method,omitempty"`
+	M              int    `json:"m,omitempty"`
+	EfConstruction int    `json:"ef_construction,omitempty"`
+	Lists          int    `json:"lists,omitempty"`
+}
+
+// Validate checks the method and that parameters are within pgvector's
+// limits and belong to the method.
+func (r *VectorIndexRequest) Validate() error {
+	switch r.Method {
+	case "", VectorIndexHNSW:
+		if r.Lists != 0 {
+			return errors.New("lists applies only to ivfflat")
+		}
+
+		if r.M != 0 && (r.M < 2 || r.M > 100) {
+			return errors.New("m must be between 2 and 100")
+		}
+
+		if r.EfConstruction != 0 && (r.EfConstruction < 4 || r.EfConstruction > 1000) {
+			return errors.New("ef_construction must be between 4 and 1000")
+		}
+
+		if r.M != 0 && r.EfConstruction != 0 && r.EfConstruction < 2*r.M {
+			return errors.New("ef_construction must be at least twice m")
+		}
+	case VectorIndexIVFFlat:
+		if r.M != 0 || r.EfConstruction != 0 {
+			return errors.New("m and ef_construction apply only to hnsw")
+		}
+
+		if r.Lists != 0 && (r.Lists < 1 || r.Lists > 32768) {
+			return errors.New("lists must be between 1 and 32768")
+		}
+	default:
+		return errors.New("method must be hnsw or ivfflat")
+	}
+
+	return nil
+}
+
+// WithDefaults returns the request with the method and omitted parameters
+// filled in for an index over rows embedded nodes. hnsw uses pgvector's
+// defaults (m 16, ef_construction 64) up to a million rows and m 32,
+// ef_construction 200 beyond; ivfflat uses rows/1000 lists up to a million
+// rows and sqrt(rows) beyond, as pgvector recommends. A validated request
+// stays valid.
+func (r VectorIndexRequest) WithDefaults(rows int64) VectorIndexRequest {
+	if r.Method == "" {
+		r.Method = VectorIndexHNSW
+	}
+
+	large := rows > 1_000_000
+
+	if r.Method == VectorIndexIVFFlat {
+		if r.Lists == 0 {
+			r.Lists = int(max(rows/1000, 1))
+			if large {
+				r.Lists = int(math.Sqrt(float64(rows)))
+			}
+		}
+
+		return r
+	}
+
+	if r.M == 0 {
+		r.M = 16
+		if large {
+			r.M = 32
+		}
+
+		// Keep an explicit ef_construction valid.
+		if r.EfConstruction != 0 {
+			r.M = min(r.M, r.EfConstruction/2)
+		}
+	}
+
+	if r.EfConstruction == 0 {
+		r.EfConstruction = max(64, 2*r.M)
+		if large {
+			r.EfConstruction = max(200, 2*r.M)
+		}
+	}
+
+	return r
+}
+
+// VectorIndexStats is what the catalog reports about the node embedding
+// index and kg_nodes. Counts are the planner's estimates and cumulative
+// statistics since they were last reset, not exact figures.
+type VectorIndexStats struct {
+	Exists        bool               `json:"exists"`
+	Valid         bool               `json:"valid"`
+	Definition    string             `json:"definition,omitempty"`
+	Params        VectorIndexRequest `json:"params"`
+	SizeBytes     int64              `json:"size_bytes"`
+	IndexScans    int64              `json:"index_scans"`
+	TableSeqScans int64              `json:"table_seq_scans"`
+	EmbeddedNodes int64              `json:"embedded_nodes"`
+}
+
+// VectorIndexStatus reports the node embedding index, the parameters a
+// rebuild would use by default, and any sign that vector searches fall back
+// to sequential scans.
+type VectorIndexStatus struct {
+	VectorIndexStats
+	Building    bool               `json:"building"`
+	LastError   string             `json:"last_error,omitempty"`
+	Recommended VectorIndexRequest `json:"recommended"`
+	Warnings    []string           `json:"warnings"`
+}
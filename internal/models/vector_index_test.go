@@ -0,0 +1,36 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestVectorIndexRequestWithDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		req  models.VectorIndexRequest
+		rows int64
+		want models.VectorIndexRequest
+	}{
+		{"hnsw small", models.VectorIndexRequest{}, 1000, models.VectorIndexRequest{Method: "hnsw", M: 16, EfConstruction: 64}},
+		{"hnsw large", models.VectorIndexRequest{}, 2_000_000, models.VectorIndexRequest{Method: "hnsw", M: 32, EfConstruction: 200}},
+		{"hnsw m only", models.VectorIndexRequest{M: 48}, 1000, models.VectorIndexRequest{Method: "hnsw", M: 48, EfConstruction: 96}},
+		{"hnsw ef only", models.VectorIndexRequest{EfConstruction: 20}, 1000, models.VectorIndexRequest{Method: "hnsw", M: 10, EfConstruction: 20}},
+		{"ivfflat empty", models.VectorIndexRequest{Method: "ivfflat"}, 0, models.VectorIndexRequest{Method: "ivfflat", Lists: 1}},
+		{"ivfflat medium", models.VectorIndexRequest{Method: "ivfflat"}, 250_000, models.VectorIndexRequest{Method: "ivfflat", Lists: 250}},
+		{"ivfflat large", models.VectorIndexRequest{Method: "ivfflat"}, 9_000_000, models.VectorIndexRequest{Method: "ivfflat", Lists: 3000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.req.WithDefaults(tt.rows)
+			if got != tt.want {
+				t.Errorf("WithDefaults = %+v, want %+v", got, tt.want)
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("defaults are invalid: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxWatchedNodes caps the nodes one watcher can follow, since every event
+// is matched against the watched set of each subscriber using it.
+const MaxWatchedNodes = 1000
+
+// maxWatcherLen caps a watcher name.
+const maxWatcherLen = 128
+
+var (
+	// ErrWatchNotFound indicates the watcher does not follow the node.
+	ErrWatchNotFound = errors.New("watch not found")
+	// ErrTooManyWatches indicates the watcher already follows
+	// MaxWatchedNodes nodes.
+	ErrTooManyWatches = fmt.Errorf("a watcher can follow at most %d nodes", MaxWatchedNodes)
+)
+
+// Watch is a node followed by a named watcher. A WebSocket or SSE
+// subscriber that gives the watcher name receives only the events for the
+// nodes it follows.
+type Watch struct {
+	Watcher   string    `json:"watcher"`
+	NodeID    string    `json:"node_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ValidateWatcher checks a watcher name: required, at most 128 characters.
+func ValidateWatcher(watcher string) error {
+	if watcher == "" {
+		return fmt.Errorf("watcher is required")
+	}
+
+	if tooLong(watcher, maxWatcherLen) {
+		return ErrFieldTooLong("watcher", maxWatcherLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// WSTicket is a short-lived, single-use credential for opening a WebSocket
+// connection without sending the API key on the upgrade request.
+type WSTicket struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
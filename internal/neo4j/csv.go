@@ -0,0 +1,149 @@
+package neo4j
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Files in the neo4j-csv archive.
+const (
+	NodesFile         = "nodes.csv"
+	RelationshipsFile = "relationships.csv"
+)
+
+// Headers of the two files. Properties vary from node to node, so they are
+// kept together as JSON text, which apoc.convert.fromJsonMap can expand after
+// the import.
+var (
+	nodeHeader = []string{
+		"id:ID", ":LABEL", "label", "type", "salience_score:double",
+		"created_at:datetime", "updated_at:datetime", "properties",
+	}
+	relationshipHeader = []string{
+		":START_ID", ":END_ID", ":TYPE", "relation", "weight:double",
+		"created_at:datetime", "updated_at:datetime", "properties",
+	}
+)
+
+// csvWriter writes a zip archive holding nodes.csv and relationships.csv for
+// neo4j-admin database import. Nodes must all come before edges, which the
+// export order guarantees.
+type csvWriter struct {
+	zw   *zip.Writer
+	csv  *csv.Writer
+	file string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{zw: zip.NewWriter(w)}
+}
+
+// open starts the named file in the archive, finishing the previous one.
+func (cw *csvWriter) open(name string, header []string) error {
+	if cw.file == name {
+		return nil
+	}
+
+	if cw.csv != nil {
+		if cw.csv.Flush(); cw.csv.Error() != nil {
+			return cw.csv.Error()
+		}
+	}
+
+	f, err := cw.zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", name, err)
+	}
+
+	cw.file, cw.csv = name, csv.NewWriter(f)
+
+	return cw.csv.Write(header)
+}
+
+// openRelationships moves on to relationships.csv, writing an empty
+// nodes.csv first if no node came.
+func (cw *csvWriter) openRelationships() error {
+	if cw.file == "" {
+		if err := cw.open(NodesFile, nodeHeader); err != nil {
+			return err
+		}
+	}
+
+	return cw.open(RelationshipsFile, relationshipHeader)
+}
+
+func (cw *csvWriter) WriteNode(n *models.ExportNode) error {
+	if cw.file == RelationshipsFile {
+		return fmt.Errorf("node %s written after relationships", n.ID)
+	}
+
+	if err := cw.open(NodesFile, nodeHeader); err != nil {
+		return err
+	}
+
+	props, err := propertiesJSON(n.Properties)
+	if err != nil {
+		return err
+	}
+
+	return cw.csv.Write([]string{
+		n.ID, NodeLabel + ";" + Label(n.Type), n.Label, n.Type,
+		strconv.FormatFloat(n.SalienceScore, 'g', -1, 64),
+		csvTime(n.CreatedAt), csvTime(n.UpdatedAt), props,
+	})
+}
+
+func (cw *csvWriter) WriteEdge(e *models.ExportEdge) error {
+	if err := cw.openRelationships(); err != nil {
+		return err
+	}
+
+	props, err := propertiesJSON(e.Properties)
+	if err != nil {
+		return err
+	}
+
+	return cw.csv.Write([]string{
+		e.Source, e.Target, RelationshipType(e.Relation), e.Relation,
+		strconv.FormatFloat(e.Weight, 'g', -1, 64),
+		csvTime(e.CreatedAt), csvTime(e.UpdatedAt), props,
+	})
+}
+
+// Close writes any file not yet started, so both are always present, and
+// finishes the archive.
+func (cw *csvWriter) Close() error {
+	if err := cw.openRelationships(); err != nil {
+		return err
+	}
+
+	if cw.csv.Flush(); cw.csv.Error() != nil {
+		return cw.csv.Error()
+	}
+
+	return cw.zw.Close()
+}
+
+func propertiesJSON(properties map[string]any) (string, error) {
+	if len(properties) == 0 {
+		return "{}", nil
+	}
+
+	raw, err := json.Marshal(properties)
+	if err != nil {
+		return "", fmt.Errorf("encoding properties: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+func csvTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
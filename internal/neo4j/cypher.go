@@ -0,0 +1,222 @@
+package neo4j
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// cypherHeader creates the constraint that makes the edge MATCHes fast and
+// rejects a second import of the same nodes.
+const cypherHeader = `// Persistor knowledge graph export. Load with: cypher-shell -f <file>
+CREATE CONSTRAINT persistor_node_id IF NOT EXISTS FOR (n:` + NodeLabel + `) REQUIRE n.id IS UNIQUE;
+`
+
+// CypherTrailer starts the comment that ends a complete Cypher export.
+const CypherTrailer = "// End of export:"
+
+// bareKey matches property keys that need no backticks.
+var bareKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// cypherWriter writes one CREATE statement per node and one MATCH ... CREATE
+// statement per edge, then a closing comment with the counts.
+type cypherWriter struct {
+	w       *bufio.Writer
+	started bool
+	nodes   int
+	edges   int
+}
+
+func (cw *cypherWriter) start() error {
+	if cw.started {
+		return nil
+	}
+
+	cw.started = true
+
+	_, err := cw.w.WriteString(cypherHeader)
+
+	return err
+}
+
+func (cw *cypherWriter) WriteNode(n *models.ExportNode) error {
+	if err := cw.start(); err != nil {
+		return err
+	}
+
+	props := userProperties(n.Properties)
+	props["id"] = n.ID
+	props["label"] = n.Label
+	props["type"] = n.Type
+	props["salience_score"] = floatLiteral(n.SalienceScore)
+	props["created_at"] = datetimeLiteral(n.CreatedAt)
+	props["updated_at"] = datetimeLiteral(n.UpdatedAt)
+
+	cw.nodes++
+	_, err := fmt.Fprintf(cw.w, "CREATE (:%s:%s %s);\n", NodeLabel, Label(n.Type), cypherMap(props))
+
+	return err
+}
+
+func (cw *cypherWriter) WriteEdge(e *models.ExportEdge) error {
+	if err := cw.start(); err != nil {
+		return err
+	}
+
+	props := userProperties(e.Properties)
+	props["relation"] = e.Relation
+	props["weight"] = floatLiteral(e.Weight)
+	props["created_at"] = datetimeLiteral(e.CreatedAt)
+	props["updated_at"] = datetimeLiteral(e.UpdatedAt)
+
+	cw.edges++
+	_, err := fmt.Fprintf(cw.w, "MATCH (a:%s {id: %s}), (b:%s {id: %s}) CREATE (a)-[:%s %s]->(b);\n",
+		NodeLabel, cypherString(e.Source), NodeLabel, cypherString(e.Target),
+		RelationshipType(e.Relation), cypherMap(props))
+
+	return err
+}
+
+func (cw *cypherWriter) Close() error {
+	if err := cw.start(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(cw.w, "%s %d nodes, %d relationships.\n", CypherTrailer, cw.nodes, cw.edges); err != nil {
+		return err
+	}
+
+	return cw.w.Flush()
+}
+
+// literal is Cypher source written as is.
+type literal string
+
+func floatLiteral(f float64) literal {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+
+	return literal(s)
+}
+
+func datetimeLiteral(t time.Time) literal {
+	return literal("datetime(" + cypherString(t.UTC().Format(time.RFC3339Nano)) + ")")
+}
+
+// userProperties converts a node or edge's properties to Neo4j values,
+// dropping nulls and renaming reserved keys.
+func userProperties(properties map[string]any) map[string]any {
+	props := make(map[string]any, len(properties)+6)
+
+	for key, v := range properties {
+		if value, ok := propertyValue(v); ok {
+			props[propertyKey(key)] = value
+		}
+	}
+
+	return props
+}
+
+// cypherMap renders a map literal with keys in sorted order.
+func cypherMap(props map[string]any) string {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, cypherKey(k)+": "+cypherValue(props[k]))
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func cypherKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+
+	return "`" + strings.ReplaceAll(key, "`", "``") + "`"
+}
+
+func cypherValue(v any) string {
+	switch val := v.(type) {
+	case literal:
+		return string(val)
+	case string:
+		return cypherString(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case []any:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = cypherValue(item)
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return cypherString(fmt.Sprint(val))
+	}
+}
+
+// cypherString quotes s as a single-quoted Cypher string literal.
+func cypherString(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('\'')
+
+	for _, r := range s {
+		switch r {
+		case '\'', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('\'')
+
+	return b.String()
+}
+
+// NewWriter returns a Writer for format, FormatCypher or FormatCSV.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCypher:
+		return &cypherWriter{w: bufio.NewWriter(w)}, nil
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown Neo4j export format %q", format)
+	}
+}
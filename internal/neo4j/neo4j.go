@@ -0,0 +1,167 @@
+// Package neo4j writes a knowledge graph export in forms Neo4j can load: a
+// Cypher script of CREATE statements, or the CSV layout of neo4j-admin
+// database import. Both are written record by record as the export streams.
+package neo4j
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// NodeLabel is added to every node alongside the label derived from its
+// type, so one uniqueness constraint on id covers the whole graph.
+const NodeLabel = "PersistorNode"
+
+// Formats accepted by NewWriter.
+const (
+	FormatCypher = "cypher"
+	FormatCSV    = "neo4j-csv"
+)
+
+// Writer writes nodes, then edges, in one of the Neo4j formats. Close
+// finishes the output; it does not close the underlying writer.
+type Writer interface {
+	WriteNode(n *models.ExportNode) error
+	WriteEdge(e *models.ExportEdge) error
+	Close() error
+}
+
+// reservedKeys are the node and relationship properties set from Persistor's
+// own fields. User properties with these names are written with a
+// "property_" prefix instead.
+var reservedKeys = map[string]bool{
+	"id": true, "label": true, "type": true, "relation": true, "weight": true,
+	"salience_score": true, "created_at": true, "updated_at": true,
+}
+
+// Label turns a node type into a Neo4j label in PascalCase:
+// "project_note" becomes "ProjectNote".
+func Label(nodeType string) string {
+	var b strings.Builder
+
+	upper := true
+	for _, r := range nodeType {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+
+			b.WriteRune(r)
+
+			upper = false
+		default:
+			upper = true
+		}
+	}
+
+	return identifier(b.String(), "Node")
+}
+
+// RelationshipType turns an edge relation into a Neo4j relationship type in
+// upper snake case: "works-at" and "worksAt" both become "WORKS_AT".
+func RelationshipType(relation string) string {
+	var b strings.Builder
+
+	prev := rune(0)
+	for _, r := range relation {
+		switch {
+		case unicode.IsUpper(r):
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			if b.Len() > 0 && prev != '_' {
+				b.WriteByte('_')
+			}
+
+			r = '_'
+		}
+
+		prev = r
+	}
+
+	return identifier(strings.Trim(b.String(), "_"), "RELATED_TO")
+}
+
+// identifier falls back when name is empty and keeps it from starting with
+// a digit, so it never needs quoting.
+func identifier(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+
+	if unicode.IsDigit([]rune(name)[0]) {
+		return "_" + name
+	}
+
+	return name
+}
+
+// propertyKey renames user properties that clash with reservedKeys.
+func propertyKey(key string) string {
+	if reservedKeys[key] {
+		return "property_" + key
+	}
+
+	return key
+}
+
+// propertyValue converts a JSON property value to one Neo4j can store:
+// strings, numbers, booleans, and lists of one of those. Objects and mixed
+// or nested lists become their JSON text. ok is false for null.
+func propertyValue(v any) (value any, ok bool) {
+	switch val := v.(type) {
+	case nil:
+		return nil, false
+	case string, bool, float64, int, int64:
+		return val, true
+	case []any:
+		if homogeneous(val) {
+			return val, true
+		}
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+
+	return string(raw), true
+}
+
+// homogeneous reports whether every element of list is a string, or every
+// one a number, or every one a boolean.
+func homogeneous(list []any) bool {
+	kind := ""
+
+	for _, v := range list {
+		var k string
+
+		switch v.(type) {
+		case string:
+			k = "string"
+		case float64, int, int64:
+			k = "number"
+		case bool:
+			k = "bool"
+		default:
+			return false
+		}
+
+		if kind != "" && k != kind {
+			return false
+		}
+
+		kind = k
+	}
+
+	return true
+}
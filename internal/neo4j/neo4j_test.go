@@ -0,0 +1,169 @@
+package neo4j_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/neo4j"
+)
+
+var created = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testGraph() ([]models.ExportNode, []models.ExportEdge) {
+	nodes := []models.ExportNode{
+		{
+			ID: "alice", Type: "person", Label: "Alice O'Neil", SalienceScore: 2,
+			Properties: map[string]any{
+				"age": 34.0, "tags": []any{"a", "b"}, "address": map[string]any{"city": "Oslo"},
+				"id": "legacy", "odd key": true, "gone": nil,
+			},
+			CreatedAt: created, UpdatedAt: created,
+		},
+		{ID: "acme", Type: "project_note", Label: "Acme", CreatedAt: created, UpdatedAt: created},
+	}
+	edges := []models.ExportEdge{
+		{Source: "alice", Target: "acme", Relation: "works-at", Weight: 1, CreatedAt: created, UpdatedAt: created},
+	}
+
+	return nodes, edges
+}
+
+func write(t *testing.T, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w, err := neo4j.NewWriter(&buf, format)
+	require.NoError(t, err)
+
+	nodes, edges := testGraph()
+	for i := range nodes {
+		require.NoError(t, w.WriteNode(&nodes[i]))
+	}
+
+	for i := range edges {
+		require.NoError(t, w.WriteEdge(&edges[i]))
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestLabel(t *testing.T) {
+	cases := map[string]string{
+		"person":       "Person",
+		"project_note": "ProjectNote",
+		"api-key v2":   "ApiKeyV2",
+		"3d_model":     "_3dModel",
+		"":             "Node",
+		"!!!":          "Node",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, neo4j.Label(in), in)
+	}
+}
+
+func TestRelationshipType(t *testing.T) {
+	cases := map[string]string{
+		"works_at":   "WORKS_AT",
+		"works-at":   "WORKS_AT",
+		"worksAt":    "WORKS_AT",
+		"NEXT_CHUNK": "NEXT_CHUNK",
+		"part of":    "PART_OF",
+		"2nd_degree": "_2ND_DEGREE",
+		"":           "RELATED_TO",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, neo4j.RelationshipType(in), in)
+	}
+}
+
+func TestCypherWriter(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(write(t, neo4j.FormatCypher))), "\n")
+	require.Len(t, lines, 6)
+
+	assert.Contains(t, lines[1], "CREATE CONSTRAINT persistor_node_id IF NOT EXISTS")
+
+	alice := lines[2]
+	assert.True(t, strings.HasPrefix(alice, "CREATE (:PersistorNode:Person {"), alice)
+	assert.Contains(t, alice, `label: 'Alice O\'Neil'`)
+	assert.Contains(t, alice, `id: 'alice'`)
+	assert.Contains(t, alice, `property_id: 'legacy'`)
+	assert.Contains(t, alice, "`odd key`: true")
+	assert.Contains(t, alice, `age: 34`)
+	assert.Contains(t, alice, `tags: ['a', 'b']`)
+	assert.Contains(t, alice, `address: '{"city":"Oslo"}'`)
+	assert.Contains(t, alice, `salience_score: 2.0`)
+	assert.Contains(t, alice, `created_at: datetime('2026-03-01T12:00:00Z')`)
+	assert.NotContains(t, alice, "gone")
+
+	assert.True(t, strings.HasPrefix(lines[3], "CREATE (:PersistorNode:ProjectNote {"), lines[3])
+	assert.Equal(t,
+		"MATCH (a:PersistorNode {id: 'alice'}), (b:PersistorNode {id: 'acme'}) CREATE (a)-[:WORKS_AT "+
+			"{created_at: datetime('2026-03-01T12:00:00Z'), relation: 'works-at', "+
+			"updated_at: datetime('2026-03-01T12:00:00Z'), weight: 1.0}]->(b);",
+		lines[4])
+	assert.Equal(t, neo4j.CypherTrailer+" 2 nodes, 1 relationships.", lines[5])
+}
+
+func TestCSVWriter(t *testing.T) {
+	raw := write(t, neo4j.FormatCSV)
+
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+
+	files := map[string][][]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+
+		files[f.Name] = records
+	}
+
+	nodes := files[neo4j.NodesFile]
+	require.Len(t, nodes, 3)
+	assert.Equal(t, "id:ID", nodes[0][0])
+	assert.Equal(t, []string{"alice", "PersistorNode;Person", "Alice O'Neil", "person", "2"}, nodes[1][:5])
+	assert.Equal(t, "{}", nodes[2][7])
+
+	rels := files[neo4j.RelationshipsFile]
+	require.Len(t, rels, 2)
+	assert.Equal(t, []string{"alice", "acme", "WORKS_AT", "works-at", "1", "2026-03-01T12:00:00Z"}, rels[1][:6])
+}
+
+func TestCSVWriter_EmptyGraph(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := neo4j.NewWriter(&buf, neo4j.FormatCSV)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	assert.Equal(t, neo4j.NodesFile, zr.File[0].Name)
+	assert.Equal(t, neo4j.RelationshipsFile, zr.File[1].Name)
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := neo4j.NewWriter(io.Discard, "graphml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+package rdf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Relations the converter gives class hierarchy and membership edges.
+const (
+	RelationSubclassOf = "subclass_of"
+	RelationInstanceOf = "instance_of"
+)
+
+// Node types the converter assigns besides the snake_case name of a
+// resource's rdf:type.
+const (
+	TypeClass    = "class"
+	TypeResource = "resource"
+)
+
+// Graph is the result of converting a document.
+type Graph struct {
+	Nodes []models.CreateNodeRequest
+	Edges []models.CreateEdgeRequest
+	// Skipped counts triples that have no place in the graph: those about
+	// or pointing at blank nodes, such as OWL restrictions, and schema
+	// statements other than class declarations and rdfs:subClassOf.
+	Skipped int
+}
+
+// Options tune a conversion.
+type Options struct {
+	// Lang is the preferred language of labels and descriptions; literals
+	// in it win over untagged ones, which win over other languages.
+	Lang string
+}
+
+// resource collects what the document says about one IRI.
+type resource struct {
+	iri     string
+	class   bool
+	schema  bool // a property, ontology, or other schema term, not a node
+	types   []string
+	labels  []Term
+	descs   []Term
+	props   map[string][]any
+	ordinal int
+}
+
+// labelPredicates and descriptionPredicates name a resource and describe it.
+var (
+	labelPredicates       = map[string]bool{RDFS + "label": true, SKOS + "prefLabel": true}
+	descriptionPredicates = map[string]bool{RDFS + "comment": true, SKOS + "definition": true}
+	classTypes            = map[string]bool{OWL + "Class": true, RDFS + "Class": true}
+)
+
+// Convert turns a document into typed nodes and edges. Classes (owl:Class,
+// rdfs:Class, or anything with a subclass) become nodes of type "class";
+// rdfs:subClassOf becomes subclass_of edges and rdf:type instance_of edges.
+// Other resources are typed by the snake_case local name of their first
+// class, or "resource". rdfs:label or skos:prefLabel sets the label,
+// rdfs:comment or skos:definition the "description" property, other
+// literals become properties, and other IRI objects become edges named
+// after the predicate. Every node keeps its IRI in the "iri" property.
+func Convert(doc *Document, opts Options) (*Graph, error) {
+	c := &converter{doc: doc, opts: opts, resources: map[string]*resource{}, edgeSeen: map[models.EdgeKey]bool{}}
+
+	for _, t := range doc.Triples {
+		c.add(t)
+	}
+
+	return c.graph()
+}
+
+type converter struct {
+	doc       *Document
+	opts      Options
+	resources map[string]*resource
+	edges     []rawEdge
+	edgeSeen  map[models.EdgeKey]bool
+	skipped   int
+}
+
+// rawEdge is an edge between IRIs, before node IDs are assigned.
+type rawEdge struct {
+	source, target, relation string
+}
+
+func (c *converter) resource(iri string) *resource {
+	r, ok := c.resources[iri]
+	if !ok {
+		r = &resource{iri: iri, props: map[string][]any{}, ordinal: len(c.resources)}
+		c.resources[iri] = r
+	}
+
+	return r
+}
+
+func (c *converter) add(t Triple) {
+	if t.Subject.Kind != IRI || t.Object.Kind == BlankNode {
+		c.skipped++
+		return
+	}
+
+	subject, pred, obj := c.resource(t.Subject.Value), t.Predicate.Value, t.Object
+
+	switch {
+	case pred == RDF+"type" && classTypes[obj.Value]:
+		subject.class = true
+	case pred == RDF+"type" && obj.Value == OWL+"NamedIndividual":
+	case pred == RDF+"type" && isSchemaIRI(obj.Value):
+		subject.schema = true
+	case pred == RDF+"type":
+		c.resource(obj.Value).class = true
+		subject.types = append(subject.types, obj.Value)
+		c.edges = append(c.edges, rawEdge{t.Subject.Value, obj.Value, RelationInstanceOf})
+	case pred == RDFS+"subClassOf":
+		subject.class = true
+		if obj.Value == OWL+"Thing" {
+			return
+		}
+
+		c.resource(obj.Value).class = true
+		c.edges = append(c.edges, rawEdge{t.Subject.Value, obj.Value, RelationSubclassOf})
+	case labelPredicates[pred] && obj.Kind == Literal:
+		subject.labels = append(subject.labels, obj)
+	case descriptionPredicates[pred] && obj.Kind == Literal:
+		subject.descs = append(subject.descs, obj)
+	case isSchemaIRI(pred):
+		c.skipped++
+	case obj.Kind == Literal:
+		key := snakeCase(localName(pred))
+		subject.props[key] = append(subject.props[key], literalValue(obj))
+	default:
+		relation := snakeCase(localName(pred))
+		if relation == "" {
+			c.skipped++
+			return
+		}
+
+		c.resource(obj.Value)
+		c.edges = append(c.edges, rawEdge{t.Subject.Value, obj.Value, relation})
+	}
+}
+
+// graph assigns node IDs in document order and builds the requests.
+func (c *converter) graph() (*Graph, error) {
+	ordered := make([]*resource, 0, len(c.resources))
+	for _, r := range c.resources {
+		if !r.schema || r.class {
+			ordered = append(ordered, r)
+		}
+	}
+
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ordinal < ordered[j].ordinal })
+
+	g := &Graph{Skipped: c.skipped}
+	ids := make(map[string]string, len(ordered))
+	used := make(map[string]bool, len(ordered))
+
+	for _, r := range ordered {
+		id := c.nodeID(r.iri, used)
+		if len(id) > 255 {
+			return nil, fmt.Errorf("node ID for %s is longer than 255 characters", r.iri)
+		}
+
+		ids[r.iri], used[id] = id, true
+		g.Nodes = append(g.Nodes, c.node(id, r))
+	}
+
+	for _, e := range c.edges {
+		source, ok1 := ids[e.source]
+		target, ok2 := ids[e.target]
+		if !ok1 || !ok2 {
+			g.Skipped++
+			continue
+		}
+
+		key := models.EdgeKey{Source: source, Target: target, Relation: e.relation}
+		if c.edgeSeen[key] {
+			continue
+		}
+
+		c.edgeSeen[key] = true
+		g.Edges = append(g.Edges, models.CreateEdgeRequest{Source: source, Target: target, Relation: e.relation})
+	}
+
+	return g, nil
+}
+
+func (c *converter) node(id string, r *resource) models.CreateNodeRequest {
+	nodeType := TypeResource
+	switch {
+	case r.class:
+		nodeType = TypeClass
+	case len(r.types) > 0 && snakeCase(localName(r.types[0])) != "":
+		nodeType = snakeCase(localName(r.types[0]))
+	}
+
+	label := localName(r.iri)
+	if best, ok := c.preferred(r.labels); ok {
+		label = best
+	}
+
+	props := map[string]any{"iri": r.iri}
+	if desc, ok := c.preferred(r.descs); ok {
+		props["description"] = desc
+	}
+
+	for key, values := range r.props {
+		if _, taken := props[key]; taken {
+			key = "rdf_" + key
+		}
+
+		if len(values) == 1 {
+			props[key] = values[0]
+		} else {
+			props[key] = values
+		}
+	}
+
+	return models.CreateNodeRequest{ID: id, Type: nodeType, Label: label, Properties: props}
+}
+
+// preferred picks the literal in the preferred language, else an untagged
+// one, else the first.
+func (c *converter) preferred(literals []Term) (string, bool) {
+	if len(literals) == 0 {
+		return "", false
+	}
+
+	best := literals[0]
+	for _, l := range literals {
+		if c.opts.Lang != "" && strings.EqualFold(l.Lang, c.opts.Lang) {
+			return l.Value, true
+		}
+
+		if l.Lang == "" && best.Lang != "" {
+			best = l
+		}
+	}
+
+	return best.Value, true
+}
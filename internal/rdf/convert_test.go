@@ -0,0 +1,117 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/rdf"
+)
+
+const ontology = `@prefix : <http://example.org/org#> .
+@prefix owl: <http://www.w3.org/2002/07/owl#> .
+@prefix rdfs: <http://www.w3.org/2000/01/rdf-schema#> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+
+:Ontology a owl:Ontology .
+:worksFor a owl:ObjectProperty ; rdfs:domain :Person .
+
+:Person a owl:Class ; rdfs:subClassOf owl:Thing ; rdfs:label "Person"@en .
+:SoftwareEngineer a owl:Class ;
+    rdfs:subClassOf :Person , [ a owl:Restriction ; owl:onProperty :worksFor ] ;
+    rdfs:label "Ingénieur"@fr , "Software engineer"@en ;
+    rdfs:comment "Writes software." .
+
+:alice a owl:NamedIndividual , :SoftwareEngineer ;
+    rdfs:label "Alice" ;
+    :worksFor <http://other.example/acme> ;
+    :age "34"^^xsd:integer ;
+    :nickname "Al" , "Ally" .
+`
+
+func convert(t *testing.T, src string) *rdf.Graph {
+	t.Helper()
+
+	doc, err := rdf.ParseTurtle([]byte(src), "")
+	require.NoError(t, err)
+
+	graph, err := rdf.Convert(doc, rdf.Options{Lang: "en"})
+	require.NoError(t, err)
+
+	return graph
+}
+
+func nodesByID(graph *rdf.Graph) map[string]models.CreateNodeRequest {
+	nodes := make(map[string]models.CreateNodeRequest, len(graph.Nodes))
+	for _, n := range graph.Nodes {
+		nodes[n.ID] = n
+	}
+
+	return nodes
+}
+
+func TestConvert_ClassHierarchy(t *testing.T) {
+	graph := convert(t, ontology)
+	nodes := nodesByID(graph)
+
+	assert.NotContains(t, nodes, "Ontology")
+	assert.NotContains(t, nodes, "worksFor")
+	assert.NotContains(t, nodes, "owl:Thing")
+
+	engineer := nodes["SoftwareEngineer"]
+	assert.Equal(t, rdf.TypeClass, engineer.Type)
+	assert.Equal(t, "Software engineer", engineer.Label)
+	assert.Equal(t, "Writes software.", engineer.Properties["description"])
+	assert.Equal(t, "http://example.org/org#SoftwareEngineer", engineer.Properties["iri"])
+	assert.Equal(t, rdf.TypeClass, nodes["Person"].Type)
+
+	assert.Contains(t, graph.Edges, models.CreateEdgeRequest{Source: "SoftwareEngineer", Target: "Person", Relation: rdf.RelationSubclassOf})
+}
+
+func TestConvert_Instances(t *testing.T) {
+	graph := convert(t, ontology)
+	nodes := nodesByID(graph)
+
+	alice := nodes["alice"]
+	assert.Equal(t, "software_engineer", alice.Type)
+	assert.Equal(t, "Alice", alice.Label)
+	assert.Equal(t, int64(34), alice.Properties["age"])
+	assert.Equal(t, []any{"Al", "Ally"}, alice.Properties["nickname"])
+
+	acme := nodes["other.example:acme"]
+	assert.Equal(t, rdf.TypeResource, acme.Type)
+	assert.Equal(t, "acme", acme.Label)
+
+	assert.Contains(t, graph.Edges, models.CreateEdgeRequest{Source: "alice", Target: "SoftwareEngineer", Relation: rdf.RelationInstanceOf})
+	assert.Contains(t, graph.Edges, models.CreateEdgeRequest{Source: "alice", Target: "other.example:acme", Relation: "works_for"})
+}
+
+func TestConvert_SkipsBlankNodes(t *testing.T) {
+	graph := convert(t, ontology)
+
+	// The restriction's two triples, the subClassOf pointing at it, and
+	// rdfs:domain on the property.
+	assert.Equal(t, 4, graph.Skipped)
+	assert.Len(t, graph.Edges, 3)
+}
+
+func TestConvert_DeduplicatesEdges(t *testing.T) {
+	graph := convert(t, `@prefix ex: <http://example.org/> .
+ex:a ex:linksTo ex:b .
+ex:a ex:linksTo ex:b .`)
+
+	require.Len(t, graph.Edges, 1)
+	assert.Equal(t, "links_to", graph.Edges[0].Relation)
+	assert.Equal(t, []string{"ex:a", "ex:b"}, []string{graph.Nodes[0].ID, graph.Nodes[1].ID})
+}
+
+func TestConvert_NodeIDsWithoutPrefix(t *testing.T) {
+	graph := convert(t, `<http://example.org/people/alice#me> <http://example.org/v#knows> <http://example.org/people/bob> .`)
+
+	require.Len(t, graph.Nodes, 2)
+	assert.Equal(t, "example.org:people:alice:me", graph.Nodes[0].ID)
+	assert.Equal(t, "example.org:people:bob", graph.Nodes[1].ID)
+	assert.Equal(t, "knows", graph.Edges[0].Relation)
+}
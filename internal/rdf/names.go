@@ -0,0 +1,112 @@
+package rdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// nodeID shortens an IRI with the longest declared prefix it starts with,
+// as "prefix:local" or just "local" for the empty prefix. Without one, or
+// when that ID is taken, it is the IRI without its scheme, with '/' and '#'
+// turned into ':' so the ID works as a URL path segment.
+func (c *converter) nodeID(iri string, used map[string]bool) string {
+	var bestPrefix, bestNS string
+	for prefix, ns := range c.doc.Prefixes {
+		if strings.HasPrefix(iri, ns) && len(ns) > len(bestNS) && len(iri) > len(ns) {
+			bestPrefix, bestNS = prefix, ns
+		}
+	}
+
+	if bestNS != "" {
+		local := iri[len(bestNS):]
+
+		id := bestPrefix + ":" + local
+		if bestPrefix == "" {
+			id = local
+		}
+
+		if !used[id] && !strings.ContainsAny(local, "/#") {
+			return id
+		}
+	}
+
+	id := iri
+	if _, rest, ok := strings.Cut(iri, "://"); ok {
+		id = rest
+	}
+
+	id = strings.Trim(strings.NewReplacer("/", ":", "#", ":").Replace(id), ":")
+
+	for n, base := 2, id; used[id]; n++ {
+		id = base + "~" + strconv.Itoa(n)
+	}
+
+	return id
+}
+
+// isSchemaIRI reports whether iri is in the RDF, RDFS, or OWL vocabulary.
+func isSchemaIRI(iri string) bool {
+	return strings.HasPrefix(iri, RDF) || strings.HasPrefix(iri, RDFS) || strings.HasPrefix(iri, OWL)
+}
+
+// localName is the part of an IRI after its last '#', '/', or ':'.
+func localName(iri string) string {
+	trimmed := strings.TrimRight(iri, "/#")
+	if i := strings.LastIndexAny(trimmed, "#/:"); i >= 0 && i < len(trimmed)-1 {
+		return trimmed[i+1:]
+	}
+
+	return trimmed
+}
+
+// snakeCase turns "subOrganizationOf" or "birth-date" into
+// "sub_organization_of" and "birth_date".
+func snakeCase(name string) string {
+	var b strings.Builder
+
+	prev := rune(0)
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if prev != 0 && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			if prev != '_' && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+
+			r = '_'
+		}
+
+		prev = r
+	}
+
+	return strings.Trim(b.String(), "_")
+}
+
+// literalValue converts numeric and boolean literals to JSON numbers and
+// booleans; everything else stays a string.
+func literalValue(t Term) any {
+	switch strings.TrimPrefix(t.Datatype, XSD) {
+	case "integer", "int", "long", "short", "nonNegativeInteger", "positiveInteger":
+		if v, err := strconv.ParseInt(t.Value, 10, 64); err == nil {
+			return v
+		}
+	case "decimal", "double", "float":
+		if v, err := strconv.ParseFloat(t.Value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(t.Value); err == nil {
+			return v
+		}
+	}
+
+	return t.Value
+}
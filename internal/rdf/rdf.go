@@ -0,0 +1,49 @@
+// Package rdf reads RDF documents in Turtle or N-Triples and converts their
+// triples into knowledge graph nodes and edges.
+package rdf
+
+// Namespaces of the vocabularies the converter understands.
+const (
+	RDF  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	RDFS = "http://www.w3.org/2000/01/rdf-schema#"
+	OWL  = "http://www.w3.org/2002/07/owl#"
+	XSD  = "http://www.w3.org/2001/XMLSchema#"
+	SKOS = "http://www.w3.org/2004/02/skos/core#"
+)
+
+// TermKind says what a Term is.
+type TermKind int
+
+// Term kinds.
+const (
+	IRI TermKind = iota
+	BlankNode
+	Literal
+)
+
+// Term is an RDF term. Value is the IRI, the blank node label, or the
+// literal's lexical form; Datatype and Lang apply to literals only.
+type Term struct {
+	Kind     TermKind
+	Value    string
+	Datatype string
+	Lang     string
+}
+
+// Triple is one RDF statement.
+type Triple struct {
+	Subject   Term
+	Predicate Term
+	Object    Term
+}
+
+// Document is a parsed RDF document: its triples in document order and the
+// prefixes it declared, which the converter uses to shorten node IDs.
+type Document struct {
+	Triples  []Triple
+	Prefixes map[string]string
+}
+
+func iri(value string) Term {
+	return Term{Kind: IRI, Value: value}
+}
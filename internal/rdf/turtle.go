@@ -0,0 +1,294 @@
+package rdf
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parser is a recursive-descent parser for Turtle, which N-Triples is a
+// subset of.
+type parser struct {
+	src      string
+	pos      int
+	base     *url.URL
+	prefixes map[string]string
+	triples  []Triple
+	bnodes   int
+}
+
+// ParseTurtle parses a Turtle or N-Triples document. Relative IRIs are
+// resolved against base, which may be empty when the document has none or
+// declares its own with @base.
+func ParseTurtle(data []byte, base string) (*Document, error) {
+	p := &parser{src: string(data), prefixes: map[string]string{}}
+
+	if base != "" {
+		u, err := url.Parse(base)
+		if err != nil {
+			return nil, fmt.Errorf("parsing base IRI: %w", err)
+		}
+
+		p.base = u
+	}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			break
+		}
+
+		if err := p.statement(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line(), err)
+		}
+	}
+
+	return &Document{Triples: p.triples, Prefixes: p.prefixes}, nil
+}
+
+func (p *parser) statement() error {
+	switch word := p.peekWord(); {
+	case word == "@prefix" || strings.EqualFold(word, "PREFIX"):
+		p.pos += len(word)
+		return p.prefix(word[0] == '@')
+	case word == "@base" || strings.EqualFold(word, "BASE"):
+		p.pos += len(word)
+		return p.baseDirective(word[0] == '@')
+	}
+
+	if err := p.triplesStatement(); err != nil {
+		return err
+	}
+
+	return p.expect('.')
+}
+
+func (p *parser) prefix(turtleStyle bool) error {
+	p.skipSpace()
+
+	name := p.readName()
+	if !strings.HasSuffix(name, ":") || strings.Count(name, ":") != 1 {
+		return fmt.Errorf("expected a prefix name ending in ':', got %q", name)
+	}
+
+	p.skipSpace()
+
+	ns, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+
+	p.prefixes[strings.TrimSuffix(name, ":")] = ns
+
+	if turtleStyle {
+		return p.expect('.')
+	}
+
+	return nil
+}
+
+func (p *parser) baseDirective(turtleStyle bool) error {
+	p.skipSpace()
+
+	ref, err := p.iriRef()
+	if err != nil {
+		return err
+	}
+
+	if p.base, err = url.Parse(ref); err != nil {
+		return fmt.Errorf("parsing base IRI: %w", err)
+	}
+
+	if turtleStyle {
+		return p.expect('.')
+	}
+
+	return nil
+}
+
+// triplesStatement parses a subject and its predicate-object list. A blank
+// node property list may stand alone as a statement.
+func (p *parser) triplesStatement() error {
+	p.skipSpace()
+
+	if p.peek() == '[' {
+		subject, err := p.blankNodePropertyList()
+		if err != nil {
+			return err
+		}
+
+		if p.skipSpace(); p.peek() == '.' {
+			return nil
+		}
+
+		return p.predicateObjectList(subject)
+	}
+
+	subject, err := p.subject()
+	if err != nil {
+		return err
+	}
+
+	return p.predicateObjectList(subject)
+}
+
+func (p *parser) subject() (Term, error) {
+	switch p.peek() {
+	case '(':
+		return p.collection()
+	case '_':
+		return p.blankNodeLabel()
+	default:
+		return p.iriTerm()
+	}
+}
+
+// predicateObjectList parses "verb objects (; verb objects)*", allowing a
+// trailing or repeated ';'.
+func (p *parser) predicateObjectList(subject Term) error {
+	for {
+		p.skipSpace()
+
+		predicate, err := p.verb()
+		if err != nil {
+			return err
+		}
+
+		if err := p.objectList(subject, predicate); err != nil {
+			return err
+		}
+
+		p.skipSpace()
+		if p.peek() != ';' {
+			return nil
+		}
+
+		for p.peek() == ';' {
+			p.pos++
+			p.skipSpace()
+		}
+
+		if c := p.peek(); c == '.' || c == ']' || c == 0 {
+			return nil
+		}
+	}
+}
+
+func (p *parser) verb() (Term, error) {
+	if p.peekWord() == "a" {
+		p.pos++
+		return iri(RDF + "type"), nil
+	}
+
+	return p.iriTerm()
+}
+
+func (p *parser) objectList(subject, predicate Term) error {
+	for {
+		p.skipSpace()
+
+		object, err := p.object()
+		if err != nil {
+			return err
+		}
+
+		p.triples = append(p.triples, Triple{Subject: subject, Predicate: predicate, Object: object})
+
+		p.skipSpace()
+		if p.peek() != ',' {
+			return nil
+		}
+
+		p.pos++
+	}
+}
+
+func (p *parser) object() (Term, error) {
+	switch c := p.peek(); {
+	case c == '[':
+		return p.blankNodePropertyList()
+	case c == '(':
+		return p.collection()
+	case c == '_' && strings.HasPrefix(p.src[p.pos:], "_:"):
+		return p.blankNodeLabel()
+	case c == '"' || c == '\'':
+		return p.literal()
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return p.number()
+	}
+
+	if word := p.peekWord(); word == "true" || word == "false" {
+		p.pos += len(word)
+		return Term{Kind: Literal, Value: word, Datatype: XSD + "boolean"}, nil
+	}
+
+	return p.iriTerm()
+}
+
+func (p *parser) blankNodePropertyList() (Term, error) {
+	p.pos++ // '['
+	node := p.newBlankNode()
+
+	if p.skipSpace(); p.peek() == ']' {
+		p.pos++
+		return node, nil
+	}
+
+	if err := p.predicateObjectList(node); err != nil {
+		return Term{}, err
+	}
+
+	return node, p.expect(']')
+}
+
+// collection parses "( items )" into an rdf:first/rdf:rest list.
+func (p *parser) collection() (Term, error) {
+	p.pos++ // '('
+
+	var items []Term
+
+	for {
+		if p.skipSpace(); p.peek() == ')' {
+			p.pos++
+			break
+		}
+
+		if p.pos >= len(p.src) {
+			return Term{}, fmt.Errorf("unterminated collection")
+		}
+
+		item, err := p.object()
+		if err != nil {
+			return Term{}, err
+		}
+
+		items = append(items, item)
+	}
+
+	head := iri(RDF + "nil")
+	for i := len(items) - 1; i >= 0; i-- {
+		node := p.newBlankNode()
+		p.triples = append(p.triples,
+			Triple{Subject: node, Predicate: iri(RDF + "first"), Object: items[i]},
+			Triple{Subject: node, Predicate: iri(RDF + "rest"), Object: head},
+		)
+		head = node
+	}
+
+	return head, nil
+}
+
+func (p *parser) newBlankNode() Term {
+	p.bnodes++
+	return Term{Kind: BlankNode, Value: fmt.Sprintf("genid%d", p.bnodes)}
+}
+
+func (p *parser) blankNodeLabel() (Term, error) {
+	name := p.readName()
+	if !strings.HasPrefix(name, "_:") || len(name) == 2 {
+		return Term{}, fmt.Errorf("invalid blank node label %q", name)
+	}
+
+	// Labels are scoped to the document; keep them apart from generated ones.
+	return Term{Kind: BlankNode, Value: "b" + name[2:]}, nil
+}
@@ -0,0 +1,85 @@
+package rdf
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// line returns the 1-based line of the current position, for errors.
+func (p *parser) line() int {
+	return strings.Count(p.src[:p.pos], "\n") + 1
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipSpace()
+
+	if p.peek() != c {
+		return fmt.Errorf("expected %q, got %s", c, p.found())
+	}
+
+	p.pos++
+
+	return nil
+}
+
+// found describes the input at the current position, for errors.
+func (p *parser) found() string {
+	if p.pos >= len(p.src) {
+		return "end of input"
+	}
+
+	r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+
+	return fmt.Sprintf("%q", r)
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.src) {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+// peekWord returns the run of letters and '@' at the current position
+// without consuming it, to recognize keywords. It is empty when the run is
+// the start of a prefixed name, such as "a:b" or "true-ish:x".
+func (p *parser) peekWord() string {
+	end := p.pos
+	for end < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[end:])
+		if !unicode.IsLetter(r) && r != '@' {
+			break
+		}
+
+		end += size
+	}
+
+	if end < len(p.src) {
+		if r, _ := utf8.DecodeRuneInString(p.src[end:]); r == ':' || r == '_' || r == '-' || unicode.IsDigit(r) {
+			return ""
+		}
+	}
+
+	return p.src[p.pos:end]
+}
+
+// skipSpace skips whitespace and comments.
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '#':
+			if i := strings.IndexByte(p.src[p.pos:], '\n'); i >= 0 {
+				p.pos += i + 1
+			} else {
+				p.pos = len(p.src)
+			}
+		default:
+			return
+		}
+	}
+}
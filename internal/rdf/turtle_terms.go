@@ -0,0 +1,289 @@
+package rdf
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// iriTerm parses an IRI reference or a prefixed name.
+func (p *parser) iriTerm() (Term, error) {
+	if p.peek() == '<' {
+		ref, err := p.iriRef()
+		return iri(ref), err
+	}
+
+	name := p.readName()
+	if name == "" {
+		return Term{}, fmt.Errorf("expected an IRI, got %s", p.found())
+	}
+
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		return Term{}, fmt.Errorf("expected an IRI, got %q", name)
+	}
+
+	ns, ok := p.prefixes[prefix]
+	if !ok {
+		return Term{}, fmt.Errorf("undeclared prefix %q", prefix)
+	}
+
+	return iri(ns + local), nil
+}
+
+// iriRef parses "<...>", resolving a relative IRI against the base.
+func (p *parser) iriRef() (string, error) {
+	if p.peek() != '<' {
+		return "", fmt.Errorf("expected '<', got %s", p.found())
+	}
+
+	p.pos++
+
+	var b strings.Builder
+
+	for {
+		if p.pos >= len(p.src) {
+			return "", fmt.Errorf("unterminated IRI")
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == '>':
+			p.pos++
+			return p.resolve(b.String()), nil
+		case c == '\\':
+			r, err := p.unicodeEscape()
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteRune(r)
+		case c == ' ' || c == '\n' || c == '"':
+			return "", fmt.Errorf("invalid character %q in IRI", c)
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+func (p *parser) resolve(ref string) string {
+	if p.base == nil {
+		return ref
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil || u.IsAbs() {
+		return ref
+	}
+
+	return p.base.ResolveReference(u).String()
+}
+
+// readName reads a prefixed name or blank node label, unescaping "\x"
+// sequences. A trailing '.' ends the statement, so it is left unread.
+func (p *parser) readName() string {
+	var b strings.Builder
+
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+
+		switch {
+		case r == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:%", r):
+			b.WriteRune(r)
+			p.pos += size
+
+			continue
+		}
+
+		break
+	}
+
+	name := b.String()
+	for strings.HasSuffix(name, ".") && p.pos > start {
+		name = name[:len(name)-1]
+		p.pos--
+	}
+
+	return name
+}
+
+// literal parses a quoted string, short or long ("""..."""), with an
+// optional language tag or datatype.
+func (p *parser) literal() (Term, error) {
+	q := p.src[p.pos]
+	long := strings.HasPrefix(p.src[p.pos:], strings.Repeat(string(q), 3))
+
+	delim := string(q)
+	if long {
+		delim = strings.Repeat(delim, 3)
+	}
+
+	p.pos += len(delim)
+
+	var b strings.Builder
+
+	for {
+		if p.pos >= len(p.src) {
+			return Term{}, fmt.Errorf("unterminated string")
+		}
+
+		if strings.HasPrefix(p.src[p.pos:], delim) {
+			p.pos += len(delim)
+			break
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == '\\':
+			r, err := p.stringEscape()
+			if err != nil {
+				return Term{}, err
+			}
+
+			b.WriteRune(r)
+		case !long && (c == '\n' || c == '\r'):
+			return Term{}, fmt.Errorf("newline in string")
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	term := Term{Kind: Literal, Value: b.String()}
+
+	switch {
+	case p.peek() == '@':
+		p.pos++
+		start := p.pos
+
+		for p.pos < len(p.src) && (isASCIILetter(p.src[p.pos]) || p.src[p.pos] == '-' || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+
+		if term.Lang = strings.ToLower(p.src[start:p.pos]); term.Lang == "" {
+			return Term{}, fmt.Errorf("empty language tag")
+		}
+	case strings.HasPrefix(p.src[p.pos:], "^^"):
+		p.pos += 2
+
+		datatype, err := p.iriTerm()
+		if err != nil {
+			return Term{}, err
+		}
+
+		term.Datatype = datatype.Value
+	}
+
+	return term, nil
+}
+
+// stringEscape reads a backslash escape in a string.
+func (p *parser) stringEscape() (rune, error) {
+	if p.pos+1 >= len(p.src) {
+		return 0, fmt.Errorf("unterminated escape")
+	}
+
+	if c := p.src[p.pos+1]; c == 'u' || c == 'U' {
+		return p.unicodeEscape()
+	}
+
+	r, ok := map[byte]rune{'t': '\t', 'b': '\b', 'n': '\n', 'r': '\r', 'f': '\f', '"': '"', '\'': '\'', '\\': '\\'}[p.src[p.pos+1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid escape \\%c", p.src[p.pos+1])
+	}
+
+	p.pos += 2
+
+	return r, nil
+}
+
+// unicodeEscape reads \uXXXX or \UXXXXXXXX.
+func (p *parser) unicodeEscape() (rune, error) {
+	if p.pos+1 >= len(p.src) {
+		return 0, fmt.Errorf("unterminated escape")
+	}
+
+	n := 4
+	switch p.src[p.pos+1] {
+	case 'u':
+	case 'U':
+		n = 8
+	default:
+		return 0, fmt.Errorf("invalid escape \\%c", p.src[p.pos+1])
+	}
+
+	if p.pos+2+n > len(p.src) {
+		return 0, fmt.Errorf("unterminated escape")
+	}
+
+	v, err := strconv.ParseUint(p.src[p.pos+2:p.pos+2+n], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unicode escape: %w", err)
+	}
+
+	p.pos += 2 + n
+
+	return rune(v), nil
+}
+
+// number parses an integer, decimal, or double.
+func (p *parser) number() (Term, error) {
+	start := p.pos
+	datatype := XSD + "integer"
+
+	if c := p.peek(); c == '+' || c == '-' {
+		p.pos++
+	}
+
+	p.digits()
+
+	// A '.' not followed by a digit ends the statement.
+	if p.peek() == '.' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1]) {
+		p.pos++
+		p.digits()
+
+		datatype = XSD + "decimal"
+	}
+
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+
+		p.digits()
+
+		datatype = XSD + "double"
+	}
+
+	value := p.src[start:p.pos]
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return Term{}, fmt.Errorf("invalid number %q", value)
+	}
+
+	return Term{Kind: Literal, Value: value, Datatype: datatype}, nil
+}
+
+func (p *parser) digits() {
+	for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,114 @@
+package rdf_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/persistorai/persistor/internal/rdf"
+)
+
+func TestParseTurtle_PrefixedNamesAndLists(t *testing.T) {
+	src := `@prefix ex: <http://example.org/> .
+PREFIX foaf: <http://xmlns.com/foaf/0.1/>
+
+# Alice knows two people.
+ex:alice a foaf:Person ;
+    foaf:name "Alice"@EN , "Alicia"@es ;
+    foaf:knows ex:bob, ex:carol ;
+    .`
+
+	doc, err := rdf.ParseTurtle([]byte(src), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://example.org/", doc.Prefixes["ex"])
+	require.Len(t, doc.Triples, 5)
+
+	assert.Equal(t, rdf.RDF+"type", doc.Triples[0].Predicate.Value)
+	assert.Equal(t, "http://xmlns.com/foaf/0.1/Person", doc.Triples[0].Object.Value)
+	assert.Equal(t, rdf.Term{Kind: rdf.Literal, Value: "Alice", Lang: "en"}, doc.Triples[1].Object)
+	assert.Equal(t, "es", doc.Triples[2].Object.Lang)
+	assert.Equal(t, "http://example.org/carol", doc.Triples[4].Object.Value)
+}
+
+func TestParseTurtle_Literals(t *testing.T) {
+	src := `@prefix ex: <http://example.org/> .
+@prefix xsd: <http://www.w3.org/2001/XMLSchema#> .
+ex:s ex:int 42 ; ex:dec -1.5 ; ex:dbl 1e3 ; ex:bool true ;
+    ex:typed "7"^^xsd:integer ;
+    ex:long """two
+lines""" ;
+    ex:esc "tab\there é" .`
+
+	doc, err := rdf.ParseTurtle([]byte(src), "")
+	require.NoError(t, err)
+	require.Len(t, doc.Triples, 7)
+
+	want := []rdf.Term{
+		{Kind: rdf.Literal, Value: "42", Datatype: rdf.XSD + "integer"},
+		{Kind: rdf.Literal, Value: "-1.5", Datatype: rdf.XSD + "decimal"},
+		{Kind: rdf.Literal, Value: "1e3", Datatype: rdf.XSD + "double"},
+		{Kind: rdf.Literal, Value: "true", Datatype: rdf.XSD + "boolean"},
+		{Kind: rdf.Literal, Value: "7", Datatype: rdf.XSD + "integer"},
+		{Kind: rdf.Literal, Value: "two\nlines"},
+		{Kind: rdf.Literal, Value: "tab\there é"},
+	}
+	for i, w := range want {
+		assert.Equal(t, w, doc.Triples[i].Object, "triple %d", i)
+	}
+}
+
+func TestParseTurtle_BlankNodesAndCollections(t *testing.T) {
+	src := `@prefix ex: <http://example.org/> .
+ex:s ex:p [ ex:q ex:o ] ; ex:list ( ex:a ex:b ) .
+_:x ex:p ex:o .`
+
+	doc, err := rdf.ParseTurtle([]byte(src), "")
+	require.NoError(t, err)
+
+	// [ ex:q ex:o ], ex:p, two list cells of two triples each, ex:list, _:x.
+	require.Len(t, doc.Triples, 8)
+	assert.Equal(t, rdf.BlankNode, doc.Triples[0].Subject.Kind)
+	assert.Equal(t, doc.Triples[0].Subject, doc.Triples[1].Object)
+	assert.Equal(t, rdf.RDF+"first", doc.Triples[4].Predicate.Value)
+	assert.Equal(t, "http://example.org/a", doc.Triples[4].Object.Value)
+	assert.Equal(t, rdf.BlankNode, doc.Triples[7].Subject.Kind)
+}
+
+func TestParseTurtle_NTriplesAndBase(t *testing.T) {
+	src := `<http://example.org/s> <http://example.org/p> <o> .
+<http://example.org/s> <http://example.org/p> "x" .`
+
+	doc, err := rdf.ParseTurtle([]byte(src), "http://example.org/dir/")
+	require.NoError(t, err)
+	require.Len(t, doc.Triples, 2)
+	assert.Equal(t, "http://example.org/dir/o", doc.Triples[0].Object.Value)
+}
+
+func TestParseTurtle_Errors(t *testing.T) {
+	tests := map[string]string{
+		"undeclared prefix":    `ex:s ex:p ex:o .`,
+		"missing dot":          `<http://a> <http://b> <http://c>`,
+		"unterminated string":  `<http://a> <http://b> "abc .`,
+		"unterminated IRI":     `<http://a> <http://b> <http://c .`,
+		"invalid escape":       `<http://a> <http://b> "\q" .`,
+		"unclosed blank node":  `<http://a> <http://b> [ <http://c> <http://d> .`,
+		"bad prefix directive": `@prefix ex <http://example.org/> .`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := rdf.ParseTurtle([]byte(src), "")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseTurtle_ErrorLine(t *testing.T) {
+	src := "@prefix ex: <http://example.org/> .\nex:a ex:b ex:c .\nex:a ex:b nope:c .\n"
+
+	_, err := rdf.ParseTurtle([]byte(src), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
@@ -4,19 +4,25 @@
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
 )
 
 const (
-	BruteForceMaxAttempts = 5
-	BruteForceWindow      = 15 * time.Minute
-	BruteForceLockout     = 5 * time.Minute
-	bruteForceCleanup     = 60 * time.Second
-	bruteForceMaxRecords  = 10000
+	BruteForceMaxAttempts  = 5
+	BruteForceWindow       = 15 * time.Minute
+	BruteForceLockout      = 5 * time.Minute
+	bruteForceCleanup      = 60 * time.Second
+	bruteForceMaxRecords   = 10000
+	bruteForceSync         = 10 * time.Second
+	bruteForceStoreTimeout = 2 * time.Second
+	blockKeyHashPrefix     = 16
 )
 
 type failureRecord struct {
@@ -27,9 +33,16 @@ type failureRecord struct {
 
 // BruteForceGuard tracks per-key-hash authentication failures and blocks
 // keys that exceed the failure threshold within the tracking window.
+//
+// Without a BlockStore all state is in memory. With one, the store is the
+// source of truth for failure counts, and active blocks are cached in shared
+// and refreshed every bruteForceSync. If the store is unreachable the guard
+// falls back to counting in memory so that failures are never ignored.
 type BruteForceGuard struct {
 	mu      sync.Mutex
 	records map[string]*failureRecord
+	shared  map[string]time.Time // key hash → blocked until, from the store
+	store   BlockStore
 	log     *logrus.Logger
 }
 
@@ -38,6 +51,7 @@ type BruteForceGuard struct {
 func NewBruteForceGuard(ctx context.Context, log *logrus.Logger) *BruteForceGuard {
 	g := &BruteForceGuard{
 		records: make(map[string]*failureRecord),
+		shared:  make(map[string]time.Time),
 		log:     log,
 	}
 	go g.cleanupLoop(ctx)
@@ -56,15 +70,12 @@ func (g *BruteForceGuard) IsBlocked(apiKey string) bool {
 	defer g.mu.Unlock()
 
 	rec, ok := g.records[kh]
-	if !ok {
-		return false
-	}
-
-	if !rec.lockedAt.IsZero() && time.Since(rec.lockedAt) < BruteForceLockout {
+	if ok && !rec.lockedAt.IsZero() && time.Since(rec.lockedAt) < BruteForceLockout {
 		return true
 	}
 
-	return false
+	until, ok := g.shared[kh]
+	return ok && time.Now().Before(until)
 }
 
 // RecordFailure records a failed authentication attempt for the given API key.
@@ -72,6 +83,10 @@ func (g *BruteForceGuard) RecordFailure(apiKey string) {
 	kh := keyHash(apiKey)
 	now := time.Now()
 
+	if g.store != nil && g.recordSharedFailure(kh, now) {
+		return
+	}
+
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
@@ -96,12 +111,70 @@ func (g *BruteForceGuard) RecordFailure(apiKey string) {
 	}
 }
 
-// ResetKey clears failure tracking for a key (call on successful auth).
+// ResetKey clears failure tracking for a key (call on successful auth). The
+// store is only written when this process has seen failures for the key, so
+// successful requests do not each cost a round trip.
 func (g *BruteForceGuard) ResetKey(apiKey string) {
 	kh := keyHash(apiKey)
 	g.mu.Lock()
+	_, failed := g.records[kh]
+	_, blocked := g.shared[kh]
 	delete(g.records, kh)
+	delete(g.shared, kh)
+	g.mu.Unlock()
+
+	if g.store == nil || (!failed && !blocked) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bruteForceStoreTimeout)
+	defer cancel()
+
+	if err := g.store.ResetAuthFailures(ctx, kh); err != nil {
+		g.log.WithError(err).Warn("resetting shared auth failures")
+	}
+}
+
+// Blocks returns the keys currently locked out, longest block first. Key
+// hashes are truncated to a prefix.
+func (g *BruteForceGuard) Blocks(ctx context.Context) ([]models.SecurityBlock, error) {
+	now := time.Now()
+	byHash := make(map[string]models.SecurityBlock)
+
+	if g.store != nil {
+		stored, err := g.store.ListSecurityBlocks(ctx, now)
+		if err != nil {
+			return nil, fmt.Errorf("listing shared blocks: %w", err)
+		}
+
+		for _, b := range stored {
+			byHash[b.KeyHash] = b
+		}
+	}
+
+	g.mu.Lock()
+	for kh, rec := range g.records {
+		until := rec.lockedAt.Add(BruteForceLockout)
+		if _, ok := byHash[kh]; !ok && !rec.lockedAt.IsZero() && now.Before(until) {
+			byHash[kh] = models.SecurityBlock{KeyHash: kh, Attempts: rec.attempts, FirstFailureAt: rec.firstFail, BlockedUntil: until}
+		}
+	}
 	g.mu.Unlock()
+
+	blocks := make([]models.SecurityBlock, 0, len(byHash))
+	for _, b := range byHash {
+		b.KeyHash = b.KeyHash[:min(len(b.KeyHash), blockKeyHashPrefix)]
+		blocks = append(blocks, b)
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if !blocks[i].BlockedUntil.Equal(blocks[j].BlockedUntil) {
+			return blocks[i].BlockedUntil.After(blocks[j].BlockedUntil)
+		}
+		return blocks[i].KeyHash < blocks[j].KeyHash
+	})
+
+	return blocks, nil
 }
 
 func (g *BruteForceGuard) cleanupLoop(ctx context.Context) {
@@ -125,7 +198,16 @@ func (g *BruteForceGuard) cleanupLoop(ctx context.Context) {
 			if len(g.records) > bruteForceMaxRecords {
 				g.evictOldest(len(g.records) - bruteForceMaxRecords)
 			}
+			for k, until := range g.shared {
+				if !now.Before(until) {
+					delete(g.shared, k)
+				}
+			}
 			g.mu.Unlock()
+
+			if g.store != nil {
+				g.purgeStore(ctx, now)
+			}
 		}
 	}
 }
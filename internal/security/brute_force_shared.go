@@ -0,0 +1,114 @@
+package security
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// BlockStore persists failure counts and blocks by key hash so that they
+// survive restarts and are shared between replicas.
+type BlockStore interface {
+	RecordAuthFailure(ctx context.Context, keyHash string, now, windowStart, lockUntil time.Time, maxAttempts int) (int, *time.Time, error)
+	ResetAuthFailures(ctx context.Context, keyHash string) error
+	ListSecurityBlocks(ctx context.Context, now time.Time) ([]models.SecurityBlock, error)
+	PurgeSecurityBlocks(ctx context.Context, now, windowStart time.Time) (int64, error)
+}
+
+// NewSharedBruteForceGuard creates a guard backed by store. Active blocks are
+// loaded before it returns, so a restart keeps existing blocks, and a key
+// blocked on one replica is blocked on all of them within bruteForceSync.
+// Background loops stop when ctx is cancelled.
+func NewSharedBruteForceGuard(ctx context.Context, log *logrus.Logger, store BlockStore) *BruteForceGuard {
+	g := &BruteForceGuard{
+		records: make(map[string]*failureRecord),
+		shared:  make(map[string]time.Time),
+		store:   store,
+		log:     log,
+	}
+	g.syncBlocks(ctx)
+	go g.syncLoop(ctx)
+	go g.cleanupLoop(ctx)
+	return g
+}
+
+// recordSharedFailure counts a failure in the store and mirrors the result
+// locally. It returns false if the store could not be reached.
+func (g *BruteForceGuard) recordSharedFailure(kh string, now time.Time) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), bruteForceStoreTimeout)
+	defer cancel()
+
+	attempts, until, err := g.store.RecordAuthFailure(ctx, kh, now, now.Add(-BruteForceWindow), now.Add(BruteForceLockout), BruteForceMaxAttempts)
+	if err != nil {
+		g.log.WithError(err).Warn("brute-force store unavailable, tracking auth failure in memory")
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// The local record lets ResetKey know the store has state to clear and
+	// carries the count forward if the store later becomes unreachable.
+	rec, ok := g.records[kh]
+	if !ok || attempts == 1 {
+		rec = &failureRecord{firstFail: now}
+		g.records[kh] = rec
+	}
+	rec.attempts = attempts
+
+	if until != nil {
+		g.shared[kh] = *until
+		g.log.WithField("key_hash", kh[:16]+"...").Warn("api key locked out due to repeated auth failures")
+	}
+
+	return true
+}
+
+// syncBlocks replaces the cached shared blocks with those active in the
+// store. On error the previous cache is kept.
+func (g *BruteForceGuard) syncBlocks(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, bruteForceStoreTimeout)
+	defer cancel()
+
+	blocks, err := g.store.ListSecurityBlocks(ctx, time.Now())
+	if err != nil {
+		g.log.WithError(err).Warn("loading shared brute-force blocks")
+		return
+	}
+
+	shared := make(map[string]time.Time, len(blocks))
+	for _, b := range blocks {
+		shared[b.KeyHash] = b.BlockedUntil
+	}
+
+	g.mu.Lock()
+	g.shared = shared
+	g.mu.Unlock()
+}
+
+func (g *BruteForceGuard) syncLoop(ctx context.Context) {
+	ticker := time.NewTicker(bruteForceSync)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.syncBlocks(ctx)
+		}
+	}
+}
+
+// purgeStore deletes expired rows from the store.
+func (g *BruteForceGuard) purgeStore(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, bruteForceStoreTimeout)
+	defer cancel()
+
+	if _, err := g.store.PurgeSecurityBlocks(ctx, now, now.Add(-BruteForceWindow)); err != nil {
+		g.log.WithError(err).Warn("purging expired brute-force blocks")
+	}
+}
@@ -2,10 +2,14 @@
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/persistorai/persistor/internal/models"
 	"github.com/persistorai/persistor/internal/security"
 )
 
@@ -54,3 +58,127 @@ func TestBruteForce_NotBlockedBeforeMax(t *testing.T) {
 		t.Fatal("key should not be blocked before max failures")
 	}
 }
+
+// memBlockStore is an in-memory security.BlockStore shared between guards to
+// stand in for the database.
+type memBlockStore struct {
+	mu      sync.Mutex
+	records map[string]*models.SecurityBlock
+	fail    bool
+}
+
+func newMemBlockStore() *memBlockStore {
+	return &memBlockStore{records: make(map[string]*models.SecurityBlock)}
+}
+
+func (m *memBlockStore) RecordAuthFailure(_ context.Context, keyHash string, now, windowStart, lockUntil time.Time, maxAttempts int) (int, *time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fail {
+		return 0, nil, errors.New("store down")
+	}
+
+	rec, ok := m.records[keyHash]
+	if !ok || rec.FirstFailureAt.Before(windowStart) {
+		rec = &models.SecurityBlock{KeyHash: keyHash, FirstFailureAt: now}
+		m.records[keyHash] = rec
+	}
+
+	rec.Attempts++
+	if rec.Attempts >= maxAttempts {
+		rec.BlockedUntil = lockUntil
+	}
+
+	if rec.BlockedUntil.IsZero() {
+		return rec.Attempts, nil, nil
+	}
+
+	until := rec.BlockedUntil
+	return rec.Attempts, &until, nil
+}
+
+func (m *memBlockStore) ResetAuthFailures(_ context.Context, keyHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, keyHash)
+	return nil
+}
+
+func (m *memBlockStore) ListSecurityBlocks(_ context.Context, now time.Time) ([]models.SecurityBlock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var blocks []models.SecurityBlock
+	for _, rec := range m.records {
+		if rec.BlockedUntil.After(now) {
+			blocks = append(blocks, *rec)
+		}
+	}
+	return blocks, nil
+}
+
+func (m *memBlockStore) PurgeSecurityBlocks(_ context.Context, _, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newSharedTestGuard(store security.BlockStore) (*security.BruteForceGuard, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return security.NewSharedBruteForceGuard(ctx, log, store), cancel
+}
+
+func TestBruteForce_SharedBlockSurvivesRestart(t *testing.T) {
+	store := newMemBlockStore()
+
+	guard, cancel := newSharedTestGuard(store)
+	for range security.BruteForceMaxAttempts {
+		guard.RecordFailure("badkey")
+	}
+	if !guard.IsBlocked("badkey") {
+		t.Fatal("key should be blocked after max failures")
+	}
+	cancel()
+
+	// A new guard on the same store, as after a restart or on another
+	// replica, sees the block immediately.
+	restarted, cancel := newSharedTestGuard(store)
+	defer cancel()
+
+	if !restarted.IsBlocked("badkey") {
+		t.Fatal("block should be loaded from the store")
+	}
+
+	blocks, err := restarted.Blocks(context.Background())
+	if err != nil {
+		t.Fatalf("Blocks: %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Attempts != security.BruteForceMaxAttempts || len(blocks[0].KeyHash) != 16 {
+		t.Fatalf("blocks = %+v, want one truncated block", blocks)
+	}
+
+	restarted.ResetKey("badkey")
+	if restarted.IsBlocked("badkey") {
+		t.Fatal("key should not be blocked after reset")
+	}
+	if blocks, _ := store.ListSecurityBlocks(context.Background(), time.Now()); len(blocks) != 0 {
+		t.Fatalf("store still has blocks after reset: %+v", blocks)
+	}
+}
+
+func TestBruteForce_SharedStoreFallsBackToMemory(t *testing.T) {
+	store := newMemBlockStore()
+	store.fail = true
+
+	guard, cancel := newSharedTestGuard(store)
+	defer cancel()
+
+	for range security.BruteForceMaxAttempts {
+		guard.RecordFailure("badkey")
+	}
+
+	if !guard.IsBlocked("badkey") {
+		t.Fatal("key should be blocked in memory when the store is down")
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	// accessFlushInterval is how often AccessAnalyticsService.Run writes
+	// buffered access counts.
+	accessFlushInterval = time.Minute
+	// maxPendingAccessKeys bounds the distinct counters buffered between
+	// flushes; accesses to new keys beyond it are dropped until the next one.
+	maxPendingAccessKeys = 50000
+)
+
+// AccessStatsStore is the data-access interface AccessAnalyticsService
+// depends on.
+type AccessStatsStore interface {
+	RecordAccessStats(ctx context.Context, tenantID string, counts []models.AccessCount) error
+	GetAccessAnalytics(ctx context.Context, tenantID string, req models.AccessAnalyticsRequest) (*models.AccessAnalytics, error)
+}
+
+// Compile-time check: *AccessAnalyticsService must satisfy domain.AccessAnalyticsService.
+var _ domain.AccessAnalyticsService = (*AccessAnalyticsService)(nil)
+
+// accessKey identifies one buffered access counter.
+type accessKey struct {
+	tenantID string
+	kind     models.AccessKind
+	key      string
+}
+
+// AccessAnalyticsService counts node reads, searches, and traversals in
+// memory, writes the counts from Run, and reports them per tenant.
+type AccessAnalyticsService struct {
+	store AccessStatsStore
+	log   *logrus.Logger
+
+	mu      sync.Mutex
+	pending map[accessKey]int64
+}
+
+// NewAccessAnalyticsService creates an AccessAnalyticsService.
+func NewAccessAnalyticsService(store AccessStatsStore, log *logrus.Logger) *AccessAnalyticsService {
+	return &AccessAnalyticsService{store: store, log: log, pending: make(map[accessKey]int64)}
+}
+
+// TrackAccess counts one access. It never blocks on the database.
+func (s *AccessAnalyticsService) TrackAccess(tenantID string, kind models.AccessKind, key string) {
+	if key == "" {
+		return
+	}
+
+	k := accessKey{tenantID: tenantID, kind: kind, key: key}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pending[k]; !ok && len(s.pending) >= maxPendingAccessKeys {
+		return
+	}
+
+	s.pending[k]++
+}
+
+// GetAccessAnalytics reports the tenant's access patterns. Accesses not yet
+// flushed are not included.
+func (s *AccessAnalyticsService) GetAccessAnalytics(
+	ctx context.Context, tenantID string, req models.AccessAnalyticsRequest,
+) (*models.AccessAnalytics, error) {
+	return s.store.GetAccessAnalytics(ctx, tenantID, req)
+}
+
+// Run flushes buffered counts every accessFlushInterval until ctx is
+// cancelled, then flushes once more.
+func (s *AccessAnalyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(accessFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			s.Flush(drainCtx)
+			cancel()
+
+			return
+		case <-ticker.C:
+			s.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the buffered counts, one batch per tenant. A tenant whose
+// write fails loses that batch.
+func (s *AccessAnalyticsService) Flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[accessKey]int64)
+	s.mu.Unlock()
+
+	byTenant := make(map[string][]models.AccessCount)
+	for k, count := range pending {
+		byTenant[k.tenantID] = append(byTenant[k.tenantID], models.AccessCount{Kind: k.kind, Key: k.key, Count: count})
+	}
+
+	for tenantID, counts := range byTenant {
+		if err := s.store.RecordAccessStats(ctx, tenantID, counts); err != nil {
+			s.log.WithError(err).WithField("tenant_id", tenantID).Warn("recording access stats")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockAccessStatsStore struct {
+	recorded map[string][]models.AccessCount
+}
+
+func (m *mockAccessStatsStore) RecordAccessStats(_ context.Context, tenantID string, counts []models.AccessCount) error {
+	m.recorded[tenantID] = append(m.recorded[tenantID], counts...)
+	return nil
+}
+
+func (m *mockAccessStatsStore) GetAccessAnalytics(
+	_ context.Context, _ string, _ models.AccessAnalyticsRequest,
+) (*models.AccessAnalytics, error) {
+	return &models.AccessAnalytics{}, nil
+}
+
+func TestAccessAnalyticsService_Flush(t *testing.T) {
+	store := &mockAccessStatsStore{recorded: make(map[string][]models.AccessCount)}
+	svc := NewAccessAnalyticsService(store, logrus.New())
+	ctx := context.Background()
+
+	svc.TrackAccess("t1", models.AccessRead, "a")
+	svc.TrackAccess("t1", models.AccessRead, "a")
+	svc.TrackAccess("t1", models.AccessTraverse, "a")
+	svc.TrackAccess("t2", models.AccessRead, "b")
+	svc.TrackAccess("t2", models.AccessRead, "")
+	svc.Flush(ctx)
+
+	counts := make(map[models.AccessKind]int64)
+	for _, c := range store.recorded["t1"] {
+		counts[c.Kind] += c.Count
+	}
+	if counts[models.AccessRead] != 2 || counts[models.AccessTraverse] != 1 {
+		t.Errorf("t1 counts = %+v, want 2 reads and 1 traversal", store.recorded["t1"])
+	}
+	if got := store.recorded["t2"]; len(got) != 1 || got[0].Key != "b" {
+		t.Errorf("t2 counts = %+v, want one read of b", got)
+	}
+
+	// Flushed counts are not written again.
+	svc.Flush(ctx)
+	if len(store.recorded["t1"]) != 2 {
+		t.Errorf("second flush rewrote counts: %+v", store.recorded["t1"])
+	}
+}
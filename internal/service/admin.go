@@ -7,19 +7,21 @@
 
 	"github.com/persistorai/persistor/internal/domain"
 	"github.com/persistorai/persistor/internal/models"
-	"github.com/persistorai/persistor/internal/store"
+	"github.com/persistorai/persistor/internal/storage"
 )
 
 // AdminStore is the data-access interface AdminService depends on.
 type AdminStore interface {
 	ListNodesWithoutEmbeddings(ctx context.Context, tenantID string, limit int) ([]models.NodeSummary, error)
-	ListNodesForReprocess(ctx context.Context, tenantID string, limit int) ([]store.ReprocessableNode, error)
-	ListNodesForMaintenance(ctx context.Context, tenantID string, limit int) ([]store.ReprocessableNode, error)
+	ListNodesForReprocess(ctx context.Context, tenantID string, limit int) ([]storage.ReprocessableNode, error)
+	ListNodesForMaintenance(ctx context.Context, tenantID string, limit int) ([]storage.ReprocessableNode, error)
 	CountNodesForReprocess(ctx context.Context, tenantID string) (remainingSearchText, remainingEmbeddings, remainingTotal int, err error)
 	UpdateNodeSearchText(ctx context.Context, tenantID, nodeID, searchText string) error
-	ListDuplicateCandidatePairs(ctx context.Context, tenantID, typeFilter string, limit int) ([]store.DuplicateCandidatePair, error)
+	ListDuplicateCandidatePairs(ctx context.Context, tenantID, typeFilter string, limit int) ([]storage.DuplicateCandidatePair, error)
+	ListDuplicateNodes(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]storage.DuplicateNodePair, error)
 	CreateRetrievalFeedback(ctx context.Context, tenantID string, req models.RetrievalFeedbackRequest) (*models.RetrievalFeedbackRecord, error)
 	ListRetrievalFeedback(ctx context.Context, tenantID string, opts models.RetrievalFeedbackListOpts) ([]models.RetrievalFeedbackRecord, error)
+	ExplainQuery(ctx context.Context, tenantID string, req models.ExplainRequest, embedding []float32) (*models.ExplainResult, error)
 }
 
 // Compile-time check: *AdminService must satisfy domain.AdminService.
@@ -29,6 +31,7 @@ type AdminStore interface {
 type AdminService struct {
 	store       AdminStore
 	embedWorker EmbedEnqueuer
+	embedder    Embedder
 	log         *logrus.Logger
 }
 
@@ -37,6 +40,13 @@ func NewAdminService(store AdminStore, embedWorker EmbedEnqueuer, log *logrus.Lo
 	return &AdminService{store: store, embedWorker: embedWorker, log: log}
 }
 
+// WithEmbedder enables query embeddings for explaining semantic and hybrid
+// search.
+func (s *AdminService) WithEmbedder(embedder Embedder) *AdminService {
+	s.embedder = embedder
+	return s
+}
+
 // ListNodesWithoutEmbeddings returns nodes with a NULL embedding vector, up to limit.
 func (s *AdminService) ListNodesWithoutEmbeddings(ctx context.Context, tenantID string, limit int) ([]models.NodeSummary, error) {
 	s.log.WithFields(logrus.Fields{
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+)
+
+// ListDuplicates returns probable duplicate node pairs, each oriented so the
+// duplicate can be merged into the canonical node with NodeService.MergeNode.
+func (s *AdminService) ListDuplicates(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]models.DuplicatePair, error) {
+	if opts.MinScore <= 0 {
+		opts.MinScore = models.DefaultDuplicateMinScore
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"type":      opts.Type,
+		"limit":     opts.Limit,
+		"min_score": opts.MinScore,
+	}).Debug("admin.list_duplicates")
+
+	pairs, err := s.store.ListDuplicateNodes(ctx, tenantID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]models.DuplicatePair, 0, len(pairs))
+	for _, p := range pairs {
+		duplicates = append(duplicates, buildDuplicatePair(p))
+	}
+
+	return duplicates, nil
+}
+
+func buildDuplicatePair(p storage.DuplicateNodePair) models.DuplicatePair {
+	canonical, duplicate := p.Left, p.Right
+	if duplicate.Salience > canonical.Salience {
+		canonical, duplicate = duplicate, canonical
+	}
+
+	pair := models.DuplicatePair{
+		Canonical:       canonical,
+		Duplicate:       duplicate,
+		Score:           roundScore(p.Score),
+		LabelSimilarity: roundScore(p.LabelSimilarity),
+	}
+	if p.EmbeddingSimilarity != nil {
+		sim := roundScore(*p.EmbeddingSimilarity)
+		pair.EmbeddingSimilarity = &sim
+	}
+
+	return pair
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
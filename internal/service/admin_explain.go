@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ExplainQuery returns the query plan for a named internal query run with
+// the request's parameters against the tenant's data. Semantic and hybrid
+// queries embed params.q unless params.embedding is given.
+func (s *AdminService) ExplainQuery(ctx context.Context, tenantID string, req models.ExplainRequest) (*models.ExplainResult, error) {
+	req = req.Normalized()
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var embedding []float32
+
+	if req.Query == models.ExplainSemantic || req.Query == models.ExplainHybrid {
+		embedding = req.Params.Embedding
+		if len(embedding) == 0 {
+			if s.embedder == nil {
+				return nil, models.ErrEmbeddingUnavailable
+			}
+
+			var err error
+			if embedding, err = s.embedder.Generate(withUsageTenant(ctx, tenantID), req.Params.Q); err != nil {
+				return nil, fmt.Errorf("%w: %w", models.ErrEmbeddingUnavailable, err)
+			}
+		}
+	}
+
+	result, err := s.store.ExplainQuery(ctx, tenantID, req, embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":         tenantID,
+		"query":             req.Query,
+		"execution_time_ms": result.ExecutionTimeMs,
+	}).Info("admin.explain_query")
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestExplainQueryEmbedsQueryText(t *testing.T) {
+	store := &mockAdminStore{}
+	svc := NewAdminService(store, nil, logrus.New()).WithEmbedder(&mockEmbedder{
+		generate: func(_ context.Context, text string) ([]float32, error) {
+			if text != "quantum" {
+				t.Fatalf("embedded %q, want %q", text, "quantum")
+			}
+			return []float32{0.1, 0.2}, nil
+		},
+	})
+
+	_, err := svc.ExplainQuery(context.Background(), "tenant", models.ExplainRequest{
+		Query:  "Hybrid",
+		Params: models.ExplainParams{Q: " quantum "},
+	})
+	if err != nil {
+		t.Fatalf("ExplainQuery: %v", err)
+	}
+	if store.explainReq.Query != models.ExplainHybrid || store.explainReq.Params.Limit != models.DefaultExplainLimit {
+		t.Fatalf("store got %+v, want normalized hybrid request", store.explainReq)
+	}
+	if len(store.explainEmbedding) != 2 {
+		t.Fatalf("embedding = %v, want generated vector", store.explainEmbedding)
+	}
+}
+
+func TestExplainQueryWithoutEmbedder(t *testing.T) {
+	svc := NewAdminService(&mockAdminStore{}, nil, logrus.New())
+
+	_, err := svc.ExplainQuery(context.Background(), "tenant", models.ExplainRequest{
+		Query:  models.ExplainSemantic,
+		Params: models.ExplainParams{Q: "quantum"},
+	})
+	if !errors.Is(err, models.ErrEmbeddingUnavailable) {
+		t.Fatalf("err = %v, want ErrEmbeddingUnavailable", err)
+	}
+
+	// A caller-supplied embedding needs no embedder.
+	store := &mockAdminStore{}
+	svc = NewAdminService(store, nil, logrus.New())
+
+	if _, err := svc.ExplainQuery(context.Background(), "tenant", models.ExplainRequest{
+		Query:  models.ExplainSemantic,
+		Params: models.ExplainParams{Embedding: []float32{0.5}},
+	}); err != nil {
+		t.Fatalf("ExplainQuery with embedding: %v", err)
+	}
+	if len(store.explainEmbedding) != 1 {
+		t.Fatalf("embedding = %v, want caller's vector", store.explainEmbedding)
+	}
+}
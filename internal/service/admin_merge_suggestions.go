@@ -8,7 +8,7 @@
 	"strings"
 
 	"github.com/persistorai/persistor/internal/models"
-	"github.com/persistorai/persistor/internal/store"
+	"github.com/persistorai/persistor/internal/storage"
 )
 
 const defaultMergeSuggestionMinScore = 0.6
@@ -50,7 +50,7 @@ func (s *AdminService) ListMergeSuggestions(ctx context.Context, tenantID string
 	return suggestions, nil
 }
 
-func buildMergeSuggestion(pair store.DuplicateCandidatePair) models.MergeSuggestion {
+func buildMergeSuggestion(pair storage.DuplicateCandidatePair) models.MergeSuggestion {
 	canonical, duplicate := orderSuggestionNodes(pair.Left, pair.Right)
 	reasons := make([]models.MergeSuggestionReason, 0, 4)
 	score := 0.0
@@ -7,25 +7,29 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/models"
-	"github.com/persistorai/persistor/internal/store"
+	"github.com/persistorai/persistor/internal/storage"
 )
 
 type mockAdminStore struct {
-	pairs       []store.DuplicateCandidatePair
-	reprocess   []store.ReprocessableNode
-	maintenance []store.ReprocessableNode
+	pairs       []storage.DuplicateCandidatePair
+	duplicates  []storage.DuplicateNodePair
+	reprocess   []storage.ReprocessableNode
+	maintenance []storage.ReprocessableNode
 	feedback    []models.RetrievalFeedbackRecord
+
+	explainReq       models.ExplainRequest
+	explainEmbedding []float32
 }
 
 func (m *mockAdminStore) ListNodesWithoutEmbeddings(_ context.Context, _ string, _ int) ([]models.NodeSummary, error) {
 	return nil, nil
 }
 
-func (m *mockAdminStore) ListNodesForReprocess(_ context.Context, _ string, _ int) ([]store.ReprocessableNode, error) {
+func (m *mockAdminStore) ListNodesForReprocess(_ context.Context, _ string, _ int) ([]storage.ReprocessableNode, error) {
 	return m.reprocess, nil
 }
 
-func (m *mockAdminStore) ListNodesForMaintenance(_ context.Context, _ string, _ int) ([]store.ReprocessableNode, error) {
+func (m *mockAdminStore) ListNodesForMaintenance(_ context.Context, _ string, _ int) ([]storage.ReprocessableNode, error) {
 	return m.maintenance, nil
 }
 
@@ -37,10 +41,14 @@ func (m *mockAdminStore) UpdateNodeSearchText(_ context.Context, _, _, _ string)
 	return nil
 }
 
-func (m *mockAdminStore) ListDuplicateCandidatePairs(_ context.Context, _, _ string, _ int) ([]store.DuplicateCandidatePair, error) {
+func (m *mockAdminStore) ListDuplicateCandidatePairs(_ context.Context, _, _ string, _ int) ([]storage.DuplicateCandidatePair, error) {
 	return m.pairs, nil
 }
 
+func (m *mockAdminStore) ListDuplicateNodes(_ context.Context, _ string, _ models.DuplicateListOpts) ([]storage.DuplicateNodePair, error) {
+	return m.duplicates, nil
+}
+
 func (m *mockAdminStore) CreateRetrievalFeedback(_ context.Context, tenantID string, req models.RetrievalFeedbackRequest) (*models.RetrievalFeedbackRecord, error) {
 	record := &models.RetrievalFeedbackRecord{
 		ID:               "feedback-1",
@@ -66,8 +74,13 @@ func (m *mockAdminStore) ListRetrievalFeedback(_ context.Context, _ string, _ mo
 	return append([]models.RetrievalFeedbackRecord(nil), m.feedback...), nil
 }
 
+func (m *mockAdminStore) ExplainQuery(_ context.Context, _ string, req models.ExplainRequest, embedding []float32) (*models.ExplainResult, error) {
+	m.explainReq, m.explainEmbedding = req, embedding
+	return &models.ExplainResult{Query: req.Query}, nil
+}
+
 func TestListMergeSuggestions(t *testing.T) {
-	svc := NewAdminService(&mockAdminStore{pairs: []store.DuplicateCandidatePair{
+	svc := NewAdminService(&mockAdminStore{pairs: []storage.DuplicateCandidatePair{
 		{
 			Left:        models.Node{ID: "node-a", Type: "person", Label: "Bill Gates", Salience: 0.9, Properties: map[string]any{"email": "bill@example.com"}},
 			Right:       models.Node{ID: "node-b", Type: "person", Label: "bill gates", Salience: 0.4, Properties: map[string]any{"email": "bill@example.com"}},
@@ -101,7 +114,7 @@ func TestListMergeSuggestions(t *testing.T) {
 }
 
 func TestListMergeSuggestionsFiltersBelowThreshold(t *testing.T) {
-	svc := NewAdminService(&mockAdminStore{pairs: []store.DuplicateCandidatePair{{
+	svc := NewAdminService(&mockAdminStore{pairs: []storage.DuplicateCandidatePair{{
 		Left:        models.Node{ID: "node-a", Type: "person", Label: "Alice", Salience: 0.5, Properties: map[string]any{}},
 		Right:       models.Node{ID: "node-b", Type: "person", Label: "Alicia", Salience: 0.4, Properties: map[string]any{}},
 		SharedNames: []string{"ali"},
@@ -116,14 +129,41 @@ func TestListMergeSuggestionsFiltersBelowThreshold(t *testing.T) {
 	}
 }
 
+func TestListDuplicatesOrientsTowardHigherSalience(t *testing.T) {
+	embSim := 0.97512
+	svc := NewAdminService(&mockAdminStore{duplicates: []storage.DuplicateNodePair{{
+		Left:                models.MergeSuggestionNode{ID: "node-a", Label: "NYC", Salience: 0.2},
+		Right:               models.MergeSuggestionNode{ID: "node-b", Label: "New York City", Salience: 0.8},
+		Score:               0.6851,
+		LabelSimilarity:     0.1,
+		EmbeddingSimilarity: &embSim,
+	}}}, nil, logrus.New())
+
+	duplicates, err := svc.ListDuplicates(context.Background(), "tenant", models.DuplicateListOpts{})
+	if err != nil {
+		t.Fatalf("ListDuplicates: %v", err)
+	}
+	if len(duplicates) != 1 {
+		t.Fatalf("len(duplicates) = %d, want 1", len(duplicates))
+	}
+
+	d := duplicates[0]
+	if d.Canonical.ID != "node-b" || d.Duplicate.ID != "node-a" {
+		t.Fatalf("canonical/duplicate = %s/%s, want node-b/node-a", d.Canonical.ID, d.Duplicate.ID)
+	}
+	if d.Score != 0.685 || d.EmbeddingSimilarity == nil || *d.EmbeddingSimilarity != 0.975 {
+		t.Fatalf("scores = %v, %v; want rounded", d.Score, d.EmbeddingSimilarity)
+	}
+}
+
 func TestRunMaintenance(t *testing.T) {
 	embed := &mockEmbedEnqueuer{}
 	svc := NewAdminService(&mockAdminStore{
-		maintenance: []store.ReprocessableNode{
+		maintenance: []storage.ReprocessableNode{
 			{ID: "node-a", Type: "person", Label: "Alice", Properties: map[string]any{"summary": "keeps notes", models.FactEvidenceProperty: map[string]any{"summary": []map[string]any{{"supersedes_prior": true}}}}, CurrentSearchText: "", NeedsEmbedding: true, HasFactEvidence: true, HasSupersededFacts: true},
 			{ID: "node-b", Type: "project", Label: "Persistor", Properties: map[string]any{"status": "active"}, CurrentSearchText: models.BuildNodeSearchText(&models.Node{Type: "project", Label: "Persistor", Properties: map[string]any{"status": "active"}}), NodeSuperseded: true},
 		},
-		pairs: []store.DuplicateCandidatePair{{Left: models.Node{ID: "node-a"}, Right: models.Node{ID: "node-c"}}},
+		pairs: []storage.DuplicateCandidatePair{{Left: models.Node{ID: "node-a"}, Right: models.Node{ID: "node-c"}}},
 	}, embed, logrus.New())
 
 	result, err := svc.RunMaintenance(context.Background(), "tenant", models.MaintenanceRunRequest{RefreshSearchText: true, RefreshEmbeddings: true, ScanStaleFacts: true, IncludeDuplicateCandidates: true})
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// apiKeyBytes is the amount of randomness in a generated API key or request
+// signing secret.
+const apiKeyBytes = 32
+
+// APIKeyStore is the data-access interface APIKeyService depends on.
+type APIKeyStore interface {
+	GetAPIKeyStatus(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	RotateAPIKey(ctx context.Context, tenantID, newKey string, grace time.Duration) (*models.APIKeyStatus, error)
+	RevokePreviousAPIKey(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	SetRequestSigningSecret(ctx context.Context, tenantID string, secret []byte) (*models.APIKeyStatus, error)
+	ClearRequestSigningSecret(ctx context.Context, tenantID string) (*models.APIKeyStatus, error)
+	CreateManagedAPIKey(ctx context.Context, tenantID, key string, req models.CreateAPIKeyRequest) (*models.ManagedAPIKey, error)
+	ListManagedAPIKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error)
+	RevokeManagedAPIKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error)
+	RotateManagedAPIKey(ctx context.Context, tenantID, keyID, newKey string, grace time.Duration) (created, previous *models.ManagedAPIKey, err error)
+}
+
+// Compile-time check: *APIKeyService must satisfy domain.APIKeyService.
+var _ domain.APIKeyService = (*APIKeyService)(nil)
+
+// APIKeyService generates and rotates tenant API keys, both the primary key
+// and named managed keys.
+type APIKeyService struct {
+	store APIKeyStore
+	log   *logrus.Logger
+}
+
+// NewAPIKeyService creates an APIKeyService.
+func NewAPIKeyService(store APIKeyStore, log *logrus.Logger) *APIKeyService {
+	return &APIKeyService{store: store, log: log}
+}
+
+// GetAPIKeyStatus returns the tenant's key scope and rotation state.
+func (s *APIKeyService) GetAPIKeyStatus(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	return s.store.GetAPIKeyStatus(ctx, tenantID)
+}
+
+// RotateAPIKey generates a new API key for the tenant. The current key keeps
+// working for the requested grace period so clients can switch over.
+func (s *APIKeyService) RotateAPIKey(
+	ctx context.Context, tenantID string, req models.RotateAPIKeyRequest,
+) (*models.APIKeyRotation, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	grace := req.Grace()
+
+	status, err := s.store.RotateAPIKey(ctx, tenantID, key, grace)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"grace":     grace.String(),
+	}).Info("api_key.rotate")
+
+	return &models.APIKeyRotation{APIKey: key, APIKeyStatus: *status}, nil
+}
+
+// RevokePreviousAPIKey stops accepting a rotated-out key before its grace
+// period ends.
+func (s *APIKeyService) RevokePreviousAPIKey(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	status, err := s.store.RevokePreviousAPIKey(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("tenant_id", tenantID).Info("api_key.revoke_previous")
+
+	return status, nil
+}
+
+// EnableRequestSigning generates a new request signing secret for the tenant,
+// replacing any existing one. Once it takes effect every request must be
+// signed; the secret is returned once and cannot be retrieved again.
+func (s *APIKeyService) EnableRequestSigning(ctx context.Context, tenantID string) (*models.RequestSigningSecret, error) {
+	secret, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.store.SetRequestSigningSecret(ctx, tenantID, []byte(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("tenant_id", tenantID).Info("api_key.enable_signing")
+
+	return &models.RequestSigningSecret{SigningSecret: secret, APIKeyStatus: *status}, nil
+}
+
+// DisableRequestSigning removes the tenant's signing secret so unsigned
+// requests are accepted again.
+func (s *APIKeyService) DisableRequestSigning(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	status, err := s.store.ClearRequestSigningSecret(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("tenant_id", tenantID).Info("api_key.disable_signing")
+
+	return status, nil
+}
+
+// CreateManagedAPIKey generates a named key for the tenant alongside its
+// primary key. The key is returned once and cannot be retrieved again.
+func (s *APIKeyService) CreateManagedAPIKey(
+	ctx context.Context, tenantID string, req models.CreateAPIKeyRequest,
+) (*models.CreatedAPIKey, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.store.CreateManagedAPIKey(ctx, tenantID, key, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"key_id":    k.ID,
+		"scope":     k.Scope,
+	}).Info("api_key.create")
+
+	return &models.CreatedAPIKey{APIKey: key, ManagedAPIKey: *k}, nil
+}
+
+// ListManagedAPIKeys returns the tenant's named keys without revealing them.
+func (s *APIKeyService) ListManagedAPIKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error) {
+	return s.store.ListManagedAPIKeys(ctx, tenantID)
+}
+
+// RevokeManagedAPIKey stops accepting a named key.
+func (s *APIKeyService) RevokeManagedAPIKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error) {
+	k, err := s.store.RevokeManagedAPIKey(ctx, tenantID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"tenant_id": tenantID, "key_id": keyID}).Info("api_key.revoke")
+
+	return k, nil
+}
+
+// RotateManagedAPIKey replaces a named key with a new one of the same name,
+// scope, and expiry. The old key keeps working for the requested grace period.
+func (s *APIKeyService) RotateManagedAPIKey(
+	ctx context.Context, tenantID, keyID string, req models.RotateAPIKeyRequest,
+) (*models.RotatedAPIKey, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	grace := req.Grace()
+
+	created, previous, err := s.store.RotateManagedAPIKey(ctx, tenantID, keyID, key, grace)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":  tenantID,
+		"key_id":     keyID,
+		"new_key_id": created.ID,
+		"grace":      grace.String(),
+	}).Info("api_key.rotate_managed")
+
+	return &models.RotatedAPIKey{
+		CreatedAPIKey: models.CreatedAPIKey{APIKey: key, ManagedAPIKey: *created},
+		Previous:      *previous,
+	}, nil
+}
+
+// generateAPIKey returns a random hex-encoded API key.
+func generateAPIKey() (string, error) {
+	buf := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating api key: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
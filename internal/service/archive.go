@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// archiveInterval is how often NodeArchiver.Run applies every tenant's
+// archive policy.
+const archiveInterval = 6 * time.Hour
+
+// ArchiveStore is the data-access interface NodeArchiver depends on.
+type ArchiveStore interface {
+	GetArchivePolicy(ctx context.Context, tenantID string) (*models.ArchivePolicy, error)
+	SetArchivePolicy(ctx context.Context, tenantID string, p models.ArchivePolicy) (*models.ArchivePolicy, error)
+	ListArchivePolicies(ctx context.Context) ([]models.TenantArchivePolicy, error)
+	ArchiveNodes(ctx context.Context, tenantID string, salienceBelow float64, before time.Time) (*models.ArchiveResult, error)
+	ListArchivedNodes(ctx context.Context, tenantID string, limit, offset int) ([]models.ArchivedNode, bool, error)
+	RestoreNode(ctx context.Context, tenantID, nodeID string) (*models.RestoreResult, error)
+}
+
+// Compile-time check: *NodeArchiver must satisfy domain.ArchiveService.
+var _ domain.ArchiveService = (*NodeArchiver)(nil)
+
+// NodeArchiver applies per-tenant forgetting policies, archiving nodes that
+// have lost their salience and gone unused, both on demand and periodically
+// from Run, and restores archived nodes.
+type NodeArchiver struct {
+	store       ArchiveStore
+	embedWorker EmbedEnqueuer
+	auditWorker AuditEnqueuer
+	scanner     NodeScanner
+	log         *logrus.Logger
+	now         func() time.Time
+}
+
+// NewNodeArchiver creates a NodeArchiver.
+func NewNodeArchiver(store ArchiveStore, embedWorker EmbedEnqueuer, auditWorker AuditEnqueuer, log *logrus.Logger) *NodeArchiver {
+	return &NodeArchiver{store: store, embedWorker: embedWorker, auditWorker: auditWorker, log: log, now: time.Now}
+}
+
+// WithScanner checks every restored node, such as for prompt injection.
+// Archiving a node drops its quarantine flag, so restoring rescans it.
+func (a *NodeArchiver) WithScanner(scanner NodeScanner) *NodeArchiver {
+	a.scanner = scanner
+	return a
+}
+
+// GetArchivePolicy returns the tenant's archive policy.
+func (a *NodeArchiver) GetArchivePolicy(ctx context.Context, tenantID string) (*models.ArchivePolicy, error) {
+	return a.store.GetArchivePolicy(ctx, tenantID)
+}
+
+// SetArchivePolicy validates and replaces the tenant's archive policy. It
+// takes effect on the next archival run.
+func (a *NodeArchiver) SetArchivePolicy(
+	ctx context.Context, tenantID string, p models.ArchivePolicy,
+) (*models.ArchivePolicy, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"tenant_id":      tenantID,
+		"salience_below": p.SalienceBelow,
+		"idle_days":      p.IdleDays,
+	}).Debug("archive.set_policy")
+
+	return a.store.SetArchivePolicy(ctx, tenantID, p)
+}
+
+// ArchiveTenant applies the tenant's current archive policy now. It archives
+// nothing when the policy is disabled.
+func (a *NodeArchiver) ArchiveTenant(ctx context.Context, tenantID string) (_ *models.ArchiveResult, err error) {
+	ctx, span := startSpan(ctx, "NodeArchiver.ArchiveTenant", tenantID)
+	defer endSpan(span, &err)
+
+	p, err := a.store.GetArchivePolicy(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.Enabled() {
+		return &models.ArchiveResult{}, nil
+	}
+
+	return a.archive(ctx, tenantID, *p)
+}
+
+// ListArchivedNodes returns the tenant's archived nodes, most recently
+// archived first.
+func (a *NodeArchiver) ListArchivedNodes(
+	ctx context.Context, tenantID string, limit, offset int,
+) ([]models.ArchivedNode, bool, error) {
+	return a.store.ListArchivedNodes(ctx, tenantID, limit, offset)
+}
+
+// RestoreNode moves an archived node back into the graph and enqueues a new
+// embedding for it.
+func (a *NodeArchiver) RestoreNode(ctx context.Context, tenantID, nodeID string) (_ *models.RestoreResult, err error) {
+	ctx, span := startSpan(ctx, "NodeArchiver.RestoreNode", tenantID)
+	defer endSpan(span, &err)
+
+	result, err := a.store.RestoreNode(ctx, tenantID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.embedWorker != nil {
+		a.embedWorker.Enqueue(EmbedJob{
+			TenantID: tenantID,
+			NodeID:   result.Node.ID,
+			Text:     models.BuildNodeEmbeddingText(result.Node),
+		})
+	}
+
+	if a.scanner != nil {
+		a.scanner.ScanNode(ctx, tenantID, result.Node)
+	}
+
+	auditAsync(a.auditWorker, tenantID, "node.restore", "node", nodeID, map[string]any{
+		"edges_restored": result.EdgesRestored,
+	})
+
+	return result, nil
+}
+
+// Run applies every tenant's archive policy on startup and then every
+// archiveInterval until ctx is cancelled.
+func (a *NodeArchiver) Run(ctx context.Context) {
+	ticker := time.NewTicker(archiveInterval)
+	defer ticker.Stop()
+
+	for {
+		a.archiveAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// archiveAll archives for each tenant with archival enabled. A failure for
+// one tenant is logged and does not stop the others.
+func (a *NodeArchiver) archiveAll(ctx context.Context) {
+	policies, err := a.store.ListArchivePolicies(ctx)
+	if err != nil {
+		a.log.WithError(err).Warn("listing archive policies")
+		return
+	}
+
+	for _, p := range policies {
+		if ctx.Err() != nil {
+			return
+		}
+
+		result, err := a.archive(ctx, p.TenantID, p.ArchivePolicy)
+		if err != nil {
+			a.log.WithError(err).WithField("tenant_id", p.TenantID).Warn("archiving nodes")
+			continue
+		}
+
+		if result.Nodes > 0 && a.auditWorker != nil {
+			a.auditWorker.Enqueue(&AuditJob{
+				TenantID:   p.TenantID,
+				Action:     "node.archive",
+				EntityType: "node",
+				Actor:      "scheduler",
+				Detail:     map[string]any{"nodes": result.Nodes, "edges": result.Edges},
+			})
+		}
+	}
+}
+
+// archive moves the nodes an enabled policy selects to the archive.
+func (a *NodeArchiver) archive(
+	ctx context.Context, tenantID string, p models.ArchivePolicy,
+) (*models.ArchiveResult, error) {
+	result, err := a.store.ArchiveNodes(ctx, tenantID, *p.SalienceBelow, daysBefore(a.now(), *p.IdleDays))
+	if err != nil {
+		return result, err
+	}
+
+	if result.Nodes > 0 {
+		a.log.WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+			"nodes":     result.Nodes,
+			"edges":     result.Edges,
+		}).Info("archive.run")
+	}
+
+	return result, nil
+}
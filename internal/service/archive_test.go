@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockArchiveStore struct {
+	policies      []models.TenantArchivePolicy
+	cutoffs       map[string]time.Time
+	archiveErrFor string
+}
+
+func (m *mockArchiveStore) GetArchivePolicy(_ context.Context, tenantID string) (*models.ArchivePolicy, error) {
+	for _, p := range m.policies {
+		if p.TenantID == tenantID {
+			return &p.ArchivePolicy, nil
+		}
+	}
+
+	return nil, models.ErrTenantNotFound
+}
+
+func (m *mockArchiveStore) SetArchivePolicy(_ context.Context, tenantID string, p models.ArchivePolicy) (*models.ArchivePolicy, error) {
+	m.policies = append(m.policies, models.TenantArchivePolicy{TenantID: tenantID, ArchivePolicy: p})
+	return &p, nil
+}
+
+func (m *mockArchiveStore) ListArchivePolicies(_ context.Context) ([]models.TenantArchivePolicy, error) {
+	return m.policies, nil
+}
+
+func (m *mockArchiveStore) ArchiveNodes(_ context.Context, tenantID string, _ float64, before time.Time) (*models.ArchiveResult, error) {
+	if tenantID == m.archiveErrFor {
+		return nil, errors.New("archive failed")
+	}
+
+	m.cutoffs[tenantID] = before
+	return &models.ArchiveResult{Nodes: 5, Edges: 7}, nil
+}
+
+func (m *mockArchiveStore) ListArchivedNodes(_ context.Context, _ string, _, _ int) ([]models.ArchivedNode, bool, error) {
+	return nil, false, nil
+}
+
+func (m *mockArchiveStore) RestoreNode(_ context.Context, _, nodeID string) (*models.RestoreResult, error) {
+	return &models.RestoreResult{Node: &models.Node{ID: nodeID, Type: "person", Label: "Alice"}, EdgesRestored: 2}, nil
+}
+
+func newTestNodeArchiver(store *mockArchiveStore, embed *mockEmbedEnqueuer, audit *recordingAuditWorker, now time.Time) *NodeArchiver {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	a := NewNodeArchiver(store, embed, audit, log)
+	a.now = func() time.Time { return now }
+
+	return a
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestNodeArchiver_ArchiveTenant(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &mockArchiveStore{
+		policies: []models.TenantArchivePolicy{
+			{TenantID: "t1", ArchivePolicy: models.ArchivePolicy{SalienceBelow: floatPtr(0.1), IdleDays: intPtr(60)}},
+			{TenantID: "t2"},
+		},
+		cutoffs: map[string]time.Time{},
+	}
+	a := newTestNodeArchiver(store, &mockEmbedEnqueuer{}, &recordingAuditWorker{}, now)
+
+	result, err := a.ArchiveTenant(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("ArchiveTenant: %v", err)
+	}
+	if result.Nodes != 5 || result.Edges != 7 {
+		t.Errorf("result = %+v, want 5 nodes, 7 edges", result)
+	}
+	if want := now.AddDate(0, 0, -60); !store.cutoffs["t1"].Equal(want) {
+		t.Errorf("idle cutoff = %s, want %s", store.cutoffs["t1"], want)
+	}
+
+	result, err = a.ArchiveTenant(context.Background(), "t2")
+	if err != nil {
+		t.Fatalf("ArchiveTenant: %v", err)
+	}
+	if _, ok := store.cutoffs["t2"]; ok || result.Nodes != 0 {
+		t.Errorf("t2 has no policy but was archived: %+v", result)
+	}
+}
+
+func TestNodeArchiver_ArchiveAllContinuesPastFailures(t *testing.T) {
+	policy := models.ArchivePolicy{SalienceBelow: floatPtr(0.1), IdleDays: intPtr(30)}
+	store := &mockArchiveStore{
+		policies: []models.TenantArchivePolicy{
+			{TenantID: "t1", ArchivePolicy: policy},
+			{TenantID: "t2", ArchivePolicy: policy},
+		},
+		cutoffs:       map[string]time.Time{},
+		archiveErrFor: "t1",
+	}
+	audit := &recordingAuditWorker{}
+	a := newTestNodeArchiver(store, &mockEmbedEnqueuer{}, audit, time.Now())
+
+	a.archiveAll(context.Background())
+
+	if _, ok := store.cutoffs["t2"]; !ok {
+		t.Error("t2 was not archived after t1 failed")
+	}
+	if len(audit.jobs) != 1 || audit.jobs[0].TenantID != "t2" || audit.jobs[0].Actor != "scheduler" {
+		t.Errorf("audit jobs = %+v, want one scheduler entry for t2", audit.jobs)
+	}
+}
+
+func TestNodeArchiver_RestoreReembeds(t *testing.T) {
+	embed := &mockEmbedEnqueuer{}
+	audit := &recordingAuditWorker{}
+	a := newTestNodeArchiver(&mockArchiveStore{}, embed, audit, time.Now())
+
+	if _, err := a.RestoreNode(context.Background(), "t1", "alice"); err != nil {
+		t.Fatalf("RestoreNode: %v", err)
+	}
+
+	if len(embed.jobs) != 1 || embed.jobs[0].NodeID != "alice" {
+		t.Errorf("embed jobs = %+v, want one for alice", embed.jobs)
+	}
+	if len(audit.jobs) != 1 || audit.jobs[0].Action != "node.restore" {
+		t.Errorf("audit jobs = %+v, want node.restore", audit.jobs)
+	}
+}
+
+func TestNodeArchiver_SetValidates(t *testing.T) {
+	store := &mockArchiveStore{}
+	a := newTestNodeArchiver(store, &mockEmbedEnqueuer{}, &recordingAuditWorker{}, time.Now())
+
+	_, err := a.SetArchivePolicy(context.Background(), "t1", models.ArchivePolicy{SalienceBelow: floatPtr(0.1)})
+	if err == nil {
+		t.Fatal("expected error when idle_days is missing")
+	}
+	if len(store.policies) != 0 {
+		t.Error("invalid policy was stored")
+	}
+}
@@ -5,6 +5,8 @@
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
 )
 
 const drainTimeout = 5 * time.Second
@@ -62,6 +64,7 @@ func auditAsync(worker AuditEnqueuer, tenantID, action, entityType, entityID str
 func (w *AuditWorker) Enqueue(job *AuditJob) {
 	select {
 	case w.jobs <- job:
+		metrics.AuditQueueDepth.Set(float64(len(w.jobs)))
 	default:
 		w.log.WithField("action", job.Action).Warn("audit queue full, dropping entry")
 	}
@@ -75,6 +78,7 @@ func (w *AuditWorker) Run(ctx context.Context) {
 			w.drain()
 			return
 		case job := <-w.jobs:
+			metrics.AuditQueueDepth.Set(float64(len(w.jobs)))
 			w.process(ctx, job)
 		}
 	}
@@ -91,6 +95,7 @@ func (w *AuditWorker) drain() {
 			w.log.WithField("remaining", len(w.jobs)).Warn("audit drain timed out, dropping remaining entries")
 			return
 		case job := <-w.jobs:
+			metrics.AuditQueueDepth.Set(float64(len(w.jobs)))
 			w.process(ctx, job)
 		default:
 			return
@@ -5,7 +5,10 @@
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
 )
 
 func TestAuditWorker_ProcessesJob(t *testing.T) {
@@ -69,6 +72,10 @@ func TestAuditWorker_DropsWhenFull(t *testing.T) {
 	if len(aw.jobs) != 2 {
 		t.Errorf("queue len = %d, want 2", len(aw.jobs))
 	}
+
+	if depth := testutil.ToFloat64(metrics.AuditQueueDepth); depth != 2 {
+		t.Errorf("audit queue depth gauge = %v, want 2", depth)
+	}
 }
 
 func TestAuditWorker_StopDrains(t *testing.T) {
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// BranchStore is the data-access interface BranchService depends on.
+// It reuses domain.BranchService since the method sets are identical, avoiding duplication.
+type BranchStore = domain.BranchService
+
+// Compile-time check: *BranchService must satisfy domain.BranchService.
+var _ domain.BranchService = (*BranchService)(nil)
+
+// BranchService wraps BranchStore with embedding and audit logging for
+// merges. Staged writes are not embedded; merged nodes are.
+type BranchService struct {
+	store       BranchStore
+	embedWorker EmbedEnqueuer
+	auditWorker AuditEnqueuer
+	scanner     NodeScanner
+	log         *logrus.Logger
+}
+
+// NewBranchService creates a BranchService.
+func NewBranchService(store BranchStore, embedWorker EmbedEnqueuer, auditWorker AuditEnqueuer, log *logrus.Logger) *BranchService {
+	return &BranchService{store: store, embedWorker: embedWorker, auditWorker: auditWorker, log: log}
+}
+
+// WithScanner checks every node a merge writes, such as for prompt
+// injection. Staged writes are checked when they are merged.
+func (s *BranchService) WithScanner(scanner NodeScanner) *BranchService {
+	s.scanner = scanner
+	return s
+}
+
+// CreateBranch creates a branch and records an audit entry.
+func (s *BranchService) CreateBranch(ctx context.Context, tenantID string, req models.CreateBranchRequest) (*models.Branch, error) {
+	b, err := s.store.CreateBranch(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	auditAsync(s.auditWorker, tenantID, "branch.create", "branch", b.ID, map[string]any{"name": b.Name})
+
+	return b, nil
+}
+
+// ListBranches returns the tenant's branches (pass-through).
+func (s *BranchService) ListBranches(ctx context.Context, tenantID string) ([]models.Branch, error) {
+	return s.store.ListBranches(ctx, tenantID)
+}
+
+// GetBranch returns a branch with its change counts (pass-through).
+func (s *BranchService) GetBranch(ctx context.Context, tenantID, branchID string) (*models.Branch, error) {
+	return s.store.GetBranch(ctx, tenantID, branchID)
+}
+
+// GetBranchChanges lists the changes staged on a branch (pass-through).
+func (s *BranchService) GetBranchChanges(ctx context.Context, tenantID, branchID string) (*models.BranchChanges, error) {
+	return s.store.GetBranchChanges(ctx, tenantID, branchID)
+}
+
+// DeleteBranch discards a branch and records an audit entry.
+func (s *BranchService) DeleteBranch(ctx context.Context, tenantID, branchID string) error {
+	if err := s.store.DeleteBranch(ctx, tenantID, branchID); err != nil {
+		return err
+	}
+
+	auditAsync(s.auditWorker, tenantID, "branch.discard", "branch", branchID, nil)
+
+	return nil
+}
+
+// MergeBranch merges a branch, then enqueues embedding jobs for the written
+// nodes and records an audit entry. A merge stopped by conflicts does neither.
+func (s *BranchService) MergeBranch(
+	ctx context.Context, tenantID, branchID string, req models.MergeBranchRequest,
+) (*models.MergeBranchResult, error) {
+	result, err := s.store.MergeBranch(ctx, tenantID, branchID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Merged {
+		return result, nil
+	}
+
+	if s.embedWorker != nil {
+		for i := range result.Nodes {
+			s.embedWorker.Enqueue(EmbedJob{
+				TenantID: tenantID,
+				NodeID:   result.Nodes[i].ID,
+				Text:     models.BuildNodeEmbeddingText(&result.Nodes[i]),
+			})
+		}
+	}
+
+	if s.scanner != nil {
+		for i := range result.Nodes {
+			s.scanner.ScanNode(ctx, tenantID, &result.Nodes[i])
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":     tenantID,
+		"branch_id":     branchID,
+		"nodes_written": result.NodesWritten,
+		"edges_written": result.EdgesWritten,
+	}).Debug("branch.merge")
+
+	auditAsync(s.auditWorker, tenantID, "branch.merge", "branch", branchID, map[string]any{
+		"force":         req.Force,
+		"nodes_written": result.NodesWritten,
+		"nodes_deleted": result.NodesDeleted,
+		"edges_written": result.EdgesWritten,
+		"edges_deleted": result.EdgesDeleted,
+	})
+
+	return result, nil
+}
+
+// GetBranchNode returns a node as the branch sees it (pass-through).
+func (s *BranchService) GetBranchNode(ctx context.Context, tenantID, branchID, nodeID string) (*models.Node, error) {
+	return s.store.GetBranchNode(ctx, tenantID, branchID, nodeID)
+}
+
+// CreateBranchNode stages a new node on a branch (pass-through).
+func (s *BranchService) CreateBranchNode(
+	ctx context.Context, tenantID, branchID string, req models.CreateNodeRequest,
+) (*models.Node, error) {
+	return s.store.CreateBranchNode(ctx, tenantID, branchID, req)
+}
+
+// UpdateBranchNode stages a node update on a branch (pass-through).
+func (s *BranchService) UpdateBranchNode(
+	ctx context.Context, tenantID, branchID, nodeID string, req models.UpdateNodeRequest,
+) (*models.Node, error) {
+	return s.store.UpdateBranchNode(ctx, tenantID, branchID, nodeID, req)
+}
+
+// DeleteBranchNode stages a node deletion on a branch (pass-through).
+func (s *BranchService) DeleteBranchNode(ctx context.Context, tenantID, branchID, nodeID string) error {
+	return s.store.DeleteBranchNode(ctx, tenantID, branchID, nodeID)
+}
+
+// GetBranchEdge returns an edge as the branch sees it (pass-through).
+func (s *BranchService) GetBranchEdge(
+	ctx context.Context, tenantID, branchID, source, target, relation string,
+) (*models.Edge, error) {
+	return s.store.GetBranchEdge(ctx, tenantID, branchID, source, target, relation)
+}
+
+// CreateBranchEdge stages a new edge on a branch (pass-through).
+func (s *BranchService) CreateBranchEdge(
+	ctx context.Context, tenantID, branchID string, req models.CreateEdgeRequest, //nolint:gocritic // hugeParam: interface signature is fixed; struct size accepted by design
+) (*models.Edge, error) {
+	return s.store.CreateBranchEdge(ctx, tenantID, branchID, req)
+}
+
+// UpdateBranchEdge stages an edge update on a branch (pass-through).
+func (s *BranchService) UpdateBranchEdge(
+	ctx context.Context, tenantID, branchID, source, target, relation string, req models.UpdateEdgeRequest,
+) (*models.Edge, error) {
+	return s.store.UpdateBranchEdge(ctx, tenantID, branchID, source, target, relation, req)
+}
+
+// DeleteBranchEdge stages an edge deletion on a branch (pass-through).
+func (s *BranchService) DeleteBranchEdge(ctx context.Context, tenantID, branchID, source, target, relation string) error {
+	return s.store.DeleteBranchEdge(ctx, tenantID, branchID, source, target, relation)
+}
+
+// BranchNeighbors returns a node's neighbors as the branch sees the graph (pass-through).
+func (s *BranchService) BranchNeighbors(
+	ctx context.Context, tenantID, branchID, nodeID string, limit int,
+) (*models.NeighborResult, error) {
+	return s.store.BranchNeighbors(ctx, tenantID, branchID, nodeID, limit)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// mockBranchStore embeds domain.BranchService so tests only implement the
+// methods they call.
+type mockBranchStore struct {
+	domain.BranchService
+	mergeResult *models.MergeBranchResult
+}
+
+func (m *mockBranchStore) MergeBranch(
+	_ context.Context, _, _ string, _ models.MergeBranchRequest,
+) (*models.MergeBranchResult, error) {
+	return m.mergeResult, nil
+}
+
+func TestBranchService_MergeBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		result   *models.MergeBranchResult
+		wantJobs int
+	}{
+		{
+			name: "merged nodes are embedded",
+			result: &models.MergeBranchResult{
+				Merged:       true,
+				NodesWritten: 2,
+				Nodes:        []models.Node{{ID: "a", Type: "person", Label: "A"}, {ID: "b", Type: "person", Label: "B"}},
+			},
+			wantJobs: 2,
+		},
+		{
+			name: "conflicted merge embeds nothing",
+			result: &models.MergeBranchResult{
+				Conflicts: []models.BranchConflict{{NodeID: "a", Reason: models.BranchConflictChanged}},
+			},
+			wantJobs: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			embedder := &mockEmbedEnqueuer{}
+			svc := NewBranchService(&mockBranchStore{mergeResult: tt.result}, embedder, nil, logrus.New())
+
+			result, err := svc.MergeBranch(context.Background(), "t1", "b1", models.MergeBranchRequest{})
+			if err != nil {
+				t.Fatalf("MergeBranch: %v", err)
+			}
+
+			if result.Merged != tt.result.Merged {
+				t.Errorf("Merged = %v, want %v", result.Merged, tt.result.Merged)
+			}
+
+			if len(embedder.jobs) != tt.wantJobs {
+				t.Errorf("enqueued %d embed jobs, want %d", len(embedder.jobs), tt.wantJobs)
+			}
+		})
+	}
+}
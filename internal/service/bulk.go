@@ -6,19 +6,19 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // BulkStore defines the data access methods BulkService depends on.
-type BulkStore interface {
-	BulkUpsertNodes(ctx context.Context, tenantID string, nodes []models.CreateNodeRequest) ([]models.Node, error)
-	BulkUpsertEdges(ctx context.Context, tenantID string, edges []models.CreateEdgeRequest) ([]models.Edge, error)
-}
+type BulkStore = storage.BulkStorage
 
 // BulkService wraps BulkStore with embedding enqueue logic for bulk node upserts.
 type BulkService struct {
 	store       BulkStore
 	embedWorker EmbedEnqueuer
 	auditWorker AuditEnqueuer
+	scanner     NodeScanner
 	log         *logrus.Logger
 }
 
@@ -27,10 +27,20 @@ func NewBulkService(store BulkStore, embedWorker EmbedEnqueuer, auditWorker Audi
 	return &BulkService{store: store, embedWorker: embedWorker, auditWorker: auditWorker, log: log}
 }
 
+// WithScanner checks every node the service upserts, such as for prompt
+// injection.
+func (s *BulkService) WithScanner(scanner NodeScanner) *BulkService {
+	s.scanner = scanner
+	return s
+}
+
 // BulkUpsertNodes upserts nodes and enqueues embedding jobs for each.
 func (s *BulkService) BulkUpsertNodes(
 	ctx context.Context, tenantID string, nodes []models.CreateNodeRequest,
-) ([]models.Node, error) {
+) (_ []models.Node, err error) {
+	ctx, span := startSpan(ctx, "BulkService.BulkUpsertNodes", tenantID, tracing.Int("node_count", len(nodes)))
+	defer endSpan(span, &err)
+
 	result, err := s.store.BulkUpsertNodes(ctx, tenantID, nodes)
 	if err != nil {
 		return nil, err
@@ -46,6 +56,12 @@ func (s *BulkService) BulkUpsertNodes(
 		}
 	}
 
+	if s.scanner != nil {
+		for i := range result {
+			s.scanner.ScanNode(ctx, tenantID, &result[i])
+		}
+	}
+
 	if s.auditWorker != nil {
 		s.auditWorker.Enqueue(&AuditJob{
 			TenantID: tenantID, Action: "bulk.nodes", EntityType: "node",
@@ -59,7 +75,10 @@ func (s *BulkService) BulkUpsertNodes(
 // BulkUpsertEdges upserts edges (pass-through).
 func (s *BulkService) BulkUpsertEdges(
 	ctx context.Context, tenantID string, edges []models.CreateEdgeRequest,
-) ([]models.Edge, error) {
+) (_ []models.Edge, err error) {
+	ctx, span := startSpan(ctx, "BulkService.BulkUpsertEdges", tenantID, tracing.Int("edge_count", len(edges)))
+	defer endSpan(span, &err)
+
 	result, err := s.store.BulkUpsertEdges(ctx, tenantID, edges)
 	if err != nil {
 		return nil, err
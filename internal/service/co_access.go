@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CoAccessStore is the data-access interface CoAccessService depends on.
+type CoAccessStore interface {
+	RecordAccess(ctx context.Context, tenantID, sessionID string, nodeIDs []string) error
+	InferCoAccessEdges(ctx context.Context, tenantID string, req models.CoAccessInferenceRequest) (*models.CoAccessInferenceResult, error)
+}
+
+// Compile-time check: *CoAccessService must satisfy domain.CoAccessService.
+var _ domain.CoAccessService = (*CoAccessService)(nil)
+
+// CoAccessService records node co-access and infers related_to edges from it.
+type CoAccessService struct {
+	store CoAccessStore
+	log   *logrus.Logger
+}
+
+// NewCoAccessService creates a CoAccessService.
+func NewCoAccessService(store CoAccessStore, log *logrus.Logger) *CoAccessService {
+	return &CoAccessService{store: store, log: log}
+}
+
+// RecordAccess marks nodes as accessed together in a session. Sessions of
+// one node are recorded too, since later accesses in the same session pair
+// with them.
+func (s *CoAccessService) RecordAccess(ctx context.Context, tenantID, sessionID string, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	if len(nodeIDs) > models.MaxCoAccessNodesPerSearch {
+		nodeIDs = nodeIDs[:models.MaxCoAccessNodesPerSearch]
+	}
+
+	return s.store.RecordAccess(ctx, tenantID, sessionID, nodeIDs)
+}
+
+// InferRelations runs co-access inference for the tenant.
+func (s *CoAccessService) InferRelations(
+	ctx context.Context, tenantID string, req models.CoAccessInferenceRequest,
+) (*models.CoAccessInferenceResult, error) {
+	result, err := s.store.InferCoAccessEdges(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"pairs":     result.Pairs,
+		"created":   result.Created,
+		"updated":   result.Updated,
+		"removed":   result.Removed,
+	}).Info("co-access relations inferred")
+
+	return result, nil
+}
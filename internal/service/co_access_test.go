@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockCoAccessStore struct {
+	recorded [][]string
+}
+
+func (m *mockCoAccessStore) RecordAccess(_ context.Context, _, _ string, nodeIDs []string) error {
+	m.recorded = append(m.recorded, nodeIDs)
+	return nil
+}
+
+func (m *mockCoAccessStore) InferCoAccessEdges(
+	_ context.Context, _ string, _ models.CoAccessInferenceRequest,
+) (*models.CoAccessInferenceResult, error) {
+	return &models.CoAccessInferenceResult{}, nil
+}
+
+func TestCoAccessService_RecordAccess(t *testing.T) {
+	store := &mockCoAccessStore{}
+	svc := NewCoAccessService(store, logrus.New())
+	ctx := context.Background()
+
+	if err := svc.RecordAccess(ctx, "t1", "s1", nil); err != nil || len(store.recorded) != 0 {
+		t.Fatalf("empty access should not be recorded: %v, %v", store.recorded, err)
+	}
+
+	ids := make([]string, models.MaxCoAccessNodesPerSearch+5)
+	for i := range ids {
+		ids[i] = string(rune('a' + i))
+	}
+	if err := svc.RecordAccess(ctx, "t1", "s1", ids); err != nil {
+		t.Fatalf("RecordAccess: %v", err)
+	}
+	if got := len(store.recorded[0]); got != models.MaxCoAccessNodesPerSearch {
+		t.Errorf("recorded %d nodes, want the top %d", got, models.MaxCoAccessNodesPerSearch)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+const crossEncoderTimeout = 10 * time.Second
+
+// Reranker providers, as set by RERANK_PROVIDER.
+const (
+	RerankProviderOllama = "ollama"
+	RerankProviderTEI    = "tei"
+)
+
+// CrossEncoderReranker scores query-document pairs with a cross-encoder
+// served by Ollama (/api/rerank) or Text Embeddings Inference (/rerank).
+type CrossEncoderReranker struct {
+	provider string
+	url      string
+	model    string
+	client   *http.Client
+}
+
+// ollamaRerankRequest is an Ollama /api/rerank request.
+type ollamaRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+type ollamaRerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// teiRerankRequest is a Text Embeddings Inference /rerank request. TEI
+// serves one model, so none is named.
+type teiRerankRequest struct {
+	Query    string   `json:"query"`
+	Texts    []string `json:"texts"`
+	Truncate bool     `json:"truncate"`
+}
+
+// rerankScore is one document's relevance, in TEI's response shape.
+type rerankScore struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// NewCrossEncoderReranker creates a CrossEncoderReranker for provider
+// (RerankProviderOllama or RerankProviderTEI) at url, using model.
+func NewCrossEncoderReranker(provider, url, model string, allowRemote bool) *CrossEncoderReranker {
+	return &CrossEncoderReranker{
+		provider: provider,
+		url:      url,
+		model:    model,
+		client:   &http.Client{Timeout: crossEncoderTimeout, Transport: loopbackTransport("rerank", allowRemote)},
+	}
+}
+
+// Rerank returns the relevance of each document to query, in document order.
+// Higher is more relevant.
+func (r *CrossEncoderReranker) Rerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	ctx, span := tracing.StartClient(ctx, r.provider+".rerank",
+		tracing.String("rerank.model", r.model),
+		tracing.Int("rerank.documents", len(documents)),
+	)
+	defer span.End()
+
+	scores, err := r.doRerank(ctx, query, documents)
+	if err != nil {
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+func (r *CrossEncoderReranker) doRerank(ctx context.Context, query string, documents []string) ([]float64, error) {
+	var (
+		path string
+		body any
+	)
+
+	if r.provider == RerankProviderTEI {
+		path, body = "/rerank", teiRerankRequest{Query: query, Texts: documents, Truncate: true}
+	} else {
+		path, body = "/api/rerank", ollamaRerankRequest{Model: r.model, Query: query, Documents: documents}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling rerank request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating rerank request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if span := tracing.FromContext(ctx); span != nil {
+		req.Header.Set("traceparent", span.TraceParent())
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s rerank API: %w", r.provider, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Drain body so the connection can be reused.
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20)) //nolint:errcheck // best-effort drain before close.
+		return nil, fmt.Errorf("%s rerank API returned status %d", r.provider, resp.StatusCode)
+	}
+
+	results, err := decodeRerankResults(r.provider, io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]float64, len(documents))
+	seen := make([]bool, len(documents))
+
+	for _, res := range results {
+		if res.Index < 0 || res.Index >= len(documents) {
+			return nil, fmt.Errorf("%s rerank API returned index %d for %d documents", r.provider, res.Index, len(documents))
+		}
+
+		scores[res.Index], seen[res.Index] = res.Score, true
+	}
+
+	for i, ok := range seen {
+		if !ok {
+			return nil, fmt.Errorf("%s rerank API returned no score for document %d", r.provider, i)
+		}
+	}
+
+	return scores, nil
+}
+
+// decodeRerankResults reads either provider's response as index-score pairs.
+func decodeRerankResults(provider string, body io.Reader) ([]rerankScore, error) {
+	if provider == RerankProviderTEI {
+		var results []rerankScore
+		if err := json.NewDecoder(body).Decode(&results); err != nil {
+			return nil, fmt.Errorf("decoding rerank response: %w", err)
+		}
+
+		return results, nil
+	}
+
+	var resp ollamaRerankResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("decoding rerank response: %w", err)
+	}
+
+	results := make([]rerankScore, len(resp.Results))
+	for i, res := range resp.Results {
+		results[i] = rerankScore{Index: res.Index, Score: res.RelevanceScore}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCrossEncoderReranker_Rerank(t *testing.T) {
+	tests := []struct {
+		provider string
+		path     string
+		response any
+	}{
+		{RerankProviderOllama, "/api/rerank", map[string]any{"results": []map[string]any{
+			{"index": 1, "relevance_score": 0.8}, {"index": 0, "relevance_score": 0.1},
+		}}},
+		{RerankProviderTEI, "/rerank", []map[string]any{
+			{"index": 1, "score": 0.8}, {"index": 0, "score": 0.1},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.path {
+					t.Errorf("path = %q, want %q", r.URL.Path, tt.path)
+				}
+
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["query"] != "deploy" {
+					t.Errorf("body = %v, %v", body, err)
+				}
+
+				json.NewEncoder(w).Encode(tt.response) //nolint:errcheck
+			}))
+			defer srv.Close()
+
+			r := NewCrossEncoderReranker(tt.provider, srv.URL, "bge-reranker", false)
+
+			scores, err := r.Rerank(context.Background(), "deploy", []string{"a", "b"})
+			if err != nil {
+				t.Fatalf("Rerank: %v", err)
+			}
+			if len(scores) != 2 || scores[0] != 0.1 || scores[1] != 0.8 {
+				t.Errorf("scores = %v, want [0.1 0.8]", scores)
+			}
+		})
+	}
+}
+
+func TestCrossEncoderReranker_MissingScore(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{{"index": 0, "score": 0.5}}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	r := NewCrossEncoderReranker(RerankProviderTEI, srv.URL, "", false)
+	if _, err := r.Rerank(context.Background(), "q", []string{"a", "b"}); err == nil {
+		t.Error("expected an error when a document has no score")
+	}
+}
@@ -8,11 +8,12 @@
 
 	"github.com/persistorai/persistor/internal/domain"
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // EdgeStore is the data-access interface EdgeService depends on.
-// It reuses domain.EdgeService since the method sets are identical, avoiding duplication.
-type EdgeStore = domain.EdgeService
+type EdgeStore = storage.EdgeStorage
 
 // Compile-time check: *EdgeService must satisfy domain.EdgeService.
 var _ domain.EdgeService = (*EdgeService)(nil)
@@ -32,15 +33,21 @@ func NewEdgeService(store EdgeStore, auditWorker AuditEnqueuer, log *logrus.Logg
 // ListEdges returns a paginated list of edges (pass-through).
 func (s *EdgeService) ListEdges(
 	ctx context.Context, tenantID string, source, target, relation string, limit, offset int,
-	activeOn *time.Time, current *bool,
-) ([]models.Edge, bool, error) {
-	return s.store.ListEdges(ctx, tenantID, source, target, relation, limit, offset, activeOn, current)
+	activeOn *time.Time, current *bool, after *models.EdgeCursor,
+) (_ []models.Edge, _ bool, err error) {
+	ctx, span := startSpan(ctx, "EdgeService.ListEdges", tenantID, tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	return s.store.ListEdges(ctx, tenantID, source, target, relation, limit, offset, activeOn, current, after)
 }
 
 // CreateEdge creates an edge and records an audit entry.
 func (s *EdgeService) CreateEdge(
 	ctx context.Context, tenantID string, req models.CreateEdgeRequest, //nolint:gocritic // hugeParam: interface signature is fixed; struct size accepted by design
-) (*models.Edge, error) {
+) (_ *models.Edge, err error) {
+	ctx, span := startSpan(ctx, "EdgeService.CreateEdge", tenantID)
+	defer endSpan(span, &err)
+
 	edge, err := s.store.CreateEdge(ctx, tenantID, req)
 	if err != nil {
 		return nil, err
@@ -55,7 +62,10 @@ func (s *EdgeService) CreateEdge(
 // UpdateEdge updates an edge and records an audit entry.
 func (s *EdgeService) UpdateEdge(
 	ctx context.Context, tenantID string, source, target, relation string, req models.UpdateEdgeRequest,
-) (*models.Edge, error) {
+) (_ *models.Edge, err error) {
+	ctx, span := startSpan(ctx, "EdgeService.UpdateEdge", tenantID)
+	defer endSpan(span, &err)
+
 	edge, err := s.store.UpdateEdge(ctx, tenantID, source, target, relation, req)
 	if err != nil {
 		return nil, err
@@ -70,7 +80,10 @@ func (s *EdgeService) UpdateEdge(
 // PatchEdgeProperties partially updates edge properties (merge semantics).
 func (s *EdgeService) PatchEdgeProperties(
 	ctx context.Context, tenantID string, source, target, relation string, req models.PatchPropertiesRequest,
-) (*models.Edge, error) {
+) (_ *models.Edge, err error) {
+	ctx, span := startSpan(ctx, "EdgeService.PatchEdgeProperties", tenantID)
+	defer endSpan(span, &err)
+
 	edge, err := s.store.PatchEdgeProperties(ctx, tenantID, source, target, relation, req)
 	if err != nil {
 		return nil, err
@@ -82,11 +95,24 @@ func (s *EdgeService) PatchEdgeProperties(
 }
 
 // DeleteEdge removes an edge and records an audit entry.
-func (s *EdgeService) DeleteEdge(ctx context.Context, tenantID, source, target, relation string) error {
-	err := s.store.DeleteEdge(ctx, tenantID, source, target, relation)
+func (s *EdgeService) DeleteEdge(ctx context.Context, tenantID, source, target, relation string) (err error) {
+	ctx, span := startSpan(ctx, "EdgeService.DeleteEdge", tenantID)
+	defer endSpan(span, &err)
+
+	err = s.store.DeleteEdge(ctx, tenantID, source, target, relation)
 	if err == nil {
 		auditAsync(s.auditWorker, tenantID, "edge.delete", "edge", source+"/"+target+"/"+relation,
 			map[string]any{"source": source, "target": target, "relation": relation})
 	}
 	return err
 }
+
+// EdgesExist reports which of a batch of edges exist (pass-through).
+func (s *EdgeService) EdgesExist(
+	ctx context.Context, tenantID string, keys []models.EdgeKey,
+) (_ []models.EdgeExistence, err error) {
+	ctx, span := startSpan(ctx, "EdgeService.EdgesExist", tenantID, tracing.Int("edges", len(keys)))
+	defer endSpan(span, &err)
+
+	return s.store.EdgesExist(ctx, tenantID, keys)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxTrackedBackfills bounds how many backfill jobs the worker remembers.
+// The oldest finished jobs are forgotten first.
+const maxTrackedBackfills = 100
+
+// backfillTracker counts the outcome of every job queued by a backfill.
+type backfillTracker struct {
+	mu    sync.Mutex
+	jobs  map[string]*models.BackfillJob
+	order []string // job IDs, oldest first
+}
+
+func newBackfillTracker() *backfillTracker {
+	return &backfillTracker{jobs: make(map[string]*models.BackfillJob)}
+}
+
+// start registers a running backfill of queued jobs and returns its ID.
+func (t *backfillTracker) start(tenantID string, queued int) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job := &models.BackfillJob{
+		ID:        uuid.NewString(),
+		TenantID:  tenantID,
+		Status:    models.BackfillRunning,
+		Queued:    queued,
+		CreatedAt: time.Now().UTC(),
+	}
+	t.jobs[job.ID] = job
+	t.order = append(t.order, job.ID)
+	t.evict()
+	t.settle(job)
+
+	return job.ID
+}
+
+// evict forgets the oldest finished jobs beyond maxTrackedBackfills.
+func (t *backfillTracker) evict() {
+	for i := 0; len(t.order) > maxTrackedBackfills && i < len(t.order); {
+		if t.jobs[t.order[i]].Status == models.BackfillRunning {
+			i++
+			continue
+		}
+		delete(t.jobs, t.order[i])
+		t.order = append(t.order[:i], t.order[i+1:]...)
+	}
+}
+
+// settle updates job's remaining count and finishes it once nothing is left.
+func (t *backfillTracker) settle(job *models.BackfillJob) {
+	job.Remaining = job.Queued - job.Dropped - job.Processed - job.Failed - job.Skipped
+	if job.Remaining > 0 || job.FinishedAt != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	job.FinishedAt = &now
+	if job.Status == models.BackfillRunning {
+		job.Status = models.BackfillCompleted
+	}
+}
+
+// update applies fn to the job with id, if it is still tracked.
+func (t *backfillTracker) update(id string, fn func(job *models.BackfillJob)) {
+	if id == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if job, ok := t.jobs[id]; ok {
+		fn(job)
+		t.settle(job)
+	}
+}
+
+func (t *backfillTracker) dropped(id string, n int) {
+	t.update(id, func(job *models.BackfillJob) { job.Dropped += n })
+}
+
+func (t *backfillTracker) processed(id string) {
+	t.update(id, func(job *models.BackfillJob) { job.Processed++ })
+}
+
+func (t *backfillTracker) failed(id string) {
+	t.update(id, func(job *models.BackfillJob) { job.Failed++ })
+}
+
+func (t *backfillTracker) skipped(id string) {
+	t.update(id, func(job *models.BackfillJob) { job.Skipped++ })
+}
+
+// cancelled reports whether the backfill with id has been cancelled.
+func (t *backfillTracker) cancelled(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+
+	return ok && job.Status == models.BackfillCancelled
+}
+
+// get returns a copy of the tenant's backfill with id.
+func (t *backfillTracker) get(tenantID, id string) (*models.BackfillJob, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+	if !ok || job.TenantID != tenantID {
+		return nil, models.ErrBackfillJobNotFound
+	}
+
+	out := *job
+
+	return &out, nil
+}
+
+// list returns copies of the tenant's backfills, newest first.
+func (t *backfillTracker) list(tenantID string) []models.BackfillJob {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := []models.BackfillJob{}
+	for i := len(t.order) - 1; i >= 0; i-- {
+		if job := t.jobs[t.order[i]]; job.TenantID == tenantID {
+			out = append(out, *job)
+		}
+	}
+
+	return out
+}
+
+// cancel marks the tenant's backfill with id cancelled. Jobs it still has
+// queued are skipped as workers reach them. Cancelling a finished backfill
+// changes nothing.
+func (t *backfillTracker) cancel(tenantID, id string) (*models.BackfillJob, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+	if !ok || job.TenantID != tenantID {
+		return nil, models.ErrBackfillJobNotFound
+	}
+	if job.Status == models.BackfillRunning {
+		job.Status = models.BackfillCancelled
+	}
+
+	out := *job
+
+	return &out, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// fillBatch returns job plus up to batchSize-1 more jobs that are already
+// queued.
+func (w *EmbedWorker) fillBatch(job EmbedJob) []EmbedJob {
+	batch := []EmbedJob{job}
+
+	for len(batch) < w.batchSize {
+		select {
+		case next := <-w.jobs:
+			batch = append(batch, next)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// splitByTenant splits batch into one batch per tenant, in first-seen
+// order, so each embedding request is counted against one tenant's budget.
+func splitByTenant(batch []EmbedJob) [][]EmbedJob {
+	var (
+		batches [][]EmbedJob
+		index   = make(map[string]int)
+	)
+
+	for _, job := range batch {
+		i, ok := index[job.TenantID]
+		if !ok {
+			i = len(batches)
+			index[job.TenantID] = i
+			batches = append(batches, nil)
+		}
+
+		batches[i] = append(batches[i], job)
+	}
+
+	return batches
+}
+
+// dropCancelled removes jobs whose backfill was cancelled from batch,
+// counting them as skipped.
+func (w *EmbedWorker) dropCancelled(batch []EmbedJob) []EmbedJob {
+	kept := batch[:0]
+	for _, job := range batch {
+		if w.backfills.cancelled(job.BackfillID) {
+			w.backfills.skipped(job.BackfillID)
+			continue
+		}
+		kept = append(kept, job)
+	}
+
+	return kept
+}
+
+const (
+	maxRetries     = 3
+	baseRetryDelay = 2 * time.Second
+)
+
+// generate embeds the text of every job in batch, all for one tenant, in
+// one request.
+func (w *EmbedWorker) generate(ctx context.Context, batch []EmbedJob) ([][]float32, error) {
+	ctx = withUsageTenant(ctx, batch[0].TenantID)
+
+	if len(batch) == 1 {
+		embedding, err := w.embed.Generate(ctx, batch[0].Text)
+		if err != nil {
+			return nil, err
+		}
+
+		return [][]float32{embedding}, nil
+	}
+
+	texts := make([]string, len(batch))
+	for i, job := range batch {
+		texts[i] = job.Text
+	}
+
+	return w.embed.GenerateBatch(ctx, texts)
+}
+
+// batchFields identifies a batch in log entries.
+func batchFields(batch []EmbedJob) logrus.Fields {
+	if len(batch) == 1 {
+		return logrus.Fields{"node_id": batch[0].NodeID}
+	}
+
+	return logrus.Fields{"batch_size": len(batch), "first_node_id": batch[0].NodeID}
+}
+
+// store saves each job's embedding, logging failures per node.
+func (w *EmbedWorker) store(ctx context.Context, batch []EmbedJob, embeddings [][]float32, failMsg string) {
+	for i, job := range batch {
+		if err := w.repo.UpdateNodeEmbedding(ctx, job.TenantID, job.NodeID, embeddings[i]); err != nil {
+			w.log.WithError(err).WithField("node_id", job.NodeID).Error(failMsg)
+			w.backfills.failed(job.BackfillID)
+		} else {
+			w.log.WithField("node_id", job.NodeID).Debug("embedding stored")
+			w.backfills.processed(job.BackfillID)
+		}
+	}
+}
+
+// fail counts every job in batch as failed for its backfill.
+func (w *EmbedWorker) fail(batch []EmbedJob) {
+	for _, job := range batch {
+		w.backfills.failed(job.BackfillID)
+	}
+}
+
+// processSingle attempts a single embedding request without retry (used during drain).
+func (w *EmbedWorker) processSingle(ctx context.Context, batch []EmbedJob) {
+	embeddings, err := w.generate(ctx, batch)
+	if err != nil {
+		w.log.WithError(err).WithFields(batchFields(batch)).Warn("embedding failed during drain")
+		w.fail(batch)
+		return
+	}
+
+	w.store(ctx, batch, embeddings, "storing embedding during drain")
+}
+
+// processWithRetry embeds and stores batch, retrying failed embedding
+// requests with exponential backoff. Quota errors are not retried.
+func (w *EmbedWorker) processWithRetry(ctx context.Context, batch []EmbedJob) {
+	for attempt := range maxRetries {
+		if ctx.Err() != nil {
+			return
+		}
+
+		embeddings, err := w.generate(ctx, batch)
+		if errors.Is(err, models.ErrQuotaExceeded) {
+			// Retrying cannot help until the budget resets.
+			w.log.WithError(err).WithFields(batchFields(batch)).Warn("embedding skipped")
+			w.fail(batch)
+
+			return
+		}
+
+		if err != nil {
+			w.log.WithError(err).WithFields(batchFields(batch)).WithField("attempt", attempt+1).
+				Warn("embedding generation failed")
+
+			if attempt < maxRetries-1 {
+				delay := baseRetryDelay * (1 << attempt) // exponential backoff
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+			}
+
+			continue
+		}
+
+		w.store(ctx, batch, embeddings, "storing embedding")
+
+		return
+	}
+
+	w.log.WithFields(batchFields(batch)).Error("embedding failed after all retries")
+	w.fail(batch)
+}
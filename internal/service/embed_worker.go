@@ -9,6 +9,7 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
 )
 
 // EmbedJob represents a request to generate and store an embedding for a node.
@@ -16,6 +17,9 @@ type EmbedJob struct {
 	TenantID string
 	NodeID   string
 	Text     string // "type:label"
+
+	// BackfillID is the backfill that queued the job, if any.
+	BackfillID string
 }
 
 // EmbeddingUpdater stores a generated embedding for a node.
@@ -31,6 +35,8 @@ type EmbedWorker struct {
 	jobs        chan EmbedJob
 	maxJobs     int
 	concurrency int
+	batchSize   int
+	backfills   *backfillTracker
 	done        chan struct{} // closed when Run() returns after drain
 }
 
@@ -50,20 +56,76 @@ func NewEmbedWorker(embed *EmbeddingService, repo EmbeddingUpdater, log *logrus.
 		jobs:        make(chan EmbedJob, queueSize),
 		maxJobs:     queueSize,
 		concurrency: concurrency,
+		batchSize:   1,
+		backfills:   newBackfillTracker(),
 		done:        make(chan struct{}),
 	}
 }
 
+// WithBatchSize makes each worker send up to n queued jobs to the embedding
+// service in one request. A worker never waits for a batch to fill: it takes
+// whatever is already queued, so a lone job goes out immediately. n <= 1
+// embeds one job per request.
+func (w *EmbedWorker) WithBatchSize(n int) *EmbedWorker {
+	w.batchSize = max(n, 1)
+
+	return w
+}
+
 // Enqueue adds an embedding job. Non-blocking; drops the job if the queue is full.
 func (w *EmbedWorker) Enqueue(job EmbedJob) {
+	w.tryEnqueue(job)
+}
+
+// tryEnqueue adds job to the queue unless it is full, reporting whether it did.
+func (w *EmbedWorker) tryEnqueue(job EmbedJob) bool {
 	select {
 	case w.jobs <- job:
 		metrics.EmbedQueueDepth.Set(float64(len(w.jobs)))
+		return true
 	default:
 		w.log.WithField("node_id", job.NodeID).Warn("embedding queue full, dropping job")
+		return false
 	}
 }
 
+// Backfill queues jobs as one tracked backfill for tenantID and returns its
+// initial status. Progress is reported by BackfillStatus.
+func (w *EmbedWorker) Backfill(tenantID string, jobs []EmbedJob) *models.BackfillJob {
+	id := w.backfills.start(tenantID, len(jobs))
+
+	dropped := 0
+	for _, job := range jobs {
+		job.BackfillID = id
+		if !w.tryEnqueue(job) {
+			dropped++
+		}
+	}
+	if dropped > 0 {
+		w.backfills.dropped(id, dropped)
+	}
+
+	job, _ := w.backfills.get(tenantID, id) //nolint:errcheck // just registered.
+
+	return job
+}
+
+// BackfillStatus returns the progress of the tenant's backfill with id.
+func (w *EmbedWorker) BackfillStatus(tenantID, id string) (*models.BackfillJob, error) {
+	return w.backfills.get(tenantID, id)
+}
+
+// ListBackfills returns the tenant's recent backfills, newest first.
+func (w *EmbedWorker) ListBackfills(tenantID string) []models.BackfillJob {
+	return w.backfills.list(tenantID)
+}
+
+// CancelBackfill cancels the tenant's backfill with id. Its jobs still in
+// the queue are skipped; a batch already being embedded finishes.
+func (w *EmbedWorker) CancelBackfill(tenantID, id string) (*models.BackfillJob, error) {
+	return w.backfills.cancel(tenantID, id)
+}
+
 // Run spawns N worker goroutines and blocks until the context is cancelled
 // and all queued jobs have been drained. Call in a goroutine.
 func (w *EmbedWorker) Run(ctx context.Context) {
@@ -71,7 +133,10 @@ func (w *EmbedWorker) Run(ctx context.Context) {
 
 	var wg sync.WaitGroup
 
-	w.log.WithField("concurrency", w.concurrency).Info("starting embed workers")
+	w.log.WithFields(logrus.Fields{
+		"concurrency": w.concurrency,
+		"batch_size":  w.batchSize,
+	}).Info("starting embed workers")
 
 	for i := range w.concurrency {
 		wg.Add(1)
@@ -105,8 +170,11 @@ func (w *EmbedWorker) runWorker(ctx context.Context, id int) {
 			w.drainWorker(id)
 			return
 		case job := <-w.jobs:
+			batch := w.dropCancelled(w.fillBatch(job))
 			metrics.EmbedQueueDepth.Set(float64(len(w.jobs)))
-			w.processWithRetry(ctx, job)
+			for _, tenantBatch := range splitByTenant(batch) {
+				w.processWithRetry(ctx, tenantBatch)
+			}
 		}
 	}
 }
@@ -129,8 +197,11 @@ func (w *EmbedWorker) drainWorker(id int) {
 	for {
 		select {
 		case job := <-w.jobs:
+			batch := w.dropCancelled(w.fillBatch(job))
 			metrics.EmbedQueueDepth.Set(float64(len(w.jobs)))
-			w.processSingle(drainCtx, job)
+			for _, tenantBatch := range splitByTenant(batch) {
+				w.processSingle(drainCtx, tenantBatch)
+			}
 		case <-drainCtx.Done():
 			w.log.WithField("worker_id", id).Warn("drain timeout, dropping remaining jobs")
 			return
@@ -140,58 +211,3 @@ func (w *EmbedWorker) drainWorker(id int) {
 		}
 	}
 }
-
-const (
-	maxRetries     = 3
-	baseRetryDelay = 2 * time.Second
-)
-
-// processSingle attempts a single embedding without retry (used during drain).
-func (w *EmbedWorker) processSingle(ctx context.Context, job EmbedJob) {
-	embedding, err := w.embed.Generate(ctx, job.Text)
-	if err != nil {
-		w.log.WithError(err).WithField("node_id", job.NodeID).Warn("embedding failed during drain")
-		return
-	}
-
-	if err := w.repo.UpdateNodeEmbedding(ctx, job.TenantID, job.NodeID, embedding); err != nil {
-		w.log.WithError(err).WithField("node_id", job.NodeID).Error("storing embedding during drain")
-	}
-}
-
-func (w *EmbedWorker) processWithRetry(ctx context.Context, job EmbedJob) {
-	for attempt := range maxRetries {
-		if ctx.Err() != nil {
-			return
-		}
-
-		embedding, err := w.embed.Generate(ctx, job.Text)
-		if err != nil {
-			w.log.WithError(err).WithFields(logrus.Fields{
-				"node_id": job.NodeID,
-				"attempt": attempt + 1,
-			}).Warn("embedding generation failed")
-
-			if attempt < maxRetries-1 {
-				delay := baseRetryDelay * (1 << attempt) // exponential backoff
-				select {
-				case <-ctx.Done():
-					return
-				case <-time.After(delay):
-				}
-			}
-
-			continue
-		}
-
-		if err := w.repo.UpdateNodeEmbedding(ctx, job.TenantID, job.NodeID, embedding); err != nil {
-			w.log.WithError(err).WithField("node_id", job.NodeID).Error("storing embedding")
-		} else {
-			w.log.WithField("node_id", job.NodeID).Debug("embedding stored")
-		}
-
-		return
-	}
-
-	w.log.WithField("node_id", job.NodeID).Error("embedding failed after all retries")
-}
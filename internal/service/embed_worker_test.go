@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type recordingEmbeddingUpdater struct {
+	mu      sync.Mutex
+	updated map[string][]float32
+}
+
+func (r *recordingEmbeddingUpdater) UpdateNodeEmbedding(_ context.Context, _, nodeID string, embedding []float32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updated[nodeID] = embedding
+
+	return nil
+}
+
+func (r *recordingEmbeddingUpdater) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.updated)
+}
+
+func TestEmbedWorker_BatchesQueuedJobs(t *testing.T) {
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		var req struct {
+			Input json.RawMessage `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req) //nolint:errcheck // test server.
+
+		// A single job is sent as a string, a batch as an array.
+		inputs := []string{""}
+		json.Unmarshal(req.Input, &inputs) //nolint:errcheck // a string input leaves one entry.
+
+		embeddings := make([][]float32, len(inputs))
+		for i := range inputs {
+			embeddings[i] = []float32{1, 0, 0}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings}) //nolint:errcheck // test server.
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	repo := &recordingEmbeddingUpdater{updated: make(map[string][]float32)}
+	w := NewEmbedWorker(NewEmbeddingService(srv.URL, "test-model", 3, false), repo, log, 10, 1).WithBatchSize(4)
+
+	for _, id := range []string{"n1", "n2", "n3", "n4", "n5"} {
+		w.Enqueue(EmbedJob{TenantID: "t1", NodeID: id, Text: "concept:" + id})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go w.Run(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for repo.count() < 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	w.Wait(time.Second)
+
+	if got := repo.count(); got != 5 {
+		t.Fatalf("stored %d embeddings, want 5", got)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("embed API called %d times, want 2 (a batch of 4, then 1)", got)
+	}
+}
+
+func TestEmbedWorker_BackfillProgressAndCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": [][]float32{{1, 0, 0}}}) //nolint:errcheck // test server.
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	repo := &recordingEmbeddingUpdater{updated: make(map[string][]float32)}
+	w := NewEmbedWorker(NewEmbeddingService(srv.URL, "test-model", 3, false), repo, log, 3, 1)
+
+	done := w.Backfill("t1", []EmbedJob{{TenantID: "t1", NodeID: "n1", Text: "concept:n1"}})
+	cancelled := w.Backfill("t1", []EmbedJob{
+		{TenantID: "t1", NodeID: "n2", Text: "concept:n2"},
+		{TenantID: "t1", NodeID: "n3", Text: "concept:n3"},
+		{TenantID: "t1", NodeID: "n4", Text: "concept:n4"}, // queue full
+	})
+	if cancelled.Dropped != 1 || cancelled.Remaining != 2 {
+		t.Fatalf("second backfill = %+v, want 1 dropped and 2 remaining", cancelled)
+	}
+
+	if _, err := w.CancelBackfill("t2", cancelled.ID); err == nil {
+		t.Fatal("another tenant cancelled the backfill")
+	}
+	if _, err := w.CancelBackfill("t1", cancelled.ID); err != nil {
+		t.Fatalf("CancelBackfill: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go w.Run(ctx)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, _ := w.BackfillStatus("t1", cancelled.ID); job.FinishedAt != nil { //nolint:errcheck // tracked.
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	w.Wait(time.Second)
+
+	got, err := w.BackfillStatus("t1", done.ID)
+	if err != nil || got.Status != models.BackfillCompleted || got.Processed != 1 || got.Remaining != 0 {
+		t.Errorf("first backfill = %+v, err=%v; want completed with 1 processed", got, err)
+	}
+
+	got, err = w.BackfillStatus("t1", cancelled.ID)
+	if err != nil || got.Status != models.BackfillCancelled || got.Skipped != 2 || got.Processed != 0 || got.FinishedAt == nil {
+		t.Errorf("second backfill = %+v, err=%v; want cancelled with 2 skipped", got, err)
+	}
+
+	if repo.count() != 1 {
+		t.Errorf("stored %d embeddings, want 1", repo.count())
+	}
+
+	if list := w.ListBackfills("t1"); len(list) != 2 || list[0].ID != cancelled.ID {
+		t.Errorf("ListBackfills = %+v, want newest first", list)
+	}
+}
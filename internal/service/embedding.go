@@ -12,6 +12,9 @@
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 const embeddingTimeout = 30 * time.Second
@@ -22,7 +25,8 @@
 	cbCooldown         = 30 * time.Second
 )
 
-// Circuit breaker states.
+// Circuit breaker states, as reported by the persistor_embedding_circuit_state
+// gauge.
 const (
 	cbClosed   = iota // Normal operation.
 	cbOpen            // Fail fast.
@@ -39,6 +43,8 @@ type EmbeddingService struct {
 	model      string
 	dimensions int
 	client     *http.Client
+	cache      EmbeddingCacheStore // nil unless SetCache was called
+	usage      ModelUsageRecorder  // nil unless SetUsageRecorder was called
 
 	mu              sync.Mutex
 	cbState         int
@@ -46,13 +52,21 @@ type EmbeddingService struct {
 	cbLastFailureAt time.Time
 }
 
+// embeddingRequest is an Ollama /api/embed request. Input is a single
+// string or, for a batch, a []string.
 type embeddingRequest struct {
 	Model string `json:"model"`
-	Input string `json:"input"`
+	Input any    `json:"input"`
 }
 
 type embeddingResponse struct {
-	Embeddings [][]float32 `json:"embeddings"`
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int64       `json:"prompt_eval_count"`
+}
+
+// Model returns the name of the embedding model.
+func (s *EmbeddingService) Model() string {
+	return s.model
 }
 
 // Dimensions returns the expected embedding vector dimensions.
@@ -62,59 +76,177 @@ func (s *EmbeddingService) Dimensions() int {
 
 // NewEmbeddingService creates an EmbeddingService for the given Ollama endpoint, model, and expected dimensions.
 func NewEmbeddingService(ollamaURL, model string, dimensions int, allowRemote bool) *EmbeddingService {
+	return &EmbeddingService{
+		ollamaURL:  ollamaURL,
+		model:      model,
+		dimensions: dimensions,
+		client:     &http.Client{Timeout: embeddingTimeout, Transport: loopbackTransport("embedding", allowRemote)},
+		cbState:    cbClosed,
+	}
+}
+
+// loopbackTransport returns a transport that, unless allowRemote, refuses to
+// dial anything but loopback addresses. name labels its errors.
+func loopbackTransport(name string, allowRemote bool) *http.Transport {
 	transport := &http.Transport{}
-	if !allowRemote {
-		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-			host, _, err := net.SplitHostPort(addr)
-			if err != nil {
-				return nil, fmt.Errorf("invalid address: %w", err)
-			}
+	if allowRemote {
+		return transport
+	}
 
-			ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
-			if err != nil {
-				return nil, fmt.Errorf("resolving embedding host: %w", err)
-			}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
 
-			for _, ip := range ips {
-				if !ip.IP.IsLoopback() {
-					return nil, fmt.Errorf("embedding service connections restricted to localhost")
-				}
-			}
+		ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s host: %w", name, err)
+		}
 
-			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		for _, ip := range ips {
+			if !ip.IP.IsLoopback() {
+				return nil, fmt.Errorf("%s service connections restricted to localhost", name)
+			}
 		}
-	}
 
-	return &EmbeddingService{
-		ollamaURL:  ollamaURL,
-		model:      model,
-		dimensions: dimensions,
-		client:     &http.Client{Timeout: embeddingTimeout, Transport: transport},
-		cbState:    cbClosed,
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
 	}
+
+	return transport
 }
 
-// Generate produces a vector embedding for the given text.
-// It uses a circuit breaker to fail fast when the embedding service is down.
+// Generate produces a vector embedding for the given text, from the cache
+// when it has one. It uses a circuit breaker to fail fast when the embedding
+// service is down.
 func (s *EmbeddingService) Generate(ctx context.Context, text string) ([]float32, error) {
+	if cached := s.cacheLookup(ctx, []string{text}); cached[0] != nil {
+		return cached[0], nil
+	}
+
+	ctx, span := tracing.StartClient(ctx, "ollama.embed",
+		tracing.String("embedding.model", s.model),
+		tracing.Int("embedding.input_chars", len(text)),
+	)
+	defer span.End()
+
+	if err := s.checkBudget(ctx); err != nil {
+		span.RecordError(err)
+
+		return nil, err
+	}
+
 	if err := s.cbAllow(); err != nil {
+		span.RecordError(err)
+
 		return nil, err
 	}
 
+	start := time.Now()
+
 	result, err := s.doGenerate(ctx, text)
 	if err != nil {
+		metrics.EmbeddingDuration.WithLabelValues("error").Observe(time.Since(start).Seconds())
 		s.cbRecordFailure()
+		span.RecordError(err)
 
 		return nil, err
 	}
 
+	metrics.EmbeddingDuration.WithLabelValues("success").Observe(time.Since(start).Seconds())
 	s.cbRecordSuccess()
+	span.SetAttributes(tracing.Int("embedding.dimensions", len(result)))
+	s.cacheStore(ctx, []string{text}, [][]float32{result})
 
 	return result, nil
 }
 
 func (s *EmbeddingService) doGenerate(ctx context.Context, text string) ([]float32, error) {
-	body, err := json.Marshal(embeddingRequest{Model: s.model, Input: text})
+	vecs, err := s.doEmbed(ctx, text, []string{text})
+	if err != nil {
+		return nil, err
+	}
+
+	return vecs[0], nil
+}
+
+// GenerateBatch produces one vector embedding per text. Texts found in the
+// cache are not sent; the rest, each distinct text once, go in a single
+// request. The whole batch counts as one call for the circuit breaker: it
+// fails fast while the breaker is open and a failed batch is one failure.
+func (s *EmbeddingService) GenerateBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	embeddings := s.cacheLookup(ctx, texts)
+
+	// Positions of each distinct uncached text, in first-seen order.
+	var misses []string
+	positions := make(map[string][]int)
+	for i, vec := range embeddings {
+		if vec != nil {
+			continue
+		}
+		if _, seen := positions[texts[i]]; !seen {
+			misses = append(misses, texts[i])
+		}
+		positions[texts[i]] = append(positions[texts[i]], i)
+	}
+
+	if len(misses) == 0 {
+		return embeddings, nil
+	}
+
+	ctx, span := tracing.StartClient(ctx, "ollama.embed",
+		tracing.String("embedding.model", s.model),
+		tracing.Int("embedding.batch_size", len(misses)),
+	)
+	defer span.End()
+
+	if err := s.checkBudget(ctx); err != nil {
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	if err := s.cbAllow(); err != nil {
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	start := time.Now()
+
+	result, err := s.doEmbed(ctx, misses, misses)
+	if err != nil {
+		metrics.EmbeddingDuration.WithLabelValues("error").Observe(time.Since(start).Seconds())
+		s.cbRecordFailure()
+		span.RecordError(err)
+
+		return nil, err
+	}
+
+	metrics.EmbeddingDuration.WithLabelValues("success").Observe(time.Since(start).Seconds())
+	s.cbRecordSuccess()
+	s.cacheStore(ctx, misses, result)
+
+	for j, text := range misses {
+		for _, i := range positions[text] {
+			embeddings[i] = result[j]
+		}
+	}
+
+	return embeddings, nil
+}
+
+// doEmbed calls the Ollama embed API with input, a string or []string of
+// texts, checks that it returned one embedding of the expected dimensions
+// per text, and records the call's usage.
+func (s *EmbeddingService) doEmbed(ctx context.Context, input any, texts []string) ([][]float32, error) {
+	want := len(texts)
+
+	body, err := json.Marshal(embeddingRequest{Model: s.model, Input: input})
 	if err != nil {
 		return nil, fmt.Errorf("marshaling embedding request: %w", err)
 	}
@@ -126,6 +258,10 @@ func (s *EmbeddingService) doGenerate(ctx context.Context, text string) ([]float
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if span := tracing.FromContext(ctx); span != nil {
+		req.Header.Set("traceparent", span.TraceParent())
+	}
+
 	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("calling ollama embed API: %w", err)
@@ -140,7 +276,7 @@ func (s *EmbeddingService) doGenerate(ctx context.Context, text string) ([]float
 
 	var result embeddingResponse
 
-	limited := io.LimitReader(resp.Body, 10<<20) // 10 MB
+	limited := io.LimitReader(resp.Body, int64(want)*10<<20) // 10 MB per input
 	if err := json.NewDecoder(limited).Decode(&result); err != nil {
 		return nil, fmt.Errorf("decoding embedding response: %w", err)
 	}
@@ -149,12 +285,19 @@ func (s *EmbeddingService) doGenerate(ctx context.Context, text string) ([]float
 		return nil, fmt.Errorf("ollama returned empty embeddings")
 	}
 
-	vec := result.Embeddings[0]
-	if s.dimensions > 0 && len(vec) != s.dimensions {
-		return nil, fmt.Errorf("embedding dimension mismatch: expected %d, got %d", s.dimensions, len(vec))
+	if len(result.Embeddings) != want {
+		return nil, fmt.Errorf("ollama returned %d embeddings for %d inputs", len(result.Embeddings), want)
+	}
+
+	for _, vec := range result.Embeddings {
+		if s.dimensions > 0 && len(vec) != s.dimensions {
+			return nil, fmt.Errorf("embedding dimension mismatch: expected %d, got %d", s.dimensions, len(vec))
+		}
 	}
 
-	return vec, nil
+	s.recordUsage(ctx, result.PromptEvalCount, texts)
+
+	return result.Embeddings, nil
 }
 
 // cbAllow checks whether the circuit breaker permits a request.
@@ -170,7 +313,7 @@ func (s *EmbeddingService) cbAllow() error {
 		return nil
 	case cbOpen:
 		if time.Since(s.cbLastFailureAt) >= cbCooldown {
-			s.cbState = cbHalfOpen
+			s.setCBState(cbHalfOpen)
 
 			return nil
 		}
@@ -191,7 +334,7 @@ func (s *EmbeddingService) cbRecordSuccess() {
 	defer s.mu.Unlock()
 
 	s.cbFailures = 0
-	s.cbState = cbClosed
+	s.setCBState(cbClosed)
 }
 
 // cbRecordFailure records a failed call. After reaching the failure threshold
@@ -204,6 +347,13 @@ func (s *EmbeddingService) cbRecordFailure() {
 	s.cbLastFailureAt = time.Now()
 
 	if s.cbFailures >= cbFailureThreshold || s.cbState == cbHalfOpen {
-		s.cbState = cbOpen
+		s.setCBState(cbOpen)
 	}
 }
+
+// setCBState moves the circuit breaker to state and publishes it. Callers
+// must hold s.mu.
+func (s *EmbeddingService) setCBState(state int) {
+	s.cbState = state
+	metrics.EmbeddingCircuitState.Set(float64(state))
+}
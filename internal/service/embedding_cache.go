@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+// embeddingCacheEvictInterval is how often EmbeddingCacheEvictor.Run trims
+// the cache back to its size limit.
+const embeddingCacheEvictInterval = time.Hour
+
+// EmbeddingCacheStore is the data-access interface for cached embeddings,
+// keyed by embeddingCacheKey.
+type EmbeddingCacheStore interface {
+	GetCachedEmbeddings(ctx context.Context, keys [][]byte) (map[string][]float32, error)
+	PutCachedEmbeddings(ctx context.Context, keys [][]byte, embeddings [][]float32) error
+	EvictEmbeddingCache(ctx context.Context, maxEntries int) (int, error)
+}
+
+// SetCache makes the service look embeddings up in cache before calling
+// Ollama and store the ones it generates there. Cache failures are treated
+// as misses.
+func (s *EmbeddingService) SetCache(cache EmbeddingCacheStore) {
+	s.cache = cache
+}
+
+// embeddingCacheKey is the sha256 of the model name and text, so the same
+// text embedded by another model is a different entry.
+func embeddingCacheKey(model, text string) []byte {
+	sum := sha256.Sum256([]byte(model + "\x00" + text))
+
+	return sum[:]
+}
+
+// cacheLookup returns the cached embedding of each text, nil for misses.
+// Without a cache every text is a miss.
+func (s *EmbeddingService) cacheLookup(ctx context.Context, texts []string) [][]float32 {
+	found := make([][]float32, len(texts))
+	if s.cache == nil {
+		return found
+	}
+
+	keys := make([][]byte, len(texts))
+	for i, text := range texts {
+		keys[i] = embeddingCacheKey(s.model, text)
+	}
+
+	cached, err := s.cache.GetCachedEmbeddings(ctx, keys)
+	if err != nil {
+		cached = nil
+	}
+
+	for i, key := range keys {
+		vec := cached[string(key)]
+		if vec == nil || (s.dimensions > 0 && len(vec) != s.dimensions) {
+			metrics.EmbeddingCacheRequests.WithLabelValues("miss").Inc()
+			continue
+		}
+
+		metrics.EmbeddingCacheRequests.WithLabelValues("hit").Inc()
+		found[i] = vec
+	}
+
+	return found
+}
+
+// cacheStore caches embeddings[i] as the embedding of texts[i], best-effort.
+func (s *EmbeddingService) cacheStore(ctx context.Context, texts []string, embeddings [][]float32) {
+	if s.cache == nil {
+		return
+	}
+
+	keys := make([][]byte, len(texts))
+	for i, text := range texts {
+		keys[i] = embeddingCacheKey(s.model, text)
+	}
+
+	s.cache.PutCachedEmbeddings(ctx, keys, embeddings) //nolint:errcheck // the cache is an optimisation; a failed write is a later miss.
+}
+
+// EmbeddingCacheEvictor keeps the embedding cache within its size limit by
+// periodically deleting the least recently used entries.
+type EmbeddingCacheEvictor struct {
+	store      EmbeddingCacheStore
+	maxEntries int
+	log        *logrus.Logger
+}
+
+// NewEmbeddingCacheEvictor creates an EmbeddingCacheEvictor that keeps at
+// most maxEntries cached embeddings.
+func NewEmbeddingCacheEvictor(store EmbeddingCacheStore, maxEntries int, log *logrus.Logger) *EmbeddingCacheEvictor {
+	return &EmbeddingCacheEvictor{store: store, maxEntries: maxEntries, log: log}
+}
+
+// Run evicts on startup and then every embeddingCacheEvictInterval until ctx
+// is cancelled.
+func (e *EmbeddingCacheEvictor) Run(ctx context.Context) {
+	ticker := time.NewTicker(embeddingCacheEvictInterval)
+	defer ticker.Stop()
+
+	for {
+		e.Evict(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evict deletes the entries beyond the size limit now.
+func (e *EmbeddingCacheEvictor) Evict(ctx context.Context) {
+	evicted, err := e.store.EvictEmbeddingCache(ctx, e.maxEntries)
+	if err != nil {
+		e.log.WithError(err).Warn("evicting embedding cache")
+		return
+	}
+
+	metrics.EmbeddingCacheEvictions.Add(float64(evicted))
+
+	if evicted > 0 {
+		e.log.WithFields(logrus.Fields{
+			"evicted":     evicted,
+			"max_entries": e.maxEntries,
+		}).Info("embedding_cache.evict")
+	}
+}
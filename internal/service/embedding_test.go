@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+func TestEmbeddingService_CircuitStateMetric(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	svc := NewEmbeddingService(srv.URL, "test-model", 3, false)
+	ctx := context.Background()
+
+	for range cbFailureThreshold {
+		if _, err := svc.Generate(ctx, "hello"); err == nil {
+			t.Fatal("expected error from failing embedding server")
+		}
+	}
+
+	if state := testutil.ToFloat64(metrics.EmbeddingCircuitState); state != cbOpen {
+		t.Fatalf("circuit state gauge = %v, want %d (open)", state, cbOpen)
+	}
+
+	if _, err := svc.Generate(ctx, "hello"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	svc.cbRecordSuccess()
+
+	if state := testutil.ToFloat64(metrics.EmbeddingCircuitState); state != cbClosed {
+		t.Fatalf("circuit state gauge = %v, want %d (closed)", state, cbClosed)
+	}
+}
+
+func TestEmbeddingService_GenerateBatch(t *testing.T) {
+	var calls int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+
+		embeddings := make([][]float32, len(req.Input))
+		for i := range req.Input {
+			embeddings[i] = []float32{float32(i), 0, 1}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings}) //nolint:errcheck // test server.
+	}))
+	defer srv.Close()
+
+	svc := NewEmbeddingService(srv.URL, "test-model", 3, false)
+
+	got, err := svc.GenerateBatch(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("embed API called %d times, want 1", calls)
+	}
+
+	if len(got) != 3 || got[2][0] != 2 {
+		t.Errorf("GenerateBatch = %v, want 3 embeddings in input order", got)
+	}
+}
+
+func TestEmbeddingService_GenerateBatchCountMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"embeddings":[[0,0,1]]}`)) //nolint:errcheck // test server.
+	}))
+	defer srv.Close()
+
+	svc := NewEmbeddingService(srv.URL, "test-model", 3, false)
+
+	if _, err := svc.GenerateBatch(context.Background(), []string{"a", "b"}); err == nil {
+		t.Fatal("expected error when fewer embeddings than inputs are returned")
+	}
+
+	if svc.cbFailures != 1 {
+		t.Errorf("circuit breaker failures = %d, want 1 for one failed batch", svc.cbFailures)
+	}
+}
+
+// memoryEmbeddingCache is an in-memory EmbeddingCacheStore.
+type memoryEmbeddingCache map[string][]float32
+
+func (c memoryEmbeddingCache) GetCachedEmbeddings(_ context.Context, keys [][]byte) (map[string][]float32, error) {
+	found := make(map[string][]float32)
+	for _, key := range keys {
+		if vec, ok := c[string(key)]; ok {
+			found[string(key)] = vec
+		}
+	}
+
+	return found, nil
+}
+
+func (c memoryEmbeddingCache) PutCachedEmbeddings(_ context.Context, keys [][]byte, embeddings [][]float32) error {
+	for i, key := range keys {
+		c[string(key)] = embeddings[i]
+	}
+
+	return nil
+}
+
+func (c memoryEmbeddingCache) EvictEmbeddingCache(context.Context, int) (int, error) {
+	return 0, nil
+}
+
+func TestEmbeddingService_Cache(t *testing.T) {
+	var inputs [][]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input any `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+
+		var texts []string
+		switch input := req.Input.(type) {
+		case string:
+			texts = []string{input}
+		case []any:
+			for _, text := range input {
+				texts = append(texts, text.(string)) //nolint:forcetypeassert // test server.
+			}
+		}
+		inputs = append(inputs, texts)
+
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = []float32{float32(len(text)), 0, 1}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings}) //nolint:errcheck // test server.
+	}))
+	defer srv.Close()
+
+	svc := NewEmbeddingService(srv.URL, "test-model", 3, false)
+	svc.SetCache(memoryEmbeddingCache{})
+	ctx := context.Background()
+
+	if _, err := svc.Generate(ctx, "person:Ada"); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	got, err := svc.GenerateBatch(ctx, []string{"person:Ada", "org:ACME", "org:ACME"})
+	if err != nil {
+		t.Fatalf("GenerateBatch: %v", err)
+	}
+
+	if len(inputs) != 2 || len(inputs[1]) != 1 || inputs[1][0] != "org:ACME" {
+		t.Fatalf("embed API inputs = %v, want the cached text skipped and the duplicate sent once", inputs)
+	}
+
+	if len(got) != 3 || got[0][0] != 10 || got[1][0] != 8 || got[2][0] != 8 {
+		t.Errorf("GenerateBatch = %v, want embeddings in input order", got)
+	}
+
+	if _, err := svc.Generate(ctx, "org:ACME"); err != nil {
+		t.Fatalf("Generate cached: %v", err)
+	}
+
+	if len(inputs) != 2 {
+		t.Errorf("embed API called %d times, want a cache hit for the third call", len(inputs))
+	}
+}
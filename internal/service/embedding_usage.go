@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// charsPerToken estimates tokens from text length when the embedding API
+// does not report them.
+const charsPerToken = 4
+
+// SetUsageRecorder makes the service count the requests and tokens of each
+// embedding call made for a tenant with usage, and refuse calls once the
+// tenant's monthly embedding token budget is used up. Cache hits cost
+// nothing and are not counted. Calls are attributed to the tenant set with
+// withUsageTenant; others are not counted.
+func (s *EmbeddingService) SetUsageRecorder(usage ModelUsageRecorder) {
+	s.usage = usage
+}
+
+// checkBudget returns an error wrapping models.ErrQuotaExceeded when the
+// tenant ctx is attributed to has no embedding tokens left this month.
+func (s *EmbeddingService) checkBudget(ctx context.Context) error {
+	tenantID := usageTenant(ctx)
+	if s.usage == nil || tenantID == "" {
+		return nil
+	}
+
+	return s.usage.CheckTokenBudget(ctx, tenantID, models.ModelUsageEmbedding)
+}
+
+// recordUsage counts one embedding request of tokens, estimated from texts
+// when the API reported none, for the tenant ctx is attributed to.
+func (s *EmbeddingService) recordUsage(ctx context.Context, tokens int64, texts []string) {
+	tenantID := usageTenant(ctx)
+	if s.usage == nil || tenantID == "" {
+		return
+	}
+
+	if tokens <= 0 {
+		for _, text := range texts {
+			tokens += int64((len(text) + charsPerToken - 1) / charsPerToken)
+		}
+	}
+
+	s.usage.RecordModelUsage(tenantID, models.ModelUsageEmbedding, 1, tokens)
+}
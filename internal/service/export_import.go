@@ -18,9 +18,16 @@
 type exportImportStore interface {
 	ExportAllNodes(ctx context.Context, tenantID string) ([]models.ExportNode, error)
 	ExportAllEdges(ctx context.Context, tenantID string) ([]models.ExportEdge, error)
+	ExportNodesPage(ctx context.Context, tenantID, afterID string, limit int) ([]models.ExportNode, error)
+	ExportEdgesPage(ctx context.Context, tenantID, afterSource, afterTarget, afterRelation string, limit int) ([]models.ExportEdge, error)
+	CountForExport(ctx context.Context, tenantID string) (nodes, edges int, err error)
 	ExistingNodeIDs(ctx context.Context, tenantID string, ids []string) (map[string]struct{}, error)
+	ExportNodesByID(ctx context.Context, tenantID string, ids []string) ([]models.ExportNode, error)
+	ExportEdgesByKey(ctx context.Context, tenantID string, keys []models.ExportEdge) ([]models.ExportEdge, error)
 	UpsertNodeFromExport(ctx context.Context, tenantID string, node models.ExportNode, overwrite bool) (string, error)
 	UpsertEdgeFromExport(ctx context.Context, tenantID string, edge models.ExportEdge, overwrite bool) (string, error)
+	GetJSONLDContext(ctx context.Context, tenantID string) (*models.JSONLDContext, error)
+	SetJSONLDContext(ctx context.Context, tenantID string, c *models.JSONLDContext) error
 }
 
 // Compile-time check: *ExportImportService must satisfy domain.ExportImportService.
@@ -29,6 +36,7 @@ type exportImportStore interface {
 // ExportImportService implements domain.ExportImportService.
 type ExportImportService struct {
 	store            exportImportStore
+	scanner          NodeScanner
 	persistorVersion string
 }
 
@@ -37,6 +45,13 @@ func NewExportImportService(store exportImportStore, persistorVersion string) *E
 	return &ExportImportService{store: store, persistorVersion: persistorVersion}
 }
 
+// WithScanner checks every node the service imports, such as for prompt
+// injection. Skipped nodes are not checked.
+func (s *ExportImportService) WithScanner(scanner NodeScanner) *ExportImportService {
+	s.scanner = scanner
+	return s
+}
+
 // Export serialises all nodes and edges for a tenant into a portable, full-fidelity format.
 // Properties are returned in plaintext; the store layer handles decryption.
 func (s *ExportImportService) Export(ctx context.Context, tenantID string) (*models.ExportFormat, error) {
@@ -132,6 +147,39 @@ func (s *ExportImportService) Import(
 	return result, nil
 }
 
+// ImportConflicts returns the stored versions of the payload's nodes and
+// edges that already exist, so a caller can decide per record how to import.
+func (s *ExportImportService) ImportConflicts(
+	ctx context.Context,
+	tenantID string,
+	data *models.ExportFormat,
+) (*models.ImportConflicts, error) {
+	ids := make([]string, len(data.Nodes))
+	for i, n := range data.Nodes {
+		ids[i] = n.ID
+	}
+
+	nodes, err := s.store.ExportNodesByID(ctx, tenantID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching conflicting nodes: %w", err)
+	}
+
+	edges, err := s.store.ExportEdgesByKey(ctx, tenantID, data.Edges)
+	if err != nil {
+		return nil, fmt.Errorf("fetching conflicting edges: %w", err)
+	}
+
+	if nodes == nil {
+		nodes = []models.ExportNode{}
+	}
+
+	if edges == nil {
+		edges = []models.ExportEdge{}
+	}
+
+	return &models.ImportConflicts{Nodes: nodes, Edges: edges}, nil
+}
+
 // importNodes upserts all nodes from the export and updates result counts.
 func (s *ExportImportService) importNodes(
 	ctx context.Context,
@@ -155,7 +203,10 @@ func (s *ExportImportService) importNodes(
 			result.NodesUpdated++
 		case "skipped":
 			result.NodesSkipped++
+			continue
 		}
+
+		scanExportNode(ctx, s.scanner, tenantID, &n)
 	}
 
 	return nil
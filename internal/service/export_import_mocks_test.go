@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"slices"
 
 	"github.com/persistorai/persistor/internal/models"
 	"github.com/persistorai/persistor/internal/service"
@@ -16,6 +17,7 @@ type mockExportImportStore struct {
 	upsertErr            error
 	existingNodeIDsCalls int
 	lastExistingNodeIDs  []string
+	jsonldContext        *models.JSONLDContext
 }
 
 func (m *mockExportImportStore) ExportAllNodes(_ context.Context, _ string) ([]models.ExportNode, error) {
@@ -32,6 +34,45 @@ func (m *mockExportImportStore) ExportAllEdges(_ context.Context, _ string) ([]m
 	return m.edges, nil
 }
 
+func (m *mockExportImportStore) ExportNodesPage(_ context.Context, _ string, afterID string, limit int) ([]models.ExportNode, error) {
+	if m.errOnExport != nil {
+		return nil, m.errOnExport
+	}
+
+	var page []models.ExportNode
+	for _, n := range m.nodes {
+		if n.ID > afterID && len(page) < limit {
+			page = append(page, n)
+		}
+	}
+	return page, nil
+}
+
+func (m *mockExportImportStore) ExportEdgesPage(
+	_ context.Context, _ string, afterSource, afterTarget, afterRelation string, limit int,
+) ([]models.ExportEdge, error) {
+	if m.errOnExport != nil {
+		return nil, m.errOnExport
+	}
+
+	after := [3]string{afterSource, afterTarget, afterRelation}
+	var page []models.ExportEdge
+	for _, e := range m.edges {
+		key := [3]string{e.Source, e.Target, e.Relation}
+		if slices.Compare(key[:], after[:]) > 0 && len(page) < limit {
+			page = append(page, e)
+		}
+	}
+	return page, nil
+}
+
+func (m *mockExportImportStore) CountForExport(_ context.Context, _ string) (int, int, error) {
+	if m.errOnExport != nil {
+		return 0, 0, m.errOnExport
+	}
+	return len(m.nodes), len(m.edges), nil
+}
+
 func (m *mockExportImportStore) ExistingNodeIDs(_ context.Context, _ string, ids []string) (map[string]struct{}, error) {
 	m.existingNodeIDsCalls++
 	m.lastExistingNodeIDs = append([]string(nil), ids...)
@@ -53,6 +94,28 @@ func (m *mockExportImportStore) ExistingNodeIDs(_ context.Context, _ string, ids
 	return result, nil
 }
 
+func (m *mockExportImportStore) ExportNodesByID(_ context.Context, _ string, ids []string) ([]models.ExportNode, error) {
+	var found []models.ExportNode
+	for _, n := range m.nodes {
+		if slices.Contains(ids, n.ID) {
+			found = append(found, n)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockExportImportStore) ExportEdgesByKey(_ context.Context, _ string, keys []models.ExportEdge) ([]models.ExportEdge, error) {
+	var found []models.ExportEdge
+	for _, e := range m.edges {
+		if slices.ContainsFunc(keys, func(k models.ExportEdge) bool {
+			return k.Source == e.Source && k.Target == e.Target && k.Relation == e.Relation
+		}) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
 func (m *mockExportImportStore) UpsertNodeFromExport(_ context.Context, _ string, _ models.ExportNode, _ bool) (string, error) {
 	if m.upsertErr != nil {
 		return "", m.upsertErr
@@ -67,6 +130,15 @@ func (m *mockExportImportStore) UpsertEdgeFromExport(_ context.Context, _ string
 	return "created", nil
 }
 
+func (m *mockExportImportStore) GetJSONLDContext(_ context.Context, _ string) (*models.JSONLDContext, error) {
+	return m.jsonldContext, nil
+}
+
+func (m *mockExportImportStore) SetJSONLDContext(_ context.Context, _ string, c *models.JSONLDContext) error {
+	m.jsonldContext = c
+	return nil
+}
+
 func newTestService(store *mockExportImportStore) *service.ExportImportService {
 	return service.NewExportImportService(store, "test-0.0.1")
 }
@@ -233,3 +233,54 @@ func TestValidateImport_SkipsDBLookupWhenExportContainsAllReferencedNodes(t *tes
 		t.Fatalf("ExistingNodeIDs calls = %d, want 0", store.existingNodeIDsCalls)
 	}
 }
+
+func TestImportConflicts(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{{ID: "a", Label: "stored A"}},
+		edges: []models.ExportEdge{{Source: "a", Target: "b", Relation: "r"}},
+	}
+	svc := newTestService(store)
+
+	data := &models.ExportFormat{
+		Nodes: []models.ExportNode{{ID: "a", Label: "incoming A"}, {ID: "b"}},
+		Edges: []models.ExportEdge{
+			{Source: "a", Target: "b", Relation: "r"},
+			{Source: "a", Target: "b", Relation: "other"},
+		},
+	}
+
+	got, err := svc.ImportConflicts(context.Background(), "t1", data)
+	if err != nil {
+		t.Fatalf("ImportConflicts: %v", err)
+	}
+	if len(got.Nodes) != 1 || got.Nodes[0].Label != "stored A" {
+		t.Errorf("nodes = %+v, want the stored version of a", got.Nodes)
+	}
+	if len(got.Edges) != 1 || got.Edges[0].Relation != "r" {
+		t.Errorf("edges = %+v, want a→b r", got.Edges)
+	}
+}
+
+type recordingScanner struct {
+	scanned []string
+}
+
+func (r *recordingScanner) ScanNode(_ context.Context, _ string, node *models.Node) {
+	r.scanned = append(r.scanned, node.ID)
+}
+
+func TestExportImportService_ScansImportedNodes(t *testing.T) {
+	scanner := &recordingScanner{}
+	svc := newTestService(&mockExportImportStore{}).WithScanner(scanner)
+
+	data := &models.ExportFormat{Nodes: []models.ExportNode{
+		{ID: "n1", Type: "note", Label: "One"},
+		{ID: "n2", Type: "note", Label: "Two"},
+	}}
+	if _, err := svc.Import(context.Background(), "t1", data, models.ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(scanner.scanned) != 2 || scanner.scanned[0] != "n1" || scanner.scanned[1] != "n2" {
+		t.Errorf("scanned = %v, want n1 and n2", scanner.scanned)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// rdfsLabel is the IRI node labels are exported under.
+const rdfsLabel = "http://www.w3.org/2000/01/rdf-schema#label"
+
+// GetJSONLDContext returns the tenant's JSON-LD context with defaults filled
+// in, or the default context when it has not set one.
+func (s *ExportImportService) GetJSONLDContext(ctx context.Context, tenantID string) (*models.JSONLDContext, error) {
+	c, err := s.store.GetJSONLDContext(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil {
+		c = &models.JSONLDContext{}
+	}
+
+	withDefaults := c.WithDefaults()
+
+	return &withDefaults, nil
+}
+
+// SetJSONLDContext replaces the tenant's JSON-LD context; nil restores the
+// default. Returns the context exports will now use.
+func (s *ExportImportService) SetJSONLDContext(
+	ctx context.Context, tenantID string, c *models.JSONLDContext,
+) (*models.JSONLDContext, error) {
+	if err := s.store.SetJSONLDContext(ctx, tenantID, c); err != nil {
+		return nil, err
+	}
+
+	if c == nil {
+		c = &models.JSONLDContext{}
+	}
+
+	withDefaults := c.WithDefaults()
+
+	return &withDefaults, nil
+}
+
+// ExportJSONLD exports the tenant's graph as JSON-LD using its context.
+// Each node becomes a node object typed by its node type, with its label as
+// rdfs:label, its properties as values, and its outgoing edges as references
+// to their targets. Edge properties and weights are not represented; use the
+// JSON export for a full-fidelity backup.
+func (s *ExportImportService) ExportJSONLD(ctx context.Context, tenantID string) (*models.JSONLDDocument, error) {
+	jsonldCtx, err := s.GetJSONLDContext(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting JSON-LD context: %w", err)
+	}
+
+	nodes, err := s.store.ExportAllNodes(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("exporting nodes: %w", err)
+	}
+
+	edges, err := s.store.ExportAllEdges(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("exporting edges: %w", err)
+	}
+
+	return buildJSONLD(*jsonldCtx, nodes, edges), nil
+}
+
+// buildJSONLD assembles the document. Values that share a key, such as a
+// property and a relation with the same name, are collected into one array;
+// references are written as {"@id": ...} so no term needs type coercion.
+func buildJSONLD(c models.JSONLDContext, nodes []models.ExportNode, edges []models.ExportEdge) *models.JSONLDDocument {
+	doc := &models.JSONLDDocument{Context: jsonldContextTerms(c), Graph: make([]map[string]any, 0, len(nodes))}
+
+	byID := make(map[string]map[string]any, len(nodes))
+
+	for _, n := range nodes {
+		obj := map[string]any{"@id": jsonldNodeIRI(c.Base, n.ID), "@type": n.Type, "label": n.Label}
+
+		for key, value := range n.Properties {
+			if value == nil || strings.HasPrefix(key, "@") {
+				continue
+			}
+
+			addJSONLDValue(obj, key, value)
+		}
+
+		byID[n.ID] = obj
+		doc.Graph = append(doc.Graph, obj)
+	}
+
+	for _, e := range edges {
+		if obj, ok := byID[e.Source]; ok {
+			addJSONLDValue(obj, e.Relation, map[string]any{"@id": jsonldNodeIRI(c.Base, e.Target)})
+		}
+	}
+
+	return doc
+}
+
+// jsonldContextTerms renders a JSONLDContext as a JSON-LD @context.
+func jsonldContextTerms(c models.JSONLDContext) map[string]any {
+	terms := map[string]any{"@vocab": c.Vocab, "label": rdfsLabel}
+
+	for _, group := range []map[string]string{c.Prefixes, c.Types, c.Relations, c.Properties} {
+		for term, iri := range group {
+			terms[term] = iri
+		}
+	}
+
+	return terms
+}
+
+// jsonldNodeIRI escapes a node ID into the base IRI.
+func jsonldNodeIRI(base, id string) string {
+	return base + url.PathEscape(id)
+}
+
+func addJSONLDValue(obj map[string]any, key string, value any) {
+	existing, ok := obj[key]
+	if !ok {
+		obj[key] = value
+		return
+	}
+
+	if values, isList := existing.([]any); isList {
+		obj[key] = append(slices.Clip(values), value)
+		return
+	}
+
+	obj[key] = []any{existing, value}
+}
@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestExportJSONLD_DefaultContext(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{
+			{ID: "alice", Type: "person", Label: "Alice", Properties: map[string]any{"age": 34.0, "@id": "x", "gone": nil}},
+			{ID: "acme corp", Type: "company", Label: "Acme"},
+		},
+		edges: []models.ExportEdge{
+			{Source: "alice", Target: "acme corp", Relation: "works_at"},
+		},
+	}
+
+	doc, err := newTestService(store).ExportJSONLD(context.Background(), "tenant-1")
+	if err != nil {
+		t.Fatalf("ExportJSONLD: %v", err)
+	}
+
+	if doc.Context["@vocab"] != models.DefaultJSONLDVocab {
+		t.Errorf("@vocab = %v, want %s", doc.Context["@vocab"], models.DefaultJSONLDVocab)
+	}
+
+	if len(doc.Graph) != 2 {
+		t.Fatalf("graph has %d nodes, want 2", len(doc.Graph))
+	}
+
+	alice := doc.Graph[0]
+	if alice["@id"] != models.DefaultJSONLDBase+"alice" || alice["@type"] != "person" || alice["label"] != "Alice" {
+		t.Errorf("alice = %v", alice)
+	}
+
+	if alice["age"] != 34.0 {
+		t.Errorf("age = %v, want 34", alice["age"])
+	}
+
+	if _, ok := alice["gone"]; ok {
+		t.Error("null property should be omitted")
+	}
+
+	ref, ok := alice["works_at"].(map[string]any)
+	if !ok || ref["@id"] != models.DefaultJSONLDBase+"acme%20corp" {
+		t.Errorf("works_at = %v, want a reference to the escaped acme corp IRI", alice["works_at"])
+	}
+}
+
+func TestExportJSONLD_TenantContext(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{
+			{ID: "a", Type: "person", Label: "A", Properties: map[string]any{"knows": "gossip"}},
+			{ID: "b", Type: "person", Label: "B"},
+			{ID: "c", Type: "person", Label: "C"},
+		},
+		edges: []models.ExportEdge{
+			{Source: "a", Target: "b", Relation: "knows"},
+			{Source: "a", Target: "c", Relation: "knows"},
+		},
+		jsonldContext: &models.JSONLDContext{
+			Base:      "https://example.org/people/",
+			Prefixes:  map[string]string{"schema": "https://schema.org/"},
+			Types:     map[string]string{"person": "schema:Person"},
+			Relations: map[string]string{"knows": "schema:knows"},
+		},
+	}
+
+	doc, err := newTestService(store).ExportJSONLD(context.Background(), "tenant-1")
+	if err != nil {
+		t.Fatalf("ExportJSONLD: %v", err)
+	}
+
+	for term, want := range map[string]any{
+		"schema": "https://schema.org/",
+		"person": "schema:Person",
+		"knows":  "schema:knows",
+		"@vocab": models.DefaultJSONLDVocab,
+	} {
+		if doc.Context[term] != want {
+			t.Errorf("@context[%s] = %v, want %v", term, doc.Context[term], want)
+		}
+	}
+
+	knows, ok := doc.Graph[0]["knows"].([]any)
+	if !ok || len(knows) != 3 {
+		t.Fatalf("knows = %v, want the property and two references", doc.Graph[0]["knows"])
+	}
+
+	if ref := knows[2].(map[string]any); ref["@id"] != "https://example.org/people/c" {
+		t.Errorf("second reference = %v", ref)
+	}
+}
+
+func TestSetJSONLDContext_NilRestoresDefault(t *testing.T) {
+	store := &mockExportImportStore{jsonldContext: &models.JSONLDContext{Vocab: "https://example.org/vocab#"}}
+	svc := newTestService(store)
+
+	got, err := svc.SetJSONLDContext(context.Background(), "tenant-1", nil)
+	if err != nil {
+		t.Fatalf("SetJSONLDContext: %v", err)
+	}
+
+	if store.jsonldContext != nil {
+		t.Error("stored context should be cleared")
+	}
+
+	if got.Base != models.DefaultJSONLDBase || got.Vocab != models.DefaultJSONLDVocab {
+		t.Errorf("context = %+v, want the defaults", got)
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ExportManifest returns the header of a chunked export along with the number
+// of nodes and edges the pages will contain.
+func (s *ExportImportService) ExportManifest(ctx context.Context, tenantID string) (*models.ExportManifest, error) {
+	nodes, edges, err := s.store.CountForExport(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("counting export records: %w", err)
+	}
+
+	return &models.ExportManifest{
+		SchemaVersion:    db.SchemaVersion(),
+		PersistorVersion: s.persistorVersion,
+		ExportedAt:       time.Now().UTC(),
+		TenantID:         tenantID,
+		Stats: models.ExportStats{
+			NodeCount: nodes,
+			EdgeCount: edges,
+		},
+	}, nil
+}
+
+// ExportNodes returns the page of nodes that follows cursor.
+func (s *ExportImportService) ExportNodes(ctx context.Context, tenantID, cursor string, limit int) (*models.ExportNodePage, error) {
+	afterID, err := decodeNodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = clampExportPageSize(limit)
+
+	nodes, err := s.store.ExportNodesPage(ctx, tenantID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("exporting node page: %w", err)
+	}
+
+	page := &models.ExportNodePage{Nodes: nodes}
+	if len(nodes) == limit {
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(nodes[len(nodes)-1].ID))
+	}
+
+	return page, nil
+}
+
+// ExportEdges returns the page of edges that follows cursor.
+func (s *ExportImportService) ExportEdges(ctx context.Context, tenantID, cursor string, limit int) (*models.ExportEdgePage, error) {
+	after, err := decodeEdgeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = clampExportPageSize(limit)
+
+	edges, err := s.store.ExportEdgesPage(ctx, tenantID, after[0], after[1], after[2], limit)
+	if err != nil {
+		return nil, fmt.Errorf("exporting edge page: %w", err)
+	}
+
+	page := &models.ExportEdgePage{Edges: edges}
+	if len(edges) == limit {
+		last := edges[len(edges)-1]
+
+		raw, err := json.Marshal([3]string{last.Source, last.Target, last.Relation})
+		if err != nil {
+			return nil, fmt.Errorf("encoding edge cursor: %w", err)
+		}
+
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+	}
+
+	return page, nil
+}
+
+// clampExportPageSize applies the default and maximum export page sizes.
+func clampExportPageSize(limit int) int {
+	if limit <= 0 {
+		return models.DefaultExportPageSize
+	}
+
+	return min(limit, models.MaxExportPageSize)
+}
+
+// decodeNodeCursor returns the node ID encoded in cursor.
+func decodeNodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) == 0 {
+		return "", models.ErrInvalidExportCursor
+	}
+
+	return string(raw), nil
+}
+
+// decodeEdgeCursor returns the (source, target, relation) key encoded in cursor.
+func decodeEdgeCursor(cursor string) ([3]string, error) {
+	var key [3]string
+	if cursor == "" {
+		return key, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return key, models.ErrInvalidExportCursor
+	}
+
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return key, models.ErrInvalidExportCursor
+	}
+
+	return key, nil
+}
+
+// StreamExport passes the tenant's export to emit one record at a time: the
+// manifest, every node, every edge, and an end record with the counts sent.
+// Records are read page by page, so memory use does not grow with the graph.
+func (s *ExportImportService) StreamExport(
+	ctx context.Context, tenantID string, emit func(*models.ExportRecord) error,
+) error {
+	manifest, err := s.ExportManifest(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := emit(&models.ExportRecord{Type: models.ExportRecordManifest, Manifest: manifest}); err != nil {
+		return err
+	}
+
+	var stats models.ExportStats
+
+	for cursor := ""; ; {
+		page, err := s.ExportNodes(ctx, tenantID, cursor, models.MaxExportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range page.Nodes {
+			if err := emit(&models.ExportRecord{Type: models.ExportRecordNode, Node: &page.Nodes[i]}); err != nil {
+				return err
+			}
+		}
+
+		stats.NodeCount += len(page.Nodes)
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	for cursor := ""; ; {
+		page, err := s.ExportEdges(ctx, tenantID, cursor, models.MaxExportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range page.Edges {
+			if err := emit(&models.ExportRecord{Type: models.ExportRecordEdge, Edge: &page.Edges[i]}); err != nil {
+				return err
+			}
+		}
+
+		stats.EdgeCount += len(page.Edges)
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	return emit(&models.ExportRecord{Type: models.ExportRecordEnd, Stats: &stats})
+}
@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestExportManifest_Counts(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{{ID: "n1"}, {ID: "n2"}},
+		edges: []models.ExportEdge{{Source: "n1", Target: "n2", Relation: "uses"}},
+	}
+	svc := newTestService(store)
+
+	got, err := svc.ExportManifest(context.Background(), "tenant-1")
+	if err != nil {
+		t.Fatalf("ExportManifest: %v", err)
+	}
+
+	if got.Stats.NodeCount != 2 || got.Stats.EdgeCount != 1 {
+		t.Errorf("Stats = %+v, want 2 nodes, 1 edge", got.Stats)
+	}
+
+	if got.PersistorVersion != "test-0.0.1" {
+		t.Errorf("PersistorVersion = %q, want %q", got.PersistorVersion, "test-0.0.1")
+	}
+}
+
+func TestExportNodes_PagesUntilExhausted(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+	}
+	svc := newTestService(store)
+	ctx := context.Background()
+
+	var ids []string
+	cursor := ""
+	for range 5 {
+		page, err := svc.ExportNodes(ctx, "tenant-1", cursor, 2)
+		if err != nil {
+			t.Fatalf("ExportNodes: %v", err)
+		}
+
+		for _, n := range page.Nodes {
+			ids = append(ids, n.ID)
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(ids) != 3 || ids[0] != "a" || ids[2] != "c" {
+		t.Errorf("ids = %v, want [a b c]", ids)
+	}
+}
+
+func TestExportEdges_PagesUntilExhausted(t *testing.T) {
+	store := &mockExportImportStore{
+		edges: []models.ExportEdge{
+			{Source: "a", Target: "b", Relation: "knows"},
+			{Source: "a", Target: "b", Relation: "uses"},
+			{Source: "b", Target: "a", Relation: "knows"},
+		},
+	}
+	svc := newTestService(store)
+	ctx := context.Background()
+
+	first, err := svc.ExportEdges(ctx, "tenant-1", "", 2)
+	if err != nil {
+		t.Fatalf("ExportEdges: %v", err)
+	}
+
+	if len(first.Edges) != 2 || first.NextCursor == "" {
+		t.Fatalf("first page = %d edges, cursor %q", len(first.Edges), first.NextCursor)
+	}
+
+	second, err := svc.ExportEdges(ctx, "tenant-1", first.NextCursor, 2)
+	if err != nil {
+		t.Fatalf("ExportEdges: %v", err)
+	}
+
+	if len(second.Edges) != 1 || second.Edges[0].Source != "b" {
+		t.Errorf("second page = %+v, want the b→a edge", second.Edges)
+	}
+
+	if second.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty on last page", second.NextCursor)
+	}
+}
+
+func TestExportEdges_InvalidCursor(t *testing.T) {
+	svc := newTestService(&mockExportImportStore{})
+
+	_, err := svc.ExportEdges(context.Background(), "tenant-1", "not-a-cursor!", 10)
+	if !errors.Is(err, models.ErrInvalidExportCursor) {
+		t.Fatalf("err = %v, want ErrInvalidExportCursor", err)
+	}
+}
+
+func TestStreamExport_RecordOrder(t *testing.T) {
+	store := &mockExportImportStore{
+		nodes: []models.ExportNode{{ID: "a"}, {ID: "b"}},
+		edges: []models.ExportEdge{{Source: "a", Target: "b", Relation: "uses"}},
+	}
+	svc := newTestService(store)
+
+	var types []string
+	var end *models.ExportStats
+	err := svc.StreamExport(context.Background(), "tenant-1", func(rec *models.ExportRecord) error {
+		types = append(types, rec.Type)
+		if rec.Type == models.ExportRecordEnd {
+			end = rec.Stats
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamExport: %v", err)
+	}
+
+	want := []string{"manifest", "node", "node", "edge", "end"}
+	if len(types) != len(want) {
+		t.Fatalf("types = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("types = %v, want %v", types, want)
+		}
+	}
+
+	if end == nil || end.NodeCount != 2 || end.EdgeCount != 1 {
+		t.Errorf("end stats = %+v, want 2 nodes, 1 edge", end)
+	}
+}
+
+func TestStreamExport_StopsOnEmitError(t *testing.T) {
+	store := &mockExportImportStore{nodes: []models.ExportNode{{ID: "a"}, {ID: "b"}}}
+	svc := newTestService(store)
+	errClosed := errors.New("client went away")
+
+	calls := 0
+	err := svc.StreamExport(context.Background(), "tenant-1", func(*models.ExportRecord) error {
+		calls++
+		if calls == 2 {
+			return errClosed
+		}
+		return nil
+	})
+	if !errors.Is(err, errClosed) || calls != 2 {
+		t.Errorf("err = %v after %d records, want errClosed after 2", err, calls)
+	}
+}
@@ -8,19 +8,22 @@
 
 	"github.com/persistorai/persistor/internal/domain"
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // GraphStore is the data-access interface GraphService depends on.
-// It reuses domain.GraphService since the method sets are identical, avoiding duplication.
-type GraphStore = domain.GraphService
+type GraphStore = storage.GraphStorage
 
 // Compile-time check: *GraphService must satisfy domain.GraphService.
 var _ domain.GraphService = (*GraphService)(nil)
 
 // GraphService wraps GraphStore with context-aware logging.
 type GraphService struct {
-	store GraphStore
-	log   *logrus.Logger
+	store      GraphStore
+	access     NodeAccessRecorder
+	quarantine QuarantineChecker
+	log        *logrus.Logger
 }
 
 // NewGraphService creates a GraphService.
@@ -28,40 +31,99 @@ func NewGraphService(store GraphStore, log *logrus.Logger) *GraphService {
 	return &GraphService{store: store, log: log}
 }
 
+// WithAccessRecorder counts node reads: the start node of every lookup and
+// traversal, and the neighbors Neighbors and GraphContext return. Nodes
+// further out in a multi-hop traversal are not counted.
+func (s *GraphService) WithAccessRecorder(access NodeAccessRecorder) *GraphService {
+	s.access = access
+	return s
+}
+
 // Neighbors returns all nodes directly connected to nodeID.
-func (s *GraphService) Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error) {
+func (s *GraphService) Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (result *models.NeighborResult, err error) {
+	ctx, span := startSpan(ctx, "GraphService.Neighbors", tenantID)
+	defer endSpan(span, &err)
+
 	s.log.WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"node_id":   nodeID,
 		"limit":     limit,
 	}).Debug("graph.neighbors")
 
-	return s.store.Neighbors(ctx, tenantID, nodeID, limit)
+	result, err = s.store.Neighbors(ctx, tenantID, nodeID, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(tracing.Int("node_count", len(result.Nodes)))
+
+	if s.access != nil {
+		s.access.TouchNodes(tenantID, nodeID)
+		touchNodes(s.access, tenantID, result.Nodes)
+	}
+
+	return result, nil
 }
 
 // Traverse performs a multi-hop graph traversal starting from nodeID.
-func (s *GraphService) Traverse(ctx context.Context, tenantID, nodeID string, maxHops int) (*models.TraverseResult, error) {
+func (s *GraphService) Traverse(
+	ctx context.Context, tenantID, nodeID string, maxHops int, opts models.ReadOpts,
+) (result *models.TraverseResult, err error) {
+	ctx, span := startSpan(ctx, "GraphService.Traverse", tenantID, tracing.Int("max_hops", maxHops))
+	defer endSpan(span, &err)
+
 	s.log.WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"node_id":   nodeID,
 		"max_hops":  maxHops,
 	}).Debug("graph.traverse")
 
-	return s.store.Traverse(ctx, tenantID, nodeID, maxHops)
+	result, err = s.store.Traverse(ctx, tenantID, nodeID, maxHops, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(tracing.Int("node_count", len(result.Nodes)))
+
+	if s.access != nil {
+		s.access.TouchNodes(tenantID, nodeID)
+	}
+
+	return result, nil
 }
 
 // GraphContext returns a node with its immediate neighbors and connecting edges.
-func (s *GraphService) GraphContext(ctx context.Context, tenantID, nodeID string) (*models.ContextResult, error) {
+func (s *GraphService) GraphContext(ctx context.Context, tenantID, nodeID string) (result *models.ContextResult, err error) {
+	ctx, span := startSpan(ctx, "GraphService.GraphContext", tenantID)
+	defer endSpan(span, &err)
+
 	s.log.WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"node_id":   nodeID,
 	}).Debug("graph.context")
 
-	return s.store.GraphContext(ctx, tenantID, nodeID)
+	result, err = s.store.GraphContext(ctx, tenantID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.filterContext(ctx, tenantID, result); err != nil {
+		return nil, err
+	}
+
+	if s.access != nil {
+		s.access.TouchNodes(tenantID, result.Node.ID)
+		touchNodes(s.access, tenantID, result.Neighbors)
+	}
+
+	return result, nil
 }
 
 // ShortestPath finds the shortest path between two nodes.
-func (s *GraphService) ShortestPath(ctx context.Context, tenantID, fromID, toID string) ([]models.Node, error) {
+func (s *GraphService) ShortestPath(ctx context.Context, tenantID, fromID, toID string) (path []models.Node, err error) {
+	ctx, span := startSpan(ctx, "GraphService.ShortestPath", tenantID)
+	defer endSpan(span, &err)
+
 	s.log.WithFields(logrus.Fields{
 		"tenant_id": tenantID,
 		"from_id":   fromID,
@@ -70,3 +132,65 @@ func (s *GraphService) ShortestPath(ctx context.Context, tenantID, fromID, toID
 
 	return s.store.ShortestPath(ctx, tenantID, fromID, toID)
 }
+
+// Summary returns a compact, prompt-friendly summary of a node's neighborhood.
+func (s *GraphService) Summary(ctx context.Context, tenantID, nodeID string, limit int) (summary *models.GraphSummary, err error) {
+	ctx, span := startSpan(ctx, "GraphService.Summary", tenantID)
+	defer endSpan(span, &err)
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"node_id":   nodeID,
+		"limit":     limit,
+	}).Debug("graph.summary")
+
+	summary, err = s.store.Summary(ctx, tenantID, nodeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.filterSummary(ctx, tenantID, summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// Subgraph returns the induced subgraph over the requested node IDs.
+func (s *GraphService) Subgraph(ctx context.Context, tenantID string, req models.SubgraphRequest) (result *models.SubgraphResult, err error) {
+	ctx, span := startSpan(ctx, "GraphService.Subgraph", tenantID, tracing.Int("seed_count", len(req.NodeIDs)))
+	defer endSpan(span, &err)
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"seeds":     len(req.NodeIDs),
+		"expand":    req.Expand,
+	}).Debug("graph.subgraph")
+
+	result, err = s.store.Subgraph(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.filterSubgraph(ctx, tenantID, result); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(tracing.Int("node_count", len(result.Nodes)))
+
+	return result, nil
+}
+
+// AsOf reconstructs a page of the graph as it was at q.Timestamp.
+func (s *GraphService) AsOf(ctx context.Context, tenantID string, q models.AsOfQuery) (snapshot *models.GraphSnapshot, err error) {
+	ctx, span := startSpan(ctx, "GraphService.AsOf", tenantID)
+	defer endSpan(span, &err)
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"as_of":     q.Timestamp,
+		"type":      q.Type,
+	}).Debug("graph.as_of")
+
+	return s.store.GraphAsOf(ctx, tenantID, q)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// Communities clusters the tenant graph with weighted label propagation and
+// returns communities of at least req.MinSize nodes, largest first.
+func (s *GraphService) Communities(ctx context.Context, tenantID string, req models.CommunityRequest) (result *models.CommunityResult, err error) {
+	ctx, span := startSpan(ctx, "GraphService.Communities", tenantID)
+	defer endSpan(span, &err)
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":      tenantID,
+		"max_iterations": req.MaxIterations,
+		"min_size":       req.MinSize,
+		"relation":       req.Relation,
+	}).Debug("graph.communities")
+
+	graph, err := s.store.LoadCommunityGraph(ctx, tenantID, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+
+	result = detectCommunities(graph, req.MaxIterations, req.MinSize)
+	result.Truncated = graph.Truncated
+	span.SetAttributes(tracing.Int("node_count", len(graph.Nodes)), tracing.Int("community_count", len(result.Communities)))
+
+	return result, nil
+}
+
+// detectCommunities runs asynchronous label propagation in node-ID order.
+// Ties keep the current label when possible, otherwise pick the smallest
+// label, so results are deterministic for a given graph.
+func detectCommunities(graph *storage.CommunityGraph, maxIterations, minSize int) *models.CommunityResult {
+	nodes := graph.Nodes
+	index := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		index[n.ID] = i
+	}
+
+	adj := make([]map[int]float64, len(nodes))
+	for i := range adj {
+		adj[i] = make(map[int]float64)
+	}
+
+	edgeCount := 0
+	for _, e := range graph.Edges {
+		a, okA := index[e.Source]
+		b, okB := index[e.Target]
+		if !okA || !okB || a == b {
+			continue
+		}
+
+		w := e.Weight
+		if w <= 0 {
+			w = 1
+		}
+
+		adj[a][b] += w
+		adj[b][a] += w
+		edgeCount++
+	}
+
+	labels := make([]int, len(nodes))
+	for i := range labels {
+		labels[i] = i
+	}
+
+	result := &models.CommunityResult{NodeCount: len(nodes), EdgeCount: edgeCount}
+
+	for result.Iterations < maxIterations {
+		result.Iterations++
+		if !propagateLabels(adj, labels) {
+			result.Converged = true
+
+			break
+		}
+	}
+
+	result.Communities = groupCommunities(nodes, labels, minSize)
+
+	return result
+}
+
+// propagateLabels performs one pass and reports whether any label changed.
+func propagateLabels(adj []map[int]float64, labels []int) bool {
+	changed := false
+
+	for i := range labels {
+		if len(adj[i]) == 0 {
+			continue
+		}
+
+		scores := make(map[int]float64, len(adj[i]))
+		maxScore := 0.0
+		for j, w := range adj[i] {
+			scores[labels[j]] += w
+			if scores[labels[j]] > maxScore {
+				maxScore = scores[labels[j]]
+			}
+		}
+
+		if scores[labels[i]] == maxScore {
+			continue
+		}
+
+		best := -1
+		for label, score := range scores {
+			if score == maxScore && (best == -1 || label < best) {
+				best = label
+			}
+		}
+
+		labels[i] = best
+		changed = true
+	}
+
+	return changed
+}
+
+func groupCommunities(nodes []storage.CommunityNode, labels []int, minSize int) []models.Community {
+	members := make(map[int][]int)
+	for i, label := range labels {
+		members[label] = append(members[label], i)
+	}
+
+	communities := make([]models.Community, 0, len(members))
+
+	for _, idxs := range members {
+		if len(idxs) < minSize {
+			continue
+		}
+
+		ids := make([]string, len(idxs))
+		leader := idxs[0]
+		for k, i := range idxs {
+			ids[k] = nodes[i].ID
+			if nodes[i].Salience > nodes[leader].Salience ||
+				(nodes[i].Salience == nodes[leader].Salience && nodes[i].ID < nodes[leader].ID) {
+				leader = i
+			}
+		}
+
+		sort.Strings(ids)
+		communities = append(communities, models.Community{
+			Label:   nodes[leader].Label,
+			Size:    len(ids),
+			NodeIDs: ids,
+		})
+	}
+
+	sort.Slice(communities, func(i, j int) bool {
+		if communities[i].Size != communities[j].Size {
+			return communities[i].Size > communities[j].Size
+		}
+
+		return communities[i].NodeIDs[0] < communities[j].NodeIDs[0]
+	})
+
+	for i := range communities {
+		communities[i].ID = i + 1
+	}
+
+	return communities
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/storage"
+)
+
+func TestDetectCommunitiesSplitsWeaklyBridgedClusters(t *testing.T) {
+	graph := &storage.CommunityGraph{
+		Nodes: []storage.CommunityNode{
+			{ID: "a1", Label: "Alpha One", Salience: 1},
+			{ID: "a2", Label: "Alpha Two", Salience: 3},
+			{ID: "a3", Label: "Alpha Three", Salience: 1},
+			{ID: "b1", Label: "Beta One", Salience: 2},
+			{ID: "b2", Label: "Beta Two", Salience: 1},
+			{ID: "b3", Label: "Beta Three", Salience: 1},
+			{ID: "c1", Label: "Loner", Salience: 1},
+			{ID: "c2", Label: "Loner Friend", Salience: 1},
+		},
+		Edges: []storage.CommunityEdge{
+			{Source: "a1", Target: "a2", Weight: 1},
+			{Source: "a2", Target: "a3", Weight: 1},
+			{Source: "a1", Target: "a3", Weight: 1},
+			{Source: "b1", Target: "b2", Weight: 1},
+			{Source: "b2", Target: "b3", Weight: 1},
+			{Source: "b1", Target: "b3", Weight: 1},
+			{Source: "a3", Target: "b1", Weight: 0.1},
+			{Source: "c1", Target: "c2", Weight: 1},
+			{Source: "a1", Target: "ghost", Weight: 1},
+		},
+	}
+
+	result := detectCommunities(graph, 20, 3)
+
+	if !result.Converged {
+		t.Fatal("expected label propagation to converge")
+	}
+	if result.NodeCount != 8 || result.EdgeCount != 8 {
+		t.Fatalf("counts = %d nodes, %d edges; want 8, 8", result.NodeCount, result.EdgeCount)
+	}
+	if len(result.Communities) != 2 {
+		t.Fatalf("communities = %d, want 2: %+v", len(result.Communities), result.Communities)
+	}
+
+	first, second := result.Communities[0], result.Communities[1]
+	if first.ID != 1 || !reflect.DeepEqual(first.NodeIDs, []string{"a1", "a2", "a3"}) || first.Label != "Alpha Two" {
+		t.Errorf("first community = %+v", first)
+	}
+	if second.ID != 2 || !reflect.DeepEqual(second.NodeIDs, []string{"b1", "b2", "b3"}) || second.Label != "Beta One" {
+		t.Errorf("second community = %+v", second)
+	}
+}
+
+func TestDetectCommunitiesHonorsMaxIterations(t *testing.T) {
+	graph := &storage.CommunityGraph{
+		Nodes: []storage.CommunityNode{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		Edges: []storage.CommunityEdge{
+			{Source: "a", Target: "b"},
+			{Source: "b", Target: "c"},
+		},
+	}
+
+	result := detectCommunities(graph, 1, 1)
+
+	if result.Iterations != 1 || result.Converged {
+		t.Fatalf("iterations = %d, converged = %v; want 1, false", result.Iterations, result.Converged)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// GraphExporter exports a tenant's whole graph.
+type GraphExporter interface {
+	Export(ctx context.Context, tenantID string) (*models.ExportFormat, error)
+}
+
+// TenantGetter looks up a tenant by ID.
+type TenantGetter interface {
+	GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+}
+
+// Compile-time check: *GraphDiffService must satisfy domain.GraphDiffService.
+var _ domain.GraphDiffService = (*GraphDiffService)(nil)
+
+// GraphDiffService compares the graphs of two tenants, e.g. a checkpoint
+// imported into a scratch tenant against the live one.
+type GraphDiffService struct {
+	exports GraphExporter
+	tenants TenantGetter
+}
+
+// NewGraphDiffService creates a GraphDiffService.
+func NewGraphDiffService(exports GraphExporter, tenants TenantGetter) *GraphDiffService {
+	return &GraphDiffService{exports: exports, tenants: tenants}
+}
+
+// DiffTenants reports what the right tenant's graph adds, removes, and
+// changes relative to the left one. It returns models.ErrTenantNotFound when
+// either tenant does not exist.
+func (s *GraphDiffService) DiffTenants(
+	ctx context.Context, leftTenantID, rightTenantID string,
+) (_ *models.GraphDiff, err error) {
+	ctx, span := startSpan(ctx, "GraphDiffService.DiffTenants", leftTenantID,
+		tracing.String("right_tenant_id", rightTenantID))
+	defer endSpan(span, &err)
+
+	graphs := make([]*models.ExportFormat, 0, 2)
+
+	for _, tenantID := range []string{leftTenantID, rightTenantID} {
+		if _, err := s.tenants.GetTenant(ctx, tenantID); err != nil {
+			return nil, err
+		}
+
+		g, err := s.exports.Export(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("exporting tenant %s: %w", tenantID, err)
+		}
+
+		graphs = append(graphs, g)
+	}
+
+	d := models.DiffGraphs(graphs[0], graphs[1])
+	d.Left, d.Right = leftTenantID, rightTenantID
+
+	return d, nil
+}
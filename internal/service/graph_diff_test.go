@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockGraphExporter map[string]*models.ExportFormat
+
+func (m mockGraphExporter) Export(_ context.Context, tenantID string) (*models.ExportFormat, error) {
+	return m[tenantID], nil
+}
+
+func (m mockGraphExporter) GetTenant(_ context.Context, tenantID string) (*models.Tenant, error) {
+	if _, ok := m[tenantID]; !ok {
+		return nil, models.ErrTenantNotFound
+	}
+
+	return &models.Tenant{ID: tenantID}, nil
+}
+
+func TestGraphDiffService_DiffTenants(t *testing.T) {
+	graphs := mockGraphExporter{
+		"t1": {Nodes: []models.ExportNode{{ID: "alice", Label: "Alice"}}},
+		"t2": {Nodes: []models.ExportNode{{ID: "alice", Label: "Alice"}, {ID: "bob", Label: "Bob"}}},
+	}
+	s := NewGraphDiffService(graphs, graphs)
+
+	d, err := s.DiffTenants(context.Background(), "t1", "t2")
+	if err != nil {
+		t.Fatalf("DiffTenants: %v", err)
+	}
+	if d.Left != "t1" || d.Right != "t2" || d.Stats.NodesAdded != 1 || d.NodesAdded[0].ID != "bob" {
+		t.Errorf("diff = %+v, want bob added", d)
+	}
+
+	if _, err := s.DiffTenants(context.Background(), "t1", "missing"); !errors.Is(err, models.ErrTenantNotFound) {
+		t.Errorf("error = %v, want ErrTenantNotFound", err)
+	}
+}
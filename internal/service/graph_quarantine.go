@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// WithQuarantine leaves quarantined nodes out of the reads meant for a
+// model's prompt: GraphContext, Summary, and Subgraph. A quarantined start
+// node reads as not found; quarantined neighbors are dropped with their
+// edges.
+func (s *GraphService) WithQuarantine(quarantine QuarantineChecker) *GraphService {
+	s.quarantine = quarantine
+	return s
+}
+
+// quarantined returns which of nodeIDs are quarantined; none when no
+// checker is set.
+func (s *GraphService) quarantined(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error) {
+	if s.quarantine == nil || len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	return s.quarantine.QuarantinedNodeIDs(ctx, tenantID, nodeIDs)
+}
+
+// filterContext drops quarantined neighbors and their edges from result.
+func (s *GraphService) filterContext(ctx context.Context, tenantID string, result *models.ContextResult) error {
+	quarantined, err := s.quarantined(ctx, tenantID, append(nodeIDs(result.Neighbors), result.Node.ID))
+	if err != nil {
+		return err
+	}
+
+	if quarantined[result.Node.ID] {
+		return models.ErrNodeNotFound
+	}
+
+	result.Neighbors = dropQuarantinedNodes(result.Neighbors, quarantined)
+	result.Edges = dropQuarantinedEdges(result.Edges, quarantined)
+
+	return nil
+}
+
+// filterSummary drops quarantined neighbors from summary. Relation counts
+// and degree still include them, since they carry no node text.
+func (s *GraphService) filterSummary(ctx context.Context, tenantID string, summary *models.GraphSummary) error {
+	ids := make([]string, 0, len(summary.TopNeighbors)+1)
+	for _, n := range summary.TopNeighbors {
+		ids = append(ids, n.ID)
+	}
+
+	quarantined, err := s.quarantined(ctx, tenantID, append(ids, summary.Node.ID))
+	if err != nil {
+		return err
+	}
+
+	if quarantined[summary.Node.ID] {
+		return models.ErrNodeNotFound
+	}
+
+	summary.TopNeighbors = slices.DeleteFunc(summary.TopNeighbors, func(n models.SummaryNeighbor) bool { return quarantined[n.ID] })
+
+	return nil
+}
+
+// filterSubgraph drops quarantined nodes and their edges from result.
+func (s *GraphService) filterSubgraph(ctx context.Context, tenantID string, result *models.SubgraphResult) error {
+	quarantined, err := s.quarantined(ctx, tenantID, nodeIDs(result.Nodes))
+	if err != nil {
+		return err
+	}
+
+	result.Nodes = dropQuarantinedNodes(result.Nodes, quarantined)
+	result.Edges = dropQuarantinedEdges(result.Edges, quarantined)
+
+	return nil
+}
+
+func nodeIDs(nodes []models.Node) []string {
+	ids := make([]string, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+
+	return ids
+}
+
+func dropQuarantinedNodes(nodes []models.Node, quarantined map[string]bool) []models.Node {
+	return slices.DeleteFunc(nodes, func(n models.Node) bool { return quarantined[n.ID] })
+}
+
+func dropQuarantinedEdges(edges []models.Edge, quarantined map[string]bool) []models.Edge {
+	return slices.DeleteFunc(edges, func(e models.Edge) bool { return quarantined[e.Source] || quarantined[e.Target] })
+}
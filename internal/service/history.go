@@ -27,17 +27,55 @@ func NewHistoryService(store HistoryStore, log *logrus.Logger) *HistoryService {
 	return &HistoryService{store: store, log: log}
 }
 
-// GetPropertyHistory returns property change history for a node with optional key filter.
+// GetPropertyHistory returns change history for a node with optional property
+// key and field filters.
 func (s *HistoryService) GetPropertyHistory(
-	ctx context.Context, tenantID, nodeID, propertyKey string, limit, offset int,
+	ctx context.Context, tenantID, nodeID, propertyKey, field string, limit, offset int,
 ) ([]models.PropertyChange, bool, error) {
 	s.log.WithFields(logrus.Fields{
 		"tenant_id":    tenantID,
 		"node_id":      nodeID,
 		"property_key": propertyKey,
+		"field":        field,
 		"limit":        limit,
 		"offset":       offset,
 	}).Debug("history.get_property_history")
 
-	return s.store.GetPropertyHistory(ctx, tenantID, nodeID, propertyKey, limit, offset)
+	return s.store.GetPropertyHistory(ctx, tenantID, nodeID, propertyKey, field, limit, offset)
+}
+
+// GetEdgePropertyHistory returns property change history for an edge with optional key filter.
+func (s *HistoryService) GetEdgePropertyHistory(
+	ctx context.Context, tenantID, source, target, relation, propertyKey string, limit, offset int,
+) ([]models.EdgePropertyChange, bool, error) {
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":    tenantID,
+		"source":       source,
+		"target":       target,
+		"relation":     relation,
+		"property_key": propertyKey,
+		"limit":        limit,
+		"offset":       offset,
+	}).Debug("history.get_edge_property_history")
+
+	return s.store.GetEdgePropertyHistory(ctx, tenantID, source, target, relation, propertyKey, limit, offset)
+}
+
+// ListHistory returns node changes across the tenant matching opts.
+func (s *HistoryService) ListHistory(
+	ctx context.Context, tenantID string, opts models.HistoryListOpts,
+) ([]models.PropertyChange, bool, error) {
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":    tenantID,
+		"since":        opts.Since,
+		"until":        opts.Until,
+		"property_key": opts.PropertyKey,
+		"field":        opts.Field,
+		"actor":        opts.Actor,
+		"reason":       opts.Reason,
+		"limit":        opts.Limit,
+		"offset":       opts.Offset,
+	}).Debug("history.list")
+
+	return s.store.ListHistory(ctx, tenantID, opts)
 }
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// historyPruneInterval is how often HistoryPruner.Run applies every tenant's
+// retention policy.
+const historyPruneInterval = 6 * time.Hour
+
+// HistoryPruneStore is the data-access interface HistoryPruner depends on.
+type HistoryPruneStore interface {
+	GetHistoryRetention(ctx context.Context, tenantID string) (*models.HistoryRetention, error)
+	SetHistoryRetention(ctx context.Context, tenantID string, r models.HistoryRetention) (*models.HistoryRetention, error)
+	ListHistoryRetentions(ctx context.Context) ([]models.TenantHistoryRetention, error)
+	PruneHistory(ctx context.Context, tenantID string, before time.Time) (int, error)
+	CompactHistory(ctx context.Context, tenantID string, before time.Time) (int, error)
+}
+
+// Compile-time check: *HistoryPruner must satisfy domain.HistoryRetentionService.
+var _ domain.HistoryRetentionService = (*HistoryPruner)(nil)
+
+// HistoryPruner applies per-tenant property history retention policies, both
+// on demand and periodically from Run.
+type HistoryPruner struct {
+	store HistoryPruneStore
+	log   *logrus.Logger
+	now   func() time.Time
+}
+
+// NewHistoryPruner creates a HistoryPruner.
+func NewHistoryPruner(store HistoryPruneStore, log *logrus.Logger) *HistoryPruner {
+	return &HistoryPruner{store: store, log: log, now: time.Now}
+}
+
+// GetHistoryRetention returns the tenant's history retention policy.
+func (p *HistoryPruner) GetHistoryRetention(ctx context.Context, tenantID string) (*models.HistoryRetention, error) {
+	return p.store.GetHistoryRetention(ctx, tenantID)
+}
+
+// SetHistoryRetention validates and replaces the tenant's history retention
+// policy. It takes effect on the next pruning run.
+func (p *HistoryPruner) SetHistoryRetention(
+	ctx context.Context, tenantID string, r models.HistoryRetention,
+) (*models.HistoryRetention, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"tenant_id":          tenantID,
+		"retention_days":     r.RetentionDays,
+		"compact_after_days": r.CompactAfterDays,
+	}).Debug("history.set_retention")
+
+	return p.store.SetHistoryRetention(ctx, tenantID, r)
+}
+
+// PruneTenant applies the tenant's current retention policy now.
+func (p *HistoryPruner) PruneTenant(ctx context.Context, tenantID string) (*models.HistoryPruneResult, error) {
+	r, err := p.store.GetHistoryRetention(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.prune(ctx, tenantID, *r)
+}
+
+// Run applies every tenant's retention policy on startup and then every
+// historyPruneInterval until ctx is cancelled.
+func (p *HistoryPruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(historyPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		p.pruneAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pruneAll prunes each tenant with a retention policy. A failure for one
+// tenant is logged and does not stop the others.
+func (p *HistoryPruner) pruneAll(ctx context.Context) {
+	retentions, err := p.store.ListHistoryRetentions(ctx)
+	if err != nil {
+		p.log.WithError(err).Warn("listing history retention policies")
+		return
+	}
+
+	for _, r := range retentions {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if _, err := p.prune(ctx, r.TenantID, r.HistoryRetention); err != nil {
+			p.log.WithError(err).WithField("tenant_id", r.TenantID).Warn("pruning property history")
+		}
+	}
+}
+
+// prune deletes history past the retention period, then compacts history past
+// the compaction period. Deleting first avoids compacting rows about to go.
+func (p *HistoryPruner) prune(
+	ctx context.Context, tenantID string, r models.HistoryRetention,
+) (*models.HistoryPruneResult, error) {
+	var result models.HistoryPruneResult
+
+	now := p.now()
+
+	if r.RetentionDays != nil {
+		deleted, err := p.store.PruneHistory(ctx, tenantID, daysBefore(now, *r.RetentionDays))
+		result.Deleted = deleted
+
+		if err != nil {
+			return &result, err
+		}
+	}
+
+	if r.CompactAfterDays != nil {
+		compacted, err := p.store.CompactHistory(ctx, tenantID, daysBefore(now, *r.CompactAfterDays))
+		result.Compacted = compacted
+
+		if err != nil {
+			return &result, err
+		}
+	}
+
+	if result.Deleted > 0 || result.Compacted > 0 {
+		p.log.WithFields(logrus.Fields{
+			"tenant_id": tenantID,
+			"deleted":   result.Deleted,
+			"compacted": result.Compacted,
+		}).Info("history.prune")
+	}
+
+	return &result, nil
+}
+
+func daysBefore(t time.Time, days int) time.Time {
+	return t.AddDate(0, 0, -days)
+}
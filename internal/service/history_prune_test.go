@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockHistoryPruneStore struct {
+	retentions  []models.TenantHistoryRetention
+	pruned      map[string]time.Time
+	compacted   map[string]time.Time
+	pruneErrFor string
+}
+
+func (m *mockHistoryPruneStore) GetHistoryRetention(_ context.Context, tenantID string) (*models.HistoryRetention, error) {
+	for _, r := range m.retentions {
+		if r.TenantID == tenantID {
+			return &r.HistoryRetention, nil
+		}
+	}
+
+	return nil, models.ErrTenantNotFound
+}
+
+func (m *mockHistoryPruneStore) SetHistoryRetention(_ context.Context, tenantID string, r models.HistoryRetention) (*models.HistoryRetention, error) {
+	m.retentions = append(m.retentions, models.TenantHistoryRetention{TenantID: tenantID, HistoryRetention: r})
+	return &r, nil
+}
+
+func (m *mockHistoryPruneStore) ListHistoryRetentions(_ context.Context) ([]models.TenantHistoryRetention, error) {
+	return m.retentions, nil
+}
+
+func (m *mockHistoryPruneStore) PruneHistory(_ context.Context, tenantID string, before time.Time) (int, error) {
+	if tenantID == m.pruneErrFor {
+		return 0, errors.New("prune failed")
+	}
+
+	m.pruned[tenantID] = before
+	return 10, nil
+}
+
+func (m *mockHistoryPruneStore) CompactHistory(_ context.Context, tenantID string, before time.Time) (int, error) {
+	m.compacted[tenantID] = before
+	return 4, nil
+}
+
+func newTestHistoryPruner(store *mockHistoryPruneStore, now time.Time) *HistoryPruner {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	p := NewHistoryPruner(store, log)
+	p.now = func() time.Time { return now }
+
+	return p
+}
+
+func intPtr(v int) *int { return &v }
+
+func TestHistoryPruner_PruneTenant(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := &mockHistoryPruneStore{
+		retentions: []models.TenantHistoryRetention{
+			{TenantID: "t1", HistoryRetention: models.HistoryRetention{RetentionDays: intPtr(90), CompactAfterDays: intPtr(7)}},
+			{TenantID: "t2", HistoryRetention: models.HistoryRetention{CompactAfterDays: intPtr(30)}},
+		},
+		pruned:    map[string]time.Time{},
+		compacted: map[string]time.Time{},
+	}
+	p := newTestHistoryPruner(store, now)
+
+	result, err := p.PruneTenant(context.Background(), "t1")
+	if err != nil {
+		t.Fatalf("PruneTenant: %v", err)
+	}
+	if result.Deleted != 10 || result.Compacted != 4 {
+		t.Errorf("result = %+v, want 10 deleted, 4 compacted", result)
+	}
+	if want := now.AddDate(0, 0, -90); !store.pruned["t1"].Equal(want) {
+		t.Errorf("prune cutoff = %s, want %s", store.pruned["t1"], want)
+	}
+	if want := now.AddDate(0, 0, -7); !store.compacted["t1"].Equal(want) {
+		t.Errorf("compact cutoff = %s, want %s", store.compacted["t1"], want)
+	}
+
+	result, err = p.PruneTenant(context.Background(), "t2")
+	if err != nil {
+		t.Fatalf("PruneTenant: %v", err)
+	}
+	if _, ok := store.pruned["t2"]; ok || result.Deleted != 0 {
+		t.Errorf("t2 has no retention period but was pruned: %+v", result)
+	}
+
+	if _, err := p.PruneTenant(context.Background(), "missing"); !errors.Is(err, models.ErrTenantNotFound) {
+		t.Errorf("missing tenant: err = %v, want ErrTenantNotFound", err)
+	}
+}
+
+func TestHistoryPruner_PruneAllContinuesPastFailures(t *testing.T) {
+	store := &mockHistoryPruneStore{
+		retentions: []models.TenantHistoryRetention{
+			{TenantID: "t1", HistoryRetention: models.HistoryRetention{RetentionDays: intPtr(30)}},
+			{TenantID: "t2", HistoryRetention: models.HistoryRetention{RetentionDays: intPtr(30)}},
+		},
+		pruned:      map[string]time.Time{},
+		compacted:   map[string]time.Time{},
+		pruneErrFor: "t1",
+	}
+	p := newTestHistoryPruner(store, time.Now())
+
+	p.pruneAll(context.Background())
+
+	if _, ok := store.pruned["t2"]; !ok {
+		t.Error("t2 was not pruned after t1 failed")
+	}
+}
+
+func TestHistoryPruner_SetValidates(t *testing.T) {
+	store := &mockHistoryPruneStore{}
+	p := newTestHistoryPruner(store, time.Now())
+
+	_, err := p.SetHistoryRetention(context.Background(), "t1", models.HistoryRetention{
+		RetentionDays:    intPtr(10),
+		CompactAfterDays: intPtr(20),
+	})
+	if err == nil {
+		t.Fatal("expected error when compaction starts after retention")
+	}
+	if len(store.retentions) != 0 {
+		t.Error("invalid retention was stored")
+	}
+}
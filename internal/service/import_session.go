@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ImportSessionStore is the data-access interface ImportSessions depends on.
+type ImportSessionStore interface {
+	CreateImportSession(
+		ctx context.Context, tenantID string, schemaVersion int, opts models.ImportOptions, expiresAt time.Time,
+	) (*models.ImportSession, error)
+	GetImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportSession, error)
+	PutImportChunk(ctx context.Context, tenantID, sessionID string, seq int, chunk *models.ImportChunk) error
+	LoadImportChunks(ctx context.Context, tenantID, sessionID string) (*models.ImportChunk, error)
+	CommitImportSession(
+		ctx context.Context, tenantID, sessionID string, data *models.ImportChunk, overwrite bool,
+	) (*models.ImportResult, error)
+	DeleteImportSession(ctx context.Context, tenantID, sessionID string) error
+	ExistingNodeIDs(ctx context.Context, tenantID string, ids []string) (map[string]struct{}, error)
+}
+
+// Compile-time check: *ImportSessions must satisfy domain.ImportSessionService.
+var _ domain.ImportSessionService = (*ImportSessions)(nil)
+
+// ImportSessions implements chunked, resumable imports. Chunks are staged
+// and validated as they arrive; commit validates edge endpoints across the
+// whole session and applies everything in one transaction.
+type ImportSessions struct {
+	store   ImportSessionStore
+	scanner NodeScanner
+	log     *logrus.Logger
+	now     func() time.Time
+}
+
+// NewImportSessions creates an ImportSessions service.
+func NewImportSessions(store ImportSessionStore, log *logrus.Logger) *ImportSessions {
+	return &ImportSessions{store: store, log: log, now: time.Now}
+}
+
+// WithScanner checks every node a commit writes, such as for prompt
+// injection.
+func (s *ImportSessions) WithScanner(scanner NodeScanner) *ImportSessions {
+	s.scanner = scanner
+	return s
+}
+
+// CreateImportSession opens a session for an export of the given schema version.
+func (s *ImportSessions) CreateImportSession(
+	ctx context.Context, tenantID string, req models.CreateImportSessionRequest,
+) (*models.ImportSession, error) {
+	if req.SchemaVersion > db.SchemaVersion() {
+		return nil, models.ErrImportSchemaTooNew
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":      tenantID,
+		"schema_version": req.SchemaVersion,
+	}).Debug("import.create_session")
+
+	return s.store.CreateImportSession(ctx, tenantID, req.SchemaVersion, req.Options, s.now().Add(models.ImportSessionTTL))
+}
+
+// GetImportSession returns a session and the chunks it has received.
+func (s *ImportSessions) GetImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportSession, error) {
+	return s.store.GetImportSession(ctx, tenantID, sessionID)
+}
+
+// PutImportChunk validates a chunk on its own and stages it. Invalid chunks
+// are not staged; their errors are returned instead.
+func (s *ImportSessions) PutImportChunk(
+	ctx context.Context, tenantID, sessionID string, seq int, chunk *models.ImportChunk,
+) ([]string, error) {
+	if errs := chunk.Validate(); len(errs) > 0 {
+		return errs, nil
+	}
+
+	if err := s.store.PutImportChunk(ctx, tenantID, sessionID, seq, chunk); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// CommitImportSession validates the staged chunks as a whole and applies
+// them. Validation failures are reported in the result's Errors and leave the
+// session open so the offending chunks can be re-uploaded. A dry-run session
+// only reports what would be created.
+func (s *ImportSessions) CommitImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportResult, error) {
+	sess, err := s.store.GetImportSession(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sess.Status != models.ImportSessionOpen {
+		return nil, models.ErrImportSessionClosed
+	}
+
+	data, err := s.store.LoadImportChunks(ctx, tenantID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading import chunks: %w", err)
+	}
+
+	errs := validateNodes(data.Nodes)
+
+	exportNodeIDs := buildNodeIDSet(data.Nodes)
+	idsToCheck := referencedDBNodeIDs(data.Edges, exportNodeIDs)
+	dbNodeIDs := map[string]struct{}{}
+	if len(idsToCheck) > 0 {
+		dbNodeIDs, err = s.store.ExistingNodeIDs(ctx, tenantID, idsToCheck)
+		if err != nil {
+			return nil, fmt.Errorf("fetching existing node IDs for validation: %w", err)
+		}
+	}
+
+	errs = append(errs, validateEdges(data.Edges, exportNodeIDs, dbNodeIDs)...)
+	if len(errs) > 0 {
+		return &models.ImportResult{Errors: errs}, nil
+	}
+
+	if sess.Options.DryRun {
+		return &models.ImportResult{NodesCreated: len(data.Nodes), EdgesCreated: len(data.Edges)}, nil
+	}
+
+	for i := range data.Nodes {
+		data.Nodes[i] = applyNodeOptions(data.Nodes[i], sess.Options)
+	}
+
+	for i := range data.Edges {
+		data.Edges[i] = applyEdgeOptions(data.Edges[i], sess.Options)
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":  tenantID,
+		"session_id": sessionID,
+		"nodes":      len(data.Nodes),
+		"edges":      len(data.Edges),
+	}).Debug("import.commit_session")
+
+	// Without overwrite, nodes that already exist are skipped, so only the
+	// others are scanned.
+	skipped := map[string]struct{}{}
+	if s.scanner != nil && !sess.Options.OverwriteExisting {
+		skipped, err = s.store.ExistingNodeIDs(ctx, tenantID, slices.Collect(maps.Keys(exportNodeIDs)))
+		if err != nil {
+			return nil, fmt.Errorf("fetching existing node IDs to scan: %w", err)
+		}
+	}
+
+	result, err := s.store.CommitImportSession(ctx, tenantID, sessionID, data, sess.Options.OverwriteExisting)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range data.Nodes {
+		if _, ok := skipped[data.Nodes[i].ID]; !ok {
+			scanExportNode(ctx, s.scanner, tenantID, &data.Nodes[i])
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteImportSession aborts a session and discards its chunks.
+func (s *ImportSessions) DeleteImportSession(ctx context.Context, tenantID, sessionID string) error {
+	return s.store.DeleteImportSession(ctx, tenantID, sessionID)
+}
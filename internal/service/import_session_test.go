@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockImportSessionStore struct {
+	session   models.ImportSession
+	chunks    map[int]*models.ImportChunk
+	existing  map[string]struct{}
+	committed *models.ImportChunk
+}
+
+func (m *mockImportSessionStore) CreateImportSession(
+	_ context.Context, _ string, schemaVersion int, opts models.ImportOptions, expiresAt time.Time,
+) (*models.ImportSession, error) {
+	m.session = models.ImportSession{ID: "s1", Status: models.ImportSessionOpen, SchemaVersion: schemaVersion, Options: opts, ExpiresAt: expiresAt}
+	return &m.session, nil
+}
+
+func (m *mockImportSessionStore) GetImportSession(_ context.Context, _, sessionID string) (*models.ImportSession, error) {
+	if sessionID != m.session.ID {
+		return nil, models.ErrImportSessionNotFound
+	}
+	return &m.session, nil
+}
+
+func (m *mockImportSessionStore) PutImportChunk(_ context.Context, _, _ string, seq int, chunk *models.ImportChunk) error {
+	m.chunks[seq] = chunk
+	return nil
+}
+
+func (m *mockImportSessionStore) LoadImportChunks(_ context.Context, _, _ string) (*models.ImportChunk, error) {
+	all := &models.ImportChunk{}
+	for seq := 0; seq < len(m.chunks); seq++ {
+		all.Nodes = append(all.Nodes, m.chunks[seq].Nodes...)
+		all.Edges = append(all.Edges, m.chunks[seq].Edges...)
+	}
+	return all, nil
+}
+
+func (m *mockImportSessionStore) CommitImportSession(
+	_ context.Context, _, _ string, data *models.ImportChunk, _ bool,
+) (*models.ImportResult, error) {
+	m.committed = data
+	m.session.Status = models.ImportSessionCommitted
+	return &models.ImportResult{NodesCreated: len(data.Nodes), EdgesCreated: len(data.Edges)}, nil
+}
+
+func (m *mockImportSessionStore) DeleteImportSession(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (m *mockImportSessionStore) ExistingNodeIDs(_ context.Context, _ string, _ []string) (map[string]struct{}, error) {
+	return m.existing, nil
+}
+
+func newTestImportSessions(t *testing.T, opts models.ImportOptions) (*ImportSessions, *mockImportSessionStore) {
+	t.Helper()
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	store := &mockImportSessionStore{chunks: map[int]*models.ImportChunk{}, existing: map[string]struct{}{}}
+	svc := NewImportSessions(store, log)
+	if _, err := svc.CreateImportSession(context.Background(), "t1", models.CreateImportSessionRequest{Options: opts}); err != nil {
+		t.Fatalf("CreateImportSession: %v", err)
+	}
+
+	return svc, store
+}
+
+func TestImportSessions_RejectsInvalidChunk(t *testing.T) {
+	svc, store := newTestImportSessions(t, models.ImportOptions{})
+
+	errs, err := svc.PutImportChunk(context.Background(), "t1", "s1", 0, &models.ImportChunk{
+		Nodes: []models.ExportNode{{ID: ""}},
+	})
+	if err != nil || len(errs) != 1 {
+		t.Fatalf("PutImportChunk = %v, %v; want one validation error", errs, err)
+	}
+	if len(store.chunks) != 0 {
+		t.Error("invalid chunk should not be staged")
+	}
+}
+
+func TestImportSessions_Commit(t *testing.T) {
+	svc, store := newTestImportSessions(t, models.ImportOptions{ResetUsage: true})
+	ctx := context.Background()
+
+	// The edge refers to a node in a later chunk, and to one missing entirely.
+	chunks := []*models.ImportChunk{
+		{Edges: []models.ExportEdge{{Source: "a", Target: "ghost", Relation: "knows"}}},
+		{Nodes: []models.ExportNode{{ID: "a", AccessCount: 5}}},
+	}
+	for seq, c := range chunks {
+		if errs, err := svc.PutImportChunk(ctx, "t1", "s1", seq, c); err != nil || len(errs) > 0 {
+			t.Fatalf("PutImportChunk(%d) = %v, %v", seq, errs, err)
+		}
+	}
+
+	result, err := svc.CommitImportSession(ctx, "t1", "s1")
+	if err != nil || len(result.Errors) != 1 || store.committed != nil {
+		t.Fatalf("commit with dangling edge = %+v, %v; want one error and nothing committed", result, err)
+	}
+
+	store.existing["ghost"] = struct{}{}
+	result, err = svc.CommitImportSession(ctx, "t1", "s1")
+	if err != nil || len(result.Errors) != 0 || result.NodesCreated != 1 || result.EdgesCreated != 1 {
+		t.Fatalf("commit = %+v, %v; want 1 node and 1 edge", result, err)
+	}
+	if store.committed.Nodes[0].AccessCount != 0 {
+		t.Error("reset_usage should be applied to committed nodes")
+	}
+
+	if _, err := svc.CommitImportSession(ctx, "t1", "s1"); !errors.Is(err, models.ErrImportSessionClosed) {
+		t.Errorf("second commit: err = %v, want ErrImportSessionClosed", err)
+	}
+}
+
+func TestImportSessions_RejectsNewerSchema(t *testing.T) {
+	svc, _ := newTestImportSessions(t, models.ImportOptions{})
+
+	_, err := svc.CreateImportSession(context.Background(), "t1", models.CreateImportSessionRequest{SchemaVersion: 1 << 20})
+	if !errors.Is(err, models.ErrImportSchemaTooNew) {
+		t.Errorf("err = %v, want ErrImportSchemaTooNew", err)
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// IndexAdvisorStore reads statement statistics and kg_nodes indexes and
+// creates advised indexes.
+type IndexAdvisorStore interface {
+	NodeStatementStats(ctx context.Context) ([]models.StatementStats, bool, error)
+	NodeIndexes(ctx context.Context) ([]models.IndexInfo, error)
+	ExtensionInstalled(ctx context.Context, name string) (bool, error)
+	CreateNodeIndex(ctx context.Context, rec models.IndexRecommendation) error
+}
+
+// Compile-time check: *IndexAdvisorService must satisfy domain.IndexAdvisorService.
+var _ domain.IndexAdvisorService = (*IndexAdvisorService)(nil)
+
+// Patterns for the node filters in a normalized statement's WHERE clause.
+var (
+	whereKeyword = regexp.MustCompile(`(?i)\bWHERE\b`)
+	typeFilter   = regexp.MustCompile(`(?i)(?:^|\W)type\s*(?:=|<>|!=|IN\s*\()`)
+	propsFilter  = regexp.MustCompile(`(?i)\bsearch_props\s*(?:@>|\?)`)
+)
+
+// advisedIndexes maps each filter combination, filters sorted and joined
+// with "+", to the index that serves it. The single-filter indexes are the
+// ones the migrations create, so dropping one is noticed.
+var advisedIndexes = map[string]models.IndexRecommendation{
+	models.IndexFilterType: {
+		Name: "idx_nodes_tenant_type", Method: "btree", Columns: []string{"tenant_id", "type"},
+	},
+	models.IndexFilterProps: {
+		Name: "idx_nodes_search_props", Method: "gin", Columns: []string{"search_props"},
+	},
+	models.IndexFilterProps + "+" + models.IndexFilterType: {
+		Name: "idx_nodes_tenant_type_props", Method: "gin", Columns: []string{"tenant_id", "type", "search_props"},
+		Requires: "btree_gin",
+	},
+}
+
+// IndexAdvisorService recommends kg_nodes indexes for the type and props
+// (tag) filter combinations that pg_stat_statements shows are frequent and
+// slow, and builds them in the background on request, one run at a time per
+// server.
+type IndexAdvisorService struct {
+	store IndexAdvisorStore
+	log   *logrus.Logger
+
+	mu        sync.Mutex
+	building  bool
+	lastError string
+}
+
+// NewIndexAdvisorService creates an IndexAdvisorService.
+func NewIndexAdvisorService(store IndexAdvisorStore, log *logrus.Logger) *IndexAdvisorService {
+	return &IndexAdvisorService{store: store, log: log}
+}
+
+// AdviseIndexes groups the recorded kg_nodes statements by the filters they
+// use, most total time first, and recommends an index for each combination
+// no existing index covers that reaches both thresholds in req.
+func (s *IndexAdvisorService) AdviseIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	req = req.WithDefaults()
+
+	stats, ok, err := s.store.NodeStatementStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading statement statistics: %w", err)
+	}
+
+	report := &models.IndexAdvisorReport{StatsAvailable: ok, Advice: []models.IndexAdvice{}}
+
+	s.mu.Lock()
+	report.Building, report.LastError = s.building, s.lastError
+	s.mu.Unlock()
+
+	if !ok {
+		return report, nil
+	}
+
+	indexes, err := s.store.NodeIndexes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading kg_nodes indexes: %w", err)
+	}
+
+	for _, advice := range groupByFilters(stats) {
+		rec := advisedIndexes[strings.Join(advice.Filters, "+")]
+
+		if i := slices.IndexFunc(indexes, func(idx models.IndexInfo) bool { return indexCovers(idx, rec) }); i >= 0 {
+			advice.CoveredBy = indexes[i].Name
+		} else if advice.Calls >= req.MinCalls && advice.MeanTimeMs >= req.MinMeanMs {
+			if err := s.checkRequires(ctx, &rec); err != nil {
+				return nil, err
+			}
+
+			advice.Recommended = &rec
+			advice.Definition = rec.Definition()
+		}
+
+		report.Advice = append(report.Advice, advice)
+	}
+
+	return report, nil
+}
+
+// checkRequires clears rec.Requires when the extension it names is
+// installed.
+func (s *IndexAdvisorService) checkRequires(ctx context.Context, rec *models.IndexRecommendation) error {
+	if rec.Requires == "" {
+		return nil
+	}
+
+	installed, err := s.store.ExtensionInstalled(ctx, rec.Requires)
+	if err != nil {
+		return err
+	}
+
+	if installed {
+		rec.Requires = ""
+	}
+
+	return nil
+}
+
+// CreateAdvisedIndexes starts building, in the background, every index
+// AdviseIndexes recommends whose extensions are installed, and returns the
+// report they came from. It returns models.ErrIndexBuilding while an earlier
+// run is still building.
+func (s *IndexAdvisorService) CreateAdvisedIndexes(ctx context.Context, req models.IndexAdvisorRequest) (*models.IndexAdvisorReport, error) {
+	report, err := s.AdviseIndexes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	recs := report.Buildable()
+	if len(recs) == 0 {
+		return report, nil
+	}
+
+	s.mu.Lock()
+	if s.building {
+		s.mu.Unlock()
+		return nil, models.ErrIndexBuilding
+	}
+	s.building, s.lastError = true, ""
+	s.mu.Unlock()
+
+	report.Building, report.LastError = true, ""
+
+	go s.build(context.WithoutCancel(ctx), recs)
+
+	return report, nil
+}
+
+func (s *IndexAdvisorService) build(ctx context.Context, recs []models.IndexRecommendation) {
+	var err error
+
+	for _, rec := range recs {
+		log := s.log.WithField("index", rec.Name)
+		log.Info("creating advised index")
+
+		if err = s.store.CreateNodeIndex(ctx, rec); err != nil {
+			log.WithError(err).Error("creating advised index")
+			break
+		}
+
+		log.Info("advised index created")
+	}
+
+	s.mu.Lock()
+	s.building = false
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+}
+
+// groupByFilters sums the statements that filter nodes by the same
+// combination, most total time first. Statements with no recognized filter
+// are left out.
+func groupByFilters(stats []models.StatementStats) []models.IndexAdvice {
+	byKey := map[string]*models.IndexAdvice{}
+
+	for _, st := range stats {
+		filters := nodeFilters(st.Query)
+		if len(filters) == 0 {
+			continue
+		}
+
+		key := strings.Join(filters, "+")
+
+		advice, ok := byKey[key]
+		if !ok {
+			advice = &models.IndexAdvice{Filters: filters}
+			byKey[key] = advice
+		}
+
+		advice.Statements++
+		advice.Calls += st.Calls
+		advice.TotalTimeMs += st.TotalTimeMs
+	}
+
+	out := make([]models.IndexAdvice, 0, len(byKey))
+	for _, advice := range byKey {
+		if advice.Calls > 0 {
+			advice.MeanTimeMs = advice.TotalTimeMs / float64(advice.Calls)
+		}
+
+		out = append(out, *advice)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].TotalTimeMs > out[j].TotalTimeMs })
+
+	return out
+}
+
+// nodeFilters returns the recognized node filters in query's WHERE clause,
+// sorted.
+func nodeFilters(query string) []string {
+	loc := whereKeyword.FindStringIndex(query)
+	if loc == nil {
+		return nil
+	}
+
+	where := query[loc[1]:]
+
+	var filters []string
+	if propsFilter.MatchString(where) {
+		filters = append(filters, models.IndexFilterProps)
+	}
+
+	if typeFilter.MatchString(where) {
+		filters = append(filters, models.IndexFilterType)
+	}
+
+	return filters
+}
+
+// indexCovers reports whether idx serves the filters rec is for. The leading
+// tenant_id is optional: a btree index must start with rec's other columns,
+// and a GIN index must include them in any order.
+func indexCovers(idx models.IndexInfo, rec models.IndexRecommendation) bool {
+	if idx.Method != rec.Method {
+		return false
+	}
+
+	want := slices.DeleteFunc(slices.Clone(rec.Columns), func(c string) bool { return c == "tenant_id" })
+
+	cols := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		cols[i] = strings.Trim(c, `"`)
+	}
+
+	if rec.Method != "btree" {
+		return !slices.ContainsFunc(want, func(c string) bool { return !slices.Contains(cols, c) })
+	}
+
+	if len(cols) > 0 && cols[0] == "tenant_id" {
+		cols = cols[1:]
+	}
+
+	return len(cols) >= len(want) && slices.Equal(cols[:len(want)], want)
+}
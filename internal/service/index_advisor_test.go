@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockIndexAdvisorStore struct {
+	stats      []models.StatementStats
+	available  bool
+	indexes    []models.IndexInfo
+	extensions []string
+	release    chan struct{}
+	built      chan models.IndexRecommendation
+	err        error
+}
+
+func (m *mockIndexAdvisorStore) NodeStatementStats(context.Context) ([]models.StatementStats, bool, error) {
+	return m.stats, m.available, nil
+}
+
+func (m *mockIndexAdvisorStore) NodeIndexes(context.Context) ([]models.IndexInfo, error) {
+	return m.indexes, nil
+}
+
+func (m *mockIndexAdvisorStore) ExtensionInstalled(_ context.Context, name string) (bool, error) {
+	return slices.Contains(m.extensions, name), nil
+}
+
+func (m *mockIndexAdvisorStore) CreateNodeIndex(_ context.Context, rec models.IndexRecommendation) error {
+	<-m.release
+	m.built <- rec
+	return m.err
+}
+
+// Statements as pg_stat_statements normalizes them.
+const (
+	typeQuery     = `SELECT id, type, label FROM kg_nodes WHERE tenant_id = $1 AND type = $2 ORDER BY updated_at DESC LIMIT $3`
+	propsQuery    = `SELECT id FROM kg_nodes WHERE tenant_id = $1 AND search_props @> $2::jsonb`
+	bothQuery     = `SELECT id FROM kg_nodes n WHERE n.tenant_id = $1 AND n.search_props @> $2 AND n.type IN ($3, $4)`
+	unfilterQuery = `SELECT id, type FROM kg_nodes WHERE tenant_id = $1 ORDER BY updated_at DESC`
+)
+
+func TestNodeFilters(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{typeQuery, []string{"type"}},
+		{propsQuery, []string{"props"}},
+		{bothQuery, []string{"props", "type"}},
+		{unfilterQuery, nil},
+		{`SELECT type FROM kg_nodes`, nil},
+		{`SELECT id FROM kg_nodes WHERE tenant_id = $1 AND node_type = $2`, nil},
+		{`SELECT id FROM kg_nodes WHERE tenant_id = $1 AND search_props ? $2`, []string{"props"}},
+	}
+
+	for _, tt := range tests {
+		if got := nodeFilters(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("nodeFilters(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestIndexCovers(t *testing.T) {
+	typeIndex := advisedIndexes["type"]
+	bothIndex := advisedIndexes["props+type"]
+
+	tests := []struct {
+		name string
+		idx  models.IndexInfo
+		rec  models.IndexRecommendation
+		want bool
+	}{
+		{"same btree", models.IndexInfo{Method: "btree", Columns: []string{"tenant_id", "type"}}, typeIndex, true},
+		{"btree without tenant", models.IndexInfo{Method: "btree", Columns: []string{"type", "label"}}, typeIndex, true},
+		{"btree type not leading", models.IndexInfo{Method: "btree", Columns: []string{"tenant_id", "label", "type"}}, typeIndex, false},
+		{"wrong method", models.IndexInfo{Method: "hash", Columns: []string{"type"}}, typeIndex, false},
+		{"gin any order", models.IndexInfo{Method: "gin", Columns: []string{"search_props", `"type"`}}, bothIndex, true},
+		{"gin missing column", models.IndexInfo{Method: "gin", Columns: []string{"search_props"}}, bothIndex, false},
+	}
+
+	for _, tt := range tests {
+		if got := indexCovers(tt.idx, tt.rec); got != tt.want {
+			t.Errorf("%s: indexCovers = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAdviseIndexes(t *testing.T) {
+	store := &mockIndexAdvisorStore{
+		available: true,
+		stats: []models.StatementStats{
+			{Query: typeQuery, Calls: 5000, TotalTimeMs: 4000},
+			{Query: propsQuery, Calls: 300, TotalTimeMs: 6000},
+			{Query: bothQuery, Calls: 200, TotalTimeMs: 3000},
+			{Query: bothQuery + " LIMIT $5", Calls: 100, TotalTimeMs: 1500},
+			{Query: unfilterQuery, Calls: 9000, TotalTimeMs: 90000},
+		},
+		indexes: []models.IndexInfo{{Name: "idx_nodes_tenant_type", Method: "btree", Columns: []string{"tenant_id", "type"}}},
+	}
+	svc := NewIndexAdvisorService(store, quietLogger())
+
+	report, err := svc.AdviseIndexes(context.Background(), models.IndexAdvisorRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Advice) != 3 {
+		t.Fatalf("advice = %+v, want three filter combinations", report.Advice)
+	}
+
+	props, both, typ := report.Advice[0], report.Advice[1], report.Advice[2]
+	if props.Recommended == nil || props.Recommended.Name != "idx_nodes_search_props" || props.MeanTimeMs != 20 {
+		t.Errorf("props advice = %+v", props)
+	}
+	if both.Statements != 2 || both.Calls != 300 || both.Recommended == nil || both.Recommended.Requires != "btree_gin" {
+		t.Errorf("props+type advice = %+v, want a recommendation needing btree_gin", both)
+	}
+	if typ.CoveredBy != "idx_nodes_tenant_type" || typ.Recommended != nil {
+		t.Errorf("type advice = %+v, want covered", typ)
+	}
+	if recs := report.Buildable(); len(recs) != 1 || recs[0].Name != "idx_nodes_search_props" {
+		t.Errorf("buildable = %+v", recs)
+	}
+
+	store.extensions = []string{"btree_gin"}
+	report, _ = svc.AdviseIndexes(context.Background(), models.IndexAdvisorRequest{})
+	if both := report.Advice[1]; both.Recommended == nil || both.Recommended.Requires != "" {
+		t.Errorf("with btree_gin: props+type advice = %+v", both)
+	}
+
+	report, _ = svc.AdviseIndexes(context.Background(), models.IndexAdvisorRequest{MinMeanMs: 16})
+	if report.Advice[0].Recommended == nil || report.Advice[1].Recommended != nil {
+		t.Errorf("with min_mean_ms 16: advice = %+v", report.Advice)
+	}
+
+	store.available = false
+	report, _ = svc.AdviseIndexes(context.Background(), models.IndexAdvisorRequest{})
+	if report.StatsAvailable || len(report.Advice) != 0 {
+		t.Errorf("without pg_stat_statements: report = %+v", report)
+	}
+}
+
+func TestCreateAdvisedIndexes(t *testing.T) {
+	store := &mockIndexAdvisorStore{
+		available: true,
+		stats:     []models.StatementStats{{Query: propsQuery, Calls: 300, TotalTimeMs: 6000}},
+		release:   make(chan struct{}),
+		built:     make(chan models.IndexRecommendation, 1),
+		err:       errors.New("disk full"),
+	}
+	svc := NewIndexAdvisorService(store, quietLogger())
+	ctx := context.Background()
+
+	report, err := svc.CreateAdvisedIndexes(ctx, models.IndexAdvisorRequest{})
+	if err != nil || !report.Building {
+		t.Fatalf("report = %+v, err = %v; want a running build", report, err)
+	}
+
+	if _, err := svc.CreateAdvisedIndexes(ctx, models.IndexAdvisorRequest{}); !errors.Is(err, models.ErrIndexBuilding) {
+		t.Errorf("second build: err = %v, want ErrIndexBuilding", err)
+	}
+
+	close(store.release)
+	if built := <-store.built; built.Name != "idx_nodes_search_props" {
+		t.Errorf("built %+v", built)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		report, _ := svc.AdviseIndexes(ctx, models.IndexAdvisorRequest{})
+		if !report.Building {
+			if report.LastError != "disk full" {
+				t.Errorf("last error = %q", report.LastError)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("build never finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
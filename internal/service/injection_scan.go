@@ -0,0 +1,74 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// injectionPatterns are phrases and markup that try to take over the model
+// reading a recall pack. They are matched case-insensitively and named in
+// the order checked, so the first match is reported.
+var injectionPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"ignore_instructions", regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+)?(the\s+|your\s+|of\s+the\s+)?(previous|prior|above|earlier|preceding|system)\s+(instructions|prompts?|messages|rules|context)`)},
+	{"new_instructions", regexp.MustCompile(`(?i)\b(new|updated|real)\s+(system\s+)?instructions\s*:`)},
+	{"role_override", regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(a|an|in|the)\b|\bact\s+as\s+(if\s+you\s+are\s+)?(an?\s+)?(unrestricted|jailbroken|dan)\b`)},
+	{"reveal_prompt", regexp.MustCompile(`(?i)\b(reveal|print|repeat|show|output)\s+(me\s+)?(your|the)\s+(system\s+prompt|hidden\s+instructions|initial\s+instructions)`)},
+	{"conceal_from_user", regexp.MustCompile(`(?i)\bdo\s+not\s+(tell|inform|mention\s+(this\s+)?to|alert)\s+the\s+user\b`)},
+	{"chat_markup", regexp.MustCompile(`(?i)<\|im_(start|end)\|>|\[/?INST\]|<</?SYS>>|</?(system|assistant)>`)},
+}
+
+// ScanForInjection reports the first likely prompt-injection pattern in a
+// node's label or string properties, walking nested objects and lists with
+// keys in sorted order.
+func ScanForInjection(label string, properties map[string]any) (models.InjectionMatch, bool) {
+	if name, ok := matchInjection(label); ok {
+		return models.InjectionMatch{Field: "label", Pattern: name}, true
+	}
+
+	return scanInjectionValue("properties", properties)
+}
+
+func scanInjectionValue(field string, v any) (models.InjectionMatch, bool) {
+	switch val := v.(type) {
+	case string:
+		if name, ok := matchInjection(val); ok {
+			return models.InjectionMatch{Field: field, Pattern: name}, true
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if m, ok := scanInjectionValue(field+"."+k, val[k]); ok {
+				return m, true
+			}
+		}
+	case []any:
+		for _, item := range val {
+			if m, ok := scanInjectionValue(field, item); ok {
+				return m, true
+			}
+		}
+	}
+
+	return models.InjectionMatch{}, false
+}
+
+func matchInjection(s string) (string, bool) {
+	for _, p := range injectionPatterns {
+		if p.re.MatchString(s) {
+			return p.name, true
+		}
+	}
+
+	return "", false
+}
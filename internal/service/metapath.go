@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// MetapathStore is the data-access interface MetapathService depends on.
+type MetapathStore interface {
+	PutMetapath(ctx context.Context, tenantID, name string, req models.PutMetapathRequest) (*models.Metapath, error)
+	GetMetapath(ctx context.Context, tenantID, name string) (*models.Metapath, error)
+	ListMetapaths(ctx context.Context, tenantID string) ([]models.Metapath, error)
+	DeleteMetapath(ctx context.Context, tenantID, name string) error
+	RunMetapath(ctx context.Context, tenantID string, m *models.Metapath, startID string, limit int, opts models.ReadOpts) (*models.MetapathResult, error)
+}
+
+// Compile-time check: *MetapathService must satisfy domain.MetapathService.
+var _ domain.MetapathService = (*MetapathService)(nil)
+
+// MetapathService manages a tenant's named metapaths and runs them.
+type MetapathService struct {
+	store  MetapathStore
+	access NodeAccessRecorder
+}
+
+// NewMetapathService creates a MetapathService.
+func NewMetapathService(store MetapathStore) *MetapathService {
+	return &MetapathService{store: store}
+}
+
+// WithAccessRecorder counts every node a metapath run returns as read.
+func (s *MetapathService) WithAccessRecorder(access NodeAccessRecorder) *MetapathService {
+	s.access = access
+	return s
+}
+
+// PutMetapath creates the named metapath or replaces its definition.
+func (s *MetapathService) PutMetapath(
+	ctx context.Context, tenantID, name string, req models.PutMetapathRequest,
+) (_ *models.Metapath, err error) {
+	ctx, span := startSpan(ctx, "MetapathService.PutMetapath", tenantID, tracing.String("metapath", name))
+	defer endSpan(span, &err)
+
+	return s.store.PutMetapath(ctx, tenantID, name, req)
+}
+
+// GetMetapath returns the named metapath.
+func (s *MetapathService) GetMetapath(ctx context.Context, tenantID, name string) (_ *models.Metapath, err error) {
+	ctx, span := startSpan(ctx, "MetapathService.GetMetapath", tenantID, tracing.String("metapath", name))
+	defer endSpan(span, &err)
+
+	return s.store.GetMetapath(ctx, tenantID, name)
+}
+
+// ListMetapaths returns the tenant's metapaths.
+func (s *MetapathService) ListMetapaths(ctx context.Context, tenantID string) (_ []models.Metapath, err error) {
+	ctx, span := startSpan(ctx, "MetapathService.ListMetapaths", tenantID)
+	defer endSpan(span, &err)
+
+	return s.store.ListMetapaths(ctx, tenantID)
+}
+
+// DeleteMetapath deletes the named metapath.
+func (s *MetapathService) DeleteMetapath(ctx context.Context, tenantID, name string) (err error) {
+	ctx, span := startSpan(ctx, "MetapathService.DeleteMetapath", tenantID, tracing.String("metapath", name))
+	defer endSpan(span, &err)
+
+	return s.store.DeleteMetapath(ctx, tenantID, name)
+}
+
+// RunMetapath follows the named metapath from startID and returns up to
+// limit paths.
+func (s *MetapathService) RunMetapath(
+	ctx context.Context, tenantID, name, startID string, limit int, opts models.ReadOpts,
+) (result *models.MetapathResult, err error) {
+	ctx, span := startSpan(ctx, "MetapathService.RunMetapath", tenantID,
+		tracing.String("metapath", name), tracing.String("node_id", startID), tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	m, err := s.store.GetMetapath(ctx, tenantID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = s.store.RunMetapath(ctx, tenantID, m, startID, limit, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(tracing.Int("path_count", len(result.Paths)))
+	touchNodes(s.access, tenantID, result.Nodes)
+
+	return result, nil
+}
@@ -13,12 +13,12 @@ type mockNodeStore struct {
 	mu    sync.Mutex
 	calls []string
 
-	listNodes           func(ctx context.Context, tenantID, typeFilter string, minSalience float64, limit, offset int) ([]models.Node, bool, error)
+	listNodes           func(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error)
 	getNode             func(ctx context.Context, tenantID, nodeID string) (*models.Node, error)
 	createNode          func(ctx context.Context, tenantID string, req models.CreateNodeRequest) (*models.Node, error)
 	updateNode          func(ctx context.Context, tenantID, nodeID string, req models.UpdateNodeRequest) (*models.Node, error)
 	patchNodeProperties func(ctx context.Context, tenantID, nodeID string, req models.PatchPropertiesRequest) (*models.Node, error)
-	deleteNode          func(ctx context.Context, tenantID, nodeID string) error
+	deleteNode          func(ctx context.Context, tenantID, nodeID, mode string) error
 }
 
 func (m *mockNodeStore) record(name string) {
@@ -27,9 +27,9 @@ func (m *mockNodeStore) record(name string) {
 	m.calls = append(m.calls, name)
 }
 
-func (m *mockNodeStore) ListNodes(ctx context.Context, tenantID, typeFilter string, minSalience float64, limit, offset int) ([]models.Node, bool, error) {
+func (m *mockNodeStore) ListNodes(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error) {
 	m.record("ListNodes")
-	return m.listNodes(ctx, tenantID, typeFilter, minSalience, limit, offset)
+	return m.listNodes(ctx, tenantID, opts)
 }
 
 func (m *mockNodeStore) GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error) {
@@ -52,9 +52,9 @@ func (m *mockNodeStore) UpdateNode(ctx context.Context, tenantID, nodeID string,
 	return m.updateNode(ctx, tenantID, nodeID, req)
 }
 
-func (m *mockNodeStore) DeleteNode(ctx context.Context, tenantID, nodeID string) error {
+func (m *mockNodeStore) DeleteNode(ctx context.Context, tenantID, nodeID, mode string) error {
 	m.record("DeleteNode")
-	return m.deleteNode(ctx, tenantID, nodeID)
+	return m.deleteNode(ctx, tenantID, nodeID, mode)
 }
 
 func (m *mockNodeStore) PatchNodeProperties(ctx context.Context, tenantID, nodeID string, req models.PatchPropertiesRequest) (*models.Node, error) {
@@ -65,9 +65,24 @@ func (m *mockNodeStore) PatchNodeProperties(ctx context.Context, tenantID, nodeI
 	return &models.Node{}, nil
 }
 
-func (m *mockNodeStore) MigrateNode(_ context.Context, _, _ string, _ models.MigrateNodeRequest) (*models.MigrateNodeResult, error) {
+func (m *mockNodeStore) MigrateNode(_ context.Context, _, oldID string, req models.MigrateNodeRequest) (*models.MigrateNodeResult, error) {
 	m.record("MigrateNode")
-	return &models.MigrateNodeResult{}, nil
+	return &models.MigrateNodeResult{OldID: oldID, NewID: req.NewID, DryRun: req.DryRun}, nil
+}
+
+func (m *mockNodeStore) MergeNode(_ context.Context, _, sourceID, targetID string, _ models.MergeNodeRequest) (*models.MergeNodeResult, error) {
+	m.record("MergeNode")
+	return &models.MergeNodeResult{SourceID: sourceID, TargetID: targetID, Node: &models.Node{ID: targetID, Label: "merged"}}, nil
+}
+
+func (m *mockNodeStore) PreviewDeleteByFilter(_ context.Context, _ string, filter models.NodeFilter) (*models.DeletePreview, error) {
+	m.record("PreviewDeleteByFilter")
+	return &models.DeletePreview{Token: "token", Filter: filter}, nil
+}
+
+func (m *mockNodeStore) DeleteByFilter(_ context.Context, _ string, _ models.DeleteByFilterRequest) (*models.DeleteByFilterResult, error) {
+	m.record("DeleteByFilter")
+	return &models.DeleteByFilterResult{NodesDeleted: 2, EdgesDeleted: 1}, nil
 }
 
 // mockEdgeStore records calls and returns configured responses.
@@ -75,7 +90,7 @@ type mockEdgeStore struct {
 	mu    sync.Mutex
 	calls []string
 
-	listEdges  func(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) ([]models.Edge, bool, error)
+	listEdges  func(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool, after *models.EdgeCursor) ([]models.Edge, bool, error)
 	createEdge func(ctx context.Context, tenantID string, req models.CreateEdgeRequest) (*models.Edge, error)
 	updateEdge func(ctx context.Context, tenantID, source, target, relation string, req models.UpdateEdgeRequest) (*models.Edge, error)
 	deleteEdge func(ctx context.Context, tenantID, source, target, relation string) error
@@ -87,9 +102,9 @@ func (m *mockEdgeStore) record(name string) {
 	m.calls = append(m.calls, name)
 }
 
-func (m *mockEdgeStore) ListEdges(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) ([]models.Edge, bool, error) {
+func (m *mockEdgeStore) ListEdges(ctx context.Context, tenantID, source, target, relation string, limit, offset int, activeOn *time.Time, current *bool, after *models.EdgeCursor) ([]models.Edge, bool, error) {
 	m.record("ListEdges")
-	return m.listEdges(ctx, tenantID, source, target, relation, limit, offset, activeOn, current)
+	return m.listEdges(ctx, tenantID, source, target, relation, limit, offset, activeOn, current, after)
 }
 
 func (m *mockEdgeStore) CreateEdge(ctx context.Context, tenantID string, req models.CreateEdgeRequest) (*models.Edge, error) { //nolint:gocritic // hugeParam: matches domain.EdgeService interface signature
@@ -112,15 +127,25 @@ func (m *mockEdgeStore) DeleteEdge(ctx context.Context, tenantID, source, target
 	return m.deleteEdge(ctx, tenantID, source, target, relation)
 }
 
+func (m *mockEdgeStore) EdgesExist(_ context.Context, _ string, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+	m.record("EdgesExist")
+	result := make([]models.EdgeExistence, len(keys))
+	for i, k := range keys {
+		result[i] = models.EdgeExistence{EdgeKey: k}
+	}
+	return result, nil
+}
+
 // mockSearchStore records calls and returns configured responses.
 type mockSearchStore struct {
 	mu    sync.Mutex
 	calls []string
 
-	fullTextSearch func(ctx context.Context, tenantID, query, typeFilter string, minSalience float64, limit int) ([]models.Node, error)
-	semanticSearch func(ctx context.Context, tenantID string, embedding []float32, limit int) ([]models.ScoredNode, error)
-	hybridSearch   func(ctx context.Context, tenantID, query string, embedding []float32, limit int) ([]models.Node, error)
-	getNodeByLabel func(ctx context.Context, tenantID, label string) (*models.Node, error)
+	fullTextSearch       func(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
+	fullTextSearchFacets func(ctx context.Context, tenantID, query string, filter models.SearchFilter) (*models.SearchFacets, error)
+	semanticSearch       func(ctx context.Context, tenantID string, embedding []float32, filter models.SearchFilter, limit int) ([]models.ScoredNode, error)
+	hybridSearch         func(ctx context.Context, tenantID, query string, embedding []float32, filter models.SearchFilter, limit int) ([]models.Node, error)
+	getNodeByLabel       func(ctx context.Context, tenantID, label string) (*models.Node, error)
 }
 
 type mockGraphLookupStore struct {
@@ -133,19 +158,24 @@ func (m *mockSearchStore) record(name string) {
 	m.calls = append(m.calls, name)
 }
 
-func (m *mockSearchStore) FullTextSearch(ctx context.Context, tenantID, query, typeFilter string, minSalience float64, limit int) ([]models.Node, error) {
+func (m *mockSearchStore) FullTextSearch(ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int) ([]models.Node, error) {
 	m.record("FullTextSearch")
-	return m.fullTextSearch(ctx, tenantID, query, typeFilter, minSalience, limit)
+	return m.fullTextSearch(ctx, tenantID, query, filter, limit)
+}
+
+func (m *mockSearchStore) FullTextSearchFacets(ctx context.Context, tenantID, query string, filter models.SearchFilter) (*models.SearchFacets, error) {
+	m.record("FullTextSearchFacets")
+	return m.fullTextSearchFacets(ctx, tenantID, query, filter)
 }
 
-func (m *mockSearchStore) SemanticSearch(ctx context.Context, tenantID string, embedding []float32, limit int) ([]models.ScoredNode, error) {
+func (m *mockSearchStore) SemanticSearch(ctx context.Context, tenantID string, embedding []float32, filter models.SearchFilter, limit int) ([]models.ScoredNode, error) {
 	m.record("SemanticSearch")
-	return m.semanticSearch(ctx, tenantID, embedding, limit)
+	return m.semanticSearch(ctx, tenantID, embedding, filter, limit)
 }
 
-func (m *mockSearchStore) HybridSearch(ctx context.Context, tenantID, query string, embedding []float32, limit int) ([]models.Node, error) {
+func (m *mockSearchStore) HybridSearch(ctx context.Context, tenantID, query string, embedding []float32, filter models.SearchFilter, limit int) ([]models.Node, error) {
 	m.record("HybridSearch")
-	return m.hybridSearch(ctx, tenantID, query, embedding, limit)
+	return m.hybridSearch(ctx, tenantID, query, embedding, filter, limit)
 }
 
 func (m *mockSearchStore) GetNodeByLabel(ctx context.Context, tenantID, label string) (*models.Node, error) {
@@ -156,7 +186,7 @@ func (m *mockSearchStore) GetNodeByLabel(ctx context.Context, tenantID, label st
 	return m.getNodeByLabel(ctx, tenantID, label)
 }
 
-func (m *mockGraphLookupStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error) {
+func (m *mockGraphLookupStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int, _ models.ReadOpts) (*models.NeighborResult, error) {
 	if m.neighbors == nil {
 		return &models.NeighborResult{}, nil
 	}
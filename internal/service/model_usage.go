@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	// modelUsageFlushInterval is how often ModelUsageMeter.Run writes
+	// buffered usage.
+	modelUsageFlushInterval = 30 * time.Second
+	// tokenBudgetTTL is how long ModelUsageMeter trusts a tenant's recorded
+	// tokens and budget before reading them again.
+	tokenBudgetTTL = 30 * time.Second
+)
+
+// ModelUsageStore is the data-access interface ModelUsageMeter depends on.
+type ModelUsageStore interface {
+	RecordModelUsage(ctx context.Context, tenantID string, at time.Time, usage map[string]models.ModelUsage) error
+	GetTokenBudget(ctx context.Context, tenantID, kind string, at time.Time) (used int64, budget *int64, err error)
+}
+
+// ModelUsageRecorder counts and budgets the embedding and LLM calls made
+// for tenants. RecordModelUsage must not block on the database.
+type ModelUsageRecorder interface {
+	CheckTokenBudget(ctx context.Context, tenantID, kind string) error
+	RecordModelUsage(tenantID, kind string, requests, tokens int64)
+}
+
+// modelUsageKey identifies one tenant's usage of one kind.
+type modelUsageKey struct {
+	tenantID string
+	kind     string
+}
+
+// tokenBudget is a tenant's budget for one kind and the tokens used against
+// it: used as read at fetched, plus those recorded since.
+type tokenBudget struct {
+	month   time.Time
+	fetched time.Time
+	used    int64
+	budget  *int64
+}
+
+// ModelUsageMeter counts model requests and tokens per tenant in memory,
+// publishes them as metrics, writes them to each tenant's monthly totals
+// from Run, and refuses calls once a tenant's monthly token budget is used
+// up. Budgets are soft: a call that starts under budget is allowed to finish
+// over it.
+type ModelUsageMeter struct {
+	store ModelUsageStore
+	log   *logrus.Logger
+	now   func() time.Time
+
+	mu      sync.Mutex
+	pending map[modelUsageKey]models.ModelUsage
+	budgets map[modelUsageKey]*tokenBudget
+}
+
+// Compile-time check: *ModelUsageMeter must satisfy ModelUsageRecorder.
+var _ ModelUsageRecorder = (*ModelUsageMeter)(nil)
+
+// NewModelUsageMeter creates a ModelUsageMeter.
+func NewModelUsageMeter(store ModelUsageStore, log *logrus.Logger) *ModelUsageMeter {
+	return &ModelUsageMeter{
+		store:   store,
+		log:     log,
+		now:     time.Now,
+		pending: make(map[modelUsageKey]models.ModelUsage),
+		budgets: make(map[modelUsageKey]*tokenBudget),
+	}
+}
+
+// RecordModelUsage counts requests and tokens of kind for the tenant.
+func (m *ModelUsageMeter) RecordModelUsage(tenantID, kind string, requests, tokens int64) {
+	metrics.ModelRequests.WithLabelValues(tenantID, kind).Add(float64(requests))
+	metrics.ModelTokens.WithLabelValues(tenantID, kind).Add(float64(tokens))
+
+	k := modelUsageKey{tenantID: tenantID, kind: kind}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u := m.pending[k]
+	u.Requests += requests
+	u.Tokens += tokens
+	m.pending[k] = u
+
+	if b, ok := m.budgets[k]; ok {
+		b.used += tokens
+	}
+}
+
+// CheckTokenBudget returns an error wrapping models.ErrQuotaExceeded when
+// the tenant has used its monthly budget of kind tokens. A failed budget
+// read is logged and the call allowed.
+func (m *ModelUsageMeter) CheckTokenBudget(ctx context.Context, tenantID, kind string) error {
+	k := modelUsageKey{tenantID: tenantID, kind: kind}
+	now := m.now()
+	month := monthOf(now)
+
+	m.mu.Lock()
+	b, ok := m.budgets[k]
+	fresh := ok && b.month.Equal(month) && now.Sub(b.fetched) < tokenBudgetTTL
+	m.mu.Unlock()
+
+	if !fresh {
+		used, budget, err := m.store.GetTokenBudget(ctx, tenantID, kind, now)
+		if err != nil {
+			m.log.WithError(err).WithField("tenant_id", tenantID).Warn("reading token budget")
+			return nil
+		}
+
+		m.mu.Lock()
+		// Tokens still buffered are not in the stored total yet.
+		b = &tokenBudget{month: month, fetched: now, used: used + m.pending[k].Tokens, budget: budget}
+		m.budgets[k] = b
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if b.budget != nil && b.used >= *b.budget {
+		metrics.ModelBudgetRejections.WithLabelValues(tenantID, kind).Inc()
+
+		return models.TokenBudgetExceeded(kind, *b.budget)
+	}
+
+	return nil
+}
+
+// Run flushes buffered usage every modelUsageFlushInterval until ctx is
+// cancelled, then flushes once more.
+func (m *ModelUsageMeter) Run(ctx context.Context) {
+	ticker := time.NewTicker(modelUsageFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			m.Flush(drainCtx)
+			cancel()
+
+			return
+		case <-ticker.C:
+			m.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the buffered usage, one batch per tenant, to the current
+// month. A tenant whose write fails loses that batch.
+func (m *ModelUsageMeter) Flush(ctx context.Context) {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = make(map[modelUsageKey]models.ModelUsage)
+	m.mu.Unlock()
+
+	byTenant := make(map[string]map[string]models.ModelUsage)
+	for k, u := range pending {
+		if byTenant[k.tenantID] == nil {
+			byTenant[k.tenantID] = make(map[string]models.ModelUsage)
+		}
+
+		byTenant[k.tenantID][k.kind] = u
+	}
+
+	now := m.now()
+	for tenantID, usage := range byTenant {
+		if err := m.store.RecordModelUsage(ctx, tenantID, now, usage); err != nil {
+			m.log.WithError(err).WithField("tenant_id", tenantID).Warn("recording model usage")
+		}
+	}
+}
+
+// monthOf returns the first day of t's calendar month in UTC.
+func monthOf(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// usageTenantKey is the context key for the tenant model calls are made for.
+type usageTenantKey struct{}
+
+// withUsageTenant attributes the embedding calls made with ctx to tenantID.
+func withUsageTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, usageTenantKey{}, tenantID)
+}
+
+// usageTenant returns the tenant embedding calls with ctx are made for, or
+// "" if they are not attributed to one.
+func usageTenant(ctx context.Context) string {
+	tenantID, _ := ctx.Value(usageTenantKey{}).(string)
+
+	return tenantID
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// fakeModelUsageStore keeps recorded usage in memory with a fixed budget.
+type fakeModelUsageStore struct {
+	mu       sync.Mutex
+	budget   *int64
+	recorded map[string]map[string]models.ModelUsage
+	reads    int
+}
+
+func (f *fakeModelUsageStore) RecordModelUsage(
+	_ context.Context, tenantID string, _ time.Time, usage map[string]models.ModelUsage,
+) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.recorded == nil {
+		f.recorded = make(map[string]map[string]models.ModelUsage)
+	}
+
+	if f.recorded[tenantID] == nil {
+		f.recorded[tenantID] = make(map[string]models.ModelUsage)
+	}
+
+	for kind, u := range usage {
+		total := f.recorded[tenantID][kind]
+		total.Requests += u.Requests
+		total.Tokens += u.Tokens
+		f.recorded[tenantID][kind] = total
+	}
+
+	return nil
+}
+
+func (f *fakeModelUsageStore) GetTokenBudget(
+	_ context.Context, tenantID, kind string, _ time.Time,
+) (int64, *int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reads++
+
+	return f.recorded[tenantID][kind].Tokens, f.budget, nil
+}
+
+func TestModelUsageMeter_Budget(t *testing.T) {
+	budget := int64(100)
+	store := &fakeModelUsageStore{budget: &budget}
+	meter := NewModelUsageMeter(store, logrus.New())
+	ctx := context.Background()
+
+	if err := meter.CheckTokenBudget(ctx, "t1", models.ModelUsageLLM); err != nil {
+		t.Fatalf("CheckTokenBudget before use: %v", err)
+	}
+
+	meter.RecordModelUsage("t1", models.ModelUsageLLM, 1, 60)
+	meter.Flush(ctx)
+	meter.RecordModelUsage("t1", models.ModelUsageLLM, 1, 40)
+
+	// The cached read plus usage recorded since is at the budget.
+	if err := meter.CheckTokenBudget(ctx, "t1", models.ModelUsageLLM); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("CheckTokenBudget at budget = %v, want ErrQuotaExceeded", err)
+	}
+
+	if store.reads != 1 {
+		t.Errorf("budget read %d times, want 1 within the TTL", store.reads)
+	}
+
+	// A fresh read counts the flushed usage and the still-buffered usage once each.
+	meter.now = func() time.Time { return time.Now().Add(tokenBudgetTTL) }
+	if err := meter.CheckTokenBudget(ctx, "t1", models.ModelUsageLLM); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("CheckTokenBudget after re-read = %v, want ErrQuotaExceeded", err)
+	}
+
+	if err := meter.CheckTokenBudget(ctx, "t1", models.ModelUsageEmbedding); err != nil {
+		t.Errorf("embedding budget is separate, got %v", err)
+	}
+
+	meter.Flush(ctx)
+
+	if got := store.recorded["t1"][models.ModelUsageLLM]; got != (models.ModelUsage{Requests: 2, Tokens: 100}) {
+		t.Errorf("recorded = %+v, want 2 requests and 100 tokens", got)
+	}
+}
+
+func TestEmbeddingService_RecordsUsage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body) //nolint:errcheck // test server.
+
+		resp := embeddingResponse{Embeddings: [][]float32{{1, 2, 3}}, PromptEvalCount: 7}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("encoding response: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	budget := int64(10)
+	store := &fakeModelUsageStore{budget: &budget}
+	meter := NewModelUsageMeter(store, logrus.New())
+
+	svc := NewEmbeddingService(srv.URL, "test-model", 3, false)
+	svc.SetUsageRecorder(meter)
+
+	// Calls not made for a tenant are not counted.
+	if _, err := svc.Generate(context.Background(), "hello"); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	ctx := withUsageTenant(context.Background(), "t1")
+	for range 2 {
+		if _, err := svc.Generate(ctx, "hello"); err != nil {
+			t.Fatalf("Generate for tenant: %v", err)
+		}
+	}
+
+	if _, err := svc.Generate(ctx, "hello"); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("Generate over budget = %v, want ErrQuotaExceeded", err)
+	}
+
+	meter.Flush(context.Background())
+
+	if got := store.recorded["t1"][models.ModelUsageEmbedding]; got != (models.ModelUsage{Requests: 2, Tokens: 14}) {
+		t.Errorf("recorded = %+v, want 2 requests and 14 tokens", got)
+	}
+}
@@ -8,11 +8,12 @@
 
 	"github.com/persistorai/persistor/internal/domain"
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // NodeStore is the data-access interface NodeService depends on.
-// It reuses domain.NodeService since the method sets are identical, avoiding duplication.
-type NodeStore = domain.NodeService
+type NodeStore = storage.NodeStorage
 
 // Compile-time check: *NodeService must satisfy domain.NodeService.
 var _ domain.NodeService = (*NodeService)(nil)
@@ -30,6 +31,8 @@ type NodeService struct {
 	store       NodeStore
 	embedWorker EmbedEnqueuer
 	auditWorker AuditEnqueuer
+	access      NodeAccessRecorder
+	scanner     NodeScanner
 	log         *logrus.Logger
 }
 
@@ -38,16 +41,51 @@ func NewNodeService(store NodeStore, embedWorker EmbedEnqueuer, auditWorker Audi
 	return &NodeService{store: store, embedWorker: embedWorker, auditWorker: auditWorker, log: log}
 }
 
+// WithAccessRecorder counts each node GetNode returns as read.
+func (s *NodeService) WithAccessRecorder(access NodeAccessRecorder) *NodeService {
+	s.access = access
+	return s
+}
+
+// WithScanner checks every node the service writes, such as for prompt
+// injection.
+func (s *NodeService) WithScanner(scanner NodeScanner) *NodeService {
+	s.scanner = scanner
+	return s
+}
+
+// scan passes a written node to the scanner, if one is set.
+func (s *NodeService) scan(ctx context.Context, tenantID string, node *models.Node) {
+	if s.scanner != nil {
+		s.scanner.ScanNode(ctx, tenantID, node)
+	}
+}
+
 // ListNodes returns a paginated list of nodes (pass-through).
 func (s *NodeService) ListNodes(
-	ctx context.Context, tenantID, typeFilter string, minSalience float64, limit, offset int,
-) ([]models.Node, bool, error) {
-	return s.store.ListNodes(ctx, tenantID, typeFilter, minSalience, limit, offset)
+	ctx context.Context, tenantID string, opts models.NodeListOpts,
+) (_ []models.Node, _ bool, err error) {
+	ctx, span := startSpan(ctx, "NodeService.ListNodes", tenantID, tracing.Int("limit", opts.Limit))
+	defer endSpan(span, &err)
+
+	return s.store.ListNodes(ctx, tenantID, opts)
 }
 
-// GetNode returns a single node by ID (pass-through).
-func (s *NodeService) GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error) {
-	return s.store.GetNode(ctx, tenantID, nodeID)
+// GetNode returns a single node by ID and counts the read.
+func (s *NodeService) GetNode(ctx context.Context, tenantID, nodeID string) (_ *models.Node, err error) {
+	ctx, span := startSpan(ctx, "NodeService.GetNode", tenantID)
+	defer endSpan(span, &err)
+
+	node, err := s.store.GetNode(ctx, tenantID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.access != nil {
+		s.access.TouchNodes(tenantID, node.ID)
+	}
+
+	return node, nil
 }
 
 // GetNodeByLabel returns the first node whose label matches exactly (case-insensitive).
@@ -59,7 +97,10 @@ func (s *NodeService) GetNodeByLabel(ctx context.Context, tenantID, label string
 // CreateNode creates a node and enqueues an embedding job.
 func (s *NodeService) CreateNode(
 	ctx context.Context, tenantID string, req models.CreateNodeRequest,
-) (*models.Node, error) {
+) (_ *models.Node, err error) {
+	ctx, span := startSpan(ctx, "NodeService.CreateNode", tenantID)
+	defer endSpan(span, &err)
+
 	node, err := s.store.CreateNode(ctx, tenantID, req)
 	if err != nil {
 		return nil, err
@@ -73,6 +114,7 @@ func (s *NodeService) CreateNode(
 		})
 	}
 
+	s.scan(ctx, tenantID, node)
 	auditAsync(s.auditWorker, tenantID, "node.create", "node", node.ID, map[string]any{"type": node.Type, "label": node.Label})
 
 	return node, nil
@@ -81,12 +123,19 @@ func (s *NodeService) CreateNode(
 // UpdateNode updates a node and re-embeds if type or label changed.
 func (s *NodeService) UpdateNode(
 	ctx context.Context, tenantID, nodeID string, req models.UpdateNodeRequest,
-) (*models.Node, error) {
+) (_ *models.Node, err error) {
+	ctx, span := startSpan(ctx, "NodeService.UpdateNode", tenantID)
+	defer endSpan(span, &err)
+
 	node, err := s.store.UpdateNode(ctx, tenantID, nodeID, req)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Label != nil || req.Properties != nil {
+		s.scan(ctx, tenantID, node)
+	}
+
 	if req.Type != nil || req.Label != nil || req.Properties != nil {
 		if s.embedWorker != nil {
 			s.embedWorker.Enqueue(EmbedJob{
@@ -105,7 +154,10 @@ func (s *NodeService) UpdateNode(
 // PatchNodeProperties partially updates node properties (merge semantics).
 func (s *NodeService) PatchNodeProperties(
 	ctx context.Context, tenantID, nodeID string, req models.PatchPropertiesRequest,
-) (*models.Node, error) {
+) (_ *models.Node, err error) {
+	ctx, span := startSpan(ctx, "NodeService.PatchNodeProperties", tenantID)
+	defer endSpan(span, &err)
+
 	node, err := s.store.PatchNodeProperties(ctx, tenantID, nodeID, req)
 	if err != nil {
 		return nil, err
@@ -119,6 +171,7 @@ func (s *NodeService) PatchNodeProperties(
 		})
 	}
 
+	s.scan(ctx, tenantID, node)
 	auditAsync(s.auditWorker, tenantID, "node.patch_properties", "node", nodeID, map[string]any{"patched_keys": mapKeys(req.Properties)})
 
 	return node, nil
@@ -143,6 +196,10 @@ func (s *NodeService) MigrateNode(
 		return nil, err
 	}
 
+	if result.DryRun {
+		return result, nil
+	}
+
 	// Re-embed the new node. We only know the new ID here, so use it as a fallback.
 	if s.embedWorker != nil {
 		s.embedWorker.Enqueue(EmbedJob{TenantID: tenantID, NodeID: result.NewID, Text: result.NewID})
@@ -153,16 +210,82 @@ func (s *NodeService) MigrateNode(
 		"outgoing_edges": result.OutgoingEdges,
 		"incoming_edges": result.IncomingEdges,
 		"old_deleted":    result.OldDeleted,
+		"copy_edges":     req.CopyEdges,
+		"edges":          result.Edges,
 	})
 
 	return result, nil
 }
 
-// DeleteNode removes a node (pass-through).
-func (s *NodeService) DeleteNode(ctx context.Context, tenantID, nodeID string) error {
-	err := s.store.DeleteNode(ctx, tenantID, nodeID)
+// MergeNode merges one node into another and re-embeds the target, whose
+// properties may have changed. The store records the audit entry in the same
+// transaction as the merge.
+func (s *NodeService) MergeNode(
+	ctx context.Context, tenantID, sourceID, targetID string, req models.MergeNodeRequest,
+) (*models.MergeNodeResult, error) {
+	result, err := s.store.MergeNode(ctx, tenantID, sourceID, targetID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.embedWorker != nil {
+		s.embedWorker.Enqueue(EmbedJob{
+			TenantID: tenantID,
+			NodeID:   result.Node.ID,
+			Text:     models.BuildNodeEmbeddingText(result.Node),
+		})
+	}
+
+	s.scan(ctx, tenantID, result.Node)
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":     tenantID,
+		"source_id":     sourceID,
+		"target_id":     targetID,
+		"edges_rewired": result.EdgesRewired,
+		"edges_merged":  result.EdgesMerged,
+	}).Debug("node.merge")
+
+	return result, nil
+}
+
+// PreviewDeleteByFilter reports the nodes a delete-by-filter would remove and
+// issues the token the delete requires (pass-through).
+func (s *NodeService) PreviewDeleteByFilter(
+	ctx context.Context, tenantID string, filter models.NodeFilter,
+) (*models.DeletePreview, error) {
+	return s.store.PreviewDeleteByFilter(ctx, tenantID, filter)
+}
+
+// DeleteByFilter deletes the nodes a preview reported. The store records the
+// audit entry in the same transaction as the delete.
+func (s *NodeService) DeleteByFilter(
+	ctx context.Context, tenantID string, req models.DeleteByFilterRequest,
+) (*models.DeleteByFilterResult, error) {
+	result, err := s.store.DeleteByFilter(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":     tenantID,
+		"type":          req.Filter.Type,
+		"min_salience":  req.Filter.MinSalience,
+		"nodes_deleted": result.NodesDeleted,
+		"edges_deleted": result.EdgesDeleted,
+	}).Debug("node.delete_by_filter")
+
+	return result, nil
+}
+
+// DeleteNode removes a node, and with models.DeleteDetach its edges (pass-through).
+func (s *NodeService) DeleteNode(ctx context.Context, tenantID, nodeID, mode string) (err error) {
+	ctx, span := startSpan(ctx, "NodeService.DeleteNode", tenantID)
+	defer endSpan(span, &err)
+
+	err = s.store.DeleteNode(ctx, tenantID, nodeID, mode)
 	if err == nil {
-		auditAsync(s.auditWorker, tenantID, "node.delete", "node", nodeID, nil)
+		auditAsync(s.auditWorker, tenantID, "node.delete", "node", nodeID, map[string]any{"mode": mode})
 	}
 	return err
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	// nodeAccessFlushInterval is how often NodeAccessTracker.Run writes
+	// buffered node reads.
+	nodeAccessFlushInterval = 30 * time.Second
+	// maxPendingNodeAccesses bounds the distinct nodes buffered between
+	// flushes; reads of new nodes beyond it are dropped until the next one.
+	maxPendingNodeAccesses = 50000
+)
+
+// NodeAccessStore is the data-access interface NodeAccessTracker depends on.
+type NodeAccessStore interface {
+	RecordNodeAccess(ctx context.Context, tenantID string, accesses []models.NodeAccess) error
+}
+
+// NodeAccessRecorder counts reads of nodes. Implementations must not block
+// on the database.
+type NodeAccessRecorder interface {
+	TouchNodes(tenantID string, nodeIDs ...string)
+}
+
+// nodeAccessKey identifies one buffered node.
+type nodeAccessKey struct {
+	tenantID string
+	nodeID   string
+}
+
+// nodeAccess is the reads of one node since the last flush.
+type nodeAccess struct {
+	count int64
+	last  time.Time
+}
+
+// NodeAccessTracker counts node reads in memory and writes them to each
+// node's access_count and last_accessed from Run, so reads feed the usage
+// and recency terms of salience without a write per request.
+type NodeAccessTracker struct {
+	store NodeAccessStore
+	log   *logrus.Logger
+	now   func() time.Time
+
+	mu      sync.Mutex
+	pending map[nodeAccessKey]*nodeAccess
+}
+
+// NewNodeAccessTracker creates a NodeAccessTracker.
+func NewNodeAccessTracker(store NodeAccessStore, log *logrus.Logger) *NodeAccessTracker {
+	return &NodeAccessTracker{
+		store:   store,
+		log:     log,
+		now:     time.Now,
+		pending: make(map[nodeAccessKey]*nodeAccess),
+	}
+}
+
+// TouchNodes counts one read of each node.
+func (t *NodeAccessTracker) TouchNodes(tenantID string, nodeIDs ...string) {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, id := range nodeIDs {
+		if id == "" {
+			continue
+		}
+
+		k := nodeAccessKey{tenantID: tenantID, nodeID: id}
+
+		a, ok := t.pending[k]
+		if !ok {
+			if len(t.pending) >= maxPendingNodeAccesses {
+				continue
+			}
+
+			a = &nodeAccess{}
+			t.pending[k] = a
+		}
+
+		a.count++
+		a.last = now
+	}
+}
+
+// Run flushes buffered reads every nodeAccessFlushInterval until ctx is
+// cancelled, then flushes once more.
+func (t *NodeAccessTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(nodeAccessFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			t.Flush(drainCtx)
+			cancel()
+
+			return
+		case <-ticker.C:
+			t.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the buffered reads, one batch per tenant sorted by node ID.
+// A tenant whose write fails loses that batch.
+func (t *NodeAccessTracker) Flush(ctx context.Context) {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = make(map[nodeAccessKey]*nodeAccess)
+	t.mu.Unlock()
+
+	byTenant := make(map[string][]models.NodeAccess)
+	for k, a := range pending {
+		byTenant[k.tenantID] = append(byTenant[k.tenantID], models.NodeAccess{
+			NodeID:       k.nodeID,
+			Count:        a.count,
+			LastAccessed: a.last,
+		})
+	}
+
+	for tenantID, accesses := range byTenant {
+		sort.Slice(accesses, func(i, j int) bool { return accesses[i].NodeID < accesses[j].NodeID })
+
+		if err := t.store.RecordNodeAccess(ctx, tenantID, accesses); err != nil {
+			t.log.WithError(err).WithField("tenant_id", tenantID).Warn("recording node access")
+		}
+	}
+}
+
+// touchNodes counts reads of nodes with recorder, which may be nil.
+func touchNodes(recorder NodeAccessRecorder, tenantID string, nodes []models.Node) {
+	if recorder == nil || len(nodes) == 0 {
+		return
+	}
+
+	ids := make([]string, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+
+	recorder.TouchNodes(tenantID, ids...)
+}
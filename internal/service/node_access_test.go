@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockNodeAccessStore struct {
+	recorded map[string][]models.NodeAccess
+}
+
+func (m *mockNodeAccessStore) RecordNodeAccess(_ context.Context, tenantID string, accesses []models.NodeAccess) error {
+	m.recorded[tenantID] = append(m.recorded[tenantID], accesses...)
+	return nil
+}
+
+func TestNodeAccessTracker_Flush(t *testing.T) {
+	store := &mockNodeAccessStore{recorded: make(map[string][]models.NodeAccess)}
+	tracker := NewNodeAccessTracker(store, logrus.New())
+	first := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := first
+	tracker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tracker.TouchNodes("t1", "b", "a")
+	now = first.Add(time.Second)
+	tracker.TouchNodes("t1", "a", "")
+	tracker.TouchNodes("t2", "c")
+	tracker.Flush(ctx)
+
+	got := store.recorded["t1"]
+	if len(got) != 2 || got[0].NodeID != "a" || got[1].NodeID != "b" {
+		t.Fatalf("t1 accesses = %+v, want a and b in ID order", got)
+	}
+	if got[0].Count != 2 || !got[0].LastAccessed.Equal(now) {
+		t.Errorf("a = %+v, want 2 reads, last at %s", got[0], now)
+	}
+	if got[1].Count != 1 || !got[1].LastAccessed.Equal(first) {
+		t.Errorf("b = %+v, want 1 read, last at %s", got[1], first)
+	}
+	if len(store.recorded["t2"]) != 1 {
+		t.Errorf("t2 accesses = %+v, want one", store.recorded["t2"])
+	}
+
+	// Flushed reads are not written again.
+	tracker.Flush(ctx)
+	if len(store.recorded["t1"]) != 2 {
+		t.Errorf("second flush rewrote reads: %+v", store.recorded["t1"])
+	}
+}
+
+type recordingAccessRecorder struct {
+	touched []string
+}
+
+func (r *recordingAccessRecorder) TouchNodes(_ string, nodeIDs ...string) {
+	r.touched = append(r.touched, nodeIDs...)
+}
+
+func TestNodeService_GetNodeTouches(t *testing.T) {
+	store := &mockNodeStore{
+		getNode: func(_ context.Context, _, nodeID string) (*models.Node, error) {
+			if nodeID == "missing" {
+				return nil, models.ErrNodeNotFound
+			}
+			return &models.Node{ID: nodeID}, nil
+		},
+	}
+	svc, _ := newTestNodeService(store, nil, &mockAuditor{})
+	access := &recordingAccessRecorder{}
+	svc.WithAccessRecorder(access)
+
+	if _, err := svc.GetNode(context.Background(), "t1", "n1"); err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if _, err := svc.GetNode(context.Background(), "t1", "missing"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Fatalf("GetNode(missing) error = %v", err)
+	}
+
+	if len(access.touched) != 1 || access.touched[0] != "n1" {
+		t.Errorf("touched = %v, want only n1", access.touched)
+	}
+}
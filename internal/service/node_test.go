@@ -160,7 +160,7 @@ func TestNodeService_DeleteNode(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			store := &mockNodeStore{
-				deleteNode: func(_ context.Context, _, _ string) error { return tc.storeErr },
+				deleteNode: func(_ context.Context, _, _, _ string) error { return tc.storeErr },
 			}
 			auditor := &mockAuditor{}
 			log := logrus.New()
@@ -171,7 +171,7 @@ func TestNodeService_DeleteNode(t *testing.T) {
 			defer cancel()
 
 			svc := NewNodeService(store, &mockEmbedEnqueuer{}, aw, log)
-			err := svc.DeleteNode(context.Background(), "t1", "n1")
+			err := svc.DeleteNode(context.Background(), "t1", "n1", models.DeleteDetach)
 
 			if tc.storeErr != nil && err == nil {
 				t.Fatal("expected error")
@@ -189,6 +189,74 @@ func TestNodeService_DeleteNode(t *testing.T) {
 	}
 }
 
+func TestNodeService_MergeNode(t *testing.T) {
+	store := &mockNodeStore{}
+	embedEnq := &mockEmbedEnqueuer{}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	svc := NewNodeService(store, embedEnq, nil, log)
+	result, err := svc.MergeNode(context.Background(), "t1", "dup", "canonical", models.MergeNodeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TargetID != "canonical" {
+		t.Errorf("target = %q, want canonical", result.TargetID)
+	}
+
+	// The merged target is re-embedded because its properties may have changed.
+	if len(embedEnq.jobs) != 1 || embedEnq.jobs[0].NodeID != "canonical" {
+		t.Errorf("expected 1 embed job for canonical, got %v", embedEnq.jobs)
+	}
+}
+
+func TestNodeService_DeleteByFilter(t *testing.T) {
+	store := &mockNodeStore{}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	svc := NewNodeService(store, nil, nil, log)
+	filter := models.NodeFilter{Type: "deprecated"}
+
+	preview, err := svc.PreviewDeleteByFilter(context.Background(), "t1", filter)
+	if err != nil || preview.Token == "" {
+		t.Fatalf("PreviewDeleteByFilter = %+v, %v", preview, err)
+	}
+
+	result, err := svc.DeleteByFilter(context.Background(), "t1", models.DeleteByFilterRequest{Filter: filter, Token: preview.Token})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.NodesDeleted != 2 {
+		t.Errorf("nodes deleted = %d, want 2", result.NodesDeleted)
+	}
+	if len(store.calls) != 2 || store.calls[1] != "DeleteByFilter" {
+		t.Errorf("store calls = %v", store.calls)
+	}
+}
+
+func TestNodeService_MigrateNode_DryRun(t *testing.T) {
+	embed := &mockEmbedEnqueuer{}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewNodeService(&mockNodeStore{}, embed, nil, log)
+
+	result, err := svc.MigrateNode(context.Background(), "t1", "n1", models.MigrateNodeRequest{NewID: "n2", DryRun: true})
+	if err != nil || !result.DryRun {
+		t.Fatalf("dry run = %+v, %v", result, err)
+	}
+	if len(embed.jobs) != 0 {
+		t.Errorf("dry run enqueued %d embed jobs, want 0", len(embed.jobs))
+	}
+
+	if _, err := svc.MigrateNode(context.Background(), "t1", "n1", models.MigrateNodeRequest{NewID: "n2"}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if len(embed.jobs) != 1 || embed.jobs[0].NodeID != "n2" {
+		t.Errorf("migrate enqueued %+v, want one job for n2", embed.jobs)
+	}
+}
+
 func TestNodeService_GetNode(t *testing.T) {
 	store := &mockNodeStore{
 		getNode: func(_ context.Context, _, _ string) (*models.Node, error) {
@@ -213,7 +281,7 @@ func TestNodeService_GetNode(t *testing.T) {
 
 func TestNodeService_ListNodes(t *testing.T) {
 	store := &mockNodeStore{
-		listNodes: func(_ context.Context, _ string, _ string, _ float64, _, _ int) ([]models.Node, bool, error) {
+		listNodes: func(_ context.Context, _ string, _ models.NodeListOpts) ([]models.Node, bool, error) {
 			return []models.Node{{ID: "n1"}, {ID: "n2"}}, true, nil
 		},
 	}
@@ -221,7 +289,7 @@ func TestNodeService_ListNodes(t *testing.T) {
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewNodeService(store, &mockEmbedEnqueuer{}, nil, log)
 
-	nodes, hasMore, err := svc.ListNodes(context.Background(), "t1", "", 0, 10, 0)
+	nodes, hasMore, err := svc.ListNodes(context.Background(), "t1", models.NodeListOpts{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// QuarantineStore is the data-access interface QuarantineService depends on.
+type QuarantineStore interface {
+	Quarantine(ctx context.Context, tenantID, nodeID string, match models.InjectionMatch) error
+	Release(ctx context.Context, tenantID, nodeID string) error
+	ListQuarantined(ctx context.Context, tenantID string, limit int) ([]models.QuarantinedNode, error)
+	QuarantinedNodeIDs(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error)
+}
+
+// NodeScanner checks a node after it is written.
+type NodeScanner interface {
+	ScanNode(ctx context.Context, tenantID string, node *models.Node)
+}
+
+// scanExportNode passes an imported node to scanner, if one is set.
+func scanExportNode(ctx context.Context, scanner NodeScanner, tenantID string, n *models.ExportNode) {
+	if scanner != nil {
+		scanner.ScanNode(ctx, tenantID, &models.Node{ID: n.ID, Type: n.Type, Label: n.Label, Properties: n.Properties})
+	}
+}
+
+// QuarantineChecker reports which nodes are quarantined.
+type QuarantineChecker interface {
+	QuarantinedNodeIDs(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error)
+}
+
+// Compile-time checks.
+var (
+	_ domain.QuarantineService = (*QuarantineService)(nil)
+	_ NodeScanner              = (*QuarantineService)(nil)
+	_ QuarantineChecker        = (*QuarantineService)(nil)
+)
+
+// QuarantineService flags nodes whose label or properties look like a
+// prompt-injection attempt. Flagged nodes are left out of recall packs until
+// an admin releases them.
+type QuarantineService struct {
+	store       QuarantineStore
+	auditWorker AuditEnqueuer
+	log         *logrus.Logger
+}
+
+// NewQuarantineService creates a QuarantineService.
+func NewQuarantineService(store QuarantineStore, auditWorker AuditEnqueuer, log *logrus.Logger) *QuarantineService {
+	return &QuarantineService{store: store, auditWorker: auditWorker, log: log}
+}
+
+// ScanNode quarantines node when ScanForInjection finds a match. A clean
+// node keeps any earlier flag, which only a release clears. Failures are
+// logged rather than returned, since the write being scanned has already
+// committed.
+func (s *QuarantineService) ScanNode(ctx context.Context, tenantID string, node *models.Node) {
+	match, ok := ScanForInjection(node.Label, node.Properties)
+	if !ok {
+		return
+	}
+
+	if err := s.store.Quarantine(ctx, tenantID, node.ID, match); err != nil {
+		s.log.WithError(err).WithFields(logrus.Fields{"tenant_id": tenantID, "node_id": node.ID}).Error("quarantining node")
+		return
+	}
+
+	auditAsync(s.auditWorker, tenantID, "node.quarantine", "node", node.ID, map[string]any{
+		"field": match.Field, "pattern": match.Pattern,
+	})
+}
+
+// ListQuarantined returns up to limit quarantined nodes, most recent first.
+func (s *QuarantineService) ListQuarantined(ctx context.Context, tenantID string, limit int) (_ []models.QuarantinedNode, err error) {
+	ctx, span := startSpan(ctx, "QuarantineService.ListQuarantined", tenantID, tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	return s.store.ListQuarantined(ctx, tenantID, limit)
+}
+
+// ReleaseNode clears a node's quarantine after review.
+func (s *QuarantineService) ReleaseNode(ctx context.Context, tenantID, nodeID string) (err error) {
+	ctx, span := startSpan(ctx, "QuarantineService.ReleaseNode", tenantID, tracing.String("node_id", nodeID))
+	defer endSpan(span, &err)
+
+	if err := s.store.Release(ctx, tenantID, nodeID); err != nil {
+		return err
+	}
+
+	auditAsync(s.auditWorker, tenantID, "node.release", "node", nodeID, nil)
+
+	return nil
+}
+
+// QuarantinedNodeIDs returns which of nodeIDs are quarantined (pass-through).
+func (s *QuarantineService) QuarantinedNodeIDs(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error) {
+	return s.store.QuarantinedNodeIDs(ctx, tenantID, nodeIDs)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// modelNamer is implemented by embedders that report their model, such as
+// EmbeddingService, so cached query embeddings never outlive a model change.
+type modelNamer interface {
+	Model() string
+}
+
+// queryEmbeddingCache is an in-memory LRU of search query embeddings whose
+// entries expire after ttl.
+type queryEmbeddingCache struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type queryEmbeddingEntry struct {
+	key       string
+	embedding []float32
+	storedAt  time.Time
+}
+
+// WithQueryEmbeddingCache keeps up to maxEntries query embeddings in memory
+// for ttl, so repeated semantic and hybrid searches skip the embedding call.
+// Entries are keyed by tenant, embedding model, and normalized query; the
+// tenant keeps one tenant's hit latency from revealing another's queries.
+func (s *SearchService) WithQueryEmbeddingCache(maxEntries int, ttl time.Duration) *SearchService {
+	if maxEntries <= 0 || ttl <= 0 {
+		s.queryCache = nil
+		return s
+	}
+
+	s.queryCache = &queryEmbeddingCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+
+	return s
+}
+
+// queryEmbedding embeds a search query, from the query cache when it has it.
+func (s *SearchService) queryEmbedding(ctx context.Context, tenantID, query string) ([]float32, error) {
+	if s.queryCache == nil {
+		return s.embedder.Generate(withUsageTenant(ctx, tenantID), query)
+	}
+
+	var model string
+	if namer, ok := s.embedder.(modelNamer); ok {
+		model = namer.Model()
+	}
+
+	key := queryEmbeddingKey(tenantID, model, query)
+	if embedding := s.queryCache.get(key); embedding != nil {
+		metrics.QueryEmbeddingCacheRequests.WithLabelValues("hit").Inc()
+		return embedding, nil
+	}
+
+	metrics.QueryEmbeddingCacheRequests.WithLabelValues("miss").Inc()
+
+	embedding, err := s.embedder.Generate(withUsageTenant(ctx, tenantID), query)
+	if err != nil {
+		return nil, err
+	}
+
+	s.queryCache.put(key, embedding)
+
+	return embedding, nil
+}
+
+// queryEmbeddingKey normalizes query to NFC, lower case, and single spaces.
+func queryEmbeddingKey(tenantID, model, query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(models.NormalizeText(query))), " ")
+
+	return tenantID + "\x00" + model + "\x00" + normalized
+}
+
+// get returns the cached embedding for key, or nil when it is missing or
+// expired. Expired entries are dropped.
+func (c *queryEmbeddingCache) get(key string) []float32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry, _ := elem.Value.(*queryEmbeddingEntry)
+	if c.now().Sub(entry.storedAt) >= c.ttl {
+		c.remove(elem)
+		return nil
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.embedding
+}
+
+// put caches embedding under key, evicting the least recently used entry
+// when the cache is full.
+func (c *queryEmbeddingCache) put(key string, embedding []float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	c.entries[key] = c.order.PushFront(&queryEmbeddingEntry{key: key, embedding: embedding, storedAt: c.now()})
+
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+
+	metrics.QueryEmbeddingCacheEntries.Set(float64(c.order.Len()))
+}
+
+// remove drops elem. The caller holds mu.
+func (c *queryEmbeddingCache) remove(elem *list.Element) {
+	entry, _ := elem.Value.(*queryEmbeddingEntry)
+	c.order.Remove(elem)
+	delete(c.entries, entry.key)
+	metrics.QueryEmbeddingCacheEntries.Set(float64(c.order.Len()))
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+func TestSearchService_QueryEmbeddingCache(t *testing.T) {
+	var generated []string
+	embedder := &mockEmbedder{
+		generate: func(_ context.Context, text string) ([]float32, error) {
+			generated = append(generated, text)
+			return []float32{float32(len(generated))}, nil
+		},
+	}
+	store := &mockSearchStore{
+		semanticSearch: func(_ context.Context, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.ScoredNode, error) {
+			return nil, nil
+		},
+	}
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewSearchService(store, embedder, log).WithQueryEmbeddingCache(2, time.Minute)
+
+	now := time.Now()
+	svc.queryCache.now = func() time.Time { return now }
+
+	search := func(tenantID, query string) {
+		t.Helper()
+		if _, err := svc.SemanticSearch(context.Background(), tenantID, query, models.SearchFilter{}, 5); err != nil {
+			t.Fatalf("SemanticSearch(%q): %v", query, err)
+		}
+	}
+
+	search("t1", "Where does Alice work")
+	search("t1", "  where does   alice WORK ")
+	if len(generated) != 1 {
+		t.Fatalf("expected the normalized repeat to hit the cache, embedded %v", generated)
+	}
+
+	search("t2", "Where does Alice work")
+	if len(generated) != 2 {
+		t.Fatalf("expected another tenant to miss, embedded %v", generated)
+	}
+
+	// A third entry evicts the least recently used, tenant t1's.
+	search("t1", "Who is Bob")
+	search("t1", "Where does Alice work")
+	if len(generated) != 4 {
+		t.Fatalf("expected the evicted query to be embedded again, embedded %v", generated)
+	}
+
+	now = now.Add(time.Minute)
+	search("t1", "Where does Alice work")
+	if len(generated) != 5 {
+		t.Fatalf("expected the expired entry to be embedded again, embedded %v", generated)
+	}
+}
+
+func TestSearchService_QueryEmbeddingCacheDisabled(t *testing.T) {
+	svc := NewSearchService(nil, nil, nil).WithQueryEmbeddingCache(0, time.Minute)
+	if svc.queryCache != nil {
+		t.Error("expected a zero size to leave the cache disabled")
+	}
+}
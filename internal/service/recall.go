@@ -10,6 +10,7 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 var recallDecisionKinds = []string{models.EventKindDecision, models.EventKindTask, models.EventKindPromise}
@@ -17,37 +18,46 @@
 // RecallStore defines the narrow data access required for recall-pack assembly.
 type RecallStore interface {
 	GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error)
-	Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error)
+	Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (*models.NeighborResult, error)
 	ListEventContexts(ctx context.Context, tenantID string, nodeIDs []string, kinds []string, limit int) ([]models.RecallEventContext, error)
 }
 
 // RecallService assembles compact deterministic recall packs for active topics.
 type RecallService struct {
-	store RecallStore
-	log   *logrus.Logger
+	store      RecallStore
+	quarantine QuarantineChecker
+	log        *logrus.Logger
 }
 
 func NewRecallService(store RecallStore, log *logrus.Logger) *RecallService {
 	return &RecallService{store: store, log: log}
 }
 
-func (s *RecallService) BuildRecallPack(ctx context.Context, tenantID string, req models.RecallPackRequest) (*models.RecallPack, error) {
+// WithQuarantine leaves quarantined nodes out of recall packs, both as core
+// entities and as neighbors.
+func (s *RecallService) WithQuarantine(quarantine QuarantineChecker) *RecallService {
+	s.quarantine = quarantine
+	return s
+}
+
+// quarantined returns which of nodeIDs are quarantined; none when no
+// checker is set.
+func (s *RecallService) quarantined(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error) {
+	if s.quarantine == nil {
+		return nil, nil
+	}
+
+	return s.quarantine.QuarantinedNodeIDs(ctx, tenantID, nodeIDs)
+}
+
+func (s *RecallService) BuildRecallPack(ctx context.Context, tenantID string, req models.RecallPackRequest) (_ *models.RecallPack, err error) {
+	ctx, span := startSpan(ctx, "RecallService.BuildRecallPack", tenantID, tracing.Int("node_count", len(req.NodeIDs)))
+	defer endSpan(span, &err)
+
 	req = req.Normalized()
-	coreNodes := make([]models.Node, 0, len(req.NodeIDs))
-	seenNodeIDs := make(map[string]struct{}, len(req.NodeIDs))
-	for _, nodeID := range req.NodeIDs {
-		if nodeID == "" {
-			continue
-		}
-		if _, ok := seenNodeIDs[nodeID]; ok {
-			continue
-		}
-		seenNodeIDs[nodeID] = struct{}{}
-		node, err := s.store.GetNode(ctx, tenantID, nodeID)
-		if err != nil {
-			return nil, err
-		}
-		coreNodes = append(coreNodes, *node)
+	coreNodes, err := s.loadCoreNodes(ctx, tenantID, req.NodeIDs)
+	if err != nil {
+		return nil, err
 	}
 	sort.SliceStable(coreNodes, func(i, j int) bool {
 		if coreNodes[i].Salience != coreNodes[j].Salience {
@@ -60,21 +70,23 @@ func (s *RecallService) BuildRecallPack(ctx context.Context, tenantID string, re
 	})
 
 	pack := &models.RecallPack{CoreEntities: make([]models.RecallEntity, 0, len(coreNodes))}
+	coreIDs := make([]string, 0, len(coreNodes))
 	for _, node := range coreNodes {
 		pack.CoreEntities = append(pack.CoreEntities, models.RecallEntity{ID: node.ID, Type: node.Type, Label: node.Label, Salience: node.Salience})
+		coreIDs = append(coreIDs, node.ID)
 	}
 
 	pack.NotableNeighbors = s.buildNeighbors(ctx, tenantID, coreNodes, req.NeighborLimit)
 	pack.Contradictions = buildContradictions(coreNodes, req.ContradictionLimit)
 	pack.StrongestEvidence = buildEvidence(coreNodes, req.EvidenceLimit)
 
-	eventContexts, err := s.store.ListEventContexts(ctx, tenantID, req.NodeIDs, nil, req.RecentEpisodeLimit*3)
+	eventContexts, err := s.store.ListEventContexts(ctx, tenantID, coreIDs, nil, req.RecentEpisodeLimit*3)
 	if err != nil {
 		return nil, err
 	}
 	pack.RecentEpisodes = buildRecentEpisodes(eventContexts, req.RecentEpisodeLimit)
 
-	decisionContexts, err := s.store.ListEventContexts(ctx, tenantID, req.NodeIDs, recallDecisionKinds, req.OpenDecisionLimit*2)
+	decisionContexts, err := s.store.ListEventContexts(ctx, tenantID, coreIDs, recallDecisionKinds, req.OpenDecisionLimit*2)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +96,31 @@ func (s *RecallService) BuildRecallPack(ctx context.Context, tenantID string, re
 	return pack, nil
 }
 
+// loadCoreNodes fetches each requested node once, skipping quarantined ones.
+func (s *RecallService) loadCoreNodes(ctx context.Context, tenantID string, nodeIDs []string) ([]models.Node, error) {
+	quarantined, err := s.quarantined(ctx, tenantID, nodeIDs)
+	if err != nil {
+		return nil, err
+	}
+	coreNodes := make([]models.Node, 0, len(nodeIDs))
+	seenNodeIDs := make(map[string]struct{}, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID == "" {
+			continue
+		}
+		if _, ok := seenNodeIDs[nodeID]; ok || quarantined[nodeID] {
+			continue
+		}
+		seenNodeIDs[nodeID] = struct{}{}
+		node, err := s.store.GetNode(ctx, tenantID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		coreNodes = append(coreNodes, *node)
+	}
+	return coreNodes, nil
+}
+
 func (s *RecallService) buildNeighbors(ctx context.Context, tenantID string, coreNodes []models.Node, limit int) []models.RecallNeighbor {
 	type agg struct {
 		neighbor    models.Node
@@ -96,14 +133,11 @@ type agg struct {
 	}
 	byKey := map[string]*agg{}
 	for _, node := range coreNodes {
-		result, err := s.store.Neighbors(ctx, tenantID, node.ID, limit*3)
+		result, err := s.store.Neighbors(ctx, tenantID, node.ID, limit*3, models.ReadOpts{})
 		if err != nil || result == nil {
 			continue
 		}
-		nodeByID := map[string]models.Node{}
-		for _, neighbor := range result.Nodes {
-			nodeByID[neighbor.ID] = neighbor
-		}
+		nodeByID := s.allowedNeighbors(ctx, tenantID, result.Nodes)
 		for _, edge := range result.Edges {
 			var neighborID, direction string
 			switch {
@@ -176,6 +210,27 @@ type agg struct {
 	return out
 }
 
+// allowedNeighbors indexes neighbors by ID, leaving out quarantined ones.
+// When the quarantine cannot be checked, none are allowed.
+func (s *RecallService) allowedNeighbors(ctx context.Context, tenantID string, neighbors []models.Node) map[string]models.Node {
+	ids := make([]string, len(neighbors))
+	for i, neighbor := range neighbors {
+		ids[i] = neighbor.ID
+	}
+	quarantined, err := s.quarantined(ctx, tenantID, ids)
+	if err != nil {
+		s.log.WithError(err).WithField("tenant_id", tenantID).Warn("recall: checking quarantine")
+		return nil
+	}
+	nodeByID := make(map[string]models.Node, len(neighbors))
+	for _, neighbor := range neighbors {
+		if !quarantined[neighbor.ID] {
+			nodeByID[neighbor.ID] = neighbor
+		}
+	}
+	return nodeByID
+}
+
 func buildRecentEpisodes(contexts []models.RecallEventContext, limit int) []models.RecallEpisode {
 	type assembledEpisode struct {
 		recall          models.RecallEpisode
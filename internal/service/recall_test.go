@@ -124,7 +124,7 @@ func (m *mockRecallStore) GetNode(ctx context.Context, tenantID, nodeID string)
 	return m.getNode(ctx, tenantID, nodeID)
 }
 
-func (m *mockRecallStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error) {
+func (m *mockRecallStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int, _ models.ReadOpts) (*models.NeighborResult, error) {
 	return m.neighbors(ctx, tenantID, nodeID, limit)
 }
 
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// reembedRetryInterval is how often ReembedWorker.Run retries running
+// re-embeds, such as those interrupted by an embedding service outage.
+const reembedRetryInterval = time.Minute
+
+// ReembedStore is the data-access interface ReembedWorker depends on.
+type ReembedStore interface {
+	EmbeddingUpdater
+	StartReembed(ctx context.Context, tenantID, model string, dimensions int) (*models.ReembedStatus, error)
+	GetReembedStatus(ctx context.Context, tenantID string) (*models.ReembedStatus, error)
+	ListRunningReembeds(ctx context.Context) ([]models.ReembedStatus, error)
+	ListNodesForReembed(ctx context.Context, tenantID, model, afterID string, limit int) ([]models.NodeSummary, error)
+	RecordReembedProgress(ctx context.Context, tenantID, lastNodeID string, processed, failed int) error
+	FinishReembed(ctx context.Context, tenantID, status, errMsg string) error
+}
+
+// BatchEmbedder generates embeddings for several texts at once with a known
+// model.
+type BatchEmbedder interface {
+	Model() string
+	Dimensions() int
+	GenerateBatch(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Compile-time check: *ReembedWorker must satisfy domain.ReembedService.
+var _ domain.ReembedService = (*ReembedWorker)(nil)
+
+// ReembedWorker moves tenants' embeddings to the configured model. A run is
+// recorded by StartReembed and carried out by Run in batches, so it survives
+// restarts: a run interrupted part way resumes after the last node it
+// finished.
+type ReembedWorker struct {
+	store     ReembedStore
+	embed     BatchEmbedder
+	log       *logrus.Logger
+	batchSize int
+	wake      chan struct{}
+}
+
+// NewReembedWorker creates a ReembedWorker that embeds batches of batchSize
+// nodes.
+func NewReembedWorker(store ReembedStore, embed BatchEmbedder, log *logrus.Logger, batchSize int) *ReembedWorker {
+	return &ReembedWorker{
+		store:     store,
+		embed:     embed,
+		log:       log,
+		batchSize: max(batchSize, 1),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// StartReembed starts regenerating every tenant embedding not produced by
+// the configured model. Vector search is refused until the run completes.
+func (w *ReembedWorker) StartReembed(ctx context.Context, tenantID string) (*models.ReembedStatus, error) {
+	run, err := w.store.StartReembed(ctx, tenantID, w.embed.Model(), w.embed.Dimensions())
+	if err != nil {
+		return nil, err
+	}
+
+	w.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"model":     run.TargetModel,
+		"total":     run.Total,
+	}).Info("reembed.start")
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+
+	return run, nil
+}
+
+// ReembedStatus returns the tenant's latest re-embed run.
+func (w *ReembedWorker) ReembedStatus(ctx context.Context, tenantID string) (*models.ReembedStatus, error) {
+	return w.store.GetReembedStatus(ctx, tenantID)
+}
+
+// ReembedInProgress reports whether the tenant has a running re-embed.
+func (w *ReembedWorker) ReembedInProgress(ctx context.Context, tenantID string) (bool, error) {
+	run, err := w.store.GetReembedStatus(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, models.ErrReembedNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return run.Status == models.ReembedRunning, nil
+}
+
+// Run carries out every running re-embed on startup, whenever one is
+// started, and every reembedRetryInterval until ctx is cancelled.
+func (w *ReembedWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(reembedRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		w.runAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runAll works through each running re-embed in turn. A failure for one
+// tenant is logged and does not stop the others.
+func (w *ReembedWorker) runAll(ctx context.Context) {
+	runs, err := w.store.ListRunningReembeds(ctx)
+	if err != nil {
+		w.log.WithError(err).Warn("listing running re-embeds")
+		return
+	}
+
+	for i := range runs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := w.reembed(ctx, &runs[i]); err != nil {
+			w.log.WithError(err).WithField("tenant_id", runs[i].TenantID).Warn("re-embedding nodes")
+		}
+	}
+}
+
+// reembed regenerates run's remaining embeddings batch by batch. When the
+// embedding service fails it returns, leaving the run to be retried; a node
+// whose embedding cannot be stored is counted as failed and skipped.
+func (w *ReembedWorker) reembed(ctx context.Context, run *models.ReembedStatus) error {
+	if run.TargetModel != w.embed.Model() || run.TargetDimensions != w.embed.Dimensions() {
+		msg := fmt.Sprintf("server now embeds with %s (%d dimensions); start a new re-embed",
+			w.embed.Model(), w.embed.Dimensions())
+		return w.store.FinishReembed(ctx, run.TenantID, models.ReembedFailed, msg)
+	}
+
+	after := run.LastNodeID
+	for ctx.Err() == nil {
+		nodes, err := w.store.ListNodesForReembed(ctx, run.TenantID, run.TargetModel, after, w.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(nodes) == 0 {
+			w.log.WithField("tenant_id", run.TenantID).Info("reembed.complete")
+			return w.store.FinishReembed(ctx, run.TenantID, models.ReembedCompleted, "")
+		}
+
+		processed, failed, err := w.reembedBatch(ctx, run.TenantID, nodes)
+		if err != nil {
+			return err
+		}
+
+		after = nodes[len(nodes)-1].ID
+		if err := w.store.RecordReembedProgress(ctx, run.TenantID, after, processed, failed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reembedBatch embeds and stores one batch of nodes, returning how many
+// were stored and how many failed to store.
+func (w *ReembedWorker) reembedBatch(
+	ctx context.Context, tenantID string, nodes []models.NodeSummary,
+) (processed, failed int, err error) {
+	texts := make([]string, len(nodes))
+	for i, n := range nodes {
+		texts[i] = n.EmbeddingText()
+	}
+
+	embeddings, err := w.embed.GenerateBatch(withUsageTenant(ctx, tenantID), texts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("generating embeddings: %w", err)
+	}
+
+	for i, n := range nodes {
+		if err := w.store.UpdateNodeEmbedding(ctx, tenantID, n.ID, embeddings[i]); err != nil {
+			w.log.WithError(err).WithField("node_id", n.ID).Warn("storing re-embedded node")
+			failed++
+
+			continue
+		}
+
+		processed++
+	}
+
+	return processed, failed, nil
+}
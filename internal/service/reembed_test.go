@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// fakeReembedStore keeps one tenant's nodes and re-embed run in memory.
+type fakeReembedStore struct {
+	nodes     map[string]string // node ID -> embedding model
+	run       *models.ReembedStatus
+	failStore map[string]bool
+}
+
+func (f *fakeReembedStore) UpdateNodeEmbedding(_ context.Context, _, nodeID string, _ []float32) error {
+	if f.failStore[nodeID] {
+		return errors.New("write failed")
+	}
+	f.nodes[nodeID] = f.run.TargetModel
+	return nil
+}
+
+func (f *fakeReembedStore) StartReembed(_ context.Context, tenantID, model string, dimensions int) (*models.ReembedStatus, error) {
+	if f.run != nil && f.run.Status == models.ReembedRunning {
+		return nil, models.ErrReembedInProgress
+	}
+	f.run = &models.ReembedStatus{TenantID: tenantID, TargetModel: model, TargetDimensions: dimensions, Status: models.ReembedRunning}
+	return f.run, nil
+}
+
+func (f *fakeReembedStore) GetReembedStatus(_ context.Context, _ string) (*models.ReembedStatus, error) {
+	if f.run == nil {
+		return nil, models.ErrReembedNotFound
+	}
+	return f.run, nil
+}
+
+func (f *fakeReembedStore) ListRunningReembeds(_ context.Context) ([]models.ReembedStatus, error) {
+	if f.run == nil || f.run.Status != models.ReembedRunning {
+		return nil, nil
+	}
+	return []models.ReembedStatus{*f.run}, nil
+}
+
+func (f *fakeReembedStore) ListNodesForReembed(_ context.Context, _, model, afterID string, limit int) ([]models.NodeSummary, error) {
+	var ids []string
+	for id, m := range f.nodes {
+		if m != model && id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var out []models.NodeSummary
+	for _, id := range ids[:min(limit, len(ids))] {
+		out = append(out, models.NodeSummary{ID: id, Type: "concept", Label: id})
+	}
+	return out, nil
+}
+
+func (f *fakeReembedStore) RecordReembedProgress(_ context.Context, _, lastNodeID string, processed, failed int) error {
+	f.run.LastNodeID = lastNodeID
+	f.run.Processed += processed
+	f.run.Failed += failed
+	return nil
+}
+
+func (f *fakeReembedStore) FinishReembed(_ context.Context, _, status, errMsg string) error {
+	f.run.Status = status
+	f.run.Error = errMsg
+	return nil
+}
+
+type fakeBatchEmbedder struct {
+	model string
+	err   error
+	calls int
+}
+
+func (f *fakeBatchEmbedder) Model() string   { return f.model }
+func (f *fakeBatchEmbedder) Dimensions() int { return 3 }
+
+func (f *fakeBatchEmbedder) GenerateBatch(_ context.Context, texts []string) ([][]float32, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([][]float32, len(texts))
+	for i := range texts {
+		out[i] = []float32{1, 0, 0}
+	}
+	return out, nil
+}
+
+func newTestReembedWorker(store *fakeReembedStore, embed *fakeBatchEmbedder) *ReembedWorker {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	return NewReembedWorker(store, embed, log, 2)
+}
+
+func TestReembedWorker_RegeneratesStaleEmbeddings(t *testing.T) {
+	store := &fakeReembedStore{
+		nodes:     map[string]string{"a": "old", "b": "", "c": "new", "d": "old", "e": "old"},
+		failStore: map[string]bool{"d": true},
+	}
+	embed := &fakeBatchEmbedder{model: "new"}
+	w := newTestReembedWorker(store, embed)
+	ctx := context.Background()
+
+	if _, err := w.StartReembed(ctx, "t1"); err != nil {
+		t.Fatalf("StartReembed: %v", err)
+	}
+	if _, err := w.StartReembed(ctx, "t1"); !errors.Is(err, models.ErrReembedInProgress) {
+		t.Fatalf("second StartReembed err = %v, want ErrReembedInProgress", err)
+	}
+	if running, err := w.ReembedInProgress(ctx, "t1"); err != nil || !running {
+		t.Fatalf("ReembedInProgress = %v, %v; want true", running, err)
+	}
+
+	w.runAll(ctx)
+
+	run, _ := w.ReembedStatus(ctx, "t1") //nolint:errcheck // fake store.
+	if run.Status != models.ReembedCompleted || run.Processed != 3 || run.Failed != 1 {
+		t.Fatalf("run = %+v, want completed with 3 processed and 1 failed", run)
+	}
+	if embed.calls != 2 {
+		t.Errorf("GenerateBatch called %d times, want 2 batches of 2", embed.calls)
+	}
+	if running, _ := w.ReembedInProgress(ctx, "t1"); running { //nolint:errcheck // fake store.
+		t.Error("ReembedInProgress after completion = true")
+	}
+}
+
+func TestReembedWorker_EmbeddingOutageLeavesRunToRetry(t *testing.T) {
+	store := &fakeReembedStore{nodes: map[string]string{"a": "old"}}
+	embed := &fakeBatchEmbedder{model: "new", err: errors.New("ollama down")}
+	w := newTestReembedWorker(store, embed)
+	ctx := context.Background()
+
+	if _, err := w.StartReembed(ctx, "t1"); err != nil {
+		t.Fatalf("StartReembed: %v", err)
+	}
+
+	w.runAll(ctx)
+	if store.run.Status != models.ReembedRunning || store.run.LastNodeID != "" {
+		t.Fatalf("run after outage = %+v, want still running from the start", store.run)
+	}
+
+	embed.err = nil
+	w.runAll(ctx)
+	if store.run.Status != models.ReembedCompleted || store.run.Processed != 1 {
+		t.Fatalf("run after retry = %+v, want completed", store.run)
+	}
+}
+
+func TestReembedWorker_FailsRunForAnotherModel(t *testing.T) {
+	store := &fakeReembedStore{
+		nodes: map[string]string{"a": "old"},
+		run:   &models.ReembedStatus{TenantID: "t1", TargetModel: "other", TargetDimensions: 3, Status: models.ReembedRunning},
+	}
+	w := newTestReembedWorker(store, &fakeBatchEmbedder{model: "new"})
+
+	w.runAll(context.Background())
+
+	if store.run.Status != models.ReembedFailed || store.run.Error == "" {
+		t.Errorf("run = %+v, want failed with a reason", store.run)
+	}
+}
+
+type fakeReembedGuard bool
+
+func (g fakeReembedGuard) ReembedInProgress(_ context.Context, _ string) (bool, error) {
+	return bool(g), nil
+}
+
+func TestSearchService_RefusesVectorSearchDuringReembed(t *testing.T) {
+	embedder := &mockEmbedder{generate: func(_ context.Context, _ string) ([]float32, error) {
+		t.Fatal("embedding generated during a re-embed")
+		return nil, nil
+	}}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewSearchService(&mockSearchStore{}, embedder, log).WithReembedGuard(fakeReembedGuard(true))
+
+	if _, err := svc.SemanticSearch(context.Background(), "t1", "query", models.SearchFilter{}, 10); !errors.Is(err, models.ErrReembedInProgress) {
+		t.Errorf("SemanticSearch err = %v, want ErrReembedInProgress", err)
+	}
+	if _, err := svc.HybridSearch(context.Background(), "t1", "query", models.SearchFilter{}, 10); !errors.Is(err, models.ErrReembedInProgress) {
+		t.Errorf("HybridSearch err = %v, want ErrReembedInProgress", err)
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// RelationCatalogStore is the data-access interface RelationCatalogService
+// depends on.
+type RelationCatalogStore interface {
+	GetRelationCatalog(ctx context.Context, tenantID string) (*models.RelationCatalog, error)
+	SetRelationCatalog(ctx context.Context, tenantID string, catalog models.RelationCatalog) (*models.RelationCatalog, error)
+	ListRelationUsage(ctx context.Context, tenantID string) ([]models.RelationUsage, error)
+}
+
+// Compile-time check: *RelationCatalogService must satisfy domain.RelationCatalogService.
+var _ domain.RelationCatalogService = (*RelationCatalogService)(nil)
+
+// RelationCatalogService manages the tenant's catalog of allowed (source
+// type, relation, target type) triples. The store checks new edges against
+// it while enforcement is on.
+type RelationCatalogService struct {
+	store       RelationCatalogStore
+	auditWorker AuditEnqueuer
+	log         *logrus.Logger
+}
+
+// NewRelationCatalogService creates a RelationCatalogService.
+func NewRelationCatalogService(store RelationCatalogStore, auditWorker AuditEnqueuer, log *logrus.Logger) *RelationCatalogService {
+	return &RelationCatalogService{store: store, auditWorker: auditWorker, log: log}
+}
+
+// GetRelationCatalog returns the tenant's relation catalog (pass-through).
+func (s *RelationCatalogService) GetRelationCatalog(ctx context.Context, tenantID string) (*models.RelationCatalog, error) {
+	return s.store.GetRelationCatalog(ctx, tenantID)
+}
+
+// SetRelationCatalog validates and replaces the tenant's relation catalog.
+// It applies to edges written afterwards; existing edges are left alone.
+func (s *RelationCatalogService) SetRelationCatalog(
+	ctx context.Context, tenantID string, catalog models.RelationCatalog,
+) (_ *models.RelationCatalog, err error) {
+	ctx, span := startSpan(ctx, "RelationCatalogService.SetRelationCatalog", tenantID)
+	defer endSpan(span, &err)
+
+	if err := catalog.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"enforce":   catalog.Enforce,
+		"rules":     len(catalog.Rules),
+	}).Debug("relation_catalog.set")
+
+	result, err := s.store.SetRelationCatalog(ctx, tenantID, catalog)
+	if err != nil {
+		return nil, err
+	}
+
+	auditAsync(s.auditWorker, tenantID, "relation_catalog.set", "tenant", tenantID, map[string]any{
+		"enforce": result.Enforce, "rules": len(result.Rules),
+	})
+
+	return result, nil
+}
+
+// ListRelationUsage returns the relation triples in use with their edge
+// counts (pass-through).
+func (s *RelationCatalogService) ListRelationUsage(ctx context.Context, tenantID string) ([]models.RelationUsage, error) {
+	return s.store.ListRelationUsage(ctx, tenantID)
+}
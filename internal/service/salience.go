@@ -62,3 +62,18 @@ func (s *SalienceService) RecalculateSalience(ctx context.Context, tenantID stri
 
 	return count, nil
 }
+
+// TopSalientNodes returns the tenant's highest-salience nodes.
+func (s *SalienceService) TopSalientNodes(
+	ctx context.Context, tenantID, typeFilter string, limit int,
+) ([]models.SalienceEntry, error) {
+	return s.store.TopSalientNodes(ctx, tenantID, typeFilter, limit)
+}
+
+// DecayingNodes returns the nodes whose salience dropped most in the last
+// recalculation.
+func (s *SalienceService) DecayingNodes(
+	ctx context.Context, tenantID, typeFilter string, limit int,
+) ([]models.SalienceEntry, error) {
+	return s.store.DecayingNodes(ctx, tenantID, typeFilter, limit)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TenantLister lists the tenants a periodic job runs for.
+type TenantLister interface {
+	ListTenants(ctx context.Context) ([]models.Tenant, error)
+}
+
+// SalienceRecalculator recalculates a tenant's salience scores.
+type SalienceRecalculator interface {
+	RecalculateSalience(ctx context.Context, tenantID string) (int, error)
+}
+
+// SalienceScheduler recalculates every active tenant's salience on a cron
+// schedule, so scores decay without anyone calling /salience/recalc.
+type SalienceScheduler struct {
+	schedule    cron.Schedule
+	tenants     TenantLister
+	salience    SalienceRecalculator
+	auditWorker AuditEnqueuer
+	log         *logrus.Logger
+	now         func() time.Time
+}
+
+// NewSalienceScheduler creates a SalienceScheduler for a standard
+// five-field cron spec, such as "0 3 * * *" for 03:00 daily.
+func NewSalienceScheduler(
+	spec string, tenants TenantLister, salience SalienceRecalculator, auditWorker AuditEnqueuer, log *logrus.Logger,
+) (*SalienceScheduler, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parsing salience recalc schedule: %w", err)
+	}
+
+	return &SalienceScheduler{
+		schedule: schedule, tenants: tenants, salience: salience, auditWorker: auditWorker, log: log, now: time.Now,
+	}, nil
+}
+
+// Run recalculates every tenant at each scheduled time until ctx is
+// cancelled. A run that overlaps the next scheduled time delays it.
+func (s *SalienceScheduler) Run(ctx context.Context) {
+	for {
+		next := s.schedule.Next(s.now())
+
+		timer := time.NewTimer(next.Sub(s.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Spread tenants over half the time until the following run.
+		s.runAll(ctx, s.schedule.Next(next).Sub(next)/2)
+	}
+}
+
+// runAll recalculates each active tenant in turn, starting the i-th of n
+// tenants i*window/n after the first so large installs do not recalculate
+// everything at once. A failure for one tenant does not stop the others.
+func (s *SalienceScheduler) runAll(ctx context.Context, window time.Duration) {
+	tenants, err := s.tenants.ListTenants(ctx)
+	if err != nil {
+		s.log.WithError(err).Warn("listing tenants for salience recalculation")
+		return
+	}
+
+	active := tenants[:0]
+	for _, t := range tenants {
+		if t.SuspendedAt == nil {
+			active = append(active, t)
+		}
+	}
+
+	start := s.now()
+
+	for i, t := range active {
+		if wait := start.Add(staggerOffset(i, len(active), window)).Sub(s.now()); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		s.recalculate(ctx, t.ID)
+	}
+}
+
+// recalculate recalculates one tenant and records the outcome in metrics
+// and the tenant's audit log.
+func (s *SalienceScheduler) recalculate(ctx context.Context, tenantID string) {
+	started := s.now()
+	updated, err := s.salience.RecalculateSalience(ctx, tenantID)
+	elapsed := s.now().Sub(started)
+
+	metrics.SalienceRecalcDuration.Observe(elapsed.Seconds())
+
+	if err != nil {
+		metrics.SalienceRecalcRuns.WithLabelValues("error").Inc()
+		s.log.WithError(err).WithField("tenant_id", tenantID).Warn("scheduled salience recalculation")
+
+		return
+	}
+
+	metrics.SalienceRecalcRuns.WithLabelValues("success").Inc()
+
+	if s.auditWorker != nil {
+		s.auditWorker.Enqueue(&AuditJob{
+			TenantID:   tenantID,
+			Action:     "salience.recalculate",
+			EntityType: "node",
+			Actor:      "scheduler",
+			Detail:     map[string]any{"updated": updated, "duration_ms": elapsed.Milliseconds()},
+		})
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"updated":   updated,
+		"duration":  elapsed.String(),
+	}).Info("salience.scheduled_recalc")
+}
+
+// staggerOffset is when the i-th of n tenants starts within window.
+func staggerOffset(i, n int, window time.Duration) time.Duration {
+	if n <= 1 || window <= 0 {
+		return 0
+	}
+
+	return window * time.Duration(i) / time.Duration(n)
+}
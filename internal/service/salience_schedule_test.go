@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockTenantLister struct {
+	tenants []models.Tenant
+}
+
+func (m *mockTenantLister) ListTenants(context.Context) ([]models.Tenant, error) {
+	return m.tenants, nil
+}
+
+type mockSalienceRecalculator struct {
+	calls []string
+	fail  map[string]bool
+}
+
+func (m *mockSalienceRecalculator) RecalculateSalience(_ context.Context, tenantID string) (int, error) {
+	m.calls = append(m.calls, tenantID)
+	if m.fail[tenantID] {
+		return 0, errors.New("boom")
+	}
+	return 3, nil
+}
+
+type recordingAuditWorker struct {
+	jobs []*AuditJob
+}
+
+func (w *recordingAuditWorker) Enqueue(job *AuditJob) {
+	w.jobs = append(w.jobs, job)
+}
+
+func TestSalienceScheduler_RunAll(t *testing.T) {
+	suspended := time.Now()
+	tenants := &mockTenantLister{tenants: []models.Tenant{
+		{ID: "t1"}, {ID: "t2", SuspendedAt: &suspended}, {ID: "t3"}, {ID: "t4"},
+	}}
+	salience := &mockSalienceRecalculator{fail: map[string]bool{"t3": true}}
+	audit := &recordingAuditWorker{}
+
+	s, err := NewSalienceScheduler("0 3 * * *", tenants, salience, audit, logrus.New())
+	if err != nil {
+		t.Fatalf("NewSalienceScheduler: %v", err)
+	}
+
+	s.runAll(context.Background(), 0)
+
+	// Suspended tenants are skipped; a failure does not stop the rest.
+	if got := salience.calls; len(got) != 3 || got[0] != "t1" || got[1] != "t3" || got[2] != "t4" {
+		t.Errorf("recalculated %v, want [t1 t3 t4]", got)
+	}
+
+	if len(audit.jobs) != 2 {
+		t.Fatalf("audit entries = %d, want 2 (one per successful run)", len(audit.jobs))
+	}
+	if job := audit.jobs[0]; job.TenantID != "t1" || job.Action != "salience.recalculate" ||
+		job.Actor != "scheduler" || job.Detail["updated"] != 3 {
+		t.Errorf("audit entry = %+v", job)
+	}
+}
+
+func TestNewSalienceScheduler_InvalidSpec(t *testing.T) {
+	if _, err := NewSalienceScheduler("every night", nil, nil, nil, logrus.New()); err == nil {
+		t.Error("expected an error for an invalid cron spec")
+	}
+}
+
+func TestStaggerOffset(t *testing.T) {
+	window := 10 * time.Minute
+	for _, tc := range []struct {
+		i, n int
+		want time.Duration
+	}{
+		{0, 4, 0},
+		{1, 4, 150 * time.Second},
+		{3, 4, 450 * time.Second},
+		{0, 1, 0},
+	} {
+		if got := staggerOffset(tc.i, tc.n, window); got != tc.want {
+			t.Errorf("staggerOffset(%d, %d) = %s, want %s", tc.i, tc.n, got, tc.want)
+		}
+	}
+}
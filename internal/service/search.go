@@ -7,26 +7,35 @@
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // SearchStore defines the data access methods SearchService depends on.
-type SearchStore interface {
-	FullTextSearch(ctx context.Context, tenantID string, query string, typeFilter string, minSalience float64, limit int) ([]models.Node, error)
-	SemanticSearch(ctx context.Context, tenantID string, embedding []float32, limit int) ([]models.ScoredNode, error)
-	HybridSearch(ctx context.Context, tenantID string, query string, embedding []float32, limit int) ([]models.Node, error)
-}
+type SearchStore = storage.SearchStorage
 
 // Embedder generates vector embeddings from text.
 type Embedder interface {
 	Generate(ctx context.Context, text string) ([]float32, error)
 }
 
+// ReembedGuard reports whether a tenant's embeddings are being moved to
+// another model.
+type ReembedGuard interface {
+	ReembedInProgress(ctx context.Context, tenantID string) (bool, error)
+}
+
 // SearchService wraps SearchStore with embedding generation logic.
 type SearchService struct {
-	store    SearchStore
-	graph    GraphLookupStore
-	embedder Embedder
-	log      *logrus.Logger
+	store      SearchStore
+	graph      GraphLookupStore
+	embedder   Embedder
+	reembed    ReembedGuard
+	access     NodeAccessRecorder
+	reranker   Reranker
+	rerankK    int
+	queryCache *queryEmbeddingCache
+	log        *logrus.Logger
 }
 
 // NewSearchService creates a SearchService.
@@ -40,57 +49,166 @@ func (s *SearchService) WithGraphLookup(graph GraphLookupStore) *SearchService {
 	return s
 }
 
+// WithReembedGuard refuses vector search with models.ErrReembedInProgress
+// while a tenant's re-embed is running, since its stored vectors then come
+// from two models.
+func (s *SearchService) WithReembedGuard(guard ReembedGuard) *SearchService {
+	s.reembed = guard
+	return s
+}
+
+// WithAccessRecorder counts every node a search returns as read.
+func (s *SearchService) WithAccessRecorder(access NodeAccessRecorder) *SearchService {
+	s.access = access
+	return s
+}
+
+// checkReembed returns models.ErrReembedInProgress when the tenant's
+// embeddings are mid re-embed.
+func (s *SearchService) checkReembed(ctx context.Context, tenantID string) error {
+	if s.reembed == nil {
+		return nil
+	}
+
+	running, err := s.reembed.ReembedInProgress(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("checking re-embed status: %w", err)
+	}
+
+	if running {
+		return models.ErrReembedInProgress
+	}
+
+	return nil
+}
+
 // FullTextSearch performs a full-text search (pass-through).
 func (s *SearchService) FullTextSearch(
-	ctx context.Context, tenantID, query, typeFilter string, minSalience float64, limit int,
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
+) (results []models.Node, err error) {
+	ctx, span := startSpan(ctx, "SearchService.FullTextSearch", tenantID, tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	results, err = s.fullTextSearch(ctx, tenantID, query, filter, limit)
+	span.SetAttributes(tracing.Int("node_count", len(results)))
+	touchNodes(s.access, tenantID, results)
+
+	return results, err
+}
+
+func (s *SearchService) fullTextSearch(
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
 ) ([]models.Node, error) {
-	intent := DetectSearchIntent(query)
-	adjustedMinSalience := minSalience
-	if adjustedMinSalience <= 0 {
-		switch intent {
-		case SearchIntentProcedural:
-			adjustedMinSalience = 1.0
-		case SearchIntentTemporal:
-			adjustedMinSalience = 0.5
-		}
-	}
-	results, err := s.firstFullTextMatch(ctx, tenantID, BuildSearchQueryVariants(query), typeFilter, adjustedMinSalience, limit)
+	filter.MinSalience = intentMinSalience(query, filter.MinSalience)
+	results, err := s.firstFullTextMatch(ctx, tenantID, BuildSearchQueryVariants(query), filter, limit)
 	if err != nil {
 		return nil, err
 	}
 	results = shapeTemporalNodes(query, results, limit)
-	results = mergeExpandedNodes(results, s.rescueByLabel(ctx, tenantID, query), limit)
-	return mergeExpandedNodes(results, s.expandFromGraph(ctx, tenantID, results, limit), limit), nil
+	results = mergeExpandedNodes(results, s.rescueByLabel(ctx, tenantID, query, filter), limit)
+	return mergeExpandedNodes(results, s.expandFromGraph(ctx, tenantID, results, limit, filter), limit), nil
+}
+
+// intentMinSalience returns minSalience, or when it is unset, the salience
+// floor suited to the query's intent.
+func intentMinSalience(query string, minSalience float64) float64 {
+	if minSalience > 0 {
+		return minSalience
+	}
+
+	switch DetectSearchIntent(query) {
+	case SearchIntentProcedural:
+		return 1.0
+	case SearchIntentTemporal:
+		return 0.5
+	default:
+		return minSalience
+	}
 }
 
-// SemanticSearch generates an embedding from the query, then searches by vector similarity.
+// FullTextSearchFacets counts the nodes a full-text search matches by type,
+// salience bucket, and creation month. It uses the same query variants and
+// salience floor as FullTextSearch, counting the first variant that matches.
+func (s *SearchService) FullTextSearchFacets(
+	ctx context.Context, tenantID, query string, filter models.SearchFilter,
+) (facets *models.SearchFacets, err error) {
+	ctx, span := startSpan(ctx, "SearchService.FullTextSearchFacets", tenantID)
+	defer endSpan(span, &err)
+
+	filter.MinSalience = intentMinSalience(query, filter.MinSalience)
+	for _, q := range BuildSearchQueryVariants(query) {
+		facets, err = s.store.FullTextSearchFacets(ctx, tenantID, q, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if facets.Total > 0 {
+			break
+		}
+	}
+
+	if facets == nil {
+		facets = &models.SearchFacets{Types: []models.FacetCount{}, Salience: []models.SalienceBucket{}, Months: []models.MonthCount{}}
+	}
+
+	span.SetAttributes(tracing.Int("node_count", facets.Total))
+
+	return facets, nil
+}
+
+// SemanticSearch generates an embedding from the query, then searches by vector similarity
+// among nodes matching filter.
 func (s *SearchService) SemanticSearch(
-	ctx context.Context, tenantID, query string, limit int,
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
+) (results []models.ScoredNode, err error) {
+	ctx, span := startSpan(ctx, "SearchService.SemanticSearch", tenantID, tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	results, err = s.semanticSearch(ctx, tenantID, query, filter, limit)
+	span.SetAttributes(tracing.Int("node_count", len(results)))
+
+	if s.access != nil && len(results) > 0 {
+		ids := make([]string, len(results))
+		for i := range results {
+			ids[i] = results[i].ID
+		}
+
+		s.access.TouchNodes(tenantID, ids...)
+	}
+
+	return results, err
+}
+
+func (s *SearchService) semanticSearch(
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
 ) ([]models.ScoredNode, error) {
+	if err := s.checkReembed(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
 	variants := BuildSearchQueryVariants(query)
 	if len(variants) == 0 {
 		variants = []string{query}
 	}
 
-	embedding, err := s.embedder.Generate(ctx, variants[0])
+	embedding, err := s.queryEmbedding(ctx, tenantID, variants[0])
 	if err != nil {
 		return nil, err
 	}
 
-	return s.store.SemanticSearch(ctx, tenantID, embedding, limit)
+	return s.store.SemanticSearch(ctx, tenantID, embedding, filter, limit)
 }
 
 func (s *SearchService) firstFullTextMatch(
 	ctx context.Context,
 	tenantID string,
 	queries []string,
-	typeFilter string,
-	minSalience float64,
+	filter models.SearchFilter,
 	limit int,
 ) ([]models.Node, error) {
 	var firstErr error
 	for _, q := range queries {
-		results, err := s.store.FullTextSearch(ctx, tenantID, q, typeFilter, minSalience, limit)
+		results, err := s.store.FullTextSearch(ctx, tenantID, q, filter, limit)
 		if err != nil {
 			if firstErr == nil {
 				firstErr = err
@@ -107,29 +225,59 @@ func (s *SearchService) firstFullTextMatch(
 	return []models.Node{}, nil
 }
 
-// HybridSearch generates an embedding from the query, then performs combined search.
+// HybridSearch generates an embedding from the query, then performs combined search
+// among nodes matching filter.
 // Returns the embedding error separately so the handler can decide on fallback.
 func (s *SearchService) HybridSearch(
-	ctx context.Context, tenantID, query string, limit int,
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
+) (results []models.Node, err error) {
+	ctx, span := startSpan(ctx, "SearchService.HybridSearch", tenantID, tracing.Int("limit", limit))
+	defer endSpan(span, &err)
+
+	results, err = s.hybridSearch(ctx, tenantID, query, filter, limit)
+	if filter.OmitProperties {
+		// Reranking and label rescue read nodes with their properties.
+		for i := range results {
+			results[i].Properties = nil
+		}
+	}
+	span.SetAttributes(tracing.Int("node_count", len(results)))
+	touchNodes(s.access, tenantID, results)
+
+	return results, err
+}
+
+func (s *SearchService) hybridSearch(
+	ctx context.Context, tenantID, query string, filter models.SearchFilter, limit int,
 ) ([]models.Node, error) {
+	if err := s.checkReembed(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
 	variants := BuildSearchQueryVariants(query)
 	if len(variants) == 0 {
 		variants = []string{query}
 	}
 
-	embedding, err := s.embedder.Generate(ctx, variants[0])
+	embedding, err := s.queryEmbedding(ctx, tenantID, variants[0])
 	if err != nil {
 		return nil, err
 	}
 
-	searchLimit := limit
-	if shouldPrototypeRerank(ctx, limit) {
+	storeFilter, searchLimit := filter, limit
+	if s.shouldCrossEncoderRerank(ctx, limit) {
+		searchLimit = max(limit, s.rerankK)
+		// The cross-encoder reads node text, properties included.
+		storeFilter.OmitProperties = false
+	} else if shouldPrototypeRerank(ctx, limit) {
 		searchLimit = rerankCandidateLimit(limit)
+		// The reranker scores belief state kept in properties.
+		storeFilter.OmitProperties = false
 	}
 
 	var firstErr error
 	for _, variant := range variants {
-		results, searchErr := s.store.HybridSearch(ctx, tenantID, variant, embedding, searchLimit)
+		results, searchErr := s.store.HybridSearch(ctx, tenantID, variant, embedding, storeFilter, searchLimit)
 		if searchErr != nil {
 			if firstErr == nil {
 				firstErr = searchErr
@@ -137,21 +285,38 @@ func (s *SearchService) HybridSearch(
 			continue
 		}
 		if len(results) > 0 {
-			if shouldPrototypeRerank(ctx, limit) {
+			switch {
+			case s.shouldCrossEncoderRerank(ctx, limit):
+				results = s.crossEncoderRerank(ctx, query, results, limit)
+			case shouldPrototypeRerank(ctx, limit):
 				results = prototypeRerankNodesWithProfile(query, results, limit, InternalRerankProfile(ctx))
-			} else {
+			default:
 				results = shapeTemporalNodes(query, results, limit)
 			}
-			results = mergeExpandedNodes(results, s.rescueByLabel(ctx, tenantID, query), limit)
-			return mergeExpandedNodes(results, s.expandFromGraph(ctx, tenantID, results, limit), limit), nil
+			// Label rescue and graph expansion bypass the store's search filter.
+			results = mergeExpandedNodes(results, filterNodes(filter, s.rescueByLabel(ctx, tenantID, query, filter)), limit)
+			expanded := filterNodes(filter, s.expandFromGraph(ctx, tenantID, results, limit, filter))
+			return mergeExpandedNodes(results, expanded, limit), nil
 		}
 	}
-	rescued := s.rescueByLabel(ctx, tenantID, query)
+	rescued := filterNodes(filter, s.rescueByLabel(ctx, tenantID, query, filter))
 	if len(rescued) > 0 {
-		return mergeExpandedNodes(rescued, s.expandFromGraph(ctx, tenantID, rescued, limit), limit), nil
+		expanded := filterNodes(filter, s.expandFromGraph(ctx, tenantID, rescued, limit, filter))
+		return mergeExpandedNodes(rescued, expanded, limit), nil
 	}
 	if firstErr != nil {
 		return nil, firstErr
 	}
 	return nil, fmt.Errorf("hybrid search returned no results")
 }
+
+// filterNodes returns the nodes that match filter, in order.
+func filterNodes(filter models.SearchFilter, nodes []models.Node) []models.Node {
+	kept := nodes[:0:0]
+	for i := range nodes {
+		if filter.Matches(&nodes[i]) {
+			kept = append(kept, nodes[i])
+		}
+	}
+	return kept
+}
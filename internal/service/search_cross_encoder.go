@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const crossEncoderRerankContextKey rerankContextKey = "rerank"
+
+// Reranker scores how relevant each document is to a query, in document
+// order, higher being more relevant.
+type Reranker interface {
+	Rerank(ctx context.Context, query string, documents []string) ([]float64, error)
+}
+
+// WithReranker lets hybrid search requests that ask for it (see WithRerank)
+// have their top topK candidates reordered by reranker.
+func (s *SearchService) WithReranker(reranker Reranker, topK int) *SearchService {
+	s.reranker = reranker
+	s.rerankK = topK
+	return s
+}
+
+// WithRerank asks hybrid search to reorder its candidates with the
+// configured cross-encoder. Without one it has no effect.
+func WithRerank(ctx context.Context) context.Context {
+	return context.WithValue(ctx, crossEncoderRerankContextKey, true)
+}
+
+// RerankRequested reports whether WithRerank was applied to ctx.
+func RerankRequested(ctx context.Context) bool {
+	requested, _ := ctx.Value(crossEncoderRerankContextKey).(bool)
+	return requested
+}
+
+func (s *SearchService) shouldCrossEncoderRerank(ctx context.Context, limit int) bool {
+	return s.reranker != nil && limit > 0 && RerankRequested(ctx)
+}
+
+// crossEncoderRerank orders the top rerankK candidates by the reranker's
+// score and returns the first limit. When the reranker fails, results keep
+// the order hybrid search would give them without it.
+func (s *SearchService) crossEncoderRerank(ctx context.Context, query string, nodes []models.Node, limit int) []models.Node {
+	candidates := nodes[:min(len(nodes), s.rerankK)]
+
+	documents := make([]string, len(candidates))
+	for i := range candidates {
+		documents[i] = models.BuildNodeSearchText(&candidates[i])
+	}
+
+	scores, err := s.reranker.Rerank(ctx, query, documents)
+	if err != nil {
+		s.log.WithError(err).Warn("cross-encoder rerank failed, keeping hybrid order")
+
+		return shapeTemporalNodes(query, nodes, limit)
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	reranked := make([]models.Node, 0, min(len(order), limit))
+	for _, i := range order[:min(len(order), limit)] {
+		reranked = append(reranked, candidates[i])
+	}
+
+	return reranked
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type fakeReranker struct {
+	scores func(query string, documents []string) ([]float64, error)
+	calls  int
+}
+
+func (f *fakeReranker) Rerank(_ context.Context, query string, documents []string) ([]float64, error) {
+	f.calls++
+	return f.scores(query, documents)
+}
+
+func TestSearchService_HybridSearch_CrossEncoderRerank(t *testing.T) {
+	embedder := &mockEmbedder{
+		generate: func(_ context.Context, _ string) ([]float32, error) {
+			return []float32{0.1, 0.2}, nil
+		},
+	}
+
+	var receivedLimit int
+	store := &mockSearchStore{
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ models.SearchFilter, limit int) ([]models.Node, error) {
+			receivedLimit = limit
+			return []models.Node{
+				{ID: "n1", Label: "Deployment log", Type: "note"},
+				{ID: "n2", Label: "Persistor deploy fix", Type: "incident"},
+				{ID: "n3", Label: "Deploy checklist", Type: "note"},
+			}, nil
+		},
+	}
+
+	reranker := &fakeReranker{scores: func(_ string, documents []string) ([]float64, error) {
+		scores := make([]float64, len(documents))
+		for i, doc := range documents {
+			if strings.Contains(doc, "Persistor deploy fix") {
+				scores[i] = 0.9
+			}
+		}
+		return scores, nil
+	}}
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewSearchService(store, embedder, log).WithReranker(reranker, 20)
+
+	t.Run("not requested", func(t *testing.T) {
+		if _, err := svc.HybridSearch(context.Background(), "t1", "deploy fix", models.SearchFilter{}, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if reranker.calls != 0 || receivedLimit != 1 {
+			t.Errorf("reranker called %d times with limit %d, want 0 and 1", reranker.calls, receivedLimit)
+		}
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		nodes, err := svc.HybridSearch(WithRerank(context.Background()), "t1", "deploy fix", models.SearchFilter{}, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if receivedLimit != 20 {
+			t.Errorf("expected top-K overfetch limit 20, got %d", receivedLimit)
+		}
+		if len(nodes) != 1 || nodes[0].ID != "n2" {
+			t.Errorf("expected reranked top result n2, got %#v", nodes)
+		}
+	})
+
+	t.Run("reranker failure keeps hybrid order", func(t *testing.T) {
+		reranker.scores = func(string, []string) ([]float64, error) { return nil, errors.New("connection refused") }
+
+		nodes, err := svc.HybridSearch(WithRerank(context.Background()), "t1", "deploy fix", models.SearchFilter{}, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(nodes) != 2 || nodes[0].ID != "n1" {
+			t.Errorf("expected hybrid order, got %#v", nodes)
+		}
+	})
+}
@@ -5,6 +5,7 @@
 	"sort"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 const defaultGraphExpansionLimit = 3
@@ -12,7 +13,7 @@
 // GraphLookupStore is the narrow graph capability SearchService can optionally use
 // for neighborhood-aware retrieval expansion.
 type GraphLookupStore interface {
-	Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error)
+	Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (*models.NeighborResult, error)
 }
 
 func mergeExpandedNodes(primary []models.Node, expanded []models.Node, limit int) []models.Node {
@@ -52,19 +53,27 @@ func mergeExpandedNodes(primary []models.Node, expanded []models.Node, limit int
 	return merged
 }
 
-func (s *SearchService) expandFromGraph(ctx context.Context, tenantID string, seeds []models.Node, limit int) []models.Node {
-	if s.graph == nil || len(seeds) == 0 || limit <= 0 {
+func (s *SearchService) expandFromGraph(
+	ctx context.Context, tenantID string, seeds []models.Node, limit int, filter models.SearchFilter,
+) []models.Node {
+	// Neighbors would bypass props filters and the neighborhood.
+	if s.graph == nil || len(seeds) == 0 || limit <= 0 || filter.StoreOnly() {
 		return nil
 	}
 
+	ctx, span := tracing.Start(ctx, "SearchService.expandFromGraph", tracing.Int("seed_count", len(seeds)))
+	defer span.End()
+
 	expanded := make([]models.Node, 0, limit*defaultGraphExpansionLimit)
 	for _, seed := range seeds {
-		neighbors, err := s.graph.Neighbors(ctx, tenantID, seed.ID, defaultGraphExpansionLimit)
+		neighbors, err := s.graph.Neighbors(ctx, tenantID, seed.ID, defaultGraphExpansionLimit, models.ReadOpts{OmitProperties: filter.OmitProperties})
 		if err != nil || neighbors == nil || len(neighbors.Nodes) == 0 {
 			continue
 		}
 		expanded = append(expanded, neighbors.Nodes...)
 	}
 
+	span.SetAttributes(tracing.Int("node_count", len(expanded)))
+
 	return expanded
 }
@@ -5,6 +5,7 @@
 	"strings"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // LabelLookupStore is the narrow label lookup capability SearchService can optionally use
@@ -70,12 +71,16 @@ func dedupeLabels(labels []string) []string {
 	return out
 }
 
-func (s *SearchService) rescueByLabel(ctx context.Context, tenantID, query string) []models.Node {
+func (s *SearchService) rescueByLabel(ctx context.Context, tenantID, query string, filter models.SearchFilter) []models.Node {
 	lookup, ok := s.store.(LabelLookupStore)
-	if !ok {
+	// Label lookups would bypass props filters and the neighborhood.
+	if !ok || filter.StoreOnly() {
 		return nil
 	}
 
+	ctx, span := tracing.Start(ctx, "SearchService.rescueByLabel")
+	defer span.End()
+
 	found := make([]models.Node, 0, 3)
 	for _, candidate := range candidateLabelsFromQuery(query) {
 		node, err := lookup.GetNodeByLabel(ctx, tenantID, candidate)
@@ -25,13 +25,13 @@ func TestSearchService_RescueByLabel(t *testing.T) {
 	t.Parallel()
 
 	store := &mockSearchStore{
-		fullTextSearch: func(_ context.Context, _, _, _ string, _ float64, _ int) ([]models.Node, error) {
+		fullTextSearch: func(_ context.Context, _, _ string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			return []models.Node{}, nil
 		},
-		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ int) ([]models.Node, error) {
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			return []models.Node{}, nil
 		},
-		semanticSearch: func(_ context.Context, _ string, _ []float32, _ int) ([]models.ScoredNode, error) {
+		semanticSearch: func(_ context.Context, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.ScoredNode, error) {
 			return []models.ScoredNode{}, nil
 		},
 		getNodeByLabel: func(_ context.Context, _, label string) (*models.Node, error) {
@@ -47,7 +47,7 @@ func TestSearchService_RescueByLabel(t *testing.T) {
 		return []float32{0.1}, nil
 	}}, log)
 
-	results, err := svc.HybridSearch(context.Background(), "t1", "What is Persistor?", 5)
+	results, err := svc.HybridSearch(context.Background(), "t1", "What is Persistor?", models.SearchFilter{}, 5)
 	if err == nil {
 		if len(results) == 0 || results[0].Label != "Persistor" {
 			t.Fatalf("expected label rescue result, got %v", results)
@@ -14,7 +14,7 @@
 func TestSearchService_FullTextSearch(t *testing.T) {
 	queries := make([]string, 0, 4)
 	store := &mockSearchStore{
-		fullTextSearch: func(_ context.Context, _, query, _ string, _ float64, _ int) ([]models.Node, error) {
+		fullTextSearch: func(_ context.Context, _, query string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			queries = append(queries, query)
 			if query == "big jerry" {
 				return []models.Node{{ID: "n1", Label: "Big Jerry", Salience: 10}}, nil
@@ -34,7 +34,7 @@ func TestSearchService_FullTextSearch(t *testing.T) {
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewSearchService(store, nil, log).WithGraphLookup(graph)
 
-	nodes, err := svc.FullTextSearch(context.Background(), "t1", "Who is Big Jerry?", "", 0, 10)
+	nodes, err := svc.FullTextSearch(context.Background(), "t1", "Who is Big Jerry?", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -49,6 +49,41 @@ func TestSearchService_FullTextSearch(t *testing.T) {
 	}
 }
 
+func TestSearchService_FullTextSearchFacets(t *testing.T) {
+	var queries []string
+	var floors []float64
+	store := &mockSearchStore{
+		fullTextSearchFacets: func(_ context.Context, _, query string, filter models.SearchFilter) (*models.SearchFacets, error) {
+			queries = append(queries, query)
+			floors = append(floors, filter.MinSalience)
+			if query == "big jerry" {
+				return &models.SearchFacets{Total: 2}, nil
+			}
+			return &models.SearchFacets{}, nil
+		},
+	}
+	svc := NewSearchService(store, nil, logrus.New())
+
+	facets, err := svc.FullTextSearchFacets(context.Background(), "t1", "Who is Big Jerry?", models.SearchFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if facets.Total != 2 {
+		t.Errorf("Total = %d, want 2 from the first matching variant", facets.Total)
+	}
+	if len(queries) < 2 || queries[len(queries)-1] != "big jerry" {
+		t.Errorf("expected variants up to the first match, got %v", queries)
+	}
+
+	floors = nil
+	if _, err := svc.FullTextSearchFacets(context.Background(), "t1", "how to deploy", models.SearchFilter{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(floors) == 0 || floors[0] != 1.0 {
+		t.Errorf("procedural query salience floor = %v, want 1.0", floors)
+	}
+}
+
 func TestSearchService_SemanticSearch(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -72,7 +107,7 @@ func TestSearchService_SemanticSearch(t *testing.T) {
 				},
 			}
 			store := &mockSearchStore{
-				semanticSearch: func(_ context.Context, _ string, _ []float32, _ int) ([]models.ScoredNode, error) {
+				semanticSearch: func(_ context.Context, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.ScoredNode, error) {
 					if tc.storeErr != nil {
 						return nil, tc.storeErr
 					}
@@ -83,7 +118,7 @@ func TestSearchService_SemanticSearch(t *testing.T) {
 			log.SetLevel(logrus.ErrorLevel)
 			svc := NewSearchService(store, embedder, log)
 
-			results, err := svc.SemanticSearch(context.Background(), "t1", "test query", 10)
+			results, err := svc.SemanticSearch(context.Background(), "t1", "test query", models.SearchFilter{}, 10)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatal("expected error")
@@ -122,7 +157,7 @@ func TestSearchService_HybridSearch(t *testing.T) {
 			}
 			queries := make([]string, 0, 4)
 			store := &mockSearchStore{
-				hybridSearch: func(_ context.Context, _, query string, _ []float32, _ int) ([]models.Node, error) {
+				hybridSearch: func(_ context.Context, _, query string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
 					queries = append(queries, query)
 					if tc.wantErr {
 						return nil, nil
@@ -145,7 +180,7 @@ func TestSearchService_HybridSearch(t *testing.T) {
 			log.SetLevel(logrus.ErrorLevel)
 			svc := NewSearchService(store, embedder, log).WithGraphLookup(graph)
 
-			nodes, err := svc.HybridSearch(context.Background(), "t1", "Who is Big Jerry?", 10)
+			nodes, err := svc.HybridSearch(context.Background(), "t1", "Who is Big Jerry?", models.SearchFilter{}, 10)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatal("expected error")
@@ -168,6 +203,41 @@ func TestSearchService_HybridSearch(t *testing.T) {
 	}
 }
 
+func TestSearchService_HybridSearch_FiltersExpandedNodes(t *testing.T) {
+	embedder := &mockEmbedder{
+		generate: func(_ context.Context, _ string) ([]float32, error) {
+			return []float32{0.1, 0.2}, nil
+		},
+	}
+	store := &mockSearchStore{
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
+			return []models.Node{{ID: "n1", Type: "person"}}, nil
+		},
+	}
+	superseded := "n1"
+	graph := &mockGraphLookupStore{
+		neighbors: func(_ context.Context, _, _ string, _ int) (*models.NeighborResult, error) {
+			return &models.NeighborResult{Nodes: []models.Node{
+				{ID: "n2", Type: "person"},
+				{ID: "n3", Type: "place"},
+				{ID: "n4", Type: "person", SupersededBy: &superseded},
+			}}, nil
+		},
+	}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewSearchService(store, embedder, log).WithGraphLookup(graph)
+
+	nodes, err := svc.HybridSearch(context.Background(), "t1", "jerry", models.SearchFilter{Type: "person"}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(nodes) != 2 || nodes[0].ID != "n1" || nodes[1].ID != "n2" {
+		t.Errorf("got %v, want n1 and n2", nodes)
+	}
+}
+
 func TestSearchService_HybridSearch_PrototypeReranksCandidates(t *testing.T) {
 	now := time.Now()
 	embedder := &mockEmbedder{
@@ -178,7 +248,7 @@ func TestSearchService_HybridSearch_PrototypeReranksCandidates(t *testing.T) {
 
 	var receivedLimit int
 	store := &mockSearchStore{
-		hybridSearch: func(_ context.Context, _, _ string, _ []float32, limit int) ([]models.Node, error) {
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ models.SearchFilter, limit int) ([]models.Node, error) {
 			receivedLimit = limit
 			return []models.Node{
 				{ID: "n1", Label: "Deployment log", Type: "note", Properties: map[string]any{"summary": "Unrelated maintenance"}, Salience: 95, UpdatedAt: now.Add(-time.Hour)},
@@ -191,7 +261,7 @@ func TestSearchService_HybridSearch_PrototypeReranksCandidates(t *testing.T) {
 	svc := NewSearchService(store, embedder, log)
 
 	ctx := WithInternalRerankMode(context.Background(), "prototype")
-	nodes, err := svc.HybridSearch(ctx, "t1", "Persistor deploy fix", 1)
+	nodes, err := svc.HybridSearch(ctx, "t1", "Persistor deploy fix", models.SearchFilter{}, 1)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -203,9 +273,40 @@ func TestSearchService_HybridSearch_PrototypeReranksCandidates(t *testing.T) {
 	}
 }
 
+func TestSearchService_HybridSearch_RerankOmitsPropertiesAfterScoring(t *testing.T) {
+	embedder := &mockEmbedder{
+		generate: func(_ context.Context, _ string) ([]float32, error) {
+			return []float32{0.1, 0.2}, nil
+		},
+	}
+
+	var storeOmitted bool
+	store := &mockSearchStore{
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, filter models.SearchFilter, _ int) ([]models.Node, error) {
+			storeOmitted = filter.OmitProperties
+			return []models.Node{{ID: "n1", Label: "Persistor deploy", Properties: map[string]any{"summary": "deploy fix"}}}, nil
+		},
+	}
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	svc := NewSearchService(store, embedder, log)
+
+	ctx := WithInternalRerankMode(context.Background(), "prototype")
+	nodes, err := svc.HybridSearch(ctx, "t1", "Persistor deploy", models.SearchFilter{OmitProperties: true}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if storeOmitted {
+		t.Error("reranked search asked the store to omit the properties it scores")
+	}
+	if len(nodes) != 1 || nodes[0].Properties != nil {
+		t.Fatalf("expected one node without properties, got %#v", nodes)
+	}
+}
+
 func TestSearchService_FullTextSearch_BeliefAwareShaping(t *testing.T) {
 	store := &mockSearchStore{
-		fullTextSearch: func(_ context.Context, _, query, _ string, _ float64, _ int) ([]models.Node, error) {
+		fullTextSearch: func(_ context.Context, _, query string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			if query != "alice" {
 				return []models.Node{}, nil
 			}
@@ -235,7 +336,7 @@ func TestSearchService_FullTextSearch_BeliefAwareShaping(t *testing.T) {
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewSearchService(store, nil, log)
 
-	nodes, err := svc.FullTextSearch(context.Background(), "t1", "Alice", "", 0, 2)
+	nodes, err := svc.FullTextSearch(context.Background(), "t1", "Alice", models.SearchFilter{}, 2)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -247,7 +348,7 @@ func TestSearchService_FullTextSearch_BeliefAwareShaping(t *testing.T) {
 func TestSearchService_HybridSearch_BeliefAwareShaping(t *testing.T) {
 	embedder := &mockEmbedder{generate: func(_ context.Context, _ string) ([]float32, error) { return []float32{0.1, 0.2}, nil }}
 	store := &mockSearchStore{
-		hybridSearch: func(_ context.Context, _, query string, _ []float32, _ int) ([]models.Node, error) {
+		hybridSearch: func(_ context.Context, _, query string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			if query != "release plan" {
 				return []models.Node{}, nil
 			}
@@ -276,7 +377,7 @@ func TestSearchService_HybridSearch_BeliefAwareShaping(t *testing.T) {
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewSearchService(store, embedder, log)
 
-	nodes, err := svc.HybridSearch(context.Background(), "t1", "release plan", 2)
+	nodes, err := svc.HybridSearch(context.Background(), "t1", "release plan", models.SearchFilter{}, 2)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -288,7 +389,7 @@ func TestSearchService_HybridSearch_BeliefAwareShaping(t *testing.T) {
 func TestSearchService_FullTextSearch_TemporalShapingPrefersRecent(t *testing.T) {
 	now := time.Date(2026, 4, 14, 12, 0, 0, 0, time.UTC)
 	store := &mockSearchStore{
-		fullTextSearch: func(_ context.Context, _, query, _ string, _ float64, _ int) ([]models.Node, error) {
+		fullTextSearch: func(_ context.Context, _, query string, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			if query != "current deployment status" {
 				return []models.Node{}, nil
 			}
@@ -302,7 +403,7 @@ func TestSearchService_FullTextSearch_TemporalShapingPrefersRecent(t *testing.T)
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewSearchService(store, nil, log)
 
-	nodes, err := svc.FullTextSearch(context.Background(), "t1", "current deployment status", "", 0, 2)
+	nodes, err := svc.FullTextSearch(context.Background(), "t1", "current deployment status", models.SearchFilter{}, 2)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -315,7 +416,7 @@ func TestSearchService_HybridSearch_TemporalShapingPrefersHistoricalYearMatch(t
 	now := time.Date(2026, 4, 14, 12, 0, 0, 0, time.UTC)
 	embedder := &mockEmbedder{generate: func(_ context.Context, _ string) ([]float32, error) { return []float32{0.1, 0.2}, nil }}
 	store := &mockSearchStore{
-		hybridSearch: func(_ context.Context, _, query string, _ []float32, _ int) ([]models.Node, error) {
+		hybridSearch: func(_ context.Context, _, query string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			if query != "history of platform migration 2024" {
 				return []models.Node{}, nil
 			}
@@ -329,7 +430,7 @@ func TestSearchService_HybridSearch_TemporalShapingPrefersHistoricalYearMatch(t
 	log.SetLevel(logrus.ErrorLevel)
 	svc := NewSearchService(store, embedder, log)
 
-	nodes, err := svc.HybridSearch(context.Background(), "t1", "history of platform migration 2024", 2)
+	nodes, err := svc.HybridSearch(context.Background(), "t1", "history of platform migration 2024", models.SearchFilter{}, 2)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -342,7 +443,7 @@ func TestSearchService_HybridSearch_PrototypeReranksWithProfile(t *testing.T) {
 	now := time.Now()
 	embedder := &mockEmbedder{generate: func(_ context.Context, _ string) ([]float32, error) { return []float32{0.1, 0.2}, nil }}
 	store := &mockSearchStore{
-		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ int) ([]models.Node, error) {
+		hybridSearch: func(_ context.Context, _, _ string, _ []float32, _ models.SearchFilter, _ int) ([]models.Node, error) {
 			return []models.Node{
 				{ID: "n1", Label: "Persistor deploy", Type: "note", Properties: map[string]any{"summary": "Operational notes"}, Salience: 140, UpdatedAt: now.Add(-time.Hour)},
 				{ID: "n2", Label: "Incident notes", Type: "incident", Properties: map[string]any{"summary": "Persistor deploy fix remediation"}, Salience: 20, UserBoosted: true, UpdatedAt: now.Add(-2 * time.Hour)},
@@ -354,7 +455,7 @@ func TestSearchService_HybridSearch_PrototypeReranksWithProfile(t *testing.T) {
 	svc := NewSearchService(store, embedder, log)
 
 	baselineCtx := WithInternalRerankMode(context.Background(), "prototype")
-	baseline, err := svc.HybridSearch(baselineCtx, "t1", "Persistor deploy fix remediation", 1)
+	baseline, err := svc.HybridSearch(baselineCtx, "t1", "Persistor deploy fix remediation", models.SearchFilter{}, 1)
 	if err != nil {
 		t.Fatalf("unexpected baseline error: %v", err)
 	}
@@ -363,7 +464,7 @@ func TestSearchService_HybridSearch_PrototypeReranksWithProfile(t *testing.T) {
 	}
 
 	profileCtx := WithInternalRerankProfile(baselineCtx, "term_focus")
-	weighted, err := svc.HybridSearch(profileCtx, "t1", "Persistor deploy fix remediation", 1)
+	weighted, err := svc.HybridSearch(profileCtx, "t1", "Persistor deploy fix remediation", models.SearchFilter{}, 1)
 	if err != nil {
 		t.Fatalf("unexpected weighted error: %v", err)
 	}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// SeedStore reports which seeded nodes and edges already exist.
+type SeedStore interface {
+	ExistingNodeIDs(ctx context.Context, tenantID string, ids []string) (map[string]struct{}, error)
+	EdgesExist(ctx context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error)
+}
+
+// SeedWriter writes seeded nodes and edges. BulkService implements it, so
+// seeded nodes are embedded, audited, and scanned like any bulk upsert.
+type SeedWriter interface {
+	BulkUpsertNodes(ctx context.Context, tenantID string, nodes []models.CreateNodeRequest) ([]models.Node, error)
+	BulkUpsertEdges(ctx context.Context, tenantID string, edges []models.CreateEdgeRequest) ([]models.Edge, error)
+}
+
+// Seeder applies a directory of seed files at startup, so container images
+// can ship baseline ontologies. Nodes and edges that already exist are left
+// as they are, which makes seeding idempotent and keeps later edits.
+type Seeder struct {
+	tenants TenantLister
+	store   SeedStore
+	writer  SeedWriter
+	log     *logrus.Logger
+}
+
+// NewSeeder creates a Seeder.
+func NewSeeder(tenants TenantLister, store SeedStore, writer SeedWriter, log *logrus.Logger) *Seeder {
+	return &Seeder{tenants: tenants, store: store, writer: writer, log: log}
+}
+
+// SeedDir applies every .yaml, .yml, and .json file in dir, in name order.
+// Every file is parsed before anything is written, so a malformed file
+// seeds nothing. A file naming an unknown or suspended tenant is
+// skipped with a warning.
+func (s *Seeder) SeedDir(ctx context.Context, dir string) (*models.SeedResult, error) {
+	names, files, err := readSeedFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := s.tenants.ListTenants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants to seed: %w", err)
+	}
+
+	result := &models.SeedResult{}
+	for i, file := range files {
+		targets := seedTargets(file.Tenant, tenants)
+		if len(targets) == 0 && file.Tenant != "" {
+			s.log.WithFields(logrus.Fields{"file": names[i], "tenant": file.Tenant}).Warn("seed file names no active tenant, skipping")
+			continue
+		}
+
+		for _, tenantID := range targets {
+			if err := s.seedTenant(ctx, tenantID, file, result); err != nil {
+				return nil, fmt.Errorf("seeding %s: %w", names[i], err)
+			}
+		}
+
+		result.Files++
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"files":         result.Files,
+		"nodes_created": result.NodesCreated,
+		"nodes_skipped": result.NodesSkipped,
+		"edges_created": result.EdgesCreated,
+		"edges_skipped": result.EdgesSkipped,
+	}).Info("seeded baseline data")
+
+	return result, nil
+}
+
+// readSeedFiles parses every seed file in dir, sorted by name.
+func readSeedFiles(dir string) ([]string, []*models.SeedFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading seed directory: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && models.IsSeedFile(e.Name()) {
+			names = append(names, e.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	files := make([]*models.SeedFile, len(names))
+	for i, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading seed file: %w", err)
+		}
+
+		if files[i], err = models.ParseSeedFile(name, data); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return names, files, nil
+}
+
+// seedTargets returns the IDs of the active tenant named name, or of every
+// active tenant when name is empty. Suspended tenants are never seeded.
+func seedTargets(name string, tenants []models.Tenant) []string {
+	var ids []string
+
+	for _, t := range tenants {
+		if t.SuspendedAt == nil && (name == "" || t.Name == name) {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	return ids
+}
+
+// seedTenant writes the file's nodes, then its edges, that the tenant does
+// not have yet, in batches of models.MaxSeedBatch.
+func (s *Seeder) seedTenant(ctx context.Context, tenantID string, file *models.SeedFile, result *models.SeedResult) error {
+	if err := s.seedNodes(ctx, tenantID, file.Nodes, result); err != nil {
+		return err
+	}
+
+	return s.seedEdges(ctx, tenantID, file.Edges, result)
+}
+
+func (s *Seeder) seedNodes(ctx context.Context, tenantID string, nodes []models.CreateNodeRequest, result *models.SeedResult) error {
+	for batch := range slices.Chunk(nodes, models.MaxSeedBatch) {
+		ids := make([]string, len(batch))
+		for i, n := range batch {
+			ids[i] = n.ID
+		}
+
+		existing, err := s.store.ExistingNodeIDs(ctx, tenantID, ids)
+		if err != nil {
+			return fmt.Errorf("checking existing nodes: %w", err)
+		}
+
+		missing := make([]models.CreateNodeRequest, 0, len(batch))
+		for _, n := range batch {
+			if _, ok := existing[n.ID]; !ok {
+				missing = append(missing, n)
+			}
+		}
+
+		if len(missing) > 0 {
+			if _, err := s.writer.BulkUpsertNodes(ctx, tenantID, missing); err != nil {
+				return fmt.Errorf("writing nodes: %w", err)
+			}
+		}
+
+		result.NodesCreated += len(missing)
+		result.NodesSkipped += len(batch) - len(missing)
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedEdges(ctx context.Context, tenantID string, edges []models.CreateEdgeRequest, result *models.SeedResult) error {
+	for batch := range slices.Chunk(edges, models.MaxSeedBatch) {
+		keys := make([]models.EdgeKey, len(batch))
+		for i, e := range batch {
+			keys[i] = models.EdgeKey{Source: e.Source, Target: e.Target, Relation: e.Relation}
+		}
+
+		existence, err := s.store.EdgesExist(ctx, tenantID, keys)
+		if err != nil {
+			return fmt.Errorf("checking existing edges: %w", err)
+		}
+
+		missing := make([]models.CreateEdgeRequest, 0, len(batch))
+		for i, e := range batch {
+			if i >= len(existence) || !existence[i].Exists {
+				missing = append(missing, e)
+			}
+		}
+
+		if len(missing) > 0 {
+			if _, err := s.writer.BulkUpsertEdges(ctx, tenantID, missing); err != nil {
+				return fmt.Errorf("writing edges: %w", err)
+			}
+		}
+
+		result.EdgesCreated += len(missing)
+		result.EdgesSkipped += len(batch) - len(missing)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockSeedStore struct {
+	nodes map[string]bool
+	edges map[models.EdgeKey]bool
+}
+
+func (m *mockSeedStore) ExistingNodeIDs(_ context.Context, tenantID string, ids []string) (map[string]struct{}, error) {
+	found := make(map[string]struct{})
+	for _, id := range ids {
+		if m.nodes[tenantID+"/"+id] {
+			found[id] = struct{}{}
+		}
+	}
+	return found, nil
+}
+
+func (m *mockSeedStore) EdgesExist(_ context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+	out := make([]models.EdgeExistence, len(keys))
+	for i, k := range keys {
+		scoped := k
+		scoped.Source = tenantID + "/" + k.Source
+		out[i] = models.EdgeExistence{EdgeKey: k, Exists: m.edges[scoped]}
+	}
+	return out, nil
+}
+
+type recordingSeedWriter struct {
+	nodes map[string][]string
+	edges map[string][]string
+}
+
+func (w *recordingSeedWriter) BulkUpsertNodes(_ context.Context, tenantID string, nodes []models.CreateNodeRequest) ([]models.Node, error) {
+	for _, n := range nodes {
+		w.nodes[tenantID] = append(w.nodes[tenantID], n.ID)
+	}
+	return nil, nil
+}
+
+func (w *recordingSeedWriter) BulkUpsertEdges(_ context.Context, tenantID string, edges []models.CreateEdgeRequest) ([]models.Edge, error) {
+	for _, e := range edges {
+		w.edges[tenantID] = append(w.edges[tenantID], e.Source+"->"+e.Target)
+	}
+	return nil, nil
+}
+
+func writeSeedFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestSeederSeedDir(t *testing.T) {
+	dir := writeSeedFiles(t, map[string]string{
+		"01-ontology.yaml": `
+nodes:
+  - {id: person, type: concept, label: Person}
+  - {id: project, type: concept, label: Project}
+edges:
+  - {source: person, target: project, relation: works_on}
+`,
+		"02-acme.json": `{"tenant": "acme", "nodes": [{"id": "acme", "type": "organization", "label": "Acme"}]}`,
+		"03-gone.json": `{"tenant": "gone", "nodes": [{"id": "x", "type": "concept", "label": "X"}]}`,
+		"README.md":    "not a seed file",
+	})
+
+	suspended := time.Now()
+	tenants := &mockTenantLister{tenants: []models.Tenant{
+		{ID: "t1", Name: "acme"},
+		{ID: "t2", Name: "globex"},
+		{ID: "t3", Name: "gone", SuspendedAt: &suspended},
+	}}
+	store := &mockSeedStore{
+		nodes: map[string]bool{"t2/person": true},
+		edges: map[models.EdgeKey]bool{{Source: "t2/person", Target: "project", Relation: "works_on"}: true},
+	}
+	writer := &recordingSeedWriter{nodes: map[string][]string{}, edges: map[string][]string{}}
+
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+
+	result, err := NewSeeder(tenants, store, writer, log).SeedDir(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("SeedDir: %v", err)
+	}
+
+	want := models.SeedResult{Files: 2, NodesCreated: 4, NodesSkipped: 1, EdgesCreated: 1, EdgesSkipped: 1}
+	if *result != want {
+		t.Errorf("result = %+v, want %+v", *result, want)
+	}
+	if got := writer.nodes["t1"]; len(got) != 3 || got[2] != "acme" {
+		t.Errorf("t1 nodes = %v, want person, project, acme", got)
+	}
+	if got := writer.nodes["t2"]; len(got) != 1 || got[0] != "project" {
+		t.Errorf("t2 nodes = %v, want only project", got)
+	}
+	if len(writer.edges["t2"]) != 0 || len(writer.nodes["t3"]) != 0 {
+		t.Errorf("wrote existing edge or suspended tenant: %v %v", writer.edges, writer.nodes)
+	}
+}
+
+func TestSeederSeedDir_InvalidFileWritesNothing(t *testing.T) {
+	dir := writeSeedFiles(t, map[string]string{
+		"01-good.yaml": "nodes:\n  - {id: a, type: concept, label: A}\n",
+		"02-bad.yaml":  "nodes:\n  - {type: concept, label: No ID}\n",
+	})
+
+	tenants := &mockTenantLister{tenants: []models.Tenant{{ID: "t1", Name: "acme"}}}
+	writer := &recordingSeedWriter{nodes: map[string][]string{}, edges: map[string][]string{}}
+
+	_, err := NewSeeder(tenants, &mockSeedStore{}, writer, logrus.New()).SeedDir(context.Background(), dir)
+	if err == nil {
+		t.Fatal("expected an error for a node without an id")
+	}
+	if len(writer.nodes) != 0 {
+		t.Errorf("wrote %v despite an invalid file", writer.nodes)
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// SnapshotStore is the data-access interface SnapshotService depends on.
+type SnapshotStore interface {
+	CreateSnapshot(ctx context.Context, tenantID string, req models.CreateSnapshotRequest) (*models.Snapshot, error)
+	GetSnapshot(ctx context.Context, tenantID, name string) (*models.Snapshot, error)
+	ListSnapshots(ctx context.Context, tenantID string) ([]models.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, tenantID, name string) error
+}
+
+// Compile-time check: *SnapshotService must satisfy domain.SnapshotService.
+var _ domain.SnapshotService = (*SnapshotService)(nil)
+
+// SnapshotService tags named logical snapshots of a tenant's graph and
+// reports the drift between them.
+type SnapshotService struct {
+	store SnapshotStore
+}
+
+// NewSnapshotService creates a SnapshotService.
+func NewSnapshotService(store SnapshotStore) *SnapshotService {
+	return &SnapshotService{store: store}
+}
+
+// CreateSnapshot records the tenant's graph as it is now under req.Name.
+func (s *SnapshotService) CreateSnapshot(
+	ctx context.Context, tenantID string, req models.CreateSnapshotRequest,
+) (_ *models.Snapshot, err error) {
+	ctx, span := startSpan(ctx, "SnapshotService.CreateSnapshot", tenantID, tracing.String("snapshot", req.Name))
+	defer endSpan(span, &err)
+
+	return s.store.CreateSnapshot(ctx, tenantID, req)
+}
+
+// GetSnapshot returns the named snapshot.
+func (s *SnapshotService) GetSnapshot(ctx context.Context, tenantID, name string) (_ *models.Snapshot, err error) {
+	ctx, span := startSpan(ctx, "SnapshotService.GetSnapshot", tenantID, tracing.String("snapshot", name))
+	defer endSpan(span, &err)
+
+	return s.store.GetSnapshot(ctx, tenantID, name)
+}
+
+// ListSnapshots returns the tenant's snapshots, newest first.
+func (s *SnapshotService) ListSnapshots(ctx context.Context, tenantID string) (_ []models.Snapshot, err error) {
+	ctx, span := startSpan(ctx, "SnapshotService.ListSnapshots", tenantID)
+	defer endSpan(span, &err)
+
+	return s.store.ListSnapshots(ctx, tenantID)
+}
+
+// DeleteSnapshot deletes the named snapshot.
+func (s *SnapshotService) DeleteSnapshot(ctx context.Context, tenantID, name string) (err error) {
+	ctx, span := startSpan(ctx, "SnapshotService.DeleteSnapshot", tenantID, tracing.String("snapshot", name))
+	defer endSpan(span, &err)
+
+	return s.store.DeleteSnapshot(ctx, tenantID, name)
+}
+
+// CompareSnapshots returns the drift from snapshot from to snapshot to.
+func (s *SnapshotService) CompareSnapshots(
+	ctx context.Context, tenantID, from, to string,
+) (_ *models.SnapshotComparison, err error) {
+	ctx, span := startSpan(ctx, "SnapshotService.CompareSnapshots", tenantID,
+		tracing.String("from", from), tracing.String("to", to))
+	defer endSpan(span, &err)
+
+	a, err := s.store.GetSnapshot(ctx, tenantID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.store.GetSnapshot(ctx, tenantID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.CompareSnapshots(a, b), nil
+}
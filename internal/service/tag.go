@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// tagCentroidMaxAge is how old a tenant's tag centroids may get before a tag
+// suggestion rebuilds them first.
+const tagCentroidMaxAge = time.Hour
+
+// TagStore is the data-access interface TagService depends on.
+type TagStore interface {
+	RebuildTagCentroids(ctx context.Context, tenantID string) (*models.TagCentroidRebuild, error)
+	TagCentroidsBuiltAt(ctx context.Context, tenantID string) (*time.Time, error)
+	SuggestTags(ctx context.Context, tenantID, nodeID string, limit int) ([]models.TagSuggestion, error)
+}
+
+// Compile-time check: *TagService must satisfy domain.TagService.
+var _ domain.TagService = (*TagService)(nil)
+
+// TagService suggests tags for nodes from per-tenant tag centroids, keeping
+// the centroids no older than tagCentroidMaxAge.
+type TagService struct {
+	store TagStore
+	log   *logrus.Logger
+	now   func() time.Time
+
+	// rebuilt records this process's last rebuild per tenant, so a tenant
+	// whose nodes have no tags, and so no centroids, is not rescanned on
+	// every suggestion.
+	mu      sync.Mutex
+	rebuilt map[string]time.Time
+}
+
+// NewTagService creates a TagService.
+func NewTagService(store TagStore, log *logrus.Logger) *TagService {
+	return &TagService{store: store, log: log, now: time.Now, rebuilt: make(map[string]time.Time)}
+}
+
+// SuggestTags returns up to limit tags for the node, best first. Centroids
+// that are missing or stale are rebuilt before ranking.
+func (s *TagService) SuggestTags(ctx context.Context, tenantID, nodeID string, limit int) (*models.TagSuggestions, error) {
+	builtAt, err := s.store.TagCentroidsBuiltAt(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.stale(builtAt) && s.stale(s.lastRebuild(tenantID)) {
+		rebuild, err := s.RebuildTagCentroids(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		builtAt = &rebuild.BuiltAt
+	}
+
+	suggestions, err := s.store.SuggestTags(ctx, tenantID, nodeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TagSuggestions{NodeID: nodeID, Suggestions: suggestions, CentroidsBuiltAt: builtAt}, nil
+}
+
+// RebuildTagCentroids recomputes the tenant's tag centroids from its nodes.
+func (s *TagService) RebuildTagCentroids(ctx context.Context, tenantID string) (*models.TagCentroidRebuild, error) {
+	result, err := s.store.RebuildTagCentroids(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.rebuilt[tenantID] = result.BuiltAt
+	s.mu.Unlock()
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"tags":      result.Tags,
+		"nodes":     result.Nodes,
+	}).Debug("tags.rebuild_centroids")
+
+	return result, nil
+}
+
+func (s *TagService) lastRebuild(tenantID string) *time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.rebuilt[tenantID]
+	if !ok {
+		return nil
+	}
+
+	return &t
+}
+
+func (s *TagService) stale(builtAt *time.Time) bool {
+	return builtAt == nil || s.now().Sub(*builtAt) > tagCentroidMaxAge
+}
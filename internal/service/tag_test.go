@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockTagStore struct {
+	builtAt  *time.Time
+	rebuilds int
+	now      time.Time
+	noTags   bool
+}
+
+func (m *mockTagStore) RebuildTagCentroids(_ context.Context, _ string) (*models.TagCentroidRebuild, error) {
+	m.rebuilds++
+	if !m.noTags {
+		m.builtAt = &m.now
+	}
+	return &models.TagCentroidRebuild{Tags: 2, Nodes: 3, BuiltAt: m.now}, nil
+}
+
+func (m *mockTagStore) TagCentroidsBuiltAt(_ context.Context, _ string) (*time.Time, error) {
+	return m.builtAt, nil
+}
+
+func (m *mockTagStore) SuggestTags(_ context.Context, _, _ string, _ int) ([]models.TagSuggestion, error) {
+	return []models.TagSuggestion{{Tag: "infra", Score: 0.9, NodeCount: 3}}, nil
+}
+
+func TestTagService_SuggestTagsRebuildsStaleCentroids(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fresh := now.Add(-10 * time.Minute)
+	stale := now.Add(-2 * tagCentroidMaxAge)
+
+	tests := []struct {
+		name         string
+		builtAt      *time.Time
+		noTags       bool
+		calls        int
+		wantRebuilds int
+	}{
+		{name: "fresh centroids", builtAt: &fresh, calls: 1, wantRebuilds: 0},
+		{name: "stale centroids", builtAt: &stale, calls: 1, wantRebuilds: 1},
+		{name: "never built", calls: 2, wantRebuilds: 1},
+		{name: "tenant without tags is not rescanned", noTags: true, calls: 3, wantRebuilds: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log := logrus.New()
+			log.SetLevel(logrus.ErrorLevel)
+
+			store := &mockTagStore{builtAt: tc.builtAt, now: now, noTags: tc.noTags}
+			svc := NewTagService(store, log)
+			svc.now = func() time.Time { return now }
+
+			for range tc.calls {
+				result, err := svc.SuggestTags(context.Background(), "tenant", "n1", 5)
+				if err != nil || len(result.Suggestions) != 1 || result.NodeID != "n1" {
+					t.Fatalf("SuggestTags = %+v, %v", result, err)
+				}
+			}
+
+			if store.rebuilds != tc.wantRebuilds {
+				t.Errorf("rebuilds = %d, want %d", store.rebuilds, tc.wantRebuilds)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TenantStore is the data-access interface TenantService depends on.
+type TenantStore interface {
+	CreateTenant(ctx context.Context, key string, req models.CreateTenantRequest) (*models.Tenant, error)
+	ListTenants(ctx context.Context) ([]models.Tenant, error)
+	GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error)
+	UpdateTenant(ctx context.Context, tenantID string, req models.UpdateTenantRequest) (*models.Tenant, error)
+	RotateAPIKey(ctx context.Context, tenantID, newKey string, grace time.Duration) (*models.APIKeyStatus, error)
+	SetTenantSuspended(ctx context.Context, tenantID string, suspended bool) (*models.Tenant, error)
+	DeleteTenant(ctx context.Context, tenantID string) (*models.TenantPurgeResult, error)
+	CreateManagedAPIKey(ctx context.Context, tenantID, key string, req models.CreateAPIKeyRequest) (*models.ManagedAPIKey, error)
+	ListManagedAPIKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error)
+	RevokeManagedAPIKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error)
+	CreateImpersonationKey(
+		ctx context.Context, operatorID, tenantID, key string, req models.ImpersonateTenantRequest,
+	) (*models.ManagedAPIKey, error)
+}
+
+// Compile-time check: *TenantService must satisfy domain.TenantService.
+var _ domain.TenantService = (*TenantService)(nil)
+
+// TenantService lets operator tenants create, suspend, and delete tenants.
+type TenantService struct {
+	store TenantStore
+	log   *logrus.Logger
+}
+
+// NewTenantService creates a TenantService.
+func NewTenantService(store TenantStore, log *logrus.Logger) *TenantService {
+	return &TenantService{store: store, log: log}
+}
+
+// CreateTenant creates a tenant with a generated primary API key, which is
+// returned once and cannot be retrieved again.
+func (s *TenantService) CreateTenant(ctx context.Context, req models.CreateTenantRequest) (*models.CreatedTenant, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := s.store.CreateTenant(ctx, key, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenant.ID,
+		"plan":      tenant.Plan,
+		"scope":     req.Scope,
+	}).Info("tenant.create")
+
+	return &models.CreatedTenant{APIKey: key, Tenant: *tenant}, nil
+}
+
+// ListTenants returns every tenant.
+func (s *TenantService) ListTenants(ctx context.Context) ([]models.Tenant, error) {
+	return s.store.ListTenants(ctx)
+}
+
+// GetTenant returns a tenant by ID.
+func (s *TenantService) GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	return s.store.GetTenant(ctx, tenantID)
+}
+
+// UpdateTenant changes a tenant's name, plan, or quotas.
+func (s *TenantService) UpdateTenant(
+	ctx context.Context, tenantID string, req models.UpdateTenantRequest,
+) (*models.Tenant, error) {
+	tenant, err := s.store.UpdateTenant(ctx, tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"tenant_id": tenantID, "plan": tenant.Plan}).Info("tenant.update")
+
+	return tenant, nil
+}
+
+// RotateTenantKey generates a new primary API key for a tenant, for example
+// when its only admin key was lost. The current key keeps working for the
+// requested grace period.
+func (s *TenantService) RotateTenantKey(
+	ctx context.Context, tenantID string, req models.RotateAPIKeyRequest,
+) (*models.APIKeyRotation, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	grace := req.Grace()
+
+	status, err := s.store.RotateAPIKey(ctx, tenantID, key, grace)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"grace":     grace.String(),
+	}).Info("tenant.rotate_key")
+
+	return &models.APIKeyRotation{APIKey: key, APIKeyStatus: *status}, nil
+}
+
+// ListTenantKeys returns a tenant's named keys without revealing them.
+func (s *TenantService) ListTenantKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error) {
+	if _, err := s.store.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	return s.store.ListManagedAPIKeys(ctx, tenantID)
+}
+
+// CreateTenantKey generates a named key for a tenant. The key is returned
+// once and cannot be retrieved again.
+func (s *TenantService) CreateTenantKey(
+	ctx context.Context, tenantID string, req models.CreateAPIKeyRequest,
+) (*models.CreatedAPIKey, error) {
+	if _, err := s.store.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.store.CreateManagedAPIKey(ctx, tenantID, key, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"key_id":    k.ID,
+		"scope":     k.Scope,
+	}).Info("tenant.create_key")
+
+	return &models.CreatedAPIKey{APIKey: key, ManagedAPIKey: *k}, nil
+}
+
+// ImpersonateTenant generates a short-lived key with which operatorID acts as
+// the tenant through the normal API, for reproducing a customer's issue
+// without its keys. The key is returned once and cannot be retrieved again.
+func (s *TenantService) ImpersonateTenant(
+	ctx context.Context, operatorID, tenantID string, req models.ImpersonateTenantRequest,
+) (*models.ImpersonationKey, error) {
+	if operatorID == tenantID {
+		return nil, models.ErrImpersonateOwnTenant
+	}
+
+	if _, err := s.store.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.store.CreateImpersonationKey(ctx, operatorID, tenantID, key, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":   tenantID,
+		"operator_id": operatorID,
+		"key_id":      k.ID,
+		"scope":       k.Scope,
+	}).Info("tenant.impersonate")
+
+	return &models.ImpersonationKey{
+		CreatedAPIKey: models.CreatedAPIKey{APIKey: key, ManagedAPIKey: *k},
+		TenantID:      tenantID,
+		Reason:        req.Reason,
+	}, nil
+}
+
+// RevokeTenantKey stops accepting one of a tenant's named keys.
+func (s *TenantService) RevokeTenantKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error) {
+	k, err := s.store.RevokeManagedAPIKey(ctx, tenantID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"tenant_id": tenantID, "key_id": keyID}).Info("tenant.revoke_key")
+
+	return k, nil
+}
+
+// SuspendTenant rejects every key of the tenant until it is resumed.
+func (s *TenantService) SuspendTenant(ctx context.Context, operatorID, tenantID string) (*models.Tenant, error) {
+	if operatorID == tenantID {
+		return nil, models.ErrOwnTenant
+	}
+
+	tenant, err := s.store.SetTenantSuspended(ctx, tenantID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("tenant_id", tenantID).Info("tenant.suspend")
+
+	return tenant, nil
+}
+
+// ResumeTenant accepts the tenant's keys again.
+func (s *TenantService) ResumeTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	tenant, err := s.store.SetTenantSuspended(ctx, tenantID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("tenant_id", tenantID).Info("tenant.resume")
+
+	return tenant, nil
+}
+
+// DeleteTenant deletes a suspended tenant and purges all of its data.
+func (s *TenantService) DeleteTenant(ctx context.Context, operatorID, tenantID string) (*models.TenantPurgeResult, error) {
+	if operatorID == tenantID {
+		return nil, models.ErrOwnTenant
+	}
+
+	result, err := s.store.DeleteTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"tenant_id":    tenantID,
+		"deleted_rows": result.DeletedRows,
+	}).Info("tenant.delete")
+
+	return result, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// startSpan begins a span for a service call, tagged with the tenant.
+func startSpan(ctx context.Context, name, tenantID string, attrs ...tracing.Attribute) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, name, append(attrs, tracing.String("tenant_id", tenantID))...)
+}
+
+// endSpan records *err on span, if set, and ends it. Defer it with the
+// method's named error result.
+func endSpan(span *tracing.Span, err *error) {
+	span.RecordError(*err)
+	span.End()
+}
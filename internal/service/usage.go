@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// UsageStore is the data-access interface UsageService depends on.
+type UsageStore interface {
+	GetUsage(ctx context.Context, tenantID string) (*models.TenantUsage, error)
+}
+
+// Compile-time check: *UsageService must satisfy domain.UsageService.
+var _ domain.UsageService = (*UsageService)(nil)
+
+// UsageService reports tenant resource usage against quotas and accepts
+// model usage incurred by clients.
+type UsageService struct {
+	store UsageStore
+	meter ModelUsageRecorder
+}
+
+// NewUsageService creates a UsageService that records reported model usage
+// with meter.
+func NewUsageService(store UsageStore, meter ModelUsageRecorder) *UsageService {
+	return &UsageService{store: store, meter: meter}
+}
+
+// GetUsage returns the tenant's current consumption and quota.
+func (s *UsageService) GetUsage(ctx context.Context, tenantID string) (*models.TenantUsage, error) {
+	return s.store.GetUsage(ctx, tenantID)
+}
+
+// ReportLLMUsage records LLM usage a client incurred for the tenant. It
+// returns an error wrapping models.ErrQuotaExceeded once the tenant's
+// monthly LLM token budget is used up, so the client can stop; the usage is
+// recorded either way.
+func (s *UsageService) ReportLLMUsage(ctx context.Context, tenantID string, req models.ReportModelUsageRequest) error {
+	s.meter.RecordModelUsage(tenantID, models.ModelUsageLLM, req.Requests, req.Tokens)
+
+	return s.meter.CheckTokenBudget(ctx, tenantID, models.ModelUsageLLM)
+}
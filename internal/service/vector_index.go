@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// VectorIndexStore inspects and rebuilds the node embedding index.
+type VectorIndexStore interface {
+	VectorIndexStats(ctx context.Context) (*models.VectorIndexStats, error)
+	BuildVectorIndex(ctx context.Context, params models.VectorIndexRequest) error
+}
+
+// VectorIndexService reports on the node embedding index and rebuilds it in
+// the background, one build at a time per server.
+type VectorIndexService struct {
+	store VectorIndexStore
+	log   *logrus.Logger
+
+	mu        sync.Mutex
+	building  bool
+	lastError string
+}
+
+// NewVectorIndexService creates a VectorIndexService.
+func NewVectorIndexService(store VectorIndexStore, log *logrus.Logger) *VectorIndexService {
+	return &VectorIndexService{store: store, log: log}
+}
+
+// VectorIndexStatus reports the index, the parameters a rebuild would use by
+// default, and warnings when semantic search is likely scanning kg_nodes
+// sequentially. Each warning is also logged.
+func (s *VectorIndexService) VectorIndexStatus(ctx context.Context) (*models.VectorIndexStatus, error) {
+	stats, err := s.store.VectorIndexStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading vector index stats: %w", err)
+	}
+
+	method := stats.Params.Method
+	if !stats.Exists {
+		method = models.VectorIndexHNSW
+	}
+
+	status := &models.VectorIndexStatus{
+		VectorIndexStats: *stats,
+		Recommended:      models.VectorIndexRequest{Method: method}.WithDefaults(stats.EmbeddedNodes),
+		Warnings:         vectorIndexWarnings(stats),
+	}
+
+	s.mu.Lock()
+	status.Building, status.LastError = s.building, s.lastError
+	s.mu.Unlock()
+
+	for _, w := range status.Warnings {
+		s.log.WithField("embedded_nodes", stats.EmbeddedNodes).Warn(w)
+	}
+
+	return status, nil
+}
+
+// vectorIndexWarnings explains why vector searches may be sequential scans.
+// Small graphs get none: below models.VectorIndexSeqScanRows a sequential
+// scan is as fast as the index.
+func vectorIndexWarnings(stats *models.VectorIndexStats) []string {
+	warnings := []string{}
+	if stats.EmbeddedNodes < models.VectorIndexSeqScanRows {
+		return warnings
+	}
+
+	switch {
+	case !stats.Exists:
+		warnings = append(warnings, "no vector index exists; semantic search scans every embedded node")
+	case !stats.Valid:
+		warnings = append(warnings, "the vector index is invalid, likely from a failed build; semantic search scans every embedded node until it is rebuilt")
+	case stats.IndexScans == 0 && stats.TableSeqScans > 0:
+		warnings = append(warnings, fmt.Sprintf(
+			"kg_nodes has had %d sequential scans and the vector index none since statistics were reset; semantic search may not be using it",
+			stats.TableSeqScans))
+	}
+
+	// Too few lists make each probe scan a large share of the nodes.
+	if stats.Exists && stats.Params.Method == models.VectorIndexIVFFlat {
+		want := models.VectorIndexRequest{Method: models.VectorIndexIVFFlat}.WithDefaults(stats.EmbeddedNodes).Lists
+		if stats.Params.Lists*4 < want {
+			warnings = append(warnings, fmt.Sprintf("the ivfflat index has %d lists for about %d embedded nodes; rebuild it with about %d",
+				stats.Params.Lists, stats.EmbeddedNodes, want))
+		}
+	}
+
+	return warnings
+}
+
+// RebuildVectorIndex fills in the validated req's omitted parameters for the
+// current number of embedded nodes and starts building the index in the
+// background. It returns the parameters used, or ErrVectorIndexBuilding
+// while a build is running.
+func (s *VectorIndexService) RebuildVectorIndex(ctx context.Context, req models.VectorIndexRequest) (*models.VectorIndexRequest, error) {
+	stats, err := s.store.VectorIndexStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading vector index stats: %w", err)
+	}
+
+	params := req.WithDefaults(stats.EmbeddedNodes)
+
+	s.mu.Lock()
+	if s.building {
+		s.mu.Unlock()
+		return nil, models.ErrVectorIndexBuilding
+	}
+	s.building, s.lastError = true, ""
+	s.mu.Unlock()
+
+	go s.build(context.WithoutCancel(ctx), params)
+
+	return &params, nil
+}
+
+func (s *VectorIndexService) build(ctx context.Context, params models.VectorIndexRequest) {
+	log := s.log.WithFields(logrus.Fields{
+		"method": params.Method, "m": params.M, "ef_construction": params.EfConstruction, "lists": params.Lists,
+	})
+	log.Info("building vector index")
+
+	err := s.store.BuildVectorIndex(ctx, params)
+
+	s.mu.Lock()
+	s.building = false
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		log.WithError(err).Error("building vector index")
+		return
+	}
+
+	log.Info("vector index built")
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+type mockVectorIndexStore struct {
+	stats   models.VectorIndexStats
+	release chan struct{}
+	built   chan models.VectorIndexRequest
+	err     error
+}
+
+func (m *mockVectorIndexStore) VectorIndexStats(context.Context) (*models.VectorIndexStats, error) {
+	stats := m.stats
+	return &stats, nil
+}
+
+func (m *mockVectorIndexStore) BuildVectorIndex(_ context.Context, params models.VectorIndexRequest) error {
+	<-m.release
+	m.built <- params
+	return m.err
+}
+
+func quietLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	return log
+}
+
+func TestVectorIndexStatus_Warnings(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats models.VectorIndexStats
+		want  string
+	}{
+		{"small graph", models.VectorIndexStats{EmbeddedNodes: 500}, ""},
+		{"missing", models.VectorIndexStats{EmbeddedNodes: 50000}, "no vector index"},
+		{"invalid", models.VectorIndexStats{Exists: true, EmbeddedNodes: 50000}, "invalid"},
+		{"unused", models.VectorIndexStats{
+			Exists: true, Valid: true, EmbeddedNodes: 50000, TableSeqScans: 7,
+			Params: models.VectorIndexRequest{Method: "hnsw", M: 16, EfConstruction: 64},
+		}, "7 sequential scans"},
+		{"too few lists", models.VectorIndexStats{
+			Exists: true, Valid: true, EmbeddedNodes: 500000, IndexScans: 3,
+			Params: models.VectorIndexRequest{Method: "ivfflat", Lists: 10},
+		}, "rebuild it with about 500"},
+		{"healthy", models.VectorIndexStats{
+			Exists: true, Valid: true, EmbeddedNodes: 50000, IndexScans: 3, TableSeqScans: 7,
+			Params: models.VectorIndexRequest{Method: "hnsw", M: 16, EfConstruction: 64},
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewVectorIndexService(&mockVectorIndexStore{stats: tt.stats}, quietLogger())
+
+			status, err := svc.VectorIndexStatus(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" && len(status.Warnings) != 0 {
+				t.Errorf("warnings = %v, want none", status.Warnings)
+			}
+			if tt.want != "" && (len(status.Warnings) != 1 || !strings.Contains(status.Warnings[0], tt.want)) {
+				t.Errorf("warnings = %v, want one containing %q", status.Warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestVectorIndexStatus_Recommended(t *testing.T) {
+	store := &mockVectorIndexStore{stats: models.VectorIndexStats{EmbeddedNodes: 4_000_000}}
+	svc := NewVectorIndexService(store, quietLogger())
+
+	status, err := svc.VectorIndexStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (models.VectorIndexRequest{Method: "hnsw", M: 32, EfConstruction: 200}); status.Recommended != want {
+		t.Errorf("recommended = %+v, want %+v", status.Recommended, want)
+	}
+
+	store.stats = models.VectorIndexStats{Exists: true, Valid: true, EmbeddedNodes: 4_000_000, Params: models.VectorIndexRequest{Method: "ivfflat", Lists: 2000}}
+	if status, _ = svc.VectorIndexStatus(context.Background()); status.Recommended.Lists != 2000 {
+		t.Errorf("ivfflat lists = %d, want sqrt(rows) = 2000", status.Recommended.Lists)
+	}
+}
+
+func TestRebuildVectorIndex(t *testing.T) {
+	store := &mockVectorIndexStore{
+		stats:   models.VectorIndexStats{EmbeddedNodes: 50000},
+		release: make(chan struct{}),
+		built:   make(chan models.VectorIndexRequest, 1),
+		err:     errors.New("out of memory"),
+	}
+	svc := NewVectorIndexService(store, quietLogger())
+	ctx := context.Background()
+
+	params, err := svc.RebuildVectorIndex(ctx, models.VectorIndexRequest{Method: "ivfflat"})
+	if err != nil || params.Lists != 50 {
+		t.Fatalf("params = %+v, err = %v; want 50 lists", params, err)
+	}
+
+	if _, err := svc.RebuildVectorIndex(ctx, models.VectorIndexRequest{}); !errors.Is(err, models.ErrVectorIndexBuilding) {
+		t.Errorf("second build: err = %v, want ErrVectorIndexBuilding", err)
+	}
+	if status, _ := svc.VectorIndexStatus(ctx); !status.Building {
+		t.Error("status should report the running build")
+	}
+
+	close(store.release)
+	if built := <-store.built; built != *params {
+		t.Errorf("built %+v, want %+v", built, *params)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		status, _ := svc.VectorIndexStatus(ctx)
+		if !status.Building {
+			if status.LastError != "out of memory" {
+				t.Errorf("last error = %q", status.LastError)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("build never finished")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
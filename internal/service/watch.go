@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// WatchStore is the data-access interface WatchService depends on.
+type WatchStore interface {
+	Watch(ctx context.Context, tenantID, watcher, nodeID string) (*models.Watch, error)
+	Unwatch(ctx context.Context, tenantID, watcher, nodeID string) error
+	ListWatches(ctx context.Context, tenantID, watcher string) ([]models.Watch, error)
+}
+
+// Compile-time check: *WatchService must satisfy domain.WatchService.
+var _ domain.WatchService = (*WatchService)(nil)
+
+// WatchService manages the nodes each named watcher follows. Subscribers
+// that give a watcher name receive only the events for its nodes.
+type WatchService struct {
+	store WatchStore
+}
+
+// NewWatchService creates a WatchService.
+func NewWatchService(store WatchStore) *WatchService {
+	return &WatchService{store: store}
+}
+
+// Watch makes watcher follow nodeID.
+func (s *WatchService) Watch(ctx context.Context, tenantID, watcher, nodeID string) (_ *models.Watch, err error) {
+	ctx, span := startSpan(ctx, "WatchService.Watch", tenantID,
+		tracing.String("watcher", watcher), tracing.String("node_id", nodeID))
+	defer endSpan(span, &err)
+
+	return s.store.Watch(ctx, tenantID, watcher, nodeID)
+}
+
+// Unwatch stops watcher following nodeID.
+func (s *WatchService) Unwatch(ctx context.Context, tenantID, watcher, nodeID string) (err error) {
+	ctx, span := startSpan(ctx, "WatchService.Unwatch", tenantID,
+		tracing.String("watcher", watcher), tracing.String("node_id", nodeID))
+	defer endSpan(span, &err)
+
+	return s.store.Unwatch(ctx, tenantID, watcher, nodeID)
+}
+
+// ListWatches returns the nodes watcher follows.
+func (s *WatchService) ListWatches(ctx context.Context, tenantID, watcher string) (_ []models.Watch, err error) {
+	ctx, span := startSpan(ctx, "WatchService.ListWatches", tenantID, tracing.String("watcher", watcher))
+	defer endSpan(span, &err)
+
+	return s.store.ListWatches(ctx, tenantID, watcher)
+}
@@ -0,0 +1,36 @@
+package storage
+
+import "github.com/persistorai/persistor/internal/models"
+
+// DuplicateCandidatePair is a pair of nodes that share a normalized label or
+// alias, a candidate for merging.
+type DuplicateCandidatePair struct {
+	Left              models.Node
+	Right             models.Node
+	SharedNames       []string
+	SameLabel         bool
+	LabelAliasOverlap bool
+}
+
+// DuplicateNodePair is a scored duplicate candidate. Left has the smaller ID.
+type DuplicateNodePair struct {
+	Left                models.MergeSuggestionNode
+	Right               models.MergeSuggestionNode
+	Score               float64
+	LabelSimilarity     float64
+	EmbeddingSimilarity *float64
+}
+
+// ReprocessableNode contains the minimum fields needed to rebuild search text and embeddings.
+type ReprocessableNode struct {
+	ID                 string
+	Type               string
+	Label              string
+	Properties         map[string]any
+	CurrentSearchText  string
+	NeedsSearchText    bool
+	NeedsEmbedding     bool
+	HasFactEvidence    bool
+	HasSupersededFacts bool
+	NodeSuperseded     bool
+}
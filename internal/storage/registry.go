@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/crypto"
+)
+
+// ErrUnknownBackend is returned by Open for a backend name nothing has
+// registered.
+var ErrUnknownBackend = errors.New("unknown storage backend")
+
+// Config is what Open passes to a backend's Factory. Backends ignore the
+// fields they have no use for.
+type Config struct {
+	// URL locates the backend's data, e.g. a Postgres connection string.
+	URL            string
+	MaxConns       int32
+	Log            *logrus.Logger
+	Crypto         *crypto.Service
+	EmbeddingModel string
+	DetectLanguage bool
+	// SearchableProperties are the property keys list and search may filter
+	// on with props.
+	SearchableProperties []string
+}
+
+// Backend is an opened storage backend: one implementation of each storage
+// interface, all sharing the backend's connection.
+type Backend struct {
+	Nodes      NodeStorage
+	Edges      EdgeStorage
+	Search     SearchStorage
+	Graph      GraphStorage
+	Bulk       BulkStorage
+	Embeddings EmbeddingStorage
+	// Release frees the backend's resources, e.g. its connection pool. Nil
+	// when there are none.
+	Release func()
+}
+
+// Close releases the backend's resources.
+func (b *Backend) Close() {
+	if b.Release != nil {
+		b.Release()
+	}
+}
+
+// Factory opens a backend with cfg.
+type Factory func(ctx context.Context, cfg Config) (*Backend, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a backend available to Open under name. Backends register
+// themselves from an init function. Register panics if name is already taken
+// or factory is nil.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		panic("storage: Register factory is nil for " + name)
+	}
+
+	if _, dup := registry[name]; dup {
+		panic("storage: Register called twice for " + name)
+	}
+
+	registry[name] = factory
+}
+
+// Open opens the backend registered under name.
+func Open(ctx context.Context, name string, cfg Config) (*Backend, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q (registered: %v)", ErrUnknownBackend, name, Backends())
+	}
+
+	backend, err := factory(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s storage: %w", name, err)
+	}
+
+	return backend, nil
+}
+
+// Backends returns the sorted names of the registered backends.
+func Backends() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
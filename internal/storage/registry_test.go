@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/storage"
+)
+
+func TestRegistry(t *testing.T) {
+	var got storage.Config
+
+	storage.Register("registry-test", func(_ context.Context, cfg storage.Config) (*storage.Backend, error) {
+		got = cfg
+		return &storage.Backend{}, nil
+	})
+
+	if !slices.Contains(storage.Backends(), "registry-test") {
+		t.Fatalf("Backends() = %v, want registry-test listed", storage.Backends())
+	}
+
+	backend, err := storage.Open(context.Background(), "registry-test", storage.Config{URL: "mem://"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	backend.Close()
+
+	if got.URL != "mem://" {
+		t.Errorf("factory got URL %q, want mem://", got.URL)
+	}
+
+	if _, err := storage.Open(context.Background(), "no-such-backend", storage.Config{}); !errors.Is(err, storage.ErrUnknownBackend) {
+		t.Errorf("Open unknown backend err = %v, want ErrUnknownBackend", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a name twice did not panic")
+		}
+	}()
+	storage.Register("registry-test", func(context.Context, storage.Config) (*storage.Backend, error) {
+		return nil, nil
+	})
+}
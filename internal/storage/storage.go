@@ -0,0 +1,80 @@
+// Package storage defines the interfaces services use to persist and query
+// the knowledge graph, and a registry of the backends that implement them.
+// Services depend only on these interfaces, so a backend other than the
+// Postgres one in package store can be added by registering it here.
+package storage
+
+import (
+	"context"
+
+	"github.com/persistorai/persistor/internal/domain"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// NodeStorage stores nodes. Its method set matches domain.NodeService, which
+// wraps it with embedding and audit logic.
+type NodeStorage interface {
+	domain.NodeService
+}
+
+// EdgeStorage stores edges. Its method set matches domain.EdgeService, which
+// wraps it with audit logic.
+type EdgeStorage interface {
+	domain.EdgeService
+}
+
+// SearchStorage finds nodes by text, by embedding, or by both.
+type SearchStorage interface {
+	FullTextSearch(ctx context.Context, tenantID string, query string, filter models.SearchFilter, limit int) ([]models.Node, error)
+	FullTextSearchFacets(ctx context.Context, tenantID string, query string, filter models.SearchFilter) (*models.SearchFacets, error)
+	SemanticSearch(ctx context.Context, tenantID string, embedding []float32, filter models.SearchFilter, limit int) ([]models.ScoredNode, error)
+	HybridSearch(ctx context.Context, tenantID string, query string, embedding []float32, filter models.SearchFilter, limit int) ([]models.Node, error)
+}
+
+// GraphStorage answers traversal and graph-shape queries.
+type GraphStorage interface {
+	Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (*models.NeighborResult, error)
+	Traverse(ctx context.Context, tenantID string, nodeID string, maxHops int, opts models.ReadOpts) (*models.TraverseResult, error)
+	GraphContext(ctx context.Context, tenantID, nodeID string) (*models.ContextResult, error)
+	ShortestPath(ctx context.Context, tenantID, fromID, toID string) ([]models.Node, error)
+	Summary(ctx context.Context, tenantID, nodeID string, limit int) (*models.GraphSummary, error)
+	Subgraph(ctx context.Context, tenantID string, req models.SubgraphRequest) (*models.SubgraphResult, error)
+	LoadCommunityGraph(ctx context.Context, tenantID, relation string) (*CommunityGraph, error)
+	GraphAsOf(ctx context.Context, tenantID string, q models.AsOfQuery) (*models.GraphSnapshot, error)
+}
+
+// BulkStorage upserts many nodes or edges at once.
+type BulkStorage interface {
+	BulkUpsertNodes(ctx context.Context, tenantID string, nodes []models.CreateNodeRequest) ([]models.Node, error)
+	BulkUpsertEdges(ctx context.Context, tenantID string, edges []models.CreateEdgeRequest) ([]models.Edge, error)
+}
+
+// EmbeddingStorage stores node embeddings and the embedding cache.
+type EmbeddingStorage interface {
+	UpdateNodeEmbedding(ctx context.Context, tenantID, nodeID string, embedding []float32) error
+	ListNodesWithoutEmbeddings(ctx context.Context, tenantID string, limit int) ([]models.NodeSummary, error)
+	GetCachedEmbeddings(ctx context.Context, keys [][]byte) (map[string][]float32, error)
+	PutCachedEmbeddings(ctx context.Context, keys [][]byte, embeddings [][]float32) error
+	EvictEmbeddingCache(ctx context.Context, maxEntries int) (int, error)
+}
+
+// CommunityNode is the minimal node view used by community detection.
+type CommunityNode struct {
+	ID       string
+	Label    string
+	Salience float64
+}
+
+// CommunityEdge is an undirected weighted link used by community detection.
+type CommunityEdge struct {
+	Source string
+	Target string
+	Weight float64
+}
+
+// CommunityGraph is the tenant graph projection loaded for clustering.
+type CommunityGraph struct {
+	Nodes     []CommunityNode
+	Edges     []CommunityEdge
+	Truncated bool
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// AccessStatsStore keeps the hourly access counters behind access analytics.
+type AccessStatsStore struct {
+	Base
+}
+
+// NewAccessStatsStore creates a new AccessStatsStore.
+func NewAccessStatsStore(base Base) *AccessStatsStore {
+	return &AccessStatsStore{Base: base}
+}
+
+// RecordAccessStats adds counts to the tenant's counters for the current
+// hour and prunes counters older than models.MaxAccessAnalyticsDays.
+func (s *AccessStatsStore) RecordAccessStats(ctx context.Context, tenantID string, counts []models.AccessCount) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	kinds := make([]string, len(counts))
+	keys := make([]string, len(counts))
+	values := make([]int64, len(counts))
+	for i, c := range counts {
+		kinds[i], keys[i], values[i] = string(c.Kind), c.Key, c.Count
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("recording access stats: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `INSERT INTO kg_access_stats (tenant_id, kind, key, bucket, count)
+		SELECT current_setting('app.tenant_id')::uuid, kind, key, date_trunc('hour', NOW()), SUM(count)
+		FROM unnest($1::text[], $2::text[], $3::bigint[]) AS c(kind, key, count)
+		GROUP BY kind, key
+		ON CONFLICT (tenant_id, kind, key, bucket) DO UPDATE
+			SET count = kg_access_stats.count + EXCLUDED.count`,
+		kinds, keys, values,
+	); err != nil {
+		return fmt.Errorf("recording access stats: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM kg_access_stats
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND bucket < NOW() - make_interval(days => $1)`,
+		models.MaxAccessAnalyticsDays,
+	); err != nil {
+		return fmt.Errorf("pruning access stats: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing access stats: %w", err)
+	}
+
+	return nil
+}
+
+// GetAccessAnalytics ranks the tenant's most read nodes, most run searches,
+// and most traversed-from nodes over the last req.Days days.
+func (s *AccessStatsStore) GetAccessAnalytics(
+	ctx context.Context, tenantID string, req models.AccessAnalyticsRequest,
+) (*models.AccessAnalytics, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("reading access analytics: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	until := time.Now().UTC()
+	result := &models.AccessAnalytics{Since: until.AddDate(0, 0, -req.Days), Until: until}
+
+	if result.TopNodes, err = topAccessedNodes(ctx, tx, models.AccessRead, result.Since, req.Limit); err != nil {
+		return nil, err
+	}
+
+	if result.TraversalHotSpots, err = topAccessedNodes(ctx, tx, models.AccessTraverse, result.Since, req.Limit); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT key, SUM(count)::bigint AS total
+		FROM kg_access_stats
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND kind = $1 AND bucket >= date_trunc('hour', $2::timestamptz)
+		GROUP BY key
+		ORDER BY total DESC, key
+		LIMIT $3`,
+		string(models.AccessSearch), result.Since, req.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ranking search queries: %w", err)
+	}
+
+	result.TopQueries, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SearchQueryCount, error) {
+		var q models.SearchQueryCount
+
+		return q, row.Scan(&q.QueryHash, &q.Count)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ranking search queries: %w", err)
+	}
+
+	return result, nil
+}
+
+// topAccessedNodes ranks the node keys of kind by count since since, with
+// the type and label of nodes that still exist.
+func topAccessedNodes(
+	ctx context.Context, tx pgx.Tx, kind models.AccessKind, since time.Time, limit int,
+) ([]models.AccessedNode, error) {
+	rows, err := tx.Query(ctx, `SELECT a.key, COALESCE(n.type, ''), COALESCE(n.label, ''), a.total
+		FROM (
+			SELECT key, SUM(count)::bigint AS total
+			FROM kg_access_stats
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND kind = $1 AND bucket >= date_trunc('hour', $2::timestamptz)
+			GROUP BY key
+			ORDER BY total DESC, key
+			LIMIT $3
+		) a
+		LEFT JOIN kg_nodes n ON n.tenant_id = current_setting('app.tenant_id')::uuid AND n.id = a.key
+		ORDER BY a.total DESC, a.key`,
+		string(kind), since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ranking %s accesses: %w", kind, err)
+	}
+
+	nodes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.AccessedNode, error) {
+		var n models.AccessedNode
+
+		return n, row.Scan(&n.NodeID, &n.Type, &n.Label, &n.Count)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ranking %s accesses: %w", kind, err)
+	}
+
+	return nodes, nil
+}
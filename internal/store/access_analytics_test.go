@@ -0,0 +1,66 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestAccessAnalytics(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	as := store.NewAccessStatsStore(base)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	for _, id := range []string{"acc-a", "acc-b"} {
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: id, Type: "note", Label: id}); err != nil {
+			t.Fatalf("CreateNode(%s): %v", id, err)
+		}
+	}
+
+	hash := models.HashSearchQuery("who is ada")
+	batches := [][]models.AccessCount{
+		{{Kind: models.AccessRead, Key: "acc-a", Count: 2}, {Kind: models.AccessRead, Key: "acc-b", Count: 5}},
+		{{Kind: models.AccessRead, Key: "acc-a", Count: 4}, {Kind: models.AccessSearch, Key: hash, Count: 3}},
+		{{Kind: models.AccessTraverse, Key: "acc-gone", Count: 1}},
+	}
+	for _, counts := range batches {
+		if err := as.RecordAccessStats(ctx, tenantID, counts); err != nil {
+			t.Fatalf("RecordAccessStats: %v", err)
+		}
+	}
+
+	req := models.AccessAnalyticsRequest{}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	got, err := as.GetAccessAnalytics(ctx, tenantID, req)
+	if err != nil {
+		t.Fatalf("GetAccessAnalytics: %v", err)
+	}
+
+	// Counts in the same hour add up: a was read 6 times, b 5.
+	if len(got.TopNodes) != 2 || got.TopNodes[0].NodeID != "acc-a" || got.TopNodes[0].Count != 6 ||
+		got.TopNodes[0].Label != "acc-a" || got.TopNodes[1].Count != 5 {
+		t.Errorf("TopNodes = %+v, want acc-a (6) then acc-b (5)", got.TopNodes)
+	}
+
+	if len(got.TopQueries) != 1 || got.TopQueries[0].QueryHash != hash || got.TopQueries[0].Count != 3 {
+		t.Errorf("TopQueries = %+v", got.TopQueries)
+	}
+
+	// A deleted node still ranks, without type or label.
+	if len(got.TraversalHotSpots) != 1 || got.TraversalHotSpots[0].NodeID != "acc-gone" || got.TraversalHotSpots[0].Label != "" {
+		t.Errorf("TraversalHotSpots = %+v", got.TraversalHotSpots)
+	}
+
+	// Another tenant sees none of it.
+	otherBase, otherTenant := setupTestBase(t)
+	other, err := store.NewAccessStatsStore(otherBase).GetAccessAnalytics(ctx, otherTenant, req)
+	if err != nil || len(other.TopNodes) != 0 || len(other.TopQueries) != 0 {
+		t.Errorf("other tenant = %+v, %v; want empty", other, err)
+	}
+}
@@ -7,22 +7,71 @@
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
 )
 
 const defaultMergeSuggestionLimit = 25
 
-type DuplicateCandidatePair struct {
-	Left              models.Node
-	Right             models.Node
-	SharedNames       []string
-	SameLabel         bool
-	LabelAliasOverlap bool
-}
+// candidateNodeColumns are the kg_nodes columns each side of a duplicate
+// candidate pair is scanned from.
+const candidateNodeColumns = `id, tenant_id, type, label, properties,
+	access_count, last_accessed, salience_score, superseded_by,
+	user_boosted, created_at, updated_at`
+
+// duplicateCandidatePairsSQL pairs active nodes of the same type that share a
+// normalized label or alias. $1 is the type filter, empty for all types, and $2 the limit.
+const duplicateCandidatePairsSQL = `WITH active_nodes AS (
+		SELECT ` + candidateNodeColumns + `,
+			LOWER(regexp_replace(BTRIM(label), '\\s+', ' ', 'g')) AS normalized_label
+		FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND superseded_by IS NULL
+		  AND ($1 = '' OR type = $1)
+	), node_names AS (
+		SELECT id AS node_id, normalized_label AS normalized_name, 'label' AS source
+		FROM active_nodes
+		WHERE normalized_label <> ''
+		UNION ALL
+		SELECT a.node_id, a.normalized_alias AS normalized_name, 'alias' AS source
+		FROM kg_aliases a
+		INNER JOIN active_nodes n ON n.id = a.node_id
+		WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
+		  AND a.normalized_alias <> ''
+	), shared_names AS (
+		SELECT n1.node_id AS left_id,
+			n2.node_id AS right_id,
+			array_agg(DISTINCT n1.normalized_name ORDER BY n1.normalized_name) AS shared_names,
+			bool_or(n1.source = 'label' AND n2.source = 'label') AS same_label,
+			bool_or((n1.source = 'label' AND n2.source = 'alias') OR (n1.source = 'alias' AND n2.source = 'label')) AS label_alias_overlap
+		FROM node_names n1
+		INNER JOIN node_names n2 ON n1.normalized_name = n2.normalized_name AND n1.node_id < n2.node_id
+		GROUP BY n1.node_id, n2.node_id
+	)
+	SELECT
+		l.id, l.tenant_id, l.type, l.label, l.properties,
+		l.access_count, l.last_accessed, l.salience_score, l.superseded_by,
+		l.user_boosted, l.created_at, l.updated_at,
+		r.id, r.tenant_id, r.type, r.label, r.properties,
+		r.access_count, r.last_accessed, r.salience_score, r.superseded_by,
+		r.user_boosted, r.created_at, r.updated_at,
+		s.shared_names,
+		s.same_label,
+		s.label_alias_overlap
+	FROM shared_names s
+	INNER JOIN active_nodes l ON l.id = s.left_id
+	INNER JOIN active_nodes r ON r.id = s.right_id
+	WHERE l.type = r.type
+	ORDER BY s.same_label DESC,
+		cardinality(s.shared_names) DESC,
+		GREATEST(l.salience_score, r.salience_score) DESC,
+		LEAST(l.id, r.id) ASC
+	LIMIT $2`
 
 // ListDuplicateCandidatePairs returns likely duplicate node pairs based on shared normalized labels/aliases.
-func (s *AdminStore) ListDuplicateCandidatePairs(ctx context.Context, tenantID, typeFilter string, limit int) ([]DuplicateCandidatePair, error) {
+func (s *AdminStore) ListDuplicateCandidatePairs(ctx context.Context, tenantID, typeFilter string, limit int) ([]storage.DuplicateCandidatePair, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -39,99 +88,14 @@ func (s *AdminStore) ListDuplicateCandidatePairs(ctx context.Context, tenantID,
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck
 
-	const candidateNodeColumns = `id, tenant_id, type, label, properties,
-		access_count, last_accessed, salience_score, superseded_by,
-		user_boosted, created_at, updated_at`
-
-	query := `WITH active_nodes AS (
-			SELECT ` + candidateNodeColumns + `,
-				LOWER(regexp_replace(BTRIM(label), '\\s+', ' ', 'g')) AS normalized_label
-			FROM kg_nodes
-			WHERE tenant_id = current_setting('app.tenant_id')::uuid
-			  AND superseded_by IS NULL
-			  AND ($1 = '' OR type = $1)
-		), node_names AS (
-			SELECT id AS node_id, normalized_label AS normalized_name, 'label' AS source
-			FROM active_nodes
-			WHERE normalized_label <> ''
-			UNION ALL
-			SELECT a.node_id, a.normalized_alias AS normalized_name, 'alias' AS source
-			FROM kg_aliases a
-			INNER JOIN active_nodes n ON n.id = a.node_id
-			WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
-			  AND a.normalized_alias <> ''
-		), shared_names AS (
-			SELECT n1.node_id AS left_id,
-				n2.node_id AS right_id,
-				array_agg(DISTINCT n1.normalized_name ORDER BY n1.normalized_name) AS shared_names,
-				bool_or(n1.source = 'label' AND n2.source = 'label') AS same_label,
-				bool_or((n1.source = 'label' AND n2.source = 'alias') OR (n1.source = 'alias' AND n2.source = 'label')) AS label_alias_overlap
-			FROM node_names n1
-			INNER JOIN node_names n2 ON n1.normalized_name = n2.normalized_name AND n1.node_id < n2.node_id
-			GROUP BY n1.node_id, n2.node_id
-		)
-		SELECT
-			l.id, l.tenant_id, l.type, l.label, l.properties,
-			l.access_count, l.last_accessed, l.salience_score, l.superseded_by,
-			l.user_boosted, l.created_at, l.updated_at,
-			r.id, r.tenant_id, r.type, r.label, r.properties,
-			r.access_count, r.last_accessed, r.salience_score, r.superseded_by,
-			r.user_boosted, r.created_at, r.updated_at,
-			s.shared_names,
-			s.same_label,
-			s.label_alias_overlap
-		FROM shared_names s
-		INNER JOIN active_nodes l ON l.id = s.left_id
-		INNER JOIN active_nodes r ON r.id = s.right_id
-		WHERE l.type = r.type
-		ORDER BY s.same_label DESC,
-			cardinality(s.shared_names) DESC,
-			GREATEST(l.salience_score, r.salience_score) DESC,
-			LEAST(l.id, r.id) ASC
-		LIMIT $2`
-
-	rows, err := tx.Query(ctx, query, typeFilter, limit)
+	rows, err := tx.Query(ctx, duplicateCandidatePairsSQL, typeFilter, limit)
 	if err != nil {
 		return nil, fmt.Errorf("querying duplicate candidate pairs: %w", err)
 	}
-	defer rows.Close()
 
-	pairs := make([]DuplicateCandidatePair, 0, limit)
-	for rows.Next() {
-		var (
-			pair                                DuplicateCandidatePair
-			leftProps, rightProps               []byte
-			leftTenantID, rightTenantID         uuid.UUID
-			leftLastAccessed, rightLastAccessed *time.Time
-			leftSupersededBy, rightSupersededBy *string
-		)
-		if err := rows.Scan(
-			&pair.Left.ID, &leftTenantID, &pair.Left.Type, &pair.Left.Label, &leftProps,
-			&pair.Left.AccessCount, &leftLastAccessed, &pair.Left.Salience, &leftSupersededBy,
-			&pair.Left.UserBoosted, &pair.Left.CreatedAt, &pair.Left.UpdatedAt,
-			&pair.Right.ID, &rightTenantID, &pair.Right.Type, &pair.Right.Label, &rightProps,
-			&pair.Right.AccessCount, &rightLastAccessed, &pair.Right.Salience, &rightSupersededBy,
-			&pair.Right.UserBoosted, &pair.Right.CreatedAt, &pair.Right.UpdatedAt,
-			&pair.SharedNames, &pair.SameLabel, &pair.LabelAliasOverlap,
-		); err != nil {
-			return nil, fmt.Errorf("scanning duplicate candidate pair: %w", err)
-		}
-		pair.Left.TenantID = leftTenantID
-		pair.Left.LastAccessed = leftLastAccessed
-		pair.Left.SupersededBy = leftSupersededBy
-		pair.Right.TenantID = rightTenantID
-		pair.Right.LastAccessed = rightLastAccessed
-		pair.Right.SupersededBy = rightSupersededBy
-		if err := json.Unmarshal(leftProps, &pair.Left.Properties); err != nil {
-			return nil, fmt.Errorf("unmarshalling left duplicate candidate properties: %w", err)
-		}
-		if err := json.Unmarshal(rightProps, &pair.Right.Properties); err != nil {
-			return nil, fmt.Errorf("unmarshalling right duplicate candidate properties: %w", err)
-		}
-		pairs = append(pairs, pair)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating duplicate candidate pairs: %w", err)
+	pairs, err := scanDuplicateCandidatePairs(rows, limit)
+	if err != nil {
+		return nil, err
 	}
 
 	for i := range pairs {
@@ -149,3 +113,162 @@ func (s *AdminStore) ListDuplicateCandidatePairs(ctx context.Context, tenantID,
 
 	return pairs, nil
 }
+
+// scanDuplicateCandidatePairs reads the rows of duplicateCandidatePairsSQL
+// and closes them.
+func scanDuplicateCandidatePairs(rows pgx.Rows, limit int) ([]storage.DuplicateCandidatePair, error) {
+	defer rows.Close()
+
+	pairs := make([]storage.DuplicateCandidatePair, 0, limit)
+	for rows.Next() {
+		pair, err := scanDuplicateCandidatePair(rows)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating duplicate candidate pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// scanDuplicateCandidatePair scans one pair, still encrypted.
+func scanDuplicateCandidatePair(rows pgx.Rows) (storage.DuplicateCandidatePair, error) {
+	var (
+		pair                                storage.DuplicateCandidatePair
+		leftProps, rightProps               []byte
+		leftTenantID, rightTenantID         uuid.UUID
+		leftLastAccessed, rightLastAccessed *time.Time
+		leftSupersededBy, rightSupersededBy *string
+	)
+	if err := rows.Scan(
+		&pair.Left.ID, &leftTenantID, &pair.Left.Type, &pair.Left.Label, &leftProps,
+		&pair.Left.AccessCount, &leftLastAccessed, &pair.Left.Salience, &leftSupersededBy,
+		&pair.Left.UserBoosted, &pair.Left.CreatedAt, &pair.Left.UpdatedAt,
+		&pair.Right.ID, &rightTenantID, &pair.Right.Type, &pair.Right.Label, &rightProps,
+		&pair.Right.AccessCount, &rightLastAccessed, &pair.Right.Salience, &rightSupersededBy,
+		&pair.Right.UserBoosted, &pair.Right.CreatedAt, &pair.Right.UpdatedAt,
+		&pair.SharedNames, &pair.SameLabel, &pair.LabelAliasOverlap,
+	); err != nil {
+		return pair, fmt.Errorf("scanning duplicate candidate pair: %w", err)
+	}
+	pair.Left.TenantID = leftTenantID
+	pair.Left.LastAccessed = leftLastAccessed
+	pair.Left.SupersededBy = leftSupersededBy
+	pair.Right.TenantID = rightTenantID
+	pair.Right.LastAccessed = rightLastAccessed
+	pair.Right.SupersededBy = rightSupersededBy
+	if err := json.Unmarshal(leftProps, &pair.Left.Properties); err != nil {
+		return pair, fmt.Errorf("unmarshalling left duplicate candidate properties: %w", err)
+	}
+	if err := json.Unmarshal(rightProps, &pair.Right.Properties); err != nil {
+		return pair, fmt.Errorf("unmarshalling right duplicate candidate properties: %w", err)
+	}
+
+	return pair, nil
+}
+
+// duplicateNeighbors is how many nearest embedding neighbours of each node
+// are considered as duplicate candidates.
+const duplicateNeighbors = 5
+
+// duplicateNodesSQL scores the candidate pairs of same-type nodes: $1 is the
+// type filter, $2 the neighbour count, $3 and $4 the label and embedding
+// weights, $5 the minimum score, and $6 the limit.
+const duplicateNodesSQL = `WITH pairs AS (
+		SELECT l.id AS left_id, r.id AS right_id
+		FROM kg_nodes l
+		INNER JOIN kg_nodes r ON r.tenant_id = l.tenant_id AND r.type = l.type AND r.id > l.id
+			AND lower(r.label) % lower(l.label)
+		WHERE l.tenant_id = current_setting('app.tenant_id')::uuid
+			AND l.superseded_by IS NULL AND r.superseded_by IS NULL
+			AND ($1 = '' OR l.type = $1)
+		UNION
+		SELECT LEAST(l.id, n.id), GREATEST(l.id, n.id)
+		FROM kg_nodes l
+		CROSS JOIN LATERAL (
+			SELECT r.id FROM kg_nodes r
+			WHERE r.tenant_id = l.tenant_id AND r.type = l.type AND r.id <> l.id
+				AND r.superseded_by IS NULL AND r.embedding IS NOT NULL
+			ORDER BY r.embedding <=> l.embedding
+			LIMIT $2
+		) n
+		WHERE l.tenant_id = current_setting('app.tenant_id')::uuid
+			AND l.superseded_by IS NULL AND l.embedding IS NOT NULL
+			AND ($1 = '' OR l.type = $1)
+	), scored AS (
+		SELECT l.id AS left_id, l.type AS left_type, l.label AS left_label, l.salience_score AS left_salience,
+			r.id AS right_id, r.type AS right_type, r.label AS right_label, r.salience_score AS right_salience,
+			similarity(lower(l.label), lower(r.label))::float8 AS label_similarity,
+			(1 - (l.embedding <=> r.embedding))::float8 AS embedding_similarity
+		FROM pairs p
+		INNER JOIN kg_nodes l ON l.tenant_id = current_setting('app.tenant_id')::uuid AND l.id = p.left_id
+		INNER JOIN kg_nodes r ON r.tenant_id = current_setting('app.tenant_id')::uuid AND r.id = p.right_id
+	)
+	SELECT left_id, left_type, left_label, left_salience,
+		right_id, right_type, right_label, right_salience,
+		label_similarity, embedding_similarity, score
+	FROM (
+		SELECT *, CASE
+			WHEN embedding_similarity IS NULL THEN label_similarity
+			ELSE $3 * label_similarity + $4 * embedding_similarity
+		END AS score
+		FROM scored
+	) s
+	WHERE score >= $5
+	ORDER BY score DESC, left_id, right_id
+	LIMIT $6`
+
+// ListDuplicateNodes finds probable duplicate nodes of the same type. Pairs
+// are drawn from labels that are trigram-similar and from each node's nearest
+// embedding neighbours, then scored with the weights in models and filtered
+// by opts.MinScore.
+func (s *AdminStore) ListDuplicateNodes(ctx context.Context, tenantID string, opts models.DuplicateListOpts) ([]storage.DuplicateNodePair, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	if opts.Limit <= 0 {
+		opts.Limit = defaultMergeSuggestionLimit
+	}
+	if opts.Limit > maxListLimit {
+		opts.Limit = maxListLimit
+	}
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing duplicate nodes: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, duplicateNodesSQL,
+		opts.Type, duplicateNeighbors, models.DuplicateLabelWeight, models.DuplicateEmbeddingWeight, opts.MinScore, opts.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying duplicate nodes: %w", err)
+	}
+	defer rows.Close()
+
+	pairs := make([]storage.DuplicateNodePair, 0, opts.Limit)
+	for rows.Next() {
+		var p storage.DuplicateNodePair
+		if err := rows.Scan(
+			&p.Left.ID, &p.Left.Type, &p.Left.Label, &p.Left.Salience,
+			&p.Right.ID, &p.Right.Type, &p.Right.Label, &p.Right.Salience,
+			&p.LabelSimilarity, &p.EmbeddingSimilarity, &p.Score,
+		); err != nil {
+			return nil, fmt.Errorf("scanning duplicate node pair: %w", err)
+		}
+		pairs = append(pairs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating duplicate node pairs: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing duplicate nodes: %w", err)
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,42 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestListDuplicateNodes(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	as := store.NewAdminStore(base)
+	ctx := context.Background()
+
+	for _, req := range []models.CreateNodeRequest{
+		{ID: "acme", Type: "company", Label: "Acme Corporation"},
+		{ID: "acme-dup", Type: "company", Label: "Acme Corporation Inc"},
+		{ID: "acme-person", Type: "person", Label: "Acme Corporation"},
+		{ID: "globex", Type: "company", Label: "Globex"},
+	} {
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode %s: %v", req.ID, err)
+		}
+	}
+
+	pairs, err := as.ListDuplicateNodes(ctx, tenantID, models.DuplicateListOpts{MinScore: 0.5})
+	if err != nil {
+		t.Fatalf("ListDuplicateNodes: %v", err)
+	}
+
+	if len(pairs) != 1 {
+		t.Fatalf("pairs = %+v, want only the two Acme companies", pairs)
+	}
+	if pairs[0].Left.ID != "acme" || pairs[0].Right.ID != "acme-dup" {
+		t.Errorf("pair = %s/%s, want acme/acme-dup", pairs[0].Left.ID, pairs[0].Right.ID)
+	}
+	if pairs[0].EmbeddingSimilarity != nil || pairs[0].Score != pairs[0].LabelSimilarity {
+		t.Errorf("pair without embeddings should score on label similarity alone: %+v", pairs[0])
+	}
+}
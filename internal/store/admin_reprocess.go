@@ -8,35 +8,22 @@
 	"github.com/google/uuid"
 
 	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/storage"
 )
 
 const defaultReprocessBatchSize = 100
 
-// ReprocessableNode contains the minimum fields needed to rebuild search text and embeddings.
-type ReprocessableNode struct {
-	ID                 string
-	Type               string
-	Label              string
-	Properties         map[string]any
-	CurrentSearchText  string
-	NeedsSearchText    bool
-	NeedsEmbedding     bool
-	HasFactEvidence    bool
-	HasSupersededFacts bool
-	NodeSuperseded     bool
-}
-
 // ListNodesForReprocess returns a batch of nodes ordered by creation time.
-func (s *EmbeddingStore) ListNodesForReprocess(ctx context.Context, tenantID string, limit int) ([]ReprocessableNode, error) {
+func (s *EmbeddingStore) ListNodesForReprocess(ctx context.Context, tenantID string, limit int) ([]storage.ReprocessableNode, error) {
 	return s.listNodesForMaintenance(ctx, tenantID, limit, false)
 }
 
 // ListNodesForMaintenance returns nodes that need explicit maintenance work.
-func (s *EmbeddingStore) ListNodesForMaintenance(ctx context.Context, tenantID string, limit int) ([]ReprocessableNode, error) {
+func (s *EmbeddingStore) ListNodesForMaintenance(ctx context.Context, tenantID string, limit int) ([]storage.ReprocessableNode, error) {
 	return s.listNodesForMaintenance(ctx, tenantID, limit, true)
 }
 
-func (s *EmbeddingStore) listNodesForMaintenance(ctx context.Context, tenantID string, limit int, includeFactEvidence bool) ([]ReprocessableNode, error) {
+func (s *EmbeddingStore) listNodesForMaintenance(ctx context.Context, tenantID string, limit int, includeFactEvidence bool) ([]storage.ReprocessableNode, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -72,7 +59,7 @@ func (s *EmbeddingStore) listNodesForMaintenance(ctx context.Context, tenantID s
 	}
 	defer rows.Close()
 
-	result := make([]ReprocessableNode, 0, limit)
+	result := make([]storage.ReprocessableNode, 0, limit)
 	for rows.Next() {
 		var (
 			node            models.Node
@@ -110,7 +97,7 @@ func (s *EmbeddingStore) listNodesForMaintenance(ctx context.Context, tenantID s
 		if err := s.decryptNode(ctx, tenantID, &node); err != nil {
 			return nil, err
 		}
-		result = append(result, ReprocessableNode{
+		result = append(result, storage.ReprocessableNode{
 			ID:                 node.ID,
 			Type:               node.Type,
 			Label:              node.Label,
@@ -160,7 +147,8 @@ func (s *EmbeddingStore) CountNodesForReprocess(ctx context.Context, tenantID st
 	return remainingSearchText, remainingEmbeddings, remainingTotal, nil
 }
 
-// UpdateNodeSearchText rewrites the search_text document for an existing node.
+// UpdateNodeSearchText rewrites the search_text document for an existing node,
+// re-detecting its language when detection is on.
 func (s *EmbeddingStore) UpdateNodeSearchText(ctx context.Context, tenantID, nodeID, searchText string) error {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
@@ -172,8 +160,9 @@ func (s *EmbeddingStore) UpdateNodeSearchText(ctx context.Context, tenantID, nod
 	defer tx.Rollback(ctx) //nolint:errcheck
 
 	tag, err := tx.Exec(ctx,
-		`UPDATE kg_nodes SET search_text = $1 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $2`,
-		searchText, nodeID,
+		`UPDATE kg_nodes SET search_text = $1, search_lang = $2::regconfig
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $3`,
+		searchText, s.searchLanguage(searchText), nodeID,
 	)
 	if err != nil {
 		return fmt.Errorf("executing node search text update: %w", err)
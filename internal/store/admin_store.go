@@ -9,6 +9,7 @@
 
 type AdminStore struct {
 	*EmbeddingStore
+	explain           *ExplainStore
 	retrievalFeedback *RetrievalFeedbackStore
 }
 
@@ -16,6 +17,7 @@ type AdminStore struct {
 func NewAdminStore(base Base) *AdminStore {
 	return &AdminStore{
 		EmbeddingStore:    NewEmbeddingStore(base),
+		explain:           NewExplainStore(base),
 		retrievalFeedback: NewRetrievalFeedbackStore(base),
 	}
 }
@@ -27,3 +29,7 @@ func (s *AdminStore) CreateRetrievalFeedback(ctx context.Context, tenantID strin
 func (s *AdminStore) ListRetrievalFeedback(ctx context.Context, tenantID string, opts models.RetrievalFeedbackListOpts) ([]models.RetrievalFeedbackRecord, error) {
 	return s.retrievalFeedback.ListRetrievalFeedback(ctx, tenantID, opts)
 }
+
+func (s *AdminStore) ExplainQuery(ctx context.Context, tenantID string, req models.ExplainRequest, embedding []float32) (*models.ExplainResult, error) {
+	return s.explain.ExplainQuery(ctx, tenantID, req, embedding)
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// managedAPIKeyColumns selects the fields of models.ManagedAPIKey.
+const managedAPIKeyColumns = `id, name, scope, expires_at, last_used_at, revoked_at, impersonated_by, created_at`
+
+// CreateManagedAPIKey stores the hash of key as a new named key for the tenant.
+func (s *TenantStore) CreateManagedAPIKey(
+	ctx context.Context, tenantID, key string, req models.CreateAPIKeyRequest,
+) (*models.ManagedAPIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `INSERT INTO api_keys (tenant_id, name, key_hash, scope, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING `+managedAPIKeyColumns,
+		tenantID, req.Name, hashAPIKey(key), req.Scope, req.ExpiresAt,
+	)
+
+	k, err := scanManagedAPIKey(row)
+	if err != nil {
+		return nil, fmt.Errorf("creating api key: %w", err)
+	}
+
+	return k, nil
+}
+
+// CreateImpersonationKey stores the hash of key as a key operatorID holds to
+// act as the tenant for req.TTL(), recording the operator and reason.
+func (s *TenantStore) CreateImpersonationKey(
+	ctx context.Context, operatorID, tenantID, key string, req models.ImpersonateTenantRequest,
+) (*models.ManagedAPIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `INSERT INTO api_keys
+			(tenant_id, name, key_hash, scope, expires_at, impersonated_by, impersonation_reason)
+		VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5::double precision), $6, $7)
+		RETURNING `+managedAPIKeyColumns,
+		tenantID, models.ImpersonationKeyName, hashAPIKey(key), req.Scope, req.TTL().Seconds(), operatorID, req.Reason,
+	)
+
+	k, err := scanManagedAPIKey(row)
+	if err != nil {
+		return nil, fmt.Errorf("creating impersonation key: %w", err)
+	}
+
+	return k, nil
+}
+
+// ListManagedAPIKeys returns the tenant's named keys, newest first, including
+// revoked and expired ones.
+func (s *TenantStore) ListManagedAPIKeys(ctx context.Context, tenantID string) ([]models.ManagedAPIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT `+managedAPIKeyColumns+` FROM api_keys
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC, id`,
+		tenantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []models.ManagedAPIKey{}
+	for rows.Next() {
+		k, err := scanManagedAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning api key: %w", err)
+		}
+
+		keys = append(keys, *k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeManagedAPIKey stops accepting a named key. Revoking a revoked key
+// keeps its original revoked_at.
+func (s *TenantStore) RevokeManagedAPIKey(ctx context.Context, tenantID, keyID string) (*models.ManagedAPIKey, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE tenant_id = $1 AND id = $2
+		RETURNING `+managedAPIKeyColumns,
+		tenantID, keyID,
+	)
+
+	k, err := scanManagedAPIKey(row)
+	if err != nil {
+		return nil, fmt.Errorf("revoking api key: %w", err)
+	}
+
+	return k, nil
+}
+
+// RotateManagedAPIKey replaces a named key with newKey, which inherits its
+// name, scope, and expiry. The old key stays valid for grace (never past its
+// own expiry) and is revoked immediately when grace is zero. It returns the
+// new key and the old one.
+func (s *TenantStore) RotateManagedAPIKey(
+	ctx context.Context, tenantID, keyID, newKey string, grace time.Duration,
+) (created, previous *models.ManagedAPIKey, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback after commit is a no-op.
+
+	previous, err = scanManagedAPIKey(tx.QueryRow(ctx, `SELECT `+managedAPIKeyColumns+` FROM api_keys
+		WHERE tenant_id = $1 AND id = $2
+		FOR UPDATE`,
+		tenantID, keyID,
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting api key: %w", err)
+	}
+
+	if !previous.Active(time.Now()) {
+		return nil, nil, models.ErrAPIKeyInactive
+	}
+
+	created, err = scanManagedAPIKey(tx.QueryRow(ctx, `INSERT INTO api_keys
+			(tenant_id, name, key_hash, scope, expires_at, impersonated_by, impersonation_reason)
+		SELECT tenant_id, name, $3, scope, expires_at, impersonated_by, impersonation_reason
+		FROM api_keys WHERE tenant_id = $1 AND id = $2
+		RETURNING `+managedAPIKeyColumns,
+		tenantID, keyID, hashAPIKey(newKey),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating replacement api key: %w", err)
+	}
+
+	previous, err = scanManagedAPIKey(tx.QueryRow(ctx, `UPDATE api_keys SET
+			revoked_at = CASE WHEN $3::double precision > 0 THEN NULL ELSE NOW() END,
+			expires_at = CASE WHEN $3::double precision > 0
+				THEN LEAST(expires_at, NOW() + make_interval(secs => $3::double precision))
+				ELSE expires_at END
+		WHERE tenant_id = $1 AND id = $2
+		RETURNING `+managedAPIKeyColumns,
+		tenantID, keyID, grace.Seconds(),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("expiring rotated api key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("committing api key rotation: %w", err)
+	}
+
+	return created, previous, nil
+}
+
+func scanManagedAPIKey(row pgx.Row) (*models.ManagedAPIKey, error) {
+	var k models.ManagedAPIKey
+
+	err := row.Scan(&k.ID, &k.Name, &k.Scope, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.ImpersonatedBy, &k.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrAPIKeyNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
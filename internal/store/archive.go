@@ -0,0 +1,280 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// archiveBatchSize limits how many nodes one archival transaction moves.
+const archiveBatchSize = 1000
+
+// archivedNodeColumns are the kg_nodes columns kept in kg_nodes_archive.
+const archivedNodeColumns = `tenant_id, id, type, label, properties,
+	access_count, last_accessed, salience_score, superseded_by, user_boosted,
+	created_at, updated_at, search_text, search_lang, search_props`
+
+// archivedEdgeColumns are the kg_edges columns kept in kg_edges_archive.
+const archivedEdgeColumns = `tenant_id, source, target, relation, properties,
+	weight, access_count, last_accessed, salience_score, superseded_by,
+	user_boosted, date_start, date_end, date_lower, date_upper, is_current,
+	date_qualifier, created_at, updated_at`
+
+// archiveBatchQuery moves up to $3 of the tenant's unboosted nodes scoring
+// below $1 and idle since $2, and their edges, to the archive. It returns
+// the number of nodes and edges moved and the moved node IDs. A node
+// archived again after being recreated replaces its older copy.
+const archiveBatchQuery = `WITH candidates AS (
+		SELECT id FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND NOT user_boosted
+			AND salience_score < $1
+			AND greatest(updated_at, last_accessed) < $2
+		ORDER BY salience_score, id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	),
+	moved_edges AS (
+		DELETE FROM kg_edges e
+		USING candidates c
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid
+			AND (e.source = c.id OR e.target = c.id)
+		RETURNING e.*
+	),
+	archived_edges AS (
+		INSERT INTO kg_edges_archive (` + archivedEdgeColumns + `)
+		SELECT ` + archivedEdgeColumns + ` FROM moved_edges
+		ON CONFLICT DO NOTHING
+	),
+	moved_nodes AS (
+		DELETE FROM kg_nodes n
+		USING candidates c
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid AND n.id = c.id
+		RETURNING n.*
+	),
+	archived_nodes AS (
+		INSERT INTO kg_nodes_archive (` + archivedNodeColumns + `)
+		SELECT ` + archivedNodeColumns + ` FROM moved_nodes
+		ON CONFLICT (tenant_id, id) DO UPDATE SET
+			type = EXCLUDED.type, label = EXCLUDED.label, properties = EXCLUDED.properties,
+			access_count = EXCLUDED.access_count, last_accessed = EXCLUDED.last_accessed,
+			salience_score = EXCLUDED.salience_score, superseded_by = EXCLUDED.superseded_by,
+			user_boosted = EXCLUDED.user_boosted, created_at = EXCLUDED.created_at,
+			updated_at = EXCLUDED.updated_at, search_text = EXCLUDED.search_text,
+			search_lang = EXCLUDED.search_lang, search_props = EXCLUDED.search_props,
+			archived_at = NOW()
+		RETURNING 1
+	)
+	SELECT (SELECT count(*) FROM archived_nodes), (SELECT count(*) FROM moved_edges),
+		(SELECT coalesce(array_agg(id), '{}') FROM moved_nodes)`
+
+// restoreNodeQuery moves archived node $1 back into kg_nodes, marking it
+// as read now, and returns it.
+const restoreNodeQuery = `WITH restored AS (
+		DELETE FROM kg_nodes_archive
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+		RETURNING ` + archivedNodeColumns + `
+	)
+	INSERT INTO kg_nodes (` + archivedNodeColumns + `)
+	SELECT tenant_id, id, type, label, properties,
+		access_count, NOW(), salience_score, superseded_by, user_boosted,
+		created_at, updated_at, search_text, search_lang, search_props
+	FROM restored
+	RETURNING ` + nodeColumns
+
+// restoreEdgesQuery moves the archived edges of node $1 whose other node
+// is live back into kg_edges.
+const restoreEdgesQuery = `WITH restored AS (
+		DELETE FROM kg_edges_archive a
+		WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
+			AND (a.source = $1 OR a.target = $1)
+			AND EXISTS (
+				SELECT 1 FROM kg_nodes n
+				WHERE n.tenant_id = current_setting('app.tenant_id')::uuid
+					AND n.id = CASE WHEN a.source = $1 THEN a.target ELSE a.source END
+			)
+		RETURNING ` + archivedEdgeColumns + `
+	)
+	INSERT INTO kg_edges (` + archivedEdgeColumns + `)
+	SELECT ` + archivedEdgeColumns + ` FROM restored
+	ON CONFLICT DO NOTHING`
+
+// ArchiveStore moves forgotten nodes and their edges to the archive tables
+// and back.
+type ArchiveStore struct {
+	Base
+}
+
+// NewArchiveStore creates a new ArchiveStore.
+func NewArchiveStore(base Base) *ArchiveStore {
+	return &ArchiveStore{Base: base}
+}
+
+// ArchiveNodes moves nodes scoring below salienceBelow that were last read
+// and last updated before the cutoff, and every edge touching them, to the
+// archive. Boosted nodes stay. Works through archiveBatchSize nodes per
+// transaction until none are left.
+func (s *ArchiveStore) ArchiveNodes(
+	ctx context.Context, tenantID string, salienceBelow float64, before time.Time,
+) (*models.ArchiveResult, error) {
+	result := &models.ArchiveResult{}
+
+	for {
+		batchCtx, cancel := withTimeout(ctx)
+
+		nodes, edges, err := s.archiveBatch(batchCtx, tenantID, salienceBelow, before)
+		cancel()
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Nodes += nodes
+		result.Edges += edges
+
+		if nodes < archiveBatchSize {
+			break
+		}
+	}
+
+	if result.Nodes > 0 {
+		s.notify("kg_nodes", "delete", tenantID)
+	}
+
+	if result.Edges > 0 {
+		s.notify("kg_edges", "delete", tenantID)
+	}
+
+	return result, nil
+}
+
+func (s *ArchiveStore) archiveBatch(
+	ctx context.Context, tenantID string, salienceBelow float64, before time.Time,
+) (nodes, edges int, err error) {
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("archiving nodes: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var archived []string
+
+	err = tx.QueryRow(ctx, archiveBatchQuery, salienceBelow, before, archiveBatchSize).
+		Scan(&nodes, &edges, &archived)
+	if err != nil {
+		return 0, 0, fmt.Errorf("moving nodes to archive: %w", err)
+	}
+
+	if err := deleteNodeDependents(ctx, tx, archived); err != nil {
+		return 0, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("committing archive batch: %w", err)
+	}
+
+	return nodes, edges, nil
+}
+
+// ListArchivedNodes returns archived nodes, most recently archived first.
+func (s *ArchiveStore) ListArchivedNodes(
+	ctx context.Context, tenantID string, limit, offset int,
+) ([]models.ArchivedNode, bool, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, false, fmt.Errorf("listing archived nodes: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx, `SELECT id, type, label, salience_score, access_count,
+			last_accessed, updated_at, archived_at
+		FROM kg_nodes_archive
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY archived_at DESC, id
+		LIMIT $1 OFFSET $2`, limit+1, offset)
+	if err != nil {
+		return nil, false, fmt.Errorf("querying archived nodes: %w", err)
+	}
+
+	nodes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ArchivedNode, error) {
+		var n models.ArchivedNode
+		err := row.Scan(&n.ID, &n.Type, &n.Label, &n.Salience, &n.AccessCount,
+			&n.LastAccessed, &n.UpdatedAt, &n.ArchivedAt)
+
+		return n, err
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("scanning archived nodes: %w", err)
+	}
+
+	hasMore := len(nodes) > limit
+	if hasMore {
+		nodes = nodes[:limit]
+	}
+
+	return nodes, hasMore, nil
+}
+
+// RestoreNode moves an archived node back into the graph with the archived
+// edges whose other node is live. The node counts as read now, so the next
+// archival run does not archive it again straight away. It has no embedding
+// until one is generated again.
+func (s *ArchiveStore) RestoreNode(ctx context.Context, tenantID, nodeID string) (*models.RestoreResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("restoring node: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	n, err := scanNode(tx.QueryRow(ctx, restoreNodeQuery, nodeID).Scan)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, models.ErrDuplicateKey
+		}
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNodeNotArchived
+		}
+
+		return nil, fmt.Errorf("restoring archived node: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, restoreEdgesQuery, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("restoring archived edges: %w", err)
+	}
+
+	if err := s.decryptNode(ctx, tenantID, n); err != nil {
+		return nil, err
+	}
+
+	edges := int(tag.RowsAffected())
+	if err := enforceQuota(ctx, tx, tenantID, 1, edges); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing node restore: %w", err)
+	}
+
+	s.notifyNode("insert", tenantID, n.ID, n.Type, nil)
+
+	if edges > 0 {
+		s.notify("kg_edges", "insert", tenantID)
+	}
+
+	return &models.RestoreResult{Node: n, EdgesRestored: edges}, nil
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GetArchivePolicy returns the tenant's archive policy.
+func (s *ArchiveStore) GetArchivePolicy(ctx context.Context, tenantID string) (*models.ArchivePolicy, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var p models.ArchivePolicy
+
+	err := s.Pool.QueryRow(ctx,
+		`SELECT archive_salience_below, archive_idle_days FROM tenants WHERE id = $1`,
+		tenantID,
+	).Scan(&p.SalienceBelow, &p.IdleDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting archive policy: %w", err)
+	}
+
+	return &p, nil
+}
+
+// SetArchivePolicy replaces the tenant's archive policy.
+func (s *ArchiveStore) SetArchivePolicy(
+	ctx context.Context, tenantID string, p models.ArchivePolicy,
+) (*models.ArchivePolicy, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tag, err := s.Pool.Exec(ctx,
+		`UPDATE tenants SET archive_salience_below = $2, archive_idle_days = $3 WHERE id = $1`,
+		tenantID, p.SalienceBelow, p.IdleDays,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("setting archive policy: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, models.ErrTenantNotFound
+	}
+
+	return &p, nil
+}
+
+// ListArchivePolicies returns every active tenant with archival enabled.
+func (s *ArchiveStore) ListArchivePolicies(ctx context.Context) ([]models.TenantArchivePolicy, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT id, archive_salience_below, archive_idle_days
+		FROM tenants
+		WHERE archive_salience_below IS NOT NULL AND archive_idle_days IS NOT NULL AND suspended_at IS NULL
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("listing archive policies: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.TenantArchivePolicy
+
+	for rows.Next() {
+		var p models.TenantArchivePolicy
+		if err := rows.Scan(&p.TenantID, &p.SalienceBelow, &p.IdleDays); err != nil {
+			return nil, fmt.Errorf("scanning archive policy: %w", err)
+		}
+
+		result = append(result, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating archive policies: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestArchiveAndRestore(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ss := store.NewSalienceStore(base)
+	as := store.NewArchiveStore(base)
+	ctx := context.Background()
+
+	a := createTestNode(t, ns, tenantID, "Archive A")
+	b := createTestNode(t, ns, tenantID, "Archive B")
+	kept := createTestNode(t, ns, tenantID, "Archive Kept")
+
+	keys := []models.EdgeKey{
+		{Source: a.ID, Target: b.ID, Relation: "related_to"},
+		{Source: b.ID, Target: kept.ID, Relation: "related_to"},
+	}
+	for _, k := range keys {
+		if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: k.Source, Target: k.Target, Relation: k.Relation}); err != nil {
+			t.Fatalf("CreateEdge: %v", err)
+		}
+	}
+
+	// Boosted nodes are never archived.
+	if _, err := ss.BoostNode(ctx, tenantID, kept.ID); err != nil {
+		t.Fatalf("BoostNode: %v", err)
+	}
+
+	result, err := as.ArchiveNodes(ctx, tenantID, 100, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ArchiveNodes: %v", err)
+	}
+	if result.Nodes != 2 || result.Edges != 2 {
+		t.Fatalf("archived %+v, want 2 nodes and 2 edges", result)
+	}
+
+	if _, err := ns.GetNode(ctx, tenantID, a.ID); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("GetNode(archived) error = %v, want ErrNodeNotFound", err)
+	}
+
+	archived, hasMore, err := as.ListArchivedNodes(ctx, tenantID, 10, 0)
+	if err != nil || hasMore || len(archived) != 2 {
+		t.Fatalf("ListArchivedNodes = %+v, %v, %v; want 2 nodes", archived, hasMore, err)
+	}
+
+	// The edge to b stays archived while b is.
+	restored, err := as.RestoreNode(ctx, tenantID, a.ID)
+	if err != nil {
+		t.Fatalf("RestoreNode(a): %v", err)
+	}
+	if restored.Node.ID != a.ID || restored.Node.LastAccessed == nil || restored.EdgesRestored != 0 {
+		t.Errorf("restored a = %+v, %d edges; want touched node and 0 edges", restored.Node, restored.EdgesRestored)
+	}
+
+	restored, err = as.RestoreNode(ctx, tenantID, b.ID)
+	if err != nil {
+		t.Fatalf("RestoreNode(b): %v", err)
+	}
+	if restored.EdgesRestored != 2 {
+		t.Errorf("restored b with %d edges, want 2", restored.EdgesRestored)
+	}
+
+	exists, err := es.EdgesExist(ctx, tenantID, keys)
+	if err != nil {
+		t.Fatalf("EdgesExist: %v", err)
+	}
+	for _, e := range exists {
+		if !e.Exists {
+			t.Errorf("edge %+v not restored", e.EdgeKey)
+		}
+	}
+
+	if _, err := as.RestoreNode(ctx, tenantID, b.ID); !errors.Is(err, models.ErrNodeNotArchived) {
+		t.Errorf("second restore error = %v, want ErrNodeNotArchived", err)
+	}
+}
+
+func TestArchivePolicySettings(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	as := store.NewArchiveStore(base)
+	ctx := context.Background()
+
+	below, days := 0.2, 90
+	if _, err := as.SetArchivePolicy(ctx, tenantID, models.ArchivePolicy{SalienceBelow: &below, IdleDays: &days}); err != nil {
+		t.Fatalf("SetArchivePolicy: %v", err)
+	}
+
+	got, err := as.GetArchivePolicy(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("GetArchivePolicy: %v", err)
+	}
+	if got.SalienceBelow == nil || *got.SalienceBelow < 0.19 || *got.SalienceBelow > 0.21 || got.IdleDays == nil || *got.IdleDays != 90 {
+		t.Errorf("policy = %+v, want 0.2 below / 90 days", got)
+	}
+
+	all, err := as.ListArchivePolicies(ctx)
+	if err != nil {
+		t.Fatalf("ListArchivePolicies: %v", err)
+	}
+
+	found := false
+	for _, p := range all {
+		found = found || p.TenantID == tenantID
+	}
+	if !found {
+		t.Error("tenant missing from ListArchivePolicies")
+	}
+}
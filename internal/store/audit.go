@@ -38,15 +38,31 @@ func (s *AuditStore) RecordAudit(
 	}
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback on early return.
 
+	if err := insertAuditEntry(ctx, tx, tenantID, action, entityType, entityID, actor, detail); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// insertAuditEntry writes an audit log entry within tx. Package-level so
+// stores can record an audit entry atomically with the change it describes.
+func insertAuditEntry(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, action, entityType, entityID, actor string,
+	detail map[string]any,
+) error {
 	var detailJSON []byte
 	if detail != nil {
+		var err error
 		detailJSON, err = json.Marshal(detail)
 		if err != nil {
 			return fmt.Errorf("marshaling audit detail: %w", err)
 		}
 	}
 
-	_, err = tx.Exec(ctx, `
+	_, err := tx.Exec(ctx, `
 		INSERT INTO kg_audit_log (tenant_id, action, entity_type, entity_id, actor, detail)
 		VALUES ($1, $2, $3, $4, $5, $6)`,
 		tenantID, action, entityType, entityID, actor, detailJSON,
@@ -55,7 +71,7 @@ func (s *AuditStore) RecordAudit(
 		return fmt.Errorf("inserting audit entry: %w", err)
 	}
 
-	return tx.Commit(ctx)
+	return nil
 }
 
 // buildAuditFilter builds WHERE clause and args from AuditQueryOpts.
@@ -83,6 +99,11 @@ func buildAuditFilter(opts models.AuditQueryOpts) (where string, args []any, nex
 		args = append(args, *opts.Since)
 		argIdx++
 	}
+	if opts.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", argIdx, argIdx+1))
+		args = append(args, opts.After.CreatedAt, opts.After.ID)
+		argIdx += 2
+	}
 
 	if len(conditions) > 0 {
 		where = "WHERE " + strings.Join(conditions, " AND ")
@@ -112,11 +133,17 @@ func (s *AuditStore) QueryAudit(
 		limit = 50
 	}
 
+	// A cursor continues after the last entry of the previous page.
+	offset := opts.Offset
+	if opts.After != nil {
+		offset = 0
+	}
+
 	query := fmt.Sprintf(
-		"SELECT id, tenant_id, action, entity_type, entity_id, actor, detail, created_at FROM kg_audit_log %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d",
+		"SELECT id, tenant_id, action, entity_type, entity_id, actor, detail, created_at FROM kg_audit_log %s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d",
 		where, argIdx, argIdx+1,
 	)
-	args = append(args, limit+1, opts.Offset)
+	args = append(args, limit+1, offset)
 
 	entries, err := scanAuditRows(ctx, tx, query, args, s.Log)
 	if err != nil {
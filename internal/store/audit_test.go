@@ -102,3 +102,35 @@ func TestPurgeOldEntries(t *testing.T) {
 		t.Errorf("QueryAudit after purge = %d entries, want 1", len(entries))
 	}
 }
+
+func TestQueryAudit_Cursor(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	as := store.NewAuditStore(base)
+	ctx := context.Background()
+
+	for _, id := range []string{"c1", "c2", "c3"} {
+		if err := as.RecordAudit(ctx, tenantID, "create", "node", id, "", nil); err != nil {
+			t.Fatalf("RecordAudit: %v", err)
+		}
+	}
+
+	opts := models.AuditQueryOpts{EntityType: "node", Limit: 2}
+	var got []string
+	for range 3 {
+		entries, hasMore, err := as.QueryAudit(ctx, tenantID, opts)
+		if err != nil {
+			t.Fatalf("QueryAudit: %v", err)
+		}
+		for _, e := range entries {
+			got = append(got, e.EntityID)
+		}
+		if !hasMore {
+			break
+		}
+		opts.After = models.AuditCursorAfter(&entries[len(entries)-1])
+	}
+
+	if len(got) != 3 || got[0] != "c3" || got[2] != "c1" {
+		t.Errorf("paged entity IDs = %v, want c3 c2 c1", got)
+	}
+}
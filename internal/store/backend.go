@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/storage"
+)
+
+// BackendName is the name the Postgres backend is registered under.
+const BackendName = "postgres"
+
+// Compile-time checks: the Postgres stores implement the storage interfaces.
+var (
+	_ storage.NodeStorage      = (*NodeStore)(nil)
+	_ storage.EdgeStorage      = (*EdgeStore)(nil)
+	_ storage.SearchStorage    = (*SearchStore)(nil)
+	_ storage.GraphStorage     = (*GraphStore)(nil)
+	_ storage.BulkStorage      = (*BulkStore)(nil)
+	_ storage.EmbeddingStorage = (*EmbeddingStore)(nil)
+)
+
+func init() {
+	storage.Register(BackendName, openBackend)
+}
+
+// NewBackend returns the Postgres implementation of each storage interface,
+// all sharing base. The caller keeps ownership of base.Pool.
+func NewBackend(base Base) *storage.Backend {
+	return &storage.Backend{
+		Nodes:      NewNodeStore(base),
+		Edges:      NewEdgeStore(base),
+		Search:     NewSearchStore(base),
+		Graph:      NewGraphStore(base),
+		Bulk:       NewBulkStore(base),
+		Embeddings: NewEmbeddingStore(base),
+	}
+}
+
+// openBackend is the storage.Factory for the Postgres backend. It connects a
+// pool to cfg.URL, which the backend closes on release.
+func openBackend(ctx context.Context, cfg storage.Config) (*storage.Backend, error) {
+	pool, err := dbpool.NewPool(ctx, cfg.URL, cfg.MaxConns)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+
+	backend := NewBackend(Base{
+		Pool:                 pool,
+		Log:                  cfg.Log,
+		Crypto:               cfg.Crypto,
+		DetectLanguage:       cfg.DetectLanguage,
+		SearchableProperties: cfg.SearchableProperties,
+		EmbeddingModel:       cfg.EmbeddingModel,
+	})
+	backend.Release = pool.Close
+
+	return backend, nil
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// BranchStore handles branches: staged, copy-on-write views of the graph.
+type BranchStore struct {
+	Base
+}
+
+// NewBranchStore creates a new BranchStore.
+func NewBranchStore(base Base) *BranchStore {
+	return &BranchStore{Base: base}
+}
+
+// branchNodesView selects the nodes branch $1 sees: its staged nodes that
+// are not deleted, and the live nodes it has not staged.
+const branchNodesView = `(SELECT ` + nodeColumns + ` FROM kg_branch_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND branch_id = $1 AND NOT deleted
+	UNION ALL
+	SELECT ` + nodeColumns + ` FROM kg_nodes n
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND NOT EXISTS (SELECT 1 FROM kg_branch_nodes b WHERE b.branch_id = $1 AND b.id = n.id)) AS v`
+
+// branchEdgesView selects the edges branch $1 sees, like branchNodesView.
+const branchEdgesView = `(SELECT ` + edgeColumns + ` FROM kg_branch_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND branch_id = $1 AND NOT deleted
+	UNION ALL
+	SELECT ` + edgeColumns + ` FROM kg_edges e
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND NOT EXISTS (SELECT 1 FROM kg_branch_edges b
+			WHERE b.branch_id = $1 AND b.source = e.source AND b.target = e.target AND b.relation = e.relation)) AS v`
+
+// branchOp derives a staged change's operation from its deleted flag and
+// whether a live row existed when it was first staged.
+const branchOp = `CASE WHEN deleted THEN 'delete' WHEN base_updated_at IS NULL THEN 'create' ELSE 'update' END`
+
+// CreateBranch creates an empty branch.
+func (s *BranchStore) CreateBranch(ctx context.Context, tenantID string, req models.CreateBranchRequest) (*models.Branch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating branch: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	b := &models.Branch{Name: req.Name}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kg_branches (tenant_id, name)
+		VALUES (current_setting('app.tenant_id')::uuid, $1)
+		RETURNING id, created_at
+	`, req.Name).Scan(&b.ID, &b.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("inserting branch: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing create branch: %w", err)
+	}
+
+	return b, nil
+}
+
+// branchSelect selects a branch with its staged change counts.
+const branchSelect = `SELECT br.id, br.name, br.created_at,
+		(SELECT COUNT(*) FROM kg_branch_nodes bn WHERE bn.branch_id = br.id),
+		(SELECT COUNT(*) FROM kg_branch_edges be WHERE be.branch_id = br.id)
+	FROM kg_branches br
+	WHERE br.tenant_id = current_setting('app.tenant_id')::uuid`
+
+func scanBranch(scan func(dest ...any) error) (*models.Branch, error) {
+	var b models.Branch
+	if err := scan(&b.ID, &b.Name, &b.CreatedAt, &b.NodeChanges, &b.EdgeChanges); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// ListBranches returns the tenant's branches, oldest first.
+func (s *BranchStore) ListBranches(ctx context.Context, tenantID string) ([]models.Branch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, branchSelect+` ORDER BY br.created_at, br.id`)
+	if err != nil {
+		return nil, fmt.Errorf("querying branches: %w", err)
+	}
+	defer rows.Close()
+
+	branches := make([]models.Branch, 0, 8)
+	for rows.Next() {
+		b, err := scanBranch(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("scanning branch: %w", err)
+		}
+		branches = append(branches, *b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+// GetBranch returns a branch with its staged change counts.
+func (s *BranchStore) GetBranch(ctx context.Context, tenantID, branchID string) (*models.Branch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	b, err := scanBranch(tx.QueryRow(ctx, branchSelect+` AND br.id = $1`, branchID).Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrBranchNotFound
+		}
+
+		return nil, fmt.Errorf("scanning branch: %w", err)
+	}
+
+	return b, nil
+}
+
+// DeleteBranch discards a branch and everything staged on it.
+func (s *BranchStore) DeleteBranch(ctx context.Context, tenantID, branchID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting branch: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM kg_branches WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`, branchID)
+	if err != nil {
+		return fmt.Errorf("executing branch delete: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrBranchNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing delete branch: %w", err)
+	}
+
+	return nil
+}
+
+// Row locks lockBranch can take on a branch. Staging writes share the lock
+// and a merge takes it exclusively, so a merge never runs while a write is
+// being staged. Read-only transactions cannot lock rows.
+const (
+	branchNoLock     = ""
+	branchShareLock  = " FOR SHARE"
+	branchUpdateLock = " FOR UPDATE"
+)
+
+// lockBranch checks that a branch exists and takes lock on it for the rest
+// of tx.
+func lockBranch(ctx context.Context, tx pgx.Tx, branchID, lock string) error {
+	var id string
+	err := tx.QueryRow(ctx,
+		`SELECT id FROM kg_branches WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`+lock,
+		branchID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrBranchNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("locking branch: %w", err)
+	}
+
+	return nil
+}
+
+// stagedNodeIDs returns the IDs of the nodes a branch writes on merge.
+func stagedNodeIDs(ctx context.Context, tx pgx.Tx, branchID string) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT id FROM kg_branch_nodes WHERE branch_id = $1 AND NOT deleted`, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("querying staged nodes: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning staged node ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating staged node IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// stagedEdgeKeys returns the keys of the edges a branch writes on merge.
+func stagedEdgeKeys(ctx context.Context, tx pgx.Tx, branchID string) ([]edgeKey, error) {
+	rows, err := tx.Query(ctx, `SELECT source, target, relation FROM kg_branch_edges WHERE branch_id = $1 AND NOT deleted`, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("querying staged edges: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []edgeKey
+	for rows.Next() {
+		var k edgeKey
+		if err := rows.Scan(&k.source, &k.target, &k.relation); err != nil {
+			return nil, fmt.Errorf("scanning staged edge key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating staged edge keys: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,289 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// branchMergeReason is recorded in property history for merged changes.
+const branchMergeReason = "branch_merge"
+
+// MergeBranch applies everything staged on a branch to the live graph in a
+// single transaction and deletes the branch. Staged nodes and edges whose
+// live version changed since they were staged are conflicts and stop the
+// merge unless req.Force is set; staged edges whose endpoints would be missing
+// always stop it. A stopped merge writes nothing and reports the conflicts.
+// Deleting a node also deletes every live edge touching it.
+func (s *BranchStore) MergeBranch(
+	ctx context.Context, tenantID, branchID string, req models.MergeBranchRequest,
+) (*models.MergeBranchResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("merging branch: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchUpdateLock); err != nil {
+		return nil, err
+	}
+
+	result := &models.MergeBranchResult{}
+	if result.Conflicts, err = branchConflicts(ctx, tx, branchID); err != nil {
+		return nil, err
+	}
+
+	for _, c := range result.Conflicts {
+		if !req.Force || c.Reason == models.BranchConflictMissingEndpoint {
+			return result, nil
+		}
+	}
+
+	if err := applyBranchDeletes(ctx, tx, branchID, result); err != nil {
+		return nil, err
+	}
+	if err := s.applyBranchNodes(ctx, tx, tenantID, branchID, result); err != nil {
+		return nil, err
+	}
+	if err := s.applyBranchEdges(ctx, tx, tenantID, branchID, result); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM kg_branches WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
+		branchID); err != nil {
+		return nil, fmt.Errorf("deleting merged branch: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing branch merge: %w", err)
+	}
+
+	result.Merged = true
+	s.notify("kg_nodes", "update", tenantID)
+	s.notify("kg_edges", "update", tenantID)
+
+	return result, nil
+}
+
+// branchConflicts finds staged rows whose live version changed since they
+// were staged, and staged edges with an endpoint the merged graph would lack.
+func branchConflicts(ctx context.Context, tx pgx.Tx, branchID string) ([]models.BranchConflict, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT b.id, '', '', '', '`+models.BranchConflictChanged+`' FROM kg_branch_nodes b
+		LEFT JOIN kg_nodes n ON n.tenant_id = current_setting('app.tenant_id')::uuid AND n.id = b.id
+		WHERE b.branch_id = $1 AND n.updated_at IS DISTINCT FROM b.base_updated_at
+		UNION ALL
+		SELECT '', b.source, b.target, b.relation, '`+models.BranchConflictChanged+`' FROM kg_branch_edges b
+		LEFT JOIN kg_edges e ON e.tenant_id = current_setting('app.tenant_id')::uuid
+			AND e.source = b.source AND e.target = b.target AND e.relation = b.relation
+		WHERE b.branch_id = $1 AND e.updated_at IS DISTINCT FROM b.base_updated_at
+		UNION ALL
+		SELECT '', b.source, b.target, b.relation, '`+models.BranchConflictMissingEndpoint+`' FROM kg_branch_edges b
+		WHERE b.branch_id = $1 AND NOT b.deleted
+		  AND (NOT EXISTS (SELECT 1 FROM `+branchNodesView+` WHERE v.id = b.source)
+		    OR NOT EXISTS (SELECT 1 FROM `+branchNodesView+` WHERE v.id = b.target))
+		ORDER BY 1, 2, 3, 4`, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("querying branch conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	var conflicts []models.BranchConflict
+	for rows.Next() {
+		var c models.BranchConflict
+		if err := rows.Scan(&c.NodeID, &c.Source, &c.Target, &c.Relation, &c.Reason); err != nil {
+			return nil, fmt.Errorf("scanning branch conflict: %w", err)
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating branch conflicts: %w", err)
+	}
+
+	return conflicts, nil
+}
+
+// applyBranchDeletes deletes the staged edge deletions, then the staged node
+// deletions along with any live edge still touching those nodes.
+func applyBranchDeletes(ctx context.Context, tx pgx.Tx, branchID string, result *models.MergeBranchResult) error {
+	rows, err := tx.Query(ctx, `DELETE FROM kg_edges e USING kg_branch_edges b
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid AND b.branch_id = $1 AND b.deleted
+		  AND e.source = b.source AND e.target = b.target AND e.relation = b.relation
+		RETURNING e.source`, branchID)
+	if err != nil {
+		return fmt.Errorf("deleting staged edges: %w", err)
+	}
+
+	sources, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("deleting staged edges: %w", err)
+	}
+	result.EdgesDeleted += len(sources)
+
+	if err := pruneInferredEdges(ctx, tx, sources); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM kg_edges e USING kg_branch_nodes b
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid AND b.branch_id = $1 AND b.deleted
+		  AND (e.source = b.id OR e.target = b.id)`, branchID)
+	if err != nil {
+		return fmt.Errorf("deleting edges of staged nodes: %w", err)
+	}
+	result.EdgesDeleted += int(tag.RowsAffected())
+
+	rows, err = tx.Query(ctx, `DELETE FROM kg_nodes n USING kg_branch_nodes b
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid AND b.branch_id = $1 AND b.deleted
+		  AND n.id = b.id
+		RETURNING n.id`, branchID)
+	if err != nil {
+		return fmt.Errorf("deleting staged nodes: %w", err)
+	}
+
+	deleted, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("deleting staged nodes: %w", err)
+	}
+	result.NodesDeleted += len(deleted)
+
+	return deleteNodeDependents(ctx, tx, deleted)
+}
+
+// applyBranchNodes writes the staged nodes over the live ones and records
+// history for the nodes that already existed.
+func (s *BranchStore) applyBranchNodes(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string, result *models.MergeBranchResult,
+) error {
+	ids, err := stagedNodeIDs(ctx, tx, branchID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.fetchExistingNodes(ctx, tx, tenantID, ids)
+	if err != nil {
+		return fmt.Errorf("fetching existing nodes for history: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO kg_nodes (id, tenant_id, type, label, properties, search_text, search_lang, search_props)
+		SELECT id, tenant_id, type, label, properties, search_text, search_lang, search_props FROM kg_branch_nodes
+		WHERE branch_id = $1 AND NOT deleted
+		ON CONFLICT (tenant_id, id) DO UPDATE SET
+			type = EXCLUDED.type,
+			label = EXCLUDED.label,
+			properties = EXCLUDED.properties,
+			search_text = EXCLUDED.search_text,
+			search_lang = EXCLUDED.search_lang,
+			search_props = EXCLUDED.search_props
+		RETURNING `+nodeColumns, branchID)
+	if err != nil {
+		return fmt.Errorf("writing staged nodes: %w", err)
+	}
+
+	result.Nodes, err = collectNodes(rows)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("collecting merged nodes: %w", err)
+	}
+
+	if err := s.decryptNodes(ctx, tenantID, result.Nodes); err != nil {
+		return err
+	}
+	result.NodesWritten = len(result.Nodes)
+
+	return recordMergedNodeHistory(ctx, tx, tenantID, existing, result.Nodes)
+}
+
+// recordMergedNodeHistory records the field and property changes a merge
+// made to nodes that existed before it.
+func recordMergedNodeHistory(
+	ctx context.Context, tx pgx.Tx, tenantID string, existing map[string]existingNode, nodes []models.Node,
+) error {
+	for _, n := range nodes {
+		old, ok := existing[n.ID]
+		if !ok {
+			continue
+		}
+
+		if err := RecordPropertyChanges(ctx, tx, tenantID, n.ID, old.properties, n.Properties, branchMergeReason); err != nil {
+			return fmt.Errorf("recording property history for %s: %w", n.ID, err)
+		}
+		if err := recordNodeFieldChange(ctx, tx, tenantID, n.ID, models.HistoryFieldType, old.nodeType, n.Type, branchMergeReason); err != nil {
+			return fmt.Errorf("recording type history for %s: %w", n.ID, err)
+		}
+		if err := recordNodeFieldChange(ctx, tx, tenantID, n.ID, models.HistoryFieldLabel, old.label, n.Label, branchMergeReason); err != nil {
+			return fmt.Errorf("recording label history for %s: %w", n.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// applyBranchEdges writes the staged edges over the live ones and records
+// property history for the edges that already existed.
+func (s *BranchStore) applyBranchEdges(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string, result *models.MergeBranchResult,
+) error {
+	keys, err := stagedEdgeKeys(ctx, tx, branchID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.fetchExistingEdgeProperties(ctx, tx, tenantID, keys)
+	if err != nil {
+		return fmt.Errorf("fetching existing edges for history: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO kg_edges (tenant_id, source, target, relation, properties, weight,
+			date_start, date_end, date_lower, date_upper, is_current, date_qualifier)
+		SELECT tenant_id, source, target, relation, properties, weight,
+			date_start, date_end, date_lower, date_upper, is_current, date_qualifier
+		FROM kg_branch_edges WHERE branch_id = $1 AND NOT deleted
+		ON CONFLICT (tenant_id, source, target, relation) DO UPDATE SET
+			properties = EXCLUDED.properties,
+			weight = EXCLUDED.weight,
+			date_start = EXCLUDED.date_start,
+			date_end = EXCLUDED.date_end,
+			date_lower = EXCLUDED.date_lower,
+			date_upper = EXCLUDED.date_upper,
+			is_current = EXCLUDED.is_current,
+			date_qualifier = EXCLUDED.date_qualifier
+		RETURNING `+edgeColumns, branchID)
+	if err != nil {
+		return fmt.Errorf("writing staged edges: %w", err)
+	}
+
+	edges, err := collectEdges(rows)
+	rows.Close()
+	if err != nil {
+		return fmt.Errorf("collecting merged edges: %w", err)
+	}
+
+	if err := s.decryptEdges(ctx, tenantID, edges); err != nil {
+		return err
+	}
+	result.EdgesWritten = len(edges)
+
+	for _, e := range edges {
+		old, ok := existing[edgeKey{e.Source, e.Target, e.Relation}]
+		if !ok {
+			continue
+		}
+
+		err := RecordEdgePropertyChanges(ctx, tx, tenantID, e.Source, e.Target, e.Relation, old, e.Properties, branchMergeReason)
+		if err != nil {
+			return fmt.Errorf("recording property history for %s->%s: %w", e.Source, e.Target, err)
+		}
+	}
+
+	return nil
+}
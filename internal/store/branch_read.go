@@ -0,0 +1,227 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GetBranchNode returns a node as the branch sees it: its staged version if
+// the branch has one, otherwise the live node.
+func (s *BranchStore) GetBranchNode(ctx context.Context, tenantID, branchID, nodeID string) (*models.Node, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchNoLock); err != nil {
+		return nil, err
+	}
+
+	return s.getBranchNode(ctx, tx, tenantID, branchID, nodeID)
+}
+
+// GetBranchEdge returns an edge as the branch sees it.
+func (s *BranchStore) GetBranchEdge(
+	ctx context.Context, tenantID, branchID, source, target, relation string,
+) (*models.Edge, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch edge: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchNoLock); err != nil {
+		return nil, err
+	}
+
+	return s.getBranchEdge(ctx, tx, tenantID, branchID, source, target, relation)
+}
+
+// BranchNeighbors is Neighbors as the branch sees the graph.
+func (s *BranchStore) BranchNeighbors(
+	ctx context.Context, tenantID, branchID, nodeID string, limit int,
+) (*models.NeighborResult, error) {
+	if limit <= 0 {
+		limit = defaultEdgesPerQuery
+	}
+
+	if limit > maxEdgesPerQuery {
+		limit = maxEdgesPerQuery
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch neighbors: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchNoLock); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getBranchNode(ctx, tx, tenantID, branchID, nodeID); err != nil {
+		return nil, err
+	}
+
+	edgeRows, err := tx.Query(ctx, `(SELECT `+edgeColumns+` FROM `+branchEdgesView+` WHERE v.source = $2 LIMIT $3)
+		UNION ALL
+		(SELECT `+edgeColumns+` FROM `+branchEdgesView+` WHERE v.target = $2 LIMIT $3)`,
+		branchID, nodeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying branch neighbor edges: %w", err)
+	}
+
+	edges, err := collectEdges(edgeRows)
+	edgeRows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("collecting branch neighbor edges: %w", err)
+	}
+
+	ids := make([]string, 0, len(edges))
+	for _, e := range edges {
+		if e.Source != nodeID {
+			ids = append(ids, e.Source)
+		}
+		if e.Target != nodeID {
+			ids = append(ids, e.Target)
+		}
+	}
+
+	nodeRows, err := tx.Query(ctx, `SELECT `+nodeColumns+` FROM `+branchNodesView+`
+		WHERE v.id = ANY($2) LIMIT `+fmt.Sprintf("%d", maxGraphNodeFetch), branchID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying branch neighbor nodes: %w", err)
+	}
+
+	nodes, err := collectNodes(nodeRows)
+	nodeRows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("collecting branch neighbor nodes: %w", err)
+	}
+
+	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+		return nil, err
+	}
+
+	if err := s.decryptEdges(ctx, tenantID, edges); err != nil {
+		return nil, err
+	}
+
+	return &models.NeighborResult{Nodes: nodes, Edges: edges}, nil
+}
+
+// GetBranchChanges lists the nodes and edges staged on a branch.
+func (s *BranchStore) GetBranchChanges(ctx context.Context, tenantID, branchID string) (*models.BranchChanges, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting branch changes: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchNoLock); err != nil {
+		return nil, err
+	}
+
+	changes := &models.BranchChanges{}
+	if changes.Nodes, err = s.stagedNodeChanges(ctx, tx, tenantID, branchID); err != nil {
+		return nil, err
+	}
+	if changes.Edges, err = s.stagedEdgeChanges(ctx, tx, tenantID, branchID); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (s *BranchStore) stagedNodeChanges(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string,
+) ([]models.BranchNodeChange, error) {
+	rows, err := tx.Query(ctx, `SELECT `+branchOp+`, `+nodeColumns+` FROM kg_branch_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND branch_id = $1
+		ORDER BY id`, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("querying staged nodes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.BranchNodeChange, 0, 16)
+	for rows.Next() {
+		var op string
+		n, err := scanNode(func(dest ...any) error { return rows.Scan(append([]any{&op}, dest...)...) })
+		if err != nil {
+			return nil, fmt.Errorf("scanning staged node: %w", err)
+		}
+
+		change := models.BranchNodeChange{ID: n.ID, Op: op}
+		if op != models.BranchOpDelete {
+			if err := s.decryptNode(ctx, tenantID, n); err != nil {
+				return nil, err
+			}
+			change.Node = n
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating staged nodes: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *BranchStore) stagedEdgeChanges(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string,
+) ([]models.BranchEdgeChange, error) {
+	rows, err := tx.Query(ctx, `SELECT `+branchOp+`, `+edgeColumns+` FROM kg_branch_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND branch_id = $1
+		ORDER BY source, target, relation`, branchID)
+	if err != nil {
+		return nil, fmt.Errorf("querying staged edges: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.BranchEdgeChange, 0, 16)
+	for rows.Next() {
+		var op string
+		e, err := scanEdge(func(dest ...any) error { return rows.Scan(append([]any{&op}, dest...)...) })
+		if err != nil {
+			return nil, fmt.Errorf("scanning staged edge: %w", err)
+		}
+
+		change := models.BranchEdgeChange{Source: e.Source, Target: e.Target, Relation: e.Relation, Op: op}
+		if op != models.BranchOpDelete {
+			if err := s.decryptEdge(ctx, tenantID, e); err != nil {
+				return nil, err
+			}
+			change.Edge = e
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating staged edges: %w", err)
+	}
+
+	return changes, nil
+}
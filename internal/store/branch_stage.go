@@ -0,0 +1,257 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CreateBranchNode stages a new node on a branch. It fails with
+// models.ErrDuplicateKey if the branch already sees a node with that ID.
+func (s *BranchStore) CreateBranchNode(
+	ctx context.Context, tenantID, branchID string, req models.CreateNodeRequest,
+) (*models.Node, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating branch node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM `+branchNodesView+` WHERE v.id = $2)`,
+		branchID, req.ID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("checking branch node: %w", err)
+	}
+	if exists {
+		return nil, models.ErrDuplicateKey
+	}
+
+	n, err := s.stageNode(ctx, tx, tenantID, branchID, &models.Node{
+		ID: req.ID, Type: req.Type, Label: req.Label, Properties: req.Properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing create branch node: %w", err)
+	}
+
+	return n, nil
+}
+
+// UpdateBranchNode stages a new version of a node the branch sees. Like
+// UpdateNode, fields left nil keep their value and properties are replaced.
+func (s *BranchStore) UpdateBranchNode(
+	ctx context.Context, tenantID, branchID, nodeID string, req models.UpdateNodeRequest,
+) (*models.Node, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("updating branch node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return nil, err
+	}
+
+	n, err := s.getBranchNode(ctx, tx, tenantID, branchID, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != nil {
+		n.Type = *req.Type
+	}
+	if req.Label != nil {
+		n.Label = *req.Label
+	}
+	if req.Properties != nil {
+		n.Properties = req.Properties
+	}
+
+	if n, err = s.stageNode(ctx, tx, tenantID, branchID, n); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing update branch node: %w", err)
+	}
+
+	return n, nil
+}
+
+// stageNode writes n as the branch's version of the node. A live node is
+// copied into the branch first so its salience and timestamps carry over and
+// its updated_at is kept for conflict detection on merge.
+func (s *BranchStore) stageNode(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string, n *models.Node,
+) (*models.Node, error) {
+	props := n.Properties
+	if props == nil {
+		props = map[string]any{}
+	}
+
+	propsJSON, err := s.encryptProperties(ctx, tenantID, props)
+	if err != nil {
+		return nil, fmt.Errorf("preparing node properties: %w", err)
+	}
+
+	if err := copyLiveNode(ctx, tx, branchID, n.ID); err != nil {
+		return nil, err
+	}
+
+	searchText := models.BuildNodeSearchText(&models.Node{Type: n.Type, Label: n.Label, Properties: props})
+
+	searchProps, err := s.searchProps(props)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO kg_branch_nodes (tenant_id, branch_id, id, type, label, properties, search_text, search_lang, search_props)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4, $5, $6, $7::regconfig, $8)
+		ON CONFLICT (branch_id, id) DO UPDATE SET
+			type = EXCLUDED.type,
+			label = EXCLUDED.label,
+			properties = EXCLUDED.properties,
+			search_text = EXCLUDED.search_text,
+			search_lang = EXCLUDED.search_lang,
+			search_props = EXCLUDED.search_props,
+			deleted = FALSE,
+			updated_at = NOW()
+		RETURNING `+nodeColumns,
+		branchID, n.ID, n.Type, n.Label, propsJSON, searchText, s.searchLanguage(searchText), searchProps)
+
+	staged, err := scanNode(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("scanning staged node: %w", err)
+	}
+
+	if err := s.decryptNode(ctx, tenantID, staged); err != nil {
+		return nil, err
+	}
+
+	return staged, nil
+}
+
+// copyLiveNode copies a live node into a branch unless the branch has
+// already staged it.
+func copyLiveNode(ctx context.Context, tx pgx.Tx, branchID, nodeID string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO kg_branch_nodes (branch_id, base_updated_at, search_text, search_lang, search_props, `+nodeColumns+`)
+		SELECT $1, updated_at, search_text, search_lang, search_props, `+nodeColumns+` FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $2
+		ON CONFLICT (branch_id, id) DO NOTHING`, branchID, nodeID)
+	if err != nil {
+		return fmt.Errorf("copying node into branch: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBranchNode stages the deletion of a node and of every edge the
+// branch sees touching it.
+func (s *BranchStore) DeleteBranchNode(ctx context.Context, tenantID, branchID, nodeID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting branch node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return err
+	}
+
+	if _, err := s.getBranchNode(ctx, tx, tenantID, branchID, nodeID); err != nil {
+		return err
+	}
+
+	stmts := []struct{ sql, what string }{
+		{`INSERT INTO kg_branch_edges (branch_id, base_updated_at, ` + edgeColumns + `)
+			SELECT $1, updated_at, ` + edgeColumns + ` FROM kg_edges
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND (source = $2 OR target = $2)
+			ON CONFLICT (branch_id, source, target, relation) DO NOTHING`, "copying node edges"},
+		{`UPDATE kg_branch_edges SET deleted = TRUE, updated_at = NOW()
+			WHERE branch_id = $1 AND (source = $2 OR target = $2)`, "deleting node edges"},
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(ctx, stmt.sql, branchID, nodeID); err != nil {
+			return fmt.Errorf("%s: %w", stmt.what, err)
+		}
+	}
+
+	if err := copyLiveNode(ctx, tx, branchID, nodeID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE kg_branch_nodes SET deleted = TRUE, updated_at = NOW()
+		WHERE branch_id = $1 AND id = $2`, branchID, nodeID); err != nil {
+		return fmt.Errorf("deleting node: %w", err)
+	}
+
+	if err := dropStagedCreates(ctx, tx, branchID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing delete branch node: %w", err)
+	}
+
+	return nil
+}
+
+// dropStagedCreates removes rows a branch created and then deleted. They
+// have nothing left to merge.
+func dropStagedCreates(ctx context.Context, tx pgx.Tx, branchID string) error {
+	for _, table := range []string{"kg_branch_edges", "kg_branch_nodes"} {
+		_, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE branch_id = $1 AND deleted AND base_updated_at IS NULL`, branchID)
+		if err != nil {
+			return fmt.Errorf("dropping deleted creates from %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// getBranchNode reads a node as the branch sees it.
+func (s *BranchStore) getBranchNode(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID, nodeID string,
+) (*models.Node, error) {
+	row := tx.QueryRow(ctx, `SELECT `+nodeColumns+` FROM `+branchNodesView+` WHERE v.id = $2`, branchID, nodeID)
+
+	n, err := scanNode(row.Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNodeNotFound
+		}
+
+		return nil, fmt.Errorf("scanning branch node: %w", err)
+	}
+
+	if err := s.decryptNode(ctx, tenantID, n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,252 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CreateBranchEdge stages a new edge on a branch. Both endpoints must exist
+// in the branch, and the branch must not already see the edge.
+func (s *BranchStore) CreateBranchEdge(
+	ctx context.Context, tenantID, branchID string, req models.CreateEdgeRequest,
+) (*models.Edge, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating branch edge: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return nil, err
+	}
+
+	var sourceExists, targetExists, edgeExists bool
+	err = tx.QueryRow(ctx, `SELECT
+			EXISTS(SELECT 1 FROM `+branchNodesView+` WHERE v.id = $2),
+			EXISTS(SELECT 1 FROM `+branchNodesView+` WHERE v.id = $3),
+			EXISTS(SELECT 1 FROM `+branchEdgesView+` WHERE v.source = $2 AND v.target = $3 AND v.relation = $4)`,
+		branchID, req.Source, req.Target, req.Relation).Scan(&sourceExists, &targetExists, &edgeExists)
+	if err != nil {
+		return nil, fmt.Errorf("checking branch edge: %w", err)
+	}
+
+	switch {
+	case !sourceExists:
+		return nil, fmt.Errorf("source node %q: %w", req.Source, models.ErrNodeNotFound)
+	case !targetExists:
+		return nil, fmt.Errorf("target node %q: %w", req.Target, models.ErrNodeNotFound)
+	case edgeExists:
+		return nil, models.ErrDuplicateKey
+	}
+
+	weight := 1.0
+	if req.Weight != nil {
+		weight = *req.Weight
+	}
+
+	e, err := s.stageEdge(ctx, tx, tenantID, branchID, &models.Edge{
+		Source: req.Source, Target: req.Target, Relation: req.Relation, Properties: req.Properties,
+		Weight: weight, DateStart: req.DateStart, DateEnd: req.DateEnd, IsCurrent: req.IsCurrent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing create branch edge: %w", err)
+	}
+
+	return e, nil
+}
+
+// UpdateBranchEdge stages a new version of an edge the branch sees, with the
+// same field semantics as UpdateEdge.
+func (s *BranchStore) UpdateBranchEdge(
+	ctx context.Context, tenantID, branchID, source, target, relation string, req models.UpdateEdgeRequest,
+) (*models.Edge, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("updating branch edge: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return nil, err
+	}
+
+	e, err := s.getBranchEdge(ctx, tx, tenantID, branchID, source, target, relation)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Properties != nil {
+		e.Properties = req.Properties
+	}
+	if req.Weight != nil {
+		e.Weight = *req.Weight
+	}
+	if req.DateStart != nil || req.DateEnd != nil {
+		e.DateStart, e.DateEnd = req.DateStart, req.DateEnd
+	}
+	if req.IsCurrent != nil {
+		e.IsCurrent = req.IsCurrent
+	}
+
+	if e, err = s.stageEdge(ctx, tx, tenantID, branchID, e); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing update branch edge: %w", err)
+	}
+
+	return e, nil
+}
+
+// stageEdge writes e as the branch's version of the edge, copying the live
+// edge into the branch first as stageNode does.
+func (s *BranchStore) stageEdge(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID string, e *models.Edge,
+) (*models.Edge, error) {
+	props := e.Properties
+	if props == nil {
+		props = map[string]any{}
+	}
+
+	propsJSON, err := s.encryptProperties(ctx, tenantID, props)
+	if err != nil {
+		return nil, fmt.Errorf("preparing edge properties: %w", err)
+	}
+
+	dateLower, dateUpper, dateQualifier, err := parseTemporalBounds(e.DateStart, e.DateEnd)
+	if err != nil {
+		return nil, fmt.Errorf("parsing temporal bounds: %w", err)
+	}
+
+	if err := copyLiveEdge(ctx, tx, branchID, e.Source, e.Target, e.Relation); err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, `
+		INSERT INTO kg_branch_edges
+			(tenant_id, branch_id, source, target, relation, properties, weight,
+			 date_start, date_end, date_lower, date_upper, is_current, date_qualifier)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (branch_id, source, target, relation) DO UPDATE SET
+			properties = EXCLUDED.properties,
+			weight = EXCLUDED.weight,
+			date_start = EXCLUDED.date_start,
+			date_end = EXCLUDED.date_end,
+			date_lower = EXCLUDED.date_lower,
+			date_upper = EXCLUDED.date_upper,
+			is_current = EXCLUDED.is_current,
+			date_qualifier = EXCLUDED.date_qualifier,
+			deleted = FALSE,
+			updated_at = NOW()
+		RETURNING `+edgeColumns,
+		branchID, e.Source, e.Target, e.Relation, propsJSON, e.Weight,
+		e.DateStart, e.DateEnd, dateLower, dateUpper, e.IsCurrent, dateQualifier)
+
+	staged, err := scanEdge(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("scanning staged edge: %w", err)
+	}
+
+	if err := s.decryptEdge(ctx, tenantID, staged); err != nil {
+		return nil, err
+	}
+
+	return staged, nil
+}
+
+// copyLiveEdge copies a live edge into a branch unless the branch has
+// already staged it.
+func copyLiveEdge(ctx context.Context, tx pgx.Tx, branchID, source, target, relation string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO kg_branch_edges (branch_id, base_updated_at, `+edgeColumns+`)
+		SELECT $1, updated_at, `+edgeColumns+` FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $2 AND target = $3 AND relation = $4
+		ON CONFLICT (branch_id, source, target, relation) DO NOTHING`, branchID, source, target, relation)
+	if err != nil {
+		return fmt.Errorf("copying edge into branch: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBranchEdge stages the deletion of an edge the branch sees.
+func (s *BranchStore) DeleteBranchEdge(ctx context.Context, tenantID, branchID, source, target, relation string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting branch edge: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := lockBranch(ctx, tx, branchID, branchShareLock); err != nil {
+		return err
+	}
+
+	if _, err := s.getBranchEdge(ctx, tx, tenantID, branchID, source, target, relation); err != nil {
+		return err
+	}
+
+	if err := copyLiveEdge(ctx, tx, branchID, source, target, relation); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE kg_branch_edges SET deleted = TRUE, updated_at = NOW()
+		WHERE branch_id = $1 AND source = $2 AND target = $3 AND relation = $4`,
+		branchID, source, target, relation); err != nil {
+		return fmt.Errorf("deleting edge: %w", err)
+	}
+
+	if err := dropStagedCreates(ctx, tx, branchID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing delete branch edge: %w", err)
+	}
+
+	return nil
+}
+
+// getBranchEdge reads an edge as the branch sees it.
+func (s *BranchStore) getBranchEdge(
+	ctx context.Context, tx pgx.Tx, tenantID, branchID, source, target, relation string,
+) (*models.Edge, error) {
+	row := tx.QueryRow(ctx, `SELECT `+edgeColumns+` FROM `+branchEdgesView+`
+		WHERE v.source = $2 AND v.target = $3 AND v.relation = $4`, branchID, source, target, relation)
+
+	e, err := scanEdge(row.Scan)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrEdgeNotFound
+		}
+
+		return nil, fmt.Errorf("scanning branch edge: %w", err)
+	}
+
+	if err := s.decryptEdge(ctx, tenantID, e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
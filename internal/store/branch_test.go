@@ -0,0 +1,120 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestBranchStageAndMerge(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	bs := store.NewBranchStore(base)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	for _, id := range []string{"br-a", "br-b"} {
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: id, Type: "person", Label: id}); err != nil {
+			t.Fatalf("CreateNode(%s): %v", id, err)
+		}
+	}
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: "br-a", Target: "br-b", Relation: "knows"}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	b, err := bs.CreateBranch(ctx, tenantID, models.CreateBranchRequest{Name: "speculative"})
+	if err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+
+	label := "A renamed"
+	if _, err := bs.UpdateBranchNode(ctx, tenantID, b.ID, "br-a", models.UpdateNodeRequest{Label: &label}); err != nil {
+		t.Fatalf("UpdateBranchNode: %v", err)
+	}
+	if _, err := bs.CreateBranchNode(ctx, tenantID, b.ID, models.CreateNodeRequest{ID: "br-c", Type: "person", Label: "C"}); err != nil {
+		t.Fatalf("CreateBranchNode: %v", err)
+	}
+	if _, err := bs.CreateBranchEdge(ctx, tenantID, b.ID, models.CreateEdgeRequest{Source: "br-a", Target: "br-c", Relation: "knows"}); err != nil {
+		t.Fatalf("CreateBranchEdge: %v", err)
+	}
+	if err := bs.DeleteBranchNode(ctx, tenantID, b.ID, "br-b"); err != nil {
+		t.Fatalf("DeleteBranchNode: %v", err)
+	}
+
+	// The branch sees its own writes; the live graph does not.
+	if n, err := bs.GetBranchNode(ctx, tenantID, b.ID, "br-a"); err != nil || n.Label != label {
+		t.Errorf("branch node = %+v, %v; want label %q", n, err, label)
+	}
+	if _, err := bs.GetBranchNode(ctx, tenantID, b.ID, "br-b"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("deleted branch node: err = %v, want ErrNodeNotFound", err)
+	}
+	if n, err := ns.GetNode(ctx, tenantID, "br-a"); err != nil || n.Label != "br-a" {
+		t.Errorf("live node changed before merge: %+v, %v", n, err)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, "br-c"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("staged node visible before merge: err = %v", err)
+	}
+
+	nb, err := bs.BranchNeighbors(ctx, tenantID, b.ID, "br-a", 0)
+	if err != nil || len(nb.Edges) != 1 || nb.Edges[0].Target != "br-c" {
+		t.Errorf("BranchNeighbors = %+v, %v; want only the br-c edge", nb, err)
+	}
+
+	result, err := bs.MergeBranch(ctx, tenantID, b.ID, models.MergeBranchRequest{})
+	if err != nil || !result.Merged || len(result.Conflicts) != 0 {
+		t.Fatalf("MergeBranch = %+v, %v", result, err)
+	}
+
+	if n, err := ns.GetNode(ctx, tenantID, "br-a"); err != nil || n.Label != label {
+		t.Errorf("merged node = %+v, %v", n, err)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, "br-b"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("merged delete: err = %v, want ErrNodeNotFound", err)
+	}
+	if _, err := bs.GetBranch(ctx, tenantID, b.ID); !errors.Is(err, models.ErrBranchNotFound) {
+		t.Errorf("merged branch still exists: err = %v", err)
+	}
+}
+
+func TestBranchMergeConflict(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	bs := store.NewBranchStore(base)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "cf-a", Type: "person", Label: "A"}); err != nil {
+		t.Fatalf("CreateNode: %v", err)
+	}
+
+	b, err := bs.CreateBranch(ctx, tenantID, models.CreateBranchRequest{})
+	if err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+
+	branchLabel, liveLabel := "branch", "live"
+	if _, err := bs.UpdateBranchNode(ctx, tenantID, b.ID, "cf-a", models.UpdateNodeRequest{Label: &branchLabel}); err != nil {
+		t.Fatalf("UpdateBranchNode: %v", err)
+	}
+	if _, err := ns.UpdateNode(ctx, tenantID, "cf-a", models.UpdateNodeRequest{Label: &liveLabel}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+
+	result, err := bs.MergeBranch(ctx, tenantID, b.ID, models.MergeBranchRequest{})
+	if err != nil || result.Merged || len(result.Conflicts) != 1 || result.Conflicts[0].NodeID != "cf-a" {
+		t.Fatalf("MergeBranch = %+v, %v; want one conflict on cf-a", result, err)
+	}
+	if n, err := ns.GetNode(ctx, tenantID, "cf-a"); err != nil || n.Label != liveLabel {
+		t.Errorf("conflicted merge wrote the node: %+v, %v", n, err)
+	}
+
+	result, err = bs.MergeBranch(ctx, tenantID, b.ID, models.MergeBranchRequest{Force: true})
+	if err != nil || !result.Merged {
+		t.Fatalf("forced MergeBranch = %+v, %v", result, err)
+	}
+	if n, err := ns.GetNode(ctx, tenantID, "cf-a"); err != nil || n.Label != branchLabel {
+		t.Errorf("forced merge node = %+v, %v", n, err)
+	}
+}
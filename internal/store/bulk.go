@@ -2,8 +2,8 @@
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +31,8 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 	tenantID string,
 	nodes []models.CreateNodeRequest,
 ) ([]models.Node, error) {
+	defer observeOperation("BulkUpsertNodes", time.Now())
+
 	if len(nodes) == 0 {
 		return nil, nil
 	}
@@ -40,6 +42,7 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 
 	// Pre-encrypt all properties BEFORE opening the transaction to minimize lock time.
 	encryptedProps := make([][]byte, len(nodes))
+	searchProps := make([][]byte, len(nodes))
 	for i, node := range nodes {
 		props := node.Properties
 		if props == nil {
@@ -52,6 +55,10 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 		}
 
 		encryptedProps[i] = propsJSON
+
+		if searchProps[i], err = s.searchProps(props); err != nil {
+			return nil, err
+		}
 	}
 
 	tx, err := s.beginTx(ctx, tenantID)
@@ -61,15 +68,15 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	// Fetch existing node properties for history tracking.
+	// Fetch existing node state for history tracking.
 	existingNodeIDs := make([]string, len(nodes))
 	for i, n := range nodes {
 		existingNodeIDs[i] = n.ID
 	}
 
-	oldPropsMap, err := s.fetchExistingProperties(ctx, tx, tenantID, existingNodeIDs)
+	existing, err := s.fetchExistingNodes(ctx, tx, tenantID, existingNodeIDs)
 	if err != nil {
-		return nil, fmt.Errorf("fetching existing properties for history: %w", err)
+		return nil, fmt.Errorf("fetching existing nodes for history: %w", err)
 	}
 
 	result := make([]models.Node, 0, len(nodes))
@@ -83,25 +90,27 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 
 		batch := nodes[i:end]
 		batchProps := encryptedProps[i:end]
+		batchSearchProps := searchProps[i:end]
 
 		valueParts := make([]string, 0, len(batch))
-		args := make([]any, 0, len(batch)*5)
+		args := make([]any, 0, len(batch)*6)
 
 		for j, node := range batch {
-			base := j*5 + 1
+			base := j*6 + 1
 			valueParts = append(valueParts, fmt.Sprintf(
-				"($%d, $%d, $%d, $%d, $%d)",
-				base, base+1, base+2, base+3, base+4,
+				"($%d, $%d, $%d, $%d, $%d, $%d)",
+				base, base+1, base+2, base+3, base+4, base+5,
 			))
-			args = append(args, node.ID, tenantID, node.Type, node.Label, batchProps[j])
+			args = append(args, node.ID, tenantID, node.Type, node.Label, batchProps[j], batchSearchProps[j])
 		}
 
-		sql := `INSERT INTO kg_nodes (id, tenant_id, type, label, properties)
+		sql := `INSERT INTO kg_nodes (id, tenant_id, type, label, properties, search_props)
 			VALUES ` + strings.Join(valueParts, ", ") + `
 			ON CONFLICT (tenant_id, id) DO UPDATE
 			SET type = EXCLUDED.type,
 				label = EXCLUDED.label,
 				properties = EXCLUDED.properties,
+				search_props = EXCLUDED.search_props,
 				updated_at = NOW()
 			RETURNING ` + nodeColumns
 
@@ -120,9 +129,9 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 		result = append(result, batchNodes...)
 	}
 
-	// Record property history for nodes that existed before the upsert.
+	// Record history for nodes that existed before the upsert.
 	for _, node := range nodes {
-		oldProps, existed := oldPropsMap[node.ID]
+		old, existed := existing[node.ID]
 		if !existed {
 			continue
 		}
@@ -132,9 +141,21 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 			newProps = map[string]any{}
 		}
 
-		if err := RecordPropertyChanges(ctx, tx, tenantID, node.ID, oldProps, newProps, "bulk_upsert"); err != nil {
+		if err := RecordPropertyChanges(ctx, tx, tenantID, node.ID, old.properties, newProps, "bulk_upsert"); err != nil {
 			return nil, fmt.Errorf("recording property history for %s: %w", node.ID, err)
 		}
+
+		if err := recordNodeFieldChange(ctx, tx, tenantID, node.ID, models.HistoryFieldType, old.nodeType, node.Type, "bulk_upsert"); err != nil {
+			return nil, fmt.Errorf("recording type history for %s: %w", node.ID, err)
+		}
+
+		if err := recordNodeFieldChange(ctx, tx, tenantID, node.ID, models.HistoryFieldLabel, old.label, node.Label, "bulk_upsert"); err != nil {
+			return nil, fmt.Errorf("recording label history for %s: %w", node.ID, err)
+		}
+	}
+
+	if err := enforceQuota(ctx, tx, tenantID, countAdded(existingNodeIDs, existing), 0); err != nil {
+		return nil, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -146,21 +167,14 @@ func (s *BulkStore) BulkUpsertNodes( //nolint:gocognit,gocyclo,cyclop,funlen //
 		return nil, fmt.Errorf("decrypting bulk upserted nodes: %w", err)
 	}
 
-	// Send aggregate notification (best-effort) using a fresh context.
-	notifyCtx, notifyCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer notifyCancel()
-
-	payload, _ := json.Marshal(map[string]any{ //nolint:errcheck // static keys, cannot fail.
+	// Send aggregate notification (best-effort).
+	s.sendEvent("kg_nodes", "BULK", tenantID, map[string]any{
 		"table":     "kg_nodes",
 		"op":        "BULK",
 		"count":     len(result),
 		"tenant_id": tenantID,
 	})
 
-	if _, err := s.Pool.Exec(notifyCtx, "SELECT pg_notify('kg_changes', $1)", string(payload)); err != nil {
-		s.Log.WithError(err).Warn("failed to send bulk node notification")
-	}
-
 	return result, nil
 }
 
@@ -246,7 +260,24 @@ func (s *BulkStore) BulkUpsertEdges( //nolint:gocognit,gocyclo,cyclop,funlen //
 			}
 		}
 
-		return nil, fmt.Errorf("missing node IDs referenced by edges: %v", missing)
+		sort.Strings(missing)
+
+		return nil, &models.MissingNodesError{IDs: missing}
+	}
+
+	if err := enforceRelationCatalog(ctx, tx, tenantID, edges); err != nil {
+		return nil, err
+	}
+
+	// Fetch existing edge properties for history tracking.
+	edgeKeys := make([]edgeKey, len(edges))
+	for i, edge := range edges {
+		edgeKeys[i] = edgeKey{edge.Source, edge.Target, edge.Relation}
+	}
+
+	oldPropsMap, err := s.fetchExistingEdgeProperties(ctx, tx, tenantID, edgeKeys)
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing edge properties for history: %w", err)
 	}
 
 	result := make([]models.Edge, 0, len(edges))
@@ -300,6 +331,28 @@ func (s *BulkStore) BulkUpsertEdges( //nolint:gocognit,gocyclo,cyclop,funlen //
 		result = append(result, batchEdges...)
 	}
 
+	// Record property history for edges that existed before the upsert.
+	for i, edge := range edges {
+		oldProps, existed := oldPropsMap[edgeKeys[i]]
+		if !existed {
+			continue
+		}
+
+		newProps := edge.Properties
+		if newProps == nil {
+			newProps = map[string]any{}
+		}
+
+		err := RecordEdgePropertyChanges(ctx, tx, tenantID, edge.Source, edge.Target, edge.Relation, oldProps, newProps, "bulk_upsert")
+		if err != nil {
+			return nil, fmt.Errorf("recording property history for %s->%s: %w", edge.Source, edge.Target, err)
+		}
+	}
+
+	if err := enforceQuota(ctx, tx, tenantID, 0, countAdded(edgeKeys, oldPropsMap)); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing bulk upsert edges: %w", err)
 	}
@@ -309,20 +362,13 @@ func (s *BulkStore) BulkUpsertEdges( //nolint:gocognit,gocyclo,cyclop,funlen //
 		return nil, fmt.Errorf("decrypting bulk upserted edges: %w", err)
 	}
 
-	// Send aggregate notification (best-effort) using a fresh context.
-	notifyCtx, notifyCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer notifyCancel()
-
-	payload, _ := json.Marshal(map[string]any{ //nolint:errcheck // static keys, cannot fail.
+	// Send aggregate notification (best-effort).
+	s.sendEvent("kg_edges", "BULK", tenantID, map[string]any{
 		"table":     "kg_edges",
 		"op":        "BULK",
 		"count":     len(result),
 		"tenant_id": tenantID,
 	})
 
-	if _, err := s.Pool.Exec(notifyCtx, "SELECT pg_notify('kg_changes', $1)", string(payload)); err != nil {
-		s.Log.WithError(err).Warn("failed to send bulk edge notification")
-	}
-
 	return result, nil
 }
@@ -7,21 +7,28 @@
 	"github.com/jackc/pgx/v5"
 )
 
-// fetchExistingProperties loads decrypted properties for a set of node IDs
-// within an existing transaction. Returns a map of nodeID -> properties for
-// nodes that exist; missing nodes are omitted.
-func (s *BulkStore) fetchExistingProperties(
+// existingNode is the pre-upsert state of a node, kept for history tracking.
+type existingNode struct {
+	nodeType   string
+	label      string
+	properties map[string]any
+}
+
+// fetchExistingNodes loads the type, label, and decrypted properties for a set
+// of node IDs within an existing transaction. Returns a map of nodeID -> state
+// for nodes that exist; missing nodes are omitted.
+func (b *Base) fetchExistingNodes(
 	ctx context.Context,
 	tx pgx.Tx,
 	tenantID string,
 	nodeIDs []string,
-) (map[string]map[string]any, error) {
+) (map[string]existingNode, error) {
 	if len(nodeIDs) == 0 {
 		return nil, nil
 	}
 
 	rows, err := tx.Query(ctx,
-		`SELECT id, properties FROM kg_nodes
+		`SELECT id, type, label, properties FROM kg_nodes
 		 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = ANY($1)`,
 		nodeIDs,
 	)
@@ -30,22 +37,22 @@ func (s *BulkStore) fetchExistingProperties(
 	}
 	defer rows.Close()
 
-	result := make(map[string]map[string]any)
+	result := make(map[string]existingNode)
 
 	for rows.Next() {
-		var id string
+		var id, nodeType, label string
 		var propsBytes []byte
 
-		if err := rows.Scan(&id, &propsBytes); err != nil {
+		if err := rows.Scan(&id, &nodeType, &label, &propsBytes); err != nil {
 			return nil, fmt.Errorf("scanning existing node properties: %w", err)
 		}
 
-		props, err := s.decryptPropertiesRaw(ctx, tenantID, propsBytes)
+		props, err := b.decryptPropertiesRaw(ctx, tenantID, propsBytes)
 		if err != nil {
 			return nil, fmt.Errorf("decrypting existing properties for %s: %w", id, err)
 		}
 
-		result[id] = props
+		result[id] = existingNode{nodeType: nodeType, label: label, properties: props}
 	}
 
 	if err := rows.Err(); err != nil {
@@ -54,3 +61,16 @@ func (s *BulkStore) fetchExistingProperties(
 
 	return result, nil
 }
+
+// countAdded returns how many distinct keys are not in existing, i.e. how
+// many rows an upsert of keys inserts rather than updates.
+func countAdded[K comparable, V any](keys []K, existing map[K]V) int {
+	added := make(map[K]struct{}, len(keys))
+	for _, k := range keys {
+		if _, ok := existing[k]; !ok {
+			added[k] = struct{}{}
+		}
+	}
+
+	return len(added)
+}
@@ -0,0 +1,286 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CoAccessStore records which nodes are accessed together and infers weak
+// related_to edges from it.
+type CoAccessStore struct {
+	Base
+}
+
+// NewCoAccessStore creates a new CoAccessStore.
+func NewCoAccessStore(base Base) *CoAccessStore {
+	return &CoAccessStore{Base: base}
+}
+
+// RecordAccess marks nodeIDs as accessed in sessionID. Accessing a node again
+// in the same session refreshes its access time.
+func (s *CoAccessStore) RecordAccess(ctx context.Context, tenantID, sessionID string, nodeIDs []string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("recording access: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `INSERT INTO kg_access_sessions (tenant_id, session_id, node_id)
+		SELECT DISTINCT current_setting('app.tenant_id')::uuid, $1, node_id
+		FROM unnest($2::text[]) AS node_id
+		ON CONFLICT (tenant_id, session_id, node_id) DO UPDATE SET accessed_at = NOW()`,
+		sessionID, nodeIDs,
+	); err != nil {
+		return fmt.Errorf("recording access: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing access: %w", err)
+	}
+
+	return nil
+}
+
+// coAccessPair is a node pair scored by decayed co-access, with source < target.
+type coAccessPair struct {
+	source, target string
+	score          float64
+}
+
+// coAccessColumns holds the qualifying pairs as parallel arrays, the shape
+// the reconciling statements unnest.
+type coAccessColumns struct {
+	sources, targets []string
+	scores, weights  []float64
+}
+
+// InferCoAccessEdges prunes accesses older than the request window, scores
+// node pairs by how often and how recently they were accessed together, and
+// reconciles the tenant's inferred related_to edges with the pairs that
+// qualify: new pairs get an edge unless the nodes are already connected,
+// existing inferred edges are reweighted, and inferred edges whose pair no
+// longer qualifies are deleted.
+func (s *CoAccessStore) InferCoAccessEdges(
+	ctx context.Context, tenantID string, req models.CoAccessInferenceRequest,
+) (*models.CoAccessInferenceResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("inferring co-access edges: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	result := &models.CoAccessInferenceResult{}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM kg_access_sessions
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND accessed_at < NOW() - make_interval(days => $1)`,
+		req.WindowDays,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pruning old accesses: %w", err)
+	}
+
+	result.Pruned = int(tag.RowsAffected())
+
+	pairs, err := scoreCoAccessPairs(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Pairs = len(pairs)
+
+	if err := s.reconcileInferredEdges(ctx, tx, tenantID, coAccessColumnsOf(pairs, req.MinScore), result); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing co-access inference: %w", err)
+	}
+
+	if result.Created > 0 || result.Updated > 0 || result.Removed > 0 {
+		s.notify("kg_edges", "update", tenantID)
+	}
+
+	return result, nil
+}
+
+// scoreCoAccessPairs returns the node pairs whose decayed co-access score
+// reaches req.MinScore, best first, at most req.MaxEdges of them.
+func scoreCoAccessPairs(ctx context.Context, tx pgx.Tx, req models.CoAccessInferenceRequest) ([]coAccessPair, error) {
+	rows, err := tx.Query(ctx, `SELECT a.node_id, b.node_id,
+			SUM(power(0.5, EXTRACT(EPOCH FROM NOW() - GREATEST(a.accessed_at, b.accessed_at))
+				/ ($1::double precision * 86400)))::double precision AS score
+		FROM kg_access_sessions a
+		JOIN kg_access_sessions b
+			ON b.tenant_id = a.tenant_id AND b.session_id = a.session_id AND a.node_id < b.node_id
+		JOIN kg_nodes sn ON sn.tenant_id = a.tenant_id AND sn.id = a.node_id
+		JOIN kg_nodes tn ON tn.tenant_id = b.tenant_id AND tn.id = b.node_id
+		WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
+		GROUP BY a.node_id, b.node_id
+		HAVING SUM(power(0.5, EXTRACT(EPOCH FROM NOW() - GREATEST(a.accessed_at, b.accessed_at))
+			/ ($1::double precision * 86400))) >= $2
+		ORDER BY score DESC, a.node_id, b.node_id
+		LIMIT $3`,
+		req.HalfLifeDays, req.MinScore, req.MaxEdges,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("scoring co-accessed pairs: %w", err)
+	}
+
+	defer rows.Close()
+
+	var pairs []coAccessPair
+	for rows.Next() {
+		var p coAccessPair
+		if err := rows.Scan(&p.source, &p.target, &p.score); err != nil {
+			return nil, fmt.Errorf("scanning co-accessed pair: %w", err)
+		}
+
+		pairs = append(pairs, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating co-accessed pairs: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// coAccessColumnsOf lays pairs out as columns and derives each pair's edge
+// weight from its score.
+func coAccessColumnsOf(pairs []coAccessPair, minScore float64) coAccessColumns {
+	c := coAccessColumns{
+		sources: make([]string, len(pairs)),
+		targets: make([]string, len(pairs)),
+		scores:  make([]float64, len(pairs)),
+		weights: make([]float64, len(pairs)),
+	}
+	for i, p := range pairs {
+		c.sources[i], c.targets[i], c.scores[i] = p.source, p.target, p.score
+		c.weights[i] = models.CoAccessEdgeWeight(p.score, minScore)
+	}
+
+	return c
+}
+
+// reconcileInferredEdges removes the inferred edges whose pair no longer
+// qualifies, reweights the ones that still do, and creates edges for the
+// new pairs, counting each in result.
+func (s *CoAccessStore) reconcileInferredEdges(
+	ctx context.Context, tx pgx.Tx, tenantID string, c coAccessColumns, result *models.CoAccessInferenceResult,
+) error {
+	removed, err := removeDecayedInferredEdges(ctx, tx, c)
+	if err != nil {
+		return err
+	}
+
+	result.Removed = removed
+
+	tag, err := tx.Exec(ctx, `WITH p AS (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::double precision[], $4::double precision[])
+				AS p(source, target, score, weight)
+		), owned AS (
+			UPDATE kg_inferred_edges i SET score = p.score, updated_at = NOW()
+			FROM p
+			WHERE i.tenant_id = current_setting('app.tenant_id')::uuid
+				AND i.source = p.source AND i.target = p.target AND i.relation = $5
+			RETURNING i.source, i.target
+		)
+		UPDATE kg_edges e SET weight = p.weight, updated_at = NOW()
+		FROM owned o
+		JOIN p ON p.source = o.source AND p.target = o.target
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid
+			AND e.source = o.source AND e.target = o.target AND e.relation = $5`,
+		c.sources, c.targets, c.scores, c.weights, models.CoAccessRelation,
+	)
+	if err != nil {
+		return fmt.Errorf("reweighting inferred edges: %w", err)
+	}
+
+	result.Updated = int(tag.RowsAffected())
+
+	created, err := s.createInferredEdges(ctx, tx, tenantID, c)
+	if err != nil {
+		return err
+	}
+
+	result.Created = created
+
+	return nil
+}
+
+// createInferredEdges creates a related_to edge for each pair and records
+// it as inferred. Pairs already connected in either direction, by any
+// relation, keep the edges they have.
+func (s *CoAccessStore) createInferredEdges(ctx context.Context, tx pgx.Tx, tenantID string, c coAccessColumns) (int, error) {
+	emptyProps, err := s.encryptProperties(ctx, tenantID, map[string]any{})
+	if err != nil {
+		return 0, fmt.Errorf("preparing edge properties: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `WITH p AS (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::double precision[], $4::double precision[])
+				AS p(source, target, score, weight)
+		), created AS (
+			INSERT INTO kg_edges (tenant_id, source, target, relation, properties, weight)
+			SELECT current_setting('app.tenant_id')::uuid, p.source, p.target, $5, $6, p.weight
+			FROM p
+			WHERE NOT EXISTS (
+				SELECT 1 FROM kg_edges e
+				WHERE e.tenant_id = current_setting('app.tenant_id')::uuid
+					AND ((e.source = p.source AND e.target = p.target)
+						OR (e.source = p.target AND e.target = p.source))
+			)
+			ON CONFLICT DO NOTHING
+			RETURNING source, target, relation
+		)
+		INSERT INTO kg_inferred_edges (tenant_id, source, target, relation, score)
+		SELECT current_setting('app.tenant_id')::uuid, c.source, c.target, c.relation, p.score
+		FROM created c
+		JOIN p ON p.source = c.source AND p.target = c.target
+		ON CONFLICT (tenant_id, source, target, relation) DO UPDATE SET score = EXCLUDED.score, updated_at = NOW()`,
+		c.sources, c.targets, c.scores, c.weights, models.CoAccessRelation, emptyProps,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("creating inferred edges: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// removeDecayedInferredEdges deletes the inferred edges whose pair is not
+// among the qualifying pairs c, with their inference records.
+func removeDecayedInferredEdges(ctx context.Context, tx pgx.Tx, c coAccessColumns) (int, error) {
+	tag, err := tx.Exec(ctx, `WITH decayed AS (
+			DELETE FROM kg_inferred_edges i
+			WHERE i.tenant_id = current_setting('app.tenant_id')::uuid
+				AND NOT EXISTS (
+					SELECT 1 FROM unnest($1::text[], $2::text[]) AS p(source, target)
+					WHERE p.source = i.source AND p.target = i.target
+				)
+			RETURNING i.*
+		)
+		DELETE FROM kg_edges e
+		USING decayed i
+		WHERE e.tenant_id = i.tenant_id AND e.source = i.source
+			AND e.target = i.target AND e.relation = i.relation`,
+		c.sources, c.targets,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("removing decayed inferred edges: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
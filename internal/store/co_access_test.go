@@ -0,0 +1,71 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestInferCoAccessEdges(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	cs := store.NewCoAccessStore(base)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	for _, id := range []string{"co-a", "co-b", "co-c", "co-d"} {
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: id, Type: "note", Label: id}); err != nil {
+			t.Fatalf("CreateNode(%s): %v", id, err)
+		}
+	}
+
+	// c and d are already connected, so co-access must not add an edge.
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: "co-d", Target: "co-c", Relation: "uses"}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	for _, session := range []string{"s1", "s2", "s3"} {
+		if err := cs.RecordAccess(ctx, tenantID, session, []string{"co-a", "co-b", "co-c", "co-d", "co-a"}); err != nil {
+			t.Fatalf("RecordAccess(%s): %v", session, err)
+		}
+	}
+
+	req := models.CoAccessInferenceRequest{}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	result, err := cs.InferCoAccessEdges(ctx, tenantID, req)
+	if err != nil {
+		t.Fatalf("InferCoAccessEdges: %v", err)
+	}
+	// Six pairs qualify; c-d already has an edge.
+	if result.Pairs != 6 || result.Created != 5 || result.Updated != 0 || result.Removed != 0 {
+		t.Fatalf("first run = %+v, want 6 pairs and 5 created", result)
+	}
+
+	edges, _, err := es.ListEdges(ctx, tenantID, "co-a", "co-b", models.CoAccessRelation, 10, 0, nil, nil, nil)
+	if err != nil || len(edges) != 1 || edges[0].Weight <= 0 || edges[0].Weight > models.MaxCoAccessEdgeWeight {
+		t.Fatalf("ListEdges = %+v, %v; want one weak related_to edge", edges, err)
+	}
+
+	result, err = cs.InferCoAccessEdges(ctx, tenantID, req)
+	if err != nil || result.Created != 0 || result.Updated != 5 {
+		t.Fatalf("second run = %+v, %v; want 5 reweighted", result, err)
+	}
+
+	// Raising the threshold past every score removes the inferred edges but
+	// leaves the asserted one.
+	req.MinScore = 100
+	result, err = cs.InferCoAccessEdges(ctx, tenantID, req)
+	if err != nil || result.Pairs != 0 || result.Removed != 5 {
+		t.Fatalf("decayed run = %+v, %v; want 5 removed", result, err)
+	}
+
+	edges, _, err = es.ListEdges(ctx, tenantID, "", "", "", 10, 0, nil, nil, nil)
+	if err != nil || len(edges) != 1 || edges[0].Relation != "uses" {
+		t.Errorf("only the asserted edge should remain: %+v, %v", edges, err)
+	}
+}
@@ -57,6 +57,10 @@ func (s *EdgeStore) CreateEdge(
 		return nil, fmt.Errorf("target node %q: %w", req.Target, models.ErrNodeNotFound)
 	}
 
+	if err := enforceRelationCatalog(ctx, tx, tenantID, []models.CreateEdgeRequest{req}); err != nil {
+		return nil, err
+	}
+
 	props := req.Properties
 	if props == nil {
 		props = map[string]any{}
@@ -102,11 +106,19 @@ func (s *EdgeStore) CreateEdge(
 		return nil, err
 	}
 
+	if err := enforceQuota(ctx, tx, tenantID, 0, 1); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing create edge: %w", err)
 	}
 
-	s.notify("kg_edges", "insert", tenantID)
+	s.notifyChange("kg_edges", "insert", tenantID, &models.ChangeDetail{
+		Source:   e.Source,
+		Target:   e.Target,
+		Relation: e.Relation,
+	})
 
 	return e, nil
 }
@@ -128,6 +140,14 @@ func (s *EdgeStore) UpdateEdge(
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
+	var oldProps map[string]any
+	if req.Properties != nil {
+		oldProps, err = fetchEdgeProperties(ctx, tx, tenantID, source, target, relation, &s.Base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	setClauses, args, argIdx, err := s.buildEdgeUpdateClauses(ctx, tenantID, req)
 	if err != nil {
 		return nil, err
@@ -171,11 +191,22 @@ func (s *EdgeStore) UpdateEdge(
 		return nil, err
 	}
 
+	if req.Properties != nil {
+		if err := RecordEdgePropertyChanges(ctx, tx, tenantID, source, target, relation, oldProps, req.Properties, ""); err != nil {
+			return nil, fmt.Errorf("recording edge property history: %w", err)
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing update edge: %w", err)
 	}
 
-	s.notify("kg_edges", "update", tenantID)
+	detail := &models.ChangeDetail{Source: source, Target: target, Relation: relation}
+	if req.Properties != nil {
+		detail.Properties = changedPropertyKeys(oldProps, req.Properties)
+	}
+
+	s.notifyChange("kg_edges", "update", tenantID, detail)
 
 	return e, nil
 }
@@ -0,0 +1,234 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// edgeKey identifies an edge by its composite key.
+type edgeKey struct {
+	source, target, relation string
+}
+
+// RecordEdgePropertyChanges diffs oldProps and newProps, inserting a history
+// row for each changed key of the edge. The edge counterpart of
+// RecordPropertyChanges.
+func RecordEdgePropertyChanges(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, source, target, relation string,
+	oldProps, newProps map[string]any,
+	reason string,
+) error {
+	changes, err := diffProperties(oldProps, newProps)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	valueParts := make([]string, 0, len(changes))
+	args := make([]any, 0, len(changes)*8)
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	for i, c := range changes {
+		base := i*8 + 1
+		valueParts = append(valueParts, fmt.Sprintf(
+			"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base, base+1, base+2, base+3, base+4, base+5, base+6, base+7,
+		))
+		args = append(args, tenantID, source, target, relation, c.key, c.oldValue, c.newValue, reasonPtr)
+	}
+
+	sql := `INSERT INTO kg_edge_property_history
+		(tenant_id, source, target, relation, property_key, old_value, new_value, reason)
+		VALUES ` + strings.Join(valueParts, ", ")
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("inserting edge property history: %w", err)
+	}
+
+	return nil
+}
+
+// fetchEdgeProperties loads and decrypts properties for a single edge within
+// a transaction, locking the row for the update that follows.
+func fetchEdgeProperties(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, source, target, relation string,
+	b *Base,
+) (map[string]any, error) {
+	var propsBytes []byte
+
+	err := tx.QueryRow(ctx,
+		`SELECT properties FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $1 AND target = $2 AND relation = $3
+		FOR UPDATE`,
+		source, target, relation,
+	).Scan(&propsBytes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrEdgeNotFound
+		}
+
+		return nil, fmt.Errorf("fetching edge properties: %w", err)
+	}
+
+	props, err := b.decryptPropertiesRaw(ctx, tenantID, propsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting edge properties: %w", err)
+	}
+
+	return props, nil
+}
+
+// fetchExistingEdgeProperties loads and decrypts properties for the edges in
+// keys that already exist, for history tracking during bulk upserts and
+// branch merges.
+func (b *Base) fetchExistingEdgeProperties(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID string,
+	keys []edgeKey,
+) (map[edgeKey]map[string]any, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sources := make([]string, len(keys))
+	targets := make([]string, len(keys))
+	relations := make([]string, len(keys))
+
+	for i, k := range keys {
+		sources[i], targets[i], relations[i] = k.source, k.target, k.relation
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT e.source, e.target, e.relation, e.properties
+		FROM kg_edges e
+		INNER JOIN unnest($1::text[], $2::text[], $3::text[]) AS k(source, target, relation)
+			ON e.source = k.source AND e.target = k.target AND e.relation = k.relation
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid`,
+		sources, targets, relations,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying existing edge properties: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[edgeKey]map[string]any)
+
+	for rows.Next() {
+		var k edgeKey
+		var propsBytes []byte
+
+		if err := rows.Scan(&k.source, &k.target, &k.relation, &propsBytes); err != nil {
+			return nil, fmt.Errorf("scanning existing edge properties: %w", err)
+		}
+
+		props, err := b.decryptPropertiesRaw(ctx, tenantID, propsBytes)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting existing properties for %s->%s: %w", k.source, k.target, err)
+		}
+
+		result[k] = props
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating existing edge properties: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetEdgePropertyHistory returns property change history for an edge with
+// optional key filter and has_more pagination.
+func (s *HistoryStore) GetEdgePropertyHistory(
+	ctx context.Context,
+	tenantID, source, target, relation string,
+	propertyKey string,
+	limit, offset int,
+) ([]models.EdgePropertyChange, bool, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, false, fmt.Errorf("getting edge property history: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, `SELECT id, tenant_id, source, target, relation, property_key,
+			old_value, new_value, changed_at, reason, changed_by
+		FROM kg_edge_property_history
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND source = $1 AND target = $2 AND relation = $3
+			AND ($4 = '' OR property_key = $4)
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $5 OFFSET $6`,
+		source, target, relation, propertyKey, limit+1, offset,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("querying edge property history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.EdgePropertyChange, 0, limit+1)
+
+	for rows.Next() {
+		var c models.EdgePropertyChange
+		var tenantUUID uuid.UUID
+
+		if err := rows.Scan(
+			&c.ID, &tenantUUID, &c.Source, &c.Target, &c.Relation, &c.PropertyKey,
+			&c.OldValue, &c.NewValue, &c.ChangedAt, &c.Reason, &c.ChangedBy,
+		); err != nil {
+			return nil, false, fmt.Errorf("scanning edge property history row: %w", err)
+		}
+
+		c.TenantID = tenantUUID
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterating edge property history rows: %w", err)
+	}
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("committing edge property history query: %w", err)
+	}
+
+	return changes, hasMore, nil
+}
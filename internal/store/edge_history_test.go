@@ -0,0 +1,66 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestEdgePropertyHistory(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	bs := store.NewBulkStore(base)
+	hs := store.NewHistoryStore(base)
+	ctx := context.Background()
+
+	src := createTestNode(t, ns, tenantID, "History Source")
+	tgt := createTestNode(t, ns, tenantID, "History Target")
+
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{
+		Source: src.ID, Target: tgt.ID, Relation: "knows", Properties: map[string]any{"since": "2020"},
+	}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	if _, err := es.UpdateEdge(ctx, tenantID, src.ID, tgt.ID, "knows", models.UpdateEdgeRequest{
+		Properties: map[string]any{"since": "2021"},
+	}); err != nil {
+		t.Fatalf("UpdateEdge: %v", err)
+	}
+
+	if _, err := es.PatchEdgeProperties(ctx, tenantID, src.ID, tgt.ID, "knows", models.PatchPropertiesRequest{
+		Properties: map[string]any{"context": "work"},
+	}); err != nil {
+		t.Fatalf("PatchEdgeProperties: %v", err)
+	}
+
+	if _, err := bs.BulkUpsertEdges(ctx, tenantID, []models.CreateEdgeRequest{{
+		Source: src.ID, Target: tgt.ID, Relation: "knows", Properties: map[string]any{"since": "2021"},
+	}}); err != nil {
+		t.Fatalf("BulkUpsertEdges: %v", err)
+	}
+
+	changes, hasMore, err := hs.GetEdgePropertyHistory(ctx, tenantID, src.ID, tgt.ID, "knows", "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetEdgePropertyHistory: %v", err)
+	}
+	if hasMore || len(changes) != 3 {
+		t.Fatalf("changes = %+v, want 3 (update, patch, bulk removal)", changes)
+	}
+
+	// Newest first: the bulk upsert removed "context".
+	if changes[0].PropertyKey != "context" || changes[0].NewValue != nil || changes[0].Reason == nil || *changes[0].Reason != "bulk_upsert" {
+		t.Errorf("bulk change = %+v", changes[0])
+	}
+
+	since, _, err := hs.GetEdgePropertyHistory(ctx, tenantID, src.ID, tgt.ID, "knows", "since", 10, 0)
+	if err != nil {
+		t.Fatalf("GetEdgePropertyHistory(since): %v", err)
+	}
+	if len(since) != 1 || string(since[0].OldValue) != `"2020"` || string(since[0].NewValue) != `"2021"` {
+		t.Errorf("since changes = %+v", since)
+	}
+}
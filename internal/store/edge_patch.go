@@ -27,24 +27,9 @@ func (s *EdgeStore) PatchEdgeProperties(
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	// Fetch existing properties.
-	var propsBytes []byte
-
-	err = tx.QueryRow(ctx,
-		`SELECT properties FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $1 AND target = $2 AND relation = $3`,
-		source, target, relation,
-	).Scan(&propsBytes)
+	oldProps, err := fetchEdgeProperties(ctx, tx, tenantID, source, target, relation, &s.Base)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, models.ErrEdgeNotFound
-		}
-
-		return nil, fmt.Errorf("fetching edge properties: %w", err)
-	}
-
-	oldProps, err := s.decryptPropertiesRaw(ctx, tenantID, propsBytes)
-	if err != nil {
-		return nil, fmt.Errorf("decrypting edge properties: %w", err)
+		return nil, err
 	}
 
 	merged := models.MergeProperties(oldProps, req.Properties)
@@ -74,11 +59,20 @@ func (s *EdgeStore) PatchEdgeProperties(
 		return nil, err
 	}
 
+	if err := RecordEdgePropertyChanges(ctx, tx, tenantID, source, target, relation, oldProps, merged, ""); err != nil {
+		return nil, fmt.Errorf("recording edge property history: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing patch edge properties: %w", err)
 	}
 
-	s.notify("kg_edges", "update", tenantID)
+	s.notifyChange("kg_edges", "update", tenantID, &models.ChangeDetail{
+		Source:     source,
+		Target:     target,
+		Relation:   relation,
+		Properties: changedPropertyKeys(oldProps, merged),
+	})
 
 	return e, nil
 }
@@ -111,11 +105,19 @@ func (s *EdgeStore) DeleteEdge(
 		return models.ErrEdgeNotFound
 	}
 
+	if err := pruneInferredEdges(ctx, tx, []string{source}); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("committing delete edge: %w", err)
 	}
 
-	s.notify("kg_edges", "delete", tenantID)
+	s.notifyChange("kg_edges", "delete", tenantID, &models.ChangeDetail{
+		Source:   source,
+		Target:   target,
+		Relation: relation,
+	})
 
 	return nil
 }
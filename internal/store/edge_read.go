@@ -12,7 +12,13 @@
 )
 
 // buildEdgeListQuery constructs the filtered SELECT query and arguments for ListEdges.
-func buildEdgeListQuery(source, target, relation string, limit, offset int, activeOn *time.Time, current *bool) (query string, args []any) {
+func buildEdgeListQuery(
+	source, target, relation string,
+	limit, offset int,
+	activeOn *time.Time,
+	current *bool,
+	after *models.EdgeCursor,
+) (query string, args []any) {
 	where := " WHERE tenant_id = current_setting('app.tenant_id')::uuid"
 	filterArgs := make([]any, 0, 5)
 	argIdx := 1
@@ -47,8 +53,17 @@ func buildEdgeListQuery(source, target, relation string, limit, offset int, acti
 		argIdx++
 	}
 
+	// A cursor continues after the last edge of the previous page, so offset
+	// is not needed.
+	if after != nil {
+		where += fmt.Sprintf(" AND (updated_at, source, target, relation) < ($%d, $%d, $%d, $%d)", argIdx, argIdx+1, argIdx+2, argIdx+3)
+		filterArgs = append(filterArgs, after.UpdatedAt, after.Source, after.Target, after.Relation)
+		argIdx += 4
+		offset = 0
+	}
+
 	query = "SELECT " + edgeColumns + " FROM kg_edges" + where
-	query += " ORDER BY updated_at DESC"
+	query += " ORDER BY updated_at DESC, source DESC, target DESC, relation DESC"
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
 	args = make([]any, 0, len(filterArgs)+2)
 	args = append(args, filterArgs...)
@@ -58,6 +73,7 @@ func buildEdgeListQuery(source, target, relation string, limit, offset int, acti
 }
 
 // ListEdges returns edges for a tenant with optional filters including temporal constraints.
+// A non-nil after selects the page that follows it; offset is then ignored.
 func (s *EdgeStore) ListEdges(
 	ctx context.Context,
 	tenantID string,
@@ -65,6 +81,7 @@ func (s *EdgeStore) ListEdges(
 	limit, offset int,
 	activeOn *time.Time,
 	current *bool,
+	after *models.EdgeCursor,
 ) ([]models.Edge, bool, error) {
 	if limit <= 0 {
 		limit = 50
@@ -88,7 +105,7 @@ func (s *EdgeStore) ListEdges(
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	query, args := buildEdgeListQuery(source, target, relation, limit, offset, activeOn, current)
+	query, args := buildEdgeListQuery(source, target, relation, limit, offset, activeOn, current, after)
 
 	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
@@ -127,6 +144,67 @@ func (s *EdgeStore) ListEdges(
 	return edges, hasMore, nil
 }
 
+// EdgesExist reports, in the order of keys, whether each edge exists.
+func (s *EdgeStore) EdgesExist(ctx context.Context, tenantID string, keys []models.EdgeKey) ([]models.EdgeExistence, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	sources := make([]string, len(keys))
+	targets := make([]string, len(keys))
+	relations := make([]string, len(keys))
+
+	for i, k := range keys {
+		sources[i], targets[i], relations[i] = k.Source, k.Target, k.Relation
+	}
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("checking edges exist: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, `
+		SELECT source, target, relation
+		FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND (source, target, relation) IN (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[])
+		  )
+	`, sources, targets, relations)
+	if err != nil {
+		return nil, fmt.Errorf("querying existing edges: %w", err)
+	}
+
+	defer rows.Close()
+
+	found := make(map[models.EdgeKey]struct{}, len(keys))
+	for rows.Next() {
+		var k models.EdgeKey
+		if err := rows.Scan(&k.Source, &k.Target, &k.Relation); err != nil {
+			return nil, fmt.Errorf("scanning existing edge: %w", err)
+		}
+
+		found[k] = struct{}{}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating existing edges: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing edge existence check: %w", err)
+	}
+
+	result := make([]models.EdgeExistence, len(keys))
+	for i, k := range keys {
+		_, exists := found[k]
+		result[i] = models.EdgeExistence{EdgeKey: k, Exists: exists}
+	}
+
+	return result, nil
+}
+
 // getEdge fetches a single edge within an existing transaction.
 func (s *EdgeStore) getEdge(
 	ctx context.Context,
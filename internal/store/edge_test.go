@@ -102,7 +102,7 @@ func TestListEdges(t *testing.T) {
 	}
 
 	// All edges.
-	all, _, err := es.ListEdges(ctx, tenantID, "", "", "", 50, 0, nil, nil)
+	all, _, err := es.ListEdges(ctx, tenantID, "", "", "", 50, 0, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("ListEdges all: %v", err)
 	}
@@ -111,7 +111,7 @@ func TestListEdges(t *testing.T) {
 	}
 
 	// Filter by source.
-	bySource, _, err := es.ListEdges(ctx, tenantID, a.ID, "", "", 50, 0, nil, nil)
+	bySource, _, err := es.ListEdges(ctx, tenantID, a.ID, "", "", 50, 0, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("ListEdges by source: %v", err)
 	}
@@ -120,11 +120,67 @@ func TestListEdges(t *testing.T) {
 	}
 
 	// Filter by relation.
-	byRel, _, err := es.ListEdges(ctx, tenantID, "", "", "likes", 50, 0, nil, nil)
+	byRel, _, err := es.ListEdges(ctx, tenantID, "", "", "likes", 50, 0, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("ListEdges by relation: %v", err)
 	}
 	if len(byRel) != 1 {
 		t.Errorf("ListEdges by relation = %d, want 1", len(byRel))
 	}
+
+	// Keyset pages of one edge each cover every edge once.
+	var paged []models.Edge
+	var after *models.EdgeCursor
+	for {
+		page, hasMore, err := es.ListEdges(ctx, tenantID, "", "", "", 1, 0, nil, nil, after)
+		if err != nil {
+			t.Fatalf("ListEdges after cursor: %v", err)
+		}
+		paged = append(paged, page...)
+		if !hasMore {
+			break
+		}
+		after = models.EdgeCursorAfter(&page[len(page)-1])
+	}
+	if len(paged) != 3 {
+		t.Errorf("ListEdges cursor pages = %d edges, want 3", len(paged))
+	}
+}
+
+func TestEdgesExist(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	src := createTestNode(t, ns, tenantID, "Exists Source")
+	tgt := createTestNode(t, ns, tenantID, "Exists Target")
+
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{
+		Source: src.ID, Target: tgt.ID, Relation: "related_to",
+	}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	keys := []models.EdgeKey{
+		{Source: src.ID, Target: tgt.ID, Relation: "knows"},
+		{Source: src.ID, Target: tgt.ID, Relation: "related_to"},
+		{Source: tgt.ID, Target: src.ID, Relation: "related_to"},
+	}
+
+	got, err := es.EdgesExist(ctx, tenantID, keys)
+	if err != nil {
+		t.Fatalf("EdgesExist: %v", err)
+	}
+
+	if len(got) != 3 || got[0].Exists || !got[1].Exists || got[2].Exists || got[1].EdgeKey != keys[1] {
+		t.Errorf("EdgesExist = %+v, want only the second key to exist", got)
+	}
+
+	// Another tenant sees none of them.
+	otherBase, otherTenant := setupTestBase(t)
+	other, err := store.NewEdgeStore(otherBase).EdgesExist(ctx, otherTenant, keys)
+	if err != nil || other[1].Exists {
+		t.Errorf("other tenant = %+v, %v; want nothing to exist", other, err)
+	}
 }
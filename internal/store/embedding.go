@@ -17,7 +17,8 @@ func NewEmbeddingStore(base Base) *EmbeddingStore {
 	return &EmbeddingStore{Base: base}
 }
 
-// UpdateNodeEmbedding sets the embedding vector for a single node.
+// UpdateNodeEmbedding sets the embedding vector for a single node and records
+// the model that produced it.
 func (s *EmbeddingStore) UpdateNodeEmbedding(ctx context.Context, tenantID, nodeID string, embedding []float32) error {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
@@ -30,9 +31,9 @@ func (s *EmbeddingStore) UpdateNodeEmbedding(ctx context.Context, tenantID, node
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
 	tag, err := tx.Exec(ctx,
-		`UPDATE kg_nodes SET embedding = $1::vector
+		`UPDATE kg_nodes SET embedding = $1::vector, embedding_model = NULLIF($3, '')
 		 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $2`,
-		formatEmbedding(embedding), nodeID,
+		formatEmbedding(embedding), nodeID, s.EmbeddingModel,
 	)
 	if err != nil {
 		return fmt.Errorf("executing embedding update: %w", err)
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetCachedEmbeddings returns the cached embeddings for the given keys,
+// keyed by string(key). Keys with no cached embedding are absent. Each hit
+// is marked as used so eviction keeps it.
+func (s *EmbeddingStore) GetCachedEmbeddings(ctx context.Context, keys [][]byte) (map[string][]float32, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx,
+		`UPDATE embedding_cache SET last_used_at = NOW()
+		 WHERE hash = ANY($1)
+		 RETURNING hash, embedding::text`, keys)
+	if err != nil {
+		return nil, fmt.Errorf("reading embedding cache: %w", err)
+	}
+	defer rows.Close()
+
+	cached := make(map[string][]float32, len(keys))
+
+	for rows.Next() {
+		var (
+			key       []byte
+			embedding string
+		)
+
+		if err := rows.Scan(&key, &embedding); err != nil {
+			return nil, fmt.Errorf("scanning cached embedding: %w", err)
+		}
+
+		cached[string(key)] = parseEmbedding(embedding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating cached embeddings: %w", err)
+	}
+
+	return cached, nil
+}
+
+// PutCachedEmbeddings caches embeddings[i] under keys[i]. A key that is
+// already cached keeps its embedding and is marked as used.
+func (s *EmbeddingStore) PutCachedEmbeddings(ctx context.Context, keys [][]byte, embeddings [][]float32) error {
+	if len(keys) != len(embeddings) {
+		return fmt.Errorf("caching embeddings: %d keys for %d embeddings", len(keys), len(embeddings))
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	formatted := make([]string, len(embeddings))
+	for i, embedding := range embeddings {
+		formatted[i] = formatEmbedding(embedding)
+	}
+
+	_, err := s.Pool.Exec(ctx,
+		`INSERT INTO embedding_cache (hash, embedding)
+		 SELECT hash, embedding::vector FROM unnest($1::bytea[], $2::text[]) AS t(hash, embedding)
+		 ON CONFLICT (hash) DO UPDATE SET last_used_at = NOW()`,
+		keys, formatted,
+	)
+	if err != nil {
+		return fmt.Errorf("caching embeddings: %w", err)
+	}
+
+	return nil
+}
+
+// EvictEmbeddingCache deletes the least recently used cache entries beyond
+// the newest maxEntries and returns how many it deleted.
+func (s *EmbeddingStore) EvictEmbeddingCache(ctx context.Context, maxEntries int) (int, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tag, err := s.Pool.Exec(ctx,
+		`DELETE FROM embedding_cache WHERE hash IN (
+		     SELECT hash FROM embedding_cache
+		     ORDER BY last_used_at DESC
+		     OFFSET $1
+		 )`, maxEntries)
+	if err != nil {
+		return 0, fmt.Errorf("evicting embedding cache: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
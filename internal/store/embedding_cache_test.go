@@ -0,0 +1,47 @@
+package store_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestEmbeddingCache(t *testing.T) {
+	base, _ := setupTestBase(t)
+	es := store.NewEmbeddingStore(base)
+	ctx := context.Background()
+
+	key := func() []byte {
+		sum := sha256.Sum256([]byte(uuid.New().String()))
+		return sum[:]
+	}
+	first, second, missing := key(), key(), key()
+
+	if err := es.PutCachedEmbeddings(ctx, [][]byte{first, second}, [][]float32{{1, 0}, {0, 1}}); err != nil {
+		t.Fatalf("PutCachedEmbeddings: %v", err)
+	}
+
+	// Caching a key again keeps the original embedding.
+	if err := es.PutCachedEmbeddings(ctx, [][]byte{first}, [][]float32{{5, 5}}); err != nil {
+		t.Fatalf("PutCachedEmbeddings again: %v", err)
+	}
+
+	cached, err := es.GetCachedEmbeddings(ctx, [][]byte{first, second, missing})
+	if err != nil {
+		t.Fatalf("GetCachedEmbeddings: %v", err)
+	}
+
+	if len(cached) != 2 {
+		t.Fatalf("got %d cached embeddings, want 2", len(cached))
+	}
+	if got := cached[string(first)]; len(got) != 2 || got[0] != 1 {
+		t.Errorf("first = %v, want [1 0]", got)
+	}
+	if got := cached[string(second)]; len(got) != 2 || got[1] != 1 {
+		t.Errorf("second = %v, want [0 1]", got)
+	}
+}
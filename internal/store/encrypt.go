@@ -3,8 +3,10 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/persistorai/persistor/internal/crypto"
 	"github.com/persistorai/persistor/internal/models"
 )
 
@@ -59,15 +61,38 @@ func (b *Base) decryptNode(ctx context.Context, tenantID string, n *models.Node)
 	return nil
 }
 
-// decryptNodes decrypts properties for a slice of nodes.
+// decryptNodes decrypts properties for a slice of nodes in one batch.
 func (b *Base) decryptNodes(ctx context.Context, tenantID string, nodes []models.Node) error {
+	ciphertexts := make([]string, len(nodes))
+
 	for i := range nodes {
-		if err := b.decryptNode(ctx, tenantID, &nodes[i]); err != nil {
-			return err
+		ct, ok := nodes[i].Properties["_enc"]
+		if !ok {
+			return fmt.Errorf("node %s: properties missing encryption envelope", nodes[i].ID)
+		}
+
+		if ciphertexts[i], ok = ct.(string); !ok {
+			return fmt.Errorf("node %s: encrypted value is not a string", nodes[i].ID)
 		}
 	}
 
-	return nil
+	err := b.Crypto.DecryptBatch(ctx, tenantID, ciphertexts, func(i int, plaintext []byte) error {
+		var props map[string]any
+		if err := json.Unmarshal(plaintext, &props); err != nil {
+			return fmt.Errorf("unmarshalling decrypted node %s properties: %w", nodes[i].ID, err)
+		}
+
+		nodes[i].Properties = props
+
+		return nil
+	})
+
+	var be *crypto.BatchError
+	if errors.As(err, &be) {
+		return fmt.Errorf("decrypting node %s properties: %w", nodes[be.Index].ID, be.Err)
+	}
+
+	return err
 }
 
 // decryptPropertiesRaw decrypts raw JSONB bytes containing an encryption envelope.
@@ -127,12 +152,69 @@ func (b *Base) decryptEdge(ctx context.Context, tenantID string, e *models.Edge)
 	return nil
 }
 
-// decryptEdges decrypts properties for a slice of edges.
+// decryptEdges decrypts properties for a slice of edges in one batch.
 func (b *Base) decryptEdges(ctx context.Context, tenantID string, edges []models.Edge) error {
+	ciphertexts := make([]string, len(edges))
+
 	for i := range edges {
-		if err := b.decryptEdge(ctx, tenantID, &edges[i]); err != nil {
-			return err
+		e := &edges[i]
+
+		ct, ok := e.Properties["_enc"]
+		if !ok {
+			return fmt.Errorf("edge %s→%s (%s): properties missing encryption envelope", e.Source, e.Target, e.Relation)
+		}
+
+		if ciphertexts[i], ok = ct.(string); !ok {
+			return fmt.Errorf("edge %s→%s (%s): encrypted value is not a string", e.Source, e.Target, e.Relation)
+		}
+	}
+
+	err := b.Crypto.DecryptBatch(ctx, tenantID, ciphertexts, func(i int, plaintext []byte) error {
+		e := &edges[i]
+
+		var props map[string]any
+		if err := json.Unmarshal(plaintext, &props); err != nil {
+			return fmt.Errorf("unmarshalling decrypted edge %s→%s (%s) properties: %w", e.Source, e.Target, e.Relation, err)
 		}
+
+		e.Properties = props
+
+		return nil
+	})
+
+	var be *crypto.BatchError
+	if errors.As(err, &be) {
+		e := &edges[be.Index]
+		return fmt.Errorf("decrypting edge %s→%s (%s) properties: %w", e.Source, e.Target, e.Relation, be.Err)
+	}
+
+	return err
+}
+
+// readNodeProperties decrypts nodes' properties in place or, when omit is
+// set, drops their ciphertext without decrypting it. Decryption dominates the
+// cost of large list, search, and traversal results when callers only need
+// IDs and labels.
+func (b *Base) readNodeProperties(ctx context.Context, tenantID string, nodes []models.Node, omit bool) error {
+	if !omit {
+		return b.decryptNodes(ctx, tenantID, nodes)
+	}
+
+	for i := range nodes {
+		nodes[i].Properties = nil
+	}
+
+	return nil
+}
+
+// readEdgeProperties is readNodeProperties for edges.
+func (b *Base) readEdgeProperties(ctx context.Context, tenantID string, edges []models.Edge, omit bool) error {
+	if !omit {
+		return b.decryptEdges(ctx, tenantID, edges)
+	}
+
+	for i := range edges {
+		edges[i].Properties = nil
 	}
 
 	return nil
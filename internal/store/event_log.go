@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// eventLogPruneBatch caps how many expired events one append removes, so
+// catching up after retention is lowered never stalls the change feed.
+const eventLogPruneBatch = 100
+
+// EventLogStore persists change-feed events so clients can resume from
+// further back than the hub's in-memory buffer. It implements ws.EventLog.
+type EventLogStore struct {
+	Base
+	retention time.Duration
+}
+
+// NewEventLogStore creates an EventLogStore that keeps events for retention.
+func NewEventLogStore(base Base, retention time.Duration) *EventLogStore {
+	return &EventLogStore{Base: base, retention: retention}
+}
+
+// AppendEvent stores an event. An event already logged under the same ID,
+// e.g. by another replica receiving the same notification, is kept. Expired
+// events of the tenant are removed on the way.
+func (s *EventLogStore) AppendEvent(ctx context.Context, evt models.LoggedEvent) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, evt.TenantID)
+	if err != nil {
+		return fmt.Errorf("appending event: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_event_log
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id IN (
+			SELECT id FROM kg_event_log
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND created_at < $1
+			ORDER BY created_at
+			LIMIT $2
+		)
+	`, evt.Time.Add(-s.retention), eventLogPruneBatch); err != nil {
+		return fmt.Errorf("removing expired events: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO kg_event_log (tenant_id, id, type, data, created_at)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4)
+		ON CONFLICT (tenant_id, id) DO NOTHING
+	`, int64(evt.ID), evt.Type, []byte(evt.Data), evt.Time); err != nil { //nolint:gosec // sequence IDs never exceed int64.
+		return fmt.Errorf("inserting event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing event: %w", err)
+	}
+
+	return nil
+}
+
+// EventsSince returns up to limit of the tenant's events with an ID above
+// afterID, oldest first.
+func (s *EventLogStore) EventsSince(
+	ctx context.Context, tenantID string, afterID uint64, limit int,
+) ([]models.LoggedEvent, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("reading events: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, type, data, created_at FROM kg_event_log
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id > $1
+		ORDER BY id
+		LIMIT $2
+	`, int64(afterID), limit) //nolint:gosec // sequence IDs never exceed int64.
+	if err != nil {
+		return nil, fmt.Errorf("querying events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.LoggedEvent
+
+	for rows.Next() {
+		evt := models.LoggedEvent{TenantID: tenantID}
+
+		var id int64
+		if err := rows.Scan(&id, &evt.Type, &evt.Data, &evt.Time); err != nil {
+			return nil, fmt.Errorf("scanning event: %w", err)
+		}
+
+		evt.ID = uint64(id) //nolint:gosec // IDs are stored from uint64 sequence values.
+		events = append(events, evt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating events: %w", err)
+	}
+
+	return events, nil
+}
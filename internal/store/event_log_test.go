@@ -0,0 +1,51 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestEventLog(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewEventLogStore(base, time.Hour)
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, at := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Minute), now} {
+		id := uint64(i + 1)
+		evt := models.LoggedEvent{TenantID: tenantID, ID: id, Type: "kg.change", Data: json.RawMessage(`{"op":"insert"}`), Time: at}
+		if err := s.AppendEvent(ctx, evt); err != nil {
+			t.Fatalf("AppendEvent %d: %v", id, err)
+		}
+	}
+
+	// A duplicate from another replica is ignored.
+	dup := models.LoggedEvent{TenantID: tenantID, ID: 3, Type: "kg.change", Data: json.RawMessage(`{}`), Time: now}
+	if err := s.AppendEvent(ctx, dup); err != nil {
+		t.Fatalf("AppendEvent duplicate: %v", err)
+	}
+
+	// Event 1 was past retention when event 3 arrived.
+	events, err := s.EventsSince(ctx, tenantID, 0, 10)
+	if err != nil {
+		t.Fatalf("EventsSince: %v", err)
+	}
+
+	if len(events) != 2 || events[0].ID != 2 || events[1].ID != 3 {
+		t.Fatalf("events = %+v, want 2 and 3", events)
+	}
+
+	var data map[string]string
+	if err := json.Unmarshal(events[1].Data, &data); err != nil || data["op"] != "insert" {
+		t.Errorf("event 3 data = %s", events[1].Data)
+	}
+
+	if events, err := s.EventsSince(ctx, tenantID, 2, 10); err != nil || len(events) != 1 {
+		t.Errorf("EventsSince(2) = %+v, %v", events, err)
+	}
+}
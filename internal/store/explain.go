@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ExplainStore runs EXPLAIN on the store's own queries so operators can
+// diagnose slow plans without database access.
+type ExplainStore struct {
+	Base
+}
+
+// NewExplainStore creates a new ExplainStore.
+func NewExplainStore(base Base) *ExplainStore {
+	return &ExplainStore{Base: base}
+}
+
+// ExplainQuery runs EXPLAIN (ANALYZE, BUFFERS) on the named query with the
+// given parameters against tenantID's data. embedding is the query vector
+// for semantic and hybrid. The statement executes inside a read-only
+// transaction that is always rolled back.
+func (s *ExplainStore) ExplainQuery(
+	ctx context.Context,
+	tenantID string,
+	req models.ExplainRequest,
+	embedding []float32,
+) (*models.ExplainResult, error) {
+	search := &SearchStore{Base: s.Base}
+	p := req.Params
+	filter := models.SearchFilter{Type: p.Type, MinSalience: p.MinSalience}
+
+	var (
+		sql  string
+		args []any
+		err  error
+	)
+
+	switch req.Query {
+	case models.ExplainFullText:
+		// Full-text search returns superseded nodes unless asked not to.
+		filter.IncludeSuperseded = true
+		sql, args, err = search.fullTextSearchQuery(p.Q, filter, nil, p.Limit)
+	case models.ExplainSemantic:
+		sql, args, err = search.semanticSearchQuery(embedding, filter, nil, p.Limit)
+	case models.ExplainHybrid:
+		sql, args, err = search.hybridSearchQuery(p.Q, embedding, filter, nil, p.Limit)
+	case models.ExplainTraverse:
+		sql, args = bfsNeighborSQL, []any{p.NodeID}
+	default:
+		return nil, fmt.Errorf("unknown query %q", req.Query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("explaining query: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only; never committed.
+
+	var raw []byte
+	if err := tx.QueryRow(ctx, "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) "+sql, args...).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("running explain: %w", err)
+	}
+
+	var plans []struct {
+		Plan          json.RawMessage `json:"Plan"`
+		PlanningTime  float64         `json:"Planning Time"`
+		ExecutionTime float64         `json:"Execution Time"`
+	}
+	if err := json.Unmarshal(raw, &plans); err != nil {
+		return nil, fmt.Errorf("parsing explain output: %w", err)
+	}
+
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("explain returned no plan")
+	}
+
+	return &models.ExplainResult{
+		Query:           req.Query,
+		PlanningTimeMs:  plans[0].PlanningTime,
+		ExecutionTimeMs: plans[0].ExecutionTime,
+		Plan:            plans[0].Plan,
+	}, nil
+}
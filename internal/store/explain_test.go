@@ -0,0 +1,37 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestExplainQuery(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewExplainStore(base)
+	ctx := context.Background()
+
+	req := models.CreateNodeRequest{Type: "concept", Label: "Quantum photosynthesis research"}
+	_ = req.Validate()
+	node, err := ns.CreateNode(ctx, tenantID, req)
+	if err != nil {
+		t.Fatalf("CreateNode: %v", err)
+	}
+
+	for _, explain := range []models.ExplainRequest{
+		{Query: models.ExplainFullText, Params: models.ExplainParams{Q: "quantum", Limit: 10}},
+		{Query: models.ExplainTraverse, Params: models.ExplainParams{NodeID: node.ID, Limit: 10}},
+	} {
+		result, err := es.ExplainQuery(ctx, tenantID, explain, nil)
+		if err != nil {
+			t.Fatalf("ExplainQuery(%s): %v", explain.Query, err)
+		}
+
+		if result.Query != explain.Query || len(result.Plan) == 0 {
+			t.Errorf("ExplainQuery(%s) = %+v, want a plan", explain.Query, result)
+		}
+	}
+}
@@ -42,30 +42,68 @@ func NewExportStore(base Base) *ExportStore {
 	return &ExportStore{Base: base}
 }
 
+// exportNodeColumns is the column list shared by full and paged node exports.
+const exportNodeColumns = `
+		SELECT id, type, label, properties,
+		       embedding, access_count, last_accessed,
+		       salience_score, user_boosted, superseded_by,
+		       created_at, updated_at
+		FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid`
+
+// exportEdgeColumns is the column list shared by full and paged edge exports.
+const exportEdgeColumns = `
+		SELECT source, target, relation, properties,
+		       weight, access_count, last_accessed,
+		       created_at, updated_at
+		FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid`
+
 // ExportAllNodes reads all nodes for a tenant with full fidelity.
 // Properties are decrypted before returning for portable export.
 // Embeddings and access metrics are included for backup/restore.
 // Returns nodes sorted by created_at, id for deterministic exports.
 func (s *ExportStore) ExportAllNodes(ctx context.Context, tenantID string) ([]models.ExportNode, error) {
+	return s.queryExportNodes(ctx, tenantID, exportNodeColumns+`
+		ORDER BY created_at, id
+	`)
+}
+
+// ExportNodesPage reads up to limit nodes with id greater than afterID,
+// ordered by id. An empty afterID starts from the first node.
+func (s *ExportStore) ExportNodesPage(ctx context.Context, tenantID, afterID string, limit int) ([]models.ExportNode, error) {
+	return s.queryExportNodes(ctx, tenantID, exportNodeColumns+`
+		  AND id > $1
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+}
+
+// ExportNodesByID reads the nodes among ids that exist, ordered by id.
+func (s *ExportStore) ExportNodesByID(ctx context.Context, tenantID string, ids []string) ([]models.ExportNode, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return s.queryExportNodes(ctx, tenantID, exportNodeColumns+`
+		  AND id = ANY($1)
+		ORDER BY id
+	`, ids)
+}
+
+// queryExportNodes runs a node export query and decrypts each row's properties.
+func (s *ExportStore) queryExportNodes(ctx context.Context, tenantID, query string, args ...any) ([]models.ExportNode, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("export nodes: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	rows, err := tx.Query(ctx, `
-		SELECT id, type, label, properties,
-		       embedding, access_count, last_accessed,
-		       salience_score, user_boosted, superseded_by,
-		       created_at, updated_at
-		FROM kg_nodes
-		WHERE tenant_id = current_setting('app.tenant_id')::uuid
-		ORDER BY created_at, id
-	`)
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("querying nodes for export: %w", err)
 	}
@@ -116,24 +154,61 @@ func (s *ExportStore) ExportAllNodes(ctx context.Context, tenantID string) ([]mo
 // Properties are decrypted before returning for portable export.
 // Returns edges sorted by (source, target, relation) for deterministic exports.
 func (s *ExportStore) ExportAllEdges(ctx context.Context, tenantID string) ([]models.ExportEdge, error) {
+	return s.queryExportEdges(ctx, tenantID, exportEdgeColumns+`
+		ORDER BY source, target, relation
+	`)
+}
+
+// ExportEdgesPage reads up to limit edges that sort after the given
+// (source, target, relation) key. An empty key starts from the first edge.
+func (s *ExportStore) ExportEdgesPage(
+	ctx context.Context,
+	tenantID, afterSource, afterTarget, afterRelation string,
+	limit int,
+) ([]models.ExportEdge, error) {
+	return s.queryExportEdges(ctx, tenantID, exportEdgeColumns+`
+		  AND (source, target, relation) > ($1, $2, $3)
+		ORDER BY source, target, relation
+		LIMIT $4
+	`, afterSource, afterTarget, afterRelation, limit)
+}
+
+// ExportEdgesByKey reads the edges among keys that exist, matched on
+// (source, target, relation) and ordered by that key.
+func (s *ExportStore) ExportEdgesByKey(ctx context.Context, tenantID string, keys []models.ExportEdge) ([]models.ExportEdge, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	sources := make([]string, len(keys))
+	targets := make([]string, len(keys))
+	relations := make([]string, len(keys))
+
+	for i, k := range keys {
+		sources[i], targets[i], relations[i] = k.Source, k.Target, k.Relation
+	}
+
+	return s.queryExportEdges(ctx, tenantID, exportEdgeColumns+`
+		  AND (source, target, relation) IN (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[])
+		  )
+		ORDER BY source, target, relation
+	`, sources, targets, relations)
+}
+
+// queryExportEdges runs an edge export query and decrypts each row's properties.
+func (s *ExportStore) queryExportEdges(ctx context.Context, tenantID, query string, args ...any) ([]models.ExportEdge, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("export edges: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	rows, err := tx.Query(ctx, `
-		SELECT source, target, relation, properties,
-		       weight, access_count, last_accessed,
-		       created_at, updated_at
-		FROM kg_edges
-		WHERE tenant_id = current_setting('app.tenant_id')::uuid
-		ORDER BY source, target, relation
-	`)
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("querying edges for export: %w", err)
 	}
@@ -174,6 +249,34 @@ func (s *ExportStore) ExportAllEdges(ctx context.Context, tenantID string) ([]mo
 	return edges, nil
 }
 
+// CountForExport returns the number of nodes and edges a tenant export will contain.
+func (s *ExportStore) CountForExport(ctx context.Context, tenantID string) (nodes, edges int, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReplicaTx(ctx, tenantID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count for export: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	err = tx.QueryRow(ctx, `
+		SELECT
+			(SELECT count(*) FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid),
+			(SELECT count(*) FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid)
+	`).Scan(&nodes, &edges)
+	if err != nil {
+		return 0, 0, fmt.Errorf("counting nodes and edges for export: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("committing export count: %w", err)
+	}
+
+	return nodes, edges, nil
+}
+
 // ExistingNodeIDs returns the subset of ids that already exist for a tenant.
 func (s *ExportStore) ExistingNodeIDs(ctx context.Context, tenantID string, ids []string) (map[string]struct{}, error) {
 	if len(ids) == 0 {
@@ -237,3 +237,41 @@ func TestExistingNodeIDs_ReturnsOnlyMatches(t *testing.T) {
 		t.Fatal("did not expect missing id to be present")
 	}
 }
+
+func TestExportByKey_ReturnsOnlyExisting(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	es := store.NewExportStore(base)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for _, id := range []string{"a", "b"} {
+		node := models.ExportNode{ID: id, Type: "t", Label: id, Properties: map[string]any{"k": id}, CreatedAt: now, UpdatedAt: now}
+		if _, err := es.UpsertNodeFromExport(ctx, tenantID, node, false); err != nil {
+			t.Fatalf("UpsertNodeFromExport(%s): %v", id, err)
+		}
+	}
+
+	edge := models.ExportEdge{Source: "a", Target: "b", Relation: "knows", Properties: map[string]any{}, Weight: 1, CreatedAt: now, UpdatedAt: now}
+	if _, err := es.UpsertEdgeFromExport(ctx, tenantID, edge, false); err != nil {
+		t.Fatalf("UpsertEdgeFromExport: %v", err)
+	}
+
+	nodes, err := es.ExportNodesByID(ctx, tenantID, []string{"b", "missing"})
+	if err != nil {
+		t.Fatalf("ExportNodesByID: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "b" || nodes[0].Properties["k"] != "b" {
+		t.Errorf("nodes = %+v, want decrypted node b", nodes)
+	}
+
+	edges, err := es.ExportEdgesByKey(ctx, tenantID, []models.ExportEdge{
+		{Source: "a", Target: "b", Relation: "knows"},
+		{Source: "a", Target: "b", Relation: "likes"},
+	})
+	if err != nil {
+		t.Fatalf("ExportEdgesByKey: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Relation != "knows" {
+		t.Errorf("edges = %+v, want a→b knows", edges)
+	}
+}
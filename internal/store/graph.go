@@ -4,6 +4,7 @@
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -28,7 +29,9 @@ func NewGraphStore(base Base) *GraphStore {
 }
 
 // Neighbors returns all nodes directly connected to nodeID and the edges between them.
-func (s *GraphStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int) (*models.NeighborResult, error) { //nolint:gocognit,gocyclo,cyclop,funlen // existence check adds necessary complexity.
+func (s *GraphStore) Neighbors(ctx context.Context, tenantID, nodeID string, limit int, opts models.ReadOpts) (*models.NeighborResult, error) { //nolint:gocognit,gocyclo,cyclop,funlen // existence check adds necessary complexity.
+	defer observeOperation("Neighbors", time.Now())
+
 	if limit <= 0 {
 		limit = defaultEdgesPerQuery
 	}
@@ -40,7 +43,7 @@ func (s *GraphStore) Neighbors(ctx context.Context, tenantID, nodeID string, lim
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("getting neighbors: %w", err)
 	}
@@ -119,11 +122,11 @@ func (s *GraphStore) Neighbors(ctx context.Context, tenantID, nodeID string, lim
 		}
 	}
 
-	if err := s.decryptNodes(ctx, tenantID, nodeList); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodeList, opts.OmitProperties); err != nil {
 		return nil, err
 	}
 
-	if err := s.decryptEdges(ctx, tenantID, edgeList); err != nil {
+	if err := s.readEdgeProperties(ctx, tenantID, edgeList, opts.OmitProperties); err != nil {
 		return nil, err
 	}
 
@@ -143,7 +146,7 @@ func (s *GraphStore) GraphContext( //nolint:gocognit,gocyclo,cyclop,funlen // in
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("getting graph context: %w", err)
 	}
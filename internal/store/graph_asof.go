@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GraphAsOf reconstructs a page of the graph as it was at q.Timestamp. Nodes
+// created after the timestamp are excluded, and each node is rolled back by
+// undoing, newest first, every kg_property_history change recorded after it,
+// including label, type, and salience changes. q.Type matches the type a
+// node had at the timestamp. Edges are those among the page's nodes that
+// existed at the timestamp.
+func (s *GraphStore) GraphAsOf(ctx context.Context, tenantID string, q models.AsOfQuery) (*models.GraphSnapshot, error) {
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	if q.Limit > maxListLimit {
+		q.Limit = maxListLimit
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("reconstructing graph as of %s: %w", q.Timestamp, err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	nodes, ids, hasMore, err := asOfNodePage(ctx, tx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := asOfEdges(ctx, tx, ids, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+		return nil, err
+	}
+
+	if err := s.decryptEdges(ctx, tenantID, edges); err != nil {
+		return nil, err
+	}
+
+	if err := rollbackNodeChanges(ctx, tx, nodes, ids, q); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing as-of query: %w", err)
+	}
+
+	return &models.GraphSnapshot{AsOf: q.Timestamp, Nodes: nodes, Edges: edges, HasMore: hasMore}, nil
+}
+
+// asOfNodePage returns the page of nodes that existed at q.Timestamp, before
+// rollback, with their IDs and whether more follow.
+func asOfNodePage(ctx context.Context, tx pgx.Tx, q models.AsOfQuery) ([]models.Node, []string, bool, error) {
+	// A node's type at the timestamp is the old value of its first type
+	// change after it, or its current type when it has none.
+	rows, err := tx.Query(ctx, `SELECT `+nodeColumns+` FROM kg_nodes n
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid
+			AND n.created_at <= $1
+			AND ($2 = '' OR COALESCE((
+				SELECT h.old_value #>> '{}' FROM kg_property_history h
+				WHERE h.tenant_id = n.tenant_id AND h.node_id = n.id
+					AND h.field = 'type' AND h.changed_at > $1
+				ORDER BY h.changed_at, h.id LIMIT 1
+			), n.type) = $2)
+		ORDER BY n.id LIMIT $3 OFFSET $4`,
+		q.Timestamp, q.Type, q.Limit+1, q.Offset,
+	)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("querying as-of nodes: %w", err)
+	}
+	defer rows.Close()
+
+	nodes, err := collectNodes(rows)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("collecting as-of nodes: %w", err)
+	}
+
+	hasMore := len(nodes) > q.Limit
+	if hasMore {
+		nodes = nodes[:q.Limit]
+	}
+
+	ids := make([]string, len(nodes))
+	for i := range nodes {
+		ids[i] = nodes[i].ID
+	}
+
+	return nodes, ids, hasMore, nil
+}
+
+// asOfEdges returns the edges among ids that existed at q.Timestamp.
+func asOfEdges(ctx context.Context, tx pgx.Tx, ids []string, q models.AsOfQuery) ([]models.Edge, error) {
+	rows, err := tx.Query(ctx, `SELECT `+edgeColumns+` FROM kg_edges
+		WHERE source = ANY($1) AND target = ANY($1)
+			AND created_at <= $2
+			AND tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY source, target, relation LIMIT `+fmt.Sprintf("%d", traverseEdgeLimit),
+		ids, q.Timestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying as-of edges: %w", err)
+	}
+	defer rows.Close()
+
+	edges, err := collectEdges(rows)
+	if err != nil {
+		return nil, fmt.Errorf("collecting as-of edges: %w", err)
+	}
+
+	return edges, nil
+}
+
+// rollbackNodeChanges undoes, newest first, every property, label, type, and
+// salience change recorded after q.Timestamp for the given nodes.
+func rollbackNodeChanges(ctx context.Context, tx pgx.Tx, nodes []models.Node, ids []string, q models.AsOfQuery) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx, `SELECT node_id, field, property_key, old_value
+		FROM kg_property_history
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND node_id = ANY($1)
+			AND changed_at > $2
+		ORDER BY changed_at DESC, id DESC`,
+		ids, q.Timestamp,
+	)
+	if err != nil {
+		return fmt.Errorf("querying property history for as-of: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*models.Node, len(nodes))
+	for i := range nodes {
+		byID[nodes[i].ID] = &nodes[i]
+	}
+
+	for rows.Next() {
+		var nodeID, field, key string
+		var oldValue json.RawMessage
+
+		if err := rows.Scan(&nodeID, &field, &key, &oldValue); err != nil {
+			return fmt.Errorf("scanning property history for as-of: %w", err)
+		}
+
+		if err := undoNodeChange(byID[nodeID], field, key, oldValue); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating property history for as-of: %w", err)
+	}
+
+	return nil
+}
+
+// undoNodeChange undoes one history row on n: a change to property key, or
+// to the node field.
+func undoNodeChange(n *models.Node, field, key string, oldValue json.RawMessage) error {
+	if field != models.HistoryFieldProperty {
+		if err := undoFieldChange(n, field, oldValue); err != nil {
+			return fmt.Errorf("rolling back %s %s: %w", n.ID, field, err)
+		}
+
+		return nil
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]any)
+	}
+
+	if err := undoPropertyChange(n.Properties, key, oldValue); err != nil {
+		return fmt.Errorf("rolling back %s.%s: %w", n.ID, key, err)
+	}
+
+	return nil
+}
+
+// undoPropertyChange restores key to oldValue. A SQL NULL old value means the
+// change added the key, so undoing it removes the key.
+func undoPropertyChange(props map[string]any, key string, oldValue json.RawMessage) error {
+	if oldValue == nil {
+		delete(props, key)
+
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(oldValue, &v); err != nil {
+		return fmt.Errorf("decoding old value: %w", err)
+	}
+
+	props[key] = v
+
+	return nil
+}
+
+// undoFieldChange restores a node's label, type, or salience to oldValue.
+func undoFieldChange(n *models.Node, field string, oldValue json.RawMessage) error {
+	var dest any
+
+	switch field {
+	case models.HistoryFieldLabel:
+		dest = &n.Label
+	case models.HistoryFieldType:
+		dest = &n.Type
+	case models.HistoryFieldSalience:
+		dest = &n.Salience
+	default:
+		return nil
+	}
+
+	if err := json.Unmarshal(oldValue, dest); err != nil {
+		return fmt.Errorf("decoding old value: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/storage"
+)
+
+// maxCommunityEdges caps the edges loaded for a single community detection run.
+const maxCommunityEdges = 100000
+
+// LoadCommunityGraph loads non-superseded nodes that participate in at least one
+// edge, plus those edges, optionally restricted to a single relation.
+// Properties are never read, so no decryption is required.
+func (s *GraphStore) LoadCommunityGraph(ctx context.Context, tenantID, relation string) (*storage.CommunityGraph, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("loading community graph: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	edgeRows, err := tx.Query(ctx,
+		`SELECT e.source, e.target, e.weight FROM kg_edges e
+		WHERE e.tenant_id = current_setting('app.tenant_id')::uuid
+			AND e.source <> e.target
+			AND ($1 = '' OR e.relation = $1)
+		ORDER BY e.source, e.target, e.relation
+		LIMIT $2`,
+		relation, maxCommunityEdges+1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying community edges: %w", err)
+	}
+	defer edgeRows.Close()
+
+	graph := &storage.CommunityGraph{Edges: make([]storage.CommunityEdge, 0, 256)}
+	ids := make(map[string]bool)
+
+	for edgeRows.Next() {
+		var e storage.CommunityEdge
+		if err := edgeRows.Scan(&e.Source, &e.Target, &e.Weight); err != nil {
+			return nil, fmt.Errorf("scanning community edge: %w", err)
+		}
+
+		if len(graph.Edges) == maxCommunityEdges {
+			graph.Truncated = true
+
+			break
+		}
+
+		graph.Edges = append(graph.Edges, e)
+		ids[e.Source] = true
+		ids[e.Target] = true
+	}
+
+	if err := edgeRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating community edges: %w", err)
+	}
+
+	edgeRows.Close()
+
+	nodeIDs := make([]string, 0, len(ids))
+	for id := range ids {
+		nodeIDs = append(nodeIDs, id)
+	}
+
+	nodeRows, err := tx.Query(ctx,
+		`SELECT id, label, salience_score FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND id = ANY($1) AND superseded_by IS NULL
+		ORDER BY id`,
+		nodeIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying community nodes: %w", err)
+	}
+	defer nodeRows.Close()
+
+	graph.Nodes = make([]storage.CommunityNode, 0, len(nodeIDs))
+
+	for nodeRows.Next() {
+		var n storage.CommunityNode
+		if err := nodeRows.Scan(&n.ID, &n.Label, &n.Salience); err != nil {
+			return nil, fmt.Errorf("scanning community node: %w", err)
+		}
+
+		graph.Nodes = append(graph.Nodes, n)
+	}
+
+	if err := nodeRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating community nodes: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing community graph: %w", err)
+	}
+
+	return graph, nil
+}
@@ -16,7 +16,7 @@ func (s *GraphStore) ShortestPath( //nolint:gocognit,gocyclo,cyclop,funlen // BF
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("finding shortest path: %w", err)
 	}
@@ -131,7 +131,7 @@ func (s *GraphStore) fetchPathNodes(ctx context.Context, tenantID string, ids []
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching path nodes: %w", err)
 	}
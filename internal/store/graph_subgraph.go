@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Subgraph returns the induced subgraph over the requested node IDs: the nodes
+// that exist plus every edge whose endpoints are both in the set. When Expand
+// is set, direct neighbors of the seeds are added to the set first.
+// Unknown IDs are silently dropped.
+func (s *GraphStore) Subgraph(ctx context.Context, tenantID string, req models.SubgraphRequest) (*models.SubgraphResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReplicaTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("extracting subgraph: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	ids := req.NodeIDs
+	if req.Expand {
+		if ids, err = expandSubgraphIDs(ctx, tx, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeSQL := `SELECT ` + nodeColumns + ` FROM kg_nodes
+		WHERE id = ANY($1) AND tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY id LIMIT ` + fmt.Sprintf("%d", traverseNodeLimit)
+
+	nodeRows, err := tx.Query(ctx, nodeSQL, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying subgraph nodes: %w", err)
+	}
+	defer nodeRows.Close()
+
+	nodes, err := collectNodes(nodeRows)
+	if err != nil {
+		return nil, fmt.Errorf("collecting subgraph nodes: %w", err)
+	}
+
+	// Restrict edges to nodes that actually exist so the result is self-consistent.
+	found := make([]string, len(nodes))
+	for i := range nodes {
+		found[i] = nodes[i].ID
+	}
+
+	edgeSQL := `SELECT ` + edgeColumns + `
+		FROM kg_edges
+		WHERE source = ANY($1) AND target = ANY($1)
+			AND tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY source, target, relation LIMIT ` + fmt.Sprintf("%d", traverseEdgeLimit)
+
+	edgeRows, err := tx.Query(ctx, edgeSQL, found)
+	if err != nil {
+		return nil, fmt.Errorf("querying subgraph edges: %w", err)
+	}
+	defer edgeRows.Close()
+
+	edges, err := collectEdges(edgeRows)
+	if err != nil {
+		return nil, fmt.Errorf("collecting subgraph edges: %w", err)
+	}
+
+	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+		return nil, err
+	}
+
+	if err := s.decryptEdges(ctx, tenantID, edges); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing subgraph: %w", err)
+	}
+
+	return &models.SubgraphResult{Nodes: nodes, Edges: edges}, nil
+}
+
+// expandSubgraphIDs adds the direct neighbors of seeds, capped at traverseNodeLimit in total.
+func expandSubgraphIDs(ctx context.Context, tx pgx.Tx, seeds []string) ([]string, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT target FROM kg_edges WHERE source = ANY($1) AND tenant_id = current_setting('app.tenant_id')::uuid
+		UNION
+		SELECT source FROM kg_edges WHERE target = ANY($1) AND tenant_id = current_setting('app.tenant_id')::uuid
+		LIMIT $2`,
+		seeds, traverseNodeLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying subgraph expansion: %w", err)
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool, len(seeds))
+	ids := make([]string, 0, len(seeds))
+
+	for _, id := range seeds {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning subgraph expansion: %w", err)
+		}
+
+		if !seen[id] && len(ids) < traverseNodeLimit {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating subgraph expansion: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Summary limits.
+const (
+	defaultSummaryNeighbors = 10
+	maxSummaryNeighbors     = 50
+	summaryRecentChanges    = 10
+)
+
+// Summary builds a compact neighborhood summary for nodeID entirely in SQL:
+// edge counts by relation and direction, the most salient neighbors, and the
+// most recent property changes. Property values are never decrypted.
+func (s *GraphStore) Summary(ctx context.Context, tenantID, nodeID string, limit int) (*models.GraphSummary, error) {
+	if limit <= 0 {
+		limit = defaultSummaryNeighbors
+	}
+
+	if limit > maxSummaryNeighbors {
+		limit = maxSummaryNeighbors
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("summarizing node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	summary := &models.GraphSummary{}
+
+	err = tx.QueryRow(ctx,
+		`SELECT id, type, label, salience_score, updated_at FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
+		nodeID,
+	).Scan(&summary.Node.ID, &summary.Node.Type, &summary.Node.Label, &summary.Salience, &summary.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNodeNotFound
+		}
+
+		return nil, fmt.Errorf("querying summary node: %w", err)
+	}
+
+	if summary.Relations, err = summaryRelationCounts(ctx, tx, nodeID); err != nil {
+		return nil, err
+	}
+
+	for _, rc := range summary.Relations {
+		summary.Degree += rc.Count
+	}
+
+	if summary.TopNeighbors, err = summaryTopNeighbors(ctx, tx, nodeID, limit); err != nil {
+		return nil, err
+	}
+
+	if summary.RecentChanges, err = summaryRecentChangeList(ctx, tx, nodeID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing summary: %w", err)
+	}
+
+	return summary, nil
+}
+
+func summaryRelationCounts(ctx context.Context, tx pgx.Tx, nodeID string) ([]models.RelationCount, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT relation, direction, count(*) FROM (
+			SELECT relation, 'out' AS direction FROM kg_edges
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $1
+			UNION ALL
+			SELECT relation, 'in' AS direction FROM kg_edges
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND target = $1
+		) e
+		GROUP BY relation, direction
+		ORDER BY count(*) DESC, relation, direction`,
+		nodeID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying summary relation counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]models.RelationCount, 0, 8)
+
+	for rows.Next() {
+		var rc models.RelationCount
+		if err := rows.Scan(&rc.Relation, &rc.Direction, &rc.Count); err != nil {
+			return nil, fmt.Errorf("scanning summary relation count: %w", err)
+		}
+
+		counts = append(counts, rc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating summary relation counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func summaryTopNeighbors(ctx context.Context, tx pgx.Tx, nodeID string, limit int) ([]models.SummaryNeighbor, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id, type, label, relation, direction, salience_score FROM (
+			SELECT DISTINCT ON (n.id) n.id, n.type, n.label, e.relation, e.direction, n.salience_score
+			FROM (
+				SELECT target AS neighbor, relation, 'out' AS direction, salience_score FROM kg_edges
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $1
+				UNION ALL
+				SELECT source AS neighbor, relation, 'in' AS direction, salience_score FROM kg_edges
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid AND target = $1
+			) e
+			JOIN kg_nodes n ON n.tenant_id = current_setting('app.tenant_id')::uuid AND n.id = e.neighbor
+			WHERE n.id <> $1
+			ORDER BY n.id, e.salience_score DESC
+		) ranked
+		ORDER BY salience_score DESC, id
+		LIMIT $2`,
+		nodeID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying summary neighbors: %w", err)
+	}
+	defer rows.Close()
+
+	neighbors := make([]models.SummaryNeighbor, 0, limit)
+
+	for rows.Next() {
+		var sn models.SummaryNeighbor
+		if err := rows.Scan(&sn.ID, &sn.Type, &sn.Label, &sn.Relation, &sn.Direction, &sn.Salience); err != nil {
+			return nil, fmt.Errorf("scanning summary neighbor: %w", err)
+		}
+
+		neighbors = append(neighbors, sn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating summary neighbors: %w", err)
+	}
+
+	return neighbors, nil
+}
+
+func summaryRecentChangeList(ctx context.Context, tx pgx.Tx, nodeID string) ([]models.SummaryChange, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT property_key, changed_at FROM kg_property_history
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = $1
+		ORDER BY changed_at DESC
+		LIMIT $2`,
+		nodeID, summaryRecentChanges,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying summary changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make([]models.SummaryChange, 0, summaryRecentChanges)
+
+	for rows.Next() {
+		var sc models.SummaryChange
+		if err := rows.Scan(&sc.PropertyKey, &sc.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scanning summary change: %w", err)
+		}
+
+		changes = append(changes, sc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating summary changes: %w", err)
+	}
+
+	return changes, nil
+}
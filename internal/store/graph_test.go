@@ -4,6 +4,7 @@
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/persistorai/persistor/internal/models"
 	"github.com/persistorai/persistor/internal/store"
@@ -29,7 +30,7 @@ func TestNeighbors(t *testing.T) {
 		}
 	}
 
-	result, err := gs.Neighbors(ctx, tenantID, center.ID, 100)
+	result, err := gs.Neighbors(ctx, tenantID, center.ID, 100, models.ReadOpts{})
 	if err != nil {
 		t.Fatalf("Neighbors: %v", err)
 	}
@@ -66,7 +67,7 @@ func TestTraverse(t *testing.T) {
 	}
 
 	// Depth 1 from A should find A and B.
-	r1, err := gs.Traverse(ctx, tenantID, a.ID, 1)
+	r1, err := gs.Traverse(ctx, tenantID, a.ID, 1, models.ReadOpts{})
 	if err != nil {
 		t.Fatalf("Traverse depth 1: %v", err)
 	}
@@ -75,7 +76,7 @@ func TestTraverse(t *testing.T) {
 	}
 
 	// Depth 2 from A should find A, B, and C.
-	r2, err := gs.Traverse(ctx, tenantID, a.ID, 2)
+	r2, err := gs.Traverse(ctx, tenantID, a.ID, 2, models.ReadOpts{})
 	if err != nil {
 		t.Fatalf("Traverse depth 2: %v", err)
 	}
@@ -106,7 +107,7 @@ func TestTraverseNodeLimitKeepsEdgesConsistent(t *testing.T) {
 		}
 	}
 
-	result, err := gs.Traverse(ctx, tenantID, root.ID, 1)
+	result, err := gs.Traverse(ctx, tenantID, root.ID, 1, models.ReadOpts{})
 	if err != nil {
 		t.Fatalf("Traverse depth 1: %v", err)
 	}
@@ -222,3 +223,132 @@ func TestGraphContext(t *testing.T) {
 		t.Errorf("GraphContext edges = %d, want 1", len(result.Edges))
 	}
 }
+
+func TestGraphSummary(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	gs := store.NewGraphStore(base)
+	ctx := context.Background()
+
+	center := createTestNode(t, ns, tenantID, "Summary Center")
+	friend := createTestNode(t, ns, tenantID, "Summary Friend")
+	employer := createTestNode(t, ns, tenantID, "Summary Employer")
+
+	for _, e := range []models.CreateEdgeRequest{
+		{Source: center.ID, Target: friend.ID, Relation: "knows"},
+		{Source: center.ID, Target: employer.ID, Relation: "works_at"},
+		{Source: friend.ID, Target: center.ID, Relation: "knows"},
+	} {
+		if _, err := es.CreateEdge(ctx, tenantID, e); err != nil {
+			t.Fatalf("CreateEdge: %v", err)
+		}
+	}
+
+	summary, err := gs.Summary(ctx, tenantID, center.ID, 10)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+
+	if summary.Node.ID != center.ID {
+		t.Errorf("Summary node = %q, want %q", summary.Node.ID, center.ID)
+	}
+	if summary.Degree != 3 {
+		t.Errorf("Summary degree = %d, want 3", summary.Degree)
+	}
+	if len(summary.Relations) != 3 {
+		t.Errorf("Summary relations = %d, want 3", len(summary.Relations))
+	}
+	if len(summary.TopNeighbors) != 2 {
+		t.Errorf("Summary top neighbors = %d, want 2", len(summary.TopNeighbors))
+	}
+
+	if _, err := gs.Summary(ctx, tenantID, "missing-node", 10); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Fatalf("Summary missing err = %v, want ErrNodeNotFound", err)
+	}
+}
+
+func TestSubgraph(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	gs := store.NewGraphStore(base)
+	ctx := context.Background()
+
+	// a → b → c, plus d → a outside the seed set.
+	a := createTestNode(t, ns, tenantID, "Subgraph A")
+	b := createTestNode(t, ns, tenantID, "Subgraph B")
+	c := createTestNode(t, ns, tenantID, "Subgraph C")
+	d := createTestNode(t, ns, tenantID, "Subgraph D")
+
+	for _, e := range []models.CreateEdgeRequest{
+		{Source: a.ID, Target: b.ID, Relation: "next"},
+		{Source: b.ID, Target: c.ID, Relation: "next"},
+		{Source: d.ID, Target: a.ID, Relation: "next"},
+	} {
+		if _, err := es.CreateEdge(ctx, tenantID, e); err != nil {
+			t.Fatalf("CreateEdge: %v", err)
+		}
+	}
+
+	induced, err := gs.Subgraph(ctx, tenantID, models.SubgraphRequest{NodeIDs: []string{a.ID, b.ID, "missing-node"}})
+	if err != nil {
+		t.Fatalf("Subgraph: %v", err)
+	}
+	if len(induced.Nodes) != 2 || len(induced.Edges) != 1 {
+		t.Errorf("Subgraph = %d nodes, %d edges; want 2, 1", len(induced.Nodes), len(induced.Edges))
+	}
+
+	expanded, err := gs.Subgraph(ctx, tenantID, models.SubgraphRequest{NodeIDs: []string{a.ID}, Expand: true})
+	if err != nil {
+		t.Fatalf("Subgraph expand: %v", err)
+	}
+	if len(expanded.Nodes) != 3 || len(expanded.Edges) != 2 {
+		t.Errorf("Subgraph expand = %d nodes, %d edges; want 3, 2", len(expanded.Nodes), len(expanded.Edges))
+	}
+}
+
+func TestGraphAsOf(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	gs := store.NewGraphStore(base)
+	ctx := context.Background()
+
+	n, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{
+		Type: "concept", Label: "AsOf", Properties: map[string]any{"status": "draft"},
+	})
+	if err != nil {
+		t.Fatalf("CreateNode: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	before := time.Now()
+	time.Sleep(50 * time.Millisecond)
+
+	if _, err := ns.UpdateNode(ctx, tenantID, n.ID, models.UpdateNodeRequest{
+		Properties: map[string]any{"status": "final", "owner": "alice"},
+	}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+
+	snap, err := gs.GraphAsOf(ctx, tenantID, models.AsOfQuery{Timestamp: before, Limit: 100})
+	if err != nil {
+		t.Fatalf("GraphAsOf: %v", err)
+	}
+
+	var got *models.Node
+	for i := range snap.Nodes {
+		if snap.Nodes[i].ID == n.ID {
+			got = &snap.Nodes[i]
+		}
+	}
+	if got == nil {
+		t.Fatalf("node %s missing from snapshot", n.ID)
+	}
+	if got.Properties["status"] != "draft" {
+		t.Errorf("status = %v, want draft", got.Properties["status"])
+	}
+	if _, ok := got.Properties["owner"]; ok {
+		t.Errorf("owner should not exist before the update, got %v", got.Properties["owner"])
+	}
+}
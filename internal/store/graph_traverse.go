@@ -4,6 +4,7 @@
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -43,20 +44,23 @@ func requireGraphNodesExist(ctx context.Context, tx pgx.Tx, nodeIDs ...string) e
 	return nil
 }
 
+// bfsNeighborSQL finds the edges touching node $1; Traverse runs it once per
+// frontier node at every hop.
+var bfsNeighborSQL = `(SELECT DISTINCT source, target FROM kg_edges
+	WHERE source = $1 AND tenant_id = current_setting('app.tenant_id')::uuid ORDER BY source, target LIMIT ` + fmt.Sprintf("%d", bfsNeighborLimit) + `)
+	UNION
+	(SELECT DISTINCT source, target FROM kg_edges
+	WHERE target = $1 AND tenant_id = current_setting('app.tenant_id')::uuid ORDER BY source, target LIMIT ` + fmt.Sprintf("%d", bfsNeighborLimit) + `)`
+
 func bfsNeighborPairs(ctx context.Context, tx pgx.Tx, frontier []string) ([][2]string, error) {
 	if len(frontier) == 0 {
 		return nil, nil
 	}
 
 	edges := make([][2]string, 0, len(frontier)*4)
-	neighborSQL := `(SELECT DISTINCT source, target FROM kg_edges
-		WHERE source = $1 AND tenant_id = current_setting('app.tenant_id')::uuid ORDER BY source, target LIMIT ` + fmt.Sprintf("%d", bfsNeighborLimit) + `)
-		UNION
-		(SELECT DISTINCT source, target FROM kg_edges
-		WHERE target = $1 AND tenant_id = current_setting('app.tenant_id')::uuid ORDER BY source, target LIMIT ` + fmt.Sprintf("%d", bfsNeighborLimit) + `)`
 
 	for _, nodeID := range frontier {
-		rows, err := tx.Query(ctx, neighborSQL, nodeID)
+		rows, err := tx.Query(ctx, bfsNeighborSQL, nodeID)
 		if err != nil {
 			return nil, fmt.Errorf("querying BFS neighbors for %q: %w", nodeID, err)
 		}
@@ -90,36 +94,10 @@ func bfsNeighborPairs(ctx context.Context, tx pgx.Tx, frontier []string) ([][2]s
 	return edges, nil
 }
 
-// Traverse performs application-level BFS from nodeID up to maxHops and returns the discovered subgraph.
-func (s *GraphStore) Traverse( //nolint:funlen,gocyclo,cyclop,gocognit // BFS loop with neighbor expansion is inherently multi-step.
-	ctx context.Context,
-	tenantID string,
-	nodeID string,
-	maxHops int,
-) (*models.TraverseResult, error) {
-	if maxHops <= 0 {
-		maxHops = 1
-	}
-
-	if maxHops > maxTraverseHops {
-		maxHops = maxTraverseHops
-	}
-
-	ctx, cancel := withTimeout(ctx)
-	defer cancel()
-
-	tx, err := s.beginReadTx(ctx, tenantID)
-	if err != nil {
-		return nil, fmt.Errorf("traversing graph: %w", err)
-	}
-
-	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
-
-	if err := requireGraphNodesExist(ctx, tx, nodeID); err != nil {
-		return nil, err
-	}
-
-	// Application-level BFS with global visited set.
+// bfsVisit runs an application-level BFS from nodeID over edges in either
+// direction for up to maxHops, with a global visited set, and returns the IDs
+// of every node reached, nodeID included, up to traverseNodeLimit.
+func bfsVisit(ctx context.Context, tx pgx.Tx, nodeID string, maxHops int) ([]string, error) {
 	visited := map[string]bool{nodeID: true}
 	frontier := []string{nodeID}
 
@@ -163,6 +141,46 @@ func (s *GraphStore) Traverse( //nolint:funlen,gocyclo,cyclop,gocognit // BFS lo
 		ids = append(ids, id)
 	}
 
+	return ids, nil
+}
+
+// Traverse performs application-level BFS from nodeID up to maxHops and returns the discovered subgraph.
+func (s *GraphStore) Traverse( //nolint:funlen,gocyclo,cyclop,gocognit // BFS loop with neighbor expansion is inherently multi-step.
+	ctx context.Context,
+	tenantID string,
+	nodeID string,
+	maxHops int,
+	opts models.ReadOpts,
+) (*models.TraverseResult, error) {
+	defer observeOperation("Traverse", time.Now())
+
+	if maxHops <= 0 {
+		maxHops = 1
+	}
+
+	if maxHops > maxTraverseHops {
+		maxHops = maxTraverseHops
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReplicaTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("traversing graph: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := requireGraphNodesExist(ctx, tx, nodeID); err != nil {
+		return nil, err
+	}
+
+	ids, err := bfsVisit(ctx, tx, nodeID, maxHops)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(ids) == 0 {
 		return &models.TraverseResult{
 			Nodes: make([]models.Node, 0),
@@ -214,11 +232,11 @@ func (s *GraphStore) Traverse( //nolint:funlen,gocyclo,cyclop,gocognit // BFS lo
 		return nil, fmt.Errorf("iterating traverse edges: %w", err)
 	}
 
-	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodes, opts.OmitProperties); err != nil {
 		return nil, err
 	}
 
-	if err := s.decryptEdges(ctx, tenantID, edgeList); err != nil {
+	if err := s.readEdgeProperties(ctx, tenantID, edgeList, opts.OmitProperties); err != nil {
 		return nil, err
 	}
 
@@ -1,12 +1,8 @@
 package store
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -24,136 +20,76 @@ func NewHistoryStore(base Base) *HistoryStore {
 	return &HistoryStore{Base: base}
 }
 
-// propertyDiff represents a single property value change.
-type propertyDiff struct {
-	key      string
-	oldValue json.RawMessage
-	newValue json.RawMessage
-}
-
-// diffProperties computes changed, added, and removed keys between two property maps.
-func diffProperties(oldProps, newProps map[string]any) ([]propertyDiff, error) {
-	var diffs []propertyDiff
-
-	for k, newVal := range newProps {
-		newJSON, err := json.Marshal(newVal)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling new value for %s: %w", k, err)
-		}
-
-		oldVal, existed := oldProps[k]
-		if !existed {
-			diffs = append(diffs, propertyDiff{key: k, oldValue: nil, newValue: newJSON})
-
-			continue
-		}
-
-		oldJSON, err := json.Marshal(oldVal)
-		if err != nil {
-			return nil, fmt.Errorf("marshalling old value for %s: %w", k, err)
-		}
-
-		if !bytes.Equal(oldJSON, newJSON) {
-			diffs = append(diffs, propertyDiff{key: k, oldValue: oldJSON, newValue: newJSON})
-		}
+// GetPropertyHistory returns change history for a node with optional property
+// key and field filters and has_more pagination.
+func (s *HistoryStore) GetPropertyHistory(
+	ctx context.Context,
+	tenantID, nodeID string,
+	propertyKey, field string,
+	limit, offset int,
+) ([]models.PropertyChange, bool, error) {
+	f := historyFilter{conds: "node_id = $1", args: []any{nodeID}}
+	if propertyKey != "" {
+		f.add("field = 'property' AND property_key = $%d", propertyKey)
 	}
 
-	for k, oldVal := range oldProps {
-		if _, exists := newProps[k]; !exists {
-			oldJSON, err := json.Marshal(oldVal)
-			if err != nil {
-				return nil, fmt.Errorf("marshalling removed value for %s: %w", k, err)
-			}
-
-			diffs = append(diffs, propertyDiff{key: k, oldValue: oldJSON, newValue: nil})
-		}
+	if field != "" {
+		f.add("field = $%d", field)
 	}
 
-	return diffs, nil
+	return s.pagePropertyChanges(ctx, tenantID, "getting property history", f, limit, offset)
 }
 
-// RecordPropertyChanges diffs oldProps and newProps, inserting a history row
-// for each changed key. Package-level so NodeStore can call it within its transaction.
-func RecordPropertyChanges(
-	ctx context.Context,
-	tx pgx.Tx,
-	tenantID, nodeID string,
-	oldProps, newProps map[string]any,
-	reason string,
-) error {
-	changes, err := diffProperties(oldProps, newProps)
-	if err != nil {
-		return err
+// ListHistory returns node changes across the tenant, newest first, filtered
+// by opts with has_more pagination.
+func (s *HistoryStore) ListHistory(
+	ctx context.Context, tenantID string, opts models.HistoryListOpts,
+) ([]models.PropertyChange, bool, error) {
+	f := historyFilter{conds: "TRUE"}
+	if opts.Since != nil {
+		f.add("changed_at >= $%d", *opts.Since)
 	}
 
-	if len(changes) == 0 {
-		return nil
+	if opts.Until != nil {
+		f.add("changed_at < $%d", *opts.Until)
 	}
 
-	valueParts := make([]string, 0, len(changes))
-	args := make([]any, 0, len(changes)*6)
-
-	var reasonPtr *string
-	if reason != "" {
-		reasonPtr = &reason
+	if opts.PropertyKey != "" {
+		f.add("field = 'property' AND property_key = $%d", opts.PropertyKey)
 	}
 
-	for i, c := range changes {
-		base := i*6 + 1
-		valueParts = append(valueParts, fmt.Sprintf(
-			"($%d, $%d, $%d, $%d, $%d, $%d)",
-			base, base+1, base+2, base+3, base+4, base+5,
-		))
-		args = append(args, tenantID, nodeID, c.key, c.oldValue, c.newValue, reasonPtr)
+	if opts.Field != "" {
+		f.add("field = $%d", opts.Field)
 	}
 
-	sql := `INSERT INTO kg_property_history (tenant_id, node_id, property_key, old_value, new_value, reason)
-		VALUES ` + strings.Join(valueParts, ", ")
+	if opts.Actor != "" {
+		f.add("changed_by = $%d", opts.Actor)
+	}
 
-	if _, err := tx.Exec(ctx, sql, args...); err != nil {
-		return fmt.Errorf("inserting property history: %w", err)
+	if opts.Reason != "" {
+		f.add("strpos(reason, $%d) > 0", opts.Reason)
 	}
 
-	return nil
+	return s.pagePropertyChanges(ctx, tenantID, "listing history", f, opts.Limit, opts.Offset)
 }
 
-// fetchNodeProperties loads and decrypts properties for a single node within a transaction.
-// Package-level so NodeStore.UpdateNode can call it.
-func fetchNodeProperties(
-	ctx context.Context,
-	tx pgx.Tx,
-	tenantID, nodeID string,
-	b *Base,
-) (map[string]any, error) {
-	var propsBytes []byte
-
-	err := tx.QueryRow(ctx,
-		`SELECT properties FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
-		nodeID,
-	).Scan(&propsBytes)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, models.ErrNodeNotFound
-		}
-
-		return nil, fmt.Errorf("fetching node properties: %w", err)
-	}
-
-	props, err := b.decryptPropertiesRaw(ctx, tenantID, propsBytes)
-	if err != nil {
-		return nil, fmt.Errorf("decrypting node properties: %w", err)
-	}
+// historyFilter is the WHERE conditions of a kg_property_history query and
+// their arguments.
+type historyFilter struct {
+	conds string
+	args  []any
+}
 
-	return props, nil
+// add appends cond, whose one %d verb becomes arg's placeholder.
+func (f *historyFilter) add(cond string, arg any) {
+	f.args = append(f.args, arg)
+	f.conds += fmt.Sprintf(" AND "+cond, len(f.args))
 }
 
-// GetPropertyHistory returns property change history for a node with optional
-// key filter and has_more pagination.
-func (s *HistoryStore) GetPropertyHistory(
-	ctx context.Context,
-	tenantID, nodeID string,
-	propertyKey string,
-	limit, offset int,
+// pagePropertyChanges returns one page of the tenant's history rows matching
+// f, newest first, and whether more follow. what prefixes errors.
+func (s *HistoryStore) pagePropertyChanges(
+	ctx context.Context, tenantID, what string, f historyFilter, limit, offset int,
 ) ([]models.PropertyChange, bool, error) {
 	if limit <= 0 {
 		limit = 50
@@ -172,27 +108,35 @@ func (s *HistoryStore) GetPropertyHistory(
 
 	tx, err := s.beginReadTx(ctx, tenantID)
 	if err != nil {
-		return nil, false, fmt.Errorf("getting property history: %w", err)
+		return nil, false, fmt.Errorf("%s: %w", what, err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	query := `SELECT id, tenant_id, node_id, property_key, old_value, new_value, changed_at, reason, changed_by
+	query := fmt.Sprintf(`SELECT id, tenant_id, node_id, field, property_key, old_value, new_value, changed_at, reason, changed_by
 		FROM kg_property_history
-		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = $1`
-	args := []any{nodeID}
-	argIdx := 2
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND %s
+		ORDER BY changed_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		f.conds, len(f.args)+1, len(f.args)+2)
+	f.args = append(f.args, limit+1, offset)
 
-	if propertyKey != "" {
-		query += fmt.Sprintf(" AND property_key = $%d", argIdx)
-		args = append(args, propertyKey)
-		argIdx++
+	changes, hasMore, err := queryPropertyChanges(ctx, tx, query, f.args, limit)
+	if err != nil {
+		return nil, false, err
 	}
 
-	query += " ORDER BY changed_at DESC"
-	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
-	args = append(args, limit+1, offset)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, fmt.Errorf("committing property history query: %w", err)
+	}
 
+	return changes, hasMore, nil
+}
+
+// queryPropertyChanges runs a kg_property_history query that selects limit+1
+// rows and reports whether there are more than limit.
+func queryPropertyChanges(
+	ctx context.Context, tx pgx.Tx, query string, args []any, limit int,
+) ([]models.PropertyChange, bool, error) {
 	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, false, fmt.Errorf("querying property history: %w", err)
@@ -206,7 +150,7 @@ func (s *HistoryStore) GetPropertyHistory(
 		var tenantUUID uuid.UUID
 
 		if err := rows.Scan(
-			&c.ID, &tenantUUID, &c.NodeID, &c.PropertyKey,
+			&c.ID, &tenantUUID, &c.NodeID, &c.Field, &c.PropertyKey,
 			&c.OldValue, &c.NewValue, &c.ChangedAt, &c.Reason, &c.ChangedBy,
 		); err != nil {
 			return nil, false, fmt.Errorf("scanning property history row: %w", err)
@@ -225,9 +169,5 @@ func (s *HistoryStore) GetPropertyHistory(
 		changes = changes[:limit]
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, false, fmt.Errorf("committing property history query: %w", err)
-	}
-
 	return changes, hasMore, nil
 }
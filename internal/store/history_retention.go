@@ -0,0 +1,217 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// historyTables lists the history tables pruned and compacted together, with
+// the columns that identify the changed entity in each.
+var historyTables = []struct {
+	name   string
+	entity string
+}{
+	{"kg_property_history", "node_id, field"},
+	{"kg_edge_property_history", "source, target, relation"},
+}
+
+// GetHistoryRetention returns the tenant's history retention policy.
+func (s *HistoryStore) GetHistoryRetention(ctx context.Context, tenantID string) (*models.HistoryRetention, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var r models.HistoryRetention
+
+	err := s.Pool.QueryRow(ctx,
+		`SELECT history_retention_days, history_compact_after_days FROM tenants WHERE id = $1`,
+		tenantID,
+	).Scan(&r.RetentionDays, &r.CompactAfterDays)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting history retention: %w", err)
+	}
+
+	return &r, nil
+}
+
+// SetHistoryRetention replaces the tenant's history retention policy.
+func (s *HistoryStore) SetHistoryRetention(
+	ctx context.Context, tenantID string, r models.HistoryRetention,
+) (*models.HistoryRetention, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tag, err := s.Pool.Exec(ctx,
+		`UPDATE tenants SET history_retention_days = $2, history_compact_after_days = $3 WHERE id = $1`,
+		tenantID, r.RetentionDays, r.CompactAfterDays,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("setting history retention: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, models.ErrTenantNotFound
+	}
+
+	return &r, nil
+}
+
+// ListHistoryRetentions returns every tenant that has a retention or
+// compaction period set.
+func (s *HistoryStore) ListHistoryRetentions(ctx context.Context) ([]models.TenantHistoryRetention, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT id, history_retention_days, history_compact_after_days
+		FROM tenants
+		WHERE history_retention_days IS NOT NULL OR history_compact_after_days IS NOT NULL
+		ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("listing history retentions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.TenantHistoryRetention
+
+	for rows.Next() {
+		var r models.TenantHistoryRetention
+		if err := rows.Scan(&r.TenantID, &r.RetentionDays, &r.CompactAfterDays); err != nil {
+			return nil, fmt.Errorf("scanning history retention: %w", err)
+		}
+
+		result = append(result, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating history retentions: %w", err)
+	}
+
+	return result, nil
+}
+
+// PruneHistory deletes node and edge history recorded before the cutoff, in
+// batches of purgeBatchSize. Returns the number of deleted rows.
+func (s *HistoryStore) PruneHistory(ctx context.Context, tenantID string, before time.Time) (int, error) {
+	var total int
+
+	for _, table := range historyTables {
+		sql := `DELETE FROM ` + table.name + ` WHERE id IN (
+			SELECT id FROM ` + table.name + `
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND changed_at < $1
+			ORDER BY changed_at
+			LIMIT $2
+		)`
+
+		deleted, err := s.runHistoryBatches(ctx, tenantID, sql, before)
+		total += deleted
+
+		if err != nil {
+			return total, fmt.Errorf("pruning %s: %w", table.name, err)
+		}
+	}
+
+	return total, nil
+}
+
+// CompactHistory thins node and edge history recorded before the cutoff to
+// the first and last change per key per day. The last change's old value is
+// rewritten to the first change's new value so the remaining rows still
+// chain, which keeps as-of reconstruction exact at day boundaries. Works
+// through at most purgeBatchSize days-with-changes per batch. Returns the
+// number of deleted rows.
+func (s *HistoryStore) CompactHistory(ctx context.Context, tenantID string, before time.Time) (int, error) {
+	var total int
+
+	for _, table := range historyTables {
+		group := table.entity + `, property_key, date_trunc('day', changed_at)`
+
+		sql := `WITH groups AS (
+				SELECT ` + table.entity + `, property_key, date_trunc('day', changed_at) AS day
+				FROM ` + table.name + `
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid AND changed_at < $1
+				GROUP BY ` + group + `
+				HAVING count(*) > 2
+				LIMIT $2
+			),
+			ranked AS (
+				SELECT h.id,
+					row_number() OVER w AS rn,
+					count(*) OVER w AS cnt,
+					first_value(h.new_value) OVER w AS first_new_value
+				FROM ` + table.name + ` h
+				JOIN groups g USING (` + table.entity + `, property_key)
+				WHERE h.tenant_id = current_setting('app.tenant_id')::uuid
+					AND h.changed_at < $1
+					AND date_trunc('day', h.changed_at) = g.day
+				WINDOW w AS (PARTITION BY ` + group + ` ORDER BY h.changed_at, h.id
+					ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)
+			),
+			relinked AS (
+				UPDATE ` + table.name + ` h SET old_value = r.first_new_value
+				FROM ranked r
+				WHERE h.id = r.id AND r.rn = r.cnt
+			)
+			DELETE FROM ` + table.name + `
+			WHERE id IN (SELECT id FROM ranked WHERE rn > 1 AND rn < cnt)`
+
+		deleted, err := s.runHistoryBatches(ctx, tenantID, sql, before)
+		total += deleted
+
+		if err != nil {
+			return total, fmt.Errorf("compacting %s: %w", table.name, err)
+		}
+	}
+
+	return total, nil
+}
+
+// runHistoryBatches executes a batched delete, taking the cutoff and batch
+// size as $1 and $2, in its own transaction until a batch removes nothing.
+// Each batch has its own timeout, like AuditStore.PurgeOldEntries.
+func (s *HistoryStore) runHistoryBatches(ctx context.Context, tenantID, sql string, before time.Time) (int, error) {
+	var total int
+
+	for {
+		batchCtx, cancel := withTimeout(ctx)
+
+		deleted, err := s.runHistoryBatch(batchCtx, tenantID, sql, before)
+		cancel()
+
+		if err != nil {
+			return total, err
+		}
+
+		total += deleted
+		if deleted == 0 {
+			return total, nil
+		}
+	}
+}
+
+func (s *HistoryStore) runHistoryBatch(ctx context.Context, tenantID, sql string, before time.Time) (int, error) {
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback on early return.
+
+	tag, err := tx.Exec(ctx, sql, before, purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestHistoryCompactAndPrune(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	hs := store.NewHistoryStore(base)
+	ctx := context.Background()
+
+	node := createTestNode(t, ns, tenantID, "Counter")
+	for i := 1; i <= 4; i++ {
+		req := models.PatchPropertiesRequest{Properties: map[string]any{"count": i}}
+		if _, err := ns.PatchNodeProperties(ctx, tenantID, node.ID, req); err != nil {
+			t.Fatalf("PatchNodeProperties(%d): %v", i, err)
+		}
+	}
+
+	cutoff := time.Now().Add(time.Minute)
+
+	compacted, err := hs.CompactHistory(ctx, tenantID, cutoff)
+	if err != nil {
+		t.Fatalf("CompactHistory: %v", err)
+	}
+	if compacted != 2 {
+		t.Errorf("compacted = %d, want 2", compacted)
+	}
+
+	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "count", "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetPropertyHistory: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want first and last", changes)
+	}
+
+	// Newest first: the kept last change now starts from the first change's value.
+	var oldValue, newValue int
+	if err := json.Unmarshal(changes[0].OldValue, &oldValue); err != nil || oldValue != 1 {
+		t.Errorf("last change old value = %s, want 1", changes[0].OldValue)
+	}
+	if err := json.Unmarshal(changes[0].NewValue, &newValue); err != nil || newValue != 4 {
+		t.Errorf("last change new value = %s, want 4", changes[0].NewValue)
+	}
+
+	if again, err := hs.CompactHistory(ctx, tenantID, cutoff); err != nil || again != 0 {
+		t.Errorf("second compaction = %d, %v; want 0", again, err)
+	}
+
+	pruned, err := hs.PruneHistory(ctx, tenantID, cutoff)
+	if err != nil {
+		t.Fatalf("PruneHistory: %v", err)
+	}
+	if pruned != 2 {
+		t.Errorf("pruned = %d, want 2", pruned)
+	}
+}
+
+func TestHistoryRetentionSettings(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	hs := store.NewHistoryStore(base)
+	ctx := context.Background()
+
+	days, compactDays := 90, 7
+	if _, err := hs.SetHistoryRetention(ctx, tenantID, models.HistoryRetention{
+		RetentionDays:    &days,
+		CompactAfterDays: &compactDays,
+	}); err != nil {
+		t.Fatalf("SetHistoryRetention: %v", err)
+	}
+
+	got, err := hs.GetHistoryRetention(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("GetHistoryRetention: %v", err)
+	}
+	if got.RetentionDays == nil || *got.RetentionDays != 90 || got.CompactAfterDays == nil || *got.CompactAfterDays != 7 {
+		t.Errorf("retention = %+v, want 90/7", got)
+	}
+
+	all, err := hs.ListHistoryRetentions(ctx)
+	if err != nil {
+		t.Fatalf("ListHistoryRetentions: %v", err)
+	}
+
+	found := false
+	for _, r := range all {
+		found = found || r.TenantID == tenantID
+	}
+	if !found {
+		t.Error("tenant missing from ListHistoryRetentions")
+	}
+}
@@ -0,0 +1,67 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestListHistory(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ss := store.NewSalienceStore(base)
+	hs := store.NewHistoryStore(base)
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Second)
+	alice := createTestNode(t, ns, tenantID, "Alice")
+	bob := createTestNode(t, ns, tenantID, "Bob")
+
+	for _, id := range []string{alice.ID, bob.ID} {
+		req := models.PatchPropertiesRequest{Properties: map[string]any{"status": "active"}}
+		if _, err := ns.PatchNodeProperties(ctx, tenantID, id, req); err != nil {
+			t.Fatalf("PatchNodeProperties: %v", err)
+		}
+	}
+
+	if _, err := ss.BoostNode(ctx, tenantID, bob.ID); err != nil {
+		t.Fatalf("BoostNode: %v", err)
+	}
+
+	all, _, err := hs.ListHistory(ctx, tenantID, models.HistoryListOpts{Since: &before})
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+
+	nodes := map[string]bool{}
+	for _, c := range all {
+		nodes[c.NodeID] = true
+	}
+	if !nodes[alice.ID] || !nodes[bob.ID] {
+		t.Errorf("changes = %+v, want both nodes", all)
+	}
+
+	status, _, err := hs.ListHistory(ctx, tenantID, models.HistoryListOpts{PropertyKey: "status"})
+	if err != nil || len(status) != 2 {
+		t.Errorf("status changes = %+v, %v; want 2", status, err)
+	}
+
+	boosts, _, err := hs.ListHistory(ctx, tenantID, models.HistoryListOpts{Reason: "boost"})
+	if err != nil || len(boosts) != 1 || boosts[0].NodeID != bob.ID {
+		t.Errorf("boost changes = %+v, %v; want Bob's boost", boosts, err)
+	}
+
+	page, hasMore, err := hs.ListHistory(ctx, tenantID, models.HistoryListOpts{Limit: 1})
+	if err != nil || len(page) != 1 || !hasMore {
+		t.Errorf("page = %+v, has_more = %v, %v; want one change with more", page, hasMore, err)
+	}
+
+	until := before
+	none, _, err := hs.ListHistory(ctx, tenantID, models.HistoryListOpts{Until: &until})
+	if err != nil || len(none) != 0 {
+		t.Errorf("changes before the test = %+v, %v; want none", none, err)
+	}
+}
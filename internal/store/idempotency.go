@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// IdempotencyStore persists idempotency keys so that retried creates are
+// deduplicated across restarts and replicas. It implements
+// middleware.IdempotencyStore.
+type IdempotencyStore struct {
+	Base
+}
+
+// NewIdempotencyStore creates a new IdempotencyStore.
+func NewIdempotencyStore(base Base) *IdempotencyStore {
+	return &IdempotencyStore{Base: base}
+}
+
+// ReserveIdempotencyKey claims key for a request with fingerprint. A key is
+// free if it was never used, has expired, or is held by a request that has
+// not finished within models.IdempotencyLockTTL. Otherwise the existing
+// record is returned. Expired keys of the tenant are removed on the way.
+func (s *IdempotencyStore) ReserveIdempotencyKey(
+	ctx context.Context, tenantID, key, fingerprint string, now time.Time,
+) (*models.IdempotencyRecord, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("reserving idempotency key: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_idempotency_keys
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND expires_at < $1
+	`, now); err != nil {
+		return nil, fmt.Errorf("removing expired idempotency keys: %w", err)
+	}
+
+	var claimed bool
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kg_idempotency_keys AS k (tenant_id, key, fingerprint, locked_until, expires_at)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4)
+		ON CONFLICT (tenant_id, key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			locked_until = EXCLUDED.locked_until,
+			expires_at = EXCLUDED.expires_at
+		WHERE k.status IS NULL AND k.locked_until < $5
+		RETURNING true
+	`, key, fingerprint, now.Add(models.IdempotencyLockTTL), now.Add(models.IdempotencyKeyTTL), now).Scan(&claimed)
+
+	var existing *models.IdempotencyRecord
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		existing, err = readIdempotencyRecord(ctx, tx, key)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("claiming idempotency key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing idempotency key: %w", err)
+	}
+
+	return existing, nil
+}
+
+func readIdempotencyRecord(ctx context.Context, tx pgx.Tx, key string) (*models.IdempotencyRecord, error) {
+	var rec models.IdempotencyRecord
+	var status *int
+
+	err := tx.QueryRow(ctx, `
+		SELECT fingerprint, status, response FROM kg_idempotency_keys
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND key = $1
+	`, key).Scan(&rec.Fingerprint, &status, &rec.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading idempotency key: %w", err)
+	}
+
+	if status != nil {
+		rec.Status = *status
+	}
+
+	return &rec, nil
+}
+
+// CompleteIdempotencyKey stores the response to the request holding key.
+func (s *IdempotencyStore) CompleteIdempotencyKey(
+	ctx context.Context, tenantID, key string, status int, body []byte,
+) error {
+	return s.execIdempotency(ctx, tenantID, "completing idempotency key", `
+		UPDATE kg_idempotency_keys SET status = $2, response = $3
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND key = $1
+	`, key, status, body)
+}
+
+// ReleaseIdempotencyKey deletes key so that a retry can run again.
+func (s *IdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, tenantID, key string) error {
+	return s.execIdempotency(ctx, tenantID, "releasing idempotency key", `
+		DELETE FROM kg_idempotency_keys
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND key = $1
+	`, key)
+}
+
+func (s *IdempotencyStore) execIdempotency(ctx context.Context, tenantID, what, query string, args ...any) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewIdempotencyStore(base)
+	ctx := context.Background()
+	now := time.Now()
+
+	rec, err := s.ReserveIdempotencyKey(ctx, tenantID, "k1", "fp", now)
+	if err != nil || rec != nil {
+		t.Fatalf("first reserve = %+v, %v; want claimed", rec, err)
+	}
+
+	rec, err = s.ReserveIdempotencyKey(ctx, tenantID, "k1", "fp", now)
+	if err != nil || rec == nil || rec.Status != 0 {
+		t.Fatalf("reserve while running = %+v, %v; want in-progress record", rec, err)
+	}
+
+	if err := s.CompleteIdempotencyKey(ctx, tenantID, "k1", 201, []byte(`{"id":"n1"}`)); err != nil {
+		t.Fatalf("CompleteIdempotencyKey: %v", err)
+	}
+
+	rec, err = s.ReserveIdempotencyKey(ctx, tenantID, "k1", "other", now.Add(2*models.IdempotencyLockTTL))
+	if err != nil || rec == nil || rec.Status != 201 || rec.Fingerprint != "fp" || string(rec.Body) != `{"id":"n1"}` {
+		t.Fatalf("reserve after completion = %+v, %v", rec, err)
+	}
+
+	rec, err = s.ReserveIdempotencyKey(ctx, tenantID, "k1", "fp", now.Add(models.IdempotencyKeyTTL+time.Minute))
+	if err != nil || rec != nil {
+		t.Errorf("reserve after expiry = %+v, %v; want claimed", rec, err)
+	}
+
+	if _, err := s.ReserveIdempotencyKey(ctx, tenantID, "k2", "fp", now); err != nil {
+		t.Fatalf("reserve k2: %v", err)
+	}
+	rec, err = s.ReserveIdempotencyKey(ctx, tenantID, "k2", "fp", now.Add(2*models.IdempotencyLockTTL))
+	if err != nil || rec != nil {
+		t.Errorf("reserve after stale lock = %+v, %v; want claimed", rec, err)
+	}
+
+	if err := s.ReleaseIdempotencyKey(ctx, tenantID, "k2"); err != nil {
+		t.Fatalf("ReleaseIdempotencyKey: %v", err)
+	}
+	rec, err = s.ReserveIdempotencyKey(ctx, tenantID, "k2", "fp", now)
+	if err != nil || rec != nil {
+		t.Errorf("reserve after release = %+v, %v; want claimed", rec, err)
+	}
+}
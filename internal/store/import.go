@@ -32,6 +32,11 @@ func (s *ExportStore) UpsertNodeFromExport(
 		return "", fmt.Errorf("encrypting node properties: %w", err)
 	}
 
+	searchProps, err := s.searchProps(props)
+	if err != nil {
+		return "", err
+	}
+
 	tx, err := s.beginTx(ctx, tenantID)
 	if err != nil {
 		return "", fmt.Errorf("upsert node from export: %w", err)
@@ -42,9 +47,9 @@ func (s *ExportStore) UpsertNodeFromExport(
 	var action string
 
 	if overwrite {
-		action, err = upsertNodeOverwrite(ctx, tx, tenantID, node, propsJSON)
+		action, err = upsertNodeOverwrite(ctx, tx, tenantID, node, propsJSON, searchProps)
 	} else {
-		action, err = upsertNodeSkip(ctx, tx, tenantID, node, propsJSON)
+		action, err = upsertNodeSkip(ctx, tx, tenantID, node, propsJSON, searchProps)
 	}
 
 	if err != nil {
@@ -63,7 +68,7 @@ func upsertNodeOverwrite(
 	tx pgx.Tx,
 	tenantID string,
 	node models.ExportNode,
-	propsJSON []byte,
+	propsJSON, searchProps []byte,
 ) (string, error) {
 	var wasInserted bool
 
@@ -77,13 +82,15 @@ func upsertNodeOverwrite(
 			(id, tenant_id, type, label, properties,
 			 embedding, access_count, last_accessed,
 			 salience_score, user_boosted, superseded_by,
-			 created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6::vector, $7, $8, $9, $10, $11, $12, $13)
+			 created_at, updated_at, search_props)
+		VALUES ($1, $2, $3, $4, $5, $6::vector, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (tenant_id, id) DO UPDATE SET
 			type          = EXCLUDED.type,
 			label         = EXCLUDED.label,
 			properties    = EXCLUDED.properties,
+			search_props  = EXCLUDED.search_props,
 			embedding     = EXCLUDED.embedding,
+			embedding_model = NULL,
 			access_count  = EXCLUDED.access_count,
 			last_accessed = EXCLUDED.last_accessed,
 			salience_score = EXCLUDED.salience_score,
@@ -95,7 +102,7 @@ func upsertNodeOverwrite(
 		node.ID, tenantID, node.Type, node.Label, propsJSON,
 		embeddingVal, node.AccessCount, node.LastAccessed,
 		node.SalienceScore, node.UserBoosted, node.SupersededBy,
-		node.CreatedAt, node.UpdatedAt,
+		node.CreatedAt, node.UpdatedAt, searchProps,
 	).Scan(&wasInserted)
 	if err != nil {
 		return "", fmt.Errorf("upserting node: %w", err)
@@ -113,7 +120,7 @@ func upsertNodeSkip(
 	tx pgx.Tx,
 	tenantID string,
 	node models.ExportNode,
-	propsJSON []byte,
+	propsJSON, searchProps []byte,
 ) (string, error) {
 	var embeddingVal any
 	if len(node.Embedding) > 0 {
@@ -125,14 +132,14 @@ func upsertNodeSkip(
 			(id, tenant_id, type, label, properties,
 			 embedding, access_count, last_accessed,
 			 salience_score, user_boosted, superseded_by,
-			 created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6::vector, $7, $8, $9, $10, $11, $12, $13)
+			 created_at, updated_at, search_props)
+		VALUES ($1, $2, $3, $4, $5, $6::vector, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (tenant_id, id) DO NOTHING
 	`,
 		node.ID, tenantID, node.Type, node.Label, propsJSON,
 		embeddingVal, node.AccessCount, node.LastAccessed,
 		node.SalienceScore, node.UserBoosted, node.SupersededBy,
-		node.CreatedAt, node.UpdatedAt,
+		node.CreatedAt, node.UpdatedAt, searchProps,
 	)
 	if err != nil {
 		return "", fmt.Errorf("inserting node: %w", err)
@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// PutImportChunk stores chunk seq of an open session, replacing any chunk
+// previously uploaded with the same seq. The payload is encrypted at rest.
+func (s *ExportStore) PutImportChunk(
+	ctx context.Context,
+	tenantID, sessionID string,
+	seq int,
+	chunk *models.ImportChunk,
+) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	plain, err := json.Marshal(chunk)
+	if err != nil {
+		return fmt.Errorf("marshalling import chunk: %w", err)
+	}
+
+	payload, err := s.Crypto.Encrypt(ctx, tenantID, plain)
+	if err != nil {
+		return fmt.Errorf("encrypting import chunk: %w", err)
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("storing import chunk: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	sess, err := getImportSession(ctx, tx, sessionID, true)
+	if err != nil {
+		return err
+	}
+
+	if sess.Status != models.ImportSessionOpen {
+		return models.ErrImportSessionClosed
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO kg_import_chunks (tenant_id, session_id, seq, node_count, edge_count, payload)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4, $5)
+		ON CONFLICT (session_id, seq) DO UPDATE SET
+			node_count = EXCLUDED.node_count,
+			edge_count = EXCLUDED.edge_count,
+			payload    = EXCLUDED.payload,
+			created_at = NOW()
+	`, sessionID, seq, len(chunk.Nodes), len(chunk.Edges), payload); err != nil {
+		return fmt.Errorf("inserting import chunk: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing import chunk: %w", err)
+	}
+
+	return nil
+}
+
+// LoadImportChunks decrypts every chunk of a session and returns their
+// records concatenated in seq order.
+func (s *ExportStore) LoadImportChunks(ctx context.Context, tenantID, sessionID string) (*models.ImportChunk, error) {
+	ctx, cancel := context.WithTimeout(ctx, importCommitTimeout)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("loading import chunks: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, `
+		SELECT payload
+		FROM kg_import_chunks
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND session_id = $1
+		ORDER BY seq
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("querying import chunks: %w", err)
+	}
+
+	defer rows.Close()
+
+	all := &models.ImportChunk{}
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("scanning import chunk: %w", err)
+		}
+
+		plain, err := s.Crypto.Decrypt(ctx, tenantID, payload)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting import chunk: %w", err)
+		}
+
+		var chunk models.ImportChunk
+		if err := json.Unmarshal(plain, &chunk); err != nil {
+			return nil, fmt.Errorf("unmarshalling import chunk: %w", err)
+		}
+
+		all.Nodes = append(all.Nodes, chunk.Nodes...)
+		all.Edges = append(all.Edges, chunk.Edges...)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating import chunks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing import chunk read: %w", err)
+	}
+
+	return all, nil
+}
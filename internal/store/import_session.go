@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// CreateImportSession opens a chunked import session. Expired sessions of
+// the tenant are removed in the same transaction.
+func (s *ExportStore) CreateImportSession(
+	ctx context.Context,
+	tenantID string,
+	schemaVersion int,
+	opts models.ImportOptions,
+	expiresAt time.Time,
+) (*models.ImportSession, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	optsJSON, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling import options: %w", err)
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating import session: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_import_sessions
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND status = 'open' AND expires_at < NOW()
+	`); err != nil {
+		return nil, fmt.Errorf("removing expired import sessions: %w", err)
+	}
+
+	sess := &models.ImportSession{
+		Status:        models.ImportSessionOpen,
+		SchemaVersion: schemaVersion,
+		Options:       opts,
+		Chunks:        []models.ImportChunkInfo{},
+		ExpiresAt:     expiresAt,
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kg_import_sessions (tenant_id, schema_version, options, expires_at)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3)
+		RETURNING id, created_at
+	`, schemaVersion, optsJSON, expiresAt).Scan(&sess.ID, &sess.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("inserting import session: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing import session: %w", err)
+	}
+
+	return sess, nil
+}
+
+// GetImportSession returns a session and the chunks it has received.
+// Expired open sessions are reported as not found.
+func (s *ExportStore) GetImportSession(ctx context.Context, tenantID, sessionID string) (*models.ImportSession, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting import session: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	sess, err := getImportSession(ctx, tx, sessionID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT seq, node_count, edge_count
+		FROM kg_import_chunks
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND session_id = $1
+		ORDER BY seq
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("querying import chunks: %w", err)
+	}
+
+	defer rows.Close()
+
+	sess.Chunks = []models.ImportChunkInfo{}
+	for rows.Next() {
+		var c models.ImportChunkInfo
+		if err := rows.Scan(&c.Seq, &c.Nodes, &c.Edges); err != nil {
+			return nil, fmt.Errorf("scanning import chunk: %w", err)
+		}
+		sess.Chunks = append(sess.Chunks, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating import chunks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing import session read: %w", err)
+	}
+
+	return sess, nil
+}
+
+// DeleteImportSession aborts a session and discards its chunks.
+func (s *ExportStore) DeleteImportSession(ctx context.Context, tenantID, sessionID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting import session: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM kg_import_sessions
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+	`, sessionID)
+	if err != nil {
+		return fmt.Errorf("executing import session delete: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrImportSessionNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing import session delete: %w", err)
+	}
+
+	return nil
+}
+
+// getImportSession reads a session row, optionally locking it for update.
+func getImportSession(ctx context.Context, tx pgx.Tx, sessionID string, forUpdate bool) (*models.ImportSession, error) {
+	query := `
+		SELECT id, status, schema_version, options, result, created_at, expires_at, committed_at
+		FROM kg_import_sessions
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+		  AND (status <> 'open' OR expires_at > NOW())`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	var (
+		sess       models.ImportSession
+		optsJSON   []byte
+		resultJSON []byte
+	)
+
+	err := tx.QueryRow(ctx, query, sessionID).Scan(
+		&sess.ID, &sess.Status, &sess.SchemaVersion, &optsJSON, &resultJSON,
+		&sess.CreatedAt, &sess.ExpiresAt, &sess.CommittedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrImportSessionNotFound
+		}
+
+		return nil, fmt.Errorf("querying import session: %w", err)
+	}
+
+	if err := json.Unmarshal(optsJSON, &sess.Options); err != nil {
+		return nil, fmt.Errorf("unmarshalling import options: %w", err)
+	}
+
+	if resultJSON != nil {
+		sess.Result = &models.ImportResult{}
+		if err := json.Unmarshal(resultJSON, sess.Result); err != nil {
+			return nil, fmt.Errorf("unmarshalling import result: %w", err)
+		}
+	}
+
+	return &sess, nil
+}
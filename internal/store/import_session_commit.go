@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// importCommitTimeout bounds the single transaction that applies a session.
+// Sessions can hold far more records than a regular request writes.
+const importCommitTimeout = 30 * time.Minute
+
+// CommitImportSession writes the given records and marks the session
+// committed in a single transaction, so either the whole import lands or
+// none of it does. The staged chunks are deleted on success.
+func (s *ExportStore) CommitImportSession(
+	ctx context.Context,
+	tenantID, sessionID string,
+	data *models.ImportChunk,
+	overwrite bool,
+) (*models.ImportResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, importCommitTimeout)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("committing import session: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	sess, err := getImportSession(ctx, tx, sessionID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if sess.Status != models.ImportSessionOpen {
+		return nil, models.ErrImportSessionClosed
+	}
+
+	result := &models.ImportResult{}
+
+	for _, n := range data.Nodes {
+		action, err := s.upsertImportedNode(ctx, tx, tenantID, n, overwrite)
+		if err != nil {
+			return nil, fmt.Errorf("importing node %s: %w", n.ID, err)
+		}
+		countImportAction(action, &result.NodesCreated, &result.NodesUpdated, &result.NodesSkipped)
+	}
+
+	for _, e := range data.Edges {
+		action, err := s.upsertImportedEdge(ctx, tx, tenantID, e, overwrite)
+		if err != nil {
+			return nil, fmt.Errorf("importing edge %s→%s (%s): %w", e.Source, e.Target, e.Relation, err)
+		}
+		countImportAction(action, &result.EdgesCreated, &result.EdgesUpdated, &result.EdgesSkipped)
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling import result: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE kg_import_sessions
+		SET status = 'committed', result = $2, committed_at = NOW()
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+	`, sessionID, resultJSON); err != nil {
+		return nil, fmt.Errorf("marking import session committed: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_import_chunks
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND session_id = $1
+	`, sessionID); err != nil {
+		return nil, fmt.Errorf("deleting import chunks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing import session: %w", err)
+	}
+
+	return result, nil
+}
+
+// upsertImportedNode writes one node inside an import session transaction.
+func (s *ExportStore) upsertImportedNode(ctx context.Context, tx pgx.Tx, tenantID string, node models.ExportNode, overwrite bool) (string, error) {
+	props := node.Properties
+	if props == nil {
+		props = map[string]any{}
+	}
+
+	propsJSON, err := s.encryptProperties(ctx, tenantID, props)
+	if err != nil {
+		return "", fmt.Errorf("encrypting node properties: %w", err)
+	}
+
+	searchProps, err := s.searchProps(props)
+	if err != nil {
+		return "", err
+	}
+
+	if overwrite {
+		return upsertNodeOverwrite(ctx, tx, tenantID, node, propsJSON, searchProps)
+	}
+
+	return upsertNodeSkip(ctx, tx, tenantID, node, propsJSON, searchProps)
+}
+
+// upsertImportedEdge writes one edge inside an import session transaction.
+func (s *ExportStore) upsertImportedEdge(ctx context.Context, tx pgx.Tx, tenantID string, edge models.ExportEdge, overwrite bool) (string, error) {
+	props := edge.Properties
+	if props == nil {
+		props = map[string]any{}
+	}
+
+	propsJSON, err := s.encryptProperties(ctx, tenantID, props)
+	if err != nil {
+		return "", fmt.Errorf("encrypting edge properties: %w", err)
+	}
+
+	if overwrite {
+		return upsertEdgeOverwrite(ctx, tx, tenantID, edge, propsJSON)
+	}
+
+	return upsertEdgeSkip(ctx, tx, tenantID, edge, propsJSON)
+}
+
+// countImportAction increments the counter matching an upsert action.
+func countImportAction(action string, created, updated, skipped *int) {
+	switch action {
+	case "created":
+		*created++
+	case "updated":
+		*updated++
+	case "skipped":
+		*skipped++
+	}
+}
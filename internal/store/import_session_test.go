@@ -0,0 +1,71 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestImportSession(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	es := store.NewExportStore(base)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	sess, err := es.CreateImportSession(ctx, tenantID, 1, models.ImportOptions{}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateImportSession: %v", err)
+	}
+
+	chunks := []*models.ImportChunk{
+		{Nodes: []models.ExportNode{{ID: "imp-a", Type: "person", Label: "A", Properties: map[string]any{"k": "v"}}}},
+		{Nodes: []models.ExportNode{{ID: "imp-b", Type: "person", Label: "B"}}},
+		{Edges: []models.ExportEdge{{Source: "imp-a", Target: "imp-b", Relation: "knows"}}},
+	}
+	for seq, c := range chunks {
+		if err := es.PutImportChunk(ctx, tenantID, sess.ID, seq, c); err != nil {
+			t.Fatalf("PutImportChunk(%d): %v", seq, err)
+		}
+	}
+
+	// Re-uploading a seq replaces it rather than adding a chunk.
+	if err := es.PutImportChunk(ctx, tenantID, sess.ID, 1, chunks[1]); err != nil {
+		t.Fatalf("re-uploading chunk: %v", err)
+	}
+
+	got, err := es.GetImportSession(ctx, tenantID, sess.ID)
+	if err != nil || len(got.Chunks) != 3 {
+		t.Fatalf("GetImportSession = %+v, %v; want 3 chunks", got, err)
+	}
+
+	if _, err := ns.GetNode(ctx, tenantID, "imp-a"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Fatalf("staged node visible before commit: err = %v", err)
+	}
+
+	data, err := es.LoadImportChunks(ctx, tenantID, sess.ID)
+	if err != nil || len(data.Nodes) != 2 || len(data.Edges) != 1 || data.Nodes[0].Properties["k"] != "v" {
+		t.Fatalf("LoadImportChunks = %+v, %v", data, err)
+	}
+
+	result, err := es.CommitImportSession(ctx, tenantID, sess.ID, data, false)
+	if err != nil || result.NodesCreated != 2 || result.EdgesCreated != 1 {
+		t.Fatalf("CommitImportSession = %+v, %v", result, err)
+	}
+
+	if _, err := ns.GetNode(ctx, tenantID, "imp-a"); err != nil {
+		t.Errorf("committed node: %v", err)
+	}
+
+	if err := es.PutImportChunk(ctx, tenantID, sess.ID, 3, chunks[0]); !errors.Is(err, models.ErrImportSessionClosed) {
+		t.Errorf("chunk after commit: err = %v, want ErrImportSessionClosed", err)
+	}
+
+	got, err = es.GetImportSession(ctx, tenantID, sess.ID)
+	if err != nil || got.Status != models.ImportSessionCommitted || got.Result == nil || len(got.Chunks) != 0 {
+		t.Errorf("committed session = %+v, %v", got, err)
+	}
+}
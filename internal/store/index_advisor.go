@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxAdvisorStatements caps how many of the most expensive recorded
+// statements the index advisor reads.
+const maxAdvisorStatements = 1000
+
+// IndexAdvisorStore reads pg_stat_statements and the kg_nodes indexes for
+// the index advisor, and creates the indexes it recommends. Like
+// VectorIndexStore it reads the catalog and cluster-wide statistics, not
+// tenant rows, so it runs without a tenant.
+type IndexAdvisorStore struct {
+	Pool *dbpool.Pool
+}
+
+// NewIndexAdvisorStore creates a new IndexAdvisorStore.
+func NewIndexAdvisorStore(pool *dbpool.Pool) *IndexAdvisorStore {
+	return &IndexAdvisorStore{Pool: pool}
+}
+
+// NodeStatementStats returns this database's recorded statements that read
+// kg_nodes, most total time first. ok is false when pg_stat_statements is
+// not installed or not loaded.
+func (s *IndexAdvisorStore) NodeStatementStats(ctx context.Context) (_ []models.StatementStats, ok bool, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	installed, err := s.ExtensionInstalled(ctx, "pg_stat_statements")
+	if err != nil || !installed {
+		return nil, false, err
+	}
+
+	rows, err := s.Pool.Query(ctx, `SELECT query, calls, total_exec_time
+		FROM pg_stat_statements
+		WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+			AND query ~ $1
+		ORDER BY total_exec_time DESC
+		LIMIT $2`, `\mkg_nodes\M`, maxAdvisorStatements)
+	if err != nil {
+		return nil, false, fmt.Errorf("querying pg_stat_statements: %w", err)
+	}
+
+	stats, err := pgx.CollectRows(rows, pgx.RowToStructByPos[models.StatementStats])
+
+	// The extension exists but shared_preload_libraries does not load it.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "55000" {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("scanning pg_stat_statements: %w", err)
+	}
+
+	return stats, true, nil
+}
+
+// NodeIndexes returns the valid indexes on kg_nodes with their key columns.
+// On a partitioned table these are the parent's partitioned indexes.
+func (s *IndexAdvisorStore) NodeIndexes(ctx context.Context) ([]models.IndexInfo, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT c.relname::text, am.amname::text,
+			ARRAY(SELECT pg_get_indexdef(x.indexrelid, k, true) FROM generate_series(1, x.indnkeyatts) k)
+		FROM pg_index x
+		JOIN pg_class c ON c.oid = x.indexrelid
+		JOIN pg_am am ON am.oid = c.relam
+		WHERE x.indrelid = 'kg_nodes'::regclass AND x.indisvalid
+		ORDER BY c.relname`)
+	if err != nil {
+		return nil, fmt.Errorf("listing kg_nodes indexes: %w", err)
+	}
+
+	indexes, err := pgx.CollectRows(rows, pgx.RowToStructByPos[models.IndexInfo])
+	if err != nil {
+		return nil, fmt.Errorf("scanning kg_nodes indexes: %w", err)
+	}
+
+	return indexes, nil
+}
+
+// ExtensionInstalled reports whether the named extension is installed in
+// this database.
+func (s *IndexAdvisorStore) ExtensionInstalled(ctx context.Context, name string) (bool, error) {
+	var installed bool
+	if err := s.Pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = $1)`, name,
+	).Scan(&installed); err != nil {
+		return false, fmt.Errorf("checking extension %s: %w", name, err)
+	}
+
+	return installed, nil
+}
+
+// CreateNodeIndex builds rec on kg_nodes, concurrently unless the table is
+// partitioned, and does nothing if a valid index of that name exists. An
+// invalid one left by a failed build is dropped first. rec must be one of
+// the advisor's fixed recommendations, so formatting it in is safe.
+func (s *IndexAdvisorStore) CreateNodeIndex(ctx context.Context, rec models.IndexRecommendation) error {
+	var invalid bool
+
+	err := s.Pool.QueryRow(ctx,
+		`SELECT NOT indisvalid FROM pg_index WHERE indexrelid = to_regclass($1)`, rec.Name,
+	).Scan(&invalid)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("checking index %s: %w", rec.Name, err)
+	}
+
+	if invalid {
+		if _, err := s.Pool.Exec(ctx, `DROP INDEX IF EXISTS `+rec.Name); err != nil {
+			return fmt.Errorf("dropping invalid index %s: %w", rec.Name, err)
+		}
+	}
+
+	partitions, err := db.PartitionCount(ctx, s.Pool, "kg_nodes")
+	if err != nil {
+		return err
+	}
+
+	createIndex := `CREATE INDEX CONCURRENTLY IF NOT EXISTS`
+	if partitions > 0 {
+		createIndex = `CREATE INDEX IF NOT EXISTS`
+	}
+
+	if _, err := s.Pool.Exec(ctx, fmt.Sprintf(`%s %s ON kg_nodes USING %s (%s)`,
+		createIndex, rec.Name, rec.Method, strings.Join(rec.Columns, ", "),
+	)); err != nil {
+		return fmt.Errorf("creating index %s: %w", rec.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// GetJSONLDContext returns the tenant's JSON-LD context, or nil when it has
+// not set one.
+func (s *ExportStore) GetJSONLDContext(ctx context.Context, tenantID string) (*models.JSONLDContext, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var raw []byte
+
+	err := s.Pool.QueryRow(ctx, `SELECT jsonld_context FROM tenants WHERE id = $1`, tenantID).Scan(&raw)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting JSON-LD context: %w", err)
+	}
+
+	if raw == nil {
+		return nil, nil
+	}
+
+	var c models.JSONLDContext
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("decoding JSON-LD context: %w", err)
+	}
+
+	return &c, nil
+}
+
+// SetJSONLDContext replaces the tenant's JSON-LD context; nil removes it.
+func (s *ExportStore) SetJSONLDContext(ctx context.Context, tenantID string, c *models.JSONLDContext) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var raw []byte
+
+	if c != nil {
+		var err error
+		if raw, err = json.Marshal(c); err != nil {
+			return fmt.Errorf("encoding JSON-LD context: %w", err)
+		}
+	}
+
+	tag, err := s.Pool.Exec(ctx, `UPDATE tenants SET jsonld_context = $2::jsonb WHERE id = $1`, tenantID, raw)
+	if err != nil {
+		return fmt.Errorf("setting JSON-LD context: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrTenantNotFound
+	}
+
+	return nil
+}
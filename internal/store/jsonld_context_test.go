@@ -0,0 +1,48 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestJSONLDContextRoundTrip(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	es := store.NewExportStore(base)
+	ctx := context.Background()
+
+	got, err := es.GetJSONLDContext(ctx, tenantID)
+	if err != nil || got != nil {
+		t.Fatalf("unset context = %+v, %v; want nil", got, err)
+	}
+
+	want := &models.JSONLDContext{
+		Vocab:    "https://example.org/vocab#",
+		Prefixes: map[string]string{"schema": "https://schema.org/"},
+		Types:    map[string]string{"person": "schema:Person"},
+	}
+	if err := es.SetJSONLDContext(ctx, tenantID, want); err != nil {
+		t.Fatalf("SetJSONLDContext: %v", err)
+	}
+
+	got, err = es.GetJSONLDContext(ctx, tenantID)
+	if err != nil || got == nil || got.Vocab != want.Vocab || got.Types["person"] != "schema:Person" {
+		t.Fatalf("GetJSONLDContext = %+v, %v", got, err)
+	}
+
+	if err := es.SetJSONLDContext(ctx, tenantID, nil); err != nil {
+		t.Fatalf("clearing context: %v", err)
+	}
+
+	if got, err = es.GetJSONLDContext(ctx, tenantID); err != nil || got != nil {
+		t.Errorf("cleared context = %+v, %v; want nil", got, err)
+	}
+
+	missing := "00000000-0000-0000-0000-000000000000"
+	if err := es.SetJSONLDContext(ctx, missing, want); !errors.Is(err, models.ErrTenantNotFound) {
+		t.Errorf("missing tenant: err = %v, want ErrTenantNotFound", err)
+	}
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// metapathColumns lists the columns selected for metapath queries.
+const metapathColumns = `name, description, start_type, steps, created_at, updated_at`
+
+// MetapathStore persists named metapaths and runs them.
+type MetapathStore struct {
+	Base
+}
+
+// NewMetapathStore creates a new MetapathStore.
+func NewMetapathStore(base Base) *MetapathStore {
+	return &MetapathStore{Base: base}
+}
+
+// scanMetapath scans one row of metapathColumns.
+func scanMetapath(row pgx.Row) (*models.Metapath, error) {
+	var m models.Metapath
+	if err := row.Scan(&m.Name, &m.Description, &m.StartType, &m.Steps, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// PutMetapath creates the named metapath or replaces its definition.
+func (s *MetapathStore) PutMetapath(
+	ctx context.Context, tenantID, name string, req models.PutMetapathRequest,
+) (*models.Metapath, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("putting metapath: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	m, err := scanMetapath(tx.QueryRow(ctx,
+		`INSERT INTO kg_metapaths (tenant_id, name, description, start_type, steps)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4::jsonb)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET
+			description = EXCLUDED.description,
+			start_type = EXCLUDED.start_type,
+			steps = EXCLUDED.steps,
+			updated_at = NOW()
+		RETURNING `+metapathColumns,
+		name, req.Description, req.StartType, req.Steps,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("upserting metapath: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing metapath: %w", err)
+	}
+
+	return m, nil
+}
+
+// GetMetapath returns the named metapath, or models.ErrMetapathNotFound.
+func (s *MetapathStore) GetMetapath(ctx context.Context, tenantID, name string) (*models.Metapath, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting metapath: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	m, err := scanMetapath(tx.QueryRow(ctx,
+		`SELECT `+metapathColumns+` FROM kg_metapaths
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND name = $1`, name,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrMetapathNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading metapath: %w", err)
+	}
+
+	return m, nil
+}
+
+// ListMetapaths returns the tenant's metapaths ordered by name.
+func (s *MetapathStore) ListMetapaths(ctx context.Context, tenantID string) ([]models.Metapath, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing metapaths: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+metapathColumns+` FROM kg_metapaths
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying metapaths: %w", err)
+	}
+
+	metapaths, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Metapath, error) {
+		m, err := scanMetapath(row)
+		if err != nil {
+			return models.Metapath{}, err
+		}
+
+		return *m, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning metapaths: %w", err)
+	}
+
+	return metapaths, nil
+}
+
+// DeleteMetapath deletes the named metapath, or returns
+// models.ErrMetapathNotFound.
+func (s *MetapathStore) DeleteMetapath(ctx context.Context, tenantID, name string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting metapath: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM kg_metapaths WHERE tenant_id = current_setting('app.tenant_id')::uuid AND name = $1`, name,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting metapath: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrMetapathNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing metapath delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// RunMetapath follows m's steps from startID as one join chain and returns
+// up to limit paths, those ending at the most salient nodes first. It
+// returns models.ErrNodeNotFound when the start node does not exist.
+func (s *MetapathStore) RunMetapath(
+	ctx context.Context, tenantID string, m *models.Metapath, startID string, limit int, opts models.ReadOpts,
+) (*models.MetapathResult, error) {
+	defer observeOperation("RunMetapath", time.Now())
+
+	if limit <= 0 {
+		limit = models.DefaultMetapathLimit
+	}
+
+	limit = min(limit, models.MaxMetapathLimit)
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReplicaTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("running metapath: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if err := requireGraphNodesExist(ctx, tx, startID); err != nil {
+		return nil, err
+	}
+
+	paths, err := queryMetapaths(ctx, tx, m, startID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.MetapathResult{Metapath: m.Name, StartID: startID, Paths: paths, Nodes: []models.Node{}}
+	if len(paths) > limit {
+		result.Paths = paths[:limit]
+		result.HasMore = true
+	}
+
+	if ids := pathNodeIDs(result.Paths); len(ids) > 0 {
+		if result.Nodes, err = s.metapathNodes(ctx, tx, tenantID, ids, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing metapath: %w", err)
+	}
+
+	return result, nil
+}
+
+// queryMetapaths returns up to limit paths of m from startID, each the IDs
+// of the nodes along it. It never returns a nil slice without an error.
+func queryMetapaths(ctx context.Context, tx pgx.Tx, m *models.Metapath, startID string, limit int) ([][]string, error) {
+	sql, args := metapathQuery(m, startID, limit)
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("executing metapath: %w", err)
+	}
+
+	paths, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) ([]string, error) {
+		path := make([]string, len(m.Steps)+1)
+		dest := make([]any, len(path), len(path)+1)
+		for i := range path {
+			dest[i] = &path[i]
+		}
+
+		// The last node's salience is selected only to order by.
+		var salience float64
+
+		return path, row.Scan(append(dest, &salience)...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning metapath: %w", err)
+	}
+
+	if paths == nil {
+		paths = [][]string{}
+	}
+
+	return paths, nil
+}
+
+// pathNodeIDs returns the distinct node IDs on paths in first-seen order.
+func pathNodeIDs(paths [][]string) []string {
+	seen := make(map[string]bool)
+	ids := make([]string, 0, len(paths)*2)
+	for _, path := range paths {
+		for _, id := range path {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
+
+// metapathNodes loads the nodes with ids, ordered by ID.
+func (s *MetapathStore) metapathNodes(
+	ctx context.Context, tx pgx.Tx, tenantID string, ids []string, opts models.ReadOpts,
+) ([]models.Node, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+nodeColumns+` FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = ANY($1)
+		ORDER BY id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("querying metapath nodes: %w", err)
+	}
+
+	nodes, err := collectNodes(rows)
+	if err != nil {
+		return nil, fmt.Errorf("collecting metapath nodes: %w", err)
+	}
+
+	if err := s.readNodeProperties(ctx, tenantID, nodes, opts.OmitProperties); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// metapathQuery builds the join chain that follows m's steps from startID:
+// node n0 is the start node and edge ei leads from node n(i-1) to node ni.
+func metapathQuery(m *models.Metapath, startID string, limit int) (string, []any) {
+	args := []any{startID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	selects := make([]string, 0, len(m.Steps)+1)
+	selects = append(selects, "n0.id")
+
+	var joins strings.Builder
+
+	for i, step := range m.Steps {
+		from, to := "source", "target"
+		if step.Direction == models.MetapathIn {
+			from, to = to, from
+		}
+
+		prev, edge, node := fmt.Sprintf("n%d", i), fmt.Sprintf("e%d", i+1), fmt.Sprintf("n%d", i+1)
+		fmt.Fprintf(&joins, `
+		JOIN kg_edges %[2]s ON %[2]s.tenant_id = %[1]s.tenant_id AND %[2]s.%[4]s = %[1]s.id AND %[2]s.relation = %[5]s
+		JOIN kg_nodes %[3]s ON %[3]s.tenant_id = %[2]s.tenant_id AND %[3]s.id = %[2]s.%[6]s`,
+			prev, edge, node, from, arg(step.Relation), to)
+
+		if step.Type != "" {
+			fmt.Fprintf(&joins, " AND %s.type = %s", node, arg(step.Type))
+		}
+
+		selects = append(selects, node+".id")
+	}
+
+	where := "n0.tenant_id = current_setting('app.tenant_id')::uuid AND n0.id = $1"
+	if m.StartType != "" {
+		where += " AND n0.type = " + arg(m.StartType)
+	}
+
+	last := fmt.Sprintf("n%d", len(m.Steps))
+	sql := `SELECT DISTINCT ` + strings.Join(selects, ", ") + `, ` + last + `.salience_score
+		FROM kg_nodes n0` + joins.String() + `
+		WHERE ` + where + `
+		ORDER BY ` + last + `.salience_score DESC, ` + strings.Join(selects[1:], ", ") + `
+		LIMIT ` + arg(limit)
+
+	return sql, args
+}
@@ -0,0 +1,68 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestMetapathStore(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	metapaths := store.NewMetapathStore(base)
+	ctx := context.Background()
+
+	alice := createTestNode(t, ns, tenantID, "Alice")
+	acme := createTestNode(t, ns, tenantID, "Acme")
+	berlin := createTestNode(t, ns, tenantID, "Berlin")
+
+	for _, e := range [][3]string{{alice.ID, acme.ID, "works_at"}, {acme.ID, berlin.ID, "located_in"}} {
+		if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: e[0], Target: e[1], Relation: e[2]}); err != nil {
+			t.Fatalf("CreateEdge(%v): %v", e, err)
+		}
+	}
+
+	req := models.PutMetapathRequest{Steps: []models.MetapathStep{{Relation: "works_at"}, {Relation: "located_in"}}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	m, err := metapaths.PutMetapath(ctx, tenantID, "employer-city", req)
+	if err != nil {
+		t.Fatalf("PutMetapath: %v", err)
+	}
+
+	result, err := metapaths.RunMetapath(ctx, tenantID, m, alice.ID, 10, models.ReadOpts{})
+	if err != nil {
+		t.Fatalf("RunMetapath: %v", err)
+	}
+
+	if len(result.Paths) != 1 || result.Paths[0][2] != berlin.ID || len(result.Nodes) != 3 {
+		t.Errorf("RunMetapath = %+v, want one path to Berlin", result)
+	}
+
+	// Walking the chain backwards from the city finds the employee.
+	reverse := &models.Metapath{Name: "city-employees", Steps: []models.MetapathStep{
+		{Relation: "located_in", Direction: models.MetapathIn},
+		{Relation: "works_at", Direction: models.MetapathIn},
+	}}
+	if result, err := metapaths.RunMetapath(ctx, tenantID, reverse, berlin.ID, 10, models.ReadOpts{}); err != nil || len(result.Paths) != 1 || result.Paths[0][2] != alice.ID {
+		t.Errorf("RunMetapath in reverse = %+v, %v; want one path to Alice", result, err)
+	}
+
+	if _, err := metapaths.RunMetapath(ctx, tenantID, m, "no-such-node", 10, models.ReadOpts{}); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("RunMetapath missing start: err = %v, want ErrNodeNotFound", err)
+	}
+
+	if err := metapaths.DeleteMetapath(ctx, tenantID, "employer-city"); err != nil {
+		t.Fatalf("DeleteMetapath: %v", err)
+	}
+
+	if _, err := metapaths.GetMetapath(ctx, tenantID, "employer-city"); !errors.Is(err, models.ErrMetapathNotFound) {
+		t.Errorf("GetMetapath after delete: err = %v, want ErrMetapathNotFound", err)
+	}
+}
@@ -0,0 +1,13 @@
+package store
+
+import (
+	"time"
+
+	"github.com/persistorai/persistor/internal/metrics"
+)
+
+// observeOperation records the latency of a store operation that began at
+// start. Call it as defer observeOperation("CreateNode", time.Now()).
+func observeOperation(operation string, start time.Time) {
+	metrics.StoreOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// ModelUsageStore records the embedding and LLM usage tenants are billed
+// and budgeted for.
+type ModelUsageStore struct {
+	Base
+}
+
+// NewModelUsageStore creates a new ModelUsageStore.
+func NewModelUsageStore(base Base) *ModelUsageStore {
+	return &ModelUsageStore{Base: base}
+}
+
+// usageMonth returns the first day of t's calendar month in UTC, the key
+// model usage is counted under.
+func usageMonth(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// RecordModelUsage adds usage, keyed by kind, to the tenant's totals for the
+// month containing at.
+func (s *ModelUsageStore) RecordModelUsage(
+	ctx context.Context, tenantID string, at time.Time, usage map[string]models.ModelUsage,
+) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	kinds := make([]string, 0, len(usage))
+	requests := make([]int64, 0, len(usage))
+	tokens := make([]int64, 0, len(usage))
+	for kind, u := range usage {
+		kinds = append(kinds, kind)
+		requests = append(requests, u.Requests)
+		tokens = append(tokens, u.Tokens)
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("recording model usage: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `INSERT INTO kg_model_usage (tenant_id, month, kind, requests, tokens)
+		SELECT current_setting('app.tenant_id')::uuid, $1, u.kind, u.requests, u.tokens
+		FROM unnest($2::text[], $3::bigint[], $4::bigint[]) AS u(kind, requests, tokens)
+		ORDER BY u.kind
+		ON CONFLICT (tenant_id, month, kind) DO UPDATE SET
+			requests = kg_model_usage.requests + EXCLUDED.requests,
+			tokens = kg_model_usage.tokens + EXCLUDED.tokens`,
+		usageMonth(at), kinds, requests, tokens,
+	); err != nil {
+		return fmt.Errorf("recording model usage: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing model usage: %w", err)
+	}
+
+	return nil
+}
+
+// GetTokenBudget returns the tenant's recorded kind tokens for the month
+// containing at and its monthly budget for them, nil if unlimited.
+func (s *ModelUsageStore) GetTokenBudget(
+	ctx context.Context, tenantID, kind string, at time.Time,
+) (used int64, budget *int64, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting token budget: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	quota, err := tenantQuota(ctx, tx, tenantID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	byKind, err := monthlyModelUsage(ctx, tx, usageMonth(at))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return byKind[kind].Tokens, quota.TokenBudget(kind), nil
+}
+
+// monthlyModelUsage returns the tenant's model usage for month by kind. Run
+// with the tenant context set.
+func monthlyModelUsage(ctx context.Context, tx pgx.Tx, month time.Time) (map[string]models.ModelUsage, error) {
+	rows, err := tx.Query(ctx, `SELECT kind, requests, tokens FROM kg_model_usage
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND month = $1`, month)
+	if err != nil {
+		return nil, fmt.Errorf("reading model usage: %w", err)
+	}
+	defer rows.Close()
+
+	byKind := make(map[string]models.ModelUsage)
+
+	for rows.Next() {
+		var (
+			kind string
+			u    models.ModelUsage
+		)
+		if err := rows.Scan(&kind, &u.Requests, &u.Tokens); err != nil {
+			return nil, fmt.Errorf("scanning model usage: %w", err)
+		}
+
+		byKind[kind] = u
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading model usage: %w", err)
+	}
+
+	return byKind, nil
+}
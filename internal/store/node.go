@@ -5,6 +5,7 @@
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -28,6 +29,8 @@ func (s *NodeStore) CreateNode(
 	tenantID string,
 	req models.CreateNodeRequest,
 ) (*models.Node, error) {
+	defer observeOperation("CreateNode", time.Now())
+
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -50,11 +53,17 @@ func (s *NodeStore) CreateNode(
 
 	searchText := models.BuildNodeSearchText(&models.Node{Type: req.Type, Label: req.Label, Properties: props})
 
-	query := `INSERT INTO kg_nodes (id, tenant_id, type, label, properties, search_text)
-		VALUES ($1, $2, $3, $4, $5, $6)
+	searchProps, err := s.searchProps(props)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO kg_nodes (id, tenant_id, type, label, properties, search_text, search_lang, search_props)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::regconfig, $8)
 		RETURNING ` + nodeColumns
 
-	row := tx.QueryRow(ctx, query, req.ID, tenantID, req.Type, req.Label, propsJSON, searchText)
+	row := tx.QueryRow(ctx, query, req.ID, tenantID, req.Type, req.Label, propsJSON, searchText,
+		s.searchLanguage(searchText), searchProps)
 
 	n, err := scanNode(row.Scan)
 	if err != nil {
@@ -70,11 +79,15 @@ func (s *NodeStore) CreateNode(
 		return nil, err
 	}
 
+	if err := enforceQuota(ctx, tx, tenantID, 1, 0); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing create node: %w", err)
 	}
 
-	s.notify("kg_nodes", "insert", tenantID)
+	s.notifyNode("insert", tenantID, n.ID, n.Type, nil)
 
 	return n, nil
 }
@@ -141,6 +154,8 @@ func (s *NodeStore) UpdateNode(
 	nodeID string,
 	req models.UpdateNodeRequest,
 ) (*models.Node, error) {
+	defer observeOperation("UpdateNode", time.Now())
+
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -193,8 +208,21 @@ func (s *NodeStore) UpdateNode(
 		if err != nil {
 			return nil, err
 		}
-		setClauses = append(setClauses, fmt.Sprintf("search_text = $%d", argIdx))
-		args = append(args, searchText)
+		setClauses = append(setClauses,
+			fmt.Sprintf("search_text = $%d", argIdx),
+			fmt.Sprintf("search_lang = $%d::regconfig", argIdx+1))
+		args = append(args, searchText, s.searchLanguage(searchText))
+		argIdx += 2
+	}
+
+	if req.Properties != nil {
+		searchProps, err := s.searchProps(req.Properties)
+		if err != nil {
+			return nil, err
+		}
+
+		setClauses = append(setClauses, fmt.Sprintf("search_props = $%d", argIdx))
+		args = append(args, searchProps)
 		argIdx++
 	}
 
@@ -232,11 +260,34 @@ func (s *NodeStore) UpdateNode(
 		}
 	}
 
+	if req.Type != nil {
+		if err := recordNodeFieldChange(ctx, tx, tenantID, nodeID, models.HistoryFieldType, currentType, n.Type, ""); err != nil {
+			return nil, fmt.Errorf("recording type history: %w", err)
+		}
+	}
+
+	if req.Label != nil {
+		if err := recordNodeFieldChange(ctx, tx, tenantID, nodeID, models.HistoryFieldLabel, currentLabel, n.Label, ""); err != nil {
+			return nil, fmt.Errorf("recording label history: %w", err)
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing update node: %w", err)
 	}
 
-	s.notify("kg_nodes", "update", tenantID)
+	detail := &models.ChangeDetail{NodeID: nodeID, Fields: map[string]models.FieldChange{}}
+	if req.Type != nil && currentType != n.Type {
+		detail.Fields[models.HistoryFieldType] = models.FieldChange{Old: currentType, New: n.Type}
+	}
+	if req.Label != nil && currentLabel != n.Label {
+		detail.Fields[models.HistoryFieldLabel] = models.FieldChange{Old: currentLabel, New: n.Label}
+	}
+	if req.Properties != nil {
+		detail.Properties = changedPropertyKeys(oldProps, req.Properties)
+	}
+
+	s.notifyNode("update", tenantID, nodeID, n.Type, detail)
 
 	return n, nil
 }
@@ -280,12 +331,17 @@ func (s *NodeStore) PatchNodeProperties(
 	}
 	searchText := models.BuildNodeSearchText(&models.Node{Type: currentType, Label: currentLabel, Properties: merged})
 
+	searchProps, err := s.searchProps(merged)
+	if err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(
-		"UPDATE kg_nodes SET properties = $1, search_text = $2 WHERE tenant_id = $3 AND id = $4 RETURNING %s",
+		"UPDATE kg_nodes SET properties = $1, search_text = $2, search_lang = $3::regconfig, search_props = $4 WHERE tenant_id = $5 AND id = $6 RETURNING %s",
 		nodeColumns,
 	)
 
-	row := tx.QueryRow(ctx, query, propsJSON, searchText, tenantID, nodeID)
+	row := tx.QueryRow(ctx, query, propsJSON, searchText, s.searchLanguage(searchText), searchProps, tenantID, nodeID)
 
 	n, err := scanNode(row.Scan)
 	if err != nil {
@@ -308,13 +364,20 @@ func (s *NodeStore) PatchNodeProperties(
 		return nil, fmt.Errorf("committing patch node properties: %w", err)
 	}
 
-	s.notify("kg_nodes", "update", tenantID)
+	s.notifyNode("update", tenantID, nodeID, n.Type, &models.ChangeDetail{
+		NodeID:     nodeID,
+		Properties: changedPropertyKeys(oldProps, merged),
+	})
 
 	return n, nil
 }
 
-// DeleteNode removes a node by ID and its associated edges within the same transaction.
-func (s *NodeStore) DeleteNode(ctx context.Context, tenantID, nodeID string) error {
+// DeleteNode removes a node by ID. With models.DeleteDetach its edges are
+// deleted in the same transaction; with models.DeleteRestrict the delete fails
+// with models.ErrNodeHasEdges while any edge still references the node.
+func (s *NodeStore) DeleteNode(ctx context.Context, tenantID, nodeID, mode string) error {
+	defer observeOperation("DeleteNode", time.Now())
+
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -325,25 +388,47 @@ func (s *NodeStore) DeleteNode(ctx context.Context, tenantID, nodeID string) err
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	_, err = tx.Exec(ctx, "DELETE FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid AND (source = $1 OR target = $1)", nodeID)
-	if err != nil {
-		return fmt.Errorf("deleting edges for node: %w", err)
+	if mode == models.DeleteDetach {
+		_, err = tx.Exec(ctx, "DELETE FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid AND (source = $1 OR target = $1)", nodeID)
+		if err != nil {
+			return fmt.Errorf("deleting edges for node: %w", err)
+		}
+	} else {
+		var edges int
+		err = tx.QueryRow(ctx,
+			"SELECT COUNT(*) FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid AND (source = $1 OR target = $1)",
+			nodeID).Scan(&edges)
+		if err != nil {
+			return fmt.Errorf("counting edges for node: %w", err)
+		}
+
+		if edges > 0 {
+			return fmt.Errorf("%w: %d", models.ErrNodeHasEdges, edges)
+		}
 	}
 
-	tag, err := tx.Exec(ctx, "DELETE FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1", nodeID)
+	var nodeType string
+
+	err = tx.QueryRow(ctx,
+		"DELETE FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1 RETURNING type",
+		nodeID).Scan(&nodeType)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ErrNodeNotFound
+		}
+
 		return fmt.Errorf("executing node delete: %w", err)
 	}
 
-	if tag.RowsAffected() == 0 {
-		return models.ErrNodeNotFound
+	if err := deleteNodeDependents(ctx, tx, []string{nodeID}); err != nil {
+		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("committing delete node: %w", err)
 	}
 
-	s.notify("kg_nodes", "delete", tenantID)
+	s.notifyNode("delete", tenantID, nodeID, nodeType, nil)
 
 	return nil
 }
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// NodeAccessStore writes node usage: access_count and last_accessed.
+type NodeAccessStore struct {
+	Base
+}
+
+// NewNodeAccessStore creates a new NodeAccessStore.
+func NewNodeAccessStore(base Base) *NodeAccessStore {
+	return &NodeAccessStore{Base: base}
+}
+
+// RecordNodeAccess adds the counted reads to each node's access_count and
+// moves last_accessed forward. Nodes deleted since they were read are
+// skipped. The nodes_updated trigger ignores these columns, so updated_at is
+// unchanged, and no change notification is sent.
+func (s *NodeAccessStore) RecordNodeAccess(ctx context.Context, tenantID string, accesses []models.NodeAccess) error {
+	if len(accesses) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	ids := make([]string, len(accesses))
+	counts := make([]int64, len(accesses))
+	times := make([]time.Time, len(accesses))
+	for i, a := range accesses {
+		ids[i], counts[i], times[i] = a.NodeID, a.Count, a.LastAccessed
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("recording node access: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	// Rows are locked in ID order so concurrent flushes from several
+	// replicas cannot deadlock.
+	if _, err := tx.Exec(ctx, `UPDATE kg_nodes n
+		SET access_count = n.access_count + a.count,
+			last_accessed = GREATEST(n.last_accessed, a.at)
+		FROM (
+			SELECT id, SUM(count) AS count, MAX(at) AS at
+			FROM unnest($1::text[], $2::bigint[], $3::timestamptz[]) AS u(id, count, at)
+			GROUP BY id
+		) a
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid
+			AND n.id = a.id
+			AND n.id IN (
+				SELECT id FROM kg_nodes
+				WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = ANY($1)
+				ORDER BY id
+				FOR UPDATE
+			)`,
+		ids, counts, times,
+	); err != nil {
+		return fmt.Errorf("recording node access: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing node access: %w", err)
+	}
+
+	return nil
+}
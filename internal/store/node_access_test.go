@@ -0,0 +1,52 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestRecordNodeAccess(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	as := store.NewNodeAccessStore(base)
+	ctx := context.Background()
+
+	node := createTestNode(t, ns, tenantID, "Accessed")
+	at := time.Now().UTC().Truncate(time.Microsecond)
+
+	err := as.RecordNodeAccess(ctx, tenantID, []models.NodeAccess{
+		{NodeID: node.ID, Count: 3, LastAccessed: at},
+		{NodeID: "deleted-since", Count: 1, LastAccessed: at},
+	})
+	if err != nil {
+		t.Fatalf("RecordNodeAccess: %v", err)
+	}
+
+	got, err := ns.GetNode(ctx, tenantID, node.ID)
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if got.AccessCount != node.AccessCount+3 || got.LastAccessed == nil || !got.LastAccessed.Equal(at) {
+		t.Errorf("access_count = %d, last_accessed = %v; want %d at %s", got.AccessCount, got.LastAccessed, node.AccessCount+3, at)
+	}
+	if !got.UpdatedAt.Equal(node.UpdatedAt) {
+		t.Errorf("updated_at moved from %s to %s", node.UpdatedAt, got.UpdatedAt)
+	}
+
+	// An older read does not move last_accessed back.
+	if err := as.RecordNodeAccess(ctx, tenantID, []models.NodeAccess{{NodeID: node.ID, Count: 1, LastAccessed: at.Add(-time.Hour)}}); err != nil {
+		t.Fatalf("RecordNodeAccess: %v", err)
+	}
+
+	got, err = ns.GetNode(ctx, tenantID, node.ID)
+	if err != nil {
+		t.Fatalf("GetNode: %v", err)
+	}
+	if !got.LastAccessed.Equal(at) {
+		t.Errorf("last_accessed = %s, want %s", got.LastAccessed, at)
+	}
+}
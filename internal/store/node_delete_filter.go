@@ -0,0 +1,214 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// nodeFilterClause returns the WHERE conditions selecting f's nodes, with
+// placeholders numbered from argIdx.
+func nodeFilterClause(f models.NodeFilter, argIdx int) (string, []any) {
+	where := "tenant_id = current_setting('app.tenant_id')::uuid"
+	var args []any
+
+	if f.Type != "" {
+		where += fmt.Sprintf(" AND type = $%d", argIdx)
+		args = append(args, f.Type)
+		argIdx++
+	}
+
+	if f.MinSalience > 0 {
+		where += fmt.Sprintf(" AND salience_score >= $%d", argIdx)
+		args = append(args, f.MinSalience)
+	}
+
+	return where, args
+}
+
+// PreviewDeleteByFilter counts the nodes filter matches, lists a sample, and
+// issues a token for deleting them that is valid for DeletePreviewTTL. Expired previews of the tenant are
+// removed in the same transaction.
+func (s *NodeStore) PreviewDeleteByFilter(
+	ctx context.Context,
+	tenantID string,
+	filter models.NodeFilter,
+) (*models.DeletePreview, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	filterJSON, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling node filter: %w", err)
+	}
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("previewing delete by filter: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_delete_previews
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND expires_at < NOW()
+	`); err != nil {
+		return nil, fmt.Errorf("removing expired delete previews: %w", err)
+	}
+
+	where, args := nodeFilterClause(filter, 1)
+	preview := &models.DeletePreview{Filter: filter, Sample: []models.NodeSummary{}}
+
+	if err := tx.QueryRow(ctx, "SELECT count(*) FROM kg_nodes WHERE "+where, args...).Scan(&preview.Count); err != nil {
+		return nil, fmt.Errorf("counting nodes to delete: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		"SELECT id, type, label FROM kg_nodes WHERE "+where+fmt.Sprintf(" ORDER BY id LIMIT %d", models.DeletePreviewSampleSize),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sampling nodes to delete: %w", err)
+	}
+
+	for rows.Next() {
+		var n models.NodeSummary
+		if err := rows.Scan(&n.ID, &n.Type, &n.Label); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning node to delete: %w", err)
+		}
+		preview.Sample = append(preview.Sample, n)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating nodes to delete: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kg_delete_previews (tenant_id, filter, node_count, expires_at)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, NOW() + make_interval(secs => $3))
+		RETURNING token, expires_at
+	`, filterJSON, preview.Count, models.DeletePreviewTTL.Seconds()).Scan(&preview.Token, &preview.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("inserting delete preview: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing delete preview: %w", err)
+	}
+
+	return preview, nil
+}
+
+// DeleteByFilter deletes the nodes a preview reported, and their edges, in
+// one transaction. It fails with ErrDeletePreviewMismatch, deleting nothing,
+// if the filter differs from the preview's or no longer matches the
+// previewed number of nodes. The token is consumed on success.
+func (s *NodeStore) DeleteByFilter(
+	ctx context.Context,
+	tenantID string,
+	req models.DeleteByFilterRequest,
+) (*models.DeleteByFilterResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting by filter: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var filterJSON []byte
+	var previewCount int
+
+	err = tx.QueryRow(ctx, `
+		SELECT filter, node_count FROM kg_delete_previews
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND token = $1 AND expires_at >= NOW()
+		FOR UPDATE
+	`, req.Token).Scan(&filterJSON, &previewCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrDeletePreviewNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading delete preview: %w", err)
+	}
+
+	var previewed models.NodeFilter
+	if err := json.Unmarshal(filterJSON, &previewed); err != nil {
+		return nil, fmt.Errorf("decoding delete preview filter: %w", err)
+	}
+
+	if previewed != req.Filter {
+		return nil, models.ErrDeletePreviewMismatch
+	}
+
+	where, args := nodeFilterClause(req.Filter, 1)
+	result := &models.DeleteByFilterResult{}
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		  AND (source IN (SELECT id FROM kg_nodes WHERE `+where+`)
+		    OR target IN (SELECT id FROM kg_nodes WHERE `+where+`))
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("deleting edges of filtered nodes: %w", err)
+	}
+
+	result.EdgesDeleted = int(tag.RowsAffected())
+
+	rows, err := tx.Query(ctx, "DELETE FROM kg_nodes WHERE "+where+" RETURNING id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("deleting filtered nodes: %w", err)
+	}
+
+	deleted, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("deleting filtered nodes: %w", err)
+	}
+
+	if err := deleteNodeDependents(ctx, tx, deleted); err != nil {
+		return nil, err
+	}
+
+	result.NodesDeleted = len(deleted)
+	if result.NodesDeleted != previewCount {
+		return nil, models.ErrDeletePreviewMismatch
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_delete_previews
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND token = $1
+	`, req.Token); err != nil {
+		return nil, fmt.Errorf("consuming delete preview: %w", err)
+	}
+
+	err = insertAuditEntry(ctx, tx, tenantID, "node.delete_by_filter", "node", "", "", map[string]any{
+		"type":          req.Filter.Type,
+		"min_salience":  req.Filter.MinSalience,
+		"nodes_deleted": result.NodesDeleted,
+		"edges_deleted": result.EdgesDeleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing delete by filter: %w", err)
+	}
+
+	s.notify("kg_nodes", "delete", tenantID)
+	s.notify("kg_edges", "delete", tenantID)
+
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestDeleteByFilter(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	for _, req := range []models.CreateNodeRequest{
+		{ID: "old1", Type: "deprecated", Label: "Old 1"},
+		{ID: "old2", Type: "deprecated", Label: "Old 2"},
+		{ID: "keep", Type: "person", Label: "Keep"},
+	} {
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode %s: %v", req.ID, err)
+		}
+	}
+
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: "keep", Target: "old1", Relation: "knows"}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	filter := models.NodeFilter{Type: "deprecated"}
+
+	preview, err := ns.PreviewDeleteByFilter(ctx, tenantID, filter)
+	if err != nil {
+		t.Fatalf("PreviewDeleteByFilter: %v", err)
+	}
+	if preview.Count != 2 || len(preview.Sample) != 2 || preview.Token == "" {
+		t.Fatalf("preview = %+v", preview)
+	}
+
+	// A different filter cannot use the token.
+	_, err = ns.DeleteByFilter(ctx, tenantID, models.DeleteByFilterRequest{Filter: models.NodeFilter{Type: "person"}, Token: preview.Token})
+	if !errors.Is(err, models.ErrDeletePreviewMismatch) {
+		t.Fatalf("mismatched filter: err = %v, want ErrDeletePreviewMismatch", err)
+	}
+
+	// A new matching node makes the preview stale and nothing is deleted.
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "old3", Type: "deprecated", Label: "Old 3"}); err != nil {
+		t.Fatalf("CreateNode old3: %v", err)
+	}
+	_, err = ns.DeleteByFilter(ctx, tenantID, models.DeleteByFilterRequest{Filter: filter, Token: preview.Token})
+	if !errors.Is(err, models.ErrDeletePreviewMismatch) {
+		t.Fatalf("stale preview: err = %v, want ErrDeletePreviewMismatch", err)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, "old1"); err != nil {
+		t.Fatalf("stale delete removed nodes: %v", err)
+	}
+
+	preview, err = ns.PreviewDeleteByFilter(ctx, tenantID, filter)
+	if err != nil {
+		t.Fatalf("PreviewDeleteByFilter: %v", err)
+	}
+
+	result, err := ns.DeleteByFilter(ctx, tenantID, models.DeleteByFilterRequest{Filter: filter, Token: preview.Token})
+	if err != nil {
+		t.Fatalf("DeleteByFilter: %v", err)
+	}
+	if result.NodesDeleted != 3 || result.EdgesDeleted != 1 {
+		t.Errorf("deleted %d nodes, %d edges; want 3, 1", result.NodesDeleted, result.EdgesDeleted)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, "keep"); err != nil {
+		t.Errorf("non-matching node was deleted: %v", err)
+	}
+
+	// Tokens are single use.
+	_, err = ns.DeleteByFilter(ctx, tenantID, models.DeleteByFilterRequest{Filter: filter, Token: preview.Token})
+	if !errors.Is(err, models.ErrDeletePreviewNotFound) {
+		t.Errorf("reused token: err = %v, want ErrDeletePreviewNotFound", err)
+	}
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// nodeDependents are the tables holding rows keyed by node_id that belong to
+// one node. The schema has no foreign keys, so every path that deletes nodes
+// removes these rows itself, in the same transaction.
+var nodeDependents = []struct {
+	table string
+	what  string
+}{
+	{"kg_quarantine", "quarantine flags"},
+	{"kg_salience_snapshots", "salience snapshots"},
+}
+
+// deleteNodeDependents removes the rows that belong to the nodes nodeIDs,
+// which tx deletes, and the inference records of their deleted edges.
+func deleteNodeDependents(ctx context.Context, tx pgx.Tx, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	for _, d := range nodeDependents {
+		if _, err := tx.Exec(ctx,
+			`DELETE FROM `+d.table+` WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = ANY($1)`,
+			nodeIDs,
+		); err != nil {
+			return fmt.Errorf("deleting %s of removed nodes: %w", d.what, err)
+		}
+	}
+
+	return pruneInferredEdges(ctx, tx, nodeIDs)
+}
+
+// pruneInferredEdges removes the co-access inference records of edges
+// touching nodeIDs that no longer exist. Call it after deleting edges in tx.
+func pruneInferredEdges(ctx context.Context, tx pgx.Tx, nodeIDs []string) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM kg_inferred_edges i
+		WHERE i.tenant_id = current_setting('app.tenant_id')::uuid
+			AND (i.source = ANY($1) OR i.target = ANY($1))
+			AND NOT EXISTS (
+				SELECT 1 FROM kg_edges e
+				WHERE e.tenant_id = i.tenant_id AND e.source = i.source
+					AND e.target = i.target AND e.relation = i.relation
+			)`,
+		nodeIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting inference records of removed edges: %w", err)
+	}
+
+	return nil
+}
@@ -73,7 +73,7 @@ func TestPatchNodeProperties_FactConflictPreservesEvidence(t *testing.T) {
 		t.Fatalf("belief claim = %+v, want preferred supported claim", belief.Claims[0])
 	}
 
-	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", 10, 0)
+	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", "", 10, 0)
 	if err != nil {
 		t.Fatalf("GetPropertyHistory: %v", err)
 	}
@@ -127,7 +127,7 @@ func TestPatchNodeProperties_FactCorroborationAddsEvidenceWithoutHistoryChange(t
 		t.Fatalf("belief counts = %+v, want 1 evidence and 1 claim", belief)
 	}
 
-	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", 10, 0)
+	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", "", 10, 0)
 	if err != nil {
 		t.Fatalf("GetPropertyHistory: %v", err)
 	}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// propertyDiff represents a single property value change.
+type propertyDiff struct {
+	key      string
+	oldValue json.RawMessage
+	newValue json.RawMessage
+}
+
+// diffProperties computes changed, added, and removed keys between two property maps.
+func diffProperties(oldProps, newProps map[string]any) ([]propertyDiff, error) {
+	var diffs []propertyDiff
+
+	for k, newVal := range newProps {
+		newJSON, err := json.Marshal(newVal)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling new value for %s: %w", k, err)
+		}
+
+		oldVal, existed := oldProps[k]
+		if !existed {
+			diffs = append(diffs, propertyDiff{key: k, oldValue: nil, newValue: newJSON})
+
+			continue
+		}
+
+		oldJSON, err := json.Marshal(oldVal)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling old value for %s: %w", k, err)
+		}
+
+		if !bytes.Equal(oldJSON, newJSON) {
+			diffs = append(diffs, propertyDiff{key: k, oldValue: oldJSON, newValue: newJSON})
+		}
+	}
+
+	for k, oldVal := range oldProps {
+		if _, exists := newProps[k]; !exists {
+			oldJSON, err := json.Marshal(oldVal)
+			if err != nil {
+				return nil, fmt.Errorf("marshalling removed value for %s: %w", k, err)
+			}
+
+			diffs = append(diffs, propertyDiff{key: k, oldValue: oldJSON, newValue: nil})
+		}
+	}
+
+	return diffs, nil
+}
+
+// RecordPropertyChanges diffs oldProps and newProps, inserting a history row
+// for each changed key. Package-level so NodeStore can call it within its transaction.
+func RecordPropertyChanges(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, nodeID string,
+	oldProps, newProps map[string]any,
+	reason string,
+) error {
+	changes, err := diffProperties(oldProps, newProps)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	valueParts := make([]string, 0, len(changes))
+	args := make([]any, 0, len(changes)*6)
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	for i, c := range changes {
+		base := i*6 + 1
+		valueParts = append(valueParts, fmt.Sprintf(
+			"($%d, $%d, $%d, $%d, $%d, $%d)",
+			base, base+1, base+2, base+3, base+4, base+5,
+		))
+		args = append(args, tenantID, nodeID, c.key, c.oldValue, c.newValue, reasonPtr)
+	}
+
+	sql := `INSERT INTO kg_property_history (tenant_id, node_id, property_key, old_value, new_value, reason)
+		VALUES ` + strings.Join(valueParts, ", ")
+
+	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("inserting property history: %w", err)
+	}
+
+	return nil
+}
+
+// recordNodeFieldChange inserts a history row when a node's label, type, or
+// salience changed. Equal values are not recorded.
+func recordNodeFieldChange(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, nodeID, field string,
+	oldValue, newValue any,
+	reason string,
+) error {
+	oldJSON, err := json.Marshal(oldValue)
+	if err != nil {
+		return fmt.Errorf("marshalling old %s: %w", field, err)
+	}
+
+	newJSON, err := json.Marshal(newValue)
+	if err != nil {
+		return fmt.Errorf("marshalling new %s: %w", field, err)
+	}
+
+	if bytes.Equal(oldJSON, newJSON) {
+		return nil
+	}
+
+	var reasonPtr *string
+	if reason != "" {
+		reasonPtr = &reason
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO kg_property_history
+			(tenant_id, node_id, field, property_key, old_value, new_value, reason)
+		VALUES ($1, $2, $3, '', $4, $5, $6)`,
+		tenantID, nodeID, field, oldJSON, newJSON, reasonPtr,
+	); err != nil {
+		return fmt.Errorf("inserting %s history: %w", field, err)
+	}
+
+	return nil
+}
+
+// fetchNodeSalience reads and row-locks a node's salience score so a change
+// to it can be recorded.
+func fetchNodeSalience(ctx context.Context, tx pgx.Tx, nodeID string) (float64, error) {
+	var salience float64
+
+	err := tx.QueryRow(ctx,
+		`SELECT salience_score FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+		FOR UPDATE`,
+		nodeID,
+	).Scan(&salience)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, models.ErrNodeNotFound
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("fetching node salience: %w", err)
+	}
+
+	return salience, nil
+}
+
+// fetchNodeProperties loads and decrypts properties for a single node within a transaction.
+// Package-level so NodeStore.UpdateNode can call it.
+func fetchNodeProperties(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, nodeID string,
+	b *Base,
+) (map[string]any, error) {
+	var propsBytes []byte
+
+	err := tx.QueryRow(ctx,
+		`SELECT properties FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
+		nodeID,
+	).Scan(&propsBytes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNodeNotFound
+		}
+
+		return nil, fmt.Errorf("fetching node properties: %w", err)
+	}
+
+	props, err := b.decryptPropertiesRaw(ctx, tenantID, propsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting node properties: %w", err)
+	}
+
+	return props, nil
+}
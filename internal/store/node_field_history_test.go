@@ -0,0 +1,102 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestNodeFieldHistory(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ss := store.NewSalienceStore(base)
+	gs := store.NewGraphStore(base)
+	hs := store.NewHistoryStore(base)
+	ctx := context.Background()
+
+	node := createTestNode(t, ns, tenantID, "Bob")
+	before := time.Now()
+
+	label, nodeType := "Robert", "person"
+	if _, err := ns.UpdateNode(ctx, tenantID, node.ID, models.UpdateNodeRequest{Label: &label, Type: &nodeType}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+
+	// Re-sending the current label records nothing.
+	if _, err := ns.UpdateNode(ctx, tenantID, node.ID, models.UpdateNodeRequest{Label: &label}); err != nil {
+		t.Fatalf("UpdateNode with unchanged label: %v", err)
+	}
+
+	if _, err := ss.BoostNode(ctx, tenantID, node.ID); err != nil {
+		t.Fatalf("BoostNode: %v", err)
+	}
+
+	changes, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", "", 10, 0)
+	if err != nil {
+		t.Fatalf("GetPropertyHistory: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("changes = %+v, want label, type, and salience", changes)
+	}
+
+	labels, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", models.HistoryFieldLabel, 10, 0)
+	if err != nil {
+		t.Fatalf("GetPropertyHistory(label): %v", err)
+	}
+	if len(labels) != 1 || labels[0].PropertyKey != "" {
+		t.Fatalf("label changes = %+v, want one", labels)
+	}
+
+	var oldLabel string
+	if err := json.Unmarshal(labels[0].OldValue, &oldLabel); err != nil || oldLabel != "Bob" {
+		t.Errorf("old label = %q, %v; want Bob", oldLabel, err)
+	}
+
+	boosts, _, err := hs.GetPropertyHistory(ctx, tenantID, node.ID, "", models.HistoryFieldSalience, 10, 0)
+	if err != nil || len(boosts) != 1 || boosts[0].Reason == nil || *boosts[0].Reason != "boost" {
+		t.Errorf("salience changes = %+v, %v; want one boost", boosts, err)
+	}
+
+	snapshot, err := gs.GraphAsOf(ctx, tenantID, models.AsOfQuery{Timestamp: before})
+	if err != nil {
+		t.Fatalf("GraphAsOf: %v", err)
+	}
+	if len(snapshot.Nodes) != 1 || snapshot.Nodes[0].Label != "Bob" || snapshot.Nodes[0].Type != "concept" {
+		t.Errorf("as-of node = %+v, want original label and type", snapshot.Nodes)
+	}
+}
+
+func TestGraphAsOf_TypeFilterUsesPastType(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	gs := store.NewGraphStore(base)
+	ctx := context.Background()
+
+	node := createTestNode(t, ns, tenantID, "Bob")
+	before := time.Now()
+
+	nodeType := "person"
+	if _, err := ns.UpdateNode(ctx, tenantID, node.ID, models.UpdateNodeRequest{Type: &nodeType}); err != nil {
+		t.Fatalf("UpdateNode: %v", err)
+	}
+
+	past, err := gs.GraphAsOf(ctx, tenantID, models.AsOfQuery{Timestamp: before, Type: "concept"})
+	if err != nil {
+		t.Fatalf("GraphAsOf(concept): %v", err)
+	}
+	if len(past.Nodes) != 1 || past.Nodes[0].Type != "concept" {
+		t.Errorf("as-of concept nodes = %+v, want the node under its past type", past.Nodes)
+	}
+
+	current, err := gs.GraphAsOf(ctx, tenantID, models.AsOfQuery{Timestamp: before, Type: "person"})
+	if err != nil {
+		t.Fatalf("GraphAsOf(person): %v", err)
+	}
+	if len(current.Nodes) != 0 {
+		t.Errorf("as-of person nodes = %+v, want none before the type change", current.Nodes)
+	}
+}
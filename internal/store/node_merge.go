@@ -0,0 +1,179 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// MergeNode merges sourceID into targetID in a single transaction. Properties
+// are unioned using req.ConflictStrategy, edges, aliases and event links move
+// to the target, access counts are summed, the higher salience is kept, the
+// source is deleted, and the merge is recorded in the audit log.
+func (s *NodeStore) MergeNode(
+	ctx context.Context, tenantID, sourceID, targetID string, req models.MergeNodeRequest,
+) (*models.MergeNodeResult, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("merge node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	source, target, err := s.lockMergeNodes(ctx, tx, tenantID, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.MergeNodeResult{SourceID: sourceID, TargetID: targetID}
+
+	merged, added, conflicts := models.MergeNodeProperties(target.Properties, source.Properties, req.ConflictStrategy)
+	if req.ConflictStrategy == models.MergeFail && len(conflicts) > 0 {
+		return nil, fmt.Errorf("%w: %s", models.ErrMergePropertyConflict, strings.Join(conflicts, ", "))
+	}
+
+	result.PropertiesAdded, result.PropertyConflicts = added, conflicts
+
+	if err := retireMergedNode(ctx, tx, sourceID, targetID, result); err != nil {
+		return nil, err
+	}
+
+	result.Node, err = s.writeMergedNode(ctx, tx, tenantID, source, target, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordNodeMerge(ctx, tx, tenantID, source, target, merged, req, result); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing merge node: %w", err)
+	}
+
+	s.notify("kg_nodes", "update", tenantID)
+	s.notify("kg_edges", "update", tenantID)
+
+	return result, nil
+}
+
+// writeMergedNode stores the merged properties on the target and folds the
+// source's access count, last access, salience, and boost into it.
+func (s *NodeStore) writeMergedNode(
+	ctx context.Context, tx pgx.Tx, tenantID string, source, target *models.Node, merged map[string]any,
+) (*models.Node, error) {
+	propsJSON, err := s.encryptProperties(ctx, tenantID, merged)
+	if err != nil {
+		return nil, fmt.Errorf("preparing merged properties: %w", err)
+	}
+
+	searchText := models.BuildNodeSearchText(&models.Node{Type: target.Type, Label: target.Label, Properties: merged})
+
+	searchProps, err := s.searchProps(merged)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(ctx, `UPDATE kg_nodes SET
+			properties = $1,
+			search_text = $2,
+			search_lang = $3::regconfig,
+			search_props = $9,
+			access_count = access_count + $4,
+			last_accessed = GREATEST(last_accessed, $5),
+			salience_score = GREATEST(salience_score, $6),
+			user_boosted = user_boosted OR $7
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $8
+		RETURNING `+nodeColumns,
+		propsJSON, searchText, s.searchLanguage(searchText),
+		source.AccessCount, source.LastAccessed, source.Salience, source.UserBoosted, target.ID, searchProps,
+	)
+
+	node, err := scanNode(row.Scan)
+	if err != nil {
+		return nil, fmt.Errorf("scanning merged node: %w", err)
+	}
+
+	if err := s.decryptNode(ctx, tenantID, node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// recordNodeMerge records the target's property and salience changes in its
+// history and the merge itself in the audit log.
+func recordNodeMerge(
+	ctx context.Context, tx pgx.Tx, tenantID string, source, target *models.Node,
+	merged map[string]any, req models.MergeNodeRequest, result *models.MergeNodeResult,
+) error {
+	reason := "merged from " + source.ID
+	if err := RecordPropertyChanges(ctx, tx, tenantID, target.ID, filterHistoryProperties(target.Properties), filterHistoryProperties(merged), reason); err != nil {
+		return fmt.Errorf("recording property history: %w", err)
+	}
+
+	if err := recordNodeFieldChange(ctx, tx, tenantID, target.ID, models.HistoryFieldSalience, target.Salience, result.Node.Salience, reason); err != nil {
+		return fmt.Errorf("recording salience history: %w", err)
+	}
+
+	return insertAuditEntry(ctx, tx, tenantID, "node.merge", "node", target.ID, "", map[string]any{
+		"source_id":          source.ID,
+		"source_label":       source.Label,
+		"conflict_strategy":  req.ConflictStrategy,
+		"properties_added":   result.PropertiesAdded,
+		"property_conflicts": result.PropertyConflicts,
+		"edges_rewired":      result.EdgesRewired,
+		"edges_merged":       result.EdgesMerged,
+		"edges_dropped":      result.EdgesDropped,
+	})
+}
+
+// lockMergeNodes reads and row-locks both nodes of a merge and decrypts their
+// properties.
+func (s *NodeStore) lockMergeNodes(
+	ctx context.Context,
+	tx pgx.Tx,
+	tenantID, sourceID, targetID string,
+) (source, target *models.Node, err error) {
+	rows, err := tx.Query(ctx, `SELECT `+nodeColumns+` FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = ANY($1)
+		ORDER BY id
+		FOR UPDATE`,
+		[]string{sourceID, targetID},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("locking nodes for merge: %w", err)
+	}
+	defer rows.Close()
+
+	nodes, err := collectNodes(rows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("collecting nodes for merge: %w", err)
+	}
+
+	for i := range nodes {
+		switch nodes[i].ID {
+		case sourceID:
+			source = &nodes[i]
+		case targetID:
+			target = &nodes[i]
+		}
+	}
+
+	if source == nil || target == nil {
+		return nil, nil, models.ErrNodeNotFound
+	}
+
+	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+		return nil, nil, err
+	}
+
+	return source, target, nil
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// retireMergedNode moves the source node's edges and references to the
+// target, then deletes the source.
+func retireMergedNode(ctx context.Context, tx pgx.Tx, sourceID, targetID string, result *models.MergeNodeResult) error {
+	if err := mergeNodeEdges(ctx, tx, sourceID, targetID, result); err != nil {
+		return err
+	}
+
+	if err := moveNodeReferences(ctx, tx, sourceID, targetID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
+		sourceID,
+	); err != nil {
+		return fmt.Errorf("deleting merged source node: %w", err)
+	}
+
+	return deleteNodeDependents(ctx, tx, []string{sourceID})
+}
+
+// mergeNodeEdges moves the source node's edges to the target. Edges between
+// the two nodes would become self-loops and are dropped. An edge the target
+// already has with the same neighbour and relation is folded into the
+// target's edge, which keeps its properties and gains the source edge's
+// access count, weight, and salience.
+func mergeNodeEdges(ctx context.Context, tx pgx.Tx, sourceID, targetID string, result *models.MergeNodeResult) error {
+	tag, err := tx.Exec(ctx, `DELETE FROM kg_edges
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+			AND ((source = $1 AND target IN ($1, $2)) OR (source = $2 AND target = $1))`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return fmt.Errorf("dropping edges between merged nodes: %w", err)
+	}
+
+	result.EdgesDropped = int(tag.RowsAffected())
+
+	// The same statements handle outgoing and incoming edges with the
+	// endpoint columns swapped.
+	for _, cols := range [][2]string{{"source", "target"}, {"target", "source"}} {
+		if err := moveMergedEdgeEnds(ctx, tx, cols[0], cols[1], sourceID, targetID, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// moveMergedEdgeEnds points the edges whose moved column, source or target,
+// holds the source node at the target, folding the ones the target already
+// has into its own edges.
+func moveMergedEdgeEnds(
+	ctx context.Context, tx pgx.Tx, moved, other, sourceID, targetID string, result *models.MergeNodeResult,
+) error {
+	folded, err := foldMergedEdges(ctx, tx, moved, other, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	result.EdgesMerged += folded
+
+	if err := pruneInferredEdges(ctx, tx, []string{sourceID}); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE kg_edges SET `+moved+` = $2
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND `+moved+` = $1`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return fmt.Errorf("rewiring %s edges: %w", moved, err)
+	}
+
+	result.EdgesRewired += int(tag.RowsAffected())
+
+	// Rewired edges stay inferred edges if co-access inference made them.
+	_, err = tx.Exec(ctx, `UPDATE kg_inferred_edges SET `+moved+` = $2
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND `+moved+` = $1`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return fmt.Errorf("rewiring inference records of %s edges: %w", moved, err)
+	}
+
+	return nil
+}
+
+// foldMergedEdges folds each source edge the target already has, with the
+// same neighbour and relation, into the target's edge and deletes it. It
+// returns the number of folded edges.
+func foldMergedEdges(ctx context.Context, tx pgx.Tx, moved, other, sourceID, targetID string) (int, error) {
+	_, err := tx.Exec(ctx, `UPDATE kg_edges t SET
+			access_count = t.access_count + s.access_count,
+			weight = GREATEST(t.weight, s.weight),
+			salience_score = GREATEST(t.salience_score, s.salience_score),
+			last_accessed = GREATEST(t.last_accessed, s.last_accessed),
+			user_boosted = t.user_boosted OR s.user_boosted
+		FROM kg_edges s
+		WHERE t.tenant_id = current_setting('app.tenant_id')::uuid
+			AND s.tenant_id = t.tenant_id
+			AND s.`+moved+` = $1 AND t.`+moved+` = $2
+			AND t.`+other+` = s.`+other+` AND t.relation = s.relation`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("folding duplicate %s edges: %w", moved, err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM kg_edges s
+		WHERE s.tenant_id = current_setting('app.tenant_id')::uuid
+			AND s.`+moved+` = $1
+			AND EXISTS (SELECT 1 FROM kg_edges t
+				WHERE t.tenant_id = s.tenant_id AND t.`+moved+` = $2
+					AND t.`+other+` = s.`+other+` AND t.relation = s.relation)`,
+		sourceID, targetID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("deleting duplicate %s edges: %w", moved, err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// moveNodeReferences points aliases, event links, episodic references, and
+// supersession links at the target. Aliases and event links the target
+// already has are dropped rather than duplicated.
+func moveNodeReferences(ctx context.Context, tx pgx.Tx, sourceID, targetID string) error {
+	statements := []struct {
+		name string
+		sql  string
+	}{
+		{"duplicate aliases", `DELETE FROM kg_aliases s
+			WHERE s.tenant_id = current_setting('app.tenant_id')::uuid AND s.node_id = $1
+				AND EXISTS (SELECT 1 FROM kg_aliases t
+					WHERE t.tenant_id = s.tenant_id AND t.node_id = $2 AND t.normalized_alias = s.normalized_alias)`},
+		{"aliases", `UPDATE kg_aliases SET node_id = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = $1`},
+		{"duplicate event links", `DELETE FROM kg_event_links s
+			WHERE s.tenant_id = current_setting('app.tenant_id')::uuid AND s.node_id = $1
+				AND EXISTS (SELECT 1 FROM kg_event_links t
+					WHERE t.tenant_id = s.tenant_id AND t.node_id = $2 AND t.event_id = s.event_id AND t.role = s.role)`},
+		{"event links", `UPDATE kg_event_links SET node_id = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = $1`},
+		{"episode project references", `UPDATE kg_episodes SET primary_project_node_id = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND primary_project_node_id = $1`},
+		{"episode artifact references", `UPDATE kg_episodes SET source_artifact_node_id = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source_artifact_node_id = $1`},
+		{"event artifact references", `UPDATE kg_event_records SET source_artifact_node_id = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source_artifact_node_id = $1`},
+		{"supersession links", `UPDATE kg_nodes SET superseded_by = $2
+			WHERE tenant_id = current_setting('app.tenant_id')::uuid AND superseded_by = $1`},
+	}
+
+	for _, st := range statements {
+		if _, err := tx.Exec(ctx, st.sql, sourceID, targetID); err != nil {
+			return fmt.Errorf("moving %s: %w", st.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestMergeNode(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	for _, req := range []models.CreateNodeRequest{
+		{ID: "canonical", Type: "person", Label: "Bill Gates", Properties: map[string]any{"role": "ceo"}},
+		{ID: "dup", Type: "person", Label: "bill gates", Properties: map[string]any{"role": "founder", "born": float64(1955)}},
+		{ID: "msft", Type: "company", Label: "Microsoft"},
+		{ID: "seattle", Type: "place", Label: "Seattle"},
+	} {
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode %s: %v", req.ID, err)
+		}
+	}
+
+	for _, req := range []models.CreateEdgeRequest{
+		{Source: "canonical", Target: "msft", Relation: "founded"},
+		{Source: "dup", Target: "msft", Relation: "founded"},
+		{Source: "dup", Target: "seattle", Relation: "lives_in"},
+		{Source: "dup", Target: "canonical", Relation: "same_as"},
+	} {
+		if _, err := es.CreateEdge(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateEdge %s->%s: %v", req.Source, req.Target, err)
+		}
+	}
+
+	_, err := ns.MergeNode(ctx, tenantID, "dup", "canonical", models.MergeNodeRequest{ConflictStrategy: models.MergeFail})
+	if !errors.Is(err, models.ErrMergePropertyConflict) {
+		t.Fatalf("MergeNode with fail strategy: err = %v, want ErrMergePropertyConflict", err)
+	}
+
+	result, err := ns.MergeNode(ctx, tenantID, "dup", "canonical", models.MergeNodeRequest{ConflictStrategy: models.MergeKeepTarget})
+	if err != nil {
+		t.Fatalf("MergeNode: %v", err)
+	}
+
+	if result.EdgesRewired != 1 || result.EdgesMerged != 1 || result.EdgesDropped != 1 {
+		t.Errorf("edges rewired/merged/dropped = %d/%d/%d, want 1/1/1",
+			result.EdgesRewired, result.EdgesMerged, result.EdgesDropped)
+	}
+	if result.Node.Properties["role"] != "ceo" || result.Node.Properties["born"] != float64(1955) {
+		t.Errorf("merged properties = %v", result.Node.Properties)
+	}
+
+	if _, err := ns.GetNode(ctx, tenantID, "dup"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("source node still exists: err = %v", err)
+	}
+
+	edges, _, err := es.ListEdges(ctx, tenantID, "canonical", "", "", 10, 0, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ListEdges: %v", err)
+	}
+	if len(edges) != 2 {
+		t.Errorf("target has %d outgoing edges, want 2 (founded, lives_in)", len(edges))
+	}
+}
@@ -5,12 +5,15 @@
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/persistorai/persistor/internal/models"
 )
 
-// MigrateNode atomically migrates a node to a new ID, updating all edges.
+// MigrateNode atomically migrates a node to a new ID, moving or copying its
+// edges as req directs. With req.DryRun the transaction is rolled back and
+// the result reports edge conflicts instead of failing on them.
 func (s *NodeStore) MigrateNode(
 	ctx context.Context,
 	tenantID string,
@@ -45,8 +48,8 @@ func (s *NodeStore) MigrateNode(
 
 	// 3. Create new node copying all fields.
 	_, err = tx.Exec(ctx,
-		`INSERT INTO kg_nodes (id, tenant_id, type, label, properties, salience_score, access_count, last_accessed, user_boosted)
-		 SELECT $1, tenant_id, type, $2, properties, salience_score, access_count, last_accessed, user_boosted
+		`INSERT INTO kg_nodes (id, tenant_id, type, label, properties, search_props, salience_score, access_count, last_accessed, user_boosted)
+		 SELECT $1, tenant_id, type, $2, properties, search_props, salience_score, access_count, last_accessed, user_boosted
 		 FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $3`,
 		req.NewID, label, oldID)
 	if err != nil {
@@ -60,7 +63,7 @@ func (s *NodeStore) MigrateNode(
 
 	// 4. Copy embedding if present.
 	_, err = tx.Exec(ctx,
-		`UPDATE kg_nodes SET embedding = old.embedding
+		`UPDATE kg_nodes SET embedding = old.embedding, embedding_model = old.embedding_model
 		 FROM kg_nodes old
 		 WHERE kg_nodes.id = $1
 		   AND kg_nodes.tenant_id = current_setting('app.tenant_id')::uuid
@@ -72,32 +75,28 @@ func (s *NodeStore) MigrateNode(
 		return nil, fmt.Errorf("copying embedding: %w", err)
 	}
 
-	// 5. Update outgoing edges.
-	tagOut, err := tx.Exec(ctx,
-		`UPDATE kg_edges SET source = $1 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND source = $2`,
-		req.NewID, oldID)
-	if err != nil {
-		return nil, fmt.Errorf("migrating outgoing edges: %w", err)
+	// A quarantined node stays quarantined under its new ID.
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO kg_quarantine (tenant_id, node_id, field, pattern, flagged_at)
+		 SELECT tenant_id, $1, field, pattern, flagged_at FROM kg_quarantine
+		 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND node_id = $2
+		 ON CONFLICT (tenant_id, node_id) DO NOTHING`,
+		req.NewID, oldID); err != nil {
+		return nil, fmt.Errorf("copying quarantine flag: %w", err)
 	}
 
-	// 6. Update incoming edges.
-	tagIn, err := tx.Exec(ctx,
-		`UPDATE kg_edges SET target = $1 WHERE tenant_id = current_setting('app.tenant_id')::uuid AND target = $2`,
-		req.NewID, oldID)
-	if err != nil {
-		return nil, fmt.Errorf("migrating incoming edges: %w", err)
+	result := &models.MigrateNodeResult{
+		OldID:    oldID,
+		NewID:    req.NewID,
+		Salience: oldNode.Salience,
 	}
 
-	result := &models.MigrateNodeResult{
-		OldID:         oldID,
-		NewID:         req.NewID,
-		OutgoingEdges: int(tagOut.RowsAffected()),
-		IncomingEdges: int(tagIn.RowsAffected()),
-		Salience:      oldNode.Salience,
-		OldDeleted:    false,
+	// 5. Move or copy edges, leaving excluded relations behind.
+	if err := migrateNodeEdges(ctx, tx, oldID, req, result); err != nil {
+		return nil, err
 	}
 
-	// 7. Delete old node if requested.
+	// 6. Delete old node if requested.
 	if req.DeleteOld {
 		_, err = tx.Exec(ctx,
 			`DELETE FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
@@ -106,14 +105,160 @@ func (s *NodeStore) MigrateNode(
 			return nil, fmt.Errorf("deleting old node: %w", err)
 		}
 
+		if err := deleteNodeDependents(ctx, tx, []string{oldID}); err != nil {
+			return nil, err
+		}
+
 		result.OldDeleted = true
 	}
 
+	if req.DryRun {
+		result.DryRun = true
+		return result, nil
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing migrate node: %w", err)
 	}
 
 	s.notify("kg_nodes", "update", tenantID)
+	if len(result.Edges) > 0 {
+		s.notify("kg_edges", "update", tenantID)
+	}
 
 	return result, nil
 }
+
+// migratedEdgeColumns are the kg_edges columns copied when an edge is
+// duplicated onto the new node; created_at and updated_at start fresh.
+const migratedEdgeColumns = `relation, properties, weight, access_count, last_accessed,
+	salience_score, superseded_by, user_boosted, date_start, date_end,
+	date_lower, date_upper, is_current, date_qualifier`
+
+// migrateNodeEdges points the old node's edges at the new node, or copies
+// them there when req.CopyEdges is set. Edges whose relation is in
+// req.ExcludeRelations stay on the old node, or are deleted when the old
+// node is. Every edge is recorded in result.Edges with its original
+// endpoints. A self-loop moves both ends and counts as outgoing and incoming.
+func migrateNodeEdges(
+	ctx context.Context, tx pgx.Tx, oldID string, req models.MigrateNodeRequest, result *models.MigrateNodeResult,
+) error {
+	excluded := req.ExcludeRelations
+	if excluded == nil {
+		excluded = []string{}
+	}
+
+	conflicts, err := planMigratedEdges(ctx, tx, oldID, req, excluded, result)
+	if err != nil {
+		return err
+	}
+
+	if conflicts > 0 {
+		if req.DryRun {
+			return nil
+		}
+
+		return models.ErrDuplicateKey
+	}
+
+	// $1 is the old ID, $2 the new ID, $3 the excluded relations.
+	const swapEnds = `CASE WHEN source = $1 THEN $2 ELSE source END,
+		CASE WHEN target = $1 THEN $2 ELSE target END`
+	const matching = `tenant_id = current_setting('app.tenant_id')::uuid
+		AND (source = $1 OR target = $1)`
+
+	query := `UPDATE kg_edges SET (source, target) = (` + swapEnds + `)
+		WHERE ` + matching + ` AND relation <> ALL($3)`
+	if req.CopyEdges {
+		query = `INSERT INTO kg_edges (tenant_id, source, target, ` + migratedEdgeColumns + `)
+			SELECT tenant_id, ` + swapEnds + `, ` + migratedEdgeColumns + ` FROM kg_edges
+			WHERE ` + matching + ` AND relation <> ALL($3)`
+	}
+
+	if _, err := tx.Exec(ctx, query, oldID, req.NewID, excluded); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return models.ErrDuplicateKey
+		}
+
+		return fmt.Errorf("migrating edges: %w", err)
+	}
+
+	// Moved edges stay inferred edges if co-access inference made them.
+	if !req.CopyEdges {
+		_, err := tx.Exec(ctx, `UPDATE kg_inferred_edges SET (source, target) = (`+swapEnds+`)
+			WHERE `+matching+` AND relation <> ALL($3)`, oldID, req.NewID, excluded)
+		if err != nil {
+			return fmt.Errorf("migrating inference records of edges: %w", err)
+		}
+	}
+
+	if req.DeleteOld && len(excluded) > 0 {
+		_, err := tx.Exec(ctx, `DELETE FROM kg_edges WHERE `+matching+` AND relation = ANY($2)`, oldID, excluded)
+		if err != nil {
+			return fmt.Errorf("deleting excluded edges: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// planMigratedEdges locks the old node's edges and fills result with the
+// action migrateNodeEdges will take on each. It returns the number of moved
+// or copied edges that collide with an edge the new node already has.
+func planMigratedEdges(
+	ctx context.Context, tx pgx.Tx, oldID string, req models.MigrateNodeRequest,
+	excluded []string, result *models.MigrateNodeResult,
+) (conflicts int, err error) {
+	rows, err := tx.Query(ctx,
+		`SELECT e.source, e.target, e.relation, e.relation = ANY($2),
+			EXISTS (SELECT 1 FROM kg_edges x
+				WHERE x.tenant_id = e.tenant_id AND x.relation = e.relation
+					AND x.source = CASE WHEN e.source = $1 THEN $3 ELSE e.source END
+					AND x.target = CASE WHEN e.target = $1 THEN $3 ELSE e.target END)
+		 FROM kg_edges e
+		 WHERE e.tenant_id = current_setting('app.tenant_id')::uuid AND (e.source = $1 OR e.target = $1)
+		 ORDER BY e.source, e.target, e.relation
+		 FOR UPDATE OF e`,
+		oldID, excluded, req.NewID)
+	if err != nil {
+		return 0, fmt.Errorf("listing edges to migrate: %w", err)
+	}
+	defer rows.Close()
+
+	result.Edges = []models.MigratedEdge{}
+	for rows.Next() {
+		var e models.MigratedEdge
+		var skip bool
+		if err := rows.Scan(&e.Source, &e.Target, &e.Relation, &skip, &e.Conflict); err != nil {
+			return 0, fmt.Errorf("scanning edge to migrate: %w", err)
+		}
+
+		e.Conflict = e.Conflict && !skip
+		if e.Conflict {
+			conflicts++
+		}
+
+		switch {
+		case skip && req.DeleteOld:
+			e.Action = models.EdgeDropped
+		case skip:
+			e.Action = models.EdgeExcluded
+		case req.CopyEdges:
+			e.Action = models.EdgeCopied
+		default:
+			e.Action = models.EdgeMoved
+		}
+
+		if !skip && e.Source == oldID {
+			result.OutgoingEdges++
+		}
+		if !skip && e.Target == oldID {
+			result.IncomingEdges++
+		}
+
+		result.Edges = append(result.Edges, e)
+	}
+
+	return conflicts, rows.Err()
+}
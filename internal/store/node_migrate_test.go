@@ -0,0 +1,131 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestMigrateNode_EdgeOptions(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	for _, id := range []string{"old", "msft", "seattle", "paul"} {
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: id, Type: "thing", Label: id}); err != nil {
+			t.Fatalf("CreateNode %s: %v", id, err)
+		}
+	}
+	for _, req := range []models.CreateEdgeRequest{
+		{Source: "old", Target: "msft", Relation: "founded"},
+		{Source: "old", Target: "seattle", Relation: "lives_in"},
+		{Source: "paul", Target: "old", Relation: "knows"},
+	} {
+		if _, err := es.CreateEdge(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateEdge %s->%s: %v", req.Source, req.Target, err)
+		}
+	}
+
+	result, err := ns.MigrateNode(ctx, tenantID, "old", models.MigrateNodeRequest{
+		NewID: "copy", CopyEdges: true, ExcludeRelations: []string{"lives_in"},
+	})
+	if err != nil {
+		t.Fatalf("MigrateNode copy: %v", err)
+	}
+	if result.OutgoingEdges != 1 || result.IncomingEdges != 1 || len(result.Edges) != 3 {
+		t.Fatalf("copy result = %+v", result)
+	}
+	actions := make(map[string]string)
+	for _, e := range result.Edges {
+		actions[e.Relation] = e.Action
+	}
+	if actions["founded"] != models.EdgeCopied || actions["lives_in"] != models.EdgeExcluded || actions["knows"] != models.EdgeCopied {
+		t.Errorf("copy actions = %v", actions)
+	}
+	for _, key := range [][3]string{{"old", "msft", "founded"}, {"copy", "msft", "founded"}, {"paul", "copy", "knows"}, {"old", "seattle", "lives_in"}} {
+		if !hasEdge(t, es, tenantID, key) {
+			t.Errorf("edge %v missing after copy", key)
+		}
+	}
+
+	result, err = ns.MigrateNode(ctx, tenantID, "old", models.MigrateNodeRequest{
+		NewID: "moved", DeleteOld: true, ExcludeRelations: []string{"lives_in"},
+	})
+	if err != nil {
+		t.Fatalf("MigrateNode move: %v", err)
+	}
+	if result.OutgoingEdges != 1 || result.IncomingEdges != 1 || !result.OldDeleted {
+		t.Errorf("move result = %+v", result)
+	}
+	if hasEdge(t, es, tenantID, [3]string{"old", "seattle", "lives_in"}) {
+		t.Error("excluded edge survived deleting the old node")
+	}
+	if !hasEdge(t, es, tenantID, [3]string{"moved", "msft", "founded"}) {
+		t.Error("moved edge missing")
+	}
+
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "x", Type: "thing", Label: "x"}); err != nil {
+		t.Fatalf("CreateNode x: %v", err)
+	}
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: "x", Target: "msft", Relation: "founded"}); err != nil {
+		t.Fatalf("CreateEdge x: %v", err)
+	}
+
+	result, err = ns.MigrateNode(ctx, tenantID, "x", models.MigrateNodeRequest{NewID: "paul", DeleteOld: true, DryRun: true})
+	if !errors.Is(err, models.ErrDuplicateKey) {
+		t.Errorf("dry run onto existing node: err = %v, want ErrDuplicateKey", err)
+	}
+
+	result, err = ns.MigrateNode(ctx, tenantID, "x", models.MigrateNodeRequest{NewID: "x2", DeleteOld: true, DryRun: true})
+	if err != nil || !result.DryRun || result.OutgoingEdges != 1 || len(result.Edges) != 1 || result.Edges[0].Conflict {
+		t.Fatalf("dry run = %+v, %v", result, err)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, "x2"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("dry run created the new node: err = %v", err)
+	}
+	if !hasEdge(t, es, tenantID, [3]string{"x", "msft", "founded"}) {
+		t.Error("dry run moved the edge")
+	}
+
+	// Without foreign keys an edge can outlive its node; one already at the
+	// new ID conflicts with the edge being moved there.
+	env := getTestEnv(t)
+	tx, err := env.pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin tx: %v", err)
+	}
+	if _, err = tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		t.Fatalf("set tenant: %v", err)
+	}
+	if _, err = tx.Exec(ctx,
+		"INSERT INTO kg_edges (tenant_id, source, target, relation) VALUES ($1, 'x2', 'msft', 'founded')",
+		tenantID); err != nil {
+		t.Fatalf("inserting dangling edge: %v", err)
+	}
+	if err = tx.Commit(ctx); err != nil {
+		t.Fatalf("commit dangling edge: %v", err)
+	}
+
+	result, err = ns.MigrateNode(ctx, tenantID, "x", models.MigrateNodeRequest{NewID: "x2", DryRun: true})
+	if err != nil || len(result.Edges) != 1 || !result.Edges[0].Conflict {
+		t.Errorf("dry run with conflict = %+v, %v; want the edge flagged", result, err)
+	}
+	if _, err := ns.MigrateNode(ctx, tenantID, "x", models.MigrateNodeRequest{NewID: "x2"}); !errors.Is(err, models.ErrDuplicateKey) {
+		t.Errorf("migrate with conflict: err = %v, want ErrDuplicateKey", err)
+	}
+}
+
+func hasEdge(t *testing.T, es *store.EdgeStore, tenantID string, key [3]string) bool {
+	t.Helper()
+
+	edges, _, err := es.ListEdges(context.Background(), tenantID, key[0], key[1], key[2], 1, 0, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ListEdges %v: %v", key, err)
+	}
+
+	return len(edges) == 1
+}
@@ -5,20 +5,21 @@
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
 	"github.com/persistorai/persistor/internal/models"
 )
 
-// ListNodes returns nodes for a tenant with optional type filter and minimum salience.
-func (s *NodeStore) ListNodes(
-	ctx context.Context,
-	tenantID string,
-	typeFilter string,
-	minSalience float64,
-	limit, offset int,
-) ([]models.Node, bool, error) {
+// ListNodes returns the page of a tenant's nodes that opts selects. A
+// non-nil opts.After selects the page that follows it; opts.Offset is then
+// ignored.
+func (s *NodeStore) ListNodes(ctx context.Context, tenantID string, opts models.NodeListOpts) ([]models.Node, bool, error) {
+	defer observeOperation("ListNodes", time.Now())
+
+	limit, offset := opts.Limit, opts.Offset
+
 	if limit <= 0 {
 		limit = 50
 	}
@@ -34,7 +35,7 @@ func (s *NodeStore) ListNodes(
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, false, fmt.Errorf("listing nodes: %w", err)
 	}
@@ -45,20 +46,38 @@ func (s *NodeStore) ListNodes(
 	filterArgs := make([]any, 0, 2)
 	argIdx := 1
 
-	if typeFilter != "" {
+	if opts.Type != "" {
 		where += fmt.Sprintf(" AND type = $%d", argIdx)
-		filterArgs = append(filterArgs, typeFilter)
+		filterArgs = append(filterArgs, opts.Type)
 		argIdx++
 	}
 
-	if minSalience > 0 {
+	if opts.MinSalience > 0 {
 		where += fmt.Sprintf(" AND salience_score >= $%d", argIdx)
-		filterArgs = append(filterArgs, minSalience)
+		filterArgs = append(filterArgs, opts.MinSalience)
 		argIdx++
 	}
 
+	propsWhere, propsArgs, err := s.propertyFilterSQL(opts.Properties, "search_props", argIdx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	where += propsWhere
+	filterArgs = append(filterArgs, propsArgs...)
+	argIdx += len(propsArgs)
+
+	// A cursor continues after the last node of the previous page, so offset
+	// is not needed.
+	if after := opts.After; after != nil {
+		where += fmt.Sprintf(" AND (salience_score, updated_at, id) < ($%d::real, $%d, $%d)", argIdx, argIdx+1, argIdx+2)
+		filterArgs = append(filterArgs, after.Salience, after.UpdatedAt, after.ID)
+		argIdx += 3
+		offset = 0
+	}
+
 	query := "SELECT " + nodeColumns + " FROM kg_nodes" + where
-	query += " ORDER BY salience_score DESC, updated_at DESC"
+	query += " ORDER BY salience_score DESC, updated_at DESC, id DESC"
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
 	args := make([]any, 0, len(filterArgs)+2)
 	args = append(args, filterArgs...)
@@ -90,7 +109,7 @@ func (s *NodeStore) ListNodes(
 		nodes = nodes[:limit]
 	}
 
-	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodes, opts.OmitProperties); err != nil {
 		return nil, false, err
 	}
 
@@ -203,6 +222,8 @@ type rankedNode struct {
 
 // GetNode retrieves a single node by ID (pure read, no side effects).
 func (s *NodeStore) GetNode(ctx context.Context, tenantID, nodeID string) (*models.Node, error) {
+	defer observeOperation("GetNode", time.Now())
+
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
@@ -117,7 +117,7 @@ func TestDeleteNode(t *testing.T) {
 		t.Fatalf("CreateNode: %v", err)
 	}
 
-	if err := ns.DeleteNode(ctx, tenantID, created.ID); err != nil {
+	if err := ns.DeleteNode(ctx, tenantID, created.ID, models.DeleteRestrict); err != nil {
 		t.Fatalf("DeleteNode: %v", err)
 	}
 
@@ -127,6 +127,38 @@ func TestDeleteNode(t *testing.T) {
 	}
 }
 
+func TestDeleteNode_Modes(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ctx := context.Background()
+
+	hub := createTestNode(t, ns, tenantID, "Hub")
+	leaf := createTestNode(t, ns, tenantID, "Leaf")
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: hub.ID, Target: leaf.ID, Relation: "links"}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+
+	err := ns.DeleteNode(ctx, tenantID, hub.ID, models.DeleteRestrict)
+	if !errors.Is(err, models.ErrNodeHasEdges) {
+		t.Fatalf("restrict delete: got %v, want ErrNodeHasEdges", err)
+	}
+	if _, err := ns.GetNode(ctx, tenantID, hub.ID); err != nil {
+		t.Fatalf("hub should survive a restricted delete: %v", err)
+	}
+
+	if err := ns.DeleteNode(ctx, tenantID, hub.ID, models.DeleteDetach); err != nil {
+		t.Fatalf("detach delete: %v", err)
+	}
+	edges, _, err := es.ListEdges(ctx, tenantID, "", leaf.ID, "", 10, 0, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ListEdges: %v", err)
+	}
+	if len(edges) != 0 {
+		t.Errorf("detach delete left %d edges", len(edges))
+	}
+}
+
 func TestListNodes(t *testing.T) {
 	base, tenantID := setupTestBase(t)
 	ns := store.NewNodeStore(base)
@@ -140,7 +172,7 @@ func TestListNodes(t *testing.T) {
 		}
 	}
 
-	nodes, hasMore, err := ns.ListNodes(ctx, tenantID, "", 0, 50, 0)
+	nodes, hasMore, err := ns.ListNodes(ctx, tenantID, models.NodeListOpts{Limit: 50})
 	if err != nil {
 		t.Fatalf("ListNodes: %v", err)
 	}
@@ -153,7 +185,7 @@ func TestListNodes(t *testing.T) {
 	}
 
 	// Filter by type.
-	filtered, _, err := ns.ListNodes(ctx, tenantID, "nonexistent", 0, 50, 0)
+	filtered, _, err := ns.ListNodes(ctx, tenantID, models.NodeListOpts{Type: "nonexistent", Limit: 50})
 	if err != nil {
 		t.Fatalf("ListNodes with filter: %v", err)
 	}
@@ -162,6 +194,71 @@ func TestListNodes(t *testing.T) {
 	}
 }
 
+func TestListNodes_WithoutProperties(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	req := models.CreateNodeRequest{Type: "concept", Label: "Secretive", Properties: map[string]any{"k": "v"}}
+	_ = req.Validate()
+	if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+		t.Fatalf("CreateNode: %v", err)
+	}
+
+	nodes, _, err := ns.ListNodes(ctx, tenantID, models.NodeListOpts{Limit: 50, OmitProperties: true})
+	if err != nil {
+		t.Fatalf("ListNodes: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Label != "Secretive" || nodes[0].Properties != nil {
+		t.Fatalf("ListNodes without properties = %+v", nodes)
+	}
+
+	nodes, _, err = ns.ListNodes(ctx, tenantID, models.NodeListOpts{Limit: 50})
+	if err != nil {
+		t.Fatalf("ListNodes: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Properties["k"] != "v" {
+		t.Fatalf("ListNodes with properties = %+v", nodes)
+	}
+}
+
+func TestListNodes_Cursor(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	// Equal salience and near-equal update times exercise the id tie-breaker.
+	for _, id := range []string{"page-a", "page-b", "page-c", "page-d", "page-e"} {
+		req := models.CreateNodeRequest{ID: id, Type: "concept", Label: id}
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode(%s): %v", id, err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var after *models.NodeCursor
+	for page := 0; ; page++ {
+		nodes, hasMore, err := ns.ListNodes(ctx, tenantID, models.NodeListOpts{Limit: 2, After: after})
+		if err != nil {
+			t.Fatalf("ListNodes page %d: %v", page, err)
+		}
+		for _, n := range nodes {
+			if seen[n.ID] {
+				t.Errorf("node %s returned twice", n.ID)
+			}
+			seen[n.ID] = true
+		}
+		if !hasMore {
+			break
+		}
+		after = models.NodeCursorAfter(&nodes[len(nodes)-1])
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("cursor pages returned %d nodes, want 5", len(seen))
+	}
+}
+
 func TestGetNodeByLabel_UsesAliasFallbacks(t *testing.T) {
 	base, tenantID := setupTestBase(t)
 	ns := store.NewNodeStore(base)
@@ -0,0 +1,175 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxChangeDetailSize caps the encoded change detail so the notification,
+// once wrapped in a WebSocket event, stays under the hub's 4 KB limit.
+const maxChangeDetailSize = 2048
+
+// maxNotifyPayload is the largest payload sent with pg_notify. Postgres
+// rejects payloads of 8000 bytes or more, failing the NOTIFY outright.
+const maxNotifyPayload = 7999
+
+// notify sends a change event on the kg_changes channel (best-effort, post-commit).
+func (b *Base) notify(table, op, tenantID string) {
+	b.notifyChange(table, op, tenantID, nil)
+}
+
+// notifyChange is notify with a description of what changed, delivered to
+// verbose change-feed subscribers. Oversized details are reduced to a
+// reference to the changed entity so the notification itself is never
+// dropped; subscribers refetch the entity for the rest.
+func (b *Base) notifyChange(table, op, tenantID string, detail *models.ChangeDetail) {
+	b.sendEvent(table, op, tenantID, changeMessage(tenantID, table, op, detail))
+}
+
+// notifyNode is notifyChange for a single node write. The node's ID and type
+// are always included so subscribers can filter on them.
+func (b *Base) notifyNode(op, tenantID, nodeID, nodeType string, detail *models.ChangeDetail) {
+	msg := changeMessage(tenantID, "kg_nodes", op, detail)
+	msg["node_id"] = nodeID
+	msg["node_type"] = nodeType
+
+	b.sendEvent("kg_nodes", op, tenantID, msg)
+}
+
+func changeMessage(tenantID, table, op string, detail *models.ChangeDetail) map[string]any {
+	msg := map[string]any{
+		"table":     table,
+		"op":        op,
+		"count":     1,
+		"tenant_id": tenantID,
+	}
+	if detail != nil {
+		msg["changes"] = boundChangeDetail(detail)
+	}
+
+	return msg
+}
+
+// sendEvent assigns the change event in msg the tenant's next event ID and
+// sends it, in one transaction so that a dropped notification uses no ID.
+// Every replica hears the same ID, so clients can resume on any of them.
+func (b *Base) sendEvent(table, op, tenantID string, msg map[string]any) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tx, err := b.beginTx(ctx, tenantID)
+	if err != nil {
+		metrics.ChangeEventsDropped.WithLabelValues("notify_failed").Inc()
+		b.Log.WithError(err).Warn("failed to send " + op + " " + table + " notification")
+		return
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var eventID int64
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO kg_event_sequences AS s (tenant_id, last_id)
+		VALUES (current_setting('app.tenant_id')::uuid, 1)
+		ON CONFLICT (tenant_id) DO UPDATE SET last_id = s.last_id + 1
+		RETURNING last_id
+	`).Scan(&eventID); err != nil {
+		metrics.ChangeEventsDropped.WithLabelValues("notify_failed").Inc()
+		b.Log.WithError(err).Warn("failed to assign " + op + " " + table + " event id")
+		return
+	}
+
+	msg["event_id"] = eventID
+
+	payload, ok := b.encodeNotification(table, op, msg)
+	if !ok {
+		return
+	}
+
+	if _, err = tx.Exec(ctx, "SELECT pg_notify('kg_changes', $1)", string(payload)); err == nil {
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		metrics.ChangeEventsDropped.WithLabelValues("notify_failed").Inc()
+		b.Log.WithError(err).Warn("failed to send " + op + " " + table + " notification")
+	}
+}
+
+// sendNotification sends msg on the kg_changes channel without an event ID,
+// for notifications the bridge applies to the hub instead of broadcasting.
+func (b *Base) sendNotification(table, op string, msg map[string]any) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	payload, ok := b.encodeNotification(table, op, msg)
+	if !ok {
+		return
+	}
+
+	if _, err := b.Pool.Exec(ctx, "SELECT pg_notify('kg_changes', $1)", string(payload)); err != nil {
+		metrics.ChangeEventsDropped.WithLabelValues("notify_failed").Inc()
+		b.Log.WithError(err).Warn("failed to send " + op + " " + table + " notification")
+	}
+}
+
+// encodeNotification encodes msg, refusing payloads pg_notify would reject.
+func (b *Base) encodeNotification(table, op string, msg map[string]any) ([]byte, bool) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		b.Log.WithError(err).Warn("failed to encode " + op + " " + table + " notification")
+		return nil, false
+	}
+
+	if len(payload) > maxNotifyPayload {
+		metrics.ChangeEventsDropped.WithLabelValues("notify_oversized").Inc()
+		b.Log.WithFields(logrus.Fields{
+			"tenant_id":    msg["tenant_id"],
+			"payload_size": len(payload),
+			"max_size":     maxNotifyPayload,
+		}).Warn("dropping oversized " + op + " " + table + " notification")
+		return nil, false
+	}
+
+	return payload, true
+}
+
+// boundChangeDetail returns detail, or a truncated copy that keeps only the
+// entity identity when the encoded detail exceeds maxChangeDetailSize.
+func boundChangeDetail(detail *models.ChangeDetail) *models.ChangeDetail {
+	if encoded, err := json.Marshal(detail); err == nil && len(encoded) <= maxChangeDetailSize {
+		return detail
+	}
+
+	metrics.ChangeEventsCompacted.WithLabelValues("notify").Inc()
+
+	return &models.ChangeDetail{
+		NodeID:    detail.NodeID,
+		Source:    detail.Source,
+		Target:    detail.Target,
+		Relation:  detail.Relation,
+		Truncated: true,
+	}
+}
+
+// changedPropertyKeys returns the sorted keys whose values differ between
+// oldProps and newProps, including added and removed keys.
+func changedPropertyKeys(oldProps, newProps map[string]any) []string {
+	diffs, err := diffProperties(oldProps, newProps)
+	if err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		keys = append(keys, d.key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
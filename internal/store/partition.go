@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// PartitionStore reports on the tenant hash partitions of the graph tables.
+// It reads the catalog, not tenant rows, so it runs without a tenant.
+type PartitionStore struct {
+	Pool *dbpool.Pool
+}
+
+// NewPartitionStore creates a new PartitionStore.
+func NewPartitionStore(pool *dbpool.Pool) *PartitionStore {
+	return &PartitionStore{Pool: pool}
+}
+
+// ListPartitions returns the size of every partition of the graph tables,
+// ordered by table and remainder. It is empty when the tables are not
+// partitioned.
+func (s *PartitionStore) ListPartitions(ctx context.Context) ([]models.PartitionSize, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT parent.relname::text, child.relname::text,
+			b.parts[1]::int, b.parts[2]::int,
+			greatest(child.reltuples, 0)::bigint,
+			pg_table_size(child.oid), pg_indexes_size(child.oid), pg_total_relation_size(child.oid)
+		FROM pg_inherits i
+		JOIN pg_class parent ON parent.oid = i.inhparent
+		JOIN pg_class child ON child.oid = i.inhrelid
+		CROSS JOIN LATERAL regexp_match(
+			pg_get_expr(child.relpartbound, child.oid), 'modulus (\d+), remainder (\d+)', 'i'
+		) AS b(parts)
+		WHERE i.inhparent IN (SELECT to_regclass(t) FROM unnest($1::text[]) AS t)
+		ORDER BY parent.relname, b.parts[2]::int`, db.PartitionedTables)
+	if err != nil {
+		return nil, fmt.Errorf("listing partitions: %w", err)
+	}
+
+	partitions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.PartitionSize, error) {
+		var p models.PartitionSize
+		err := row.Scan(&p.Table, &p.Partition, &p.Modulus, &p.Remainder,
+			&p.Rows, &p.TableBytes, &p.IndexBytes, &p.TotalBytes)
+
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning partitions: %w", err)
+	}
+
+	return partitions, nil
+}
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// searchProps returns the search_props document for props: the plaintext
+// copy of its searchable properties that props filters match against.
+func (b *Base) searchProps(props map[string]any) ([]byte, error) {
+	doc, err := json.Marshal(models.SearchableProperties(props, b.SearchableProperties))
+	if err != nil {
+		return nil, fmt.Errorf("marshalling searchable properties: %w", err)
+	}
+
+	return doc, nil
+}
+
+// propertyFilterSQL returns SQL conditions, each prefixed with AND, for the
+// props filters on the search_props column col, with their arguments
+// numbered from argIdx. It returns models.ErrPropertyNotSearchable for a key
+// outside the allowlist.
+func (b *Base) propertyFilterSQL(filters []models.PropertyFilter, col string, argIdx int) (string, []any, error) {
+	if len(filters) == 0 {
+		return "", nil, nil
+	}
+
+	var (
+		sql  strings.Builder
+		args []any
+	)
+
+	for _, f := range filters {
+		if !slices.Contains(b.SearchableProperties, f.Key) {
+			return "", nil, fmt.Errorf("%w: %s", models.ErrPropertyNotSearchable, f.Key)
+		}
+
+		matches := f.Matches()
+		if len(matches) == 0 {
+			fmt.Fprintf(&sql, " AND %s ? $%d", col, argIdx)
+			args = append(args, f.Key)
+			argIdx++
+
+			continue
+		}
+
+		conds := make([]string, len(matches))
+		for i, m := range matches {
+			doc, err := json.Marshal(map[string]any{f.Key: m})
+			if err != nil {
+				return "", nil, fmt.Errorf("marshalling property filter: %w", err)
+			}
+
+			conds[i] = fmt.Sprintf("%s @> $%d::jsonb", col, argIdx)
+			args = append(args, string(doc))
+			argIdx++
+		}
+
+		fmt.Fprintf(&sql, " AND (%s)", strings.Join(conds, " OR "))
+	}
+
+	return sql.String(), args, nil
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// QuarantineStore persists the nodes the prompt-injection scanner flagged.
+// A flag is removed with its node when the node is deleted, merged into
+// another, or archived.
+type QuarantineStore struct {
+	Base
+}
+
+// NewQuarantineStore creates a new QuarantineStore.
+func NewQuarantineStore(base Base) *QuarantineStore {
+	return &QuarantineStore{Base: base}
+}
+
+// Quarantine flags nodeID with the match found in it. Flagging a node again
+// replaces its match and flag time.
+func (s *QuarantineStore) Quarantine(ctx context.Context, tenantID, nodeID string, match models.InjectionMatch) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("quarantining node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO kg_quarantine (tenant_id, node_id, field, pattern) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, node_id) DO UPDATE
+		SET field = EXCLUDED.field, pattern = EXCLUDED.pattern, flagged_at = NOW()`,
+		tenantID, nodeID, match.Field, match.Pattern,
+	); err != nil {
+		return fmt.Errorf("inserting quarantine: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing quarantine: %w", err)
+	}
+
+	return nil
+}
+
+// Release clears the flag on nodeID. It returns models.ErrNotQuarantined
+// when the node is not flagged.
+func (s *QuarantineStore) Release(ctx context.Context, tenantID, nodeID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("releasing node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx, "DELETE FROM kg_quarantine WHERE tenant_id = $1 AND node_id = $2", tenantID, nodeID)
+	if err != nil {
+		return fmt.Errorf("deleting quarantine: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotQuarantined
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing release: %w", err)
+	}
+
+	return nil
+}
+
+// ListQuarantined returns up to limit flagged nodes, most recently flagged
+// first.
+func (s *QuarantineStore) ListQuarantined(ctx context.Context, tenantID string, limit int) ([]models.QuarantinedNode, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing quarantined nodes: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		`SELECT q.node_id, n.type, n.label, q.field, q.pattern, q.flagged_at
+		FROM kg_quarantine q
+		JOIN kg_nodes n ON n.tenant_id = q.tenant_id AND n.id = q.node_id
+		WHERE q.tenant_id = $1
+		ORDER BY q.flagged_at DESC, q.node_id
+		LIMIT $2`,
+		tenantID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying quarantined nodes: %w", err)
+	}
+
+	nodes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.QuarantinedNode, error) {
+		var q models.QuarantinedNode
+		err := row.Scan(&q.NodeID, &q.Type, &q.Label, &q.Field, &q.Pattern, &q.FlaggedAt)
+
+		return q, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning quarantined nodes: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// QuarantinedNodeIDs returns which of nodeIDs are quarantined.
+func (s *QuarantineStore) QuarantinedNodeIDs(ctx context.Context, tenantID string, nodeIDs []string) (map[string]bool, error) {
+	flagged := make(map[string]bool)
+	if len(nodeIDs) == 0 {
+		return flagged, nil
+	}
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("checking quarantine: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		"SELECT node_id FROM kg_quarantine WHERE tenant_id = $1 AND node_id = ANY($2)", tenantID, nodeIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying quarantine: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("scanning quarantine: %w", err)
+	}
+
+	for _, id := range ids {
+		flagged[id] = true
+	}
+
+	return flagged, nil
+}
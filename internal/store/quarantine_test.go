@@ -0,0 +1,62 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestQuarantineStore(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	quarantine := store.NewQuarantineStore(base)
+	ctx := context.Background()
+
+	flagged := createTestNode(t, ns, tenantID, "Flagged")
+	clean := createTestNode(t, ns, tenantID, "Clean")
+
+	match := models.InjectionMatch{Field: "label", Pattern: "ignore_instructions"}
+	if err := quarantine.Quarantine(ctx, tenantID, flagged.ID, match); err != nil {
+		t.Fatalf("Quarantine: %v", err)
+	}
+
+	// Flagging again replaces the match.
+	match.Field = "properties.notes"
+	if err := quarantine.Quarantine(ctx, tenantID, flagged.ID, match); err != nil {
+		t.Fatalf("Quarantine again: %v", err)
+	}
+
+	list, err := quarantine.ListQuarantined(ctx, tenantID, 10)
+	if err != nil || len(list) != 1 || list[0].NodeID != flagged.ID || list[0].Field != "properties.notes" || list[0].Label != "Flagged" {
+		t.Fatalf("ListQuarantined = %+v, %v", list, err)
+	}
+
+	ids, err := quarantine.QuarantinedNodeIDs(ctx, tenantID, []string{flagged.ID, clean.ID})
+	if err != nil || !ids[flagged.ID] || ids[clean.ID] {
+		t.Errorf("QuarantinedNodeIDs = %v, %v", ids, err)
+	}
+
+	if err := quarantine.Release(ctx, tenantID, flagged.ID); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	if err := quarantine.Release(ctx, tenantID, flagged.ID); !errors.Is(err, models.ErrNotQuarantined) {
+		t.Errorf("Release again: err = %v, want ErrNotQuarantined", err)
+	}
+
+	// Deleting a node drops its flag.
+	if err := quarantine.Quarantine(ctx, tenantID, flagged.ID, match); err != nil {
+		t.Fatalf("Quarantine before delete: %v", err)
+	}
+
+	if err := ns.DeleteNode(ctx, tenantID, flagged.ID, models.DeleteRestrict); err != nil {
+		t.Fatalf("DeleteNode: %v", err)
+	}
+
+	if err := quarantine.Release(ctx, tenantID, flagged.ID); !errors.Is(err, models.ErrNotQuarantined) {
+		t.Errorf("Release after delete: err = %v, want ErrNotQuarantined", err)
+	}
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Per-tenant consumption, counted against quotas. Run with the tenant
+// context set.
+const (
+	nodeCountQuery    = `SELECT COUNT(*) FROM kg_nodes WHERE tenant_id = current_setting('app.tenant_id')::uuid`
+	edgeCountQuery    = `SELECT COUNT(*) FROM kg_edges WHERE tenant_id = current_setting('app.tenant_id')::uuid`
+	storageBytesQuery = `SELECT
+		(SELECT COALESCE(SUM(pg_column_size(n.*)), 0) FROM kg_nodes n
+			WHERE n.tenant_id = current_setting('app.tenant_id')::uuid)
+		+ (SELECT COALESCE(SUM(pg_column_size(e.*)), 0) FROM kg_edges e
+			WHERE e.tenant_id = current_setting('app.tenant_id')::uuid)`
+)
+
+// QuotaStore reports tenant resource usage.
+type QuotaStore struct {
+	Base
+}
+
+// NewQuotaStore creates a new QuotaStore.
+func NewQuotaStore(base Base) *QuotaStore {
+	return &QuotaStore{Base: base}
+}
+
+// GetUsage returns the tenant's node and edge counts, storage size, this
+// month's recorded model usage, and quota.
+func (s *QuotaStore) GetUsage(ctx context.Context, tenantID string) (*models.TenantUsage, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting usage: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	quota, err := tenantQuota(ctx, tx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &models.TenantUsage{Quota: *quota}
+
+	if err := tx.QueryRow(ctx, `SELECT (`+nodeCountQuery+`), (`+edgeCountQuery+`), (`+storageBytesQuery+`)`).Scan(
+		&usage.Nodes, &usage.Edges, &usage.StorageBytes,
+	); err != nil {
+		return nil, fmt.Errorf("measuring usage: %w", err)
+	}
+
+	month := usageMonth(time.Now())
+	usage.Month = month.Format(time.DateOnly)
+
+	byKind, err := monthlyModelUsage(ctx, tx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.Embedding, usage.LLM = byKind[models.ModelUsageEmbedding], byKind[models.ModelUsageLLM]
+
+	return usage, nil
+}
+
+// enforceQuota fails with models.ErrQuotaExceeded when the rows written so
+// far in tx leave the tenant over a quota. It is a no-op for writes that
+// added no nodes or edges, so a tenant over a lowered quota can still update
+// and delete. Call it after writing, before committing.
+func enforceQuota(ctx context.Context, tx pgx.Tx, tenantID string, addedNodes, addedEdges int) error {
+	if addedNodes <= 0 && addedEdges <= 0 {
+		return nil
+	}
+
+	quota, err := tenantQuota(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	checkNodes := addedNodes > 0 && quota.MaxNodes != nil
+	checkEdges := addedEdges > 0 && quota.MaxEdges != nil
+
+	if !checkNodes && !checkEdges && quota.MaxStorageBytes == nil {
+		return nil
+	}
+
+	// Serialize the tenant's limited writes. Each statement after the lock
+	// sees rows committed by writers that held it before, so concurrent
+	// writes cannot overshoot together.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('tenant_quota:' || $1, 0))`, tenantID); err != nil {
+		return fmt.Errorf("locking tenant quota: %w", err)
+	}
+
+	if checkNodes {
+		if err := checkQuota(ctx, tx, nodeCountQuery, *quota.MaxNodes, "nodes"); err != nil {
+			return err
+		}
+	}
+
+	if checkEdges {
+		if err := checkQuota(ctx, tx, edgeCountQuery, *quota.MaxEdges, "edges"); err != nil {
+			return err
+		}
+	}
+
+	if quota.MaxStorageBytes != nil {
+		if err := checkQuota(ctx, tx, storageBytesQuery, *quota.MaxStorageBytes, "bytes of storage"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkQuota(ctx context.Context, tx pgx.Tx, query string, limit int64, unit string) error {
+	var used int64
+	if err := tx.QueryRow(ctx, query).Scan(&used); err != nil {
+		return fmt.Errorf("measuring %s: %w", unit, err)
+	}
+
+	if used > limit {
+		return fmt.Errorf("%w: tenant is limited to %d %s", models.ErrQuotaExceeded, limit, unit)
+	}
+
+	return nil
+}
+
+func tenantQuota(ctx context.Context, tx pgx.Tx, tenantID string) (*models.TenantQuota, error) {
+	var quota models.TenantQuota
+
+	err := tx.QueryRow(ctx,
+		`SELECT max_nodes, max_edges, max_storage_bytes, max_monthly_embedding_tokens, max_monthly_llm_tokens
+		FROM tenants WHERE id = $1`, tenantID,
+	).Scan(
+		&quota.MaxNodes, &quota.MaxEdges, &quota.MaxStorageBytes,
+		&quota.MaxMonthlyEmbeddingTokens, &quota.MaxMonthlyLLMTokens,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("reading tenant quota: %w", err)
+	}
+
+	return &quota, nil
+}
@@ -0,0 +1,122 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestQuotaEnforcement(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	bs := store.NewBulkStore(base)
+	qs := store.NewQuotaStore(base)
+	ctx := context.Background()
+
+	if _, err := base.Pool.Exec(ctx, "UPDATE tenants SET max_nodes = 2, max_edges = 1 WHERE id = $1", tenantID); err != nil {
+		t.Fatalf("setting quota: %v", err)
+	}
+
+	for _, id := range []string{"q-a", "q-b"} {
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: id, Type: "note", Label: id}); err != nil {
+			t.Fatalf("CreateNode(%s): %v", id, err)
+		}
+	}
+
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "q-c", Type: "note", Label: "q-c"}); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("CreateNode over quota = %v, want ErrQuotaExceeded", err)
+	}
+
+	// Upserting existing nodes adds nothing, so it is allowed at the limit.
+	if _, err := bs.BulkUpsertNodes(ctx, tenantID, []models.CreateNodeRequest{{ID: "q-a", Type: "note", Label: "renamed"}}); err != nil {
+		t.Fatalf("BulkUpsertNodes of existing node: %v", err)
+	}
+	if _, err := bs.BulkUpsertNodes(ctx, tenantID, []models.CreateNodeRequest{
+		{ID: "q-a", Type: "note", Label: "q-a"}, {ID: "q-d", Type: "note", Label: "q-d"},
+	}); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("BulkUpsertNodes over quota = %v, want ErrQuotaExceeded", err)
+	}
+
+	if _, err := es.CreateEdge(ctx, tenantID, models.CreateEdgeRequest{Source: "q-a", Target: "q-b", Relation: "knows"}); err != nil {
+		t.Fatalf("CreateEdge: %v", err)
+	}
+	if _, err := bs.BulkUpsertEdges(ctx, tenantID, []models.CreateEdgeRequest{{Source: "q-b", Target: "q-a", Relation: "knows"}}); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Fatalf("BulkUpsertEdges over quota = %v, want ErrQuotaExceeded", err)
+	}
+
+	usage, err := qs.GetUsage(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if usage.Nodes != 2 || usage.Edges != 1 || usage.StorageBytes <= 0 {
+		t.Errorf("usage = %+v, want 2 nodes, 1 edge, and some storage", usage)
+	}
+	if usage.Quota.MaxNodes == nil || *usage.Quota.MaxNodes != 2 || usage.Quota.MaxStorageBytes != nil {
+		t.Errorf("quota = %+v, want max_nodes 2 and unlimited storage", usage.Quota)
+	}
+
+	// A storage limit below current usage blocks any further growth.
+	if _, err := base.Pool.Exec(ctx, "UPDATE tenants SET max_nodes = NULL, max_storage_bytes = 1 WHERE id = $1", tenantID); err != nil {
+		t.Fatalf("setting storage quota: %v", err)
+	}
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "q-e", Type: "note", Label: "q-e"}); !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Errorf("CreateNode over storage quota = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestModelUsage(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ms := store.NewModelUsageStore(base)
+	qs := store.NewQuotaStore(base)
+	ctx := context.Background()
+
+	if _, err := base.Pool.Exec(ctx, "UPDATE tenants SET max_monthly_llm_tokens = 500 WHERE id = $1", tenantID); err != nil {
+		t.Fatalf("setting budget: %v", err)
+	}
+
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+
+	for range 2 {
+		if err := ms.RecordModelUsage(ctx, tenantID, now, map[string]models.ModelUsage{
+			models.ModelUsageEmbedding: {Requests: 1, Tokens: 10},
+			models.ModelUsageLLM:       {Requests: 2, Tokens: 200},
+		}); err != nil {
+			t.Fatalf("RecordModelUsage: %v", err)
+		}
+	}
+
+	// Last month's usage is not this month's.
+	if err := ms.RecordModelUsage(ctx, tenantID, lastMonth, map[string]models.ModelUsage{
+		models.ModelUsageLLM: {Requests: 1, Tokens: 1000},
+	}); err != nil {
+		t.Fatalf("RecordModelUsage(last month): %v", err)
+	}
+
+	used, budget, err := ms.GetTokenBudget(ctx, tenantID, models.ModelUsageLLM, now)
+	if err != nil {
+		t.Fatalf("GetTokenBudget: %v", err)
+	}
+
+	if used != 400 || budget == nil || *budget != 500 {
+		t.Errorf("GetTokenBudget = %d, %v; want 400 of 500", used, budget)
+	}
+
+	usage, err := qs.GetUsage(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+
+	if usage.Embedding != (models.ModelUsage{Requests: 2, Tokens: 20}) || usage.LLM != (models.ModelUsage{Requests: 4, Tokens: 400}) {
+		t.Errorf("GetUsage model usage = %+v / %+v", usage.Embedding, usage.LLM)
+	}
+
+	if usage.Quota.MaxMonthlyEmbeddingTokens != nil {
+		t.Errorf("embedding budget = %v, want unlimited", *usage.Quota.MaxMonthlyEmbeddingTokens)
+	}
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// reembedColumns is the column list scanned by scanReembedStatus.
+const reembedColumns = `tenant_id, target_model, target_dimensions, status, total, processed, failed,
+	last_node_id, COALESCE(error, ''), started_at, updated_at, completed_at`
+
+// staleEmbeddingFilter matches the nodes a re-embed to the model in $1 must
+// regenerate: those without an embedding and those embedded by another model.
+const staleEmbeddingFilter = `tenant_id = current_setting('app.tenant_id')::uuid
+	AND (embedding IS NULL OR embedding_model IS DISTINCT FROM $1)`
+
+func scanReembedStatus(row pgx.Row) (*models.ReembedStatus, error) {
+	var r models.ReembedStatus
+	if err := row.Scan(&r.TenantID, &r.TargetModel, &r.TargetDimensions, &r.Status, &r.Total,
+		&r.Processed, &r.Failed, &r.LastNodeID, &r.Error, &r.StartedAt, &r.UpdatedAt, &r.CompletedAt); err != nil {
+		return nil, err
+	}
+
+	r.Remaining = max(r.Total-r.Processed-r.Failed, 0)
+
+	return &r, nil
+}
+
+// StartReembed records a running re-embed of the tenant's nodes to model,
+// counting the nodes it has to regenerate. It replaces the tenant's previous
+// run unless that run is still going, which is models.ErrReembedInProgress.
+func (s *EmbeddingStore) StartReembed(ctx context.Context, tenantID, model string, dimensions int) (*models.ReembedStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("starting re-embed: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var total int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM kg_nodes WHERE `+staleEmbeddingFilter, model).Scan(&total); err != nil {
+		return nil, fmt.Errorf("counting nodes to re-embed: %w", err)
+	}
+
+	run, err := scanReembedStatus(tx.QueryRow(ctx,
+		`INSERT INTO reembed_runs (tenant_id, target_model, target_dimensions, status, total)
+		 VALUES ($1, $2, $3, 'running', $4)
+		 ON CONFLICT (tenant_id) DO UPDATE SET
+			target_model = EXCLUDED.target_model,
+			target_dimensions = EXCLUDED.target_dimensions,
+			status = 'running',
+			total = EXCLUDED.total,
+			processed = 0,
+			failed = 0,
+			last_node_id = '',
+			error = NULL,
+			started_at = NOW(),
+			updated_at = NOW(),
+			completed_at = NULL
+		 WHERE reembed_runs.status <> 'running'
+		 RETURNING `+reembedColumns,
+		tenantID, model, dimensions, total,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrReembedInProgress
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("recording re-embed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing re-embed start: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetReembedStatus returns the tenant's latest re-embed run.
+func (s *EmbeddingStore) GetReembedStatus(ctx context.Context, tenantID string) (*models.ReembedStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	run, err := scanReembedStatus(s.Pool.QueryRow(ctx,
+		`SELECT `+reembedColumns+` FROM reembed_runs WHERE tenant_id = $1`, tenantID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrReembedNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting re-embed status: %w", err)
+	}
+
+	return run, nil
+}
+
+// ListRunningReembeds returns every tenant's running re-embed.
+func (s *EmbeddingStore) ListRunningReembeds(ctx context.Context) ([]models.ReembedStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx,
+		`SELECT `+reembedColumns+` FROM reembed_runs WHERE status = 'running' ORDER BY started_at`)
+	if err != nil {
+		return nil, fmt.Errorf("listing running re-embeds: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []models.ReembedStatus
+
+	for rows.Next() {
+		run, err := scanReembedStatus(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning re-embed run: %w", err)
+		}
+
+		runs = append(runs, *run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating re-embed runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// ListNodesForReembed returns up to limit nodes after afterID, in ID order,
+// whose embedding is missing or was produced by a model other than model.
+func (s *EmbeddingStore) ListNodesForReembed(
+	ctx context.Context, tenantID, model, afterID string, limit int,
+) ([]models.NodeSummary, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes to re-embed: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		`SELECT id, type, label FROM kg_nodes
+		 WHERE `+staleEmbeddingFilter+` AND id > $2
+		 ORDER BY id
+		 LIMIT $3`, model, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying nodes to re-embed: %w", err)
+	}
+
+	defer rows.Close()
+
+	var summaries []models.NodeSummary
+
+	for rows.Next() {
+		var n models.NodeSummary
+		if err := rows.Scan(&n.ID, &n.Type, &n.Label); err != nil {
+			return nil, fmt.Errorf("scanning node summary: %w", err)
+		}
+
+		summaries = append(summaries, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating node summaries: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// RecordReembedProgress adds a batch's outcome to the tenant's running
+// re-embed and advances its cursor to lastNodeID.
+func (s *EmbeddingStore) RecordReembedProgress(ctx context.Context, tenantID, lastNodeID string, processed, failed int) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.Pool.Exec(ctx,
+		`UPDATE reembed_runs
+		 SET processed = processed + $3, failed = failed + $4, last_node_id = $2, updated_at = NOW()
+		 WHERE tenant_id = $1 AND status = 'running'`,
+		tenantID, lastNodeID, processed, failed)
+	if err != nil {
+		return fmt.Errorf("recording re-embed progress: %w", err)
+	}
+
+	return nil
+}
+
+// FinishReembed ends the tenant's running re-embed with status, recording
+// errMsg when it failed.
+func (s *EmbeddingStore) FinishReembed(ctx context.Context, tenantID, status, errMsg string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.Pool.Exec(ctx,
+		`UPDATE reembed_runs
+		 SET status = $2, error = NULLIF($3, ''), updated_at = NOW(), completed_at = NOW()
+		 WHERE tenant_id = $1 AND status = 'running'`,
+		tenantID, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("finishing re-embed: %w", err)
+	}
+
+	return nil
+}
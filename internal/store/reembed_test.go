@@ -0,0 +1,68 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestReembedRun(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ctx := context.Background()
+
+	first := createTestNode(t, ns, tenantID, "First")
+	second := createTestNode(t, ns, tenantID, "Second")
+
+	base.EmbeddingModel = "model-b"
+	es := store.NewEmbeddingStore(base)
+
+	run, err := es.StartReembed(ctx, tenantID, "model-b", 1024)
+	if err != nil {
+		t.Fatalf("StartReembed: %v", err)
+	}
+	if run.Status != models.ReembedRunning || run.Total != 2 || run.Remaining != 2 {
+		t.Fatalf("run = %+v, want running with 2 nodes", run)
+	}
+
+	if _, err := es.StartReembed(ctx, tenantID, "model-b", 1024); !errors.Is(err, models.ErrReembedInProgress) {
+		t.Fatalf("second StartReembed err = %v, want ErrReembedInProgress", err)
+	}
+
+	embedding := make([]float32, 1024)
+	embedding[0] = 1
+	if err := es.UpdateNodeEmbedding(ctx, tenantID, first.ID, embedding); err != nil {
+		t.Fatalf("UpdateNodeEmbedding: %v", err)
+	}
+
+	nodes, err := es.ListNodesForReembed(ctx, tenantID, "model-b", "", 10)
+	if err != nil {
+		t.Fatalf("ListNodesForReembed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != second.ID {
+		t.Fatalf("nodes to re-embed = %+v, want only %s", nodes, second.ID)
+	}
+
+	if err := es.RecordReembedProgress(ctx, tenantID, second.ID, 1, 1); err != nil {
+		t.Fatalf("RecordReembedProgress: %v", err)
+	}
+	if err := es.FinishReembed(ctx, tenantID, models.ReembedCompleted, ""); err != nil {
+		t.Fatalf("FinishReembed: %v", err)
+	}
+
+	run, err = es.GetReembedStatus(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("GetReembedStatus: %v", err)
+	}
+	if run.Status != models.ReembedCompleted || run.Processed != 1 || run.Failed != 1 ||
+		run.Remaining != 0 || run.CompletedAt == nil {
+		t.Errorf("finished run = %+v", run)
+	}
+
+	if running, err := es.ListRunningReembeds(ctx); err != nil || len(running) != 0 {
+		t.Errorf("ListRunningReembeds = %+v, %v; want none", running, err)
+	}
+}
@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// catalogAllows is true when a rule in the tenant's catalog allows relation
+// e.relation from a node s to a node t. Tenant ID is $1.
+const catalogAllows = `EXISTS (SELECT 1 FROM kg_relation_catalog c
+	WHERE c.tenant_id = $1 AND c.relation = e.relation
+		AND c.source_type IN (s.type, '*') AND c.target_type IN (t.type, '*'))`
+
+// RelationCatalogStore persists tenants' relation catalogs and reports the
+// relation triples in use.
+type RelationCatalogStore struct {
+	Base
+}
+
+// NewRelationCatalogStore creates a new RelationCatalogStore.
+func NewRelationCatalogStore(base Base) *RelationCatalogStore {
+	return &RelationCatalogStore{Base: base}
+}
+
+// GetRelationCatalog returns the tenant's relation catalog, rules ordered by
+// relation, then source and target type.
+func (s *RelationCatalogStore) GetRelationCatalog(ctx context.Context, tenantID string) (*models.RelationCatalog, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting relation catalog: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	catalog := &models.RelationCatalog{}
+
+	err = tx.QueryRow(ctx, `SELECT enforce_relation_catalog FROM tenants WHERE id = $1`, tenantID).Scan(&catalog.Enforce)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading relation catalog toggle: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT source_type, relation, target_type FROM kg_relation_catalog
+		WHERE tenant_id = $1 ORDER BY relation, source_type, target_type`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying relation catalog: %w", err)
+	}
+
+	catalog.Rules, err = pgx.CollectRows(rows, pgx.RowToStructByPos[models.RelationRule])
+	if err != nil {
+		return nil, fmt.Errorf("scanning relation catalog: %w", err)
+	}
+
+	if catalog.Rules == nil {
+		catalog.Rules = []models.RelationRule{}
+	}
+
+	return catalog, nil
+}
+
+// SetRelationCatalog replaces the tenant's relation catalog and enforcement
+// toggle. Existing edges are not rechecked.
+func (s *RelationCatalogStore) SetRelationCatalog(
+	ctx context.Context, tenantID string, catalog models.RelationCatalog,
+) (*models.RelationCatalog, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("setting relation catalog: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx, `UPDATE tenants SET enforce_relation_catalog = $2 WHERE id = $1`, tenantID, catalog.Enforce)
+	if err != nil {
+		return nil, fmt.Errorf("setting relation catalog toggle: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM kg_relation_catalog WHERE tenant_id = $1`, tenantID); err != nil {
+		return nil, fmt.Errorf("clearing relation catalog: %w", err)
+	}
+
+	sources := make([]string, len(catalog.Rules))
+	relations := make([]string, len(catalog.Rules))
+	targets := make([]string, len(catalog.Rules))
+
+	for i, r := range catalog.Rules {
+		sources[i], relations[i], targets[i] = r.SourceType, r.Relation, r.TargetType
+	}
+
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO kg_relation_catalog (tenant_id, source_type, relation, target_type)
+		SELECT $1::uuid, * FROM unnest($2::text[], $3::text[], $4::text[])`,
+		tenantID, sources, relations, targets,
+	); err != nil {
+		return nil, fmt.Errorf("inserting relation catalog: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing relation catalog: %w", err)
+	}
+
+	if catalog.Rules == nil {
+		catalog.Rules = []models.RelationRule{}
+	}
+
+	return &catalog, nil
+}
+
+// ListRelationUsage counts the tenant's edges by (source type, relation,
+// target type), most used first, and marks the triples the catalog allows.
+func (s *RelationCatalogStore) ListRelationUsage(ctx context.Context, tenantID string) ([]models.RelationUsage, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing relation usage: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx, `SELECT s.type, e.relation, t.type, COUNT(*), `+catalogAllows+`
+		FROM kg_edges e
+		JOIN kg_nodes s ON s.tenant_id = e.tenant_id AND s.id = e.source
+		JOIN kg_nodes t ON t.tenant_id = e.tenant_id AND t.id = e.target
+		WHERE e.tenant_id = $1
+		GROUP BY s.type, e.relation, t.type
+		ORDER BY COUNT(*) DESC, e.relation, s.type, t.type`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("querying relation usage: %w", err)
+	}
+
+	usage, err := pgx.CollectRows(rows, pgx.RowToStructByPos[models.RelationUsage])
+	if err != nil {
+		return nil, fmt.Errorf("scanning relation usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+// enforceRelationCatalog fails with models.ErrRelationNotAllowed when the
+// tenant enforces its relation catalog and one of edges matches no rule. It
+// names the first such triple. The edges' nodes must exist. Call it in the
+// writing transaction, before committing.
+func enforceRelationCatalog(ctx context.Context, tx pgx.Tx, tenantID string, edges []models.CreateEdgeRequest) error {
+	var enforce bool
+	if err := tx.QueryRow(ctx,
+		`SELECT enforce_relation_catalog FROM tenants WHERE id = $1`, tenantID,
+	).Scan(&enforce); err != nil {
+		return fmt.Errorf("reading relation catalog toggle: %w", err)
+	}
+
+	if !enforce {
+		return nil
+	}
+
+	sources := make([]string, len(edges))
+	relations := make([]string, len(edges))
+	targets := make([]string, len(edges))
+
+	for i, e := range edges {
+		sources[i], relations[i], targets[i] = e.Source, e.Relation, e.Target
+	}
+
+	var rule models.RelationRule
+
+	err := tx.QueryRow(ctx, `SELECT s.type, e.relation, t.type
+		FROM unnest($2::text[], $3::text[], $4::text[]) AS e(source, relation, target)
+		JOIN kg_nodes s ON s.tenant_id = $1 AND s.id = e.source
+		JOIN kg_nodes t ON t.tenant_id = $1 AND t.id = e.target
+		WHERE NOT `+catalogAllows+`
+		LIMIT 1`,
+		tenantID, sources, relations, targets,
+	).Scan(&rule.SourceType, &rule.Relation, &rule.TargetType)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("checking relation catalog: %w", err)
+	}
+
+	return fmt.Errorf("%w: %s -[%s]-> %s", models.ErrRelationNotAllowed, rule.SourceType, rule.Relation, rule.TargetType)
+}
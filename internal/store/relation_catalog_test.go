@@ -0,0 +1,98 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestRelationCatalog(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	bs := store.NewBulkStore(base)
+	catalogs := store.NewRelationCatalogStore(base)
+	ctx := context.Background()
+
+	node := func(nodeType, label string) *models.Node {
+		n, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{Type: nodeType, Label: label})
+		if err != nil {
+			t.Fatalf("CreateNode(%s): %v", label, err)
+		}
+
+		return n
+	}
+	alice, acme, notes := node("person", "Alice"), node("organization", "Acme"), node("note", "Notes")
+
+	edge := func(source, relation, target string) models.CreateEdgeRequest {
+		return models.CreateEdgeRequest{Source: source, Target: target, Relation: relation}
+	}
+
+	// Not enforced: anything goes.
+	if _, err := es.CreateEdge(ctx, tenantID, edge(notes.ID, "cites", acme.ID)); err != nil {
+		t.Fatalf("CreateEdge before enforcement: %v", err)
+	}
+
+	catalog := models.RelationCatalog{Enforce: true, Rules: []models.RelationRule{
+		{SourceType: "person", Relation: "works_at", TargetType: "organization"},
+		{SourceType: "*", Relation: "mentions", TargetType: "*"},
+	}}
+	if _, err := catalogs.SetRelationCatalog(ctx, tenantID, catalog); err != nil {
+		t.Fatalf("SetRelationCatalog: %v", err)
+	}
+
+	got, err := catalogs.GetRelationCatalog(ctx, tenantID)
+	if err != nil || !got.Enforce || len(got.Rules) != 2 || got.Rules[0].Relation != "mentions" {
+		t.Fatalf("GetRelationCatalog = %+v, %v", got, err)
+	}
+
+	if _, err := es.CreateEdge(ctx, tenantID, edge(alice.ID, "works_at", acme.ID)); err != nil {
+		t.Errorf("allowed edge: %v", err)
+	}
+
+	if _, err := es.CreateEdge(ctx, tenantID, edge(notes.ID, "mentions", alice.ID)); err != nil {
+		t.Errorf("wildcard edge: %v", err)
+	}
+
+	if _, err := es.CreateEdge(ctx, tenantID, edge(acme.ID, "works_at", alice.ID)); !errors.Is(err, models.ErrRelationNotAllowed) {
+		t.Errorf("reversed edge: err = %v, want ErrRelationNotAllowed", err)
+	}
+
+	// One disallowed edge rejects the whole batch.
+	_, err = bs.BulkUpsertEdges(ctx, tenantID, []models.CreateEdgeRequest{
+		edge(alice.ID, "mentions", notes.ID), edge(alice.ID, "owns", acme.ID),
+	})
+	if !errors.Is(err, models.ErrRelationNotAllowed) {
+		t.Errorf("bulk with a disallowed edge: err = %v, want ErrRelationNotAllowed", err)
+	}
+
+	usage, err := catalogs.ListRelationUsage(ctx, tenantID)
+	if err != nil || len(usage) != 3 {
+		t.Fatalf("ListRelationUsage = %+v, %v", usage, err)
+	}
+
+	declared := map[string]bool{}
+	for _, u := range usage {
+		declared[u.Relation] = u.Declared
+		if u.Count != 1 {
+			t.Errorf("%s count = %d, want 1", u.Relation, u.Count)
+		}
+	}
+
+	if !declared["works_at"] || !declared["mentions"] || declared["cites"] {
+		t.Errorf("declared = %v", declared)
+	}
+
+	// Turning enforcement off keeps the rules but allows everything again.
+	catalog.Enforce = false
+	if _, err := catalogs.SetRelationCatalog(ctx, tenantID, catalog); err != nil {
+		t.Fatalf("SetRelationCatalog off: %v", err)
+	}
+
+	if _, err := bs.BulkUpsertEdges(ctx, tenantID, []models.CreateEdgeRequest{edge(alice.ID, "owns", acme.ID)}); err != nil {
+		t.Errorf("bulk after disabling enforcement: %v", err)
+	}
+}
@@ -2,7 +2,6 @@
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -13,6 +12,7 @@
 )
 
 // salienceFormula is the SQL expression for computing salience_score.
+// models.ExplainSalience mirrors it; keep the two in sync.
 const salienceFormula = `GREATEST(0.1,
 	1.0
 	+ log(2.0, access_count + 1) * 0.3
@@ -47,6 +47,11 @@ func (s *SalienceStore) BoostNode(ctx context.Context, tenantID, nodeID string)
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
+	oldSalience, err := fetchNodeSalience(ctx, tx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+
 	sql := `UPDATE kg_nodes
 		SET user_boosted = TRUE,
 			salience_score = ` + salienceFormula + `
@@ -68,6 +73,10 @@ func (s *SalienceStore) BoostNode(ctx context.Context, tenantID, nodeID string)
 		return nil, err
 	}
 
+	if err := recordNodeFieldChange(ctx, tx, tenantID, nodeID, models.HistoryFieldSalience, oldSalience, n.Salience, "boost"); err != nil {
+		return nil, fmt.Errorf("recording salience history: %w", err)
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("committing boost node: %w", err)
 	}
@@ -91,31 +100,45 @@ func (s *SalienceStore) SupersedeNode(
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
+	oldBefore, err := fetchNodeSalience(ctx, tx, oldID)
+	if err != nil {
+		return err
+	}
+
+	newBefore, err := fetchNodeSalience(ctx, tx, newID)
+	if err != nil {
+		return fmt.Errorf("new node %s: %w", newID, err)
+	}
+
+	var oldAfter, newAfter float64
+
 	oldSQL := `UPDATE kg_nodes
 		SET superseded_by = $2,
 			salience_score = ` + salienceFormula + `
-		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+		RETURNING salience_score`
 
-	tag, err := tx.Exec(ctx, oldSQL, oldID, newID)
-	if err != nil {
+	if err := tx.QueryRow(ctx, oldSQL, oldID, newID).Scan(&oldAfter); err != nil {
 		return fmt.Errorf("marking node superseded: %w", err)
 	}
 
-	if tag.RowsAffected() == 0 {
-		return models.ErrNodeNotFound
-	}
-
 	newSQL := `UPDATE kg_nodes
 		SET salience_score = ` + salienceFormula + `
-		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1
+		RETURNING salience_score`
 
-	newTag, err := tx.Exec(ctx, newSQL, newID)
-	if err != nil {
+	if err := tx.QueryRow(ctx, newSQL, newID).Scan(&newAfter); err != nil {
 		return fmt.Errorf("recalculating new node salience: %w", err)
 	}
 
-	if newTag.RowsAffected() == 0 {
-		return fmt.Errorf("new node %s: %w", newID, models.ErrNodeNotFound)
+	reason := "superseded by " + newID
+	if err := recordNodeFieldChange(ctx, tx, tenantID, oldID, models.HistoryFieldSalience, oldBefore, oldAfter, reason); err != nil {
+		return fmt.Errorf("recording salience history: %w", err)
+	}
+
+	reason = "supersedes " + oldID
+	if err := recordNodeFieldChange(ctx, tx, tenantID, newID, models.HistoryFieldSalience, newBefore, newAfter, reason); err != nil {
+		return fmt.Errorf("recording salience history: %w", err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -148,21 +171,17 @@ func (s *SalienceStore) RecalculateSalience(ctx context.Context, tenantID string
 		lastID = newLastID
 	}
 
-	saliencePayload, _ := json.Marshal(map[string]any{ //nolint:errcheck // static keys, cannot fail.
+	s.sendEvent("kg_nodes", "salience_recalculated", tenantID, map[string]any{
 		"event":     "salience_recalculated",
 		"tenant_id": tenantID,
 	})
-	notifyCtx, notifyCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer notifyCancel()
-	if _, err := s.Pool.Exec(notifyCtx, "SELECT pg_notify('kg_changes', $1)", string(saliencePayload)); err != nil {
-		s.Log.WithError(err).Warn("failed to send salience recalculation notification")
-	}
 
 	return total, nil
 }
 
 // recalculateSalienceBatchCursor processes nodes with id > lastID using
-// cursor-based pagination. Returns updated count and the last processed ID.
+// cursor-based pagination, snapshotting each node's score beforehand for the
+// decay report. Returns updated count and the last processed ID.
 func (s *SalienceStore) recalculateSalienceBatchCursor(ctx context.Context, tenantID, lastID string) (updated int, newCursor string, err error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
@@ -188,6 +207,11 @@ func (s *SalienceStore) recalculateSalienceBatchCursor(ctx context.Context, tena
 			FROM batch b
 			WHERE n.id = b.id AND n.tenant_id = current_setting('app.tenant_id')::uuid AND b.old_score IS DISTINCT FROM b.new_score
 			RETURNING n.id
+		),
+		snapshot AS (
+			INSERT INTO kg_salience_snapshots (tenant_id, node_id, score, taken_at)
+			SELECT current_setting('app.tenant_id')::uuid, id, old_score, NOW() FROM batch
+			ON CONFLICT (tenant_id, node_id) DO UPDATE SET score = EXCLUDED.score, taken_at = EXCLUDED.taken_at
 		)
 		SELECT COALESCE((SELECT max(id) FROM batch), ''), (SELECT count(*) FROM updated)`
 
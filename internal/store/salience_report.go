@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const salienceEntryColumns = `n.id, n.type, n.label, n.salience_score, n.access_count,
+	n.last_accessed, n.user_boosted, n.superseded_by`
+
+// TopSalientNodes returns the tenant's highest-salience nodes, optionally of
+// one type, highest first.
+func (s *SalienceStore) TopSalientNodes(
+	ctx context.Context, tenantID, typeFilter string, limit int,
+) ([]models.SalienceEntry, error) {
+	sql := `SELECT ` + salienceEntryColumns + `
+		FROM kg_nodes n
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid AND ($1 = '' OR n.type = $1)
+		ORDER BY n.salience_score DESC, n.id
+		LIMIT $2`
+
+	return s.querySalienceEntries(ctx, tenantID, "listing top salient nodes", sql, false, typeFilter, limit)
+}
+
+// DecayingNodes returns the tenant's nodes whose salience dropped since the
+// snapshot taken before the last recalculation, largest drop first.
+func (s *SalienceStore) DecayingNodes(
+	ctx context.Context, tenantID, typeFilter string, limit int,
+) ([]models.SalienceEntry, error) {
+	sql := `SELECT ` + salienceEntryColumns + `,
+			sn.score, (n.salience_score - sn.score)::float8, sn.taken_at
+		FROM kg_nodes n
+		JOIN kg_salience_snapshots sn ON sn.tenant_id = n.tenant_id AND sn.node_id = n.id
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid AND ($1 = '' OR n.type = $1)
+			AND n.salience_score < sn.score
+		ORDER BY sn.score - n.salience_score DESC, n.id
+		LIMIT $2`
+
+	return s.querySalienceEntries(ctx, tenantID, "listing decaying nodes", sql, true, typeFilter, limit)
+}
+
+func (s *SalienceStore) querySalienceEntries(
+	ctx context.Context, tenantID, what, sql string, withSnapshot bool, args ...any,
+) ([]models.SalienceEntry, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SalienceEntry, error) {
+		var e models.SalienceEntry
+
+		dest := []any{&e.NodeID, &e.Type, &e.Label, &e.Salience, &e.AccessCount,
+			&e.LastAccessed, &e.UserBoosted, &e.SupersededBy}
+		if withSnapshot {
+			dest = append(dest, &e.Previous, &e.Change, &e.SnapshotAt)
+		}
+
+		return e, row.Scan(dest...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+
+	return entries, nil
+}
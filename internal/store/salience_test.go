@@ -61,3 +61,57 @@ func TestRecalculateSalience(t *testing.T) {
 	// Just verify it doesn't error.
 	t.Logf("RecalculateSalience updated %d nodes", count)
 }
+
+func TestSalienceReports(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ss := store.NewSalienceStore(base)
+	ctx := context.Background()
+
+	ids := make([]string, 0, 2)
+	for _, label := range []string{"Report A", "Report B"} {
+		req := models.CreateNodeRequest{Type: "concept", Label: label}
+		_ = req.Validate()
+		n, err := ns.CreateNode(ctx, tenantID, req)
+		if err != nil {
+			t.Fatalf("CreateNode(%s): %v", label, err)
+		}
+		ids = append(ids, n.ID)
+	}
+
+	// Inflate A's stored score so the recalculation demotes it.
+	tx, err := base.Pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		t.Fatalf("set_config: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "UPDATE kg_nodes SET salience_score = 9 WHERE tenant_id = $1 AND id = $2", tenantID, ids[0]); err != nil {
+		t.Fatalf("inflating score: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	top, err := ss.TopSalientNodes(ctx, tenantID, "concept", 1)
+	if err != nil || len(top) != 1 || top[0].NodeID != ids[0] {
+		t.Fatalf("TopSalientNodes = %v, %v; want %s first", top, err, ids[0])
+	}
+
+	if _, err := ss.RecalculateSalience(ctx, tenantID); err != nil {
+		t.Fatalf("RecalculateSalience: %v", err)
+	}
+
+	decaying, err := ss.DecayingNodes(ctx, tenantID, "", 10)
+	if err != nil {
+		t.Fatalf("DecayingNodes: %v", err)
+	}
+	if len(decaying) != 1 || decaying[0].NodeID != ids[0] {
+		t.Fatalf("DecayingNodes = %v; want only %s", decaying, ids[0])
+	}
+	if d := decaying[0]; d.Previous == nil || *d.Previous != 9 || d.Change == nil || *d.Change >= 0 {
+		t.Errorf("decay = previous %v, change %v", d.Previous, d.Change)
+	}
+}
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/persistorai/persistor/internal/models"
 )
@@ -17,16 +18,17 @@ func NewSearchStore(base Base) *SearchStore {
 	return &SearchStore{Base: base}
 }
 
-// FullTextSearch searches nodes using PostgreSQL full-text search with optional
-// type and salience filters. Results are ranked by text relevance and salience.
+// FullTextSearch searches nodes matching filter using PostgreSQL full-text
+// search. Results are ranked by text relevance and salience.
 func (s *SearchStore) FullTextSearch(
 	ctx context.Context,
 	tenantID string,
 	query string,
-	typeFilter string,
-	minSalience float64,
+	filter models.SearchFilter,
 	limit int,
 ) ([]models.Node, error) {
+	defer observeOperation("FullTextSearch", time.Now())
+
 	if limit <= 0 {
 		limit = 20
 	}
@@ -34,68 +36,23 @@ func (s *SearchStore) FullTextSearch(
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("full-text search: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	normalized := models.NormalizeAlias(query)
-	sql := `WITH q AS (SELECT plainto_tsquery('english', $1) AS tsq),
-		node_candidates AS (
-			SELECT id, tenant_id, ts_rank(search_tsv, q.tsq) AS match_score
-			FROM kg_nodes, q
-			WHERE search_tsv @@ q.tsq
-				AND tenant_id = current_setting('app.tenant_id')::uuid
-		),
-		alias_candidates AS (
-			SELECT a.node_id AS id, a.tenant_id,
-				GREATEST(
-					CASE WHEN LOWER(a.alias) = LOWER($1) THEN 1.0 ELSE 0 END,
-					CASE WHEN a.normalized_alias = $2 THEN 0.95 ELSE 0 END,
-					COALESCE(ts_rank(to_tsvector('english', a.alias), q.tsq), 0) * 0.9
-				) AS match_score
-			FROM kg_aliases a, q
-			WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
-				AND (
-					LOWER(a.alias) = LOWER($1)
-					OR a.normalized_alias = $2
-					OR to_tsvector('english', a.alias) @@ q.tsq
-				)
-		),
-		candidates AS (
-			SELECT id, tenant_id, MAX(match_score) AS match_score
-			FROM (
-				SELECT * FROM node_candidates
-				UNION ALL
-				SELECT * FROM alias_candidates
-			) combined
-			GROUP BY id, tenant_id
-		)
-		SELECT ` + nodeColumns + `
-		FROM kg_nodes n
-		INNER JOIN candidates c ON n.tenant_id = c.tenant_id AND n.id = c.id
-		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid`
-
-	args := []any{query, normalized}
-	argIdx := 3
-
-	if typeFilter != "" {
-		sql += fmt.Sprintf(" AND n.type = $%d", argIdx)
-		args = append(args, typeFilter)
-		argIdx++
+	hood, err := resolveNeighborhood(ctx, tx, filter.Neighborhood)
+	if err != nil {
+		return nil, err
 	}
 
-	if minSalience > 0 {
-		sql += fmt.Sprintf(" AND n.salience_score >= $%d", argIdx)
-		args = append(args, minSalience)
-		argIdx++
+	sql, args, err := s.fullTextSearchQuery(query, filter, hood, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	sql += fmt.Sprintf(` ORDER BY (c.match_score * 0.8 + LEAST(n.salience_score / 100.0, 1.0) * 0.2) DESC, n.salience_score DESC, n.updated_at DESC LIMIT $%d`, argIdx)
-	args = append(args, limit)
-
 	rows, err := tx.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("executing full-text search: %w", err)
@@ -107,7 +64,7 @@ func (s *SearchStore) FullTextSearch(
 		return nil, err
 	}
 
-	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodes, filter.OmitProperties); err != nil {
 		return nil, err
 	}
 
@@ -119,13 +76,17 @@ func (s *SearchStore) FullTextSearch(
 }
 
 // SemanticSearch finds nodes similar to the given embedding vector using
-// pgvector cosine distance. The embedding must be pre-computed.
+// pgvector cosine distance, restricted to nodes matching filter. The
+// embedding must be pre-computed.
 func (s *SearchStore) SemanticSearch(
 	ctx context.Context,
 	tenantID string,
 	embedding []float32,
+	filter models.SearchFilter,
 	limit int,
 ) ([]models.ScoredNode, error) {
+	defer observeOperation("SemanticSearch", time.Now())
+
 	if limit <= 0 {
 		limit = 10
 	}
@@ -133,23 +94,24 @@ func (s *SearchStore) SemanticSearch(
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("semantic search: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	embeddingStr := formatEmbedding(embedding)
+	hood, err := resolveNeighborhood(ctx, tx, filter.Neighborhood)
+	if err != nil {
+		return nil, err
+	}
 
-	sql := `SELECT ` + nodeColumns + `, 1 - (embedding <=> $1::vector) AS similarity
-		FROM kg_nodes
-		WHERE embedding IS NOT NULL
-			AND tenant_id = current_setting('app.tenant_id')::uuid
-		ORDER BY embedding <=> $1::vector
-		LIMIT $2`
+	sql, args, err := s.semanticSearchQuery(embedding, filter, hood, limit)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := tx.Query(ctx, sql, embeddingStr, limit)
+	rows, err := tx.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("executing semantic search: %w", err)
 	}
@@ -175,7 +137,7 @@ func (s *SearchStore) SemanticSearch(
 	for i := range scored {
 		nodes[i] = scored[i].Node
 	}
-	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodes, filter.OmitProperties); err != nil {
 		return nil, err
 	}
 	for i := range scored {
@@ -190,14 +152,18 @@ func (s *SearchStore) SemanticSearch(
 }
 
 // HybridSearch combines full-text and vector similarity search using
-// Reciprocal Rank Fusion (RRF) to merge the ranked result lists.
+// Reciprocal Rank Fusion (RRF) to merge the ranked result lists. Both
+// lists are restricted to nodes matching filter.
 func (s *SearchStore) HybridSearch(
 	ctx context.Context,
 	tenantID string,
 	query string,
 	embedding []float32,
+	filter models.SearchFilter,
 	limit int,
 ) ([]models.Node, error) {
+	defer observeOperation("HybridSearch", time.Now())
+
 	if limit <= 0 {
 		limit = 10
 	}
@@ -205,75 +171,24 @@ func (s *SearchStore) HybridSearch(
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	tx, err := s.beginReadTx(ctx, tenantID)
+	tx, err := s.beginReplicaTx(ctx, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("hybrid search: %w", err)
 	}
 
 	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	embeddingStr := formatEmbedding(embedding)
-	normalized := models.NormalizeAlias(query)
-
-	sql := `WITH q AS (SELECT plainto_tsquery('english', $1) AS tsq),
-		fts_raw AS (
-			SELECT id, tenant_id, ts_rank(search_tsv, q.tsq) AS rank
-			FROM kg_nodes, q
-			WHERE search_tsv @@ q.tsq
-				AND tenant_id = current_setting('app.tenant_id')::uuid
-			UNION ALL
-			SELECT a.node_id AS id, a.tenant_id,
-				GREATEST(
-					CASE WHEN LOWER(a.alias) = LOWER($1) THEN 1.0 ELSE 0 END,
-					CASE WHEN a.normalized_alias = $3 THEN 0.95 ELSE 0 END,
-					COALESCE(ts_rank(to_tsvector('english', a.alias), q.tsq), 0) * 0.9
-				) AS rank
-			FROM kg_aliases a, q
-			WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
-				AND (
-					LOWER(a.alias) = LOWER($1)
-					OR a.normalized_alias = $3
-					OR to_tsvector('english', a.alias) @@ q.tsq
-				)
-		),
-		fts AS (
-			SELECT fts_raw.id AS id, fts_raw.tenant_id AS tenant_id, MAX(fts_raw.rank) AS rank
-			FROM fts_raw
-			GROUP BY fts_raw.id, fts_raw.tenant_id
-			ORDER BY MAX(fts_raw.rank) DESC
-			LIMIT $4
-		),
-		vec AS (
-			SELECT id, tenant_id, embedding <=> $2::vector AS dist
-			FROM kg_nodes
-			WHERE embedding IS NOT NULL
-				AND tenant_id = current_setting('app.tenant_id')::uuid
-			ORDER BY dist
-			LIMIT $4
-		),
-		ranked_fts AS (
-			SELECT id, tenant_id, 1.0 / (60 + ROW_NUMBER() OVER (ORDER BY rank DESC)) AS rrf FROM fts
-		),
-		ranked_vec AS (
-			SELECT id, tenant_id, 1.0 / (60 + ROW_NUMBER() OVER (ORDER BY dist)) AS rrf FROM vec
-		),
-		combined AS (
-			SELECT COALESCE(f.id, v.id) AS id,
-				COALESCE(f.tenant_id, v.tenant_id) AS tenant_id,
-				COALESCE(f.rrf, 0) + COALESCE(v.rrf, 0) AS rrf_score
-			FROM ranked_fts f
-			FULL OUTER JOIN ranked_vec v ON f.tenant_id = v.tenant_id AND f.id = v.id
-		)
-		SELECT n.id, n.tenant_id, n.type, n.label, n.properties,
-			n.access_count, n.last_accessed, n.salience_score, n.superseded_by,
-			n.user_boosted, n.created_at, n.updated_at
-		FROM kg_nodes n
-		INNER JOIN combined c ON n.tenant_id = c.tenant_id AND n.id = c.id
-		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid
-		ORDER BY (c.rrf_score * 0.85 + LEAST(n.salience_score / 100.0, 1.0) * 0.15) DESC, n.updated_at DESC
-		LIMIT $4`
-
-	rows, err := tx.Query(ctx, sql, query, embeddingStr, normalized, limit)
+	hood, err := resolveNeighborhood(ctx, tx, filter.Neighborhood)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := s.hybridSearchQuery(query, embedding, filter, hood, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("executing hybrid search: %w", err)
 	}
@@ -284,7 +199,7 @@ func (s *SearchStore) HybridSearch(
 		return nil, err
 	}
 
-	if err := s.decryptNodes(ctx, tenantID, nodes); err != nil {
+	if err := s.readNodeProperties(ctx, tenantID, nodes, filter.OmitProperties); err != nil {
 		return nil, err
 	}
 
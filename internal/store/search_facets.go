@@ -0,0 +1,120 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Facet grouping sets, as reported by GROUPING(f.type, f.bucket, f.month):
+// a bit is set for each column the row is not grouped by.
+const (
+	facetByType     = 0b011
+	facetBySalience = 0b101
+	facetByMonth    = 0b110
+	facetTotal      = 0b111
+)
+
+// FullTextSearchFacets counts the nodes FullTextSearch would match for the
+// same query and filters, ignoring its limit, by type, salience bucket, and
+// creation month. All counts come from one grouped query.
+func (s *SearchStore) FullTextSearchFacets(
+	ctx context.Context,
+	tenantID string,
+	query string,
+	filter models.SearchFilter,
+) (*models.SearchFacets, error) {
+	defer observeOperation("FullTextSearchFacets", time.Now())
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReplicaTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("full-text search facets: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	hood, err := resolveNeighborhood(ctx, tx, filter.Neighborhood)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, args, err := s.fullTextMatchQuery(fmt.Sprintf(`n.type,
+			LEAST(FLOOR(n.salience_score), %d)::int AS bucket,
+			date_trunc('month', n.created_at AT TIME ZONE 'UTC') AS month`, models.SalienceFacetCeiling),
+		query, filter, hood)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT GROUPING(f.type, f.bucket, f.month), f.type, f.bucket, f.month, COUNT(*)
+		FROM (`+matches+`) f
+		GROUP BY GROUPING SETS ((f.type), (f.bucket), (f.month), ())`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("executing full-text search facets: %w", err)
+	}
+	defer rows.Close()
+
+	facets := &models.SearchFacets{
+		Types:    []models.FacetCount{},
+		Salience: []models.SalienceBucket{},
+		Months:   []models.MonthCount{},
+	}
+
+	for rows.Next() {
+		var (
+			grouping int
+			nodeType *string
+			bucket   *int
+			month    *time.Time
+			count    int
+		)
+		if err := rows.Scan(&grouping, &nodeType, &bucket, &month, &count); err != nil {
+			return nil, fmt.Errorf("scanning full-text search facet: %w", err)
+		}
+
+		switch grouping {
+		case facetByType:
+			facets.Types = append(facets.Types, models.FacetCount{Value: *nodeType, Count: count})
+		case facetBySalience:
+			facets.Salience = append(facets.Salience, salienceBucket(*bucket, count))
+		case facetByMonth:
+			facets.Months = append(facets.Months, models.MonthCount{Month: month.UTC(), Count: count})
+		case facetTotal:
+			facets.Total = count
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading full-text search facets: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing full-text search facets: %w", err)
+	}
+
+	slices.SortFunc(facets.Types, func(a, b models.FacetCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Value, b.Value))
+	})
+	slices.SortFunc(facets.Salience, func(a, b models.SalienceBucket) int { return cmp.Compare(a.Min, b.Min) })
+	slices.SortFunc(facets.Months, func(a, b models.MonthCount) int { return a.Month.Compare(b.Month) })
+
+	return facets, nil
+}
+
+// salienceBucket returns the facet bucket whose floor is floor.
+func salienceBucket(floor, count int) models.SalienceBucket {
+	b := models.SalienceBucket{Min: float64(floor), Count: count}
+	if floor < models.SalienceFacetCeiling {
+		maxScore := float64(floor + 1)
+		b.Max = &maxScore
+	}
+
+	return b
+}
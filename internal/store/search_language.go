@@ -0,0 +1,34 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// searchLanguage returns the text search configuration to index searchText
+// with: its detected language when detection is on, English otherwise.
+func (b *Base) searchLanguage(searchText string) string {
+	if !b.DetectLanguage {
+		return models.DefaultSearchLanguage
+	}
+
+	return models.DetectSearchLanguage(searchText)
+}
+
+// searchTSQuery returns SQL for the tsquery of the query text in parameter
+// param. With detection on, nodes may be indexed in any supported language,
+// so the query is stemmed in each of them and the results OR'd together.
+func (b *Base) searchTSQuery(param int) string {
+	if !b.DetectLanguage {
+		return fmt.Sprintf("plainto_tsquery('%s', $%d)", models.DefaultSearchLanguage, param)
+	}
+
+	parts := make([]string, len(models.SearchLanguages))
+	for i, lang := range models.SearchLanguages {
+		parts[i] = fmt.Sprintf("plainto_tsquery('%s', $%d)", lang, param)
+	}
+
+	return "(" + strings.Join(parts, " || ") + ")"
+}
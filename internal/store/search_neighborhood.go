@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// resolveNeighborhood returns the IDs of the nodes within hood, found with
+// the same BFS Traverse uses, for neighborhoodSQL. It returns
+// models.ErrNodeNotFound when the root does not exist, and nil when hood does
+// not restrict search.
+func resolveNeighborhood(ctx context.Context, tx pgx.Tx, hood models.Neighborhood) ([]string, error) {
+	if hood.Root == "" {
+		return nil, nil
+	}
+
+	if err := requireGraphNodesExist(ctx, tx, hood.Root); err != nil {
+		return nil, err
+	}
+
+	ids, err := bfsVisit(ctx, tx, hood.Root, min(max(hood.Depth, 1), maxTraverseHops))
+	if err != nil {
+		return nil, fmt.Errorf("resolving search neighborhood: %w", err)
+	}
+
+	return ids, nil
+}
+
+// neighborhoodSQL returns the WHERE clause and argument restricting column to
+// ids, a neighborhood from resolveNeighborhood, as parameter argIdx. A nil
+// ids does not restrict column.
+func neighborhoodSQL(ids []string, column string, argIdx int) (string, []any) {
+	if ids == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" AND %s = ANY($%d)", column, argIdx), []any{ids}
+}
@@ -0,0 +1,237 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// fullTextSearchQuery builds the FullTextSearch statement and its arguments.
+// hood is the resolved neighborhood, nil when search is not restricted to one.
+func (s *SearchStore) fullTextSearchQuery(
+	query string, filter models.SearchFilter, hood []string, limit int,
+) (string, []any, error) {
+	sql, args, err := s.fullTextMatchQuery(nodeColumns, query, filter, hood)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql += fmt.Sprintf(` ORDER BY (c.match_score * 0.8 + LEAST(n.salience_score / 100.0, 1.0) * 0.2) DESC, n.salience_score DESC, n.updated_at DESC LIMIT $%d`, len(args)+1)
+
+	return sql, append(args, limit), nil
+}
+
+// fullTextMatchQuery builds a statement selecting columns from every node,
+// aliased n, that matches a full-text search and its filters, with the match
+// score available as c.match_score.
+func (s *SearchStore) fullTextMatchQuery(
+	columns, query string, filter models.SearchFilter, hood []string,
+) (string, []any, error) {
+	query = models.NormalizeText(query)
+	normalized := models.NormalizeAlias(query)
+	sql := `WITH q AS (SELECT ` + s.searchTSQuery(1) + ` AS tsq),
+		node_candidates AS (
+			SELECT id, tenant_id, ts_rank(search_tsv, q.tsq) AS match_score
+			FROM kg_nodes, q
+			WHERE search_tsv @@ q.tsq
+				AND tenant_id = current_setting('app.tenant_id')::uuid
+		),
+		alias_candidates AS (
+			SELECT a.node_id AS id, a.tenant_id,
+				GREATEST(
+					CASE WHEN LOWER(a.alias) = LOWER($1) THEN 1.0 ELSE 0 END,
+					CASE WHEN a.normalized_alias = $2 THEN 0.95 ELSE 0 END,
+					COALESCE(ts_rank(to_tsvector('english', a.alias), q.tsq), 0) * 0.9
+				) AS match_score
+			FROM kg_aliases a, q
+			WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
+				AND (
+					LOWER(a.alias) = LOWER($1)
+					OR a.normalized_alias = $2
+					OR to_tsvector('english', a.alias) @@ q.tsq
+				)
+		),
+		candidates AS (
+			SELECT id, tenant_id, MAX(match_score) AS match_score
+			FROM (
+				SELECT * FROM node_candidates
+				UNION ALL
+				SELECT * FROM alias_candidates
+			) combined
+			GROUP BY id, tenant_id
+		)
+		SELECT ` + columns + `
+		FROM kg_nodes n
+		INNER JOIN candidates c ON n.tenant_id = c.tenant_id AND n.id = c.id
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid`
+
+	args := []any{query, normalized}
+
+	filterWhere, filterArgs := searchFilterSQL(filter, "n.", len(args)+1)
+	sql += filterWhere
+	args = append(args, filterArgs...)
+
+	hoodWhere, hoodArgs := neighborhoodSQL(hood, "n.id", len(args)+1)
+	sql += hoodWhere
+	args = append(args, hoodArgs...)
+
+	propsWhere, propsArgs, err := s.propertyFilterSQL(filter.Properties, "n.search_props", len(args)+1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return sql + propsWhere, append(args, propsArgs...), nil
+}
+
+// searchFilterSQL returns the WHERE clauses and arguments for filter,
+// numbering its parameters from argIdx. prefix qualifies the node columns,
+// e.g. "n.".
+func searchFilterSQL(filter models.SearchFilter, prefix string, argIdx int) (string, []any) {
+	var (
+		sql  string
+		args []any
+	)
+
+	if filter.Type != "" {
+		sql += fmt.Sprintf(" AND %stype = $%d", prefix, argIdx)
+		args = append(args, filter.Type)
+		argIdx++
+	}
+
+	if filter.MinSalience > 0 {
+		sql += fmt.Sprintf(" AND %ssalience_score >= $%d", prefix, argIdx)
+		args = append(args, filter.MinSalience)
+	}
+
+	if !filter.IncludeSuperseded {
+		sql += " AND " + prefix + "superseded_by IS NULL"
+	}
+
+	return sql, args
+}
+
+// semanticSearchQuery builds the SemanticSearch statement and its arguments.
+func (s *SearchStore) semanticSearchQuery(
+	embedding []float32,
+	filter models.SearchFilter,
+	hood []string,
+	limit int,
+) (string, []any, error) {
+	args := []any{formatEmbedding(embedding), limit}
+
+	filterWhere, filterArgs := searchFilterSQL(filter, "", len(args)+1)
+	args = append(args, filterArgs...)
+
+	hoodWhere, hoodArgs := neighborhoodSQL(hood, "id", len(args)+1)
+	args = append(args, hoodArgs...)
+
+	propsWhere, propsArgs, err := s.propertyFilterSQL(filter.Properties, "search_props", len(args)+1)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql := `SELECT ` + nodeColumns + `, 1 - (embedding <=> $1::vector) AS similarity
+	FROM kg_nodes
+	WHERE embedding IS NOT NULL
+		AND tenant_id = current_setting('app.tenant_id')::uuid` + filterWhere + hoodWhere + propsWhere + `
+	ORDER BY embedding <=> $1::vector
+	LIMIT $2`
+
+	return sql, append(args, propsArgs...), nil
+}
+
+// hybridSearchQuery builds the HybridSearch statement and its arguments.
+func (s *SearchStore) hybridSearchQuery(
+	query string, embedding []float32, filter models.SearchFilter, hood []string, limit int,
+) (string, []any, error) {
+	// Filters go inside the fts and vec candidate lists too, so their limits
+	// are not used up by nodes the filters drop.
+	filterWhere, filterArgs := searchFilterSQL(filter, "pn.", 5)
+	hoodIdx := 5 + len(filterArgs)
+	hoodWhere, hoodArgs := neighborhoodSQL(hood, "pn.id", hoodIdx)
+	propsIdx := hoodIdx + len(hoodArgs)
+
+	propsWhere, propsArgs, err := s.propertyFilterSQL(filter.Properties, "pn.search_props", propsIdx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Same filters and arguments, on the vec candidates' own columns.
+	vecFilterWhere, _ := searchFilterSQL(filter, "", 5)
+	vecHoodWhere, _ := neighborhoodSQL(hood, "id", hoodIdx)
+	vecWhere, _, _ := s.propertyFilterSQL(filter.Properties, "search_props", propsIdx)
+
+	ftsJoin := ""
+	if filterWhere != "" || hoodWhere != "" || propsWhere != "" {
+		ftsJoin = `
+			INNER JOIN kg_nodes pn ON pn.tenant_id = fts_raw.tenant_id AND pn.id = fts_raw.id
+			WHERE TRUE` + filterWhere + hoodWhere + propsWhere
+	}
+
+	embeddingStr := formatEmbedding(embedding)
+	query = models.NormalizeText(query)
+	normalized := models.NormalizeAlias(query)
+
+	sql := `WITH q AS (SELECT ` + s.searchTSQuery(1) + ` AS tsq),
+		fts_raw AS (
+			SELECT id, tenant_id, ts_rank(search_tsv, q.tsq) AS rank
+			FROM kg_nodes, q
+			WHERE search_tsv @@ q.tsq
+				AND tenant_id = current_setting('app.tenant_id')::uuid
+			UNION ALL
+			SELECT a.node_id AS id, a.tenant_id,
+				GREATEST(
+					CASE WHEN LOWER(a.alias) = LOWER($1) THEN 1.0 ELSE 0 END,
+					CASE WHEN a.normalized_alias = $3 THEN 0.95 ELSE 0 END,
+					COALESCE(ts_rank(to_tsvector('english', a.alias), q.tsq), 0) * 0.9
+				) AS rank
+			FROM kg_aliases a, q
+			WHERE a.tenant_id = current_setting('app.tenant_id')::uuid
+				AND (
+					LOWER(a.alias) = LOWER($1)
+					OR a.normalized_alias = $3
+					OR to_tsvector('english', a.alias) @@ q.tsq
+				)
+		),
+		fts AS (
+			SELECT fts_raw.id AS id, fts_raw.tenant_id AS tenant_id, MAX(fts_raw.rank) AS rank
+			FROM fts_raw` + ftsJoin + `
+			GROUP BY fts_raw.id, fts_raw.tenant_id
+			ORDER BY MAX(fts_raw.rank) DESC
+			LIMIT $4
+		),
+		vec AS (
+			SELECT id, tenant_id, embedding <=> $2::vector AS dist
+			FROM kg_nodes
+			WHERE embedding IS NOT NULL
+				AND tenant_id = current_setting('app.tenant_id')::uuid` + vecFilterWhere + vecHoodWhere + vecWhere + `
+			ORDER BY dist
+			LIMIT $4
+		),
+		ranked_fts AS (
+			SELECT id, tenant_id, 1.0 / (60 + ROW_NUMBER() OVER (ORDER BY rank DESC)) AS rrf FROM fts
+		),
+		ranked_vec AS (
+			SELECT id, tenant_id, 1.0 / (60 + ROW_NUMBER() OVER (ORDER BY dist)) AS rrf FROM vec
+		),
+		combined AS (
+			SELECT COALESCE(f.id, v.id) AS id,
+				COALESCE(f.tenant_id, v.tenant_id) AS tenant_id,
+				COALESCE(f.rrf, 0) + COALESCE(v.rrf, 0) AS rrf_score
+			FROM ranked_fts f
+			FULL OUTER JOIN ranked_vec v ON f.tenant_id = v.tenant_id AND f.id = v.id
+		)
+		SELECT n.id, n.tenant_id, n.type, n.label, n.properties,
+			n.access_count, n.last_accessed, n.salience_score, n.superseded_by,
+			n.user_boosted, n.created_at, n.updated_at
+		FROM kg_nodes n
+		INNER JOIN combined c ON n.tenant_id = c.tenant_id AND n.id = c.id
+		WHERE n.tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY (c.rrf_score * 0.85 + LEAST(n.salience_score / 100.0, 1.0) * 0.15) DESC, n.updated_at DESC
+		LIMIT $4`
+
+	args := append([]any{query, embeddingStr, normalized, limit}, filterArgs...)
+	args = append(args, hoodArgs...)
+
+	return sql, append(args, propsArgs...), nil
+}
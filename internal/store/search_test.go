@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/persistorai/persistor/internal/models"
@@ -28,7 +29,7 @@ func TestFullTextSearch(t *testing.T) {
 	}
 
 	// Search for "quantum" — should find 2 nodes.
-	results, err := ss.FullTextSearch(ctx, tenantID, "quantum", "", 0, 10)
+	results, err := ss.FullTextSearch(ctx, tenantID, "quantum", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch: %v", err)
 	}
@@ -38,7 +39,7 @@ func TestFullTextSearch(t *testing.T) {
 	}
 
 	// Search for "classical" — should find 1 node.
-	results, err = ss.FullTextSearch(ctx, tenantID, "classical", "", 0, 10)
+	results, err = ss.FullTextSearch(ctx, tenantID, "classical", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch: %v", err)
 	}
@@ -48,7 +49,7 @@ func TestFullTextSearch(t *testing.T) {
 	}
 
 	// Search with type filter.
-	results, err = ss.FullTextSearch(ctx, tenantID, "quantum", "nonexistent", 0, 10)
+	results, err = ss.FullTextSearch(ctx, tenantID, "quantum", models.SearchFilter{Type: "nonexistent"}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch with type filter: %v", err)
 	}
@@ -70,7 +71,7 @@ func TestFullTextSearch(t *testing.T) {
 		t.Fatalf("CreateNode(propertyReq): %v", err)
 	}
 
-	results, err = ss.FullTextSearch(ctx, tenantID, "agents", "", 0, 10)
+	results, err = ss.FullTextSearch(ctx, tenantID, "agents", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch(property text): %v", err)
 	}
@@ -101,7 +102,7 @@ func TestFullTextSearch_UsesAliasCandidates(t *testing.T) {
 		t.Fatalf("CreateAlias full_name: %v", err)
 	}
 
-	results, err := ss.FullTextSearch(ctx, tenantID, "Bill Gates", "", 0, 10)
+	results, err := ss.FullTextSearch(ctx, tenantID, "Bill Gates", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch exact alias: %v", err)
 	}
@@ -109,7 +110,7 @@ func TestFullTextSearch_UsesAliasCandidates(t *testing.T) {
 		t.Fatalf("FullTextSearch exact alias = %#v, want node %q", results, node.ID)
 	}
 
-	results, err = ss.FullTextSearch(ctx, tenantID, "william h. gates", "", 0, 10)
+	results, err = ss.FullTextSearch(ctx, tenantID, "william h. gates", models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("FullTextSearch normalized alias: %v", err)
 	}
@@ -136,7 +137,7 @@ func TestHybridSearch_UsesAliasCandidates(t *testing.T) {
 		t.Fatalf("CreateAlias: %v", err)
 	}
 
-	results, err := ss.HybridSearch(ctx, tenantID, "Mark Twain", []float32{0.1, 0.2}, 10)
+	results, err := ss.HybridSearch(ctx, tenantID, "Mark Twain", []float32{0.1, 0.2}, models.SearchFilter{}, 10)
 	if err != nil {
 		t.Fatalf("HybridSearch alias: %v", err)
 	}
@@ -144,3 +145,235 @@ func TestHybridSearch_UsesAliasCandidates(t *testing.T) {
 		t.Fatalf("HybridSearch alias = %#v, want node %q", results, node.ID)
 	}
 }
+
+func TestHybridSearch_SearchFilter(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	sal := store.NewSalienceStore(base)
+	ss := store.NewSearchStore(base)
+	ctx := context.Background()
+
+	ids := make([]string, 0, 3)
+	for _, nodeType := range []string{"project", "project", "person"} {
+		req := models.CreateNodeRequest{Type: nodeType, Label: "Apollo " + nodeType}
+		_ = req.Validate()
+		node, err := ns.CreateNode(ctx, tenantID, req)
+		if err != nil {
+			t.Fatalf("CreateNode(%s): %v", nodeType, err)
+		}
+		ids = append(ids, node.ID)
+	}
+
+	if err := sal.SupersedeNode(ctx, tenantID, ids[1], ids[0]); err != nil {
+		t.Fatalf("SupersedeNode: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.SearchFilter
+		want   int
+	}{
+		{"default excludes superseded", models.SearchFilter{}, 2},
+		{"type", models.SearchFilter{Type: "project"}, 1},
+		{"include superseded", models.SearchFilter{IncludeSuperseded: true}, 3},
+		{"type and superseded", models.SearchFilter{Type: "project", IncludeSuperseded: true}, 2},
+		{"salience above all", models.SearchFilter{MinSalience: 1e6}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := ss.HybridSearch(ctx, tenantID, "apollo", []float32{0.1, 0.2}, tt.filter, 10)
+			if err != nil {
+				t.Fatalf("HybridSearch: %v", err)
+			}
+
+			if len(results) != tt.want {
+				t.Errorf("HybridSearch(%+v) = %d results, want %d", tt.filter, len(results), tt.want)
+			}
+		})
+	}
+}
+
+func TestFullTextSearch_DetectsLanguage(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	base.DetectLanguage = true
+	ns := store.NewNodeStore(base)
+	ss := store.NewSearchStore(base)
+	ctx := context.Background()
+
+	req := models.CreateNodeRequest{
+		Type:       "place",
+		Label:      "Die Häuser der Altstadt",
+		Properties: map[string]any{"description": "Die Häuser sind nicht mehr bewohnt und werden von der Stadt verwaltet."},
+	}
+	_ = req.Validate()
+	if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+		t.Fatalf("CreateNode: %v", err)
+	}
+
+	// German stemming reduces both "Häuser" and "Haus" to "haus".
+	results, err := ss.FullTextSearch(ctx, tenantID, "Haus", models.SearchFilter{}, 10)
+	if err != nil {
+		t.Fatalf("FullTextSearch: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("FullTextSearch(Haus) = %d results, want 1", len(results))
+	}
+}
+
+func TestFullTextSearch_PropertyFilters(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	base.SearchableProperties = []string{"status", "priority"}
+	ns := store.NewNodeStore(base)
+	ss := store.NewSearchStore(base)
+	ctx := context.Background()
+
+	for _, props := range []map[string]any{
+		{"status": "active", "priority": 3},
+		{"status": "archived"},
+		{"secret": "active"},
+	} {
+		req := models.CreateNodeRequest{Type: "project", Label: "Apollo project", Properties: props}
+		_ = req.Validate()
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode(%v): %v", props, err)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		filters []models.PropertyFilter
+		want    int
+	}{
+		{"value", []models.PropertyFilter{{Key: "status", Value: str("active")}}, 1},
+		{"numeric value", []models.PropertyFilter{{Key: "priority", Value: str("3")}}, 1},
+		{"key only", []models.PropertyFilter{{Key: "status"}}, 2},
+		{"no match", []models.PropertyFilter{{Key: "status", Value: str("draft")}}, 0},
+	}
+
+	for _, tt := range tests {
+		results, err := ss.FullTextSearch(ctx, tenantID, "apollo", models.SearchFilter{Properties: tt.filters}, 10)
+		if err != nil {
+			t.Fatalf("%s: FullTextSearch: %v", tt.name, err)
+		}
+
+		if len(results) != tt.want {
+			t.Errorf("%s: got %d results, want %d", tt.name, len(results), tt.want)
+		}
+	}
+
+	secret := []models.PropertyFilter{{Key: "secret", Value: str("active")}}
+	_, err := ss.FullTextSearch(ctx, tenantID, "apollo", models.SearchFilter{Properties: secret}, 10)
+	if !errors.Is(err, models.ErrPropertyNotSearchable) {
+		t.Errorf("FullTextSearch(secret) error = %v, want ErrPropertyNotSearchable", err)
+	}
+}
+
+func TestFullTextSearchFacets(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	ss := store.NewSearchStore(base)
+	ctx := context.Background()
+
+	for _, nodeType := range []string{"project", "project", "person"} {
+		req := models.CreateNodeRequest{Type: nodeType, Label: "Apollo " + nodeType}
+		_ = req.Validate()
+		if _, err := ns.CreateNode(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateNode(%s): %v", nodeType, err)
+		}
+	}
+
+	facets, err := ss.FullTextSearchFacets(ctx, tenantID, "apollo", models.SearchFilter{})
+	if err != nil {
+		t.Fatalf("FullTextSearchFacets: %v", err)
+	}
+
+	if facets.Total != 3 {
+		t.Errorf("Total = %d, want 3", facets.Total)
+	}
+
+	want := []models.FacetCount{{Value: "project", Count: 2}, {Value: "person", Count: 1}}
+	if len(facets.Types) != len(want) || facets.Types[0] != want[0] || facets.Types[1] != want[1] {
+		t.Errorf("Types = %v, want %v", facets.Types, want)
+	}
+
+	for name, counted := range map[string]int{
+		"salience": sumCounts(facets.Salience, func(b models.SalienceBucket) int { return b.Count }),
+		"months":   sumCounts(facets.Months, func(m models.MonthCount) int { return m.Count }),
+	} {
+		if counted != 3 {
+			t.Errorf("%s facets count %d nodes, want 3", name, counted)
+		}
+	}
+
+	facets, err = ss.FullTextSearchFacets(ctx, tenantID, "apollo", models.SearchFilter{Type: "person"})
+	if err != nil {
+		t.Fatalf("FullTextSearchFacets(person): %v", err)
+	}
+
+	if facets.Total != 1 || len(facets.Types) != 1 {
+		t.Errorf("type-filtered facets = %+v, want one person", facets)
+	}
+}
+
+func sumCounts[T any](items []T, count func(T) int) int {
+	total := 0
+	for _, item := range items {
+		total += count(item)
+	}
+
+	return total
+}
+
+func TestSearch_Neighborhood(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	es := store.NewEdgeStore(base)
+	ss := store.NewSearchStore(base)
+	ctx := context.Background()
+
+	// root -> a -> b, plus an unconnected node.
+	ids := make([]string, 0, 4)
+	for _, label := range []string{"root", "a", "b", "island"} {
+		req := models.CreateNodeRequest{Type: "concept", Label: "Apollo " + label}
+		_ = req.Validate()
+		node, err := ns.CreateNode(ctx, tenantID, req)
+		if err != nil {
+			t.Fatalf("CreateNode(%s): %v", label, err)
+		}
+		ids = append(ids, node.ID)
+	}
+	for i := range 2 {
+		req := models.CreateEdgeRequest{Source: ids[i], Target: ids[i+1], Relation: "related_to"}
+		if _, err := es.CreateEdge(ctx, tenantID, req); err != nil {
+			t.Fatalf("CreateEdge: %v", err)
+		}
+	}
+
+	for depth, want := range map[int]int{1: 2, 2: 3} {
+		hood := models.SearchFilter{Neighborhood: models.Neighborhood{Root: ids[0], Depth: depth}}
+
+		nodes, err := ss.FullTextSearch(ctx, tenantID, "apollo", hood, 10)
+		if err != nil {
+			t.Fatalf("FullTextSearch: %v", err)
+		}
+		if len(nodes) != want {
+			t.Errorf("FullTextSearch depth %d = %d results, want %d", depth, len(nodes), want)
+		}
+
+		nodes, err = ss.HybridSearch(ctx, tenantID, "apollo", []float32{0.1, 0.2}, hood, 10)
+		if err != nil {
+			t.Fatalf("HybridSearch: %v", err)
+		}
+		if len(nodes) != want {
+			t.Errorf("HybridSearch depth %d = %d results, want %d", depth, len(nodes), want)
+		}
+	}
+
+	missing := models.SearchFilter{Neighborhood: models.Neighborhood{Root: "no-such-node", Depth: 1}}
+	if _, err := ss.FullTextSearch(ctx, tenantID, "apollo", missing, 10); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("unknown root: err = %v, want ErrNodeNotFound", err)
+	}
+}
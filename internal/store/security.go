@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// SecurityStore persists brute-force guard state so that blocks survive
+// restarts and are shared between replicas. It implements
+// security.BlockStore.
+type SecurityStore struct {
+	Pool *dbpool.Pool
+}
+
+// NewSecurityStore creates a new SecurityStore.
+func NewSecurityStore(pool *dbpool.Pool) *SecurityStore {
+	return &SecurityStore{Pool: pool}
+}
+
+// RecordAuthFailure counts a failed authentication for keyHash at now. The
+// count restarts when the first recorded failure is older than windowStart,
+// and the key is blocked until lockUntil once it reaches maxAttempts.
+func (s *SecurityStore) RecordAuthFailure(
+	ctx context.Context,
+	keyHash string,
+	now, windowStart, lockUntil time.Time,
+	maxAttempts int,
+) (attempts int, blockedUntil *time.Time, err error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	err = s.Pool.QueryRow(ctx, `INSERT INTO security_blocks AS b (key_hash, attempts, first_failure_at)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key_hash) DO UPDATE SET
+			attempts = CASE WHEN b.first_failure_at < $3 THEN 1 ELSE b.attempts + 1 END,
+			first_failure_at = CASE WHEN b.first_failure_at < $3 THEN $2 ELSE b.first_failure_at END,
+			blocked_until = CASE
+				WHEN b.first_failure_at < $3 THEN NULL
+				WHEN b.attempts + 1 >= $5 THEN $4
+				ELSE b.blocked_until
+			END
+		RETURNING attempts, blocked_until`,
+		keyHash, now, windowStart, lockUntil, maxAttempts,
+	).Scan(&attempts, &blockedUntil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("recording auth failure: %w", err)
+	}
+
+	return attempts, blockedUntil, nil
+}
+
+// ResetAuthFailures clears the failure count and any block for keyHash.
+func (s *SecurityStore) ResetAuthFailures(ctx context.Context, keyHash string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	if _, err := s.Pool.Exec(ctx, `DELETE FROM security_blocks WHERE key_hash = $1`, keyHash); err != nil {
+		return fmt.Errorf("resetting auth failures: %w", err)
+	}
+
+	return nil
+}
+
+// ListSecurityBlocks returns keys still blocked at now, longest block first.
+func (s *SecurityStore) ListSecurityBlocks(ctx context.Context, now time.Time) ([]models.SecurityBlock, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT key_hash, attempts, first_failure_at, blocked_until
+		FROM security_blocks
+		WHERE blocked_until > $1
+		ORDER BY blocked_until DESC, key_hash`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listing security blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []models.SecurityBlock{}
+
+	for rows.Next() {
+		var b models.SecurityBlock
+		if err := rows.Scan(&b.KeyHash, &b.Attempts, &b.FirstFailureAt, &b.BlockedUntil); err != nil {
+			return nil, fmt.Errorf("scanning security block: %w", err)
+		}
+
+		blocks = append(blocks, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating security blocks: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// PurgeSecurityBlocks deletes blocks that ended before now and unblocked
+// failure counts whose window started before windowStart.
+func (s *SecurityStore) PurgeSecurityBlocks(ctx context.Context, now, windowStart time.Time) (int64, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tag, err := s.Pool.Exec(ctx, `DELETE FROM security_blocks
+		WHERE blocked_until <= $1
+			OR (blocked_until IS NULL AND first_failure_at < $2)`,
+		now, windowStart,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purging security blocks: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
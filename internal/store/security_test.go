@@ -0,0 +1,58 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/security"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestSecurityBlocks(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	var s security.BlockStore = store.NewSecurityStore(base.Pool)
+	ctx := context.Background()
+	keyHash := "hash-" + tenantID
+	now := time.Now()
+
+	t.Cleanup(func() { s.ResetAuthFailures(ctx, keyHash) }) //nolint:errcheck // best-effort cleanup
+
+	for i := 1; i <= 3; i++ {
+		attempts, until, err := s.RecordAuthFailure(ctx, keyHash, now, now.Add(-time.Minute), now.Add(time.Minute), 3)
+		if err != nil {
+			t.Fatalf("RecordAuthFailure: %v", err)
+		}
+		if attempts != i || (until != nil) != (i == 3) {
+			t.Fatalf("attempt %d = %d, blocked until %v", i, attempts, until)
+		}
+	}
+
+	blocks, err := s.ListSecurityBlocks(ctx, now)
+	if err != nil {
+		t.Fatalf("ListSecurityBlocks: %v", err)
+	}
+
+	found := false
+	for _, b := range blocks {
+		found = found || b.KeyHash == keyHash
+	}
+	if !found {
+		t.Fatalf("blocks = %+v, want %s", blocks, keyHash)
+	}
+
+	// Once the block has ended it is no longer listed and is purged.
+	later := now.Add(2 * time.Minute)
+	blocks, err = s.ListSecurityBlocks(ctx, later)
+	if err != nil {
+		t.Fatalf("ListSecurityBlocks: %v", err)
+	}
+	for _, b := range blocks {
+		if b.KeyHash == keyHash {
+			t.Fatalf("expired block still listed: %+v", b)
+		}
+	}
+	if n, err := s.PurgeSecurityBlocks(ctx, later, later.Add(-time.Minute)); err != nil || n == 0 {
+		t.Fatalf("PurgeSecurityBlocks = %d, %v", n, err)
+	}
+}
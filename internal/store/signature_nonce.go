@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SignatureNonceStore persists the nonces of verified request signatures so
+// that replay is refused on every replica. It implements security.NonceStore.
+type SignatureNonceStore struct {
+	Base
+}
+
+// NewSignatureNonceStore creates a new SignatureNonceStore.
+func NewSignatureNonceStore(base Base) *SignatureNonceStore {
+	return &SignatureNonceStore{Base: base}
+}
+
+// RememberNonce records nonce until expiresAt and reports whether it was
+// free. An expired nonce is free again. Expired nonces of the tenant are
+// removed on the way.
+func (s *SignatureNonceStore) RememberNonce(ctx context.Context, tenantID, nonce string, now, expiresAt time.Time) (bool, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("recording signature nonce: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM kg_signature_nonces
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND expires_at < $1
+	`, now); err != nil {
+		return false, fmt.Errorf("removing expired signature nonces: %w", err)
+	}
+
+	var fresh bool
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kg_signature_nonces AS n (tenant_id, nonce, expires_at)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2)
+		ON CONFLICT (tenant_id, nonce) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE n.expires_at <= $3
+		RETURNING true
+	`, nonce, expiresAt, now).Scan(&fresh)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("recording signature nonce: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("committing signature nonce: %w", err)
+	}
+
+	return fresh, nil
+}
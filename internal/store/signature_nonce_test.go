@@ -0,0 +1,31 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestSignatureNonces(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewSignatureNonceStore(base)
+	ctx := context.Background()
+	now := time.Now()
+
+	fresh, err := s.RememberNonce(ctx, tenantID, "n1", now, now.Add(time.Minute))
+	if err != nil || !fresh {
+		t.Fatalf("first RememberNonce = %v, %v; want fresh", fresh, err)
+	}
+
+	fresh, err = s.RememberNonce(ctx, tenantID, "n1", now, now.Add(time.Minute))
+	if err != nil || fresh {
+		t.Errorf("replayed RememberNonce = %v, %v; want refused", fresh, err)
+	}
+
+	fresh, err = s.RememberNonce(ctx, tenantID, "n1", now.Add(2*time.Minute), now.Add(3*time.Minute))
+	if err != nil || !fresh {
+		t.Errorf("RememberNonce after expiry = %v, %v; want fresh", fresh, err)
+	}
+}
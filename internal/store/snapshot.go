@@ -0,0 +1,221 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// snapshotColumns lists the columns selected for snapshot queries.
+const snapshotColumns = `name, description, export_ref, node_count, edge_count, types, relations, created_at`
+
+// snapshotGroupsSQL counts the tenant's nodes per type and edges per
+// relation, with an order-independent hash of each group's contents.
+const snapshotGroupsSQL = `SELECT 'node', type, COUNT(*),
+		to_hex(bit_xor(hashtextextended(id || E'\x1f' || label || E'\x1f' || updated_at::text, 0)))
+	FROM kg_nodes
+	WHERE tenant_id = current_setting('app.tenant_id')::uuid
+	GROUP BY type
+	UNION ALL
+	SELECT 'edge', relation, COUNT(*),
+		to_hex(bit_xor(hashtextextended(source || E'\x1f' || target || E'\x1f' || updated_at::text, 0)))
+	FROM kg_edges
+	WHERE tenant_id = current_setting('app.tenant_id')::uuid
+	GROUP BY relation`
+
+// SnapshotStore records named logical snapshots of a tenant's graph.
+type SnapshotStore struct {
+	Base
+}
+
+// NewSnapshotStore creates a new SnapshotStore.
+func NewSnapshotStore(base Base) *SnapshotStore {
+	return &SnapshotStore{Base: base}
+}
+
+// scanSnapshot scans one row of snapshotColumns.
+func scanSnapshot(row pgx.Row) (*models.Snapshot, error) {
+	var s models.Snapshot
+	if err := row.Scan(&s.Name, &s.Description, &s.ExportRef, &s.NodeCount, &s.EdgeCount,
+		&s.Types, &s.Relations, &s.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// CreateSnapshot records the counts and content hashes of the tenant's
+// graph as it is now under req.Name. It returns models.ErrSnapshotExists
+// when the name is taken.
+func (s *SnapshotStore) CreateSnapshot(
+	ctx context.Context, tenantID string, req models.CreateSnapshotRequest,
+) (*models.Snapshot, error) {
+	defer observeOperation("CreateSnapshot", time.Now())
+
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating snapshot: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	snap := &models.Snapshot{
+		Name:        req.Name,
+		Description: req.Description,
+		ExportRef:   req.ExportRef,
+		Types:       make(map[string]models.SnapshotGroup),
+		Relations:   make(map[string]models.SnapshotGroup),
+	}
+
+	rows, err := tx.Query(ctx, snapshotGroupsSQL)
+	if err != nil {
+		return nil, fmt.Errorf("summarizing graph: %w", err)
+	}
+
+	for rows.Next() {
+		var (
+			kind, name string
+			g          models.SnapshotGroup
+		)
+		if err := rows.Scan(&kind, &name, &g.Count, &g.Hash); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning graph summary: %w", err)
+		}
+
+		if kind == "node" {
+			snap.Types[name] = g
+			snap.NodeCount += g.Count
+		} else {
+			snap.Relations[name] = g
+			snap.EdgeCount += g.Count
+		}
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading graph summary: %w", err)
+	}
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO kg_snapshots (tenant_id, name, description, export_ref, node_count, edge_count, types, relations)
+		VALUES (current_setting('app.tenant_id')::uuid, $1, $2, $3, $4, $5, $6::jsonb, $7::jsonb)
+		RETURNING created_at`,
+		snap.Name, snap.Description, snap.ExportRef, snap.NodeCount, snap.EdgeCount, snap.Types, snap.Relations,
+	).Scan(&snap.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, models.ErrSnapshotExists
+		}
+
+		return nil, fmt.Errorf("inserting snapshot: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// GetSnapshot returns the named snapshot, or models.ErrSnapshotNotFound.
+func (s *SnapshotStore) GetSnapshot(ctx context.Context, tenantID, name string) (*models.Snapshot, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting snapshot: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	snap, err := scanSnapshot(tx.QueryRow(ctx,
+		`SELECT `+snapshotColumns+` FROM kg_snapshots
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND name = $1`, name,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrSnapshotNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	}
+
+	return snap, nil
+}
+
+// ListSnapshots returns the tenant's snapshots, newest first.
+func (s *SnapshotStore) ListSnapshots(ctx context.Context, tenantID string) ([]models.Snapshot, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing snapshots: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+snapshotColumns+` FROM kg_snapshots
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid
+		ORDER BY created_at DESC, name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying snapshots: %w", err)
+	}
+
+	snapshots, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Snapshot, error) {
+		snap, err := scanSnapshot(row)
+		if err != nil {
+			return models.Snapshot{}, err
+		}
+
+		return *snap, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// DeleteSnapshot deletes the named snapshot, or returns
+// models.ErrSnapshotNotFound.
+func (s *SnapshotStore) DeleteSnapshot(ctx context.Context, tenantID, name string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("deleting snapshot: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx,
+		`DELETE FROM kg_snapshots WHERE tenant_id = current_setting('app.tenant_id')::uuid AND name = $1`, name,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting snapshot: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrSnapshotNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing snapshot delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	snapshots := store.NewSnapshotStore(base)
+	ctx := context.Background()
+
+	createTestNode(t, ns, tenantID, "Before")
+
+	before, err := snapshots.CreateSnapshot(ctx, tenantID, models.CreateSnapshotRequest{Name: "before"})
+	if err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+
+	if before.NodeCount != 1 || before.Types["concept"].Count != 1 {
+		t.Errorf("snapshot = %+v, want one concept", before)
+	}
+
+	if _, err := snapshots.CreateSnapshot(ctx, tenantID, models.CreateSnapshotRequest{Name: "before"}); !errors.Is(err, models.ErrSnapshotExists) {
+		t.Errorf("CreateSnapshot again: err = %v, want ErrSnapshotExists", err)
+	}
+
+	createTestNode(t, ns, tenantID, "After")
+
+	after, err := snapshots.CreateSnapshot(ctx, tenantID, models.CreateSnapshotRequest{Name: "after"})
+	if err != nil {
+		t.Fatalf("CreateSnapshot after: %v", err)
+	}
+
+	stored, err := snapshots.GetSnapshot(ctx, tenantID, "before")
+	if err != nil {
+		t.Fatalf("GetSnapshot: %v", err)
+	}
+
+	if stored.Types["concept"].Hash == after.Types["concept"].Hash {
+		t.Error("content hash did not change when a node was added")
+	}
+
+	if list, err := snapshots.ListSnapshots(ctx, tenantID); err != nil || len(list) != 2 || list[0].Name != "after" {
+		t.Errorf("ListSnapshots = %v, %v; want after, before", list, err)
+	}
+
+	if err := snapshots.DeleteSnapshot(ctx, tenantID, "before"); err != nil {
+		t.Fatalf("DeleteSnapshot: %v", err)
+	}
+
+	if _, err := snapshots.GetSnapshot(ctx, tenantID, "before"); !errors.Is(err, models.ErrSnapshotNotFound) {
+		t.Errorf("GetSnapshot after delete: err = %v, want ErrSnapshotNotFound", err)
+	}
+}
@@ -9,7 +9,6 @@
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -29,6 +28,18 @@ type Base struct {
 	Pool   *dbpool.Pool
 	Log    *logrus.Logger
 	Crypto *crypto.Service
+	// Replicas, when set, serves the read-only transactions started with
+	// beginReplicaTx. Nil sends every query to Pool.
+	Replicas *dbpool.ReplicaSet
+	// DetectLanguage indexes each node's search text with the text search
+	// configuration of its detected language instead of always English.
+	DetectLanguage bool
+	// SearchableProperties are the property keys copied in plaintext to each
+	// node's search_props so props filters can match them.
+	SearchableProperties []string
+	// EmbeddingModel is recorded on each node whose embedding is updated, so
+	// a re-embed can find the embeddings another model produced.
+	EmbeddingModel string
 }
 
 // withTimeout creates a context with the default query timeout.
@@ -82,19 +93,34 @@ func (b *Base) beginReadTx(ctx context.Context, tenantID string) (pgx.Tx, error)
 	return tx, nil
 }
 
-// notify sends a pg_notify on the kg_changes channel (best-effort, post-commit).
-func (b *Base) notify(table, op, tenantID string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	payload, _ := json.Marshal(map[string]any{ //nolint:errcheck // static keys, cannot fail.
-		"table":     table,
-		"op":        op,
-		"count":     1,
-		"tenant_id": tenantID,
-	})
-	if _, err := b.Pool.Exec(ctx, "SELECT pg_notify('kg_changes', $1)", string(payload)); err != nil {
-		b.Log.WithError(err).Warn("failed to send " + op + " " + table + " notification")
+// beginReplicaTx starts a read-only transaction on a read replica when one
+// is usable, otherwise on the primary, and sets the tenant context. Replicas
+// may trail the primary by up to their configured lag, so it is only for
+// reads that tolerate slightly stale data: search, listing, traversal, and
+// export. A replica that fails to start the transaction falls back to the
+// primary.
+func (b *Base) beginReplicaTx(ctx context.Context, tenantID string) (pgx.Tx, error) {
+	replica := b.Replicas.Pick()
+	if replica == nil {
+		return b.beginReadTx(ctx, tenantID)
 	}
-}
 
+	tx, err := replica.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("beginning replica read transaction: %w", err)
+		}
+
+		b.Log.WithError(err).Debug("replica unavailable, reading from primary")
+
+		return b.beginReadTx(ctx, tenantID)
+	}
+
+	if err := setTenant(ctx, tx, tenantID); err != nil {
+		tx.Rollback(ctx) //nolint:errcheck // best-effort rollback on setup failure.
+
+		return nil, err
+	}
+
+	return tx, nil
+}
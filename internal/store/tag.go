@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// TagStore maintains per-tenant tag centroids and ranks tags against nodes.
+type TagStore struct {
+	Base
+}
+
+// NewTagStore creates a new TagStore.
+func NewTagStore(base Base) *TagStore {
+	return &TagStore{Base: base}
+}
+
+// RebuildTagCentroids replaces the tenant's tag centroids with the mean
+// embedding of the nodes carrying each tag. Nodes without an embedding are
+// skipped.
+func (s *TagStore) RebuildTagCentroids(ctx context.Context, tenantID string) (*models.TagCentroidRebuild, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("rebuilding tag centroids: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	rows, err := tx.Query(ctx, `SELECT id, properties FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND embedding IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("listing embedded nodes: %w", err)
+	}
+
+	// Tags are in encrypted properties, so pair each tag with its nodes here
+	// and let the database average the embeddings.
+	var (
+		tags, nodeIDs []string
+		nodes         int
+	)
+
+	for rows.Next() {
+		var (
+			id    string
+			props []byte
+		)
+		if err := rows.Scan(&id, &props); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scanning node properties: %w", err)
+		}
+
+		decrypted, err := s.decryptPropertiesRaw(ctx, tenantID, props)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		nodeTags := models.NodeTags(decrypted)
+		if len(nodeTags) > 0 {
+			nodes++
+		}
+
+		for _, tag := range nodeTags {
+			tags = append(tags, tag)
+			nodeIDs = append(nodeIDs, id)
+		}
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating node properties: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM kg_tag_centroids
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid`); err != nil {
+		return nil, fmt.Errorf("clearing tag centroids: %w", err)
+	}
+
+	result := &models.TagCentroidRebuild{Nodes: nodes}
+
+	err = tx.QueryRow(ctx, `WITH inserted AS (
+			INSERT INTO kg_tag_centroids (tenant_id, tag, centroid, node_count)
+			SELECT current_setting('app.tenant_id')::uuid, p.tag, AVG(n.embedding), COUNT(*)
+			FROM unnest($1::text[], $2::text[]) AS p(tag, node_id)
+			JOIN kg_nodes n ON n.tenant_id = current_setting('app.tenant_id')::uuid AND n.id = p.node_id
+			GROUP BY p.tag
+			RETURNING 1
+		)
+		SELECT COUNT(*), NOW() FROM inserted`,
+		tags, nodeIDs,
+	).Scan(&result.Tags, &result.BuiltAt)
+	if err != nil {
+		return nil, fmt.Errorf("inserting tag centroids: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing tag centroids: %w", err)
+	}
+
+	return result, nil
+}
+
+// TagCentroidsBuiltAt returns when the tenant's tag centroids were last
+// built, or nil if they never were or no node had tags.
+func (s *TagStore) TagCentroidsBuiltAt(ctx context.Context, tenantID string) (*time.Time, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("getting tag centroid age: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var builtAt *time.Time
+	if err := tx.QueryRow(ctx, `SELECT MIN(built_at) FROM kg_tag_centroids
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid`).Scan(&builtAt); err != nil {
+		return nil, fmt.Errorf("getting tag centroid age: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing tag centroid age: %w", err)
+	}
+
+	return builtAt, nil
+}
+
+// SuggestTags ranks the tenant's tags by cosine similarity between their
+// centroid and the node's embedding, leaving out tags the node already has.
+func (s *TagStore) SuggestTags(ctx context.Context, tenantID, nodeID string, limit int) ([]models.TagSuggestion, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("suggesting tags: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var (
+		embedded bool
+		props    []byte
+	)
+
+	err = tx.QueryRow(ctx, `SELECT embedding IS NOT NULL, properties FROM kg_nodes
+		WHERE tenant_id = current_setting('app.tenant_id')::uuid AND id = $1`,
+		nodeID,
+	).Scan(&embedded, &props)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrNodeNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting node: %w", err)
+	}
+
+	if !embedded {
+		return nil, models.ErrNodeNotEmbedded
+	}
+
+	decrypted, err := s.decryptPropertiesRaw(ctx, tenantID, props)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT c.tag, 1 - (c.centroid <=> n.embedding), c.node_count
+		FROM kg_tag_centroids c
+		JOIN kg_nodes n ON n.tenant_id = c.tenant_id AND n.id = $1
+		WHERE c.tenant_id = current_setting('app.tenant_id')::uuid
+			AND NOT (c.tag = ANY($2::text[]))
+		ORDER BY c.centroid <=> n.embedding
+		LIMIT $3`,
+		nodeID, models.NodeTags(decrypted), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ranking tag centroids: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]models.TagSuggestion, 0, limit)
+	for rows.Next() {
+		var sg models.TagSuggestion
+		if err := rows.Scan(&sg.Tag, &sg.Score, &sg.NodeCount); err != nil {
+			return nil, fmt.Errorf("scanning tag suggestion: %w", err)
+		}
+
+		suggestions = append(suggestions, sg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating tag suggestions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing tag suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
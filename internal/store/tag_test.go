@@ -0,0 +1,83 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+// unitEmbedding returns a 1024-dimension embedding pointing along axis i.
+func unitEmbedding(i int) []float32 {
+	e := make([]float32, 1024)
+	e[i] = 1
+
+	return e
+}
+
+func TestTagCentroidsAndSuggestions(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ts := store.NewTagStore(base)
+	ns := store.NewNodeStore(base)
+	es := store.NewEmbeddingStore(base)
+	ctx := context.Background()
+
+	nodes := []struct {
+		id   string
+		tags []any
+		axis int
+	}{
+		{"tag-db1", []any{"databases"}, 0},
+		{"tag-db2", []any{"databases", "postgres"}, 0},
+		{"tag-ml", []any{"ml"}, 1},
+		{"tag-new", nil, 0},
+	}
+	for _, n := range nodes {
+		props := map[string]any{}
+		if n.tags != nil {
+			props[models.TagsProperty] = n.tags
+		}
+		if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: n.id, Type: "note", Label: n.id, Properties: props}); err != nil {
+			t.Fatalf("CreateNode(%s): %v", n.id, err)
+		}
+		if err := es.UpdateNodeEmbedding(ctx, tenantID, n.id, unitEmbedding(n.axis)); err != nil {
+			t.Fatalf("UpdateNodeEmbedding(%s): %v", n.id, err)
+		}
+	}
+
+	rebuild, err := ts.RebuildTagCentroids(ctx, tenantID)
+	if err != nil || rebuild.Tags != 3 || rebuild.Nodes != 3 {
+		t.Fatalf("RebuildTagCentroids = %+v, %v; want 3 tags over 3 nodes", rebuild, err)
+	}
+
+	suggestions, err := ts.SuggestTags(ctx, tenantID, "tag-new", 5)
+	if err != nil || len(suggestions) != 3 {
+		t.Fatalf("SuggestTags = %+v, %v; want all 3 tags", suggestions, err)
+	}
+	if suggestions[2].Tag != "ml" {
+		t.Errorf("ml should rank last for a database node: %+v", suggestions)
+	}
+
+	// Tags the node already has are not suggested.
+	suggestions, err = ts.SuggestTags(ctx, tenantID, "tag-db1", 5)
+	if err != nil {
+		t.Fatalf("SuggestTags(tag-db1): %v", err)
+	}
+	for _, s := range suggestions {
+		if s.Tag == "databases" {
+			t.Errorf("suggested existing tag: %+v", suggestions)
+		}
+	}
+
+	if _, err := ns.CreateNode(ctx, tenantID, models.CreateNodeRequest{ID: "tag-raw", Type: "note", Label: "raw"}); err != nil {
+		t.Fatalf("CreateNode(tag-raw): %v", err)
+	}
+	if _, err := ts.SuggestTags(ctx, tenantID, "tag-raw", 5); !errors.Is(err, models.ErrNodeNotEmbedded) {
+		t.Errorf("unembedded node: err = %v, want ErrNodeNotEmbedded", err)
+	}
+	if _, err := ts.SuggestTags(ctx, tenantID, "tag-missing", 5); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("missing node: err = %v, want ErrNodeNotFound", err)
+	}
+}
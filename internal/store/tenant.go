@@ -4,20 +4,28 @@
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/crypto"
 	"github.com/persistorai/persistor/internal/dbpool"
 	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/models"
 )
 
-// TenantStore handles tenant lookups (API key → tenant ID).
+// TenantStore handles tenant lookups (API key → tenant ID). Crypto encrypts
+// request signing secrets with the tenant's key.
 type TenantStore struct {
-	Pool *dbpool.Pool
+	Pool   *dbpool.Pool
+	Crypto *crypto.Service
 }
 
 // NewTenantStore creates a new TenantStore.
-func NewTenantStore(pool *dbpool.Pool) *TenantStore {
-	return &TenantStore{Pool: pool}
+func NewTenantStore(pool *dbpool.Pool, cryptoSvc *crypto.Service) *TenantStore {
+	return &TenantStore{Pool: pool, Crypto: cryptoSvc}
 }
 
 // GetTenantByAPIKey looks up a tenant ID by API key hash.
@@ -30,20 +38,204 @@ func (s *TenantStore) GetTenantByAPIKey(ctx context.Context, apiKey string) (str
 	return principal.TenantID, nil
 }
 
-// GetAuthPrincipalByAPIKey looks up the tenant ID and auth scope for an API key.
+// GetAuthPrincipalByAPIKey looks up the tenant ID, auth scope, request
+// signing secret, plan, rate limit override, operator flag, and impersonating
+// operator for an API key. A rotated-out key is accepted until its grace
+// period ends, and a managed key until it is revoked or expires. Keys of
+// suspended tenants are rejected.
 func (s *TenantStore) GetAuthPrincipalByAPIKey(ctx context.Context, apiKey string) (middleware.AuthPrincipal, error) {
 	ctx, cancel := withTimeout(ctx)
 	defer cancel()
 
-	hash := sha256.Sum256([]byte(apiKey))
-	apiKeyHash := hex.EncodeToString(hash[:])
-
-	var principal middleware.AuthPrincipal
+	var (
+		principal      middleware.AuthPrincipal
+		signingSecret  *string
+		rateLimitRate  *int
+		rateLimitBurst *int
+		impersonatedBy *string
+	)
 
-	err := s.Pool.QueryRow(ctx, "SELECT id, api_key_scope FROM tenants WHERE api_key_hash = $1", apiKeyHash).Scan(&principal.TenantID, &principal.Scope)
+	// The key is either the tenant's primary key (or its rotated-out
+	// predecessor) or an active managed key, whose last use is recorded.
+	err := s.Pool.QueryRow(ctx, `WITH managed AS (
+			UPDATE api_keys SET last_used_at = NOW()
+			WHERE key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+			RETURNING tenant_id, scope, impersonated_by
+		), matched AS (
+			SELECT id AS tenant_id, api_key_scope AS scope, NULL::uuid AS impersonated_by FROM tenants
+			WHERE api_key_hash = $1
+				OR (previous_api_key_hash = $1 AND previous_api_key_expires_at > NOW())
+			UNION ALL
+			SELECT tenant_id, scope, impersonated_by FROM managed
+			LIMIT 1
+		)
+		SELECT t.id, m.scope, t.signing_secret, t.plan, t.rate_limit_per_sec, t.rate_limit_burst, t.operator,
+			m.impersonated_by
+		FROM matched m JOIN tenants t ON t.id = m.tenant_id
+		WHERE t.suspended_at IS NULL`,
+		hashAPIKey(apiKey),
+	).Scan(
+		&principal.TenantID, &principal.Scope, &signingSecret, &principal.Plan,
+		&rateLimitRate, &rateLimitBurst, &principal.Operator, &impersonatedBy,
+	)
 	if err != nil {
 		return middleware.AuthPrincipal{}, fmt.Errorf("looking up tenant by API key: %w", err)
 	}
 
+	if rateLimitRate != nil && rateLimitBurst != nil {
+		principal.RateLimit = middleware.RateLimit{PerSec: *rateLimitRate, Burst: *rateLimitBurst}
+	}
+
+	if impersonatedBy != nil {
+		principal.ImpersonatedBy = *impersonatedBy
+	}
+
+	if signingSecret != nil {
+		principal.SigningSecret, err = s.Crypto.Decrypt(ctx, principal.TenantID, *signingSecret)
+		if err != nil {
+			return middleware.AuthPrincipal{}, fmt.Errorf("decrypting signing secret: %w", err)
+		}
+	}
+
 	return principal, nil
 }
+
+// GetAPIKeyStatus returns the tenant's key scope and rotation state.
+func (s *TenantStore) GetAPIKeyStatus(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `SELECT `+apiKeyStatusColumns+` FROM tenants WHERE id = $1`, tenantID)
+
+	status, err := scanAPIKeyStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("getting api key status: %w", err)
+	}
+
+	return status, nil
+}
+
+// RotateAPIKey replaces the tenant's API key with newKey. When grace is
+// positive the current key stays valid for that long; any key still in an
+// earlier grace period is revoked, so at most two keys are ever accepted.
+func (s *TenantStore) RotateAPIKey(ctx context.Context, tenantID, newKey string, grace time.Duration) (*models.APIKeyStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			previous_api_key_hash = CASE WHEN $3::double precision > 0 THEN api_key_hash END,
+			previous_api_key_expires_at = CASE WHEN $3::double precision > 0
+				THEN NOW() + make_interval(secs => $3::double precision) END,
+			api_key_hash = $2,
+			api_key_rotated_at = NOW()
+		WHERE id = $1
+		RETURNING `+apiKeyStatusColumns,
+		tenantID, hashAPIKey(newKey), grace.Seconds(),
+	)
+
+	status, err := scanAPIKeyStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("rotating api key: %w", err)
+	}
+
+	return status, nil
+}
+
+// RevokePreviousAPIKey ends the grace period of a rotated-out key immediately.
+func (s *TenantStore) RevokePreviousAPIKey(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			previous_api_key_hash = NULL,
+			previous_api_key_expires_at = NULL
+		WHERE id = $1
+		RETURNING `+apiKeyStatusColumns,
+		tenantID,
+	)
+
+	status, err := scanAPIKeyStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("revoking previous api key: %w", err)
+	}
+
+	return status, nil
+}
+
+// SetRequestSigningSecret encrypts and stores secret, requiring every request
+// from the tenant to be signed with it. Replacing an existing secret keeps the
+// original signing_enabled_at.
+func (s *TenantStore) SetRequestSigningSecret(ctx context.Context, tenantID string, secret []byte) (*models.APIKeyStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	encrypted, err := s.Crypto.Encrypt(ctx, tenantID, secret)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting signing secret: %w", err)
+	}
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			signing_secret = $2,
+			signing_enabled_at = COALESCE(signing_enabled_at, NOW())
+		WHERE id = $1
+		RETURNING `+apiKeyStatusColumns,
+		tenantID, encrypted,
+	)
+
+	status, err := scanAPIKeyStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("setting signing secret: %w", err)
+	}
+
+	return status, nil
+}
+
+// ClearRequestSigningSecret removes the tenant's signing secret so unsigned
+// requests are accepted again.
+func (s *TenantStore) ClearRequestSigningSecret(ctx context.Context, tenantID string) (*models.APIKeyStatus, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			signing_secret = NULL,
+			signing_enabled_at = NULL
+		WHERE id = $1
+		RETURNING `+apiKeyStatusColumns,
+		tenantID,
+	)
+
+	status, err := scanAPIKeyStatus(row)
+	if err != nil {
+		return nil, fmt.Errorf("clearing signing secret: %w", err)
+	}
+
+	return status, nil
+}
+
+// apiKeyStatusColumns selects the fields of models.APIKeyStatus. An expired
+// previous key is reported as absent.
+const apiKeyStatusColumns = `api_key_scope, api_key_rotated_at,
+	CASE WHEN previous_api_key_expires_at > NOW() THEN previous_api_key_expires_at END,
+	signing_secret IS NOT NULL, signing_enabled_at`
+
+func scanAPIKeyStatus(row pgx.Row) (*models.APIKeyStatus, error) {
+	var status models.APIKeyStatus
+
+	err := row.Scan(&status.Scope, &status.RotatedAt, &status.PreviousKeyExpiresAt, &status.SigningRequired, &status.SigningEnabledAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// hashAPIKey returns the hex SHA-256 digest stored in place of an API key.
+func hashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,242 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// tenantPurgeTimeout bounds deleting a tenant and all of its data, which can
+// take far longer than an ordinary query.
+const tenantPurgeTimeout = 10 * time.Minute
+
+const tenantColumns = `id, name, plan, operator, suspended_at,
+	max_nodes, max_edges, max_storage_bytes,
+	max_monthly_embedding_tokens, max_monthly_llm_tokens, created_at`
+
+// CreateTenant creates a tenant whose primary API key is key.
+func (s *TenantStore) CreateTenant(ctx context.Context, key string, req models.CreateTenantRequest) (*models.Tenant, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `INSERT INTO tenants (name, plan, api_key_hash, api_key_scope)
+		VALUES ($1, $2, $3, $4)
+		RETURNING `+tenantColumns,
+		req.Name, req.Plan, hashAPIKey(key), req.Scope,
+	)
+
+	tenant, err := scanTenant(row)
+	if err != nil {
+		return nil, fmt.Errorf("creating tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+// ListTenants returns every tenant, oldest first.
+func (s *TenantStore) ListTenants(ctx context.Context) ([]models.Tenant, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	rows, err := s.Pool.Query(ctx, `SELECT `+tenantColumns+` FROM tenants ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []models.Tenant{}
+	for rows.Next() {
+		tenant, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning tenant: %w", err)
+		}
+
+		tenants = append(tenants, *tenant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// GetTenant returns a tenant by ID.
+func (s *TenantStore) GetTenant(ctx context.Context, tenantID string) (*models.Tenant, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `SELECT `+tenantColumns+` FROM tenants WHERE id = $1`, tenantID)
+
+	tenant, err := scanTenant(row)
+	if err != nil {
+		return nil, fmt.Errorf("getting tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+// UpdateTenant changes the tenant's name, plan, or quotas. A quota of 0 is
+// stored as NULL, removing the limit.
+func (s *TenantStore) UpdateTenant(ctx context.Context, tenantID string, req models.UpdateTenantRequest) (*models.Tenant, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			name = COALESCE($2, name),
+			plan = COALESCE($3, plan),
+			max_nodes = CASE WHEN $4::bigint IS NULL THEN max_nodes ELSE NULLIF($4, 0) END,
+			max_edges = CASE WHEN $5::bigint IS NULL THEN max_edges ELSE NULLIF($5, 0) END,
+			max_storage_bytes = CASE WHEN $6::bigint IS NULL THEN max_storage_bytes ELSE NULLIF($6, 0) END,
+			max_monthly_embedding_tokens = CASE WHEN $7::bigint IS NULL
+				THEN max_monthly_embedding_tokens ELSE NULLIF($7, 0) END,
+			max_monthly_llm_tokens = CASE WHEN $8::bigint IS NULL THEN max_monthly_llm_tokens ELSE NULLIF($8, 0) END
+		WHERE id = $1
+		RETURNING `+tenantColumns,
+		tenantID, req.Name, req.Plan, req.MaxNodes, req.MaxEdges, req.MaxStorageBytes,
+		req.MaxMonthlyEmbeddingTokens, req.MaxMonthlyLLMTokens,
+	)
+
+	tenant, err := scanTenant(row)
+	if err != nil {
+		return nil, fmt.Errorf("updating tenant: %w", err)
+	}
+
+	return tenant, nil
+}
+
+// SetTenantSuspended suspends or resumes a tenant. Suspending an already
+// suspended tenant keeps the original suspended_at.
+func (s *TenantStore) SetTenantSuspended(ctx context.Context, tenantID string, suspended bool) (*models.Tenant, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	row := s.Pool.QueryRow(ctx, `UPDATE tenants SET
+			suspended_at = CASE WHEN $2 THEN COALESCE(suspended_at, NOW()) END
+		WHERE id = $1
+		RETURNING `+tenantColumns,
+		tenantID, suspended,
+	)
+
+	tenant, err := scanTenant(row)
+	if err != nil {
+		return nil, fmt.Errorf("setting tenant suspension: %w", err)
+	}
+
+	return tenant, nil
+}
+
+// DeleteTenant deletes a suspended tenant and purges its data from every
+// table with a tenant_id column, in one transaction.
+func (s *TenantStore) DeleteTenant(ctx context.Context, tenantID string) (*models.TenantPurgeResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, tenantPurgeTimeout)
+	defer cancel()
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("beginning tenant purge: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var suspended bool
+
+	err = tx.QueryRow(ctx,
+		`SELECT suspended_at IS NOT NULL FROM tenants WHERE id = $1 FOR UPDATE`, tenantID,
+	).Scan(&suspended)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("locking tenant: %w", err)
+	}
+
+	if !suspended {
+		return nil, models.ErrTenantNotSuspended
+	}
+
+	// Row-level security hides other tenants' rows, so the purge runs as
+	// the tenant being deleted.
+	if err := setTenant(ctx, tx, tenantID); err != nil {
+		return nil, err
+	}
+
+	tables, err := tenantTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TenantPurgeResult{TenantID: tenantID}
+
+	for _, table := range tables {
+		tag, err := tx.Exec(ctx,
+			`DELETE FROM `+pgx.Identifier{table}.Sanitize()+` WHERE tenant_id = $1`, tenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("purging %s: %w", table, err)
+		}
+
+		result.DeletedRows += tag.RowsAffected()
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM tenants WHERE id = $1`, tenantID); err != nil {
+		return nil, fmt.Errorf("deleting tenant: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing tenant purge: %w", err)
+	}
+
+	return result, nil
+}
+
+// tenantTables lists the tables in the current schema that hold per-tenant
+// rows, so tables added by later migrations are purged too. Partitions are
+// purged through their parent table.
+func tenantTables(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT c.table_name
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+			ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE c.table_schema = current_schema()
+			AND c.column_name = 'tenant_id'
+			AND t.table_type = 'BASE TABLE'
+			AND NOT EXISTS (SELECT 1 FROM pg_inherits i
+				WHERE i.inhrelid = format('%I.%I', c.table_schema, c.table_name)::regclass)
+		ORDER BY c.table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing tenant tables: %w", err)
+	}
+
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("listing tenant tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+func scanTenant(row pgx.Row) (*models.Tenant, error) {
+	var t models.Tenant
+
+	err := row.Scan(
+		&t.ID, &t.Name, &t.Plan, &t.Operator, &t.SuspendedAt,
+		&t.Quota.MaxNodes, &t.Quota.MaxEdges, &t.Quota.MaxStorageBytes,
+		&t.Quota.MaxMonthlyEmbeddingTokens, &t.Quota.MaxMonthlyLLMTokens, &t.CreatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, models.ErrTenantNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,237 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/persistorai/persistor/internal/middleware"
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestRotateAPIKey(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewTenantStore(base.Pool, base.Crypto)
+	ctx := context.Background()
+	oldKey := "test-key-" + tenantID
+
+	status, err := s.RotateAPIKey(ctx, tenantID, "rotated-"+tenantID, time.Hour)
+	if err != nil {
+		t.Fatalf("RotateAPIKey: %v", err)
+	}
+	if status.RotatedAt == nil || status.PreviousKeyExpiresAt == nil {
+		t.Fatalf("status = %+v, want rotation time and previous key expiry", status)
+	}
+
+	for _, key := range []string{oldKey, "rotated-" + tenantID} {
+		if got, err := s.GetTenantByAPIKey(ctx, key); err != nil || got != tenantID {
+			t.Errorf("GetTenantByAPIKey during grace = %q, %v; want %s", got, err, tenantID)
+		}
+	}
+
+	status, err = s.RevokePreviousAPIKey(ctx, tenantID)
+	if err != nil || status.PreviousKeyExpiresAt != nil {
+		t.Fatalf("RevokePreviousAPIKey = %+v, %v", status, err)
+	}
+	if _, err := s.GetTenantByAPIKey(ctx, oldKey); err == nil {
+		t.Error("old key should be rejected after revoke")
+	}
+
+	// Rotating with no grace period revokes the current key immediately.
+	if _, err := s.RotateAPIKey(ctx, tenantID, "final-"+tenantID, 0); err != nil {
+		t.Fatalf("RotateAPIKey without grace: %v", err)
+	}
+	if _, err := s.GetTenantByAPIKey(ctx, "rotated-"+tenantID); err == nil {
+		t.Error("rotated-out key should be rejected without a grace period")
+	}
+}
+
+func TestRequestSigningSecret(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewTenantStore(base.Pool, base.Crypto)
+	ctx := context.Background()
+	apiKey := "test-key-" + tenantID
+
+	status, err := s.SetRequestSigningSecret(ctx, tenantID, []byte("first-secret"))
+	if err != nil {
+		t.Fatalf("SetRequestSigningSecret: %v", err)
+	}
+	if !status.SigningRequired || status.SigningEnabledAt == nil {
+		t.Fatalf("status = %+v, want signing required", status)
+	}
+	enabledAt := *status.SigningEnabledAt
+
+	status, err = s.SetRequestSigningSecret(ctx, tenantID, []byte("second-secret"))
+	if err != nil {
+		t.Fatalf("rotating signing secret: %v", err)
+	}
+	if !status.SigningEnabledAt.Equal(enabledAt) {
+		t.Errorf("signing_enabled_at changed on rotation: %v → %v", enabledAt, status.SigningEnabledAt)
+	}
+
+	principal, err := s.GetAuthPrincipalByAPIKey(ctx, apiKey)
+	if err != nil {
+		t.Fatalf("GetAuthPrincipalByAPIKey: %v", err)
+	}
+	if !bytes.Equal(principal.SigningSecret, []byte("second-secret")) {
+		t.Errorf("SigningSecret = %q, want second-secret", principal.SigningSecret)
+	}
+
+	status, err = s.ClearRequestSigningSecret(ctx, tenantID)
+	if err != nil || status.SigningRequired || status.SigningEnabledAt != nil {
+		t.Fatalf("ClearRequestSigningSecret = %+v, %v", status, err)
+	}
+
+	principal, err = s.GetAuthPrincipalByAPIKey(ctx, apiKey)
+	if err != nil || principal.SigningSecret != nil {
+		t.Errorf("principal after clear = %+v, %v; want no signing secret", principal, err)
+	}
+}
+
+func TestManagedAPIKeys(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewTenantStore(base.Pool, base.Crypto)
+	ctx := context.Background()
+	key := "managed-" + tenantID
+
+	created, err := s.CreateManagedAPIKey(ctx, tenantID, key, models.CreateAPIKeyRequest{Name: "ci", Scope: models.APIKeyScopeAdmin})
+	if err != nil {
+		t.Fatalf("CreateManagedAPIKey: %v", err)
+	}
+
+	principal, err := s.GetAuthPrincipalByAPIKey(ctx, key)
+	if err != nil || principal.TenantID != tenantID || principal.Scope != middleware.ScopeAdmin {
+		t.Fatalf("GetAuthPrincipalByAPIKey = %+v, %v; want admin principal for %s", principal, err, tenantID)
+	}
+
+	keys, err := s.ListManagedAPIKeys(ctx, tenantID)
+	if err != nil || len(keys) != 1 || keys[0].ID != created.ID || keys[0].LastUsedAt == nil {
+		t.Fatalf("ListManagedAPIKeys = %+v, %v; want one key with last use recorded", keys, err)
+	}
+
+	rotated, previous, err := s.RotateManagedAPIKey(ctx, tenantID, created.ID, "rotated-"+key, time.Hour)
+	if err != nil || rotated.Name != "ci" || rotated.Scope != models.APIKeyScopeAdmin || previous.ExpiresAt == nil {
+		t.Fatalf("RotateManagedAPIKey = %+v, %+v, %v", rotated, previous, err)
+	}
+	for _, k := range []string{key, "rotated-" + key} {
+		if _, err := s.GetAuthPrincipalByAPIKey(ctx, k); err != nil {
+			t.Errorf("key during grace rejected: %v", err)
+		}
+	}
+
+	if _, err := s.RevokeManagedAPIKey(ctx, tenantID, created.ID); err != nil {
+		t.Fatalf("RevokeManagedAPIKey: %v", err)
+	}
+	if _, err := s.GetAuthPrincipalByAPIKey(ctx, key); err == nil {
+		t.Error("revoked key should be rejected")
+	}
+	if _, _, err := s.RotateManagedAPIKey(ctx, tenantID, created.ID, "again-"+key, 0); !errors.Is(err, models.ErrAPIKeyInactive) {
+		t.Errorf("rotating a revoked key: err = %v, want ErrAPIKeyInactive", err)
+	}
+}
+
+func TestImpersonationKey(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewTenantStore(base.Pool, base.Crypto)
+	ctx := context.Background()
+
+	operator, err := s.CreateTenant(ctx, "operator-key-"+tenantID, models.CreateTenantRequest{Name: "support"})
+	if err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	t.Cleanup(func() {
+		base.Pool.Exec(context.Background(), "DELETE FROM tenants WHERE id = $1", operator.ID) //nolint:errcheck // best-effort cleanup
+	})
+
+	req := models.ImpersonateTenantRequest{Reason: "ticket 42: search returns nothing"}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	key := "impersonation-" + tenantID
+	created, err := s.CreateImpersonationKey(ctx, operator.ID, tenantID, key, req)
+	if err != nil {
+		t.Fatalf("CreateImpersonationKey: %v", err)
+	}
+	if created.ImpersonatedBy == nil || *created.ImpersonatedBy != operator.ID || created.ExpiresAt == nil {
+		t.Fatalf("created = %+v, want an expiring key impersonated by %s", created, operator.ID)
+	}
+	if until := time.Until(*created.ExpiresAt); until <= 0 || until > req.TTL() {
+		t.Errorf("expires in %v, want within %v", until, req.TTL())
+	}
+
+	principal, err := s.GetAuthPrincipalByAPIKey(ctx, key)
+	if err != nil || principal.TenantID != tenantID || principal.Scope != middleware.ScopeRead || principal.ImpersonatedBy != operator.ID {
+		t.Fatalf("GetAuthPrincipalByAPIKey = %+v, %v; want read principal impersonated by %s", principal, err, operator.ID)
+	}
+
+	primary, err := s.GetAuthPrincipalByAPIKey(ctx, "operator-key-"+tenantID)
+	if err != nil || primary.ImpersonatedBy != "" {
+		t.Errorf("primary key principal = %+v, %v; want no impersonator", primary, err)
+	}
+}
+
+func TestTenantLifecycle(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	s := store.NewTenantStore(base.Pool, base.Crypto)
+	ctx := context.Background()
+
+	created, err := s.CreateTenant(ctx, "created-key-"+tenantID, models.CreateTenantRequest{
+		Name: "lifecycle", Plan: "pro", Scope: models.APIKeyScopeRead,
+	})
+	if err != nil {
+		t.Fatalf("CreateTenant: %v", err)
+	}
+	t.Cleanup(func() {
+		base.Pool.Exec(context.Background(), "DELETE FROM tenants WHERE id = $1", created.ID) //nolint:errcheck // best-effort cleanup
+	})
+
+	principal, err := s.GetAuthPrincipalByAPIKey(ctx, "created-key-"+tenantID)
+	if err != nil || principal.TenantID != created.ID || principal.Scope != middleware.ScopeRead || principal.Plan != "pro" {
+		t.Fatalf("principal = %+v, %v; want the new read-scoped pro tenant", principal, err)
+	}
+
+	if _, err := s.DeleteTenant(ctx, created.ID); !errors.Is(err, models.ErrTenantNotSuspended) {
+		t.Fatalf("DeleteTenant before suspend = %v, want ErrTenantNotSuspended", err)
+	}
+
+	suspended, err := s.SetTenantSuspended(ctx, created.ID, true)
+	if err != nil || suspended.SuspendedAt == nil {
+		t.Fatalf("SetTenantSuspended = %+v, %v", suspended, err)
+	}
+	if _, err := s.GetAuthPrincipalByAPIKey(ctx, "created-key-"+tenantID); err == nil {
+		t.Error("suspended tenant's key should be rejected")
+	}
+
+	resumed, err := s.SetTenantSuspended(ctx, created.ID, false)
+	if err != nil || resumed.SuspendedAt != nil {
+		t.Fatalf("resume = %+v, %v", resumed, err)
+	}
+	if _, err := s.GetAuthPrincipalByAPIKey(ctx, "created-key-"+tenantID); err != nil {
+		t.Errorf("resumed tenant's key rejected: %v", err)
+	}
+
+	if _, err := base.Pool.Exec(ctx,
+		"INSERT INTO api_keys (tenant_id, name, key_hash, scope) VALUES ($1, 'ci', $2, 'read')",
+		created.ID, "managed-hash-"+created.ID,
+	); err != nil {
+		t.Fatalf("inserting managed key: %v", err)
+	}
+
+	if _, err := s.SetTenantSuspended(ctx, created.ID, true); err != nil {
+		t.Fatalf("SetTenantSuspended: %v", err)
+	}
+	result, err := s.DeleteTenant(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("DeleteTenant: %v", err)
+	}
+	if result.DeletedRows < 1 {
+		t.Errorf("DeletedRows = %d, want the managed key purged", result.DeletedRows)
+	}
+	if _, err := s.GetTenant(ctx, created.ID); !errors.Is(err, models.ErrTenantNotFound) {
+		t.Errorf("GetTenant after delete = %v, want ErrTenantNotFound", err)
+	}
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/dbpool"
+	"github.com/persistorai/persistor/internal/models"
+)
+
+const (
+	vectorIndexName    = "idx_nodes_embedding"
+	vectorIndexRebuild = "idx_nodes_embedding_rebuild"
+)
+
+// VectorIndexStore inspects and rebuilds the HNSW or IVFFlat index on node
+// embeddings. It reads the catalog, not tenant rows, so it runs without a
+// tenant; the index covers every tenant's nodes.
+type VectorIndexStore struct {
+	Pool *dbpool.Pool
+}
+
+// NewVectorIndexStore creates a new VectorIndexStore.
+func NewVectorIndexStore(pool *dbpool.Pool) *VectorIndexStore {
+	return &VectorIndexStore{Pool: pool}
+}
+
+// VectorIndexStats reports the embedding index and how kg_nodes has been
+// scanned since statistics were last reset. Partitioned tables and indexes
+// are summed over their partitions.
+func (s *VectorIndexStore) VectorIndexStats(ctx context.Context) (*models.VectorIndexStats, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	var stats models.VectorIndexStats
+
+	tableSeqScans, tableRows, err := s.nodeTableStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TableSeqScans = tableSeqScans
+
+	var (
+		method    string
+		options   []string
+		indexRows int64
+	)
+
+	err = s.Pool.QueryRow(ctx, `SELECT x.indisvalid, pg_get_indexdef(i.oid), am.amname::text,
+			coalesce(i.reloptions, '{}'),
+			(SELECT coalesce(sum(pg_relation_size(t.relid)), 0)::bigint FROM pg_partition_tree(i.oid) t),
+			(SELECT coalesce(sum(st.idx_scan), 0)::bigint FROM pg_partition_tree(i.oid) t
+				JOIN pg_stat_user_indexes st ON st.indexrelid = t.relid),
+			(SELECT coalesce(sum(greatest(c.reltuples, 0)), 0)::bigint FROM pg_partition_tree(i.oid) t
+				JOIN pg_class c ON c.oid = t.relid)
+		FROM pg_index x
+		JOIN pg_class i ON i.oid = x.indexrelid
+		JOIN pg_am am ON am.oid = i.relam
+		WHERE x.indexrelid = to_regclass($1)`, vectorIndexName,
+	).Scan(&stats.Valid, &stats.Definition, &method, &options, &stats.SizeBytes, &stats.IndexScans, &indexRows)
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		// Without the partial index, every node is a candidate for embedding.
+		stats.EmbeddedNodes = tableRows
+
+		return &stats, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading vector index: %w", err)
+	}
+
+	stats.Exists = true
+	stats.Params = vectorIndexParams(method, options)
+
+	stats.EmbeddedNodes = indexRows
+	if !stats.Valid || indexRows == 0 {
+		stats.EmbeddedNodes = tableRows
+	}
+
+	return &stats, nil
+}
+
+// nodeTableStats returns kg_nodes' sequential scans since statistics were
+// last reset and its estimated rows.
+func (s *VectorIndexStore) nodeTableStats(ctx context.Context) (seqScans, rows int64, err error) {
+	if err := s.Pool.QueryRow(ctx, `SELECT
+			(SELECT coalesce(sum(st.seq_scan), 0)::bigint FROM pg_partition_tree('kg_nodes') t
+				JOIN pg_stat_user_tables st ON st.relid = t.relid),
+			(SELECT coalesce(sum(greatest(c.reltuples, 0)), 0)::bigint FROM pg_partition_tree('kg_nodes') t
+				JOIN pg_class c ON c.oid = t.relid)`,
+	).Scan(&seqScans, &rows); err != nil {
+		return 0, 0, fmt.Errorf("reading kg_nodes statistics: %w", err)
+	}
+
+	return seqScans, rows, nil
+}
+
+// vectorIndexParams reads an index's storage options, falling back to
+// pgvector's defaults for options that were not set.
+func vectorIndexParams(method string, options []string) models.VectorIndexRequest {
+	params := models.VectorIndexRequest{Method: method}
+
+	switch method {
+	case models.VectorIndexHNSW:
+		params.M, params.EfConstruction = 16, 64
+	case models.VectorIndexIVFFlat:
+		params.Lists = 100
+	}
+
+	for _, opt := range options {
+		key, value, _ := strings.Cut(opt, "=")
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "m":
+			params.M = n
+		case "ef_construction":
+			params.EfConstruction = n
+		case "lists":
+			params.Lists = n
+		}
+	}
+
+	return params
+}
+
+// BuildVectorIndex builds a new embedding index with params beside the
+// current one, then swaps it in, so vector searches keep an index until the
+// new one is ready. The build runs concurrently unless kg_nodes is
+// partitioned, which locks the table for writes while it builds. params
+// must be validated and have every parameter of its method set.
+func (s *VectorIndexStore) BuildVectorIndex(ctx context.Context, params models.VectorIndexRequest) error {
+	// A build that failed or was cancelled leaves an invalid index behind.
+	if _, err := s.Pool.Exec(ctx, `DROP INDEX IF EXISTS `+vectorIndexRebuild); err != nil {
+		return fmt.Errorf("dropping leftover vector index: %w", err)
+	}
+
+	partitions, err := db.PartitionCount(ctx, s.Pool, "kg_nodes")
+	if err != nil {
+		return err
+	}
+
+	createIndex := `CREATE INDEX CONCURRENTLY`
+	if partitions > 0 {
+		createIndex = `CREATE INDEX`
+	}
+
+	with := fmt.Sprintf("m = %d, ef_construction = %d", params.M, params.EfConstruction)
+	if params.Method == models.VectorIndexIVFFlat {
+		with = fmt.Sprintf("lists = %d", params.Lists)
+	}
+
+	// Method and parameters are validated, so formatting them in is safe.
+	if _, err := s.Pool.Exec(ctx, fmt.Sprintf(
+		`%s %s ON kg_nodes USING %s (embedding vector_cosine_ops) WITH (%s) WHERE embedding IS NOT NULL`,
+		createIndex, vectorIndexRebuild, params.Method, with,
+	)); err != nil {
+		return fmt.Errorf("building vector index: %w", err)
+	}
+
+	tx, err := s.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("beginning vector index swap: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, `DROP INDEX IF EXISTS `+vectorIndexName); err != nil {
+		return fmt.Errorf("dropping vector index: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `ALTER INDEX `+vectorIndexRebuild+` RENAME TO `+vectorIndexName); err != nil {
+		return fmt.Errorf("renaming vector index: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing vector index swap: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// WatchStore persists the nodes each named watcher follows.
+type WatchStore struct {
+	Base
+}
+
+// NewWatchStore creates a new WatchStore.
+func NewWatchStore(base Base) *WatchStore {
+	return &WatchStore{Base: base}
+}
+
+// Watch makes watcher follow nodeID. Watching a node twice returns the
+// existing watch. It returns models.ErrNodeNotFound when the node does not
+// exist and models.ErrTooManyWatches when the watcher is at its limit.
+func (s *WatchStore) Watch(ctx context.Context, tenantID, watcher, nodeID string) (*models.Watch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("watching node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	var exists bool
+	if err := tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM kg_nodes WHERE tenant_id = $1 AND id = $2)", tenantID, nodeID,
+	).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("checking watched node: %w", err)
+	}
+
+	if !exists {
+		return nil, models.ErrNodeNotFound
+	}
+
+	// Serialize watches by the same watcher so concurrent requests cannot
+	// both pass the limit check.
+	if _, err := tx.Exec(ctx,
+		"SELECT pg_advisory_xact_lock(hashtext('kg_watches:' || $1 || ':' || $2))", tenantID, watcher,
+	); err != nil {
+		return nil, fmt.Errorf("locking watcher: %w", err)
+	}
+
+	w := &models.Watch{Watcher: watcher, NodeID: nodeID}
+
+	err = tx.QueryRow(ctx,
+		"SELECT created_at FROM kg_watches WHERE tenant_id = $1 AND watcher = $2 AND node_id = $3",
+		tenantID, watcher, nodeID,
+	).Scan(&w.CreatedAt)
+	if err == nil {
+		return w, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("reading watch: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx,
+		"SELECT COUNT(*) FROM kg_watches WHERE tenant_id = $1 AND watcher = $2", tenantID, watcher,
+	).Scan(&count); err != nil {
+		return nil, fmt.Errorf("counting watches: %w", err)
+	}
+
+	if count >= models.MaxWatchedNodes {
+		return nil, models.ErrTooManyWatches
+	}
+
+	if err := tx.QueryRow(ctx,
+		`INSERT INTO kg_watches (tenant_id, watcher, node_id) VALUES ($1, $2, $3)
+		RETURNING created_at`,
+		tenantID, watcher, nodeID,
+	).Scan(&w.CreatedAt); err != nil {
+		return nil, fmt.Errorf("inserting watch: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing watch: %w", err)
+	}
+
+	s.notifyWatch("insert", tenantID, watcher, nodeID)
+
+	return w, nil
+}
+
+// Unwatch stops watcher following nodeID. It returns models.ErrWatchNotFound
+// when the watcher does not follow the node.
+func (s *WatchStore) Unwatch(ctx context.Context, tenantID, watcher, nodeID string) error {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginTx(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("unwatching node: %w", err)
+	}
+
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	tag, err := tx.Exec(ctx,
+		"DELETE FROM kg_watches WHERE tenant_id = $1 AND watcher = $2 AND node_id = $3",
+		tenantID, watcher, nodeID,
+	)
+	if err != nil {
+		return fmt.Errorf("deleting watch: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrWatchNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing unwatch: %w", err)
+	}
+
+	s.notifyWatch("delete", tenantID, watcher, nodeID)
+
+	return nil
+}
+
+// ListWatches returns the nodes watcher follows, ordered by node ID.
+func (s *WatchStore) ListWatches(ctx context.Context, tenantID, watcher string) ([]models.Watch, error) {
+	ctx, cancel := withTimeout(ctx)
+	defer cancel()
+
+	tx, err := s.beginReadTx(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing watches: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only tx, rollback is cleanup.
+
+	rows, err := tx.Query(ctx,
+		`SELECT watcher, node_id, created_at FROM kg_watches
+		WHERE tenant_id = $1 AND watcher = $2
+		ORDER BY node_id`,
+		tenantID, watcher,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying watches: %w", err)
+	}
+
+	watches, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Watch, error) {
+		var w models.Watch
+		err := row.Scan(&w.Watcher, &w.NodeID, &w.CreatedAt)
+
+		return w, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning watches: %w", err)
+	}
+
+	return watches, nil
+}
+
+// WatchedNodeIDs returns the IDs of the nodes watcher follows. It satisfies
+// ws.WatchLookup.
+func (s *WatchStore) WatchedNodeIDs(ctx context.Context, tenantID, watcher string) ([]string, error) {
+	watches, err := s.ListWatches(ctx, tenantID, watcher)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(watches))
+	for i, w := range watches {
+		ids[i] = w.NodeID
+	}
+
+	return ids, nil
+}
+
+// notifyWatch tells every instance's hub that a watch list changed, so
+// subscribers using it pick up the change without reconnecting. The bridge
+// applies it to the hub rather than broadcasting it as an event.
+func (s *WatchStore) notifyWatch(op, tenantID, watcher, nodeID string) {
+	s.sendNotification("kg_watches", op, map[string]any{
+		"table":     "kg_watches",
+		"op":        op,
+		"tenant_id": tenantID,
+		"watcher":   watcher,
+		"node_id":   nodeID,
+	})
+}
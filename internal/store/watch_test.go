@@ -0,0 +1,51 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/store"
+)
+
+func TestWatchStore(t *testing.T) {
+	base, tenantID := setupTestBase(t)
+	ns := store.NewNodeStore(base)
+	watches := store.NewWatchStore(base)
+	ctx := context.Background()
+
+	node := createTestNode(t, ns, tenantID, "Watched")
+
+	first, err := watches.Watch(ctx, tenantID, "agent-1", node.ID)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	// Watching again returns the existing watch.
+	again, err := watches.Watch(ctx, tenantID, "agent-1", node.ID)
+	if err != nil || !again.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("Watch again = %+v, %v; want %+v", again, err, first)
+	}
+
+	if _, err := watches.Watch(ctx, tenantID, "agent-1", "no-such-node"); !errors.Is(err, models.ErrNodeNotFound) {
+		t.Errorf("Watch missing node: err = %v, want ErrNodeNotFound", err)
+	}
+
+	ids, err := watches.WatchedNodeIDs(ctx, tenantID, "agent-1")
+	if err != nil || len(ids) != 1 || ids[0] != node.ID {
+		t.Errorf("WatchedNodeIDs = %v, %v; want [%s]", ids, err, node.ID)
+	}
+
+	if others, _ := watches.ListWatches(ctx, tenantID, "agent-2"); len(others) != 0 {
+		t.Errorf("other watcher sees %v", others)
+	}
+
+	if err := watches.Unwatch(ctx, tenantID, "agent-1", node.ID); err != nil {
+		t.Fatalf("Unwatch: %v", err)
+	}
+
+	if err := watches.Unwatch(ctx, tenantID, "agent-1", node.ID); !errors.Is(err, models.ErrWatchNotFound) {
+		t.Errorf("Unwatch again: err = %v, want ErrWatchNotFound", err)
+	}
+}
@@ -0,0 +1,112 @@
+//go:build integration
+
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"github.com/persistorai/persistor/internal/db"
+	"github.com/persistorai/persistor/internal/db/migrations"
+	"github.com/persistorai/persistor/internal/dbpool"
+)
+
+// postgresImage matches the image docker-compose.yml runs.
+const postgresImage = "pgvector/pgvector:pg18"
+
+// appRole is the unprivileged role tests connect as. Superusers bypass
+// row-level security, so connecting as one would hide tenant isolation bugs.
+const appRole = "persistor"
+
+// startContainer starts Postgres with pgvector, creates the extensions and
+// an unprivileged owner role as the superuser, applies every migration as
+// that role, and returns its connection string. The container is removed
+// when the test binary exits.
+func startContainer(ctx context.Context) (string, error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        postgresImage,
+			ExposedPorts: []string{"5432/tcp"},
+			Env: map[string]string{
+				"POSTGRES_USER":     "postgres",
+				"POSTGRES_PASSWORD": "postgres",
+				"POSTGRES_DB":       appRole,
+			},
+			// The server restarts once after initdb, so wait for the second
+			// ready message.
+			WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("starting postgres container: %w", err)
+	}
+
+	endpoint, err := container.PortEndpoint(ctx, "5432/tcp", "")
+	if err != nil {
+		return "", fmt.Errorf("resolving postgres port: %w", err)
+	}
+
+	if err := createAppRole(ctx, dsn(endpoint, "postgres", "postgres")); err != nil {
+		return "", err
+	}
+
+	appDSN := dsn(endpoint, appRole, appRole)
+
+	pool, err := dbpool.NewPool(ctx, appDSN, 2)
+	if err != nil {
+		return "", fmt.Errorf("connecting as %s: %w", appRole, err)
+	}
+	defer pool.Close()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	if err := db.RunMigrations(ctx, pool, log, migrations.FS); err != nil {
+		return "", err
+	}
+
+	return appDSN, nil
+}
+
+// createAppRole sets up what migrations need a superuser for: the
+// extensions, and a non-superuser role that owns the database.
+func createAppRole(ctx context.Context, superuserDSN string) error {
+	pool, err := dbpool.NewPool(ctx, superuserDSN, 2)
+	if err != nil {
+		return fmt.Errorf("connecting as superuser: %w", err)
+	}
+	defer pool.Close()
+
+	for _, stmt := range []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE ROLE " + appRole + " LOGIN NOSUPERUSER PASSWORD '" + appRole + "'",
+		"ALTER DATABASE " + appRole + " OWNER TO " + appRole,
+		"ALTER SCHEMA public OWNER TO " + appRole,
+	} {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("preparing test database: %s: %w", stmt, err)
+		}
+	}
+
+	return nil
+}
+
+func dsn(endpoint, user, password string) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, password),
+		Host:     endpoint,
+		Path:     appRole,
+		RawQuery: "sslmode=disable",
+	}
+
+	return u.String()
+}
@@ -0,0 +1,11 @@
+//go:build !integration
+
+package testutil
+
+import "context"
+
+// startContainer reports that containers are unavailable: they need the
+// integration build tag.
+func startContainer(context.Context) (string, error) {
+	return "", errNoContainers
+}
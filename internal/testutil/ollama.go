@@ -0,0 +1,65 @@
+package testutil
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// FakeOllama serves the Ollama /api/embed endpoint with deterministic
+// embeddings derived from each input, so identical texts embed identically.
+// It is closed when t finishes.
+func FakeOllama(t testing.TB, dimensions int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/embed" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var req struct {
+			Input any `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var texts []string
+		switch input := req.Input.(type) {
+		case string:
+			texts = []string{input}
+		case []any:
+			for _, text := range input {
+				s, _ := text.(string)
+				texts = append(texts, s)
+			}
+		}
+
+		embeddings := make([][]float32, len(texts))
+		for i, text := range texts {
+			embeddings[i] = FakeEmbedding(text, dimensions)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings}) //nolint:errcheck // test server.
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// FakeEmbedding is the embedding FakeOllama returns for text.
+func FakeEmbedding(text string, dimensions int) []float32 {
+	sum := sha256.Sum256([]byte(text))
+
+	vec := make([]float32, dimensions)
+	for i := range vec {
+		vec[i] = float32(sum[i%len(sum)])/255 - 0.5
+	}
+
+	return vec
+}
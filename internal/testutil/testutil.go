@@ -0,0 +1,191 @@
+// Package testutil is the harness for tests that need a real database. It
+// provides a migrated Postgres with pgvector, seeds tenants, and fakes the
+// Ollama embedding API, so store, service, and API tests can exercise the
+// full request path including encryption and row-level security.
+//
+// Tests use the database named by TEST_DATABASE_URL when it is set. Without
+// it, building with the integration tag starts a pgvector container on first
+// use, shared by every test in the binary:
+//
+//	go test -tags integration ./...
+//
+// Otherwise tests that need a database are skipped.
+package testutil
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/crypto"
+	"github.com/persistorai/persistor/internal/dbpool"
+)
+
+// TestHexKey is a valid 64-char hex string (32 bytes) for test encryption.
+const TestHexKey = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// errNoContainers is returned by startContainer in builds without the
+// integration tag.
+var errNoContainers = errors.New("containers need the integration build tag")
+
+// Env is a connection to the test database, shared by the tests of one
+// binary.
+type Env struct {
+	Pool *dbpool.Pool
+	Log  *logrus.Logger
+}
+
+var (
+	envOnce sync.Once
+	env     *Env
+	envErr  error
+)
+
+// NewEnv returns the shared test database environment, connecting on first
+// use. It skips t when no database is available.
+func NewEnv(t testing.TB) *Env {
+	t.Helper()
+
+	url := DatabaseURL(t)
+
+	envOnce.Do(func() {
+		pool, err := dbpool.NewPool(context.Background(), url, 5)
+		if err != nil {
+			envErr = fmt.Errorf("connecting to test DB: %w", err)
+			return
+		}
+
+		log := logrus.New()
+		log.SetLevel(logrus.ErrorLevel)
+
+		env = &Env{Pool: pool, Log: log}
+	})
+
+	if envErr != nil {
+		t.Fatal(envErr)
+	}
+
+	return env
+}
+
+// DatabaseURL returns TEST_DATABASE_URL, or the URL of a migrated container
+// in builds with the integration tag. It skips t when neither is available.
+func DatabaseURL(t testing.TB) string {
+	t.Helper()
+
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		return url
+	}
+
+	url, err := containerURL()
+	if errors.Is(err, errNoContainers) {
+		t.Skip("TEST_DATABASE_URL not set; run with -tags integration to start Postgres in a container")
+	}
+
+	if err != nil {
+		t.Fatalf("starting test database: %v", err)
+	}
+
+	return url
+}
+
+var (
+	containerOnce sync.Once
+	containerDSN  string
+	containerErr  error
+)
+
+// containerURL starts the test container once per binary.
+func containerURL() (string, error) {
+	containerOnce.Do(func() {
+		containerDSN, containerErr = startContainer(context.Background())
+	})
+
+	return containerDSN, containerErr
+}
+
+// NewCryptoService creates a fresh crypto.Service (StaticProvider locks to
+// the first tenant it sees).
+func NewCryptoService(t testing.TB) *crypto.Service {
+	t.Helper()
+
+	provider, err := crypto.NewStaticProvider(TestHexKey)
+	if err != nil {
+		t.Fatalf("creating static provider: %v", err)
+	}
+
+	return crypto.NewService(provider)
+}
+
+// tenantTables are the tenant-scoped tables emptied when a seeded tenant is
+// cleaned up, in dependency order.
+var tenantTables = []string{
+	"kg_event_links", "kg_event_records", "kg_episodes", "kg_audit_log",
+	"kg_import_sessions", "kg_branches", "kg_tag_centroids", "kg_access_sessions", "kg_access_stats",
+	"kg_delete_previews", "kg_idempotency_keys", "kg_signature_nonces", "kg_event_log", "kg_event_sequences", "kg_property_history",
+	"kg_inferred_edges", "kg_salience_snapshots", "kg_aliases", "kg_edges", "kg_nodes",
+}
+
+// SeedTenant creates a tenant and returns its ID and API key. The tenant and
+// its data are deleted when t finishes.
+func (e *Env) SeedTenant(t testing.TB) (tenantID, apiKey string) {
+	t.Helper()
+
+	tenantID = uuid.New().String()
+	apiKey = "test-key-" + tenantID
+	hash := sha256.Sum256([]byte(apiKey))
+
+	_, err := e.Pool.Exec(context.Background(),
+		"INSERT INTO tenants (id, name, api_key_hash) VALUES ($1, $2, $3)",
+		tenantID, "test-tenant-"+tenantID[:8], hex.EncodeToString(hash[:]),
+	)
+	if err != nil {
+		t.Fatalf("creating test tenant: %v", err)
+	}
+
+	t.Cleanup(func() { e.deleteTenant(t, tenantID) })
+
+	return tenantID, apiKey
+}
+
+// deleteTenant removes a seeded tenant's data inside its tenant context, so
+// row-level security lets the deletes through, then the tenant itself.
+func (e *Env) deleteTenant(t testing.TB, tenantID string) {
+	ctx := context.Background()
+
+	tx, err := e.Pool.Begin(ctx)
+	if err != nil {
+		t.Logf("cleaning up tenant %s: %v", tenantID, err)
+		return
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID); err != nil {
+		t.Logf("cleaning up tenant %s: %v", tenantID, err)
+		return
+	}
+
+	for _, table := range tenantTables {
+		if _, err := tx.Exec(ctx, "DELETE FROM "+table+" WHERE tenant_id = $1", tenantID); err != nil {
+			t.Logf("cleaning up tenant %s: deleting from %s: %v", tenantID, table, err)
+			return
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "DELETE FROM tenants WHERE id = $1", tenantID); err != nil {
+		t.Logf("cleaning up tenant %s: %v", tenantID, err)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Logf("cleaning up tenant %s: %v", tenantID, err)
+	}
+}
@@ -0,0 +1,238 @@
+// Package tracing records request traces and exports them to an
+// OpenTelemetry collector over OTLP/HTTP.
+//
+// Spans are started with Start, StartServer, or StartClient and carried in
+// the context. Until a Tracer is installed with SetDefault every call is a
+// no-op returning a nil *Span, whose methods are all safe to call.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanKind is the OTLP span kind.
+type SpanKind int
+
+// Span kinds, numbered as in OTLP.
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// Attribute is one span attribute. Value is a string, bool, int64, or
+// float64.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute { return Attribute{Key: key, Value: int64(value)} }
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Float64 returns a floating-point attribute.
+func Float64(key string, value float64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// spanContext identifies a span within a trace.
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+// Span is one timed operation in a trace. A nil *Span ignores every call.
+type Span struct {
+	tracer *Tracer
+	name   string
+	kind   SpanKind
+	sc     spanContext
+	parent [8]byte
+	start  time.Time
+
+	mu     sync.Mutex
+	end    time.Time
+	attrs  []Attribute
+	errMsg string
+	failed bool
+	ended  bool
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// RecordError marks the span as failed with err. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.failed = true
+	s.errMsg = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and queues it for export. Only the first call has
+// any effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.enqueue(s)
+}
+
+// TraceParent returns the span's W3C traceparent header value, for
+// propagating the trace to a downstream service.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+
+	return "00-" + hex.EncodeToString(s.sc.traceID[:]) + "-" + hex.EncodeToString(s.sc.spanID[:]) + "-01"
+}
+
+// TraceID returns the span's trace ID in hex.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(s.sc.traceID[:])
+}
+
+type spanKey struct{}
+
+// FromContext returns the span carried by ctx, or nil.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// defaultTracer is the tracer Start and friends use; nil disables tracing.
+var defaultTracer atomic.Pointer[Tracer]
+
+// SetDefault installs t as the tracer spans are recorded with. Passing nil
+// disables tracing.
+func SetDefault(t *Tracer) {
+	defaultTracer.Store(t)
+}
+
+// Enabled reports whether a tracer is installed.
+func Enabled() bool {
+	return defaultTracer.Load() != nil
+}
+
+// Start begins an internal span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return start(ctx, KindInternal, name, attrs)
+}
+
+// StartClient begins a span for a call to another service.
+func StartClient(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	return start(ctx, KindClient, name, attrs)
+}
+
+// StartServer begins a span for an incoming request. When traceparent is a
+// valid W3C traceparent header the span joins the caller's trace.
+func StartServer(ctx context.Context, name, traceparent string, attrs ...Attribute) (context.Context, *Span) {
+	if remote, ok := parseTraceParent(traceparent); ok {
+		ctx = context.WithValue(ctx, spanKey{}, &Span{sc: remote})
+	}
+
+	return start(ctx, KindServer, name, attrs)
+}
+
+func start(ctx context.Context, kind SpanKind, name string, attrs []Attribute) (context.Context, *Span) {
+	t := defaultTracer.Load()
+	if t == nil {
+		return ctx, nil
+	}
+
+	s := &Span{
+		tracer: t,
+		name:   name,
+		kind:   kind,
+		start:  time.Now(),
+		attrs:  attrs,
+	}
+
+	if parent := FromContext(ctx); parent != nil {
+		s.sc.traceID = parent.sc.traceID
+		s.parent = parent.sc.spanID
+	} else {
+		randomID(s.sc.traceID[:])
+	}
+
+	randomID(s.sc.spanID[:])
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// randomID fills id with random bytes, never all zero.
+func randomID(id []byte) {
+	for {
+		rand.Read(id) //nolint:errcheck // crypto/rand.Read never fails.
+
+		for _, b := range id {
+			if b != 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseTraceParent parses a version 00 W3C traceparent header.
+func parseTraceParent(h string) (spanContext, bool) {
+	var sc spanContext
+
+	parts := strings.Split(strings.TrimSpace(h), "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, false
+	}
+
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+
+	if sc.traceID == ([16]byte{}) || sc.spanID == ([8]byte{}) {
+		return sc, false
+	}
+
+	return sc, true
+}
@@ -0,0 +1,158 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+// collect runs a tracer against a fake collector, calls record, shuts the
+// tracer down, and returns the spans the collector received.
+func collect(t *testing.T, record func()) []exportedSpan {
+	t.Helper()
+
+	var spans []exportedSpan
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected export request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		var req struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("decoding export: %v", err)
+		}
+
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	defer srv.Close()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	tracer := tracing.NewTracer(srv.URL+"/", "persistor", "test", log)
+	tracing.SetDefault(tracer)
+	t.Cleanup(func() { tracing.SetDefault(nil) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		tracer.Run(ctx)
+		close(done)
+	}()
+
+	record()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("tracer did not shut down")
+	}
+
+	return spans
+}
+
+func TestTracer_ExportsSpanTree(t *testing.T) {
+	spans := collect(t, func() {
+		ctx, root := tracing.StartServer(context.Background(), "GET /api/v1/search/hybrid",
+			"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		_, child := tracing.Start(ctx, "SearchService.HybridSearch", tracing.String("tenant_id", "t1"), tracing.Int("node_count", 3))
+		child.RecordError(errors.New("boom"))
+		child.End()
+		root.End()
+		root.End()
+	})
+
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+
+	child, root := spans[0], spans[1]
+
+	if root.TraceID != "0af7651916cd43dd8448eb211c80319c" || root.ParentSpanID != "b7ad6b7169203331" {
+		t.Errorf("root did not join the caller's trace: %+v", root)
+	}
+	if root.Kind != int(tracing.KindServer) {
+		t.Errorf("root kind = %d", root.Kind)
+	}
+	if child.TraceID != root.TraceID || child.ParentSpanID != root.SpanID {
+		t.Errorf("child is not a child of root: %+v", child)
+	}
+	if child.Status == nil || child.Status.Code != 2 || child.Status.Message != "boom" {
+		t.Errorf("child status = %+v", child.Status)
+	}
+
+	attrs := map[string]map[string]any{}
+	for _, a := range child.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if attrs["tenant_id"]["stringValue"] != "t1" || attrs["node_count"]["intValue"] != "3" {
+		t.Errorf("child attributes = %v", attrs)
+	}
+}
+
+func TestStart_DisabledIsNoop(t *testing.T) {
+	tracing.SetDefault(nil)
+
+	ctx, span := tracing.Start(context.Background(), "noop")
+	if span != nil || tracing.FromContext(ctx) != nil {
+		t.Fatal("expected no span without a tracer")
+	}
+
+	span.SetAttributes(tracing.Bool("ok", true))
+	span.RecordError(errors.New("ignored"))
+	span.End()
+
+	if span.TraceParent() != "" {
+		t.Error("expected empty traceparent")
+	}
+}
+
+func TestStartServer_IgnoresInvalidTraceParent(t *testing.T) {
+	spans := collect(t, func() {
+		_, span := tracing.StartServer(context.Background(), "GET /health", "00-00000000000000000000000000000000-b7ad6b7169203331-01")
+		span.End()
+	})
+
+	if len(spans) != 1 || spans[0].ParentSpanID != "" || spans[0].TraceID == "00000000000000000000000000000000" {
+		t.Fatalf("spans = %+v", spans)
+	}
+}
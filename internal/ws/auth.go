@@ -0,0 +1,70 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+// authTimeout bounds how long an unauthenticated connection may stay open
+// waiting for its auth message.
+const authTimeout = 10 * time.Second
+
+// ErrInvalidAuthMsg is returned when the first frame is not a usable auth message.
+var ErrInvalidAuthMsg = errors.New("first message must be an auth message")
+
+// AuthMsg is the first frame sent by clients that could not authenticate during
+// the HTTP upgrade, e.g. browsers. Exactly one of Token or Ticket must be set.
+type AuthMsg struct {
+	Type   string `json:"type"`
+	Token  string `json:"token,omitempty"`
+	Ticket string `json:"ticket,omitempty"`
+}
+
+// AuthOKMsg acknowledges a successful first-message authentication.
+type AuthOKMsg struct {
+	Type     string `json:"type"`
+	TenantID string `json:"tenant_id"`
+}
+
+// ReadAuthMessage reads and validates the auth frame that must be the first
+// message on a connection that was accepted without credentials.
+func ReadAuthMessage(ctx context.Context, conn *websocket.Conn) (AuthMsg, error) {
+	ctx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+
+	conn.SetReadLimit(wsReadLimit)
+
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		return AuthMsg{}, fmt.Errorf("reading auth message: %w", err)
+	}
+
+	var msg AuthMsg
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return AuthMsg{}, ErrInvalidAuthMsg
+	}
+
+	if msg.Type != "auth" || (msg.Token == "") == (msg.Ticket == "") {
+		return AuthMsg{}, ErrInvalidAuthMsg
+	}
+
+	return msg, nil
+}
+
+// AckAuth tells the client its auth message was accepted.
+func AckAuth(ctx context.Context, conn *websocket.Conn, tenantID string) error {
+	msg, err := json.Marshal(AuthOKMsg{Type: "auth_ok", TenantID: tenantID})
+	if err != nil {
+		return fmt.Errorf("marshalling auth ack: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	return conn.Write(ctx, websocket.MessageText, msg)
+}
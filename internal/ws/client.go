@@ -39,6 +39,14 @@ type Client struct {
 	validator   TenantValidator
 	closeOnce   sync.Once
 	connectedAt time.Time
+	verbose     atomic.Bool                 // set by a verbose subscribe; read by the hub
+	filter      atomic.Pointer[EventFilter] // set by a filtered subscribe; read by the hub
+
+	// writeMu keeps WritePump from interleaving live events with an event
+	// log replay, which writes to the connection directly; replayedThrough
+	// then lets WritePump skip live copies of replayed events.
+	writeMu         sync.Mutex
+	replayedThrough atomic.Uint64
 }
 
 // closeSend safely closes the send channel exactly once.
@@ -46,6 +54,17 @@ func (c *Client) closeSend() {
 	c.closeOnce.Do(func() { close(c.send) })
 }
 
+// setFilter replaces the client's event filter; an empty filter removes it.
+func (c *Client) setFilter(f EventFilter) {
+	if f.empty() {
+		c.filter.Store(nil)
+
+		return
+	}
+
+	c.filter.Store(&f)
+}
+
 // NewClient creates a new Client for the given WebSocket connection.
 func NewClient(hub *Hub, conn *websocket.Conn, validator TenantValidator, apiKey string) *Client {
 	return &Client{
@@ -106,11 +125,8 @@ func (c *Client) sendPing(ctx context.Context, missedPongs *atomic.Int32) bool {
 }
 
 // handleMessage processes an incoming client message.
-func (c *Client) handleMessage(_ context.Context, msgBytes []byte) {
-	var msg struct {
-		Type        string `json:"type"`
-		LastEventID uint64 `json:"last_event_id"`
-	}
+func (c *Client) handleMessage(ctx context.Context, msgBytes []byte) {
+	var msg SubscribeMsg
 	if err := json.Unmarshal(msgBytes, &msg); err != nil {
 		return
 	}
@@ -119,7 +135,22 @@ func (c *Client) handleMessage(_ context.Context, msgBytes []byte) {
 		return
 	}
 
-	if !c.hub.ReplayEvents(c, msg.LastEventID) {
+	if err := msg.EventFilter.Validate(); err != nil {
+		c.sendError("invalid subscribe: " + err.Error())
+
+		return
+	}
+
+	if err := c.hub.ResolveWatches(ctx, c.TenantID, &msg.EventFilter); err != nil {
+		c.sendError("subscribe failed: " + err.Error())
+
+		return
+	}
+
+	c.verbose.Store(msg.Verbose)
+	c.setFilter(msg.EventFilter)
+
+	if !c.hub.ReplayEvents(ctx, c, msg.LastEventID) {
 		resetMsg, err := json.Marshal(ResetMsg{
 			Type:   "reset",
 			Reason: "requested events no longer available, perform full refresh",
@@ -134,6 +165,19 @@ func (c *Client) handleMessage(_ context.Context, msgBytes []byte) {
 	}
 }
 
+// sendError queues an error message for the client, dropping it when the
+// send buffer is full.
+func (c *Client) sendError(reason string) {
+	errMsg, err := json.Marshal(ErrorMsg{Type: "error", Reason: reason})
+	if err != nil {
+		return
+	}
+	select {
+	case c.send <- errMsg:
+	default:
+	}
+}
+
 // WritePump writes messages from the send channel to the WebSocket connection.
 // It enforces a maximum connection lifetime and periodically re-validates the API key.
 func (c *Client) WritePump(ctx context.Context) {
@@ -161,13 +205,7 @@ func (c *Client) WritePump(ctx context.Context) {
 				return
 			}
 
-			writeCtx, cancel := context.WithTimeout(ctx, writeTimeout)
-
-			err := c.conn.Write(writeCtx, websocket.MessageText, msg)
-
-			cancel()
-
-			if err != nil {
+			if err := c.writeMessage(ctx, msg); err != nil {
 				c.log.WithError(err).Debug("write failed")
 
 				return
@@ -185,6 +223,66 @@ func (c *Client) WritePump(ctx context.Context) {
 	}
 }
 
+// writeMessage writes msg unless it is an event an event log replay already
+// wrote.
+func (c *Client) writeMessage(ctx context.Context, msg []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if through := c.replayedThrough.Load(); through > 0 {
+		if id := eventID(msg); id > 0 && id <= through {
+			return nil
+		}
+	}
+
+	writeCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	return c.conn.Write(writeCtx, websocket.MessageText, msg)
+}
+
+// replayFromEventLog writes the events after lastEventID from the hub's
+// event log straight to the connection, holding off WritePump until done.
+// Returns false if the log does not reach back to lastEventID.
+func (c *Client) replayFromEventLog(ctx context.Context, lastEventID, oldest uint64) bool {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	verbose := c.verbose.Load()
+
+	last, ok, err := c.hub.replayFromEventLog(ctx, c.TenantID, lastEventID, oldest, c.filter.Load(), func(evt Event) error {
+		msg, err := marshalEvent(evt, verbose)
+		if err != nil {
+			return nil //nolint:nilerr // skip an event that cannot be encoded, as the buffer replay does.
+		}
+
+		writeCtx, cancel := context.WithTimeout(ctx, writeTimeout)
+		defer cancel()
+
+		return c.conn.Write(writeCtx, websocket.MessageText, msg)
+	})
+	if err != nil {
+		c.log.WithError(err).Debug("event log replay write failed")
+	}
+
+	c.replayedThrough.Store(last)
+
+	return ok
+}
+
+// eventID returns the sequence ID of an encoded event, or 0 for messages
+// that are not sequenced events.
+func eventID(msg []byte) uint64 {
+	var head struct {
+		ID uint64 `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &head); err != nil {
+		return 0
+	}
+
+	return head.ID
+}
+
 // refreshToken re-validates the API key. Returns true if valid, false if the connection should close.
 func (c *Client) refreshToken(ctx context.Context) bool {
 	if c.validator == nil {
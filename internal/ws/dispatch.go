@@ -0,0 +1,196 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/tracing"
+)
+
+// tenantBroadcast is sent through the broadcast channel to the Run goroutine.
+// When all is set the message goes to every client regardless of tenant.
+// verboseMsg, when set, replaces msg for clients subscribed in verbose mode.
+// subject, when set, is matched against each client's event filter.
+// watch, when set, is a watch list change to apply instead of a message;
+// it shares the channel so it stays ordered with the events around it.
+type tenantBroadcast struct {
+	tenantID   string
+	msg        []byte
+	verboseMsg []byte
+	all        bool
+	subject    *eventSubject
+	watch      *watchChange
+}
+
+// maxBroadcastPayload is the maximum allowed notification payload size (4 KB).
+const maxBroadcastPayload = 4096
+
+// maxEventData is the largest event data sent as-is, leaving room in
+// maxBroadcastPayload for the event envelope. Larger data is compacted.
+const maxEventData = maxBroadcastPayload - 256
+
+// BroadcastToTenant sends a message only to clients belonging to the specified tenant.
+// Payloads exceeding 4 KB are dropped with a warning log and counted in the
+// dropped change events metric.
+// The actual send is performed by the Run goroutine via a channel.
+func (h *Hub) BroadcastToTenant(tenantID string, msg []byte) {
+	h.enqueue(tenantBroadcast{tenantID: tenantID, msg: msg})
+}
+
+// enqueue hands a tenant broadcast to the Run goroutine, dropping it when
+// the payload is oversized or the channel is full.
+func (h *Hub) enqueue(b tenantBroadcast) {
+	if len(b.msg) > maxBroadcastPayload {
+		metrics.ChangeEventsDropped.WithLabelValues("oversized").Inc()
+		h.log.WithFields(logrus.Fields{
+			"tenant_id":    b.tenantID,
+			"payload_size": len(b.msg),
+			"max_size":     maxBroadcastPayload,
+		}).Warn("dropping oversized broadcast payload")
+		return
+	}
+	select {
+	case h.broadcast <- b:
+	default:
+		metrics.ChangeEventsDropped.WithLabelValues("channel_full").Inc()
+		h.log.WithField("tenant_id", b.tenantID).Warn("broadcast channel full, dropping message")
+	}
+}
+
+// dispatch delivers a broadcast to the clients of its tenant whose filter
+// admits it, disconnecting clients too slow to keep up. It runs on the Run
+// goroutine.
+func (h *Hub) dispatch(b tenantBroadcast) {
+	if b.watch != nil {
+		h.applyWatchChange(b.tenantID, *b.watch)
+		return
+	}
+
+	for client := range h.clients {
+		if !b.all && client.TenantID != b.tenantID {
+			continue
+		}
+		if b.subject != nil && !client.filter.Load().matches(*b.subject) {
+			continue
+		}
+		msg := b.msg
+		if b.verboseMsg != nil && client.verbose.Load() {
+			msg = b.verboseMsg
+		}
+		select {
+		case client.send <- msg:
+		default:
+			metrics.ChangeEventsDropped.WithLabelValues("slow_client").Inc()
+			h.log.WithField("tenant_id", client.TenantID).Warn("client send buffer full, disconnecting slow client")
+			client.closeSend()
+			delete(h.clients, client)
+			h.tenantCount[client.TenantID]--
+			if h.tenantCount[client.TenantID] <= 0 {
+				delete(h.tenantCount, client.TenantID)
+			}
+		}
+	}
+	h.count.Store(int64(len(h.clients)))
+}
+
+// BroadcastEvent stores an event in the buffer and event log and broadcasts
+// it to all clients of the given tenant. The ID is assigned by the database
+// when the change notification is sent; an event with ID 0 is sent live
+// only. Verbose clients receive the data as-is; everyone else gets it
+// without the "changes" detail. Data too large to broadcast is compacted to
+// references to the changed entities rather than dropped.
+func (h *Hub) BroadcastEvent(eventType, tenantID string, id uint64, data json.RawMessage) {
+	ctx, span := tracing.Start(context.Background(), "ws.broadcast",
+		tracing.String("tenant_id", tenantID),
+		tracing.String("event.type", eventType),
+	)
+	defer span.End()
+
+	evt := Event{
+		Type:     eventType,
+		ID:       id,
+		TenantID: tenantID,
+		Data:     h.boundEventData(tenantID, data),
+		Time:     time.Now(),
+	}
+
+	span.SetAttributes(tracing.Int64("event.id", int64(evt.ID))) //nolint:gosec // sequence IDs never exceed int64.
+	if evt.ID != 0 {
+		h.logEvent(ctx, &evt)
+		h.buffer.Append(tenantID, &evt)
+	}
+
+	msg, err := marshalEvent(evt, false)
+	if err != nil {
+		span.RecordError(err)
+		h.log.WithError(err).Error("failed to marshal event")
+		return
+	}
+
+	var verboseMsg []byte
+	if hasChangeDetail(evt.Data) {
+		verboseMsg, err = marshalEvent(evt, true)
+		if err != nil || len(verboseMsg) > maxBroadcastPayload {
+			// Verbose clients fall back to the event without detail.
+			metrics.ChangeEventsCompacted.WithLabelValues("broadcast").Inc()
+			verboseMsg = nil
+		}
+	}
+
+	subject := subjectOf(evt)
+	h.enqueue(tenantBroadcast{tenantID: tenantID, msg: msg, verboseMsg: verboseMsg, subject: &subject})
+}
+
+// boundEventData returns data, or its compacted form when it is too large to
+// broadcast.
+func (h *Hub) boundEventData(tenantID string, data json.RawMessage) json.RawMessage {
+	if len(withoutChangeDetail(data)) <= maxEventData {
+		return data
+	}
+
+	metrics.ChangeEventsCompacted.WithLabelValues("broadcast").Inc()
+	h.log.WithFields(logrus.Fields{
+		"tenant_id": tenantID,
+		"data_size": len(data),
+		"max_size":  maxEventData,
+	}).Warn("compacting oversized event to entity references")
+
+	return compactEventData(data)
+}
+
+// ReplayEvents sends events since lastEventID that pass the client's filter,
+// from the event log when the buffer no longer reaches back that far.
+// Returns false if the requested ID is too old (in neither).
+func (h *Hub) ReplayEvents(ctx context.Context, client *Client, lastEventID uint64) bool {
+	oldest := h.buffer.OldestID(client.TenantID)
+	if h.needsEventLog(lastEventID, oldest) {
+		return client.replayFromEventLog(ctx, lastEventID, oldest)
+	}
+
+	if oldest > 0 && lastEventID > 0 && lastEventID < oldest {
+		return false
+	}
+
+	verbose := client.verbose.Load()
+	filter := client.filter.Load()
+	events := h.buffer.Since(client.TenantID, lastEventID)
+	for _, evt := range events {
+		if !filter.matches(subjectOf(evt)) {
+			continue
+		}
+		msg, err := marshalEvent(evt, verbose)
+		if err != nil {
+			continue
+		}
+		select {
+		case client.send <- msg:
+		default:
+			return true // channel full, stop replay
+		}
+	}
+	return true
+}
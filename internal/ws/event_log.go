@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"context"
+	"time"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// Event log limits.
+const (
+	eventLogPageSize = 500
+	eventLogTimeout  = 5 * time.Second
+)
+
+// EventLog persists events beyond the in-memory buffer so clients that were
+// disconnected for longer than it covers can still resume.
+type EventLog interface {
+	AppendEvent(ctx context.Context, evt models.LoggedEvent) error
+	EventsSince(ctx context.Context, tenantID string, afterID uint64, limit int) ([]models.LoggedEvent, error)
+}
+
+// SetEventLog makes the hub log every event and fall back to the log when a
+// client asks to resume from before the in-memory buffer. Call it before
+// Run. Replicas sharing the log append the same events under the same IDs.
+func (h *Hub) SetEventLog(log EventLog) {
+	h.eventLog = log
+}
+
+// logEvent appends evt to the event log, if there is one. Failures are
+// logged and the live broadcast still goes out.
+func (h *Hub) logEvent(ctx context.Context, evt *Event) {
+	if h.eventLog == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, eventLogTimeout)
+	defer cancel()
+
+	err := h.eventLog.AppendEvent(ctx, models.LoggedEvent{
+		TenantID: evt.TenantID,
+		ID:       evt.ID,
+		Type:     evt.Type,
+		Data:     evt.Data,
+		Time:     evt.Time,
+	})
+	if err != nil {
+		h.log.WithError(err).WithField("tenant_id", evt.TenantID).Warn("appending event to event log")
+	}
+}
+
+// needsEventLog reports whether resuming after lastEventID needs events the
+// in-memory buffer no longer holds, or never held because of a restart.
+func (h *Hub) needsEventLog(lastEventID, oldest uint64) bool {
+	return h.eventLog != nil && lastEventID > 0 && (oldest == 0 || lastEventID < oldest)
+}
+
+// replayFromEventLog pages the tenant's events after lastEventID out of the
+// event log, passing each one the filter admits to write. It returns the ID
+// of the last event read and false when the log no longer reaches back to
+// lastEventID either, in which case the client must do a full refresh. A
+// write error stops the replay and is returned.
+func (h *Hub) replayFromEventLog(
+	ctx context.Context, tenantID string, lastEventID, oldest uint64, filter *EventFilter, write func(Event) error,
+) (uint64, bool, error) {
+	last := lastEventID
+
+	for {
+		queryCtx, cancel := context.WithTimeout(ctx, eventLogTimeout)
+		page, err := h.eventLog.EventsSince(queryCtx, tenantID, last, eventLogPageSize)
+		cancel()
+
+		if err != nil {
+			h.log.WithError(err).WithField("tenant_id", tenantID).Warn("reading event log for replay")
+
+			return last, false, nil
+		}
+
+		// IDs are consecutive, so the first event must directly follow the
+		// client's. An empty log is only complete if the buffer is empty too.
+		if last == lastEventID {
+			if len(page) == 0 {
+				return last, oldest == 0, nil
+			}
+
+			if page[0].ID != lastEventID+1 {
+				return last, false, nil
+			}
+		}
+
+		for _, logged := range page {
+			evt := Event{
+				Type:     logged.Type,
+				ID:       logged.ID,
+				TenantID: tenantID,
+				Data:     logged.Data,
+				Time:     logged.Time,
+			}
+			last = evt.ID
+
+			if !filter.matches(subjectOf(evt)) {
+				continue
+			}
+
+			if err := write(evt); err != nil {
+				return last, true, err
+			}
+		}
+
+		if len(page) < eventLogPageSize {
+			return last, true, nil
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/models"
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+// memoryEventLog is an in-memory ws.EventLog.
+type memoryEventLog struct {
+	mu     sync.Mutex
+	events []models.LoggedEvent
+}
+
+func (l *memoryEventLog) AppendEvent(_ context.Context, evt models.LoggedEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, evt)
+
+	return nil
+}
+
+func (l *memoryEventLog) EventsSince(_ context.Context, tenantID string, afterID uint64, limit int) ([]models.LoggedEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []models.LoggedEvent
+	for _, e := range l.events {
+		if e.TenantID == tenantID && e.ID > afterID && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+
+	return out, nil
+}
+
+// newLoggedHub starts a hub backed by log and a WebSocket server for
+// tenant-1 clients.
+func newLoggedHub(ctx context.Context, t *testing.T, log ws.EventLog) (*ws.Hub, string) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	hub := ws.NewHub(logger)
+	hub.SetEventLog(log)
+
+	go hub.Run(ctx)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := ws.NewClient(hub, conn, nil, "")
+		client.TenantID = "tenant-1"
+		hub.Register(client)
+		go client.WritePump(r.Context())
+		client.ReadPump(r.Context())
+	}))
+	t.Cleanup(srv.Close)
+
+	return hub, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestHub_EventLogReplay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	log := &memoryEventLog{}
+
+	// Events logged before a restart.
+	before, _ := newLoggedHub(ctx, t, log)
+	for id := range uint64(3) {
+		before.BroadcastEvent("kg.change", "tenant-1", id+1, json.RawMessage(`{"op":"insert"}`))
+	}
+
+	// After the restart the buffer starts at 4.
+	hub, url := newLoggedHub(ctx, t, log)
+	hub.BroadcastEvent("kg.change", "tenant-1", 4, json.RawMessage(`{"op":"update"}`))
+
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // test teardown
+
+	for hub.ClientCount() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", LastEventID: 1})
+	if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	readID := func(want uint64) {
+		t.Helper()
+
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+
+		var evt ws.Event
+		if err := json.Unmarshal(msg, &evt); err != nil || evt.ID != want {
+			t.Fatalf("got %s, want event %d", msg, want)
+		}
+	}
+
+	// The log replays 2-4, then live events continue from 5.
+	for _, want := range []uint64{2, 3, 4} {
+		readID(want)
+	}
+
+	hub.BroadcastEvent("kg.change", "tenant-1", 5, json.RawMessage(`{"op":"delete"}`))
+	readID(5)
+}
+
+func TestHub_EventLogGap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Event 2 has aged out of the log.
+	log := &memoryEventLog{events: []models.LoggedEvent{
+		{TenantID: "tenant-1", ID: 3, Type: "kg.change", Data: json.RawMessage(`{}`), Time: time.Now()},
+	}}
+
+	_, url := newLoggedHub(ctx, t, log)
+
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // test teardown
+
+	sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", LastEventID: 1})
+	if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	_, msg, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	if !strings.Contains(string(msg), `"type":"reset"`) {
+		t.Errorf("got %s, want a reset", msg)
+	}
+}
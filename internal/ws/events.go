@@ -2,8 +2,6 @@
 
 import (
 	"encoding/json"
-	"sync"
-	"sync/atomic"
 	"time"
 )
 
@@ -17,9 +15,13 @@ type Event struct {
 }
 
 // SubscribeMsg is sent by the client on connect to request event replay.
+// Verbose opts in to the "changes" detail on change events; the embedded
+// filter limits which events are replayed and delivered.
 type SubscribeMsg struct {
 	Type        string `json:"type"`
 	LastEventID uint64 `json:"last_event_id"`
+	Verbose     bool   `json:"verbose,omitempty"`
+	EventFilter
 }
 
 // ResetMsg tells the client to do a full refresh (requested events too old).
@@ -28,28 +30,113 @@ type ResetMsg struct {
 	Reason string `json:"reason"`
 }
 
-// EventSequence tracks monotonic event IDs per tenant.
-type EventSequence struct {
-	mu       sync.Mutex
-	counters map[string]*atomic.Uint64
+// ErrorMsg tells the client a request was rejected or the stream is closing.
+type ErrorMsg struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// OperatorMsg is an operator announcement, e.g. a maintenance notice.
+type OperatorMsg struct {
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Level   string    `json:"level"`
+	Time    time.Time `json:"time"`
+}
+
+// changeDetailKey is the data field carrying per-write change detail, which
+// is only sent to verbose subscribers.
+const changeDetailKey = "changes"
+
+// hasChangeDetail reports whether event data carries a change detail.
+func hasChangeDetail(data json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+
+	_, ok := fields[changeDetailKey]
+
+	return ok
 }
 
-// NewEventSequence creates a new EventSequence.
-func NewEventSequence() *EventSequence {
-	return &EventSequence{
-		counters: make(map[string]*atomic.Uint64),
+// withoutChangeDetail returns data with the change detail removed. Data that
+// has none, or is not a JSON object, is returned unchanged.
+func withoutChangeDetail(data json.RawMessage) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return data
+	}
+
+	if _, ok := fields[changeDetailKey]; !ok {
+		return data
 	}
+
+	delete(fields, changeDetailKey)
+
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+
+	return stripped
+}
+
+// eventReferenceKeys are the data fields kept when an event is compacted:
+// enough to identify what changed so the client can refetch it.
+var eventReferenceKeys = []string{"table", "op", "count", "tenant_id", "event", "node_id", "node_type"}
+
+// changeReferenceKeys are the change detail fields kept when an event is
+// compacted.
+var changeReferenceKeys = []string{"node_id", "source", "target", "relation"}
+
+// compactEventData reduces data to references to the changed entities,
+// marked "truncated" so clients know to fetch the details themselves. Data
+// that is not a JSON object is returned unchanged.
+func compactEventData(data json.RawMessage) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return data
+	}
+
+	compact := pickFields(fields, eventReferenceKeys)
+	compact["truncated"] = json.RawMessage("true")
+
+	var detail map[string]json.RawMessage
+	if err := json.Unmarshal(fields[changeDetailKey], &detail); err == nil {
+		changes := pickFields(detail, changeReferenceKeys)
+		changes["truncated"] = json.RawMessage("true")
+		if encoded, err := json.Marshal(changes); err == nil {
+			compact[changeDetailKey] = encoded
+		}
+	}
+
+	encoded, err := json.Marshal(compact)
+	if err != nil {
+		return data
+	}
+
+	return encoded
+}
+
+// pickFields returns the entries of fields named in keys.
+func pickFields(fields map[string]json.RawMessage, keys []string) map[string]json.RawMessage {
+	picked := make(map[string]json.RawMessage, len(keys)+1)
+	for _, key := range keys {
+		if v, ok := fields[key]; ok {
+			picked[key] = v
+		}
+	}
+
+	return picked
 }
 
-// Next returns the next sequence number for a tenant.
-func (es *EventSequence) Next(tenantID string) uint64 {
-	es.mu.Lock()
-	counter, ok := es.counters[tenantID]
-	if !ok {
-		counter = &atomic.Uint64{}
-		es.counters[tenantID] = counter
+// marshalEvent encodes evt for a client, dropping the change detail unless
+// the client subscribed in verbose mode.
+func marshalEvent(evt Event, verbose bool) ([]byte, error) {
+	if !verbose {
+		evt.Data = withoutChangeDetail(evt.Data)
 	}
-	es.mu.Unlock()
 
-	return counter.Add(1)
+	return json.Marshal(evt)
 }
@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// fanoutTimeout bounds publishing one message to the other instances.
+const fanoutTimeout = 5 * time.Second
+
+// Fanout carries operator messages to every server instance sharing the
+// database, this one included, so clients connected to any replica receive
+// them. Change events need no fanout: every instance already hears the
+// database's change notifications, which carry their event IDs.
+type Fanout interface {
+	PublishOperatorMessage(ctx context.Context, tenantID string, msg []byte) error
+}
+
+// SetFanout makes BroadcastOperatorMessage publish through f instead of
+// queueing locally; each instance then delivers what it hears with
+// DeliverOperatorMessage. Call it before Run.
+func (h *Hub) SetFanout(f Fanout) {
+	h.fanout = f
+}
+
+// DeliverOperatorMessage queues an operator message published by any
+// instance for this hub's clients of tenantID, or for all of them when
+// tenantID is empty.
+func (h *Hub) DeliverOperatorMessage(tenantID string, msg []byte) {
+	h.enqueue(tenantBroadcast{tenantID: tenantID, msg: msg, all: tenantID == ""})
+}
+
+// publishOperatorMessage hands an encoded operator message to the fanout.
+func (h *Hub) publishOperatorMessage(tenantID string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fanoutTimeout)
+	defer cancel()
+
+	if err := h.fanout.PublishOperatorMessage(ctx, tenantID, msg); err != nil {
+		return fmt.Errorf("publishing operator message: %w", err)
+	}
+
+	return nil
+}
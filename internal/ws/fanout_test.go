@@ -0,0 +1,75 @@
+package ws_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+// loopbackFanout delivers every published message to all of its hubs, as
+// the database does for every listening instance.
+type loopbackFanout struct {
+	hubs []*ws.Hub
+}
+
+func (f *loopbackFanout) PublishOperatorMessage(_ context.Context, tenantID string, msg []byte) error {
+	for _, h := range f.hubs {
+		h.DeliverOperatorMessage(tenantID, msg)
+	}
+
+	return nil
+}
+
+func TestHub_FanoutReachesOtherInstances(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a, b := ws.NewHub(log), ws.NewHub(log)
+	fanout := &loopbackFanout{hubs: []*ws.Hub{a, b}}
+	a.SetFanout(fanout)
+	b.SetFanout(fanout)
+
+	go a.Run(ctx)
+	go b.Run(ctx)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.ServeSSE(r.Context(), w, ws.SSEOptions{TenantID: "tenant-1"})
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	for b.ClientCount() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := a.BroadcastOperatorMessage("tenant-1", "maintenance at noon", "info"); err != nil {
+		t.Fatalf("BroadcastOperatorMessage: %v", err)
+	}
+
+	frames := readSSE(t, bufio.NewReader(resp.Body), 1)
+	if !strings.HasPrefix(frames[0], "event: operator_message\n") || !strings.Contains(frames[0], "maintenance at noon") {
+		t.Errorf("frame = %q", frames[0])
+	}
+
+	cancel()
+	a.Shutdown()
+	b.Shutdown()
+}
@@ -0,0 +1,158 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/persistorai/persistor/internal/models"
+)
+
+// maxFilterValues caps each filter list, since every broadcast is matched
+// against every subscriber's filter in the hub loop.
+const maxFilterValues = 32
+
+// EventFilter narrows the events a client receives. Each non-empty list must
+// match: Types against the event type, NodeTypes and NodeIDPrefixes against
+// the node an event names. Events that name no node, such as bulk or edge
+// changes, pass the node filters since they may affect any node. Operator
+// messages and shutdown notices are never filtered.
+//
+// Watcher names a watch list. Unlike the node filters it is strict: only
+// events naming a watched node, or an edge with a watched endpoint, pass.
+// The hub loads the list with ResolveWatches and keeps it current as nodes
+// are watched and unwatched.
+type EventFilter struct {
+	Types          []string `json:"types,omitempty"`
+	NodeTypes      []string `json:"node_types,omitempty"`
+	NodeIDPrefixes []string `json:"node_id_prefixes,omitempty"`
+	Watcher        string   `json:"watcher,omitempty"`
+
+	watched map[string]struct{} // loaded by Hub.ResolveWatches
+}
+
+// Validate checks the filter's size and watcher name.
+func (f *EventFilter) Validate() error {
+	if f.Watcher != "" {
+		if err := models.ValidateWatcher(f.Watcher); err != nil {
+			return err
+		}
+	}
+
+	for name, values := range map[string][]string{
+		"types":            f.Types,
+		"node_types":       f.NodeTypes,
+		"node_id_prefixes": f.NodeIDPrefixes,
+	} {
+		if len(values) > maxFilterValues {
+			return fmt.Errorf("%s has %d values, max %d", name, len(values), maxFilterValues)
+		}
+	}
+
+	return nil
+}
+
+// empty reports whether the filter matches every event.
+func (f *EventFilter) empty() bool {
+	return len(f.Types) == 0 && len(f.NodeTypes) == 0 && len(f.NodeIDPrefixes) == 0 && f.Watcher == ""
+}
+
+// matches reports whether an event with the given subject passes the filter.
+// A nil filter matches everything.
+func (f *EventFilter) matches(s eventSubject) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.Types) > 0 && !slices.Contains(f.Types, s.eventType) {
+		return false
+	}
+
+	if len(f.NodeTypes) > 0 && s.nodeType != "" && !slices.Contains(f.NodeTypes, s.nodeType) {
+		return false
+	}
+
+	if len(f.NodeIDPrefixes) > 0 && s.nodeID != "" &&
+		!slices.ContainsFunc(f.NodeIDPrefixes, func(p string) bool { return strings.HasPrefix(s.nodeID, p) }) {
+		return false
+	}
+
+	if f.Watcher != "" && !f.watching(s) {
+		return false
+	}
+
+	return true
+}
+
+// watching reports whether the event names a watched node.
+func (f *EventFilter) watching(s eventSubject) bool {
+	for _, id := range []string{s.nodeID, s.source, s.target} {
+		if _, ok := f.watched[id]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withWatch returns a copy of the filter with nodeID added to or removed
+// from the watched set. The filter itself is shared with the hub and never
+// modified.
+func (f *EventFilter) withWatch(nodeID string, watched bool) *EventFilter {
+	out := *f
+	out.watched = make(map[string]struct{}, len(f.watched)+1)
+
+	for id := range f.watched {
+		out.watched[id] = struct{}{}
+	}
+
+	if watched {
+		out.watched[nodeID] = struct{}{}
+	} else {
+		delete(out.watched, nodeID)
+	}
+
+	return &out
+}
+
+// eventSubject is what filters match an event on, extracted once per
+// broadcast rather than once per client.
+type eventSubject struct {
+	eventType string
+	nodeID    string
+	nodeType  string
+	source    string
+	target    string
+}
+
+// subjectOf returns the event's type and the node it names, if any. Single
+// node writes carry node_id and node_type; older payloads may only name the
+// node in their change detail. Single edge writes name their endpoints in
+// their change detail.
+func subjectOf(evt Event) eventSubject {
+	s := eventSubject{eventType: evt.Type}
+
+	var data struct {
+		NodeID   string `json:"node_id"`
+		NodeType string `json:"node_type"`
+		Changes  struct {
+			NodeID string `json:"node_id"`
+			Source string `json:"source"`
+			Target string `json:"target"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(evt.Data, &data); err != nil {
+		return s
+	}
+
+	s.nodeID = data.NodeID
+	if s.nodeID == "" {
+		s.nodeID = data.Changes.NodeID
+	}
+	s.nodeType = data.NodeType
+	s.source = data.Changes.Source
+	s.target = data.Changes.Target
+
+	return s
+}
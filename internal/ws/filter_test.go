@@ -0,0 +1,109 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+func TestHub_FilteredSubscribe(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	hub := ws.NewHub(log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go hub.Run(ctx)
+
+	node := func(id, typ string) json.RawMessage {
+		return json.RawMessage(`{"table":"kg_nodes","op":"update","node_id":"` + id + `","node_type":"` + typ + `"}`)
+	}
+
+	hub.BroadcastEvent("kg.change", "tenant-1", 1, node("acme-1", "person"))  // 1: match
+	hub.BroadcastEvent("kg.change", "tenant-1", 2, node("acme-2", "project")) // 2: wrong type
+	hub.BroadcastEvent("kg.change", "tenant-1", 3, node("other-1", "person")) // 3: wrong prefix
+	hub.BroadcastEvent("kg.change", "tenant-1", 4, json.RawMessage(`{"table":"kg_nodes","op":"BULK","count":3}`))
+	hub.BroadcastEvent("kg.audit", "tenant-1", 5, node("acme-3", "person")) // 5: wrong event type
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := ws.NewClient(hub, conn, nil, "")
+		client.TenantID = "tenant-1"
+		hub.Register(client)
+		go client.WritePump(r.Context())
+		client.ReadPump(r.Context())
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // test teardown
+
+	sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", EventFilter: ws.EventFilter{
+		Types:          []string{"kg.change"},
+		NodeTypes:      []string{"person"},
+		NodeIDPrefixes: []string{"acme-"},
+	}})
+	if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	readID := func() uint64 {
+		t.Helper()
+
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+
+		var evt ws.Event
+		if err := json.Unmarshal(msg, &evt); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+
+		return evt.ID
+	}
+
+	// Replay skips the events the filter excludes; the bulk change names no
+	// node, so it passes.
+	for _, want := range []uint64{1, 4} {
+		if got := readID(); got != want {
+			t.Fatalf("replayed event %d, want %d", got, want)
+		}
+	}
+
+	hub.BroadcastEvent("kg.change", "tenant-1", 6, node("other-2", "person")) // 6: filtered live
+	hub.BroadcastEvent("kg.change", "tenant-1", 7, node("acme-4", "person"))  // 7: delivered
+
+	if got := readID(); got != 7 {
+		t.Errorf("live event %d, want 7", got)
+	}
+}
+
+func TestEventFilter_Validate(t *testing.T) {
+	f := ws.EventFilter{NodeTypes: make([]string, 33)}
+	if err := f.Validate(); err == nil {
+		t.Error("expected an error for 33 node types")
+	}
+
+	f.NodeTypes = f.NodeTypes[:32]
+	if err := f.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -4,12 +4,15 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/persistorai/persistor/internal/metrics"
+	"github.com/persistorai/persistor/internal/tracing"
 )
 
 // Hub channel buffer sizes.
@@ -18,12 +21,6 @@
 	registerBuffer  = 64
 )
 
-// tenantBroadcast is sent through the broadcast channel to the Run goroutine.
-type tenantBroadcast struct {
-	tenantID string
-	msg      []byte
-}
-
 // Hub manages active WebSocket clients and broadcasts messages.
 // All client map mutations happen exclusively in the Run goroutine.
 type Hub struct {
@@ -36,8 +33,10 @@ type Hub struct {
 	done        chan struct{} // closed when Run has finished draining
 	count       atomic.Int64
 	log         *logrus.Logger
-	seq         *EventSequence
 	buffer      *EventBuffer
+	eventLog    EventLog    // nil unless SetEventLog was called
+	fanout      Fanout      // nil unless SetFanout was called
+	watches     WatchLookup // nil unless SetWatchLookup was called
 }
 
 // NewHub creates a new Hub instance.
@@ -51,7 +50,6 @@ func NewHub(log *logrus.Logger) *Hub {
 		shutdown:    make(chan struct{}),
 		done:        make(chan struct{}),
 		log:         log,
-		seq:         NewEventSequence(),
 		buffer:      NewEventBuffer(defaultBufferMaxLen, defaultBufferMaxAge),
 	}
 }
@@ -108,45 +106,51 @@ func (h *Hub) Run(ctx context.Context) { //nolint:gocognit,gocyclo,cyclop // con
 			h.log.WithField("total", len(h.clients)).Info("client unregistered")
 
 		case b := <-h.broadcast:
-			for client := range h.clients {
-				if client.TenantID != b.tenantID {
-					continue
-				}
-				select {
-				case client.send <- b.msg:
-				default:
-					client.closeSend()
-					delete(h.clients, client)
-					h.tenantCount[client.TenantID]--
-					if h.tenantCount[client.TenantID] <= 0 {
-						delete(h.tenantCount, client.TenantID)
-					}
-				}
-			}
-			h.count.Store(int64(len(h.clients)))
+			h.dispatch(b)
 		}
 	}
 }
 
-// maxBroadcastPayload is the maximum allowed notification payload size (4 KB).
-const maxBroadcastPayload = 4096
+// ErrBroadcastDropped is returned when an operator message cannot be queued.
+var ErrBroadcastDropped = errors.New("broadcast channel full")
+
+// BroadcastOperatorMessage queues an operator message for the clients of
+// tenantID, or for every connected client when tenantID is empty. Unlike
+// BroadcastEvent it is not sequenced or buffered for replay. With a fanout
+// set, the message goes to the clients of every instance.
+func (h *Hub) BroadcastOperatorMessage(tenantID, message, level string) (err error) {
+	_, span := tracing.Start(context.Background(), "ws.operator_broadcast",
+		tracing.String("tenant_id", tenantID),
+		tracing.String("level", level),
+	)
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	msg, err := json.Marshal(OperatorMsg{
+		Type:    "operator_message",
+		Message: message,
+		Level:   level,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling operator message: %w", err)
+	}
 
-// BroadcastToTenant sends a message only to clients belonging to the specified tenant.
-// Payloads exceeding 4 KB are dropped with a warning log.
-// The actual send is performed by the Run goroutine via a channel.
-func (h *Hub) BroadcastToTenant(tenantID string, msg []byte) {
 	if len(msg) > maxBroadcastPayload {
-		h.log.WithFields(logrus.Fields{
-			"tenant_id":    tenantID,
-			"payload_size": len(msg),
-			"max_size":     maxBroadcastPayload,
-		}).Warn("dropping oversized broadcast payload")
-		return
+		return fmt.Errorf("operator message exceeds %d bytes", maxBroadcastPayload)
+	}
+
+	if h.fanout != nil {
+		return h.publishOperatorMessage(tenantID, msg)
 	}
+
 	select {
-	case h.broadcast <- tenantBroadcast{tenantID: tenantID, msg: msg}:
+	case h.broadcast <- tenantBroadcast{tenantID: tenantID, msg: msg, all: tenantID == ""}:
+		return nil
 	default:
-		h.log.Warn("broadcast channel full, dropping message")
+		return ErrBroadcastDropped
 	}
 }
 
@@ -174,27 +178,6 @@ func (h *Hub) ClientCount() int {
 	return int(h.count.Load())
 }
 
-// BroadcastEvent assigns a sequence ID, stores in the buffer, and broadcasts
-// a typed event to all clients of the given tenant.
-func (h *Hub) BroadcastEvent(eventType, tenantID string, data json.RawMessage) {
-	evt := Event{
-		Type:     eventType,
-		ID:       h.seq.Next(tenantID),
-		TenantID: tenantID,
-		Data:     data,
-		Time:     time.Now(),
-	}
-
-	msg, err := json.Marshal(evt)
-	if err != nil {
-		h.log.WithError(err).Error("failed to marshal event")
-		return
-	}
-
-	h.buffer.Append(tenantID, &evt)
-	h.BroadcastToTenant(tenantID, msg)
-}
-
 // Shutdown initiates a graceful WebSocket drain: sends a shutdown frame to
 // every connected client, waits for their write pumps to flush, then closes
 // all connections. It blocks until drain is complete or the timeout expires.
@@ -259,26 +242,3 @@ func (h *Hub) drainClients() {
 	h.count.Store(0)
 	metrics.WSConnections.Set(0)
 }
-
-// ReplayEvents sends buffered events since lastEventID to the client.
-// Returns false if the requested ID is too old (not in buffer).
-func (h *Hub) ReplayEvents(client *Client, lastEventID uint64) bool {
-	oldest := h.buffer.OldestID(client.TenantID)
-	if oldest > 0 && lastEventID > 0 && lastEventID < oldest {
-		return false
-	}
-
-	events := h.buffer.Since(client.TenantID, lastEventID)
-	for _, evt := range events {
-		msg, err := json.Marshal(evt)
-		if err != nil {
-			continue
-		}
-		select {
-		case client.send <- msg:
-		default:
-			return true // channel full, stop replay
-		}
-	}
-	return true
-}
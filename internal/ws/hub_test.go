@@ -0,0 +1,138 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+func TestHub_VerboseReplay(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	hub := ws.NewHub(log)
+
+	hub.BroadcastEvent("kg.change", "tenant-1", 1, json.RawMessage(
+		`{"table":"kg_nodes","op":"update","tenant_id":"tenant-1","changes":{"node_id":"n1","properties":["city"]}}`,
+	))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := ws.NewClient(hub, conn, nil, "")
+		client.TenantID = "tenant-1"
+		go client.WritePump(r.Context())
+		client.ReadPump(r.Context())
+	}))
+	defer srv.Close()
+
+	for _, verbose := range []bool{false, true} {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+		if err != nil {
+			cancel()
+			t.Fatalf("Dial: %v", err)
+		}
+
+		sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", Verbose: verbose})
+		if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+
+		var evt struct {
+			Data map[string]json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &evt); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+
+		if _, ok := evt.Data["changes"]; ok != verbose {
+			t.Errorf("verbose=%v: event %s, changes present = %v", verbose, msg, ok)
+		}
+		if _, ok := evt.Data["table"]; !ok {
+			t.Errorf("verbose=%v: event %s is missing the table", verbose, msg)
+		}
+
+		conn.Close(websocket.StatusNormalClosure, "") //nolint:errcheck // test teardown
+		cancel()
+	}
+}
+
+func TestHub_CompactsOversizedEvent(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	hub := ws.NewHub(log)
+
+	summary := strings.Repeat("x", 5000)
+	hub.BroadcastEvent("kg.change", "tenant-1", 1, json.RawMessage(
+		`{"table":"kg_nodes","op":"update","tenant_id":"tenant-1","node_id":"n1","summary":"`+summary+
+			`","changes":{"node_id":"n1","properties":["city"]}}`,
+	))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := ws.NewClient(hub, conn, nil, "")
+		client.TenantID = "tenant-1"
+		go client.WritePump(r.Context())
+		client.ReadPump(r.Context())
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "") //nolint:errcheck // test teardown
+
+	sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", Verbose: true})
+	if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	_, msg, err := conn.Read(ctx)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	var evt struct {
+		Data struct {
+			NodeID    string         `json:"node_id"`
+			Summary   string         `json:"summary"`
+			Truncated bool           `json:"truncated"`
+			Changes   map[string]any `json:"changes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &evt); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !evt.Data.Truncated || evt.Data.NodeID != "n1" || evt.Data.Summary != "" {
+		t.Errorf("event %s, want a truncated reference to n1", msg)
+	}
+	if evt.Data.Changes["node_id"] != "n1" || evt.Data.Changes["properties"] != nil {
+		t.Errorf("changes = %v, want only the node reference", evt.Data.Changes)
+	}
+}
@@ -0,0 +1,242 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// sseHeartbeatInterval is how often an idle stream sends a comment so proxies
+// keep the connection open and dead clients are noticed.
+const sseHeartbeatInterval = 30 * time.Second
+
+// SSEOptions describes one Server-Sent Events subscriber.
+type SSEOptions struct {
+	TenantID  string
+	APIKey    string
+	Validator TenantValidator
+	// Replay requests the buffered events after LastEventID, as a
+	// reconnecting EventSource does by sending Last-Event-ID.
+	Replay      bool
+	LastEventID uint64
+	Verbose     bool
+	Filter      EventFilter
+}
+
+// ServeSSE streams the tenant's events to w as text/event-stream until ctx
+// ends, the hub drops or drains the subscriber, the API key stops validating,
+// or the maximum connection lifetime passes. The subscriber counts against
+// the same connection limits as a WebSocket client, and events carry their
+// sequence ID as the SSE id so a reconnect resumes where it left off.
+func (h *Hub) ServeSSE(ctx context.Context, w http.ResponseWriter, opts SSEOptions) {
+	client := &Client{
+		hub:         h,
+		send:        make(chan []byte, clientSendBuffer),
+		log:         h.log,
+		TenantID:    opts.TenantID,
+		apiKey:      opts.APIKey,
+		validator:   opts.Validator,
+		connectedAt: time.Now(),
+	}
+	client.verbose.Store(opts.Verbose)
+	client.setFilter(opts.Filter)
+
+	h.Register(client)
+	defer h.Unregister(client)
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Replay is written directly, after registering, so nothing broadcast in
+	// between is missed; live copies of replayed events are skipped by ID.
+	lastSent, ok := h.replaySSE(ctx, w, client, opts)
+	if !ok || rc.Flush() != nil {
+		return
+	}
+
+	lifetimeTimer := time.NewTimer(time.Until(client.connectedAt.Add(maxConnLifetime)))
+	defer lifetimeTimer.Stop()
+
+	refreshTicker := time.NewTicker(tokenRefreshInterval)
+	defer refreshTicker.Stop()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-client.send:
+			if !ok {
+				return
+			}
+
+			err = writeSSEMessage(w, rc, msg, &lastSent)
+		case <-heartbeat.C:
+			err = writeSSE(w, rc, ": heartbeat\n\n")
+		case <-refreshTicker.C:
+			if !client.refreshSSEToken(ctx) {
+				if msg, err := json.Marshal(ErrorMsg{Type: "error", Reason: "authentication expired"}); err == nil {
+					writeSSEMessage(w, rc, msg, &lastSent) //nolint:errcheck // best-effort
+				}
+
+				return
+			}
+		case <-lifetimeTimer.C:
+			client.log.Info("closing event stream: max connection lifetime exceeded")
+
+			return
+		}
+
+		if err != nil {
+			client.log.WithError(err).Debug("event stream write failed")
+
+			return
+		}
+	}
+}
+
+// replaySSE writes the events the subscriber missed, from the event log
+// when the buffer no longer reaches back that far, or a reset event when
+// neither does. It returns the last event ID replayed and false if the
+// client has gone away.
+func (h *Hub) replaySSE(ctx context.Context, w io.Writer, client *Client, opts SSEOptions) (uint64, bool) {
+	lastSent := opts.LastEventID
+	if !opts.Replay {
+		return lastSent, true
+	}
+
+	filter := client.filter.Load()
+	oldest := h.buffer.OldestID(client.TenantID)
+
+	if h.needsEventLog(opts.LastEventID, oldest) {
+		last, ok, err := h.replayFromEventLog(ctx, client.TenantID, opts.LastEventID, oldest, filter, func(evt Event) error {
+			return writeSSEEvent(w, evt, opts.Verbose)
+		})
+		if err != nil {
+			return last, false
+		}
+
+		if !ok {
+			return last, writeSSEReset(w) == nil
+		}
+
+		return last, true
+	}
+
+	if oldest > 0 && opts.LastEventID > 0 && opts.LastEventID < oldest {
+		return lastSent, writeSSEReset(w) == nil
+	}
+
+	for _, evt := range h.buffer.Since(client.TenantID, opts.LastEventID) {
+		if !filter.matches(subjectOf(evt)) {
+			continue
+		}
+
+		if err := writeSSEEvent(w, evt, opts.Verbose); err != nil {
+			return lastSent, false
+		}
+
+		lastSent = evt.ID
+	}
+
+	return lastSent, true
+}
+
+// writeSSEEvent writes one replayed event. Events that cannot be encoded are
+// skipped, as in the WebSocket replay.
+func writeSSEEvent(w io.Writer, evt Event, verbose bool) error {
+	msg, err := marshalEvent(evt, verbose)
+	if err != nil {
+		return nil //nolint:nilerr // skip the event.
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Type, msg)
+
+	return err
+}
+
+// writeSSEReset tells the subscriber the events it asked for are gone.
+func writeSSEReset(w io.Writer) error {
+	reset, err := json.Marshal(ResetMsg{
+		Type:   "reset",
+		Reason: "requested events no longer available, perform full refresh",
+	})
+	if err != nil {
+		return nil //nolint:nilerr // nothing to send.
+	}
+
+	_, err = fmt.Fprintf(w, "event: reset\ndata: %s\n\n", reset)
+
+	return err
+}
+
+// writeSSEMessage frames a hub message as an SSE event named after its type.
+// Sequenced events also get an id line; ones at or below lastSent were
+// already replayed and are skipped.
+func writeSSEMessage(w io.Writer, rc *http.ResponseController, msg []byte, lastSent *uint64) error {
+	var head struct {
+		Type string `json:"type"`
+		ID   uint64 `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &head); err != nil {
+		return nil //nolint:nilerr // not an event; nothing to frame.
+	}
+
+	if head.ID > 0 {
+		if head.ID <= *lastSent {
+			return nil
+		}
+
+		*lastSent = head.ID
+
+		return writeSSE(w, rc, fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", head.ID, head.Type, msg))
+	}
+
+	return writeSSE(w, rc, fmt.Sprintf("event: %s\ndata: %s\n\n", head.Type, msg))
+}
+
+// writeSSE writes one frame within writeTimeout and flushes it to the client.
+func writeSSE(w io.Writer, rc *http.ResponseController, frame string) error {
+	if err := rc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	if _, err := io.WriteString(w, frame); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+// refreshSSEToken re-validates the subscriber's API key. Returns false if
+// the stream should close.
+func (c *Client) refreshSSEToken(ctx context.Context) bool {
+	if c.validator == nil {
+		return true
+	}
+
+	refreshCtx, cancel := context.WithTimeout(ctx, tokenRefreshTimeout)
+	_, err := c.validator.GetTenantByAPIKey(refreshCtx, c.apiKey)
+	cancel()
+
+	if err != nil {
+		c.log.Info("closing event stream: token refresh failed")
+
+		return false
+	}
+
+	return true
+}
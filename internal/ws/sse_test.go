@@ -0,0 +1,101 @@
+package ws_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+// readSSE returns the next n frames from an event stream, skipping comments.
+func readSSE(t *testing.T, r *bufio.Reader, n int) []string {
+	t.Helper()
+
+	var frames []string
+	var frame strings.Builder
+
+	for len(frames) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading stream after %d frames: %v", len(frames), err)
+		}
+
+		switch {
+		case line == "\n":
+			if frame.Len() > 0 {
+				frames = append(frames, frame.String())
+				frame.Reset()
+			}
+		case !strings.HasPrefix(line, ":"):
+			frame.WriteString(line)
+		}
+	}
+
+	return frames
+}
+
+func TestHub_ServeSSE(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	hub := ws.NewHub(log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go hub.Run(ctx)
+
+	for i := range 3 {
+		hub.BroadcastEvent("kg.change", "tenant-1", uint64(i+1), json.RawMessage(fmt.Sprintf(`{"n":%d}`, i+1)))
+	}
+	hub.BroadcastEvent("kg.change", "tenant-2", 1, json.RawMessage(`{"n":0}`))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.ServeSSE(r.Context(), w, ws.SSEOptions{TenantID: "tenant-1", Replay: true, LastEventID: 1})
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	replayed := readSSE(t, r, 2)
+
+	for i, id := range []string{"2", "3"} {
+		if !strings.HasPrefix(replayed[i], "id: "+id+"\nevent: kg.change\ndata: {") {
+			t.Errorf("replayed frame %d = %q", i, replayed[i])
+		}
+	}
+
+	for hub.ClientCount() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	hub.BroadcastEvent("kg.change", "tenant-2", 2, json.RawMessage(`{"n":0}`))
+	hub.BroadcastEvent("kg.change", "tenant-1", 4, json.RawMessage(`{"n":4}`))
+
+	live := readSSE(t, r, 1)
+	if !strings.HasPrefix(live[0], "id: 4\n") || !strings.Contains(live[0], `"data":{"n":4}`) {
+		t.Errorf("live frame = %q", live[0])
+	}
+
+	cancel()
+	hub.Shutdown()
+}
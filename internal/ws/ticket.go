@@ -0,0 +1,95 @@
+package ws
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Ticket limits.
+const (
+	TicketTTL        = 30 * time.Second
+	ticketBytes      = 32
+	ticketMaxPending = 10000
+)
+
+type ticketEntry struct {
+	tenantID  string
+	apiKey    string
+	expiresAt time.Time
+}
+
+// TicketStore issues short-lived, single-use tickets that let browsers open a
+// WebSocket without sending their API key in a header or query string.
+type TicketStore struct {
+	mu      sync.Mutex
+	tickets map[string]ticketEntry
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewTicketStore creates an empty TicketStore using TicketTTL.
+func NewTicketStore() *TicketStore {
+	return &TicketStore{
+		tickets: make(map[string]ticketEntry),
+		ttl:     TicketTTL,
+		now:     time.Now,
+	}
+}
+
+// Issue creates a ticket bound to the tenant and API key that requested it.
+// The API key is kept so the connection can be re-validated periodically.
+func (s *TicketStore) Issue(tenantID, apiKey string) (string, time.Time, error) {
+	buf := make([]byte, ticketBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("generating ticket: %w", err)
+	}
+
+	ticket := hex.EncodeToString(buf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.pruneLocked(now)
+
+	if len(s.tickets) >= ticketMaxPending {
+		return "", time.Time{}, fmt.Errorf("too many pending tickets")
+	}
+
+	expiresAt := now.Add(s.ttl)
+	s.tickets[ticket] = ticketEntry{tenantID: tenantID, apiKey: apiKey, expiresAt: expiresAt}
+
+	return ticket, expiresAt, nil
+}
+
+// Redeem consumes a ticket and returns the tenant and API key it was issued
+// for. A ticket can be redeemed at most once and only before it expires.
+func (s *TicketStore) Redeem(ticket string) (tenantID, apiKey string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, found := s.tickets[ticket]
+	if !found {
+		return "", "", false
+	}
+
+	delete(s.tickets, ticket)
+
+	if !s.now().Before(entry.expiresAt) {
+		return "", "", false
+	}
+
+	return entry.tenantID, entry.apiKey, true
+}
+
+// pruneLocked drops expired tickets. Caller must hold s.mu.
+func (s *TicketStore) pruneLocked(now time.Time) {
+	for t, e := range s.tickets {
+		if !now.Before(e.expiresAt) {
+			delete(s.tickets, t)
+		}
+	}
+}
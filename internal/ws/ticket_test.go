@@ -0,0 +1,36 @@
+package ws_test
+
+import (
+	"testing"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+func TestTicketStore_RedeemOnce(t *testing.T) {
+	store := ws.NewTicketStore()
+
+	ticket, expiresAt, err := store.Issue("tenant-1", "key-1")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if ticket == "" || expiresAt.IsZero() {
+		t.Fatalf("Issue returned empty ticket %q or expiry %v", ticket, expiresAt)
+	}
+
+	tenantID, apiKey, ok := store.Redeem(ticket)
+	if !ok || tenantID != "tenant-1" || apiKey != "key-1" {
+		t.Fatalf("Redeem = (%q, %q, %v), want (tenant-1, key-1, true)", tenantID, apiKey, ok)
+	}
+
+	if _, _, ok := store.Redeem(ticket); ok {
+		t.Fatal("ticket should not be redeemable twice")
+	}
+}
+
+func TestTicketStore_UnknownTicket(t *testing.T) {
+	store := ws.NewTicketStore()
+
+	if _, _, ok := store.Redeem("does-not-exist"); ok {
+		t.Fatal("unknown ticket should not redeem")
+	}
+}
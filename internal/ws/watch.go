@@ -0,0 +1,88 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// watchLookupTimeout bounds loading a watch list on subscribe.
+const watchLookupTimeout = 5 * time.Second
+
+// ErrWatchesUnavailable is returned when a subscriber names a watcher but
+// the hub has no watch lookup.
+var ErrWatchesUnavailable = errors.New("watch lists not available")
+
+// WatchLookup loads the nodes a named watcher follows.
+type WatchLookup interface {
+	WatchedNodeIDs(ctx context.Context, tenantID, watcher string) ([]string, error)
+}
+
+// watchChange is one node added to or removed from a watch list.
+type watchChange struct {
+	watcher string
+	nodeID  string
+	watched bool
+}
+
+// SetWatchLookup lets subscribers limit their events to a watch list. Call
+// it before Run.
+func (h *Hub) SetWatchLookup(l WatchLookup) {
+	h.watches = l
+}
+
+// ResolveWatches loads the watch list f names, if any, so f matches only
+// events for the watched nodes. Call it before handing f to a subscriber.
+func (h *Hub) ResolveWatches(ctx context.Context, tenantID string, f *EventFilter) error {
+	if f.Watcher == "" {
+		return nil
+	}
+
+	if h.watches == nil {
+		return ErrWatchesUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, watchLookupTimeout)
+	defer cancel()
+
+	ids, err := h.watches.WatchedNodeIDs(ctx, tenantID, f.Watcher)
+	if err != nil {
+		return fmt.Errorf("loading watch list: %w", err)
+	}
+
+	f.watched = make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		f.watched[id] = struct{}{}
+	}
+
+	return nil
+}
+
+// ApplyWatchChange updates the subscribers of tenantID using watcher after
+// nodeID was watched or unwatched on any instance, so they need not
+// resubscribe. The update is applied by the Run goroutine in order with the
+// events broadcast around it.
+func (h *Hub) ApplyWatchChange(tenantID, watcher, nodeID string, watched bool) {
+	h.enqueue(tenantBroadcast{
+		tenantID: tenantID,
+		watch:    &watchChange{watcher: watcher, nodeID: nodeID, watched: watched},
+	})
+}
+
+// applyWatchChange swaps in an updated filter for each affected client. A
+// client that resubscribed meanwhile keeps its newly loaded filter.
+func (h *Hub) applyWatchChange(tenantID string, c watchChange) {
+	for client := range h.clients {
+		if client.TenantID != tenantID {
+			continue
+		}
+
+		f := client.filter.Load()
+		if f == nil || f.Watcher != c.watcher {
+			continue
+		}
+
+		client.filter.CompareAndSwap(f, f.withWatch(c.nodeID, c.watched))
+	}
+}
@@ -0,0 +1,119 @@
+package ws_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/persistorai/persistor/internal/ws"
+)
+
+type fakeWatchLookup map[string][]string
+
+func (f fakeWatchLookup) WatchedNodeIDs(_ context.Context, _, watcher string) ([]string, error) {
+	return f[watcher], nil
+}
+
+func TestHub_WatchedSubscribe(t *testing.T) {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	hub := ws.NewHub(log)
+	hub.SetWatchLookup(fakeWatchLookup{"agent-1": {"alice"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go hub.Run(ctx)
+
+	node := func(id string) json.RawMessage {
+		return json.RawMessage(`{"table":"kg_nodes","op":"update","node_id":"` + id + `","node_type":"person"}`)
+	}
+	edge := func(source, target string) json.RawMessage {
+		return json.RawMessage(`{"table":"kg_edges","op":"insert","changes":{"source":"` + source +
+			`","target":"` + target + `","relation":"knows"}}`)
+	}
+
+	hub.BroadcastEvent("kg.change", "tenant-1", 1, node("alice")) // 1: watched
+	hub.BroadcastEvent("kg.change", "tenant-1", 2, node("bob"))   // 2: not watched
+	hub.BroadcastEvent("kg.change", "tenant-1", 3, json.RawMessage(`{"table":"kg_nodes","op":"BULK","count":3}`))
+	hub.BroadcastEvent("kg.change", "tenant-1", 4, edge("bob", "alice")) // 4: watched target
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := ws.NewClient(hub, conn, nil, "")
+		client.TenantID = "tenant-1"
+		hub.Register(client)
+		go client.WritePump(r.Context())
+		client.ReadPump(r.Context())
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.CloseNow() //nolint:errcheck // test teardown
+
+	sub, _ := json.Marshal(ws.SubscribeMsg{Type: "subscribe", EventFilter: ws.EventFilter{Watcher: "agent-1"}})
+	if err := conn.Write(ctx, websocket.MessageText, sub); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	readID := func() uint64 {
+		t.Helper()
+
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+
+		var evt ws.Event
+		if err := json.Unmarshal(msg, &evt); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+
+		return evt.ID
+	}
+
+	// Unlike the node filters, a watch list drops events naming no node.
+	for _, want := range []uint64{1, 4} {
+		if got := readID(); got != want {
+			t.Fatalf("replayed event %d, want %d", got, want)
+		}
+	}
+
+	// Watch changes apply in order with the events around them.
+	hub.ApplyWatchChange("tenant-1", "agent-1", "bob", true)
+	hub.ApplyWatchChange("tenant-1", "agent-1", "alice", false)
+	hub.BroadcastEvent("kg.change", "tenant-1", 5, node("alice")) // 5: no longer watched
+	hub.BroadcastEvent("kg.change", "tenant-1", 6, node("bob"))   // 6: now watched
+
+	if got := readID(); got != 6 {
+		t.Errorf("live event %d, want 6", got)
+	}
+}
+
+func TestHub_ResolveWatchesWithoutLookup(t *testing.T) {
+	hub := ws.NewHub(logrus.New())
+
+	f := ws.EventFilter{Watcher: "agent-1"}
+	if err := hub.ResolveWatches(context.Background(), "tenant-1", &f); !errors.Is(err, ws.ErrWatchesUnavailable) {
+		t.Errorf("err = %v, want ErrWatchesUnavailable", err)
+	}
+
+	if err := hub.ResolveWatches(context.Background(), "tenant-1", &ws.EventFilter{}); err != nil {
+		t.Errorf("without a watcher: %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// checkpointTableSQL creates the table that records how far each migration
+// has got. A row is keyed by tenant and SQLite path, so separate sources can
+// be migrated into one tenant independently.
+const checkpointTableSQL = `
+CREATE TABLE IF NOT EXISTS migrate_checkpoints (
+	tenant_id    uuid        NOT NULL,
+	source       text        NOT NULL,
+	nodes_done   integer     NOT NULL DEFAULT 0,
+	edges_done   integer     NOT NULL DEFAULT 0,
+	history_done integer     NOT NULL DEFAULT 0,
+	audit_done   integer     NOT NULL DEFAULT 0,
+	updated_at   timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (tenant_id, source)
+)`
+
+// checkpointColumnsSQL adds the columns later versions of this script
+// track to a checkpoint table created by an earlier one.
+const checkpointColumnsSQL = `
+ALTER TABLE migrate_checkpoints
+	ADD COLUMN IF NOT EXISTS history_done integer NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS audit_done   integer NOT NULL DEFAULT 0`
+
+// checkpoint is how many rows of each table, in SQLite read order, have
+// been committed to PostgreSQL.
+type checkpoint struct {
+	NodesDone   int
+	EdgesDone   int
+	HistoryDone int
+	AuditDone   int
+}
+
+// ensureCheckpointTable creates the checkpoint table if it does not exist.
+func ensureCheckpointTable(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, checkpointTableSQL); err != nil {
+		return err
+	}
+	_, err := conn.Exec(ctx, checkpointColumnsSQL)
+	return err
+}
+
+// loadCheckpoint returns the saved checkpoint for tenantID and source, or a
+// zero checkpoint when there is none.
+func loadCheckpoint(ctx context.Context, conn *pgx.Conn, tenantID, source string) (checkpoint, error) {
+	var cp checkpoint
+	err := conn.QueryRow(ctx,
+		`SELECT nodes_done, edges_done, history_done, audit_done FROM migrate_checkpoints
+		 WHERE tenant_id = $1 AND source = $2`,
+		tenantID, source,
+	).Scan(&cp.NodesDone, &cp.EdgesDone, &cp.HistoryDone, &cp.AuditDone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return checkpoint{}, nil
+	}
+	return cp, err
+}
+
+// saveCheckpoint records cp in the same transaction as the rows it covers,
+// so a checkpoint never runs ahead of the data.
+func saveCheckpoint(ctx context.Context, tx pgx.Tx, tenantID, source string, cp checkpoint) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO migrate_checkpoints (tenant_id, source, nodes_done, edges_done, history_done, audit_done)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (tenant_id, source) DO UPDATE
+		 SET nodes_done = EXCLUDED.nodes_done,
+		     edges_done = EXCLUDED.edges_done,
+		     history_done = EXCLUDED.history_done,
+		     audit_done = EXCLUDED.audit_done,
+		     updated_at = now()`,
+		tenantID, source, cp.NodesDone, cp.EdgesDone, cp.HistoryDone, cp.AuditDone)
+	return err
+}
+
+// migrateChunks commits rows [*done, total) in chunks of cfg.ChunkSize.
+// done points at one of cp's counters; each chunk's rows and the advanced
+// checkpoint commit in the same transaction. insert writes rows [start, end)
+// and returns how many were new.
+func migrateChunks(
+	ctx context.Context, conn *pgx.Conn, cfg config, what string, total int,
+	cp *checkpoint, done *int, insert func(tx pgx.Tx, start, end int) (int, error),
+) (int, error) {
+	inserted := 0
+	for start := *done; start < total; start += cfg.ChunkSize {
+		end := min(start+cfg.ChunkSize, total)
+
+		var n int
+		*done = end
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			var err error
+			if n, err = insert(tx, start, end); err != nil {
+				return err
+			}
+			return saveCheckpoint(ctx, tx, cfg.TenantID, cfg.SQLitePath, *cp)
+		})
+		if err != nil {
+			*done = start
+			return inserted, fmt.Errorf("%s %d-%d: %w", what, start, end, err)
+		}
+
+		inserted += n
+		slog.Info("committed "+what, "done", end, "total", total)
+	}
+	return inserted, nil
+}
+
+// migrateNodes inserts the nodes after cp.NodesDone in chunks.
+func migrateNodes(ctx context.Context, conn *pgx.Conn, cfg config, nodes []node, cp *checkpoint, r *report) error {
+	start := cp.NodesDone
+	inserted, err := migrateChunks(ctx, conn, cfg, "nodes", len(nodes), cp, &cp.NodesDone,
+		func(tx pgx.Tx, start, end int) (int, error) {
+			return insertNodes(ctx, tx, nodes[start:end], cfg.TenantID, cfg.enc)
+		})
+	r.NodesInserted += inserted
+	r.NodesExisting += cp.NodesDone - start - inserted
+	return err
+}
+
+// migrateEdges inserts the edges after cp.EdgesDone in chunks.
+func migrateEdges(ctx context.Context, conn *pgx.Conn, cfg config, edges []edge, nodeIDs map[string]bool, cp *checkpoint, r *report) error {
+	start := cp.EdgesDone
+	var skipped []skippedEdge
+	inserted, err := migrateChunks(ctx, conn, cfg, "edges", len(edges), cp, &cp.EdgesDone,
+		func(tx pgx.Tx, start, end int) (int, error) {
+			n, chunkSkipped, err := insertEdges(ctx, tx, edges[start:end], cfg.TenantID, nodeIDs, cfg.enc)
+			if err == nil {
+				skipped = append(skipped, chunkSkipped...)
+			}
+			return n, err
+		})
+	r.EdgesInserted += inserted
+	r.EdgesExisting += cp.EdgesDone - start - inserted - len(skipped)
+	r.EdgesSkipped += len(skipped)
+	r.SkippedEdges = append(r.SkippedEdges, skipped...)
+	return err
+}
+
+// migrateHistory inserts the property history rows after cp.HistoryDone in
+// chunks.
+func migrateHistory(ctx context.Context, conn *pgx.Conn, cfg config, rows []historyRow, cp *checkpoint, r *report) error {
+	start := cp.HistoryDone
+	inserted, err := migrateChunks(ctx, conn, cfg, "history", len(rows), cp, &cp.HistoryDone,
+		func(tx pgx.Tx, start, end int) (int, error) {
+			return insertHistory(ctx, tx, rows[start:end], cfg.TenantID)
+		})
+	r.HistoryInserted += inserted
+	r.HistoryExisting += cp.HistoryDone - start - inserted
+	return err
+}
+
+// migrateAudit inserts the audit entries after cp.AuditDone in chunks.
+func migrateAudit(ctx context.Context, conn *pgx.Conn, cfg config, rows []auditRow, cp *checkpoint, r *report) error {
+	start := cp.AuditDone
+	inserted, err := migrateChunks(ctx, conn, cfg, "audit", len(rows), cp, &cp.AuditDone,
+		func(tx pgx.Tx, start, end int) (int, error) {
+			return insertAudit(ctx, tx, rows[start:end], cfg.TenantID)
+		})
+	r.AuditInserted += inserted
+	r.AuditExisting += cp.AuditDone - start - inserted
+	return err
+}
@@ -25,12 +25,14 @@ type edge struct {
 	UserBoosted   int
 }
 
-// readEdges reads all kg_edges from SQLite.
+// readEdges reads all kg_edges from SQLite, ordered by key so checkpoints
+// index the same rows on every run.
 func readEdges(ctx context.Context, db *sql.DB) ([]edge, error) {
 	rows, err := db.QueryContext(ctx,
 		`SELECT source, target, relation, properties, created, updated,
 		        weight, access_count, last_accessed, salience_score, superseded_by, user_boosted
-		 FROM kg_edges`)
+		 FROM kg_edges
+		 ORDER BY source, target, relation`)
 	if err != nil {
 		return nil, err
 	}
@@ -49,8 +51,11 @@ func readEdges(ctx context.Context, db *sql.DB) ([]edge, error) {
 	return edges, rows.Err()
 }
 
-// insertEdges batch-inserts edges, skipping those with missing source/target nodes.
-func insertEdges(ctx context.Context, tx pgx.Tx, edges []edge, tenantID string, nodeIDs map[string]bool, enc *encryptor) (int, []skippedEdge) {
+// insertEdges batch-inserts edges, skipping those with missing source/target
+// nodes. Edges that already exist are left alone; it returns how many were
+// new. Each edge is inserted under a savepoint so that one failed insert
+// skips that edge without aborting the transaction.
+func insertEdges(ctx context.Context, tx pgx.Tx, edges []edge, tenantID string, nodeIDs map[string]bool, enc *encryptor) (int, []skippedEdge, error) {
 	var skipped []skippedEdge
 	inserted := 0
 
@@ -76,7 +81,12 @@ func insertEdges(ctx context.Context, tx pgx.Tx, edges []edge, tenantID string,
 		lastAccessed := parseNullableTime(e.LastAccessed)
 		supersededBy := nullStr(e.SupersededBy)
 
-		_, err := tx.Exec(ctx,
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return inserted, skipped, fmt.Errorf("savepoint: %w", err)
+		}
+
+		tag, err := sp.Exec(ctx,
 			`INSERT INTO kg_edges (tenant_id, source, target, relation, properties, weight,
 			    access_count, last_accessed, salience_score, superseded_by, user_boosted,
 			    created_at, updated_at)
@@ -87,11 +97,17 @@ func insertEdges(ctx context.Context, tx pgx.Tx, edges []edge, tenantID string,
 			createdAt, updatedAt,
 		)
 		if err != nil {
+			if rbErr := sp.Rollback(ctx); rbErr != nil {
+				return inserted, skipped, fmt.Errorf("rollback to savepoint: %w", rbErr)
+			}
 			slog.Warn("edge insert failed, skipping", "source", e.Source, "target", e.Target, "error", err)
 			skipped = append(skipped, skippedEdge{e.Source, e.Target, err.Error()})
 			continue
 		}
-		inserted++
+		if err := sp.Commit(ctx); err != nil {
+			return inserted, skipped, fmt.Errorf("release savepoint: %w", err)
+		}
+		inserted += int(tag.RowsAffected())
 	}
-	return inserted, skipped
+	return inserted, skipped, nil
 }
@@ -15,6 +15,7 @@
 	"math/rand/v2"
 	"net/url"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -138,10 +139,26 @@ func envOr(key, def string) string {
 	return def
 }
 
+// envInt returns the environment variable as an integer, the default when it
+// is unset, or 0 when it is not a number.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
 // allowedTables is the set of table names that countRows may query.
 var allowedTables = map[string]bool{
-	"kg_nodes": true,
-	"kg_edges": true,
+	"kg_nodes":            true,
+	"kg_edges":            true,
+	"kg_property_history": true,
+	"kg_audit_log":        true,
 }
 
 // countRows counts rows in a table for a given tenant.
@@ -193,14 +210,17 @@ func spotCheck(ctx context.Context, tx pgx.Tx, _ *sql.DB, nodes []node, tenantID
 
 // printReport outputs the final migration summary.
 func printReport(r *report) {
-	nodeStatus := statusIcon(r.NodesRead, r.NodesInserted, r.NodesVerified)
-	edgeStatus := statusIcon(r.EdgesInserted, r.EdgesInserted, r.EdgesVerified)
+	nodeStatus := statusIcon(r.NodesRead, r.NodesInserted+r.NodesExisting, r.NodesVerified)
+	edgeStatus := statusIcon(r.EdgesInserted+r.EdgesExisting, r.EdgesInserted+r.EdgesExisting, r.EdgesVerified)
 
 	fmt.Println()
 	fmt.Println("=== Persistor Migration Report ===")
 	if r.DryRun {
 		fmt.Println("MODE: DRY RUN (no changes made)")
 	}
+	if r.Resumed {
+		fmt.Println("MODE: RESUMED from checkpoint")
+	}
 	fmt.Printf("Source: %s\n", r.Source)
 	fmt.Printf("Target: %s\n", r.Target)
 	fmt.Printf("Tenant: %s (%s)\n", r.TenantName, r.TenantID)
@@ -215,6 +235,22 @@ func printReport(r *report) {
 			r.EdgesRead, r.EdgesInserted, r.EdgesVerified, edgeStatus)
 	}
 
+	if r.HistoryPresent {
+		fmt.Printf("Property history: %d read → %d inserted → %d verified %s\n",
+			r.HistoryRead, r.HistoryInserted, r.HistoryVerified,
+			statusIcon(r.HistoryRead, r.HistoryInserted+r.HistoryExisting, r.HistoryVerified))
+	}
+	if r.AuditPresent {
+		fmt.Printf("Audit log: %d read → %d inserted → %d verified %s\n",
+			r.AuditRead, r.AuditInserted, r.AuditVerified,
+			statusIcon(r.AuditRead, r.AuditInserted+r.AuditExisting, r.AuditVerified))
+	}
+
+	if r.NodesExisting > 0 || r.EdgesExisting > 0 || r.HistoryExisting > 0 || r.AuditExisting > 0 {
+		fmt.Printf("Already migrated: %d nodes, %d edges, %d history rows, %d audit entries\n",
+			r.NodesExisting, r.EdgesExisting, r.HistoryExisting, r.AuditExisting)
+	}
+
 	if len(r.SkippedEdges) > 0 {
 		fmt.Println("\nSkipped edges:")
 		for _, s := range r.SkippedEdges {
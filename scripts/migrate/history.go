@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// historyRow is a node property history row read from SQLite.
+type historyRow struct {
+	NodeID      string
+	Field       sql.NullString
+	PropertyKey sql.NullString
+	OldValue    sql.NullString
+	NewValue    sql.NullString
+	ChangedAt   string
+	Reason      sql.NullString
+	ChangedBy   sql.NullString
+}
+
+// auditRow is an audit log entry read from SQLite.
+type auditRow struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Actor      sql.NullString
+	Detail     sql.NullString
+	CreatedAt  string
+}
+
+// historyFields are the values kg_property_history.field accepts.
+var historyFields = map[string]bool{"property": true, "label": true, "type": true, "salience": true}
+
+// sqliteColumns returns the columns of a SQLite table, or an empty set when
+// the table does not exist.
+func sqliteColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan column: %w", err)
+		}
+		cols[name] = true
+	}
+	return cols, rows.Err()
+}
+
+// optionalColumn selects col when the table has it and NULL otherwise.
+func optionalColumn(cols map[string]bool, col string) string {
+	if cols[col] {
+		return col
+	}
+	return "NULL"
+}
+
+// readHistory reads kg_property_history from SQLite, ordered so checkpoints
+// index the same rows on every run. present is false when the SQLite file
+// has no history table.
+func readHistory(ctx context.Context, db *sql.DB) (rows []historyRow, present bool, err error) {
+	cols, err := sqliteColumns(ctx, db, "kg_property_history")
+	if err != nil || len(cols) == 0 {
+		return nil, false, err
+	}
+
+	q := fmt.Sprintf(
+		`SELECT node_id, %s, %s, %s, %s, changed_at, %s, %s
+		 FROM kg_property_history
+		 ORDER BY changed_at, rowid`,
+		optionalColumn(cols, "field"), optionalColumn(cols, "property_key"),
+		optionalColumn(cols, "old_value"), optionalColumn(cols, "new_value"),
+		optionalColumn(cols, "reason"), optionalColumn(cols, "changed_by"))
+
+	res, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, true, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var h historyRow
+		if err := res.Scan(&h.NodeID, &h.Field, &h.PropertyKey, &h.OldValue, &h.NewValue,
+			&h.ChangedAt, &h.Reason, &h.ChangedBy); err != nil {
+			return nil, true, fmt.Errorf("scan history: %w", err)
+		}
+		rows = append(rows, h)
+	}
+	return rows, true, res.Err()
+}
+
+// readAudit reads kg_audit_log from SQLite, ordered so checkpoints index the
+// same rows on every run. present is false when the SQLite file has no audit
+// table.
+func readAudit(ctx context.Context, db *sql.DB) (rows []auditRow, present bool, err error) {
+	cols, err := sqliteColumns(ctx, db, "kg_audit_log")
+	if err != nil || len(cols) == 0 {
+		return nil, false, err
+	}
+
+	q := fmt.Sprintf(
+		`SELECT action, entity_type, entity_id, %s, %s, created_at
+		 FROM kg_audit_log
+		 ORDER BY created_at, rowid`,
+		optionalColumn(cols, "actor"), optionalColumn(cols, "detail"))
+
+	res, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, true, err
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var a auditRow
+		if err := res.Scan(&a.Action, &a.EntityType, &a.EntityID, &a.Actor, &a.Detail, &a.CreatedAt); err != nil {
+			return nil, true, fmt.Errorf("scan audit entry: %w", err)
+		}
+		rows = append(rows, a)
+	}
+	return rows, true, res.Err()
+}
+
+// jsonValue returns s as a JSON document for a jsonb column: nil when
+// empty, as is when it is valid JSON, and as a JSON string otherwise.
+func jsonValue(s sql.NullString) any {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	if json.Valid([]byte(s.String)) {
+		return s.String
+	}
+	quoted, _ := json.Marshal(s.String) //nolint:errcheck // marshaling a string cannot fail.
+	return string(quoted)
+}
+
+// insertHistory inserts property history rows, keeping their timestamps.
+// A row already present with the same node, field, key, and timestamp is
+// skipped, so re-runs do not duplicate history. It returns how many rows
+// were new.
+func insertHistory(ctx context.Context, tx pgx.Tx, rows []historyRow, tenantID string) (int, error) {
+	inserted := 0
+	for i := range rows {
+		h := &rows[i]
+		field := "property"
+		if h.Field.Valid && historyFields[h.Field.String] {
+			field = h.Field.String
+		}
+
+		tag, err := tx.Exec(ctx,
+			`INSERT INTO kg_property_history
+			    (tenant_id, node_id, field, property_key, old_value, new_value, changed_at, reason, changed_by)
+			 SELECT $1::uuid, $2::text, $3::text, $4::text, $5::jsonb, $6::jsonb, $7::timestamptz, $8::text, $9::text
+			 WHERE NOT EXISTS (
+			     SELECT 1 FROM kg_property_history
+			     WHERE tenant_id = $1 AND node_id = $2 AND field = $3
+			       AND property_key = $4 AND changed_at = $7
+			 )`,
+			tenantID, h.NodeID, field, h.PropertyKey.String,
+			jsonValue(h.OldValue), jsonValue(h.NewValue), parseTime(h.ChangedAt),
+			nullStr(h.Reason), nullStr(h.ChangedBy),
+		)
+		if err != nil {
+			return inserted, fmt.Errorf("insert history for node %s: %w", h.NodeID, err)
+		}
+		inserted += int(tag.RowsAffected())
+	}
+	return inserted, nil
+}
+
+// insertAudit inserts audit entries, keeping their timestamps. An entry
+// already present with the same action, entity, and timestamp is skipped,
+// so re-runs do not duplicate the log. It returns how many entries were new.
+func insertAudit(ctx context.Context, tx pgx.Tx, rows []auditRow, tenantID string) (int, error) {
+	inserted := 0
+	for i := range rows {
+		a := &rows[i]
+
+		tag, err := tx.Exec(ctx,
+			`INSERT INTO kg_audit_log (tenant_id, action, entity_type, entity_id, actor, detail, created_at)
+			 SELECT $1::uuid, $2::text, $3::text, $4::text, $5::text, $6::jsonb, $7::timestamptz
+			 WHERE NOT EXISTS (
+			     SELECT 1 FROM kg_audit_log
+			     WHERE tenant_id = $1 AND action = $2 AND entity_type = $3
+			       AND entity_id = $4 AND created_at = $7
+			 )`,
+			tenantID, a.Action, a.EntityType, a.EntityID,
+			nullStr(a.Actor), jsonValue(a.Detail), parseTime(a.CreatedAt),
+		)
+		if err != nil {
+			return inserted, fmt.Errorf("insert audit entry for %s %s: %w", a.EntityType, a.EntityID, err)
+		}
+		inserted += int(tag.RowsAffected())
+	}
+	return inserted, nil
+}
@@ -4,12 +4,26 @@
 // Usage:
 //
 //	SQLITE_PATH=/path/to/sqlite DATABASE_URL=postgres://... go run scripts/migrate-from-sqlite.go
+//
+// Rows are committed in chunks of CHUNK_SIZE (default 1000) together with a
+// checkpoint in the migrate_checkpoints table. After a failure, run again
+// with --resume to continue from the last committed chunk. Without --resume
+// every row is offered again; rows already in PostgreSQL are skipped, so
+// re-running a migration is safe either way.
+//
+// Set MIGRATE_HISTORY=true and MIGRATE_AUDIT=true to also carry over the
+// kg_property_history and kg_audit_log tables, timestamps included, when the
+// SQLite file has them.
+//
+// Set REPORT_FORMAT=json to also write a machine-readable report to
+// REPORT_PATH (default migration-report.json).
 package main
 
 import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
@@ -21,12 +35,18 @@
 
 // config holds environment-driven migration settings.
 type config struct {
-	SQLitePath  string
-	DatabaseURL string
-	TenantID    string
-	TenantName  string
-	DryRun      bool
-	enc         *encryptor
+	SQLitePath   string
+	DatabaseURL  string
+	TenantID     string
+	TenantName   string
+	DryRun       bool
+	History      bool
+	Audit        bool
+	Resume       bool
+	ChunkSize    int
+	ReportFormat string
+	ReportPath   string
+	enc          *encryptor
 }
 
 // skippedEdge records an edge that was skipped during migration.
@@ -44,25 +64,55 @@ type report struct {
 	TenantID      string
 	NodesRead     int
 	NodesInserted int
+	NodesExisting int
 	NodesVerified int
 	EdgesRead     int
 	EdgesInserted int
+	EdgesExisting int
 	EdgesSkipped  int
 	EdgesVerified int
 	SkippedEdges  []skippedEdge
 	SpotChecks    []string
 	Duration      time.Duration
 	DryRun        bool
+	Resumed       bool
 	Err           error
+
+	// History and audit counts; *Present is false when the table was not
+	// requested or the SQLite file has none.
+	HistoryPresent  bool
+	HistoryRead     int
+	HistoryInserted int
+	HistoryExisting int
+	HistoryVerified int
+	AuditPresent    bool
+	AuditRead       int
+	AuditInserted   int
+	AuditExisting   int
+	AuditVerified   int
 }
 
 func main() {
+	resume := flag.Bool("resume", false, "continue from the last committed checkpoint")
+	flag.Parse()
+
 	cfg := loadConfig()
+	cfg.Resume = *resume
 	if cfg.DatabaseURL == "" {
 		slog.Error("DATABASE_URL is required")
 		os.Exit(1)
 	}
 
+	if cfg.ChunkSize < 1 {
+		slog.Error("CHUNK_SIZE must be a positive integer")
+		os.Exit(1)
+	}
+
+	if cfg.ReportFormat != reportFormatText && cfg.ReportFormat != reportFormatJSON {
+		slog.Error("REPORT_FORMAT must be text or json", "report_format", cfg.ReportFormat)
+		os.Exit(1)
+	}
+
 	// Initialize encryption — required for property security.
 	encKey := os.Getenv("ENCRYPTION_KEY")
 	if encKey == "" {
@@ -83,6 +133,10 @@ func main() {
 		"sqlite", cfg.SQLitePath,
 		"tenant", cfg.TenantName,
 		"dry_run", cfg.DryRun,
+		"history", cfg.History,
+		"audit", cfg.Audit,
+		"resume", cfg.Resume,
+		"chunk_size", cfg.ChunkSize,
 	)
 
 	start := time.Now()
@@ -93,6 +147,13 @@ func main() {
 		slog.Error("migration failed", "error", err)
 	}
 	printReport(&r)
+	if cfg.ReportFormat == reportFormatJSON {
+		if werr := writeJSONReport(&r, cfg.ReportPath); werr != nil {
+			slog.Error("failed to write JSON report", "path", cfg.ReportPath, "error", werr)
+			os.Exit(1)
+		}
+		slog.Info("wrote JSON report", "path", cfg.ReportPath)
+	}
 	if err != nil {
 		os.Exit(1)
 	}
@@ -101,10 +162,15 @@ func main() {
 // loadConfig reads configuration from environment variables.
 func loadConfig() config {
 	c := config{
-		SQLitePath:  envOr("SQLITE_PATH", "memory/main.sqlite"),
-		DatabaseURL: envOr("DATABASE_URL", ""),
-		TenantName:  "persistor-default",
-		DryRun:      os.Getenv("DRY_RUN") == "true" || os.Getenv("DRY_RUN") == "1",
+		SQLitePath:   envOr("SQLITE_PATH", "memory/main.sqlite"),
+		DatabaseURL:  envOr("DATABASE_URL", ""),
+		TenantName:   "persistor-default",
+		DryRun:       os.Getenv("DRY_RUN") == "true" || os.Getenv("DRY_RUN") == "1",
+		History:      os.Getenv("MIGRATE_HISTORY") == "true" || os.Getenv("MIGRATE_HISTORY") == "1",
+		Audit:        os.Getenv("MIGRATE_AUDIT") == "true" || os.Getenv("MIGRATE_AUDIT") == "1",
+		ChunkSize:    envInt("CHUNK_SIZE", 1000),
+		ReportFormat: envOr("REPORT_FORMAT", reportFormatText),
+		ReportPath:   envOr("REPORT_PATH", "migration-report.json"),
 	}
 	if tid := os.Getenv("TENANT_ID"); tid != "" {
 		c.TenantID = tid
@@ -126,11 +192,11 @@ func deterministicUUID(name string) string {
 }
 
 // ensureTenant creates the tenant row if it doesn't already exist.
-func ensureTenant(ctx context.Context, tx pgx.Tx, tenantID, name string) error {
+func ensureTenant(ctx context.Context, conn *pgx.Conn, tenantID, name string) error {
 	slog.Info("ensuring tenant exists", "id", tenantID, "name", name)
 	hash := sha256.Sum256([]byte("migration-" + tenantID))
 	apiKeyHash := fmt.Sprintf("%x", hash)
-	_, err := tx.Exec(ctx,
+	_, err := conn.Exec(ctx,
 		`INSERT INTO tenants (id, name, api_key_hash, plan)
 		 VALUES ($1, $2, $3, 'free')
 		 ON CONFLICT (id) DO NOTHING`,
@@ -172,43 +238,99 @@ func runMigration(ctx context.Context, cfg config) (report, error) {
 	r.EdgesRead = len(edges)
 	slog.Info("read edges from sqlite", "count", r.EdgesRead)
 
+	var history []historyRow
+	if cfg.History {
+		if history, r.HistoryPresent, err = readHistory(ctx, lite); err != nil {
+			return r, fmt.Errorf("read property history: %w", err)
+		}
+		r.HistoryRead = len(history)
+		slog.Info("read property history from sqlite", "present", r.HistoryPresent, "count", r.HistoryRead)
+	}
+
+	var audit []auditRow
+	if cfg.Audit {
+		if audit, r.AuditPresent, err = readAudit(ctx, lite); err != nil {
+			return r, fmt.Errorf("read audit log: %w", err)
+		}
+		r.AuditRead = len(audit)
+		slog.Info("read audit log from sqlite", "present", r.AuditPresent, "count", r.AuditRead)
+	}
+
 	if cfg.DryRun {
 		slog.Info("dry run — skipping PostgreSQL writes")
 		r.NodesInserted = r.NodesRead
 		r.EdgesInserted = r.EdgesRead
+		r.HistoryInserted = r.HistoryRead
+		r.AuditInserted = r.AuditRead
 		return r, nil
 	}
 
-	// Connect to PostgreSQL and run in a transaction.
+	// Connect to PostgreSQL; each chunk commits in its own transaction.
 	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
 	if err != nil {
 		return r, fmt.Errorf("connect postgres: %w", err)
 	}
 	defer conn.Close(ctx)
 
-	tx, err := conn.Begin(ctx)
-	if err != nil {
-		return r, fmt.Errorf("begin tx: %w", err)
+	if err := ensureTenant(ctx, conn, cfg.TenantID, cfg.TenantName); err != nil {
+		return r, fmt.Errorf("ensure tenant: %w", err)
 	}
-	defer tx.Rollback(ctx) //nolint:errcheck // best-effort rollback after commit.
 
-	if err := ensureTenant(ctx, tx, cfg.TenantID, cfg.TenantName); err != nil {
-		return r, fmt.Errorf("ensure tenant: %w", err)
+	if err := ensureCheckpointTable(ctx, conn); err != nil {
+		return r, fmt.Errorf("ensure checkpoint table: %w", err)
+	}
+
+	var cp checkpoint
+	if cfg.Resume {
+		if cp, err = loadCheckpoint(ctx, conn, cfg.TenantID, cfg.SQLitePath); err != nil {
+			return r, fmt.Errorf("load checkpoint: %w", err)
+		}
+		// Rows before the checkpoint were committed by an earlier run.
+		cp.NodesDone = min(cp.NodesDone, len(nodes))
+		cp.EdgesDone = min(cp.EdgesDone, len(edges))
+		cp.HistoryDone = min(cp.HistoryDone, len(history))
+		cp.AuditDone = min(cp.AuditDone, len(audit))
+		r.Resumed = true
+		r.NodesExisting = cp.NodesDone
+		r.EdgesExisting = cp.EdgesDone
+		r.HistoryExisting = cp.HistoryDone
+		r.AuditExisting = cp.AuditDone
+		slog.Info("resuming from checkpoint",
+			"nodes_done", cp.NodesDone, "edges_done", cp.EdgesDone,
+			"history_done", cp.HistoryDone, "audit_done", cp.AuditDone)
 	}
 
-	if err := insertNodes(ctx, tx, nodes, cfg.TenantID, cfg.enc); err != nil {
+	if err := migrateNodes(ctx, conn, cfg, nodes, &cp, &r); err != nil {
 		return r, fmt.Errorf("insert nodes: %w", err)
 	}
-	r.NodesInserted = len(nodes)
-	slog.Info("inserted nodes", "count", r.NodesInserted)
+	slog.Info("inserted nodes", "count", r.NodesInserted, "existing", r.NodesExisting)
+
+	if err := migrateEdges(ctx, conn, cfg, edges, buildNodeSet(nodes), &cp, &r); err != nil {
+		return r, fmt.Errorf("insert edges: %w", err)
+	}
+	slog.Info("inserted edges", "count", r.EdgesInserted, "existing", r.EdgesExisting, "skipped", r.EdgesSkipped)
+
+	if r.HistoryPresent {
+		if err := migrateHistory(ctx, conn, cfg, history, &cp, &r); err != nil {
+			return r, fmt.Errorf("insert property history: %w", err)
+		}
+		slog.Info("inserted property history", "count", r.HistoryInserted, "existing", r.HistoryExisting)
+	}
+
+	if r.AuditPresent {
+		if err := migrateAudit(ctx, conn, cfg, audit, &cp, &r); err != nil {
+			return r, fmt.Errorf("insert audit log: %w", err)
+		}
+		slog.Info("inserted audit log", "count", r.AuditInserted, "existing", r.AuditExisting)
+	}
 
-	inserted, skipped := insertEdges(ctx, tx, edges, cfg.TenantID, buildNodeSet(nodes), cfg.enc)
-	r.EdgesInserted = inserted
-	r.EdgesSkipped = len(skipped)
-	r.SkippedEdges = skipped
-	slog.Info("inserted edges", "count", r.EdgesInserted, "skipped", r.EdgesSkipped)
+	// Verify in a read-only transaction.
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return r, fmt.Errorf("begin verify tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only; nothing to commit.
 
-	// Verify counts.
 	r.NodesVerified, err = countRows(ctx, tx, "kg_nodes", cfg.TenantID)
 	if err != nil {
 		return r, fmt.Errorf("verify node count: %w", err)
@@ -217,6 +339,16 @@ func runMigration(ctx context.Context, cfg config) (report, error) {
 	if err != nil {
 		return r, fmt.Errorf("verify edge count: %w", err)
 	}
+	if r.HistoryPresent {
+		if r.HistoryVerified, err = countRows(ctx, tx, "kg_property_history", cfg.TenantID); err != nil {
+			return r, fmt.Errorf("verify history count: %w", err)
+		}
+	}
+	if r.AuditPresent {
+		if r.AuditVerified, err = countRows(ctx, tx, "kg_audit_log", cfg.TenantID); err != nil {
+			return r, fmt.Errorf("verify audit count: %w", err)
+		}
+	}
 
 	// Spot-check random nodes.
 	r.SpotChecks, err = spotCheck(ctx, tx, lite, nodes, cfg.TenantID)
@@ -224,9 +356,6 @@ func runMigration(ctx context.Context, cfg config) (report, error) {
 		return r, fmt.Errorf("spot check: %w", err)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return r, fmt.Errorf("commit: %w", err)
-	}
-	slog.Info("transaction committed")
+	slog.Info("migration complete")
 	return r, nil
 }
@@ -23,12 +23,14 @@ type node struct {
 	UserBoosted   int
 }
 
-// readNodes reads all kg_nodes from SQLite.
+// readNodes reads all kg_nodes from SQLite, ordered by id so checkpoints
+// index the same rows on every run.
 func readNodes(ctx context.Context, db *sql.DB) ([]node, error) {
 	rows, err := db.QueryContext(ctx,
 		`SELECT id, type, label, properties, created, updated,
 		        access_count, last_accessed, salience_score, superseded_by, user_boosted
-		 FROM kg_nodes`)
+		 FROM kg_nodes
+		 ORDER BY id`)
 	if err != nil {
 		return nil, err
 	}
@@ -47,32 +49,37 @@ func readNodes(ctx context.Context, db *sql.DB) ([]node, error) {
 	return nodes, rows.Err()
 }
 
-// insertNodes batch-inserts nodes into PostgreSQL in groups of 100.
-func insertNodes(ctx context.Context, tx pgx.Tx, nodes []node, tenantID string, enc *encryptor) error {
+// insertNodes batch-inserts nodes into PostgreSQL in groups of 100. Nodes
+// that already exist are left alone; it returns how many were new.
+func insertNodes(ctx context.Context, tx pgx.Tx, nodes []node, tenantID string, enc *encryptor) (int, error) {
 	const batchSize = 100
+	inserted := 0
 	for i := 0; i < len(nodes); i += batchSize {
 		end := min(i+batchSize, len(nodes))
-		if err := insertNodeBatch(ctx, tx, nodes[i:end], tenantID, enc); err != nil {
-			return fmt.Errorf("batch %d-%d: %w", i, end, err)
+		n, err := insertNodeBatch(ctx, tx, nodes[i:end], tenantID, enc)
+		if err != nil {
+			return inserted, fmt.Errorf("batch %d-%d: %w", i, end, err)
 		}
+		inserted += n
 	}
-	return nil
+	return inserted, nil
 }
 
-// insertNodeBatch inserts a single batch of nodes.
-func insertNodeBatch(ctx context.Context, tx pgx.Tx, batch []node, tenantID string, enc *encryptor) error {
+// insertNodeBatch inserts a single batch of nodes and returns how many were new.
+func insertNodeBatch(ctx context.Context, tx pgx.Tx, batch []node, tenantID string, enc *encryptor) (int, error) {
+	inserted := 0
 	for i := range batch {
 		n := &batch[i]
 		createdAt := parseTime(n.Created)
 		updatedAt := parseTime(n.Updated)
 		props, err := encryptProps(enc, normalizeJSON(n.Properties), tenantID)
 		if err != nil {
-			return fmt.Errorf("encrypting node %s properties: %w", n.ID, err)
+			return inserted, fmt.Errorf("encrypting node %s properties: %w", n.ID, err)
 		}
 		lastAccessed := parseNullableTime(n.LastAccessed)
 		supersededBy := nullStr(n.SupersededBy)
 
-		_, err = tx.Exec(ctx,
+		tag, err := tx.Exec(ctx,
 			`INSERT INTO kg_nodes (id, tenant_id, type, label, properties,
 			    access_count, last_accessed, salience_score, superseded_by, user_boosted,
 			    created_at, updated_at)
@@ -83,10 +90,11 @@ func insertNodeBatch(ctx context.Context, tx pgx.Tx, batch []node, tenantID stri
 			createdAt, updatedAt,
 		)
 		if err != nil {
-			return fmt.Errorf("insert node %s: %w", n.ID, err)
+			return inserted, fmt.Errorf("insert node %s: %w", n.ID, err)
 		}
+		inserted += int(tag.RowsAffected())
 	}
-	return nil
+	return inserted, nil
 }
 
 // buildNodeSet creates a set of node IDs for fast lookup.
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Report formats selected with REPORT_FORMAT.
+const (
+	reportFormatText = "text"
+	reportFormatJSON = "json"
+)
+
+// jsonReport is the machine-readable migration report written when
+// REPORT_FORMAT=json.
+type jsonReport struct {
+	Success         bool              `json:"success"`
+	Error           string            `json:"error,omitempty"`
+	DryRun          bool              `json:"dry_run"`
+	Resumed         bool              `json:"resumed"`
+	Source          string            `json:"source"`
+	Target          string            `json:"target"`
+	TenantName      string            `json:"tenant_name"`
+	TenantID        string            `json:"tenant_id"`
+	Nodes           jsonReportCounts  `json:"nodes"`
+	Edges           jsonReportCounts  `json:"edges"`
+	History         *jsonReportCounts `json:"property_history,omitempty"`
+	Audit           *jsonReportCounts `json:"audit_log,omitempty"`
+	SkippedEdges    []jsonSkippedEdge `json:"skipped_edges"`
+	SpotChecks      []string          `json:"spot_checks"`
+	DurationSeconds float64           `json:"duration_seconds"`
+}
+
+// jsonReportCounts tracks one record kind through the migration.
+type jsonReportCounts struct {
+	Read     int `json:"read"`
+	Inserted int `json:"inserted"`
+	Existing int `json:"existing"`
+	Skipped  int `json:"skipped"`
+	Verified int `json:"verified"`
+}
+
+// jsonSkippedEdge is a skippedEdge in the JSON report.
+type jsonSkippedEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+}
+
+// newJSONReport converts r to its JSON form.
+func newJSONReport(r *report) jsonReport {
+	out := jsonReport{
+		Success:    r.Err == nil,
+		DryRun:     r.DryRun,
+		Resumed:    r.Resumed,
+		Source:     r.Source,
+		Target:     r.Target,
+		TenantName: r.TenantName,
+		TenantID:   r.TenantID,
+		Nodes: jsonReportCounts{
+			Read:     r.NodesRead,
+			Inserted: r.NodesInserted,
+			Existing: r.NodesExisting,
+			Verified: r.NodesVerified,
+		},
+		Edges: jsonReportCounts{
+			Read:     r.EdgesRead,
+			Inserted: r.EdgesInserted,
+			Existing: r.EdgesExisting,
+			Skipped:  r.EdgesSkipped,
+			Verified: r.EdgesVerified,
+		},
+		SkippedEdges:    make([]jsonSkippedEdge, 0, len(r.SkippedEdges)),
+		SpotChecks:      r.SpotChecks,
+		DurationSeconds: r.Duration.Seconds(),
+	}
+
+	if r.HistoryPresent {
+		out.History = &jsonReportCounts{
+			Read:     r.HistoryRead,
+			Inserted: r.HistoryInserted,
+			Existing: r.HistoryExisting,
+			Verified: r.HistoryVerified,
+		}
+	}
+
+	if r.AuditPresent {
+		out.Audit = &jsonReportCounts{
+			Read:     r.AuditRead,
+			Inserted: r.AuditInserted,
+			Existing: r.AuditExisting,
+			Verified: r.AuditVerified,
+		}
+	}
+
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+
+	for _, s := range r.SkippedEdges {
+		out.SkippedEdges = append(out.SkippedEdges, jsonSkippedEdge(s))
+	}
+
+	if out.SpotChecks == nil {
+		out.SpotChecks = []string{}
+	}
+
+	return out
+}
+
+// writeJSONReport writes r as indented JSON to path.
+func writeJSONReport(r *report, path string) error {
+	data, err := json.MarshalIndent(newJSONReport(r), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}